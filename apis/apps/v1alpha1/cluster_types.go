@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -28,6 +29,7 @@ import (
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/recurrence"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
@@ -169,6 +171,21 @@ type ClusterSpec struct {
 	// +optional
 	SchedulingPolicy *SchedulingPolicy `json:"schedulingPolicy,omitempty"`
 
+	// Specifies how the Pods of each Component are spread across the topology domains by default.
+	// Valid values are:
+	//
+	// - zone: The Pods are spread across the availability zones, by the node label "topology.kubernetes.io/zone".
+	// - node: The Pods are spread across the nodes, by the node label "kubernetes.io/hostname".
+	// - none: No default spread constraint is applied.
+	//
+	// The default constraint keeps the skew of the Pods of the Component between the domains within 1 when possible,
+	// and it is applied to the Components whose scheduling policy specifies no `topologySpreadConstraints`.
+	// The instance templates inherit the constraints of the Component unless they specify their own.
+	// Unlike the pod anti-affinity, it balances the Pods across the domains when the replicas outnumber the domains.
+	//
+	// +optional
+	TopologySpreadPolicy AvailabilityPolicyType `json:"topologySpreadPolicy,omitempty"`
+
 	// Specifies runtimeClassName for all Pods managed by this Cluster.
 	//
 	// +optional
@@ -179,6 +196,49 @@ type ClusterSpec struct {
 	// +optional
 	Backup *ClusterBackup `json:"backup,omitempty"`
 
+	// Specifies the time windows in which the changes to the Cluster are frozen.
+	//
+	// During a freeze window, the OpsRequests targeting the Cluster are deferred until the window ends,
+	// unless `spec.force` of the OpsRequest is set. Creating an OpsRequest during a freeze window is warned.
+	//
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	FreezeWindows []FreezeWindow `json:"freezeWindows,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+
+	// Specifies the weekly time windows in which the disruptive changes to the Cluster are allowed.
+	//
+	// If specified, the OpsRequests targeting the Cluster are deferred until a window opens,
+	// unless `spec.force` of the OpsRequest is set, or the OpsRequest has its own `spec.schedule`.
+	// The restarts of the Components caused by the configuration changes out of OpsRequests are deferred likewise.
+	// The changes are allowed at any time if not specified.
+	//
+	// +kubebuilder:validation:MaxItems=32
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// Specifies the default values of the OpsRequests targeting the Cluster.
+	//
+	// The defaults are filled into the fields left empty when an OpsRequest is created,
+	// so that the OpsRequests of the Cluster behave consistently with minimal specs.
+	//
+	// +optional
+	OpsRequestDefaults *OpsRequestDefaults `json:"opsRequestDefaults,omitempty"`
+
+	// Specifies whether the Cluster is paused, i.e. hibernated.
+	//
+	// When paused, all the Components and shardings of the Cluster are scaled to zero replicas, and their PVCs,
+	// Services and Secrets are retained. The Components are in the `Stopped` phase once all their Pods are deleted.
+	// The replicas specified are kept in the spec, and restored when the Cluster is resumed by setting the field to false.
+	//
+	// The changes to the spec of a paused Cluster are applied when it is resumed,
+	// and the OpsRequests targeting it are rejected.
+	//
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
 	// !!!!! The following fields may be deprecated in subsequent versions, please DO NOT rely on them for new requirements.
 
 	// Describes how Pods are distributed across node.
@@ -236,11 +296,9 @@ type ClusterSpec struct {
 
 	// The configuration of network.
 	//
-	// Deprecated since v0.9.
-	// This field is maintained for backward compatibility and its use is discouraged.
-	// Existing usage should be updated to the current preferred approach to avoid compatibility issues in future releases.
+	// The `hostNetworkAccessible` and `publiclyAccessible` fields are deprecated since v0.9,
+	// they are maintained for backward compatibility and their use is discouraged.
 	//
-	// +kubebuilder:deprecatedversion:warning="This field has been deprecated since 0.9.0"
 	// +optional
 	Network *ClusterNetwork `json:"network,omitempty"`
 }
@@ -301,6 +359,87 @@ type ClusterBackup struct {
 	PITREnabled *bool `json:"pitrEnabled,omitempty"`
 }
 
+// FreezeWindow defines a (recurring) time window in which the changes to the Cluster are frozen.
+type FreezeWindow struct {
+	// Specifies the name of the freeze window, it must be unique within the Cluster.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the start time of the first occurrence of the window.
+	//
+	// +kubebuilder:validation:Required
+	Start metav1.Time `json:"start"`
+
+	// Specifies how long each occurrence of the window lasts, e.g. "2h".
+	//
+	// +kubebuilder:validation:Required
+	Duration metav1.Duration `json:"duration"`
+
+	// Specifies the recurrence rule of the window in the RFC 5545 RRULE format,
+	// e.g. "FREQ=WEEKLY;BYDAY=SA,SU" or "FREQ=MONTHLY;BYMONTHDAY=1;COUNT=12".
+	//
+	// The supported rule parts are FREQ (DAILY, WEEKLY or MONTHLY), INTERVAL, BYDAY, BYMONTHDAY, COUNT and UNTIL.
+	// The window occurs only once if not specified.
+	//
+	// +optional
+	Recurrence string `json:"recurrence,omitempty"`
+
+	// Specifies the IANA time zone in which the recurrence is evaluated, e.g. "Asia/Shanghai".
+	// Defaults to UTC.
+	//
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// MaintenanceWindow defines a weekly time window in which the disruptive changes to the Cluster are allowed.
+type MaintenanceWindow struct {
+	// Specifies the days of the week on which the window opens.
+	// The window opens every day if not specified.
+	//
+	// +listType=set
+	// +optional
+	DaysOfWeek []DayOfWeek `json:"daysOfWeek,omitempty"`
+
+	// Specifies the time of the day when the window opens, in the format "HH:MM", e.g. "02:00".
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	StartTime string `json:"startTime"`
+
+	// Specifies how long the window lasts, e.g. "4h".
+	//
+	// +kubebuilder:validation:Required
+	Duration metav1.Duration `json:"duration"`
+
+	// Specifies the IANA time zone in which the window is evaluated, e.g. "Asia/Shanghai".
+	// Defaults to UTC.
+	//
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// OpsRequestDefaults defines the default values of the OpsRequests targeting a Cluster.
+type OpsRequestDefaults struct {
+	// Specifies the default `ttlSecondsAfterSucceed` of the OpsRequests.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TTLSecondsAfterSucceed *int32 `json:"ttlSecondsAfterSucceed,omitempty"`
+
+	// Specifies the default `preConditionDeadlineSeconds` of the OpsRequests.
+	// It is filled if the OpsRequest leaves the field empty or sets it to 0.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PreConditionDeadlineSeconds *int32 `json:"preConditionDeadlineSeconds,omitempty"`
+
+	// Specifies the default `failurePolicy` of the OpsRequests.
+	//
+	// +optional
+	FailurePolicy OpsFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
 // ClusterResources is deprecated since v0.9.
 type ClusterResources struct {
 	// Specifies the amount of CPU resource the Cluster needs.
@@ -438,6 +577,23 @@ type InstanceTemplate struct {
 	// +optional
 	SchedulingPolicy *SchedulingPolicy `json:"schedulingPolicy,omitempty"`
 
+	// Pins the instances created from this InstanceTemplate to the specified node, bypassing the scheduler.
+	// It takes precedence over the `nodeName` of the scheduling policy, and is intended for bare-metal deployments
+	// that require deterministic data locality, such as instances using local persistent volumes.
+	//
+	// The node must exist, the instances stay on it across restarts and updates.
+	//
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+
+	// Constrains the instances created from this InstanceTemplate to the nodes with the specified labels.
+	// It is merged into the `nodeSelector` of the scheduling policy and the Component, overriding the values of the same keys.
+	//
+	// At least one existing node must match the selector.
+	//
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
 	// Specifies an override for the resource requirements of the first container in the Pod.
 	// This field allows for customizing resource allocation (CPU, memory, etc.) for the container.
 	//
@@ -502,6 +658,17 @@ type ClusterStatus struct {
 	//
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Represents the name of the primary (leader) instance of the Cluster.
+	// It is taken from the first Component that has a leader, in the order of the Components defined in the Cluster.
+	//
+	// +optional
+	PrimaryInstance string `json:"primaryInstance,omitempty"`
+
+	// Represents the completion time of the most recent successful Backup of the Cluster.
+	//
+	// +optional
+	LastBackupTime *metav1.Time `json:"lastBackupTime,omitempty"`
 }
 
 // ShardingSpec defines how KubeBlocks manage dynamic provisioned shards.
@@ -560,6 +727,7 @@ type ShardingSpec struct {
 // ClusterComponentSpec defines the specification of a Component within a Cluster.
 // TODO +kubebuilder:validation:XValidation:rule="!has(oldSelf.componentDefRef) || has(self.componentDefRef)", message="componentDefRef is required once set"
 // TODO +kubebuilder:validation:XValidation:rule="!has(oldSelf.componentDef) || has(self.componentDef)", message="componentDef is required once set"
+// +kubebuilder:validation:XValidation:rule="has(self.tls) && self.tls ? has(self.issuer) : true",message="issuer must be set when tls is enabled"
 type ClusterComponentSpec struct {
 	// Specifies the Component's name.
 	// It's part of the Service DNS name and must comply with the IANA service naming rule.
@@ -727,6 +895,19 @@ type ClusterComponentSpec struct {
 	// +optional
 	Volumes []corev1.Volume `json:"volumes,omitempty"`
 
+	// Specifies the volumes shared by all the instances of the Component, such as a shared WAL archive
+	// or a plugins directory.
+	// Each of them is backed by a single ReadWriteMany PersistentVolumeClaim, which is mounted in all the instances
+	// and kept when the Component is scaled in or out.
+	//
+	// The StorageClass of a shared volume must support the ReadWriteMany access mode,
+	// otherwise the provisioning of the Component is blocked.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	SharedVolumes []SharedVolume `json:"sharedVolumes,omitempty"`
+
 	// Overrides services defined in referenced ComponentDefinition and expose endpoints that can be accessed by clients.
 	//
 	// +optional
@@ -865,6 +1046,104 @@ type ClusterComponentSpec struct {
 	// +optional
 	DisableExporter *bool `json:"disableExporter,omitempty"`
 
+	// Specifies whether to provision the read-write and read-only Services for the Component.
+	//
+	// If set to true, the following Services are managed for the Component:
+	//
+	// - "{cluster.name}-{component.name}-rw": selects the Pod with a writable role.
+	// - "{cluster.name}-{component.name}-ro": selects the Pods with a serviceable but not writable role.
+	//
+	// The Services select the Pods by the access mode label, which is updated as soon as the role of a Pod changes,
+	// so that the Services follow the switchover and failover automatically.
+	// The roles must be defined in the ComponentDefinition, and the read-only Service is not provisioned
+	// if no read-only role is defined.
+	//
+	// +optional
+	ReadWriteSplitServices *bool `json:"readWriteSplitServices,omitempty"`
+
+	// Specifies whether to provision a canary Service for the Component during updates.
+	//
+	// If set to true, the Service "{cluster.name}-{component.name}-canary" is provisioned while the Component is
+	// being updated, and it selects only the Pods that have already been updated to the latest revision.
+	// It allows applications or QA to validate the new version with real traffic before the rollout proceeds,
+	// e.g. while the rollout is held at a partition of the InstanceSet.
+	// The Service is removed once all the Pods are updated.
+	//
+	// +optional
+	CanaryService *bool `json:"canaryService,omitempty"`
+
+	// Specifies the preferred VolumeSnapshotClass and the retention of the VolumeSnapshots automatically created
+	// for the Component, e.g. by the horizontal scaling and the scheduled backups.
+	//
+	// +optional
+	VolumeSnapshotPolicy *VolumeSnapshotPolicy `json:"volumeSnapshotPolicy,omitempty"`
+
+	// Specifies the DNS policy for the Pods of the Component, overriding the one defined in the ComponentDefinition.
+	// Valid values are 'ClusterFirstWithHostNet', 'ClusterFirst', 'Default' or 'None'.
+	//
+	// If not specified, the Pods use the DNS policy defined in the ComponentDefinition, or 'ClusterFirstWithHostNet'
+	// if the host network is enabled.
+	//
+	// +kubebuilder:validation:Enum={ClusterFirstWithHostNet,ClusterFirst,Default,None}
+	// +optional
+	DNSPolicy *corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// Specifies the DNS parameters for the Pods of the Component, such as the search domains and the resolver options.
+	// The parameters are merged with the DNS configuration generated from the DNS policy.
+	//
+	// For example, engines doing client-side discovery across namespaces can lower the `ndots` option
+	// to avoid resolving the fully qualified names through all the search domains.
+	//
+	// +optional
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+
+	// Specifies the name of the PriorityClass for the Pods of the Component, overriding the one defined in the ComponentDefinition.
+	// The PriorityClass determines the priority of the Pods and whether they preempt the Pods of lower priority,
+	// so that the database Pods outrank the batch workloads under resource pressure.
+	//
+	// If not specified, the Pods use the PriorityClass defined in the ComponentDefinition,
+	// or the default one configured for the data plane of KubeBlocks.
+	// The PriorityClass must exist, otherwise the provisioning of the Component is blocked.
+	//
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Specifies the export of the data of the Component to a Backup before the Component is removed from the Cluster.
+	// The volumes of a removed Component are deleted, the export keeps its data for later retrieval.
+	//
+	// +optional
+	DataExport *ComponentDataExport `json:"dataExport,omitempty"`
+
+	// Specifies the init containers supplied by the user, which run after the init containers defined in the
+	// ComponentDefinition and before the containers of the engine start.
+	// They are useful to prepare the filesystem or to load the seed data, e.g. downloading the data files into
+	// the data volumes of the Component.
+	//
+	// The names of the containers must not collide with the names of the init containers and the containers
+	// defined in the ComponentDefinition.
+	//
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	InitContainers []corev1.Container `json:"initContainers,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+
+	// Specifies the scripts executed before the engine starts on the first provision of the Component,
+	// e.g. to create the databases and load the seed data.
+	//
+	// +optional
+	InitScripts *InitScripts `json:"initScripts,omitempty"`
+
+	// Specifies whether to pin the images of the Component to their digests.
+	//
+	// If enabled, the image references of the containers are resolved to the digests reported by the replicas
+	// running them, when the Component is provisioned or its images are updated, and recorded in `status.imageDigests`.
+	// If any replica runs an image whose digest differs from the pinned one afterward, e.g. a mutable tag has been
+	// re-pushed and pulled by a rescheduled replica, the `ImageDigestDrifted` condition is set and a warning event
+	// is emitted.
+	//
+	// +optional
+	PinImageDigests *bool `json:"pinImageDigests,omitempty"`
+
 	// Deprecated since v0.9
 	// Determines whether metrics exporter information is annotated on the Component's headless Service.
 	//
@@ -909,6 +1188,30 @@ type ClusterComponentStatus struct {
 	//
 	// +optional
 	MembersStatus []workloads.MemberStatus `json:"membersStatus,omitempty"`
+
+	// Represents the validity of the TLS certificate used by the Component, if TLS is enabled.
+	//
+	// +optional
+	TLSCertificate *TLSCertificateStatus `json:"tlsCertificate,omitempty"`
+
+	// Represents the progress of restoring the data of the Component from a backup during provisioning.
+	//
+	// +optional
+	RestoreProgress *dpv1alpha1.RestoreDataProgress `json:"restoreProgress,omitempty"`
+}
+
+// TLSCertificateStatus represents the validity period of a TLS certificate.
+type TLSCertificateStatus struct {
+	// The time when the certificate becomes valid, it is also the time when the certificate was last rotated
+	// if it is issued by KubeBlocks.
+	//
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// The time when the certificate expires.
+	//
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
 }
 
 // ClusterSwitchPolicy defines the switch policy for a Cluster.
@@ -953,6 +1256,40 @@ func (r *ClusterComponentVolumeClaimTemplate) toVolumeClaimTemplate() corev1.Per
 	}
 }
 
+// SharedVolume defines a volume backed by a single ReadWriteMany PersistentVolumeClaim, which is shared by
+// all the instances of a Component.
+type SharedVolume struct {
+	// Specifies the name of the volume.
+	// The PersistentVolumeClaim is named as $(cluster.name)-$(component.name)-$(name).
+	//
+	// If it matches the name of a volumeMount defined in `componentDefinition.spec.runtime.containers[*].volumeMounts`,
+	// the volume is mounted as declared there.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([a-z0-9\-]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Specifies the path within the containers at which the volume is mounted,
+	// in addition to the volumeMounts defined in the ComponentDefinition.
+	//
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+
+	// Specifies the names of the containers in which the volume is mounted at the `mountPath`.
+	// If not specified, the volume is mounted in all the containers.
+	//
+	// +listType=set
+	// +optional
+	Containers []string `json:"containers,omitempty"`
+
+	// Defines the desired characteristics of the PersistentVolumeClaim.
+	// The access modes default to ReadWriteMany, and must contain ReadWriteMany if specified.
+	//
+	// +kubebuilder:validation:Required
+	Spec PersistentVolumeClaimSpec `json:"spec"`
+}
+
 type PersistentVolumeClaimSpec struct {
 	// Contains the desired access modes the volume should have.
 	// More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#access-modes-1.
@@ -1124,6 +1461,100 @@ type SchedulingPolicy struct {
 	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 }
 
+// VolumeSnapshotPolicy defines how the VolumeSnapshots of a Component are created and retained.
+type VolumeSnapshotPolicy struct {
+	// Specifies the name of the VolumeSnapshotClass used to create the VolumeSnapshots of the Component.
+	//
+	// If not specified, the first VolumeSnapshotClass whose driver matches the CSI driver of the volume is used.
+	//
+	// +optional
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName,omitempty"`
+
+	// Determines a duration up to which the automatically created VolumeSnapshots of the Component should be kept.
+	// The VolumeSnapshots created by the horizontal scaling and the scheduled backups are removed, together with
+	// the Backups they belong to, once they are older than the RetentionPeriod.
+	// If not set, the VolumeSnapshots are kept until the Backups are deleted.
+	// Sample duration format:
+	//
+	// - years: 	2y
+	// - months: 	6mo
+	// - days: 		30d
+	// - hours: 	12h
+	// - minutes: 	30m
+	//
+	// You can also combine the above durations. For example: 30d12h30m.
+	//
+	// +optional
+	RetentionPeriod dpv1alpha1.RetentionPeriod `json:"retentionPeriod,omitempty"`
+}
+
+// ComponentDataExport defines how the data of a Component is exported before the Component is removed.
+type ComponentDataExport struct {
+	// Confirms the export of the data when the Component is removed from the Cluster.
+	// The export may take a long time for a large amount of data, so it's only performed if confirmed,
+	// and the removal of the Component waits until the export completes.
+	//
+	// If the export fails, the removal is blocked until the failed Backup is deleted to retry the export,
+	// or the confirmation is withdrawn from the Component object to skip it.
+	//
+	// +optional
+	Confirmed bool `json:"confirmed,omitempty"`
+
+	// Specifies the backup method used to export the data, which must be defined in the BackupPolicy of the Component.
+	//
+	// If not specified, the first backup method of the BackupPolicy not snapshotting the volumes is used,
+	// which dumps the data to the BackupRepo.
+	//
+	// +optional
+	BackupMethod string `json:"backupMethod,omitempty"`
+
+	// Specifies the name of the BackupRepo where the exported data is stored.
+	//
+	// If not specified, the BackupRepo of the BackupPolicy, or the default BackupRepo is used.
+	//
+	// +optional
+	BackupRepoName string `json:"backupRepoName,omitempty"`
+
+	// Determines a duration up to which the exported Backup should be kept.
+	// If not set, the Backup is kept until it's deleted.
+	// Sample duration format:
+	//
+	// - years: 	2y
+	// - months: 	6mo
+	// - days: 		30d
+	// - hours: 	12h
+	// - minutes: 	30m
+	//
+	// You can also combine the above durations. For example: 30d12h30m.
+	//
+	// +optional
+	RetentionPeriod dpv1alpha1.RetentionPeriod `json:"retentionPeriod,omitempty"`
+}
+
+// InitScripts references the scripts executed before the engine of a Component starts on its first provision.
+//
+// The scripts are executed by an init container added to the Pods of the Component, until all the instances
+// of the first provision are ready. The completion is recorded by the annotation "kubeblocks.io/init-scripts-done"
+// of the Component, the instances created afterward, e.g. by the horizontal scaling, don't execute the scripts.
+// Since the Pods recreated before the completion may execute the scripts again, they should be idempotent.
+type InitScripts struct {
+	// Specifies the name of the ConfigMap holding the scripts, in the same namespace with the Cluster.
+	// Each key of the ConfigMap is a script, the scripts are executed by "sh" in the lexical order of the keys,
+	// and the failure of any script fails the start of the Pod.
+	//
+	// +kubebuilder:validation:Required
+	ConfigMapName string `json:"configMapName"`
+
+	// Specifies the name of the container defined in the ComponentDefinition whose image, environment variables
+	// and volume mounts are used to execute the scripts, so the scripts can access the tools of the engine
+	// and the data volumes.
+	//
+	// If not specified, the first container defined in the ComponentDefinition is used.
+	//
+	// +optional
+	Container string `json:"container,omitempty"`
+}
+
 type TLSConfig struct {
 	// A boolean flag that indicates whether the Component should use Transport Layer Security (TLS)
 	// for secure communication.
@@ -1147,6 +1578,8 @@ type TLSConfig struct {
 }
 
 // Issuer defines the TLS certificates issuer for the Cluster.
+//
+// +kubebuilder:validation:XValidation:rule="self.name == 'UserProvided' ? has(self.secretRef) : true",message="secretRef must be provided when the issuer name is UserProvided"
 type Issuer struct {
 	// The issuer for TLS certificates.
 	// It only allows two enum values: `KubeBlocks` and `UserProvided`.
@@ -1273,19 +1706,46 @@ type ClusterComponentConfigSource struct {
 	// - Local file
 }
 
-// ClusterNetwork is deprecated since v0.9.
+// ClusterNetwork defines the network configuration of the Cluster.
 type ClusterNetwork struct {
 	// Indicates whether the host network can be accessed. By default, this is set to false.
 	//
+	// Deprecated since v0.9.
+	//
 	// +kubebuilder:default=false
 	// +optional
 	HostNetworkAccessible bool `json:"hostNetworkAccessible,omitempty"`
 
 	// Indicates whether the network is accessible to the public. By default, this is set to false.
 	//
+	// Deprecated since v0.9.
+	//
 	// +kubebuilder:default=false
 	// +optional
 	PubliclyAccessible bool `json:"publiclyAccessible,omitempty"`
+
+	// Indicates whether to generate NetworkPolicies for the Components of the Cluster.
+	// Once enabled, the Pods of a Component only accept the traffic from:
+	//
+	// - the Pods of the same Cluster, including the backup and restore jobs of it;
+	// - the Pods in the namespace of KubeBlocks;
+	// - the Pods in the namespaces listed in `allowedNamespaces`, on the ports of the Component's services (all ports if it has none);
+	// - anywhere, on the ports of the Component's services of type NodePort or LoadBalancer;
+	// - anywhere, on the metrics port of the exporter, for scraping.
+	//
+	// The NetworkPolicies are kept in sync with the Components and their services,
+	// and are removed once this is disabled.
+	//
+	// +kubebuilder:default=false
+	// +optional
+	NetworkPolicyEnabled bool `json:"networkPolicyEnabled,omitempty"`
+
+	// Specifies the namespaces whose Pods are allowed to access the services of the Cluster,
+	// when the NetworkPolicies are enabled.
+	//
+	// +listType=set
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
 }
 
 type ServiceRef struct {
@@ -1415,6 +1875,8 @@ type ServiceRefCredentialSelector struct {
 // +kubebuilder:printcolumn:name="VERSION",type="string",JSONPath=".spec.clusterVersionRef",description="Cluster Application Version."
 // +kubebuilder:printcolumn:name="TERMINATION-POLICY",type="string",JSONPath=".spec.terminationPolicy",description="Cluster termination policy."
 // +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.phase",description="Cluster Status."
+// +kubebuilder:printcolumn:name="PRIMARY",type="string",JSONPath=".status.primaryInstance",description="The primary instance of the cluster."
+// +kubebuilder:printcolumn:name="LAST-BACKUP",type="date",JSONPath=".status.lastBackupTime",description="The completion time of the last successful backup."
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Cluster offers a unified management interface for a wide variety of database and storage systems:
@@ -1548,6 +2010,135 @@ func (r ClusterSpec) GetShardingByName(shardingName string) *ShardingSpec {
 	return nil
 }
 
+// GetActiveFreezeWindow returns the freeze window which is active at the given time and the time it ends.
+// If several windows overlap, the one ending last is returned. Invalid windows are ignored.
+func (r ClusterSpec) GetActiveFreezeWindow(now time.Time) (*FreezeWindow, time.Time) {
+	var (
+		active *FreezeWindow
+		end    time.Time
+	)
+	for i := range r.FreezeWindows {
+		window, err := r.FreezeWindows[i].Window()
+		if err != nil {
+			continue
+		}
+		if e, ok := window.ActiveAt(now); ok && e.After(end) {
+			active, end = &r.FreezeWindows[i], e
+		}
+	}
+	return active, end
+}
+
+// Window converts the freeze window to a recurrence.Window.
+func (r *FreezeWindow) Window() (recurrence.Window, error) {
+	loc := time.UTC
+	if r.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(r.TimeZone); err != nil {
+			return recurrence.Window{}, fmt.Errorf("invalid time zone %q: %v", r.TimeZone, err)
+		}
+	}
+	if r.Duration.Duration <= 0 {
+		return recurrence.Window{}, fmt.Errorf("the duration must be positive")
+	}
+	window := recurrence.Window{
+		Start:    r.Start.In(loc),
+		Duration: r.Duration.Duration,
+	}
+	if r.Recurrence != "" {
+		rule, err := recurrence.Parse(r.Recurrence, loc)
+		if err != nil {
+			return recurrence.Window{}, err
+		}
+		window.Rule = rule
+	}
+	return window, nil
+}
+
+// GetMaintenanceWindow returns the maintenance window which is open at the given time, or the next one to open.
+// If several windows are open, the one ending last is returned. Invalid windows are ignored.
+// It returns false if there is no valid maintenance window, which means the changes are allowed at any time.
+func (r ClusterSpec) GetMaintenanceWindow(now time.Time) (time.Time, time.Time, bool) {
+	var (
+		start, end time.Time
+		found      bool
+	)
+	for i := range r.MaintenanceWindows {
+		s, e, err := r.MaintenanceWindows[i].Window(now)
+		if err != nil {
+			continue
+		}
+		// the open windows take precedence over the ones to open
+		open, lastOpen := !now.Before(s), found && !now.Before(start)
+		switch {
+		case !found, open && !lastOpen, open && e.After(end), !open && !lastOpen && s.Before(start):
+			start, end, found = s, e, true
+		}
+	}
+	return start, end, found
+}
+
+// IsInMaintenanceWindow checks whether the disruptive changes to the Cluster are allowed at the given time.
+func (r ClusterSpec) IsInMaintenanceWindow(now time.Time) bool {
+	start, _, found := r.GetMaintenanceWindow(now)
+	return !found || !now.Before(start)
+}
+
+var cronDaysOfWeek = map[DayOfWeek]string{
+	Sunday:    "0",
+	Monday:    "1",
+	Tuesday:   "2",
+	Wednesday: "3",
+	Thursday:  "4",
+	Friday:    "5",
+	Saturday:  "6",
+}
+
+// Window returns the occurrence of the maintenance window which contains now, or the next one if now is in none of them.
+func (r *MaintenanceWindow) Window(now time.Time) (time.Time, time.Time, error) {
+	loc := time.UTC
+	if r.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(r.TimeZone); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid time zone %q: %v", r.TimeZone, err)
+		}
+	}
+	if r.Duration.Duration <= 0 {
+		return time.Time{}, time.Time{}, fmt.Errorf("the duration must be positive")
+	}
+	startTime, err := time.Parse("15:04", r.StartTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start time %q, it must be in the format HH:MM", r.StartTime)
+	}
+	days := "*"
+	if len(r.DaysOfWeek) > 0 {
+		dayList := make([]string, 0, len(r.DaysOfWeek))
+		for _, day := range r.DaysOfWeek {
+			d, ok := cronDaysOfWeek[day]
+			if !ok {
+				return time.Time{}, time.Time{}, fmt.Errorf("invalid day of week %q", day)
+			}
+			dayList = append(dayList, d)
+		}
+		days = strings.Join(dayList, ",")
+	}
+	cron, err := recurrence.ParseCron(fmt.Sprintf("%d %d * * %s", startTime.Minute(), startTime.Hour(), days), loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	// the window opened within the duration before now is still open
+	start := cron.Next(now.Add(-r.Duration.Duration))
+	return start, start.Add(r.Duration.Duration), nil
+}
+
+// GetBackupRepoName gets the name of the backupRepo specified in the backup configuration.
+func (r ClusterSpec) GetBackupRepoName() string {
+	if r.Backup == nil {
+		return ""
+	}
+	return r.Backup.RepoName
+}
+
 // GetComponentDefRefName gets the name of referenced component definition.
 func (r ClusterSpec) GetComponentDefRefName(componentName string) string {
 	for _, component := range r.ComponentSpecs {
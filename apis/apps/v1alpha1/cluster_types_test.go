@@ -334,3 +334,86 @@ func TestGetComponentOrName(t *testing.T) {
 		t.Error("function GetComponentByName should return nil")
 	}
 }
+
+func TestGetActiveFreezeWindow(t *testing.T) {
+	start := time.Date(2024, 3, 2, 22, 0, 0, 0, time.UTC)
+	spec := ClusterSpec{
+		FreezeWindows: []FreezeWindow{
+			{
+				Name:       "weekend",
+				Start:      metav1.NewTime(start),
+				Duration:   metav1.Duration{Duration: 4 * time.Hour},
+				Recurrence: "FREQ=WEEKLY;BYDAY=SA",
+			},
+			{
+				Name:     "invalid",
+				Start:    metav1.NewTime(start),
+				Duration: metav1.Duration{Duration: 24 * time.Hour},
+				TimeZone: "Invalid/Zone",
+			},
+		},
+	}
+	window, end := spec.GetActiveFreezeWindow(start.AddDate(0, 0, 7).Add(time.Hour))
+	if window == nil || window.Name != "weekend" {
+		t.Fatalf("expect the weekend freeze window is active, but got: %v", window)
+	}
+	if !end.Equal(start.AddDate(0, 0, 7).Add(4 * time.Hour)) {
+		t.Errorf("unexpected end of the freeze window: %s", end)
+	}
+	if window, _ = spec.GetActiveFreezeWindow(start.AddDate(0, 0, 1)); window != nil {
+		t.Errorf("expect no active freeze window, but got: %s", window.Name)
+	}
+}
+
+func TestGetMaintenanceWindow(t *testing.T) {
+	// 2024-03-02 is a Saturday
+	saturday := time.Date(2024, 3, 2, 22, 0, 0, 0, time.UTC)
+	spec := ClusterSpec{
+		MaintenanceWindows: []MaintenanceWindow{
+			{
+				DaysOfWeek: []DayOfWeek{Saturday},
+				StartTime:  "22:00",
+				Duration:   metav1.Duration{Duration: 4 * time.Hour},
+			},
+			{
+				DaysOfWeek: []DayOfWeek{Wednesday, Friday},
+				StartTime:  "01:00",
+				Duration:   metav1.Duration{Duration: 2 * time.Hour},
+				TimeZone:   "Asia/Shanghai",
+			},
+			{
+				StartTime: "00:00",
+				Duration:  metav1.Duration{Duration: time.Hour},
+				TimeZone:  "Invalid/Zone",
+			},
+		},
+	}
+
+	check := func(name string, now, expectedStart, expectedEnd time.Time, expectedOpen bool) {
+		start, end, found := spec.GetMaintenanceWindow(now)
+		if !found || !start.Equal(expectedStart) || !end.Equal(expectedEnd) {
+			t.Errorf("%s: unexpected maintenance window from %s to %s, found: %v", name, start, end, found)
+		}
+		if open := spec.IsInMaintenanceWindow(now); open != expectedOpen {
+			t.Errorf("%s: expect the maintenance window open: %v, but got: %v", name, expectedOpen, open)
+		}
+	}
+	check("the window across midnight is open", saturday.Add(3*time.Hour), saturday, saturday.Add(4*time.Hour), true)
+	// 01:00 on Wednesday in Asia/Shanghai is 17:00 on Tuesday in UTC
+	tuesday := time.Date(2024, 3, 5, 17, 0, 0, 0, time.UTC)
+	check("the earliest window to open", saturday.AddDate(0, 0, 2), tuesday, tuesday.Add(2*time.Hour), false)
+
+	spec.MaintenanceWindows = append(spec.MaintenanceWindows, MaintenanceWindow{
+		StartTime: "23:00",
+		Duration:  metav1.Duration{Duration: 30 * time.Minute},
+	})
+	check("the open window ending last", saturday.Add(70*time.Minute), saturday, saturday.Add(4*time.Hour), true)
+
+	spec.MaintenanceWindows = nil
+	if _, _, found := spec.GetMaintenanceWindow(saturday); found {
+		t.Error("expect no maintenance window")
+	}
+	if !spec.IsInMaintenanceWindow(saturday) {
+		t.Error("expect the changes are allowed at any time without maintenance windows")
+	}
+}
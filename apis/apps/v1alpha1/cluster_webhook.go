@@ -17,8 +17,10 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -26,9 +28,13 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/opsvalidation"
 )
 
 // log is for logging in this package.
@@ -48,7 +54,13 @@ var _ webhook.Validator = &Cluster{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *Cluster) ValidateCreate() (admission.Warnings, error) {
 	clusterlog.Info("validate create", "name", r.Name)
-	return nil, r.validate()
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	if err := r.validateBackupRepo(nil); err != nil {
+		return nil, err
+	}
+	return nil, r.validatePolicies(ValidationOperationCreate, nil)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -61,7 +73,17 @@ func (r *Cluster) ValidateUpdate(old runtime.Object) (admission.Warnings, error)
 	if err := r.validate(); err != nil {
 		return nil, err
 	}
-	return nil, r.validateVolumeClaimTemplates(lastCluster)
+	if err := r.validateVolumeClaimTemplates(lastCluster); err != nil {
+		return nil, err
+	}
+	if err := r.validateBackupRepo(lastCluster); err != nil {
+		return nil, err
+	}
+	// the updates of metadata and status are made by the controllers, and they are not subject to the policies.
+	if reflect.DeepEqual(lastCluster.Spec, r.Spec) {
+		return nil, nil
+	}
+	return nil, r.validatePolicies(ValidationOperationUpdate, lastCluster)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -73,6 +95,55 @@ func (r *Cluster) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
 
+// validatePolicies validates the Cluster against the ValidationPolicies targeting Clusters.
+func (r *Cluster) validatePolicies(operation ValidationOperation, lastCluster *Cluster) error {
+	if webhookMgr == nil {
+		return nil
+	}
+	var oldObj client.Object
+	if lastCluster != nil {
+		oldObj = lastCluster
+	}
+	return enforceValidationPolicies(context.Background(), webhookMgr.client, ValidationTargetCluster, operation, r, oldObj, r)
+}
+
+// validateBackupRepo checks that the backup repo of the Cluster is allowed to be accessed from its namespace,
+// it is only checked when the backup repo is specified or changed.
+func (r *Cluster) validateBackupRepo(lastCluster *Cluster) error {
+	if webhookMgr == nil || webhookMgr.client == nil {
+		return nil
+	}
+	repoName := r.Spec.GetBackupRepoName()
+	if repoName == "" || (lastCluster != nil && lastCluster.Spec.GetBackupRepoName() == repoName) {
+		return nil
+	}
+	return checkBackupRepoAccess(context.Background(), webhookMgr.client, repoName, r.Namespace)
+}
+
+// checkBackupRepoAccess checks whether the backup repo is allowed to be accessed from the namespace.
+// The backup repo that does not exist is not checked, as it is reported by the controllers.
+func checkBackupRepoAccess(ctx context.Context, cli client.Client, repoName, namespace string) error {
+	repo := &dpv1alpha1.BackupRepo{}
+	if err := cli.Get(ctx, client.ObjectKey{Name: repoName}, repo); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !repo.IsNamespaceRestricted() {
+		return nil
+	}
+	ns := &corev1.Namespace{}
+	if err := cli.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		return err
+	}
+	allowed, err := repo.IsNamespaceAllowed(ns.Name, ns.Labels)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return fmt.Errorf(`backup repo "%s" is not allowed to be accessed from the namespace "%s"`, repoName, namespace)
+	}
+	return nil
+}
+
 // validateVolumeClaimTemplates volumeClaimTemplates is forbidden modification except for storage size.
 func (r *Cluster) validateVolumeClaimTemplates(lastCluster *Cluster) error {
 	var allErrs field.ErrorList
@@ -124,6 +195,8 @@ func (r *Cluster) validate() error {
 	}
 
 	r.validateComponents(&allErrs)
+	r.validateFreezeWindows(&allErrs)
+	r.validateMaintenanceWindows(&allErrs)
 
 	if len(allErrs) > 0 {
 		return apierrors.NewInvalid(
@@ -138,33 +211,28 @@ func (r *Cluster) validateComponents(allErrs *field.ErrorList) {
 	for i, v := range r.Spec.ComponentSpecs {
 		r.validateComponentResources(allErrs, v.Resources, i)
 	}
-	r.validateComponentTLSSettings(allErrs)
 }
 
 // validateComponentResources validate component resources
 func (r *Cluster) validateComponentResources(allErrs *field.ErrorList, resources corev1.ResourceRequirements, index int) {
-	if invalidValue, err := validateVerticalResourceList(resources.Requests); err != nil {
-		*allErrs = append(*allErrs, field.Invalid(field.NewPath(fmt.Sprintf("spec.components[%d].resources.requests", index)), invalidValue, err.Error()))
-	}
-	if invalidValue, err := validateVerticalResourceList(resources.Limits); err != nil {
-		*allErrs = append(*allErrs, field.Invalid(field.NewPath(fmt.Sprintf("spec.components[%d].resources.limits", index)), invalidValue, err.Error()))
-	}
-	if invalidValue, err := compareRequestsAndLimits(resources); err != nil {
-		*allErrs = append(*allErrs, field.Invalid(field.NewPath(fmt.Sprintf("spec.components[%d].resources.requests", index)), invalidValue, err.Error()))
-	}
+	path := field.NewPath("spec", "components").Index(index).Child("resources")
+	*allErrs = append(*allErrs, opsvalidation.ValidateResourceRequirements(path, resources)...)
 }
 
-func (r *Cluster) validateComponentTLSSettings(allErrs *field.ErrorList) {
-	for index, component := range r.Spec.ComponentSpecs {
-		if !component.TLS {
-			continue
+// validateFreezeWindows validate spec.freezeWindows is legal
+func (r *Cluster) validateFreezeWindows(allErrs *field.ErrorList) {
+	for i := range r.Spec.FreezeWindows {
+		if _, err := r.Spec.FreezeWindows[i].Window(); err != nil {
+			*allErrs = append(*allErrs, field.Invalid(field.NewPath(fmt.Sprintf("spec.freezeWindows[%d]", i)), r.Spec.FreezeWindows[i].Name, err.Error()))
 		}
-		if component.Issuer == nil {
-			*allErrs = append(*allErrs, field.Required(field.NewPath(fmt.Sprintf("spec.components[%d].issuer", index)), "Issuer must be set when Tls enabled"))
-			continue
-		}
-		if component.Issuer.Name == IssuerUserProvided && component.Issuer.SecretRef == nil {
-			*allErrs = append(*allErrs, field.Required(field.NewPath(fmt.Sprintf("spec.components[%d].issuer.secretRef", index)), "Secret must provide when issuer name is UserProvided"))
+	}
+}
+
+// validateMaintenanceWindows validate spec.maintenanceWindows is legal
+func (r *Cluster) validateMaintenanceWindows(allErrs *field.ErrorList) {
+	for i := range r.Spec.MaintenanceWindows {
+		if _, _, err := r.Spec.MaintenanceWindows[i].Window(time.Now()); err != nil {
+			*allErrs = append(*allErrs, field.Invalid(field.NewPath(fmt.Sprintf("spec.maintenanceWindows[%d]", i)), r.Spec.MaintenanceWindows[i].StartTime, err.Error()))
 		}
 	}
 }
@@ -0,0 +1,78 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+)
+
+func TestCheckBackupRepoAccess(t *testing.T) {
+	newNamespace := func(name, team string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"team": team}}}
+	}
+	newRepo := func(name string, allowedNamespaces []string, selector *metav1.LabelSelector) *dpv1alpha1.BackupRepo {
+		return &dpv1alpha1.BackupRepo{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       dpv1alpha1.BackupRepoSpec{AllowedNamespaces: allowedNamespaces, NamespaceSelector: selector},
+		}
+	}
+
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{dpv1alpha1.AddToScheme, corev1.AddToScheme} {
+		if err := addToScheme(scheme); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newNamespace("team-a", "a"),
+		newNamespace("team-b", "b"),
+		newRepo("shared", nil, nil),
+		newRepo("repo-a", []string{"team-a"}, nil),
+		newRepo("repo-b", nil, &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}}),
+	).Build()
+	tests := []struct {
+		repo      string
+		namespace string
+		errMsg    string
+	}{
+		{"shared", "team-a", ""},
+		{"shared", "team-b", ""},
+		{"repo-a", "team-a", ""},
+		{"repo-a", "team-b", "is not allowed to be accessed"},
+		{"repo-b", "team-a", "is not allowed to be accessed"},
+		{"repo-b", "team-b", ""},
+		{"not-exist", "team-a", ""},
+	}
+	for _, tt := range tests {
+		err := checkBackupRepoAccess(context.Background(), cli, tt.repo, tt.namespace)
+		if tt.errMsg == "" && err != nil {
+			t.Errorf("repo %s, namespace %s: unexpected error: %v", tt.repo, tt.namespace, err)
+		}
+		if tt.errMsg != "" && (err == nil || !strings.Contains(err.Error(), tt.errMsg)) {
+			t.Errorf("repo %s, namespace %s: expect error containing %q, got %v", tt.repo, tt.namespace, tt.errMsg, err)
+		}
+	}
+}
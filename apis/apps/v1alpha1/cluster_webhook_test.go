@@ -121,7 +121,7 @@ var _ = Describe("cluster webhook", func() {
 					"memory1": resource.MustParse("200Mi"),
 				},
 			}
-			Expect(k8sClient.Patch(ctx, cluster, patch).Error()).To(ContainSubstring("resource key is not cpu or memory or hugepages- "))
+			Expect(k8sClient.Patch(ctx, cluster, patch).Error()).To(ContainSubstring(`spec.components[0].resources.requests[memory1]: Unsupported value: "memory1"`))
 			patch = client.MergeFrom(cluster.DeepCopy())
 			cluster.Spec.ComponentSpecs[0].Resources = corev1.ResourceRequirements{
 				Requests: corev1.ResourceList{
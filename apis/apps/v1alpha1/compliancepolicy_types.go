@@ -0,0 +1,247 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CompliancePolicySpec defines the desired state of CompliancePolicy.
+type CompliancePolicySpec struct {
+	// Selects the Clusters evaluated against the policy, across all namespaces.
+	// All Clusters are selected if it is not specified.
+	//
+	// +optional
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+
+	// Specifies the built-in rules enforced by the policy.
+	//
+	// +optional
+	BuiltinRules ComplianceBuiltinRules `json:"builtinRules,omitempty"`
+
+	// Specifies the custom rules enforced by the policy, each of them is expressed in CEL.
+	//
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Rules []ComplianceRule `json:"rules,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+
+	// Specifies the interval in seconds between two evaluations of the selected Clusters.
+	//
+	// +kubebuilder:validation:Minimum=60
+	// +kubebuilder:default=3600
+	// +optional
+	EvaluationIntervalSeconds int32 `json:"evaluationIntervalSeconds,omitempty"`
+}
+
+// ComplianceBuiltinRules defines the built-in rules of a CompliancePolicy, a rule is enforced only if it is specified.
+type ComplianceBuiltinRules struct {
+	// Requires the backup of the Clusters to be enabled, through `spec.backup.enabled` of the Cluster.
+	//
+	// +optional
+	BackupEnabled bool `json:"backupEnabled,omitempty"`
+
+	// Requires the Components of the Clusters to have enough replicas to be highly available.
+	//
+	// +optional
+	HighAvailability *ComplianceHighAvailabilityRule `json:"highAvailability,omitempty"`
+
+	// Declares the end-of-life service versions, which are not allowed to be run by the Components of the Clusters.
+	//
+	// +optional
+	EOLServiceVersions []ComplianceEOLServiceVersions `json:"eolServiceVersions,omitempty"`
+}
+
+// ComplianceHighAvailabilityRule requires the Components of the Clusters to have a minimum number of replicas.
+type ComplianceHighAvailabilityRule struct {
+	// Selects the Clusters required to be highly available among the ones selected by the policy,
+	// for example, the Clusters labeled as production ones.
+	// All the Clusters selected by the policy are required if it is not specified.
+	//
+	// +optional
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+
+	// Specifies the minimum number of replicas of each Component.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=2
+	// +optional
+	MinReplicas int32 `json:"minReplicas,omitempty"`
+}
+
+// ComplianceEOLServiceVersions declares the end-of-life service versions of a set of component definitions.
+type ComplianceEOLServiceVersions struct {
+	// Specifies the names of the component definitions.
+	// Each name in the list can represent an exact name, or a name prefix.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	CompDefs []string `json:"compDefs"`
+
+	// Specifies the end-of-life service versions as semantic version constraints, for example, "< 8.0.0".
+	// A Component is non-compliant if its service version satisfies any of the constraints.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	ServiceVersions []string `json:"serviceVersions"`
+}
+
+// ComplianceRule defines a custom rule of a CompliancePolicy expressed in CEL.
+type ComplianceRule struct {
+	// Specifies the name of the rule, which is unique within the policy.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([a-z0-9\.\-]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Specifies the CEL expression which evaluates to true if the Cluster complies with the rule.
+	//
+	// The expression can access the following variables:
+	//
+	// - `cluster`: the Cluster object.
+	// - `components`: the list of Component objects of the Cluster.
+	//
+	// For example, `cluster.spec.terminationPolicy != 'WipeOut'`.
+	//
+	// +kubebuilder:validation:Required
+	Expression string `json:"expression"`
+
+	// Specifies the message reported when the Cluster violates the rule.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// CompliancePolicyStatus defines the observed state of CompliancePolicy.
+type CompliancePolicyStatus struct {
+	// Refers to the most recent generation that has been observed for the CompliancePolicy.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Records the time of the last evaluation.
+	//
+	// +optional
+	LastEvaluationTime metav1.Time `json:"lastEvaluationTime,omitempty"`
+
+	// Represents the number of Clusters evaluated in the last evaluation.
+	//
+	// +optional
+	EvaluatedClusters int32 `json:"evaluatedClusters,omitempty"`
+
+	// Represents the number of Clusters which violate any of the rules in the last evaluation.
+	//
+	// +optional
+	NonCompliantClusters int32 `json:"nonCompliantClusters,omitempty"`
+
+	// Reports the violations of the non-compliant Clusters.
+	//
+	// +optional
+	Reports []ClusterComplianceReport `json:"reports,omitempty"`
+
+	// Provides additional information about the evaluation, such as the custom rules failed to be compiled.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterComplianceReport reports the violations of a non-compliant Cluster.
+type ClusterComplianceReport struct {
+	// Specifies the namespace of the Cluster.
+	//
+	// +kubebuilder:validation:Required
+	Namespace string `json:"namespace"`
+
+	// Specifies the name of the Cluster.
+	//
+	// +kubebuilder:validation:Required
+	ClusterName string `json:"clusterName"`
+
+	// Lists the rules violated by the Cluster.
+	//
+	// +optional
+	Violations []ComplianceViolation `json:"violations,omitempty"`
+}
+
+// ComplianceViolation describes a rule violated by a Cluster.
+type ComplianceViolation struct {
+	// Specifies the name of the violated rule.
+	// The built-in rules are named "BackupEnabled", "HighAvailability" and "EOLServiceVersions".
+	//
+	// +kubebuilder:validation:Required
+	Rule string `json:"rule"`
+
+	// Describes the violation.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks},scope=Cluster,shortName=cp
+// +kubebuilder:printcolumn:name="EVALUATED",type="integer",JSONPath=".status.evaluatedClusters",description="The number of evaluated Clusters."
+// +kubebuilder:printcolumn:name="NON-COMPLIANT",type="integer",JSONPath=".status.nonCompliantClusters",description="The number of non-compliant Clusters."
+// +kubebuilder:printcolumn:name="LAST-EVALUATION",type="date",JSONPath=".status.lastEvaluationTime"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// CompliancePolicy evaluates the Clusters selected by labels against a set of built-in and custom rules periodically,
+// reporting the violations in its status and in the "Compliant" condition of the Clusters.
+type CompliancePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CompliancePolicySpec   `json:"spec,omitempty"`
+	Status CompliancePolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CompliancePolicyList contains a list of CompliancePolicy.
+type CompliancePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CompliancePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CompliancePolicy{}, &CompliancePolicyList{})
+}
+
+// GetEvaluationInterval returns the interval between two evaluations.
+func (r CompliancePolicySpec) GetEvaluationInterval() time.Duration {
+	if r.EvaluationIntervalSeconds < 60 {
+		return time.Hour
+	}
+	return time.Duration(r.EvaluationIntervalSeconds) * time.Second
+}
+
+// GetMinReplicas returns the minimum number of replicas of each Component.
+func (r ComplianceHighAvailabilityRule) GetMinReplicas() int32 {
+	if r.MinReplicas < 1 {
+		return 2
+	}
+	return r.MinReplicas
+}
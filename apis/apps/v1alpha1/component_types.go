@@ -19,6 +19,8 @@ package v1alpha1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 )
 
 // ComponentSpec defines the desired state of Component.
@@ -102,6 +104,19 @@ type ComponentSpec struct {
 	// +optional
 	Volumes []corev1.Volume `json:"volumes,omitempty"`
 
+	// Specifies the volumes shared by all the instances of the Component, such as a shared WAL archive
+	// or a plugins directory.
+	// Each of them is backed by a single ReadWriteMany PersistentVolumeClaim, which is mounted in all the instances
+	// and kept when the Component is scaled in or out.
+	//
+	// The StorageClass of a shared volume must support the ReadWriteMany access mode,
+	// otherwise the provisioning of the Component is blocked.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	SharedVolumes []SharedVolume `json:"sharedVolumes,omitempty"`
+
 	// Overrides Services defined in referenced ComponentDefinition and exposes endpoints that can be accessed
 	// by clients.
 	//
@@ -255,6 +270,104 @@ type ComponentSpec struct {
 	//
 	// +optional
 	DisableExporter *bool `json:"disableExporter,omitempty"`
+
+	// Specifies whether to provision the read-write and read-only Services for the Component.
+	//
+	// If set to true, the following Services are managed for the Component:
+	//
+	// - "{cluster.name}-{component.name}-rw": selects the Pod with a writable role.
+	// - "{cluster.name}-{component.name}-ro": selects the Pods with a serviceable but not writable role.
+	//
+	// The Services select the Pods by the access mode label, which is updated as soon as the role of a Pod changes,
+	// so that the Services follow the switchover and failover automatically.
+	// The roles must be defined in the ComponentDefinition, and the read-only Service is not provisioned
+	// if no read-only role is defined.
+	//
+	// +optional
+	ReadWriteSplitServices *bool `json:"readWriteSplitServices,omitempty"`
+
+	// Specifies whether to provision a canary Service for the Component during updates.
+	//
+	// If set to true, the Service "{cluster.name}-{component.name}-canary" is provisioned while the Component is
+	// being updated, and it selects only the Pods that have already been updated to the latest revision.
+	// It allows applications or QA to validate the new version with real traffic before the rollout proceeds,
+	// e.g. while the rollout is held at a partition of the InstanceSet.
+	// The Service is removed once all the Pods are updated.
+	//
+	// +optional
+	CanaryService *bool `json:"canaryService,omitempty"`
+
+	// Specifies the preferred VolumeSnapshotClass and the retention of the VolumeSnapshots automatically created
+	// for the Component, e.g. by the horizontal scaling and the scheduled backups.
+	//
+	// +optional
+	VolumeSnapshotPolicy *VolumeSnapshotPolicy `json:"volumeSnapshotPolicy,omitempty"`
+
+	// Specifies the DNS policy for the Pods of the Component, overriding the one defined in the ComponentDefinition.
+	// Valid values are 'ClusterFirstWithHostNet', 'ClusterFirst', 'Default' or 'None'.
+	//
+	// If not specified, the Pods use the DNS policy defined in the ComponentDefinition, or 'ClusterFirstWithHostNet'
+	// if the host network is enabled.
+	//
+	// +kubebuilder:validation:Enum={ClusterFirstWithHostNet,ClusterFirst,Default,None}
+	// +optional
+	DNSPolicy *corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// Specifies the DNS parameters for the Pods of the Component, such as the search domains and the resolver options.
+	// The parameters are merged with the DNS configuration generated from the DNS policy.
+	//
+	// For example, engines doing client-side discovery across namespaces can lower the `ndots` option
+	// to avoid resolving the fully qualified names through all the search domains.
+	//
+	// +optional
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+
+	// Specifies the name of the PriorityClass for the Pods of the Component, overriding the one defined in the ComponentDefinition.
+	// The PriorityClass determines the priority of the Pods and whether they preempt the Pods of lower priority,
+	// so that the database Pods outrank the batch workloads under resource pressure.
+	//
+	// If not specified, the Pods use the PriorityClass defined in the ComponentDefinition,
+	// or the default one configured for the data plane of KubeBlocks.
+	// The PriorityClass must exist, otherwise the provisioning of the Component is blocked.
+	//
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Specifies the export of the data of the Component to a Backup before the Component is removed from the Cluster.
+	// The volumes of a removed Component are deleted, the export keeps its data for later retrieval.
+	//
+	// +optional
+	DataExport *ComponentDataExport `json:"dataExport,omitempty"`
+
+	// Specifies the init containers supplied by the user, which run after the init containers defined in the
+	// ComponentDefinition and before the containers of the engine start.
+	// They are useful to prepare the filesystem or to load the seed data, e.g. downloading the data files into
+	// the data volumes of the Component.
+	//
+	// The names of the containers must not collide with the names of the init containers and the containers
+	// defined in the ComponentDefinition.
+	//
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	InitContainers []corev1.Container `json:"initContainers,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+
+	// Specifies the scripts executed before the engine starts on the first provision of the Component,
+	// e.g. to create the databases and load the seed data.
+	//
+	// +optional
+	InitScripts *InitScripts `json:"initScripts,omitempty"`
+
+	// Specifies whether to pin the images of the Component to their digests.
+	//
+	// If enabled, the image references of the containers are resolved to the digests reported by the replicas
+	// running them, when the Component is provisioned or its images are updated, and recorded in `status.imageDigests`.
+	// If any replica runs an image whose digest differs from the pinned one afterward, e.g. a mutable tag has been
+	// re-pushed and pulled by a rescheduled replica, the `ImageDigestDrifted` condition is set and a warning event
+	// is emitted.
+	//
+	// +optional
+	PinImageDigests *bool `json:"pinImageDigests,omitempty"`
 }
 
 // ComponentStatus represents the observed state of a Component within the Cluster.
@@ -286,6 +399,7 @@ type ComponentStatus struct {
 	// - Stopping: All Pods are being terminated, with current replica count at zero.
 	// - Stopped: All associated Pods have been successfully deleted.
 	// - Deleting: The Component is being deleted.
+	// - Completed: All Pods of a run-to-completion Component have terminated successfully.
 	Phase ClusterComponentPhase `json:"phase,omitempty"`
 
 	// A map that stores detailed message about the Component.
@@ -296,6 +410,59 @@ type ComponentStatus struct {
 	//
 	// +optional
 	Message ComponentMessageMap `json:"message,omitempty"`
+
+	// Lists the live VolumeSnapshots of the Component, sorted by the creation time.
+	//
+	// +optional
+	VolumeSnapshots []ComponentVolumeSnapshot `json:"volumeSnapshots,omitempty"`
+
+	// Represents the progress of restoring the data of the Component from a backup during provisioning.
+	// It is removed once the restore is done.
+	//
+	// +optional
+	RestoreProgress *dpv1alpha1.RestoreDataProgress `json:"restoreProgress,omitempty"`
+
+	// Records the digests of the images pinned by `spec.pinImageDigests`, keyed by the image references
+	// specified for the containers, e.g. "apecloud/mysql:8.0.33": "sha256:1a2b...".
+	//
+	// +optional
+	ImageDigests map[string]string `json:"imageDigests,omitempty"`
+}
+
+// ComponentVolumeSnapshot represents a VolumeSnapshot taken from a volume of the Component.
+type ComponentVolumeSnapshot struct {
+	// The name of the VolumeSnapshot.
+	Name string `json:"name"`
+
+	// The name of the Backup that the VolumeSnapshot belongs to.
+	//
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+
+	// The name of the PersistentVolumeClaim that the VolumeSnapshot is taken from.
+	//
+	// +optional
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName,omitempty"`
+
+	// The name of the VolumeSnapshotClass used by the VolumeSnapshot.
+	//
+	// +optional
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName,omitempty"`
+
+	// Indicates whether the VolumeSnapshot is ready to be used to restore a volume.
+	//
+	// +optional
+	ReadyToUse bool `json:"readyToUse,omitempty"`
+
+	// The time when the VolumeSnapshot was created.
+	//
+	// +optional
+	CreationTimestamp metav1.Time `json:"creationTimestamp,omitempty"`
+
+	// The time when the VolumeSnapshot will be removed according to the retention period of the Component.
+	//
+	// +optional
+	ExpirationTimestamp *metav1.Time `json:"expirationTimestamp,omitempty"`
 }
 
 // +genclient
@@ -0,0 +1,46 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=componentclassdefinitions,verbs=get;list;watch
+
+// ResolveComponentClass gets the class referenced by classRef for the Components of the ComponentDefinition,
+// and validates it against the constraint of the ComponentClassDefinition.
+func ResolveComponentClass(ctx context.Context, cli client.Client, classRef ComponentClassRef, compDef string) (*ComponentClass, error) {
+	classDef := &ComponentClassDefinition{}
+	if err := cli.Get(ctx, client.ObjectKey{Name: classRef.Name}, classDef); err != nil {
+		return nil, fmt.Errorf(`get ComponentClassDefinition "%s" failed: %s`, classRef.Name, err.Error())
+	}
+	if !classDef.Spec.IsApplicableTo(compDef) {
+		return nil, fmt.Errorf(`ComponentClassDefinition "%s" is not applicable to ComponentDefinition "%s"`, classRef.Name, compDef)
+	}
+	class := classDef.Spec.GetClass(classRef.Class)
+	if class == nil {
+		return nil, fmt.Errorf(`class "%s" not found in ComponentClassDefinition "%s"`, classRef.Class, classRef.Name)
+	}
+	if err := classDef.Spec.Constraint.Validate(*class); err != nil {
+		return nil, fmt.Errorf(`class "%s" of ComponentClassDefinition "%s" violates the constraint: %s`, classRef.Class, classRef.Name, err.Error())
+	}
+	return class, nil
+}
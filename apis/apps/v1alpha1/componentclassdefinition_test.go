@@ -0,0 +1,73 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestResolveComponentClass(t *testing.T) {
+	maxCPU := resource.MustParse("4")
+	classDef := &ComponentClassDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "general"},
+		Spec: ComponentClassDefinitionSpec{
+			CompDefs:   []string{"mysql-"},
+			Constraint: &ComponentResourceConstraint{CPU: &ResourceRange{Max: &maxCPU}},
+			Classes: []ComponentClass{
+				{Name: "small", CPU: resource.MustParse("1"), Memory: resource.MustParse("2Gi")},
+				{Name: "xlarge", CPU: resource.MustParse("8"), Memory: resource.MustParse("32Gi")},
+			},
+		},
+	}
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(classDef).Build()
+
+	class, err := ResolveComponentClass(context.Background(), cli, ComponentClassRef{Name: "general", Class: "small"}, "mysql-8.0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resources := class.ToResourceRequirements()
+	if !resources.Requests.Cpu().Equal(resource.MustParse("1")) || !resources.Limits.Memory().Equal(resource.MustParse("2Gi")) {
+		t.Errorf("unexpected resources: %v", resources)
+	}
+	if !resources.Limits.Cpu().Equal(*resources.Requests.Cpu()) || !resources.Requests.Memory().Equal(*resources.Limits.Memory()) {
+		t.Errorf("expect the requests are equal to the limits: %v", resources)
+	}
+
+	for _, ref := range []struct {
+		classRef ComponentClassRef
+		compDef  string
+	}{
+		{ComponentClassRef{Name: "general", Class: "small"}, "postgresql-14"},
+		{ComponentClassRef{Name: "general", Class: "medium"}, "mysql-8.0"},
+		{ComponentClassRef{Name: "general", Class: "xlarge"}, "mysql-8.0"},
+		{ComponentClassRef{Name: "not-exist", Class: "small"}, "mysql-8.0"},
+	} {
+		if _, err := ResolveComponentClass(context.Background(), cli, ref.classRef, ref.compDef); err == nil {
+			t.Errorf("expect an error for class %v of %s", ref.classRef, ref.compDef)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ComponentClassDefinitionSpec defines the desired state of ComponentClassDefinition.
+type ComponentClassDefinitionSpec struct {
+	// Specifies the names of the ComponentDefinitions to which the classes are applicable.
+	// Each name in the list can represent an exact name, or a name prefix.
+	//
+	// The classes are applicable to all Components if it is not specified.
+	//
+	// +kubebuilder:validation:MaxItems=128
+	// +optional
+	CompDefs []string `json:"compDefs,omitempty"`
+
+	// Specifies the range of the compute resources allowed for the Components,
+	// the classes out of the range can not be referenced.
+	//
+	// +optional
+	Constraint *ComponentResourceConstraint `json:"constraint,omitempty"`
+
+	// Specifies the classes of the compute resources, such as "small", "medium" and "large".
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	Classes []ComponentClass `json:"classes" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+}
+
+// ComponentClass defines a named class of the compute resources.
+type ComponentClass struct {
+	// Specifies the name of the class, which is unique within the ComponentClassDefinition.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([a-z0-9\.\-]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Specifies the CPU of the class.
+	//
+	// +kubebuilder:validation:Required
+	CPU resource.Quantity `json:"cpu"`
+
+	// Specifies the memory of the class.
+	//
+	// +kubebuilder:validation:Required
+	Memory resource.Quantity `json:"memory"`
+
+	// Provides a human-readable description of the class.
+	//
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// ComponentResourceConstraint defines the range of the compute resources allowed for the Components.
+type ComponentResourceConstraint struct {
+	// Specifies the range of the CPU.
+	//
+	// +optional
+	CPU *ResourceRange `json:"cpu,omitempty"`
+
+	// Specifies the range of the memory.
+	//
+	// +optional
+	Memory *ResourceRange `json:"memory,omitempty"`
+}
+
+// ResourceRange defines the range of a compute resource, both ends are inclusive.
+type ResourceRange struct {
+	// Specifies the minimum amount of the resource.
+	//
+	// +optional
+	Min *resource.Quantity `json:"min,omitempty"`
+
+	// Specifies the maximum amount of the resource.
+	//
+	// +optional
+	Max *resource.Quantity `json:"max,omitempty"`
+}
+
+// ComponentClassRef references a class defined in a ComponentClassDefinition.
+type ComponentClassRef struct {
+	// Specifies the name of the ComponentClassDefinition.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the name of the class defined in the ComponentClassDefinition.
+	//
+	// +kubebuilder:validation:Required
+	Class string `json:"class"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories={kubeblocks},scope=Cluster,shortName=ccd
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ComponentClassDefinition defines a catalog of named classes of the compute resources, such as t-shirt sizes,
+// which can be referenced to vertically scale the Components instead of specifying the raw requests and limits.
+type ComponentClassDefinition struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ComponentClassDefinitionSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ComponentClassDefinitionList contains a list of ComponentClassDefinition.
+type ComponentClassDefinitionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ComponentClassDefinition `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ComponentClassDefinition{}, &ComponentClassDefinitionList{})
+}
+
+// IsApplicableTo checks whether the classes are applicable to the Components of the ComponentDefinition.
+func (r ComponentClassDefinitionSpec) IsApplicableTo(compDef string) bool {
+	if len(r.CompDefs) == 0 {
+		return true
+	}
+	for _, v := range r.CompDefs {
+		if strings.HasPrefix(compDef, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetClass gets the class by name.
+func (r ComponentClassDefinitionSpec) GetClass(name string) *ComponentClass {
+	for i := range r.Classes {
+		if r.Classes[i].Name == name {
+			return &r.Classes[i]
+		}
+	}
+	return nil
+}
+
+// ToResourceRequirements converts the class to the compute resources, the requests are equal to the limits.
+func (r ComponentClass) ToResourceRequirements() corev1.ResourceRequirements {
+	resources := corev1.ResourceList{
+		corev1.ResourceCPU:    r.CPU.DeepCopy(),
+		corev1.ResourceMemory: r.Memory.DeepCopy(),
+	}
+	return corev1.ResourceRequirements{
+		Requests: resources,
+		Limits:   resources.DeepCopy(),
+	}
+}
+
+// Validate checks whether the class is in the range of the constraint.
+func (r *ComponentResourceConstraint) Validate(class ComponentClass) error {
+	if r == nil {
+		return nil
+	}
+	if err := r.CPU.validate(corev1.ResourceCPU, class.CPU); err != nil {
+		return err
+	}
+	return r.Memory.validate(corev1.ResourceMemory, class.Memory)
+}
+
+func (r *ResourceRange) validate(name corev1.ResourceName, value resource.Quantity) error {
+	if r == nil {
+		return nil
+	}
+	if r.Min != nil && value.Cmp(*r.Min) < 0 {
+		return fmt.Errorf("%s %s is less than the minimum %s", name, value.String(), r.Min.String())
+	}
+	if r.Max != nil && value.Cmp(*r.Max) > 0 {
+		return fmt.Errorf("%s %s is greater than the maximum %s", name, value.String(), r.Max.String())
+	}
+	return nil
+}
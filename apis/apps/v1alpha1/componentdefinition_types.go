@@ -24,6 +24,8 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 )
 
 // +genclient
@@ -491,6 +493,16 @@ type ComponentDefinitionSpec struct {
 	//   - `dataLoad`: Defines the procedure to import data into a replica.
 	//   - `reconfigure`: Defines the procedure that update a replica with new configuration file.
 	//   - `accountProvision`: Defines the procedure to generate a new database account.
+	//   - `accountRotation`: Defines the procedure to change the password of a system account at the engine level.
+	//   - `reloadTLS`: Defines the procedure to reload the TLS certificates of a replica without restarting it.
+	//   - `fence`: Defines the procedure to fence the former leader before a new leader is promoted.
+	//   - `purge`: Defines the procedure to purge the logs and temporary files of a replica.
+	//   - `upgradePreCheck`: Defines the procedure to check whether a replica is ready to be upgraded to another major version.
+	//   - `shardRebalance`: Defines the procedure to rebalance the data across the shards of a sharding.
+	//   - `replicationLag`: Defines the procedure to measure the replication lag of a replica.
+	//   - `drainBackend`: Defines the procedure for a proxy to drain the connections to a backend replica.
+	//   - `resumeBackend`: Defines the procedure for a proxy to route connections to a backend replica again.
+	//   - `warmUpCheck`: Defines the procedure to check whether a replica has been warmed up after it is restarted.
 	//
 	// This field is immutable.
 	//
@@ -514,6 +526,42 @@ type ComponentDefinitionSpec struct {
 	// +kubebuilder:default=0
 	// +optional
 	MinReadySeconds int32 `json:"minReadySeconds,omitempty"`
+
+	// Specifies the type of the workload that manages the instances of the Component.
+	//
+	// - `InstanceSet`: The instances are long-running, this is the default.
+	// - `Job`: The instances run to completion, such as schema migration runners or bootstrap data loaders.
+	//   The Component enters the `Completed` phase once all instances have terminated successfully,
+	//   and can be ordered relative to the long-running Components through the `orders` of the cluster topology.
+	//   Persistent volumes, roles and lifecycle actions except `postProvision` are not supported.
+	//   A change of the Component spec recreates the Job to run the instances again.
+	//
+	// This field is immutable.
+	//
+	// +optional
+	WorkloadType ComponentWorkloadType `json:"workloadType,omitempty"`
+
+	// Defines the container to debug the instances of the Component.
+	// It is attached to a running Pod as an ephemeral container by the `DebugInstance` OpsRequest,
+	// and provides the tooling of the engine that is absent in the distroless images of the data containers.
+	//
+	// This field is immutable.
+	//
+	// +optional
+	DebugContainer *DebugContainer `json:"debugContainer,omitempty"`
+
+	// Defines how to fence the former leader before a new leader is promoted, to prevent split-brain
+	// when the former leader is partitioned from the other replicas but still alive.
+	//
+	// The leader renews its lease periodically as the heartbeat.
+	// Once the lease expires without being released, the candidate is promoted only after the former leader
+	// is verified to be fenced by one of the strategies, otherwise the promotion is blocked.
+	// Switchovers where the former leader releases the lease gracefully are not affected.
+	//
+	// This field is immutable.
+	//
+	// +optional
+	Fencing *FencingPolicy `json:"fencing,omitempty"`
 }
 
 // ComponentDefinitionStatus defines the observed state of ComponentDefinition.
@@ -535,6 +583,47 @@ type ComponentDefinitionStatus struct {
 	Message string `json:"message,omitempty"`
 }
 
+// DebugContainer defines the ephemeral container to debug an instance of the Component.
+type DebugContainer struct {
+	// Specifies the image that contains the tooling of the engine, such as the client and diagnostic tools.
+	//
+	// +kubebuilder:validation:Required
+	Image string `json:"image"`
+
+	// Specifies the entrypoint of the container, the entrypoint of the image is used if not specified.
+	//
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// Specifies the environment variables to set in the container.
+	//
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Specifies the security options of the container.
+	// If not specified, the container drops all capabilities except `SYS_PTRACE`,
+	// and the privilege escalation is not allowed.
+	//
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+}
+
+// FencingPolicy defines how to verify that the former leader is fenced.
+type FencingPolicy struct {
+	// Specifies the strategies to verify that the former leader is fenced, they are tried in order
+	// and the former leader is considered fenced once any of them succeeds.
+	//
+	// - `PodDeletion`: The Pod of the former leader has been deleted, or recreated after its last heartbeat.
+	// - `NodeCordon`: The Node of the former leader has been removed, or cordoned and tainted with `NoExecute`
+	// and the Pod of the former leader no longer runs on it. Cordoning alone is not fencing.
+	// - `Action`: The `fence` lifecycle action succeeds.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	Strategies []FencingStrategy `json:"strategies"`
+}
+
 type ComponentVolume struct {
 	// Specifies the name of the volume.
 	// It must be a DNS_LABEL and unique within the pod.
@@ -583,6 +672,14 @@ type ReplicasLimit struct {
 	//
 	// +kubebuilder:validation:Required
 	MaxReplicas int32 `json:"maxReplicas"`
+
+	// Specifies whether the number of replicas is expected to be odd, e.g. for the consensus-based engines like etcd,
+	// as an even number of replicas tolerates no more failures than one replica less.
+	//
+	// It's checked when the Component is scaled horizontally by an OpsRequest, unless the OpsRequest is forced.
+	//
+	// +optional
+	OddReplicas bool `json:"oddReplicas,omitempty"`
 }
 
 type SystemAccount struct {
@@ -1353,6 +1450,156 @@ type ComponentLifecycleActions struct {
 	//
 	// +optional
 	AccountProvision *LifecycleActionHandler `json:"accountProvision,omitempty"`
+
+	// Defines the procedure to change the password of a system account at the engine level,
+	// such as executing the `ALTER USER` statement.
+	//
+	// The action is invoked on a replica with a writable role, or any ready replica if no writable role is defined,
+	// by the `RotateCredentials` OpsRequest with the following environment variables:
+	//
+	// - KB_ACCOUNT_NAME: The name of the system account.
+	// - KB_ACCOUNT_PASSWORD: The new password of the system account.
+	//
+	// The Secret of the account is updated with the new password only after the action succeeds.
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	AccountRotation *LifecycleActionHandler `json:"accountRotation,omitempty"`
+
+	// Defines the procedure to reload the TLS certificates of a replica without restarting it.
+	//
+	// Use Case:
+	// This action is invoked on each replica by the `RotateTLS` OpsRequest after the certificates are renewed
+	// and the new certificates are mounted into the replica.
+	// If it is not defined, the replicas are restarted to load the new certificates.
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	ReloadTLS *LifecycleActionHandler `json:"reloadTLS,omitempty"`
+
+	// Defines the procedure to fence the former leader whose lease has expired before a new leader is promoted.
+	//
+	// Use Case:
+	// This action is invoked on the candidate leader if the `Action` fencing strategy is specified,
+	// to isolate the former leader at the engine level, such as revoking its write permission
+	// or removing it from the replication group.
+	// The former leader is considered fenced if the action succeeds.
+	//
+	// The following dedicated environment variables are provided for the action:
+	//
+	// - KB_FENCED_MEMBER_POD_NAME: The name of the former leader's Pod.
+	// - KB_FENCED_MEMBER_POD_IP: The IP of the former leader's Pod.
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	Fence *LifecycleActionHandler `json:"fence,omitempty"`
+
+	// Defines the procedure to purge the logs and temporary files of a replica,
+	// such as purging binlogs, trimming WAL archives or cleaning up temp dirs.
+	//
+	// The action is invoked on each replica by the `Purge` OpsRequest with the following environment variables:
+	//
+	// - KB_PURGE_RETAIN_HOURS: The number of hours of the most recent data to retain, empty if not specified.
+	// - KB_PURGE_RETAIN_BYTES: The size in bytes of the most recent data to retain, empty if not specified.
+	//
+	// The action is expected to print the reclaimed space in bytes as the last line of its output.
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	Purge *LifecycleActionHandler `json:"purge,omitempty"`
+
+	// Defines the procedure to check whether a replica is ready to be upgraded to another major version,
+	// such as checking the free disk space, the deprecated parameters or the health of the replication.
+	//
+	// The action is invoked on a replica with a writable role, or any ready replica if no writable role is defined,
+	// by the `Upgrade` OpsRequest for each pre-check declared by the upgrade path in the ComponentVersion,
+	// before any replica is upgraded. The following environment variables are provided for the action:
+	//
+	// - KB_UPGRADE_PRE_CHECK: The name of the pre-check.
+	// - KB_UPGRADE_FROM_VERSION: The service version to upgrade from.
+	// - KB_UPGRADE_TO_VERSION: The service version to upgrade to.
+	//
+	// The pre-check fails if the action exits with a non-zero code, and the error output is recorded as the reason.
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	UpgradePreCheck *LifecycleActionHandler `json:"upgradePreCheck,omitempty"`
+
+	// Defines the procedure to rebalance the data across the shards of a sharding, typically after the number
+	// of shards is changed, such as migrating the slots of a Redis Cluster or the chunks of a MongoDB sharded cluster.
+	//
+	// The action is invoked on a replica with a writable role of each shard, or any ready replica if no writable role
+	// is defined, by the `ShardRebalance` OpsRequest with the following environment variables:
+	//
+	// - KB_REBALANCE_SHARD_NAME: The name of the shard, which is the name of the Component.
+	// - KB_REBALANCE_SHARDS: The names of all the shards of the sharding, separated by commas.
+	//
+	// The action is expected to print the size in bytes of the data moved into the shard as the last line of its output.
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	ShardRebalance *LifecycleActionHandler `json:"shardRebalance,omitempty"`
+
+	// Defines the procedure to measure the replication lag of a replica behind the current leader.
+	//
+	// The action is invoked on the candidate replica by the `Switchover` OpsRequest with `maxLagSeconds` specified,
+	// before the candidate is promoted, with the following environment variables:
+	//
+	// - KB_LEADER_POD_NAME: The name of the current leader's Pod.
+	// - KB_LEADER_POD_FQDN: The FQDN of the current leader's Pod.
+	//
+	// The action is expected to print the replication lag in seconds as the last line of its output.
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	ReplicationLag *LifecycleActionHandler `json:"replicationLag,omitempty"`
+
+	// Defines the procedure for a proxy, such as ProxySQL or PgBouncer, to stop routing new connections to
+	// a replica of the backend Component and drain the existing ones.
+	//
+	// When a Component of the Cluster is restarted or upgraded by an OpsRequest, and another Component
+	// of the Cluster provides this action, the replicas are restarted one by one, and the action is invoked on
+	// each replica of the proxy Component before stopping a backend replica, with the following environment variables:
+	//
+	// - KB_BACKEND_COMP_NAME: The name of the Component to which the backend replica belongs.
+	// - KB_BACKEND_POD_NAME: The name of the backend replica's Pod.
+	// - KB_BACKEND_POD_FQDN: The FQDN of the backend replica's Pod.
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	DrainBackend *LifecycleActionHandler `json:"drainBackend,omitempty"`
+
+	// Defines the procedure for a proxy to route connections to a replica of the backend Component again,
+	// after the replica drained by the `drainBackend` action is restarted and ready.
+	//
+	// The action is invoked with the same environment variables as the `drainBackend` action.
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	ResumeBackend *LifecycleActionHandler `json:"resumeBackend,omitempty"`
+
+	// Defines the procedure to check whether a replica has been warmed up after it is restarted and ready,
+	// such as whether the cache hit ratio or the buffer pool usage has reached a threshold.
+	//
+	// Either a metric exposed by the engine and its threshold, or an action can be specified.
+	// The action is invoked on the replica periodically, and the replica is considered warmed up once it exits with code 0.
+	//
+	// A ready replica is not considered available until it has been warmed up or the check times out,
+	// so the rolling restart or update of the Component does not proceed to the next replica while the caches are cold.
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	WarmUpCheck *WarmUpCheck `json:"warmUpCheck,omitempty"`
 }
 
 type ComponentSwitchover struct {
@@ -1401,3 +1648,31 @@ type RoleProbe struct {
 	// +optional
 	PeriodSeconds int32 `json:"periodSeconds,omitempty" protobuf:"varint,4,opt,name=periodSeconds"`
 }
+
+// WarmUpCheck defines the warm-up check of the replicas.
+// The check is satisfied by either the metric specified or the action specified.
+//
+// +kubebuilder:validation:XValidation:rule="!(has(self.metric) && (has(self.builtinHandler) || has(self.customHandler)))",message="metric and action handler are mutually exclusive"
+type WarmUpCheck struct {
+	LifecycleActionHandler `json:",inline"`
+
+	// Specifies the engine metric and the threshold to check.
+	//
+	// +optional
+	Metric *workloads.WarmUpMetric `json:"metric,omitempty"`
+
+	// Specifies how often (in seconds) to perform the check.
+	// Defaults to 10 seconds. Minimum value is 1.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+
+	// Specifies the number of seconds after the replica becomes ready, after which the replica is considered
+	// available even if it has not been warmed up.
+	// Defaults to 600 seconds. Minimum value is 1.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+}
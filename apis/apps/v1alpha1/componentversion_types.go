@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"github.com/Masterminds/semver/v3"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -35,6 +36,16 @@ type ComponentVersionSpec struct {
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:MaxItems=128
 	Releases []ComponentVersionRelease `json:"releases"`
+
+	// UpgradePaths declares the allowed upgrades across major service versions, and the pre-checks to run before them.
+	//
+	// If not specified, the upgrades between any service versions are allowed.
+	// Otherwise, an upgrade across major service versions is rejected unless it matches one of the paths,
+	// the upgrades within a major service version are not restricted.
+	//
+	// +kubebuilder:validation:MaxItems=128
+	// +optional
+	UpgradePaths []ComponentVersionUpgradePath `json:"upgradePaths,omitempty"`
 }
 
 // ComponentVersionCompatibilityRule defines the compatibility between a set of component definitions and a set of releases.
@@ -94,6 +105,29 @@ type ComponentVersionRelease struct {
 	Images map[string]string `json:"images"`
 }
 
+// ComponentVersionUpgradePath declares an allowed upgrade across major service versions.
+type ComponentVersionUpgradePath struct {
+	// Specifies the service versions to upgrade from as a semantic version constraint, for example, "~5.7".
+	//
+	// +kubebuilder:validation:Required
+	From string `json:"from"`
+
+	// Specifies the service versions to upgrade to as a semantic version constraint, for example, "~8.0".
+	//
+	// +kubebuilder:validation:Required
+	To string `json:"to"`
+
+	// Specifies the names of the pre-checks to run before the upgrade, such as "diskSpace", "deprecatedParameters"
+	// and "replicationHealth".
+	// Each pre-check is performed by the `upgradePreCheck` lifecycle action defined in the ComponentDefinition
+	// of the Component, and the upgrade is aborted before any instance is touched if any of them fails.
+	//
+	// +kubebuilder:validation:MaxItems=32
+	// +listType=set
+	// +optional
+	PreChecks []string `json:"preChecks,omitempty"`
+}
+
 // ComponentVersionStatus defines the observed state of ComponentVersion
 type ComponentVersionStatus struct {
 	// ObservedGeneration is the most recent generation observed for this ComponentVersion.
@@ -145,3 +179,46 @@ type ComponentVersionList struct {
 func init() {
 	SchemeBuilder.Register(&ComponentVersion{}, &ComponentVersionList{})
 }
+
+// IsMajorVersionUpgrade checks whether the upgrade between the service versions crosses major versions,
+// the service versions that are not semantic versions are never regarded as a major-version upgrade.
+func IsMajorVersionUpgrade(from, to string) bool {
+	fromVersion, err := semver.NewVersion(from)
+	if err != nil {
+		return false
+	}
+	toVersion, err := semver.NewVersion(to)
+	if err != nil {
+		return false
+	}
+	return fromVersion.Major() != toVersion.Major()
+}
+
+// GetUpgradePath gets the upgrade path matched by the upgrade between the service versions,
+// and returns false if the upgrade is not allowed.
+func (r ComponentVersionSpec) GetUpgradePath(from, to string) (*ComponentVersionUpgradePath, bool) {
+	if len(r.UpgradePaths) == 0 || !IsMajorVersionUpgrade(from, to) {
+		return nil, true
+	}
+	for i, path := range r.UpgradePaths {
+		if path.matches(from, to) {
+			return &r.UpgradePaths[i], true
+		}
+	}
+	return nil, false
+}
+
+func (r ComponentVersionUpgradePath) matches(from, to string) bool {
+	match := func(constraint, ver string) bool {
+		c, err := semver.NewConstraint(constraint)
+		if err != nil {
+			return false
+		}
+		v, err := semver.NewVersion(ver)
+		if err != nil {
+			return false
+		}
+		return c.Check(v)
+	}
+	return match(r.From, from) && match(r.To, to)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+)
+
+func TestIsMajorVersionUpgrade(t *testing.T) {
+	cases := []struct {
+		from, to string
+		expected bool
+	}{
+		{"5.7.44", "8.0.33", true},
+		{"8.0.30", "8.0.33", false},
+		{"8.0.33", "5.7.44", true},
+		{"latest", "8.0.33", false},
+		{"", "8.0.33", false},
+	}
+	for _, c := range cases {
+		if actual := IsMajorVersionUpgrade(c.from, c.to); actual != c.expected {
+			t.Errorf("IsMajorVersionUpgrade(%q, %q) = %v, expected %v", c.from, c.to, actual, c.expected)
+		}
+	}
+}
+
+func TestGetUpgradePath(t *testing.T) {
+	spec := ComponentVersionSpec{}
+	if path, ok := spec.GetUpgradePath("5.7.44", "8.0.33"); path != nil || !ok {
+		t.Error("expect all upgrades are allowed if no upgrade path is declared")
+	}
+
+	spec.UpgradePaths = []ComponentVersionUpgradePath{
+		{From: "~5.7", To: ">=8.0.30, <8.1", PreChecks: []string{"diskSpace"}},
+	}
+	if path, ok := spec.GetUpgradePath("5.7.44", "8.0.33"); path == nil || !ok || path.PreChecks[0] != "diskSpace" {
+		t.Errorf("expect the upgrade path is matched, path: %+v", path)
+	}
+	if path, ok := spec.GetUpgradePath("8.0.30", "8.0.33"); path != nil || !ok {
+		t.Error("expect the upgrades within a major version are allowed")
+	}
+	if _, ok := spec.GetUpgradePath("5.7.44", "8.0.20"); ok {
+		t.Error("expect the upgrade to an unmatched version is not allowed")
+	}
+	if _, ok := spec.GetUpgradePath("5.6.51", "8.0.33"); ok {
+		t.Error("expect the upgrade from an unmatched version is not allowed")
+	}
+}
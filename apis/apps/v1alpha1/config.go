@@ -99,4 +99,10 @@ type ConfigParams struct {
 	//
 	// +optional
 	Parameters map[string]*string `json:"parameters,omitempty"`
+
+	// Represents the updated parameters whose values are referenced from Secrets, for a single configuration file.
+	// Only the references are stored, the values are resolved when the configuration is rendered.
+	//
+	// +optional
+	ParametersFrom map[string]ParameterValueSource `json:"parametersFrom,omitempty"`
 }
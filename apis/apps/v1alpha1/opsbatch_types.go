@@ -0,0 +1,198 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OpsBatchSpec defines the desired state of OpsBatch.
+type OpsBatchSpec struct {
+	// Selects the Clusters to which the operation is applied, within the namespace of the OpsBatch.
+	//
+	// The Clusters are selected once when the OpsBatch starts,
+	// Clusters created or labeled afterward are not included.
+	//
+	// +kubebuilder:validation:Required
+	ClusterSelector metav1.LabelSelector `json:"clusterSelector"`
+
+	// Defines the OpsRequest created for each selected Cluster.
+	// The `clusterName` of the OpsRequest is set to the name of the Cluster, and must be left empty in the template.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="!has(self.clusterName) && !has(self.clusterRef)",message="forbidden to specify the cluster in the ops template"
+	OpsTemplate OpsRequestSpec `json:"opsTemplate"`
+
+	// Specifies how the OpsRequests are rolled out to the selected Clusters in waves.
+	//
+	// +optional
+	WaveStrategy OpsBatchWaveStrategy `json:"waveStrategy,omitempty"`
+}
+
+// OpsBatchWaveStrategy specifies how the OpsRequests of an OpsBatch are rolled out in waves.
+//
+// The selected Clusters are sorted by name and split into waves of `size` Clusters.
+// The OpsRequests of a wave are created at the same time, and the next wave starts `soakSeconds` after
+// all OpsRequests of the current wave have finished.
+type OpsBatchWaveStrategy struct {
+	// Specifies the number of Clusters operated in each wave.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	Size int32 `json:"size,omitempty"`
+
+	// Specifies the number of seconds to wait after a wave has finished before starting the next one,
+	// giving the operated Clusters some time to expose problems.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	SoakSeconds int32 `json:"soakSeconds,omitempty"`
+
+	// Specifies the maximum percentage of the finished OpsRequests that are allowed to fail.
+	// Once it is exceeded, the OpsBatch is aborted and no more waves are started,
+	// the OpsRequests already created are not affected.
+	//
+	// Defaults to 0, which means the OpsBatch is aborted on the first failure.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxFailurePercentage int32 `json:"maxFailurePercentage,omitempty"`
+}
+
+// OpsBatchStatus defines the observed state of OpsBatch.
+type OpsBatchStatus struct {
+	// Represents the current phase of the OpsBatch.
+	//
+	// +optional
+	Phase OpsBatchPhase `json:"phase,omitempty"`
+
+	// Represents the index of the wave in progress, starting from 0.
+	//
+	// +optional
+	CurrentWave int32 `json:"currentWave"`
+
+	// Represents the total number of waves.
+	//
+	// +optional
+	TotalWaves int32 `json:"totalWaves,omitempty"`
+
+	// Represents the progress of the OpsBatch, in the format of "finished/total".
+	//
+	// +optional
+	Progress string `json:"progress,omitempty"`
+
+	// Records the OpsRequests created for the selected Clusters.
+	//
+	// +optional
+	Clusters []OpsBatchClusterStatus `json:"clusters,omitempty"`
+
+	// Provides additional information about the current phase.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Records the time when the OpsBatch started.
+	//
+	// +optional
+	StartTimestamp metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Records the time when the last wave finished.
+	//
+	// +optional
+	LastWaveCompletionTimestamp metav1.Time `json:"lastWaveCompletionTimestamp,omitempty"`
+
+	// Records the time when the OpsBatch finished.
+	//
+	// +optional
+	CompletionTimestamp metav1.Time `json:"completionTimestamp,omitempty"`
+}
+
+// OpsBatchClusterStatus records the OpsRequest created for a Cluster selected by the OpsBatch.
+type OpsBatchClusterStatus struct {
+	// Specifies the name of the Cluster.
+	//
+	// +kubebuilder:validation:Required
+	ClusterName string `json:"clusterName"`
+
+	// Specifies the index of the wave to which the Cluster belongs.
+	//
+	// +kubebuilder:validation:Required
+	Wave int32 `json:"wave"`
+
+	// Specifies the name of the OpsRequest created for the Cluster.
+	//
+	// +optional
+	OpsRequestName string `json:"opsRequestName,omitempty"`
+
+	// Represents the phase of the OpsRequest.
+	//
+	// +optional
+	Phase OpsPhase `json:"phase,omitempty"`
+}
+
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks,all},shortName=opsb
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".spec.opsTemplate.type",description="Operation request type."
+// +kubebuilder:printcolumn:name="WAVE",type="integer",JSONPath=".status.currentWave",description="The wave in progress."
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.phase",description="Operation batch status phase."
+// +kubebuilder:printcolumn:name="PROGRESS",type="string",JSONPath=".status.progress",description="Operation batch processing progress."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// OpsBatch applies an OpsRequest template to the Clusters selected by labels,
+// rolling the OpsRequests out in waves and aggregating their results.
+type OpsBatch struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpsBatchSpec   `json:"spec,omitempty"`
+	Status OpsBatchStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OpsBatchList contains a list of OpsBatch.
+type OpsBatchList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpsBatch `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpsBatch{}, &OpsBatchList{})
+}
+
+// GetWaveSize returns the number of Clusters operated in each wave.
+func (r OpsBatchWaveStrategy) GetWaveSize() int32 {
+	if r.Size < 1 {
+		return 1
+	}
+	return r.Size
+}
+
+// IsTerminalPhase checks if the OpsBatch has finished.
+func (r OpsBatchStatus) IsTerminalPhase() bool {
+	switch r.Phase {
+	case OpsBatchSucceedPhase, OpsBatchFailedPhase, OpsBatchAbortedPhase:
+		return true
+	}
+	return false
+}
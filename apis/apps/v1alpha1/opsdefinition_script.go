@@ -0,0 +1,562 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// the interpreters whose scripts are passed by the `-c` option.
+var shellInterpreters = []string{"sh", "bash", "ash", "dash", "ksh", "zsh"}
+
+// the SQL clients and the options to pass the SQL statements.
+var sqlClientOptions = map[string][]string{
+	"mysql":             {"-e", "--execute"},
+	"mariadb":           {"-e", "--execute"},
+	"psql":              {"-c", "--command"},
+	"clickhouse-client": {"-q", "--query"},
+}
+
+var dollarQuoteRegex = regexp.MustCompile(`^\$[A-Za-z_]*\$`)
+
+// commandScript is a script passed to an interpreter or a SQL client in a command line.
+type commandScript struct {
+	lang   string
+	script string
+}
+
+// extractCommandScripts extracts the shell script or the SQL statements from the command line.
+func extractCommandScripts(argv []string) []commandScript {
+	if len(argv) == 0 {
+		return nil
+	}
+	name := path.Base(argv[0])
+	var scripts []commandScript
+	for i := 1; i < len(argv); i++ {
+		arg := argv[i]
+		switch {
+		case slices.Contains(shellInterpreters, name):
+			// the short options can be combined, e.g. "-ec".
+			if strings.HasPrefix(arg, "-") && !strings.HasPrefix(arg, "--") && strings.Contains(arg, "c") && i+1 < len(argv) {
+				return append(scripts, commandScript{lang: "shell", script: argv[i+1]})
+			}
+		case sqlClientOptions[name] != nil:
+			for _, opt := range sqlClientOptions[name] {
+				switch {
+				case arg == opt && i+1 < len(argv):
+					scripts = append(scripts, commandScript{lang: "SQL", script: argv[i+1]})
+				case strings.HasPrefix(opt, "--") && strings.HasPrefix(arg, opt+"="):
+					scripts = append(scripts, commandScript{lang: "SQL", script: strings.TrimPrefix(arg, opt+"=")})
+				}
+			}
+		}
+	}
+	return scripts
+}
+
+func (s commandScript) check() error {
+	if s.lang == "shell" {
+		return checkShellSyntax(s.script)
+	}
+	return checkSQLSyntax(s.script)
+}
+
+// checkShellSyntax checks the syntax of a shell script statically, the script is never executed.
+// It detects the unterminated quotes, substitutions and here-documents, and the unbalanced compound commands,
+// i.e. if/fi, case/esac, do/done, braces and parentheses.
+func checkShellSyntax(script string) error {
+	l := &shellLexer{src: script, cmdStart: true}
+	if err := l.loop(false); err != nil {
+		return err
+	}
+	if len(l.heredocs) > 0 {
+		return l.errorf("unterminated here-document %s", l.heredocs[0].delimiter)
+	}
+	if len(l.stack) > 0 {
+		return l.errorf("missing '%s'", l.stack[len(l.stack)-1].closer)
+	}
+	return nil
+}
+
+type shellBlock struct {
+	// the reserved word or operator closing the block.
+	closer string
+	// for the case block, whether the keyword `in` is read, and whether the lexer is reading the patterns.
+	seenIn      bool
+	casePattern bool
+}
+
+type heredoc struct {
+	delimiter string
+	stripTabs bool
+}
+
+type shellLexer struct {
+	src  string
+	pos  int
+	line int
+	// the open compound commands.
+	stack []shellBlock
+	// whether the next word is at the position of a command, where the reserved words are recognized.
+	cmdStart bool
+	// the pending here-documents, whose bodies start at the next line.
+	heredocs []heredoc
+}
+
+func (l *shellLexer) errorf(format string, args ...any) error {
+	return fmt.Errorf("line %d: %s", l.line+1, fmt.Sprintf(format, args...))
+}
+
+func (l *shellLexer) top() *shellBlock {
+	if len(l.stack) == 0 {
+		return nil
+	}
+	return &l.stack[len(l.stack)-1]
+}
+
+func (l *shellLexer) push(closer string) {
+	l.stack = append(l.stack, shellBlock{closer: closer})
+}
+
+func (l *shellLexer) pop(closer string) error {
+	if top := l.top(); top == nil || top.closer != closer {
+		return l.errorf("unexpected '%s'", closer)
+	}
+	l.stack = l.stack[:len(l.stack)-1]
+	return nil
+}
+
+func (l *shellLexer) inCasePattern() bool {
+	top := l.top()
+	return top != nil && top.closer == "esac" && top.casePattern
+}
+
+// loop reads the commands, if nested, it returns when the command substitution is closed.
+func (l *shellLexer) loop(nested bool) error {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		rest := l.src[l.pos:]
+		switch {
+		case c == '\n':
+			l.pos++
+			l.line++
+			if err := l.readHeredocs(); err != nil {
+				return err
+			}
+			l.cmdStart = true
+		case c == ' ' || c == '\t' || c == '\r':
+			l.pos++
+		case c == '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.pos++
+			}
+		case strings.HasPrefix(rest, ";;&") || strings.HasPrefix(rest, ";;") || strings.HasPrefix(rest, ";&"):
+			top := l.top()
+			if top == nil || top.closer != "esac" {
+				return l.errorf("unexpected ';;'")
+			}
+			top.casePattern = true
+			if strings.HasPrefix(rest, ";;&") {
+				l.pos += 3
+			} else {
+				l.pos += 2
+			}
+			l.cmdStart = true
+		case c == ';' || c == '&' || c == '|':
+			l.pos++
+			l.cmdStart = true
+		case c == '(':
+			if l.inCasePattern() {
+				l.pos++
+				continue
+			}
+			if strings.HasPrefix(rest, "((") {
+				if err := l.skipArithmetic(); err != nil {
+					return err
+				}
+				continue
+			}
+			l.push(")")
+			l.pos++
+			l.cmdStart = true
+		case c == ')':
+			if l.inCasePattern() {
+				l.top().casePattern = false
+				l.pos++
+				l.cmdStart = true
+				continue
+			}
+			if err := l.pop(")"); err != nil {
+				return err
+			}
+			l.pos++
+			l.cmdStart = true
+			if nested && len(l.stack) == 0 {
+				return nil
+			}
+		case strings.HasPrefix(rest, "<<") && !strings.HasPrefix(rest, "<<<"):
+			if err := l.readHeredocDelimiter(); err != nil {
+				return err
+			}
+		case c == '<' || c == '>':
+			l.pos++
+		default:
+			word, err := l.word()
+			if err != nil {
+				return err
+			}
+			if err = l.reservedWord(word); err != nil {
+				return err
+			}
+		}
+	}
+	if nested {
+		return l.errorf("unterminated command substitution")
+	}
+	return nil
+}
+
+// reservedWord handles the reserved words which open or close the compound commands.
+func (l *shellLexer) reservedWord(word string) error {
+	top := l.top()
+	if l.inCasePattern() {
+		if word == "esac" {
+			l.cmdStart = false
+			return l.pop("esac")
+		}
+		return nil
+	}
+	if !l.cmdStart {
+		if word == "in" && top != nil && top.closer == "esac" && !top.seenIn {
+			top.seenIn = true
+			top.casePattern = true
+		}
+		return nil
+	}
+	l.cmdStart = true
+	switch word {
+	case "if":
+		l.push("fi")
+	case "then", "elif", "else":
+		if top == nil || top.closer != "fi" {
+			return l.errorf("unexpected '%s'", word)
+		}
+	case "fi", "done", "esac", "}":
+		l.cmdStart = false
+		return l.pop(word)
+	case "while", "until":
+		l.push("done")
+	case "for", "select":
+		l.push("done")
+		l.cmdStart = false
+	case "do":
+		if top == nil || top.closer != "done" {
+			return l.errorf("unexpected 'do'")
+		}
+	case "case":
+		l.push("esac")
+		l.cmdStart = false
+	case "{":
+		l.push("}")
+	case "!":
+	default:
+		// the assignments preceding a command
+		if i := strings.Index(word, "="); i <= 0 || strings.ContainsAny(word[:i], `'"$`+"`") {
+			l.cmdStart = false
+		}
+	}
+	return nil
+}
+
+// word reads a word until an unquoted metacharacter, and returns its raw text.
+func (l *shellLexer) word() (string, error) {
+	start := l.pos
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		var err error
+		switch {
+		case strings.IndexByte(" \t\r\n;&|()<>", c) >= 0:
+			return l.src[start:l.pos], nil
+		case c == '\\':
+			if l.pos+1 < len(l.src) && l.src[l.pos+1] == '\n' {
+				l.line++
+			}
+			l.pos += 2
+		case c == '\'':
+			err = l.skipSingleQuote()
+		case c == '"':
+			err = l.skipDoubleQuote()
+		case c == '`':
+			err = l.skipBacktick()
+		case c == '$':
+			err = l.skipDollar()
+		default:
+			l.pos++
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	if l.pos > len(l.src) {
+		l.pos = len(l.src)
+	}
+	return l.src[start:l.pos], nil
+}
+
+func (l *shellLexer) skipSingleQuote() error {
+	end := strings.IndexByte(l.src[l.pos+1:], '\'')
+	if end < 0 {
+		return l.errorf("unterminated single quote")
+	}
+	l.line += strings.Count(l.src[l.pos:l.pos+end+2], "\n")
+	l.pos += end + 2
+	return nil
+}
+
+func (l *shellLexer) skipDoubleQuote() error {
+	l.pos++
+	for l.pos < len(l.src) {
+		var err error
+		switch c := l.src[l.pos]; c {
+		case '"':
+			l.pos++
+			return nil
+		case '\\':
+			l.pos += 2
+		case '`':
+			err = l.skipBacktick()
+		case '$':
+			err = l.skipDollar()
+		case '\n':
+			l.line++
+			l.pos++
+		default:
+			l.pos++
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return l.errorf("unterminated double quote")
+}
+
+func (l *shellLexer) skipBacktick() error {
+	for i := l.pos + 1; i < len(l.src); i++ {
+		switch l.src[i] {
+		case '\\':
+			i++
+		case '\n':
+			l.line++
+		case '`':
+			l.pos = i + 1
+			return nil
+		}
+	}
+	return l.errorf("unterminated backquote")
+}
+
+// skipDollar skips the parameter expansion, the command substitution or the arithmetic expansion.
+func (l *shellLexer) skipDollar() error {
+	rest := l.src[l.pos:]
+	switch {
+	case strings.HasPrefix(rest, "$(("):
+		l.pos++
+		return l.skipArithmetic()
+	case strings.HasPrefix(rest, "$("):
+		l.pos += 2
+		saved, savedCmdStart := l.stack, l.cmdStart
+		l.stack, l.cmdStart = []shellBlock{{closer: ")"}}, true
+		if err := l.loop(true); err != nil {
+			return err
+		}
+		l.stack, l.cmdStart = saved, savedCmdStart
+		return nil
+	case strings.HasPrefix(rest, "${"):
+		l.pos += 2
+		for depth := 1; l.pos < len(l.src); {
+			var err error
+			switch l.src[l.pos] {
+			case '{':
+				depth++
+				l.pos++
+			case '}':
+				depth--
+				l.pos++
+				if depth == 0 {
+					return nil
+				}
+			case '\'':
+				err = l.skipSingleQuote()
+			case '"':
+				err = l.skipDoubleQuote()
+			case '\\':
+				l.pos += 2
+			default:
+				l.pos++
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return l.errorf("unterminated parameter expansion")
+	default:
+		l.pos++
+		return nil
+	}
+}
+
+// skipArithmetic skips the arithmetic expression, starting with "((".
+func (l *shellLexer) skipArithmetic() error {
+	depth := 0
+	for ; l.pos < len(l.src); l.pos++ {
+		switch l.src[l.pos] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				l.pos++
+				return nil
+			}
+		case '\n':
+			l.line++
+		}
+	}
+	return l.errorf("unterminated arithmetic expression")
+}
+
+func (l *shellLexer) readHeredocDelimiter() error {
+	l.pos += 2
+	doc := heredoc{}
+	if l.pos < len(l.src) && l.src[l.pos] == '-' {
+		doc.stripTabs = true
+		l.pos++
+	}
+	for l.pos < len(l.src) && (l.src[l.pos] == ' ' || l.src[l.pos] == '\t') {
+		l.pos++
+	}
+	word, err := l.word()
+	if err != nil {
+		return err
+	}
+	doc.delimiter = strings.NewReplacer(`'`, "", `"`, "", `\`, "").Replace(word)
+	if doc.delimiter == "" {
+		return l.errorf("missing here-document delimiter")
+	}
+	l.heredocs = append(l.heredocs, doc)
+	l.cmdStart = false
+	return nil
+}
+
+// readHeredocs reads the bodies of the pending here-documents, starting at the current line.
+func (l *shellLexer) readHeredocs() error {
+	for len(l.heredocs) > 0 {
+		doc := l.heredocs[0]
+		for {
+			if l.pos >= len(l.src) {
+				return l.errorf("unterminated here-document %s", doc.delimiter)
+			}
+			end := strings.IndexByte(l.src[l.pos:], '\n')
+			if end < 0 {
+				end = len(l.src) - l.pos
+			}
+			line := l.src[l.pos : l.pos+end]
+			l.pos = min(l.pos+end+1, len(l.src))
+			l.line++
+			if doc.stripTabs {
+				line = strings.TrimLeft(line, "\t")
+			}
+			if line == doc.delimiter {
+				break
+			}
+		}
+		l.heredocs = l.heredocs[1:]
+	}
+	return nil
+}
+
+// checkSQLSyntax checks the SQL statements statically, the statements are never executed.
+// It detects the unterminated quotes and comments, and the unbalanced parentheses.
+func checkSQLSyntax(sql string) error {
+	depth := 0
+	for i := 0; i < len(sql); i++ {
+		rest := sql[i:]
+		switch c := sql[i]; {
+		case strings.HasPrefix(rest, "--"):
+			if end := strings.IndexByte(rest, '\n'); end >= 0 {
+				i += end
+			} else {
+				i = len(sql)
+			}
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest[2:], "*/")
+			if end < 0 {
+				return fmt.Errorf("unterminated comment")
+			}
+			i += end + 3
+		case c == '\'' || c == '"' || c == '`':
+			end := closingQuote(rest, c)
+			if end < 0 {
+				return fmt.Errorf("unterminated quote %c", c)
+			}
+			i += end
+		case c == '$' && dollarQuoteRegex.MatchString(rest):
+			tag := dollarQuoteRegex.FindString(rest)
+			end := strings.Index(rest[len(tag):], tag)
+			if end < 0 {
+				return fmt.Errorf("unterminated dollar-quoted string %s", tag)
+			}
+			i += len(tag) + end + len(tag) - 1
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth < 0 {
+				return fmt.Errorf("unexpected ')'")
+			}
+		}
+	}
+	if depth > 0 {
+		return fmt.Errorf("missing ')'")
+	}
+	return nil
+}
+
+// closingQuote returns the index of the quote closing the quoted string at the start of s, the quote is escaped
+// by doubling it or by a backslash, it returns -1 if the string is unterminated.
+func closingQuote(s string, quote byte) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if quote != '`' {
+				i++
+			}
+		case quote:
+			if i+1 < len(s) && s[i+1] == quote {
+				i++
+				continue
+			}
+			return i
+		}
+	}
+	return -1
+}
@@ -269,6 +269,14 @@ type OpsAction struct {
 	// +optional
 	FailurePolicy FailurePolicyType `json:"failurePolicy"`
 
+	// Specifies the maximum duration in seconds of each attempt of the 'workload' or 'exec' action.
+	// The Pod of an attempt that exceeds it is terminated, and the attempt is considered failed.
+	// 0 means no timeout.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
 	// Specifies the parameters for the OpsAction. Their usage varies based on the action type:
 	//
 	// - For 'workload' or 'exec' actions, parameters are injected as environment variables.
@@ -0,0 +1,248 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var opsdefinitionlog = logf.Log.WithName("opsdefinition-resource")
+
+// the name of the fake objects used to dry-run the expressions.
+const dryRunObjectName = "dry-run"
+
+func (r *OpsDefinition) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-apps-kubeblocks-io-v1alpha1-opsdefinition,mutating=false,failurePolicy=fail,sideEffects=None,groups=apps.kubeblocks.io,resources=opsdefinitions,verbs=create;update,versions=v1alpha1,name=vopsdefinition.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &OpsDefinition{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *OpsDefinition) ValidateCreate() (admission.Warnings, error) {
+	opsdefinitionlog.Info("validate create", "name", r.Name)
+	return r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *OpsDefinition) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	opsdefinitionlog.Info("validate update", "name", r.Name)
+	return r.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *OpsDefinition) ValidateDelete() (admission.Warnings, error) {
+	opsdefinitionlog.Info("validate delete", "name", r.Name)
+	return nil, nil
+}
+
+// validate checks the user-provided snippets of the OpsDefinition before they fail at runtime:
+//
+//  1. the Go template expressions are parsed, and the expressions of the preConditions are dry-run with
+//     fake inputs, whose failures are reported as warnings, as the fake inputs may not cover all the cases.
+//  2. the shell scripts and the SQL statements in the commands of the actions are checked statically,
+//     they are never executed by the webhook.
+func (r *OpsDefinition) validate() (admission.Warnings, error) {
+	var (
+		allErrs  field.ErrorList
+		warnings admission.Warnings
+	)
+	specPath := field.NewPath("spec")
+	for i, preCondition := range r.Spec.PreConditions {
+		if preCondition.Rule == nil {
+			continue
+		}
+		fieldPath := specPath.Child("preConditions").Index(i).Child("rule", "expression")
+		tmpl, err := template.New("opsDefTemplate").Parse(preCondition.Rule.Expression)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fieldPath, preCondition.Rule.Expression, err.Error()))
+			continue
+		}
+		if warning := r.dryRunRule(tmpl); warning != "" {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", fieldPath, warning))
+		}
+	}
+	for i, action := range r.Spec.Actions {
+		actionPath := specPath.Child("actions").Index(i)
+		if action.Workload != nil {
+			podSpecPath := actionPath.Child("workload", "podSpec")
+			allErrs = append(allErrs, validateContainerScripts(podSpecPath.Child("initContainers"), action.Workload.PodSpec.InitContainers)...)
+			allErrs = append(allErrs, validateContainerScripts(podSpecPath.Child("containers"), action.Workload.PodSpec.Containers)...)
+		}
+		if action.Exec != nil {
+			allErrs = append(allErrs, validateCommandScripts(actionPath.Child("exec", "command"), action.Exec.Command)...)
+		}
+		if action.ResourceModifier != nil {
+			allErrs = append(allErrs, validateResourceModifierTemplates(actionPath.Child("resourceModifier"), action.ResourceModifier)...)
+		}
+	}
+	if len(allErrs) > 0 {
+		return warnings, apierrors.NewInvalid(
+			schema.GroupKind{
+				Group: "apps.kubeblocks.io/v1alpha1",
+				Kind:  OpsDefinitionKind,
+			},
+			r.Name, allErrs)
+	}
+	return warnings, nil
+}
+
+// dryRunRule executes the expression of the preCondition with a fake Cluster, Component and parameters,
+// it returns a warning if the execution fails or the result is neither `true` nor `false`.
+func (r *OpsDefinition) dryRunRule(tmpl *template.Template) string {
+	data, err := r.dryRunInputs()
+	if err != nil {
+		return fmt.Sprintf("failed to build the fake inputs: %s", err.Error())
+	}
+	var buf strings.Builder
+	if err = tmpl.Execute(&buf, data); err != nil {
+		return fmt.Sprintf("failed to execute with fake inputs: %s", err.Error())
+	}
+	if result := buf.String(); result != "true" && result != "false" {
+		return fmt.Sprintf("evaluates to %q with fake inputs, expected true or false", result)
+	}
+	return ""
+}
+
+// dryRunInputs builds the built-in objects of the expressions in the same way as the OpsRequest,
+// the parameters take their default values, or fake values of their types.
+func (r *OpsDefinition) dryRunInputs() (map[string]any, error) {
+	compDef := ""
+	if len(r.Spec.ComponentInfos) > 0 {
+		compDef = r.Spec.ComponentInfos[0].ComponentDefinitionName
+	}
+	cluster := &Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: dryRunObjectName, Namespace: metav1.NamespaceDefault},
+		Spec: ClusterSpec{
+			ComponentSpecs: []ClusterComponentSpec{{Name: dryRunObjectName, ComponentDef: compDef, Replicas: 1}},
+		},
+		Status: ClusterStatus{
+			Phase:      RunningClusterPhase,
+			Components: map[string]ClusterComponentStatus{dryRunObjectName: {Phase: RunningClusterCompPhase}},
+		},
+	}
+	comp := &Component{
+		ObjectMeta: metav1.ObjectMeta{Name: dryRunObjectName + "-" + dryRunObjectName, Namespace: metav1.NamespaceDefault},
+		Spec:       ComponentSpec{CompDef: compDef, Replicas: 1},
+		Status:     ComponentStatus{Phase: RunningClusterCompPhase},
+	}
+	params := map[string]string{}
+	if r.Spec.ParametersSchema != nil && r.Spec.ParametersSchema.OpenAPIV3Schema != nil {
+		for name, prop := range r.Spec.ParametersSchema.OpenAPIV3Schema.Properties {
+			params[name] = fakeParameterValue(prop)
+		}
+	}
+	b, err := json.Marshal(map[string]any{
+		"cluster":    cluster,
+		"component":  comp,
+		"parameters": params,
+	})
+	if err != nil {
+		return nil, err
+	}
+	data := map[string]any{}
+	if err = json.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func fakeParameterValue(prop apiextensionsv1.JSONSchemaProps) string {
+	value := func(v *apiextensionsv1.JSON) (string, bool) {
+		if v == nil || len(v.Raw) == 0 || string(v.Raw) == "null" {
+			return "", false
+		}
+		var s string
+		if err := json.Unmarshal(v.Raw, &s); err == nil {
+			return s, true
+		}
+		return string(v.Raw), true
+	}
+	if v, ok := value(prop.Default); ok {
+		return v
+	}
+	if len(prop.Enum) > 0 {
+		if v, ok := value(&prop.Enum[0]); ok {
+			return v
+		}
+	}
+	switch prop.Type {
+	case "integer", "number":
+		return "1"
+	case "boolean":
+		return "true"
+	default:
+		return dryRunObjectName
+	}
+}
+
+func validateContainerScripts(fieldPath *field.Path, containers []corev1.Container) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, c := range containers {
+		argv := append(append([]string{}, c.Command...), c.Args...)
+		allErrs = append(allErrs, validateCommandScripts(fieldPath.Index(i).Child("command"), argv)...)
+	}
+	return allErrs
+}
+
+func validateCommandScripts(fieldPath *field.Path, argv []string) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, s := range extractCommandScripts(argv) {
+		if err := s.check(); err != nil {
+			allErrs = append(allErrs, field.Invalid(fieldPath, s.script, fmt.Sprintf("invalid %s syntax: %s", s.lang, err.Error())))
+		}
+	}
+	return allErrs
+}
+
+func validateResourceModifierTemplates(fieldPath *field.Path, modifier *OpsResourceModifierAction) field.ErrorList {
+	var allErrs field.ErrorList
+	parse := func(p *field.Path, text string) {
+		if text == "" {
+			return
+		}
+		if _, err := template.New("opsDefTemplate").Parse(text); err != nil {
+			allErrs = append(allErrs, field.Invalid(p, text, err.Error()))
+		}
+	}
+	for i, patch := range modifier.JSONPatches {
+		parse(fieldPath.Child("jsonPatches").Index(i).Child("value"), patch.Value)
+	}
+	expressionsPath := fieldPath.Child("completionProbe", "matchExpressions")
+	parse(expressionsPath.Child("success"), modifier.CompletionProbe.MatchExpressions.Success)
+	parse(expressionsPath.Child("failure"), modifier.CompletionProbe.MatchExpressions.Failure)
+	return allErrs
+}
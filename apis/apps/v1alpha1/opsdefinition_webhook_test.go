@@ -0,0 +1,112 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckShellSyntax(t *testing.T) {
+	cases := []struct {
+		script string
+		valid  bool
+	}{
+		{script: `echo "hello world"`, valid: true},
+		{script: "if [ -f /tmp/a ]; then\n  cat /tmp/a\nfi", valid: true},
+		{script: "for i in 1 2 3; do echo $i; done", valid: true},
+		{script: "case $1 in\n  a) echo a;;\n  *) echo other;;\nesac", valid: true},
+		{script: "cat <<EOF\nif then\nEOF\necho $(date)", valid: true},
+		{script: `echo "unterminated`, valid: false},
+		{script: "if [ -f /tmp/a ]; then\n  cat /tmp/a\n", valid: false},
+		{script: "echo $(date", valid: false},
+		{script: "done", valid: false},
+	}
+	for _, c := range cases {
+		err := checkShellSyntax(c.script)
+		if c.valid && err != nil {
+			t.Errorf("expected script %q to be valid, got: %v", c.script, err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("expected script %q to be invalid", c.script)
+		}
+	}
+}
+
+func TestCheckSQLSyntax(t *testing.T) {
+	if err := checkSQLSyntax("SELECT 'a;b', \"c\" FROM t WHERE (id IN (1, 2));"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, sql := range []string{"SELECT 'a FROM t", "SELECT * FROM t WHERE (id = 1", "SELECT 1)"} {
+		if err := checkSQLSyntax(sql); err == nil {
+			t.Errorf("expected sql %q to be invalid", sql)
+		}
+	}
+}
+
+func TestExtractCommandScripts(t *testing.T) {
+	scripts := extractCommandScripts([]string{"/bin/sh", "-c", "echo a"})
+	if len(scripts) != 1 || scripts[0].script != "echo a" {
+		t.Fatalf("unexpected shell scripts: %v", scripts)
+	}
+	scripts = extractCommandScripts([]string{"mysql", "-uroot", "-e", "SELECT 1"})
+	if len(scripts) != 1 || scripts[0].script != "SELECT 1" {
+		t.Fatalf("unexpected sql scripts: %v", scripts)
+	}
+	if scripts = extractCommandScripts([]string{"kubectl", "get", "pods"}); len(scripts) != 0 {
+		t.Fatalf("expected no scripts, got: %v", scripts)
+	}
+}
+
+func TestOpsDefinitionValidate(t *testing.T) {
+	opsDef := &OpsDefinition{
+		Spec: OpsDefinitionSpec{
+			PreConditions: []PreCondition{
+				{Rule: &Rule{Expression: `{{ eq .component.status.phase "Running" }}`}},
+			},
+			Actions: []OpsAction{
+				{
+					Name: "exec",
+					Exec: &OpsExecAction{Command: []string{"sh", "-c", "echo ok"}},
+				},
+			},
+		},
+	}
+	warnings, err := opsDef.validate()
+	if err != nil || len(warnings) != 0 {
+		t.Fatalf("expected valid OpsDefinition, got warnings: %v, error: %v", warnings, err)
+	}
+
+	// the expression does not evaluate to a boolean at dry-run, which is only a warning.
+	opsDef.Spec.PreConditions[0].Rule.Expression = `{{ .cluster.metadata.name }}`
+	warnings, err = opsDef.validate()
+	if err != nil || len(warnings) != 1 {
+		t.Fatalf("expected one warning, got warnings: %v, error: %v", warnings, err)
+	}
+
+	opsDef.Spec.PreConditions[0].Rule.Expression = `{{ eq .component.status.phase "Running" `
+	opsDef.Spec.Actions[0].Exec.Command = []string{"sh", "-c", "if true; then echo ok"}
+	_, err = opsDef.validate()
+	if err == nil {
+		t.Fatal("expected invalid OpsDefinition")
+	}
+	for _, path := range []string{"spec.preConditions[0].rule.expression", "spec.actions[0].exec.command"} {
+		if !strings.Contains(err.Error(), path) {
+			t.Errorf("expected error on %s, got: %v", path, err)
+		}
+	}
+}
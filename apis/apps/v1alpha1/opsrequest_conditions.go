@@ -18,9 +18,13 @@ package v1alpha1
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
-	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/conditions"
+	"github.com/apecloud/kubeblocks/pkg/kberrors"
 )
 
 const (
@@ -41,10 +45,21 @@ const (
 	ConditionTypeStart              = "Starting"
 	ConditionTypeVersionUpgrading   = "VersionUpgrading"
 	ConditionTypeExpose             = "Exposing"
+	ConditionTypeEndpointHealthy    = "EndpointHealthy"
 	ConditionTypeDataScript         = "ExecuteDataScript"
 	ConditionTypeBackup             = "Backup"
 	ConditionTypeInstanceRebuilding = "InstancesRebuilding"
 	ConditionTypeCustomOperation    = "CustomOperation"
+	ConditionTypeDebugInstance      = "DebugInstance"
+	ConditionTypeMigrateNodePool    = "MigratingNodePool"
+	ConditionTypeRotateTLS          = "RotatingTLS"
+	ConditionTypePurge              = "Purging"
+	ConditionTypeRotateCredentials  = "RotatingCredentials"
+	ConditionTypeShardRebalance     = "RebalancingShards"
+	ConditionTypeMigrateInstance    = "MigratingInstance"
+	ConditionTypeScheduled          = "Scheduled"
+	ConditionTypeRetrying           = "Retrying"
+	ConditionTypeRolledBack         = "RolledBack"
 
 	// condition and event reasons
 
@@ -56,19 +71,30 @@ const (
 	ReasonReconfigureNoChanged     = "ReconfigureNoChanged"
 	ReasonReconfigureSucceed       = "ReconfigureSucceed"
 	ReasonReconfigureRunning       = "ReconfigureRunning"
+	ReasonReconfigureDeferred      = "ReconfigureDeferred"
 	ReasonClusterPhaseMismatch     = "ClusterPhaseMismatch"
 	ReasonOpsTypeNotSupported      = "OpsTypeNotSupported"
 	ReasonValidateFailed           = "ValidateFailed"
 	ReasonClusterNotFound          = "ClusterNotFound"
+	ReasonClusterTerminating       = "ClusterTerminating"
+	ReasonDryRunSucceed            = "DryRunSucceed"
 	ReasonOpsRequestFailed         = "OpsRequestFailed"
 	ReasonOpsCanceling             = "Canceling"
 	ReasonOpsCancelFailed          = "CancelFailed"
 	ReasonOpsCancelSucceed         = "CancelSucceed"
 	ReasonOpsCancelByController    = "CancelByController"
+	ReasonEndpointHealthy          = "EndpointHealthy"
+	ReasonEndpointUnhealthy        = "EndpointUnhealthy"
+	ReasonClusterFrozen            = "ClusterFrozen"
+	ReasonOutOfMaintenanceWindow   = "OutOfMaintenanceWindow"
+	ReasonNodeConcurrencyLimited   = "NodeConcurrencyLimited"
+	ReasonWaitForMaintenanceWindow = "WaitForMaintenanceWindow"
+	ReasonMaintenanceWindowOpened  = "MaintenanceWindowOpened"
+	ReasonRolledBackOnFailure      = "RolledBackOnFailure"
 )
 
 func (r *OpsRequest) SetStatusCondition(condition metav1.Condition) {
-	meta.SetStatusCondition(&r.Status.Conditions, condition)
+	conditions.Set(&r.Status.Conditions, condition)
 }
 
 // NewWaitForProcessingCondition waits the controller to process the opsRequest.
@@ -83,6 +109,67 @@ func NewWaitForProcessingCondition(ops *OpsRequest) *metav1.Condition {
 	}
 }
 
+// NewClusterFrozenCondition creates a condition that the OpsRequest is deferred by the freeze window of the Cluster.
+func NewClusterFrozenCondition(ops *OpsRequest, windowName string, end time.Time) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeWaitForProgressing,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonClusterFrozen,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf(`the OpsRequest "%s" is deferred by the freeze window "%s" of Cluster: "%s" until %s`,
+			ops.Name, windowName, ops.Spec.GetClusterName(), end.Format(time.RFC3339)),
+	}
+}
+
+// NewOutOfMaintenanceWindowCondition creates a condition that the OpsRequest is deferred to the next maintenance window
+// of the Cluster.
+func NewOutOfMaintenanceWindowCondition(ops *OpsRequest, start, end time.Time) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeWaitForProgressing,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonOutOfMaintenanceWindow,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf(`the OpsRequest "%s" is deferred to the maintenance window of Cluster: "%s" from %s to %s`,
+			ops.Name, ops.Spec.GetClusterName(), start.Format(time.RFC3339), end.Format(time.RFC3339)),
+	}
+}
+
+// NewNodeConcurrencyLimitedCondition creates a condition that the OpsRequest is deferred by the node concurrency policy,
+// as other disruptive OpsRequests are running on the same nodes.
+func NewNodeConcurrencyLimitedCondition(ops *OpsRequest, message string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeWaitForProgressing,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonNodeConcurrencyLimited,
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf(`the OpsRequest "%s" of Cluster: "%s" is deferred: %s`, ops.Name, ops.Spec.GetClusterName(), message),
+	}
+}
+
+// NewWaitForMaintenanceWindowCondition creates a condition that the OpsRequest waits for its maintenance window to open.
+func NewWaitForMaintenanceWindowCondition(ops *OpsRequest, start, end time.Time) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeScheduled,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonWaitForMaintenanceWindow,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf(`the OpsRequest "%s" is scheduled to start in the maintenance window from %s to %s`,
+			ops.Name, start.Format(time.RFC3339), end.Format(time.RFC3339)),
+	}
+}
+
+// NewMaintenanceWindowOpenedCondition creates a condition that the maintenance window of the OpsRequest is open.
+func NewMaintenanceWindowOpenedCondition(ops *OpsRequest, end time.Time) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeScheduled,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonMaintenanceWindowOpened,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf(`the maintenance window of the OpsRequest "%s" is open until %s`,
+			ops.Name, end.Format(time.RFC3339)),
+	}
+}
+
 // NewCancelingCondition the controller is canceling the OpsRequest
 func NewCancelingCondition(ops *OpsRequest) *metav1.Condition {
 	return &metav1.Condition{
@@ -132,6 +219,17 @@ func NewCancelSucceedCondition(opsName string) *metav1.Condition {
 	}
 }
 
+// NewClusterTerminatingCondition creates a condition that the OpsRequest is cancelled as the Cluster is being deleted.
+func NewClusterTerminatingCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeCancelled,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonClusterTerminating,
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf(`OpsRequest "%s" is cancelled as the Cluster "%s" is being deleted`, ops.Name, ops.Spec.GetClusterName()),
+	}
+}
+
 // NewValidatePassedCondition creates a condition for operation validation to pass.
 func NewValidatePassedCondition(opsRequestName string) *metav1.Condition {
 	return &metav1.Condition{
@@ -163,12 +261,36 @@ func NewFailedCondition(ops *OpsRequest, err error) *metav1.Condition {
 	return &metav1.Condition{
 		Type:               ConditionTypeFailed,
 		Status:             metav1.ConditionFalse,
-		Reason:             ReasonOpsRequestFailed,
+		Reason:             kberrors.ReasonOrDefault(err, ReasonOpsRequestFailed),
 		LastTransitionTime: metav1.Now(),
 		Message:            msg,
 	}
 }
 
+// NewRetryingCondition creates a condition that the failed OpsRequest is retried according to its retry policy.
+func NewRetryingCondition(ops *OpsRequest, failedCondition *metav1.Condition) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeRetrying,
+		Status:             metav1.ConditionTrue,
+		Reason:             failedCondition.Reason,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf("Retrying the OpsRequest: %s in Cluster: %s (%d/%d) after failure: %s",
+			ops.Name, ops.Spec.GetClusterName(), ops.Status.RetryCount, ops.Spec.RetryPolicy.MaxRetries, failedCondition.Message),
+	}
+}
+
+// NewRolledBackCondition creates a condition that the changes made by the failed OpsRequest have been rolled back.
+func NewRolledBackCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeRolledBack,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonRolledBackOnFailure,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf("Rolled back the changes of the failed OpsRequest: %s in Cluster: %s",
+			ops.Name, ops.Spec.GetClusterName()),
+	}
+}
+
 // NewSucceedCondition creates a condition that the controller has successfully processed the OpsRequest
 func NewSucceedCondition(ops *OpsRequest) *metav1.Condition {
 	return &metav1.Condition{
@@ -181,6 +303,31 @@ func NewSucceedCondition(ops *OpsRequest) *metav1.Condition {
 	}
 }
 
+// NewDryRunSucceedCondition creates a condition that the changes of the OpsRequest are rendered in the dry-run mode.
+func NewDryRunSucceedCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeSucceed,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonDryRunSucceed,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf("The changes of the OpsRequest: %s in Cluster: %s are rendered in status.dryRunResult without being applied",
+			ops.Name, ops.Spec.GetClusterName()),
+	}
+}
+
+// NewSucceedWithWarningsCondition creates a condition that the OpsRequest processed successfully,
+// but failed on some instances which are tolerated by the failure policy.
+func NewSucceedWithWarningsCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeSucceed,
+		Status:             metav1.ConditionTrue,
+		Reason:             "OpsRequestProcessedWithWarnings",
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf("Processed the OpsRequest: %s in Cluster: %s, but failed on some instances tolerated by the failure policy: %s",
+			ops.Name, ops.Spec.GetClusterName(), ops.Spec.FailurePolicy),
+	}
+}
+
 // NewRestartingCondition creates a condition that the operation starts to restart components
 func NewRestartingCondition(ops *OpsRequest) *metav1.Condition {
 	return &metav1.Condition{
@@ -203,6 +350,88 @@ func NewInstancesRebuildingCondition(ops *OpsRequest) *metav1.Condition {
 	}
 }
 
+// NewDebugInstanceCondition creates a condition that the OpsRequest starts to attach the debug container.
+func NewDebugInstanceCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeDebugInstance,
+		Status:             metav1.ConditionTrue,
+		Reason:             "StartToDebugInstance",
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("Start to attach the debug container to instance: %s", ops.Spec.DebugInstance.InstanceName),
+	}
+}
+
+// NewMigrateNodePoolCondition creates a condition that the OpsRequest starts to migrate the component to another node pool.
+func NewMigrateNodePoolCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeMigrateNodePool,
+		Status:             metav1.ConditionTrue,
+		Reason:             "StartToMigrateNodePool",
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf("Start to migrate the instances of component %s to the nodes selected by %v",
+			ops.Spec.MigrateNodePool.ComponentName, ops.Spec.MigrateNodePool.NodeSelector),
+	}
+}
+
+// NewRotateTLSCondition creates a condition that the OpsRequest starts to rotate the TLS certificates.
+func NewRotateTLSCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeRotateTLS,
+		Status:             metav1.ConditionTrue,
+		Reason:             "StartToRotateTLS",
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("Start to rotate the TLS certificates in Cluster: %s", ops.Spec.GetClusterName()),
+	}
+}
+
+// NewPurgeCondition creates a condition that the OpsRequest starts to purge the logs and temporary files.
+func NewPurgeCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypePurge,
+		Status:             metav1.ConditionTrue,
+		Reason:             "StartToPurge",
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("Start to purge the logs and temporary files in Cluster: %s", ops.Spec.GetClusterName()),
+	}
+}
+
+// NewRotateCredentialsCondition creates a condition that the OpsRequest starts to rotate the passwords of the system accounts.
+func NewRotateCredentialsCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeRotateCredentials,
+		Status:             metav1.ConditionTrue,
+		Reason:             "StartToRotateCredentials",
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("Start to rotate the passwords of the system accounts in Cluster: %s", ops.Spec.GetClusterName()),
+	}
+}
+
+// NewShardRebalanceCondition creates a condition that the OpsRequest starts to rebalance the data across the shards.
+func NewShardRebalanceCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeShardRebalance,
+		Status:             metav1.ConditionTrue,
+		Reason:             "StartToRebalanceShards",
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("Start to rebalance the data across the shards in Cluster: %s", ops.Spec.GetClusterName()),
+	}
+}
+
+// NewMigrateInstanceCondition creates a condition that the OpsRequest starts to move the instance to another node or zone.
+func NewMigrateInstanceCondition(ops *OpsRequest) *metav1.Condition {
+	target := fmt.Sprintf("node %s", ops.Spec.MigrateInstance.TargetNodeName)
+	if ops.Spec.MigrateInstance.TargetZone != "" {
+		target = fmt.Sprintf("zone %s", ops.Spec.MigrateInstance.TargetZone)
+	}
+	return &metav1.Condition{
+		Type:               ConditionTypeMigrateInstance,
+		Status:             metav1.ConditionTrue,
+		Reason:             "StartToMigrateInstance",
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("Start to move instance %s to %s", ops.Spec.MigrateInstance.InstanceName, target),
+	}
+}
+
 // NewSwitchoveringCondition creates a condition that the operation starts to switchover components
 func NewSwitchoveringCondition(generation int64, message string) *metav1.Condition {
 	return &metav1.Condition{
@@ -258,6 +487,26 @@ func NewExposingCondition(ops *OpsRequest) *metav1.Condition {
 	}
 }
 
+// NewEndpointHealthyCondition creates a condition that reports the health of the exposed endpoints.
+func NewEndpointHealthyCondition(ops *OpsRequest, failures []string) *metav1.Condition {
+	if len(failures) > 0 {
+		return &metav1.Condition{
+			Type:               ConditionTypeEndpointHealthy,
+			Status:             metav1.ConditionFalse,
+			Reason:             ReasonEndpointUnhealthy,
+			LastTransitionTime: metav1.Now(),
+			Message:            fmt.Sprintf("The exposed endpoints are unreachable: %s", strings.Join(failures, "; ")),
+		}
+	}
+	return &metav1.Condition{
+		Type:               ConditionTypeEndpointHealthy,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonEndpointHealthy,
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("The exposed endpoints of Cluster: %s are reachable", ops.Spec.GetClusterName()),
+	}
+}
+
 // NewUpgradingCondition creates a condition that the OpsRequest starts to upgrade the cluster version
 func NewUpgradingCondition(ops *OpsRequest) *metav1.Condition {
 	return &metav1.Condition{
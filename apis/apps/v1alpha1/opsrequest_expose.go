@@ -0,0 +1,251 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/kberrors"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// defaultServiceNodePortRange is the default range of the NodePorts allowed by the API server.
+const defaultServiceNodePortRange = "30000-32767"
+
+// exposeValidator validates the services to be exposed against the existing services and the ports declared by the components.
+type exposeValidator struct {
+	ctx     context.Context
+	cli     client.Client
+	cluster *Cluster
+
+	// the node ports allocated by the existing services, keyed by the ports
+	allocatedNodePorts map[int32]string
+	// the services to be exposed, keyed by the names of them
+	exposedServices map[string]string
+	// the node ports of the services to be exposed, keyed by the ports
+	exposedNodePorts map[int32]string
+}
+
+// validateExposeServices validates the services to be enabled, their names and node ports must not conflict with
+// the existing services, and their target ports must be declared by the containers of the components.
+func (r *OpsRequest) validateExposeServices(ctx context.Context, cli client.Client, cluster *Cluster) error {
+	if cli == nil {
+		return nil
+	}
+	v := &exposeValidator{
+		ctx:              ctx,
+		cli:              cli,
+		cluster:          cluster,
+		exposedServices:  map[string]string{},
+		exposedNodePorts: map[int32]string{},
+	}
+	for i, expose := range r.Spec.ExposeList {
+		if expose.Switch != EnableExposeSwitch {
+			continue
+		}
+		containerPorts, err := v.getContainerPorts(expose.ComponentName)
+		if err != nil {
+			return err
+		}
+		for j, opsService := range expose.Services {
+			path := fmt.Sprintf("spec.expose[%d].services[%d]", i, j)
+			serviceName := opsService.Name
+			if len(expose.ComponentName) > 0 {
+				serviceName = fmt.Sprintf("%s-%s", expose.ComponentName, opsService.Name)
+			}
+			// the services exposed already are skipped by the expose operation
+			if v.isExposed(expose.ComponentName, serviceName) {
+				continue
+			}
+			if err = v.checkServiceName(path, expose.ComponentName, serviceName); err != nil {
+				return err
+			}
+			for k, port := range opsService.Ports {
+				portPath := fmt.Sprintf("%s.ports[%d]", path, k)
+				if err = v.checkNodePort(portPath, opsService.ServiceType, port); err != nil {
+					return err
+				}
+				if err = checkTargetPort(portPath, expose.ComponentName, containerPorts, port); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (v *exposeValidator) isExposed(compName, serviceName string) bool {
+	for _, svc := range v.cluster.Spec.Services {
+		if svc.Name == serviceName && svc.ComponentSelector == compName {
+			return true
+		}
+	}
+	return false
+}
+
+// checkServiceName checks that the service to be exposed conflicts with none of the services in the OpsRequest,
+// the services of the Cluster and the Services in the namespace.
+func (v *exposeValidator) checkServiceName(path, compName, serviceName string) error {
+	if conflict, ok := v.exposedServices[serviceName]; ok {
+		return kberrors.New(kberrors.ReasonInvalid, `the service "%s" of %s conflicts with the one of %s`, serviceName, path, conflict)
+	}
+	v.exposedServices[serviceName] = path
+	for _, svc := range v.cluster.Spec.Services {
+		if svc.Name == serviceName || svc.ServiceName == serviceName {
+			return kberrors.New(kberrors.ReasonInvalid, `the service "%s" of %s conflicts with the service "%s" of the cluster`, serviceName, path, svc.Name).
+				WithHint("rename the service to be exposed")
+		}
+	}
+	// the Services of the shardings are generated per shard
+	for _, shardingSpec := range v.cluster.Spec.ShardingSpecs {
+		if shardingSpec.Name == compName {
+			return nil
+		}
+	}
+	svcKey := client.ObjectKey{Namespace: v.cluster.Namespace, Name: constant.GenerateClusterServiceName(v.cluster.Name, serviceName)}
+	if err := v.cli.Get(v.ctx, svcKey, &corev1.Service{}); err == nil {
+		return kberrors.New(kberrors.ReasonInvalid, `the service "%s" of %s conflicts with the existing Service "%s"`, serviceName, path, svcKey.Name).
+			WithHint("rename the service to be exposed")
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// checkNodePort checks that the node port specified is in the range allowed by the API server,
+// and allocated by neither the other ports to be exposed nor the existing Services.
+func (v *exposeValidator) checkNodePort(path string, serviceType corev1.ServiceType, port corev1.ServicePort) error {
+	if port.NodePort == 0 {
+		return nil
+	}
+	if serviceType != corev1.ServiceTypeNodePort && serviceType != corev1.ServiceTypeLoadBalancer {
+		return kberrors.New(kberrors.ReasonInvalid, `the nodePort of %s is only allowed by the services of type NodePort or LoadBalancer`, path)
+	}
+	nodePortRange, err := utilnet.ParsePortRange(viper.GetString(constant.CfgKeyServiceNodePortRange))
+	if err != nil {
+		nodePortRange = utilnet.ParsePortRangeOrDie(defaultServiceNodePortRange)
+	}
+	if !nodePortRange.Contains(int(port.NodePort)) {
+		return kberrors.New(kberrors.ReasonInvalid, `the nodePort %d of %s is out of the range %s allowed`, port.NodePort, path, nodePortRange.String())
+	}
+	if conflict, ok := v.exposedNodePorts[port.NodePort]; ok {
+		return kberrors.New(kberrors.ReasonInvalid, `the nodePort %d of %s conflicts with the one of %s`, port.NodePort, path, conflict)
+	}
+	v.exposedNodePorts[port.NodePort] = path
+	if v.allocatedNodePorts == nil {
+		if err = v.listAllocatedNodePorts(); err != nil {
+			return err
+		}
+	}
+	if svcName, ok := v.allocatedNodePorts[port.NodePort]; ok {
+		return kberrors.New(kberrors.ReasonInvalid, `the nodePort %d of %s is allocated by the Service "%s"`, port.NodePort, path, svcName)
+	}
+	return nil
+}
+
+// listAllocatedNodePorts lists the node ports allocated by the Services, which are unique in the K8s cluster.
+func (v *exposeValidator) listAllocatedNodePorts() error {
+	svcList := &corev1.ServiceList{}
+	if err := v.cli.List(v.ctx, svcList); err != nil {
+		return err
+	}
+	v.allocatedNodePorts = map[int32]string{}
+	for _, svc := range svcList.Items {
+		for _, port := range svc.Spec.Ports {
+			if port.NodePort != 0 {
+				v.allocatedNodePorts[port.NodePort] = svc.Namespace + "/" + svc.Name
+			}
+		}
+	}
+	return nil
+}
+
+// getContainerPorts returns the ports declared by the containers of the ComponentDefinition of the component,
+// it returns nil if the ComponentDefinition is unknown.
+func (v *exposeValidator) getContainerPorts(compName string) ([]corev1.ContainerPort, error) {
+	if len(compName) == 0 {
+		return nil, nil
+	}
+	var compDefName string
+	if compSpec := v.cluster.Spec.GetComponentByName(compName); compSpec != nil {
+		compDefName = compSpec.ComponentDef
+		// the ComponentDefinition referenced by the Cluster may be a prefix, the resolved one is recorded in the Component.
+		comp := &Component{}
+		compKey := client.ObjectKey{Namespace: v.cluster.Namespace, Name: constant.GenerateClusterComponentName(v.cluster.Name, compName)}
+		if err := v.cli.Get(v.ctx, compKey, comp); err == nil && comp.Spec.CompDef != "" {
+			compDefName = comp.Spec.CompDef
+		}
+	}
+	for _, shardingSpec := range v.cluster.Spec.ShardingSpecs {
+		if shardingSpec.Name == compName {
+			compDefName = shardingSpec.Template.ComponentDef
+		}
+	}
+	if compDefName == "" {
+		return nil, nil
+	}
+	compDef, err := getComponentDefByName(v.ctx, v.cli, compDefName)
+	if err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	var containerPorts []corev1.ContainerPort
+	for _, container := range compDef.Spec.Runtime.Containers {
+		containerPorts = append(containerPorts, container.Ports...)
+	}
+	return containerPorts, nil
+}
+
+// checkTargetPort checks that the target port, which defaults to the port, is declared by the containers of
+// the component with the same protocol.
+func checkTargetPort(path, compName string, containerPorts []corev1.ContainerPort, port corev1.ServicePort) error {
+	if len(containerPorts) == 0 {
+		return nil
+	}
+	targetPort := port.TargetPort
+	if targetPort.Type == intstr.Int && targetPort.IntVal == 0 {
+		targetPort = intstr.FromInt32(port.Port)
+	}
+	protocol := port.Protocol
+	if protocol == "" {
+		protocol = corev1.ProtocolTCP
+	}
+	for _, containerPort := range containerPorts {
+		if targetPort.Type == intstr.String && containerPort.Name != targetPort.StrVal ||
+			targetPort.Type == intstr.Int && containerPort.ContainerPort != targetPort.IntVal {
+			continue
+		}
+		declaredProtocol := containerPort.Protocol
+		if declaredProtocol == "" {
+			declaredProtocol = corev1.ProtocolTCP
+		}
+		if declaredProtocol != protocol {
+			return kberrors.New(kberrors.ReasonInvalid, `the protocol %s of %s mismatches the protocol %s of the target port "%s" declared by component "%s"`,
+				protocol, path, declaredProtocol, targetPort.String(), compName)
+		}
+		return nil
+	}
+	return kberrors.New(kberrors.ReasonInvalid, `the target port "%s" of %s is not declared by the containers of component "%s"`, targetPort.String(), path, compName).
+		WithHint("set the targetPort to one of the container ports of the component")
+}
@@ -0,0 +1,164 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestValidateExposeServices(t *testing.T) {
+	compDef := &ComponentDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "mysql-8.0"},
+		Spec: ComponentDefinitionSpec{
+			Runtime: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name: "mysql",
+					Ports: []corev1.ContainerPort{
+						{Name: "mysql", ContainerPort: 3306},
+						{Name: "metrics", ContainerPort: 9104, Protocol: corev1.ProtocolTCP},
+					},
+				}},
+			},
+		},
+	}
+	cluster := &Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mycluster"},
+		Spec: ClusterSpec{
+			ComponentSpecs: []ClusterComponentSpec{{Name: "mysql", ComponentDef: "mysql-8.0"}},
+			Services: []ClusterService{
+				{Service: Service{Name: "mysql-vpc"}, ComponentSelector: "mysql"},
+				{Service: Service{Name: "mysql-admin"}},
+			},
+		},
+	}
+	headlessSvc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mycluster-mysql-headless"}}
+	ingressSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ingress", Name: "ingress"},
+		Spec: corev1.ServiceSpec{
+			Type:  corev1.ServiceTypeNodePort,
+			Ports: []corev1.ServicePort{{Port: 80, NodePort: 30080}},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{AddToScheme, corev1.AddToScheme} {
+		if err := addToScheme(scheme); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(compDef, headlessSvc, ingressSvc).Build()
+
+	newOps := func(services ...OpsService) *OpsRequest {
+		return &OpsRequest{Spec: OpsRequestSpec{
+			Type: ExposeType,
+			ExposeList: []Expose{{
+				ComponentName: "mysql",
+				Switch:        EnableExposeSwitch,
+				Services:      services,
+			}},
+		}}
+	}
+	nodePortService := func(nodePort int32) OpsService {
+		return OpsService{
+			Name:        "internet",
+			ServiceType: corev1.ServiceTypeNodePort,
+			Ports:       []corev1.ServicePort{{Port: 3306, NodePort: nodePort}},
+		}
+	}
+	tests := []struct {
+		name   string
+		ops    *OpsRequest
+		errMsg string
+	}{
+		{
+			name: "valid",
+			ops: newOps(OpsService{Name: "internet", Ports: []corev1.ServicePort{
+				{Port: 3306},
+				{Port: 9104, TargetPort: intstr.FromString("metrics")},
+			}}),
+		},
+		{
+			name: "exposed already",
+			ops:  newOps(OpsService{Name: "vpc", Ports: []corev1.ServicePort{{Port: 1234}}}),
+		},
+		{
+			name:   "duplicated services",
+			ops:    newOps(OpsService{Name: "internet"}, OpsService{Name: "internet"}),
+			errMsg: `the service "mysql-internet" of spec.expose[0].services[1] conflicts with the one of spec.expose[0].services[0]`,
+		},
+		{
+			name:   "conflict with the cluster service",
+			ops:    newOps(OpsService{Name: "admin"}),
+			errMsg: `conflicts with the service "mysql-admin" of the cluster`,
+		},
+		{
+			name:   "conflict with the existing service",
+			ops:    newOps(OpsService{Name: "headless"}),
+			errMsg: `conflicts with the existing Service "mycluster-mysql-headless"`,
+		},
+		{
+			name:   "node port of cluster ip",
+			ops:    newOps(OpsService{Name: "internet", Ports: []corev1.ServicePort{{Port: 3306, NodePort: 30306}}}),
+			errMsg: "only allowed by the services of type NodePort or LoadBalancer",
+		},
+		{
+			name: "valid node port",
+			ops:  newOps(nodePortService(30306)),
+		},
+		{
+			name:   "node port out of range",
+			ops:    newOps(nodePortService(3306)),
+			errMsg: "is out of the range 30000-32767 allowed",
+		},
+		{
+			name:   "allocated node port",
+			ops:    newOps(nodePortService(30080)),
+			errMsg: `is allocated by the Service "ingress/ingress"`,
+		},
+		{
+			name: "protocol mismatch",
+			ops: newOps(OpsService{Name: "internet", Ports: []corev1.ServicePort{
+				{Port: 9104, Protocol: corev1.ProtocolUDP, TargetPort: intstr.FromString("metrics")},
+			}}),
+			errMsg: "mismatches the protocol TCP of the target port",
+		},
+		{
+			name:   "undeclared target port",
+			ops:    newOps(OpsService{Name: "internet", Ports: []corev1.ServicePort{{Port: 3306, TargetPort: intstr.FromInt32(8080)}}}),
+			errMsg: `the target port "8080" of spec.expose[0].services[0].ports[0] is not declared`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ops.validateExposeServices(context.Background(), cli, cluster)
+			if tt.errMsg == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.errMsg != "" && (err == nil || !strings.Contains(err.Error(), tt.errMsg)) {
+				t.Errorf("expect error containing %q, but got: %v", tt.errMsg, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/exp/slices"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+var opsImpactLevels = map[OpsImpact]int{
+	OpsImpactOnline:             1,
+	OpsImpactBriefInterruptions: 2,
+	OpsImpactDowntime:           3,
+}
+
+// opsImpactResult keeps the most severe impact among the Components, and the message explaining it.
+type opsImpactResult struct {
+	impact  OpsImpact
+	message string
+}
+
+func (res *opsImpactResult) merge(impact OpsImpact, message string) {
+	if opsImpactLevels[impact] > opsImpactLevels[res.impact] {
+		res.impact = impact
+		res.message = message
+	}
+}
+
+// ClassifyImpact classifies the expected impact of the OpsRequest on the availability of the Cluster,
+// and returns the message explaining it.
+// An empty impact is returned if it can not be determined, such as for the Custom OpsRequest.
+// The client is optional, the definition metadata is not taken into account without it.
+func (r *OpsRequest) ClassifyImpact(ctx context.Context, cli client.Client, cluster *Cluster) (OpsImpact, string) {
+	if cluster == nil {
+		return "", ""
+	}
+	res := &opsImpactResult{}
+	rolling := func(compNames ...string) {
+		for _, compName := range compNames {
+			res.merge(r.classifyRollingImpact(ctx, cli, cluster, compName))
+		}
+	}
+	switch r.Spec.Type {
+	case StopType:
+		if len(r.Spec.StopList) == 0 {
+			res.merge(OpsImpactDowntime, "all the instances of the Cluster are stopped")
+		}
+		for _, v := range r.Spec.StopList {
+			res.merge(OpsImpactDowntime, fmt.Sprintf(`all the instances of component "%s" are stopped`, v.ComponentName))
+		}
+	case SwitchoverType:
+		res.merge(OpsImpactBriefInterruptions, "the connections to the switched instances are interrupted briefly")
+	case RestartType:
+		for _, v := range r.Spec.RestartList {
+			rolling(v.ComponentName)
+		}
+	case VerticalScalingType:
+		for _, v := range r.Spec.VerticalScalingList {
+			rolling(v.ComponentName)
+		}
+	case UpgradeType:
+		rolling(r.upgradeComponentNames(cluster)...)
+	case RebuildInstanceType:
+		for _, v := range r.Spec.RebuildFrom {
+			rolling(v.ComponentName)
+		}
+	case MigrateNodePoolType:
+		if r.Spec.MigrateNodePool != nil {
+			rolling(r.Spec.MigrateNodePool.ComponentName)
+		}
+	case MigrateInstanceType:
+		if r.Spec.MigrateInstance != nil {
+			compName := r.Spec.MigrateInstance.ComponentName
+			if getImpactComponentReplicas(cluster, compName) <= 1 {
+				res.merge(OpsImpactDowntime, fmt.Sprintf(`component "%s" has 1 replica, which stops serving while it is moved`, compName))
+			} else {
+				res.merge(OpsImpactBriefInterruptions, fmt.Sprintf(`instance "%s" stops serving while it is moved`, r.Spec.MigrateInstance.InstanceName))
+			}
+		}
+	case RotateTLSType:
+		for _, v := range r.Spec.RotateTLSList {
+			compDef := getImpactComponentDefinition(ctx, cli, cluster, v.ComponentName)
+			if compDef != nil && compDef.Spec.LifecycleActions != nil && compDef.Spec.LifecycleActions.ReloadTLS != nil {
+				res.merge(OpsImpactOnline, fmt.Sprintf(`the certificates are reloaded into the instances of component "%s" online`, v.ComponentName))
+				continue
+			}
+			rolling(v.ComponentName)
+		}
+	case RotateCredentialsType:
+		for _, v := range r.Spec.RotateCredentialsList {
+			res.merge(OpsImpactOnline, fmt.Sprintf(`the passwords of the accounts of component "%s" are changed online, `+
+				"the new connections must use the new passwords", v.ComponentName))
+		}
+	case ShardRebalanceType:
+		for _, v := range r.Spec.ShardRebalanceList {
+			res.merge(OpsImpactOnline, fmt.Sprintf(`the data of sharding "%s" is moved between the shards online, `+
+				"which increases the load of the shards", v.ComponentName))
+		}
+	case ReconfiguringType:
+		reconfigures := slices.Clone(r.Spec.Reconfigures)
+		if r.Spec.Reconfigure != nil {
+			reconfigures = append(reconfigures, *r.Spec.Reconfigure)
+		}
+		for _, reconfigure := range reconfigures {
+			if r.isDynamicReconfigure(ctx, cli, reconfigure) {
+				res.merge(OpsImpactOnline, fmt.Sprintf(`the parameters of component "%s" are dynamic, which are reloaded online`, reconfigure.ComponentName))
+				continue
+			}
+			rolling(reconfigure.ComponentName)
+		}
+	case CustomType:
+		return "", ""
+	default:
+		res.merge(OpsImpactOnline, fmt.Sprintf("the %s operation does not restart the instances", r.Spec.Type))
+	}
+	return res.impact, res.message
+}
+
+// classifyRollingImpact classifies the impact of restarting the replicas of a Component one by one.
+func (r *OpsRequest) classifyRollingImpact(ctx context.Context, cli client.Client, cluster *Cluster, compName string) (OpsImpact, string) {
+	replicas := getImpactComponentReplicas(cluster, compName)
+	if replicas <= 1 {
+		return OpsImpactDowntime, fmt.Sprintf(`component "%s" has %d replica, which stops serving while it is restarted`, compName, replicas)
+	}
+	compDef := getImpactComponentDefinition(ctx, cli, cluster, compName)
+	if compDef != nil && len(compDef.Spec.Roles) > 0 {
+		return OpsImpactBriefInterruptions, fmt.Sprintf(`the replicas of component "%s" are restarted one by one, and the roles are switched over`, compName)
+	}
+	return OpsImpactBriefInterruptions, fmt.Sprintf(`the replicas of component "%s" are restarted one by one`, compName)
+}
+
+// isDynamicReconfigure checks whether all the parameters updated are dynamic, which can be reloaded without restarting.
+func (r *OpsRequest) isDynamicReconfigure(ctx context.Context, cli client.Client, reconfigure Reconfigure) bool {
+	if cli == nil {
+		return false
+	}
+	for _, configuration := range reconfigure.Configurations {
+		cmObj, err := r.getConfigMap(ctx, cli, fmt.Sprintf("%s-%s-%s", r.Spec.GetClusterName(), reconfigure.ComponentName, configuration.Name))
+		if err != nil {
+			return false
+		}
+		ccName := cmObj.Labels[constant.CMConfigurationConstraintsNameLabelKey]
+		if ccName == "" {
+			return false
+		}
+		cc := &appsv1beta1.ConfigConstraint{}
+		if err = cli.Get(ctx, client.ObjectKey{Name: ccName}, cc); err != nil || cc.Spec.ReloadAction == nil {
+			return false
+		}
+		for _, key := range configuration.Keys {
+			if key.FileContent != "" {
+				return false
+			}
+			for _, param := range key.Parameters {
+				if !isDynamicParameter(param.Key, &cc.Spec) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+func (r *OpsRequest) upgradeComponentNames(cluster *Cluster) []string {
+	var compNames []string
+	if r.Spec.Upgrade == nil {
+		return nil
+	}
+	if r.Spec.Upgrade.ClusterVersionRef != nil && *r.Spec.Upgrade.ClusterVersionRef != "" {
+		for _, compSpec := range cluster.Spec.ComponentSpecs {
+			compNames = append(compNames, compSpec.Name)
+		}
+		return compNames
+	}
+	for _, v := range r.Spec.Upgrade.Components {
+		compNames = append(compNames, v.ComponentName)
+	}
+	return compNames
+}
+
+func isDynamicParameter(paramName string, cc *appsv1beta1.ConfigConstraintSpec) bool {
+	if len(cc.DynamicParameters) != 0 {
+		return slices.Contains(cc.DynamicParameters, paramName)
+	}
+	if len(cc.StaticParameters) != 0 {
+		return !slices.Contains(cc.StaticParameters, paramName)
+	}
+	return false
+}
+
+func getImpactComponentReplicas(cluster *Cluster, compName string) int32 {
+	if compSpec := cluster.Spec.GetComponentByName(compName); compSpec != nil {
+		return compSpec.Replicas
+	}
+	if shardingSpec := cluster.Spec.GetShardingByName(compName); shardingSpec != nil {
+		return shardingSpec.Template.Replicas
+	}
+	return 0
+}
+
+func getImpactComponentDefinition(ctx context.Context, cli client.Client, cluster *Cluster, compName string) *ComponentDefinition {
+	if cli == nil {
+		return nil
+	}
+	var compDefName string
+	if compSpec := cluster.Spec.GetComponentByName(compName); compSpec != nil {
+		compDefName = compSpec.ComponentDef
+	} else if shardingSpec := cluster.Spec.GetShardingByName(compName); shardingSpec != nil {
+		compDefName = shardingSpec.Template.ComponentDef
+	}
+	if compDefName == "" {
+		return nil
+	}
+	compDef := &ComponentDefinition{}
+	if err := cli.Get(ctx, client.ObjectKey{Name: compDefName}, compDef); err != nil {
+		return nil
+	}
+	return compDef
+}
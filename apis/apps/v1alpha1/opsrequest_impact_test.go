@@ -0,0 +1,69 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+)
+
+func TestClassifyImpact(t *testing.T) {
+	cluster := &Cluster{
+		Spec: ClusterSpec{
+			ComponentSpecs: []ClusterComponentSpec{
+				{Name: "mysql", Replicas: 3},
+				{Name: "proxy", Replicas: 1},
+			},
+		},
+	}
+	newOps := func(opsType OpsType, compNames ...string) *OpsRequest {
+		ops := &OpsRequest{}
+		ops.Spec.Type = opsType
+		for _, compName := range compNames {
+			ops.Spec.RestartList = append(ops.Spec.RestartList, ComponentOps{ComponentName: compName})
+		}
+		return ops
+	}
+
+	tests := []struct {
+		name   string
+		ops    *OpsRequest
+		impact OpsImpact
+	}{
+		{"restart multiple replicas", newOps(RestartType, "mysql"), OpsImpactBriefInterruptions},
+		{"restart single replica", newOps(RestartType, "mysql", "proxy"), OpsImpactDowntime},
+		{"stop", newOps(StopType), OpsImpactDowntime},
+		{"switchover", newOps(SwitchoverType), OpsImpactBriefInterruptions},
+		{"volume expansion", newOps(VolumeExpansionType), OpsImpactOnline},
+		{"reconfigure without definition metadata", &OpsRequest{Spec: OpsRequestSpec{
+			Type:         ReconfiguringType,
+			Reconfigures: []Reconfigure{{ComponentOps: ComponentOps{ComponentName: "mysql"}}},
+		}}, OpsImpactBriefInterruptions},
+		{"custom", newOps(CustomType), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			impact, message := tt.ops.ClassifyImpact(context.Background(), nil, cluster)
+			if impact != tt.impact {
+				t.Errorf("expect impact %s, but got %s: %s", tt.impact, impact, message)
+			}
+			if impact != "" && message == "" {
+				t.Error("expect the impact is explained")
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOpsRequestLint(t *testing.T) {
+	cluster := &Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "mycluster", Namespace: "default"},
+		Spec: ClusterSpec{
+			ComponentSpecs: []ClusterComponentSpec{{
+				Name:                 "mysql",
+				VolumeClaimTemplates: []ClusterComponentVolumeClaimTemplate{{Name: "data"}},
+				Instances:            []InstanceTemplate{{Name: "large"}},
+			}},
+			ShardingSpecs: []ShardingSpec{{
+				Name:     "shard",
+				Template: ClusterComponentSpec{Name: "shard"},
+			}},
+		},
+	}
+	newOps := func(opsType OpsType, setSpec func(spec *OpsRequestSpec)) *OpsRequest {
+		ops := &OpsRequest{Spec: OpsRequestSpec{ClusterName: cluster.Name, Type: opsType}}
+		setSpec(&ops.Spec)
+		return ops
+	}
+	cases := []struct {
+		name string
+		ops  *OpsRequest
+		err  string
+	}{
+		{
+			name: "valid vertical scaling",
+			ops: newOps(VerticalScalingType, func(spec *OpsRequestSpec) {
+				spec.VerticalScalingList = []VerticalScaling{{
+					ComponentOps: ComponentOps{ComponentName: "shard"},
+					ResourceRequirements: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+					},
+				}}
+			}),
+		},
+		{
+			name: "vertical scaling with invalid resources",
+			ops: newOps(VerticalScalingType, func(spec *OpsRequestSpec) {
+				spec.VerticalScalingList = []VerticalScaling{{
+					ComponentOps: ComponentOps{ComponentName: "mysql"},
+					ResourceRequirements: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+					},
+				}}
+			}),
+			err: `spec.verticalScaling[0].limits[storage]: Unsupported value: "storage"`,
+		},
+		{
+			name: "volume expansion of an unknown volumeClaimTemplate",
+			ops: newOps(VolumeExpansionType, func(spec *OpsRequestSpec) {
+				spec.VolumeExpansionList = []VolumeExpansion{{
+					ComponentOps:         ComponentOps{ComponentName: "mysql"},
+					VolumeClaimTemplates: []OpsRequestVolumeClaimTemplate{{Name: "log", Storage: resource.MustParse("1Gi")}},
+				}}
+			}),
+			err: `spec.volumeExpansion[0].volumeClaimTemplates[0].name: Not found: "log"`,
+		},
+		{
+			name: "restart of an unknown component",
+			ops: newOps(RestartType, func(spec *OpsRequestSpec) {
+				spec.RestartList = []ComponentOps{{ComponentName: "redis"}}
+			}),
+			err: `spec.restart[0].componentName: Not found: "redis"`,
+		},
+		{
+			name: "empty horizontal scaling",
+			ops:  newOps(HorizontalScalingType, func(spec *OpsRequestSpec) {}),
+			err:  "spec.horizontalScaling: Required value",
+		},
+		{
+			name: "custom ops of an unknown component",
+			ops: newOps(CustomType, func(spec *OpsRequestSpec) {
+				spec.CustomOps = &CustomOps{CustomOpsComponents: []CustomOpsComponent{
+					{ComponentOps: ComponentOps{ComponentName: "shard"}},
+					{ComponentOps: ComponentOps{ComponentName: "redis"}},
+				}}
+			}),
+			err: `spec.custom.components[1].componentName: Not found: "redis"`,
+		},
+	}
+	for _, c := range cases {
+		err := c.ops.Lint(cluster)
+		switch {
+		case c.err == "" && err != nil:
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+		case c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)):
+			t.Errorf("%s: expected error containing %q, got %v", c.name, c.err, err)
+		}
+	}
+}
+
+func TestLintOpsRequestSpec(t *testing.T) {
+	cluster := &Cluster{
+		Spec: ClusterSpec{
+			ComponentSpecs: []ClusterComponentSpec{{
+				Name:                 "mysql",
+				VolumeClaimTemplates: []ClusterComponentVolumeClaimTemplate{{Name: "data"}},
+				Instances: []InstanceTemplate{{
+					Name:                 "large",
+					VolumeClaimTemplates: []ClusterComponentVolumeClaimTemplate{{Name: "data"}},
+				}},
+			}},
+		},
+	}
+	spec := OpsRequestSpec{
+		Type: VolumeExpansionType,
+		VolumeExpansionList: []VolumeExpansion{
+			{
+				ComponentOps:         ComponentOps{ComponentName: "mysql"},
+				VolumeClaimTemplates: []OpsRequestVolumeClaimTemplate{{Name: "data"}, {Name: "log"}},
+				Instances: []InstanceVolumeClaimTemplate{
+					{Name: "large", VolumeClaimTemplates: []OpsRequestVolumeClaimTemplate{{Name: "wal"}}},
+					{Name: "small"},
+				},
+			},
+			{ComponentOps: ComponentOps{ComponentName: "redis"}},
+		},
+	}
+	var fields []string
+	for _, err := range LintOpsRequestSpec(cluster, spec) {
+		fields = append(fields, err.Field)
+	}
+	expected := []string{
+		"spec.volumeExpansion[0].volumeClaimTemplates[1].name",
+		"spec.volumeExpansion[0].instances[0].volumeClaimTemplates[0].name",
+		"spec.volumeExpansion[0].instances[1].name",
+		"spec.volumeExpansion[1].componentName",
+	}
+	if strings.Join(fields, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected the errors of the fields %v, got %v", expected, fields)
+	}
+}
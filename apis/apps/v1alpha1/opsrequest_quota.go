@@ -0,0 +1,212 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/apecloud/kubeblocks/pkg/kberrors"
+)
+
+// computeQuotaResourceNames maps the compute resources of the containers to the names of them in the ResourceQuotas.
+var computeQuotaResourceNames = map[corev1.ResourceName][]corev1.ResourceName{
+	corev1.ResourceCPU:    {corev1.ResourceCPU, corev1.ResourceRequestsCPU},
+	corev1.ResourceMemory: {corev1.ResourceMemory, corev1.ResourceRequestsMemory},
+}
+
+var computeQuotaLimitNames = map[corev1.ResourceName]corev1.ResourceName{
+	corev1.ResourceCPU:    corev1.ResourceLimitsCPU,
+	corev1.ResourceMemory: corev1.ResourceLimitsMemory,
+}
+
+// validateComputeQuota rejects the scaling which requires more CPU or memory than the ResourceQuotas of the namespace allow,
+// as the recreated or new pods would be stuck in the middle of the operation. It's bypassed if the OpsRequest is forced.
+func (r *OpsRequest) validateComputeQuota(ctx context.Context, cli client.Client, cluster *Cluster) error {
+	if r.Spec.Force {
+		return nil
+	}
+	if message := r.exceededComputeQuota(ctx, cli, cluster); message != "" {
+		return kberrors.New(kberrors.ReasonQuotaExceeded, "%s", message).
+			WithHint(`increase the limits of the ResourceQuota, or set "spec.force" to bypass the check if the resources will be released in time`)
+	}
+	return nil
+}
+
+// computeQuotaWarnings warns about the forced scaling which requires more CPU or memory than the ResourceQuotas allow.
+func (r *OpsRequest) computeQuotaWarnings(ctx context.Context, cli client.Client, cluster *Cluster) admission.Warnings {
+	if !r.Spec.Force {
+		return nil
+	}
+	if message := r.exceededComputeQuota(ctx, cli, cluster); message != "" {
+		return admission.Warnings{message + ", the pods may stay pending until the resources are released"}
+	}
+	return nil
+}
+
+// exceededComputeQuota returns the message describing the ResourceQuota exceeded by the CPU and memory
+// requested additionally by the scaling, or an empty string if no quota is exceeded.
+// Only the quotas without scopes are taken into account, as the scopes are not evaluated against the pods to be created.
+func (r *OpsRequest) exceededComputeQuota(ctx context.Context, cli client.Client, cluster *Cluster) string {
+	if cli == nil || cluster == nil || (r.Status.Phase != "" && r.Status.Phase != OpsPendingPhase) {
+		return ""
+	}
+	var delta corev1.ResourceList
+	switch r.Spec.Type {
+	case VerticalScalingType:
+		delta = r.verticalScalingResourceDelta(ctx, cli, cluster)
+	case HorizontalScalingType:
+		delta = r.horizontalScalingResourceDelta(cluster)
+	}
+	if len(delta) == 0 {
+		return ""
+	}
+	quotaList := &corev1.ResourceQuotaList{}
+	if err := cli.List(ctx, quotaList, client.InNamespace(cluster.Namespace)); err != nil {
+		return ""
+	}
+	for _, quota := range quotaList.Items {
+		if len(quota.Spec.Scopes) > 0 || quota.Spec.ScopeSelector != nil {
+			continue
+		}
+		for _, name := range sets.List(sets.KeySet(delta)) {
+			increase := delta[name]
+			hard, ok := quota.Status.Hard[name]
+			if !ok || increase.Sign() <= 0 {
+				continue
+			}
+			used := quota.Status.Used[name]
+			requested := used.DeepCopy()
+			requested.Add(increase)
+			if requested.Cmp(hard) > 0 {
+				return fmt.Sprintf(`the %s requires %s more of "%s", which exceeds the limit %s of ResourceQuota "%s" with %s used`,
+					r.Spec.Type, increase.String(), name, hard.String(), quota.Name, used.String())
+			}
+		}
+	}
+	return ""
+}
+
+// verticalScalingResourceDelta returns the compute resources requested additionally by the vertical scaling,
+// keyed by the names of them in the ResourceQuotas.
+func (r *OpsRequest) verticalScalingResourceDelta(ctx context.Context, cli client.Client, cluster *Cluster) corev1.ResourceList {
+	delta := corev1.ResourceList{}
+	for _, verticalScaling := range r.Spec.VerticalScalingList {
+		compSpec, shards := getScalingComponentSpec(cluster, verticalScaling.ComponentName)
+		if compSpec == nil {
+			continue
+		}
+		resources := verticalScaling.ResourceRequirements
+		if verticalScaling.ClassRef != nil {
+			class, err := ResolveComponentClass(ctx, cli, *verticalScaling.ClassRef, compSpec.ComponentDef)
+			if err != nil {
+				continue
+			}
+			resources = class.ToResourceRequirements()
+		}
+		insResources := map[string]corev1.ResourceRequirements{}
+		for _, ins := range verticalScaling.Instances {
+			insResources[ins.Name] = ins.ResourceRequirements
+		}
+		// the instances of the templates having their own resources are not scaled by the component-level resources.
+		compReplicas := compSpec.Replicas
+		for _, template := range compSpec.Instances {
+			current := compSpec.Resources
+			if template.Resources != nil {
+				current = *template.Resources
+			}
+			desired, ok := insResources[template.Name]
+			if template.Resources != nil || ok {
+				compReplicas -= template.GetReplicas()
+			}
+			if ok {
+				addComputeQuotaUsage(delta, desired, template.GetReplicas()*shards)
+				addComputeQuotaUsage(delta, current, -template.GetReplicas()*shards)
+			}
+		}
+		if len(resources.Requests) > 0 || len(resources.Limits) > 0 {
+			addComputeQuotaUsage(delta, resources, compReplicas*shards)
+			addComputeQuotaUsage(delta, compSpec.Resources, -compReplicas*shards)
+		}
+	}
+	return delta
+}
+
+// horizontalScalingResourceDelta returns the compute resources and pods requested additionally by the horizontal scaling,
+// keyed by the names of them in the ResourceQuotas. The new instances are assumed to request the resources of the component.
+func (r *OpsRequest) horizontalScalingResourceDelta(cluster *Cluster) corev1.ResourceList {
+	delta := corev1.ResourceList{}
+	for _, hScale := range r.Spec.HorizontalScalingList {
+		compSpec, shards := getScalingComponentSpec(cluster, hScale.ComponentName)
+		if compSpec == nil {
+			continue
+		}
+		scaleOutReplicas := -getScaleInReplicas(hScale, *compSpec) * shards
+		if scaleOutReplicas <= 0 {
+			continue
+		}
+		addComputeQuotaUsage(delta, compSpec.Resources, scaleOutReplicas)
+		addQuantity(delta, corev1.ResourcePods, *resource.NewQuantity(int64(scaleOutReplicas), resource.DecimalSI))
+	}
+	return delta
+}
+
+// getScalingComponentSpec returns the spec of the component or sharding, and the number of the shards.
+func getScalingComponentSpec(cluster *Cluster, compName string) (*ClusterComponentSpec, int32) {
+	if compSpec := cluster.Spec.GetComponentByName(compName); compSpec != nil {
+		return compSpec, 1
+	}
+	if shardingSpec := cluster.Spec.GetShardingByName(compName); shardingSpec != nil {
+		return &shardingSpec.Template, shardingSpec.Shards
+	}
+	return nil, 0
+}
+
+// addComputeQuotaUsage adds the CPU and memory of the replicas with the resources to the quota usage,
+// the requests default to the limits if not specified.
+func addComputeQuotaUsage(usage corev1.ResourceList, resources corev1.ResourceRequirements, replicas int32) {
+	for resourceName, quotaNames := range computeQuotaResourceNames {
+		request, ok := resources.Requests[resourceName]
+		if !ok {
+			request, ok = resources.Limits[resourceName]
+		}
+		if ok {
+			for _, quotaName := range quotaNames {
+				addQuantity(usage, quotaName, multiplyQuantity(request, replicas))
+			}
+		}
+		if limit, ok := resources.Limits[resourceName]; ok {
+			addQuantity(usage, computeQuotaLimitNames[resourceName], multiplyQuantity(limit, replicas))
+		}
+	}
+}
+
+func addQuantity(list corev1.ResourceList, name corev1.ResourceName, quantity resource.Quantity) {
+	total := list[name]
+	total.Add(quantity)
+	list[name] = total
+}
+
+func multiplyQuantity(quantity resource.Quantity, n int32) resource.Quantity {
+	return *resource.NewMilliQuantity(quantity.MilliValue()*int64(n), quantity.Format)
+}
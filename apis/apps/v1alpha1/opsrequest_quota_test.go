@@ -0,0 +1,128 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestComputeQuota(t *testing.T) {
+	resources := func(cpu, memory string) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu), corev1.ResourceMemory: resource.MustParse(memory)},
+		}
+	}
+	cluster := &Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mycluster"},
+		Spec: ClusterSpec{
+			ComponentSpecs: []ClusterComponentSpec{{
+				Name:      "mysql",
+				Replicas:  3,
+				Resources: resources("1", "2Gi"),
+				Instances: []InstanceTemplate{{Name: "large", Replicas: pointer.Int32(1), Resources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("4Gi")},
+				}}},
+			}},
+			ShardingSpecs: []ShardingSpec{{
+				Name:     "shard",
+				Shards:   2,
+				Template: ClusterComponentSpec{Replicas: 2, Resources: resources("1", "2Gi")},
+			}},
+		},
+	}
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "compute"},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("12"), corev1.ResourcePods: resource.MustParse("10")},
+			Used: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("8"), corev1.ResourcePods: resource.MustParse("7")},
+		},
+	}
+	scopedQuota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "best-effort"},
+		Spec:       corev1.ResourceQuotaSpec{Scopes: []corev1.ResourceQuotaScope{corev1.ResourceQuotaScopeBestEffort}},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("0")},
+		},
+	}
+	cli := fake.NewClientBuilder().WithObjects(quota, scopedQuota).Build()
+
+	verticalScaling := func(compName, cpu string, instances ...InstanceResourceTemplate) *OpsRequest {
+		vs := VerticalScaling{ComponentOps: ComponentOps{ComponentName: compName}, Instances: instances}
+		if cpu != "" {
+			vs.ResourceRequirements = resources(cpu, "2Gi")
+		}
+		return &OpsRequest{Spec: OpsRequestSpec{Type: VerticalScalingType, VerticalScalingList: []VerticalScaling{vs}}}
+	}
+	horizontalScaling := func(compName string, replicas int32) *OpsRequest {
+		return &OpsRequest{Spec: OpsRequestSpec{
+			Type:                  HorizontalScalingType,
+			HorizontalScalingList: []HorizontalScaling{{ComponentOps: ComponentOps{ComponentName: compName}, Replicas: pointer.Int32(replicas)}},
+		}}
+	}
+
+	tests := []struct {
+		name    string
+		ops     *OpsRequest
+		message string
+	}{
+		{"scale up within quota", verticalScaling("mysql", "3"), ""},
+		{"scale up exceeding quota", verticalScaling("mysql", "4"), `requires 6 more of "requests.cpu"`},
+		{"scale down", verticalScaling("mysql", "500m"), ""},
+		{"scale up instance template", verticalScaling("mysql", "", InstanceResourceTemplate{Name: "large", ResourceRequirements: resources("7", "4Gi")}), `requires 5 more of "requests.cpu"`},
+		{"scale up sharding", verticalScaling("shard", "2"), ""},
+		{"scale out within quota", horizontalScaling("mysql", 6), ""},
+		{"scale out exceeding pods quota", horizontalScaling("mysql", 7), `requires 4 more of "pods"`},
+		{"scale out sharding", horizontalScaling("shard", 3), ""},
+		{"scale out sharding exceeding quota", horizontalScaling("shard", 5), `requires 6 more of "pods"`},
+		{"scale in", horizontalScaling("mysql", 1), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := tt.ops.exceededComputeQuota(context.Background(), cli, cluster)
+			if tt.message == "" && message != "" {
+				t.Errorf("unexpected quota exceeded: %s", message)
+			}
+			if tt.message != "" && !strings.Contains(message, tt.message) {
+				t.Errorf("expect message containing %q, but got: %s", tt.message, message)
+			}
+		})
+	}
+
+	// the forced OpsRequest is warned instead of rejected
+	ops := verticalScaling("mysql", "4")
+	if err := ops.validateComputeQuota(context.Background(), cli, cluster); err == nil || !strings.Contains(err.Error(), `requires 6 more of "requests.cpu"`) {
+		t.Errorf("expect the quota exceeded error, but got: %v", err)
+	}
+	if warnings := ops.computeQuotaWarnings(context.Background(), cli, cluster); len(warnings) > 0 {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+	ops.Spec.Force = true
+	if err := ops.validateComputeQuota(context.Background(), cli, cluster); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if warnings := ops.computeQuotaWarnings(context.Background(), cli, cluster); len(warnings) != 1 {
+		t.Errorf("expect the quota exceeded warning, but got: %v", warnings)
+	}
+}
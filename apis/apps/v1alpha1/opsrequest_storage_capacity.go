@@ -0,0 +1,153 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/apecloud/kubeblocks/pkg/kberrors"
+)
+
+// validateStorageCapacity rejects the volume expansion which requires more storage than the CSIStorageCapacities
+// reported by the CSI drivers, as the resize of the volumes would hang at the CSI level. It's bypassed if the OpsRequest is forced.
+func (r *OpsRequest) validateStorageCapacity(ctx context.Context, cli client.Client) error {
+	if r.Spec.Force {
+		return nil
+	}
+	if message := r.exceededStorageCapacity(ctx, cli); message != "" {
+		return kberrors.New(kberrors.ReasonStorageCapacityExceeded, "%s", message).
+			WithHint(`view the capacities by command: kubectl get csistoragecapacities -A, or set "spec.force" to bypass the check if the capacities are out of date`)
+	}
+	return nil
+}
+
+// storageCapacityWarnings warns about the forced volume expansion which requires more storage than the CSIStorageCapacities.
+func (r *OpsRequest) storageCapacityWarnings(ctx context.Context, cli client.Client) admission.Warnings {
+	if !r.Spec.Force {
+		return nil
+	}
+	if message := r.exceededStorageCapacity(ctx, cli); message != "" {
+		return admission.Warnings{message + ", the expansion of the volumes may hang until the storage is available"}
+	}
+	return nil
+}
+
+// exceededStorageCapacity returns the message describing the CSIStorageCapacity exceeded by the storage requested
+// additionally by the volume expansion, or an empty string if no capacity is exceeded.
+// The volumes are matched with the capacities by the StorageClass and the topology the PersistentVolumes are accessible from,
+// the volumes without any capacity reported are not checked.
+func (r *OpsRequest) exceededStorageCapacity(ctx context.Context, cli client.Client) string {
+	if cli == nil || len(r.Spec.VolumeExpansionList) == 0 || (r.Status.Phase != "" && r.Status.Phase != OpsPendingPhase) {
+		return ""
+	}
+	capacityList := &storagev1.CSIStorageCapacityList{}
+	if err := cli.List(ctx, capacityList); err != nil || len(capacityList.Items) == 0 {
+		return ""
+	}
+	var (
+		message string
+		// the storage requested additionally from each capacity
+		increases = map[*storagev1.CSIStorageCapacity]resource.Quantity{}
+	)
+	addIncrease := func(pvc corev1.PersistentVolumeClaim, requestStorage resource.Quantity) {
+		if message != "" || pvc.Spec.StorageClassName == nil || pvc.Spec.VolumeName == "" {
+			return
+		}
+		increase := getStorageIncrease(pvc, requestStorage)
+		if increase.Sign() <= 0 {
+			return
+		}
+		pv := &corev1.PersistentVolume{}
+		if err := cli.Get(ctx, client.ObjectKey{Name: pvc.Spec.VolumeName}, pv); err != nil {
+			return
+		}
+		capacity := matchStorageCapacity(capacityList.Items, *pvc.Spec.StorageClassName, getVolumeTopology(pv))
+		if capacity == nil {
+			return
+		}
+		if capacity.MaximumVolumeSize != nil && requestStorage.Cmp(*capacity.MaximumVolumeSize) > 0 {
+			message = fmt.Sprintf(`the volume expansion requests %s for PVC "%s", which exceeds the maximum volume size %s of CSIStorageCapacity "%s/%s"`,
+				requestStorage.String(), pvc.Name, capacity.MaximumVolumeSize.String(), capacity.Namespace, capacity.Name)
+			return
+		}
+		total := increases[capacity]
+		total.Add(increase)
+		increases[capacity] = total
+	}
+	if err := r.forEachExpandedPVC(ctx, cli, addIncrease); err != nil || message != "" {
+		return message
+	}
+	for i := range capacityList.Items {
+		capacity := &capacityList.Items[i]
+		increase, ok := increases[capacity]
+		if !ok || capacity.Capacity == nil || increase.Cmp(*capacity.Capacity) <= 0 {
+			continue
+		}
+		return fmt.Sprintf(`the volume expansion requests %s more of StorageClass "%s", which exceeds the capacity %s of CSIStorageCapacity "%s/%s"`,
+			increase.String(), capacity.StorageClassName, capacity.Capacity.String(), capacity.Namespace, capacity.Name)
+	}
+	return ""
+}
+
+// getVolumeTopology returns the topology labels the PersistentVolume is accessible from, which are required by its node affinity,
+// e.g. "topology.kubernetes.io/zone": "us-east-1a" for the volumes provisioned by the CSI drivers.
+func getVolumeTopology(pv *corev1.PersistentVolume) labels.Set {
+	topology := labels.Set{}
+	if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+		return topology
+	}
+	for _, term := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+		for _, expr := range term.MatchExpressions {
+			if expr.Operator == corev1.NodeSelectorOpIn && len(expr.Values) == 1 {
+				topology[expr.Key] = expr.Values[0]
+			}
+		}
+		// the volume is accessible from any of the terms, take the first one
+		if len(topology) > 0 {
+			break
+		}
+	}
+	return topology
+}
+
+// matchStorageCapacity returns the CSIStorageCapacity of the StorageClass whose node topology matches the topology of a volume.
+// The capacities without node topology are not accessible from any node, and are never matched.
+func matchStorageCapacity(capacities []storagev1.CSIStorageCapacity, storageClassName string, topology labels.Set) *storagev1.CSIStorageCapacity {
+	for i := range capacities {
+		capacity := &capacities[i]
+		if capacity.StorageClassName != storageClassName || capacity.NodeTopology == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(capacity.NodeTopology)
+		if err != nil {
+			continue
+		}
+		if selector.Matches(topology) {
+			return capacity
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestStorageCapacity(t *testing.T) {
+	const (
+		storageClassName = "csi-ssd"
+		zoneKey          = "topology.kubernetes.io/zone"
+	)
+	newVolume := func(name, zone string) (*corev1.PersistentVolumeClaim, *corev1.PersistentVolume) {
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "data-" + name, Labels: map[string]string{
+				constant.AppInstanceLabelKey:             "mycluster",
+				constant.KBAppComponentLabelKey:          "mysql",
+				constant.VolumeClaimTemplateNameLabelKey: "data",
+			}},
+			Spec: corev1.PersistentVolumeClaimSpec{StorageClassName: func(s string) *string { return &s }(storageClassName), VolumeName: "pv-" + name},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			},
+		}
+		pv := &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "pv-" + name},
+			Spec: corev1.PersistentVolumeSpec{NodeAffinity: &corev1.VolumeNodeAffinity{Required: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{
+					{Key: zoneKey, Operator: corev1.NodeSelectorOpIn, Values: []string{zone}},
+				}}},
+			}}},
+		}
+		return pvc, pv
+	}
+	newCapacity := func(zone, capacity, maximumVolumeSize string) *storagev1.CSIStorageCapacity {
+		c := &storagev1.CSIStorageCapacity{
+			ObjectMeta:       metav1.ObjectMeta{Namespace: "kube-system", Name: "csisc-" + zone},
+			StorageClassName: storageClassName,
+			NodeTopology:     &metav1.LabelSelector{MatchLabels: map[string]string{zoneKey: zone}},
+		}
+		if capacity != "" {
+			q := resource.MustParse(capacity)
+			c.Capacity = &q
+		}
+		if maximumVolumeSize != "" {
+			q := resource.MustParse(maximumVolumeSize)
+			c.MaximumVolumeSize = &q
+		}
+		return c
+	}
+	// two volumes in zone a, and one in zone b
+	var objs []client.Object
+	for name, zone := range map[string]string{"mysql-0": "a", "mysql-1": "a", "mysql-2": "b"} {
+		pvc, pv := newVolume(name, zone)
+		objs = append(objs, pvc, pv)
+	}
+	objs = append(objs, newCapacity("a", "15Gi", ""), newCapacity("b", "50Gi", "30Gi"))
+	cli := fake.NewClientBuilder().WithObjects(objs...).Build()
+
+	volumeExpansion := func(storage string) *OpsRequest {
+		return &OpsRequest{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+			Spec: OpsRequestSpec{
+				ClusterName: "mycluster",
+				Type:        VolumeExpansionType,
+				VolumeExpansionList: []VolumeExpansion{{
+					ComponentOps:         ComponentOps{ComponentName: "mysql"},
+					VolumeClaimTemplates: []OpsRequestVolumeClaimTemplate{{Name: "data", Storage: resource.MustParse(storage)}},
+				}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		ops     *OpsRequest
+		message string
+	}{
+		{"expand within capacity", volumeExpansion("15Gi"), ""},
+		{"expand exceeding capacity", volumeExpansion("20Gi"), `requests 20Gi more of StorageClass "csi-ssd", which exceeds the capacity 15Gi of CSIStorageCapacity "kube-system/csisc-a"`},
+		{"expand exceeding maximum volume size", volumeExpansion("40Gi"), `which exceeds the maximum volume size 30Gi of CSIStorageCapacity "kube-system/csisc-b"`},
+		{"no expansion", volumeExpansion("10Gi"), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := tt.ops.exceededStorageCapacity(context.Background(), cli)
+			if tt.message == "" && message != "" {
+				t.Errorf("unexpected capacity exceeded: %s", message)
+			}
+			if tt.message != "" && !strings.Contains(message, tt.message) {
+				t.Errorf("expect message containing %q, but got: %s", tt.message, message)
+			}
+		})
+	}
+
+	// the volumes without any capacity reported are not checked
+	noCapacityCli := fake.NewClientBuilder().WithObjects(objs[:len(objs)-2]...).Build()
+	if message := volumeExpansion("100Gi").exceededStorageCapacity(context.Background(), noCapacityCli); message != "" {
+		t.Errorf("unexpected capacity exceeded: %s", message)
+	}
+
+	// the forced OpsRequest is warned instead of rejected
+	ops := volumeExpansion("20Gi")
+	if err := ops.validateStorageCapacity(context.Background(), cli); err == nil || !strings.Contains(err.Error(), "exceeds the capacity 15Gi") {
+		t.Errorf("expect the capacity exceeded error, but got: %v", err)
+	}
+	if warnings := ops.storageCapacityWarnings(context.Background(), cli); len(warnings) > 0 {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+	ops.Spec.Force = true
+	if err := ops.validateStorageCapacity(context.Background(), cli); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if warnings := ops.storageCapacityWarnings(context.Background(), cli); len(warnings) != 1 {
+		t.Errorf("expect the capacity exceeded warning, but got: %v", warnings)
+	}
+}
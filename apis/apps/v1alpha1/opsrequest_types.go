@@ -17,17 +17,26 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/apecloud/kubeblocks/pkg/recurrence"
 )
 
 // TODO: @wangyelei could refactor to ops group
 
 // OpsRequestSpec defines the desired state of OpsRequest
 //
-// +kubebuilder:validation:XValidation:rule="has(self.cancel) && self.cancel ? (self.type in ['VerticalScaling', 'HorizontalScaling']) : true",message="forbidden to cancel the opsRequest which type not in ['VerticalScaling','HorizontalScaling']"
+// +kubebuilder:validation:XValidation:rule="has(self.cancel) && self.cancel ? (self.type in ['VerticalScaling', 'HorizontalScaling', 'Custom']) : true",message="forbidden to cancel the opsRequest which type not in ['VerticalScaling','HorizontalScaling','Custom']"
+// +kubebuilder:validation:XValidation:rule="has(self.dryRun) && self.dryRun ? (self.type in ['VerticalScaling', 'HorizontalScaling', 'VolumeExpansion', 'Reconfiguring', 'Start', 'Stop', 'Expose']) : true",message="spec.dryRun is only supported by the opsRequest which type in ['VerticalScaling','HorizontalScaling','VolumeExpansion','Reconfiguring','Start','Stop','Expose']"
+// +kubebuilder:validation:XValidation:rule="has(self.rollbackOnFailure) && self.rollbackOnFailure ? (self.type in ['VerticalScaling', 'Upgrade']) : true",message="spec.rollbackOnFailure is only supported by the opsRequest which type in ['VerticalScaling','Upgrade']"
 type OpsRequestSpec struct {
 	// Specifies the name of the Cluster resource that this operation is targeting.
 	//
@@ -43,7 +52,8 @@ type OpsRequestSpec struct {
 	// Indicates whether the current operation should be canceled and terminated gracefully if it's in the
 	// "Pending", "Creating", or "Running" state.
 	//
-	// This field applies only to "VerticalScaling" and "HorizontalScaling" opsRequests.
+	// This field applies only to "VerticalScaling", "HorizontalScaling" and "Custom" opsRequests.
+	// Cancelling a "Custom" opsRequest terminates its running actions.
 	//
 	// Note: Setting `cancel` to true is irreversible; further modifications to this field are ineffective.
 	//
@@ -64,9 +74,22 @@ type OpsRequestSpec struct {
 	// +optional
 	Force bool `json:"force,omitempty"`
 
+	// Acknowledges that the operation may drop the available voting members of a consensus component
+	// below the quorum, which makes the component unavailable until the quorum is restored.
+	//
+	// The quorum-safety checks of the "HorizontalScaling", "Stop", "MigrateNodePool" and "MigrateInstance" opsRequests
+	// are bypassed only if both `force` and `acknowledgeQuorumLoss` are true.
+	//
+	// Note: This field is immutable once set.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.acknowledgeQuorumLoss"
+	// +optional
+	AcknowledgeQuorumLoss bool `json:"acknowledgeQuorumLoss,omitempty"`
+
 	// Specifies the type of this operation. Supported types include "Start", "Stop", "Restart", "Switchover",
 	// "VerticalScaling", "HorizontalScaling", "VolumeExpansion", "Reconfiguring", "Upgrade", "Backup", "Restore",
-	// "Expose", "DataScript", "RebuildInstance", "Custom".
+	// "Expose", "DataScript", "RebuildInstance", "DebugInstance", "MigrateNodePool", "RotateTLS", "Purge",
+	// "RotateCredentials", "ShardRebalance", "MigrateInstance", "Custom".
 	//
 	// Note: This field is immutable once set.
 	//
@@ -75,11 +98,23 @@ type OpsRequestSpec struct {
 	Type OpsType `json:"type"`
 
 	// Specifies the duration in seconds that an OpsRequest will remain in the system after successfully completing
-	// (when `opsRequest.status.phase` is "Succeed") before automatic deletion.
+	// (when `opsRequest.status.phase` is "Succeed" or "SucceededWithWarnings") before automatic deletion.
 	//
 	// +optional
 	TTLSecondsAfterSucceed int32 `json:"ttlSecondsAfterSucceed,omitempty"`
 
+	// Specifies the duration in seconds that an OpsRequest will remain in the system after it completes
+	// (when `opsRequest.status.phase` is "Succeed", "SucceededWithWarnings", "Failed", "Cancelled" or "Aborted")
+	// before automatic deletion.
+	// For a succeeded OpsRequest, `ttlSecondsAfterSucceed` takes precedence if it is set.
+	//
+	// The most recent completed OpsRequests of each Cluster are retained regardless of this field,
+	// and the number of them is configured by the operator.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TTLSecondsAfterFinished int32 `json:"ttlSecondsAfterFinished,omitempty"`
+
 	// Specifies the maximum time in seconds that the OpsRequest will wait for its pre-conditions to be met
 	// before it aborts the operation.
 	// If set to 0 (default), pre-conditions must be satisfied immediately for the OpsRequest to proceed.
@@ -88,6 +123,119 @@ type OpsRequestSpec struct {
 	// +optional
 	PreConditionDeadlineSeconds *int32 `json:"preConditionDeadlineSeconds,omitempty"`
 
+	// Specifies how the operation handles the instances that fail to be processed.
+	// It applies to the operations that affect multiple instances, such as "Restart", "VerticalScaling",
+	// "HorizontalScaling" and "Upgrade".
+	//
+	// - Fail: the operation fails once an instance fails. This is the default policy.
+	// - Ignore: the operation continues on the remaining instances, and the phase is "SucceededWithWarnings"
+	//   if some instances failed.
+	// - Threshold(n%): the same as "Ignore" as long as the failed instances do not exceed n percent of
+	//   the instances involved, otherwise the operation fails.
+	//
+	// Note: This field is immutable once set.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.failurePolicy"
+	// +optional
+	FailurePolicy OpsFailurePolicy `json:"failurePolicy,omitempty"`
+
+	// Specifies the maintenance window in which the OpsRequest is allowed to start, so that it can be created
+	// ahead of time. The OpsRequest stays in the "Scheduled" phase until the window opens.
+	//
+	// Note: This field is immutable once set.
+	//
+	// +kubebuilder:validation:XValidation:rule="has(self.cronExpression) ? !has(self.startTime) && !has(self.endTime) : has(self.startTime) && has(self.endTime)",message="either cronExpression or both startTime and endTime must be specified"
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.schedule"
+	// +optional
+	Schedule *OpsSchedule `json:"schedule,omitempty"`
+
+	// Specifies the names of the OpsRequests in the same namespace that this OpsRequest depends on.
+	// The OpsRequest stays in the "Pending" phase until all of them have succeeded,
+	// and fails if any of them fails, is cancelled or aborted.
+	//
+	// Note: This field is immutable once set.
+	//
+	// +kubebuilder:validation:MaxItems=32
+	// +listType=set
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.dependsOn"
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// Specifies the priority of the OpsRequest in the queue of the Cluster.
+	// An OpsRequest with a higher value is started ahead of the queued OpsRequests with lower values,
+	// e.g. a VerticalScaling to relieve the OOM of a Component. OpsRequests with the same priority
+	// are started in the order they are queued.
+	//
+	// Note: This field is immutable once set.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.priority"
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// Specifies whether the OpsRequest can jump ahead of the queued OpsRequests with lower priorities.
+	//
+	// Valid values are:
+	//
+	// - PreemptLowerPriority: The OpsRequest is queued ahead of the queued OpsRequests with lower priorities.
+	// - Never: The OpsRequest is queued behind all the OpsRequests queued before it, regardless of the priorities.
+	//
+	// OpsRequests that are already running are never preempted.
+	//
+	// Note: This field is immutable once set.
+	//
+	// +kubebuilder:default=PreemptLowerPriority
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.preemptionPolicy"
+	// +optional
+	PreemptionPolicy OpsPreemptionPolicy `json:"preemptionPolicy,omitempty"`
+
+	// Indicates whether the OpsRequest runs in the dry-run mode.
+	// In the dry-run mode, the OpsRequest is fully validated, and the changes it would make to the Cluster
+	// and other objects are rendered into `status.dryRunResult` without being persisted,
+	// then the OpsRequest is marked as "Succeed" right away.
+	//
+	// Supported by the following operations: "VerticalScaling", "HorizontalScaling", "VolumeExpansion",
+	// "Reconfiguring", "Start", "Stop", "Expose".
+	//
+	// Note: This field is immutable once set.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.dryRun"
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Specifies how the OpsRequest is retried automatically when the operation fails while running,
+	// e.g. the instances fail to start due to image pulling errors or the scheduling pressure.
+	// The failed steps are re-run after the backoff instead of marking the OpsRequest as "Failed",
+	// until the retries are exhausted. The validation failures and the timeouts are not retried.
+	//
+	// Note: This field is immutable once set.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.retryPolicy"
+	// +optional
+	RetryPolicy *OpsRequestRetryPolicy `json:"retryPolicy,omitempty"`
+
+	// Indicates whether the changes made to the Cluster are rolled back when the OpsRequest fails,
+	// the previous resources or versions of the Components are restored from `status.lastConfiguration`.
+	// The rollback takes place after the retries of `spec.retryPolicy` are exhausted.
+	//
+	// Supported by the following operations: "VerticalScaling", "Upgrade".
+	//
+	// Note: This field is immutable once set.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.rollbackOnFailure"
+	// +optional
+	RollbackOnFailure bool `json:"rollbackOnFailure,omitempty"`
+
+	// Specifies the assertions on the current state of the Cluster, which are verified right before the OpsRequest
+	// starts to change the Cluster, i.e. after it leaves the queue of the Cluster.
+	// The OpsRequest fails with the reason "PreconditionMismatch" if any of them does not hold,
+	// which guards the automations against acting on a Cluster that has changed since the OpsRequest was created.
+	//
+	// Note: This field is immutable once set.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.assertions"
+	// +optional
+	Assertions *OpsAssertions `json:"assertions,omitempty"`
+
 	// Exactly one of its members must be set.
 	SpecificOpsRequest `json:",inline"`
 }
@@ -125,6 +273,9 @@ type SpecificOpsRequest struct {
 
 	// Lists Components to be restarted.
 	//
+	// The changes of the scheduling policy which are pending a restart to take effect, such as the affinity and
+	// the removed tolerations, are applied to the instances along with the restart.
+	//
 	// +optional
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.restart"
 	// +kubebuilder:validation:MaxItems=1024
@@ -134,6 +285,30 @@ type SpecificOpsRequest struct {
 	// +listMapKey=componentName
 	RestartList []ComponentOps `json:"restart,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
 
+	// Lists Components to be stopped.
+	// The whole Cluster is stopped if it is not specified.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.stop"
+	// +kubebuilder:validation:MaxItems=1024
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	StopList []ComponentOps `json:"stop,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
+	// Lists Components to be started, they must be stopped by a Stop OpsRequest before.
+	// The whole Cluster is started if it is not specified.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.start"
+	// +kubebuilder:validation:MaxItems=1024
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	StartList []ComponentOps `json:"start,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
 	// Lists Switchover objects, each specifying a Component to perform the switchover operation.
 	//
 	// +optional
@@ -226,6 +401,169 @@ type SpecificOpsRequest struct {
 	//
 	// +optional
 	CustomOps *CustomOps `json:"custom,omitempty"`
+
+	// Specifies the instance to attach the debug container defined in the ComponentDefinition.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.debugInstance"
+	DebugInstance *DebugInstance `json:"debugInstance,omitempty"`
+
+	// Specifies the node pool to migrate the instances of a Component to.
+	// Only `paused` can be updated after the OpsRequest is created.
+	//
+	// +optional
+	MigrateNodePool *MigrateNodePool `json:"migrateNodePool,omitempty"`
+
+	// Lists Components whose TLS certificates will be rotated.
+	//
+	// For the certificates issued by KubeBlocks, new certificates are generated and the Secret is updated.
+	// For the certificates provided by users, the referenced Secret should be updated before the OpsRequest is created.
+	// Each instance then reloads the certificates by the `reloadTLS` lifecycle action if it is defined
+	// in the ComponentDefinition, otherwise the instances are restarted one by one.
+	// The certificates of all the shards are rotated if a sharding is specified.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.rotateTLS"
+	// +kubebuilder:validation:MaxItems=1024
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	RotateTLSList []ComponentOps `json:"rotateTLS,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
+	// Lists Components whose logs and temporary files will be purged by the `purge` lifecycle action
+	// defined in the ComponentDefinition, such as purging binlogs, trimming WAL archives or cleaning up temp dirs.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.purge"
+	// +kubebuilder:validation:MaxItems=1024
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	PurgeList []Purge `json:"purge,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
+	// Lists Components whose system accounts will have their passwords rotated.
+	// The new passwords are generated by the password generation policies of the accounts, applied to the engine
+	// by the `accountRotation` lifecycle action defined in the ComponentDefinition, and then saved into the Secrets
+	// of the accounts.
+	//
+	// The instances referencing the passwords by environment variables are not restarted,
+	// restart the Component to refresh them if needed.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.rotateCredentials"
+	// +kubebuilder:validation:MaxItems=1024
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	RotateCredentialsList []RotateCredentials `json:"rotateCredentials,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
+	// Lists the shardings whose data will be rebalanced across the shards, typically after the number of shards
+	// is changed. The `shardRebalance` lifecycle action defined in the ComponentDefinition is invoked for each shard,
+	// and the data moved into each shard is tracked in the progress details of the sharding.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.shardRebalance"
+	// +kubebuilder:validation:MaxItems=1024
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	ShardRebalanceList []ComponentOps `json:"shardRebalance,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
+	// Specifies the instance to move to another node or zone, typically before the node is decommissioned.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="has(self.targetNodeName) != has(self.targetZone)",message="exactly one of targetNodeName and targetZone must be specified"
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.migrateInstance"
+	MigrateInstance *MigrateInstance `json:"migrateInstance,omitempty"`
+}
+
+// OpsSchedule defines the maintenance window in which an OpsRequest is allowed to start.
+// The window either recurs by the `cronExpression`, or occurs once between the `startTime` and the `endTime`.
+type OpsSchedule struct {
+	// Specifies the cron expression of the starts of the recurring windows, in the standard 5-field format
+	// "minute hour day-of-month month day-of-week", e.g. "0 2 * * 6" for 02:00 every Saturday.
+	//
+	// +optional
+	CronExpression string `json:"cronExpression,omitempty"`
+
+	// Specifies how long each window started by the `cronExpression` lasts, e.g. "2h". Defaults to 1h.
+	//
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Specifies the IANA time zone in which the `cronExpression` is evaluated, e.g. "Asia/Shanghai".
+	// Defaults to UTC.
+	//
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Specifies the start time of the one-off window.
+	//
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Specifies the end time of the one-off window, the OpsRequest fails if it has not started by then.
+	//
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+}
+
+// OpsRequestRetryPolicy defines how a failed OpsRequest is retried automatically.
+type OpsRequestRetryPolicy struct {
+	// Specifies the maximum number of retries before the OpsRequest is marked as "Failed".
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	MaxRetries int32 `json:"maxRetries"`
+
+	// Specifies the duration in seconds to wait before each retry.
+	//
+	// +kubebuilder:default=30
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	BackoffSeconds int32 `json:"backoffSeconds,omitempty"`
+}
+
+// OpsAssertions declares the expected current state of the Cluster.
+type OpsAssertions struct {
+	// Specifies the expected `spec.clusterVersionRef` of the Cluster.
+	//
+	// +optional
+	ClusterVersionRef string `json:"clusterVersionRef,omitempty"`
+
+	// Specifies the expected current state of the Components or the shardings.
+	//
+	// +kubebuilder:validation:MaxItems=128
+	// +listType=map
+	// +listMapKey=componentName
+	// +optional
+	Components []ComponentAssertion `json:"components,omitempty"`
+}
+
+// ComponentAssertion declares the expected current state of a Component or a sharding.
+type ComponentAssertion struct {
+	// Specifies the name of the Component or the sharding.
+	ComponentOps `json:",inline"`
+
+	// Specifies the expected number of replicas of the Component, or of each shard of the sharding.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Specifies the expected service version of the Component.
+	//
+	// +optional
+	ServiceVersion string `json:"serviceVersion,omitempty"`
+
+	// Specifies the instance (Pod) expected to be the primary or leader of the Component, or of a shard of the sharding.
+	//
+	// +optional
+	PrimaryInstance string `json:"primaryInstance,omitempty"`
 }
 
 // ComponentOps specifies the Component to be operated on.
@@ -271,6 +609,132 @@ type RebuildInstance struct {
 	RestoreEnv []corev1.EnvVar `json:"restoreEnv,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
 }
 
+// DebugInstance defines the parameters to attach an ephemeral debug container to an instance.
+type DebugInstance struct {
+	// Specifies the name of the Component.
+	ComponentOps `json:",inline"`
+
+	// Specifies the name of the instance (Pod) to debug.
+	//
+	// +kubebuilder:validation:Required
+	InstanceName string `json:"instanceName"`
+
+	// Specifies the name of the container in the Pod whose process namespace is shared with the debug container.
+	// Defaults to the first container of the Pod.
+	//
+	// +optional
+	TargetContainerName string `json:"targetContainerName,omitempty"`
+}
+
+// MigrateNodePool defines the parameters to move the instances of a Component to another node pool.
+// The instances are moved one at a time, and the primary is switched over to a moved instance before it is moved.
+type MigrateNodePool struct {
+	// Specifies the name of the Component.
+	ComponentOps `json:",inline"`
+
+	// Specifies the labels of the nodes in the target node pool.
+	// The labels are merged into the `nodeSelector` of the scheduling policy of the Component.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinProperties=1
+	NodeSelector map[string]string `json:"nodeSelector"`
+
+	// Specifies the tolerations required by the taints of the nodes in the target node pool.
+	// They are appended to the tolerations of the scheduling policy of the Component.
+	//
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Pauses the migration before moving the next instance when set to true,
+	// and resumes it when set back to false.
+	//
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// MigrateInstance defines the parameters to move an instance of a Component to another node or zone.
+// The instance is evicted from its node and recreated by the InstanceSet on the target, while the other instances
+// are not affected.
+type MigrateInstance struct {
+	// Specifies the name of the Component.
+	ComponentOps `json:",inline"`
+
+	// Specifies the name of the instance (Pod) to move.
+	//
+	// +kubebuilder:validation:Required
+	InstanceName string `json:"instanceName"`
+
+	// Specifies the name of the node to move the instance to.
+	//
+	// +optional
+	TargetNodeName string `json:"targetNodeName,omitempty"`
+
+	// Specifies the zone to move the instance to, the instance is scheduled to one of the nodes labeled
+	// with `topology.kubernetes.io/zone` of the zone.
+	//
+	// +optional
+	TargetZone string `json:"targetZone,omitempty"`
+
+	// Specifies how the volumes of the instance are handled when it is moved.
+	//
+	// - `Retain`: keeps the PVCs, which works only if the volumes can be attached to the target,
+	//   such as the network storage in the same zone.
+	// - `Recreate`: deletes the PVCs and provisions new ones on the target,
+	//   the data is synchronized from the other replicas by the database itself.
+	//
+	// +kubebuilder:default=Retain
+	// +optional
+	VolumePolicy MigrateInstanceVolumePolicy `json:"volumePolicy,omitempty"`
+
+	// Cordons the node which the instance is moved from, so that no instances are scheduled to it any more.
+	// The node is not uncordoned after the OpsRequest completes.
+	//
+	// +optional
+	CordonSourceNode bool `json:"cordonSourceNode,omitempty"`
+}
+
+// Purge defines the parameters to purge the logs and temporary files of a Component.
+// At least one of `retainHours` and `retainBytes` must be specified to keep the recent data.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.retainHours) || has(self.retainBytes)",message="at least one retainHours or retainBytes"
+type Purge struct {
+	// Specifies the name of the Component.
+	ComponentOps `json:",inline"`
+
+	// Specifies the names of the instances to purge.
+	// If not set, all instances of the Component are purged.
+	//
+	// +optional
+	Instances []string `json:"instances,omitempty"`
+
+	// Specifies the number of hours of the most recent data to retain.
+	// It is passed to the action by the `KB_PURGE_RETAIN_HOURS` environment variable.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RetainHours *int32 `json:"retainHours,omitempty"`
+
+	// Specifies the amount of the most recent data to retain.
+	// It is passed to the action in bytes by the `KB_PURGE_RETAIN_BYTES` environment variable.
+	//
+	// +optional
+	RetainBytes *resource.Quantity `json:"retainBytes,omitempty"`
+}
+
+// RotateCredentials defines the system accounts of a Component whose passwords are rotated.
+type RotateCredentials struct {
+	// Specifies the name of the Component.
+	ComponentOps `json:",inline"`
+
+	// Specifies the names of the system accounts defined in the ComponentDefinition.
+	// The accounts whose passwords are provided by a referenced Secret are not supported.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	Accounts []string `json:"accounts"`
+}
+
 type Instance struct {
 	// Pod name of the instance.
 	// +kubebuilder:validation:Required
@@ -282,6 +746,8 @@ type Instance struct {
 	TargetNodeName string `json:"targetNodeName,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="has(self.maxLagSeconds) ? self.instanceName != '*' : true",message="maxLagSeconds requires a specific instance to be promoted"
+// +kubebuilder:validation:XValidation:rule="has(self.lagWaitSeconds) ? has(self.maxLagSeconds) : true",message="lagWaitSeconds requires maxLagSeconds to be specified"
 type Switchover struct {
 	// Specifies the name of the Component.
 	ComponentOps `json:",inline"`
@@ -303,9 +769,47 @@ type Switchover struct {
 	//
 	// +kubebuilder:validation:Required
 	InstanceName string `json:"instanceName"`
+
+	// Specifies the maximum replication lag in seconds of the candidate instance to be promoted.
+	//
+	// If specified, the replication lag of the candidate is measured by the `replicationLag` lifecycle action
+	// before the promotion, which is delayed until the candidate has caught up with the primary or leader,
+	// and the OpsRequest fails if the candidate has not caught up within `lagWaitSeconds`.
+	// The measured lag is recorded in `status.components[componentName].replicationLagSeconds`.
+	//
+	// It requires a specific instance to be specified by `instanceName`,
+	// and the `replicationLag` lifecycle action to be defined in the ComponentDefinition.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxLagSeconds *int32 `json:"maxLagSeconds,omitempty"`
+
+	// Specifies the maximum duration in seconds to wait for the candidate instance to catch up within `maxLagSeconds`,
+	// counted from the start of the OpsRequest. Defaults to 300 seconds.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	LagWaitSeconds *int32 `json:"lagWaitSeconds,omitempty"`
+
+	// Specifies the maximum number of times to retry the switchover if the switchover action fails.
+	//
+	// Before each retry, the candidate instance is re-selected from the ready instances other than the primary or leader
+	// and the candidates that have failed, it must hold a role that can be promoted and,
+	// if `maxLagSeconds` is specified, have caught up within `maxLagSeconds`.
+	// The switchover is retried by the same action with the candidate "*" if `instanceName` is "*".
+	// Each attempt is recorded in `status.components[componentName].switchoverAttempts`.
+	//
+	// Defaults to 0, which means the OpsRequest fails once the switchover action fails.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
 }
 
 // Upgrade defines the parameters for an upgrade operation.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.clusterVersionRef) || (has(self.components) && size(self.components) > 0)",message="at least one clusterVersionRef or components"
 type Upgrade struct {
 	// Deprecated: since v0.9 because ClusterVersion is deprecated.
 	// Specifies the name of the target ClusterVersion for the upgrade.
@@ -359,6 +863,13 @@ type VerticalScaling struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	corev1.ResourceRequirements `json:",inline"`
 
+	// Specifies the class of the desired compute resources defined in a ComponentClassDefinition,
+	// instead of the raw requests and limits, which can not be specified together.
+	// Both the requests and limits of the Component's instances are set to the resources of the class.
+	//
+	// +optional
+	ClassRef *ComponentClassRef `json:"classRef,omitempty"`
+
 	// Specifies the desired compute resources of the instance template that need to vertical scale.
 	// +patchMergeKey=name
 	// +patchStrategy=merge,retainKeys
@@ -633,15 +1144,33 @@ type Parameter struct {
 	Value string `json:"value"`
 }
 
+// +kubebuilder:validation:XValidation:rule="!(has(self.value) && has(self.valueFrom))",message="value and valueFrom are mutually exclusive"
 type ParameterPair struct {
 	// Represents the name of the parameter that is to be updated.
 	// +kubebuilder:validation:Required
 	Key string `json:"key"`
 
 	// Represents the parameter values that are to be updated.
-	// If set to nil, the parameter defined by the Key field will be removed from the configuration file.
+	// If both `value` and `valueFrom` are nil, the parameter defined by the Key field will be removed from the configuration file.
 	// +optional
 	Value *string `json:"value"`
+
+	// Specifies the source of the parameter value, it can not be set together with `value`.
+	//
+	// The value is resolved when the configuration is rendered, and never stored in plaintext
+	// in the Configuration object or the ConfigMap.
+	// It is only allowed by the configurations with `allowSecretParameters` set in the ComponentDefinition.
+	//
+	// +optional
+	ValueFrom *ParameterValueSource `json:"valueFrom,omitempty"`
+}
+
+// ParameterValueSource represents the source of a parameter value.
+type ParameterValueSource struct {
+	// Selects a key of a Secret in the namespace of the Cluster.
+	//
+	// +kubebuilder:validation:Required
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef"`
 }
 
 type ParameterConfig struct {
@@ -850,6 +1379,8 @@ type PointInTimeRefSpec struct {
 //
 // ScriptSpec has been replaced by the more versatile OpsDefinition.
 // It is recommended to use OpsDefinition instead. ScriptSpec is deprecated and will be removed in a future version.
+//
+// +kubebuilder:validation:XValidation:rule="(has(self.script) && size(self.script) > 0) || has(self.scriptFrom)",message="at least one script or scriptFrom"
 type ScriptSpec struct {
 	// Specifies the name of the Component.
 	ComponentOps `json:",inline"`
@@ -995,6 +1526,53 @@ type Restore struct {
 	//
 	// This setting is useful for coordinating PostReady operations across the Cluster for optimal cluster conditions.
 	DeferPostReadyUntilClusterRunning bool `json:"deferPostReadyUntilClusterRunning,omitempty"`
+
+	// Specifies how the Components in the Backup are mapped to the Components of the Cluster specified by `clusterName`.
+	//
+	// If specified, the data is restored in place into the existing Cluster instead of creating a new one:
+	// the target Components are stopped to stop the writes, their volumes are restored from the Backup,
+	// and then they are started again.
+	// Only the Backups taken by a backup tool are supported, the Backups of volume snapshots are not.
+	//
+	// +patchMergeKey=targetComponentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=targetComponentName
+	// +optional
+	ComponentMappings []RestoreComponentMapping `json:"componentMappings,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"targetComponentName"`
+}
+
+// RestoreComponentMapping maps a Component in the Backup to a Component of the Cluster to be restored in place.
+type RestoreComponentMapping struct {
+	// Specifies the name of the Component in the Backup, whose data is restored.
+	//
+	// +kubebuilder:validation:Required
+	SourceComponentName string `json:"sourceComponentName"`
+
+	// Specifies the name of the Component of the Cluster, into which the data is restored.
+	// Sharding Components and Components with instance templates are not supported.
+	//
+	// +kubebuilder:validation:Required
+	TargetComponentName string `json:"targetComponentName"`
+
+	// Specifies how the volumes in the Backup are mapped to the volumes of the target Component.
+	// The volumes not listed here are restored from the volumes with the same name in the Backup.
+	//
+	// +optional
+	VolumeMappings []RestoreVolumeMapping `json:"volumeMappings,omitempty"`
+}
+
+// RestoreVolumeMapping maps a volume in the Backup to a volume of the target Component.
+type RestoreVolumeMapping struct {
+	// Specifies the name of the volume in the Backup.
+	//
+	// +kubebuilder:validation:Required
+	SourceVolumeName string `json:"sourceVolumeName"`
+
+	// Specifies the name of the volumeClaimTemplate of the target Component.
+	//
+	// +kubebuilder:validation:Required
+	TargetVolumeName string `json:"targetVolumeName"`
 }
 
 // ScriptSecret represents the secret that is used to execute the script.
@@ -1015,6 +1593,8 @@ type ScriptSecret struct {
 }
 
 // ScriptFrom specifies the source of the script to be executed, which can be either a ConfigMap or a Secret.
+//
+// +kubebuilder:validation:XValidation:rule="(has(self.configMapRef) && size(self.configMapRef) > 0) || (has(self.secretRef) && size(self.secretRef) > 0)",message="at least one configMapRef or secretRef"
 type ScriptFrom struct {
 	// A list of ConfigMapKeySelector objects, each specifies a ConfigMap and a key containing the script.
 	//
@@ -1040,14 +1620,48 @@ type OpsRequestStatus struct {
 	ClusterGeneration int64 `json:"clusterGeneration,omitempty"`
 
 	// Represents the phase of the OpsRequest.
-	// Possible values include "Pending", "Creating", "Running", "Cancelling", "Cancelled", "Failed", "Succeed".
+	// Possible values include "Pending", "Scheduled", "Creating", "Running", "Cancelling", "Cancelled", "Failed", "Succeed", "SucceededWithWarnings".
 	Phase OpsPhase `json:"phase,omitempty"`
 
+	// Represents the position of the OpsRequest in the queue of the Cluster, starting from 1,
+	// which means it is the next one to run. It is cleared once the OpsRequest leaves the queue.
+	// +optional
+	QueuePosition int32 `json:"queuePosition,omitempty"`
+
 	// Represents the progress of the OpsRequest.
 	// +kubebuilder:validation:Pattern:=`^(\d+|\-)/(\d+|\-)$`
 	// +kubebuilder:default=-/-
 	Progress string `json:"progress"`
 
+	// Represents the overall progress of the OpsRequest in percentage, which is computed from `progress`
+	// and reaches 100 when the OpsRequest succeeds.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	ProgressPercentage int32 `json:"progressPercentage,omitempty"`
+
+	// Represents the expected impact of the OpsRequest on the availability of the Cluster,
+	// which is classified before the OpsRequest is executed, based on the operation, the replicas of the Components,
+	// and the definition metadata, such as the roles of the ComponentDefinition
+	// and the dynamic parameters of the ConfigConstraint.
+	//
+	// Valid values are:
+	//
+	// - Online: The Components keep serving during the operation.
+	// - BriefInterruptions: The replicas are restarted or switched over one by one, the connections to them are interrupted briefly.
+	// - Downtime: Some Components stop serving during the operation.
+	//
+	// It is left empty if the impact can not be determined, such as for the Custom OpsRequest.
+	//
+	// +optional
+	Impact OpsImpact `json:"impact,omitempty"`
+
+	// Explains why the OpsRequest is classified as the `impact`.
+	//
+	// +optional
+	ImpactMessage string `json:"impactMessage,omitempty"`
+
 	// Records the configuration prior to any changes.
 	// +optional
 	LastConfiguration LastConfiguration `json:"lastConfiguration,omitempty"`
@@ -1056,6 +1670,27 @@ type OpsRequestStatus struct {
 	// +optional
 	Components map[string]OpsRequestComponentStatus `json:"components,omitempty"`
 
+	// Records the results of the pre-checks run by the `Upgrade` OpsRequest before upgrading Components
+	// across major service versions.
+	// +optional
+	PreCheckResults []UpgradePreCheckResult `json:"preCheckResults,omitempty"`
+
+	// Records the changes that the OpsRequest would make if `spec.dryRun` is true.
+	// +optional
+	DryRunResult *OpsDryRunResult `json:"dryRunResult,omitempty"`
+
+	// Records the number of times the OpsRequest has been retried according to `spec.retryPolicy`.
+	// +optional
+	RetryCount int32 `json:"retryCount,omitempty"`
+
+	// Records the reason of the last failure which the OpsRequest has been retried for.
+	// +optional
+	LastFailureReason string `json:"lastFailureReason,omitempty"`
+
+	// Records the time of the last failure which the OpsRequest has been retried for.
+	// +optional
+	LastFailureTimestamp metav1.Time `json:"lastFailureTimestamp,omitempty"`
+
 	// A collection of additional key-value pairs that provide supplementary information for the OpsRequest.
 	Extras []map[string]string `json:"extras,omitempty"`
 
@@ -1083,7 +1718,7 @@ type OpsRequestStatus struct {
 	// Describes the detailed status of the OpsRequest.
 	// Possible condition types include "Cancelled", "WaitForProgressing", "Validated", "Succeed", "Failed", "Restarting",
 	// "VerticalScaling", "HorizontalScaling", "VolumeExpanding", "Reconfigure", "Switchover", "Stopping", "Starting",
-	// "VersionUpgrading", "Exposing", "ExecuteDataScript", "Backup", "InstancesRebuilding", "CustomOperation".
+	// "VersionUpgrading", "Exposing", "ExecuteDataScript", "Backup", "InstancesRebuilding", "CustomOperation", "Retrying".
 	// +optional
 	// +patchMergeKey=type
 	// +patchStrategy=merge
@@ -1139,10 +1774,16 @@ type ActionTask struct {
 	// +kubebuilder:validation:Required
 	Namespace string `json:"namespace"`
 
-	// Indicates the current status of the task, including "Processing", "Failed", "Succeed".
+	// Indicates the current status of the task, including "Processing", "Failed", "Succeed", "Terminated".
+	// A task is "Terminated" if it was still running when the OpsRequest was cancelled or timed out.
 	// +kubebuilder:validation:Required
 	Status ActionTaskStatus `json:"status"`
 
+	// Provides the reason and the output of the task if it failed or was terminated.
+	// The output is the termination message of the containers, which falls back to the tail of their logs.
+	// +optional
+	Message string `json:"message,omitempty"`
+
 	// The name of the Pod that the task is associated with or operates on.
 	// +optional
 	TargetPodName string `json:"targetPodName,omitempty"`
@@ -1192,6 +1833,10 @@ type LastComponentConfiguration struct {
 	// Records the name of the ComponentDefinition prior to any changes.
 	// +optional
 	ComponentDefinitionName string `json:"componentDefinitionName,omitempty"`
+
+	// Records the scheduling policy of the Component prior to any changes.
+	// +optional
+	SchedulingPolicy *SchedulingPolicy `json:"schedulingPolicy,omitempty"`
 }
 
 type LastConfiguration struct {
@@ -1223,6 +1868,35 @@ type OpsRequestComponentStatus struct {
 	// +optional
 	ProgressDetails []ProgressStatusDetail `json:"progressDetails,omitempty"`
 
+	// Represents the progress of the Component, in the form of "completed/total" of the `progressDetails`.
+	// +optional
+	Progress string `json:"progress,omitempty"`
+
+	// Records the time when the first object or action of the Component started.
+	// +optional
+	StartTime metav1.Time `json:"startTime,omitempty"`
+
+	// Records the time when the last object or action of the Component completed,
+	// it is set only when all of them have completed.
+	// +optional
+	EndTime metav1.Time `json:"endTime,omitempty"`
+
+	// Records the total space reclaimed from the instances of the Component by the `Purge` OpsRequest.
+	// +optional
+	ReclaimedSpace *resource.Quantity `json:"reclaimedSpace,omitempty"`
+
+	// Records the total size of the data moved between the shards of the sharding by the `ShardRebalance` OpsRequest.
+	// +optional
+	MovedData *resource.Quantity `json:"movedData,omitempty"`
+
+	// Records the latest replication lag in seconds of the candidate instance measured by the `Switchover` OpsRequest.
+	// +optional
+	ReplicationLagSeconds *int64 `json:"replicationLagSeconds,omitempty"`
+
+	// Records the attempts of the `Switchover` OpsRequest, the last one is the current attempt.
+	// +optional
+	SwitchoverAttempts []SwitchoverAttempt `json:"switchoverAttempts,omitempty"`
+
 	// Records the workload type of Component in ClusterDefinition.
 	// Deprecated and should be removed in the future version.
 	// +optional
@@ -1239,6 +1913,33 @@ type OpsRequestComponentStatus struct {
 	Message string `json:"message,omitempty" protobuf:"bytes,6,opt,name=message"`
 }
 
+// SwitchoverAttempt records an attempt of the switchover.
+type SwitchoverAttempt struct {
+	// Specifies the candidate instance of the attempt, "*" if no specific instance is designated.
+	// +kubebuilder:validation:Required
+	InstanceName string `json:"instanceName"`
+
+	// Specifies the name of the job that performs the switchover action.
+	// +kubebuilder:validation:Required
+	JobName string `json:"jobName"`
+
+	// Represents the status of the attempt, including "Processing", "Failed", "Succeed".
+	// +kubebuilder:validation:Required
+	Status ProgressStatus `json:"status"`
+
+	// Provides a human-readable explanation of the attempt.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Records the start time of the attempt.
+	// +optional
+	StartTime metav1.Time `json:"startTime,omitempty"`
+
+	// Records the completion time of the attempt.
+	// +optional
+	EndTime metav1.Time `json:"endTime,omitempty"`
+}
+
 type OverrideBy struct {
 	// Indicates the name of the OpsRequest.
 	// +optional
@@ -1257,6 +1958,50 @@ type PreCheckResult struct {
 	Message string `json:"message,omitempty"`
 }
 
+// UpgradePreCheckResult records the result of a pre-check of an upgrade across major service versions.
+type UpgradePreCheckResult struct {
+	// Specifies the name of the Component, or the name of the shard if the Component is a sharding.
+	// +kubebuilder:validation:Required
+	ComponentName string `json:"componentName"`
+
+	// Specifies the name of the pre-check declared by the upgrade path in the ComponentVersion.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	PreCheckResult `json:",inline"`
+}
+
+type OpsDryRunResult struct {
+	// Lists the changes of the objects in the order they would be made.
+	// +optional
+	ObjectChanges []DryRunObjectChange `json:"objectChanges,omitempty"`
+}
+
+// DryRunObjectChange describes a change of an object rendered by the dry-run of an OpsRequest.
+type DryRunObjectChange struct {
+	// Specifies the API version of the object.
+	// +kubebuilder:validation:Required
+	APIVersion string `json:"apiVersion"`
+
+	// Specifies the kind of the object.
+	// +kubebuilder:validation:Required
+	Kind string `json:"kind"`
+
+	// Specifies the name of the object.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the operation which would be made to the object.
+	// +kubebuilder:validation:Required
+	Operation DryRunOperation `json:"operation"`
+
+	// Represents the changes of the object in the format of the JSON merge patch (RFC 7386),
+	// the status and the system populated metadata are excluded.
+	// The whole object is rendered if it would be created, and it's empty if the object would be deleted.
+	// +optional
+	Diff string `json:"diff,omitempty"`
+}
+
 type ReconfiguringStatus struct {
 	// Describes the reconfiguring detail status.
 	// Possible condition types include "Creating", "Init", "Running", "Pending", "Merged", "MergeFailed", "FailedAndPause",
@@ -1362,6 +2107,8 @@ type UpdatedParameters struct {
 // +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.phase",description="Operation status phase."
 // +kubebuilder:printcolumn:name="PROGRESS",type="string",JSONPath=".status.progress",description="Operation processing progress."
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:selectablefield:JSONPath=".spec.clusterName"
+// +kubebuilder:selectablefield:JSONPath=".spec.clusterRef"
 
 // OpsRequest is the Schema for the opsrequests API
 type OpsRequest struct {
@@ -1405,6 +2152,11 @@ func (r OpsRequestSpec) GetClusterName() string {
 	return r.ClusterRef
 }
 
+// QuorumLossAcknowledged checks if the quorum-safety checks are bypassed by the user explicitly.
+func (r OpsRequestSpec) QuorumLossAcknowledged() bool {
+	return r.Force && r.AcknowledgeQuorumLoss
+}
+
 func (r OpsRequestSpec) GetBackup() *Backup {
 	if r.Backup != nil {
 		return r.Backup
@@ -1419,6 +2171,95 @@ func (r OpsRequestSpec) GetRestore() *Restore {
 	return r.RestoreSpec
 }
 
+// IsInPlaceRestore checks if the Restore OpsRequest restores the data into the existing Cluster.
+func (r OpsRequestSpec) IsInPlaceRestore() bool {
+	restore := r.GetRestore()
+	return r.Type == RestoreType && restore != nil && len(restore.ComponentMappings) > 0
+}
+
+// GetSourceVolumeName gets the name of the volume in the Backup which the target volume is restored from.
+func (r RestoreComponentMapping) GetSourceVolumeName(targetVolumeName string) string {
+	for _, v := range r.VolumeMappings {
+		if v.TargetVolumeName == targetVolumeName {
+			return v.SourceVolumeName
+		}
+	}
+	return targetVolumeName
+}
+
+// defaultOpsScheduleDuration is the duration of the windows started by the cron expression if not specified.
+const defaultOpsScheduleDuration = time.Hour
+
+// Window returns the window of the schedule which contains now, or the next one if now is in none of them.
+func (r *OpsSchedule) Window(now time.Time) (time.Time, time.Time, error) {
+	if r.CronExpression == "" {
+		if r.StartTime == nil || r.EndTime == nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("either cronExpression or both startTime and endTime must be specified")
+		}
+		return r.StartTime.Time, r.EndTime.Time, nil
+	}
+	loc := time.UTC
+	if r.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(r.TimeZone); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid time zone %q: %v", r.TimeZone, err)
+		}
+	}
+	duration := defaultOpsScheduleDuration
+	if r.Duration != nil {
+		duration = r.Duration.Duration
+	}
+	if duration <= 0 {
+		return time.Time{}, time.Time{}, fmt.Errorf("the duration must be positive")
+	}
+	cron, err := recurrence.ParseCron(r.CronExpression, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	// the window started within the duration before now is still open
+	start := cron.Next(now.Add(-duration))
+	if start.IsZero() {
+		return time.Time{}, time.Time{}, fmt.Errorf(`the cron expression "%s" never matches`, r.CronExpression)
+	}
+	return start, start.Add(duration), nil
+}
+
+// Validate validates the syntax of the schedule, and that the window is in the future.
+func (r *OpsSchedule) Validate(now time.Time) error {
+	if r.CronExpression != "" && (r.StartTime != nil || r.EndTime != nil) {
+		return fmt.Errorf("startTime and endTime can not be specified together with cronExpression")
+	}
+	start, end, err := r.Window(now)
+	if err != nil {
+		return err
+	}
+	if !end.After(start) {
+		return fmt.Errorf("the endTime must be after the startTime")
+	}
+	if !end.After(now) {
+		return fmt.Errorf("the window has ended at %s", end.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// IsFailureTolerated checks if the failed instances are tolerated by the failure policy.
+func (p OpsFailurePolicy) IsFailureTolerated(failedCount, totalCount int) bool {
+	switch {
+	case failedCount == 0:
+		return true
+	case p == "" || p == OpsFailurePolicyFail:
+		return false
+	case p == OpsFailurePolicyIgnore:
+		return true
+	}
+	threshold := strings.TrimSuffix(strings.TrimPrefix(string(p), "Threshold("), "%)")
+	percent, err := strconv.Atoi(threshold)
+	if err != nil {
+		return false
+	}
+	return failedCount*100 <= percent*totalCount
+}
+
 func (p *ProgressStatusDetail) SetStatusAndMessage(status ProgressStatus, message string) {
 	p.Message = message
 	p.Status = status
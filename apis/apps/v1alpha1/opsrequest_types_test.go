@@ -18,6 +18,9 @@ package v1alpha1
 
 import (
 	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var componentName = "mysql"
@@ -52,3 +55,149 @@ func TestSetStatusAndMessage(t *testing.T) {
 		t.Error("set progressDetail status and message failed")
 	}
 }
+
+func TestIsFailureTolerated(t *testing.T) {
+	testCases := []struct {
+		policy      OpsFailurePolicy
+		failedCount int
+		tolerated   bool
+	}{
+		{"", 0, true},
+		{"", 1, false},
+		{OpsFailurePolicyFail, 1, false},
+		{OpsFailurePolicyIgnore, 20, true},
+		{"Threshold(10%)", 2, true},
+		{"Threshold(10%)", 3, false},
+		{"Threshold(0%)", 1, false},
+		{"Threshold(n%)", 1, false},
+	}
+	for _, tc := range testCases {
+		if tolerated := tc.policy.IsFailureTolerated(tc.failedCount, 20); tolerated != tc.tolerated {
+			t.Errorf("policy %q with %d failed instances: expected tolerated %v, got %v", tc.policy, tc.failedCount, tc.tolerated, tolerated)
+		}
+	}
+}
+
+func TestGetScaleInReplicas(t *testing.T) {
+	int32Ptr := func(i int32) *int32 { return &i }
+	compSpec := ClusterComponentSpec{Name: componentName, Replicas: 5}
+	testCases := []struct {
+		hScale   HorizontalScaling
+		expected int32
+	}{
+		{HorizontalScaling{Replicas: int32Ptr(3)}, 2},
+		{HorizontalScaling{Replicas: int32Ptr(7)}, -2},
+		{HorizontalScaling{ScaleIn: &ScaleIn{ReplicaChanger: ReplicaChanger{ReplicaChanges: int32Ptr(2)}}}, 2},
+		{HorizontalScaling{ScaleIn: &ScaleIn{
+			ReplicaChanger:           ReplicaChanger{Instances: []InstanceReplicasTemplate{{Name: "foo", ReplicaChanges: 1}}},
+			OnlineInstancesToOffline: []string{"mysql-0", "mysql-1"},
+		}}, 3},
+		{HorizontalScaling{
+			ScaleIn:  &ScaleIn{ReplicaChanger: ReplicaChanger{ReplicaChanges: int32Ptr(2)}},
+			ScaleOut: &ScaleOut{NewInstances: []InstanceTemplate{{Name: "bar", Replicas: int32Ptr(1)}}},
+		}, 1},
+	}
+	for i, tc := range testCases {
+		if replicas := getScaleInReplicas(tc.hScale, compSpec); replicas != tc.expected {
+			t.Errorf("case %d: expected %d scale-in replicas, got %d", i, tc.expected, replicas)
+		}
+	}
+}
+
+func TestOpsScheduleWindow(t *testing.T) {
+	// 2024-01-06 is a Saturday
+	now := time.Date(2024, 1, 6, 2, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		schedule OpsSchedule
+		start    time.Time
+		end      time.Time
+		invalid  bool
+	}{
+		{
+			name:     "in the window",
+			schedule: OpsSchedule{CronExpression: "0 2 * * 6"},
+			start:    time.Date(2024, 1, 6, 2, 0, 0, 0, time.UTC),
+			end:      time.Date(2024, 1, 6, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "next window",
+			schedule: OpsSchedule{CronExpression: "0 2 * * 6", Duration: &metav1.Duration{Duration: 30 * time.Minute}},
+			start:    time.Date(2024, 1, 13, 2, 0, 0, 0, time.UTC),
+			end:      time.Date(2024, 1, 13, 2, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "one-off window",
+			schedule: OpsSchedule{
+				StartTime: &metav1.Time{Time: now.Add(time.Hour)},
+				EndTime:   &metav1.Time{Time: now.Add(2 * time.Hour)},
+			},
+			start: now.Add(time.Hour),
+			end:   now.Add(2 * time.Hour),
+		},
+		{
+			name:     "invalid cron expression",
+			schedule: OpsSchedule{CronExpression: "0 2 * *"},
+			invalid:  true,
+		},
+		{
+			name:     "invalid time zone",
+			schedule: OpsSchedule{CronExpression: "0 2 * * *", TimeZone: "Invalid/Zone"},
+			invalid:  true,
+		},
+		{
+			name:     "never matches",
+			schedule: OpsSchedule{CronExpression: "0 0 30 2 *"},
+			invalid:  true,
+		},
+		{
+			name:     "no window",
+			schedule: OpsSchedule{StartTime: &metav1.Time{Time: now}},
+			invalid:  true,
+		},
+	}
+	for _, tt := range tests {
+		start, end, err := tt.schedule.Window(now)
+		if tt.invalid {
+			if err == nil {
+				t.Errorf("%s: expect an error", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if !start.Equal(tt.start) || !end.Equal(tt.end) {
+			t.Errorf("%s: expect window [%v, %v), but got [%v, %v)", tt.name, tt.start, tt.end, start, end)
+		}
+	}
+}
+
+func TestOpsScheduleValidate(t *testing.T) {
+	now := time.Date(2024, 1, 6, 2, 30, 0, 0, time.UTC)
+	window := func(start, end time.Duration) *OpsSchedule {
+		return &OpsSchedule{
+			StartTime: &metav1.Time{Time: now.Add(start)},
+			EndTime:   &metav1.Time{Time: now.Add(end)},
+		}
+	}
+	if err := window(time.Hour, 2*time.Hour).Validate(now); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	// the window which has started but not ended is still in the future
+	if err := window(-time.Hour, time.Hour).Validate(now); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := window(-2*time.Hour, -time.Hour).Validate(now); err == nil {
+		t.Error("expect an error for the ended window")
+	}
+	if err := window(2*time.Hour, time.Hour).Validate(now); err == nil {
+		t.Error("expect an error for the window ending before it starts")
+	}
+	schedule := window(time.Hour, 2*time.Hour)
+	schedule.CronExpression = "0 2 * * *"
+	if err := schedule.Validate(now); err == nil {
+		t.Error("expect an error for the cron expression together with the window")
+	}
+}
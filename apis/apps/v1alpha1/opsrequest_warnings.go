@@ -0,0 +1,212 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/quorum"
+)
+
+// storageQuotaWarningRatio is the ratio of a storage quota, above which the volume expansion is warned.
+const storageQuotaWarningRatio = 0.9
+
+// riskWarnings warns about the risky operations which are allowed, so that they are surfaced to the operator
+// without blocking the OpsRequest. They are only checked before the OpsRequest starts.
+func (r *OpsRequest) riskWarnings(ctx context.Context, cli client.Client, cluster *Cluster) admission.Warnings {
+	if cluster == nil || (r.Status.Phase != "" && r.Status.Phase != OpsPendingPhase) {
+		return nil
+	}
+	switch r.Spec.Type {
+	case HorizontalScalingType:
+		return append(r.quorumLossWarnings(ctx, cli, cluster), r.computeQuotaWarnings(ctx, cli, cluster)...)
+	case VerticalScalingType:
+		return r.computeQuotaWarnings(ctx, cli, cluster)
+	case RestartType:
+		return r.singleReplicaRestartWarnings(cluster)
+	case VolumeExpansionType:
+		return append(r.storageQuotaWarnings(ctx, cli), r.storageCapacityWarnings(ctx, cli)...)
+	}
+	return nil
+}
+
+// quorumLossWarnings warns about the consensus components scaled in below the quorum,
+// which is allowed as the quorum loss is acknowledged.
+func (r *OpsRequest) quorumLossWarnings(ctx context.Context, cli client.Client, cluster *Cluster) admission.Warnings {
+	if cli == nil || !r.Spec.QuorumLossAcknowledged() {
+		return nil
+	}
+	var warnings admission.Warnings
+	for _, hScale := range r.Spec.HorizontalScalingList {
+		compSpec := cluster.Spec.GetComponentByName(hScale.ComponentName)
+		if compSpec == nil {
+			continue
+		}
+		scaleInReplicas := getScaleInReplicas(hScale, *compSpec)
+		if scaleInReplicas <= 0 {
+			continue
+		}
+		members, ok, err := getQuorumMembers(ctx, cli, cluster, compSpec.Name)
+		if err != nil || !ok {
+			continue
+		}
+		target := members.Voters - scaleInReplicas
+		if members.CheckScaleIn(target) != nil {
+			warnings = append(warnings, fmt.Sprintf(`scaling in component "%s" to %d voting members loses the quorum of %d, %d of %d voting members are available`,
+				compSpec.Name, target, quorum.Size(target), members.Available, members.Voters))
+		}
+	}
+	return warnings
+}
+
+// singleReplicaRestartWarnings warns about the restarted components which have a single replica,
+// they stop serving until the replica is restarted.
+func (r *OpsRequest) singleReplicaRestartWarnings(cluster *Cluster) admission.Warnings {
+	var warnings admission.Warnings
+	for _, v := range r.Spec.RestartList {
+		if cluster.Spec.GetComponentByName(v.ComponentName) == nil && cluster.Spec.GetShardingByName(v.ComponentName) == nil {
+			continue
+		}
+		if getImpactComponentReplicas(cluster, v.ComponentName) == 1 {
+			warnings = append(warnings, fmt.Sprintf(`component "%s" has a single replica, it stops serving until the replica is restarted`,
+				v.ComponentName))
+		}
+	}
+	return warnings
+}
+
+// storageQuotaWarnings warns if the storage requested by the volume expansion exceeds or is close to
+// the storage quotas of the namespace, in total or of the StorageClasses.
+func (r *OpsRequest) storageQuotaWarnings(ctx context.Context, cli client.Client) admission.Warnings {
+	if cli == nil || len(r.Spec.VolumeExpansionList) == 0 {
+		return nil
+	}
+	quotaList := &corev1.ResourceQuotaList{}
+	if err := cli.List(ctx, quotaList, client.InNamespace(r.Namespace)); err != nil || len(quotaList.Items) == 0 {
+		return nil
+	}
+	increases, err := r.getStorageIncreases(ctx, cli)
+	if err != nil || len(increases) == 0 {
+		return nil
+	}
+	var warnings admission.Warnings
+	for _, quota := range quotaList.Items {
+		for _, name := range sets.List(sets.KeySet(increases)) {
+			hard, ok := quota.Status.Hard[name]
+			if !ok {
+				continue
+			}
+			used := quota.Status.Used[name]
+			requested := used.DeepCopy()
+			requested.Add(increases[name])
+			switch {
+			case requested.Cmp(hard) > 0:
+				warnings = append(warnings, fmt.Sprintf(`the volume expansion requests %s of "%s", which exceeds the limit %s of ResourceQuota "%s", the expansion of the volumes will be rejected`,
+					requested.String(), name, hard.String(), quota.Name))
+			case requested.AsApproximateFloat64() >= hard.AsApproximateFloat64()*storageQuotaWarningRatio:
+				warnings = append(warnings, fmt.Sprintf(`the volume expansion requests %s of "%s", which is close to the limit %s of ResourceQuota "%s"`,
+					requested.String(), name, hard.String(), quota.Name))
+			}
+		}
+	}
+	return warnings
+}
+
+// getStorageIncreases returns the storage increased by the volume expansion, keyed by the quota resource names
+// of the total storage and of the StorageClasses.
+func (r *OpsRequest) getStorageIncreases(ctx context.Context, cli client.Client) (map[corev1.ResourceName]resource.Quantity, error) {
+	increases := map[corev1.ResourceName]resource.Quantity{}
+	err := r.forEachExpandedPVC(ctx, cli, func(pvc corev1.PersistentVolumeClaim, requestStorage resource.Quantity) {
+		increase := getStorageIncrease(pvc, requestStorage)
+		if increase.Sign() <= 0 {
+			return
+		}
+		names := []corev1.ResourceName{corev1.ResourceRequestsStorage}
+		if pvc.Spec.StorageClassName != nil && *pvc.Spec.StorageClassName != "" {
+			names = append(names, corev1.ResourceName(*pvc.Spec.StorageClassName+".storageclass.storage.k8s.io/"+string(corev1.ResourceRequestsStorage)))
+		}
+		for _, name := range names {
+			total := increases[name]
+			total.Add(increase)
+			increases[name] = total
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return increases, nil
+}
+
+// getStorageIncrease returns the storage requested additionally for the PVC.
+func getStorageIncrease(pvc corev1.PersistentVolumeClaim, requestStorage resource.Quantity) resource.Quantity {
+	increase := requestStorage.DeepCopy()
+	increase.Sub(*pvc.Status.Capacity.Storage())
+	return increase
+}
+
+// forEachExpandedPVC calls the function with each PVC expanded by the volume expansion and the storage requested for it.
+func (r *OpsRequest) forEachExpandedPVC(ctx context.Context, cli client.Client,
+	fn func(pvc corev1.PersistentVolumeClaim, requestStorage resource.Quantity)) error {
+	for _, volumeExpansion := range r.Spec.VolumeExpansionList {
+		// the volumes of the instance templates are expanded by the instance-level volumeClaimTemplates if specified.
+		instanceVCTs := map[string]map[string]resource.Quantity{}
+		for _, ins := range volumeExpansion.Instances {
+			for _, vct := range ins.VolumeClaimTemplates {
+				if instanceVCTs[vct.Name] == nil {
+					instanceVCTs[vct.Name] = map[string]resource.Quantity{}
+				}
+				instanceVCTs[vct.Name][ins.Name] = vct.Storage
+			}
+		}
+		vctNames := sets.New[string]()
+		componentVCTs := map[string]resource.Quantity{}
+		for _, vct := range volumeExpansion.VolumeClaimTemplates {
+			vctNames.Insert(vct.Name)
+			componentVCTs[vct.Name] = vct.Storage
+		}
+		vctNames.Insert(sets.List(sets.KeySet(instanceVCTs))...)
+		for _, vctName := range sets.List(vctNames) {
+			pvcList := &corev1.PersistentVolumeClaimList{}
+			if err := cli.List(ctx, pvcList, client.InNamespace(r.Namespace), client.MatchingLabels{
+				constant.AppInstanceLabelKey:             r.Spec.GetClusterName(),
+				constant.VolumeClaimTemplateNameLabelKey: vctName,
+			}); err != nil {
+				return err
+			}
+			for _, pvc := range pvcList.Items {
+				if pvc.Labels[constant.KBAppComponentLabelKey] != volumeExpansion.ComponentName &&
+					pvc.Labels[constant.KBAppShardingNameLabelKey] != volumeExpansion.ComponentName {
+					continue
+				}
+				if requestStorage, ok := instanceVCTs[vctName][pvc.Labels[constant.KBAppComponentInstanceTemplateLabelKey]]; ok {
+					fn(pvc, requestStorage)
+				} else if requestStorage, ok = componentVCTs[vctName]; ok {
+					fn(pvc, requestStorage)
+				}
+			}
+		}
+	}
+	return nil
+}
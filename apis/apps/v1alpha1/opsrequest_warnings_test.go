@@ -0,0 +1,157 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestRiskWarnings(t *testing.T) {
+	cluster := &Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mycluster"},
+		Spec: ClusterSpec{
+			ComponentSpecs: []ClusterComponentSpec{
+				{Name: "mysql", Replicas: 3},
+				{Name: "proxy", Replicas: 1},
+			},
+		},
+	}
+	its := &workloads.InstanceSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mycluster-mysql"},
+		Spec: workloads.InstanceSetSpec{
+			Replicas: pointer.Int32(3),
+			Roles:    []workloads.ReplicaRole{{Name: "leader", CanVote: true}, {Name: "follower", CanVote: true}},
+		},
+	}
+	newPod := func(name, role string, ready bool) *corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				Labels: map[string]string{
+					constant.AppInstanceLabelKey:    "mycluster",
+					constant.KBAppComponentLabelKey: "mysql",
+					constant.RoleLabelKey:           role,
+				},
+			},
+			Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}},
+		}
+	}
+	newPVC := func(name, capacity string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				Labels: map[string]string{
+					constant.AppInstanceLabelKey:             "mycluster",
+					constant.KBAppComponentLabelKey:          "mysql",
+					constant.VolumeClaimTemplateNameLabelKey: "data",
+				},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{StorageClassName: pointer.String("fast")},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(capacity)},
+			},
+		}
+	}
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "storage"},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{"fast.storageclass.storage.k8s.io/requests.storage": resource.MustParse("100Gi")},
+			Used: corev1.ResourceList{"fast.storageclass.storage.k8s.io/requests.storage": resource.MustParse("60Gi")},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{AddToScheme, workloads.AddToScheme, corev1.AddToScheme} {
+		if err := addToScheme(scheme); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(its, quota,
+		newPod("mycluster-mysql-0", "leader", true), newPod("mycluster-mysql-1", "follower", true), newPod("mycluster-mysql-2", "follower", false),
+		newPVC("data-mycluster-mysql-0", "10Gi"), newPVC("data-mycluster-mysql-1", "10Gi")).Build()
+
+	scaleIn := func(replicas int32, acknowledged bool) *OpsRequest {
+		return &OpsRequest{Spec: OpsRequestSpec{
+			Type:                  HorizontalScalingType,
+			Force:                 acknowledged,
+			AcknowledgeQuorumLoss: acknowledged,
+			HorizontalScalingList: []HorizontalScaling{{ComponentOps: ComponentOps{ComponentName: "mysql"}, Replicas: pointer.Int32(replicas)}},
+		}}
+	}
+	restart := func(compName string) *OpsRequest {
+		return &OpsRequest{Spec: OpsRequestSpec{Type: RestartType, RestartList: []ComponentOps{{ComponentName: compName}}}}
+	}
+	expand := func(storage string) *OpsRequest {
+		ops := &OpsRequest{Spec: OpsRequestSpec{
+			ClusterName: "mycluster",
+			Type:        VolumeExpansionType,
+			VolumeExpansionList: []VolumeExpansion{{
+				ComponentOps:         ComponentOps{ComponentName: "mysql"},
+				VolumeClaimTemplates: []OpsRequestVolumeClaimTemplate{{Name: "data", Storage: resource.MustParse(storage)}},
+			}},
+		}}
+		ops.Namespace = "default"
+		return ops
+	}
+
+	tests := []struct {
+		name    string
+		ops     *OpsRequest
+		warning string
+	}{
+		{"scale out", scaleIn(4, true), ""},
+		{"scale in rejected without acknowledgement", scaleIn(2, false), ""},
+		{"scale in below quorum", scaleIn(2, true), `scaling in component "mysql" to 2 voting members loses the quorum of 2, 2 of 3`},
+		{"restart multiple replicas", restart("mysql"), ""},
+		{"restart single replica", restart("proxy"), `component "proxy" has a single replica`},
+		{"expand volumes far from quota", expand("15Gi"), ""},
+		{"expand volumes close to quota", expand("25Gi"), "which is close to the limit 100Gi"},
+		{"expand volumes over quota", expand("40Gi"), "which exceeds the limit 100Gi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := tt.ops.riskWarnings(context.Background(), cli, cluster)
+			if tt.warning == "" {
+				if len(warnings) > 0 {
+					t.Errorf("unexpected warnings: %v", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || !strings.Contains(warnings[0], tt.warning) {
+				t.Errorf("expect warning containing %q, but got: %v", tt.warning, warnings)
+			}
+		})
+	}
+}
@@ -22,23 +22,35 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/kberrors"
+	"github.com/apecloud/kubeblocks/pkg/openapischema"
+	"github.com/apecloud/kubeblocks/pkg/opsvalidation"
+	"github.com/apecloud/kubeblocks/pkg/quorum"
 )
 
 const (
@@ -59,6 +71,121 @@ func (r *OpsRequest) SetupWebhookWithManager(mgr ctrl.Manager) error {
 		Complete()
 }
 
+// +kubebuilder:webhook:path=/mutate-apps-kubeblocks-io-v1alpha1-opsrequest,mutating=true,failurePolicy=fail,sideEffects=None,groups=apps.kubeblocks.io,resources=opsrequests,verbs=create,versions=v1alpha1,name=mopsrequest.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &OpsRequest{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type.
+// It fills the fields left empty with the OpsRequestDefaults of the Cluster on creation,
+// and normalizes the OpsRequest in the same way as the ones created by kbcli.
+func (r *OpsRequest) Default() {
+	opsRequestLog.Info("default", "name", r.Name)
+	defer r.applyBuiltinDefaults()
+	if webhookMgr == nil || webhookMgr.client == nil {
+		return
+	}
+	cluster, err := r.getCluster(context.Background(), webhookMgr.client)
+	if err != nil || cluster == nil {
+		// leave the error of the missing cluster to the validating webhook,
+		// and the cluster to be created by the OpsRequest is labeled by its action.
+		return
+	}
+	r.applyDefaults(cluster.Spec.OpsRequestDefaults)
+	r.applyClusterLabels(cluster)
+}
+
+// applyDefaults fills the fields left empty with the defaults, and records the defaulted fields in the annotations.
+func (r *OpsRequest) applyDefaults(defaults *OpsRequestDefaults) {
+	if defaults == nil {
+		return
+	}
+	var defaultedFields []string
+	if r.Spec.TTLSecondsAfterSucceed == 0 && defaults.TTLSecondsAfterSucceed != nil {
+		r.Spec.TTLSecondsAfterSucceed = *defaults.TTLSecondsAfterSucceed
+		defaultedFields = append(defaultedFields, "ttlSecondsAfterSucceed")
+	}
+	// preConditionDeadlineSeconds is defaulted to 0 by the CRD schema before the mutating webhooks.
+	if (r.Spec.PreConditionDeadlineSeconds == nil || *r.Spec.PreConditionDeadlineSeconds == 0) &&
+		defaults.PreConditionDeadlineSeconds != nil {
+		deadlineSeconds := *defaults.PreConditionDeadlineSeconds
+		r.Spec.PreConditionDeadlineSeconds = &deadlineSeconds
+		defaultedFields = append(defaultedFields, "preConditionDeadlineSeconds")
+	}
+	if r.Spec.FailurePolicy == "" && defaults.FailurePolicy != "" {
+		r.Spec.FailurePolicy = defaults.FailurePolicy
+		defaultedFields = append(defaultedFields, "failurePolicy")
+	}
+	if len(defaultedFields) == 0 {
+		return
+	}
+	if r.Annotations == nil {
+		r.Annotations = map[string]string{}
+	}
+	r.Annotations[constant.OpsDefaultedFieldsAnnotationKey] = strings.Join(defaultedFields, ",")
+}
+
+// applyBuiltinDefaults fills the fields which are still empty after the defaults of the Cluster are applied,
+// and the requests of the resources to be scaled default to their limits as the Pods do.
+func (r *OpsRequest) applyBuiltinDefaults() {
+	// TODO: remove it after 0.9.0
+	if r.Spec.ClusterName == "" {
+		r.Spec.ClusterName = r.Spec.ClusterRef
+	}
+	if r.Spec.PreConditionDeadlineSeconds == nil {
+		r.Spec.PreConditionDeadlineSeconds = pointer.Int32(0)
+	}
+	for i := range r.Spec.VerticalScalingList {
+		verticalScaling := &r.Spec.VerticalScalingList[i]
+		defaultResourceRequests(&verticalScaling.ResourceRequirements)
+		for j := range verticalScaling.Instances {
+			defaultResourceRequests(&verticalScaling.Instances[j].ResourceRequirements)
+		}
+	}
+}
+
+// applyClusterLabels labels the OpsRequest with the name of the Cluster and the type of the operation,
+// and copies the labels of the Cluster which are not managed by KubeBlocks or Kubernetes.
+func (r *OpsRequest) applyClusterLabels(cluster *Cluster) {
+	if r.Labels == nil {
+		r.Labels = map[string]string{}
+	}
+	for k, v := range cluster.Labels {
+		if _, ok := r.Labels[k]; ok || isManagedLabelKey(k) {
+			continue
+		}
+		r.Labels[k] = v
+	}
+	r.Labels[constant.AppInstanceLabelKey] = cluster.Name
+	r.Labels[constant.OpsRequestTypeLabelKey] = string(r.Spec.Type)
+}
+
+// isManagedLabelKey checks whether the label key is prefixed by the domains of KubeBlocks or Kubernetes.
+func isManagedLabelKey(key string) bool {
+	prefix, _, found := strings.Cut(key, "/")
+	if !found {
+		return false
+	}
+	for _, domain := range []string{"kubeblocks.io", "kubernetes.io", "k8s.io"} {
+		if prefix == domain || strings.HasSuffix(prefix, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultResourceRequests sets the requests of the resources to their limits if not specified.
+func defaultResourceRequests(resources *corev1.ResourceRequirements) {
+	for name, limit := range resources.Limits {
+		if _, ok := resources.Requests[name]; ok {
+			continue
+		}
+		if resources.Requests == nil {
+			resources.Requests = corev1.ResourceList{}
+		}
+		resources.Requests[name] = limit.DeepCopy()
+	}
+}
+
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
 // +kubebuilder:webhook:path=/validate-apps-kubeblocks-io-v1alpha1-opsrequest,mutating=false,failurePolicy=fail,sideEffects=None,groups=apps.kubeblocks.io,resources=opsrequests,verbs=create;update,versions=v1alpha1,name=vopsrequest.kb.io,admissionReviewVersions=v1
 
@@ -67,13 +194,17 @@ var _ webhook.Validator = &OpsRequest{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *OpsRequest) ValidateCreate() (admission.Warnings, error) {
 	opsRequestLog.Info("validate create", "name", r.Name)
-	return nil, r.validateEntry(true)
+	if err := r.validateSchedule(time.Now()); err != nil {
+		return nil, err
+	}
+	return r.validateEntry(nil)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *OpsRequest) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	opsRequestLog.Info("validate update", "name", r.Name)
-	lastOpsRequest := old.(*OpsRequest).DeepCopy()
+	oldOpsRequest := old.(*OpsRequest)
+	lastOpsRequest := oldOpsRequest.DeepCopy()
 	// if no spec updated, we should skip validation.
 	// if not, we can not delete the OpsRequest when cluster has been deleted.
 	// because when cluster not existed, r.validate will report an error.
@@ -87,10 +218,14 @@ func (r *OpsRequest) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 
 	// Keep the cancel consistent between the two opsRequest for comparing the diff.
 	lastOpsRequest.Spec.Cancel = r.Spec.Cancel
+	// the migration of node pool can be paused and resumed.
+	if lastOpsRequest.Spec.MigrateNodePool != nil && r.Spec.MigrateNodePool != nil {
+		lastOpsRequest.Spec.MigrateNodePool.Paused = r.Spec.MigrateNodePool.Paused
+	}
 	if !reflect.DeepEqual(lastOpsRequest.Spec, r.Spec) && r.Status.Phase != "" {
 		return nil, fmt.Errorf("update OpsRequest: %s is forbidden except for cancel when status.Phase is %s", r.Name, r.Status.Phase)
 	}
-	return nil, r.validateEntry(false)
+	return r.validateEntry(oldOpsRequest)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -102,7 +237,7 @@ func (r *OpsRequest) ValidateDelete() (admission.Warnings, error) {
 // IsComplete checks if opsRequest has been completed.
 func (r *OpsRequest) IsComplete(phases ...OpsPhase) bool {
 	completedPhase := func(phase OpsPhase) bool {
-		return slices.Contains([]OpsPhase{OpsCancelledPhase, OpsSucceedPhase, OpsAbortedPhase, OpsFailedPhase}, phase)
+		return slices.Contains([]OpsPhase{OpsCancelledPhase, OpsSucceedPhase, OpsSucceedWithWarningsPhase, OpsAbortedPhase, OpsFailedPhase}, phase)
 	}
 	if len(phases) == 0 {
 		return completedPhase(r.Status.Phase)
@@ -117,8 +252,33 @@ func (r *OpsRequest) IsComplete(phases ...OpsPhase) bool {
 
 // Force checks if the current opsRequest can be forcibly executed
 func (r *OpsRequest) Force() bool {
+	if !r.Spec.Force {
+		return false
+	}
+	if opsBehaviour, ok := OpsRequestBehaviourMapper[r.Spec.Type]; ok {
+		return !opsBehaviour.ForceDisabled
+	}
 	// ops of type 'Start' do not support force execution.
-	return r.Spec.Force && r.Spec.Type != StartType
+	return r.Spec.Type != StartType
+}
+
+// WaitsForMaintenanceWindow checks if the OpsRequest has a schedule and its maintenance window has not opened yet.
+func (r *OpsRequest) WaitsForMaintenanceWindow() bool {
+	return r.Spec.Schedule != nil && !meta.IsStatusConditionTrue(r.Status.Conditions, ConditionTypeScheduled)
+}
+
+// GetDependentOps returns the names of the OpsRequests that the OpsRequest depends on,
+// declared by spec.dependsOn or the annotation "ops.kubeblocks.io/dependent-on-successful-ops".
+func (r *OpsRequest) GetDependentOps() []string {
+	names := slices.Clone(r.Spec.DependsOn)
+	if dependentOps := r.Annotations[constant.OpsDependentOnSuccessfulOpsAnnoKey]; dependentOps != "" {
+		for _, name := range strings.Split(dependentOps, ",") {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
 }
 
 // validateClusterPhase validates whether the current cluster state supports the OpsRequest
@@ -200,62 +360,286 @@ func (r *OpsRequest) Validate(ctx context.Context,
 	return r.validateOps(ctx, k8sClient, cluster)
 }
 
-// ValidateEntry OpsRequest webhook validate entry
-func (r *OpsRequest) validateEntry(isCreate bool) error {
+// ValidateEntry OpsRequest webhook validate entry, lastOpsRequest is nil on creation.
+func (r *OpsRequest) validateEntry(lastOpsRequest *OpsRequest) (admission.Warnings, error) {
 	if webhookMgr == nil || webhookMgr.client == nil {
-		return nil
+		return nil, nil
 	}
 	ctx := context.Background()
 	k8sClient := webhookMgr.client
 	cluster, err := r.getCluster(ctx, k8sClient)
+	if err != nil {
+		return nil, err
+	}
+	isCreate := lastOpsRequest == nil
+	if isCreate {
+		if cluster != nil && !cluster.DeletionTimestamp.IsZero() {
+			return nil, kberrors.New(kberrors.ReasonClusterTerminating, `the cluster "%s" is being deleted`, cluster.Name)
+		}
+		if cluster != nil && cluster.Spec.Paused {
+			return nil, kberrors.New(kberrors.ReasonClusterPaused, `the cluster "%s" is paused`, cluster.Name).
+				WithHint(`resume the cluster by setting "spec.paused" to false`)
+		}
+		if err = r.validateDependsOn(ctx, k8sClient); err != nil {
+			return nil, err
+		}
+		if err = r.validateAssertions(cluster); err != nil {
+			return nil, err
+		}
+	}
+	// the cluster phase of a scheduled OpsRequest is checked when its maintenance window opens
+	if err = r.Validate(ctx, k8sClient, cluster, isCreate && !r.WaitsForMaintenanceWindow()); err != nil {
+		return nil, err
+	}
+	if err = r.validatePolicies(ctx, k8sClient, cluster, lastOpsRequest); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	warnings := r.freezeWindowWarnings(cluster, now)
+	warnings = append(warnings, r.maintenanceWindowWarnings(cluster, now)...)
+	if isCreate {
+		warnings = append(warnings, r.impactWarnings(ctx, k8sClient, cluster)...)
+	}
+	warnings = append(warnings, r.riskWarnings(ctx, k8sClient, cluster)...)
+	return warnings, nil
+}
+
+// validateSchedule validates the maintenance window of the OpsRequest, which must be in the future on creation.
+func (r *OpsRequest) validateSchedule(now time.Time) error {
+	if r.Spec.Schedule == nil {
+		return nil
+	}
+	if err := r.Spec.Schedule.Validate(now); err != nil {
+		return fmt.Errorf("invalid spec.schedule: %v", err)
+	}
+	return nil
+}
+
+// validateAssertions validates that the Components asserted by spec.assertions exist in the cluster.
+func (r *OpsRequest) validateAssertions(cluster *Cluster) error {
+	if r.Spec.Assertions == nil {
+		return nil
+	}
+	path := field.NewPath("spec", "assertions", "components")
+	targets := make([]opsvalidation.Target, len(r.Spec.Assertions.Components))
+	for i, assertion := range r.Spec.Assertions.Components {
+		targets[i] = opsvalidation.Target{Path: path.Index(i), ComponentName: assertion.ComponentName}
+	}
+	return NewOpsTopology(cluster).ValidateTargets(targets).ToAggregate()
+}
+
+// validateDependsOn validates that the OpsRequests the OpsRequest depends on exist, and none of them depends on
+// the OpsRequest in turn, directly or indirectly.
+func (r *OpsRequest) validateDependsOn(ctx context.Context, cli client.Client) error {
+	if len(r.Spec.DependsOn) == 0 {
+		return nil
+	}
+	getDependentOps := func(name string) ([]string, error) {
+		ops := &OpsRequest{}
+		if err := cli.Get(ctx, types.NamespacedName{Namespace: r.Namespace, Name: name}, ops); err != nil {
+			return nil, err
+		}
+		return ops.GetDependentOps(), nil
+	}
+	for _, name := range r.Spec.DependsOn {
+		if name == r.Name {
+			return fmt.Errorf("spec.dependsOn: the OpsRequest can not depend on itself")
+		}
+		if _, err := getDependentOps(name); err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf(`spec.dependsOn: the OpsRequest "%s" is not found`, name)
+			}
+			return err
+		}
+	}
+	cycle, err := findOpsDependencyCycle(r.Name, r.GetDependentOps(), getDependentOps)
 	if err != nil {
 		return err
 	}
-	return r.Validate(ctx, k8sClient, cluster, isCreate)
+	if len(cycle) > 0 {
+		return fmt.Errorf("spec.dependsOn: a dependency cycle is found: %s", strings.Join(cycle, " -> "))
+	}
+	return nil
+}
+
+// findOpsDependencyCycle walks the dependencies of the OpsRequest name, and returns the first path leading back to it.
+// The OpsRequests not found are considered to have no dependencies.
+func findOpsDependencyCycle(name string, dependsOn []string, getDependentOps func(name string) ([]string, error)) ([]string, error) {
+	visited := sets.New[string]()
+	var walk func(path []string, dependsOn []string) ([]string, error)
+	walk = func(path []string, dependsOn []string) ([]string, error) {
+		for _, dep := range dependsOn {
+			if dep == name {
+				return append(slices.Clone(path), dep), nil
+			}
+			if visited.Has(dep) {
+				continue
+			}
+			visited.Insert(dep)
+			deps, err := getDependentOps(dep)
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			if cycle, err := walk(append(path, dep), deps); err != nil || len(cycle) > 0 {
+				return cycle, err
+			}
+		}
+		return nil, nil
+	}
+	return walk([]string{name}, dependsOn)
+}
+
+// validatePolicies validates the OpsRequest against the ValidationPolicies targeting OpsRequests.
+func (r *OpsRequest) validatePolicies(ctx context.Context, cli client.Client, cluster *Cluster, lastOpsRequest *OpsRequest) error {
+	if lastOpsRequest == nil {
+		return enforceValidationPolicies(ctx, cli, ValidationTargetOpsRequest, ValidationOperationCreate, r, nil, cluster)
+	}
+	return enforceValidationPolicies(ctx, cli, ValidationTargetOpsRequest, ValidationOperationUpdate, r, lastOpsRequest, cluster)
+}
+
+// impactWarnings surfaces the expected impact of the OpsRequest on the availability of the Cluster.
+func (r *OpsRequest) impactWarnings(ctx context.Context, cli client.Client, cluster *Cluster) admission.Warnings {
+	impact, message := r.ClassifyImpact(ctx, cli, cluster)
+	if impact == "" {
+		return nil
+	}
+	return admission.Warnings{fmt.Sprintf(`the impact of the OpsRequest is expected to be "%s": %s`, impact, message)}
+}
+
+// freezeWindowWarnings warns that the OpsRequest will be deferred if the cluster is in a freeze window.
+func (r *OpsRequest) freezeWindowWarnings(cluster *Cluster, now time.Time) admission.Warnings {
+	if r.Spec.Force {
+		return nil
+	}
+	window, end := cluster.Spec.GetActiveFreezeWindow(now)
+	if window == nil {
+		return nil
+	}
+	return admission.Warnings{fmt.Sprintf(`cluster "%s" is in the freeze window "%s", the OpsRequest will be deferred until %s, set "spec.force" to run it immediately`,
+		cluster.Name, window.Name, end.Format(time.RFC3339))}
+}
+
+// maintenanceWindowWarnings warns that the OpsRequest will be deferred if the cluster is out of its maintenance windows.
+func (r *OpsRequest) maintenanceWindowWarnings(cluster *Cluster, now time.Time) admission.Warnings {
+	if r.Spec.Force || r.Spec.Schedule != nil || r.Spec.DryRun {
+		return nil
+	}
+	start, _, found := cluster.Spec.GetMaintenanceWindow(now)
+	if !found || !now.Before(start) {
+		return nil
+	}
+	return admission.Warnings{fmt.Sprintf(`cluster "%s" is out of its maintenance windows, the OpsRequest will be deferred until %s, set "spec.force" to run it immediately`,
+		cluster.Name, start.Format(time.RFC3339))}
 }
 
 // validateOps validates ops attributes
 func (r *OpsRequest) validateOps(ctx context.Context,
 	k8sClient client.Client,
 	cluster *Cluster) error {
+	if err := LintOpsRequestSpec(cluster, r.Spec).ToAggregate(); err != nil {
+		return err
+	}
 	// Check whether the corresponding attribute is legal according to the operation type
 	switch r.Spec.Type {
 	case UpgradeType:
 		return r.validateUpgrade(ctx, k8sClient, cluster)
 	case VerticalScalingType:
-		return r.validateVerticalScaling(cluster)
+		return r.validateVerticalScaling(ctx, k8sClient, cluster)
 	case HorizontalScalingType:
 		return r.validateHorizontalScaling(ctx, k8sClient, cluster)
+	case StopType:
+		return r.validateStop(ctx, k8sClient, cluster)
+	case StartType:
+		return r.validateStart(ctx, k8sClient, cluster)
 	case VolumeExpansionType:
 		return r.validateVolumeExpansion(ctx, k8sClient, cluster)
-	case RestartType:
-		return r.validateRestart(cluster)
 	case ReconfiguringType:
 		return r.validateReconfigure(ctx, k8sClient, cluster)
 	case SwitchoverType:
 		return r.validateSwitchover(ctx, k8sClient, cluster)
 	case DataScriptType:
-		return r.validateDataScript(ctx, k8sClient, cluster)
+		return r.validateDataScript(ctx, k8sClient)
 	case ExposeType:
-		return r.validateExpose(ctx, cluster)
+		return r.validateExpose(ctx, k8sClient, cluster)
+	case BackupType:
+		return r.validateBackup(ctx, k8sClient, cluster)
 	case RebuildInstanceType:
-		return r.validateRebuildInstance(cluster)
+		return r.validateRebuildInstance(ctx, k8sClient, cluster)
+	case DebugInstanceType:
+		return r.validateDebugInstance()
+	case MigrateNodePoolType:
+		return r.validateMigrateNodePool(cluster)
+	case RotateTLSType:
+		return r.validateRotateTLS(cluster)
+	case PurgeType:
+		return r.validatePurge(cluster)
+	case RotateCredentialsType:
+		return r.validateRotateCredentials(ctx, k8sClient, cluster)
+	case ShardRebalanceType:
+		return r.validateShardRebalance(ctx, k8sClient, cluster)
+	case MigrateInstanceType:
+		return r.validateMigrateInstance(ctx, k8sClient, cluster)
+	case RestoreType:
+		return r.validateRestore(ctx, k8sClient, cluster)
+	case CustomType:
+		return r.validateCustom(ctx, k8sClient)
 	}
 	return nil
 }
 
+// validateBackup validates the backup api when spec.type is Backup.
+// The default backup policy and method are resolved when the OpsRequest runs, only the specified ones are validated here.
+func (r *OpsRequest) validateBackup(ctx context.Context, cli client.Client, cluster *Cluster) error {
+	backupSpec := r.Spec.GetBackup()
+	if backupSpec == nil {
+		return nil
+	}
+	if _, err := dpv1alpha1.RetentionPeriod(backupSpec.RetentionPeriod).ToDuration(); err != nil {
+		return fmt.Errorf(`invalid retention period "%s": %s`, backupSpec.RetentionPeriod, err.Error())
+	}
+	// the backup policy is only checked before the OpsRequest starts.
+	if backupSpec.BackupPolicyName == "" || cli == nil || (r.Status.Phase != "" && r.Status.Phase != OpsPendingPhase) {
+		return nil
+	}
+	backupPolicy := &dpv1alpha1.BackupPolicy{}
+	if err := cli.Get(ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: backupSpec.BackupPolicyName}, backupPolicy); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf(`backup policy "%s" not found`, backupSpec.BackupPolicyName)
+		}
+		return err
+	}
+	if backupPolicy.Labels[constant.AppInstanceLabelKey] != cluster.Name {
+		return fmt.Errorf(`backup policy "%s" does not belong to cluster "%s"`, backupSpec.BackupPolicyName, cluster.Name)
+	}
+	if backupPolicy.Spec.BackupRepoName != nil && *backupPolicy.Spec.BackupRepoName != "" {
+		if err := checkBackupRepoAccess(ctx, cli, *backupPolicy.Spec.BackupRepoName, r.Namespace); err != nil {
+			return err
+		}
+	}
+	if backupSpec.BackupMethod == "" {
+		return nil
+	}
+	for _, method := range backupPolicy.Spec.BackupMethods {
+		if method.Name == backupSpec.BackupMethod {
+			return nil
+		}
+	}
+	return fmt.Errorf(`backup method "%s" is not defined in backup policy "%s"`, backupSpec.BackupMethod, backupSpec.BackupPolicyName)
+}
+
 // validateExpose validates expose api when spec.type is Expose
-func (r *OpsRequest) validateExpose(_ context.Context, cluster *Cluster) error {
+func (r *OpsRequest) validateExpose(ctx context.Context, cli client.Client, cluster *Cluster) error {
 	exposeList := r.Spec.ExposeList
 	if exposeList == nil {
-		return notEmptyError("spec.expose")
+		return opsvalidation.NotEmptyError("spec.expose")
 	}
 
-	var compOpsList []ComponentOps
 	counter := 0
 	for _, v := range exposeList {
 		if len(v.ComponentName) > 0 {
-			compOpsList = append(compOpsList, ComponentOps{ComponentName: v.ComponentName})
 			continue
 		} else {
 			counter++
@@ -271,28 +655,387 @@ func (r *OpsRequest) validateExpose(_ context.Context, cluster *Cluster) error {
 			}
 		}
 	}
-	return r.checkComponentExistence(cluster, compOpsList)
+	return r.validateExposeServices(ctx, cli, cluster)
 }
 
-func (r *OpsRequest) validateRebuildInstance(cluster *Cluster) error {
+// validateRebuildInstance validates spec.rebuildFrom, the instances to rebuild must be the members of the components,
+// and there must be a healthy peer to rebuild them from if no backup is specified.
+func (r *OpsRequest) validateRebuildInstance(ctx context.Context, cli client.Client, cluster *Cluster) error {
 	rebuildFrom := r.Spec.RebuildFrom
 	if len(rebuildFrom) == 0 {
-		return notEmptyError("spec.rebuildFrom")
+		return opsvalidation.NotEmptyError("spec.rebuildFrom")
+	}
+	instanceNames := map[string]bool{}
+	for i, v := range rebuildFrom {
+		if len(v.Instances) == 0 {
+			return opsvalidation.NotEmptyError(fmt.Sprintf("spec.rebuildFrom[%d].instances", i))
+		}
+		for _, ins := range v.Instances {
+			if instanceNames[ins.Name] {
+				return fmt.Errorf(`instance "%s" is duplicated in spec.rebuildFrom`, ins.Name)
+			}
+			instanceNames[ins.Name] = true
+		}
+	}
+	// the membership is only checked before the OpsRequest starts, as the instances are recreated during the operation.
+	if cli == nil || (r.Status.Phase != "" && r.Status.Phase != OpsPendingPhase) {
+		return nil
 	}
-	var compOpsList []ComponentOps
 	for _, v := range rebuildFrom {
-		compOpsList = append(compOpsList, v.ComponentOps)
+		if err := r.validateRebuildInstanceMembership(ctx, cli, cluster, v); err != nil {
+			return err
+		}
 	}
-	return r.checkComponentExistence(cluster, compOpsList)
+	return nil
 }
 
-// validateUpgrade validates spec.restart
-func (r *OpsRequest) validateRestart(cluster *Cluster) error {
-	restartList := r.Spec.RestartList
-	if len(restartList) == 0 {
-		return notEmptyError("spec.restart")
+func (r *OpsRequest) validateRebuildInstanceMembership(ctx context.Context,
+	cli client.Client,
+	cluster *Cluster,
+	rebuildInstance RebuildInstance) error {
+	matchingLabels := client.MatchingLabels{constant.AppInstanceLabelKey: cluster.Name}
+	if cluster.Spec.GetShardingByName(rebuildInstance.ComponentName) != nil {
+		matchingLabels[constant.KBAppShardingNameLabelKey] = rebuildInstance.ComponentName
+	} else {
+		matchingLabels[constant.KBAppComponentLabelKey] = rebuildInstance.ComponentName
+	}
+	podList := &corev1.PodList{}
+	if err := cli.List(ctx, podList, client.InNamespace(cluster.Namespace), matchingLabels); err != nil {
+		return err
 	}
-	return r.checkComponentExistence(cluster, restartList)
+	members := map[string]*corev1.Pod{}
+	for i := range podList.Items {
+		members[podList.Items[i].Name] = &podList.Items[i]
+	}
+	rebuilt := map[string]bool{}
+	for _, ins := range rebuildInstance.Instances {
+		if _, ok := members[ins.Name]; !ok {
+			return fmt.Errorf(`instance "%s" is not a member of component "%s"`, ins.Name, rebuildInstance.ComponentName)
+		}
+		rebuilt[ins.Name] = true
+	}
+	if rebuildInstance.BackupName != "" || r.Spec.Force {
+		return nil
+	}
+	// the instances rebuilt with empty volumes sync the data from a healthy peer, which is in the same shard for sharding.
+	healthyComps := map[string]bool{}
+	for name, pod := range members {
+		if !rebuilt[name] && isPodReady(pod) {
+			healthyComps[pod.Labels[constant.KBAppComponentLabelKey]] = true
+		}
+	}
+	for name := range rebuilt {
+		if !healthyComps[members[name].Labels[constant.KBAppComponentLabelKey]] {
+			return fmt.Errorf(`there is no healthy peer of instance "%s" to rebuild it from, please specify a backup`, name)
+		}
+	}
+	return nil
+}
+
+// validateDebugInstance validates spec.debugInstance
+func (r *OpsRequest) validateDebugInstance() error {
+	debugInstance := r.Spec.DebugInstance
+	if debugInstance == nil {
+		return opsvalidation.NotEmptyError("spec.debugInstance")
+	}
+	if debugInstance.InstanceName == "" {
+		return opsvalidation.NotEmptyError("spec.debugInstance.instanceName")
+	}
+	return nil
+}
+
+// validateMigrateNodePool validates spec.migrateNodePool
+func (r *OpsRequest) validateMigrateNodePool(cluster *Cluster) error {
+	migrateNodePool := r.Spec.MigrateNodePool
+	if migrateNodePool == nil {
+		return opsvalidation.NotEmptyError("spec.migrateNodePool")
+	}
+	if len(migrateNodePool.NodeSelector) == 0 {
+		return opsvalidation.NotEmptyError("spec.migrateNodePool.nodeSelector")
+	}
+	compSpec := cluster.Spec.GetComponentByName(migrateNodePool.ComponentName)
+	if compSpec == nil {
+		return fmt.Errorf(`component "%s" not found or it is a sharding component, which is not supported to migrate node pool`,
+			migrateNodePool.ComponentName)
+	}
+	// the pinned instances never follow the node selector of the component.
+	for _, instance := range compSpec.Instances {
+		if instance.NodeName != "" || len(instance.NodeSelector) > 0 {
+			return fmt.Errorf(`the instances of template "%s" are pinned to nodes, which is not supported to migrate node pool`, instance.Name)
+		}
+	}
+	return nil
+}
+
+// validateRotateTLS validates spec.rotateTLS
+func (r *OpsRequest) validateRotateTLS(cluster *Cluster) error {
+	rotateTLSList := r.Spec.RotateTLSList
+	if len(rotateTLSList) == 0 {
+		return opsvalidation.NotEmptyError("spec.rotateTLS")
+	}
+	for _, v := range rotateTLSList {
+		compSpec := cluster.Spec.GetComponentByName(v.ComponentName)
+		if compSpec == nil {
+			if shardingSpec := cluster.Spec.GetShardingByName(v.ComponentName); shardingSpec != nil {
+				compSpec = &shardingSpec.Template
+			}
+		}
+		if compSpec == nil {
+			return fmt.Errorf(`component "%s" not found`, v.ComponentName)
+		}
+		if !compSpec.TLS {
+			return fmt.Errorf(`TLS is not enabled for component "%s"`, v.ComponentName)
+		}
+	}
+	return nil
+}
+
+// validatePurge validates spec.purge
+func (r *OpsRequest) validatePurge(cluster *Cluster) error {
+	purgeList := r.Spec.PurgeList
+	if len(purgeList) == 0 {
+		return opsvalidation.NotEmptyError("spec.purge")
+	}
+	for _, v := range purgeList {
+		if cluster.Spec.GetComponentByName(v.ComponentName) == nil {
+			return fmt.Errorf(`component "%s" not found or it is a sharding component, which is not supported to purge`, v.ComponentName)
+		}
+		if v.RetainBytes != nil && v.RetainBytes.Sign() < 0 {
+			return fmt.Errorf(`retainBytes of component "%s" can not be negative`, v.ComponentName)
+		}
+		instancePrefix := fmt.Sprintf("%s-%s-", cluster.Name, v.ComponentName)
+		for _, insName := range v.Instances {
+			if !strings.HasPrefix(insName, instancePrefix) {
+				return fmt.Errorf(`instance "%s" does not belong to component "%s"`, insName, v.ComponentName)
+			}
+		}
+	}
+	return nil
+}
+
+// validateRotateCredentials validates spec.rotateCredentials, the accounts must be defined in the ComponentDefinition
+// and their passwords must be generated by KubeBlocks.
+func (r *OpsRequest) validateRotateCredentials(ctx context.Context, cli client.Client, cluster *Cluster) error {
+	rotateList := r.Spec.RotateCredentialsList
+	if len(rotateList) == 0 {
+		return opsvalidation.NotEmptyError("spec.rotateCredentials")
+	}
+	for _, v := range rotateList {
+		compSpec := cluster.Spec.GetComponentByName(v.ComponentName)
+		if compSpec == nil {
+			return fmt.Errorf(`component "%s" not found or it is a sharding component, which is not supported to rotate credentials`, v.ComponentName)
+		}
+		if compSpec.ComponentDef == "" {
+			return fmt.Errorf(`component "%s" does not reference a ComponentDefinition, which is not supported to rotate credentials`, v.ComponentName)
+		}
+		compDef, err := getComponentDefByName(ctx, cli, compSpec.ComponentDef)
+		if err != nil {
+			return err
+		}
+		if compDef.Spec.LifecycleActions == nil || compDef.Spec.LifecycleActions.AccountRotation == nil {
+			return fmt.Errorf(`the accountRotation action is not defined in ComponentDefinition "%s"`, compDef.Name)
+		}
+		for _, accountName := range v.Accounts {
+			if err = validateRotatedAccount(compDef, compSpec, accountName); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateShardRebalance validates spec.shardRebalance, the components must be shardings whose ComponentDefinition
+// defines the shardRebalance action.
+func (r *OpsRequest) validateShardRebalance(ctx context.Context, cli client.Client, cluster *Cluster) error {
+	rebalanceList := r.Spec.ShardRebalanceList
+	if len(rebalanceList) == 0 {
+		return opsvalidation.NotEmptyError("spec.shardRebalance")
+	}
+	for _, v := range rebalanceList {
+		shardingSpec := cluster.Spec.GetShardingByName(v.ComponentName)
+		if shardingSpec == nil {
+			return fmt.Errorf(`sharding "%s" not found, only the shardings are supported to rebalance shards`, v.ComponentName)
+		}
+		if shardingSpec.Template.ComponentDef == "" {
+			return fmt.Errorf(`sharding "%s" does not reference a ComponentDefinition, which is not supported to rebalance shards`, v.ComponentName)
+		}
+		compDef, err := getComponentDefByName(ctx, cli, shardingSpec.Template.ComponentDef)
+		if err != nil {
+			return err
+		}
+		if compDef.Spec.LifecycleActions == nil || compDef.Spec.LifecycleActions.ShardRebalance == nil {
+			return fmt.Errorf(`the shardRebalance action is not defined in ComponentDefinition "%s"`, compDef.Name)
+		}
+	}
+	return nil
+}
+
+// validateMigrateInstance validates spec.migrateInstance
+func (r *OpsRequest) validateMigrateInstance(ctx context.Context, cli client.Client, cluster *Cluster) error {
+	migrateInstance := r.Spec.MigrateInstance
+	if migrateInstance == nil {
+		return opsvalidation.NotEmptyError("spec.migrateInstance")
+	}
+	if migrateInstance.InstanceName == "" {
+		return opsvalidation.NotEmptyError("spec.migrateInstance.instanceName")
+	}
+	if (migrateInstance.TargetNodeName == "") == (migrateInstance.TargetZone == "") {
+		return fmt.Errorf("exactly one of spec.migrateInstance.targetNodeName and spec.migrateInstance.targetZone must be specified")
+	}
+	if migrateInstance.VolumePolicy == RecreateMigrateVolumePolicy && getImpactComponentReplicas(cluster, migrateInstance.ComponentName) <= 1 {
+		return fmt.Errorf(`component "%s" has no other replica to synchronize the data from, the volumes of the instance can not be recreated`,
+			migrateInstance.ComponentName)
+	}
+	// the instance and the target are only checked before the OpsRequest starts, as the instance is moved during the operation.
+	if cli == nil || (r.Status.Phase != "" && r.Status.Phase != OpsPendingPhase) {
+		return nil
+	}
+	pod := &corev1.Pod{}
+	if err := cli.Get(ctx, client.ObjectKey{Name: migrateInstance.InstanceName, Namespace: cluster.Namespace}, pod); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf(`instance "%s" is not a member of component "%s"`, migrateInstance.InstanceName, migrateInstance.ComponentName)
+		}
+		return err
+	}
+	compLabelKey := constant.KBAppComponentLabelKey
+	if cluster.Spec.GetShardingByName(migrateInstance.ComponentName) != nil {
+		compLabelKey = constant.KBAppShardingNameLabelKey
+	}
+	if pod.Labels[constant.AppInstanceLabelKey] != cluster.Name || pod.Labels[compLabelKey] != migrateInstance.ComponentName {
+		return fmt.Errorf(`instance "%s" is not a member of component "%s"`, migrateInstance.InstanceName, migrateInstance.ComponentName)
+	}
+
+	if migrateInstance.TargetNodeName != "" {
+		if pod.Spec.NodeName == migrateInstance.TargetNodeName {
+			return fmt.Errorf(`instance "%s" is already on node "%s"`, pod.Name, migrateInstance.TargetNodeName)
+		}
+		node := &corev1.Node{}
+		if err := cli.Get(ctx, client.ObjectKey{Name: migrateInstance.TargetNodeName}, node); err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf(`node "%s" not found`, migrateInstance.TargetNodeName)
+			}
+			return err
+		}
+		if node.Spec.Unschedulable {
+			return fmt.Errorf(`node "%s" is unschedulable`, migrateInstance.TargetNodeName)
+		}
+		return nil
+	}
+	nodeList := &corev1.NodeList{}
+	if err := cli.List(ctx, nodeList, client.MatchingLabels{corev1.LabelTopologyZone: migrateInstance.TargetZone}); err != nil {
+		return err
+	}
+	schedulable := false
+	for _, node := range nodeList.Items {
+		if node.Name == pod.Spec.NodeName {
+			return fmt.Errorf(`instance "%s" is already in zone "%s"`, pod.Name, migrateInstance.TargetZone)
+		}
+		schedulable = schedulable || !node.Spec.Unschedulable
+	}
+	if !schedulable {
+		return fmt.Errorf(`no schedulable node found in zone "%s"`, migrateInstance.TargetZone)
+	}
+	return nil
+}
+
+// validateRotatedAccount checks whether the password of the system account can be rotated.
+func validateRotatedAccount(compDef *ComponentDefinition, compSpec *ClusterComponentSpec, accountName string) error {
+	index := slices.IndexFunc(compDef.Spec.SystemAccounts, func(account SystemAccount) bool {
+		return account.Name == accountName
+	})
+	if index < 0 {
+		return fmt.Errorf(`system account "%s" is not defined in ComponentDefinition "%s"`, accountName, compDef.Name)
+	}
+	// the secretRef of the Component overrides the one of the ComponentDefinition.
+	secretRef := compDef.Spec.SystemAccounts[index].SecretRef
+	for _, account := range compSpec.SystemAccounts {
+		if account.Name == accountName {
+			secretRef = account.SecretRef
+		}
+	}
+	if secretRef != nil {
+		return fmt.Errorf(`the password of system account "%s" is provided by Secret "%s/%s", which is not supported to rotate`,
+			accountName, secretRef.Namespace, secretRef.Name)
+	}
+	return nil
+}
+
+// validateCustom validates spec.custom, the parameters of the components are validated with the parametersSchema of the OpsDefinition.
+func (r *OpsRequest) validateCustom(ctx context.Context, cli client.Client) error {
+	customOps := r.Spec.CustomOps
+	if customOps == nil {
+		return opsvalidation.NotEmptyError("spec.custom")
+	}
+	opsDef := &OpsDefinition{}
+	if err := cli.Get(ctx, client.ObjectKey{Name: customOps.OpsDefinitionName}, opsDef); err != nil {
+		return fmt.Errorf(`get OpsDefinition "%s" failed: %s`, customOps.OpsDefinitionName, err.Error())
+	}
+	if opsDef.Spec.ParametersSchema == nil {
+		return nil
+	}
+	for _, v := range customOps.CustomOpsComponents {
+		params := map[string]string{}
+		for _, p := range v.Parameters {
+			params[p.Name] = p.Value
+		}
+		if err := openapischema.ValidateParameters(opsDef.Spec.ParametersSchema.OpenAPIV3Schema, params); err != nil {
+			return fmt.Errorf(`invalid parameters of component "%s" for OpsDefinition "%s": %s`, v.ComponentName, opsDef.Name, err.Error())
+		}
+	}
+	return nil
+}
+
+// validateRestore validates spec.restore, only the restore into the existing Cluster is validated against the Cluster.
+func (r *OpsRequest) validateRestore(ctx context.Context, cli client.Client, cluster *Cluster) error {
+	restore := r.Spec.GetRestore()
+	if restore == nil {
+		return opsvalidation.NotEmptyError("spec.restore")
+	}
+	if err := r.validateRestoreBackupRepo(ctx, cli, restore); err != nil {
+		return err
+	}
+	if !r.Spec.IsInPlaceRestore() {
+		return nil
+	}
+	for _, mapping := range restore.ComponentMappings {
+		compSpec := cluster.Spec.GetComponentByName(mapping.TargetComponentName)
+		if compSpec == nil {
+			return fmt.Errorf(`component "%s" not found or it is a sharding component, which is not supported to restore in place`,
+				mapping.TargetComponentName)
+		}
+		if len(compSpec.Instances) > 0 {
+			return fmt.Errorf(`component "%s" has instance templates, which is not supported to restore in place`, mapping.TargetComponentName)
+		}
+		targetVolumes := sets.New[string]()
+		for _, v := range mapping.VolumeMappings {
+			if targetVolumes.Has(v.TargetVolumeName) {
+				return fmt.Errorf(`volume "%s" of component "%s" is mapped more than once`, v.TargetVolumeName, mapping.TargetComponentName)
+			}
+			targetVolumes.Insert(v.TargetVolumeName)
+			if !slices.ContainsFunc(compSpec.VolumeClaimTemplates, func(vct ClusterComponentVolumeClaimTemplate) bool {
+				return vct.Name == v.TargetVolumeName
+			}) {
+				return fmt.Errorf(`volume "%s" not found in the volumeClaimTemplates of component "%s"`, v.TargetVolumeName, mapping.TargetComponentName)
+			}
+		}
+	}
+	return nil
+}
+
+// validateRestoreBackupRepo checks that the backup repo of the Backup to restore is allowed to be accessed from
+// the namespace of the OpsRequest, it is only checked before the OpsRequest starts.
+func (r *OpsRequest) validateRestoreBackupRepo(ctx context.Context, cli client.Client, restore *Restore) error {
+	if cli == nil || (r.Status.Phase != "" && r.Status.Phase != OpsPendingPhase) {
+		return nil
+	}
+	backup := &dpv1alpha1.Backup{}
+	if err := cli.Get(ctx, types.NamespacedName{Namespace: r.Namespace, Name: restore.BackupName}, backup); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if backup.Status.BackupRepoName == "" {
+		return nil
+	}
+	return checkBackupRepoAccess(ctx, cli, backup.Status.BackupRepoName, r.Namespace)
 }
 
 // validateUpgrade validates spec.clusterOps.upgrade
@@ -301,47 +1044,87 @@ func (r *OpsRequest) validateUpgrade(ctx context.Context,
 	cluster *Cluster) error {
 	upgrade := r.Spec.Upgrade
 	if upgrade == nil {
-		return notEmptyError("spec.upgrade")
+		return opsvalidation.NotEmptyError("spec.upgrade")
 	}
 	if upgrade.ClusterVersionRef != nil && *upgrade.ClusterVersionRef != "" {
 		// TODO: remove this deprecated api after v0.9
 		return k8sClient.Get(ctx, types.NamespacedName{Name: *upgrade.ClusterVersionRef}, &ClusterVersion{})
 	}
-	if len(r.Spec.Upgrade.Components) == 0 {
-		return notEmptyError("spec.upgrade.components")
+	for _, comp := range r.Spec.Upgrade.Components {
+		if err := r.validateUpgradePath(ctx, k8sClient, cluster, comp); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-// validateVerticalScaling validates api when spec.type is VerticalScaling
-func (r *OpsRequest) validateVerticalScaling(cluster *Cluster) error {
-	verticalScalingList := r.Spec.VerticalScalingList
-	if len(verticalScalingList) == 0 {
-		return notEmptyError("spec.verticalScaling")
+// validateUpgradePath rejects the major-version upgrade of the component if it is not declared as an upgrade path
+// in the ComponentVersions of the target ComponentDefinition.
+func (r *OpsRequest) validateUpgradePath(ctx context.Context,
+	cli client.Client,
+	cluster *Cluster,
+	upgradeComp UpgradeComponent) error {
+	if upgradeComp.ServiceVersion == nil || cli == nil {
+		return nil
 	}
-
-	// validate resources is legal and get component name slice
-	compOpsList := make([]ComponentOps, len(verticalScalingList))
-	for i, v := range verticalScalingList {
-		compOpsList[i] = v.ComponentOps
-		var instanceNames []string
-		for j := range v.Instances {
-			instanceNames = append(instanceNames, v.Instances[j].Name)
-		}
-		if err := r.checkInstanceTemplate(cluster, v.ComponentOps, instanceNames); err != nil {
-			return err
+	compSpec := cluster.Spec.GetComponentByName(upgradeComp.ComponentName)
+	if compSpec == nil {
+		if shardingSpec := cluster.Spec.GetShardingByName(upgradeComp.ComponentName); shardingSpec != nil {
+			compSpec = &shardingSpec.Template
 		}
-		if invalidValue, err := validateVerticalResourceList(v.Requests); err != nil {
-			return invalidValueError(invalidValue, err.Error())
+	}
+	if compSpec == nil || !IsMajorVersionUpgrade(compSpec.ServiceVersion, *upgradeComp.ServiceVersion) {
+		return nil
+	}
+	compDefName := compSpec.ComponentDef
+	if upgradeComp.ComponentDefinitionName != nil && *upgradeComp.ComponentDefinitionName != "" {
+		compDefName = *upgradeComp.ComponentDefinitionName
+	}
+	if compDefName == "" {
+		return nil
+	}
+	compVersionList := &ComponentVersionList{}
+	if err := cli.List(ctx, compVersionList, client.MatchingLabels{compDefName: compDefName}); err != nil {
+		return err
+	}
+	for _, compVersion := range compVersionList.Items {
+		if _, ok := compVersion.Spec.GetUpgradePath(compSpec.ServiceVersion, *upgradeComp.ServiceVersion); !ok {
+			return fmt.Errorf(`upgrading the service version of component "%s" from "%s" to "%s" is not allowed by ComponentVersion "%s"`,
+				upgradeComp.ComponentName, compSpec.ServiceVersion, *upgradeComp.ServiceVersion, compVersion.Name)
 		}
-		if invalidValue, err := validateVerticalResourceList(v.Limits); err != nil {
-			return invalidValueError(invalidValue, err.Error())
+	}
+	return nil
+}
+
+// validateVerticalScaling validates api when spec.type is VerticalScaling
+func (r *OpsRequest) validateVerticalScaling(ctx context.Context, cli client.Client, cluster *Cluster) error {
+	for _, v := range r.Spec.VerticalScalingList {
+		if err := r.validateComponentClass(ctx, cli, cluster, v); err != nil {
+			return err
 		}
-		if invalidValue, err := compareRequestsAndLimits(v.ResourceRequirements); err != nil {
-			return invalidValueError(invalidValue, err.Error())
+	}
+	return r.validateComputeQuota(ctx, cli, cluster)
+}
+
+// validateComponentClass resolves the class referenced by the verticalScaling, and validates it against the ComponentDefinition of the component.
+func (r *OpsRequest) validateComponentClass(ctx context.Context, cli client.Client, cluster *Cluster, verticalScaling VerticalScaling) error {
+	if verticalScaling.ClassRef == nil {
+		return nil
+	}
+	if len(verticalScaling.Requests) > 0 || len(verticalScaling.Limits) > 0 {
+		return fmt.Errorf(`classRef and the requests or limits can not be specified together for component "%s"`, verticalScaling.ComponentName)
+	}
+	compSpec := cluster.Spec.GetComponentByName(verticalScaling.ComponentName)
+	if compSpec == nil {
+		if shardingSpec := cluster.Spec.GetShardingByName(verticalScaling.ComponentName); shardingSpec != nil {
+			compSpec = &shardingSpec.Template
 		}
 	}
-	return r.checkComponentExistence(cluster, compOpsList)
+	if compSpec == nil || cli == nil {
+		return nil
+	}
+	_, err := ResolveComponentClass(ctx, cli, *verticalScaling.ClassRef, compSpec.ComponentDef)
+	return err
 }
 
 // validateVerticalScaling validate api is legal when spec.type is VerticalScaling
@@ -350,13 +1133,13 @@ func (r *OpsRequest) validateReconfigure(ctx context.Context,
 	cluster *Cluster) error {
 	reconfigure := r.Spec.Reconfigure
 	if reconfigure == nil && len(r.Spec.Reconfigures) == 0 {
-		return notEmptyError("spec.reconfigure")
+		return opsvalidation.NotEmptyError("spec.reconfigure")
 	}
 	if reconfigure != nil {
-		return r.validateReconfigureParams(ctx, k8sClient, cluster, reconfigure)
+		return r.validateReconfigureParams(ctx, k8sClient, cluster, reconfigure, field.NewPath("spec", "reconfigure"))
 	}
-	for _, reconfigure := range r.Spec.Reconfigures {
-		if err := r.validateReconfigureParams(ctx, k8sClient, cluster, &reconfigure); err != nil {
+	for i, reconfigure := range r.Spec.Reconfigures {
+		if err := r.validateReconfigureParams(ctx, k8sClient, cluster, &reconfigure, field.NewPath("spec", "reconfigures").Index(i)); err != nil {
 			return err
 		}
 	}
@@ -366,11 +1149,13 @@ func (r *OpsRequest) validateReconfigure(ctx context.Context,
 func (r *OpsRequest) validateReconfigureParams(ctx context.Context,
 	k8sClient client.Client,
 	cluster *Cluster,
-	reconfigure *Reconfigure) error {
+	reconfigure *Reconfigure,
+	path *field.Path) error {
 	if cluster.Spec.GetComponentByName(reconfigure.ComponentName) == nil {
 		return fmt.Errorf("component %s not found", reconfigure.ComponentName)
 	}
-	for _, configuration := range reconfigure.Configurations {
+	var allErrs field.ErrorList
+	for i, configuration := range reconfigure.Configurations {
 		cmObj, err := r.getConfigMap(ctx, k8sClient, fmt.Sprintf("%s-%s-%s", r.Spec.GetClusterName(), reconfigure.ComponentName, configuration.Name))
 		if err != nil {
 			return err
@@ -384,52 +1169,208 @@ func (r *OpsRequest) validateReconfigureParams(ctx context.Context,
 				return errors.New("key.fileContent and key.parameters cannot be empty at the same time")
 			}
 		}
+		ccName := cmObj.Labels[constant.CMConfigurationConstraintsNameLabelKey]
+		if ccName == "" {
+			continue
+		}
+		cc := &appsv1beta1.ConfigConstraint{}
+		if err = k8sClient.Get(ctx, client.ObjectKey{Name: ccName}, cc); err != nil {
+			return fmt.Errorf(`get ConfigConstraint "%s" failed: %s`, ccName, err.Error())
+		}
+		allErrs = append(allErrs, validateParametersWithConfigConstraint(configuration.Keys,
+			&cc.Spec, path.Child("configurations").Index(i).Child("keys"))...)
 	}
-	return nil
+	return allErrs.ToAggregate()
 }
 
-// compareRequestsAndLimits compares the resource requests and limits
-func compareRequestsAndLimits(resources corev1.ResourceRequirements) (string, error) {
-	requests := resources.Requests
-	limits := resources.Limits
-	if requests == nil || limits == nil {
-		return "", nil
+// validateParametersWithConfigConstraint validates the parameters to be updated against the ConfigConstraint,
+// the immutable parameters are forbidden to be updated, and the values are validated with the schema
+// converted from the CUE of the ConfigConstraint.
+func validateParametersWithConfigConstraint(keys []ParameterConfig,
+	cc *appsv1beta1.ConfigConstraintSpec,
+	path *field.Path) field.ErrorList {
+	var schema *apiextensionsv1.JSONSchemaProps
+	if cc.ParametersSchema != nil && cc.ParametersSchema.SchemaInJSON != nil {
+		// the parameters are nested under the "spec" property of the schema generated from the CUE
+		if s, ok := cc.ParametersSchema.SchemaInJSON.Properties["spec"]; ok {
+			schema = &s
+		}
 	}
-	for k, v := range requests {
-		if limitQuantity, ok := limits[k]; !ok {
+	var allErrs field.ErrorList
+	for i, key := range keys {
+		for j, param := range key.Parameters {
+			paramPath := path.Index(i).Child("parameters").Index(j)
+			if slices.Contains(cc.ImmutableParameters, param.Key) {
+				allErrs = append(allErrs, field.Forbidden(paramPath.Child("key"),
+					fmt.Sprintf(`parameter "%s" is immutable`, param.Key)))
+				continue
+			}
+			if param.Value == nil || schema == nil {
+				continue
+			}
+			paramSchema := lookupParameterSchema(schema, param.Key)
+			if paramSchema == nil {
+				continue
+			}
+			if err := openapischema.ValidateParameters(&apiextensionsv1.JSONSchemaProps{
+				Type:       "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{param.Key: *paramSchema},
+			}, map[string]string{param.Key: *param.Value}); err != nil {
+				allErrs = append(allErrs, field.Invalid(paramPath.Child("value"), *param.Value, err.Error()))
+			}
+		}
+	}
+	return allErrs
+}
+
+// lookupParameterSchema returns the schema of the parameter, the parameters of a section are
+// referenced by the dotted path, e.g. "mysqld.max_connections".
+func lookupParameterSchema(schema *apiextensionsv1.JSONSchemaProps, key string) *apiextensionsv1.JSONSchemaProps {
+	if s, ok := schema.Properties[key]; ok {
+		return &s
+	}
+	section, name, found := strings.Cut(key, ".")
+	if !found {
+		return nil
+	}
+	if s, ok := schema.Properties[section]; ok {
+		return lookupParameterSchema(&s, name)
+	}
+	return nil
+}
+
+// validateStop validates the stopped components and their quorum when spec.type is Stop
+func (r *OpsRequest) validateStop(ctx context.Context, cli client.Client, cluster *Cluster) error {
+	compNames := getStopStartComponentNames(cluster, r.Spec.StopList)
+	for _, compSpec := range cluster.Spec.ComponentSpecs {
+		if !compNames.Has(compSpec.Name) {
 			continue
-		} else if compareQuantity(&v, &limitQuantity) {
-			return v.String(), errors.New(fmt.Sprintf(`must be less than or equal to %s limit`, k))
 		}
+		// all the voting members of the component are stopped together
+		if err := r.validateQuorum(ctx, cli, cluster, compSpec.Name, func(members quorum.Members) error {
+			return members.CheckScaleIn(0)
+		}); err != nil {
+			return err
+		}
+	}
+	// the dependencies are only checked before the OpsRequest starts.
+	if len(r.Spec.StopList) == 0 || (r.Status.Phase != "" && r.Status.Phase != OpsPendingPhase) {
+		return nil
+	}
+	return r.validateStopStartDependencies(ctx, cli, cluster, compNames, true)
+}
+
+// validateStart validates the started components when spec.type is Start
+func (r *OpsRequest) validateStart(ctx context.Context, cli client.Client, cluster *Cluster) error {
+	// the stopped components and dependencies are only checked before the OpsRequest starts.
+	if len(r.Spec.StartList) == 0 || (r.Status.Phase != "" && r.Status.Phase != OpsPendingPhase) {
+		return nil
+	}
+	compNames := getStopStartComponentNames(cluster, r.Spec.StartList)
+	for _, compName := range sets.List(compNames) {
+		if !isComponentStopped(cluster, compName) {
+			return fmt.Errorf(`component "%s" is not stopped`, compName)
+		}
+	}
+	return r.validateStopStartDependencies(ctx, cli, cluster, compNames, false)
+}
+
+// validateStopStartDependencies validates that no running component is left depending on a stopped one after the OpsRequest.
+// The dependencies are derived from the provision order of the cluster topology,
+// the components of a stage depend on all the components of the earlier stages.
+func (r *OpsRequest) validateStopStartDependencies(ctx context.Context, cli client.Client, cluster *Cluster, compNames sets.Set[string], stop bool) error {
+	if cli == nil || cluster.Spec.ClusterDefRef == "" || cluster.Spec.Topology == "" {
+		return nil
+	}
+	clusterDef := &ClusterDefinition{}
+	if err := cli.Get(ctx, types.NamespacedName{Name: cluster.Spec.ClusterDefRef}, clusterDef); err != nil {
+		return fmt.Errorf(`get ClusterDefinition "%s" failed: %s`, cluster.Spec.ClusterDefRef, err.Error())
+	}
+	var stages [][]string
+	for _, topology := range clusterDef.Spec.Topologies {
+		if topology.Name == cluster.Spec.Topology && topology.Orders != nil {
+			for _, stage := range topology.Orders.Provision {
+				stages = append(stages, strings.Split(stage, ","))
+			}
+		}
+	}
+	// stoppedAfterOps checks whether the component is stopped after the OpsRequest is done.
+	stoppedAfterOps := func(compName string) bool {
+		if compNames.Has(compName) {
+			return stop
+		}
+		return isComponentStopped(cluster, compName)
+	}
+	for i := range stages {
+		for _, compName := range stages[i] {
+			if stoppedAfterOps(compName) {
+				continue
+			}
+			for _, depStage := range stages[:i] {
+				for _, depCompName := range depStage {
+					if !stoppedAfterOps(depCompName) || (!compNames.Has(compName) && !compNames.Has(depCompName)) {
+						continue
+					}
+					return fmt.Errorf(`component "%s" can not be running while the component "%s" it depends on is stopped`, compName, depCompName)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// getStopStartComponentNames returns the names of the components and shardings to stop or start,
+// all of them are returned if the list is empty.
+func getStopStartComponentNames(cluster *Cluster, compOpsList []ComponentOps) sets.Set[string] {
+	compNames := sets.New[string]()
+	for _, v := range compOpsList {
+		compNames.Insert(v.ComponentName)
+	}
+	if len(compOpsList) > 0 {
+		return compNames
+	}
+	for _, v := range cluster.Spec.ComponentSpecs {
+		compNames.Insert(v.Name)
+	}
+	for _, v := range cluster.Spec.ShardingSpecs {
+		compNames.Insert(v.Name)
 	}
-	return "", nil
+	return compNames
 }
 
-// compareQuantity compares requests quantity and limits quantity
-func compareQuantity(requestQuantity, limitQuantity *resource.Quantity) bool {
-	return requestQuantity != nil && limitQuantity != nil && requestQuantity.Cmp(*limitQuantity) > 0
+// isComponentStopped checks whether the component or sharding is stopped, whose replicas are 0.
+func isComponentStopped(cluster *Cluster, compName string) bool {
+	if compSpec := cluster.Spec.GetComponentByName(compName); compSpec != nil {
+		return compSpec.Replicas == 0
+	}
+	if shardingSpec := cluster.Spec.GetShardingByName(compName); shardingSpec != nil {
+		return shardingSpec.Template.Replicas == 0
+	}
+	return false
 }
 
 // validateHorizontalScaling validates api when spec.type is HorizontalScaling
-func (r *OpsRequest) validateHorizontalScaling(_ context.Context, _ client.Client, cluster *Cluster) error {
+func (r *OpsRequest) validateHorizontalScaling(ctx context.Context, cli client.Client, cluster *Cluster) error {
 	horizontalScalingList := r.Spec.HorizontalScalingList
-	if len(horizontalScalingList) == 0 {
-		return notEmptyError("spec.horizontalScaling")
-	}
-	compOpsList := make([]ComponentOps, len(horizontalScalingList))
 	hScaleMap := map[string]HorizontalScaling{}
 	for i, v := range horizontalScalingList {
-		compOpsList[i] = v.ComponentOps
 		hScaleMap[v.ComponentName] = horizontalScalingList[i]
 	}
-	if err := r.checkComponentExistence(cluster, compOpsList); err != nil {
-		return err
-	}
 	for _, comSpec := range cluster.Spec.ComponentSpecs {
 		if hScale, ok := hScaleMap[comSpec.Name]; ok {
 			if err := r.validateHorizontalScalingSpec(hScale, comSpec, cluster.Name, false); err != nil {
 				return err
 			}
+			if err := r.validateReplicasLimit(ctx, cli, cluster, hScale, comSpec, false); err != nil {
+				return err
+			}
+			if scaleInReplicas := getScaleInReplicas(hScale, comSpec); scaleInReplicas > 0 {
+				if err := r.validateQuorum(ctx, cli, cluster, comSpec.Name, func(members quorum.Members) error {
+					return members.CheckScaleIn(members.Voters - scaleInReplicas)
+				}); err != nil {
+					return err
+				}
+			}
 		}
 	}
 	for _, shardingSpec := range cluster.Spec.ShardingSpecs {
@@ -437,11 +1378,125 @@ func (r *OpsRequest) validateHorizontalScaling(_ context.Context, _ client.Clien
 			if err := r.validateHorizontalScalingSpec(hScale, shardingSpec.Template, cluster.Name, true); err != nil {
 				return err
 			}
+			if err := r.validateReplicasLimit(ctx, cli, cluster, hScale, shardingSpec.Template, true); err != nil {
+				return err
+			}
+		}
+	}
+	return r.validateComputeQuota(ctx, cli, cluster)
+}
+
+// validateReplicasLimit checks the replicas after the horizontal scaling against the replicas limit of the ComponentDefinition,
+// the replicas out of the range are always rejected as the Component refuses them, while the even replicas are allowed
+// if the OpsRequest is forced.
+func (r *OpsRequest) validateReplicasLimit(ctx context.Context,
+	cli client.Client,
+	cluster *Cluster,
+	hScale HorizontalScaling,
+	compSpec ClusterComponentSpec,
+	isSharding bool) error {
+	if cli == nil || (r.Status.Phase != "" && r.Status.Phase != OpsPendingPhase) {
+		return nil
+	}
+	compDefName := compSpec.ComponentDef
+	if !isSharding {
+		// the ComponentDefinition referenced by the Cluster may be a prefix, the resolved one is recorded in the Component.
+		comp := &Component{}
+		compKey := client.ObjectKey{Namespace: cluster.Namespace, Name: constant.GenerateClusterComponentName(cluster.Name, compSpec.Name)}
+		if err := cli.Get(ctx, compKey, comp); err == nil && comp.Spec.CompDef != "" {
+			compDefName = comp.Spec.CompDef
 		}
 	}
+	if compDefName == "" {
+		return nil
+	}
+	compDef, err := getComponentDefByName(ctx, cli, compDefName)
+	if err != nil || compDef.Spec.ReplicasLimit == nil {
+		return client.IgnoreNotFound(err)
+	}
+	limit := compDef.Spec.ReplicasLimit
+	replicas := compSpec.Replicas - getScaleInReplicas(hScale, compSpec)
+	if replicas < limit.MinReplicas || replicas > limit.MaxReplicas {
+		return kberrors.New(kberrors.ReasonInvalid, `the replicas %d of component "%s" is out of the limit [%d, %d] of ComponentDefinition "%s"`,
+			replicas, compSpec.Name, limit.MinReplicas, limit.MaxReplicas, compDef.Name)
+	}
+	if limit.OddReplicas && replicas > 0 && replicas%2 == 0 && !r.Spec.Force {
+		return kberrors.New(kberrors.ReasonInvalid, `the replicas of component "%s" is expected to be odd by ComponentDefinition "%s", but got %d`,
+			compSpec.Name, compDef.Name, replicas).
+			WithHint(`an even number of replicas tolerates no more failures than one replica less, set "spec.force" to scale anyway`)
+	}
 	return nil
 }
 
+// getScaleInReplicas returns the number of the replicas removed by the horizontal scaling,
+// it's negative if the replicas are increased.
+func getScaleInReplicas(hScale HorizontalScaling, compSpec ClusterComponentSpec) int32 {
+	if hScale.Replicas != nil {
+		return compSpec.Replicas - *hScale.Replicas
+	}
+	replicaChanges := func(replicaChanger ReplicaChanger, insNames []string, newInstances []InstanceTemplate) int32 {
+		if replicaChanger.ReplicaChanges != nil {
+			return *replicaChanger.ReplicaChanges
+		}
+		changes := int32(len(insNames))
+		for _, v := range replicaChanger.Instances {
+			changes += v.ReplicaChanges
+		}
+		for _, v := range newInstances {
+			changes += v.GetReplicas()
+		}
+		return changes
+	}
+	var scaleInReplicas int32
+	if hScale.ScaleIn != nil {
+		scaleInReplicas += replicaChanges(hScale.ScaleIn.ReplicaChanger, hScale.ScaleIn.OnlineInstancesToOffline, nil)
+	}
+	if hScale.ScaleOut != nil {
+		scaleInReplicas -= replicaChanges(hScale.ScaleOut.ReplicaChanger, hScale.ScaleOut.OfflineInstancesToOnline, hScale.ScaleOut.NewInstances)
+	}
+	return scaleInReplicas
+}
+
+// validateQuorum rejects the OpsRequest if it would drop the available voting members of a consensus component
+// below the quorum, unless the quorum loss is acknowledged. It's only checked before the OpsRequest starts,
+// as the instances are expected to be unavailable during the operation.
+func (r *OpsRequest) validateQuorum(ctx context.Context,
+	cli client.Client,
+	cluster *Cluster,
+	compName string,
+	check func(members quorum.Members) error) error {
+	if cli == nil || r.Spec.QuorumLossAcknowledged() || (r.Status.Phase != "" && r.Status.Phase != OpsPendingPhase) {
+		return nil
+	}
+	members, ok, err := getQuorumMembers(ctx, cli, cluster, compName)
+	if err != nil || !ok {
+		return err
+	}
+	if err = check(members); err != nil {
+		return kberrors.Wrap(err, kberrors.ReasonQuorumLoss, `the quorum of component "%s" is not kept`, compName)
+	}
+	return nil
+}
+
+// getQuorumMembers returns the voting members of the component, false is returned if it's not a consensus component
+// or its InstanceSet is not found.
+func getQuorumMembers(ctx context.Context, cli client.Client, cluster *Cluster, compName string) (quorum.Members, bool, error) {
+	its := &workloads.InstanceSet{}
+	itsKey := client.ObjectKey{Namespace: cluster.Namespace, Name: constant.GenerateWorkloadNamePattern(cluster.Name, compName)}
+	if err := cli.Get(ctx, itsKey, its); err != nil {
+		return quorum.Members{}, false, client.IgnoreNotFound(err)
+	}
+	podList := &corev1.PodList{}
+	if err := cli.List(ctx, podList, client.InNamespace(cluster.Namespace), client.MatchingLabels{
+		constant.AppInstanceLabelKey:    cluster.Name,
+		constant.KBAppComponentLabelKey: compName,
+	}); err != nil {
+		return quorum.Members{}, false, err
+	}
+	members, ok := quorum.ForInstanceSet(its, podList.Items)
+	return members, ok, nil
+}
+
 // CountOfflineOrOnlineInstances calculate the number of instances that need to be brought online and offline corresponding to the instance template name.
 func (r *OpsRequest) CountOfflineOrOnlineInstances(clusterName, componentName string, hScaleInstanceNames []string) map[string]int32 {
 	offlineOrOnlineInsCountMap := map[string]int32{}
@@ -551,99 +1606,158 @@ func (r *OpsRequest) validateHorizontalScalingSpec(hScale HorizontalScaling, com
 
 // validateVolumeExpansion validates volumeExpansion api when spec.type is VolumeExpansion
 func (r *OpsRequest) validateVolumeExpansion(ctx context.Context, cli client.Client, cluster *Cluster) error {
-	volumeExpansionList := r.Spec.VolumeExpansionList
-	if len(volumeExpansionList) == 0 {
-		return notEmptyError("spec.volumeExpansion")
-	}
-
-	compOpsList := make([]ComponentOps, len(volumeExpansionList))
-	for i, v := range volumeExpansionList {
-		compOpsList[i] = v.ComponentOps
-		var instanceNames []string
-		for j := range v.Instances {
-			instanceNames = append(instanceNames, v.Instances[j].Name)
-		}
-		if err := r.checkInstanceTemplate(cluster, v.ComponentOps, instanceNames); err != nil {
-			return err
-		}
-	}
-	if err := r.checkComponentExistence(cluster, compOpsList); err != nil {
+	if err := r.checkVolumesAllowExpansion(ctx, cli, cluster); err != nil {
 		return err
 	}
-	return r.checkVolumesAllowExpansion(ctx, cli, cluster)
+	return r.validateStorageCapacity(ctx, cli)
 }
 
 // validateSwitchover validates switchover api when spec.type is Switchover.
 func (r *OpsRequest) validateSwitchover(ctx context.Context, cli client.Client, cluster *Cluster) error {
-	switchoverList := r.Spec.SwitchoverList
-	if len(switchoverList) == 0 {
-		return notEmptyError("spec.switchover")
-	}
-	compOpsList := make([]ComponentOps, len(switchoverList))
-	for i, v := range switchoverList {
-		compOpsList[i] = v.ComponentOps
-
-	}
-	if err := r.checkComponentExistence(cluster, compOpsList); err != nil {
-		return err
-	}
-	return validateSwitchoverResourceList(ctx, cli, cluster, switchoverList)
+	return validateSwitchoverResourceList(ctx, cli, cluster, r.Spec.SwitchoverList)
 }
 
-func (r *OpsRequest) checkInstanceTemplate(cluster *Cluster, componentOps ComponentOps, inputInstances []string) error {
-	instanceNameMap := make(map[string]sets.Empty)
-	setInstanceMap := func(instances []InstanceTemplate) {
-		for i := range instances {
-			instanceNameMap[instances[i].Name] = sets.Empty{}
-		}
+// NewOpsTopology returns the topology of the Cluster that the OpsRequests are validated against by the static rules.
+func NewOpsTopology(cluster *Cluster) opsvalidation.Topology {
+	topology := opsvalidation.Topology{
+		ClusterName: cluster.Name,
+		Namespace:   cluster.Namespace,
+		Components:  map[string]opsvalidation.Component{},
 	}
-	for _, shardingSpec := range cluster.Spec.ShardingSpecs {
-		if shardingSpec.Name != componentOps.ComponentName {
-			continue
-		}
-		setInstanceMap(shardingSpec.Template.Instances)
-	}
-	for _, compSpec := range cluster.Spec.ComponentSpecs {
-		if compSpec.Name != componentOps.ComponentName {
-			continue
+	addComponent := func(compName string, compSpec ClusterComponentSpec) {
+		comp := opsvalidation.Component{Instances: map[string][]string{}}
+		for _, vct := range compSpec.VolumeClaimTemplates {
+			comp.VolumeClaimTemplates = append(comp.VolumeClaimTemplates, vct.Name)
 		}
-		setInstanceMap(compSpec.Instances)
-	}
-	var notFoundInstanceNames []string
-	for _, insName := range inputInstances {
-		if _, ok := instanceNameMap[insName]; !ok {
-			notFoundInstanceNames = append(notFoundInstanceNames, insName)
+		for _, ins := range compSpec.Instances {
+			var vctNames []string
+			for _, vct := range ins.VolumeClaimTemplates {
+				vctNames = append(vctNames, vct.Name)
+			}
+			comp.Instances[ins.Name] = vctNames
 		}
+		topology.Components[compName] = comp
 	}
-	if len(notFoundInstanceNames) > 0 {
-		return fmt.Errorf("instance: %v not found in cluster: %s", notFoundInstanceNames, r.Spec.GetClusterName())
-	}
-	return nil
-}
-
-// checkComponentExistence checks whether components to be operated exist in cluster spec.
-func (r *OpsRequest) checkComponentExistence(cluster *Cluster, compOpsList []ComponentOps) error {
-	compNameMap := make(map[string]sets.Empty)
 	for _, compSpec := range cluster.Spec.ComponentSpecs {
-		compNameMap[compSpec.Name] = sets.Empty{}
+		addComponent(compSpec.Name, compSpec)
 	}
 	for _, shardingSpec := range cluster.Spec.ShardingSpecs {
-		compNameMap[shardingSpec.Name] = sets.Empty{}
+		addComponent(shardingSpec.Name, shardingSpec.Template)
 	}
+	return topology
+}
+
+// Lint validates the OpsRequest against the Cluster with the static rules of the webhook, which need no access
+// to the API server, including the resource lists and the existence of the components, instance templates and
+// volumeClaimTemplates to be operated. It allows the OpsRequests to be linted offline, e.g. in CI pipelines.
+func (r *OpsRequest) Lint(cluster *Cluster) error {
+	return LintOpsRequestSpec(cluster, r.Spec).ToAggregate()
+}
+
+// LintOpsRequestSpec validates the OpsRequest spec against the Cluster with the static rules, and returns all the
+// errors found with the paths of the fields, so that the clients are able to report them in the manifests.
+func LintOpsRequestSpec(cluster *Cluster, spec OpsRequestSpec) field.ErrorList {
 	var (
-		notFoundCompNames []string
+		allErrs field.ErrorList
+		targets []opsvalidation.Target
 	)
-	for _, compOps := range compOpsList {
-		if _, ok := compNameMap[compOps.ComponentName]; !ok {
-			notFoundCompNames = append(notFoundCompNames, compOps.ComponentName)
+	specPath := field.NewPath("spec")
+	addComponentTargets := func(path *field.Path, compOpsList []ComponentOps) {
+		for i, v := range compOpsList {
+			targets = append(targets, opsvalidation.Target{Path: path.Index(i), ComponentName: v.ComponentName})
 		}
-		continue
 	}
-
-	if len(notFoundCompNames) > 0 {
-		return fmt.Errorf("components: %v not found, in cluster.spec.componentSpecs or cluster.spec.shardingSpecs", notFoundCompNames)
+	switch spec.Type {
+	case VerticalScalingType:
+		path := specPath.Child("verticalScaling")
+		if len(spec.VerticalScalingList) == 0 {
+			allErrs = append(allErrs, field.Required(path, ""))
+		}
+		for i := range spec.VerticalScalingList {
+			v := &spec.VerticalScalingList[i]
+			targets = append(targets, opsvalidation.Target{Path: path.Index(i), ComponentName: v.ComponentName, Resources: &v.ResourceRequirements})
+			for j, ins := range v.Instances {
+				targets = append(targets, opsvalidation.Target{Path: path.Index(i).Child("instances").Index(j), ComponentName: v.ComponentName, InstanceName: ins.Name})
+			}
+		}
+	case VolumeExpansionType:
+		path := specPath.Child("volumeExpansion")
+		if len(spec.VolumeExpansionList) == 0 {
+			allErrs = append(allErrs, field.Required(path, ""))
+		}
+		getVCTNames := func(vcts []OpsRequestVolumeClaimTemplate) []string {
+			var vctNames []string
+			for _, vct := range vcts {
+				vctNames = append(vctNames, vct.Name)
+			}
+			return vctNames
+		}
+		for i, v := range spec.VolumeExpansionList {
+			targets = append(targets, opsvalidation.Target{Path: path.Index(i), ComponentName: v.ComponentName,
+				VolumeClaimTemplates: getVCTNames(v.VolumeClaimTemplates)})
+			for j, ins := range v.Instances {
+				targets = append(targets, opsvalidation.Target{Path: path.Index(i).Child("instances").Index(j), ComponentName: v.ComponentName,
+					InstanceName: ins.Name, VolumeClaimTemplates: getVCTNames(ins.VolumeClaimTemplates)})
+			}
+		}
+	case HorizontalScalingType:
+		path := specPath.Child("horizontalScaling")
+		if len(spec.HorizontalScalingList) == 0 {
+			allErrs = append(allErrs, field.Required(path, ""))
+		}
+		for i, v := range spec.HorizontalScalingList {
+			targets = append(targets, opsvalidation.Target{Path: path.Index(i), ComponentName: v.ComponentName})
+		}
+	case RestartType:
+		path := specPath.Child("restart")
+		if len(spec.RestartList) == 0 {
+			allErrs = append(allErrs, field.Required(path, ""))
+		}
+		addComponentTargets(path, spec.RestartList)
+	case StopType:
+		addComponentTargets(specPath.Child("stop"), spec.StopList)
+	case StartType:
+		addComponentTargets(specPath.Child("start"), spec.StartList)
+	case SwitchoverType:
+		path := specPath.Child("switchover")
+		if len(spec.SwitchoverList) == 0 {
+			allErrs = append(allErrs, field.Required(path, ""))
+		}
+		for i, v := range spec.SwitchoverList {
+			targets = append(targets, opsvalidation.Target{Path: path.Index(i), ComponentName: v.ComponentName})
+		}
+	case ExposeType:
+		// the services of the cluster are exposed if the componentName is empty.
+		for i, v := range spec.ExposeList {
+			if v.ComponentName != "" {
+				targets = append(targets, opsvalidation.Target{Path: specPath.Child("expose").Index(i), ComponentName: v.ComponentName})
+			}
+		}
+	case RebuildInstanceType:
+		for i, v := range spec.RebuildFrom {
+			targets = append(targets, opsvalidation.Target{Path: specPath.Child("rebuildFrom").Index(i), ComponentName: v.ComponentName})
+		}
+	case DataScriptType:
+		if spec.ScriptSpec != nil {
+			targets = append(targets, opsvalidation.Target{Path: specPath.Child("scriptSpec"), ComponentName: spec.ScriptSpec.ComponentName})
+		}
+	case DebugInstanceType:
+		if spec.DebugInstance != nil {
+			targets = append(targets, opsvalidation.Target{Path: specPath.Child("debugInstance"), ComponentName: spec.DebugInstance.ComponentName})
+		}
+	case MigrateInstanceType:
+		if spec.MigrateInstance != nil {
+			targets = append(targets, opsvalidation.Target{Path: specPath.Child("migrateInstance"), ComponentName: spec.MigrateInstance.ComponentName})
+		}
+	case CustomType:
+		if spec.CustomOps != nil {
+			path := specPath.Child("custom", "components")
+			for i, v := range spec.CustomOps.CustomOpsComponents {
+				targets = append(targets, opsvalidation.Target{Path: path.Index(i), ComponentName: v.ComponentName})
+			}
+		}
 	}
-	return nil
+	return append(allErrs, NewOpsTopology(cluster).ValidateTargets(targets)...)
 }
 
 func (r *OpsRequest) checkVolumesAllowExpansion(ctx context.Context, cli client.Client, cluster *Cluster) error {
@@ -734,15 +1848,15 @@ func (r *OpsRequest) checkVolumesAllowExpansion(ctx context.Context, cli client.
 		}
 	}
 
+	// the volumeClaimTemplates not found in the spec have been rejected by LintOpsRequestSpec.
 	for key, compVols := range vols {
 		var (
-			notFound     []string
 			notSupport   []string
 			notSupportSc []string
 		)
 		for vct, e := range compVols {
 			if !e.existInSpec {
-				notFound = append(notFound, vct)
+				continue
 			}
 			if !e.allowExpansion {
 				notSupport = append(notSupport, vct)
@@ -751,17 +1865,13 @@ func (r *OpsRequest) checkVolumesAllowExpansion(ctx context.Context, cli client.
 				}
 			}
 		}
-		if len(notFound) > 0 {
-			return fmt.Errorf("volumeClaimTemplates: %v not found in component: %s, you can view infos by command: "+
-				"kubectl get cluster %s -n %s", notFound, key, cluster.Name, r.Namespace)
-		}
 		if len(notSupport) > 0 {
 			var notSupportScString string
 			if len(notSupportSc) > 0 {
 				notSupportScString = fmt.Sprintf("storageClass: %v of ", notSupportSc)
 			}
-			return fmt.Errorf(notSupportScString+"volumeClaimTemplate: %v not support volume expansion in component: %s, you can view infos by command: "+
-				"kubectl get sc", notSupport, key)
+			return kberrors.New(kberrors.ReasonVolumeExpansionNotSupported, notSupportScString+"volumeClaimTemplate: %v not support volume expansion in component: %s", notSupport, key).
+				WithHint("view the allowVolumeExpansion of the storageClasses by command: kubectl get sc")
 		}
 	}
 	return nil
@@ -818,17 +1928,10 @@ func (r *OpsRequest) getSCNameByPvcAndCheckStorageSize(ctx context.Context,
 }
 
 // validateDataScript validates the data script.
-func (r *OpsRequest) validateDataScript(ctx context.Context, cli client.Client, cluster *Cluster) error {
+func (r *OpsRequest) validateDataScript(ctx context.Context, cli client.Client) error {
 	validateScript := func(spec *ScriptSpec) error {
-		rawScripts := spec.Script
 		scriptsFrom := spec.ScriptFrom
-		if len(rawScripts) == 0 && (scriptsFrom == nil) {
-			return fmt.Errorf("spec.scriptSpec.script and spec.scriptSpec.scriptFrom can not be empty at the same time")
-		}
 		if scriptsFrom != nil {
-			if scriptsFrom.ConfigMapRef == nil && scriptsFrom.SecretRef == nil {
-				return fmt.Errorf("spec.scriptSpec.scriptFrom.configMapRefs and spec.scriptSpec.scriptFrom.secretRefs can not be empty at the same time")
-			}
 			for _, configMapRef := range scriptsFrom.ConfigMapRef {
 				if err := cli.Get(ctx, types.NamespacedName{Name: configMapRef.Name, Namespace: r.Namespace}, &corev1.ConfigMap{}); err != nil {
 					return err
@@ -845,11 +1948,7 @@ func (r *OpsRequest) validateDataScript(ctx context.Context, cli client.Client,
 
 	scriptSpec := r.Spec.ScriptSpec
 	if scriptSpec == nil {
-		return notEmptyError("spec.scriptSpec")
-	}
-
-	if err := r.checkComponentExistence(cluster, []ComponentOps{scriptSpec.ComponentOps}); err != nil {
-		return err
+		return opsvalidation.NotEmptyError("spec.scriptSpec")
 	}
 
 	if err := validateScript(scriptSpec); err != nil {
@@ -859,23 +1958,16 @@ func (r *OpsRequest) validateDataScript(ctx context.Context, cli client.Client,
 	return nil
 }
 
-// validateVerticalResourceList checks if k8s resourceList is legal
-func validateVerticalResourceList(resourceList map[corev1.ResourceName]resource.Quantity) (string, error) {
-	for k := range resourceList {
-		if k != corev1.ResourceCPU && k != corev1.ResourceMemory && !strings.HasPrefix(k.String(), corev1.ResourceHugePagesPrefix) {
-			return string(k), fmt.Errorf("resource key is not cpu or memory or hugepages- ")
+func isPodReady(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
 		}
 	}
-
-	return "", nil
-}
-
-func notEmptyError(target string) error {
-	return fmt.Errorf(`"%s" can not be empty`, target)
-}
-
-func invalidValueError(target string, value string) error {
-	return fmt.Errorf(`invalid value for "%s": %s`, target, value)
+	return false
 }
 
 // GetRunningOpsByOpsType gets the running opsRequests by type.
@@ -908,7 +2000,7 @@ func validateSwitchoverResourceList(ctx context.Context, cli client.Client, clus
 	)
 	for _, switchover := range switchoverList {
 		if switchover.InstanceName == "" {
-			return notEmptyError("switchover.instanceName")
+			return opsvalidation.NotEmptyError("switchover.instanceName")
 		}
 
 		// TODO(xingran): this will be removed in the future.
@@ -924,6 +2016,9 @@ func validateSwitchoverResourceList(ctx context.Context, cli client.Client, clus
 			if clusterCompDefObj.SwitchoverSpec == nil {
 				return fmt.Errorf("this cluster component %s does not support switchover", switchover.ComponentName)
 			}
+			if switchover.MaxLagSeconds != nil {
+				return fmt.Errorf("this cluster component %s does not support switchover.maxLagSeconds", switchover.ComponentName)
+			}
 			switch switchover.InstanceName {
 			case KBSwitchoverCandidateInstanceForAnyPod:
 				if clusterCompDefObj.SwitchoverSpec.WithoutCandidate == nil {
@@ -940,7 +2035,8 @@ func validateSwitchoverResourceList(ctx context.Context, cli client.Client, clus
 			}
 			pod := &corev1.Pod{}
 			if err := cli.Get(ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: switchover.InstanceName}, pod); err != nil {
-				return fmt.Errorf("get instanceName %s failed, err: %s, and check the validity of the instanceName using \"kbcli cluster list-instances\"", switchover.InstanceName, err.Error())
+				return kberrors.Wrap(err, kberrors.ReasonInstanceNotFound, "get instanceName %s failed", switchover.InstanceName).
+					WithHint("check the validity of the instanceName by command: kbcli cluster list-instances %s -n %s", cluster.Name, cluster.Namespace)
 			}
 			v, ok := pod.Labels[constant.RoleLabelKey]
 			if !ok || v == "" {
@@ -950,7 +2046,8 @@ func validateSwitchoverResourceList(ctx context.Context, cli client.Client, clus
 				return fmt.Errorf("instanceName %s cannot be promoted because it is already the primary or leader instance", switchover.InstanceName)
 			}
 			if !strings.HasPrefix(pod.Name, fmt.Sprintf("%s-%s", cluster.Name, switchover.ComponentName)) {
-				return fmt.Errorf("instanceName %s does not belong to the current component, please check the validity of the instance using \"kbcli cluster list-instances\"", switchover.InstanceName)
+				return kberrors.New(kberrors.ReasonInstanceNotFound, "instanceName %s does not belong to the current component", switchover.InstanceName).
+					WithHint("check the validity of the instanceName by command: kbcli cluster list-instances %s -n %s", cluster.Name, cluster.Namespace)
 			}
 			return nil
 		}
@@ -981,6 +2078,9 @@ func validateSwitchoverResourceList(ctx context.Context, cli client.Client, clus
 			if compDefObj.Spec.LifecycleActions == nil || compDefObj.Spec.LifecycleActions.Switchover == nil {
 				return fmt.Errorf("this cluster component %s does not support switchover", switchover.ComponentName)
 			}
+			if switchover.MaxLagSeconds != nil && compDefObj.Spec.LifecycleActions.ReplicationLag == nil {
+				return fmt.Errorf("this cluster component %s does not support switchover.maxLagSeconds, the replicationLag lifecycle action is not defined", switchover.ComponentName)
+			}
 			switch switchover.InstanceName {
 			case KBSwitchoverCandidateInstanceForAnyPod:
 				if compDefObj.Spec.LifecycleActions.Switchover.WithoutCandidate == nil {
@@ -1004,7 +2104,8 @@ func validateSwitchoverResourceList(ctx context.Context, cli client.Client, clus
 			}
 			pod := &corev1.Pod{}
 			if err := cli.Get(ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: switchover.InstanceName}, pod); err != nil {
-				return fmt.Errorf("get instanceName %s failed, err: %s, and check the validity of the instanceName using \"kbcli cluster list-instances\"", switchover.InstanceName, err.Error())
+				return kberrors.Wrap(err, kberrors.ReasonInstanceNotFound, "get instanceName %s failed", switchover.InstanceName).
+					WithHint("check the validity of the instanceName by command: kbcli cluster list-instances %s -n %s", cluster.Name, cluster.Namespace)
 			}
 			v, ok := pod.Labels[constant.RoleLabelKey]
 			if !ok || v == "" {
@@ -1014,7 +2115,8 @@ func validateSwitchoverResourceList(ctx context.Context, cli client.Client, clus
 				return fmt.Errorf("instanceName %s cannot be promoted because it is already the primary or leader instance", switchover.InstanceName)
 			}
 			if !strings.HasPrefix(pod.Name, fmt.Sprintf("%s-%s", cluster.Name, switchover.ComponentName)) {
-				return fmt.Errorf("instanceName %s does not belong to the current component, please check the validity of the instance using \"kbcli cluster list-instances\"", switchover.InstanceName)
+				return kberrors.New(kberrors.ReasonInstanceNotFound, "instanceName %s does not belong to the current component", switchover.InstanceName).
+					WithHint("check the validity of the instanceName by command: kbcli cluster list-instances %s -n %s", cluster.Name, cluster.Namespace)
 			}
 			return nil
 		}
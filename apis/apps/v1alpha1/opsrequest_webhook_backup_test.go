@@ -0,0 +1,80 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestValidateBackup(t *testing.T) {
+	cluster := &Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mycluster"}}
+	newBackupPolicy := func(name, clusterName string) *dpv1alpha1.BackupPolicy {
+		return &dpv1alpha1.BackupPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				Labels:    map[string]string{constant.AppInstanceLabelKey: clusterName},
+			},
+			Spec: dpv1alpha1.BackupPolicySpec{
+				BackupMethods: []dpv1alpha1.BackupMethod{{Name: "xtrabackup"}, {Name: "volume-snapshot"}},
+			},
+		}
+	}
+	newOps := func(backup *Backup) *OpsRequest {
+		return &OpsRequest{Spec: OpsRequestSpec{Type: BackupType, Backup: backup}}
+	}
+
+	scheme := runtime.NewScheme()
+	if err := dpv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(newBackupPolicy("mycluster-backup-policy", "mycluster"), newBackupPolicy("other-backup-policy", "other")).
+		Build()
+	tests := []struct {
+		name   string
+		ops    *OpsRequest
+		errMsg string
+	}{
+		{"default backup", newOps(nil), ""},
+		{"backup with the specified method", newOps(&Backup{BackupPolicyName: "mycluster-backup-policy", BackupMethod: "xtrabackup", RetentionPeriod: "7d"}), ""},
+		{"invalid retention period", newOps(&Backup{RetentionPeriod: "7x"}), "invalid retention period"},
+		{"backup policy not found", newOps(&Backup{BackupPolicyName: "not-exist"}), "not found"},
+		{"backup policy of another cluster", newOps(&Backup{BackupPolicyName: "other-backup-policy"}), "does not belong to"},
+		{"backup method not defined", newOps(&Backup{BackupPolicyName: "mycluster-backup-policy", BackupMethod: "mysqldump"}), "is not defined"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ops.validateBackup(context.Background(), cli, cluster)
+			if tt.errMsg == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.errMsg != "" && (err == nil || !strings.Contains(err.Error(), tt.errMsg)) {
+				t.Errorf("expect error containing %q, but got: %v", tt.errMsg, err)
+			}
+		})
+	}
+}
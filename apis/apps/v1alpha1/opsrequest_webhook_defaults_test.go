@@ -0,0 +1,175 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestApplyDefaults(t *testing.T) {
+	int32Ptr := func(i int32) *int32 { return &i }
+	defaults := &OpsRequestDefaults{
+		TTLSecondsAfterSucceed:      int32Ptr(3600),
+		PreConditionDeadlineSeconds: int32Ptr(300),
+		FailurePolicy:               OpsFailurePolicyIgnore,
+	}
+
+	tests := []struct {
+		name            string
+		spec            OpsRequestSpec
+		defaults        *OpsRequestDefaults
+		expected        OpsRequestSpec
+		defaultedFields string
+	}{
+		{
+			name:            "no defaults",
+			spec:            OpsRequestSpec{Type: RestartType},
+			expected:        OpsRequestSpec{Type: RestartType},
+			defaultedFields: "",
+		},
+		{
+			name:     "empty fields",
+			spec:     OpsRequestSpec{Type: RestartType, PreConditionDeadlineSeconds: int32Ptr(0)},
+			defaults: defaults,
+			expected: OpsRequestSpec{
+				Type:                        RestartType,
+				TTLSecondsAfterSucceed:      3600,
+				PreConditionDeadlineSeconds: int32Ptr(300),
+				FailurePolicy:               OpsFailurePolicyIgnore,
+			},
+			defaultedFields: "ttlSecondsAfterSucceed,preConditionDeadlineSeconds,failurePolicy",
+		},
+		{
+			name: "specified fields",
+			spec: OpsRequestSpec{
+				Type:                        RestartType,
+				TTLSecondsAfterSucceed:      60,
+				PreConditionDeadlineSeconds: int32Ptr(30),
+				FailurePolicy:               OpsFailurePolicyFail,
+			},
+			defaults: defaults,
+			expected: OpsRequestSpec{
+				Type:                        RestartType,
+				TTLSecondsAfterSucceed:      60,
+				PreConditionDeadlineSeconds: int32Ptr(30),
+				FailurePolicy:               OpsFailurePolicyFail,
+			},
+			defaultedFields: "",
+		},
+		{
+			name:     "partial defaults",
+			spec:     OpsRequestSpec{Type: RestartType},
+			defaults: &OpsRequestDefaults{FailurePolicy: "Threshold(20%)"},
+			expected: OpsRequestSpec{
+				Type:          RestartType,
+				FailurePolicy: "Threshold(20%)",
+			},
+			defaultedFields: "failurePolicy",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops := &OpsRequest{Spec: tt.spec}
+			ops.applyDefaults(tt.defaults)
+			if ops.Spec.TTLSecondsAfterSucceed != tt.expected.TTLSecondsAfterSucceed {
+				t.Errorf("expected ttlSecondsAfterSucceed %d, got %d", tt.expected.TTLSecondsAfterSucceed, ops.Spec.TTLSecondsAfterSucceed)
+			}
+			if (ops.Spec.PreConditionDeadlineSeconds == nil) != (tt.expected.PreConditionDeadlineSeconds == nil) ||
+				(ops.Spec.PreConditionDeadlineSeconds != nil && *ops.Spec.PreConditionDeadlineSeconds != *tt.expected.PreConditionDeadlineSeconds) {
+				t.Errorf("expected preConditionDeadlineSeconds %v, got %v", tt.expected.PreConditionDeadlineSeconds, ops.Spec.PreConditionDeadlineSeconds)
+			}
+			if ops.Spec.FailurePolicy != tt.expected.FailurePolicy {
+				t.Errorf("expected failurePolicy %s, got %s", tt.expected.FailurePolicy, ops.Spec.FailurePolicy)
+			}
+			if fields := ops.Annotations[constant.OpsDefaultedFieldsAnnotationKey]; fields != tt.defaultedFields {
+				t.Errorf("expected defaulted fields %q, got %q", tt.defaultedFields, fields)
+			}
+		})
+	}
+}
+
+func TestApplyBuiltinDefaults(t *testing.T) {
+	ops := &OpsRequest{
+		Spec: OpsRequestSpec{
+			ClusterRef: "mycluster",
+			Type:       VerticalScalingType,
+			SpecificOpsRequest: SpecificOpsRequest{
+				VerticalScalingList: []VerticalScaling{
+					{
+						ComponentOps: ComponentOps{ComponentName: "mysql"},
+						ResourceRequirements: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("1"),
+								corev1.ResourceMemory: resource.MustParse("1Gi"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	ops.applyBuiltinDefaults()
+	if ops.Spec.ClusterName != "mycluster" {
+		t.Errorf("expected clusterName mycluster, got %s", ops.Spec.ClusterName)
+	}
+	if ops.Spec.PreConditionDeadlineSeconds == nil || *ops.Spec.PreConditionDeadlineSeconds != 0 {
+		t.Errorf("expected preConditionDeadlineSeconds 0, got %v", ops.Spec.PreConditionDeadlineSeconds)
+	}
+	requests := ops.Spec.VerticalScalingList[0].Requests
+	if cpu := requests[corev1.ResourceCPU]; cpu.String() != "500m" {
+		t.Errorf("expected the cpu request to be kept, got %s", cpu.String())
+	}
+	if memory := requests[corev1.ResourceMemory]; memory.String() != "1Gi" {
+		t.Errorf("expected the memory request to default to the limit, got %s", memory.String())
+	}
+}
+
+func TestApplyClusterLabels(t *testing.T) {
+	cluster := &Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "mycluster",
+			Labels: map[string]string{
+				"team":                                 "dba",
+				"env":                                  "prod",
+				"clusterdefinition.kubeblocks.io/name": "mysql",
+				"app.kubernetes.io/managed-by":         "kubeblocks",
+			},
+		},
+	}
+	ops := &OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"env": "staging"}},
+		Spec:       OpsRequestSpec{ClusterName: cluster.Name, Type: RestartType},
+	}
+	ops.applyClusterLabels(cluster)
+	expected := map[string]string{
+		"team":                          "dba",
+		"env":                           "staging",
+		constant.AppInstanceLabelKey:    "mycluster",
+		constant.OpsRequestTypeLabelKey: string(RestartType),
+	}
+	if !reflect.DeepEqual(ops.Labels, expected) {
+		t.Errorf("expected labels %v, got %v", expected, ops.Labels)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestGetDependentOps(t *testing.T) {
+	ops := &OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "ops-c",
+			Annotations: map[string]string{
+				constant.OpsDependentOnSuccessfulOpsAnnoKey: "ops-b,ops-x",
+			},
+		},
+		Spec: OpsRequestSpec{DependsOn: []string{"ops-a", "ops-b"}},
+	}
+	expected := []string{"ops-a", "ops-b", "ops-x"}
+	if names := ops.GetDependentOps(); !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected dependent ops %v, got %v", expected, names)
+	}
+	if names := (&OpsRequest{}).GetDependentOps(); len(names) != 0 {
+		t.Errorf("expected no dependent ops, got %v", names)
+	}
+}
+
+func TestFindOpsDependencyCycle(t *testing.T) {
+	graph := map[string][]string{
+		"ops-a": {"ops-b"},
+		"ops-b": {"ops-c", "ops-missing"},
+		"ops-c": {"ops-d"},
+		"ops-d": nil,
+		"ops-e": {"ops-new"},
+		"ops-f": {"ops-e", "ops-b"},
+	}
+	getDependentOps := func(name string) ([]string, error) {
+		deps, ok := graph[name]
+		if !ok {
+			return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "opsrequests"}, name)
+		}
+		return deps, nil
+	}
+
+	tests := []struct {
+		name      string
+		dependsOn []string
+		cycle     string
+	}{
+		{
+			name:      "no cycle",
+			dependsOn: []string{"ops-a", "ops-c"},
+		},
+		{
+			name:      "dependency not found",
+			dependsOn: []string{"ops-missing"},
+		},
+		{
+			name:      "direct cycle",
+			dependsOn: []string{"ops-e"},
+			cycle:     "ops-new -> ops-e -> ops-new",
+		},
+		{
+			name:      "indirect cycle",
+			dependsOn: []string{"ops-a", "ops-f"},
+			cycle:     "ops-new -> ops-f -> ops-e -> ops-new",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cycle, err := findOpsDependencyCycle("ops-new", tt.dependsOn, getDependentOps)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if strings.Join(cycle, " -> ") != tt.cycle {
+				t.Errorf("expected cycle %q, got %q", tt.cycle, strings.Join(cycle, " -> "))
+			}
+		})
+	}
+}
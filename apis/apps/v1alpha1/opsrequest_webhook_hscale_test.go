@@ -0,0 +1,90 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestValidateReplicasLimit(t *testing.T) {
+	compDef := &ComponentDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "etcd-3.5"},
+		Spec: ComponentDefinitionSpec{
+			ReplicasLimit: &ReplicasLimit{MinReplicas: 1, MaxReplicas: 7, OddReplicas: true},
+		},
+	}
+	// the ComponentDefinition referenced by the cluster is resolved in the Component
+	comp := &Component{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mycluster-etcd"},
+		Spec:       ComponentSpec{CompDef: "etcd-3.5"},
+	}
+	cluster := &Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mycluster"}}
+	compSpec := ClusterComponentSpec{Name: "etcd", ComponentDef: "etcd", Replicas: 3}
+	shardingTemplate := ClusterComponentSpec{Name: "shard", ComponentDef: "etcd-3.5", Replicas: 3}
+
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(compDef, comp).Build()
+
+	scaleTo := func(replicas int32) HorizontalScaling {
+		return HorizontalScaling{ComponentOps: ComponentOps{ComponentName: "etcd"}, Replicas: pointer.Int32(replicas)}
+	}
+	scaleIn := func(replicas int32) HorizontalScaling {
+		return HorizontalScaling{
+			ComponentOps: ComponentOps{ComponentName: "etcd"},
+			ScaleIn:      &ScaleIn{ReplicaChanger: ReplicaChanger{ReplicaChanges: pointer.Int32(replicas)}},
+		}
+	}
+	tests := []struct {
+		name       string
+		hScale     HorizontalScaling
+		compSpec   ClusterComponentSpec
+		isSharding bool
+		force      bool
+		errMsg     string
+	}{
+		{name: "odd replicas", hScale: scaleTo(5), compSpec: compSpec},
+		{name: "even replicas", hScale: scaleTo(4), compSpec: compSpec, errMsg: "expected to be odd"},
+		{name: "even replicas forced", hScale: scaleTo(4), compSpec: compSpec, force: true},
+		{name: "scale in to even replicas", hScale: scaleIn(1), compSpec: compSpec, errMsg: "expected to be odd"},
+		{name: "exceeding the max replicas", hScale: scaleTo(9), compSpec: compSpec, force: true, errMsg: "out of the limit [1, 7]"},
+		{name: "below the min replicas", hScale: scaleIn(3), compSpec: compSpec, force: true, errMsg: "out of the limit [1, 7]"},
+		{name: "even replicas of sharding", hScale: scaleTo(2), compSpec: shardingTemplate, isSharding: true, errMsg: "expected to be odd"},
+		{name: "no ComponentDefinition", hScale: scaleTo(2), compSpec: ClusterComponentSpec{Name: "etcd", Replicas: 3}, isSharding: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops := &OpsRequest{Spec: OpsRequestSpec{Type: HorizontalScalingType, Force: tt.force}}
+			err := ops.validateReplicasLimit(context.Background(), cli, cluster, tt.hScale, tt.compSpec, tt.isSharding)
+			if tt.errMsg == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.errMsg != "" && (err == nil || !strings.Contains(err.Error(), tt.errMsg)) {
+				t.Errorf("expect error containing %q, but got: %v", tt.errMsg, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestValidateRebuildInstance(t *testing.T) {
+	cluster := &Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mycluster"},
+		Spec: ClusterSpec{
+			ComponentSpecs: []ClusterComponentSpec{{Name: "mysql", Replicas: 2}},
+		},
+	}
+	newPod := func(name string, ready bool) *corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				Labels: map[string]string{
+					constant.AppInstanceLabelKey:    "mycluster",
+					constant.KBAppComponentLabelKey: "mysql",
+				},
+			},
+			Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}},
+		}
+	}
+	newOps := func(backupName string, instances ...string) *OpsRequest {
+		rebuild := RebuildInstance{ComponentOps: ComponentOps{ComponentName: "mysql"}, BackupName: backupName}
+		for _, name := range instances {
+			rebuild.Instances = append(rebuild.Instances, Instance{Name: name})
+		}
+		return &OpsRequest{Spec: OpsRequestSpec{Type: RebuildInstanceType, RebuildFrom: []RebuildInstance{rebuild}}}
+	}
+
+	cli := fake.NewClientBuilder().WithObjects(newPod("mycluster-mysql-0", true), newPod("mycluster-mysql-1", false)).Build()
+	tests := []struct {
+		name   string
+		ops    *OpsRequest
+		errMsg string
+	}{
+		{"rebuild from a healthy peer", newOps("", "mycluster-mysql-1"), ""},
+		{"no instance", newOps(""), "can not be empty"},
+		{"duplicated instances", newOps("", "mycluster-mysql-1", "mycluster-mysql-1"), "is duplicated"},
+		{"not a member", newOps("", "othercluster-mysql-0"), "is not a member"},
+		{"no healthy peer", newOps("", "mycluster-mysql-0", "mycluster-mysql-1"), "no healthy peer"},
+		{"rebuild from a backup", newOps("backup", "mycluster-mysql-0", "mycluster-mysql-1"), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ops.validateRebuildInstance(context.Background(), cli, cluster)
+			if tt.errMsg == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.errMsg != "" && (err == nil || !strings.Contains(err.Error(), tt.errMsg)) {
+				t.Errorf("expect error containing %q, but got: %v", tt.errMsg, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+)
+
+func TestValidateParametersWithConfigConstraint(t *testing.T) {
+	minimum, maximum := float64(1), float64(100000)
+	cc := &appsv1beta1.ConfigConstraintSpec{
+		ImmutableParameters: []string{"datadir"},
+		ParametersSchema: &appsv1beta1.ParametersSchema{
+			SchemaInJSON: &apiextensionsv1.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"spec": {
+						Type: "object",
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"max_connections": {Type: "integer", Minimum: &minimum, Maximum: &maximum},
+							"autocommit":      {Type: "string", Enum: []apiextensionsv1.JSON{{Raw: []byte(`"ON"`)}, {Raw: []byte(`"OFF"`)}}},
+							"mysqld": {
+								Type: "object",
+								Properties: map[string]apiextensionsv1.JSONSchemaProps{
+									"read_only": {Type: "boolean"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	value := func(v string) *string { return &v }
+
+	tests := []struct {
+		name   string
+		params []ParameterPair
+		errors []string
+	}{
+		{
+			name: "valid parameters",
+			params: []ParameterPair{
+				{Key: "max_connections", Value: value("1000")},
+				{Key: "autocommit", Value: value("ON")},
+				{Key: "mysqld.read_only", Value: value("true")},
+				{Key: "undeclared", Value: value("any")},
+				{Key: "max_connections"},
+			},
+		},
+		{
+			name: "invalid parameters",
+			params: []ParameterPair{
+				{Key: "max_connections", Value: value("many")},
+				{Key: "max_connections", Value: value("0")},
+				{Key: "autocommit", Value: value("YES")},
+				{Key: "mysqld.read_only", Value: value("maybe")},
+			},
+			errors: []string{
+				"keys[0].parameters[0].value",
+				"keys[0].parameters[1].value",
+				"keys[0].parameters[2].value",
+				"keys[0].parameters[3].value",
+			},
+		},
+		{
+			name:   "immutable parameters",
+			params: []ParameterPair{{Key: "datadir", Value: value("/data")}, {Key: "datadir"}},
+			errors: []string{"keys[0].parameters[0].key", "keys[0].parameters[1].key"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := []ParameterConfig{{Key: "my.cnf", Parameters: tt.params}}
+			errs := validateParametersWithConfigConstraint(keys, cc, field.NewPath("keys"))
+			if len(errs) != len(tt.errors) {
+				t.Fatalf("expected %d errors, got %v", len(tt.errors), errs)
+			}
+			for i, err := range errs {
+				if !strings.HasPrefix(err.Error(), tt.errors[i]) {
+					t.Errorf("expected error of %s, got %s", tt.errors[i], err.Error())
+				}
+			}
+		})
+	}
+
+	// no schema to validate with
+	keys := []ParameterConfig{{Key: "my.cnf", Parameters: []ParameterPair{{Key: "max_connections", Value: value("many")}}}}
+	if errs := validateParametersWithConfigConstraint(keys, &appsv1beta1.ConfigConstraintSpec{}, field.NewPath("keys")); len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+)
+
+func TestValidateStopAndStart(t *testing.T) {
+	clusterDef := &ClusterDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "mydef"},
+		Spec: ClusterDefinitionSpec{
+			Topologies: []ClusterTopology{{
+				Name:       "proxy",
+				Components: []ClusterTopologyComponent{{Name: "storage"}, {Name: "proxy"}, {Name: "monitor"}},
+				Orders:     &ClusterTopologyOrders{Provision: []string{"storage", "proxy,monitor"}},
+			}},
+		},
+	}
+	newCluster := func(storageReplicas, proxyReplicas int32) *Cluster {
+		return &Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mycluster"},
+			Spec: ClusterSpec{
+				ClusterDefRef: "mydef",
+				Topology:      "proxy",
+				ComponentSpecs: []ClusterComponentSpec{
+					{Name: "storage", Replicas: storageReplicas},
+					{Name: "proxy", Replicas: proxyReplicas},
+					{Name: "monitor", Replicas: 0},
+				},
+			},
+		}
+	}
+	newOps := func(opsType OpsType, compNames ...string) *OpsRequest {
+		ops := &OpsRequest{Spec: OpsRequestSpec{Type: opsType}}
+		for _, name := range compNames {
+			if opsType == StopType {
+				ops.Spec.StopList = append(ops.Spec.StopList, ComponentOps{ComponentName: name})
+			} else {
+				ops.Spec.StartList = append(ops.Spec.StartList, ComponentOps{ComponentName: name})
+			}
+		}
+		return ops
+	}
+
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{AddToScheme, workloads.AddToScheme, corev1.AddToScheme} {
+		if err := addToScheme(scheme); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(clusterDef).Build()
+	tests := []struct {
+		name    string
+		ops     *OpsRequest
+		cluster *Cluster
+		errMsg  string
+	}{
+		{"stop the whole cluster", newOps(StopType), newCluster(3, 2), ""},
+		{"stop a dependent component", newOps(StopType, "proxy"), newCluster(3, 2), ""},
+		{"stop a depended component", newOps(StopType, "storage"), newCluster(3, 2), `component "proxy" can not be running`},
+		{"stop the components together", newOps(StopType, "storage", "proxy"), newCluster(3, 2), ""},
+		{"stop a non-existent component", newOps(StopType, "mysql"), newCluster(3, 2), "not found"},
+		{"start the whole cluster", newOps(StartType), newCluster(0, 0), ""},
+		{"start a running component", newOps(StartType, "proxy"), newCluster(3, 2), "is not stopped"},
+		{"start a component with the dependency stopped", newOps(StartType, "proxy"), newCluster(0, 0), `component "proxy" can not be running`},
+		{"start the components together", newOps(StartType, "storage", "proxy"), newCluster(0, 0), ""},
+		{"start a depended component", newOps(StartType, "storage"), newCluster(0, 0), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ops.validateOps(context.Background(), cli, tt.cluster)
+			if tt.errMsg == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.errMsg != "" && (err == nil || !strings.Contains(err.Error(), tt.errMsg)) {
+				t.Errorf("expect error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
@@ -127,7 +127,7 @@ var _ = Describe("OpsRequest webhook", func() {
 	}
 
 	notFoundComponentsString := func(notFoundComponents string) string {
-		return fmt.Sprintf("components: [%s] not found", notFoundComponents)
+		return fmt.Sprintf(`componentName: Not found: "%s"`, notFoundComponents)
 	}
 
 	testUpgrade := func(cluster *Cluster) {
@@ -167,7 +167,7 @@ var _ = Describe("OpsRequest webhook", func() {
 
 		By("Expect an error for cancelling this opsRequest")
 		opsRequest.Spec.Cancel = true
-		Expect(k8sClient.Update(context.Background(), opsRequest).Error()).Should(ContainSubstring("forbidden to cancel the opsRequest which type not in ['VerticalScaling','HorizontalScaling']"))
+		Expect(k8sClient.Update(context.Background(), opsRequest).Error()).Should(ContainSubstring("forbidden to cancel the opsRequest which type not in ['VerticalScaling','HorizontalScaling','Custom']"))
 	}
 
 	testVerticalScaling := func(_ *Cluster) {
@@ -305,12 +305,15 @@ var _ = Describe("OpsRequest webhook", func() {
 		},
 		}
 		opsRequest.Spec.VolumeExpansionList = volumeExpansionList
-		Expect(testCtx.CreateObj(ctx, opsRequest).Error()).To(ContainSubstring("volumeClaimTemplates: [log] not found in component: " + componentName))
+		err := testCtx.CreateObj(ctx, opsRequest)
+		Expect(err.Error()).To(ContainSubstring(`volumeClaimTemplates[0].name: Not found: "log"`))
+		Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("Hint: view the volumeClaimTemplates of the component by command: kubectl get cluster %s -n %s -o yaml",
+			clusterName, opsRequest.Namespace)))
 
 		By("By testing volumeExpansion - storageClass do not support volume expansion")
 		volumeExpansionList = getSingleVolumeExpansionList(componentName, defaultVCTName, targetStorage)
 		opsRequest.Spec.VolumeExpansionList = volumeExpansionList
-		notSupportMsg := fmt.Sprintf("volumeClaimTemplate: [data] not support volume expansion in component: %s, you can view infos by command: kubectl get sc", componentName)
+		notSupportMsg := fmt.Sprintf("volumeClaimTemplate: [data] not support volume expansion in component: %s. Hint: view the allowVolumeExpansion of the storageClasses by command: kubectl get sc", componentName)
 		Expect(testCtx.CreateObj(ctx, opsRequest).Error()).To(ContainSubstring(notSupportMsg))
 
 		By("testing volumeExpansion - storageClass supports volume expansion")
@@ -687,12 +690,25 @@ var _ = Describe("OpsRequest webhook", func() {
 		By("delete cluster")
 		newCluster := &Cluster{}
 		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: clusterName, Namespace: cluster.Namespace}, newCluster)).Should(Succeed())
+		patch := client.MergeFrom(newCluster.DeepCopy())
+		newCluster.Finalizers = append(newCluster.Finalizers, "test-finalizer")
+		Expect(k8sClient.Patch(ctx, newCluster, patch)).Should(Succeed())
 		Expect(k8sClient.Delete(ctx, newCluster)).Should(Succeed())
 
+		By("expect an error when creating an OpsRequest for the terminating cluster")
+		restartOps := createTestOpsRequest(clusterName, opsRequestName+"-terminating", RestartType)
+		restartOps.Spec.RestartList = []ComponentOps{{ComponentName: componentName}}
+		Expect(testCtx.CreateObj(ctx, restartOps).Error()).To(ContainSubstring("is being deleted"))
+
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: clusterName, Namespace: cluster.Namespace}, newCluster)).Should(Succeed())
+		patch = client.MergeFrom(newCluster.DeepCopy())
+		newCluster.Finalizers = nil
+		Expect(k8sClient.Patch(ctx, newCluster, patch)).Should(Succeed())
+
 		By("test path labels")
 		Eventually(k8sClient.Get(ctx, client.ObjectKey{Name: clusterName, Namespace: cluster.Namespace}, &Cluster{})).Should(HaveOccurred())
 
-		patch := client.MergeFrom(opsRequest.DeepCopy())
+		patch = client.MergeFrom(opsRequest.DeepCopy())
 		opsRequest.Labels["test"] = "test-ops"
 		Expect(k8sClient.Patch(ctx, opsRequest, patch)).Should(Succeed())
 	}
@@ -705,8 +721,18 @@ var _ = Describe("OpsRequest webhook", func() {
 		}
 		Expect(testCtx.CreateObj(ctx, opsRequest).Error()).To(ContainSubstring(notFoundComponentsString("replicasets1")))
 
-		By("By testing restart. if api is legal, it will create successfully")
+		By("By testing restart in the dry-run mode, which is not supported")
 		opsRequest.Spec.RestartList[0].ComponentName = componentName
+		opsRequest.Spec.DryRun = true
+		Expect(testCtx.CreateObj(ctx, opsRequest).Error()).To(ContainSubstring("spec.dryRun is only supported"))
+		opsRequest.Spec.DryRun = false
+
+		By("By testing restart with rollbackOnFailure, which is not supported")
+		opsRequest.Spec.RollbackOnFailure = true
+		Expect(testCtx.CreateObj(ctx, opsRequest).Error()).To(ContainSubstring("spec.rollbackOnFailure is only supported"))
+		opsRequest.Spec.RollbackOnFailure = false
+
+		By("By testing restart. if api is legal, it will create successfully")
 		Expect(testCtx.CheckedCreateObj(ctx, opsRequest)).Should(Succeed())
 		return opsRequest
 	}
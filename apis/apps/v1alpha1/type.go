@@ -33,6 +33,7 @@ const (
 	ClusterKind           = "Cluster"
 	ComponentKind         = "Component"
 	OpsRequestKind        = "OpsRequestKind"
+	OpsDefinitionKind     = "OpsDefinition"
 
 	defaultInstanceTemplateReplicas = 1
 )
@@ -203,6 +204,21 @@ type ComponentConfigSpec struct {
 	// +listType=set
 	// +optional
 	ReRenderResourceTypes []RerenderResourceType `json:"reRenderResourceTypes,omitempty"`
+
+	// Specifies whether the parameters of the configuration can reference the values of Secrets,
+	// by `valueFrom.secretKeyRef` of the parameters in the Reconfigure OpsRequest.
+	//
+	// The secret-backed parameters are masked as "******" in the ConfigMap, so they never appear in plaintext
+	// in the ConfigMap, its revisions or the diffs reported by the OpsRequest.
+	// The configuration files containing them are rendered with the actual values into a Secret with the same name
+	// as the ConfigMap, and the ConfigMap and the Secret are mounted by a projected volume,
+	// in which the files of the Secret take precedence.
+	//
+	// Note that the secret-backed parameters must be of string type in the ConfigConstraint,
+	// and changing this field causes the Pods to be restarted.
+	//
+	// +optional
+	AllowSecretParameters bool `json:"allowSecretParameters,omitempty"`
 }
 
 // RerenderResourceType defines the resource requirements for a component.
@@ -266,7 +282,7 @@ const (
 // ClusterComponentPhase defines the phase of a cluster component as represented in cluster.status.components.phase field.
 //
 // +enum
-// +kubebuilder:validation:Enum={Creating,Running,Updating,Stopping,Stopped,Deleting,Failed,Abnormal}
+// +kubebuilder:validation:Enum={Creating,Running,Updating,Stopping,Stopped,Deleting,Failed,Abnormal,Completed}
 type ClusterComponentPhase string
 
 const (
@@ -297,16 +313,29 @@ const (
 	// AbnormalClusterCompPhase indicates the component has more than zero replicas, but there are some failed pods.
 	// The component is functioning, but it is in a fragile state.
 	AbnormalClusterCompPhase ClusterComponentPhase = "Abnormal"
+
+	// CompletedClusterCompPhase indicates all pods of a run-to-completion component have terminated successfully.
+	CompletedClusterCompPhase ClusterComponentPhase = "Completed"
 )
 
 const (
 	// define the cluster condition type
-	ConditionTypeHaltRecovery        = "HaltRecovery"        // ConditionTypeHaltRecovery describe Halt recovery processing stage
-	ConditionTypeProvisioningStarted = "ProvisioningStarted" // ConditionTypeProvisioningStarted the operator starts resource provisioning to create or change the cluster
-	ConditionTypeApplyResources      = "ApplyResources"      // ConditionTypeApplyResources the operator start to apply resources to create or change the cluster
-	ConditionTypeReplicasReady       = "ReplicasReady"       // ConditionTypeReplicasReady all pods of components are ready
-	ConditionTypeReady               = "Ready"               // ConditionTypeReady all components are running
-	ConditionTypeSwitchoverPrefix    = "Switchover-"         // ConditionTypeSwitchoverPrefix component status condition of switchover
+	ConditionTypeHaltRecovery            = "HaltRecovery"            // ConditionTypeHaltRecovery describe Halt recovery processing stage
+	ConditionTypeProvisioningStarted     = "ProvisioningStarted"     // ConditionTypeProvisioningStarted the operator starts resource provisioning to create or change the cluster
+	ConditionTypeApplyResources          = "ApplyResources"          // ConditionTypeApplyResources the operator start to apply resources to create or change the cluster
+	ConditionTypeReplicasReady           = "ReplicasReady"           // ConditionTypeReplicasReady all pods of components are ready
+	ConditionTypeReady                   = "Ready"                   // ConditionTypeReady all components are running
+	ConditionTypeSwitchoverPrefix        = "Switchover-"             // ConditionTypeSwitchoverPrefix component status condition of switchover
+	ConditionTypeInsufficientCapacity    = "InsufficientCapacity"    // ConditionTypeInsufficientCapacity the nodes can not hold the pods of component
+	ConditionTypeEndpointsReady          = "EndpointsReady"          // ConditionTypeEndpointsReady the external endpoints of the LoadBalancer services are reachable
+	ConditionTypeVolumesInSync           = "VolumesInSync"           // ConditionTypeVolumesInSync the PVCs are in sync with the volumeClaimTemplates
+	ConditionTypeCompliant               = "Compliant"               // ConditionTypeCompliant the cluster complies with all the CompliancePolicies selecting it
+	ConditionTypeNodePressure            = "NodePressure"            // ConditionTypeNodePressure some nodes hosting the pods of component are under pressure or not ready
+	ConditionTypeExternalResourcesLeaked = "ExternalResourcesLeaked" // ConditionTypeExternalResourcesLeaked the external resources of the deleted services are not released in time
+	ConditionTypeRestartPending          = "RestartPending"          // ConditionTypeRestartPending some changes of the component are pending a restart to take effect
+	ConditionTypeWorkloadMigrated        = "WorkloadMigrated"        // ConditionTypeWorkloadMigrated the legacy workload of component is migrated to InstanceSet
+	ConditionTypeReconcileBackoff        = "ReconcileBackoff"        // ConditionTypeReconcileBackoff the reconciliation of cluster repeatedly fails and is retried with backoff
+	ConditionTypeImageDigestDrifted      = "ImageDigestDrifted"      // ConditionTypeImageDigestDrifted some pods of component run images whose digests differ from the pinned ones
 )
 
 // Phase represents the current status of the ClusterDefinition and ClusterVersion CR.
@@ -325,11 +354,12 @@ const (
 
 // OpsPhase defines opsRequest phase.
 // +enum
-// +kubebuilder:validation:Enum={Pending,Creating,Running,Cancelling,Cancelled,Aborted,Failed,Succeed}
+// +kubebuilder:validation:Enum={Pending,Scheduled,Creating,Running,Cancelling,Cancelled,Aborted,Failed,Succeed,SucceededWithWarnings}
 type OpsPhase string
 
 const (
 	OpsPendingPhase    OpsPhase = "Pending"
+	OpsScheduledPhase  OpsPhase = "Scheduled"
 	OpsCreatingPhase   OpsPhase = "Creating"
 	OpsRunningPhase    OpsPhase = "Running"
 	OpsCancellingPhase OpsPhase = "Cancelling"
@@ -337,6 +367,91 @@ const (
 	OpsCancelledPhase  OpsPhase = "Cancelled"
 	OpsFailedPhase     OpsPhase = "Failed"
 	OpsAbortedPhase    OpsPhase = "Aborted"
+	// OpsSucceedWithWarningsPhase indicates that the operation is completed, but failed on some instances,
+	// which are tolerated by the failure policy.
+	OpsSucceedWithWarningsPhase OpsPhase = "SucceededWithWarnings"
+)
+
+// OpsImpact classifies the expected impact of an OpsRequest on the availability of the Cluster.
+//
+// +enum
+// +kubebuilder:validation:Enum={Online,BriefInterruptions,Downtime}
+type OpsImpact string
+
+const (
+	// OpsImpactOnline indicates the Components keep serving during the operation.
+	OpsImpactOnline OpsImpact = "Online"
+
+	// OpsImpactBriefInterruptions indicates the replicas of the Components are restarted or switched over one by one,
+	// the connections to them are interrupted briefly, while the Components keep serving.
+	OpsImpactBriefInterruptions OpsImpact = "BriefInterruptions"
+
+	// OpsImpactDowntime indicates some Components stop serving during the operation.
+	OpsImpactDowntime OpsImpact = "Downtime"
+)
+
+// OpsBatchPhase defines the phase of the OpsBatch.
+//
+// +enum
+// +kubebuilder:validation:Enum={Pending,Running,Succeed,Failed,Aborted}
+type OpsBatchPhase string
+
+const (
+	// OpsBatchPendingPhase indicates the OpsBatch has not started yet.
+	OpsBatchPendingPhase OpsBatchPhase = "Pending"
+
+	// OpsBatchRunningPhase indicates the waves of the OpsBatch are in progress.
+	OpsBatchRunningPhase OpsBatchPhase = "Running"
+
+	// OpsBatchSucceedPhase indicates all OpsRequests of the OpsBatch have succeeded.
+	OpsBatchSucceedPhase OpsBatchPhase = "Succeed"
+
+	// OpsBatchFailedPhase indicates all waves have finished, with some of the OpsRequests failed
+	// within the tolerated failure percentage.
+	OpsBatchFailedPhase OpsBatchPhase = "Failed"
+
+	// OpsBatchAbortedPhase indicates the OpsBatch is aborted because the tolerated failure percentage is exceeded,
+	// or no Cluster is selected.
+	OpsBatchAbortedPhase OpsBatchPhase = "Aborted"
+)
+
+// OpsFailurePolicy defines how an operation handles the failures of instances.
+// It is one of "Fail", "Ignore" or "Threshold(n%)".
+//
+// +kubebuilder:validation:Pattern=`^(Fail|Ignore|Threshold\(([0-9]|[1-9][0-9]|100)%\))$`
+type OpsFailurePolicy string
+
+const (
+	// OpsFailurePolicyFail fails the operation once an instance fails.
+	OpsFailurePolicyFail OpsFailurePolicy = "Fail"
+	// OpsFailurePolicyIgnore continues the operation on the remaining instances regardless of the failed ones.
+	OpsFailurePolicyIgnore OpsFailurePolicy = "Ignore"
+)
+
+// OpsPreemptionPolicy defines whether an OpsRequest can jump ahead of the queued OpsRequests with lower priorities.
+//
+// +enum
+// +kubebuilder:validation:Enum={PreemptLowerPriority,Never}
+type OpsPreemptionPolicy string
+
+const (
+	// PreemptLowerPriorityPolicy queues the OpsRequest ahead of the queued OpsRequests with lower priorities.
+	PreemptLowerPriorityPolicy OpsPreemptionPolicy = "PreemptLowerPriority"
+	// PreemptNeverPolicy queues the OpsRequest behind all the OpsRequests queued before it.
+	PreemptNeverPolicy OpsPreemptionPolicy = "Never"
+)
+
+// DryRunOperation defines the operation made to an object by the dry-run of an OpsRequest.
+//
+// +enum
+// +kubebuilder:validation:Enum={Create,Update,Patch,Delete}
+type DryRunOperation string
+
+const (
+	DryRunCreateOperation DryRunOperation = "Create"
+	DryRunUpdateOperation DryRunOperation = "Update"
+	DryRunPatchOperation  DryRunOperation = "Patch"
+	DryRunDeleteOperation DryRunOperation = "Delete"
 )
 
 // PodSelectionPolicy pod selection strategy.
@@ -372,7 +487,7 @@ const (
 
 // OpsType defines operation types.
 // +enum
-// +kubebuilder:validation:Enum={Upgrade,VerticalScaling,VolumeExpansion,HorizontalScaling,Restart,Reconfiguring,Start,Stop,Expose,Switchover,DataScript,Backup,Restore,RebuildInstance,Custom}
+// +kubebuilder:validation:Enum={Upgrade,VerticalScaling,VolumeExpansion,HorizontalScaling,Restart,Reconfiguring,Start,Stop,Expose,Switchover,DataScript,Backup,Restore,RebuildInstance,DebugInstance,MigrateNodePool,RotateTLS,Purge,RotateCredentials,ShardRebalance,MigrateInstance,Custom}
 type OpsType string
 
 const (
@@ -389,8 +504,26 @@ const (
 	DataScriptType        OpsType = "DataScript" // DataScriptType the data script operation will execute the data script against the cluster.
 	BackupType            OpsType = "Backup"
 	RestoreType           OpsType = "Restore"
-	RebuildInstanceType   OpsType = "RebuildInstance" // RebuildInstance rebuilding an instance is very useful when a node is offline or an instance is unrecoverable.
-	CustomType            OpsType = "Custom"          // use opsDefinition
+	RebuildInstanceType   OpsType = "RebuildInstance"   // RebuildInstance rebuilding an instance is very useful when a node is offline or an instance is unrecoverable.
+	DebugInstanceType     OpsType = "DebugInstance"     // DebugInstanceType attaches an ephemeral debug container to an instance.
+	MigrateNodePoolType   OpsType = "MigrateNodePool"   // MigrateNodePoolType moves the instances of a component to another node pool one by one.
+	RotateTLSType         OpsType = "RotateTLS"         // RotateTLSType renews the TLS certificates of components and reloads them into the instances.
+	PurgeType             OpsType = "Purge"             // PurgeType purges the logs and temporary files of components by the engine-defined action.
+	RotateCredentialsType OpsType = "RotateCredentials" // RotateCredentialsType rotates the passwords of the system accounts of components.
+	ShardRebalanceType    OpsType = "ShardRebalance"    // ShardRebalanceType moves the data between the shards of shardings by the engine-defined action.
+	MigrateInstanceType   OpsType = "MigrateInstance"   // MigrateInstanceType moves an instance of a component to another node or zone.
+	CustomType            OpsType = "Custom"            // use opsDefinition
+)
+
+// MigrateInstanceVolumePolicy defines how the volumes of the instance are handled when it is moved.
+//
+// +enum
+// +kubebuilder:validation:Enum={Retain,Recreate}
+type MigrateInstanceVolumePolicy string
+
+const (
+	RetainMigrateVolumePolicy   MigrateInstanceVolumePolicy = "Retain"
+	RecreateMigrateVolumePolicy MigrateInstanceVolumePolicy = "Recreate"
 )
 
 // ComponentResourceKey defines the resource key of component, such as pod/pvc.
@@ -457,6 +590,38 @@ var DefaultLeader = ConsensusMember{
 	AccessMode: ReadWrite,
 }
 
+// ComponentWorkloadType defines the type of the workload that manages the instances of a Component.
+//
+// +enum
+// +kubebuilder:validation:Enum={InstanceSet,Job}
+type ComponentWorkloadType string
+
+const (
+	// InstanceSetWorkloadType represents long-running instances managed by an InstanceSet.
+	InstanceSetWorkloadType ComponentWorkloadType = "InstanceSet"
+
+	// JobWorkloadType represents run-to-completion instances managed by a Job, such as schema migrations or data loaders.
+	JobWorkloadType ComponentWorkloadType = "Job"
+)
+
+// FencingStrategy defines the way to verify that the former leader is fenced.
+//
+// +enum
+// +kubebuilder:validation:Enum={PodDeletion,NodeCordon,Action}
+type FencingStrategy string
+
+const (
+	// PodDeletionFencingStrategy verifies that the Pod of the former leader has been deleted or recreated.
+	PodDeletionFencingStrategy FencingStrategy = "PodDeletion"
+
+	// NodeCordonFencingStrategy verifies that the Node of the former leader has been removed,
+	// or cordoned and tainted with NoExecute and the Pod of the former leader no longer runs on it.
+	NodeCordonFencingStrategy FencingStrategy = "NodeCordon"
+
+	// ActionFencingStrategy fences the former leader by the `fence` lifecycle action.
+	ActionFencingStrategy FencingStrategy = "Action"
+)
+
 // WorkloadType defines the type of workload for the components of the ClusterDefinition.
 // It can be one of the following: `Stateless`, `Stateful`, `Consensus`, or `Replication`.
 //
@@ -578,6 +743,18 @@ const (
 	AvailabilityPolicyNone AvailabilityPolicyType = "none"
 )
 
+// TopologyKey returns the node label key of the topology domains to spread the Pods across,
+// or empty if the Pods are not spread.
+func (p AvailabilityPolicyType) TopologyKey() string {
+	switch p {
+	case AvailabilityPolicyZone:
+		return corev1.LabelTopologyZone
+	case AvailabilityPolicyNode:
+		return corev1.LabelHostname
+	}
+	return ""
+}
+
 // ProgressStatus defines the status of the opsRequest progress.
 // +enum
 // +kubebuilder:validation:Enum={Processing,Pending,Failed,Succeed}
@@ -592,18 +769,21 @@ const (
 
 // ActionTaskStatus defines the status of the task.
 // +enum
-// +kubebuilder:validation:Enum={Processing,Failed,Succeed}
+// +kubebuilder:validation:Enum={Processing,Failed,Succeed,Terminated}
 type ActionTaskStatus string
 
 const (
 	ProcessingActionTaskStatus ActionTaskStatus = "Processing"
 	FailedActionTaskStatus     ActionTaskStatus = "Failed"
 	SucceedActionTaskStatus    ActionTaskStatus = "Succeed"
+	TerminatedActionTaskStatus ActionTaskStatus = "Terminated"
 )
 
 type OpsRequestBehaviour struct {
 	FromClusterPhases []ClusterPhase
 	ToClusterPhase    ClusterPhase
+	// ForceDisabled indicates that the OpsRequest can not be forcibly executed.
+	ForceDisabled bool
 }
 
 type OpsRecorder struct {
@@ -615,6 +795,8 @@ type OpsRecorder struct {
 	InQueue bool `json:"inQueue,omitempty"`
 	// indicates that the operation is queued for execution within its own-type scope.
 	QueueBySelf bool `json:"queueBySelf,omitempty"`
+	// the priority of the opsRequest, the queued opsRequests are sorted by it in descending order.
+	Priority int32 `json:"priority,omitempty"`
 }
 
 // ProvisionPolicyType defines the policy for creating accounts.
@@ -1288,3 +1470,19 @@ const (
 	HTTPProtocol  PrometheusScheme = "http"
 	HTTPSProtocol PrometheusScheme = "https"
 )
+
+// DayOfWeek defines a day of the week.
+//
+// +enum
+// +kubebuilder:validation:Enum={Monday,Tuesday,Wednesday,Thursday,Friday,Saturday,Sunday}
+type DayOfWeek string
+
+const (
+	Monday    DayOfWeek = "Monday"
+	Tuesday   DayOfWeek = "Tuesday"
+	Wednesday DayOfWeek = "Wednesday"
+	Thursday  DayOfWeek = "Thursday"
+	Friday    DayOfWeek = "Friday"
+	Saturday  DayOfWeek = "Saturday"
+	Sunday    DayOfWeek = "Sunday"
+)
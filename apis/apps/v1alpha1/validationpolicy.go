@@ -0,0 +1,171 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=validationpolicies,verbs=get;list;watch
+
+var validationPolicyLog = logf.Log.WithName("validationpolicy")
+
+// the resources of the objects validated by ValidationPolicies, used to build the forbidden errors.
+var validationTargetResources = map[ValidationTargetKind]string{
+	ValidationTargetCluster:    "clusters",
+	ValidationTargetOpsRequest: "opsrequests",
+}
+
+// enforceValidationPolicies validates the object against the rules of the ValidationPolicies targeting it.
+// oldObj is nil on creation, and cluster is the Cluster operated by the object, or the object itself if it is a Cluster.
+func enforceValidationPolicies(ctx context.Context, cli client.Client, kind ValidationTargetKind,
+	operation ValidationOperation, obj, oldObj client.Object, cluster *Cluster) error {
+	if cli == nil {
+		return nil
+	}
+	policyList := &ValidationPolicyList{}
+	if err := cli.List(ctx, policyList); err != nil {
+		return err
+	}
+	policies := make([]ValidationPolicy, 0, len(policyList.Items))
+	for _, policy := range policyList.Items {
+		if policy.DeletionTimestamp == nil && policy.Spec.IsEnforcedOn(kind, obj.GetNamespace(), operation) {
+			policies = append(policies, policy)
+		}
+	}
+	if len(policies) == 0 {
+		return nil
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].Name < policies[j].Name
+	})
+
+	vars, err := buildValidationVariables(operation, obj, oldObj, cluster)
+	if err != nil {
+		return err
+	}
+	var violations []string
+	for _, policy := range policies {
+		violations = append(violations, evaluateValidationPolicy(policy, vars)...)
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return apierrors.NewForbidden(schema.GroupResource{Group: GroupVersion.Group, Resource: validationTargetResources[kind]},
+		obj.GetName(), fmt.Errorf("%s", strings.Join(violations, "; ")))
+}
+
+// evaluateValidationPolicy evaluates the rules of the policy, and returns the messages of the violated ones.
+// The rules that fail to be compiled or evaluated are regarded as violated unless the failure policy is Ignore.
+func evaluateValidationPolicy(policy ValidationPolicy, vars map[string]any) []string {
+	var violations []string
+	handleError := func(rule ValidationRule, err error) {
+		if policy.Spec.FailurePolicy == ValidationFailurePolicyIgnore {
+			validationPolicyLog.Info("ignore the rule failed to be evaluated", "policy", policy.Name, "rule", rule.Name, "error", err.Error())
+			return
+		}
+		violations = append(violations, fmt.Sprintf("ValidationPolicy %s rule %s: %s", policy.Name, rule.Name, err.Error()))
+	}
+
+	env, err := newValidationEnv()
+	if err != nil {
+		for _, rule := range policy.Spec.Rules {
+			handleError(rule, err)
+		}
+		return violations
+	}
+	for _, rule := range policy.Spec.Rules {
+		allowed, err := evaluateValidationRule(env, rule.Expression, vars)
+		if err != nil {
+			handleError(rule, err)
+			continue
+		}
+		if allowed {
+			continue
+		}
+		message := rule.Message
+		if message == "" {
+			message = fmt.Sprintf("the expression %q is not satisfied", rule.Expression)
+		}
+		violations = append(violations, fmt.Sprintf("denied by ValidationPolicy %s rule %s: %s", policy.Name, rule.Name, message))
+	}
+	return violations
+}
+
+func newValidationEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("object", cel.DynType),
+		cel.Variable("oldObject", cel.DynType),
+		cel.Variable("cluster", cel.DynType),
+		cel.Variable("operation", cel.StringType),
+	)
+}
+
+func evaluateValidationRule(env *cel.Env, expression string, vars map[string]any) (bool, error) {
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return false, fmt.Errorf("invalid expression: %s", issues.Err().Error())
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		return false, fmt.Errorf("invalid expression: %s", err.Error())
+	}
+	out, _, err := program.Eval(vars)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate the expression: %s", err.Error())
+	}
+	allowed, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("the expression is evaluated to %v rather than a bool", out.Value())
+	}
+	return allowed, nil
+}
+
+// buildValidationVariables converts the objects to the unstructured variables accessible by the CEL expressions,
+// oldObject and cluster are null if they are absent.
+func buildValidationVariables(operation ValidationOperation, obj, oldObj client.Object, cluster *Cluster) (map[string]any, error) {
+	vars := map[string]any{
+		"operation": string(operation),
+		"oldObject": nil,
+		"cluster":   nil,
+	}
+	var err error
+	if vars["object"], err = runtime.DefaultUnstructuredConverter.ToUnstructured(obj); err != nil {
+		return nil, err
+	}
+	if oldObj != nil {
+		if vars["oldObject"], err = runtime.DefaultUnstructuredConverter.ToUnstructured(oldObj); err != nil {
+			return nil, err
+		}
+	}
+	if cluster != nil {
+		if vars["cluster"], err = runtime.DefaultUnstructuredConverter.ToUnstructured(cluster); err != nil {
+			return nil, err
+		}
+	}
+	return vars, nil
+}
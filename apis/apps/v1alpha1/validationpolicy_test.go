@@ -0,0 +1,138 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEnforceValidationPolicies(t *testing.T) {
+	newPolicy := func(name string, spec ValidationPolicySpec) *ValidationPolicy {
+		return &ValidationPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec}
+	}
+	policies := []client.Object{
+		newPolicy("no-stop-in-production", ValidationPolicySpec{
+			TargetKind: ValidationTargetOpsRequest,
+			Operations: []ValidationOperation{ValidationOperationCreate},
+			Rules: []ValidationRule{{
+				Name:       "no-stop",
+				Expression: "object.spec.type != 'Stop' || cluster.metadata.labels['environment'] != 'production'",
+				Message:    "the Clusters in production are not allowed to be stopped",
+			}},
+		}),
+		newPolicy("immutable-termination-policy", ValidationPolicySpec{
+			TargetKind: ValidationTargetCluster,
+			Namespaces: []string{"prod"},
+			Rules: []ValidationRule{{
+				Name:       "termination-policy",
+				Expression: "oldObject == null || object.spec.terminationPolicy == oldObject.spec.terminationPolicy",
+			}},
+		}),
+		newPolicy("broken", ValidationPolicySpec{
+			TargetKind:    ValidationTargetCluster,
+			FailurePolicy: ValidationFailurePolicyIgnore,
+			Rules:         []ValidationRule{{Name: "invalid", Expression: "object.spec.replicas +"}},
+		}),
+	}
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(policies...).Build()
+
+	newCluster := func(namespace string, policy TerminationPolicyType) *Cluster {
+		return &Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "mycluster",
+				Labels:    map[string]string{"environment": "production"},
+			},
+			Spec: ClusterSpec{TerminationPolicy: policy},
+		}
+	}
+	newOps := func(opsType OpsType) *OpsRequest {
+		return &OpsRequest{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "myops"},
+			Spec:       OpsRequestSpec{ClusterRef: "mycluster", Type: opsType},
+		}
+	}
+	cluster := newCluster("prod", Delete)
+	tests := []struct {
+		name      string
+		kind      ValidationTargetKind
+		operation ValidationOperation
+		obj       client.Object
+		oldObj    client.Object
+		cluster   *Cluster
+		errMsg    string
+	}{
+		{"allowed ops", ValidationTargetOpsRequest, ValidationOperationCreate, newOps(RestartType), nil, cluster, ""},
+		{"denied ops", ValidationTargetOpsRequest, ValidationOperationCreate, newOps(StopType), nil, cluster,
+			"the Clusters in production are not allowed to be stopped"},
+		{"operation not enforced", ValidationTargetOpsRequest, ValidationOperationUpdate, newOps(StopType), newOps(StopType), cluster, ""},
+		{"cluster created", ValidationTargetCluster, ValidationOperationCreate, cluster, nil, cluster, ""},
+		{"cluster updated", ValidationTargetCluster, ValidationOperationUpdate, cluster, newCluster("prod", Delete), cluster, ""},
+		{"termination policy changed", ValidationTargetCluster, ValidationOperationUpdate, newCluster("prod", WipeOut), cluster, cluster,
+			"rule termination-policy"},
+		{"namespace not enforced", ValidationTargetCluster, ValidationOperationUpdate, newCluster("dev", WipeOut), newCluster("dev", Delete), nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := enforceValidationPolicies(context.Background(), cli, tt.kind, tt.operation, tt.obj, tt.oldObj, tt.cluster)
+			if tt.errMsg == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.errMsg != "" && (!apierrors.IsForbidden(err) || !strings.Contains(err.Error(), tt.errMsg)) {
+				t.Errorf("expect forbidden error containing %q, but got: %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestEvaluateValidationPolicyFailure(t *testing.T) {
+	policy := ValidationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "broken"},
+		Spec: ValidationPolicySpec{
+			TargetKind: ValidationTargetCluster,
+			Rules: []ValidationRule{
+				{Name: "invalid", Expression: "object.spec.replicas +"},
+				{Name: "not-bool", Expression: "object.metadata.name"},
+			},
+		},
+	}
+	vars := map[string]any{
+		"object":    map[string]any{"metadata": map[string]any{"name": "mycluster"}},
+		"oldObject": nil,
+		"cluster":   nil,
+		"operation": string(ValidationOperationCreate),
+	}
+	if violations := evaluateValidationPolicy(policy, vars); len(violations) != 2 {
+		t.Errorf("expect the failed rules are denied, but got: %v", violations)
+	}
+	policy.Spec.FailurePolicy = ValidationFailurePolicyIgnore
+	if violations := evaluateValidationPolicy(policy, vars); len(violations) != 0 {
+		t.Errorf("expect the failed rules are ignored, but got: %v", violations)
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"golang.org/x/exp/slices"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ValidationPolicySpec defines the desired state of ValidationPolicy.
+type ValidationPolicySpec struct {
+	// Specifies the kind of the objects validated by the policy.
+	//
+	// +kubebuilder:validation:Required
+	TargetKind ValidationTargetKind `json:"targetKind"`
+
+	// Specifies the operations on which the policy is enforced.
+	// The policy is enforced on both "Create" and "Update" if it is not specified.
+	//
+	// Updates that do not change the spec of the object are not validated.
+	//
+	// +optional
+	Operations []ValidationOperation `json:"operations,omitempty"`
+
+	// Limits the policy to the objects in the specified namespaces.
+	// The policy is enforced in all namespaces if it is not specified.
+	//
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Specifies the rules enforced by the policy, each of them is expressed in CEL.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	Rules []ValidationRule `json:"rules" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+
+	// Specifies how the rules that fail to be compiled or evaluated are handled.
+	//
+	// - `Fail`: the request is denied.
+	// - `Ignore`: the rule is skipped.
+	//
+	// +kubebuilder:default=Fail
+	// +optional
+	FailurePolicy ValidationFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// ValidationRule defines a rule of a ValidationPolicy expressed in CEL.
+type ValidationRule struct {
+	// Specifies the name of the rule, which is unique within the policy.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([a-z0-9\.\-]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Specifies the CEL expression which evaluates to true if the request is allowed.
+	//
+	// The expression can access the following variables:
+	//
+	// - `object`: the object being created or updated.
+	// - `oldObject`: the object before the update, it is null on creation.
+	// - `cluster`: the Cluster operated by the OpsRequest, or the Cluster itself if the object is a Cluster.
+	// - `operation`: the operation of the request, either "Create" or "Update".
+	//
+	// For example, `object.spec.type != 'Stop' || cluster.metadata.labels['env'] != 'production'`.
+	//
+	// +kubebuilder:validation:Required
+	Expression string `json:"expression"`
+
+	// Specifies the message returned when the request is denied by the rule.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ValidationTargetKind defines the kind of the objects validated by a ValidationPolicy.
+//
+// +enum
+// +kubebuilder:validation:Enum={Cluster,OpsRequest}
+type ValidationTargetKind string
+
+const (
+	ValidationTargetCluster    ValidationTargetKind = "Cluster"
+	ValidationTargetOpsRequest ValidationTargetKind = "OpsRequest"
+)
+
+// ValidationOperation defines the operation of the request validated by a ValidationPolicy.
+//
+// +enum
+// +kubebuilder:validation:Enum={Create,Update}
+type ValidationOperation string
+
+const (
+	ValidationOperationCreate ValidationOperation = "Create"
+	ValidationOperationUpdate ValidationOperation = "Update"
+)
+
+// ValidationFailurePolicy defines how the rules that fail to be compiled or evaluated are handled.
+//
+// +enum
+// +kubebuilder:validation:Enum={Fail,Ignore}
+type ValidationFailurePolicy string
+
+const (
+	ValidationFailurePolicyFail   ValidationFailurePolicy = "Fail"
+	ValidationFailurePolicyIgnore ValidationFailurePolicy = "Ignore"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories={kubeblocks},scope=Cluster,shortName=vp
+// +kubebuilder:printcolumn:name="TARGET",type="string",JSONPath=".spec.targetKind",description="The kind of the validated objects."
+// +kubebuilder:printcolumn:name="FAILURE-POLICY",type="string",JSONPath=".spec.failurePolicy"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ValidationPolicy registers extra validation rules for Clusters or OpsRequests, which are enforced by their admission webhooks.
+// It allows platform admins to add organization-specific guardrails without changing the webhooks.
+type ValidationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ValidationPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ValidationPolicyList contains a list of ValidationPolicy.
+type ValidationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ValidationPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ValidationPolicy{}, &ValidationPolicyList{})
+}
+
+// IsEnforcedOn checks whether the policy is enforced on the operation of the object.
+func (r ValidationPolicySpec) IsEnforcedOn(kind ValidationTargetKind, namespace string, operation ValidationOperation) bool {
+	if r.TargetKind != kind {
+		return false
+	}
+	if len(r.Namespaces) > 0 && !slices.Contains(r.Namespaces, namespace) {
+		return false
+	}
+	return len(r.Operations) == 0 || slices.Contains(r.Operations, operation)
+}
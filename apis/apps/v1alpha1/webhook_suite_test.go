@@ -165,6 +165,9 @@ var _ = BeforeSuite(func() {
 	err = (&OpsRequest{}).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = (&OpsDefinition{}).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
 	err = (&ServiceDescriptor{}).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -25,6 +25,7 @@ package v1alpha1
 
 import (
 	"github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+	dataprotectionv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	workloadsv1alpha1 "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/api/core/v1"
@@ -369,6 +370,26 @@ func (in *ClusterBackup) DeepCopy() *ClusterBackup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterComplianceReport) DeepCopyInto(out *ClusterComplianceReport) {
+	*out = *in
+	if in.Violations != nil {
+		in, out := &in.Violations, &out.Violations
+		*out = make([]ComplianceViolation, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterComplianceReport.
+func (in *ClusterComplianceReport) DeepCopy() *ClusterComplianceReport {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterComplianceReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterComponentConfig) DeepCopyInto(out *ClusterComponentConfig) {
 	*out = *in
@@ -640,6 +661,13 @@ func (in *ClusterComponentSpec) DeepCopyInto(out *ClusterComponentSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SharedVolumes != nil {
+		in, out := &in.SharedVolumes, &out.SharedVolumes
+		*out = make([]SharedVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Services != nil {
 		in, out := &in.Services, &out.Services
 		*out = make([]ClusterComponentService, len(*in))
@@ -698,6 +726,53 @@ func (in *ClusterComponentSpec) DeepCopyInto(out *ClusterComponentSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ReadWriteSplitServices != nil {
+		in, out := &in.ReadWriteSplitServices, &out.ReadWriteSplitServices
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CanaryService != nil {
+		in, out := &in.CanaryService, &out.CanaryService
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VolumeSnapshotPolicy != nil {
+		in, out := &in.VolumeSnapshotPolicy, &out.VolumeSnapshotPolicy
+		*out = new(VolumeSnapshotPolicy)
+		**out = **in
+	}
+	if in.DNSPolicy != nil {
+		in, out := &in.DNSPolicy, &out.DNSPolicy
+		*out = new(v1.DNSPolicy)
+		**out = **in
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(v1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DataExport != nil {
+		in, out := &in.DataExport, &out.DataExport
+		*out = new(ComponentDataExport)
+		**out = **in
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitScripts != nil {
+		in, out := &in.InitScripts, &out.InitScripts
+		*out = new(InitScripts)
+		**out = **in
+	}
+	if in.PinImageDigests != nil {
+		in, out := &in.PinImageDigests, &out.PinImageDigests
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Monitor != nil {
 		in, out := &in.Monitor, &out.Monitor
 		*out = new(bool)
@@ -741,6 +816,16 @@ func (in *ClusterComponentStatus) DeepCopyInto(out *ClusterComponentStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TLSCertificate != nil {
+		in, out := &in.TLSCertificate, &out.TLSCertificate
+		*out = new(TLSCertificateStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestoreProgress != nil {
+		in, out := &in.RestoreProgress, &out.RestoreProgress
+		*out = new(dataprotectionv1alpha1.RestoreDataProgress)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterComponentStatus.
@@ -1022,6 +1107,11 @@ func (in *ClusterList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterNetwork) DeepCopyInto(out *ClusterNetwork) {
 	*out = *in
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNetwork.
@@ -1143,6 +1233,25 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(ClusterBackup)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FreezeWindows != nil {
+		in, out := &in.FreezeWindows, &out.FreezeWindows
+		*out = make([]FreezeWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OpsRequestDefaults != nil {
+		in, out := &in.OpsRequestDefaults, &out.OpsRequestDefaults
+		*out = new(OpsRequestDefaults)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Replicas != nil {
 		in, out := &in.Replicas, &out.Replicas
 		*out = new(int32)
@@ -1153,7 +1262,7 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 	if in.Network != nil {
 		in, out := &in.Network, &out.Network
 		*out = new(ClusterNetwork)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -1184,6 +1293,10 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastBackupTime != nil {
+		in, out := &in.LastBackupTime, &out.LastBackupTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
@@ -1474,116 +1587,98 @@ func (in *CompletionProbe) DeepCopy() *CompletionProbe {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Component) DeepCopyInto(out *Component) {
+func (in *ComplianceBuiltinRules) DeepCopyInto(out *ComplianceBuiltinRules) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.HighAvailability != nil {
+		in, out := &in.HighAvailability, &out.HighAvailability
+		*out = new(ComplianceHighAvailabilityRule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EOLServiceVersions != nil {
+		in, out := &in.EOLServiceVersions, &out.EOLServiceVersions
+		*out = make([]ComplianceEOLServiceVersions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Component.
-func (in *Component) DeepCopy() *Component {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceBuiltinRules.
+func (in *ComplianceBuiltinRules) DeepCopy() *ComplianceBuiltinRules {
 	if in == nil {
 		return nil
 	}
-	out := new(Component)
+	out := new(ComplianceBuiltinRules)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Component) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComponentConfigSpec) DeepCopyInto(out *ComponentConfigSpec) {
+func (in *ComplianceEOLServiceVersions) DeepCopyInto(out *ComplianceEOLServiceVersions) {
 	*out = *in
-	in.ComponentTemplateSpec.DeepCopyInto(&out.ComponentTemplateSpec)
-	if in.Keys != nil {
-		in, out := &in.Keys, &out.Keys
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.LegacyRenderedConfigSpec != nil {
-		in, out := &in.LegacyRenderedConfigSpec, &out.LegacyRenderedConfigSpec
-		*out = new(LegacyRenderedTemplateSpec)
-		**out = **in
-	}
-	if in.AsEnvFrom != nil {
-		in, out := &in.AsEnvFrom, &out.AsEnvFrom
+	if in.CompDefs != nil {
+		in, out := &in.CompDefs, &out.CompDefs
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.InjectEnvTo != nil {
-		in, out := &in.InjectEnvTo, &out.InjectEnvTo
+	if in.ServiceVersions != nil {
+		in, out := &in.ServiceVersions, &out.ServiceVersions
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.ReRenderResourceTypes != nil {
-		in, out := &in.ReRenderResourceTypes, &out.ReRenderResourceTypes
-		*out = make([]RerenderResourceType, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentConfigSpec.
-func (in *ComponentConfigSpec) DeepCopy() *ComponentConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceEOLServiceVersions.
+func (in *ComplianceEOLServiceVersions) DeepCopy() *ComplianceEOLServiceVersions {
 	if in == nil {
 		return nil
 	}
-	out := new(ComponentConfigSpec)
+	out := new(ComplianceEOLServiceVersions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComponentDefRef) DeepCopyInto(out *ComponentDefRef) {
+func (in *ComplianceHighAvailabilityRule) DeepCopyInto(out *ComplianceHighAvailabilityRule) {
 	*out = *in
-	if in.ComponentRefEnvs != nil {
-		in, out := &in.ComponentRefEnvs, &out.ComponentRefEnvs
-		*out = make([]ComponentRefEnv, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentDefRef.
-func (in *ComponentDefRef) DeepCopy() *ComponentDefRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceHighAvailabilityRule.
+func (in *ComplianceHighAvailabilityRule) DeepCopy() *ComplianceHighAvailabilityRule {
 	if in == nil {
 		return nil
 	}
-	out := new(ComponentDefRef)
+	out := new(ComplianceHighAvailabilityRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComponentDefinition) DeepCopyInto(out *ComponentDefinition) {
+func (in *CompliancePolicy) DeepCopyInto(out *CompliancePolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentDefinition.
-func (in *ComponentDefinition) DeepCopy() *ComponentDefinition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompliancePolicy.
+func (in *CompliancePolicy) DeepCopy() *CompliancePolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(ComponentDefinition)
+	out := new(CompliancePolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ComponentDefinition) DeepCopyObject() runtime.Object {
+func (in *CompliancePolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1591,31 +1686,31 @@ func (in *ComponentDefinition) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComponentDefinitionList) DeepCopyInto(out *ComponentDefinitionList) {
+func (in *CompliancePolicyList) DeepCopyInto(out *CompliancePolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ComponentDefinition, len(*in))
+		*out = make([]CompliancePolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentDefinitionList.
-func (in *ComponentDefinitionList) DeepCopy() *ComponentDefinitionList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompliancePolicyList.
+func (in *CompliancePolicyList) DeepCopy() *CompliancePolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(ComponentDefinitionList)
+	out := new(CompliancePolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ComponentDefinitionList) DeepCopyObject() runtime.Object {
+func (in *CompliancePolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1623,140 +1718,536 @@ func (in *ComponentDefinitionList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComponentDefinitionSpec) DeepCopyInto(out *ComponentDefinitionSpec) {
+func (in *CompliancePolicySpec) DeepCopyInto(out *CompliancePolicySpec) {
 	*out = *in
-	in.Runtime.DeepCopyInto(&out.Runtime)
-	if in.Monitor != nil {
-		in, out := &in.Monitor, &out.Monitor
-		*out = new(MonitorConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Exporter != nil {
-		in, out := &in.Exporter, &out.Exporter
-		*out = new(Exporter)
-		**out = **in
-	}
-	if in.Vars != nil {
-		in, out := &in.Vars, &out.Vars
-		*out = make([]EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Volumes != nil {
-		in, out := &in.Volumes, &out.Volumes
-		*out = make([]ComponentVolume, len(*in))
-		copy(*out, *in)
-	}
-	if in.HostNetwork != nil {
-		in, out := &in.HostNetwork, &out.HostNetwork
-		*out = new(HostNetwork)
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Services != nil {
-		in, out := &in.Services, &out.Services
-		*out = make([]ComponentService, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Configs != nil {
-		in, out := &in.Configs, &out.Configs
-		*out = make([]ComponentConfigSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.LogConfigs != nil {
-		in, out := &in.LogConfigs, &out.LogConfigs
-		*out = make([]LogConfig, len(*in))
-		copy(*out, *in)
-	}
-	if in.Scripts != nil {
-		in, out := &in.Scripts, &out.Scripts
-		*out = make([]ComponentTemplateSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.PolicyRules != nil {
-		in, out := &in.PolicyRules, &out.PolicyRules
-		*out = make([]rbacv1.PolicyRule, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.ReplicasLimit != nil {
-		in, out := &in.ReplicasLimit, &out.ReplicasLimit
-		*out = new(ReplicasLimit)
-		**out = **in
-	}
-	if in.SystemAccounts != nil {
-		in, out := &in.SystemAccounts, &out.SystemAccounts
-		*out = make([]SystemAccount, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.UpdateStrategy != nil {
-		in, out := &in.UpdateStrategy, &out.UpdateStrategy
-		*out = new(UpdateStrategy)
-		**out = **in
-	}
-	if in.PodManagementPolicy != nil {
-		in, out := &in.PodManagementPolicy, &out.PodManagementPolicy
-		*out = new(appsv1.PodManagementPolicyType)
-		**out = **in
-	}
-	if in.Roles != nil {
-		in, out := &in.Roles, &out.Roles
-		*out = make([]ReplicaRole, len(*in))
+	in.BuiltinRules.DeepCopyInto(&out.BuiltinRules)
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ComplianceRule, len(*in))
 		copy(*out, *in)
 	}
-	if in.LifecycleActions != nil {
-		in, out := &in.LifecycleActions, &out.LifecycleActions
-		*out = new(ComponentLifecycleActions)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ServiceRefDeclarations != nil {
-		in, out := &in.ServiceRefDeclarations, &out.ServiceRefDeclarations
-		*out = make([]ServiceRefDeclaration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentDefinitionSpec.
-func (in *ComponentDefinitionSpec) DeepCopy() *ComponentDefinitionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompliancePolicySpec.
+func (in *CompliancePolicySpec) DeepCopy() *CompliancePolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ComponentDefinitionSpec)
+	out := new(CompliancePolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComponentDefinitionStatus) DeepCopyInto(out *ComponentDefinitionStatus) {
+func (in *CompliancePolicyStatus) DeepCopyInto(out *CompliancePolicyStatus) {
 	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentDefinitionStatus.
+	in.LastEvaluationTime.DeepCopyInto(&out.LastEvaluationTime)
+	if in.Reports != nil {
+		in, out := &in.Reports, &out.Reports
+		*out = make([]ClusterComplianceReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompliancePolicyStatus.
+func (in *CompliancePolicyStatus) DeepCopy() *CompliancePolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CompliancePolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceRule) DeepCopyInto(out *ComplianceRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceRule.
+func (in *ComplianceRule) DeepCopy() *ComplianceRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceViolation) DeepCopyInto(out *ComplianceViolation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceViolation.
+func (in *ComplianceViolation) DeepCopy() *ComplianceViolation {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceViolation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Component) DeepCopyInto(out *Component) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Component.
+func (in *Component) DeepCopy() *Component {
+	if in == nil {
+		return nil
+	}
+	out := new(Component)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Component) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentAssertion) DeepCopyInto(out *ComponentAssertion) {
+	*out = *in
+	out.ComponentOps = in.ComponentOps
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentAssertion.
+func (in *ComponentAssertion) DeepCopy() *ComponentAssertion {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentAssertion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentClass) DeepCopyInto(out *ComponentClass) {
+	*out = *in
+	out.CPU = in.CPU.DeepCopy()
+	out.Memory = in.Memory.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentClass.
+func (in *ComponentClass) DeepCopy() *ComponentClass {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentClassDefinition) DeepCopyInto(out *ComponentClassDefinition) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentClassDefinition.
+func (in *ComponentClassDefinition) DeepCopy() *ComponentClassDefinition {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentClassDefinition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ComponentClassDefinition) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentClassDefinitionList) DeepCopyInto(out *ComponentClassDefinitionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ComponentClassDefinition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentClassDefinitionList.
+func (in *ComponentClassDefinitionList) DeepCopy() *ComponentClassDefinitionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentClassDefinitionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ComponentClassDefinitionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentClassDefinitionSpec) DeepCopyInto(out *ComponentClassDefinitionSpec) {
+	*out = *in
+	if in.CompDefs != nil {
+		in, out := &in.CompDefs, &out.CompDefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Constraint != nil {
+		in, out := &in.Constraint, &out.Constraint
+		*out = new(ComponentResourceConstraint)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Classes != nil {
+		in, out := &in.Classes, &out.Classes
+		*out = make([]ComponentClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentClassDefinitionSpec.
+func (in *ComponentClassDefinitionSpec) DeepCopy() *ComponentClassDefinitionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentClassDefinitionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentClassRef) DeepCopyInto(out *ComponentClassRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentClassRef.
+func (in *ComponentClassRef) DeepCopy() *ComponentClassRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentClassRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentConfigSpec) DeepCopyInto(out *ComponentConfigSpec) {
+	*out = *in
+	in.ComponentTemplateSpec.DeepCopyInto(&out.ComponentTemplateSpec)
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LegacyRenderedConfigSpec != nil {
+		in, out := &in.LegacyRenderedConfigSpec, &out.LegacyRenderedConfigSpec
+		*out = new(LegacyRenderedTemplateSpec)
+		**out = **in
+	}
+	if in.AsEnvFrom != nil {
+		in, out := &in.AsEnvFrom, &out.AsEnvFrom
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InjectEnvTo != nil {
+		in, out := &in.InjectEnvTo, &out.InjectEnvTo
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReRenderResourceTypes != nil {
+		in, out := &in.ReRenderResourceTypes, &out.ReRenderResourceTypes
+		*out = make([]RerenderResourceType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentConfigSpec.
+func (in *ComponentConfigSpec) DeepCopy() *ComponentConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentDataExport) DeepCopyInto(out *ComponentDataExport) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentDataExport.
+func (in *ComponentDataExport) DeepCopy() *ComponentDataExport {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentDataExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentDefRef) DeepCopyInto(out *ComponentDefRef) {
+	*out = *in
+	if in.ComponentRefEnvs != nil {
+		in, out := &in.ComponentRefEnvs, &out.ComponentRefEnvs
+		*out = make([]ComponentRefEnv, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentDefRef.
+func (in *ComponentDefRef) DeepCopy() *ComponentDefRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentDefRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentDefinition) DeepCopyInto(out *ComponentDefinition) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentDefinition.
+func (in *ComponentDefinition) DeepCopy() *ComponentDefinition {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentDefinition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ComponentDefinition) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentDefinitionList) DeepCopyInto(out *ComponentDefinitionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ComponentDefinition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentDefinitionList.
+func (in *ComponentDefinitionList) DeepCopy() *ComponentDefinitionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentDefinitionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ComponentDefinitionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentDefinitionSpec) DeepCopyInto(out *ComponentDefinitionSpec) {
+	*out = *in
+	in.Runtime.DeepCopyInto(&out.Runtime)
+	if in.Monitor != nil {
+		in, out := &in.Monitor, &out.Monitor
+		*out = new(MonitorConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Exporter != nil {
+		in, out := &in.Exporter, &out.Exporter
+		*out = new(Exporter)
+		**out = **in
+	}
+	if in.Vars != nil {
+		in, out := &in.Vars, &out.Vars
+		*out = make([]EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]ComponentVolume, len(*in))
+		copy(*out, *in)
+	}
+	if in.HostNetwork != nil {
+		in, out := &in.HostNetwork, &out.HostNetwork
+		*out = new(HostNetwork)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]ComponentService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Configs != nil {
+		in, out := &in.Configs, &out.Configs
+		*out = make([]ComponentConfigSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LogConfigs != nil {
+		in, out := &in.LogConfigs, &out.LogConfigs
+		*out = make([]LogConfig, len(*in))
+		copy(*out, *in)
+	}
+	if in.Scripts != nil {
+		in, out := &in.Scripts, &out.Scripts
+		*out = make([]ComponentTemplateSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PolicyRules != nil {
+		in, out := &in.PolicyRules, &out.PolicyRules
+		*out = make([]rbacv1.PolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ReplicasLimit != nil {
+		in, out := &in.ReplicasLimit, &out.ReplicasLimit
+		*out = new(ReplicasLimit)
+		**out = **in
+	}
+	if in.SystemAccounts != nil {
+		in, out := &in.SystemAccounts, &out.SystemAccounts
+		*out = make([]SystemAccount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(UpdateStrategy)
+		**out = **in
+	}
+	if in.PodManagementPolicy != nil {
+		in, out := &in.PodManagementPolicy, &out.PodManagementPolicy
+		*out = new(appsv1.PodManagementPolicyType)
+		**out = **in
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]ReplicaRole, len(*in))
+		copy(*out, *in)
+	}
+	if in.LifecycleActions != nil {
+		in, out := &in.LifecycleActions, &out.LifecycleActions
+		*out = new(ComponentLifecycleActions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceRefDeclarations != nil {
+		in, out := &in.ServiceRefDeclarations, &out.ServiceRefDeclarations
+		*out = make([]ServiceRefDeclaration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DebugContainer != nil {
+		in, out := &in.DebugContainer, &out.DebugContainer
+		*out = new(DebugContainer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Fencing != nil {
+		in, out := &in.Fencing, &out.Fencing
+		*out = new(FencingPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentDefinitionSpec.
+func (in *ComponentDefinitionSpec) DeepCopy() *ComponentDefinitionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentDefinitionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentDefinitionStatus) DeepCopyInto(out *ComponentDefinitionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentDefinitionStatus.
 func (in *ComponentDefinitionStatus) DeepCopy() *ComponentDefinitionStatus {
 	if in == nil {
 		return nil
@@ -1844,6 +2335,56 @@ func (in *ComponentLifecycleActions) DeepCopyInto(out *ComponentLifecycleActions
 		*out = new(LifecycleActionHandler)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AccountRotation != nil {
+		in, out := &in.AccountRotation, &out.AccountRotation
+		*out = new(LifecycleActionHandler)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReloadTLS != nil {
+		in, out := &in.ReloadTLS, &out.ReloadTLS
+		*out = new(LifecycleActionHandler)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Fence != nil {
+		in, out := &in.Fence, &out.Fence
+		*out = new(LifecycleActionHandler)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Purge != nil {
+		in, out := &in.Purge, &out.Purge
+		*out = new(LifecycleActionHandler)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradePreCheck != nil {
+		in, out := &in.UpgradePreCheck, &out.UpgradePreCheck
+		*out = new(LifecycleActionHandler)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ShardRebalance != nil {
+		in, out := &in.ShardRebalance, &out.ShardRebalance
+		*out = new(LifecycleActionHandler)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReplicationLag != nil {
+		in, out := &in.ReplicationLag, &out.ReplicationLag
+		*out = new(LifecycleActionHandler)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DrainBackend != nil {
+		in, out := &in.DrainBackend, &out.DrainBackend
+		*out = new(LifecycleActionHandler)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResumeBackend != nil {
+		in, out := &in.ResumeBackend, &out.ResumeBackend
+		*out = new(LifecycleActionHandler)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WarmUpCheck != nil {
+		in, out := &in.WarmUpCheck, &out.WarmUpCheck
+		*out = new(WarmUpCheck)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentLifecycleActions.
@@ -1944,6 +2485,31 @@ func (in *ComponentRefEnv) DeepCopy() *ComponentRefEnv {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentResourceConstraint) DeepCopyInto(out *ComponentResourceConstraint) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		*out = new(ResourceRange)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(ResourceRange)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentResourceConstraint.
+func (in *ComponentResourceConstraint) DeepCopy() *ComponentResourceConstraint {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentResourceConstraint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentService) DeepCopyInto(out *ComponentService) {
 	*out = *in
@@ -2016,6 +2582,13 @@ func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SharedVolumes != nil {
+		in, out := &in.SharedVolumes, &out.SharedVolumes
+		*out = make([]SharedVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Services != nil {
 		in, out := &in.Services, &out.Services
 		*out = make([]ComponentService, len(*in))
@@ -2086,6 +2659,53 @@ func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ReadWriteSplitServices != nil {
+		in, out := &in.ReadWriteSplitServices, &out.ReadWriteSplitServices
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CanaryService != nil {
+		in, out := &in.CanaryService, &out.CanaryService
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VolumeSnapshotPolicy != nil {
+		in, out := &in.VolumeSnapshotPolicy, &out.VolumeSnapshotPolicy
+		*out = new(VolumeSnapshotPolicy)
+		**out = **in
+	}
+	if in.DNSPolicy != nil {
+		in, out := &in.DNSPolicy, &out.DNSPolicy
+		*out = new(v1.DNSPolicy)
+		**out = **in
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(v1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DataExport != nil {
+		in, out := &in.DataExport, &out.DataExport
+		*out = new(ComponentDataExport)
+		**out = **in
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitScripts != nil {
+		in, out := &in.InitScripts, &out.InitScripts
+		*out = new(InitScripts)
+		**out = **in
+	}
+	if in.PinImageDigests != nil {
+		in, out := &in.PinImageDigests, &out.PinImageDigests
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentSpec.
@@ -2115,6 +2735,25 @@ func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.VolumeSnapshots != nil {
+		in, out := &in.VolumeSnapshots, &out.VolumeSnapshots
+		*out = make([]ComponentVolumeSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RestoreProgress != nil {
+		in, out := &in.RestoreProgress, &out.RestoreProgress
+		*out = new(dataprotectionv1alpha1.RestoreDataProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImageDigests != nil {
+		in, out := &in.ImageDigests, &out.ImageDigests
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
@@ -2392,6 +3031,13 @@ func (in *ComponentVersionSpec) DeepCopyInto(out *ComponentVersionSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.UpgradePaths != nil {
+		in, out := &in.UpgradePaths, &out.UpgradePaths
+		*out = make([]ComponentVersionUpgradePath, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentVersionSpec.
@@ -2419,6 +3065,26 @@ func (in *ComponentVersionStatus) DeepCopy() *ComponentVersionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentVersionUpgradePath) DeepCopyInto(out *ComponentVersionUpgradePath) {
+	*out = *in
+	if in.PreChecks != nil {
+		in, out := &in.PreChecks, &out.PreChecks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentVersionUpgradePath.
+func (in *ComponentVersionUpgradePath) DeepCopy() *ComponentVersionUpgradePath {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentVersionUpgradePath)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentVolume) DeepCopyInto(out *ComponentVolume) {
 	*out = *in
@@ -2434,6 +3100,26 @@ func (in *ComponentVolume) DeepCopy() *ComponentVolume {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentVolumeSnapshot) DeepCopyInto(out *ComponentVolumeSnapshot) {
+	*out = *in
+	in.CreationTimestamp.DeepCopyInto(&out.CreationTimestamp)
+	if in.ExpirationTimestamp != nil {
+		in, out := &in.ExpirationTimestamp, &out.ExpirationTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentVolumeSnapshot.
+func (in *ComponentVolumeSnapshot) DeepCopy() *ComponentVolumeSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentVolumeSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigConstraint) DeepCopyInto(out *ConfigConstraint) {
 	*out = *in
@@ -2626,6 +3312,13 @@ func (in *ConfigParams) DeepCopyInto(out *ConfigParams) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.ParametersFrom != nil {
+		in, out := &in.ParametersFrom, &out.ParametersFrom
+		*out = make(map[string]ParameterValueSource, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigParams.
@@ -3142,12 +3835,75 @@ func (in *CustomParametersValidation) DeepCopyInto(out *CustomParametersValidati
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomParametersValidation.
-func (in *CustomParametersValidation) DeepCopy() *CustomParametersValidation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomParametersValidation.
+func (in *CustomParametersValidation) DeepCopy() *CustomParametersValidation {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomParametersValidation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebugContainer) DeepCopyInto(out *DebugContainer) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebugContainer.
+func (in *DebugContainer) DeepCopy() *DebugContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(DebugContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DebugInstance) DeepCopyInto(out *DebugInstance) {
+	*out = *in
+	out.ComponentOps = in.ComponentOps
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DebugInstance.
+func (in *DebugInstance) DeepCopy() *DebugInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(DebugInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DryRunObjectChange) DeepCopyInto(out *DryRunObjectChange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunObjectChange.
+func (in *DryRunObjectChange) DeepCopy() *DryRunObjectChange {
 	if in == nil {
 		return nil
 	}
-	out := new(CustomParametersValidation)
+	out := new(DryRunObjectChange)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -3286,6 +4042,43 @@ func (in *Expose) DeepCopy() *Expose {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FencingPolicy) DeepCopyInto(out *FencingPolicy) {
+	*out = *in
+	if in.Strategies != nil {
+		in, out := &in.Strategies, &out.Strategies
+		*out = make([]FencingStrategy, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FencingPolicy.
+func (in *FencingPolicy) DeepCopy() *FencingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FencingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreezeWindow) DeepCopyInto(out *FreezeWindow) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreezeWindow.
+func (in *FreezeWindow) DeepCopy() *FreezeWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(FreezeWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GVKResource) DeepCopyInto(out *GVKResource) {
 	*out = *in
@@ -3454,6 +4247,21 @@ func (in *HostNetworkVars) DeepCopy() *HostNetworkVars {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InitScripts) DeepCopyInto(out *InitScripts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InitScripts.
+func (in *InitScripts) DeepCopy() *InitScripts {
+	if in == nil {
+		return nil
+	}
+	out := new(InitScripts)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Instance) DeepCopyInto(out *Instance) {
 	*out = *in
@@ -3532,6 +4340,13 @@ func (in *InstanceTemplate) DeepCopyInto(out *InstanceTemplate) {
 		*out = new(SchedulingPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(v1.ResourceRequirements)
@@ -3685,6 +4500,11 @@ func (in *LastComponentConfiguration) DeepCopyInto(out *LastComponentConfigurati
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SchedulingPolicy != nil {
+		in, out := &in.SchedulingPolicy, &out.SchedulingPolicy
+		*out = new(SchedulingPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LastComponentConfiguration.
@@ -3775,6 +4595,27 @@ func (in *LogConfig) DeepCopy() *LogConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.DaysOfWeek != nil {
+		in, out := &in.DaysOfWeek, &out.DaysOfWeek
+		*out = make([]DayOfWeek, len(*in))
+		copy(*out, *in)
+	}
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MatchExpressions) DeepCopyInto(out *MatchExpressions) {
 	*out = *in
@@ -3790,6 +4631,52 @@ func (in *MatchExpressions) DeepCopy() *MatchExpressions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrateInstance) DeepCopyInto(out *MigrateInstance) {
+	*out = *in
+	out.ComponentOps = in.ComponentOps
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrateInstance.
+func (in *MigrateInstance) DeepCopy() *MigrateInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrateInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrateNodePool) DeepCopyInto(out *MigrateNodePool) {
+	*out = *in
+	out.ComponentOps = in.ComponentOps
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrateNodePool.
+func (in *MigrateNodePool) DeepCopy() *MigrateNodePool {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrateNodePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MonitorConfig) DeepCopyInto(out *MonitorConfig) {
 	*out = *in
@@ -3805,122 +4692,274 @@ func (in *MonitorConfig) DeepCopy() *MonitorConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(MonitorConfig)
-	in.DeepCopyInto(out)
-	return out
+	out := new(MonitorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultipleClusterObjectCombinedOption) DeepCopyInto(out *MultipleClusterObjectCombinedOption) {
+	*out = *in
+	if in.NewVarSuffix != nil {
+		in, out := &in.NewVarSuffix, &out.NewVarSuffix
+		*out = new(string)
+		**out = **in
+	}
+	if in.FlattenFormat != nil {
+		in, out := &in.FlattenFormat, &out.FlattenFormat
+		*out = new(MultipleClusterObjectValueFormatFlatten)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultipleClusterObjectCombinedOption.
+func (in *MultipleClusterObjectCombinedOption) DeepCopy() *MultipleClusterObjectCombinedOption {
+	if in == nil {
+		return nil
+	}
+	out := new(MultipleClusterObjectCombinedOption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultipleClusterObjectOption) DeepCopyInto(out *MultipleClusterObjectOption) {
+	*out = *in
+	if in.CombinedOption != nil {
+		in, out := &in.CombinedOption, &out.CombinedOption
+		*out = new(MultipleClusterObjectCombinedOption)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultipleClusterObjectOption.
+func (in *MultipleClusterObjectOption) DeepCopy() *MultipleClusterObjectOption {
+	if in == nil {
+		return nil
+	}
+	out := new(MultipleClusterObjectOption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultipleClusterObjectValueFormatFlatten) DeepCopyInto(out *MultipleClusterObjectValueFormatFlatten) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultipleClusterObjectValueFormatFlatten.
+func (in *MultipleClusterObjectValueFormatFlatten) DeepCopy() *MultipleClusterObjectValueFormatFlatten {
+	if in == nil {
+		return nil
+	}
+	out := new(MultipleClusterObjectValueFormatFlatten)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamedVar) DeepCopyInto(out *NamedVar) {
+	*out = *in
+	if in.Option != nil {
+		in, out := &in.Option, &out.Option
+		*out = new(VarOption)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedVar.
+func (in *NamedVar) DeepCopy() *NamedVar {
+	if in == nil {
+		return nil
+	}
+	out := new(NamedVar)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsAction) DeepCopyInto(out *OpsAction) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(OpsWorkloadAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(OpsExecAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceModifier != nil {
+		in, out := &in.ResourceModifier, &out.ResourceModifier
+		*out = new(OpsResourceModifierAction)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsAction.
+func (in *OpsAction) DeepCopy() *OpsAction {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsAssertions) DeepCopyInto(out *OpsAssertions) {
+	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]ComponentAssertion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsAssertions.
+func (in *OpsAssertions) DeepCopy() *OpsAssertions {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsAssertions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsBatch) DeepCopyInto(out *OpsBatch) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsBatch.
+func (in *OpsBatch) DeepCopy() *OpsBatch {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsBatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpsBatch) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MultipleClusterObjectCombinedOption) DeepCopyInto(out *MultipleClusterObjectCombinedOption) {
+func (in *OpsBatchClusterStatus) DeepCopyInto(out *OpsBatchClusterStatus) {
 	*out = *in
-	if in.NewVarSuffix != nil {
-		in, out := &in.NewVarSuffix, &out.NewVarSuffix
-		*out = new(string)
-		**out = **in
-	}
-	if in.FlattenFormat != nil {
-		in, out := &in.FlattenFormat, &out.FlattenFormat
-		*out = new(MultipleClusterObjectValueFormatFlatten)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultipleClusterObjectCombinedOption.
-func (in *MultipleClusterObjectCombinedOption) DeepCopy() *MultipleClusterObjectCombinedOption {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsBatchClusterStatus.
+func (in *OpsBatchClusterStatus) DeepCopy() *OpsBatchClusterStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MultipleClusterObjectCombinedOption)
+	out := new(OpsBatchClusterStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MultipleClusterObjectOption) DeepCopyInto(out *MultipleClusterObjectOption) {
+func (in *OpsBatchList) DeepCopyInto(out *OpsBatchList) {
 	*out = *in
-	if in.CombinedOption != nil {
-		in, out := &in.CombinedOption, &out.CombinedOption
-		*out = new(MultipleClusterObjectCombinedOption)
-		(*in).DeepCopyInto(*out)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpsBatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultipleClusterObjectOption.
-func (in *MultipleClusterObjectOption) DeepCopy() *MultipleClusterObjectOption {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsBatchList.
+func (in *OpsBatchList) DeepCopy() *OpsBatchList {
 	if in == nil {
 		return nil
 	}
-	out := new(MultipleClusterObjectOption)
+	out := new(OpsBatchList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpsBatchList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MultipleClusterObjectValueFormatFlatten) DeepCopyInto(out *MultipleClusterObjectValueFormatFlatten) {
+func (in *OpsBatchSpec) DeepCopyInto(out *OpsBatchSpec) {
 	*out = *in
+	in.ClusterSelector.DeepCopyInto(&out.ClusterSelector)
+	in.OpsTemplate.DeepCopyInto(&out.OpsTemplate)
+	out.WaveStrategy = in.WaveStrategy
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultipleClusterObjectValueFormatFlatten.
-func (in *MultipleClusterObjectValueFormatFlatten) DeepCopy() *MultipleClusterObjectValueFormatFlatten {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsBatchSpec.
+func (in *OpsBatchSpec) DeepCopy() *OpsBatchSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MultipleClusterObjectValueFormatFlatten)
+	out := new(OpsBatchSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NamedVar) DeepCopyInto(out *NamedVar) {
+func (in *OpsBatchStatus) DeepCopyInto(out *OpsBatchStatus) {
 	*out = *in
-	if in.Option != nil {
-		in, out := &in.Option, &out.Option
-		*out = new(VarOption)
-		**out = **in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]OpsBatchClusterStatus, len(*in))
+		copy(*out, *in)
 	}
+	in.StartTimestamp.DeepCopyInto(&out.StartTimestamp)
+	in.LastWaveCompletionTimestamp.DeepCopyInto(&out.LastWaveCompletionTimestamp)
+	in.CompletionTimestamp.DeepCopyInto(&out.CompletionTimestamp)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedVar.
-func (in *NamedVar) DeepCopy() *NamedVar {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsBatchStatus.
+func (in *OpsBatchStatus) DeepCopy() *OpsBatchStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(NamedVar)
+	out := new(OpsBatchStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpsAction) DeepCopyInto(out *OpsAction) {
+func (in *OpsBatchWaveStrategy) DeepCopyInto(out *OpsBatchWaveStrategy) {
 	*out = *in
-	if in.Parameters != nil {
-		in, out := &in.Parameters, &out.Parameters
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Workload != nil {
-		in, out := &in.Workload, &out.Workload
-		*out = new(OpsWorkloadAction)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Exec != nil {
-		in, out := &in.Exec, &out.Exec
-		*out = new(OpsExecAction)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ResourceModifier != nil {
-		in, out := &in.ResourceModifier, &out.ResourceModifier
-		*out = new(OpsResourceModifierAction)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsAction.
-func (in *OpsAction) DeepCopy() *OpsAction {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsBatchWaveStrategy.
+func (in *OpsBatchWaveStrategy) DeepCopy() *OpsBatchWaveStrategy {
 	if in == nil {
 		return nil
 	}
-	out := new(OpsAction)
+	out := new(OpsBatchWaveStrategy)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -4045,6 +5084,26 @@ func (in *OpsDefinitionStatus) DeepCopy() *OpsDefinitionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsDryRunResult) DeepCopyInto(out *OpsDryRunResult) {
+	*out = *in
+	if in.ObjectChanges != nil {
+		in, out := &in.ObjectChanges, &out.ObjectChanges
+		*out = make([]DryRunObjectChange, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsDryRunResult.
+func (in *OpsDryRunResult) DeepCopy() *OpsDryRunResult {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsDryRunResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsEnvVar) DeepCopyInto(out *OpsEnvVar) {
 	*out = *in
@@ -4163,6 +5222,30 @@ func (in *OpsRequestComponentStatus) DeepCopyInto(out *OpsRequestComponentStatus
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.EndTime.DeepCopyInto(&out.EndTime)
+	if in.ReclaimedSpace != nil {
+		in, out := &in.ReclaimedSpace, &out.ReclaimedSpace
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MovedData != nil {
+		in, out := &in.MovedData, &out.MovedData
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ReplicationLagSeconds != nil {
+		in, out := &in.ReplicationLagSeconds, &out.ReplicationLagSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SwitchoverAttempts != nil {
+		in, out := &in.SwitchoverAttempts, &out.SwitchoverAttempts
+		*out = make([]SwitchoverAttempt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRequestComponentStatus.
@@ -4175,6 +5258,31 @@ func (in *OpsRequestComponentStatus) DeepCopy() *OpsRequestComponentStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsRequestDefaults) DeepCopyInto(out *OpsRequestDefaults) {
+	*out = *in
+	if in.TTLSecondsAfterSucceed != nil {
+		in, out := &in.TTLSecondsAfterSucceed, &out.TTLSecondsAfterSucceed
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PreConditionDeadlineSeconds != nil {
+		in, out := &in.PreConditionDeadlineSeconds, &out.PreConditionDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRequestDefaults.
+func (in *OpsRequestDefaults) DeepCopy() *OpsRequestDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsRequestDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsRequestList) DeepCopyInto(out *OpsRequestList) {
 	*out = *in
@@ -4207,6 +5315,21 @@ func (in *OpsRequestList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsRequestRetryPolicy) DeepCopyInto(out *OpsRequestRetryPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRequestRetryPolicy.
+func (in *OpsRequestRetryPolicy) DeepCopy() *OpsRequestRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsRequestRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsRequestSpec) DeepCopyInto(out *OpsRequestSpec) {
 	*out = *in
@@ -4215,6 +5338,26 @@ func (in *OpsRequestSpec) DeepCopyInto(out *OpsRequestSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(OpsSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(OpsRequestRetryPolicy)
+		**out = **in
+	}
+	if in.Assertions != nil {
+		in, out := &in.Assertions, &out.Assertions
+		*out = new(OpsAssertions)
+		(*in).DeepCopyInto(*out)
+	}
 	in.SpecificOpsRequest.DeepCopyInto(&out.SpecificOpsRequest)
 }
 
@@ -4239,6 +5382,17 @@ func (in *OpsRequestStatus) DeepCopyInto(out *OpsRequestStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.PreCheckResults != nil {
+		in, out := &in.PreCheckResults, &out.PreCheckResults
+		*out = make([]UpgradePreCheckResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.DryRunResult != nil {
+		in, out := &in.DryRunResult, &out.DryRunResult
+		*out = new(OpsDryRunResult)
+		(*in).DeepCopyInto(*out)
+	}
+	in.LastFailureTimestamp.DeepCopyInto(&out.LastFailureTimestamp)
 	if in.Extras != nil {
 		in, out := &in.Extras, &out.Extras
 		*out = make([]map[string]string, len(*in))
@@ -4333,6 +5487,34 @@ func (in *OpsResourceModifierAction) DeepCopy() *OpsResourceModifierAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsSchedule) DeepCopyInto(out *OpsSchedule) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsSchedule.
+func (in *OpsSchedule) DeepCopy() *OpsSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsService) DeepCopyInto(out *OpsService) {
 	*out = *in
@@ -4481,6 +5663,11 @@ func (in *ParameterPair) DeepCopyInto(out *ParameterPair) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(ParameterValueSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterPair.
@@ -4493,6 +5680,26 @@ func (in *ParameterPair) DeepCopy() *ParameterPair {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParameterValueSource) DeepCopyInto(out *ParameterValueSource) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterValueSource.
+func (in *ParameterValueSource) DeepCopy() *ParameterValueSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ParameterValueSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParametersSchema) DeepCopyInto(out *ParametersSchema) {
 	*out = *in
@@ -4818,6 +6025,37 @@ func (in *ProvisionStatements) DeepCopy() *ProvisionStatements {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Purge) DeepCopyInto(out *Purge) {
+	*out = *in
+	out.ComponentOps = in.ComponentOps
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RetainHours != nil {
+		in, out := &in.RetainHours, &out.RetainHours
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RetainBytes != nil {
+		in, out := &in.RetainBytes, &out.RetainBytes
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Purge.
+func (in *Purge) DeepCopy() *Purge {
+	if in == nil {
+		return nil
+	}
+	out := new(Purge)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RSMSpec) DeepCopyInto(out *RSMSpec) {
 	*out = *in
@@ -5028,78 +6266,145 @@ func (in *ReplicaRole) DeepCopyInto(out *ReplicaRole) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaRole.
-func (in *ReplicaRole) DeepCopy() *ReplicaRole {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaRole.
+func (in *ReplicaRole) DeepCopy() *ReplicaRole {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicasLimit) DeepCopyInto(out *ReplicasLimit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicasLimit.
+func (in *ReplicasLimit) DeepCopy() *ReplicasLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicasLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationSetSpec) DeepCopyInto(out *ReplicationSetSpec) {
+	*out = *in
+	in.StatefulSetSpec.DeepCopyInto(&out.StatefulSetSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSetSpec.
+func (in *ReplicationSetSpec) DeepCopy() *ReplicationSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceMeta) DeepCopyInto(out *ResourceMeta) {
+	*out = *in
+	if in.AsVolumeFrom != nil {
+		in, out := &in.AsVolumeFrom, &out.AsVolumeFrom
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceMeta.
+func (in *ResourceMeta) DeepCopy() *ResourceMeta {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicaRole)
+	out := new(ResourceMeta)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicasLimit) DeepCopyInto(out *ReplicasLimit) {
+func (in *ResourceRange) DeepCopyInto(out *ResourceRange) {
 	*out = *in
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicasLimit.
-func (in *ReplicasLimit) DeepCopy() *ReplicasLimit {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRange.
+func (in *ResourceRange) DeepCopy() *ResourceRange {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicasLimit)
+	out := new(ResourceRange)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicationSetSpec) DeepCopyInto(out *ReplicationSetSpec) {
+func (in *Restore) DeepCopyInto(out *Restore) {
 	*out = *in
-	in.StatefulSetSpec.DeepCopyInto(&out.StatefulSetSpec)
+	if in.ComponentMappings != nil {
+		in, out := &in.ComponentMappings, &out.ComponentMappings
+		*out = make([]RestoreComponentMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSetSpec.
-func (in *ReplicationSetSpec) DeepCopy() *ReplicationSetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Restore.
+func (in *Restore) DeepCopy() *Restore {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicationSetSpec)
+	out := new(Restore)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceMeta) DeepCopyInto(out *ResourceMeta) {
+func (in *RestoreComponentMapping) DeepCopyInto(out *RestoreComponentMapping) {
 	*out = *in
-	if in.AsVolumeFrom != nil {
-		in, out := &in.AsVolumeFrom, &out.AsVolumeFrom
-		*out = make([]string, len(*in))
+	if in.VolumeMappings != nil {
+		in, out := &in.VolumeMappings, &out.VolumeMappings
+		*out = make([]RestoreVolumeMapping, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceMeta.
-func (in *ResourceMeta) DeepCopy() *ResourceMeta {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreComponentMapping.
+func (in *RestoreComponentMapping) DeepCopy() *RestoreComponentMapping {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceMeta)
+	out := new(RestoreComponentMapping)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Restore) DeepCopyInto(out *Restore) {
+func (in *RestoreVolumeMapping) DeepCopyInto(out *RestoreVolumeMapping) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Restore.
-func (in *Restore) DeepCopy() *Restore {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreVolumeMapping.
+func (in *RestoreVolumeMapping) DeepCopy() *RestoreVolumeMapping {
 	if in == nil {
 		return nil
 	}
-	out := new(Restore)
+	out := new(RestoreVolumeMapping)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -5135,6 +6440,27 @@ func (in *RoleProbe) DeepCopy() *RoleProbe {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RotateCredentials) DeepCopyInto(out *RotateCredentials) {
+	*out = *in
+	out.ComponentOps = in.ComponentOps
+	if in.Accounts != nil {
+		in, out := &in.Accounts, &out.Accounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RotateCredentials.
+func (in *RotateCredentials) DeepCopy() *RotateCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(RotateCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rule) DeepCopyInto(out *Rule) {
 	*out = *in
@@ -5757,6 +7083,27 @@ func (in *ServiceVars) DeepCopy() *ServiceVars {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SharedVolume) DeepCopyInto(out *SharedVolume) {
+	*out = *in
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedVolume.
+func (in *SharedVolume) DeepCopy() *SharedVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(SharedVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ShardingSpec) DeepCopyInto(out *ShardingSpec) {
 	*out = *in
@@ -5800,10 +7147,22 @@ func (in *SpecificOpsRequest) DeepCopyInto(out *SpecificOpsRequest) {
 		*out = make([]ComponentOps, len(*in))
 		copy(*out, *in)
 	}
+	if in.StopList != nil {
+		in, out := &in.StopList, &out.StopList
+		*out = make([]ComponentOps, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartList != nil {
+		in, out := &in.StartList, &out.StartList
+		*out = make([]ComponentOps, len(*in))
+		copy(*out, *in)
+	}
 	if in.SwitchoverList != nil {
 		in, out := &in.SwitchoverList, &out.SwitchoverList
 		*out = make([]Switchover, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.VerticalScalingList != nil {
 		in, out := &in.VerticalScalingList, &out.VerticalScalingList
@@ -5849,12 +7208,12 @@ func (in *SpecificOpsRequest) DeepCopyInto(out *SpecificOpsRequest) {
 	if in.Restore != nil {
 		in, out := &in.Restore, &out.Restore
 		*out = new(Restore)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.RestoreSpec != nil {
 		in, out := &in.RestoreSpec, &out.RestoreSpec
 		*out = new(Restore)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.RebuildFrom != nil {
 		in, out := &in.RebuildFrom, &out.RebuildFrom
@@ -5868,6 +7227,45 @@ func (in *SpecificOpsRequest) DeepCopyInto(out *SpecificOpsRequest) {
 		*out = new(CustomOps)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DebugInstance != nil {
+		in, out := &in.DebugInstance, &out.DebugInstance
+		*out = new(DebugInstance)
+		**out = **in
+	}
+	if in.MigrateNodePool != nil {
+		in, out := &in.MigrateNodePool, &out.MigrateNodePool
+		*out = new(MigrateNodePool)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RotateTLSList != nil {
+		in, out := &in.RotateTLSList, &out.RotateTLSList
+		*out = make([]ComponentOps, len(*in))
+		copy(*out, *in)
+	}
+	if in.PurgeList != nil {
+		in, out := &in.PurgeList, &out.PurgeList
+		*out = make([]Purge, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RotateCredentialsList != nil {
+		in, out := &in.RotateCredentialsList, &out.RotateCredentialsList
+		*out = make([]RotateCredentials, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ShardRebalanceList != nil {
+		in, out := &in.ShardRebalanceList, &out.ShardRebalanceList
+		*out = make([]ComponentOps, len(*in))
+		copy(*out, *in)
+	}
+	if in.MigrateInstance != nil {
+		in, out := &in.MigrateInstance, &out.MigrateInstance
+		*out = new(MigrateInstance)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpecificOpsRequest.
@@ -5920,6 +7318,21 @@ func (in *StatelessSetSpec) DeepCopy() *StatelessSetSpec {
 func (in *Switchover) DeepCopyInto(out *Switchover) {
 	*out = *in
 	out.ComponentOps = in.ComponentOps
+	if in.MaxLagSeconds != nil {
+		in, out := &in.MaxLagSeconds, &out.MaxLagSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LagWaitSeconds != nil {
+		in, out := &in.LagWaitSeconds, &out.LagWaitSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Switchover.
@@ -5957,6 +7370,23 @@ func (in *SwitchoverAction) DeepCopy() *SwitchoverAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwitchoverAttempt) DeepCopyInto(out *SwitchoverAttempt) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.EndTime.DeepCopyInto(&out.EndTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchoverAttempt.
+func (in *SwitchoverAttempt) DeepCopy() *SwitchoverAttempt {
+	if in == nil {
+		return nil
+	}
+	out := new(SwitchoverAttempt)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SwitchoverShortSpec) DeepCopyInto(out *SwitchoverShortSpec) {
 	*out = *in
@@ -6087,6 +7517,29 @@ func (in *SystemAccountSpec) DeepCopy() *SystemAccountSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSCertificateStatus) DeepCopyInto(out *TLSCertificateStatus) {
+	*out = *in
+	if in.NotBefore != nil {
+		in, out := &in.NotBefore, &out.NotBefore
+		*out = (*in).DeepCopy()
+	}
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSCertificateStatus.
+func (in *TLSCertificateStatus) DeepCopy() *TLSCertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSCertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
 	*out = *in
@@ -6247,6 +7700,22 @@ func (in *UpgradeComponent) DeepCopy() *UpgradeComponent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradePreCheckResult) DeepCopyInto(out *UpgradePreCheckResult) {
+	*out = *in
+	out.PreCheckResult = in.PreCheckResult
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradePreCheckResult.
+func (in *UpgradePreCheckResult) DeepCopy() *UpgradePreCheckResult {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradePreCheckResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserResourceRefs) DeepCopyInto(out *UserResourceRefs) {
 	*out = *in
@@ -6276,6 +7745,109 @@ func (in *UserResourceRefs) DeepCopy() *UserResourceRefs {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationPolicy) DeepCopyInto(out *ValidationPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationPolicy.
+func (in *ValidationPolicy) DeepCopy() *ValidationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ValidationPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationPolicyList) DeepCopyInto(out *ValidationPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ValidationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationPolicyList.
+func (in *ValidationPolicyList) DeepCopy() *ValidationPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ValidationPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationPolicySpec) DeepCopyInto(out *ValidationPolicySpec) {
+	*out = *in
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]ValidationOperation, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ValidationRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationPolicySpec.
+func (in *ValidationPolicySpec) DeepCopy() *ValidationPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationRule) DeepCopyInto(out *ValidationRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationRule.
+func (in *ValidationRule) DeepCopy() *ValidationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValueFrom) DeepCopyInto(out *ValueFrom) {
 	*out = *in
@@ -6409,6 +7981,11 @@ func (in *VerticalScaling) DeepCopyInto(out *VerticalScaling) {
 	*out = *in
 	out.ComponentOps = in.ComponentOps
 	in.ResourceRequirements.DeepCopyInto(&out.ResourceRequirements)
+	if in.ClassRef != nil {
+		in, out := &in.ClassRef, &out.ClassRef
+		*out = new(ComponentClassRef)
+		**out = **in
+	}
 	if in.Instances != nil {
 		in, out := &in.Instances, &out.Instances
 		*out = make([]InstanceResourceTemplate, len(*in))
@@ -6480,6 +8057,21 @@ func (in *VolumeProtectionSpec) DeepCopy() *VolumeProtectionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSnapshotPolicy) DeepCopyInto(out *VolumeSnapshotPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshotPolicy.
+func (in *VolumeSnapshotPolicy) DeepCopy() *VolumeSnapshotPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSnapshotPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeTypeSpec) DeepCopyInto(out *VolumeTypeSpec) {
 	*out = *in
@@ -6494,3 +8086,24 @@ func (in *VolumeTypeSpec) DeepCopy() *VolumeTypeSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WarmUpCheck) DeepCopyInto(out *WarmUpCheck) {
+	*out = *in
+	in.LifecycleActionHandler.DeepCopyInto(&out.LifecycleActionHandler)
+	if in.Metric != nil {
+		in, out := &in.Metric, &out.Metric
+		*out = new(workloadsv1alpha1.WarmUpMetric)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarmUpCheck.
+func (in *WarmUpCheck) DeepCopy() *WarmUpCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(WarmUpCheck)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -117,6 +117,18 @@ type BackupActionSpec struct {
 	//
 	// +optional
 	PreDeleteBackup *BaseJobActionSpec `json:"preDelete,omitempty"`
+
+	// Represents a job that introspects the backed up data after the backup data action has completed,
+	// listing the restorable artifacts (e.g. databases, tables or collections) within the backup.
+	//
+	// The job is expected to write the artifacts as a JSON object, in the format of
+	// `{"catalog": [{"name": "db1", "type": "database", "size": "1Gi"}]}`, into the file specified by
+	// the environment variable `DP_BACKUP_INFO_FILE`, which are then recorded in the `status.catalog` of the Backup.
+	//
+	// It only applies to the backups of type Full, and runs on the first target pod of the first backup target.
+	//
+	// +optional
+	Introspect *JobActionSpec `json:"introspect,omitempty"`
 }
 
 // BackupDataActionSpec defines how to back up data.
@@ -156,6 +168,16 @@ type RestoreActionSpec struct {
 	//
 	// +optional
 	PostReady []ActionSpec `json:"postReady,omitempty"`
+
+	// Determines if the progress of the prepareData action should be synchronized and the interval
+	// for synchronization in seconds.
+	//
+	// If enabled, the prepareData action is expected to write its progress as a JSON object, in the format of
+	// `{"bytesRestored": 1048576, "totalBytes": 4194304}`, into the file specified by the environment variable
+	// `DP_RESTORE_PROGRESS_FILE`, which is then recorded in the `status.dataProgress` of the Restore.
+	//
+	// +optional
+	SyncProgress *SyncProgress `json:"syncProgress,omitempty"`
 }
 
 // ActionSpec defines an action that should be executed. Only one of the fields may be set.
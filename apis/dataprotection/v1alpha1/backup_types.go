@@ -187,6 +187,41 @@ type BackupStatus struct {
 	//
 	// +optional
 	Extras []map[string]string `json:"extras,omitempty"`
+
+	// Records the restorable artifacts within the backup, such as databases, tables or collections,
+	// which are reported by the introspect action of the ActionSet.
+	//
+	// +optional
+	Catalog []BackupCatalogEntry `json:"catalog,omitempty"`
+}
+
+// BackupCatalogEntry describes a restorable artifact within the backup.
+type BackupCatalogEntry struct {
+	// Specifies the name of the artifact.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the type of the artifact, such as "database", "table" or "collection",
+	// which is defined by the database engine.
+	//
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Specifies the name of the artifact that contains this one, e.g. the database of a table.
+	//
+	// +optional
+	Parent string `json:"parent,omitempty"`
+
+	// Records the size of the artifact in the backup.
+	//
+	// +optional
+	Size string `json:"size,omitempty"`
+
+	// Records the time of the latest data of the artifact in the backup.
+	//
+	// +optional
+	Timestamp *metav1.Time `json:"timestamp,omitempty"`
 }
 
 // BackupTimeRange records the time range of backed up data, for PITR, this is the
@@ -79,6 +79,52 @@ type BackupPolicySpec struct {
 	//
 	// +optional
 	EncryptionConfig *EncryptionConfig `json:"encryptionConfig,omitempty"`
+
+	// Specifies the restore drill which periodically restores the latest backup into a temporary
+	// cluster to verify that the backups are restorable.
+	//
+	// +optional
+	RestoreDrill *RestoreDrill `json:"restoreDrill,omitempty"`
+}
+
+// RestoreDrill defines a periodical, non-destructive restore verification.
+// The latest completed backup is restored into a temporary cluster, which is deleted
+// after the verification is finished.
+type RestoreDrill struct {
+	// Specifies whether the restore drill is enabled.
+	//
+	// +kubebuilder:default=true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Specifies the interval between two drills, such as `7d` or `12h`.
+	// It shares the format with the `retentionPeriod` of the Backup.
+	//
+	// +kubebuilder:validation:Required
+	Interval RetentionPeriod `json:"interval"`
+
+	// Specifies the backup method whose latest completed backup is restored.
+	// If not set, the latest completed backup of any method is used.
+	//
+	// +optional
+	BackupMethod string `json:"backupMethod,omitempty"`
+
+	// Specifies the maximum duration in seconds of a drill, including the restore
+	// and the verification. The drill is marked as Failed when it exceeds.
+	//
+	// +kubebuilder:default=3600
+	// +kubebuilder:validation:Minimum=60
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// Specifies the action to verify the restored cluster, it runs as a Job after
+	// the temporary cluster is running. The connection credential of the temporary
+	// cluster is injected as the environment variables if the backup target has one.
+	//
+	// If not set, the drill succeeds once the temporary cluster is running.
+	//
+	// +optional
+	VerifyAction *BaseJobActionSpec `json:"verifyAction,omitempty"`
 }
 
 type BackupTarget struct {
@@ -280,8 +326,75 @@ type BackupPolicyStatus struct {
 	//
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Records the status of the latest restore drill.
+	//
+	// +optional
+	RestoreDrill *RestoreDrillStatus `json:"restoreDrill,omitempty"`
 }
 
+// RestoreDrillStatus records the result and the measurements of a restore drill.
+type RestoreDrillStatus struct {
+	// Represents the phase of the latest drill.
+	//
+	// +optional
+	Phase RestoreDrillPhase `json:"phase,omitempty"`
+
+	// The name of the backup restored by the latest drill.
+	//
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+
+	// The name of the temporary cluster which the backup is restored into.
+	//
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// Records the time when the latest drill started.
+	//
+	// +optional
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Records the time when the latest drill completed.
+	//
+	// +optional
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// Records the time when the latest drill succeeded.
+	//
+	// +optional
+	LastSucceededTimestamp *metav1.Time `json:"lastSucceededTimestamp,omitempty"`
+
+	// The measured recovery time objective, the duration in seconds from the start
+	// of the drill until the temporary cluster is running.
+	//
+	// +optional
+	RTOSeconds *int64 `json:"rtoSeconds,omitempty"`
+
+	// The measured recovery point objective, the duration in seconds between the time
+	// of the restored data and the start of the drill.
+	//
+	// +optional
+	RPOSeconds *int64 `json:"rpoSeconds,omitempty"`
+
+	// A human-readable message indicating details about the latest drill.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// RestoreDrillPhase defines the phase of a restore drill.
+//
+// +enum
+// +kubebuilder:validation:Enum={Running,Succeeded,Failed}
+type RestoreDrillPhase string
+
+const (
+	RestoreDrillPhaseRunning   RestoreDrillPhase = "Running"
+	RestoreDrillPhaseSucceeded RestoreDrillPhase = "Succeeded"
+	RestoreDrillPhaseFailed    RestoreDrillPhase = "Failed"
+)
+
 // BackupPolicyPhase defines phases for BackupPolicy.
 // +enum
 // +kubebuilder:validation:Enum={Available,Failed}
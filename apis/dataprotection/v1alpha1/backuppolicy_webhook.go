@@ -0,0 +1,187 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+// log is for logging in this package.
+var backuppolicylog = logf.Log.WithName("backuppolicy-resource")
+
+func (r *BackupPolicy) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-dataprotection-kubeblocks-io-v1alpha1-backuppolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=dataprotection.kubeblocks.io,resources=backuppolicies,verbs=create;update,versions=v1alpha1,name=vbackuppolicy.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &BackupPolicy{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *BackupPolicy) ValidateCreate() (admission.Warnings, error) {
+	backuppolicylog.Info("validate create", "name", r.Name)
+	return r.validate(context.Background(), getWebhookClient())
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *BackupPolicy) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	backuppolicylog.Info("validate update", "name", r.Name)
+	// the policies are updated by the cluster controller in each reconciliation, only the changed spec is validated.
+	if oldPolicy, ok := old.(*BackupPolicy); ok && reflect.DeepEqual(oldPolicy.Spec, r.Spec) {
+		return nil, nil
+	}
+	return r.validate(context.Background(), getWebhookClient())
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *BackupPolicy) ValidateDelete() (admission.Warnings, error) {
+	backuppolicylog.Info("validate delete", "name", r.Name)
+	return nil, nil
+}
+
+// validate checks the references of the BackupPolicy, which fail the backups only when they are running otherwise.
+func (r *BackupPolicy) validate(ctx context.Context, cli client.Client) (admission.Warnings, error) {
+	if cli == nil {
+		return nil, nil
+	}
+	var (
+		allErrs  field.ErrorList
+		specPath = field.NewPath("spec")
+	)
+	validateTargets := func(path *field.Path, target *BackupTarget, targets []BackupTarget) {
+		if err := validateTargetRole(ctx, cli, r.Namespace, target, path.Child("target")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+		for i := range targets {
+			if err := validateTargetRole(ctx, cli, r.Namespace, &targets[i], path.Child("targets").Index(i)); err != nil {
+				allErrs = append(allErrs, err)
+			}
+		}
+	}
+	validateTargets(specPath, r.Spec.Target, r.Spec.Targets)
+	for i, method := range r.Spec.BackupMethods {
+		methodPath := specPath.Child("backupMethods").Index(i)
+		if err := validateActionSet(ctx, cli, method, methodPath.Child("actionSetName")); err != nil {
+			allErrs = append(allErrs, err)
+		}
+		validateTargets(methodPath, method.Target, method.Targets)
+	}
+	warnings := r.checkBackupRepo(ctx, cli)
+	if len(allErrs) > 0 {
+		return warnings, apierrors.NewInvalid(schema.GroupKind{Group: GroupVersion.Group, Kind: "BackupPolicy"}, r.Name, allErrs)
+	}
+	return warnings, nil
+}
+
+// checkBackupRepo warns if the backup repo fails the accessibility pre-check, which runs asynchronously
+// in the BackupRepo controller.
+func (r *BackupPolicy) checkBackupRepo(ctx context.Context, cli client.Client) admission.Warnings {
+	if r.Spec.BackupRepoName == nil || *r.Spec.BackupRepoName == "" {
+		return nil
+	}
+	repoName := *r.Spec.BackupRepoName
+	repo := &BackupRepo{}
+	if err := cli.Get(ctx, client.ObjectKey{Name: repoName}, repo); err != nil {
+		if apierrors.IsNotFound(err) {
+			return admission.Warnings{fmt.Sprintf(`backup repo "%s" is not found`, repoName)}
+		}
+		return nil
+	}
+	switch repo.Status.Phase {
+	case BackupRepoPreChecking:
+		return admission.Warnings{fmt.Sprintf(`backup repo "%s" is still being pre-checked, it may be not accessible`, repoName)}
+	case BackupRepoFailed:
+		return admission.Warnings{fmt.Sprintf(`backup repo "%s" failed the accessibility pre-check, the backups to it will fail`, repoName)}
+	case BackupRepoDeleting:
+		return admission.Warnings{fmt.Sprintf(`backup repo "%s" is being deleted`, repoName)}
+	}
+	return nil
+}
+
+// validateActionSet checks that the ActionSet of the backup method exists and defines the backup action.
+func validateActionSet(ctx context.Context, cli client.Client, method BackupMethod, path *field.Path) *field.Error {
+	if method.ActionSetName == "" {
+		return nil
+	}
+	actionSet := &ActionSet{}
+	if err := cli.Get(ctx, client.ObjectKey{Name: method.ActionSetName}, actionSet); err != nil {
+		if apierrors.IsNotFound(err) {
+			return field.NotFound(path, method.ActionSetName)
+		}
+		return field.InternalError(path, err)
+	}
+	// the ActionSets of the volume snapshot methods may define the restore action only
+	snapshotVolumes := method.SnapshotVolumes != nil && *method.SnapshotVolumes
+	if actionSet.Spec.Backup == nil && !snapshotVolumes {
+		return field.Invalid(path, method.ActionSetName, "the ActionSet defines no backup action")
+	}
+	return nil
+}
+
+// validateTargetRole checks that the role selected by the target is defined by the InstanceSet of the component.
+func validateTargetRole(ctx context.Context, cli client.Client, namespace string, target *BackupTarget, path *field.Path) *field.Error {
+	if target == nil || target.PodSelector == nil || target.PodSelector.LabelSelector == nil {
+		return nil
+	}
+	matchLabels := target.PodSelector.MatchLabels
+	role := matchLabels[constant.RoleLabelKey]
+	clusterName := matchLabels[constant.AppInstanceLabelKey]
+	compName := matchLabels[constant.KBAppComponentLabelKey]
+	if role == "" || clusterName == "" || compName == "" {
+		return nil
+	}
+	its := &workloads.InstanceSet{}
+	itsKey := client.ObjectKey{Namespace: namespace, Name: constant.GenerateClusterComponentName(clusterName, compName)}
+	if err := cli.Get(ctx, itsKey, its); err != nil {
+		// the component may be not created yet
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return field.InternalError(path, err)
+	}
+	for _, r := range its.Spec.Roles {
+		if r.Name == role {
+			return nil
+		}
+	}
+	return field.NotSupported(path.Child("podSelector", "matchLabels").Key(constant.RoleLabelKey), role, roleNames(its.Spec.Roles))
+}
+
+func roleNames(roles []workloads.ReplicaRole) []string {
+	names := make([]string, 0, len(roles))
+	for _, r := range roles {
+		names = append(names, r.Name)
+	}
+	return names
+}
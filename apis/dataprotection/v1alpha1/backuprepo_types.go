@@ -20,6 +20,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
 )
 
 // AccessMethod represents an enumeration type that outlines
@@ -76,6 +77,21 @@ type BackupRepoSpec struct {
 	// +kubebuilder:validation:Pattern=`^([a-zA-Z0-9-_]+/?)*$`
 	// +optional
 	PathPrefix string `json:"pathPrefix,omitempty"`
+
+	// Specifies the namespaces that are allowed to store backups into and restore from this backup repository.
+	//
+	// If neither `allowedNamespaces` nor `namespaceSelector` is specified, the backup repository
+	// can be accessed from all namespaces.
+	// Otherwise, a namespace is allowed if it is listed here or matches the `namespaceSelector`.
+	//
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+
+	// Selects the namespaces that are allowed to store backups into and restore from this backup repository
+	// by their labels.
+	//
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
 }
 
 // BackupRepoStatus defines the observed state of `BackupRepo`.
@@ -164,3 +180,29 @@ func (repo *BackupRepo) AccessByMount() bool {
 func (repo *BackupRepo) AccessByTool() bool {
 	return repo.Spec.AccessMethod == AccessMethodTool
 }
+
+// IsNamespaceRestricted returns true if the access to the backup repository is limited to some namespaces.
+func (repo *BackupRepo) IsNamespaceRestricted() bool {
+	return len(repo.Spec.AllowedNamespaces) > 0 || repo.Spec.NamespaceSelector != nil
+}
+
+// IsNamespaceAllowed checks whether the namespace with the specified name and labels
+// is allowed to access the backup repository.
+func (repo *BackupRepo) IsNamespaceAllowed(namespace string, labels map[string]string) (bool, error) {
+	if !repo.IsNamespaceRestricted() {
+		return true, nil
+	}
+	for _, ns := range repo.Spec.AllowedNamespaces {
+		if ns == namespace {
+			return true, nil
+		}
+	}
+	if repo.Spec.NamespaceSelector == nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(repo.Spec.NamespaceSelector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(k8slabels.Set(labels)), nil
+}
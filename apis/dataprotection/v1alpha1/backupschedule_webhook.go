@@ -0,0 +1,142 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilduration "k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var backupschedulelog = logf.Log.WithName("backupschedule-resource")
+
+func (r *BackupSchedule) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-dataprotection-kubeblocks-io-v1alpha1-backupschedule,mutating=false,failurePolicy=fail,sideEffects=None,groups=dataprotection.kubeblocks.io,resources=backupschedules,verbs=create;update,versions=v1alpha1,name=vbackupschedule.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &BackupSchedule{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *BackupSchedule) ValidateCreate() (admission.Warnings, error) {
+	backupschedulelog.Info("validate create", "name", r.Name)
+	return r.validate(context.Background(), getWebhookClient())
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *BackupSchedule) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	backupschedulelog.Info("validate update", "name", r.Name)
+	// the schedules are updated by the cluster controller in each reconciliation, only the changed spec is validated.
+	if oldSchedule, ok := old.(*BackupSchedule); ok && reflect.DeepEqual(oldSchedule.Spec, r.Spec) {
+		return nil, nil
+	}
+	return r.validate(context.Background(), getWebhookClient())
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *BackupSchedule) ValidateDelete() (admission.Warnings, error) {
+	backupschedulelog.Info("validate delete", "name", r.Name)
+	return nil, nil
+}
+
+// validate checks the cron expressions, the retention periods and the backup methods of the schedules,
+// which fail the backups only when they are running otherwise.
+func (r *BackupSchedule) validate(ctx context.Context, cli client.Client) (admission.Warnings, error) {
+	var (
+		allErrs  field.ErrorList
+		warnings admission.Warnings
+		policy   *BackupPolicy
+	)
+	if cli != nil {
+		policy = &BackupPolicy{}
+		if err := cli.Get(ctx, client.ObjectKey{Namespace: r.Namespace, Name: r.Spec.BackupPolicyName}, policy); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+			warnings = append(warnings, fmt.Sprintf(`backup policy "%s" is not found`, r.Spec.BackupPolicyName))
+			policy = nil
+		}
+	}
+	for i, schedule := range r.Spec.Schedules {
+		path := field.NewPath("spec", "schedules").Index(i)
+		if policy != nil && !hasBackupMethod(policy, schedule.BackupMethod) {
+			allErrs = append(allErrs, field.NotFound(path.Child("backupMethod"), schedule.BackupMethod))
+		}
+		errs, warning := validateSchedulePolicy(schedule, path)
+		allErrs = append(allErrs, errs...)
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+	if len(allErrs) > 0 {
+		return warnings, apierrors.NewInvalid(schema.GroupKind{Group: GroupVersion.Group, Kind: "BackupSchedule"}, r.Name, allErrs)
+	}
+	return warnings, nil
+}
+
+// validateSchedulePolicy checks the cron expression and the retention period of the schedule.
+// The retention period shorter than the shortest interval of the schedule is rejected as every backup
+// is deleted before the next one, and it's warned if it's shorter than the longest interval.
+func validateSchedulePolicy(schedule SchedulePolicy, path *field.Path) (field.ErrorList, string) {
+	cron, err := parseCronExpression(schedule.CronExpression)
+	if err != nil {
+		return field.ErrorList{field.Invalid(path.Child("cronExpression"), schedule.CronExpression, err.Error())}, ""
+	}
+	minInterval, maxInterval := cron.intervals()
+	if maxInterval == 0 {
+		return field.ErrorList{field.Invalid(path.Child("cronExpression"), schedule.CronExpression, "the schedule is never triggered")}, ""
+	}
+	retention, err := schedule.RetentionPeriod.ToDuration()
+	if err != nil {
+		return field.ErrorList{field.Invalid(path.Child("retentionPeriod"), schedule.RetentionPeriod, err.Error())}, ""
+	}
+	// the backups are kept forever if the retention period is not specified
+	switch {
+	case retention == 0 || retention >= maxInterval:
+		return nil, ""
+	case retention < minInterval:
+		return field.ErrorList{field.Invalid(path.Child("retentionPeriod"), schedule.RetentionPeriod,
+			fmt.Sprintf("the retention period is shorter than the interval %s of the schedule, every backup is deleted before the next one", utilduration.HumanDuration(minInterval)))}, ""
+	default:
+		return nil, fmt.Sprintf(`the retention period "%s" of the backup method "%s" is shorter than the longest interval %s of the schedule, no backup is kept for a while`,
+			schedule.RetentionPeriod, schedule.BackupMethod, utilduration.HumanDuration(maxInterval))
+	}
+}
+
+func hasBackupMethod(policy *BackupPolicy, methodName string) bool {
+	for _, method := range policy.Spec.BackupMethods {
+		if method.Name == methodName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCronScheduleIntervals(t *testing.T) {
+	const day = 24 * time.Hour
+	tests := []struct {
+		expr        string
+		minInterval time.Duration
+		maxInterval time.Duration
+		errMsg      string
+	}{
+		{expr: "*/5 * * * *", minInterval: 5 * time.Minute, maxInterval: 5 * time.Minute},
+		{expr: "0 18 * * *", minInterval: day, maxInterval: day},
+		{expr: "CRON_TZ=Asia/Shanghai 0 1,13 * * *", minInterval: 12 * time.Hour, maxInterval: 12 * time.Hour},
+		{expr: "0 1,2 * * *", minInterval: time.Hour, maxInterval: 23 * time.Hour},
+		{expr: "@weekly", minInterval: 7 * day, maxInterval: 7 * day},
+		{expr: "0 0 * * MON-FRI", minInterval: day, maxInterval: 3 * day},
+		{expr: "@monthly", minInterval: 28 * day, maxInterval: 31 * day},
+		{expr: "0 0 1 1 *", minInterval: 365 * day, maxInterval: 366 * day},
+		{expr: "0 0 29 2 *", minInterval: 1461 * day, maxInterval: 1461 * day},
+		// the days matching either field are triggered
+		{expr: "0 0 1 * 0", minInterval: day, maxInterval: 7 * day},
+		{expr: "@every 90m", minInterval: 90 * time.Minute, maxInterval: 90 * time.Minute},
+		{expr: "0 0 30 2 *"},
+		{expr: "0 0 * *", errMsg: "expected exactly 5 fields"},
+		{expr: "60 * * * *", errMsg: `invalid minute "60"`},
+		{expr: "0 0 * * 7", errMsg: `invalid day-of-week "7"`},
+		{expr: "0 5-1 * * *", errMsg: "beyond the end"},
+		{expr: "*/0 * * * *", errMsg: "invalid step"},
+		{expr: "0 0 * JUNE *", errMsg: `invalid month "JUNE"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := parseCronExpression(tt.expr)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("expect error containing %q, but got: %v", tt.errMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			minInterval, maxInterval := s.intervals()
+			if minInterval != tt.minInterval || maxInterval != tt.maxInterval {
+				t.Errorf("expect intervals [%s, %s], but got [%s, %s]", tt.minInterval, tt.maxInterval, minInterval, maxInterval)
+			}
+		})
+	}
+}
+
+func TestValidateSchedulePolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		cron      string
+		retention RetentionPeriod
+		errMsg    string
+		warning   string
+	}{
+		{name: "forever", cron: "0 18 * * *"},
+		{name: "longer retention", cron: "0 18 * * *", retention: "7d"},
+		{name: "monthly", cron: "@monthly", retention: "1mo", warning: "shorter than the longest interval 31d"},
+		{name: "shorter retention", cron: "@weekly", retention: "3d", errMsg: "shorter than the interval 7d"},
+		{name: "invalid retention", cron: "@weekly", retention: "3x", errMsg: "invalid duration"},
+		{name: "invalid cron", cron: "0 18 * *", errMsg: "expected exactly 5 fields"},
+		{name: "never triggered", cron: "0 0 31 4 *", errMsg: "never triggered"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := SchedulePolicy{BackupMethod: "full", CronExpression: tt.cron, RetentionPeriod: tt.retention}
+			errs, warning := validateSchedulePolicy(schedule, nil)
+			if tt.errMsg == "" && len(errs) > 0 {
+				t.Errorf("unexpected errors: %v", errs)
+			}
+			if tt.errMsg != "" && (len(errs) == 0 || !strings.Contains(errs.ToAggregate().Error(), tt.errMsg)) {
+				t.Errorf("expect error containing %q, but got: %v", tt.errMsg, errs)
+			}
+			if !strings.Contains(warning, tt.warning) || (tt.warning == "" && warning != "") {
+				t.Errorf("expect warning containing %q, but got: %q", tt.warning, warning)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSchedule is the parsed cron expression in the syntax accepted by the CronJobs,
+// it's used to validate the expressions and to estimate the intervals between the backups.
+type cronSchedule struct {
+	// the bits of the values matched by the fields
+	minute, hour, dom, month, dow uint64
+	// whether the day-of-month and day-of-week fields are unrestricted
+	domStar, dowStar bool
+	// the interval of the "@every <duration>" expression
+	every time.Duration
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day-of-month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	cronDow = cronField{name: "day-of-week", min: 0, max: 6, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+
+	cronDescriptors = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+// parseCronExpression parses the cron expression with the standard five fields, the descriptors
+// and the optional time zone prefix.
+func parseCronExpression(expr string) (*cronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "TZ=") || strings.HasPrefix(expr, "CRON_TZ=") {
+		i := strings.Index(expr, " ")
+		if i < 0 {
+			return nil, fmt.Errorf("no fields follow the time zone")
+		}
+		expr = strings.TrimSpace(expr[i:])
+	}
+	if strings.HasPrefix(expr, "@every ") {
+		every, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(expr, "@every ")))
+		if err != nil || every < time.Second {
+			return nil, fmt.Errorf(`invalid duration of "%s"`, expr)
+		}
+		return &cronSchedule{every: every}, nil
+	}
+	if descriptor, ok := cronDescriptors[expr]; ok {
+		expr = descriptor
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected exactly 5 fields, found %d", len(fields))
+	}
+	var (
+		s   = &cronSchedule{}
+		err error
+	)
+	if s.minute, _, err = cronMinute.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if s.hour, _, err = cronHour.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if s.dom, s.domStar, err = cronDom.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if s.month, _, err = cronMonth.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if s.dow, s.dowStar, err = cronDow.parse(fields[4]); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// parse returns the bits of the values matched by the field, and whether the field is unrestricted.
+func (f cronField) parse(expr string) (uint64, bool, error) {
+	var (
+		bits uint64
+		star bool
+	)
+	for _, item := range strings.Split(expr, ",") {
+		b, s, err := f.parseItem(item)
+		if err != nil {
+			return 0, false, fmt.Errorf(`invalid %s "%s": %s`, f.name, item, err.Error())
+		}
+		bits |= b
+		star = star || s
+	}
+	return bits, star, nil
+}
+
+func (f cronField) parseItem(item string) (uint64, bool, error) {
+	rangeAndStep := strings.Split(item, "/")
+	if len(rangeAndStep) > 2 {
+		return 0, false, fmt.Errorf("too many slashes")
+	}
+	lowAndHigh := strings.Split(rangeAndStep[0], "-")
+	if len(lowAndHigh) > 2 {
+		return 0, false, fmt.Errorf("too many hyphens")
+	}
+	var (
+		start, end int
+		star       bool
+		err        error
+	)
+	if lowAndHigh[0] == "*" || lowAndHigh[0] == "?" {
+		if len(lowAndHigh) > 1 {
+			return 0, false, fmt.Errorf("a range can't start with %s", lowAndHigh[0])
+		}
+		start, end, star = f.min, f.max, true
+	} else {
+		if start, err = f.value(lowAndHigh[0]); err != nil {
+			return 0, false, err
+		}
+		end = start
+		if len(lowAndHigh) == 2 {
+			if end, err = f.value(lowAndHigh[1]); err != nil {
+				return 0, false, err
+			}
+		}
+	}
+	step := 1
+	if len(rangeAndStep) == 2 {
+		if step, err = strconv.Atoi(rangeAndStep[1]); err != nil || step <= 0 {
+			return 0, false, fmt.Errorf(`invalid step "%s"`, rangeAndStep[1])
+		}
+		// "N/step" means "N-max/step"
+		if !star && len(lowAndHigh) == 1 {
+			end = f.max
+		}
+		if step > 1 {
+			star = false
+		}
+	}
+	if start > end {
+		return 0, false, fmt.Errorf("the beginning %d is beyond the end %d", start, end)
+	}
+	var bits uint64
+	for v := start; v <= end; v += step {
+		bits |= 1 << uint(v)
+	}
+	return bits, star, nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf(`failed to parse "%s"`, s)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("%d is out of the range [%d, %d]", v, f.min, f.max)
+	}
+	return v, nil
+}
+
+// intervals returns the shortest and the longest intervals between two successive triggers of the schedule in UTC,
+// they are zero if the schedule never triggers.
+func (s *cronSchedule) intervals() (time.Duration, time.Duration) {
+	if s.every > 0 {
+		return s.every, s.every
+	}
+	var (
+		times       []time.Duration // the trigger times in a day
+		minInterval time.Duration
+		maxInterval time.Duration
+		lastTrigger time.Time
+	)
+	observe := func(interval time.Duration) {
+		if minInterval == 0 || interval < minInterval {
+			minInterval = interval
+		}
+		maxInterval = max(maxInterval, interval)
+	}
+	for h := 0; h < 24; h++ {
+		if s.hour&(1<<uint(h)) == 0 {
+			continue
+		}
+		for m := 0; m < 60; m++ {
+			if s.minute&(1<<uint(m)) != 0 {
+				times = append(times, time.Duration(h)*time.Hour+time.Duration(m)*time.Minute)
+			}
+		}
+	}
+	// scan two leap-year cycles, which covers the schedules triggered on February 29 only.
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for day := start; day.Before(start.AddDate(8, 0, 1)); day = day.AddDate(0, 0, 1) {
+		if !s.matchDay(day) {
+			continue
+		}
+		if !lastTrigger.IsZero() {
+			observe(day.Add(times[0]).Sub(lastTrigger))
+		}
+		lastTrigger = day.Add(times[len(times)-1])
+	}
+	if lastTrigger.IsZero() {
+		return 0, 0
+	}
+	for i := 1; i < len(times); i++ {
+		observe(times[i] - times[i-1])
+	}
+	return minInterval, maxInterval
+}
+
+func (s *cronSchedule) matchDay(day time.Time) bool {
+	if s.month&(1<<uint(day.Month())) == 0 {
+		return false
+	}
+	domMatch := s.dom&(1<<uint(day.Day())) != 0
+	dowMatch := s.dow&(1<<uint(day.Weekday())) != 0
+	// the days matching either field are triggered if both fields are restricted
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
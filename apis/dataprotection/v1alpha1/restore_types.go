@@ -22,6 +22,8 @@ import (
 )
 
 // RestoreSpec defines the desired state of Restore
+//
+// +kubebuilder:validation:XValidation:rule="!has(self.partialRestore) || has(self.readyConfig)",message="readyConfig is required for partialRestore"
 type RestoreSpec struct {
 	// Specifies the backup to be restored. The restore behavior is based on the backup type:
 	//
@@ -64,6 +66,15 @@ type RestoreSpec struct {
 	// +optional
 	ReadyConfig *ReadyConfig `json:"readyConfig,omitempty"`
 
+	// Specifies a subset of the backup, such as some databases or tables, to be restored
+	// into the existing cluster selected by the `readyConfig`.
+	// The selected objects are passed to the "postReady" actions of the ActionSet by the environment variables,
+	// which are responsible for restoring them with the tooling of the database engine.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.partialRestore"
+	// +optional
+	PartialRestore *PartialRestore `json:"partialRestore,omitempty"`
+
 	// List of environment variables to set in the container for restore. These will be
 	// merged with the env of Backup and ActionSet.
 	//
@@ -171,6 +182,46 @@ type PrepareDataConfig struct {
 	SchedulingSpec SchedulingSpec `json:"schedulingSpec,omitempty"`
 }
 
+// PartialRestore specifies the objects of the backup to be restored, and how to handle the conflicts
+// with the existing objects in the cluster.
+type PartialRestore struct {
+	// Specifies the objects to be restored, identified by the `name` and `parent` of the entries
+	// in the `status.catalog` of the Backup.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:Required
+	Objects []PartialRestoreObject `json:"objects"`
+
+	// Specifies how to handle the objects that already exist in the cluster. Options include:
+	//
+	// - `Fail`: fails the restore without changing the existing objects.
+	// - `Overwrite`: replaces the existing objects with the ones in the backup.
+	// - `Rename`: restores the objects with their names suffixed by `renameSuffix`.
+	//
+	// +kubebuilder:default=Fail
+	// +optional
+	ConflictPolicy PartialRestoreConflictPolicy `json:"conflictPolicy,omitempty"`
+
+	// Specifies the suffix appended to the names of the restored objects when the `conflictPolicy` is `Rename`.
+	//
+	// +kubebuilder:default="_restored"
+	// +optional
+	RenameSuffix string `json:"renameSuffix,omitempty"`
+}
+
+// PartialRestoreObject identifies an object of the backup to be restored.
+type PartialRestoreObject struct {
+	// Specifies the name of the object, such as the name of a database or a table.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the name of the object that contains this one, e.g. the database of a table.
+	//
+	// +optional
+	Parent string `json:"parent,omitempty"`
+}
+
 type ReadyConfig struct {
 	// Specifies the configuration for a job action.
 	//
@@ -446,6 +497,29 @@ type RestoreStatusAction struct {
 	EndTime metav1.Time `json:"endTime,omitempty"`
 }
 
+// RestoreDataProgress describes the progress of the data restored.
+type RestoreDataProgress struct {
+	// Records the size in bytes of the data restored so far.
+	//
+	// +optional
+	BytesRestored int64 `json:"bytesRestored,omitempty"`
+
+	// Records the total size in bytes of the data to restore, it is 0 if the size is unknown.
+	//
+	// +optional
+	TotalBytes int64 `json:"totalBytes,omitempty"`
+
+	// Records the average rate of the restore in bytes per second.
+	//
+	// +optional
+	BytesPerSecond int64 `json:"bytesPerSecond,omitempty"`
+
+	// Records the estimated time when the data will be restored completely.
+	//
+	// +optional
+	EstimatedCompletionTime *metav1.Time `json:"estimatedCompletionTime,omitempty"`
+}
+
 // RestoreStatus defines the observed state of Restore
 type RestoreStatus struct {
 	// Represents the current phase of the restore.
@@ -474,6 +548,17 @@ type RestoreStatus struct {
 	// +optional
 	Actions RestoreStatusActions `json:"actions,omitempty"`
 
+	// Represents the progress of the "postReady" actions, in the format of "completed/total".
+	//
+	// +optional
+	Progress string `json:"progress,omitempty"`
+
+	// Represents the progress of the data restored by the "prepareData" actions of the backup being restored,
+	// which is reported by the actions if the `restore.syncProgress` of the ActionSet is enabled.
+	//
+	// +optional
+	DataProgress *RestoreDataProgress `json:"dataProgress,omitempty"`
+
 	// Describes the current state of the restore API Resource, like warning.
 	//
 	// +optional
@@ -520,3 +605,14 @@ func (p *PrepareDataConfig) IsSerialPolicy() bool {
 	}
 	return p.VolumeClaimRestorePolicy == VolumeClaimRestorePolicySerial
 }
+
+// Percentage returns the percentage of the data restored, or -1 if the total size is unknown.
+func (p *RestoreDataProgress) Percentage() int {
+	if p == nil || p.TotalBytes <= 0 {
+		return -1
+	}
+	if p.BytesRestored >= p.TotalBytes {
+		return 100
+	}
+	return int(p.BytesRestored * 100 / p.TotalBytes)
+}
@@ -24,6 +24,8 @@ import (
 	"unicode"
 
 	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
 // Phase defines the BackupPolicy and ActionSet CR .status.phase
@@ -231,6 +233,19 @@ const (
 	VolumeClaimRestorePolicySerial   VolumeClaimRestorePolicy = "Serial"
 )
 
+// PartialRestoreConflictPolicy defines how to handle the objects that already exist in the cluster
+// during a partial restore.
+//
+// +enum
+// +kubebuilder:validation:Enum={Fail,Overwrite,Rename}
+type PartialRestoreConflictPolicy string
+
+const (
+	PartialRestoreConflictPolicyFail      PartialRestoreConflictPolicy = "Fail"
+	PartialRestoreConflictPolicyOverwrite PartialRestoreConflictPolicy = "Overwrite"
+	PartialRestoreConflictPolicyRename    PartialRestoreConflictPolicy = "Rename"
+)
+
 type DataRestorePolicy string
 
 const (
@@ -261,3 +276,21 @@ type EncryptionConfig struct {
 	// +kubebuilder:validation:Required
 	PassPhraseSecretKeyRef *corev1.SecretKeySelector `json:"passPhraseSecretKeyRef"`
 }
+
+var webhookMgr *webhookManager
+
+type webhookManager struct {
+	client client.Client
+}
+
+func RegisterWebhookManager(mgr manager.Manager) {
+	webhookMgr = &webhookManager{mgr.GetClient()}
+}
+
+// getWebhookClient returns the client of the registered webhook manager, it returns nil if there is none.
+func getWebhookClient() client.Client {
+	if webhookMgr == nil {
+		return nil
+	}
+	return webhookMgr.client
+}
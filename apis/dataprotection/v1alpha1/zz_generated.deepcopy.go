@@ -264,6 +264,11 @@ func (in *BackupActionSpec) DeepCopyInto(out *BackupActionSpec) {
 		*out = new(BaseJobActionSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Introspect != nil {
+		in, out := &in.Introspect, &out.Introspect
+		*out = new(JobActionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupActionSpec.
@@ -276,6 +281,25 @@ func (in *BackupActionSpec) DeepCopy() *BackupActionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupCatalogEntry) DeepCopyInto(out *BackupCatalogEntry) {
+	*out = *in
+	if in.Timestamp != nil {
+		in, out := &in.Timestamp, &out.Timestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupCatalogEntry.
+func (in *BackupCatalogEntry) DeepCopy() *BackupCatalogEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupCatalogEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupDataActionSpec) DeepCopyInto(out *BackupDataActionSpec) {
 	*out = *in
@@ -384,7 +408,7 @@ func (in *BackupPolicy) DeepCopyInto(out *BackupPolicy) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupPolicy.
@@ -474,6 +498,11 @@ func (in *BackupPolicySpec) DeepCopyInto(out *BackupPolicySpec) {
 		*out = new(EncryptionConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RestoreDrill != nil {
+		in, out := &in.RestoreDrill, &out.RestoreDrill
+		*out = new(RestoreDrill)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupPolicySpec.
@@ -489,6 +518,11 @@ func (in *BackupPolicySpec) DeepCopy() *BackupPolicySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupPolicyStatus) DeepCopyInto(out *BackupPolicyStatus) {
 	*out = *in
+	if in.RestoreDrill != nil {
+		in, out := &in.RestoreDrill, &out.RestoreDrill
+		*out = new(RestoreDrillStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupPolicyStatus.
@@ -591,6 +625,16 @@ func (in *BackupRepoSpec) DeepCopyInto(out *BackupRepoSpec) {
 		*out = new(v1.SecretReference)
 		**out = **in
 	}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRepoSpec.
@@ -825,6 +869,13 @@ func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
 			}
 		}
 	}
+	if in.Catalog != nil {
+		in, out := &in.Catalog, &out.Catalog
+		*out = make([]BackupCatalogEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStatus.
@@ -1154,6 +1205,41 @@ func (in *ParametersSchema) DeepCopy() *ParametersSchema {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartialRestore) DeepCopyInto(out *PartialRestore) {
+	*out = *in
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]PartialRestoreObject, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartialRestore.
+func (in *PartialRestore) DeepCopy() *PartialRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(PartialRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartialRestoreObject) DeepCopyInto(out *PartialRestoreObject) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartialRestoreObject.
+func (in *PartialRestoreObject) DeepCopy() *PartialRestoreObject {
+	if in == nil {
+		return nil
+	}
+	out := new(PartialRestoreObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSelector) DeepCopyInto(out *PodSelector) {
 	*out = *in
@@ -1345,6 +1431,11 @@ func (in *RestoreActionSpec) DeepCopyInto(out *RestoreActionSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SyncProgress != nil {
+		in, out := &in.SyncProgress, &out.SyncProgress
+		*out = new(SyncProgress)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreActionSpec.
@@ -1357,6 +1448,87 @@ func (in *RestoreActionSpec) DeepCopy() *RestoreActionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreDataProgress) DeepCopyInto(out *RestoreDataProgress) {
+	*out = *in
+	if in.EstimatedCompletionTime != nil {
+		in, out := &in.EstimatedCompletionTime, &out.EstimatedCompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreDataProgress.
+func (in *RestoreDataProgress) DeepCopy() *RestoreDataProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreDataProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreDrill) DeepCopyInto(out *RestoreDrill) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VerifyAction != nil {
+		in, out := &in.VerifyAction, &out.VerifyAction
+		*out = new(BaseJobActionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreDrill.
+func (in *RestoreDrill) DeepCopy() *RestoreDrill {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreDrill)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreDrillStatus) DeepCopyInto(out *RestoreDrillStatus) {
+	*out = *in
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTimestamp != nil {
+		in, out := &in.CompletionTimestamp, &out.CompletionTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSucceededTimestamp != nil {
+		in, out := &in.LastSucceededTimestamp, &out.LastSucceededTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.RTOSeconds != nil {
+		in, out := &in.RTOSeconds, &out.RTOSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RPOSeconds != nil {
+		in, out := &in.RPOSeconds, &out.RPOSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreDrillStatus.
+func (in *RestoreDrillStatus) DeepCopy() *RestoreDrillStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreDrillStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RestoreKubeResources) DeepCopyInto(out *RestoreKubeResources) {
 	*out = *in
@@ -1430,6 +1602,11 @@ func (in *RestoreSpec) DeepCopyInto(out *RestoreSpec) {
 		*out = new(ReadyConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PartialRestore != nil {
+		in, out := &in.PartialRestore, &out.PartialRestore
+		*out = new(PartialRestore)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
 		*out = make([]v1.EnvVar, len(*in))
@@ -1472,6 +1649,11 @@ func (in *RestoreStatus) DeepCopyInto(out *RestoreStatus) {
 		**out = **in
 	}
 	in.Actions.DeepCopyInto(&out.Actions)
+	if in.DataProgress != nil {
+		in, out := &in.DataProgress, &out.DataProgress
+		*out = new(RestoreDataProgress)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -0,0 +1,166 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CRDMigrationSpec defines the desired state of a CRD storage version migration.
+type CRDMigrationSpec struct {
+	// Specifies the KubeBlocks version that the migration is performed for.
+	//
+	// +kubebuilder:validation:Required
+	TargetVersion string `json:"targetVersion"`
+
+	// Specifies the names of the CRDs to migrate.
+	// If not specified, all the CRDs of the `kubeblocks.io` API groups are migrated.
+	//
+	// +optional
+	CRDNames []string `json:"crdNames,omitempty"`
+
+	// Specifies the number of objects listed and rewritten in one batch.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=500
+	// +optional
+	BatchSize int32 `json:"batchSize,omitempty"`
+}
+
+// CRDMigrationStatus defines the observed state of a CRD storage version migration.
+type CRDMigrationStatus struct {
+	// Represents the current phase of the migration. It can take one of
+	// the following values: `Pending`, `Running`, `Succeeded`, `Failed`.
+	//
+	// +optional
+	Phase CRDMigrationPhase `json:"phase,omitempty"`
+
+	// Provides a human-readable message about the migration.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Records the time when the migration started.
+	//
+	// +optional
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Records the time when the migration finished.
+	//
+	// +optional
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// Records the migration progress of each CRD.
+	//
+	// +optional
+	CRDs []CRDStorageMigrationStatus `json:"crds,omitempty"`
+}
+
+// CRDStorageMigrationStatus records the storage version migration progress of a CRD.
+type CRDStorageMigrationStatus struct {
+	// Specifies the name of the CRD.
+	Name string `json:"name"`
+
+	// Represents the storage version of the CRD that the objects are migrated to.
+	//
+	// +optional
+	StorageVersion string `json:"storageVersion,omitempty"`
+
+	// Represents the stored versions of the CRD.
+	// Once all the objects are migrated and the conversion is verified,
+	// the stored versions are reduced to the storage version.
+	//
+	// +optional
+	StoredVersions []string `json:"storedVersions,omitempty"`
+
+	// Represents the migration phase of the CRD.
+	//
+	// +optional
+	Phase CRDMigrationPhase `json:"phase,omitempty"`
+
+	// Represents the total number of objects of the CRD.
+	//
+	// +optional
+	Total int32 `json:"total,omitempty"`
+
+	// Represents the number of objects that have been rewritten in the storage version.
+	//
+	// +optional
+	Migrated int32 `json:"migrated,omitempty"`
+
+	// Represents the number of objects that failed to be migrated or converted.
+	//
+	// +optional
+	Failed int32 `json:"failed,omitempty"`
+
+	// Lists the problems found during the migration and the conversion verification.
+	// At most 20 problems are recorded.
+	//
+	// +optional
+	Problems []CRDMigrationProblem `json:"problems,omitempty"`
+}
+
+// CRDMigrationProblem describes a problem found while migrating an object.
+type CRDMigrationProblem struct {
+	// Specifies the key of the object, in the format of `namespace/name` or `name`.
+	// It is empty if the problem is not specific to an object.
+	//
+	// +optional
+	ObjectKey string `json:"objectKey,omitempty"`
+
+	// Specifies the API version in which the problem occurs.
+	//
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Describes the problem.
+	Message string `json:"message"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks},scope=Cluster
+// +kubebuilder:printcolumn:name="VERSION",type="string",JSONPath=".spec.targetVersion",description="target version"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.phase",description="status phase"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// CRDMigration is the Schema for the CRD storage version migrations API.
+// It is created by the upgrade job of KubeBlocks to rewrite all the existing objects of the KubeBlocks CRDs
+// in their storage versions, and reports the progress and the problems found.
+type CRDMigration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CRDMigrationSpec   `json:"spec,omitempty"`
+	Status CRDMigrationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CRDMigrationList contains a list of CRD storage version migrations.
+type CRDMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CRDMigration `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CRDMigration{}, &CRDMigrationList{})
+}
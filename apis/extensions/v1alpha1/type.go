@@ -49,6 +49,18 @@ const (
 	AddonDisabling AddonPhase = "Disabling"
 )
 
+// CRDMigrationPhase defines the phases of a CRD storage version migration.
+// +enum
+// +kubebuilder:validation:Enum={Pending,Running,Succeeded,Failed}
+type CRDMigrationPhase string
+
+const (
+	CRDMigrationPending   CRDMigrationPhase = "Pending"
+	CRDMigrationRunning   CRDMigrationPhase = "Running"
+	CRDMigrationSucceeded CRDMigrationPhase = "Succeeded"
+	CRDMigrationFailed    CRDMigrationPhase = "Failed"
+)
+
 // AddonSelectorKey are selector requirement key types.
 // +enum
 // +kubebuilder:validation:Enum={KubeGitVersion,KubeVersion,KubeProvider}
@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -280,6 +281,23 @@ type InstanceSetSpec struct {
 	// +optional
 	MemberUpdateStrategy *MemberUpdateStrategy `json:"memberUpdateStrategy,omitempty"`
 
+	// Specifies the number of old ControllerRevisions of the pod template to retain, which can be rolled back to.
+	// The ControllerRevision of the current pod template is always retained.
+	//
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+
+	// Specifies the revision of the pod template to roll the instances back to.
+	//
+	// While set, the instances are updated to the pod template recorded in the ControllerRevision
+	// with the given revision number instead of `spec.template`, following the same update strategy.
+	// Remove it to update the instances to `spec.template` again.
+	//
+	// +optional
+	RollbackTo *RollbackConfig `json:"rollbackTo,omitempty"`
+
 	// Indicates that the InstanceSet is paused, meaning the reconciliation of this InstanceSet object will be paused.
 	// +optional
 	Paused bool `json:"paused,omitempty"`
@@ -288,6 +306,14 @@ type InstanceSetSpec struct {
 	//
 	// +optional
 	Credential *Credential `json:"credential,omitempty"`
+
+	// Defines the warm-up check of the instances.
+	//
+	// A ready instance is not considered available until it has been warmed up, or the check times out,
+	// so that the rolling update of the InstanceSet waits for the caches of the restarted instance to be warmed up.
+	//
+	// +optional
+	WarmUpCheck *WarmUpCheck `json:"warmUpCheck,omitempty"`
 }
 
 // InstanceSetStatus defines the observed state of InstanceSet
@@ -354,6 +380,14 @@ type InstanceSetStatus struct {
 	// +optional
 	MembersStatus []MemberStatus `json:"membersStatus,omitempty"`
 
+	// Provides the stable network identity of each instance, which is resolved by the headless Service of the InstanceSet.
+	// Config templates and ServiceDescriptors can reference these addresses even if the instance names are not ordinal.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=podName
+	InstanceStatus []InstanceStatus `json:"instanceStatus,omitempty"`
+
 	// Indicates whether it is required for the InstanceSet to have at least one primary instance ready.
 	//
 	// +optional
@@ -370,6 +404,12 @@ type InstanceSetStatus struct {
 	//
 	// +optional
 	UpdateRevisions map[string]string `json:"updateRevisions,omitempty"`
+
+	// templateRevision is the revision number of the ControllerRevision of the pod template
+	// which the instances are updated to.
+	//
+	// +optional
+	TemplateRevision int64 `json:"templateRevision,omitempty"`
 }
 
 // +genclient
@@ -407,6 +447,15 @@ type InstanceSetList struct {
 	Items           []InstanceSet `json:"items"`
 }
 
+// RollbackConfig specifies the revision to roll back to.
+type RollbackConfig struct {
+	// Specifies the revision number of the ControllerRevision owned by the InstanceSet to roll back to.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	Revision int64 `json:"revision"`
+}
+
 type ReplicaRole struct {
 
 	// Defines the role name of the replica.
@@ -560,6 +609,74 @@ type CredentialVar struct {
 	ValueFrom *corev1.EnvVarSource `json:"valueFrom,omitempty"`
 }
 
+// WarmUpCheck defines how to check whether an instance has been warmed up after it becomes ready.
+type WarmUpCheck struct {
+	// Specifies the engine metric to check.
+	// If not specified, the `warmUpCheck` action is invoked on the instance through lorry.
+	//
+	// +optional
+	Metric *WarmUpMetric `json:"metric,omitempty"`
+
+	// Specifies how often (in seconds) to perform the check.
+	// Defaults to 10 seconds. Minimum value is 1.
+	//
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+
+	// Specifies the number of seconds after the instance becomes ready, after which the instance is considered
+	// available even if it has not been warmed up.
+	// Defaults to 600 seconds. Minimum value is 1.
+	//
+	// +kubebuilder:default=600
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+}
+
+// WarmUpMetricOperator defines how to compare the value of a metric with the threshold.
+//
+// +enum
+// +kubebuilder:validation:Enum={GreaterThanOrEqual,LessThanOrEqual}
+type WarmUpMetricOperator string
+
+const (
+	WarmUpMetricGreaterThanOrEqual WarmUpMetricOperator = "GreaterThanOrEqual"
+	WarmUpMetricLessThanOrEqual    WarmUpMetricOperator = "LessThanOrEqual"
+)
+
+// WarmUpMetric defines a metric exposed by the engine in the Prometheus text format,
+// and the threshold it should reach once the instance has been warmed up.
+type WarmUpMetric struct {
+	// Specifies the container port of the metrics endpoint.
+	//
+	// +kubebuilder:validation:Required
+	Port int32 `json:"port"`
+
+	// Specifies the HTTP path of the metrics endpoint.
+	//
+	// +kubebuilder:default="/metrics"
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Specifies the name of the metric. The values of all the series of the metric are summed.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the threshold the value of the metric is compared with.
+	//
+	// +kubebuilder:validation:Required
+	Threshold resource.Quantity `json:"threshold"`
+
+	// Specifies how to compare the value of the metric with the threshold.
+	//
+	// +kubebuilder:default=GreaterThanOrEqual
+	// +optional
+	Operator WarmUpMetricOperator `json:"operator,omitempty"`
+}
+
 type MembershipReconfiguration struct {
 	// Specifies the environment variables that can be used in all following Actions:
 	// - KB_ITS_USERNAME: Represents the username part of the credential
@@ -629,6 +746,26 @@ type MemberStatus struct {
 	ReplicaRole *ReplicaRole `json:"role,omitempty"`
 }
 
+// InstanceStatus describes the network identity of an instance.
+type InstanceStatus struct {
+	// Represents the name of the pod.
+	//
+	// +kubebuilder:validation:Required
+	PodName string `json:"podName"`
+
+	// Represents the fully qualified domain name of the pod,
+	// in the format of "<hostname>.<subdomain>.<namespace>.svc.<clusterDomain>".
+	// The subdomain is the headless Service of the InstanceSet.
+	//
+	// +optional
+	FQDN string `json:"fqdn,omitempty"`
+
+	// Represents the IP address allocated to the pod, it's empty before the pod is scheduled.
+	//
+	// +optional
+	PodIP string `json:"podIP,omitempty"`
+}
+
 type ConditionType string
 
 const (
@@ -0,0 +1,141 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/clustertemplate"
+)
+
+var (
+	clusterFile        string
+	configurationFiles []string
+	templateFile       string
+	values             []string
+)
+
+func setupFlags() {
+	pflag.StringVar(&clusterFile, "cluster", "", `The YAML file of the Cluster to export, "-" to read from stdin`)
+	pflag.StringSliceVar(&configurationFiles, "configuration", nil, "The YAML files of the Configurations of the Components to export the updated parameters")
+	pflag.StringVar(&templateFile, "template", "", `The YAML file of the template to render, "-" to read from stdin`)
+	pflag.StringArrayVar(&values, "set", nil, "Set the value of a parameter when rendering the template, in the form of <name>=<value>")
+	pflag.Parse()
+}
+
+func main() {
+	setupFlags()
+	if err := run(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+// run exports the Cluster into a template if --cluster is set, or renders the template into manifests if --template is set.
+func run(in io.Reader, out io.Writer) error {
+	switch {
+	case clusterFile != "" && templateFile != "":
+		return errors.New("only one of --cluster and --template can be set")
+	case clusterFile != "":
+		return export(in, out)
+	case templateFile != "":
+		return render(in, out)
+	default:
+		return errors.New("either --cluster or --template is required")
+	}
+}
+
+func export(in io.Reader, out io.Writer) error {
+	cluster := &appsv1alpha1.Cluster{}
+	if err := readObject(clusterFile, in, cluster); err != nil {
+		return err
+	}
+	configurations := make([]appsv1alpha1.Configuration, len(configurationFiles))
+	for i, file := range configurationFiles {
+		if err := readObject(file, in, &configurations[i]); err != nil {
+			return err
+		}
+	}
+	tpl, err := clustertemplate.Export(cluster, configurations)
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(tpl)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(data)
+	return err
+}
+
+func render(in io.Reader, out io.Writer) error {
+	tpl := &clustertemplate.Template{}
+	if err := readObject(templateFile, in, tpl); err != nil {
+		return err
+	}
+	params := map[string]string{}
+	for _, value := range values {
+		name, v, ok := strings.Cut(value, "=")
+		if !ok {
+			return fmt.Errorf("invalid value %s, expected <name>=<value>", value)
+		}
+		params[name] = v
+	}
+	objs, err := tpl.Render(params)
+	if err != nil {
+		return err
+	}
+	for i, obj := range objs {
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			if _, err = io.WriteString(out, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err = out.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readObject(file string, in io.Reader, obj any) error {
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	return utilyaml.NewYAMLOrJSONDecoder(in, 4096).Decode(obj)
+}
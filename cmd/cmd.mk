@@ -97,3 +97,75 @@ lorry-fast:
 .PHONY: clean-lorry
 clean-lorry: ## Clean bin/lorry.
 	rm -f bin/lorry
+
+## simulator cmd
+
+SIMULATOR_LD_FLAGS = "-s -w"
+
+bin/simulator.%: ## Cross build bin/simulator.$(OS).$(ARCH) .
+	GOOS=$(word 2,$(subst ., ,$@)) GOARCH=$(word 3,$(subst ., ,$@)) $(GO) build -ldflags=${SIMULATOR_LD_FLAGS} -o $@ ./cmd/simulator/main.go
+
+.PHONY: simulator
+simulator: OS=$(shell $(GO) env GOOS)
+simulator: ARCH=$(shell $(GO) env GOARCH)
+simulator: build-checks ## Build simulator related binaries
+	$(MAKE) bin/simulator.${OS}.${ARCH}
+	mv bin/simulator.${OS}.${ARCH} bin/simulator
+
+.PHONY: clean-simulator
+clean-simulator: ## Clean bin/simulator.
+	rm -f bin/simulator
+
+## loadgen cmd
+
+LOADGEN_LD_FLAGS = "-s -w"
+
+bin/loadgen.%: ## Cross build bin/loadgen.$(OS).$(ARCH) .
+	GOOS=$(word 2,$(subst ., ,$@)) GOARCH=$(word 3,$(subst ., ,$@)) $(GO) build -ldflags=${LOADGEN_LD_FLAGS} -o $@ ./cmd/loadgen/main.go
+
+.PHONY: loadgen
+loadgen: OS=$(shell $(GO) env GOOS)
+loadgen: ARCH=$(shell $(GO) env GOARCH)
+loadgen: build-checks ## Build loadgen related binaries
+	$(MAKE) bin/loadgen.${OS}.${ARCH}
+	mv bin/loadgen.${OS}.${ARCH} bin/loadgen
+
+.PHONY: clean-loadgen
+clean-loadgen: ## Clean bin/loadgen.
+	rm -f bin/loadgen
+
+## configexport cmd
+
+CONFIGEXPORT_LD_FLAGS = "-s -w"
+
+bin/configexport.%: ## Cross build bin/configexport.$(OS).$(ARCH) .
+	GOOS=$(word 2,$(subst ., ,$@)) GOARCH=$(word 3,$(subst ., ,$@)) $(GO) build -ldflags=${CONFIGEXPORT_LD_FLAGS} -o $@ ./cmd/configexport/main.go
+
+.PHONY: configexport
+configexport: OS=$(shell $(GO) env GOOS)
+configexport: ARCH=$(shell $(GO) env GOARCH)
+configexport: build-checks ## Build configexport related binaries
+	$(MAKE) bin/configexport.${OS}.${ARCH}
+	mv bin/configexport.${OS}.${ARCH} bin/configexport
+
+.PHONY: clean-configexport
+clean-configexport: ## Clean bin/configexport.
+	rm -f bin/configexport
+
+## clustertemplate cmd
+
+CLUSTERTEMPLATE_LD_FLAGS = "-s -w"
+
+bin/clustertemplate.%: ## Cross build bin/clustertemplate.$(OS).$(ARCH) .
+	GOOS=$(word 2,$(subst ., ,$@)) GOARCH=$(word 3,$(subst ., ,$@)) $(GO) build -ldflags=${CLUSTERTEMPLATE_LD_FLAGS} -o $@ ./cmd/clustertemplate/main.go
+
+.PHONY: clustertemplate
+clustertemplate: OS=$(shell $(GO) env GOOS)
+clustertemplate: ARCH=$(shell $(GO) env GOARCH)
+clustertemplate: build-checks ## Build clustertemplate related binaries
+	$(MAKE) bin/clustertemplate.${OS}.${ARCH}
+	mv bin/clustertemplate.${OS}.${ARCH} bin/clustertemplate
+
+.PHONY: clean-clustertemplate
+clean-clustertemplate: ## Clean bin/clustertemplate.
+	rm -f bin/clustertemplate
@@ -0,0 +1,121 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/pflag"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	cfgcore "github.com/apecloud/kubeblocks/pkg/configuration/core"
+)
+
+var (
+	configurationFile string
+	configSpecs       []string
+	namespace         string
+	clusterName       string
+	componentName     string
+)
+
+func setupFlags() {
+	pflag.StringVarP(&configurationFile, "file", "f", "", `The YAML file of the Configuration of the source component, "-" to read from stdin`)
+	pflag.StringSliceVar(&configSpecs, "config-spec", nil, "The names of the config specs to export, all config specs are exported if not set")
+	pflag.StringVarP(&namespace, "namespace", "n", "", "The namespace of the target cluster, defaults to the namespace of the source")
+	pflag.StringVar(&clusterName, "cluster", "", "The name of the target cluster")
+	pflag.StringVar(&componentName, "component", "", "The name of the target component, defaults to the name of the source component")
+	pflag.Parse()
+}
+
+func main() {
+	setupFlags()
+	if err := run(os.Stdin, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+// run exports the updated parameters of the source component, and writes a Reconfigure OpsRequest applying them
+// to the target component, which is expected to be reviewed before being applied to the target cluster.
+func run(in io.Reader, out, errOut io.Writer) error {
+	if configurationFile == "" {
+		return errors.New("the configuration file is required")
+	}
+	config := &appsv1alpha1.Configuration{}
+	if err := readObject(configurationFile, in, config); err != nil {
+		return err
+	}
+	if namespace == "" {
+		namespace = config.Namespace
+	}
+	items := cfgcore.ExportConfigParameters(config, configSpecs...)
+	ops, err := cfgcore.BuildReconfigureOpsRequest(config, items, namespace, clusterName, componentName)
+	if err != nil {
+		return err
+	}
+	for _, secret := range referencedSecrets(items) {
+		fmt.Fprintf(errOut, "warning: the parameters reference the secret %s, make sure it exists in namespace %s\n", secret, namespace)
+	}
+	data, err := yaml.Marshal(ops)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(data)
+	return err
+}
+
+func referencedSecrets(items []appsv1alpha1.ConfigurationItem) []string {
+	var (
+		secrets []string
+		seen    = map[string]bool{}
+	)
+	for _, item := range items {
+		for _, key := range item.Keys {
+			for _, param := range key.Parameters {
+				if param.ValueFrom == nil || param.ValueFrom.SecretKeyRef == nil {
+					continue
+				}
+				if name := param.ValueFrom.SecretKeyRef.Name; !seen[name] {
+					seen[name] = true
+					secrets = append(secrets, name)
+				}
+			}
+		}
+	}
+	return secrets
+}
+
+func readObject(file string, in io.Reader, obj any) error {
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	return utilyaml.NewYAMLOrJSONDecoder(in, 4096).Decode(obj)
+}
@@ -0,0 +1,333 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+
+	extensionsv1alpha1 "github.com/apecloud/kubeblocks/apis/extensions/v1alpha1"
+)
+
+const (
+	// kubeblocksAPIGroupSuffix the suffix of the API groups served by KubeBlocks
+	kubeblocksAPIGroupSuffix = "kubeblocks.io"
+
+	defaultMigrationBatchSize = 500
+	maxMigrationProblems      = 20
+	operatorAvailableTimeout  = 10 * time.Minute
+)
+
+// WaitForOperator waits for the upgraded KubeBlocks deployment to be available,
+// the conversion webhooks are served by it.
+type WaitForOperator struct {
+	BasedHandler
+}
+
+func (w *WaitForOperator) Handle(ctx *UpgradeContext) error {
+	Log("wait for KubeBlocks to be available")
+	return wait.PollUntilContextTimeout(ctx, 5*time.Second, operatorAvailableTimeout, true,
+		func(_ context.Context) (bool, error) {
+			deploy, err := GetKubeBlocksDeploy(ctx, ctx.K8sClient, ctx.Namespace, kubeblocksAppComponent)
+			if err != nil || deploy == nil {
+				return false, err
+			}
+			replicas := int32(1)
+			if deploy.Spec.Replicas != nil {
+				replicas = *deploy.Spec.Replicas
+			}
+			return deploy.Status.ObservedGeneration >= deploy.Generation &&
+				deploy.Status.UpdatedReplicas == replicas &&
+				deploy.Status.AvailableReplicas == replicas, nil
+		})
+}
+
+// MigrateStorageVersion rewrites all the existing objects of the KubeBlocks CRDs in their storage versions,
+// verifies that the objects can be converted to all the served versions, and then drops the stale versions
+// from the stored versions of the CRDs. The progress and the problems are reported in a CRDMigration object.
+type MigrateStorageVersion struct {
+	BasedHandler
+
+	BatchSize int32
+}
+
+func (m *MigrateStorageVersion) Handle(ctx *UpgradeContext) error {
+	migration, err := m.getOrCreateMigration(ctx)
+	if err != nil {
+		return err
+	}
+	if migration.Status.Phase == extensionsv1alpha1.CRDMigrationSucceeded {
+		Log("storage version migration %s has succeeded, skip it", migration.Name)
+		return nil
+	}
+
+	crds, err := listMigratingCRDs(ctx, migration.Spec.CRDNames)
+	if err != nil {
+		return err
+	}
+	batchSize := int64(migration.Spec.BatchSize)
+	if batchSize <= 0 {
+		batchSize = defaultMigrationBatchSize
+	}
+
+	status := &extensionsv1alpha1.CRDMigrationStatus{
+		Phase:          extensionsv1alpha1.CRDMigrationRunning,
+		StartTimestamp: &metav1.Time{Time: time.Now()},
+		CRDs:           make([]extensionsv1alpha1.CRDStorageMigrationStatus, len(crds)),
+	}
+	for i := range crds {
+		status.CRDs[i] = extensionsv1alpha1.CRDStorageMigrationStatus{
+			Name:           crds[i].Name,
+			StorageVersion: getStorageVersion(&crds[i]),
+			StoredVersions: crds[i].Status.StoredVersions,
+			Phase:          extensionsv1alpha1.CRDMigrationPending,
+		}
+	}
+	report := func() error {
+		return updateMigrationStatus(ctx, migration.Name, status)
+	}
+	if err = report(); err != nil {
+		return err
+	}
+
+	var failedCRDs []string
+	for i := range crds {
+		if err = migrateCRD(ctx, &crds[i], &status.CRDs[i], batchSize, report); err != nil {
+			return err
+		}
+		if status.CRDs[i].Phase == extensionsv1alpha1.CRDMigrationFailed {
+			failedCRDs = append(failedCRDs, crds[i].Name)
+		}
+		if err = report(); err != nil {
+			return err
+		}
+	}
+
+	status.CompletionTimestamp = &metav1.Time{Time: time.Now()}
+	if len(failedCRDs) == 0 {
+		status.Phase = extensionsv1alpha1.CRDMigrationSucceeded
+		status.Message = fmt.Sprintf("%d CRDs are migrated to their storage versions", len(crds))
+	} else {
+		// the failure is reported in the CRDMigration object rather than failing the upgrade,
+		// the stored versions of the failed CRDs are kept and the migration can be retried.
+		status.Phase = extensionsv1alpha1.CRDMigrationFailed
+		status.Message = fmt.Sprintf("failed to migrate CRDs: %s, see the problems of them for details", strings.Join(failedCRDs, ", "))
+	}
+	Log("storage version migration %s: %s", migration.Name, status.Message)
+	return report()
+}
+
+func (m *MigrateStorageVersion) getOrCreateMigration(ctx *UpgradeContext) (*extensionsv1alpha1.CRDMigration, error) {
+	name := "kubeblocks-" + strings.ToLower(strings.ReplaceAll(ctx.Version, "+", "-"))
+	migration, err := ctx.KBClient.ExtensionsV1alpha1().CRDMigrations().Get(ctx, name, metav1.GetOptions{})
+	if err == nil || !apierrors.IsNotFound(err) {
+		return migration, err
+	}
+	Log("create storage version migration: %s", name)
+	migration = &extensionsv1alpha1.CRDMigration{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: extensionsv1alpha1.CRDMigrationSpec{
+			TargetVersion: ctx.Version,
+			BatchSize:     m.BatchSize,
+		},
+	}
+	return ctx.KBClient.ExtensionsV1alpha1().CRDMigrations().Create(ctx, migration, metav1.CreateOptions{})
+}
+
+func updateMigrationStatus(ctx *UpgradeContext, name string, status *extensionsv1alpha1.CRDMigrationStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		migration, err := ctx.KBClient.ExtensionsV1alpha1().CRDMigrations().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		status.DeepCopyInto(&migration.Status)
+		_, err = ctx.KBClient.ExtensionsV1alpha1().CRDMigrations().UpdateStatus(ctx, migration, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// listMigratingCRDs lists the CRDs with the given names, or all the CRDs of KubeBlocks if no name is given.
+func listMigratingCRDs(ctx *UpgradeContext, names []string) ([]apiextensionsv1.CustomResourceDefinition, error) {
+	crdList, err := ctx.CRDClient.ApiextensionsV1().CustomResourceDefinitions().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	nameSet := sets.NewString(names...)
+	var crds []apiextensionsv1.CustomResourceDefinition
+	for _, crd := range crdList.Items {
+		if nameSet.Len() > 0 && !nameSet.Has(crd.Name) {
+			continue
+		}
+		if nameSet.Len() == 0 && !strings.HasSuffix(crd.Spec.Group, kubeblocksAPIGroupSuffix) {
+			continue
+		}
+		crds = append(crds, crd)
+	}
+	return crds, nil
+}
+
+func migrateCRD(ctx *UpgradeContext,
+	crd *apiextensionsv1.CustomResourceDefinition,
+	crdStatus *extensionsv1alpha1.CRDStorageMigrationStatus,
+	batchSize int64,
+	report func() error) error {
+	if crdStatus.StorageVersion == "" {
+		addMigrationProblem(crdStatus, "", "", fmt.Errorf("no storage version found"))
+		crdStatus.Phase = extensionsv1alpha1.CRDMigrationFailed
+		return nil
+	}
+	if len(crd.Status.StoredVersions) == 1 && crd.Status.StoredVersions[0] == crdStatus.StorageVersion {
+		Log("CRD %s is stored in the storage version %s only, skip it", crd.Name, crdStatus.StorageVersion)
+		crdStatus.Phase = extensionsv1alpha1.CRDMigrationSucceeded
+		return nil
+	}
+
+	Log("migrate CRD %s to the storage version %s", crd.Name, crdStatus.StorageVersion)
+	crdStatus.Phase = extensionsv1alpha1.CRDMigrationRunning
+	if err := report(); err != nil {
+		return err
+	}
+
+	var reportErr error
+	gvr := schema.GroupVersionResource{Group: crd.Spec.Group, Version: crdStatus.StorageVersion, Resource: crd.Spec.Names.Plural}
+	err := listInBatches(ctx, gvr, batchSize, func(items []unstructured.Unstructured) error {
+		for i := range items {
+			crdStatus.Total++
+			if err := rewriteObject(ctx, gvr, &items[i]); err != nil {
+				crdStatus.Failed++
+				addMigrationProblem(crdStatus, getObjectKey(&items[i]), gvr.Version, err)
+				continue
+			}
+			crdStatus.Migrated++
+		}
+		reportErr = report()
+		return reportErr
+	})
+	if reportErr != nil {
+		return reportErr
+	}
+	if err != nil {
+		addMigrationProblem(crdStatus, "", gvr.Version, err)
+	}
+
+	// verify that the objects can be converted to all the other served versions
+	for _, version := range crd.Spec.Versions {
+		if !version.Served || version.Name == crdStatus.StorageVersion {
+			continue
+		}
+		versionGVR := gvr
+		versionGVR.Version = version.Name
+		if err = listInBatches(ctx, versionGVR, batchSize, func([]unstructured.Unstructured) error { return nil }); err != nil {
+			addMigrationProblem(crdStatus, "", version.Name, err)
+		}
+	}
+
+	if crdStatus.Failed > 0 || len(crdStatus.Problems) > 0 {
+		crdStatus.Phase = extensionsv1alpha1.CRDMigrationFailed
+		return nil
+	}
+	if err = trimStoredVersions(ctx, crd.Name, crdStatus.StorageVersion); err != nil {
+		addMigrationProblem(crdStatus, "", crdStatus.StorageVersion, err)
+		crdStatus.Phase = extensionsv1alpha1.CRDMigrationFailed
+		return nil
+	}
+	crdStatus.StoredVersions = []string{crdStatus.StorageVersion}
+	crdStatus.Phase = extensionsv1alpha1.CRDMigrationSucceeded
+	return nil
+}
+
+func listInBatches(ctx *UpgradeContext, gvr schema.GroupVersionResource, batchSize int64, fn func([]unstructured.Unstructured) error) error {
+	opts := metav1.ListOptions{Limit: batchSize}
+	for {
+		list, err := ctx.Resource(gvr).List(ctx, opts)
+		if err != nil {
+			return err
+		}
+		if err = fn(list.Items); err != nil {
+			return err
+		}
+		if list.GetContinue() == "" {
+			return nil
+		}
+		opts.Continue = list.GetContinue()
+	}
+}
+
+// rewriteObject updates the object without any change, which makes the API server store it in the storage version.
+func rewriteObject(ctx *UpgradeContext, gvr schema.GroupVersionResource, obj *unstructured.Unstructured) error {
+	_, err := ctx.Resource(gvr).Namespace(obj.GetNamespace()).Update(ctx, obj, metav1.UpdateOptions{})
+	// the object has been deleted, or it has been updated and stored in the storage version since listed.
+	if err != nil && (apierrors.IsNotFound(err) || apierrors.IsConflict(err)) {
+		return nil
+	}
+	return err
+}
+
+// trimStoredVersions drops the stale versions from the stored versions of the CRD.
+func trimStoredVersions(ctx *UpgradeContext, name, storageVersion string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		crd, err := ctx.CRDClient.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if version := getStorageVersion(crd); version != storageVersion {
+			return fmt.Errorf("the storage version of CRD %s is changed from %s to %s", name, storageVersion, version)
+		}
+		crd.Status.StoredVersions = []string{storageVersion}
+		_, err = ctx.CRDClient.ApiextensionsV1().CustomResourceDefinitions().UpdateStatus(ctx, crd, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+func getStorageVersion(crd *apiextensionsv1.CustomResourceDefinition) string {
+	for _, version := range crd.Spec.Versions {
+		if version.Storage {
+			return version.Name
+		}
+	}
+	return ""
+}
+
+func getObjectKey(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return obj.GetName()
+	}
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
+
+func addMigrationProblem(crdStatus *extensionsv1alpha1.CRDStorageMigrationStatus, objectKey, version string, err error) {
+	Log("failed to migrate CRD %s, object: %s, version: %s, error: %s", crdStatus.Name, objectKey, version, err.Error())
+	if len(crdStatus.Problems) >= maxMigrationProblems {
+		return
+	}
+	crdStatus.Problems = append(crdStatus.Problems, extensionsv1alpha1.CRDMigrationProblem{
+		ObjectKey: objectKey,
+		Version:   version,
+		Message:   err.Error(),
+	})
+}
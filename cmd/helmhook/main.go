@@ -39,6 +39,9 @@ var (
 	version    string
 	namespace  string
 	keepAddons bool
+
+	migrateStorageVersion bool
+	migrationBatchSize    int32
 )
 
 func setupFlags() {
@@ -46,6 +49,8 @@ func setupFlags() {
 	pflag.StringVar(&version, "version", "", "KubeBlocks version")
 	pflag.StringVar(&namespace, "namespace", "default", "The namespace scope for this request")
 	pflag.BoolVar(&keepAddons, "keep-addons", true, "Whether to allow addon updates. If set to true, the addons that KubeBlocks depends on will not be upgraded after KubeBlocks is upgrade")
+	pflag.BoolVar(&migrateStorageVersion, "migrate-storage-version", false, "Whether to migrate the existing objects of the KubeBlocks CRDs to their storage versions. It's expected to run after KubeBlocks is upgraded")
+	pflag.Int32Var(&migrationBatchSize, "migration-batch-size", 500, "The number of objects listed and rewritten in one batch when migrating the storage versions")
 
 	opts := zap.Options{
 		Development: true,
@@ -70,6 +75,13 @@ func main() {
 	hook.CheckErr(err)
 
 	upgradeContext := hook.NewUpgradeContext(ctx, config, version, crdPath, namespace)
+	if migrateStorageVersion {
+		hook.CheckErr(hook.NewUpgradeWorkflow().
+			AddStage(&hook.WaitForOperator{}).
+			AddStage(&hook.MigrateStorageVersion{BatchSize: migrationBatchSize}).
+			Do(upgradeContext))
+		return
+	}
 	hook.CheckErr(hook.NewUpgradeWorkflow().
 		WrapStage(hook.PrepareFor).
 		AddStage(&hook.StopOperator{}).
@@ -0,0 +1,200 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/loadgen"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+var (
+	clusterFile   string
+	opsFile       string
+	runID         string
+	namespace     string
+	clusters      int
+	opsPerCluster int
+	concurrency   int
+	metricsURL    string
+	timeout       time.Duration
+	pollInterval  time.Duration
+	cleanup       bool
+	output        string
+)
+
+func setupFlags() {
+	pflag.StringVar(&clusterFile, "cluster", "", "The YAML file of the template of the synthetic Clusters")
+	pflag.StringVar(&opsFile, "ops", "", "The YAML file of the template of the OpsRequests, the OpsRequests restart all the components if not set")
+	pflag.StringVar(&runID, "run-id", "", "The ID of the run, which prefixes the names of the objects created, defaults to loadgen-<timestamp>")
+	pflag.StringVarP(&namespace, "namespace", "n", "default", "The namespace to create the objects in")
+	pflag.IntVar(&clusters, "clusters", 10, "The number of the synthetic Clusters to create")
+	pflag.IntVar(&opsPerCluster, "ops-per-cluster", 1, "The number of the OpsRequests to create for each Cluster once it has been reconciled")
+	pflag.IntVar(&concurrency, "concurrency", loadgen.DefaultConcurrency, "The number of the objects created concurrently")
+	pflag.StringVar(&metricsURL, "metrics-url", "", `The URL of the metrics endpoint of the manager, e.g. "http://localhost:8080/metrics", `+
+		"the reconcile time and the memory of the manager are not measured if not set")
+	pflag.DurationVar(&timeout, "timeout", loadgen.DefaultTimeout, "The duration to wait for all the objects to be reconciled")
+	pflag.DurationVar(&pollInterval, "poll-interval", loadgen.DefaultPollInterval, "The interval to poll the objects, which is the resolution of the latencies")
+	pflag.BoolVar(&cleanup, "cleanup", true, "Delete the objects created after the run")
+	pflag.StringVarP(&output, "output", "o", outputTable, "The output format, one of table|json")
+	// the --kubeconfig flag of controller-runtime
+	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
+	pflag.Parse()
+}
+
+func main() {
+	setupFlags()
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	if err := run(ctx, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+// run generates the load against the API server of the kubeconfig, which is expected to be an envtest or kwok cluster
+// with the KubeBlocks manager running, and writes the report.
+func run(ctx context.Context, out io.Writer) error {
+	if clusterFile == "" {
+		return errors.New("the cluster file is required")
+	}
+	if output != outputTable && output != outputJSON {
+		return fmt.Errorf("unsupported output format: %s", output)
+	}
+	cluster := &appsv1alpha1.Cluster{}
+	if err := readObject(clusterFile, cluster); err != nil {
+		return err
+	}
+	var ops *appsv1alpha1.OpsRequest
+	if opsFile != "" {
+		ops = &appsv1alpha1.OpsRequest{}
+		if err := readObject(opsFile, ops); err != nil {
+			return err
+		}
+	}
+	if runID == "" {
+		runID = fmt.Sprintf("loadgen-%d", time.Now().Unix())
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(appsv1alpha1.AddToScheme(scheme))
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		return err
+	}
+	cli, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+
+	report, err := loadgen.Run(ctx, cli, loadgen.Options{
+		RunID:           runID,
+		Namespace:       namespace,
+		Clusters:        clusters,
+		OpsPerCluster:   opsPerCluster,
+		ClusterTemplate: cluster,
+		OpsTemplate:     ops,
+		Concurrency:     concurrency,
+		MetricsURL:      metricsURL,
+		Timeout:         timeout,
+		PollInterval:    pollInterval,
+	})
+	if cleanup {
+		// clean up with a fresh context, in case the run is interrupted
+		if cleanupErr := loadgen.Cleanup(context.Background(), cli, namespace, runID); cleanupErr != nil {
+			fmt.Fprintf(os.Stderr, "warning: clean up the objects of run %s failed: %v\n", runID, cleanupErr)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if output == outputJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	printTable(out, report)
+	return nil
+}
+
+func printTable(out io.Writer, report *loadgen.Report) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintf(w, "RUN\t%s\n", report.RunID)
+	fmt.Fprintf(w, "DURATION\t%.1fs\n", report.DurationSeconds)
+	fmt.Fprintf(w, "TIMED OUT\t%d\n", report.TimedOut)
+	if report.Memory != nil {
+		fmt.Fprintf(w, "HEAP IN USE\t%.1fMiB (peak %.1fMiB)\n", report.Memory.HeapInuseBytes/(1<<20), report.Memory.PeakHeapInuseBytes/(1<<20))
+		fmt.Fprintf(w, "RESIDENT\t%.1fMiB\n", report.Memory.ResidentBytes/(1<<20))
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "LATENCY\tCOUNT\tP50\tP90\tP99\tMAX")
+	printStats := func(name string, stats loadgen.LatencyStats) {
+		fmt.Fprintf(w, "%s\t%d\t%.3fs\t%.3fs\t%.3fs\t%.3fs\n", name, stats.Count, stats.P50, stats.P90, stats.P99, stats.Max)
+	}
+	printStats("cluster", report.ClusterLatency)
+	printStats("opsrequest", report.OpsLatency)
+	for _, controller := range sortedKeys(report.ReconcileTime) {
+		printStats("reconcile/"+controller, report.ReconcileTime[controller])
+	}
+}
+
+func sortedKeys(m map[string]loadgen.LatencyStats) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func readObject(file string, obj any) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return utilyaml.NewYAMLOrJSONDecoder(f, 4096).Decode(obj)
+}
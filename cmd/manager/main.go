@@ -24,6 +24,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"runtime"
 	"strings"
 	"time"
 
@@ -77,6 +78,11 @@ import (
 const (
 	appName = "kubeblocks"
 
+	// the sampling rates of the mutex and block profiles in the profiling mode: one of every 100 mutex contention events,
+	// and one blocking event per millisecond spent blocked on average, are reported.
+	mutexProfileFraction = 100
+	blockProfileRate     = int(time.Millisecond)
+
 	probeAddrFlagKey     flagName = "health-probe-bind-address"
 	metricsAddrFlagKey   flagName = "metrics-bind-address"
 	leaderElectFlagKey   flagName = "leader-elect"
@@ -91,6 +97,9 @@ const (
 	multiClusterKubeConfigFlagKey       flagName = "multi-cluster-kubeconfig"
 	multiClusterContextsFlagKey         flagName = "multi-cluster-contexts"
 	multiClusterContextsDisabledFlagKey flagName = "multi-cluster-contexts-disabled"
+
+	profileFlagKey            flagName = "profile"
+	profileBindAddressFlagKey flagName = "profile-bind-address"
 )
 
 var (
@@ -143,6 +152,19 @@ func init() {
 	viper.SetDefault(intctrlutil.FeatureGateEnableRuntimeMetrics, false)
 	viper.SetDefault(constant.CfgKBReconcileWorkers, 8)
 	viper.SetDefault(constant.FeatureGateIgnoreConfigTemplateDefaultMode, false)
+	viper.SetDefault(constant.CfgKeyCapacityPreCheck, true)
+	viper.SetDefault(constant.CfgKeyEndpointProbe, false)
+	viper.SetDefault(constant.CfgKeyVolumeAdoption, true)
+	viper.SetDefault(constant.CfgKeyNodePressureDetection, true)
+	viper.SetDefault(constant.CfgKeyNodePressureSwitchover, false)
+	viper.SetDefault(constant.CfgKeyWorkloadMigration, true)
+	viper.SetDefault(constant.CfgKeyRestoreProgressStep, 10)
+	viper.SetDefault(constant.CfgKeyMeteringInterval, time.Hour)
+	viper.SetDefault(constant.CfgKeyMeteringFilePath, "/var/log/kubeblocks/metering.jsonl")
+	viper.SetDefault(constant.CfgKeyMeteringKafkaTopic, "kubeblocks-metering")
+	viper.SetDefault(constant.CfgKeyOpsHistoryLimit, 3)
+	viper.SetDefault(constant.CfgKeyOpsCapacityPreCheck, false)
+	viper.SetDefault(constant.CfgKeyServiceNodePortRange, "30000-32767")
 }
 
 type flagName string
@@ -179,6 +201,11 @@ func setupFlags() {
 	flag.String(multiClusterContextsFlagKey.String(), "", "Kube contexts the manager will talk to.")
 	flag.String(multiClusterContextsDisabledFlagKey.String(), "", "Kube contexts that mark as disabled.")
 
+	flag.Bool(profileFlagKey.String(), false,
+		"Enable the profiling mode, which serves the pprof endpoints on the profile bind address, "+
+			"and the Go runtime metrics on the metric endpoint regardless of the feature gate.")
+	flag.String(profileBindAddressFlagKey.String(), "127.0.0.1:6060", "The address the pprof endpoints bind to in the profiling mode.")
+
 	flag.String(constant.ManagedNamespacesFlag, "",
 		"The namespaces that the operator will manage, multiple namespaces are separated by commas.")
 
@@ -256,6 +283,7 @@ func main() {
 	var (
 		metricsAddr                  string
 		probeAddr                    string
+		pprofAddr                    string
 		enableLeaderElection         bool
 		enableLeaderElectionID       string
 		multiClusterKubeConfig       string
@@ -296,13 +324,23 @@ func main() {
 	multiClusterContextsDisabled = viper.GetString(multiClusterContextsDisabledFlagKey.viperName())
 
 	setupLog.Info("golang runtime metrics.", "featureGate", intctrlutil.EnabledRuntimeMetrics())
+	metricsExtraHandlers := metrics.RuntimeMetric()
+	if viper.GetBool(profileFlagKey.viperName()) {
+		pprofAddr = viper.GetString(profileBindAddressFlagKey.viperName())
+		metricsExtraHandlers = metrics.RuntimeMetricHandlers()
+		// sample the contended mutexes and the blocking events, which are not profiled by default
+		runtime.SetMutexProfileFraction(mutexProfileFraction)
+		runtime.SetBlockProfileRate(blockProfileRate)
+		setupLog.Info("profiling mode enabled", "pprofBindAddress", pprofAddr)
+	}
 	mgr, err := ctrl.NewManager(intctrlutil.GeKubeRestConfig(), ctrl.Options{
 		Scheme: scheme,
 		Metrics: server.Options{
 			BindAddress:   metricsAddr,
-			ExtraHandlers: metrics.RuntimeMetric(),
+			ExtraHandlers: metricsExtraHandlers,
 		},
 		HealthProbeBindAddress: probeAddr,
+		PprofBindAddress:       pprofAddr,
 		LeaderElection:         enableLeaderElection,
 		// NOTES:
 		// following LeaderElectionID is generated via hash/fnv (FNV-1 and FNV-1a), in
@@ -430,6 +468,33 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = (&appscontrollers.OpsBatchReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("ops-batch-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "OpsBatch")
+			os.Exit(1)
+		}
+
+		if err = (&appscontrollers.ClusterOpsTriggerReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("cluster-ops-trigger-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ClusterOpsTrigger")
+			os.Exit(1)
+		}
+
+		if err = (&appscontrollers.CompliancePolicyReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("compliance-policy-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CompliancePolicy")
+			os.Exit(1)
+		}
+
 		if err = (&configuration.ConfigConstraintReconciler{
 			Client:   mgr.GetClient(),
 			Scheme:   mgr.GetScheme(),
@@ -483,6 +548,17 @@ func main() {
 			setupLog.Error(err, "unable to create controller", "controller", "BackupPolicyTemplate")
 			os.Exit(1)
 		}
+
+		if viper.GetString(constant.CfgKeyMeteringSinks) != "" {
+			if err = (&appscontrollers.ClusterMeteringReconciler{
+				Client:   mgr.GetClient(),
+				Scheme:   mgr.GetScheme(),
+				Recorder: mgr.GetEventRecorderFor("cluster-metering-controller"),
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "ClusterMetering")
+				os.Exit(1)
+			}
+		}
 	}
 
 	if viper.GetBool(extensionsFlagKey.viperName()) {
@@ -553,6 +629,11 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = (&appsv1alpha1.OpsDefinition{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OpsDefinition")
+			os.Exit(1)
+		}
+
 		if err = (&appsv1alpha1.OpsRequest{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "OpsRequest")
 			os.Exit(1)
@@ -572,6 +653,18 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "ConfigConstraint")
 			os.Exit(1)
 		}
+
+		dpv1alpha1.RegisterWebhookManager(mgr)
+
+		if err = (&dpv1alpha1.BackupPolicy{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "BackupPolicy")
+			os.Exit(1)
+		}
+
+		if err = (&dpv1alpha1.BackupSchedule{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "BackupSchedule")
+			os.Exit(1)
+		}
 	}
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
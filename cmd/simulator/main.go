@@ -0,0 +1,272 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/simulation"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+var (
+	clusterFile             string
+	opsFiles                []string
+	historyFiles            []string
+	scales                  []string
+	upgrades                []string
+	output                  string
+	defaultInstanceDuration time.Duration
+)
+
+func setupFlags() {
+	pflag.StringVar(&clusterFile, "cluster", "", "The YAML file of the Cluster to simulate")
+	pflag.StringSliceVar(&opsFiles, "ops", nil, "The YAML files of the proposed OpsRequests, applied in order")
+	pflag.StringSliceVar(&historyFiles, "history", nil, "The YAML files of the historical OpsRequests to estimate the rollout duration")
+	pflag.StringArrayVar(&scales, "scale", nil, "Scale a component to the replicas, in the form of <component>=<replicas>")
+	pflag.StringArrayVar(&upgrades, "upgrade", nil, "Upgrade a component to the service version, in the form of <component>=<serviceVersion>")
+	pflag.StringVarP(&output, "output", "o", outputTable, "The output format, one of table|json")
+	pflag.DurationVar(&defaultInstanceDuration, "default-instance-duration", simulation.DefaultInstanceDuration,
+		"The duration to roll out an instance if there is no historical OpsRequest of the type")
+	pflag.Parse()
+}
+
+func main() {
+	setupFlags()
+	if err := run(os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(out io.Writer) error {
+	if clusterFile == "" {
+		return errors.New("the cluster file is required")
+	}
+	if output != outputTable && output != outputJSON {
+		return fmt.Errorf("unsupported output format: %s", output)
+	}
+	cluster := &appsv1alpha1.Cluster{}
+	if err := readObject(clusterFile, cluster); err != nil {
+		return err
+	}
+	var proposed []appsv1alpha1.OpsRequest
+	for _, f := range opsFiles {
+		opsRequests, err := readOpsRequests(f)
+		if err != nil {
+			return err
+		}
+		proposed = append(proposed, opsRequests...)
+	}
+	opsRequests, err := buildOpsRequestsFromFlags(cluster.Name)
+	if err != nil {
+		return err
+	}
+	proposed = append(proposed, opsRequests...)
+	var historical []appsv1alpha1.OpsRequest
+	for _, f := range historyFiles {
+		opsRequests, err := readOpsRequests(f)
+		if err != nil {
+			return err
+		}
+		historical = append(historical, opsRequests...)
+	}
+
+	result, err := simulation.Simulate(cluster, proposed, simulation.NewHistory(historical),
+		simulation.Options{DefaultInstanceDuration: defaultInstanceDuration})
+	if err != nil {
+		return err
+	}
+	if output == outputJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+	printTable(out, result)
+	return nil
+}
+
+// buildOpsRequestsFromFlags builds the OpsRequests of the --scale and --upgrade flags.
+func buildOpsRequestsFromFlags(clusterName string) ([]appsv1alpha1.OpsRequest, error) {
+	var opsRequests []appsv1alpha1.OpsRequest
+	newOpsRequest := func(name string, opsType appsv1alpha1.OpsType) appsv1alpha1.OpsRequest {
+		return appsv1alpha1.OpsRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       appsv1alpha1.OpsRequestSpec{ClusterName: clusterName, Type: opsType},
+		}
+	}
+	for _, scale := range scales {
+		compName, value, err := parseComponentValue("scale", scale)
+		if err != nil {
+			return nil, err
+		}
+		replicas, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf(`invalid replicas of --scale "%s": %v`, scale, err)
+		}
+		ops := newOpsRequest("scale-"+compName, appsv1alpha1.HorizontalScalingType)
+		replicas32 := int32(replicas)
+		ops.Spec.HorizontalScalingList = []appsv1alpha1.HorizontalScaling{{
+			ComponentOps: appsv1alpha1.ComponentOps{ComponentName: compName},
+			Replicas:     &replicas32,
+		}}
+		opsRequests = append(opsRequests, ops)
+	}
+	for _, upgrade := range upgrades {
+		compName, serviceVersion, err := parseComponentValue("upgrade", upgrade)
+		if err != nil {
+			return nil, err
+		}
+		ops := newOpsRequest("upgrade-"+compName, appsv1alpha1.UpgradeType)
+		ops.Spec.Upgrade = &appsv1alpha1.Upgrade{
+			Components: []appsv1alpha1.UpgradeComponent{{
+				ComponentOps:   appsv1alpha1.ComponentOps{ComponentName: compName},
+				ServiceVersion: &serviceVersion,
+			}},
+		}
+		opsRequests = append(opsRequests, ops)
+	}
+	return opsRequests, nil
+}
+
+func parseComponentValue(flagName, value string) (string, string, error) {
+	compName, v, ok := strings.Cut(value, "=")
+	if !ok || compName == "" || v == "" {
+		return "", "", fmt.Errorf(`invalid --%s "%s", expect <component>=<value>`, flagName, value)
+	}
+	return compName, v, nil
+}
+
+func readObject(file string, obj any) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return utilyaml.NewYAMLOrJSONDecoder(f, 4096).Decode(obj)
+}
+
+// readOpsRequests reads the OpsRequests from a multi-document YAML file, a List of OpsRequests is also supported.
+func readOpsRequests(file string) ([]appsv1alpha1.OpsRequest, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var (
+		opsRequests []appsv1alpha1.OpsRequest
+		decoder     = utilyaml.NewYAMLOrJSONDecoder(f, 4096)
+	)
+	for {
+		var raw json.RawMessage
+		if err = decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return opsRequests, nil
+			}
+			return nil, fmt.Errorf("failed to read %s: %v", file, err)
+		}
+		if len(raw) == 0 || string(raw) == "null" {
+			continue
+		}
+		typeMeta := metav1.TypeMeta{}
+		if err = json.Unmarshal(raw, &typeMeta); err != nil {
+			return nil, err
+		}
+		if strings.HasSuffix(typeMeta.Kind, "List") {
+			list := appsv1alpha1.OpsRequestList{}
+			if err = json.Unmarshal(raw, &list); err != nil {
+				return nil, err
+			}
+			opsRequests = append(opsRequests, list.Items...)
+			continue
+		}
+		ops := appsv1alpha1.OpsRequest{}
+		if err = json.Unmarshal(raw, &ops); err != nil {
+			return nil, err
+		}
+		opsRequests = append(opsRequests, ops)
+	}
+}
+
+func printTable(out io.Writer, result *simulation.Result) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+
+	fmt.Fprintf(w, "CLUSTER: %s\n\n", result.Cluster)
+	fmt.Fprintln(w, "COMPONENT\tREPLICAS\tCPU REQUESTS\tMEMORY REQUESTS\tSTORAGE\tMIN AVAILABLE\tQUORUM KEPT")
+	for _, comp := range result.Components {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%t\n", comp.Name,
+			fmt.Sprintf("%d -> %d", comp.Before.Replicas, comp.After.Replicas),
+			formatChange(comp.Before.Requests.Cpu().String(), comp.After.Requests.Cpu().String()),
+			formatChange(comp.Before.Requests.Memory().String(), comp.After.Requests.Memory().String()),
+			formatChange(comp.Before.Storage.String(), comp.After.Storage.String()),
+			comp.Disruption.MinAvailable, comp.Disruption.QuorumKept)
+	}
+	fmt.Fprintf(w, "TOTAL\t%s\t%s\t%s\t%s\t\t\n",
+		fmt.Sprintf("%d -> %d", result.Before.Replicas, result.After.Replicas),
+		formatChange(result.Before.Requests.Cpu().String(), result.After.Requests.Cpu().String()),
+		formatChange(result.Before.Requests.Memory().String(), result.After.Requests.Memory().String()),
+		formatChange(result.Before.Storage.String(), result.After.Storage.String()))
+
+	fmt.Fprintln(w, "\nSTEP\tTYPE\tCOMPONENTS\tINSTANCES\tDURATION\tFROM HISTORY")
+	for i, step := range result.Steps {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\t%t\n", i+1, step.Type, strings.Join(step.Components, ","),
+			step.Instances, step.Duration, step.FromHistory)
+	}
+	fmt.Fprintf(w, "\nEXPECTED DURATION: %s\n", result.ExpectedDuration)
+
+	var warnings []string
+	for _, comp := range result.Components {
+		for _, warning := range comp.Disruption.Warnings {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", comp.Name, warning))
+		}
+	}
+	warnings = append(warnings, result.Warnings...)
+	if len(warnings) > 0 {
+		fmt.Fprintln(w, "\nWARNINGS:")
+		for _, warning := range warnings {
+			fmt.Fprintf(w, "- %s\n", warning)
+		}
+	}
+}
+
+func formatChange(before, after string) string {
+	if before == after {
+		return after
+	}
+	return fmt.Sprintf("%s -> %s", before, after)
+}
@@ -26,14 +26,21 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
@@ -68,7 +75,7 @@ import (
 // dataprotection get list and delete
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=backuppolicytemplates,verbs=get;list
 // +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backuppolicies,verbs=get;list;create;update;patch;delete;deletecollection
-// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups,verbs=get;list;delete;deletecollection
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups,verbs=get;list;watch;delete;deletecollection
 
 // ClusterReconciler reconciles a Cluster object
 type ClusterReconciler struct {
@@ -76,6 +83,8 @@ type ClusterReconciler struct {
 	Scheme          *runtime.Scheme
 	Recorder        record.EventRecorder
 	MultiClusterMgr multicluster.Manager
+
+	backoff *reconcileBackoff
 }
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -97,6 +106,9 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	// Init stage
 	planBuilder := newClusterPlanBuilder(reqCtx, r.Client)
 	if err := planBuilder.Init(); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.backoff.forget(req.NamespacedName)
+		}
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 	}
 
@@ -109,7 +121,13 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		}
 		c := planBuilder.(*clusterPlanBuilder)
 		sendWarningEventWithError(r.Recorder, c.transCtx.Cluster, corev1.EventTypeWarning, err)
-		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+		// report the backoff state in the conditions, so that users can check it without the access to the logs
+		delay, errBackoff := r.requeueWithBackoff(reqCtx, c.transCtx.Cluster, err)
+		if errBackoff != nil {
+			reqCtx.Log.Error(errBackoff, "failed to report the reconciliation backoff")
+		}
+		reqCtx.Log.Info(err.Error())
+		return intctrlutil.RequeueAfter(delay, reqCtx.Log, "reconciliation backoff")
 	}
 
 	// Build stage
@@ -137,12 +155,16 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			&clusterAssureMetaTransformer{},
 			// validate cd & cv's existence and availability
 			&clusterLoadRefResourcesTransformer{},
+			// adopt the volumes expanded out-of-band
+			&clusterVolumeAdoptionTransformer{},
 			// normalize the cluster and component API
 			&ClusterAPINormalizationTransformer{},
 			// placement replicas across data-plane k8s clusters
 			&clusterPlacementTransformer{multiClusterMgr: r.MultiClusterMgr},
 			// handle cluster services
 			&clusterServiceTransformer{},
+			// probe the external endpoints of cluster services
+			&clusterServiceEndpointTransformer{},
 			// handle the restore for cluster
 			&clusterRestoreTransformer{},
 			// create all cluster components objects
@@ -159,6 +181,8 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			&clusterSecretTransformer{},
 			// update cluster status
 			&clusterStatusTransformer{},
+			// track the release of the external resources of the deleted services
+			&clusterExternalResourceTransformer{},
 			// always safe to put your transformer below
 		).
 		Build()
@@ -172,13 +196,19 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	if errBuild != nil {
 		return requeueError(errBuild)
 	}
+	if err := r.clearReconcileBackoff(reqCtx, planBuilder.(*clusterPlanBuilder).transCtx.Cluster); err != nil {
+		return requeueError(err)
+	}
 	return intctrlutil.Reconciled()
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.backoff = newReconcileBackoff()
 	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
-		For(&appsv1alpha1.Cluster{}).
+		For(&appsv1alpha1.Cluster{}, builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: func(e event.UpdateEvent) bool { return !isReconcileBackoffUpdated(e) },
+		})).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: int(math.Ceil(viper.GetFloat64(constant.CfgKBReconcileWorkers) / 4)),
 		}).
@@ -187,5 +217,22 @@ func (r *ClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&corev1.Secret{}).  // cluster conn-credential secret
 		Owns(&dpv1alpha1.BackupPolicy{}).
 		Owns(&dpv1alpha1.BackupSchedule{}).
+		Watches(&workloads.InstanceSet{}, handler.EnqueueRequestsFromMapFunc(r.filterClusterResources)). // for the primary instance
+		Watches(&dpv1alpha1.Backup{}, handler.EnqueueRequestsFromMapFunc(r.filterClusterResources)).     // for the last backup time
 		Complete(r)
 }
+
+func (r *ClusterReconciler) filterClusterResources(_ context.Context, obj client.Object) []reconcile.Request {
+	clusterName, ok := obj.GetLabels()[constant.AppInstanceLabelKey]
+	if !ok || len(clusterName) == 0 {
+		return []reconcile.Request{}
+	}
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Namespace: obj.GetNamespace(),
+				Name:      clusterName,
+			},
+		},
+	}
+}
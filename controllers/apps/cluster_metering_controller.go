@@ -0,0 +1,168 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/metering"
+)
+
+// ClusterMeteringReconciler samples the resources of the Clusters periodically,
+// and emits the usage records of the Clusters to the metering sinks for the billing integration.
+type ClusterMeteringReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	sink         metering.Sink
+	meter        *metering.Meter
+	tenantLabels []string
+}
+
+func (r *ClusterMeteringReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("cluster", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	now := time.Now()
+	cluster := &appsv1alpha1.Cluster{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, cluster); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		// the usage of the deleted cluster is flushed, the records are dropped if they fail to be emitted
+		if records := r.meter.Flush(req.NamespacedName, now); len(records) > 0 {
+			if err = r.sink.Emit(reqCtx.Ctx, records); err != nil {
+				reqCtx.Log.Error(err, "failed to emit the usage records of the deleted cluster", "records", records)
+			}
+		}
+		return intctrlutil.Reconciled()
+	}
+
+	sample, err := r.sample(reqCtx.Ctx, cluster)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	records := r.meter.Observe(req.NamespacedName, metering.TenantLabels(cluster.Labels, r.tenantLabels), sample, now)
+	if len(records) > 0 {
+		if err = r.sink.Emit(reqCtx.Ctx, records); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "failed to emit the usage records")
+		}
+		r.meter.Emitted(req.NamespacedName, len(records))
+	}
+	return intctrlutil.RequeueAfter(r.meter.NextSample(req.NamespacedName, now), reqCtx.Log, "")
+}
+
+// sample takes the vCPUs requested by the pods, the capacity of the volumes and the size of the completed backups
+// of the cluster.
+func (r *ClusterMeteringReconciler) sample(ctx context.Context, cluster *appsv1alpha1.Cluster) (metering.Sample, error) {
+	var (
+		sample metering.Sample
+		inNS   = client.InNamespace(cluster.Namespace)
+		ml     = client.MatchingLabels(constant.GetClusterWellKnownLabels(cluster.Name))
+	)
+	pods := &corev1.PodList{}
+	if err := r.Client.List(ctx, pods, inNS, ml); err != nil {
+		return sample, err
+	}
+	for _, pod := range pods.Items {
+		// the pods not scheduled or terminated occupy no resources
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for _, container := range pod.Spec.Containers {
+			if cpu, ok := container.Resources.Requests[corev1.ResourceCPU]; ok {
+				sample.VCPUs += cpu.AsApproximateFloat64()
+			} else if cpu, ok = container.Resources.Limits[corev1.ResourceCPU]; ok {
+				sample.VCPUs += cpu.AsApproximateFloat64()
+			}
+		}
+	}
+
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	if err := r.Client.List(ctx, pvcs, inNS, ml); err != nil {
+		return sample, err
+	}
+	for _, pvc := range pvcs.Items {
+		if pvc.Status.Phase != corev1.ClaimBound {
+			continue
+		}
+		if storage, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+			sample.StorageGB += storage.AsApproximateFloat64() / metering.GigaByte
+		}
+	}
+
+	backups := &dpv1alpha1.BackupList{}
+	if err := r.Client.List(ctx, backups, inNS, getAppInstanceML(*cluster)); err != nil {
+		return sample, err
+	}
+	for _, backup := range backups.Items {
+		if backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted || backup.Status.TotalSize == "" {
+			continue
+		}
+		if size, err := resource.ParseQuantity(backup.Status.TotalSize); err == nil {
+			sample.BackupGB += size.AsApproximateFloat64() / metering.GigaByte
+		}
+	}
+	return sample, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterMeteringReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.tenantLabels = metering.ParseTenantLabels(viper.GetString(constant.CfgKeyMeteringTenantLabels))
+	sink, err := metering.NewSink(viper.GetString(constant.CfgKeyMeteringSinks), metering.Config{
+		TenantLabels:   r.tenantLabels,
+		FilePath:       viper.GetString(constant.CfgKeyMeteringFilePath),
+		KafkaRESTProxy: viper.GetString(constant.CfgKeyMeteringKafkaRESTProxy),
+		KafkaTopic:     viper.GetString(constant.CfgKeyMeteringKafkaTopic),
+	})
+	if err != nil {
+		return err
+	}
+	interval := viper.GetDuration(constant.CfgKeyMeteringInterval)
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	r.sink = sink
+	r.meter = metering.NewMeter(interval)
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("cluster-metering").
+		For(&appsv1alpha1.Cluster{}).
+		Complete(r)
+}
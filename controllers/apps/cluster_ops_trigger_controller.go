@@ -0,0 +1,233 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// opsTriggers maps the suffixes of the trigger annotations to the types of the OpsRequests they create.
+var opsTriggers = map[string]appsv1alpha1.OpsType{
+	"restart": appsv1alpha1.RestartType,
+	"start":   appsv1alpha1.StartType,
+	"stop":    appsv1alpha1.StopType,
+}
+
+// ClusterOpsTriggerReconciler converts the trigger annotations of the Clusters into OpsRequests, e.g.
+//
+//	ops.kubeblocks.io/trigger-restart: component=mysql,proxy;at=2024-05-01T00:00:00Z
+//
+// Each value of the annotation creates one OpsRequest, changing the value (typically the "at" token) triggers the
+// operation again. It lets the GitOps users trigger the day-2 operations from the same repository as the Clusters.
+type ClusterOpsTriggerReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clusters,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequests,verbs=get;list;watch;create
+
+func (r *ClusterOpsTriggerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("cluster", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	cluster := &appsv1alpha1.Cluster{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, cluster); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if !cluster.DeletionTimestamp.IsZero() {
+		return intctrlutil.Reconciled()
+	}
+
+	triggered := getTriggeredOps(cluster)
+	patch := client.MergeFrom(cluster.DeepCopy())
+	changed := false
+	// the annotations of the Cluster being created are taken as applied, the operations make no sense for it.
+	creating := cluster.Status.Phase == "" || cluster.Status.Phase == appsv1alpha1.CreatingClusterPhase
+	for _, name := range sortedOpsTriggers() {
+		key := constant.OpsTriggerAnnotationKeyPrefix + name
+		value, ok := cluster.Annotations[key]
+		if !ok {
+			continue
+		}
+		hash := hashOpsTrigger(value)
+		if triggered[name] == hash {
+			continue
+		}
+		if !creating {
+			opsRequest, err := buildTriggeredOpsRequest(cluster, name, value, hash)
+			if err != nil {
+				r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "InvalidOpsTrigger", "invalid annotation %s: %s", key, err.Error())
+				continue
+			}
+			if err = r.Client.Create(reqCtx.Ctx, opsRequest); err != nil && !apierrors.IsAlreadyExists(err) {
+				r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "OpsTriggerFailed",
+					"failed to create the OpsRequest for the annotation %s: %s", key, err.Error())
+				return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+			}
+			r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "OpsTriggered",
+				"OpsRequest %s is created by the annotation %s", opsRequest.Name, key)
+		}
+		triggered[name] = hash
+		changed = true
+	}
+	if !changed {
+		return intctrlutil.Reconciled()
+	}
+	triggeredValue, _ := json.Marshal(triggered)
+	cluster.Annotations[constant.TriggeredOpsAnnotationKey] = string(triggeredValue)
+	if err := r.Client.Patch(reqCtx.Ctx, cluster, patch); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.Reconciled()
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterOpsTriggerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("cluster-ops-trigger").
+		For(&appsv1alpha1.Cluster{}, builder.WithPredicates(predicate.AnnotationChangedPredicate{})).
+		Complete(r)
+}
+
+func sortedOpsTriggers() []string {
+	names := make([]string, 0, len(opsTriggers))
+	for name := range opsTriggers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// getTriggeredOps returns the hashes of the trigger annotations which have been applied, keyed by the trigger names.
+func getTriggeredOps(cluster *appsv1alpha1.Cluster) map[string]string {
+	triggered := map[string]string{}
+	if value, ok := cluster.Annotations[constant.TriggeredOpsAnnotationKey]; ok {
+		_ = json.Unmarshal([]byte(value), &triggered)
+	}
+	return triggered
+}
+
+func hashOpsTrigger(value string) string {
+	hash := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(hash[:])[:8]
+}
+
+// parseOpsTrigger parses the value of the trigger annotation, which consists of the "key=value" pairs separated by ";":
+//   - component: the names of the components or shardings separated by ",", all of them if not specified.
+//   - at: an arbitrary token, e.g. the timestamp, changing it triggers the operation again.
+func parseOpsTrigger(value string) ([]string, error) {
+	var compNames []string
+	for _, pair := range strings.Split(value, ";") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf(`"%s" is not in the format of "key=value"`, pair)
+		}
+		switch strings.TrimSpace(k) {
+		case "component":
+			for _, compName := range strings.Split(v, ",") {
+				if compName = strings.TrimSpace(compName); compName != "" {
+					compNames = append(compNames, compName)
+				}
+			}
+		case "at":
+		default:
+			return nil, fmt.Errorf(`unknown key "%s", the supported keys are "component" and "at"`, k)
+		}
+	}
+	return compNames, nil
+}
+
+// buildTriggeredOpsRequest builds the OpsRequest for the trigger annotation, it's named after the hash of the value
+// to avoid creating the OpsRequest repeatedly.
+func buildTriggeredOpsRequest(cluster *appsv1alpha1.Cluster, name, value, hash string) (*appsv1alpha1.OpsRequest, error) {
+	compNames, err := parseOpsTrigger(value)
+	if err != nil {
+		return nil, err
+	}
+	opsType := opsTriggers[name]
+	compOpsList := make([]appsv1alpha1.ComponentOps, 0, len(compNames))
+	for _, compName := range compNames {
+		compOpsList = append(compOpsList, appsv1alpha1.ComponentOps{ComponentName: compName})
+	}
+	if opsType == appsv1alpha1.RestartType && len(compOpsList) == 0 {
+		for _, compSpec := range cluster.Spec.ComponentSpecs {
+			compOpsList = append(compOpsList, appsv1alpha1.ComponentOps{ComponentName: compSpec.Name})
+		}
+		for _, shardingSpec := range cluster.Spec.ShardingSpecs {
+			compOpsList = append(compOpsList, appsv1alpha1.ComponentOps{ComponentName: shardingSpec.Name})
+		}
+	}
+	opsRequest := &appsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s-%s", cluster.Name, name, hash),
+			Namespace: cluster.Namespace,
+			Annotations: map[string]string{
+				constant.OpsTriggeredByAnnotationKey: fmt.Sprintf("%s%s: %s", constant.OpsTriggerAnnotationKeyPrefix, name, value),
+			},
+		},
+		Spec: appsv1alpha1.OpsRequestSpec{
+			ClusterName: cluster.Name,
+			Type:        opsType,
+		},
+	}
+	if len(compOpsList) == 0 {
+		return opsRequest, nil
+	}
+	switch opsType {
+	case appsv1alpha1.RestartType:
+		opsRequest.Spec.RestartList = compOpsList
+	case appsv1alpha1.StopType:
+		opsRequest.Spec.StopList = compOpsList
+	case appsv1alpha1.StartType:
+		opsRequest.Spec.StartList = compOpsList
+	}
+	return opsRequest, nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestParseOpsTrigger(t *testing.T) {
+	compNames, err := parseOpsTrigger("component=mysql, proxy;at=2024-05-01T00:00:00Z")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mysql", "proxy"}, compNames)
+
+	compNames, err = parseOpsTrigger("at=2024-05-01T00:00:00Z;")
+	assert.NoError(t, err)
+	assert.Empty(t, compNames)
+
+	_, err = parseOpsTrigger("mysql")
+	assert.Error(t, err)
+	_, err = parseOpsTrigger("components=mysql")
+	assert.Error(t, err)
+}
+
+func TestBuildTriggeredOpsRequest(t *testing.T) {
+	cluster := &appsv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "mycluster", Namespace: "default"},
+		Spec: appsv1alpha1.ClusterSpec{
+			ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{{Name: "mysql"}, {Name: "proxy"}},
+			ShardingSpecs:  []appsv1alpha1.ShardingSpec{{Name: "shard"}},
+		},
+	}
+	value := "component=mysql;at=1"
+	hash := hashOpsTrigger(value)
+	assert.Len(t, hash, 8)
+	assert.NotEqual(t, hash, hashOpsTrigger("component=mysql;at=2"))
+
+	opsRequest, err := buildTriggeredOpsRequest(cluster, "restart", value, hash)
+	assert.NoError(t, err)
+	assert.Equal(t, "mycluster-restart-"+hash, opsRequest.Name)
+	assert.Equal(t, "default", opsRequest.Namespace)
+	assert.Equal(t, "ops.kubeblocks.io/trigger-restart: component=mysql;at=1", opsRequest.Annotations[constant.OpsTriggeredByAnnotationKey])
+	assert.Equal(t, "mycluster", opsRequest.Spec.ClusterName)
+	assert.Equal(t, appsv1alpha1.RestartType, opsRequest.Spec.Type)
+	assert.Equal(t, []appsv1alpha1.ComponentOps{{ComponentName: "mysql"}}, opsRequest.Spec.RestartList)
+
+	// all the components and shardings are restarted if not specified
+	opsRequest, err = buildTriggeredOpsRequest(cluster, "restart", "at=1", hash)
+	assert.NoError(t, err)
+	assert.Equal(t, []appsv1alpha1.ComponentOps{{ComponentName: "mysql"}, {ComponentName: "proxy"}, {ComponentName: "shard"}}, opsRequest.Spec.RestartList)
+
+	// the whole cluster is stopped if no component is specified
+	opsRequest, err = buildTriggeredOpsRequest(cluster, "stop", "at=1", hash)
+	assert.NoError(t, err)
+	assert.Equal(t, appsv1alpha1.StopType, opsRequest.Spec.Type)
+	assert.Empty(t, opsRequest.Spec.StopList)
+
+	opsRequest, err = buildTriggeredOpsRequest(cluster, "start", "component=proxy", hash)
+	assert.NoError(t, err)
+	assert.Equal(t, []appsv1alpha1.ComponentOps{{ComponentName: "proxy"}}, opsRequest.Spec.StartList)
+
+	_, err = buildTriggeredOpsRequest(cluster, "start", "proxy", hash)
+	assert.Error(t, err)
+}
+
+func TestGetTriggeredOps(t *testing.T) {
+	cluster := &appsv1alpha1.Cluster{}
+	assert.Empty(t, getTriggeredOps(cluster))
+
+	cluster.Annotations = map[string]string{constant.TriggeredOpsAnnotationKey: `{"restart":"0a1b2c3d"}`}
+	assert.Equal(t, map[string]string{"restart": "0a1b2c3d"}, getTriggeredOps(cluster))
+
+	// the malformed records are discarded
+	cluster.Annotations[constant.TriggeredOpsAnnotationKey] = "restart"
+	assert.Empty(t, getTriggeredOps(cluster))
+}
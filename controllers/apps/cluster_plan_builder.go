@@ -30,7 +30,6 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -43,6 +42,7 @@ import (
 	extensionsv1alpha1 "github.com/apecloud/kubeblocks/apis/extensions/v1alpha1"
 	storagev1alpha1 "github.com/apecloud/kubeblocks/apis/storage/v1alpha1"
 	workloadsv1alpha1 "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/conditions"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
@@ -55,6 +55,9 @@ const (
 	clusterWeight
 )
 
+// clusterControllerName is the name of the Cluster controller in the metrics of the status writes.
+const clusterControllerName = "cluster"
+
 // clusterTransformContext a graph.TransformContext implementation for Cluster reconciliation
 type clusterTransformContext struct {
 	context.Context
@@ -155,7 +158,7 @@ func (c *clusterPlanBuilder) Build() (graph.Plan, error) {
 		if c.transCtx.Cluster.IsDeleting() {
 			return
 		}
-		preCheckCondition := meta.FindStatusCondition(c.transCtx.Cluster.Status.Conditions, appsv1alpha1.ConditionTypeProvisioningStarted)
+		preCheckCondition := conditions.Get(c.transCtx.Cluster.Status.Conditions, appsv1alpha1.ConditionTypeProvisioningStarted)
 		if preCheckCondition == nil {
 			// this should not happen
 			return
@@ -216,8 +219,8 @@ func (p *clusterPlan) Execute() error {
 func (p *clusterPlan) handlePlanExecutionError(err error) error {
 	clusterCopy := p.transCtx.OrigCluster.DeepCopy()
 	condition := newFailedApplyResourcesCondition(err)
-	meta.SetStatusCondition(&clusterCopy.Status.Conditions, condition)
-	return p.cli.Status().Patch(p.transCtx.Context, clusterCopy, client.MergeFrom(p.transCtx.OrigCluster))
+	conditions.Set(&clusterCopy.Status.Conditions, condition)
+	return model.PatchStatus(p.transCtx.Context, p.cli, clusterControllerName, p.transCtx.OrigCluster, clusterCopy)
 }
 
 // Do the real works
@@ -362,8 +365,7 @@ func (c *clusterPlanBuilder) reconcileDeleteObject(ctx context.Context, node *mo
 }
 
 func (c *clusterPlanBuilder) reconcileStatusObject(ctx context.Context, node *model.ObjectVertex) error {
-	patch := client.MergeFrom(node.OriObj)
-	if err := c.cli.Status().Patch(ctx, node.Obj, patch, clientOption(node)); err != nil {
+	if err := model.PatchStatus(ctx, c.cli, clusterControllerName, node.OriObj, node.Obj, clientOption(node)); err != nil {
 		return err
 	}
 	// handle condition and phase changing triggered events
@@ -381,7 +383,7 @@ func (c *clusterPlanBuilder) reconcileNoopObject(ctx context.Context, node *mode
 
 func (c *clusterPlanBuilder) emitConditionUpdatingEvent(oldConditions, newConditions []metav1.Condition) {
 	for _, newCondition := range newConditions {
-		oldCondition := meta.FindStatusCondition(oldConditions, newCondition.Type)
+		oldCondition := conditions.Get(oldConditions, newCondition.Type)
 		// filtered in cluster creation
 		if oldCondition == nil && newCondition.Status == metav1.ConditionFalse {
 			return
@@ -0,0 +1,147 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/conditions"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	reconcileBackoffBaseDelay = time.Second
+	reconcileBackoffMaxDelay  = 5 * time.Minute
+)
+
+// reconcileBackoff tracks the consecutive reconciliation failures of the clusters.
+// Like the rate limiter of the work queue, it's kept in memory and starts over once the controller restarts.
+type reconcileBackoff struct {
+	mu    sync.Mutex
+	items map[types.NamespacedName]*reconcileBackoffItem
+}
+
+type reconcileBackoffItem struct {
+	attempts int32
+	// retryToken is the value of the force-retry annotation when the failures started to be counted.
+	retryToken string
+}
+
+func newReconcileBackoff() *reconcileBackoff {
+	return &reconcileBackoff{
+		items: map[types.NamespacedName]*reconcileBackoffItem{},
+	}
+}
+
+// next records a failure of the cluster, and returns the attempts and the delay before the next retry.
+// The attempts are reset if the force-retry annotation of the cluster is changed.
+func (b *reconcileBackoff) next(key types.NamespacedName, retryToken string) (int32, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	item, ok := b.items[key]
+	if !ok || item.retryToken != retryToken {
+		item = &reconcileBackoffItem{retryToken: retryToken}
+		b.items[key] = item
+	}
+	item.attempts++
+	delay := reconcileBackoffBaseDelay
+	for i := int32(1); i < item.attempts && delay < reconcileBackoffMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > reconcileBackoffMaxDelay {
+		delay = reconcileBackoffMaxDelay
+	}
+	return item.attempts, delay
+}
+
+// forget stops tracking the failures of the cluster.
+func (b *reconcileBackoff) forget(key types.NamespacedName) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.items, key)
+}
+
+// newReconcileBackoffCondition creates the condition which reports the backoff state of the cluster reconciliation.
+func newReconcileBackoffCondition(cluster *appsv1alpha1.Cluster, attempts int32, nextRetryTime time.Time, err error) metav1.Condition {
+	return metav1.Condition{
+		Type:               appsv1alpha1.ConditionTypeReconcileBackoff,
+		ObservedGeneration: cluster.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             getConditionReasonWithError(ReasonReconcileFailed, err),
+		Message: fmt.Sprintf("Reconciliation failed %d time(s), next retry at %s, set the annotation %s to retry immediately: %s",
+			attempts, nextRetryTime.UTC().Format(time.RFC3339), constant.ForceRetryAnnotationKey, err.Error()),
+	}
+}
+
+// requeueWithBackoff reports the backoff state in the cluster conditions and requeues the cluster after the backoff delay.
+func (r *ClusterReconciler) requeueWithBackoff(reqCtx intctrlutil.RequestCtx, cluster *appsv1alpha1.Cluster, err error) (time.Duration, error) {
+	attempts, delay := r.backoff.next(reqCtx.Req.NamespacedName, cluster.Annotations[constant.ForceRetryAnnotationKey])
+	latest := &appsv1alpha1.Cluster{}
+	if err1 := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, latest); err1 != nil {
+		return delay, client.IgnoreNotFound(err1)
+	}
+	patch := client.MergeFrom(latest.DeepCopy())
+	conditions.Set(&latest.Status.Conditions, newReconcileBackoffCondition(latest, attempts, time.Now().Add(delay), err))
+	return delay, r.Client.Status().Patch(reqCtx.Ctx, latest, patch)
+}
+
+// clearReconcileBackoff removes the backoff state of the cluster once it's reconciled successfully.
+func (r *ClusterReconciler) clearReconcileBackoff(reqCtx intctrlutil.RequestCtx, cluster *appsv1alpha1.Cluster) error {
+	r.backoff.forget(reqCtx.Req.NamespacedName)
+	if conditions.Get(cluster.Status.Conditions, appsv1alpha1.ConditionTypeReconcileBackoff) == nil {
+		return nil
+	}
+	patch := client.MergeFrom(cluster.DeepCopy())
+	conditions.Remove(&cluster.Status.Conditions, appsv1alpha1.ConditionTypeReconcileBackoff)
+	return client.IgnoreNotFound(r.Client.Status().Patch(reqCtx.Ctx, cluster, patch))
+}
+
+// isReconcileBackoffUpdated checks whether the update of the cluster only changes the backoff condition,
+// the cluster shouldn't be reconciled again for that before the backoff delay elapses.
+func isReconcileBackoffUpdated(e event.UpdateEvent) bool {
+	oldCluster, ok1 := e.ObjectOld.(*appsv1alpha1.Cluster)
+	newCluster, ok2 := e.ObjectNew.(*appsv1alpha1.Cluster)
+	if !ok1 || !ok2 {
+		return false
+	}
+	oldBackoff := conditions.Get(oldCluster.Status.Conditions, appsv1alpha1.ConditionTypeReconcileBackoff)
+	newBackoff := conditions.Get(newCluster.Status.Conditions, appsv1alpha1.ConditionTypeReconcileBackoff)
+	if newBackoff == nil || reflect.DeepEqual(oldBackoff, newBackoff) {
+		return false
+	}
+	strip := func(cluster *appsv1alpha1.Cluster) *appsv1alpha1.Cluster {
+		cluster = cluster.DeepCopy()
+		cluster.ResourceVersion = ""
+		cluster.ManagedFields = nil
+		conditions.Remove(&cluster.Status.Conditions, appsv1alpha1.ConditionTypeReconcileBackoff)
+		return cluster
+	}
+	return reflect.DeepEqual(strip(oldCluster), strip(newCluster))
+}
@@ -0,0 +1,80 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+func TestReconcileBackoff(t *testing.T) {
+	b := newReconcileBackoff()
+	key := types.NamespacedName{Namespace: "default", Name: "test"}
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second}
+	for i, d := range expected {
+		attempts, delay := b.next(key, "")
+		assert.Equal(t, int32(i+1), attempts)
+		assert.Equal(t, d, delay)
+	}
+
+	// the delay is capped
+	for i := 0; i < 20; i++ {
+		b.next(key, "")
+	}
+	_, delay := b.next(key, "")
+	assert.Equal(t, reconcileBackoffMaxDelay, delay)
+
+	// the force-retry annotation resets the attempts
+	attempts, delay := b.next(key, "retry-1")
+	assert.Equal(t, int32(1), attempts)
+	assert.Equal(t, reconcileBackoffBaseDelay, delay)
+
+	b.forget(key)
+	attempts, _ = b.next(key, "retry-1")
+	assert.Equal(t, int32(1), attempts)
+}
+
+func TestIsReconcileBackoffUpdated(t *testing.T) {
+	oldCluster := &appsv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test", ResourceVersion: "1"},
+	}
+	backoffCluster := oldCluster.DeepCopy()
+	backoffCluster.ResourceVersion = "2"
+	backoffCluster.Status.Conditions = []metav1.Condition{
+		{Type: appsv1alpha1.ConditionTypeReconcileBackoff, Status: metav1.ConditionTrue, Reason: ReasonReconcileFailed, Message: "attempt 1"},
+	}
+	assert.True(t, isReconcileBackoffUpdated(event.UpdateEvent{ObjectOld: oldCluster, ObjectNew: backoffCluster}))
+
+	// the other changes should be reconciled
+	specChanged := backoffCluster.DeepCopy()
+	specChanged.Annotations = map[string]string{"foo": "bar"}
+	assert.False(t, isReconcileBackoffUpdated(event.UpdateEvent{ObjectOld: oldCluster, ObjectNew: specChanged}))
+
+	// the removal of the backoff condition should be reconciled
+	assert.False(t, isReconcileBackoffUpdated(event.UpdateEvent{ObjectOld: backoffCluster, ObjectNew: oldCluster}))
+}
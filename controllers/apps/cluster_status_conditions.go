@@ -24,32 +24,51 @@ import (
 
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
-	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/conditions"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/kberrors"
 )
 
 const (
-	ReasonPreCheckSucceed       = "PreCheckSucceed"       // ReasonPreCheckSucceed preChecks succeeded for provisioning started
-	ReasonPreCheckFailed        = "PreCheckFailed"        // ReasonPreCheckFailed preChecks failed for provisioning started
-	ReasonApplyResourcesFailed  = "ApplyResourcesFailed"  // ReasonApplyResourcesFailed applies resources failed to create or change the cluster
-	ReasonApplyResourcesSucceed = "ApplyResourcesSucceed" // ReasonApplyResourcesSucceed applies resources succeeded to create or change the cluster
-	ReasonReplicasNotReady      = "ReplicasNotReady"      // ReasonReplicasNotReady the pods of components are not ready
-	ReasonAllReplicasReady      = "AllReplicasReady"      // ReasonAllReplicasReady the pods of components are ready
-	ReasonComponentsNotReady    = "ComponentsNotReady"    // ReasonComponentsNotReady the components of cluster are not ready
-	ReasonClusterReady          = "ClusterReady"          // ReasonClusterReady the components of cluster are ready, the component phase is running
+	ReasonPreCheckSucceed           = "PreCheckSucceed"           // ReasonPreCheckSucceed preChecks succeeded for provisioning started
+	ReasonPreCheckFailed            = "PreCheckFailed"            // ReasonPreCheckFailed preChecks failed for provisioning started
+	ReasonApplyResourcesFailed      = "ApplyResourcesFailed"      // ReasonApplyResourcesFailed applies resources failed to create or change the cluster
+	ReasonApplyResourcesSucceed     = "ApplyResourcesSucceed"     // ReasonApplyResourcesSucceed applies resources succeeded to create or change the cluster
+	ReasonReplicasNotReady          = "ReplicasNotReady"          // ReasonReplicasNotReady the pods of components are not ready
+	ReasonAllReplicasReady          = "AllReplicasReady"          // ReasonAllReplicasReady the pods of components are ready
+	ReasonComponentsNotReady        = "ComponentsNotReady"        // ReasonComponentsNotReady the components of cluster are not ready
+	ReasonClusterReady              = "ClusterReady"              // ReasonClusterReady the components of cluster are ready, the component phase is running
+	ReasonInsufficientCapacity      = "InsufficientCapacity"      // ReasonInsufficientCapacity the nodes can not hold the pods of component
+	ReasonEndpointsReachable        = "EndpointsReachable"        // ReasonEndpointsReachable the external endpoints of the services are reachable
+	ReasonEndpointsUnreachable      = "EndpointsUnreachable"      // ReasonEndpointsUnreachable some external endpoints of the services are unreachable
+	ReasonVolumesAdopted            = "VolumesAdopted"            // ReasonVolumesAdopted the storage of the PVCs expanded out-of-band is adopted
+	ReasonVolumesExpandedOutOfBand  = "VolumesExpandedOutOfBand"  // ReasonVolumesExpandedOutOfBand the PVCs are expanded out-of-band to different sizes
+	ReasonNodePressure              = "NodePressure"              // ReasonNodePressure some nodes hosting the pods of component are under pressure or not ready
+	ReasonNodePressureResolved      = "NodePressureResolved"      // ReasonNodePressureResolved the nodes hosting the pods of component are healthy again
+	ReasonNodePressureSwitchover    = "NodePressureSwitchover"    // ReasonNodePressureSwitchover the leader is switched over to a healthy node
+	ReasonExternalResourcesLeaked   = "ExternalResourcesLeaked"   // ReasonExternalResourcesLeaked the external resources of the deleted services are not released in time
+	ReasonExternalResourcesReleased = "ExternalResourcesReleased" // ReasonExternalResourcesReleased the leaked external resources of the deleted services are released
+	ReasonSchedulingPolicyPending   = "SchedulingPolicyPending"   // ReasonSchedulingPolicyPending the changes of the scheduling policy are pending a restart
+	ReasonSchedulingPolicyApplied   = "SchedulingPolicyApplied"   // ReasonSchedulingPolicyApplied the pending changes of the scheduling policy are applied
+	ReasonWorkloadMigrated          = "WorkloadMigrated"          // ReasonWorkloadMigrated the legacy workload is migrated to InstanceSet
+	ReasonWorkloadMigrationBlocked  = "WorkloadMigrationBlocked"  // ReasonWorkloadMigrationBlocked the preflight check of the workload migration finds some blockers
+	ReasonWorkloadMigrationDisabled = "WorkloadMigrationDisabled" // ReasonWorkloadMigrationDisabled the workload migration is disabled by the feature gate
+	ReasonReconcileFailed           = "ReconcileFailed"           // ReasonReconcileFailed the reconciliation of cluster fails and is retried with backoff
+	ReasonImageDigestDrifted        = "ImageDigestDrifted"        // ReasonImageDigestDrifted some pods run images whose digests differ from the pinned ones
+	ReasonImageDigestDriftResolved  = "ImageDigestDriftResolved"  // ReasonImageDigestDriftResolved the pods run the images of the pinned digests again
 )
 
-func setProvisioningStartedCondition(conditions *[]metav1.Condition, clusterName string, clusterGeneration int64, err error) {
+func setProvisioningStartedCondition(clusterConditions *[]metav1.Condition, clusterName string, clusterGeneration int64, err error) {
 	var condition metav1.Condition
 	if err == nil {
 		condition = newProvisioningStartedCondition(clusterName, clusterGeneration)
 	} else {
 		condition = newFailedProvisioningStartedCondition(err)
 	}
-	meta.SetStatusCondition(conditions, condition)
+	conditions.Set(clusterConditions, condition)
 }
 
 // newProvisioningStartedCondition creates the provisioning started condition in cluster conditions.
@@ -71,7 +90,7 @@ func getConditionReasonWithError(defaultReason string, err error) string {
 	if controllerErr != nil {
 		defaultReason = string(controllerErr.Type)
 	}
-	return defaultReason
+	return kberrors.ReasonOrDefault(err, defaultReason)
 }
 
 // newApplyResourcesCondition creates a condition when applied resources succeed.
@@ -84,13 +103,13 @@ func newFailedProvisioningStartedCondition(err error) metav1.Condition {
 	}
 }
 
-func setApplyResourceCondition(conditions *[]metav1.Condition, clusterGeneration int64, err error) {
+func setApplyResourceCondition(clusterConditions *[]metav1.Condition, clusterGeneration int64, err error) {
 	condition := newApplyResourcesCondition(clusterGeneration)
 	// ignore requeue error
 	if err != nil && !intctrlutil.IsRequeueError(err) {
 		condition = newFailedApplyResourcesCondition(err)
 	}
-	meta.SetStatusCondition(conditions, condition)
+	conditions.Set(clusterConditions, condition)
 }
 
 // newApplyResourcesCondition creates a condition when applied resources succeed.
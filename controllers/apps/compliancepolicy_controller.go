@@ -0,0 +1,285 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	reasonCompliant    = "Compliant"
+	reasonNonCompliant = "NonCompliant"
+)
+
+// CompliancePolicyReconciler reconciles a CompliancePolicy object
+type CompliancePolicyReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=compliancepolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=compliancepolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=compliancepolicies/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.14.4/pkg/reconcile
+func (r *CompliancePolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("compliancePolicy", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	policy := &appsv1alpha1.CompliancePolicy{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, policy); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	res, err := intctrlutil.HandleCRDeletion(reqCtx, r, policy, compliancePolicyFinalizerName, r.deletionHandler(reqCtx, policy))
+	if res != nil {
+		return *res, err
+	}
+
+	// the reconciliation is triggered by the status update of the last evaluation, wait for the next one.
+	interval := policy.Spec.GetEvaluationInterval()
+	if policy.Status.ObservedGeneration == policy.Generation {
+		if remaining := time.Until(policy.Status.LastEvaluationTime.Add(interval)); remaining > 0 {
+			return intctrlutil.RequeueAfter(remaining, reqCtx.Log, "waiting for the next evaluation")
+		}
+	}
+
+	patch := client.MergeFrom(policy.DeepCopy())
+	if err = r.evaluatePolicy(reqCtx, policy); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if err = r.Client.Status().Patch(reqCtx.Ctx, policy, patch); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.RequeueAfter(interval, reqCtx.Log, "waiting for the next evaluation")
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CompliancePolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&appsv1alpha1.CompliancePolicy{}).
+		Complete(r)
+}
+
+// deletionHandler refreshes the Compliant condition of the Clusters reported by the policy, without its violations.
+func (r *CompliancePolicyReconciler) deletionHandler(reqCtx intctrlutil.RequestCtx, policy *appsv1alpha1.CompliancePolicy) func() (*ctrl.Result, error) {
+	return func() (*ctrl.Result, error) {
+		otherPolicies, err := r.listOtherPolicies(reqCtx, policy.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, report := range policy.Status.Reports {
+			cluster := &appsv1alpha1.Cluster{}
+			if err = r.Client.Get(reqCtx.Ctx, client.ObjectKey{Namespace: report.Namespace, Name: report.ClusterName}, cluster); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			if err = r.updateCompliantCondition(reqCtx, cluster, collectClusterViolations(otherPolicies, cluster)); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+}
+
+// evaluatePolicy evaluates the selected Clusters against the policy, reports the violations in the status of the policy
+// and refreshes the Compliant condition of the Clusters.
+func (r *CompliancePolicyReconciler) evaluatePolicy(reqCtx intctrlutil.RequestCtx, policy *appsv1alpha1.CompliancePolicy) error {
+	status := &policy.Status
+	status.ObservedGeneration = policy.Generation
+	status.LastEvaluationTime = metav1.Now()
+
+	clusterSelector, err := buildComplianceSelector(policy.Spec.ClusterSelector)
+	if err != nil {
+		status.Message = fmt.Sprintf("invalid cluster selector: %s", err.Error())
+		return nil
+	}
+	var haSelector labels.Selector
+	if policy.Spec.BuiltinRules.HighAvailability != nil {
+		if haSelector, err = buildComplianceSelector(policy.Spec.BuiltinRules.HighAvailability.ClusterSelector); err != nil {
+			status.Message = fmt.Sprintf("invalid cluster selector of the high availability rule: %s", err.Error())
+			return nil
+		}
+	}
+	compiled, errs := compileCompliancePolicy(policy.Spec)
+	status.Message = formatComplianceErrors(errs)
+	if len(errs) > 0 {
+		r.Recorder.Eventf(policy, corev1.EventTypeWarning, "InvalidRules", "some rules are skipped: %s", status.Message)
+	}
+
+	var clusters []*appsv1alpha1.Cluster
+	if err = intctrlutil.ForEachObject(reqCtx.Ctx, r.Client, &appsv1alpha1.ClusterList{}, func(obj client.Object) error {
+		clusters = append(clusters, obj.(*appsv1alpha1.Cluster).DeepCopy())
+		return nil
+	}, client.MatchingLabelsSelector{Selector: clusterSelector}); err != nil {
+		return err
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		if clusters[i].Namespace != clusters[j].Namespace {
+			return clusters[i].Namespace < clusters[j].Namespace
+		}
+		return clusters[i].Name < clusters[j].Name
+	})
+	otherPolicies, err := r.listOtherPolicies(reqCtx, policy.Name)
+	if err != nil {
+		return err
+	}
+
+	var reports []appsv1alpha1.ClusterComplianceReport
+	for _, cluster := range clusters {
+		if !cluster.DeletionTimestamp.IsZero() {
+			continue
+		}
+		comps, err := component.ListClusterComponents(reqCtx.Ctx, r.Client, cluster)
+		if err != nil {
+			return err
+		}
+		haRequired := haSelector != nil && haSelector.Matches(labels.Set(cluster.Labels))
+		violations, err := compiled.evaluate(cluster, comps, haRequired)
+		if err != nil {
+			return err
+		}
+		if len(violations) > 0 {
+			reports = append(reports, appsv1alpha1.ClusterComplianceReport{
+				Namespace:   cluster.Namespace,
+				ClusterName: cluster.Name,
+				Violations:  violations,
+			})
+		}
+
+		clusterViolations := collectClusterViolations(otherPolicies, cluster)
+		if len(violations) > 0 {
+			clusterViolations[policy.Name] = violations
+		}
+		if err = r.updateCompliantCondition(reqCtx, cluster, clusterViolations); err != nil {
+			return err
+		}
+	}
+	status.EvaluatedClusters = int32(len(clusters))
+	status.NonCompliantClusters = int32(len(reports))
+	status.Reports = reports
+	return nil
+}
+
+func (r *CompliancePolicyReconciler) listOtherPolicies(reqCtx intctrlutil.RequestCtx, policyName string) ([]appsv1alpha1.CompliancePolicy, error) {
+	policyList := &appsv1alpha1.CompliancePolicyList{}
+	if err := r.Client.List(reqCtx.Ctx, policyList); err != nil {
+		return nil, err
+	}
+	policies := make([]appsv1alpha1.CompliancePolicy, 0, len(policyList.Items))
+	for _, policy := range policyList.Items {
+		if policy.Name != policyName && policy.DeletionTimestamp.IsZero() {
+			policies = append(policies, policy)
+		}
+	}
+	return policies, nil
+}
+
+// updateCompliantCondition sets the Compliant condition of the Cluster with the violations reported by all the policies.
+func (r *CompliancePolicyReconciler) updateCompliantCondition(reqCtx intctrlutil.RequestCtx,
+	cluster *appsv1alpha1.Cluster, violations map[string][]appsv1alpha1.ComplianceViolation) error {
+	condition := buildCompliantCondition(cluster, violations)
+	if existing := meta.FindStatusCondition(cluster.Status.Conditions, condition.Type); existing != nil &&
+		existing.Status == condition.Status && existing.Reason == condition.Reason && existing.Message == condition.Message {
+		return nil
+	}
+	patch := client.MergeFrom(cluster.DeepCopy())
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+	return r.Client.Status().Patch(reqCtx.Ctx, cluster, patch)
+}
+
+// collectClusterViolations collects the violations of the Cluster reported by the policies, keyed by the policy name.
+func collectClusterViolations(policies []appsv1alpha1.CompliancePolicy,
+	cluster *appsv1alpha1.Cluster) map[string][]appsv1alpha1.ComplianceViolation {
+	violations := map[string][]appsv1alpha1.ComplianceViolation{}
+	for _, policy := range policies {
+		for _, report := range policy.Status.Reports {
+			if report.Namespace == cluster.Namespace && report.ClusterName == cluster.Name {
+				violations[policy.Name] = report.Violations
+			}
+		}
+	}
+	return violations
+}
+
+func buildCompliantCondition(cluster *appsv1alpha1.Cluster,
+	violations map[string][]appsv1alpha1.ComplianceViolation) metav1.Condition {
+	if len(violations) == 0 {
+		return metav1.Condition{
+			Type:               appsv1alpha1.ConditionTypeCompliant,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: cluster.Generation,
+			Reason:             reasonCompliant,
+			Message:            "the Cluster complies with all the CompliancePolicies",
+		}
+	}
+	return metav1.Condition{
+		Type:               appsv1alpha1.ConditionTypeCompliant,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: cluster.Generation,
+		Reason:             reasonNonCompliant,
+		Message:            formatComplianceViolations(violations),
+	}
+}
+
+func buildComplianceSelector(selector *metav1.LabelSelector) (labels.Selector, error) {
+	if selector == nil {
+		return labels.Everything(), nil
+	}
+	return metav1.LabelSelectorAsSelector(selector)
+}
+
+func formatComplianceErrors(errs []error) string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,103 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+func TestCompliancePolicyEvaluate(t *testing.T) {
+	compiled, errs := compileCompliancePolicy(appsv1alpha1.CompliancePolicySpec{
+		BuiltinRules: appsv1alpha1.ComplianceBuiltinRules{
+			BackupEnabled:    true,
+			HighAvailability: &appsv1alpha1.ComplianceHighAvailabilityRule{MinReplicas: 2},
+			EOLServiceVersions: []appsv1alpha1.ComplianceEOLServiceVersions{
+				{CompDefs: []string{"mysql-"}, ServiceVersions: []string{"< 8.0.0", "invalid-constraint"}},
+			},
+		},
+		Rules: []appsv1alpha1.ComplianceRule{
+			{Name: "no-wipeout", Expression: "cluster.spec.terminationPolicy != 'WipeOut'", Message: "WipeOut is forbidden"},
+			{Name: "invalid", Expression: "cluster.spec.("},
+		},
+	})
+	assert.Len(t, errs, 2)
+
+	cluster := &appsv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mysql"},
+		Spec:       appsv1alpha1.ClusterSpec{TerminationPolicy: appsv1alpha1.WipeOut},
+	}
+	comps := []appsv1alpha1.Component{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mysql-mysql"},
+			Spec:       appsv1alpha1.ComponentSpec{CompDef: "mysql-5.7", ServiceVersion: "5.7.44", Replicas: 1},
+		},
+	}
+	violations, err := compiled.evaluate(cluster, comps, true)
+	assert.NoError(t, err)
+	rules := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		rules = append(rules, violation.Rule)
+	}
+	assert.Equal(t, []string{complianceRuleBackupEnabled, complianceRuleHighAvailability,
+		complianceRuleEOLServiceVersions, "no-wipeout"}, rules)
+	assert.Equal(t, "WipeOut is forbidden", violations[3].Message)
+
+	// the Cluster is not required to be highly available
+	enabled := true
+	cluster.Spec.Backup = &appsv1alpha1.ClusterBackup{Enabled: &enabled}
+	cluster.Spec.TerminationPolicy = appsv1alpha1.Delete
+	comps[0].Spec.ServiceVersion = "8.0.33"
+	violations, err = compiled.evaluate(cluster, comps, false)
+	assert.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestBuildCompliantCondition(t *testing.T) {
+	cluster := &appsv1alpha1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mysql"}}
+	condition := buildCompliantCondition(cluster, nil)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+
+	policies := []appsv1alpha1.CompliancePolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+			Status: appsv1alpha1.CompliancePolicyStatus{
+				Reports: []appsv1alpha1.ClusterComplianceReport{
+					{
+						Namespace:   "default",
+						ClusterName: "mysql",
+						Violations:  []appsv1alpha1.ComplianceViolation{{Rule: complianceRuleBackupEnabled, Message: "disabled"}},
+					},
+					{Namespace: "other", ClusterName: "mysql"},
+				},
+			},
+		},
+	}
+	violations := collectClusterViolations(policies, cluster)
+	violations["base"] = []appsv1alpha1.ComplianceViolation{{Rule: "custom", Message: "violated"}}
+	condition = buildCompliantCondition(cluster, violations)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, reasonNonCompliant, condition.Reason)
+	assert.Equal(t, "base/custom: violated; prod/BackupEnabled: disabled", condition.Message)
+}
@@ -0,0 +1,249 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/google/cel-go/cel"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+// the names of the built-in compliance rules
+const (
+	complianceRuleBackupEnabled      = "BackupEnabled"
+	complianceRuleHighAvailability   = "HighAvailability"
+	complianceRuleEOLServiceVersions = "EOLServiceVersions"
+)
+
+// complianceProgram is a compiled custom compliance rule.
+type complianceProgram struct {
+	name    string
+	message string
+	program cel.Program
+}
+
+// eolServiceVersions is a parsed end-of-life service versions declaration.
+type eolServiceVersions struct {
+	compDefs    []string
+	constraints []*semver.Constraints
+}
+
+// compiledCompliancePolicy holds the rules of a CompliancePolicy ready to be evaluated.
+type compiledCompliancePolicy struct {
+	builtinRules appsv1alpha1.ComplianceBuiltinRules
+	eolVersions  []eolServiceVersions
+	programs     []complianceProgram
+}
+
+// compileCompliancePolicy compiles the rules of the policy, the invalid rules are skipped and returned as errors.
+func compileCompliancePolicy(spec appsv1alpha1.CompliancePolicySpec) (*compiledCompliancePolicy, []error) {
+	var errs []error
+	compiled := &compiledCompliancePolicy{builtinRules: spec.BuiltinRules}
+	for _, eol := range spec.BuiltinRules.EOLServiceVersions {
+		parsed := eolServiceVersions{compDefs: eol.CompDefs}
+		for _, version := range eol.ServiceVersions {
+			constraint, err := semver.NewConstraint(version)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("invalid end-of-life service version %q: %s", version, err.Error()))
+				continue
+			}
+			parsed.constraints = append(parsed.constraints, constraint)
+		}
+		compiled.eolVersions = append(compiled.eolVersions, parsed)
+	}
+
+	if len(spec.Rules) == 0 {
+		return compiled, errs
+	}
+	env, err := cel.NewEnv(
+		cel.Variable("cluster", cel.DynType),
+		cel.Variable("components", cel.ListType(cel.DynType)),
+	)
+	if err != nil {
+		return compiled, append(errs, err)
+	}
+	for _, rule := range spec.Rules {
+		ast, issues := env.Compile(rule.Expression)
+		if issues != nil && issues.Err() != nil {
+			errs = append(errs, fmt.Errorf("invalid expression of rule %s: %s", rule.Name, issues.Err().Error()))
+			continue
+		}
+		program, err := env.Program(ast)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid expression of rule %s: %s", rule.Name, err.Error()))
+			continue
+		}
+		compiled.programs = append(compiled.programs, complianceProgram{
+			name:    rule.Name,
+			message: rule.Message,
+			program: program,
+		})
+	}
+	return compiled, errs
+}
+
+// evaluate evaluates the Cluster and its Components against the rules, and returns the violations.
+func (p *compiledCompliancePolicy) evaluate(cluster *appsv1alpha1.Cluster, comps []appsv1alpha1.Component,
+	haRequired bool) ([]appsv1alpha1.ComplianceViolation, error) {
+	sort.Slice(comps, func(i, j int) bool {
+		return comps[i].Name < comps[j].Name
+	})
+
+	var violations []appsv1alpha1.ComplianceViolation
+	if p.builtinRules.BackupEnabled && !isClusterBackupEnabled(cluster) {
+		violations = append(violations, appsv1alpha1.ComplianceViolation{
+			Rule:    complianceRuleBackupEnabled,
+			Message: "the backup of the Cluster is not enabled",
+		})
+	}
+	if p.builtinRules.HighAvailability != nil && haRequired {
+		minReplicas := p.builtinRules.HighAvailability.GetMinReplicas()
+		for _, comp := range comps {
+			if comp.Spec.Replicas < minReplicas {
+				violations = append(violations, appsv1alpha1.ComplianceViolation{
+					Rule: complianceRuleHighAvailability,
+					Message: fmt.Sprintf("the Component %s has %d replicas, less than %d",
+						comp.Name, comp.Spec.Replicas, minReplicas),
+				})
+			}
+		}
+	}
+	for _, comp := range comps {
+		if p.isEOLServiceVersion(comp.Spec.CompDef, comp.Spec.ServiceVersion) {
+			violations = append(violations, appsv1alpha1.ComplianceViolation{
+				Rule: complianceRuleEOLServiceVersions,
+				Message: fmt.Sprintf("the Component %s runs the end-of-life service version %s",
+					comp.Name, comp.Spec.ServiceVersion),
+			})
+		}
+	}
+
+	if len(p.programs) == 0 {
+		return violations, nil
+	}
+	vars, err := buildComplianceVariables(cluster, comps)
+	if err != nil {
+		return nil, err
+	}
+	for _, program := range p.programs {
+		if message := evaluateComplianceProgram(program, vars); message != "" {
+			violations = append(violations, appsv1alpha1.ComplianceViolation{
+				Rule:    program.name,
+				Message: message,
+			})
+		}
+	}
+	return violations, nil
+}
+
+func (p *compiledCompliancePolicy) isEOLServiceVersion(compDef, serviceVersion string) bool {
+	if serviceVersion == "" {
+		return false
+	}
+	version, err := semver.NewVersion(serviceVersion)
+	if err != nil {
+		return false
+	}
+	for _, eol := range p.eolVersions {
+		if !matchCompDefs(eol.compDefs, compDef) {
+			continue
+		}
+		for _, constraint := range eol.constraints {
+			if constraint.Check(version) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// evaluateComplianceProgram returns the message of the violation, or an empty string if the rule is complied with.
+func evaluateComplianceProgram(program complianceProgram, vars map[string]any) string {
+	out, _, err := program.program.Eval(vars)
+	if err != nil {
+		return fmt.Sprintf("failed to evaluate the rule: %s", err.Error())
+	}
+	compliant, ok := out.Value().(bool)
+	if !ok {
+		return fmt.Sprintf("the rule is expected to evaluate to a bool, but got %v", out.Value())
+	}
+	if compliant {
+		return ""
+	}
+	if program.message != "" {
+		return program.message
+	}
+	return fmt.Sprintf("the Cluster violates the rule %s", program.name)
+}
+
+func buildComplianceVariables(cluster *appsv1alpha1.Cluster, comps []appsv1alpha1.Component) (map[string]any, error) {
+	clusterObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cluster)
+	if err != nil {
+		return nil, err
+	}
+	compObjs := make([]any, 0, len(comps))
+	for i := range comps {
+		compObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&comps[i])
+		if err != nil {
+			return nil, err
+		}
+		compObjs = append(compObjs, compObj)
+	}
+	return map[string]any{
+		"cluster":    clusterObj,
+		"components": compObjs,
+	}, nil
+}
+
+func isClusterBackupEnabled(cluster *appsv1alpha1.Cluster) bool {
+	return cluster.Spec.Backup != nil && cluster.Spec.Backup.Enabled != nil && *cluster.Spec.Backup.Enabled
+}
+
+// matchCompDefs checks whether the component definition matches any of the names, each of them is an exact name or a name prefix.
+func matchCompDefs(names []string, compDef string) bool {
+	for _, name := range names {
+		if strings.HasPrefix(compDef, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatComplianceViolations formats the violations reported by the policies, ordered by the policy name.
+func formatComplianceViolations(violations map[string][]appsv1alpha1.ComplianceViolation) string {
+	policies := make([]string, 0, len(violations))
+	for policy := range violations {
+		policies = append(policies, policy)
+	}
+	sort.Strings(policies)
+	var messages []string
+	for _, policy := range policies {
+		for _, violation := range violations[policy] {
+			messages = append(messages, fmt.Sprintf("%s/%s: %s", policy, violation.Rule, violation.Message))
+		}
+	}
+	return strings.Join(messages, "; ")
+}
@@ -21,20 +21,25 @@ package apps
 
 import (
 	"context"
+	"reflect"
 	"time"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -81,6 +86,7 @@ type ComponentReconciler struct {
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete;deletecollection
 // +kubebuilder:rbac:groups=core,resources=services/status,verbs=get
 // +kubebuilder:rbac:groups=core,resources=services/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=endpoints,verbs=get;list;watch
 
 // +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims/status,verbs=get
@@ -91,6 +97,8 @@ type ComponentReconciler struct {
 // +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete;deletecollection
 // +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets/finalizers,verbs=update
 
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=batch,resources=jobs/status,verbs=get
 // +kubebuilder:rbac:groups=batch,resources=jobs/finalizers,verbs=update
@@ -102,6 +110,8 @@ type ComponentReconciler struct {
 
 // read only + watch access
 // +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=scheduling.k8s.io,resources=priorityclasses,verbs=get;list;watch
 
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts/status,verbs=get
@@ -114,6 +124,9 @@ type ComponentReconciler struct {
 
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
 
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backuppolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups,verbs=get;list;watch;create
+
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 //
@@ -162,6 +175,8 @@ func (r *ComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			&componentHostNetworkTransformer{},
 			// handle component services
 			&componentServiceTransformer{},
+			// handle component network policies
+			&componentNetworkPolicyTransformer{},
 			// handle component system accounts
 			&componentAccountTransformer{},
 			// provision component system accounts
@@ -172,14 +187,18 @@ func (r *ComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			&componentRelatedParametersTransformer{Client: r.Client},
 			// handle component custom volumes
 			&componentCustomVolumesTransformer{},
+			// handle the volumes shared by all the instances
+			&componentSharedVolumesTransformer{},
 			// resolve and build vars for template and Env
 			&componentVarsTransformer{},
 			// render component configurations
 			&componentConfigurationTransformer{Client: r.Client},
 			// handle restore before workloads transform
 			&componentRestoreTransformer{Client: r.Client},
-			// handle upgrade from the legacy RSM API to the InstanceSet API
+			// handle migration from the legacy StatefulSet and RSM API to the InstanceSet API
 			&componentWorkloadUpgradeTransformer{},
+			// report the insufficient capacity of nodes before provisioning the workload, it never blocks the provisioning
+			&componentCapacityAdvisoryTransformer{},
 			// handle the component workload
 			&componentWorkloadTransformer{Client: r.Client},
 			// handle RBAC for component workloads
@@ -188,6 +207,12 @@ func (r *ComponentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			&componentOwnershipTransformer{},
 			// handle component postProvision lifecycle action
 			&componentPostProvisionTransformer{},
+			// record the completion of the init scripts on the first provision
+			&componentInitScriptsTransformer{},
+			// report the nodes under pressure, after the ownership since the switchover OpsRequest is not owned by the component
+			&componentNodePressureTransformer{},
+			// pin the image digests and report the drifts
+			&componentImageDigestTransformer{},
 			// update component status
 			&componentStatusTransformer{Client: r.Client},
 		).Build()
@@ -224,13 +249,26 @@ func (r *ComponentReconciler) setupWithManager(mgr ctrl.Manager) error {
 		}).
 		Owns(&workloads.InstanceSet{}).
 		Owns(&corev1.Service{}).
+		Owns(&networkingv1.NetworkPolicy{}).
 		Owns(&corev1.Secret{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&dpv1alpha1.Backup{}).
 		Owns(&dpv1alpha1.Restore{}).
 		Watches(&corev1.PersistentVolumeClaim{}, handler.EnqueueRequestsFromMapFunc(r.filterComponentResources)).
 		Owns(&batchv1.Job{}).
-		Watches(&appsv1alpha1.Configuration{}, handler.EnqueueRequestsFromMapFunc(r.configurationEventHandler))
+		Watches(&appsv1alpha1.Configuration{}, handler.EnqueueRequestsFromMapFunc(r.configurationEventHandler)).
+		Watches(&corev1.Endpoints{}, newRoleServicePropagationHandler(mgr.GetClient()),
+			builder.WithPredicates(predicate.NewPredicateFuncs(isRoleServiceEndpoints)))
+
+	if viper.GetBool(constant.CfgKeyNodePressureDetection) {
+		b.Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.nodePressureEventHandler),
+			builder.WithPredicates(predicate.Funcs{
+				CreateFunc:  func(event.CreateEvent) bool { return false },
+				DeleteFunc:  func(event.DeleteEvent) bool { return false },
+				GenericFunc: func(event.GenericEvent) bool { return false },
+				UpdateFunc:  isNodePressureChanged,
+			}))
+	}
 
 	if viper.GetBool(constant.EnableRBACManager) {
 		b.Owns(&rbacv1.ClusterRoleBinding{}).
@@ -258,6 +296,7 @@ func (r *ComponentReconciler) setupWithMultiClusterManager(mgr ctrl.Manager, mul
 
 	eventHandler := handler.EnqueueRequestsFromMapFunc(r.filterComponentResources)
 	multiClusterMgr.Watch(b, &corev1.Service{}, eventHandler).
+		Watch(b, &networkingv1.NetworkPolicy{}, eventHandler).
 		Watch(b, &corev1.Secret{}, eventHandler).
 		Watch(b, &corev1.ConfigMap{}, eventHandler).
 		Watch(b, &corev1.PersistentVolumeClaim{}, eventHandler).
@@ -291,6 +330,42 @@ func (r *ComponentReconciler) filterComponentResources(ctx context.Context, obj
 	}
 }
 
+// nodePressureEventHandler enqueues the components which have pods on the node.
+func (r *ComponentReconciler) nodePressureEventHandler(ctx context.Context, obj client.Object) []reconcile.Request {
+	pods := &corev1.PodList{}
+	if err := r.Client.List(ctx, pods, client.MatchingLabels{constant.AppManagedByLabelKey: constant.AppName}); err != nil {
+		return []reconcile.Request{}
+	}
+	requests := make([]reconcile.Request, 0)
+	enqueued := map[types.NamespacedName]bool{}
+	for i := range pods.Items {
+		if pods.Items[i].Spec.NodeName != obj.GetName() {
+			continue
+		}
+		for _, req := range r.filterComponentResources(ctx, &pods.Items[i]) {
+			if !enqueued[req.NamespacedName] {
+				enqueued[req.NamespacedName] = true
+				requests = append(requests, req)
+			}
+		}
+	}
+	return requests
+}
+
+// isNodePressureChanged checks whether the node becomes or is no longer under pressure.
+func isNodePressureChanged(e event.UpdateEvent) bool {
+	oldNode, ok1 := e.ObjectOld.(*corev1.Node)
+	newNode, ok2 := e.ObjectNew.(*corev1.Node)
+	if !ok1 || !ok2 {
+		return false
+	}
+	oldPressure, newPressure := getNodePressure(oldNode), getNodePressure(newNode)
+	if oldPressure == nil || newPressure == nil {
+		return oldPressure != newPressure
+	}
+	return !reflect.DeepEqual(oldPressure.conditions, newPressure.conditions)
+}
+
 func (r *ComponentReconciler) configurationEventHandler(_ context.Context, obj client.Object) []reconcile.Request {
 	cr, ok := obj.(*appsv1alpha1.Configuration)
 	if !ok {
@@ -36,6 +36,7 @@ import (
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	storagev1 "k8s.io/api/storage/v1"
@@ -2055,6 +2056,55 @@ var _ = Describe("Component Controller", func() {
 		})).Should(Succeed())
 	}
 
+	testCompTaskJob := func(compName, compDefName string) {
+		By("Create a componentDefinition obj with workload type Job")
+		taskCompDefObj := testapps.NewComponentDefinitionFactory(compDefName).
+			WithRandomName().
+			SetRuntime(nil).
+			SetWorkloadType(appsv1alpha1.JobWorkloadType).
+			Create(&testCtx).
+			GetObject()
+		Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(taskCompDefObj),
+			func(g Gomega, cmpd *appsv1alpha1.ComponentDefinition) {
+				g.Expect(cmpd.Status.Phase).Should(Equal(appsv1alpha1.AvailablePhase))
+			})).Should(Succeed())
+
+		createClusterObjV2(compName, taskCompDefObj.Name, nil)
+
+		By("check the Job of the component")
+		jobKey := types.NamespacedName{
+			Namespace: compObj.Namespace,
+			Name:      compObj.Name,
+		}
+		var specHash string
+		Eventually(testapps.CheckObj(&testCtx, jobKey, func(g Gomega, job *batchv1.Job) {
+			g.Expect(*job.Spec.Completions).Should(BeEquivalentTo(1))
+			specHash = job.Annotations[constant.TaskJobSpecHashAnnotationKey]
+			g.Expect(specHash).ShouldNot(BeEmpty())
+		})).Should(Succeed())
+		Consistently(testapps.CheckObjExists(&testCtx, jobKey, &workloads.InstanceSet{}, false)).Should(Succeed())
+
+		By("the Job is recreated after the replicas are changed")
+		changeCompReplicas(clusterKey, 2, &clusterObj.Spec.ComponentSpecs[0])
+		Eventually(testapps.CheckObj(&testCtx, jobKey, func(g Gomega, job *batchv1.Job) {
+			g.Expect(*job.Spec.Completions).Should(BeEquivalentTo(2))
+			g.Expect(job.Annotations[constant.TaskJobSpecHashAnnotationKey]).ShouldNot(Equal(specHash))
+		})).Should(Succeed())
+
+		By("mock the Job completed")
+		Expect(testapps.GetAndChangeObjStatus(&testCtx, jobKey, func(job *batchv1.Job) {
+			now := metav1.Now()
+			job.Status.StartTime = &now
+			job.Status.CompletionTime = &now
+			job.Status.Succeeded = 2
+			job.Status.Conditions = []batchv1.JobCondition{{
+				Type:   batchv1.JobComplete,
+				Status: corev1.ConditionTrue,
+			}}
+		})()).Should(Succeed())
+		Eventually(testapps.GetComponentPhase(&testCtx, compKey)).Should(Equal(appsv1alpha1.CompletedClusterCompPhase))
+	}
+
 	Context("component resources provisioning", func() {
 		BeforeEach(func() {
 			createAllWorkloadTypesClusterDef()
@@ -2120,6 +2170,10 @@ var _ = Describe("Component Controller", func() {
 		It("create component with custom RBAC which is already exist created by User", func() {
 			tesCreateCompWithRBACCreateByUser(defaultCompName, compDefName)
 		})
+
+		It("with workload type Job", func() {
+			testCompTaskJob(defaultCompName, compDefName)
+		})
 	})
 
 	Context("scale-out with different backup methods", func() {
@@ -0,0 +1,145 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	"github.com/apecloud/kubeblocks/pkg/kberrors"
+)
+
+// exportCompData exports the data of the component removed from the cluster to a Backup if it's confirmed,
+// and returns true once the export is completed or not required.
+func exportCompData(transCtx *componentTransformContext, graphCli model.GraphClient, dag *graph.DAG,
+	cluster *appsv1alpha1.Cluster, comp *appsv1alpha1.Component, compShortName string) (bool, error) {
+	dataExport := comp.Spec.DataExport
+	if dataExport == nil || !dataExport.Confirmed {
+		return true, nil
+	}
+	backup := &dpv1alpha1.Backup{}
+	backupKey := types.NamespacedName{Namespace: comp.Namespace, Name: exportBackupName(comp)}
+	if err := transCtx.Client.Get(transCtx.Context, backupKey, backup); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		backupPolicyList := &dpv1alpha1.BackupPolicyList{}
+		if err = transCtx.Client.List(transCtx.Context, backupPolicyList, client.InNamespace(comp.Namespace),
+			client.MatchingLabels{
+				constant.AppInstanceLabelKey:    cluster.Name,
+				constant.KBAppComponentLabelKey: compShortName,
+			}); err != nil {
+			return false, err
+		}
+		backup, err = buildExportBackup(cluster.Name, compShortName, backupKey, dataExport, backupPolicyList.Items)
+		if err != nil {
+			return false, err
+		}
+		graphCli.Create(dag, backup)
+		transCtx.EventRecorder.Eventf(comp, corev1.EventTypeNormal, "DataExporting",
+			"exporting the data of component %s to backup %s before it is removed", compShortName, backup.Name)
+		return false, nil
+	}
+	switch backup.Status.Phase {
+	case dpv1alpha1.BackupPhaseCompleted:
+		return true, nil
+	case dpv1alpha1.BackupPhaseFailed:
+		return false, kberrors.New(kberrors.ReasonDataExportFailed, "failed to export the data of component %s to backup %s: %s",
+			compShortName, backup.Name, backup.Status.FailureReason).
+			WithHint("delete the backup to retry the export, or set spec.dataExport.confirmed of the component %s to false to skip it", comp.Name)
+	}
+	return false, nil
+}
+
+// exportBackupName returns the name of the Backup exporting the data of the component, which is unique
+// for each incarnation of the component.
+func exportBackupName(comp *appsv1alpha1.Component) string {
+	uid, _, _ := strings.Cut(string(comp.UID), "-")
+	return fmt.Sprintf("%s-export-%s", comp.Name, uid)
+}
+
+// buildExportBackup builds the Backup exporting the data of the component with the backup policies of it.
+// The Backup is not owned by the component, so that it's kept after the component is removed.
+func buildExportBackup(clusterName, compShortName string, backupKey types.NamespacedName,
+	dataExport *appsv1alpha1.ComponentDataExport, backupPolicies []dpv1alpha1.BackupPolicy) (*dpv1alpha1.Backup, error) {
+	backupPolicy, backupMethod := selectExportBackupMethod(dataExport, backupPolicies)
+	if backupPolicy == nil {
+		return nil, kberrors.New(kberrors.ReasonBackupMethodNotFound, "failed to find the backup method to export the data of component %s", compShortName).
+			WithHint("check the backup policies of the component by command: kubectl get backuppolicy -n %s -l %s=%s,%s=%s",
+				backupKey.Namespace, constant.AppInstanceLabelKey, clusterName, constant.KBAppComponentLabelKey, compShortName)
+	}
+	backupBuilder := builder.NewBackupBuilder(backupKey.Namespace, backupKey.Name).
+		AddLabels(dptypes.BackupMethodLabelKey, backupMethod).
+		AddLabels(dptypes.BackupPolicyLabelKey, backupPolicy.Name).
+		AddLabels(constant.AppInstanceLabelKey, clusterName).
+		AddLabels(constant.AppManagedByLabelKey, constant.AppName).
+		AddLabels(constant.KBAppExportedComponentLabelKey, compShortName).
+		SetBackupPolicyName(backupPolicy.Name).
+		SetBackupMethod(backupMethod)
+	if len(dataExport.BackupRepoName) > 0 {
+		backupBuilder.AddLabels(dptypes.BackupRepoNameLabelKey, dataExport.BackupRepoName)
+	}
+	backup := backupBuilder.GetObject()
+	backup.Spec.RetentionPeriod = dataExport.RetentionPeriod
+	return backup, nil
+}
+
+// selectExportBackupMethod selects the backup policy and method to export the data, the default backup policy is preferred.
+// If the backup method is not specified, the first one not snapshotting the volumes is selected,
+// as the volume snapshots are not exported to the BackupRepo.
+func selectExportBackupMethod(dataExport *appsv1alpha1.ComponentDataExport,
+	backupPolicies []dpv1alpha1.BackupPolicy) (*dpv1alpha1.BackupPolicy, string) {
+	policies := make([]*dpv1alpha1.BackupPolicy, 0, len(backupPolicies))
+	for i := range backupPolicies {
+		policies = append(policies, &backupPolicies[i])
+	}
+	sort.SliceStable(policies, func(i, j int) bool {
+		return policies[i].Annotations[dptypes.DefaultBackupPolicyAnnotationKey] == trueVal &&
+			policies[j].Annotations[dptypes.DefaultBackupPolicyAnnotationKey] != trueVal
+	})
+	for _, policy := range policies {
+		for _, method := range policy.Spec.BackupMethods {
+			if len(dataExport.BackupMethod) > 0 {
+				if method.Name == dataExport.BackupMethod {
+					return policy, method.Name
+				}
+				continue
+			}
+			if !boolptr.IsSetToTrue(method.SnapshotVolumes) {
+				return policy, method.Name
+			}
+		}
+	}
+	return nil, ""
+}
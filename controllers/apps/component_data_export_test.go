@@ -0,0 +1,90 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+func TestBuildExportBackup(t *testing.T) {
+	newPolicy := func(name string, isDefault bool, methods ...dpv1alpha1.BackupMethod) dpv1alpha1.BackupPolicy {
+		policy := dpv1alpha1.BackupPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       dpv1alpha1.BackupPolicySpec{BackupMethods: methods},
+		}
+		if isDefault {
+			policy.Annotations = map[string]string{dptypes.DefaultBackupPolicyAnnotationKey: trueVal}
+		}
+		return policy
+	}
+	snapshot := dpv1alpha1.BackupMethod{Name: "volume-snapshot", SnapshotVolumes: pointer.Bool(true)}
+	dump := dpv1alpha1.BackupMethod{Name: "dump"}
+	policies := []dpv1alpha1.BackupPolicy{
+		newPolicy("pitr", false, dpv1alpha1.BackupMethod{Name: "archive-log"}),
+		newPolicy("default", true, snapshot, dump),
+	}
+
+	// the dump method of the default backup policy is preferred
+	policy, method := selectExportBackupMethod(&appsv1alpha1.ComponentDataExport{}, policies)
+	assert.Equal(t, "default", policy.Name)
+	assert.Equal(t, "dump", method)
+
+	// the specified backup method
+	policy, method = selectExportBackupMethod(&appsv1alpha1.ComponentDataExport{BackupMethod: "archive-log"}, policies)
+	assert.Equal(t, "pitr", policy.Name)
+	assert.Equal(t, "archive-log", method)
+
+	// no backup method to export the data
+	policy, _ = selectExportBackupMethod(&appsv1alpha1.ComponentDataExport{}, []dpv1alpha1.BackupPolicy{newPolicy("default", true, snapshot)})
+	assert.Nil(t, policy)
+	_, err := buildExportBackup("mycluster", "mysql", types.NamespacedName{Namespace: "default", Name: "export"},
+		&appsv1alpha1.ComponentDataExport{}, nil)
+	assert.ErrorContains(t, err, "failed to find the backup method")
+
+	dataExport := &appsv1alpha1.ComponentDataExport{Confirmed: true, BackupRepoName: "repo", RetentionPeriod: "7d"}
+	backup, err := buildExportBackup("mycluster", "mysql", types.NamespacedName{Namespace: "default", Name: "export"}, dataExport, policies)
+	assert.NoError(t, err)
+	assert.Equal(t, "default", backup.Spec.BackupPolicyName)
+	assert.Equal(t, "dump", backup.Spec.BackupMethod)
+	assert.Equal(t, dpv1alpha1.RetentionPeriod("7d"), backup.Spec.RetentionPeriod)
+	assert.Equal(t, "repo", backup.Labels[dptypes.BackupRepoNameLabelKey])
+	assert.Equal(t, "mysql", backup.Labels[constant.KBAppExportedComponentLabelKey])
+	// the backup is not deleted together with the sub-resources of the component
+	assert.NotContains(t, backup.Labels, constant.KBAppComponentLabelKey)
+	assert.Empty(t, backup.OwnerReferences)
+}
+
+func TestExportBackupName(t *testing.T) {
+	comp := &appsv1alpha1.Component{ObjectMeta: metav1.ObjectMeta{
+		Name: "mycluster-mysql",
+		UID:  "5c7a1f0e-58e2-4c3b-9a5e-2f4d6b8c9e01",
+	}}
+	assert.Equal(t, "mycluster-mysql-export-5c7a1f0e", exportBackupName(comp))
+}
@@ -85,6 +85,9 @@ type componentPlan struct {
 	transCtx *componentTransformContext
 }
 
+// componentControllerName is the name of the Component controller in the metrics of the status writes.
+const componentControllerName = "component"
+
 var _ graph.TransformContext = &componentTransformContext{}
 var _ graph.PlanBuilder = &componentPlanBuilder{}
 var _ graph.Plan = &componentPlan{}
@@ -256,5 +259,5 @@ func (c *componentPlanBuilder) reconcileDeleteObject(ctx context.Context, vertex
 }
 
 func (c *componentPlanBuilder) reconcileStatusObject(ctx context.Context, vertex *model.ObjectVertex) error {
-	return c.cli.Status().Update(ctx, vertex.Obj, clientOption(vertex))
+	return model.PatchStatus(ctx, c.cli, componentControllerName, vertex.OriObj, vertex.Obj, clientOption(vertex))
 }
@@ -0,0 +1,92 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/metrics"
+)
+
+// isRoleServiceEndpoints checks whether the endpoints belong to a read-write or read-only Service of a Component,
+// the labels of the Service are copied to its endpoints.
+func isRoleServiceEndpoints(obj client.Object) bool {
+	labels := obj.GetLabels()
+	return labels[constant.AppManagedByLabelKey] == constant.AppName && len(labels[constant.AccessModeLabelKey]) > 0
+}
+
+// newRoleServicePropagationHandler returns a handler that observes the latency of the role changes
+// propagated to the read-write and read-only Services, it never enqueues any request.
+func newRoleServicePropagationHandler(cli client.Reader) handler.EventHandler {
+	return handler.Funcs{
+		UpdateFunc: func(ctx context.Context, e event.UpdateEvent, _ workqueue.RateLimitingInterface) {
+			oldEndpoints, ok1 := e.ObjectOld.(*corev1.Endpoints)
+			newEndpoints, ok2 := e.ObjectNew.(*corev1.Endpoints)
+			if ok1 && ok2 {
+				observeRoleServicePropagation(ctx, cli, oldEndpoints, newEndpoints)
+			}
+		},
+	}
+}
+
+func observeRoleServicePropagation(ctx context.Context, cli client.Reader, oldEndpoints, newEndpoints *corev1.Endpoints) {
+	// the pods already in the endpoints, no matter ready or not, are not selected by a role change.
+	existing := sets.New[string]()
+	insert := func(addresses []corev1.EndpointAddress) {
+		for _, addr := range addresses {
+			if addr.TargetRef != nil {
+				existing.Insert(addr.TargetRef.Name)
+			}
+		}
+	}
+	for _, subset := range oldEndpoints.Subsets {
+		insert(subset.Addresses)
+		insert(subset.NotReadyAddresses)
+	}
+	accessMode := newEndpoints.Labels[constant.AccessModeLabelKey]
+	for _, subset := range newEndpoints.Subsets {
+		for _, addr := range subset.Addresses {
+			if addr.TargetRef == nil || addr.TargetRef.Kind != constant.PodKind || existing.Has(addr.TargetRef.Name) {
+				continue
+			}
+			pod := &corev1.Pod{}
+			if err := cli.Get(ctx, types.NamespacedName{Namespace: newEndpoints.Namespace, Name: addr.TargetRef.Name}, pod); err != nil {
+				continue
+			}
+			updateTime, err := time.Parse(time.RFC3339Nano, pod.Annotations[constant.LastRoleUpdateTimestampAnnotationKey])
+			if err != nil {
+				continue
+			}
+			if latency := time.Since(updateTime); latency >= 0 {
+				metrics.RoleServicePropagationSeconds.WithLabelValues(accessMode).Observe(latency.Seconds())
+			}
+		}
+	}
+}
@@ -20,12 +20,14 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package apps
 
 import (
+	"slices"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controllerutil"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
@@ -48,6 +50,7 @@ func delayUpdatePodSpecSystemFields(obj corev1.PodSpec, pobj *corev1.PodSpec) {
 		delayUpdateKubeBlocksToolsImage(obj.InitContainers, &pobj.InitContainers[i])
 	}
 	updateLorryContainer(obj.Containers, pobj.Containers)
+	delayRemoveInitScriptsContainer(obj, pobj)
 }
 
 func updateInstanceSetSystemFields(obj v1alpha1.InstanceSetSpec, pobj *v1alpha1.InstanceSetSpec) {
@@ -65,6 +68,38 @@ func updatePodSpecSystemFields(obj corev1.PodSpec, pobj *corev1.PodSpec) {
 	}
 
 	updateLorryContainer(obj.Containers, pobj.Containers)
+	removeInitScriptsContainer(obj, pobj)
+}
+
+// delayRemoveInitScriptsContainer keeps the init container executing the init scripts and its volume in the running
+// pod template after the first provision, to avoid recreating the pods only for removing them.
+func delayRemoveInitScriptsContainer(obj corev1.PodSpec, pobj *corev1.PodSpec) {
+	idx := slices.IndexFunc(obj.InitContainers, isInitScriptsContainer)
+	if idx < 0 || slices.ContainsFunc(pobj.InitContainers, isInitScriptsContainer) {
+		return
+	}
+	pobj.InitContainers = slices.Insert(pobj.InitContainers, min(idx, len(pobj.InitContainers)), *obj.InitContainers[idx].DeepCopy())
+	if idx = slices.IndexFunc(obj.Volumes, isInitScriptsVolume); idx >= 0 && !slices.ContainsFunc(pobj.Volumes, isInitScriptsVolume) {
+		pobj.Volumes = slices.Insert(pobj.Volumes, min(idx, len(pobj.Volumes)), *obj.Volumes[idx].DeepCopy())
+	}
+}
+
+// removeInitScriptsContainer removes the init container executing the init scripts and its volume if they're not
+// desired any more, it's called only if the pod template is about to be updated.
+func removeInitScriptsContainer(obj corev1.PodSpec, pobj *corev1.PodSpec) {
+	if slices.ContainsFunc(obj.InitContainers, isInitScriptsContainer) {
+		return
+	}
+	pobj.InitContainers = slices.DeleteFunc(pobj.InitContainers, isInitScriptsContainer)
+	pobj.Volumes = slices.DeleteFunc(pobj.Volumes, isInitScriptsVolume)
+}
+
+func isInitScriptsContainer(c corev1.Container) bool {
+	return c.Name == component.InitScriptsContainerName
+}
+
+func isInitScriptsVolume(v corev1.Volume) bool {
+	return v.Name == component.InitScriptsVolumeName
 }
 
 func updateLorryContainer(containers []corev1.Container, pcontainers []corev1.Container) {
@@ -25,6 +25,7 @@ import (
 	"reflect"
 	"strings"
 
+	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -37,6 +38,7 @@ import (
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	appsconfig "github.com/apecloud/kubeblocks/controllers/apps/configuration"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
@@ -161,6 +163,8 @@ func (r *ComponentDefinitionReconciler) validate(cli client.Client, rctx intctrl
 		r.validateReplicaRoles,
 		r.validateLifecycleActions,
 		r.validateComponentDefRef,
+		r.validateWorkloadType,
+		r.validateFencing,
 	} {
 		if err := validator(cli, rctx, cmpd); err != nil {
 			return err
@@ -314,10 +318,62 @@ func (r *ComponentDefinitionReconciler) validateReplicaRoles(cli client.Client,
 	return nil
 }
 
+func (r *ComponentDefinitionReconciler) validateWorkloadType(cli client.Client, reqCtx intctrlutil.RequestCtx,
+	cmpd *appsv1alpha1.ComponentDefinition) error {
+	if cmpd.Spec.WorkloadType != appsv1alpha1.JobWorkloadType {
+		return nil
+	}
+	// the pods of a run-to-completion component are not long-running, persistent volumes and roles are meaningless.
+	if len(cmpd.Spec.Volumes) > 0 {
+		return fmt.Errorf("volumes are not supported by the component with workload type %s", cmpd.Spec.WorkloadType)
+	}
+	if len(cmpd.Spec.Roles) > 0 {
+		return fmt.Errorf("roles are not supported by the component with workload type %s", cmpd.Spec.WorkloadType)
+	}
+	// the other actions are performed against the running instances, which a run-to-completion component has not.
+	if cmpd.Spec.LifecycleActions != nil {
+		actions := cmpd.Spec.LifecycleActions.DeepCopy()
+		actions.PostProvision = nil
+		if !reflect.DeepEqual(*actions, appsv1alpha1.ComponentLifecycleActions{}) {
+			return fmt.Errorf("lifecycle actions except postProvision are not supported by the component with workload type %s",
+				cmpd.Spec.WorkloadType)
+		}
+	}
+	return nil
+}
+
+func (r *ComponentDefinitionReconciler) validateFencing(cli client.Client, reqCtx intctrlutil.RequestCtx,
+	cmpd *appsv1alpha1.ComponentDefinition) error {
+	if cmpd.Spec.Fencing == nil {
+		return nil
+	}
+	// only the former leader needs to be fenced.
+	if len(cmpd.Spec.Roles) == 0 {
+		return fmt.Errorf("fencing is not supported by the component without roles")
+	}
+	if slices.Contains(cmpd.Spec.Fencing.Strategies, appsv1alpha1.ActionFencingStrategy) &&
+		(cmpd.Spec.LifecycleActions == nil || cmpd.Spec.LifecycleActions.Fence == nil) {
+		return fmt.Errorf("the fence lifecycle action is required by the %s fencing strategy", appsv1alpha1.ActionFencingStrategy)
+	}
+	return nil
+}
+
 func (r *ComponentDefinitionReconciler) validateLifecycleActions(cli client.Client, reqCtx intctrlutil.RequestCtx, cmpd *appsv1alpha1.ComponentDefinition) error {
 	if err := r.validateLifecycleActionBuiltInHandlers(cmpd.Spec.LifecycleActions); err != nil {
 		return err
 	}
+	return r.validateLifecycleActionCustomHandlers(cmpd.Spec.LifecycleActions)
+}
+
+func (r *ComponentDefinitionReconciler) validateLifecycleActionCustomHandlers(lifecycleActions *appsv1alpha1.ComponentLifecycleActions) error {
+	actions := component.CustomLifecycleActions(lifecycleActions)
+	names := maps.Keys(actions)
+	slices.Sort(names)
+	for _, name := range names {
+		if err := component.ValidateCustomAction(actions[name]); err != nil {
+			return fmt.Errorf("invalid custom handler of the %s action: %s", name, err.Error())
+		}
+	}
 	return nil
 }
 
@@ -349,6 +405,7 @@ func (r *ComponentDefinitionReconciler) validateLifecycleActionBuiltInHandlers(l
 		{lifecycleActions.DataLoad},
 		{lifecycleActions.Reconfigure},
 		{lifecycleActions.AccountProvision},
+		{lifecycleActions.Fence},
 	}
 
 	for _, action := range actions {
@@ -330,4 +330,40 @@ var _ = Describe("ComponentDefinition Controller", func() {
 			checkObjectStatus(componentDefObj, appsv1alpha1.UnavailablePhase)
 		})
 	})
+
+	Context("workload type", func() {
+		It("job w/ postProvision action", func() {
+			By("create a ComponentDefinition obj")
+			componentDefObj := testapps.NewComponentDefinitionFactory(componentDefName).
+				SetRuntime(nil).
+				SetWorkloadType(appsv1alpha1.JobWorkloadType).
+				SetLifecycleAction("PostProvision", defaultActionHandler).
+				Create(&testCtx).GetObject()
+
+			checkObjectStatus(componentDefObj, appsv1alpha1.AvailablePhase)
+		})
+
+		It("job w/ roles", func() {
+			By("create a ComponentDefinition obj")
+			componentDefObj := testapps.NewComponentDefinitionFactory(componentDefName).
+				SetRuntime(nil).
+				SetWorkloadType(appsv1alpha1.JobWorkloadType).
+				AddRole("leader", true, true).
+				Create(&testCtx).GetObject()
+
+			checkObjectStatus(componentDefObj, appsv1alpha1.UnavailablePhase)
+		})
+
+		It("job w/ actions performed against the running instances", func() {
+			By("create a ComponentDefinition obj")
+			componentDefObj := testapps.NewComponentDefinitionFactory(componentDefName).
+				SetRuntime(nil).
+				SetWorkloadType(appsv1alpha1.JobWorkloadType).
+				SetLifecycleAction("PostProvision", defaultActionHandler).
+				SetLifecycleAction("MemberJoin", defaultActionHandler).
+				Create(&testCtx).GetObject()
+
+			checkObjectStatus(componentDefObj, appsv1alpha1.UnavailablePhase)
+		})
+	})
 })
@@ -39,6 +39,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	opsutil "github.com/apecloud/kubeblocks/controllers/apps/operations/util"
 	cfgcm "github.com/apecloud/kubeblocks/pkg/configuration/config_manager"
 	"github.com/apecloud/kubeblocks/pkg/configuration/core"
 	"github.com/apecloud/kubeblocks/pkg/constant"
@@ -212,6 +213,15 @@ func (r *ReconfigureReconciler) sync(reqCtx intctrlutil.RequestCtx, configMap *c
 		return updateConfigPhase(r.Client, reqCtx, configMap, appsv1alpha1.CFinishedPhase, configurationNotUsingMessage)
 	}
 
+	restart := forceRestart || !cfgcm.IsSupportReload(resources.configConstraintObj.Spec.ReloadAction)
+	if restart {
+		if start, ok := waitForMaintenanceWindow(reconcileContext.ClusterObj, time.Now()); ok {
+			message := fmt.Sprintf("the restart is deferred to the maintenance window of the cluster which opens at %s", start.Format(time.RFC3339))
+			reqCtx.Recorder.Event(configMap, corev1.EventTypeNormal, appsv1alpha1.ReasonReconfigureDeferred, message)
+			return intctrlutil.RequeueAfter(time.Until(start), reqCtx.Log, message)
+		}
+	}
+
 	return r.performUpgrade(reconfigureParams{
 		ConfigSpecName:           resources.configSpec.Name,
 		ConfigPatch:              configPatch,
@@ -224,11 +234,32 @@ func (r *ReconfigureReconciler) sync(reqCtx intctrlutil.RequestCtx, configMap *c
 		InstanceSetUnits:         reconcileContext.InstanceSetList,
 		ClusterComponent:         reconcileContext.ClusterComObj,
 		SynthesizedComponent:     reconcileContext.BuiltinComponent,
-		Restart:                  forceRestart || !cfgcm.IsSupportReload(resources.configConstraintObj.Spec.ReloadAction),
+		Restart:                  restart,
 		ReconfigureClientFactory: GetClientFactory(),
 	})
 }
 
+// waitForMaintenanceWindow checks whether the restart caused by the configuration changes should wait for the next
+// maintenance window of the cluster, and returns the time it opens.
+// The restart of a running Reconfiguring OpsRequest is not deferred, as the OpsRequest has been admitted
+// by the maintenance windows or forced.
+func waitForMaintenanceWindow(cluster *appsv1alpha1.Cluster, now time.Time) (time.Time, bool) {
+	if cluster == nil {
+		return time.Time{}, false
+	}
+	start, _, found := cluster.Spec.GetMaintenanceWindow(now)
+	if !found || !now.Before(start) {
+		return time.Time{}, false
+	}
+	opsRecords, _ := opsutil.GetOpsRequestSliceFromCluster(cluster)
+	for _, record := range opsRecords {
+		if record.Type == appsv1alpha1.ReconfiguringType && !record.InQueue {
+			return time.Time{}, false
+		}
+	}
+	return start, true
+}
+
 func (r *ReconfigureReconciler) updateConfigCMStatus(reqCtx intctrlutil.RequestCtx, cfg *corev1.ConfigMap, reconfigureType string, result *intctrlutil.Result) (ctrl.Result, error) {
 	configData, err := json.Marshal(cfg.Data)
 	if err != nil {
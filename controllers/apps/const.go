@@ -19,6 +19,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 
 package apps
 
+import "time"
+
 const (
 	// name of our custom finalizer
 	clusterDefinitionFinalizerName   = "clusterdefinition.kubeblocks.io/finalizer"
@@ -26,6 +28,7 @@ const (
 	opsDefinitionFinalizerName       = "opsdefinition.kubeblocks.io/finalizer"
 	componentDefinitionFinalizerName = "componentdefinition.kubeblocks.io/finalizer"
 	componentVersionFinalizerName    = "componentversion.kubeblocks.io/finalizer"
+	compliancePolicyFinalizerName    = "compliancepolicy.kubeblocks.io/finalizer"
 )
 
 const (
@@ -38,3 +41,8 @@ const (
 const (
 	trueVal = "true"
 )
+
+const (
+	// opsHistoryRecheckInterval is the interval to recheck the expired OpsRequest retained in the cluster's ops history.
+	opsHistoryRecheckInterval = 10 * time.Minute
+)
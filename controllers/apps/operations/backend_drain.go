@@ -0,0 +1,369 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+)
+
+const (
+	// drainingOpsAnnotationKey records the OpsRequest which pauses the rolling update of the InstanceSet,
+	// to restart the instances one by one with the connections drained by the proxies of the cluster.
+	drainingOpsAnnotationKey = "ops.kubeblocks.io/draining-by"
+	// drainingInstanceAnnotationKey records the instance whose connections have been drained by the proxies,
+	// the proxies resume routing connections to it once it is restarted and ready.
+	drainingInstanceAnnotationKey = "ops.kubeblocks.io/draining-instance"
+
+	// drainRequeueInterval is the interval to check the instance restarted with draining,
+	// as no events are triggered for the OpsRequest when the instance is ready.
+	drainRequeueInterval = 5 * time.Second
+
+	reasonBackendDraining    = "BackendDraining"
+	reasonBackendDrainFailed = "BackendDrainFailed"
+)
+
+// listDrainProxyComponents returns the ComponentDefinitions of the components which provide the drainBackend action,
+// keyed by the component names.
+func listDrainProxyComponents(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	cluster *appsv1alpha1.Cluster) (map[string]*appsv1alpha1.ComponentDefinition, error) {
+	proxies := map[string]*appsv1alpha1.ComponentDefinition{}
+	for _, compSpec := range cluster.Spec.ComponentSpecs {
+		if compSpec.ComponentDef == "" {
+			continue
+		}
+		compDef, err := component.GetCompDefByName(reqCtx.Ctx, cli, compSpec.ComponentDef)
+		if err != nil {
+			return nil, err
+		}
+		if compDef.Spec.LifecycleActions != nil && compDef.Spec.LifecycleActions.DrainBackend != nil {
+			proxies[compSpec.Name] = compDef
+		}
+	}
+	return proxies, nil
+}
+
+// pauseForDraining pauses the rolling update of the InstanceSets of the components if there are proxies in the cluster
+// to drain the connections to their instances, the instances are restarted one by one by rollWithDraining then.
+// The proxies themselves and the sharding components are rolled by the InstanceSet as usual.
+func pauseForDraining(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource, compNames []string) error {
+	proxies, err := listDrainProxyComponents(reqCtx, cli, opsRes.Cluster)
+	if err != nil || len(proxies) == 0 {
+		return err
+	}
+	for _, compName := range compNames {
+		if _, ok := proxies[compName]; ok || opsRes.Cluster.Spec.GetComponentByName(compName) == nil {
+			continue
+		}
+		its, err := getDrainingInstanceSet(reqCtx, cli, opsRes, compName)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		// the InstanceSet is paused by others, e.g. the node pool migration.
+		if its.Spec.Paused && its.Annotations[drainingOpsAnnotationKey] == "" {
+			continue
+		}
+		if its.Annotations[drainingOpsAnnotationKey] == opsRes.OpsRequest.Name {
+			continue
+		}
+		patch := client.MergeFrom(its.DeepCopy())
+		its.Spec.Paused = true
+		if its.Annotations == nil {
+			its.Annotations = map[string]string{}
+		}
+		// take over the InstanceSet paused by an aborted OpsRequest, the drained instance is kept to be resumed.
+		its.Annotations[drainingOpsAnnotationKey] = opsRes.OpsRequest.Name
+		if err = cli.Patch(reqCtx.Ctx, its, patch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rollWithDraining restarts the instances of the component one by one while its InstanceSet is paused for draining.
+// The proxies drain the connections to an instance before it is deleted, and resume routing connections to it
+// once it is recreated with the updated template and ready. It returns true if the component is not paused for draining
+// or all instances have been rolled.
+func rollWithDraining(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	compName string,
+	templateApplied func(its *workloads.InstanceSet) bool) (bool, error) {
+	its, err := getDrainingInstanceSet(reqCtx, cli, opsRes, compName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	if !its.Spec.Paused || its.Annotations[drainingOpsAnnotationKey] != opsRes.OpsRequest.Name {
+		return true, nil
+	}
+	if its.Generation != its.Status.ObservedGeneration || !templateApplied(its) {
+		// waiting for the updated template to be observed by the InstanceSet
+		return false, nil
+	}
+	proxies, err := listDrainProxyComponents(reqCtx, cli, opsRes.Cluster)
+	if err != nil {
+		return false, err
+	}
+	pods, err := component.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, opsRes.Cluster.Name, compName)
+	if err != nil {
+		return false, err
+	}
+	podList := make([]corev1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		podList = append(podList, *pod)
+	}
+
+	// resume the instance drained in the previous round once it is restarted and ready.
+	if drainingInstance := its.Annotations[drainingInstanceAnnotationKey]; drainingInstance != "" {
+		i := slices.IndexFunc(podList, func(pod corev1.Pod) bool { return pod.Name == drainingInstance })
+		if i < 0 || !isPodRolled(its, &podList[i]) {
+			return false, nil
+		}
+		if err = invokeBackendAction(reqCtx, cli, opsRes, proxies, compName, &podList[i], false); err != nil {
+			return false, err
+		}
+		patch := client.MergeFrom(its.DeepCopy())
+		delete(its.Annotations, drainingInstanceAnnotationKey)
+		return false, cli.Patch(reqCtx.Ctx, its, patch)
+	}
+
+	// followers are restarted before the leader
+	instanceset.SortPods(podList, instanceset.ComposeRolePriorityMap(its.Spec.Roles), false)
+	var next *corev1.Pod
+	for i := range podList {
+		pod := &podList[i]
+		if pod.DeletionTimestamp != nil || !intctrlutil.PodIsReady(pod) {
+			// waiting for the unavailable instance to be ready
+			return false, nil
+		}
+		if next == nil && !isPodRolled(its, pod) {
+			next = pod
+		}
+	}
+	if next == nil {
+		return true, releaseDraining(reqCtx, cli, opsRes, compName)
+	}
+
+	if err = invokeBackendAction(reqCtx, cli, opsRes, proxies, compName, next, true); err != nil {
+		// the instance is restarted without draining, as it is before.
+		opsRes.Recorder.Eventf(opsRes.OpsRequest, corev1.EventTypeWarning, reasonBackendDrainFailed,
+			"failed to drain the connections to pod %s: %s", next.Name, err.Error())
+	}
+	patch := client.MergeFrom(its.DeepCopy())
+	its.Annotations[drainingInstanceAnnotationKey] = next.Name
+	if err = cli.Patch(reqCtx.Ctx, its, patch); err != nil {
+		return false, err
+	}
+	// the pod is recreated by the InstanceSet with the updated template.
+	if err = intctrlutil.BackgroundDeleteObject(cli, reqCtx.Ctx, next); err != nil {
+		return false, err
+	}
+	opsRes.Recorder.Eventf(opsRes.OpsRequest, corev1.EventTypeNormal, reasonBackendDraining,
+		"Restarting pod %s after its connections are drained by the proxies", next.Name)
+	return false, nil
+}
+
+// handleDrainingResult keeps the OpsRequest running until all instances are rolled with draining, as the progress
+// may be completed before the proxies resume routing connections to the last instance.
+// The InstanceSets paused for draining are released once the OpsRequest is completed.
+func handleDrainingResult(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	compNames []string,
+	drainPending bool,
+	phase appsv1alpha1.OpsPhase,
+	requeueAfter time.Duration,
+	err error) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	if err != nil {
+		return phase, requeueAfter, err
+	}
+	if drainPending && phase != appsv1alpha1.OpsFailedPhase {
+		if phase == appsv1alpha1.OpsSucceedPhase || phase == appsv1alpha1.OpsSucceedWithWarningsPhase {
+			phase = appsv1alpha1.OpsRunningPhase
+		}
+		if phase == appsv1alpha1.OpsRunningPhase && (requeueAfter == 0 || requeueAfter > drainRequeueInterval) {
+			requeueAfter = drainRequeueInterval
+		}
+		return phase, requeueAfter, nil
+	}
+	if !slices.Contains([]appsv1alpha1.OpsPhase{appsv1alpha1.OpsSucceedPhase,
+		appsv1alpha1.OpsSucceedWithWarningsPhase, appsv1alpha1.OpsFailedPhase}, phase) {
+		return phase, requeueAfter, nil
+	}
+	for _, compName := range compNames {
+		if err = releaseDraining(reqCtx, cli, opsRes, compName); err != nil {
+			return opsRes.OpsRequest.Status.Phase, 0, err
+		}
+	}
+	return phase, requeueAfter, nil
+}
+
+// terminateDraining resumes the instance being drained and releases the InstanceSets paused for draining
+// by the OpsRequest when it times out, as handleDrainingResult is never reached then.
+func terminateDraining(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource, compNames []string) error {
+	proxies, err := listDrainProxyComponents(reqCtx, cli, opsRes.Cluster)
+	if err != nil {
+		return err
+	}
+	for _, compName := range compNames {
+		its, err := getDrainingInstanceSet(reqCtx, cli, opsRes, compName)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if its.Annotations[drainingOpsAnnotationKey] != opsRes.OpsRequest.Name {
+			continue
+		}
+		if drainingInstance := its.Annotations[drainingInstanceAnnotationKey]; drainingInstance != "" && len(proxies) > 0 {
+			pod := &corev1.Pod{}
+			if err = cli.Get(reqCtx.Ctx, client.ObjectKey{Name: drainingInstance, Namespace: its.Namespace}, pod); err == nil {
+				err = invokeBackendAction(reqCtx, cli, opsRes, proxies, compName, pod, false)
+			}
+			if err != nil {
+				opsRes.Recorder.Eventf(opsRes.OpsRequest, corev1.EventTypeWarning, reasonBackendDrainFailed,
+					"failed to resume the connections to pod %s: %s", drainingInstance, err.Error())
+			}
+		}
+		if err = releaseInstanceSetDraining(reqCtx, cli, its, opsRes.OpsRequest.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReleaseDrainingInstanceSets releases the InstanceSets of the cluster paused for draining by the OpsRequest,
+// it is called when the OpsRequest is deleted, e.g. after it is aborted by a later OpsRequest.
+func ReleaseDrainingInstanceSets(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *appsv1alpha1.OpsRequest) error {
+	itsList := &workloads.InstanceSetList{}
+	if err := cli.List(reqCtx.Ctx, itsList, client.InNamespace(opsRequest.Namespace),
+		client.MatchingLabels{constant.AppInstanceLabelKey: opsRequest.Spec.GetClusterName()}); err != nil {
+		return err
+	}
+	for i := range itsList.Items {
+		if err := releaseInstanceSetDraining(reqCtx, cli, &itsList.Items[i], opsRequest.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseDraining resumes the rolling update of the InstanceSet paused for draining by the OpsRequest.
+func releaseDraining(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource, compName string) error {
+	its, err := getDrainingInstanceSet(reqCtx, cli, opsRes, compName)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	return releaseInstanceSetDraining(reqCtx, cli, its, opsRes.OpsRequest.Name)
+}
+
+func releaseInstanceSetDraining(reqCtx intctrlutil.RequestCtx, cli client.Client, its *workloads.InstanceSet, opsName string) error {
+	if its.Annotations[drainingOpsAnnotationKey] != opsName {
+		return nil
+	}
+	patch := client.MergeFrom(its.DeepCopy())
+	its.Spec.Paused = false
+	delete(its.Annotations, drainingOpsAnnotationKey)
+	delete(its.Annotations, drainingInstanceAnnotationKey)
+	return cli.Patch(reqCtx.Ctx, its, patch)
+}
+
+// invokeBackendAction invokes the drainBackend or resumeBackend action on the ready instances of the proxies.
+func invokeBackendAction(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	proxies map[string]*appsv1alpha1.ComponentDefinition,
+	compName string,
+	backend *corev1.Pod,
+	drain bool) error {
+	podFQDN := fmt.Sprintf("%s.%s.%s.svc.%s", backend.Name, backend.Spec.Subdomain, backend.Namespace,
+		viper.GetString(constant.KubernetesClusterDomainEnv))
+	for proxyName, compDef := range proxies {
+		if !drain && compDef.Spec.LifecycleActions.ResumeBackend == nil {
+			continue
+		}
+		proxyPods, err := component.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, opsRes.Cluster.Name, proxyName)
+		if err != nil {
+			return err
+		}
+		for _, pod := range proxyPods {
+			// the instances which are not ready load the backends from scratch when they are started.
+			if !intctrlutil.PodIsReady(pod) {
+				continue
+			}
+			lorryCli, err := lorry.NewClient(*pod)
+			if err != nil {
+				return err
+			}
+			if intctrlutil.IsNil(lorryCli) {
+				return fmt.Errorf("the lorry client of the proxy pod %s is not available", pod.Name)
+			}
+			if drain {
+				err = lorryCli.DrainBackend(reqCtx.Ctx, compName, backend.Name, podFQDN)
+			} else {
+				err = lorryCli.ResumeBackend(reqCtx.Ctx, compName, backend.Name, podFQDN)
+			}
+			if err != nil {
+				return fmt.Errorf("proxy pod %s: %s", pod.Name, err.Error())
+			}
+		}
+	}
+	return nil
+}
+
+func getDrainingInstanceSet(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource, compName string) (*workloads.InstanceSet, error) {
+	its := &workloads.InstanceSet{}
+	itsName := constant.GenerateWorkloadNamePattern(opsRes.Cluster.Name, compName)
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: itsName, Namespace: opsRes.Cluster.Namespace}, its); err != nil {
+		return nil, err
+	}
+	return its, nil
+}
+
+// isPodRolled checks whether the pod has been recreated with the updated template of the InstanceSet and is ready.
+func isPodRolled(its *workloads.InstanceSet, pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil || !intctrlutil.PodIsReady(pod) {
+		return false
+	}
+	updated, err := instanceset.IsPodUpdated(its, pod)
+	return err == nil && updated
+}
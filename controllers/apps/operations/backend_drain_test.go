@@ -0,0 +1,86 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("Restart OpsRequest with the backend draining", func() {
+	var (
+		randomStr   = testCtx.GetRandomStr()
+		clusterName = "cluster-for-ops-" + randomStr
+	)
+
+	newOpsRes := func() *OpsResource {
+		ops := testapps.NewOpsRequestObj("restart-ops-"+randomStr, testCtx.DefaultNamespace,
+			clusterName, appsv1alpha1.RestartType)
+		ops.Status.StartTimestamp = metav1.NewTime(time.Now().Truncate(time.Second))
+		return &OpsResource{OpsRequest: ops}
+	}
+
+	It("checks whether the pod template is restarted by the opsRequest", func() {
+		opsRes := newOpsRes()
+		startTimestamp := opsRes.OpsRequest.Status.StartTimestamp
+		podTemplate := &corev1.PodTemplateSpec{}
+		r := restartOpsHandler{}
+		Expect(r.isTemplateRestarted(opsRes, podTemplate)).Should(BeFalse())
+
+		By("expect the template restarted by an earlier opsRequest is not restarted")
+		podTemplate.Annotations = map[string]string{
+			constant.RestartAnnotationKey: startTimestamp.Add(-time.Hour).Format(time.RFC3339),
+		}
+		Expect(r.isTemplateRestarted(opsRes, podTemplate)).Should(BeFalse())
+
+		podTemplate.Annotations[constant.RestartAnnotationKey] = startTimestamp.Format(time.RFC3339)
+		Expect(r.isTemplateRestarted(opsRes, podTemplate)).Should(BeTrue())
+	})
+
+	It("keeps the opsRequest running until the last instance is resumed", func() {
+		reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx, Log: logf.FromContext(testCtx.Ctx)}
+		opsRes := newOpsRes()
+
+		phase, requeueAfter, err := handleDrainingResult(reqCtx, k8sClient, opsRes, nil, true, appsv1alpha1.OpsSucceedPhase, 0, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(phase).Should(Equal(appsv1alpha1.OpsRunningPhase))
+		Expect(requeueAfter).Should(Equal(drainRequeueInterval))
+
+		phase, requeueAfter, err = handleDrainingResult(reqCtx, k8sClient, opsRes, nil, true, appsv1alpha1.OpsRunningPhase, time.Second, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(phase).Should(Equal(appsv1alpha1.OpsRunningPhase))
+		Expect(requeueAfter).Should(Equal(time.Second))
+
+		phase, requeueAfter, err = handleDrainingResult(reqCtx, k8sClient, opsRes, nil, false, appsv1alpha1.OpsRunningPhase, 0, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(phase).Should(Equal(appsv1alpha1.OpsRunningPhase))
+		Expect(requeueAfter).Should(BeZero())
+	})
+})
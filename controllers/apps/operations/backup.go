@@ -24,6 +24,7 @@ import (
 	"strings"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -33,6 +34,7 @@ import (
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/kberrors"
 )
 
 const backupTimeLayout = "20060102150405"
@@ -46,7 +48,10 @@ func init() {
 	backupBehaviour := OpsBehaviour{
 		FromClusterPhases: []appsv1alpha1.ClusterPhase{appsv1alpha1.RunningClusterPhase,
 			appsv1alpha1.UpdatingClusterPhase, appsv1alpha1.AbnormalClusterPhase},
-		OpsHandler: BackupOpsHandler{},
+		// backups of the same cluster are performed one by one.
+		QueueBySelf: true,
+		CancelFunc:  BackupOpsHandler{}.Cancel,
+		OpsHandler:  BackupOpsHandler{},
 	}
 
 	opsMgr := GetOpsManager()
@@ -73,32 +78,62 @@ func (b BackupOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clien
 }
 
 // ReconcileAction implements the backup reconcile action.
-// It will check the backup status and update the OpsRequest status.
+// It mirrors the progress of the backup into the OpsRequest status.
 // If the backup is completed, it will return OpsSuccess
 // If the backup is failed, it will return OpsFailed
 func (b BackupOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
 	opsRequest := opsRes.OpsRequest
-	cluster := opsRes.Cluster
+	oldOpsRequest := opsRequest.DeepCopy()
 
-	// get backup
-	backups := &dpv1alpha1.BackupList{}
-	if err := cli.List(reqCtx.Ctx, backups, client.InNamespace(cluster.Namespace), client.MatchingLabels(getBackupLabels(cluster.Name, opsRequest.Name))); err != nil {
-		return appsv1alpha1.OpsFailedPhase, 0, err
+	backup, err := getOpsBackup(reqCtx, cli, opsRes.Cluster, opsRequest)
+	if err != nil {
+		return opsRequest.Status.Phase, 0, err
 	}
-
-	if len(backups.Items) == 0 {
+	if opsRequest.Status.Phase == appsv1alpha1.OpsCancellingPhase {
+		// the OpsRequest is cancelled once the backup is deleted.
+		if backup == nil {
+			return appsv1alpha1.OpsSucceedPhase, 0, nil
+		}
+		return opsRequest.Status.Phase, 0, nil
+	}
+	if backup == nil {
 		return appsv1alpha1.OpsFailedPhase, 0, fmt.Errorf("backup not found")
 	}
+
 	// check backup status
-	phase := backups.Items[0].Status.Phase
-	if phase == dpv1alpha1.BackupPhaseCompleted {
+	completedCount := 0
+	phase := backup.Status.Phase
+	if phase == dpv1alpha1.BackupPhaseCompleted || phase == dpv1alpha1.BackupPhaseFailed {
+		completedCount = 1
+	}
+	setBackupProgressCondition(opsRequest, backup)
+	if err = syncProgressToOpsRequest(reqCtx, cli, opsRes, oldOpsRequest, completedCount, 1); err != nil {
+		return opsRequest.Status.Phase, 0, err
+	}
+	switch phase {
+	case dpv1alpha1.BackupPhaseCompleted:
 		return appsv1alpha1.OpsSucceedPhase, 0, nil
-	} else if phase == dpv1alpha1.BackupPhaseFailed {
+	case dpv1alpha1.BackupPhaseFailed:
+		if backup.Status.FailureReason != "" {
+			return appsv1alpha1.OpsFailedPhase, 0, fmt.Errorf("backup failed: %s", backup.Status.FailureReason)
+		}
 		return appsv1alpha1.OpsFailedPhase, 0, fmt.Errorf("backup failed")
 	}
 	return appsv1alpha1.OpsRunningPhase, 0, nil
 }
 
+// Cancel deletes the backup created by the OpsRequest if it is not completed yet.
+func (b BackupOpsHandler) Cancel(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	backup, err := getOpsBackup(reqCtx, cli, opsRes.Cluster, opsRes.OpsRequest)
+	if err != nil || backup == nil {
+		return err
+	}
+	if backup.Status.Phase == dpv1alpha1.BackupPhaseCompleted {
+		return intctrlutil.NewErrorf(intctrlutil.ErrorIgnoreCancel, "backup %s has been completed", backup.Name)
+	}
+	return intctrlutil.BackgroundDeleteObject(cli, reqCtx.Ctx, backup)
+}
+
 // SaveLastConfiguration records last configuration to the OpsRequest.status.lastConfiguration
 func (b BackupOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
 	return nil
@@ -131,12 +166,14 @@ func buildBackup(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *a
 	defaultBackupMethod, backupMethodMap := utils.GetBackupMethodsFromBackupPolicy(backupPolicyList, backupSpec.BackupPolicyName)
 	if backupSpec.BackupMethod == "" {
 		if defaultBackupMethod == "" {
-			return nil, fmt.Errorf("failed to find default backup method, please check cluster's backup policy")
+			return nil, kberrors.New(kberrors.ReasonBackupMethodNotFound, "failed to find default backup method").
+				WithHint("check the backup policies of the cluster by command: kubectl get backuppolicy -n %s -l %s=%s", cluster.Namespace, constant.AppInstanceLabelKey, cluster.Name)
 		}
 		backupSpec.BackupMethod = defaultBackupMethod
 	}
 	if _, ok := backupMethodMap[backupSpec.BackupMethod]; !ok {
-		return nil, fmt.Errorf("backup method %s is not supported, please check cluster's backup policy", backupSpec.BackupMethod)
+		return nil, kberrors.New(kberrors.ReasonBackupMethodNotFound, "backup method %s is not supported", backupSpec.BackupMethod).
+			WithHint("check the backup policies of the cluster by command: kubectl get backuppolicy -n %s -l %s=%s", cluster.Namespace, constant.AppInstanceLabelKey, cluster.Name)
 	}
 
 	backup := &dpv1alpha1.Backup{
@@ -180,6 +217,36 @@ func buildBackup(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *a
 	return backup, nil
 }
 
+// getOpsBackup gets the backup created by the OpsRequest, it returns nil if the backup does not exist.
+func getOpsBackup(reqCtx intctrlutil.RequestCtx, cli client.Client, cluster *appsv1alpha1.Cluster, opsRequest *appsv1alpha1.OpsRequest) (*dpv1alpha1.Backup, error) {
+	backups := &dpv1alpha1.BackupList{}
+	if err := cli.List(reqCtx.Ctx, backups, client.InNamespace(cluster.Namespace), client.MatchingLabels(getBackupLabels(cluster.Name, opsRequest.Name))); err != nil {
+		return nil, err
+	}
+	if len(backups.Items) == 0 {
+		return nil, nil
+	}
+	return &backups.Items[0], nil
+}
+
+// setBackupProgressCondition mirrors the phase of the backup into the backup condition of the OpsRequest.
+func setBackupProgressCondition(opsRequest *appsv1alpha1.OpsRequest, backup *dpv1alpha1.Backup) {
+	phase := backup.Status.Phase
+	if phase == "" {
+		phase = dpv1alpha1.BackupPhaseNew
+	}
+	message := fmt.Sprintf("Backup %s is %s", backup.Name, phase)
+	if phase == dpv1alpha1.BackupPhaseFailed && backup.Status.FailureReason != "" {
+		message = fmt.Sprintf("%s: %s", message, backup.Status.FailureReason)
+	}
+	meta.SetStatusCondition(&opsRequest.Status.Conditions, metav1.Condition{
+		Type:    appsv1alpha1.ConditionTypeBackup,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Backup" + string(phase),
+		Message: message,
+	})
+}
+
 func getDefaultBackupPolicy(reqCtx intctrlutil.RequestCtx, cli client.Client, cluster *appsv1alpha1.Cluster, backupPolicy string) (string, error) {
 	// if backupPolicy is not empty, return it directly
 	if backupPolicy != "" {
@@ -31,10 +31,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/controllers/apps/operations/custom"
 	"github.com/apecloud/kubeblocks/pkg/common"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/openapischema"
 )
 
 type CustomOpsHandler struct{}
@@ -42,8 +44,11 @@ type CustomOpsHandler struct{}
 var _ OpsHandler = CustomOpsHandler{}
 
 func init() {
+	customHandler := CustomOpsHandler{}
 	customBehaviour := OpsBehaviour{
-		OpsHandler: CustomOpsHandler{},
+		OpsHandler:    customHandler,
+		CancelFunc:    customHandler.Cancel,
+		TerminateFunc: customHandler.Terminate,
 	}
 
 	opsMgr := GetOpsManager()
@@ -120,6 +125,45 @@ func (c CustomOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, c
 	return nil
 }
 
+// Cancel terminates the in-flight action tasks, the pending actions are skipped,
+// and the output of the terminated tasks is captured when they stop.
+func (c CustomOpsHandler) Cancel(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return c.terminateActionTasks(reqCtx, cli, opsRes, false, "terminated as the OpsRequest is cancelled")
+}
+
+// Terminate terminates the in-flight action tasks and fails their actions when the opsRequest times out.
+func (c CustomOpsHandler) Terminate(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return c.terminateActionTasks(reqCtx, cli, opsRes, true, "terminated as the OpsRequest timed out")
+}
+
+func (c CustomOpsHandler) terminateActionTasks(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	failActions bool,
+	message string) error {
+	for compName, compStatus := range opsRes.OpsRequest.Status.Components {
+		for i := range compStatus.ProgressDetails {
+			progressDetail := &compStatus.ProgressDetails[i]
+			if progressDetail.ActionName == "" || progressDetail.Status != appsv1alpha1.ProcessingProgressStatus {
+				continue
+			}
+			for j := range progressDetail.ActionTasks {
+				if err := custom.TerminateActionTask(reqCtx.Ctx, cli, &progressDetail.ActionTasks[j], message); err != nil {
+					return err
+				}
+			}
+			if failActions {
+				failedDetail := *progressDetail.DeepCopy()
+				failedDetail.SetStatusAndMessage(appsv1alpha1.FailedProgressStatus,
+					fmt.Sprintf(`the action "%s" of the component "%s" is %s`, progressDetail.ActionName, compName, message))
+				setComponentStatusProgressDetail(reqCtx.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, failedDetail)
+			}
+		}
+		opsRes.OpsRequest.Status.Components[compName] = compStatus
+	}
+	return nil
+}
+
 func (c CustomOpsHandler) listComponents(reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
 	cluster *appsv1alpha1.Cluster,
@@ -248,16 +292,9 @@ func initOpsDefAndValidate(reqCtx intctrlutil.RequestCtx,
 		return nil
 	}
 	for _, v := range customSpec.CustomOpsComponents {
-		// covert to type map[string]interface{}
-		params, err := common.CoverStringToInterfaceBySchemaType(parametersSchema.OpenAPIV3Schema, covertParametersToMap(v.Parameters))
-		if err != nil {
+		if err := openapischema.ValidateParameters(parametersSchema.OpenAPIV3Schema, covertParametersToMap(v.Parameters)); err != nil {
 			return err
 		}
-		if parametersSchema != nil && parametersSchema.OpenAPIV3Schema != nil {
-			if err = common.ValidateDataWithSchema(parametersSchema.OpenAPIV3Schema, params); err != nil {
-				return err
-			}
-		}
 
 		// 2. validate component and componentDef
 		if len(opsRes.OpsDef.Spec.ComponentInfos) > 0 {
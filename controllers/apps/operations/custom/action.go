@@ -20,17 +20,30 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package custom
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
 )
 
+const (
+	// maxTaskMessageLength is the maximum length of the message of the action task.
+	maxTaskMessageLength = 1024
+
+	// terminatingDeadlineSeconds is the active deadline set to the workload of the terminated task.
+	terminatingDeadlineSeconds int64 = 1
+)
+
 type OpsAction interface {
 	// Execute executes the action.
 	Execute(actionCtx ActionContext) (*ActionStatus, error)
@@ -101,7 +114,9 @@ func (actionCtx ActionContext) checkActionStatus(progressDetail appsv1alpha1.Pro
 		completedCount += 1
 		if failed {
 			existFailed = true
-			tasks[i].Status = appsv1alpha1.FailedActionTaskStatus
+			if tasks[i].Status != appsv1alpha1.TerminatedActionTaskStatus {
+				tasks[i].Status = appsv1alpha1.FailedActionTaskStatus
+			}
 		} else {
 			tasks[i].Status = appsv1alpha1.SucceedActionTaskStatus
 		}
@@ -128,6 +143,22 @@ func (actionCtx ActionContext) checkPodTaskStatus(
 		existFailure = true
 	case appsv1alpha1.SucceedActionTaskStatus:
 		completed = true
+	case appsv1alpha1.TerminatedActionTaskStatus:
+		// wait for the terminated pod to stop, and capture its output.
+		pod := &corev1.Pod{}
+		err = actionCtx.Client.Get(actionCtx.ReqCtx.Ctx,
+			client.ObjectKey{Name: getNameFromObjectKey(task.ObjectKey), Namespace: task.Namespace}, pod)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return false, false, err
+		}
+		if err == nil && pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+			return false, false, nil
+		}
+		// the task is checked until all tasks of the action are completed, keep the termination reason only.
+		reason, _, _ := strings.Cut(task.Message, "\n")
+		task.Message = buildPodTaskMessage(reason, pod)
+		completed = true
+		existFailure = true
 	default:
 		pod := &corev1.Pod{}
 		err = actionCtx.Client.Get(actionCtx.ReqCtx.Ctx,
@@ -143,6 +174,7 @@ func (actionCtx ActionContext) checkPodTaskStatus(
 		case corev1.PodSucceeded:
 			completed = true
 		case corev1.PodFailed:
+			task.Message = buildPodTaskMessage(pod.Status.Message, pod)
 			if task.Retries < backOffLimit {
 				task.Retries += 1
 				return false, false, createPod()
@@ -154,3 +186,80 @@ func (actionCtx ActionContext) checkPodTaskStatus(
 	}
 	return completed, existFailure, nil
 }
+
+// TerminateActionTask terminates the workload of the task if it is still running, and marks the task as Terminated.
+// The workload is terminated by shortening its active deadline, so that its containers receive SIGTERM,
+// and are killed after the termination grace period, the output of them is captured in the termination message.
+func TerminateActionTask(ctx context.Context, cli client.Client, task *appsv1alpha1.ActionTask, message string) error {
+	if task.Status != appsv1alpha1.ProcessingActionTaskStatus {
+		return nil
+	}
+	var (
+		obj client.Object
+		key = client.ObjectKey{Name: getNameFromObjectKey(task.ObjectKey), Namespace: task.Namespace}
+	)
+	switch getKindFromObjectKey(task.ObjectKey) {
+	case constant.JobKind:
+		obj = &batchv1.Job{}
+	default:
+		obj = &corev1.Pod{}
+	}
+	if err := cli.Get(ctx, key, obj); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+	} else if err = terminateWorkload(ctx, cli, obj); err != nil {
+		return err
+	}
+	task.Status = appsv1alpha1.TerminatedActionTaskStatus
+	task.Message = message
+	return nil
+}
+
+func terminateWorkload(ctx context.Context, cli client.Client, obj client.Object) error {
+	// the active deadline is counted from the start time of the workload, and can only be shortened.
+	isTerminating := func(activeDeadlineSeconds *int64) bool {
+		return activeDeadlineSeconds != nil && *activeDeadlineSeconds <= terminatingDeadlineSeconds
+	}
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	switch o := obj.(type) {
+	case *batchv1.Job:
+		if o.Status.CompletionTime != nil || isTerminating(o.Spec.ActiveDeadlineSeconds) {
+			return nil
+		}
+		o.Spec.ActiveDeadlineSeconds = pointer.Int64(terminatingDeadlineSeconds)
+	case *corev1.Pod:
+		if o.Status.Phase == corev1.PodSucceeded || o.Status.Phase == corev1.PodFailed ||
+			isTerminating(o.Spec.ActiveDeadlineSeconds) {
+			return nil
+		}
+		o.Spec.ActiveDeadlineSeconds = pointer.Int64(terminatingDeadlineSeconds)
+	}
+	return client.IgnoreNotFound(cli.Patch(ctx, obj, patch))
+}
+
+// buildPodTaskMessage builds the message of the failed task with the reason and the termination messages
+// of the pod containers.
+func buildPodTaskMessage(reason string, pod *corev1.Pod) string {
+	var messages []string
+	if reason != "" {
+		messages = append(messages, reason)
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		terminated := status.State.Terminated
+		if terminated == nil || terminated.Message == "" {
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("container %s exited with code %d: %s",
+			status.Name, terminated.ExitCode, strings.TrimSpace(terminated.Message)))
+	}
+	return truncateTaskMessage(strings.Join(messages, "\n"))
+}
+
+// truncateTaskMessage keeps the tail of the message, which is more meaningful for the failed task.
+func truncateTaskMessage(message string) string {
+	if len(message) <= maxTaskMessageLength {
+		return message
+	}
+	return "..." + message[len(message)-maxTaskMessageLength:]
+}
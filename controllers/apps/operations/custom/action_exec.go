@@ -20,7 +20,10 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package custom
 
 import (
+	"strconv"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
@@ -29,6 +32,41 @@ import (
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
+const (
+	// execActionKillTimeoutSeconds is the time the remote command has to exit after SIGTERM before it is killed.
+	execActionKillTimeoutSeconds = 10
+
+	// execActionTerminationGracePeriodSeconds must be greater than execActionKillTimeoutSeconds,
+	// so that the exec pod is able to kill the remote command before it is killed itself.
+	execActionTerminationGracePeriodSeconds int64 = 30
+)
+
+// execActionScript runs the command in the target container by 'kubectl exec', and records the pid of the remote
+// process in the target container. As 'kubectl exec' does not propagate the signals to the remote process,
+// the script sends SIGTERM to the remote process when the exec pod is terminating, and kills it with SIGKILL
+// if it does not exit within the kill timeout.
+//
+// the arguments are: <namespace> <pod> <container> <kill timeout seconds> <command>...
+const execActionScript = `namespace="$1"; pod="$2"; container="$3"; timeout="$4"; shift 4
+pidfile="/tmp/kb-ops-action-$(hostname).pid"
+terminate() {
+  kubectl -n "${namespace}" exec "${pod}" -c "${container}" -- sh -c '
+pid=$(cat "$1" 2>/dev/null) || exit 0
+kill -TERM "${pid}" 2>/dev/null
+i=0
+while [ "${i}" -lt "$2" ] && kill -0 "${pid}" 2>/dev/null; do sleep 1; i=$((i+1)); done
+kill -KILL "${pid}" 2>/dev/null
+rm -f "$1"' sh "${pidfile}" "${timeout}"
+  exit 143
+}
+trap terminate TERM INT
+kubectl -n "${namespace}" exec "${pod}" -c "${container}" -- sh -c 'echo $$ >"$0"; exec "$@"' "${pidfile}" "$@" &
+wait $!
+code=$?
+kubectl -n "${namespace}" exec "${pod}" -c "${container}" -- rm -f "${pidfile}" >/dev/null 2>&1
+exit ${code}
+`
+
 type ExecAction struct {
 	OpsRequest     *appsv1alpha1.OpsRequest
 	Cluster        *appsv1alpha1.Cluster
@@ -155,22 +193,22 @@ func (e *ExecAction) buildExecPodSpec(actionCtx ActionContext,
 		Name:            actionCtx.Action.Name,
 		Image:           viper.GetString(constant.KBToolsImage),
 		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
-		Command:         []string{"kubectl"},
+		Command:         []string{"sh", "-c", execActionScript, "kb-ops-exec"},
 		Env:             env,
 		Args: append([]string{
-			"-n",
 			targetPod.Namespace,
-			"exec",
 			targetPod.Name,
-			"-c",
 			containerName,
-			"--",
+			strconv.Itoa(execActionKillTimeoutSeconds),
 		}, execAction.Command...),
 	}
 	intctrlutil.InjectZeroResourcesLimitsIfEmpty(container)
-	return &corev1.PodSpec{
+	podSpec := &corev1.PodSpec{
 		Containers: []corev1.Container{*container},
 		// tolerate all taints
-		Tolerations: e.Comp.Tolerations,
-	}, nil
+		Tolerations:                   e.Comp.Tolerations,
+		TerminationGracePeriodSeconds: pointer.Int64(execActionTerminationGracePeriodSeconds),
+	}
+	setActionPodTimeoutAndOutput(actionCtx.Action, podSpec)
+	return podSpec, nil
 }
@@ -177,6 +177,7 @@ func (w *WorkloadAction) buildPodSpec(actionCtx ActionContext,
 	if len(podSpec.Tolerations) == 0 {
 		podSpec.Tolerations = w.Comp.Tolerations
 	}
+	setActionPodTimeoutAndOutput(actionCtx.Action, &podSpec)
 	switch {
 	case w.OpsRequest.Spec.CustomOps.ServiceAccountName != nil:
 		// prioritize using the input sa.
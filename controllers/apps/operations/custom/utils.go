@@ -29,6 +29,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/util/jsonpath"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -369,6 +370,19 @@ func getTargetPods(
 	return targetPods, nil
 }
 
+// setActionPodTimeoutAndOutput sets the timeout of each attempt of the action, and makes the containers
+// fall back to the tail of their logs as the termination message when they fail.
+func setActionPodTimeoutAndOutput(action *appsv1alpha1.OpsAction, podSpec *corev1.PodSpec) {
+	if action.TimeoutSeconds > 0 && podSpec.ActiveDeadlineSeconds == nil {
+		podSpec.ActiveDeadlineSeconds = pointer.Int64(int64(action.TimeoutSeconds))
+	}
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].TerminationMessagePolicy == "" {
+			podSpec.Containers[i].TerminationMessagePolicy = corev1.TerminationMessageFallbackToLogsOnError
+		}
+	}
+}
+
 func buildLabels(clusterName, opsName, compName, actionName string) map[string]string {
 	return map[string]string{
 		constant.AppInstanceLabelKey:    clusterName,
@@ -379,6 +393,14 @@ func buildLabels(clusterName, opsName, compName, actionName string) map[string]s
 	}
 }
 
+func getKindFromObjectKey(objectKey string) string {
+	strs := strings.Split(objectKey, "/")
+	if len(strs) == 2 {
+		return strs[0]
+	}
+	return ""
+}
+
 func getNameFromObjectKey(objectKey string) string {
 	strs := strings.Split(objectKey, "/")
 	if len(strs) == 2 {
@@ -78,6 +78,19 @@ func (w *WorkloadAction) checkJobStatus(actionCtx ActionContext, task *appsv1alp
 		existFailure = true
 	case appsv1alpha1.SucceedActionTaskStatus:
 		completed = true
+	case appsv1alpha1.TerminatedActionTaskStatus:
+		// wait for the terminated job to stop.
+		job := &batchv1.Job{}
+		err = actionCtx.Client.Get(actionCtx.ReqCtx.Ctx,
+			client.ObjectKey{Name: getNameFromObjectKey(task.ObjectKey), Namespace: task.Namespace}, job)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return false, false, err
+		}
+		if err == nil && job.Status.Active > 0 {
+			return false, false, nil
+		}
+		completed = true
+		existFailure = true
 	default:
 		job := &batchv1.Job{}
 		err = actionCtx.Client.Get(actionCtx.ReqCtx.Ctx,
@@ -100,6 +113,7 @@ func (w *WorkloadAction) checkJobStatus(actionCtx ActionContext, task *appsv1alp
 			if c.Type == batchv1.JobFailed {
 				completed = true
 				existFailure = true
+				task.Message = truncateTaskMessage(c.Message)
 			}
 		}
 	}
@@ -20,6 +20,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	batchv1 "k8s.io/api/batch/v1"
@@ -210,5 +212,47 @@ var _ = Describe("CustomOps", func() {
 			Expect(opsResource.OpsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsSucceedPhase))
 		})
 
+		It("Test custom ops when the opsRequest is cancelled", func() {
+			By("create custom Ops")
+			params := []appsv1alpha1.Parameter{
+				{Name: "sql", Value: "select 1"},
+			}
+			ops := createCustomOps(consensusComp, params)
+
+			By("mock component is Running")
+			Expect(testapps.ChangeObjStatus(&testCtx, compObj, func() {
+				compObj.Status.Phase = appsv1alpha1.RunningClusterCompPhase
+			})).Should(Succeed())
+
+			By("job should be created successfully")
+			_, err := GetOpsManager().Reconcile(reqCtx, k8sClient, opsResource)
+			Expect(err).ShouldNot(HaveOccurred())
+			jobList := &batchv1.JobList{}
+			Expect(k8sClient.List(ctx, jobList, client.MatchingLabels{constant.OpsRequestNameLabelKey: ops.Name},
+				client.InNamespace(ops.Namespace))).Should(Succeed())
+			Expect(len(jobList.Items)).Should(Equal(1))
+
+			By("cancel the opsRequest, expect the job to be terminated")
+			cancelOpsRequest(reqCtx, opsResource, time.Now())
+			actionTask := opsResource.OpsRequest.Status.Components[consensusComp].ProgressDetails[0].ActionTasks[0]
+			Expect(actionTask.Status).Should(Equal(appsv1alpha1.TerminatedActionTaskStatus))
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(&jobList.Items[0]), func(g Gomega, job *batchv1.Job) {
+				g.Expect(job.Spec.ActiveDeadlineSeconds).ShouldNot(BeNil())
+				g.Expect(*job.Spec.ActiveDeadlineSeconds).Should(BeEquivalentTo(1))
+			})).Should(Succeed())
+
+			By("reconcile once and make the action fail as the job is stopped")
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsResource)
+			Expect(err).ShouldNot(HaveOccurred())
+			progressDetail := opsResource.OpsRequest.Status.Components[consensusComp].ProgressDetails[0]
+			Expect(progressDetail.Status).Should(Equal(appsv1alpha1.FailedProgressStatus))
+			Expect(progressDetail.ActionTasks[0].Status).Should(Equal(appsv1alpha1.TerminatedActionTaskStatus))
+			Expect(progressDetail.ActionTasks[0].Message).Should(ContainSubstring("cancelled"))
+
+			By("reconcile again and make the opsRequest cancelled")
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsResource)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsResource.OpsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsCancelledPhase))
+		})
 	})
 })
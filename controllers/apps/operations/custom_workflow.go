@@ -86,6 +86,11 @@ steps:
 		}
 		switch actionProgress.Status {
 		case appsv1alpha1.PendingProgressStatus:
+			if w.OpsRes.OpsRequest.Status.Phase == appsv1alpha1.OpsCancellingPhase {
+				// skip the pending actions as the opsRequest is cancelled.
+				workflowStatus.IsCompleted = true
+				break steps
+			}
 			// execute action and set status progress
 			progressDetail := *actionProgress
 			ac := w.getAction(actions[i], compCustomSpec, compSpec, progressDetail)
@@ -29,6 +29,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -38,6 +39,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/scheduling"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/kberrors"
 	"github.com/apecloud/kubeblocks/pkg/lorry/engines/register"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
@@ -150,18 +152,20 @@ func (o DataScriptOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli
 		opsStatus = appsv1alpha1.OpsFailedPhase
 	}
 
-	patch := client.MergeFrom(opsRequest.DeepCopy())
+	opsDeepCopy := opsRequest.DeepCopy()
 	opsRequest.Status.Progress = fmt.Sprintf("%d/%d", succeedCount, expectedCount)
 
 	// patch OpsRequest.status.components
-	if err := cli.Status().Patch(reqCtx.Ctx, opsRequest, patch); err != nil {
+	if err := PatchOpsRequestStatus(reqCtx.Ctx, cli, opsDeepCopy, opsRequest); err != nil {
 		return opsStatus, time.Second, err
 	}
 
 	if succeedCount == expectedCount {
 		return appsv1alpha1.OpsSucceedPhase, 0, nil
 	} else if failedCount+succeedCount == expectedCount {
-		return appsv1alpha1.OpsFailedPhase, 0, fmt.Errorf("%d job execution failed, please check the job log ", failedCount)
+		return appsv1alpha1.OpsFailedPhase, 0, kberrors.New(kberrors.ReasonJobFailed, "%d job execution failed", failedCount).
+			WithHint("check the job logs by command: kubectl logs -n %s -l %s", cluster.Namespace,
+				labels.SelectorFromSet(getDataScriptJobLabels(cluster.Name, spec.ComponentName, opsRequest.Name)).String())
 	}
 	return appsv1alpha1.OpsRunningPhase, 5 * time.Second, nil
 }
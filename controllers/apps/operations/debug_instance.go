@@ -0,0 +1,236 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	debugContainerNamePrefix = "kb-debug-"
+
+	// the event reasons for auditing the attachment of debug containers
+	reasonDebugContainerAttached = "DebugContainerAttached"
+	reasonDebugContainerFailed   = "DebugContainerFailed"
+)
+
+type debugInstanceOpsHandler struct{}
+
+var _ OpsHandler = debugInstanceOpsHandler{}
+
+func init() {
+	// FromClusterPhases and ToClusterPhase are not defined, because debugging is mostly needed
+	// when the cluster is abnormal, and it does not affect the cluster status.
+	debugInstanceBehaviour := OpsBehaviour{
+		OpsHandler: debugInstanceOpsHandler{},
+	}
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(appsv1alpha1.DebugInstanceType, debugInstanceBehaviour)
+}
+
+// ActionStartedCondition the started condition when handling the debug-instance request.
+func (d debugInstanceOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return appsv1alpha1.NewDebugInstanceCondition(opsRes.OpsRequest), nil
+}
+
+// Action attaches the debug container defined in the ComponentDefinition to the instance as an ephemeral container.
+func (d debugInstanceOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	debugInstance := opsRes.OpsRequest.Spec.DebugInstance
+	pod, err := d.getTargetPod(reqCtx, cli, opsRes, debugInstance)
+	if err != nil {
+		return err
+	}
+	containerName := getDebugContainerName(opsRes.OpsRequest)
+	for _, c := range pod.Spec.EphemeralContainers {
+		if c.Name == containerName {
+			// the debug container has been attached
+			return nil
+		}
+	}
+
+	compName := pod.Labels[constant.KBAppComponentLabelKey]
+	_, compDef, err := component.GetCompNCompDefByName(reqCtx.Ctx, cli, pod.Namespace,
+		constant.GenerateClusterComponentName(opsRes.Cluster.Name, compName))
+	if err != nil {
+		return err
+	}
+	if compDef.Spec.DebugContainer == nil {
+		return intctrlutil.NewFatalError(fmt.Sprintf(`the debug container is not defined in the ComponentDefinition "%s"`, compDef.Name))
+	}
+	ephemeralContainer, err := buildDebugContainer(compDef.Spec.DebugContainer, containerName, pod, debugInstance.TargetContainerName)
+	if err != nil {
+		return err
+	}
+
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, *ephemeralContainer)
+	if err = cli.SubResource("ephemeralcontainers").Update(reqCtx.Ctx, pod); err != nil {
+		return err
+	}
+	message := fmt.Sprintf("attached debug container %s with image %s to pod %s, target container: %s",
+		containerName, ephemeralContainer.Image, pod.Name, ephemeralContainer.TargetContainerName)
+	opsRes.Recorder.Event(opsRes.OpsRequest, corev1.EventTypeNormal, reasonDebugContainerAttached, message)
+	opsRes.Recorder.Eventf(pod, corev1.EventTypeNormal, reasonDebugContainerAttached,
+		"%s, by OpsRequest %s", message, opsRes.OpsRequest.Name)
+	return nil
+}
+
+// ReconcileAction will be performed when action is done and loops till OpsRequest.status.phase is Succeed/Failed.
+// The OpsRequest succeeds once the debug container is running.
+func (d debugInstanceOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	var (
+		debugInstance   = opsRes.OpsRequest.Spec.DebugInstance
+		oldOpsRequest   = opsRes.OpsRequest.DeepCopy()
+		opsRequestPhase = opsRes.OpsRequest.Status.Phase
+		containerName   = getDebugContainerName(opsRes.OpsRequest)
+	)
+	pod := &corev1.Pod{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: debugInstance.InstanceName, Namespace: opsRes.Cluster.Namespace}, pod); err != nil {
+		return opsRequestPhase, 0, err
+	}
+	if opsRes.OpsRequest.Status.Components == nil {
+		opsRes.OpsRequest.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{}
+	}
+	compStatus := opsRes.OpsRequest.Status.Components[debugInstance.ComponentName]
+	progressDetail := appsv1alpha1.ProgressStatusDetail{
+		ObjectKey: getProgressObjectKey(constant.PodKind, pod.Name),
+		Status:    appsv1alpha1.ProcessingProgressStatus,
+		Message:   fmt.Sprintf("Waiting for the debug container %s to be running", containerName),
+	}
+	completedCount := 0
+	for _, status := range pod.Status.EphemeralContainerStatuses {
+		if status.Name != containerName {
+			continue
+		}
+		switch {
+		case status.State.Running != nil, status.State.Terminated != nil && status.State.Terminated.ExitCode == 0:
+			completedCount = 1
+			progressDetail.SetStatusAndMessage(appsv1alpha1.SucceedProgressStatus,
+				fmt.Sprintf("The debug container %s is attached to pod %s", containerName, pod.Name))
+		case status.State.Terminated != nil:
+			completedCount = 1
+			progressDetail.SetStatusAndMessage(appsv1alpha1.FailedProgressStatus,
+				fmt.Sprintf("The debug container %s terminated with exit code %d: %s", containerName,
+					status.State.Terminated.ExitCode, status.State.Terminated.Message))
+		case status.State.Waiting != nil && isImagePullFailed(status.State.Waiting.Reason):
+			completedCount = 1
+			progressDetail.SetStatusAndMessage(appsv1alpha1.FailedProgressStatus,
+				fmt.Sprintf("Failed to pull the image of debug container %s: %s", containerName, status.State.Waiting.Message))
+		}
+	}
+	setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
+	opsRes.OpsRequest.Status.Components[debugInstance.ComponentName] = compStatus
+	if err := syncProgressToOpsRequest(reqCtx, cli, opsRes, oldOpsRequest, completedCount, 1); err != nil {
+		return opsRequestPhase, 0, err
+	}
+	switch progressDetail.Status {
+	case appsv1alpha1.SucceedProgressStatus:
+		return appsv1alpha1.OpsSucceedPhase, 0, nil
+	case appsv1alpha1.FailedProgressStatus:
+		opsRes.Recorder.Event(pod, corev1.EventTypeWarning, reasonDebugContainerFailed, progressDetail.Message)
+		return appsv1alpha1.OpsFailedPhase, 0, nil
+	}
+	return opsRequestPhase, time.Second, nil
+}
+
+func (d debugInstanceOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
+// getTargetPod gets the instance to debug and checks that it belongs to the component.
+func (d debugInstanceOpsHandler) getTargetPod(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	debugInstance *appsv1alpha1.DebugInstance) (*corev1.Pod, error) {
+	pod := &corev1.Pod{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: debugInstance.InstanceName, Namespace: opsRes.Cluster.Namespace}, pod); err != nil {
+		return nil, err
+	}
+	if pod.Labels[constant.AppInstanceLabelKey] != opsRes.Cluster.Name ||
+		(pod.Labels[constant.KBAppComponentLabelKey] != debugInstance.ComponentName &&
+			pod.Labels[constant.KBAppShardingNameLabelKey] != debugInstance.ComponentName) {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf(`the instance "%s" does not belong to the component "%s"`,
+			debugInstance.InstanceName, debugInstance.ComponentName))
+	}
+	if pod.Status.Phase != corev1.PodRunning {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf(`the instance "%s" is not running, the debug container can not be attached`, pod.Name))
+	}
+	return pod, nil
+}
+
+// buildDebugContainer builds the ephemeral container from the debug container defined in the ComponentDefinition.
+func buildDebugContainer(debugContainer *appsv1alpha1.DebugContainer,
+	containerName string,
+	pod *corev1.Pod,
+	targetContainerName string) (*corev1.EphemeralContainer, error) {
+	if targetContainerName == "" {
+		targetContainerName = pod.Spec.Containers[0].Name
+	} else if _, c := intctrlutil.GetContainerByName(pod.Spec.Containers, targetContainerName); c == nil {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf(`the target container "%s" is not found in pod "%s"`, targetContainerName, pod.Name))
+	}
+	securityContext := debugContainer.SecurityContext.DeepCopy()
+	if securityContext == nil {
+		securityContext = &corev1.SecurityContext{
+			Privileged:               pointer.Bool(false),
+			AllowPrivilegeEscalation: pointer.Bool(false),
+			Capabilities: &corev1.Capabilities{
+				Add:  []corev1.Capability{"SYS_PTRACE"},
+				Drop: []corev1.Capability{"ALL"},
+			},
+		}
+	}
+	return &corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:            containerName,
+			Image:           debugContainer.Image,
+			Command:         debugContainer.Command,
+			Env:             debugContainer.Env,
+			SecurityContext: securityContext,
+			// keep the stdin open for attaching to the container interactively
+			Stdin: true,
+			TTY:   true,
+		},
+		TargetContainerName: targetContainerName,
+	}, nil
+}
+
+// getDebugContainerName returns the name of the debug container attached by the OpsRequest.
+func getDebugContainerName(opsRequest *appsv1alpha1.OpsRequest) string {
+	name := debugContainerNamePrefix + opsRequest.Name
+	if len(name) > 63 {
+		name = strings.TrimRight(name[:63], "-")
+	}
+	return name
+}
+
+func isImagePullFailed(reason string) bool {
+	return reason == "ErrImagePull" || reason == "ImagePullBackOff" || reason == "InvalidImageName"
+}
@@ -0,0 +1,202 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("DebugInstance OpsRequest", func() {
+	var (
+		randomStr   = testCtx.GetRandomStr()
+		clusterName = "cluster-for-ops-" + randomStr
+		compDefName = "compdef-for-debug-" + randomStr
+		podName     = clusterName + "-" + consensusComp + "-0"
+		reqCtx      intctrlutil.RequestCtx
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		// delete cluster(and all dependent sub-resources), clusterversion and clusterdef
+		testapps.ClearClusterResources(&testCtx)
+
+		// delete rest resources
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		// namespaced
+		testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
+		testapps.ClearResources(&testCtx, generics.PodSignature, inNS, ml, client.GracePeriodSeconds(0))
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ComponentSignature, true, inNS, ml)
+		// non-namespaced
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ComponentDefinitionSignature, true, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(cleanEnv)
+
+	Context("builds the debug container", func() {
+		var debugContainer *appsv1alpha1.DebugContainer
+
+		BeforeEach(func() {
+			debugContainer = &appsv1alpha1.DebugContainer{
+				Image:   "apecloud/mysql-tools:8.0",
+				Command: []string{"/bin/bash"},
+			}
+		})
+
+		newPod := func() *corev1.Pod {
+			return testapps.NewPodFactory(testCtx.DefaultNamespace, podName).
+				AddContainer(corev1.Container{Name: "mysql"}).
+				AddContainer(corev1.Container{Name: "exporter"}).
+				GetObject()
+		}
+
+		It("targets the first container and drops the privileges by default", func() {
+			container, err := buildDebugContainer(debugContainer, "kb-debug-test", newPod(), "")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(container.TargetContainerName).Should(Equal("mysql"))
+			Expect(container.Image).Should(Equal(debugContainer.Image))
+			Expect(container.Stdin).Should(BeTrue())
+			Expect(container.TTY).Should(BeTrue())
+			Expect(*container.SecurityContext.AllowPrivilegeEscalation).Should(BeFalse())
+			Expect(container.SecurityContext.Capabilities.Add).Should(Equal([]corev1.Capability{"SYS_PTRACE"}))
+		})
+
+		It("uses the target container and the security context specified", func() {
+			privileged := true
+			debugContainer.SecurityContext = &corev1.SecurityContext{Privileged: &privileged}
+			container, err := buildDebugContainer(debugContainer, "kb-debug-test", newPod(), "exporter")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(container.TargetContainerName).Should(Equal("exporter"))
+			Expect(*container.SecurityContext.Privileged).Should(BeTrue())
+		})
+
+		It("fails if the target container does not exist", func() {
+			_, err := buildDebugContainer(debugContainer, "kb-debug-test", newPod(), "not-exist")
+			Expect(intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal)).Should(BeTrue())
+		})
+	})
+
+	It("generates a valid name of the debug container", func() {
+		ops := testapps.NewOpsRequestObj("debug-mysql-0", testCtx.DefaultNamespace, clusterName, appsv1alpha1.DebugInstanceType)
+		Expect(getDebugContainerName(ops)).Should(Equal("kb-debug-debug-mysql-0"))
+
+		ops.Name = strings.Repeat("a", 53) + "-" + strings.Repeat("b", 10)
+		name := getDebugContainerName(ops)
+		Expect(len(name)).Should(BeNumerically("<=", 63))
+		Expect(name).ShouldNot(HaveSuffix("-"))
+	})
+
+	Context("with the cluster and the running instance", func() {
+		initOpsRes := func(debugContainer *appsv1alpha1.DebugContainer) *OpsResource {
+			By("create the ComponentDefinition, the cluster and the component")
+			compDefFactory := testapps.NewComponentDefinitionFactory(compDefName).SetDefaultSpec()
+			compDefFactory.Get().Spec.DebugContainer = debugContainer
+			compDef := compDefFactory.Create(&testCtx).GetObject()
+			cluster := testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, "", "").
+				AddComponentV2(consensusComp, compDef.Name).SetReplicas(1).
+				Create(&testCtx).GetObject()
+			testapps.NewComponentFactory(testCtx.DefaultNamespace, constant.GenerateClusterComponentName(clusterName, consensusComp), compDef.Name).
+				AddLabels(constant.AppInstanceLabelKey, clusterName).
+				SetReplicas(1).
+				Create(&testCtx)
+
+			By("mock the instance is running")
+			pod := testapps.MockInstanceSetPod(&testCtx, nil, clusterName, consensusComp, podName, "leader", "ReadWrite")
+			Expect(testapps.ChangeObjStatus(&testCtx, pod, func() {
+				pod.Status.Phase = corev1.PodRunning
+			})).Should(Succeed())
+
+			reqCtx = intctrlutil.RequestCtx{Ctx: testCtx.Ctx, Log: logf.FromContext(testCtx.Ctx)}
+			return initOpsResourceWithCluster(cluster, consensusComp)
+		}
+
+		newDebugOps := func() *appsv1alpha1.OpsRequest {
+			ops := testapps.NewOpsRequestObj("debug-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.DebugInstanceType)
+			ops.Spec.DebugInstance = &appsv1alpha1.DebugInstance{
+				ComponentOps: appsv1alpha1.ComponentOps{ComponentName: consensusComp},
+				InstanceName: podName,
+			}
+			return ops
+		}
+
+		It("attaches the debug container to the instance", func() {
+			opsRes := initOpsRes(&appsv1alpha1.DebugContainer{
+				Image:   "apecloud/mysql-tools:8.0",
+				Command: []string{"/bin/bash"},
+			})
+
+			By("create the DebugInstance opsRequest and run the action")
+			createOpsAndRunAction(reqCtx, opsRes, newDebugOps())
+			containerName := getDebugContainerName(opsRes.OpsRequest)
+			podKey := client.ObjectKey{Name: podName, Namespace: testCtx.DefaultNamespace}
+			Eventually(testapps.CheckObj(&testCtx, podKey, func(g Gomega, pod *corev1.Pod) {
+				g.Expect(pod.Spec.EphemeralContainers).Should(HaveLen(1))
+				g.Expect(pod.Spec.EphemeralContainers[0].Name).Should(Equal(containerName))
+				g.Expect(pod.Spec.EphemeralContainers[0].TargetContainerName).Should(Equal(testapps.DefaultMySQLContainerName))
+			})).Should(Succeed())
+
+			By("mock the debug container is running and expect the opsRequest succeeds")
+			Eventually(testapps.GetAndChangeObjStatus(&testCtx, podKey, func(pod *corev1.Pod) {
+				pod.Status.EphemeralContainerStatuses = []corev1.ContainerStatus{
+					{
+						Name:  containerName,
+						State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+					},
+				}
+			})).Should(Succeed())
+			_, err := GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(appsv1alpha1.OpsSucceedPhase))
+		})
+
+		It("fails if the debug container is not defined in the ComponentDefinition", func() {
+			opsRes := initOpsRes(nil)
+
+			By("create the DebugInstance opsRequest and run the action")
+			createOpsAndRunAction(reqCtx, opsRes, newDebugOps())
+
+			By("expect the opsRequest is failed and the instance is not changed")
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(appsv1alpha1.OpsFailedPhase))
+			Consistently(testapps.CheckObj(&testCtx, client.ObjectKey{Name: podName, Namespace: testCtx.DefaultNamespace}, func(g Gomega, pod *corev1.Pod) {
+				g.Expect(pod.Spec.EphemeralContainers).Should(BeEmpty())
+			})).Should(Succeed())
+		})
+	})
+})
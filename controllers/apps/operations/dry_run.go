@@ -0,0 +1,184 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"encoding/json"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// dryRunClient performs the writes in the dry-run mode of the API server, and records the changes they would make.
+type dryRunClient struct {
+	client.Client
+	reader  client.Reader
+	changes []appsv1alpha1.DryRunObjectChange
+}
+
+var _ client.Client = &dryRunClient{}
+
+func newDryRunClient(cli client.Client) *dryRunClient {
+	return &dryRunClient{
+		Client: client.NewDryRunClient(cli),
+		reader: cli,
+	}
+}
+
+func (c *dryRunClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.Client.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+	return c.record(appsv1alpha1.DryRunCreateOperation, nil, obj)
+}
+
+func (c *dryRunClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	live, err := c.getLive(ctx, obj)
+	if err != nil {
+		return err
+	}
+	if err = c.Client.Update(ctx, obj, opts...); err != nil {
+		return err
+	}
+	return c.record(appsv1alpha1.DryRunUpdateOperation, live, obj)
+}
+
+func (c *dryRunClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	live, err := c.getLive(ctx, obj)
+	if err != nil {
+		return err
+	}
+	if err = c.Client.Patch(ctx, obj, patch, opts...); err != nil {
+		return err
+	}
+	return c.record(appsv1alpha1.DryRunPatchOperation, live, obj)
+}
+
+func (c *dryRunClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.Client.Delete(ctx, obj, opts...); err != nil {
+		return err
+	}
+	return c.record(appsv1alpha1.DryRunDeleteOperation, nil, nil)
+}
+
+func (c *dryRunClient) getLive(ctx context.Context, obj client.Object) (client.Object, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return nil, err
+	}
+	ro, err := c.Scheme().New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	live := ro.(client.Object)
+	if err = c.reader.Get(ctx, client.ObjectKeyFromObject(obj), live); err != nil {
+		return nil, err
+	}
+	return live, nil
+}
+
+// record records the change of the object, the status and the system populated metadata are excluded from the diff.
+func (c *dryRunClient) record(operation appsv1alpha1.DryRunOperation, live, obj client.Object) error {
+	if obj == nil {
+		return nil
+	}
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return err
+	}
+	change := appsv1alpha1.DryRunObjectChange{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Name:       obj.GetName(),
+		Operation:  operation,
+	}
+	if operation != appsv1alpha1.DryRunDeleteOperation {
+		modified, err := dryRunObjectJSON(obj)
+		if err != nil {
+			return err
+		}
+		diff := modified
+		if live != nil {
+			original, err := dryRunObjectJSON(live)
+			if err != nil {
+				return err
+			}
+			if diff, err = jsonpatch.CreateMergePatch(original, modified); err != nil {
+				return err
+			}
+			// the object is not changed
+			if string(diff) == "{}" {
+				return nil
+			}
+		}
+		change.Diff = string(diff)
+	}
+	c.changes = append(c.changes, change)
+	return nil
+}
+
+func dryRunObjectJSON(obj client.Object) ([]byte, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	delete(content, "status")
+	metadata := map[string]any{
+		"name":      obj.GetName(),
+		"namespace": obj.GetNamespace(),
+	}
+	if labels := obj.GetLabels(); len(labels) > 0 {
+		metadata["labels"] = labels
+	}
+	if annotations := obj.GetAnnotations(); len(annotations) > 0 {
+		metadata["annotations"] = annotations
+	}
+	content["metadata"] = metadata
+	return json.Marshal(content)
+}
+
+// dryRunOpsRequest performs the action of the OpsRequest in the dry-run mode, renders the changes
+// it would make into status.dryRunResult, and marks the OpsRequest as Succeed without mutating anything.
+func dryRunOpsRequest(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource, opsBehaviour OpsBehaviour) error {
+	opsDeepCopy := opsRes.OpsRequest.DeepCopy()
+	dryRunCli := newDryRunClient(cli)
+	if err := opsBehaviour.OpsHandler.SaveLastConfiguration(reqCtx, dryRunCli, opsRes); err != nil {
+		return err
+	}
+	if err := opsBehaviour.OpsHandler.Action(reqCtx, dryRunCli, opsRes); err != nil {
+		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+			return patchFatalFailErrorCondition(reqCtx.Ctx, cli, opsRes, err)
+		}
+		return err
+	}
+	// drop the progress made by the action, only the changes are recorded
+	opsRes.OpsRequest.Status = *opsDeepCopy.Status.DeepCopy()
+	opsRes.OpsRequest.Status.DryRunResult = &appsv1alpha1.OpsDryRunResult{
+		ObjectChanges: dryRunCli.changes,
+	}
+	return PatchOpsStatusWithOpsDeepCopy(reqCtx.Ctx, cli, opsRes, opsDeepCopy, appsv1alpha1.OpsSucceedPhase,
+		appsv1alpha1.NewDryRunSucceedCondition(opsRes.OpsRequest))
+}
@@ -21,7 +21,6 @@ package operations
 
 import (
 	"fmt"
-	"reflect"
 	"strings"
 	"time"
 
@@ -34,6 +33,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 type ExposeOpsHandler struct {
@@ -95,11 +95,10 @@ func (e ExposeOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clien
 
 func (e ExposeOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsResource *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
 	var (
-		opsRequest          = opsResource.OpsRequest
-		oldOpsRequestStatus = opsRequest.Status.DeepCopy()
-		opsRequestPhase     = appsv1alpha1.OpsRunningPhase
+		opsRequest      = opsResource.OpsRequest
+		opsDeepCopy     = opsRequest.DeepCopy()
+		opsRequestPhase = appsv1alpha1.OpsRunningPhase
 	)
-	patch := client.MergeFrom(opsRequest.DeepCopy())
 
 	// update component status
 	if opsRequest.Status.Components == nil {
@@ -114,14 +113,16 @@ func (e ExposeOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli cli
 	var (
 		actualProgressCount int
 		expectProgressCount int
+		endpointFailures    []string
 	)
 	for _, v := range opsRequest.Spec.ExposeList {
-		actualCount, expectCount, err := e.handleComponentServices(reqCtx, cli, opsResource, v)
+		actualCount, expectCount, failures, err := e.handleComponentServices(reqCtx, cli, opsResource, v)
 		if err != nil {
 			return "", 0, err
 		}
 		actualProgressCount += actualCount
 		expectProgressCount += expectCount
+		endpointFailures = append(endpointFailures, failures...)
 
 		// update component status if completed
 		if actualCount == expectCount {
@@ -130,12 +131,14 @@ func (e ExposeOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli cli
 		}
 	}
 	opsRequest.Status.Progress = fmt.Sprintf("%d/%d", actualProgressCount, expectProgressCount)
+	if e.needProbeEndpoints(opsRequest) {
+		// the condition keeps its last transition time if the status is not changed
+		opsRequest.SetStatusCondition(*appsv1alpha1.NewEndpointHealthyCondition(opsRequest, endpointFailures))
+	}
 
 	// patch OpsRequest.status.components
-	if !reflect.DeepEqual(*oldOpsRequestStatus, opsRequest.Status) {
-		if err := cli.Status().Patch(reqCtx.Ctx, opsRequest, patch); err != nil {
-			return opsRequestPhase, 0, err
-		}
+	if err := PatchOpsRequestStatus(reqCtx.Ctx, cli, opsDeepCopy, opsRequest); err != nil {
+		return opsRequestPhase, 0, err
 	}
 
 	if actualProgressCount == expectProgressCount {
@@ -145,12 +148,14 @@ func (e ExposeOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli cli
 	return opsRequestPhase, 5 * time.Second, nil
 }
 
-func (e ExposeOpsHandler) handleComponentServices(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource, expose appsv1alpha1.Expose) (int, int, error) {
+// handleComponentServices returns the count of services that are ready, the expected count,
+// and the unreachable endpoints of the LoadBalancer services.
+func (e ExposeOpsHandler) handleComponentServices(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource, expose appsv1alpha1.Expose) (int, int, []string, error) {
 	svcList := &corev1.ServiceList{}
 	if err := cli.List(reqCtx.Ctx, svcList, client.MatchingLabels{
 		constant.AppInstanceLabelKey: opsRes.Cluster.Name,
 	}, client.InNamespace(opsRes.Cluster.Namespace)); err != nil {
-		return 0, 0, err
+		return 0, 0, nil, err
 	}
 
 	getSvcName := func(clusterName string, componentName string, name string) string {
@@ -178,6 +183,7 @@ func (e ExposeOpsHandler) handleComponentServices(reqCtx intctrlutil.RequestCtx,
 	var (
 		expectCount = len(expose.Services)
 		actualCount int
+		failures    []string
 	)
 
 	checkEnableExposeService := func() {
@@ -195,6 +201,15 @@ func (e ExposeOpsHandler) handleComponentServices(reqCtx intctrlutil.RequestCtx,
 					actualCount += 1
 					break
 				}
+				if !viper.GetBool(constant.CfgKeyEndpointProbe) {
+					continue
+				}
+				// the endpoints are probed in background, the unreachable ones are only reported by the condition
+				if result := intctrlutil.DefaultAsyncEndpointProber.Get(&service); result != nil {
+					for _, failure := range result.Failures {
+						failures = append(failures, fmt.Sprintf("%s(%s)", service.Name, failure))
+					}
+				}
 			} else {
 				actualCount += 1
 			}
@@ -218,7 +233,25 @@ func (e ExposeOpsHandler) handleComponentServices(reqCtx intctrlutil.RequestCtx,
 		checkDisableExposeService()
 	}
 
-	return actualCount, expectCount, nil
+	return actualCount, expectCount, failures, nil
+}
+
+// needProbeEndpoints checks if the OpsRequest exposes LoadBalancer services whose endpoints should be probed.
+func (e ExposeOpsHandler) needProbeEndpoints(opsRequest *appsv1alpha1.OpsRequest) bool {
+	if !viper.GetBool(constant.CfgKeyEndpointProbe) {
+		return false
+	}
+	for _, expose := range opsRequest.Spec.ExposeList {
+		if expose.Switch != appsv1alpha1.EnableExposeSwitch {
+			continue
+		}
+		for _, svc := range expose.Services {
+			if svc.ServiceType == corev1.ServiceTypeLoadBalancer {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 func (e ExposeOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
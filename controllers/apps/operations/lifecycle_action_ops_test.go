@@ -0,0 +1,454 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/plan"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+// lifecycleActionOpsCase describes an OpsRequest which invokes a lifecycle action of the ComponentDefinition,
+// and what is expected with or without the action.
+type lifecycleActionOpsCase struct {
+	// action is the lifecycle action invoked by the OpsRequest.
+	action string
+	// initOpsRes creates the cluster and the sub-resources of the component provided by the ComponentDefinition.
+	initOpsRes func(compDef *appsv1alpha1.ComponentDefinition) *OpsResource
+	newOps     func() *appsv1alpha1.OpsRequest
+	// mockAction mocks the lorry client to run the action, it is called only if the action is defined.
+	mockAction func(recorder *lorry.MockClientMockRecorder)
+	// expect checks the OpsRequest and the cluster after the action of the OpsRequest is done.
+	expect func(opsRes *OpsResource, actionDefined bool)
+}
+
+var _ = Describe("OpsRequests invoking the lifecycle actions", func() {
+	var (
+		randomStr   = testCtx.GetRandomStr()
+		clusterName = "cluster-for-ops-" + randomStr
+		compDefName = "compdef-for-actions-" + randomStr
+		reqCtx      intctrlutil.RequestCtx
+		secret      *corev1.Secret
+		its         *workloads.InstanceSet
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		// delete cluster(and all dependent sub-resources), clusterversion and clusterdef
+		testapps.ClearClusterResources(&testCtx)
+
+		// delete rest resources
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		// namespaced
+		testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
+		testapps.ClearResources(&testCtx, generics.PodSignature, inNS, ml, client.GracePeriodSeconds(0))
+		testapps.ClearResources(&testCtx, generics.SecretSignature, inNS, ml)
+		// the secrets created by the opsRequests are not labeled as the test objects.
+		testapps.ClearResources(&testCtx, generics.SecretSignature, inNS, client.MatchingLabels{constant.AppInstanceLabelKey: clusterName})
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.InstanceSetSignature, true, inNS, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ComponentSignature, true, inNS, ml)
+		// non-namespaced
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ComponentDefinitionSignature, true, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ComponentVersionSignature, true, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(cleanEnv)
+
+	checkOpsPhase := func(opsRes *OpsResource, phase appsv1alpha1.OpsPhase) {
+		Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(phase))
+	}
+
+	newClusterWithConsensusComp := func(compDef *appsv1alpha1.ComponentDefinition, replicas int32) *OpsResource {
+		cluster := testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, "", "").
+			AddComponentV2(consensusComp, compDef.Name).SetReplicas(replicas).
+			Create(&testCtx).GetObject()
+		return initOpsResourceWithCluster(cluster, consensusComp)
+	}
+
+	rotateTLSCase := &lifecycleActionOpsCase{
+		action: "ReloadTLS",
+		initOpsRes: func(compDef *appsv1alpha1.ComponentDefinition) *OpsResource {
+			By("create the cluster with TLS enabled")
+			cluster := testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, "", "").
+				AddComponentV2(consensusComp, compDef.Name).SetReplicas(3).
+				SetTLS(true).SetIssuer(&appsv1alpha1.Issuer{Name: appsv1alpha1.IssuerKubeBlocks}).
+				Create(&testCtx).GetObject()
+
+			By("mock the TLS secret and the InstanceSet of the component")
+			var err error
+			secret, err = plan.ComposeTLSSecret(testCtx.DefaultNamespace, clusterName, consensusComp)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(testCtx.CreateObj(testCtx.Ctx, secret)).Should(Succeed())
+			Expect(k8sClient.Get(testCtx.Ctx, client.ObjectKeyFromObject(secret), secret)).Should(Succeed())
+			its = testapps.MockInstanceSetComponent(&testCtx, clusterName, consensusComp)
+			return initOpsResourceWithCluster(cluster, consensusComp)
+		},
+		newOps: func() *appsv1alpha1.OpsRequest {
+			ops := testapps.NewOpsRequestObj("rotate-tls-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.RotateTLSType)
+			ops.Spec.RotateTLSList = []appsv1alpha1.ComponentOps{{ComponentName: consensusComp}}
+			return ops
+		},
+		expect: func(opsRes *OpsResource, actionDefined bool) {
+			By("expect the certificates are renewed")
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(secret), func(g Gomega, renewed *corev1.Secret) {
+				g.Expect(renewed.Annotations[constant.TLSRotatedByOpsAnnotationKey]).Should(Equal(opsRes.OpsRequest.Name))
+				g.Expect(renewed.Data[constant.CertName]).ShouldNot(Equal(secret.Data[constant.CertName]))
+			})).Should(Succeed())
+			if actionDefined {
+				By("expect the component is not restarted, the certificates are reloaded online")
+				Consistently(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(its), func(g Gomega, its *workloads.InstanceSet) {
+					g.Expect(its.Spec.Template.Annotations).ShouldNot(HaveKey(constant.RestartAnnotationKey))
+				})).Should(Succeed())
+				return
+			}
+			By("expect the component is restarted to load the certificates")
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(its), func(g Gomega, its *workloads.InstanceSet) {
+				g.Expect(its.Spec.Template.Annotations).Should(HaveKeyWithValue(constant.RestartAnnotationKey,
+					opsRes.OpsRequest.Status.StartTimestamp.Format(time.RFC3339)))
+			})).Should(Succeed())
+		},
+	}
+
+	purgeCase := &lifecycleActionOpsCase{
+		action: "Purge",
+		initOpsRes: func(compDef *appsv1alpha1.ComponentDefinition) *OpsResource {
+			opsRes := newClusterWithConsensusComp(compDef, 3)
+			testapps.MockInstanceSetPods(&testCtx, nil, opsRes.Cluster, consensusComp)
+			return opsRes
+		},
+		newOps: func() *appsv1alpha1.OpsRequest {
+			retainHours := int32(24)
+			ops := testapps.NewOpsRequestObj("purge-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.PurgeType)
+			ops.Spec.PurgeList = []appsv1alpha1.Purge{
+				{
+					ComponentOps: appsv1alpha1.ComponentOps{ComponentName: consensusComp},
+					RetainHours:  &retainHours,
+				},
+			}
+			return ops
+		},
+		mockAction: func(recorder *lorry.MockClientMockRecorder) {
+			recorder.Purge(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(1<<20), nil).Times(3)
+		},
+		expect: func(opsRes *OpsResource, actionDefined bool) {
+			if !actionDefined {
+				checkOpsPhase(opsRes, appsv1alpha1.OpsFailedPhase)
+				return
+			}
+			By("reconcile the opsRequest and expect the instances are purged")
+			_, err := GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+				g.Expect(ops.Status.Phase).Should(Equal(appsv1alpha1.OpsSucceedPhase))
+				reclaimedSpace := ops.Status.Components[consensusComp].ReclaimedSpace
+				g.Expect(reclaimedSpace).ShouldNot(BeNil())
+				g.Expect(reclaimedSpace.String()).Should(Equal("3Mi"))
+			})).Should(Succeed())
+		},
+	}
+
+	const accountName = "root"
+	var rotatedPassword string
+	rotateCredentialsCase := &lifecycleActionOpsCase{
+		action: "AccountRotation",
+		initOpsRes: func(compDef *appsv1alpha1.ComponentDefinition) *OpsResource {
+			opsRes := newClusterWithConsensusComp(compDef, 1)
+			testapps.NewComponentFactory(testCtx.DefaultNamespace, constant.GenerateClusterComponentName(clusterName, consensusComp), compDef.Name).
+				AddLabels(constant.AppInstanceLabelKey, clusterName).
+				SetReplicas(1).
+				Create(&testCtx)
+
+			By("mock the writable instance of the component")
+			testapps.MockInstanceSetPod(&testCtx, nil, clusterName, consensusComp,
+				constant.GenerateWorkloadNamePattern(clusterName, consensusComp)+"-0", "leader", "ReadWrite")
+			return opsRes
+		},
+		newOps: func() *appsv1alpha1.OpsRequest {
+			ops := testapps.NewOpsRequestObj("rotate-credentials-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.RotateCredentialsType)
+			ops.Spec.RotateCredentialsList = []appsv1alpha1.RotateCredentials{
+				{
+					ComponentOps: appsv1alpha1.ComponentOps{ComponentName: consensusComp},
+					Accounts:     []string{accountName},
+				},
+			}
+			return ops
+		},
+		mockAction: func(recorder *lorry.MockClientMockRecorder) {
+			rotatedPassword = ""
+			recorder.RotateCredential(gomock.Any(), accountName, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _, password string) error {
+					rotatedPassword = password
+					return nil
+				}).Times(1)
+		},
+		expect: func(opsRes *OpsResource, actionDefined bool) {
+			if !actionDefined {
+				By("expect the opsRequest is rejected")
+				checkOpsPhase(opsRes, appsv1alpha1.OpsFailedPhase)
+				return
+			}
+			By("reconcile the opsRequest and expect the password is rotated")
+			_, err := GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			checkOpsPhase(opsRes, appsv1alpha1.OpsSucceedPhase)
+			Expect(rotatedPassword).ShouldNot(BeEmpty())
+			accountSecretKey := client.ObjectKey{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      constant.GenerateAccountSecretName(clusterName, consensusComp, accountName),
+			}
+			Eventually(testapps.CheckObj(&testCtx, accountSecretKey, func(g Gomega, secret *corev1.Secret) {
+				g.Expect(string(secret.Data[constant.AccountPasswdForSecret])).Should(Equal(rotatedPassword))
+			})).Should(Succeed())
+		},
+	}
+
+	const (
+		fromVersion = "5.7.44"
+		toVersion   = "8.0.36"
+	)
+	checkServiceVersion := func(opsRes *OpsResource, serviceVersion string) {
+		Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.Cluster), func(g Gomega, cluster *appsv1alpha1.Cluster) {
+			g.Expect(cluster.Spec.ComponentSpecs[0].ServiceVersion).Should(Equal(serviceVersion))
+		})).Should(Succeed())
+	}
+	newUpgradeCase := func(preCheckErr error) *lifecycleActionOpsCase {
+		return &lifecycleActionOpsCase{
+			action: "UpgradePreCheck",
+			initOpsRes: func(compDef *appsv1alpha1.ComponentDefinition) *OpsResource {
+				By("create the ComponentVersion declaring the upgrade path with the pre-checks")
+				images := map[string]string{testapps.DefaultMySQLContainerName: testapps.ApeCloudMySQLImage}
+				testapps.NewComponentVersionFactory("compversion-for-actions-"+randomStr).
+					AddLabels(compDef.Name, compDef.Name).
+					SetSpec(appsv1alpha1.ComponentVersionSpec{
+						CompatibilityRules: []appsv1alpha1.ComponentVersionCompatibilityRule{
+							{CompDefs: []string{compDef.Name}, Releases: []string{fromVersion, toVersion}},
+						},
+						Releases: []appsv1alpha1.ComponentVersionRelease{
+							{Name: fromVersion, ServiceVersion: fromVersion, Images: images},
+							{Name: toVersion, ServiceVersion: toVersion, Images: images},
+						},
+						UpgradePaths: []appsv1alpha1.ComponentVersionUpgradePath{
+							{From: "~5.7", To: "~8.0", PreChecks: []string{"diskSpace"}},
+						},
+					}).Create(&testCtx)
+
+				By("create the cluster and mock the writable instance")
+				cluster := testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, "", "").
+					AddComponentV2(consensusComp, compDef.Name).SetReplicas(1).SetServiceVersion(fromVersion).
+					Create(&testCtx).GetObject()
+				testapps.MockInstanceSetPod(&testCtx, nil, clusterName, consensusComp,
+					constant.GenerateWorkloadNamePattern(clusterName, consensusComp)+"-0", "leader", "ReadWrite")
+				return initOpsResourceWithCluster(cluster, consensusComp)
+			},
+			newOps: func() *appsv1alpha1.OpsRequest {
+				ops := testapps.NewOpsRequestObj("upgrade-ops-"+randomStr, testCtx.DefaultNamespace,
+					clusterName, appsv1alpha1.UpgradeType)
+				ops.Spec.Upgrade = &appsv1alpha1.Upgrade{
+					Components: []appsv1alpha1.UpgradeComponent{
+						{
+							ComponentOps:   appsv1alpha1.ComponentOps{ComponentName: consensusComp},
+							ServiceVersion: pointer.String(toVersion),
+						},
+					},
+				}
+				return ops
+			},
+			mockAction: func(recorder *lorry.MockClientMockRecorder) {
+				recorder.UpgradePreCheck(gomock.Any(), "diskSpace", fromVersion, toVersion).Return(preCheckErr).Times(1)
+			},
+			expect: func(opsRes *OpsResource, actionDefined bool) {
+				switch {
+				case !actionDefined:
+					By("expect the opsRequest is failed and the cluster is not upgraded")
+					checkOpsPhase(opsRes, appsv1alpha1.OpsFailedPhase)
+					checkServiceVersion(opsRes, fromVersion)
+				case preCheckErr != nil:
+					By("expect the opsRequest is failed with the pre-check results and the cluster is not upgraded")
+					Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+						g.Expect(ops.Status.Phase).Should(Equal(appsv1alpha1.OpsFailedPhase))
+						g.Expect(ops.Status.PreCheckResults).Should(HaveLen(1))
+						g.Expect(ops.Status.PreCheckResults[0].Pass).Should(BeFalse())
+						g.Expect(ops.Status.PreCheckResults[0].Message).Should(Equal(preCheckErr.Error()))
+					})).Should(Succeed())
+					checkServiceVersion(opsRes, fromVersion)
+				default:
+					By("expect the pre-check results are recorded and the cluster is upgraded")
+					Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+						g.Expect(ops.Status.PreCheckResults).Should(HaveLen(1))
+						g.Expect(ops.Status.PreCheckResults[0].Pass).Should(BeTrue())
+					})).Should(Succeed())
+					checkServiceVersion(opsRes, toVersion)
+				}
+			},
+		}
+	}
+	upgradeCase := newUpgradeCase(nil)
+	upgradePreCheckFailedCase := newUpgradeCase(errors.New("no enough disk space"))
+
+	const shardingName = "shard"
+	shards := []string{shardingName + "-abc", shardingName + "-def"}
+	shardRebalanceCase := &lifecycleActionOpsCase{
+		action: "ShardRebalance",
+		initOpsRes: func(compDef *appsv1alpha1.ComponentDefinition) *OpsResource {
+			By("create the cluster with a sharding")
+			cluster := testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, "", "").
+				AddShardingSpecV2(shardingName, compDef.Name).SetShards(int32(len(shards))).
+				Create(&testCtx).GetObject()
+
+			By("mock the components and the writable instances of the shards")
+			for _, shard := range shards {
+				testapps.NewComponentFactory(testCtx.DefaultNamespace, constant.GenerateClusterComponentName(clusterName, shard), compDef.Name).
+					AddLabels(constant.AppInstanceLabelKey, clusterName).
+					AddLabels(constant.KBAppShardingNameLabelKey, shardingName).
+					AddLabels(constant.KBAppComponentLabelKey, shard).
+					SetReplicas(1).
+					Create(&testCtx)
+				testapps.MockInstanceSetPod(&testCtx, nil, clusterName, shard,
+					constant.GenerateWorkloadNamePattern(clusterName, shard)+"-0", "leader", "ReadWrite")
+			}
+			return initOpsResourceWithCluster(cluster)
+		},
+		newOps: func() *appsv1alpha1.OpsRequest {
+			ops := testapps.NewOpsRequestObj("shard-rebalance-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.ShardRebalanceType)
+			ops.Spec.ShardRebalanceList = []appsv1alpha1.ComponentOps{{ComponentName: shardingName}}
+			return ops
+		},
+		mockAction: func(recorder *lorry.MockClientMockRecorder) {
+			for _, shard := range shards {
+				recorder.ShardRebalance(gomock.Any(), shard, shards).Return(int64(1<<20), nil).Times(1)
+			}
+		},
+		expect: func(opsRes *OpsResource, actionDefined bool) {
+			if !actionDefined {
+				By("expect the opsRequest is rejected")
+				checkOpsPhase(opsRes, appsv1alpha1.OpsFailedPhase)
+				return
+			}
+			By("reconcile the opsRequest and expect the shards are rebalanced")
+			_, err := GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+				g.Expect(ops.Status.Phase).Should(Equal(appsv1alpha1.OpsSucceedPhase))
+				movedData := ops.Status.Components[shardingName].MovedData
+				g.Expect(movedData).ShouldNot(BeNil())
+				g.Expect(movedData.String()).Should(Equal("2Mi"))
+			})).Should(Succeed())
+		},
+	}
+
+	const proxyComp = "proxy"
+	drainBackendCase := &lifecycleActionOpsCase{
+		action: "DrainBackend",
+		initOpsRes: func(compDef *appsv1alpha1.ComponentDefinition) *OpsResource {
+			By("create the cluster with a proxy component")
+			backendCompDef := createCompDefWithLifecycleActions(compDefName + "-backend")
+			cluster := testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, "", "").
+				AddComponentV2(proxyComp, compDef.Name).SetReplicas(1).
+				AddComponentV2(consensusComp, backendCompDef.Name).SetReplicas(3).
+				Create(&testCtx).GetObject()
+			its = testapps.MockInstanceSetComponent(&testCtx, clusterName, consensusComp)
+			return initOpsResourceWithCluster(cluster, proxyComp, consensusComp)
+		},
+		newOps: func() *appsv1alpha1.OpsRequest {
+			ops := testapps.NewOpsRequestObj("restart-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.RestartType)
+			ops.Spec.RestartList = []appsv1alpha1.ComponentOps{{ComponentName: consensusComp}}
+			return ops
+		},
+		expect: func(opsRes *OpsResource, actionDefined bool) {
+			if actionDefined {
+				By("expect the InstanceSet is paused for draining by the opsRequest")
+				Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(its), func(g Gomega, its *workloads.InstanceSet) {
+					g.Expect(its.Spec.Paused).Should(BeTrue())
+					g.Expect(its.Annotations).Should(HaveKeyWithValue(drainingOpsAnnotationKey, opsRes.OpsRequest.Name))
+				})).Should(Succeed())
+				return
+			}
+			By("expect the InstanceSet is restarted without pausing")
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(its), func(g Gomega, its *workloads.InstanceSet) {
+				g.Expect(its.Spec.Template.Annotations).Should(HaveKey(constant.RestartAnnotationKey))
+				g.Expect(its.Spec.Paused).Should(BeFalse())
+				g.Expect(its.Annotations).ShouldNot(HaveKey(drainingOpsAnnotationKey))
+			})).Should(Succeed())
+		},
+	}
+
+	DescribeTable("runs the OpsRequest with or without the lifecycle action",
+		func(c *lifecycleActionOpsCase, actionDefined bool) {
+			By("create the ComponentDefinition and the cluster")
+			var actions []string
+			if actionDefined {
+				actions = append(actions, c.action)
+			}
+			compDef := createCompDefWithLifecycleActions(compDefName, actions...)
+			opsRes := c.initOpsRes(compDef)
+			reqCtx = intctrlutil.RequestCtx{Ctx: testCtx.Ctx, Log: logf.FromContext(testCtx.Ctx)}
+			if actionDefined && c.mockAction != nil {
+				c.mockAction(mockLorryClient())
+			}
+
+			By("create the opsRequest and run the action")
+			createOpsAndRunAction(reqCtx, opsRes, c.newOps())
+			c.expect(opsRes, actionDefined)
+		},
+		Entry("RotateTLS reloads the certificates by the reloadTLS action", rotateTLSCase, true),
+		Entry("RotateTLS restarts the component without the reloadTLS action", rotateTLSCase, false),
+		Entry("Purge purges the instances by the purge action", purgeCase, true),
+		Entry("Purge fails without the purge action", purgeCase, false),
+		Entry("RotateCredentials rotates the password by the accountRotation action", rotateCredentialsCase, true),
+		Entry("RotateCredentials is rejected without the accountRotation action", rotateCredentialsCase, false),
+		Entry("Upgrade upgrades the component if the pre-checks pass", upgradeCase, true),
+		Entry("Upgrade is aborted if the pre-checks fail", upgradePreCheckFailedCase, true),
+		Entry("Upgrade fails without the upgradePreCheck action", upgradeCase, false),
+		Entry("ShardRebalance rebalances the shards by the shardRebalance action", shardRebalanceCase, true),
+		Entry("ShardRebalance is rejected without the shardRebalance action", shardRebalanceCase, false),
+		Entry("Restart pauses the rolling update of the backend if the proxy can drain the connections", drainBackendCase, true),
+		Entry("Restart rolls the backend as usual if the proxy can not drain the connections", drainBackendCase, false),
+	)
+})
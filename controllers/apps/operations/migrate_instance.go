@@ -0,0 +1,342 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	reasonInstanceEvicted         = "InstanceEvicted"
+	reasonInstanceMigrationFailed = "InstanceMigrationFailed"
+	reasonSourceNodeCordoned      = "SourceNodeCordoned"
+)
+
+type migrateInstanceOpsHandler struct{}
+
+var _ OpsHandler = migrateInstanceOpsHandler{}
+
+func init() {
+	migrateHandler := migrateInstanceOpsHandler{}
+	migrateInstanceBehaviour := OpsBehaviour{
+		FromClusterPhases: appsv1alpha1.GetClusterUpRunningPhases(),
+		ToClusterPhase:    appsv1alpha1.UpdatingClusterPhase,
+		QueueByCluster:    true,
+		OpsHandler:        migrateHandler,
+		CancelFunc:        migrateHandler.Cancel,
+	}
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(appsv1alpha1.MigrateInstanceType, migrateInstanceBehaviour)
+}
+
+// ActionStartedCondition the started condition when handling the migrate-instance request.
+func (m migrateInstanceOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return appsv1alpha1.NewMigrateInstanceCondition(opsRes.OpsRequest), nil
+}
+
+// Action pins the instance to the target node or zone by the node-selector-once annotation of the InstanceSet,
+// which only takes effect when the instance is recreated. The instance is evicted by ReconcileAction.
+func (m migrateInstanceOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	migrateInstance := opsRes.OpsRequest.Spec.MigrateInstance
+	pod, its, err := m.getInstance(reqCtx, cli, opsRes)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`instance "%s" not found: %s`, migrateInstance.InstanceName, err.Error()))
+		}
+		return err
+	}
+	var targetNode *corev1.Node
+	if migrateInstance.TargetNodeName != "" {
+		targetNode = &corev1.Node{}
+		if err = cli.Get(reqCtx.Ctx, client.ObjectKey{Name: migrateInstance.TargetNodeName}, targetNode); err != nil {
+			if apierrors.IsNotFound(err) {
+				return intctrlutil.NewFatalError(fmt.Sprintf(`node "%s" not found`, migrateInstance.TargetNodeName))
+			}
+			return err
+		}
+	}
+	return setNodeSelectorOnce(reqCtx, cli, its, pod.Name, buildMigrateNodeSelector(migrateInstance, targetNode))
+}
+
+// ReconcileAction will be performed when action is done and loops till OpsRequest.status.phase is Succeed/Failed.
+// It evicts the instance from the source node once the quorum allows, and waits for it to be recreated and ready
+// on the target node or zone.
+func (m migrateInstanceOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	var (
+		migrateInstance = opsRes.OpsRequest.Spec.MigrateInstance
+		compName        = migrateInstance.ComponentName
+		oldOpsRequest   = opsRes.OpsRequest.DeepCopy()
+		opsRequestPhase = opsRes.OpsRequest.Status.Phase
+	)
+	pod, its, err := m.getInstance(reqCtx, cli, opsRes)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// waiting for the instance to be recreated by the InstanceSet.
+			return opsRequestPhase, defaultOpsResyncAfter, nil
+		}
+		return opsRequestPhase, 0, err
+	}
+
+	if opsRes.OpsRequest.Status.Components == nil {
+		opsRes.OpsRequest.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{}
+	}
+	compStatus := opsRes.OpsRequest.Status.Components[compName]
+	syncProgress := func(status appsv1alpha1.ProgressStatus, message string) error {
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails,
+			appsv1alpha1.ProgressStatusDetail{
+				ObjectKey: getProgressObjectKey(constant.PodKind, pod.Name),
+				Status:    status,
+				Message:   message,
+			})
+		opsRes.OpsRequest.Status.Components[compName] = compStatus
+		completedCount := 0
+		if isCompletedProgressStatus(status) {
+			completedCount = 1
+		}
+		return syncProgressToOpsRequest(reqCtx, cli, opsRes, oldOpsRequest, completedCount, 1)
+	}
+
+	if pod.DeletionTimestamp != nil {
+		return opsRequestPhase, defaultOpsResyncAfter,
+			syncProgress(appsv1alpha1.ProcessingProgressStatus, fmt.Sprintf("Waiting for pod %s to be recreated", pod.Name))
+	}
+	if pod.Spec.NodeName == "" {
+		if isPodUnschedulable(pod, migrateUnschedulableTimeout) {
+			message := fmt.Sprintf("Pod %s can not be scheduled to the target, roll back the placement constraints", pod.Name)
+			if err = m.rollback(reqCtx, cli, opsRes, pod, its); err != nil {
+				return opsRequestPhase, 0, err
+			}
+			opsRes.Recorder.Event(opsRes.OpsRequest, corev1.EventTypeWarning, reasonInstanceMigrationFailed, message)
+			return appsv1alpha1.OpsFailedPhase, 0, syncProgress(appsv1alpha1.FailedProgressStatus, message)
+		}
+		return opsRequestPhase, defaultOpsResyncAfter,
+			syncProgress(appsv1alpha1.ProcessingProgressStatus, fmt.Sprintf("Waiting for pod %s to be scheduled to the target", pod.Name))
+	}
+
+	node := &corev1.Node{}
+	if err = cli.Get(reqCtx.Ctx, client.ObjectKey{Name: pod.Spec.NodeName}, node); err != nil {
+		return opsRequestPhase, 0, err
+	}
+	if isNodeMigrationTarget(node, migrateInstance) {
+		if !intctrlutil.PodIsReady(pod) {
+			return opsRequestPhase, defaultOpsResyncAfter,
+				syncProgress(appsv1alpha1.ProcessingProgressStatus, fmt.Sprintf("Waiting for pod %s to be ready on node %s", pod.Name, node.Name))
+		}
+		if err = setNodeSelectorOnce(reqCtx, cli, its, pod.Name, nil); err != nil {
+			return opsRequestPhase, 0, err
+		}
+		return appsv1alpha1.OpsSucceedPhase, 0,
+			syncProgress(appsv1alpha1.SucceedProgressStatus, fmt.Sprintf("Successfully moved pod %s to node %s", pod.Name, node.Name))
+	}
+	if opsRes.OpsRequest.Status.StartTimestamp.Before(&pod.CreationTimestamp) {
+		// the instance is recreated but not placed on the target, which means the placement constraints are overridden.
+		message := fmt.Sprintf("Pod %s is recreated on node %s which is not the target", pod.Name, node.Name)
+		if err = m.rollback(reqCtx, cli, opsRes, pod, its); err != nil {
+			return opsRequestPhase, 0, err
+		}
+		opsRes.Recorder.Event(opsRes.OpsRequest, corev1.EventTypeWarning, reasonInstanceMigrationFailed, message)
+		return appsv1alpha1.OpsFailedPhase, 0, syncProgress(appsv1alpha1.FailedProgressStatus, message)
+	}
+
+	if migrateInstance.CordonSourceNode && !node.Spec.Unschedulable {
+		patch := client.MergeFrom(node.DeepCopy())
+		node.Spec.Unschedulable = true
+		if err = cli.Patch(reqCtx.Ctx, node, patch); err != nil {
+			return opsRequestPhase, 0, err
+		}
+		opsRes.Recorder.Eventf(opsRes.OpsRequest, corev1.EventTypeNormal, reasonSourceNodeCordoned, "Cordoned node %s", node.Name)
+	}
+	if !opsRes.OpsRequest.Spec.QuorumLossAcknowledged() {
+		pods, err := component.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, opsRes.Cluster.Name, pod.Labels[constant.KBAppComponentLabelKey])
+		if err != nil {
+			return opsRequestPhase, 0, err
+		}
+		podList := make([]corev1.Pod, 0, len(pods))
+		for _, p := range pods {
+			podList = append(podList, *p)
+		}
+		if err = checkMigrationQuorum(its, podList, pod); err != nil {
+			return opsRequestPhase, defaultOpsResyncAfter,
+				syncProgress(appsv1alpha1.PendingProgressStatus, fmt.Sprintf("Waiting for the quorum before moving pod %s: %s", pod.Name, err.Error()))
+		}
+	}
+	if migrateInstance.VolumePolicy == appsv1alpha1.RecreateMigrateVolumePolicy {
+		// the PVCs are removed after the pod is deleted, and the new ones are provisioned on the target by the InstanceSet.
+		if err = m.deleteInstancePVCs(reqCtx, cli, pod); err != nil {
+			return opsRequestPhase, 0, err
+		}
+	}
+	eviction := &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace}}
+	if err = cli.SubResource("eviction").Create(reqCtx.Ctx, pod, eviction); err != nil {
+		if apierrors.IsTooManyRequests(err) {
+			return opsRequestPhase, defaultOpsResyncAfter,
+				syncProgress(appsv1alpha1.PendingProgressStatus, fmt.Sprintf("Waiting for the PodDisruptionBudget to allow evicting pod %s", pod.Name))
+		}
+		return opsRequestPhase, 0, client.IgnoreNotFound(err)
+	}
+	message := fmt.Sprintf("Evicted pod %s from node %s", pod.Name, node.Name)
+	opsRes.Recorder.Event(opsRes.OpsRequest, corev1.EventTypeNormal, reasonInstanceEvicted, message)
+	return opsRequestPhase, defaultOpsResyncAfter, syncProgress(appsv1alpha1.ProcessingProgressStatus, message)
+}
+
+// SaveLastConfiguration this operation only moves the instance, no changes for Cluster.spec.
+// empty implementation here.
+func (m migrateInstanceOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
+// Cancel this function defines the cancel migrateInstance action, the placement constraints of the instance
+// are removed. The source node is not uncordoned if it has been cordoned.
+func (m migrateInstanceOpsHandler) Cancel(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	pod, its, err := m.getInstance(reqCtx, cli, opsRes)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	return m.rollback(reqCtx, cli, opsRes, pod, its)
+}
+
+// rollback removes the placement constraints of the instance, and recreates the instance if it is still pending
+// on the constraints.
+func (m migrateInstanceOpsHandler) rollback(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	pod *corev1.Pod,
+	its *workloads.InstanceSet) error {
+	if err := setNodeSelectorOnce(reqCtx, cli, its, pod.Name, nil); err != nil {
+		return err
+	}
+	if pod.Spec.NodeName == "" && pod.DeletionTimestamp == nil && opsRes.OpsRequest.Status.StartTimestamp.Before(&pod.CreationTimestamp) {
+		return intctrlutil.BackgroundDeleteObject(cli, reqCtx.Ctx, pod)
+	}
+	return nil
+}
+
+// getInstance gets the instance to be moved and the InstanceSet which owns it.
+func (m migrateInstanceOpsHandler) getInstance(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*corev1.Pod, *workloads.InstanceSet, error) {
+	pod := &corev1.Pod{}
+	podKey := client.ObjectKey{Name: opsRes.OpsRequest.Spec.MigrateInstance.InstanceName, Namespace: opsRes.Cluster.Namespace}
+	if err := cli.Get(reqCtx.Ctx, podKey, pod); err != nil {
+		return nil, nil, err
+	}
+	itsName := constant.GenerateWorkloadNamePattern(opsRes.Cluster.Name, pod.Labels[constant.KBAppComponentLabelKey])
+	its := &workloads.InstanceSet{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: itsName, Namespace: opsRes.Cluster.Namespace}, its); err != nil {
+		return nil, nil, err
+	}
+	return pod, its, nil
+}
+
+func (m migrateInstanceOpsHandler) deleteInstancePVCs(reqCtx intctrlutil.RequestCtx, cli client.Client, pod *corev1.Pod) error {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+		pvc := &corev1.PersistentVolumeClaim{}
+		if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: volume.PersistentVolumeClaim.ClaimName, Namespace: pod.Namespace}, pvc); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if pvc.DeletionTimestamp != nil {
+			continue
+		}
+		if err := intctrlutil.BackgroundDeleteObject(cli, reqCtx.Ctx, pvc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setNodeSelectorOnce sets the node selector of the pod in the node-selector-once annotation of the InstanceSet,
+// the node selector of the pod is removed if nodeSelector is nil.
+func setNodeSelectorOnce(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	its *workloads.InstanceSet,
+	podName string,
+	nodeSelector map[string]string) error {
+	nodeSelectors, err := instanceset.GetNodeSelectorOnce(its.Annotations)
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(nodeSelectors[podName], nodeSelector) {
+		return nil
+	}
+	if nodeSelectors == nil {
+		nodeSelectors = map[string]map[string]string{}
+	}
+	if nodeSelector == nil {
+		delete(nodeSelectors, podName)
+	} else {
+		nodeSelectors[podName] = nodeSelector
+	}
+
+	patch := client.MergeFrom(its.DeepCopy())
+	if len(nodeSelectors) == 0 {
+		delete(its.Annotations, constant.NodeSelectorOnceAnnotationKey)
+	} else {
+		value, err := json.Marshal(nodeSelectors)
+		if err != nil {
+			return err
+		}
+		if its.Annotations == nil {
+			its.Annotations = map[string]string{}
+		}
+		its.Annotations[constant.NodeSelectorOnceAnnotationKey] = string(value)
+	}
+	return cli.Patch(reqCtx.Ctx, its, patch)
+}
+
+// buildMigrateNodeSelector builds the node selector which places the instance on the target node or zone.
+// The hostname label of the target node is used, which may differ from the node name on some providers.
+func buildMigrateNodeSelector(migrateInstance *appsv1alpha1.MigrateInstance, targetNode *corev1.Node) map[string]string {
+	if targetNode != nil {
+		hostname, ok := targetNode.Labels[corev1.LabelHostname]
+		if !ok {
+			hostname = targetNode.Name
+		}
+		return map[string]string{corev1.LabelHostname: hostname}
+	}
+	return map[string]string{corev1.LabelTopologyZone: migrateInstance.TargetZone}
+}
+
+// isNodeMigrationTarget checks whether the node is the target node or in the target zone.
+func isNodeMigrationTarget(node *corev1.Node, migrateInstance *appsv1alpha1.MigrateInstance) bool {
+	if migrateInstance.TargetNodeName != "" {
+		return node.Name == migrateInstance.TargetNodeName
+	}
+	return node.Labels[corev1.LabelTopologyZone] == migrateInstance.TargetZone
+}
@@ -0,0 +1,160 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("MigrateInstance OpsRequest", func() {
+	var (
+		randomStr   = testCtx.GetRandomStr()
+		clusterName = "cluster-for-ops-" + randomStr
+		compDefName = "compdef-for-migration-" + randomStr
+		nodeName    = "node-for-migration-" + randomStr
+		podName     = clusterName + "-" + consensusComp + "-0"
+		reqCtx      intctrlutil.RequestCtx
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		// delete cluster(and all dependent sub-resources), clusterversion and clusterdef
+		testapps.ClearClusterResources(&testCtx)
+
+		// delete rest resources
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		// namespaced
+		testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
+		testapps.ClearResources(&testCtx, generics.PodSignature, inNS, ml, client.GracePeriodSeconds(0))
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.InstanceSetSignature, true, inNS, ml)
+		// non-namespaced
+		testapps.ClearResources(&testCtx, generics.NodeSignature, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ComponentDefinitionSignature, true, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(cleanEnv)
+
+	newNode := func(labels map[string]string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   nodeName,
+				Labels: labels,
+			},
+		}
+	}
+
+	It("builds the node selector of the target node or zone", func() {
+		migrateInstance := &appsv1alpha1.MigrateInstance{TargetNodeName: nodeName}
+
+		By("expect the hostname label of the target node is used")
+		node := newNode(map[string]string{corev1.LabelHostname: "ip-10-0-0-1"})
+		Expect(buildMigrateNodeSelector(migrateInstance, node)).Should(Equal(map[string]string{corev1.LabelHostname: "ip-10-0-0-1"}))
+
+		By("expect the node name is used if the target node has no hostname label")
+		node.Labels = nil
+		Expect(buildMigrateNodeSelector(migrateInstance, node)).Should(Equal(map[string]string{corev1.LabelHostname: nodeName}))
+
+		migrateInstance = &appsv1alpha1.MigrateInstance{TargetZone: "zone-b"}
+		Expect(buildMigrateNodeSelector(migrateInstance, nil)).Should(Equal(map[string]string{corev1.LabelTopologyZone: "zone-b"}))
+	})
+
+	It("checks whether the node is the target node or in the target zone", func() {
+		node := newNode(map[string]string{corev1.LabelTopologyZone: "zone-a"})
+		Expect(isNodeMigrationTarget(node, &appsv1alpha1.MigrateInstance{TargetNodeName: nodeName})).Should(BeTrue())
+		Expect(isNodeMigrationTarget(node, &appsv1alpha1.MigrateInstance{TargetNodeName: "node-2"})).Should(BeFalse())
+		Expect(isNodeMigrationTarget(node, &appsv1alpha1.MigrateInstance{TargetZone: "zone-a"})).Should(BeTrue())
+		Expect(isNodeMigrationTarget(node, &appsv1alpha1.MigrateInstance{TargetZone: "zone-b"})).Should(BeFalse())
+	})
+
+	Context("with the cluster and the InstanceSet", func() {
+		checkNodeSelectors := func(its *workloads.InstanceSet, expected map[string]map[string]string) {
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(its), func(g Gomega, its *workloads.InstanceSet) {
+				nodeSelectors, err := instanceset.GetNodeSelectorOnce(its.Annotations)
+				g.Expect(err).ShouldNot(HaveOccurred())
+				g.Expect(nodeSelectors).Should(Equal(expected))
+			})).Should(Succeed())
+		}
+
+		It("sets and removes the node selectors of the instances in the InstanceSet", func() {
+			its := testapps.MockInstanceSetComponent(&testCtx, clusterName, consensusComp)
+			reqCtx = intctrlutil.RequestCtx{Ctx: testCtx.Ctx, Log: logf.FromContext(testCtx.Ctx)}
+			selector := map[string]string{corev1.LabelTopologyZone: "zone-b"}
+
+			Expect(setNodeSelectorOnce(reqCtx, k8sClient, its, its.Name+"-0", selector)).Should(Succeed())
+			Expect(setNodeSelectorOnce(reqCtx, k8sClient, its, its.Name+"-1", selector)).Should(Succeed())
+			checkNodeSelectors(its, map[string]map[string]string{its.Name + "-0": selector, its.Name + "-1": selector})
+
+			By("expect the annotation is removed with the last node selector")
+			Expect(setNodeSelectorOnce(reqCtx, k8sClient, its, its.Name+"-0", nil)).Should(Succeed())
+			Expect(setNodeSelectorOnce(reqCtx, k8sClient, its, its.Name+"-1", nil)).Should(Succeed())
+			Expect(its.Annotations).ShouldNot(HaveKey(constant.NodeSelectorOnceAnnotationKey))
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(its), func(g Gomega, its *workloads.InstanceSet) {
+				g.Expect(its.Annotations).ShouldNot(HaveKey(constant.NodeSelectorOnceAnnotationKey))
+			})).Should(Succeed())
+		})
+
+		It("pins the instance to the target node", func() {
+			By("create the ComponentDefinition, the cluster, the InstanceSet and the target node")
+			compDef := createCompDefWithLifecycleActions(compDefName)
+			cluster := testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, "", "").
+				AddComponentV2(consensusComp, compDef.Name).SetReplicas(3).
+				Create(&testCtx).GetObject()
+			its := testapps.MockInstanceSetComponent(&testCtx, clusterName, consensusComp)
+			testapps.MockInstanceSetPod(&testCtx, its, clusterName, consensusComp, podName, "follower", "Readonly")
+			Expect(testCtx.CreateObj(testCtx.Ctx, newNode(map[string]string{corev1.LabelHostname: "ip-10-0-0-1"}))).Should(Succeed())
+			reqCtx = intctrlutil.RequestCtx{Ctx: testCtx.Ctx, Log: logf.FromContext(testCtx.Ctx)}
+			opsRes := initOpsResourceWithCluster(cluster, consensusComp)
+
+			By("create the MigrateInstance opsRequest and run the action")
+			ops := testapps.NewOpsRequestObj("migrate-instance-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.MigrateInstanceType)
+			ops.Spec.MigrateInstance = &appsv1alpha1.MigrateInstance{
+				ComponentOps:   appsv1alpha1.ComponentOps{ComponentName: consensusComp},
+				InstanceName:   podName,
+				TargetNodeName: nodeName,
+			}
+			createOpsAndRunAction(reqCtx, opsRes, ops)
+
+			By("expect the instance is pinned to the hostname of the target node")
+			checkNodeSelectors(its, map[string]map[string]string{podName: {corev1.LabelHostname: "ip-10-0-0-1"}})
+		})
+	})
+})
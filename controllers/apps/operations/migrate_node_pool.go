@@ -0,0 +1,424 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
+	"github.com/apecloud/kubeblocks/pkg/controller/job"
+	"github.com/apecloud/kubeblocks/pkg/controller/scheduling"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/quorum"
+)
+
+const (
+	// migrateUnschedulableTimeout is the duration to wait for a moved instance to be scheduled to the target node pool,
+	// the placement constraints are rolled back if it is exceeded.
+	migrateUnschedulableTimeout = 5 * time.Minute
+
+	reasonInstanceMigrating = "InstanceMigrating"
+	reasonMigrationFailed   = "NodePoolMigrationFailed"
+)
+
+type migrateNodePoolOpsHandler struct{}
+
+var _ OpsHandler = migrateNodePoolOpsHandler{}
+
+func init() {
+	migrateHandler := migrateNodePoolOpsHandler{}
+	migrateNodePoolBehaviour := OpsBehaviour{
+		FromClusterPhases: appsv1alpha1.GetClusterUpRunningPhases(),
+		ToClusterPhase:    appsv1alpha1.UpdatingClusterPhase,
+		QueueByCluster:    true,
+		OpsHandler:        migrateHandler,
+		CancelFunc:        migrateHandler.Cancel,
+		TerminateFunc:     migrateHandler.Terminate,
+	}
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(appsv1alpha1.MigrateNodePoolType, migrateNodePoolBehaviour)
+}
+
+// ActionStartedCondition the started condition when handling the migrate-node-pool request.
+func (m migrateNodePoolOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return appsv1alpha1.NewMigrateNodePoolCondition(opsRes.OpsRequest), nil
+}
+
+// Action pauses the rolling update of the InstanceSet and merges the placement constraints of the target node pool
+// into the scheduling policy of the component. The instances are moved by ReconcileAction one at a time.
+func (m migrateNodePoolOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	migrateNodePool := opsRes.OpsRequest.Spec.MigrateNodePool
+	compSpec := opsRes.Cluster.Spec.GetComponentByName(migrateNodePool.ComponentName)
+	if compSpec == nil {
+		return intctrlutil.NewFatalError(fmt.Sprintf(`the component "%s" is not found`, migrateNodePool.ComponentName))
+	}
+	if err := m.setInstanceSetPaused(reqCtx, cli, opsRes, true); err != nil {
+		return err
+	}
+	policy, err := buildMigrationSchedulingPolicy(opsRes.Cluster, compSpec, migrateNodePool)
+	if err != nil {
+		return err
+	}
+	compSpec.SchedulingPolicy = policy
+	return cli.Update(reqCtx.Ctx, opsRes.Cluster)
+}
+
+// ReconcileAction will be performed when action is done and loops till OpsRequest.status.phase is Succeed/Failed.
+// It moves the instances to the target node pool one at a time in the order of role priority, and switches over
+// the leader to a moved instance before moving it.
+func (m migrateNodePoolOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	var (
+		migrateNodePool = opsRes.OpsRequest.Spec.MigrateNodePool
+		compName        = migrateNodePool.ComponentName
+		oldOpsRequest   = opsRes.OpsRequest.DeepCopy()
+		opsRequestPhase = opsRes.OpsRequest.Status.Phase
+	)
+	its, err := m.getInstanceSet(reqCtx, cli, opsRes)
+	if err != nil {
+		return opsRequestPhase, 0, err
+	}
+	if !selectorMatched(its.Spec.Template.Spec.NodeSelector, migrateNodePool.NodeSelector) {
+		// waiting for the placement constraints to be applied to the InstanceSet
+		return opsRequestPhase, defaultOpsResyncAfter, nil
+	}
+	pods, err := component.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, opsRes.Cluster.Name, compName)
+	if err != nil {
+		return opsRequestPhase, 0, err
+	}
+	podList := make([]corev1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		podList = append(podList, *pod)
+	}
+	// followers are moved before the leader
+	instanceset.SortPods(podList, instanceset.ComposeRolePriorityMap(its.Spec.Roles), false)
+
+	if opsRes.OpsRequest.Status.Components == nil {
+		opsRes.OpsRequest.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{}
+	}
+	compStatus := opsRes.OpsRequest.Status.Components[compName]
+	setProgress := func(pod *corev1.Pod, status appsv1alpha1.ProgressStatus, message string) {
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails,
+			appsv1alpha1.ProgressStatusDetail{
+				ObjectKey: getProgressObjectKey(constant.PodKind, pod.Name),
+				Status:    status,
+				Message:   message,
+			})
+	}
+	syncProgress := func(completedCount int) error {
+		opsRes.OpsRequest.Status.Components[compName] = compStatus
+		return syncProgressToOpsRequest(reqCtx, cli, opsRes, oldOpsRequest, completedCount, len(podList))
+	}
+
+	var (
+		completedCount int
+		migrated       *corev1.Pod
+		migrating      *corev1.Pod
+		next           *corev1.Pod
+	)
+	for i := range podList {
+		pod := &podList[i]
+		switch {
+		case isPodMigrated(pod, migrateNodePool.NodeSelector):
+			completedCount++
+			migrated = pod
+			setProgress(pod, appsv1alpha1.SucceedProgressStatus, fmt.Sprintf("Successfully moved pod %s to node %s", pod.Name, pod.Spec.NodeName))
+		case pod.DeletionTimestamp != nil || selectorMatched(pod.Spec.NodeSelector, migrateNodePool.NodeSelector):
+			migrating = pod
+		case next == nil:
+			next = pod
+		}
+	}
+
+	if migrating != nil {
+		if isPodUnschedulable(migrating, migrateUnschedulableTimeout) {
+			message := fmt.Sprintf("Pod %s can not be scheduled to the target node pool, roll back the placement constraints", migrating.Name)
+			setProgress(migrating, appsv1alpha1.FailedProgressStatus, message)
+			return m.rollback(reqCtx, cli, opsRes, oldOpsRequest, syncProgress, completedCount, message)
+		}
+		setProgress(migrating, appsv1alpha1.ProcessingProgressStatus, fmt.Sprintf("Moving pod %s to the target node pool", migrating.Name))
+		return opsRequestPhase, defaultOpsResyncAfter, syncProgress(completedCount)
+	}
+
+	if next == nil {
+		if err = m.setInstanceSetPaused(reqCtx, cli, opsRes, false); err != nil {
+			return opsRequestPhase, 0, err
+		}
+		jobName := genSwitchoverJobName(opsRes.Cluster.Name, compName, opsRes.Cluster.Generation)
+		if err = job.CleanJobByName(reqCtx.Ctx, cli, opsRes.Cluster, jobName); err != nil && !apierrors.IsNotFound(err) {
+			return opsRequestPhase, 0, err
+		}
+		return appsv1alpha1.OpsSucceedPhase, 0, syncProgress(completedCount)
+	}
+	if migrateNodePool.Paused {
+		setProgress(next, appsv1alpha1.PendingProgressStatus, "The migration is paused")
+		return opsRequestPhase, 5 * time.Second, syncProgress(completedCount)
+	}
+
+	if isLeaderPod(its, next) && migrated != nil {
+		switchedOver, err := m.switchoverLeader(reqCtx, cli, opsRes, next, migrated)
+		if err != nil {
+			if !intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+				return opsRequestPhase, 0, err
+			}
+			message := fmt.Sprintf("Failed to switch over the leader %s before moving it: %s", next.Name, err.Error())
+			setProgress(next, appsv1alpha1.FailedProgressStatus, message)
+			return m.rollback(reqCtx, cli, opsRes, oldOpsRequest, syncProgress, completedCount, message)
+		}
+		if !switchedOver {
+			setProgress(next, appsv1alpha1.ProcessingProgressStatus, fmt.Sprintf("Switching over the leader %s before moving it", next.Name))
+			return opsRequestPhase, time.Second, syncProgress(completedCount)
+		}
+	}
+
+	if !opsRes.OpsRequest.Spec.QuorumLossAcknowledged() {
+		if err = checkMigrationQuorum(its, podList, next); err != nil {
+			setProgress(next, appsv1alpha1.PendingProgressStatus, fmt.Sprintf("Waiting for the quorum before moving pod %s: %s", next.Name, err.Error()))
+			return opsRequestPhase, defaultOpsResyncAfter, syncProgress(completedCount)
+		}
+	}
+
+	// the pod is recreated by the InstanceSet with the placement constraints of the target node pool.
+	if err = intctrlutil.BackgroundDeleteObject(cli, reqCtx.Ctx, next); err != nil {
+		return opsRequestPhase, 0, err
+	}
+	message := fmt.Sprintf("Moving pod %s from node %s to the target node pool", next.Name, next.Spec.NodeName)
+	opsRes.Recorder.Event(opsRes.OpsRequest, corev1.EventTypeNormal, reasonInstanceMigrating, message)
+	setProgress(next, appsv1alpha1.ProcessingProgressStatus, message)
+	return opsRequestPhase, defaultOpsResyncAfter, syncProgress(completedCount)
+}
+
+// SaveLastConfiguration records the scheduling policy of the component for rolling back the placement constraints.
+func (m migrateNodePoolOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	compOpsHelper := newComponentOpsHelper([]appsv1alpha1.MigrateNodePool{*opsRes.OpsRequest.Spec.MigrateNodePool})
+	compOpsHelper.saveLastConfigurations(opsRes, func(compSpec appsv1alpha1.ClusterComponentSpec, comOps ComponentOpsInteface) appsv1alpha1.LastComponentConfiguration {
+		return appsv1alpha1.LastComponentConfiguration{
+			SchedulingPolicy: compSpec.SchedulingPolicy,
+		}
+	})
+	return nil
+}
+
+// Cancel this function defines the cancel migrateNodePool action, the placement constraints are rolled back
+// and the moved instances are moved back by the rolling update of the InstanceSet.
+func (m migrateNodePoolOpsHandler) Cancel(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return m.rollbackPlacement(reqCtx, cli, opsRes)
+}
+
+// Terminate rolls back the placement constraints and resumes the rolling update of the InstanceSet
+// when the opsRequest times out, otherwise the InstanceSet is left paused.
+func (m migrateNodePoolOpsHandler) Terminate(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return m.rollbackPlacement(reqCtx, cli, opsRes)
+}
+
+func (m migrateNodePoolOpsHandler) rollback(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	oldOpsRequest *appsv1alpha1.OpsRequest,
+	syncProgress func(completedCount int) error,
+	completedCount int,
+	message string) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	if err := m.rollbackPlacement(reqCtx, cli, opsRes); err != nil {
+		return oldOpsRequest.Status.Phase, 0, err
+	}
+	opsRes.Recorder.Event(opsRes.OpsRequest, corev1.EventTypeWarning, reasonMigrationFailed, message)
+	return appsv1alpha1.OpsFailedPhase, 0, syncProgress(completedCount)
+}
+
+// rollbackPlacement restores the scheduling policy of the component and resumes the rolling update of the InstanceSet.
+func (m migrateNodePoolOpsHandler) rollbackPlacement(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	compOpsHelper := newComponentOpsHelper([]appsv1alpha1.MigrateNodePool{*opsRes.OpsRequest.Spec.MigrateNodePool})
+	if err := compOpsHelper.cancelComponentOps(reqCtx.Ctx, cli, opsRes, func(lastConfig *appsv1alpha1.LastComponentConfiguration, comp *appsv1alpha1.ClusterComponentSpec) {
+		comp.SchedulingPolicy = lastConfig.SchedulingPolicy
+	}); err != nil {
+		return err
+	}
+	return m.setInstanceSetPaused(reqCtx, cli, opsRes, false)
+}
+
+// switchoverLeader switches over the leader to the candidate which has been moved to the target node pool.
+// It returns true once the leader is not the pod to be moved.
+func (m migrateNodePoolOpsHandler) switchoverLeader(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	leader, candidate *corev1.Pod) (bool, error) {
+	compSpec := opsRes.Cluster.Spec.GetComponentByName(opsRes.OpsRequest.Spec.MigrateNodePool.ComponentName)
+	synthesizedComp, err := buildSynthesizedComp(reqCtx, cli, opsRes, compSpec)
+	if err != nil {
+		return false, err
+	}
+	if synthesizedComp.LifecycleActions == nil || synthesizedComp.LifecycleActions.Switchover == nil {
+		// move the leader directly, and the new leader is elected by the database itself.
+		opsRes.Recorder.Eventf(opsRes.OpsRequest, corev1.EventTypeWarning, reasonInstanceMigrating,
+			"the switchover action is not defined, the leader %s will be moved directly", leader.Name)
+		return true, nil
+	}
+	switchover := &appsv1alpha1.Switchover{
+		ComponentOps: appsv1alpha1.ComponentOps{ComponentName: compSpec.Name},
+		InstanceName: candidate.Name,
+	}
+	if err = createSwitchoverJob(reqCtx, cli, opsRes.Cluster, synthesizedComp, switchover); err != nil {
+		return false, err
+	}
+	jobName := genSwitchoverJobName(opsRes.Cluster.Name, compSpec.Name, opsRes.Cluster.Generation)
+	if err = job.CheckJobSucceed(reqCtx.Ctx, cli, opsRes.Cluster, jobName); err != nil {
+		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+			return false, err
+		}
+		return false, nil
+	}
+	// waiting for the role label of the leader to be updated
+	return false, nil
+}
+
+func (m migrateNodePoolOpsHandler) getInstanceSet(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*workloads.InstanceSet, error) {
+	itsName := constant.GenerateWorkloadNamePattern(opsRes.Cluster.Name, opsRes.OpsRequest.Spec.MigrateNodePool.ComponentName)
+	its := &workloads.InstanceSet{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: itsName, Namespace: opsRes.Cluster.Namespace}, its); err != nil {
+		return nil, err
+	}
+	return its, nil
+}
+
+// setInstanceSetPaused pauses or resumes the rolling update of the InstanceSet,
+// the instances are moved by the OpsRequest while the InstanceSet is paused.
+func (m migrateNodePoolOpsHandler) setInstanceSetPaused(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource, paused bool) error {
+	its, err := m.getInstanceSet(reqCtx, cli, opsRes)
+	if err != nil {
+		if apierrors.IsNotFound(err) && !paused {
+			return nil
+		}
+		return err
+	}
+	if its.Spec.Paused == paused {
+		return nil
+	}
+	patch := client.MergeFrom(its.DeepCopy())
+	its.Spec.Paused = paused
+	return cli.Patch(reqCtx.Ctx, its, patch)
+}
+
+// buildMigrationSchedulingPolicy merges the placement constraints of the target node pool into
+// the scheduling policy that is currently applied to the component.
+func buildMigrationSchedulingPolicy(cluster *appsv1alpha1.Cluster,
+	compSpec *appsv1alpha1.ClusterComponentSpec,
+	migrateNodePool *appsv1alpha1.MigrateNodePool) (*appsv1alpha1.SchedulingPolicy, error) {
+	var policy *appsv1alpha1.SchedulingPolicy
+	switch {
+	case compSpec.SchedulingPolicy != nil:
+		policy = compSpec.SchedulingPolicy.DeepCopy()
+	case cluster.Spec.SchedulingPolicy != nil:
+		policy = cluster.Spec.SchedulingPolicy.DeepCopy()
+	default:
+		// convert the legacy affinity and tolerations, the cluster-wide ones are still merged by the controller.
+		affinity := cluster.Spec.Affinity
+		if compSpec.Affinity != nil {
+			affinity = compSpec.Affinity
+		}
+		tolerations := cluster.Spec.Tolerations
+		if len(compSpec.Tolerations) != 0 {
+			tolerations = compSpec.Tolerations
+		}
+		var err error
+		if policy, err = scheduling.BuildSchedulingPolicy4Component(cluster.Name, compSpec.Name, affinity, tolerations); err != nil {
+			return nil, err
+		}
+	}
+	if policy.NodeSelector == nil {
+		policy.NodeSelector = map[string]string{}
+	}
+	for k, v := range migrateNodePool.NodeSelector {
+		policy.NodeSelector[k] = v
+	}
+	// the instances can not be moved if they are pinned to a node.
+	policy.NodeName = ""
+	for _, toleration := range migrateNodePool.Tolerations {
+		exists := false
+		for _, t := range policy.Tolerations {
+			if t.MatchToleration(&toleration) {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			policy.Tolerations = append(policy.Tolerations, toleration)
+		}
+	}
+	return policy, nil
+}
+
+// isPodMigrated checks whether the pod has been moved to the target node pool and is ready.
+func isPodMigrated(pod *corev1.Pod, nodeSelector map[string]string) bool {
+	return pod.DeletionTimestamp == nil && selectorMatched(pod.Spec.NodeSelector, nodeSelector) &&
+		pod.Spec.NodeName != "" && intctrlutil.PodIsReady(pod)
+}
+
+// isPodUnschedulable checks whether the pod has been unschedulable for longer than the timeout.
+func isPodUnschedulable(pod *corev1.Pod, timeout time.Duration) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionFalse &&
+			cond.Reason == corev1.PodReasonUnschedulable {
+			return time.Since(cond.LastTransitionTime.Time) > timeout
+		}
+	}
+	return false
+}
+
+func isLeaderPod(its *workloads.InstanceSet, pod *corev1.Pod) bool {
+	roleName := pod.Labels[constant.RoleLabelKey]
+	for _, role := range its.Spec.Roles {
+		if role.IsLeader && strings.EqualFold(role.Name, roleName) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkMigrationQuorum checks whether the quorum of the component is kept after the pod is moved.
+func checkMigrationQuorum(its *workloads.InstanceSet, pods []corev1.Pod, pod *corev1.Pod) error {
+	if !quorum.IsAvailableVoter(its, pod) {
+		return nil
+	}
+	members, ok := quorum.ForInstanceSet(its, pods)
+	if !ok {
+		return nil
+	}
+	return members.CheckDisruption(1)
+}
+
+// selectorMatched checks whether all labels of the selector are contained in the node selector.
+func selectorMatched(nodeSelector, selector map[string]string) bool {
+	for k, v := range selector {
+		if nodeSelector[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,230 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("MigrateNodePool OpsRequest", func() {
+	var (
+		randomStr   = testCtx.GetRandomStr()
+		clusterName = "cluster-for-ops-" + randomStr
+		compDefName = "compdef-for-migration-" + randomStr
+		reqCtx      intctrlutil.RequestCtx
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		// delete cluster(and all dependent sub-resources), clusterversion and clusterdef
+		testapps.ClearClusterResources(&testCtx)
+
+		// delete rest resources
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		// namespaced
+		testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.InstanceSetSignature, true, inNS, ml)
+		// non-namespaced
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ComponentDefinitionSignature, true, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(cleanEnv)
+
+	var (
+		oldSchedulingPolicy = &appsv1alpha1.SchedulingPolicy{
+			NodeSelector: map[string]string{"zone": "a", "pool": "old"},
+			NodeName:     "node-1",
+			Tolerations:  []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}},
+		}
+		migrateNodePool = &appsv1alpha1.MigrateNodePool{
+			ComponentOps: appsv1alpha1.ComponentOps{ComponentName: consensusComp},
+			NodeSelector: map[string]string{"pool": "new"},
+			Tolerations: []corev1.Toleration{
+				{Key: "dedicated", Operator: corev1.TolerationOpExists},
+				{Key: "pool", Operator: corev1.TolerationOpEqual, Value: "new", Effect: corev1.TaintEffectNoSchedule},
+			},
+		}
+	)
+
+	newClusterFactory := func(schedulingPolicy *appsv1alpha1.SchedulingPolicy) *testapps.MockClusterFactory {
+		return testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, "", "").
+			AddComponentV2(consensusComp, compDefName).SetReplicas(3).
+			Apply(func(cluster *appsv1alpha1.Cluster) {
+				cluster.Spec.ComponentSpecs[0].SchedulingPolicy = schedulingPolicy.DeepCopy()
+			})
+	}
+
+	newInstanceSet := func() *workloads.InstanceSet {
+		return testapps.NewInstanceSetFactory(testCtx.DefaultNamespace, clusterName+"-"+consensusComp, clusterName, consensusComp).
+			SetReplicas(3).
+			SetRoles([]workloads.ReplicaRole{
+				{Name: "leader", AccessMode: workloads.ReadWriteMode, CanVote: true, IsLeader: true},
+				{Name: "follower", AccessMode: workloads.ReadonlyMode, CanVote: true},
+			}).GetObject()
+	}
+
+	newPod := func(name, role string, ready bool) *corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		pod := testapps.NewPodFactory(testCtx.DefaultNamespace, name).
+			AddAppInstanceLabel(clusterName).
+			AddAppComponentLabel(consensusComp).
+			AddRoleLabel(role).
+			AddContainer(corev1.Container{Name: testapps.DefaultMySQLContainerName, Image: testapps.ApeCloudMySQLImage}).
+			GetObject()
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}
+		return pod
+	}
+
+	Context("builds the scheduling policy of the target node pool", func() {
+		It("merges the placement constraints into the scheduling policy of the component", func() {
+			cluster := newClusterFactory(oldSchedulingPolicy).GetObject()
+			compSpec := &cluster.Spec.ComponentSpecs[0]
+			policy, err := buildMigrationSchedulingPolicy(cluster, compSpec, migrateNodePool)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(policy.NodeSelector).Should(Equal(map[string]string{"zone": "a", "pool": "new"}))
+			Expect(policy.NodeName).Should(BeEmpty())
+			Expect(policy.Tolerations).Should(HaveLen(2))
+
+			By("expect the original scheduling policy is kept for rolling back")
+			Expect(compSpec.SchedulingPolicy.NodeSelector).Should(HaveKeyWithValue("pool", "old"))
+		})
+
+		It("uses the placement constraints only if no scheduling policy is specified", func() {
+			cluster := newClusterFactory(nil).GetObject()
+			policy, err := buildMigrationSchedulingPolicy(cluster, &cluster.Spec.ComponentSpecs[0], migrateNodePool)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(policy.NodeSelector).Should(Equal(map[string]string{"pool": "new"}))
+		})
+	})
+
+	It("checks whether the instance is moved to the target node pool", func() {
+		pod := newPod(clusterName+"-"+consensusComp+"-0", "leader", true)
+		pod.Spec.NodeSelector = map[string]string{"pool": "old"}
+		pod.Spec.NodeName = "node-1"
+		Expect(isPodMigrated(pod, migrateNodePool.NodeSelector)).Should(BeFalse())
+
+		pod.Spec.NodeSelector = migrateNodePool.NodeSelector
+		Expect(isPodMigrated(pod, migrateNodePool.NodeSelector)).Should(BeTrue())
+
+		By("expect the unscheduled instance is not moved")
+		pod.Spec.NodeName = ""
+		pod.Status.Conditions = []corev1.PodCondition{{
+			Type:               corev1.PodScheduled,
+			Status:             corev1.ConditionFalse,
+			Reason:             corev1.PodReasonUnschedulable,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+		}}
+		Expect(isPodMigrated(pod, migrateNodePool.NodeSelector)).Should(BeFalse())
+		Expect(isPodUnschedulable(pod, migrateUnschedulableTimeout)).Should(BeFalse())
+		Expect(isPodUnschedulable(pod, 30*time.Second)).Should(BeTrue())
+	})
+
+	It("checks whether the instance is the leader", func() {
+		its := newInstanceSet()
+		Expect(isLeaderPod(its, newPod(clusterName+"-"+consensusComp+"-0", "leader", true))).Should(BeTrue())
+		Expect(isLeaderPod(its, newPod(clusterName+"-"+consensusComp+"-1", "follower", true))).Should(BeFalse())
+	})
+
+	It("keeps the quorum when moving the instances", func() {
+		its := newInstanceSet()
+		pods := []corev1.Pod{
+			*newPod(clusterName+"-"+consensusComp+"-0", "leader", true),
+			*newPod(clusterName+"-"+consensusComp+"-1", "follower", true),
+			*newPod(clusterName+"-"+consensusComp+"-2", "follower", true),
+		}
+		Expect(checkMigrationQuorum(its, pods, &pods[1])).Should(Succeed())
+
+		By("expect moving another voter breaks the quorum when one of them is unavailable")
+		pods[2] = *newPod(clusterName+"-"+consensusComp+"-2", "follower", false)
+		Expect(checkMigrationQuorum(its, pods, &pods[1])).ShouldNot(Succeed())
+
+		By("expect the unavailable voter itself can be moved")
+		Expect(checkMigrationQuorum(its, pods, &pods[2])).Should(Succeed())
+	})
+
+	Context("with the cluster and the InstanceSet", func() {
+		initOpsRes := func() (*OpsResource, *workloads.InstanceSet) {
+			By("create the ComponentDefinition, the cluster and the InstanceSet")
+			createCompDefWithLifecycleActions(compDefName)
+			cluster := newClusterFactory(oldSchedulingPolicy).Create(&testCtx).GetObject()
+			its := testapps.MockInstanceSetComponent(&testCtx, clusterName, consensusComp)
+
+			reqCtx = intctrlutil.RequestCtx{Ctx: testCtx.Ctx, Log: logf.FromContext(testCtx.Ctx)}
+			return initOpsResourceWithCluster(cluster, consensusComp), its
+		}
+
+		newMigrateOps := func() *appsv1alpha1.OpsRequest {
+			ops := testapps.NewOpsRequestObj("migrate-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.MigrateNodePoolType)
+			ops.Spec.MigrateNodePool = migrateNodePool.DeepCopy()
+			return ops
+		}
+
+		It("pauses the InstanceSet and applies the placement constraints, and rolls them back when cancelled", func() {
+			opsRes, its := initOpsRes()
+
+			By("create the MigrateNodePool opsRequest and run the action")
+			createOpsAndRunAction(reqCtx, opsRes, newMigrateOps())
+
+			By("expect the InstanceSet is paused and the placement constraints are applied to the component")
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(its), func(g Gomega, its *workloads.InstanceSet) {
+				g.Expect(its.Spec.Paused).Should(BeTrue())
+			})).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.Cluster), func(g Gomega, cluster *appsv1alpha1.Cluster) {
+				policy := cluster.Spec.ComponentSpecs[0].SchedulingPolicy
+				g.Expect(policy.NodeSelector).Should(Equal(map[string]string{"zone": "a", "pool": "new"}))
+				g.Expect(policy.NodeName).Should(BeEmpty())
+			})).Should(Succeed())
+
+			By("cancel the opsRequest and expect the placement constraints are rolled back")
+			Expect(migrateNodePoolOpsHandler{}.Cancel(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.Cluster), func(g Gomega, cluster *appsv1alpha1.Cluster) {
+				g.Expect(cluster.Spec.ComponentSpecs[0].SchedulingPolicy).Should(Equal(oldSchedulingPolicy))
+			})).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(its), func(g Gomega, its *workloads.InstanceSet) {
+				g.Expect(its.Spec.Paused).Should(BeFalse())
+			})).Should(Succeed())
+		})
+	})
+})
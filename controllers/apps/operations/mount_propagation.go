@@ -0,0 +1,125 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+)
+
+const (
+	// mountPropagationLagThreshold is the time after which the instances which have not picked up the renewed
+	// content of the mounted secrets are reported, kubelet refreshes the secret volumes within its sync period
+	// plus the TTL of its secret cache, which are both one minute by default.
+	mountPropagationLagThreshold = 3 * time.Minute
+
+	reasonMountPropagationLagging = "MountPropagationLagging"
+)
+
+// mountPropagationVerifier verifies whether the renewed content of a mounted file has been propagated to the instances,
+// by comparing the checksum of the file in the instances, and records the instances lagging behind.
+type mountPropagationVerifier struct {
+	file       string
+	checksum   string
+	stragglers map[string]string
+}
+
+func newMountPropagationVerifier(file, checksum string) *mountPropagationVerifier {
+	return &mountPropagationVerifier{
+		file:       file,
+		checksum:   checksum,
+		stragglers: map[string]string{},
+	}
+}
+
+// verify checks the checksum of the file mounted in the pod by the checksum action of Lorry, the pods which have
+// succeeded are not checked again. The pods without Lorry are taken as propagated since they can not be verified.
+func (v *mountPropagationVerifier) verify(reqCtx intctrlutil.RequestCtx, pod *corev1.Pod, compStatus *appsv1alpha1.OpsRequestComponentStatus) bool {
+	progressDetail := findStatusProgressDetail(compStatus.ProgressDetails, getProgressObjectKey(constant.PodKind, pod.Name))
+	if progressDetail != nil && progressDetail.Status == appsv1alpha1.SucceedProgressStatus {
+		return true
+	}
+	lorryCli, err := lorry.NewClient(*pod)
+	if err != nil {
+		v.stragglers[pod.Name] = err.Error()
+		return false
+	}
+	if intctrlutil.IsNil(lorryCli) {
+		return true
+	}
+	checksums, err := lorryCli.Checksum(reqCtx.Ctx, []string{v.file})
+	switch {
+	case err != nil:
+		reqCtx.Log.Info("failed to get the checksum of the mounted file", "pod", pod.Name, "file", v.file, "error", err.Error())
+		v.stragglers[pod.Name] = err.Error()
+		return false
+	case checksums[v.file] != v.checksum:
+		v.stragglers[pod.Name] = "the file has not been refreshed"
+		return false
+	}
+	delete(v.stragglers, pod.Name)
+	return true
+}
+
+// report surfaces the stragglers in the status of the component once the propagation lags beyond the threshold,
+// and clears the status after all the instances have picked up the renewed content.
+func (v *mountPropagationVerifier) report(opsRes *OpsResource, compStatus *appsv1alpha1.OpsRequestComponentStatus) {
+	if len(v.stragglers) == 0 {
+		if compStatus.Reason == reasonMountPropagationLagging {
+			compStatus.Reason = ""
+			compStatus.Message = ""
+		}
+		return
+	}
+	startTime := opsRes.OpsRequest.Status.StartTimestamp
+	if startTime.IsZero() || time.Since(startTime.Time) < mountPropagationLagThreshold {
+		return
+	}
+	message := v.stragglersMessage()
+	if compStatus.Reason == reasonMountPropagationLagging && compStatus.Message == message {
+		return
+	}
+	compStatus.Reason = reasonMountPropagationLagging
+	compStatus.Message = message
+	opsRes.Recorder.Event(opsRes.OpsRequest, corev1.EventTypeWarning, reasonMountPropagationLagging, message)
+}
+
+func (v *mountPropagationVerifier) stragglersMessage() string {
+	podNames := make([]string, 0, len(v.stragglers))
+	for podName := range v.stragglers {
+		podNames = append(podNames, podName)
+	}
+	sort.Strings(podNames)
+	details := make([]string, 0, len(podNames))
+	for _, podName := range podNames {
+		details = append(details, fmt.Sprintf("%s: %s", podName, v.stragglers[podName]))
+	}
+	return fmt.Sprintf("the renewed content of %s has not been propagated to the instances after %s, %s",
+		v.file, mountPropagationLagThreshold, strings.Join(details, "; "))
+}
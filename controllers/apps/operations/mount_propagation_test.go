@@ -0,0 +1,117 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+)
+
+func TestMountPropagationVerifier(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockLorryCli := lorry.NewMockClient(ctrl)
+	lorry.SetMockClient(mockLorryCli, nil)
+	defer lorry.UnsetMockClient()
+
+	const file = "/etc/pki/tls/tls.crt"
+	var (
+		reqCtx     = intctrlutil.RequestCtx{Ctx: context.Background(), Log: logr.Discard()}
+		pod0       = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-mysql-0"}}
+		pod1       = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-mysql-1"}}
+		compStatus = &appsv1alpha1.OpsRequestComponentStatus{}
+		recorder   = record.NewFakeRecorder(10)
+		opsRes     = &OpsResource{OpsRequest: &appsv1alpha1.OpsRequest{}, Recorder: recorder}
+		verifier   = newMountPropagationVerifier(file, "new")
+	)
+
+	mockLorryCli.EXPECT().Checksum(gomock.Any(), []string{file}).Return(map[string]string{file: "new"}, nil).Times(1)
+	mockLorryCli.EXPECT().Checksum(gomock.Any(), []string{file}).Return(map[string]string{file: "old"}, nil).Times(1)
+	if !verifier.verify(reqCtx, pod0, compStatus) {
+		t.Error("expect the file is propagated to the pod")
+	}
+	if verifier.verify(reqCtx, pod1, compStatus) {
+		t.Error("expect the file is not propagated to the pod")
+	}
+
+	// the stragglers are not reported within the threshold
+	opsRes.OpsRequest.Status.StartTimestamp = metav1.Now()
+	verifier.report(opsRes, compStatus)
+	if compStatus.Reason != "" || len(recorder.Events) != 0 {
+		t.Errorf("unexpected report: %s", compStatus.Message)
+	}
+
+	opsRes.OpsRequest.Status.StartTimestamp = metav1.NewTime(time.Now().Add(-mountPropagationLagThreshold))
+	verifier.report(opsRes, compStatus)
+	if compStatus.Reason != reasonMountPropagationLagging || !strings.Contains(compStatus.Message, "test-mysql-1: the file has not been refreshed") ||
+		strings.Contains(compStatus.Message, "test-mysql-0") {
+		t.Errorf("unexpected report: %s", compStatus.Message)
+	}
+	if len(recorder.Events) != 1 {
+		t.Errorf("expect the stragglers are reported by an event")
+	}
+	// the same stragglers are reported once
+	verifier.report(opsRes, compStatus)
+	if len(recorder.Events) != 1 {
+		t.Errorf("expect the stragglers are reported once")
+	}
+
+	mockLorryCli.EXPECT().Checksum(gomock.Any(), []string{file}).Return(nil, errors.New("lorry is unavailable")).Times(1)
+	if verifier.verify(reqCtx, pod1, compStatus) {
+		t.Error("expect the file is not propagated to the pod")
+	}
+	if verifier.stragglers[pod1.Name] != "lorry is unavailable" {
+		t.Errorf("unexpected stragglers: %v", verifier.stragglers)
+	}
+
+	// the succeed instances are not verified again
+	compStatus.ProgressDetails = []appsv1alpha1.ProgressStatusDetail{{
+		ObjectKey: getProgressObjectKey(constant.PodKind, pod1.Name),
+		Status:    appsv1alpha1.SucceedProgressStatus,
+	}}
+	if !verifier.verify(reqCtx, pod1, compStatus) {
+		t.Error("expect the file is propagated to the pod")
+	}
+
+	// the report is cleared after all the instances pick up the renewed content
+	verifier = newMountPropagationVerifier(file, "new")
+	mockLorryCli.EXPECT().Checksum(gomock.Any(), []string{file}).Return(map[string]string{file: "new"}, nil).Times(1)
+	if !verifier.verify(reqCtx, pod0, compStatus) {
+		t.Error("expect the file is propagated to the pod")
+	}
+	verifier.report(opsRes, compStatus)
+	if compStatus.Reason != "" || compStatus.Message != "" {
+		t.Errorf("expect the report is cleared, but got: %s", compStatus.Message)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/kberrors"
+)
+
+// verifyOpsAssertions verifies spec.assertions of the OpsRequest against the current state of the cluster,
+// it returns an error with the reason "PreconditionMismatch" if any of them does not hold.
+func verifyOpsAssertions(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	assertions := opsRes.OpsRequest.Spec.Assertions
+	if assertions == nil || opsRes.Cluster == nil {
+		return nil
+	}
+	mismatches := checkClusterAssertions(opsRes.Cluster, assertions)
+	for _, assertion := range assertions.Components {
+		if len(assertion.PrimaryInstance) == 0 {
+			continue
+		}
+		mismatch, err := checkPrimaryInstanceAssertion(reqCtx, cli, opsRes, assertion)
+		if err != nil {
+			return err
+		}
+		if len(mismatch) > 0 {
+			mismatches = append(mismatches, mismatch)
+		}
+	}
+	if len(mismatches) == 0 {
+		return nil
+	}
+	return kberrors.New(kberrors.ReasonPreconditionMismatch, "the assertions of the OpsRequest do not hold: %s", strings.Join(mismatches, "; ")).
+		WithHint(`the cluster "%s" has changed since the OpsRequest was created, re-create the OpsRequest against its current state`, opsRes.Cluster.Name)
+}
+
+// checkClusterAssertions checks the assertions on the spec of the cluster, and returns the mismatches.
+func checkClusterAssertions(cluster *appsv1alpha1.Cluster, assertions *appsv1alpha1.OpsAssertions) []string {
+	var mismatches []string
+	if len(assertions.ClusterVersionRef) > 0 && assertions.ClusterVersionRef != cluster.Spec.ClusterVersionRef {
+		mismatches = append(mismatches, fmt.Sprintf(`the cluster version is "%s" rather than "%s"`,
+			cluster.Spec.ClusterVersionRef, assertions.ClusterVersionRef))
+	}
+	for _, assertion := range assertions.Components {
+		compSpec := cluster.Spec.GetComponentByName(assertion.ComponentName)
+		if compSpec == nil {
+			if shardingSpec := cluster.Spec.GetShardingByName(assertion.ComponentName); shardingSpec != nil {
+				compSpec = &shardingSpec.Template
+			}
+		}
+		if compSpec == nil {
+			mismatches = append(mismatches, fmt.Sprintf(`the component "%s" is not found`, assertion.ComponentName))
+			continue
+		}
+		if assertion.Replicas != nil && *assertion.Replicas != compSpec.Replicas {
+			mismatches = append(mismatches, fmt.Sprintf(`the replicas of the component "%s" is %d rather than %d`,
+				assertion.ComponentName, compSpec.Replicas, *assertion.Replicas))
+		}
+		if len(assertion.ServiceVersion) > 0 && assertion.ServiceVersion != compSpec.ServiceVersion {
+			mismatches = append(mismatches, fmt.Sprintf(`the service version of the component "%s" is "%s" rather than "%s"`,
+				assertion.ComponentName, compSpec.ServiceVersion, assertion.ServiceVersion))
+		}
+	}
+	return mismatches
+}
+
+// checkPrimaryInstanceAssertion checks whether the asserted instance is the primary or leader of the component,
+// or of a shard of the sharding, and returns the mismatch.
+func checkPrimaryInstanceAssertion(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	assertion appsv1alpha1.ComponentAssertion) (string, error) {
+	mismatch := fmt.Sprintf(`the instance "%s" is not the primary of the component "%s"`, assertion.PrimaryInstance, assertion.ComponentName)
+	pod := &corev1.Pod{}
+	if err := cli.Get(reqCtx.Ctx, types.NamespacedName{Namespace: opsRes.Cluster.Namespace, Name: assertion.PrimaryInstance}, pod); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Sprintf(`the instance "%s" is not found`, assertion.PrimaryInstance), nil
+		}
+		return "", err
+	}
+	compName := pod.Labels[constant.KBAppComponentLabelKey]
+	if pod.Labels[constant.AppInstanceLabelKey] != opsRes.Cluster.Name ||
+		(compName != assertion.ComponentName && pod.Labels[constant.KBAppShardingNameLabelKey] != assertion.ComponentName) {
+		return mismatch, nil
+	}
+	compSpec := opsRes.Cluster.Spec.GetComponentByName(compName)
+	if compSpec == nil {
+		shardingSpec := opsRes.Cluster.Spec.GetShardingByName(assertion.ComponentName)
+		if shardingSpec == nil {
+			return mismatch, nil
+		}
+		// the shards share the template of the sharding
+		compSpec = shardingSpec.Template.DeepCopy()
+		compSpec.Name = compName
+	}
+	synthesizedComp, err := buildSynthesizedComp(reqCtx, cli, opsRes, compSpec)
+	if err != nil {
+		return "", err
+	}
+	leader, err := getServiceableNWritablePod(reqCtx.Ctx, cli, *synthesizedComp)
+	if err != nil {
+		// no unique primary, e.g. the component has no writable role or is failing over
+		return fmt.Sprintf("%s: %s", mismatch, err.Error()), nil
+	}
+	if leader.Name != pod.Name {
+		return mismatch, nil
+	}
+	return "", nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"testing"
+
+	"k8s.io/utils/pointer"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+func TestCheckClusterAssertions(t *testing.T) {
+	cluster := &appsv1alpha1.Cluster{
+		Spec: appsv1alpha1.ClusterSpec{
+			ClusterVersionRef: "mysql-8.0.30",
+			ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{
+				{Name: "mysql", Replicas: 3, ServiceVersion: "8.0.30"},
+			},
+			ShardingSpecs: []appsv1alpha1.ShardingSpec{
+				{Name: "shard", Shards: 2, Template: appsv1alpha1.ClusterComponentSpec{Name: "shard", Replicas: 2}},
+			},
+		},
+	}
+	cases := []struct {
+		name       string
+		assertions appsv1alpha1.OpsAssertions
+		mismatches int
+	}{
+		{"no assertion", appsv1alpha1.OpsAssertions{}, 0},
+		{"all hold", appsv1alpha1.OpsAssertions{
+			ClusterVersionRef: "mysql-8.0.30",
+			Components: []appsv1alpha1.ComponentAssertion{
+				{ComponentOps: appsv1alpha1.ComponentOps{ComponentName: "mysql"}, Replicas: pointer.Int32(3), ServiceVersion: "8.0.30"},
+				{ComponentOps: appsv1alpha1.ComponentOps{ComponentName: "shard"}, Replicas: pointer.Int32(2)},
+			},
+		}, 0},
+		{"cluster version changed", appsv1alpha1.OpsAssertions{ClusterVersionRef: "mysql-5.7.44"}, 1},
+		{"component changed", appsv1alpha1.OpsAssertions{
+			Components: []appsv1alpha1.ComponentAssertion{
+				{ComponentOps: appsv1alpha1.ComponentOps{ComponentName: "mysql"}, Replicas: pointer.Int32(5), ServiceVersion: "8.0.33"},
+				{ComponentOps: appsv1alpha1.ComponentOps{ComponentName: "shard"}, Replicas: pointer.Int32(2)},
+			},
+		}, 2},
+		{"component not found", appsv1alpha1.OpsAssertions{
+			Components: []appsv1alpha1.ComponentAssertion{
+				{ComponentOps: appsv1alpha1.ComponentOps{ComponentName: "proxy"}, Replicas: pointer.Int32(1)},
+			},
+		}, 1},
+	}
+	for _, c := range cases {
+		if mismatches := checkClusterAssertions(cluster, &c.assertions); len(mismatches) != c.mismatches {
+			t.Errorf("%s: expect %d mismatches, but got %v", c.name, c.mismatches, mismatches)
+		}
+	}
+}
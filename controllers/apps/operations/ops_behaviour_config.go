@@ -0,0 +1,147 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+const (
+	// defaultOpsRequeueAfter is the interval to recheck the pre-conditions of a pending OpsRequest.
+	defaultOpsRequeueAfter = time.Second
+	// defaultOpsResyncAfter is the fallback interval to recheck a running OpsRequest which waits for the state of
+	// the watched objects, such as pods and PVCs, whose changes trigger the reconciliation immediately.
+	defaultOpsResyncAfter = 30 * time.Second
+)
+
+// OpsBehaviourConfig overrides the built-in behaviour of an OpsType.
+// The configs are loaded from the manager config with the key "OPS_BEHAVIOURS", which is a json object
+// keyed by the OpsType, for example:
+//
+//	{"VerticalScaling": {"fromClusterPhases": ["Running"], "timeoutSeconds": 3600, "retryPolicy": {"backoffSeconds": 30}}}
+type OpsBehaviourConfig struct {
+	// FromClusterPhases overrides the cluster phases in which the OpsRequest can be executed.
+	FromClusterPhases []appsv1alpha1.ClusterPhase `json:"fromClusterPhases,omitempty"`
+
+	// ToClusterPhase overrides the cluster phase during the operation.
+	ToClusterPhase *appsv1alpha1.ClusterPhase `json:"toClusterPhase,omitempty"`
+
+	// TimeoutSeconds specifies the maximum duration in seconds of a running OpsRequest,
+	// the OpsRequest will be marked as Failed when it exceeds. 0 means no timeout.
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// RetryPolicy specifies how the OpsRequest is requeued.
+	RetryPolicy *OpsRetryPolicy `json:"retryPolicy,omitempty"`
+
+	// ForceDisabled indicates whether the `spec.force` of the OpsRequest is ignored.
+	ForceDisabled *bool `json:"forceDisabled,omitempty"`
+}
+
+// OpsRetryPolicy defines how an OpsRequest is requeued when it waits for its pre-conditions
+// or fails with a non-fatal error.
+type OpsRetryPolicy struct {
+	// RequeueAfterSeconds is the interval to recheck the pre-conditions, such as the cluster phase.
+	// Defaults to 1 second.
+	RequeueAfterSeconds int32 `json:"requeueAfterSeconds,omitempty"`
+
+	// BackoffSeconds is the interval to retry after the action fails with a non-fatal error.
+	// 0 means the rate limiter of the controller takes effect.
+	BackoffSeconds int32 `json:"backoffSeconds,omitempty"`
+}
+
+// requeueAfter returns the interval to recheck the pre-conditions.
+func (p *OpsRetryPolicy) requeueAfter() time.Duration {
+	if p == nil || p.RequeueAfterSeconds <= 0 {
+		return defaultOpsRequeueAfter
+	}
+	return time.Duration(p.RequeueAfterSeconds) * time.Second
+}
+
+// backoff returns the interval to retry the failed action, 0 means using the controller's rate limiter.
+func (p *OpsRetryPolicy) backoff() time.Duration {
+	if p == nil || p.BackoffSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(p.BackoffSeconds) * time.Second
+}
+
+// LoadBehaviourConfigs parses the behaviour configs and applies them to the registered OpsBehaviours.
+func (opsMgr *OpsManager) LoadBehaviourConfigs(configs string) error {
+	if configs == "" {
+		return nil
+	}
+	behaviourConfigs := map[appsv1alpha1.OpsType]OpsBehaviourConfig{}
+	if err := json.Unmarshal([]byte(configs), &behaviourConfigs); err != nil {
+		return fmt.Errorf("failed to parse the ops behaviour configs: %s", err.Error())
+	}
+	for opsType, config := range behaviourConfigs {
+		opsBehaviour, ok := opsMgr.OpsMap[opsType]
+		if !ok {
+			return fmt.Errorf("the ops behaviour config of type %s is not supported", opsType)
+		}
+		if err := config.validate(); err != nil {
+			return fmt.Errorf("invalid ops behaviour config of type %s: %s", opsType, err.Error())
+		}
+		opsMgr.RegisterOps(opsType, config.applyTo(opsBehaviour))
+	}
+	return nil
+}
+
+func (c OpsBehaviourConfig) validate() error {
+	if c.TimeoutSeconds != nil && *c.TimeoutSeconds < 0 {
+		return fmt.Errorf("timeoutSeconds must not be negative")
+	}
+	if c.RetryPolicy != nil && (c.RetryPolicy.RequeueAfterSeconds < 0 || c.RetryPolicy.BackoffSeconds < 0) {
+		return fmt.Errorf("the seconds of retryPolicy must not be negative")
+	}
+	return nil
+}
+
+func (c OpsBehaviourConfig) applyTo(opsBehaviour OpsBehaviour) OpsBehaviour {
+	if c.FromClusterPhases != nil {
+		opsBehaviour.FromClusterPhases = c.FromClusterPhases
+	}
+	if c.ToClusterPhase != nil {
+		opsBehaviour.ToClusterPhase = *c.ToClusterPhase
+	}
+	if c.TimeoutSeconds != nil {
+		opsBehaviour.TimeoutSeconds = *c.TimeoutSeconds
+	}
+	if c.RetryPolicy != nil {
+		opsBehaviour.RetryPolicy = c.RetryPolicy
+	}
+	if c.ForceDisabled != nil {
+		opsBehaviour.ForceDisabled = *c.ForceDisabled
+	}
+	return opsBehaviour
+}
+
+// isOpsRequestTimedOut checks if the running OpsRequest exceeds the timeout of its behaviour.
+func isOpsRequestTimedOut(opsRequest *appsv1alpha1.OpsRequest, opsBehaviour OpsBehaviour) bool {
+	if opsBehaviour.TimeoutSeconds <= 0 || opsRequest.Status.StartTimestamp.IsZero() {
+		return false
+	}
+	deadline := opsRequest.Status.StartTimestamp.Add(time.Duration(opsBehaviour.TimeoutSeconds) * time.Second)
+	return time.Now().After(deadline)
+}
@@ -0,0 +1,76 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+func TestLoadBehaviourConfigs(t *testing.T) {
+	opsMgr := GetOpsManager()
+	origin := opsMgr.OpsMap[appsv1alpha1.RestartType]
+	defer opsMgr.RegisterOps(appsv1alpha1.RestartType, origin)
+
+	if err := opsMgr.LoadBehaviourConfigs(`{"Restart": {"fromClusterPhases": ["Running"], "timeoutSeconds": 600, "forceDisabled": true, "retryPolicy": {"backoffSeconds": 30}}}`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	behaviour := opsMgr.OpsMap[appsv1alpha1.RestartType]
+	if len(behaviour.FromClusterPhases) != 1 || behaviour.FromClusterPhases[0] != appsv1alpha1.RunningClusterPhase {
+		t.Errorf("unexpected fromClusterPhases: %v", behaviour.FromClusterPhases)
+	}
+	if behaviour.ToClusterPhase != origin.ToClusterPhase {
+		t.Errorf("toClusterPhase should not be changed, got: %s", behaviour.ToClusterPhase)
+	}
+	if behaviour.TimeoutSeconds != 600 || !behaviour.ForceDisabled || behaviour.RetryPolicy.backoff() != 30*time.Second {
+		t.Errorf("unexpected behaviour: %+v", behaviour)
+	}
+	if behaviour.RetryPolicy.requeueAfter() != defaultOpsRequeueAfter {
+		t.Errorf("unexpected requeueAfter: %s", behaviour.RetryPolicy.requeueAfter())
+	}
+	if !appsv1alpha1.OpsRequestBehaviourMapper[appsv1alpha1.RestartType].ForceDisabled {
+		t.Errorf("the behaviour of the webhook should be updated")
+	}
+
+	for _, configs := range []string{`{"Unknown": {}}`, `{"Restart": {"timeoutSeconds": -1}}`, `[]`} {
+		if err := opsMgr.LoadBehaviourConfigs(configs); err == nil {
+			t.Errorf("expect error for configs: %s", configs)
+		}
+	}
+}
+
+func TestIsOpsRequestTimedOut(t *testing.T) {
+	opsRequest := &appsv1alpha1.OpsRequest{}
+	behaviour := OpsBehaviour{TimeoutSeconds: 60}
+	if isOpsRequestTimedOut(opsRequest, behaviour) {
+		t.Errorf("the ops which is not started should not time out")
+	}
+	opsRequest.Status.StartTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	if !isOpsRequestTimedOut(opsRequest, behaviour) {
+		t.Errorf("expect the ops times out")
+	}
+	if isOpsRequestTimedOut(opsRequest, OpsBehaviour{}) {
+		t.Errorf("the ops without timeout should not time out")
+	}
+}
@@ -22,13 +22,13 @@ package operations
 import (
 	"context"
 	"fmt"
-	"reflect"
 	"slices"
 	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/conditions"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
@@ -112,6 +112,10 @@ func (c componentOpsHelper) cancelComponentOps(ctx context.Context,
 	rollBackCompSpec := func(compSpec *appsv1alpha1.ClusterComponentSpec,
 		lastCompInfos map[string]appsv1alpha1.LastComponentConfiguration,
 		componentName string) {
+		// the snapshots of the other components are recorded too, which are not touched by the opsRequest.
+		if !c.isTargetComponent(componentName) {
+			return
+		}
 		lastConfig, ok := lastCompInfos[componentName]
 		if !ok {
 			return
@@ -134,6 +138,25 @@ func (c componentOpsHelper) cancelComponentOps(ctx context.Context,
 	return cli.Update(ctx, opsRes.Cluster)
 }
 
+// isTargetComponent checks whether the component is operated, all the components are operated if no one is specified.
+func (c componentOpsHelper) isTargetComponent(componentName string) bool {
+	if len(c.componentOpsSet) == 0 {
+		return true
+	}
+	_, ok := c.componentOpsSet[componentName]
+	return ok
+}
+
+func (c componentOpsHelper) failedCount(ops *appsv1alpha1.OpsRequest, componentName string) int {
+	var count int
+	for _, v := range ops.Status.Components[componentName].ProgressDetails {
+		if v.Status == appsv1alpha1.FailedProgressStatus {
+			count += 1
+		}
+	}
+	return count
+}
+
 func (c componentOpsHelper) existFailure(ops *appsv1alpha1.OpsRequest, componentName string) bool {
 	for _, v := range ops.Status.Components[componentName].ProgressDetails {
 		if v.Status == appsv1alpha1.FailedProgressStatus {
@@ -169,8 +192,7 @@ func (c componentOpsHelper) reconcileActionWithComponentOps(reqCtx intctrlutil.R
 		}
 	}
 	// if no specified components, we should check the all components phase of cluster.
-	oldOpsRequest := opsRequest.DeepCopy()
-	patch := client.MergeFrom(oldOpsRequest)
+	opsDeepCopy := opsRequest.DeepCopy()
 	if opsRequest.Status.Components == nil {
 		opsRequest.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{}
 	}
@@ -235,6 +257,8 @@ func (c componentOpsHelper) reconcileActionWithComponentOps(reqCtx intctrlutil.R
 	}
 	opsIsCompleted := true
 	existFailure := false
+	failedProgressCount := 0
+	countedComponents := map[string]struct{}{}
 	for i := range progressResources {
 		pgResource := progressResources[i]
 		opsCompStatus := opsRequest.Status.Components[pgResource.compOps.GetComponentName()]
@@ -247,6 +271,10 @@ func (c componentOpsHelper) reconcileActionWithComponentOps(reqCtx intctrlutil.R
 		if c.existFailure(opsRes.OpsRequest, pgResource.compOps.GetComponentName()) {
 			existFailure = true
 		}
+		if _, ok := countedComponents[pgResource.compOps.GetComponentName()]; !ok {
+			countedComponents[pgResource.compOps.GetComponentName()] = struct{}{}
+			failedProgressCount += c.failedCount(opsRes.OpsRequest, pgResource.compOps.GetComponentName())
+		}
 		componentPhase := opsRes.Cluster.Status.Components[pgResource.compOps.GetComponentName()].Phase
 		if !pgResource.isShardingComponent {
 			if opsCompStatus.Phase != componentPhase {
@@ -276,20 +304,24 @@ func (c componentOpsHelper) reconcileActionWithComponentOps(reqCtx intctrlutil.R
 	}
 	// TODO: wait for sharding cluster to completed for next opsRequest.
 	opsRequest.Status.Progress = fmt.Sprintf("%d/%d", completedProgressCount, expectProgressCount)
-	if !reflect.DeepEqual(opsRequest.Status, oldOpsRequest.Status) {
-		if err = cli.Status().Patch(reqCtx.Ctx, opsRequest, patch); err != nil {
-			return opsRequestPhase, 0, err
-		}
+	if err = PatchOpsRequestStatus(reqCtx.Ctx, cli, opsDeepCopy, opsRequest); err != nil {
+		return opsRequestPhase, 0, err
 	}
 	if !opsIsCompleted {
 		return opsRequestPhase, 0, nil
 	}
-	if existFailure {
-		if requeueTimeAfterFailed != 0 {
-			// component failure may be temporary, waiting for component failure timeout.
-			return opsRequestPhase, requeueTimeAfterFailed, nil
-		}
-		return appsv1alpha1.OpsFailedPhase, 0, nil
+	failureTolerated := opsRequest.Spec.FailurePolicy.IsFailureTolerated(failedProgressCount, int(expectProgressCount))
+	opsRequestPhase, _ = conditions.ComputePhase(
+		conditions.Rule(appsv1alpha1.OpsSucceedPhase, !existFailure),
+		// continue on the remaining instances if the failures are tolerated.
+		conditions.Rule(appsv1alpha1.OpsRunningPhase, failureTolerated && completedProgressCount < expectProgressCount),
+		conditions.Rule(appsv1alpha1.OpsSucceedWithWarningsPhase, failureTolerated),
+		// component failure may be temporary, waiting for component failure timeout.
+		conditions.Rule(appsv1alpha1.OpsRunningPhase, requeueTimeAfterFailed != 0),
+		conditions.Rule(appsv1alpha1.OpsFailedPhase, true),
+	)
+	if opsRequestPhase == appsv1alpha1.OpsRunningPhase {
+		return opsRequestPhase, requeueTimeAfterFailed, nil
 	}
-	return appsv1alpha1.OpsSucceedPhase, 0, nil
+	return opsRequestPhase, 0, nil
 }
@@ -20,12 +20,15 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"fmt"
 	"slices"
 	"strings"
 	"sync"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -33,6 +36,7 @@ import (
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/kberrors"
 )
 
 var (
@@ -46,6 +50,7 @@ func (opsMgr *OpsManager) RegisterOps(opsType appsv1alpha1.OpsType, opsBehaviour
 	appsv1alpha1.OpsRequestBehaviourMapper[opsType] = appsv1alpha1.OpsRequestBehaviour{
 		FromClusterPhases: opsBehaviour.FromClusterPhases,
 		ToClusterPhase:    opsBehaviour.ToClusterPhase,
+		ForceDisabled:     opsBehaviour.ForceDisabled,
 	}
 }
 
@@ -61,28 +66,58 @@ func (opsMgr *OpsManager) Do(reqCtx intctrlutil.RequestCtx, cli client.Client, o
 		return &ctrl.Result{}, PatchOpsHandlerNotSupported(reqCtx.Ctx, cli, opsRes)
 	}
 
+	// the retrying OpsRequest waits for the backoff of spec.retryPolicy before re-running the action
+	if backoff := retryBackoffRemaining(opsRequest); backoff > 0 {
+		return intctrlutil.ResultToP(intctrlutil.RequeueAfter(backoff, reqCtx.Log, "wait for the backoff to retry the OpsRequest"))
+	}
+
 	if opsRequest.Spec.Type == appsv1alpha1.CustomType {
 		err = initOpsDefAndValidate(reqCtx, cli, opsRes)
 		if err != nil {
 			return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, err.Error())
 		}
-	} else {
-		// validate OpsRequest.spec
-		// if the operation will create a new cluster, don't validate the cluster
-		if err = opsRequest.Validate(reqCtx.Ctx, cli, opsRes.Cluster, !opsBehaviour.IsClusterCreation); err != nil {
+	} else if !isRetryingOpsRequest(opsRequest) {
+		// validate OpsRequest.spec, the retrying OpsRequest has been validated before the action was run
+		// if the operation will create a new cluster, or waits for its maintenance window, don't validate the cluster
+		needCheckClusterPhase := !opsBehaviour.CreatesCluster(opsRequest) && !opsRequest.WaitsForMaintenanceWindow()
+		if err = opsRequest.Validate(reqCtx.Ctx, cli, opsRes.Cluster, needCheckClusterPhase); err != nil {
 			return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, err.Error())
 		}
 	}
 
-	if opsRequest.Status.Phase == appsv1alpha1.OpsPendingPhase {
+	if opsRequest.Status.Phase == appsv1alpha1.OpsPendingPhase || opsRequest.Status.Phase == appsv1alpha1.OpsScheduledPhase {
 		if opsRequest.Spec.Cancel {
 			return &ctrl.Result{}, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsCancelledPhase)
 		}
+		// the dry-run OpsRequest is neither queued nor scheduled, as nothing is mutated
+		if opsRequest.Spec.DryRun {
+			return &ctrl.Result{}, dryRunOpsRequest(reqCtx, cli, opsRes, opsBehaviour)
+		}
+		// validate if the dependent ops have been successful
+		if pass, err := opsMgr.validateDependOnSuccessfulOps(reqCtx, cli, opsRes); intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+			return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, err.Error())
+		} else if err != nil {
+			return nil, err
+		} else if !pass {
+			return intctrlutil.ResultToP(intctrlutil.Reconciled())
+		}
+		// wait for the maintenance window of the OpsRequest to open
+		if res, err := waitForMaintenanceWindow(reqCtx, cli, opsRes); res != nil || err != nil {
+			return res, err
+		}
+		// defer the OpsRequest if the cluster is in a freeze window
+		if res, err := deferByFreezeWindow(reqCtx, cli, opsRes); res != nil || err != nil {
+			return res, err
+		}
+		// defer the OpsRequest if the cluster is out of its maintenance windows
+		if res, err := deferByMaintenanceWindow(reqCtx, cli, opsRes); res != nil || err != nil {
+			return res, err
+		}
 		// validate entry condition for OpsRequest, check if the cluster is in the right phase
 		if err = validateOpsWaitingPhase(opsRes.Cluster, opsRequest, opsBehaviour); err != nil {
 			// check if the error is caused by WaitForClusterPhaseErr  error
 			if _, ok := err.(*WaitForClusterPhaseErr); ok {
-				return intctrlutil.ResultToP(intctrlutil.RequeueAfter(time.Second, reqCtx.Log, ""))
+				return intctrlutil.ResultToP(intctrlutil.RequeueAfter(opsBehaviour.RetryPolicy.requeueAfter(), reqCtx.Log, ""))
 			}
 			return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, err.Error())
 		}
@@ -96,24 +131,30 @@ func (opsMgr *OpsManager) Do(reqCtx intctrlutil.RequestCtx, cli client.Client, o
 				return nil, err
 			}
 			if opsRecorde != nil && opsRecorde.InQueue {
-				// if the opsRequest is in the queue, return
+				// if the opsRequest is in the queue, update its queue position and return
+				if err = patchOpsQueuePosition(reqCtx.Ctx, cli, opsRes, opsBehaviour); err != nil {
+					return nil, err
+				}
 				return intctrlutil.ResultToP(intctrlutil.Reconciled())
 			}
 		}
-
-		// validate if the dependent ops have been successful
-		if pass, err := opsMgr.validateDependOnSuccessfulOps(reqCtx, cli, opsRes); intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
-			return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, err.Error())
+		// limit the disruptive OpsRequests running simultaneously on the same nodes across the clusters
+		if res, err := opsMgr.waitForNodeConcurrency(reqCtx, cli, opsRes); res != nil || err != nil {
+			return res, err
+		}
+		// verify the assertions on the current state of the cluster right before it is changed
+		if err = verifyOpsAssertions(reqCtx, cli, opsRes); kberrors.IsReason(err, kberrors.ReasonPreconditionMismatch) {
+			return &ctrl.Result{}, patchFatalFailErrorCondition(reqCtx.Ctx, cli, opsRes, err)
 		} else if err != nil {
 			return nil, err
-		} else if !pass {
-			return intctrlutil.ResultToP(intctrlutil.Reconciled())
 		}
+
 		opsDeepCopy := opsRequest.DeepCopy()
 		// save last configuration into status.lastConfiguration
 		if err = opsBehaviour.OpsHandler.SaveLastConfiguration(reqCtx, cli, opsRes); err != nil {
 			return nil, err
 		}
+		saveComponentSnapshots(opsRes)
 
 		return &ctrl.Result{}, patchOpsRequestToCreating(reqCtx, cli, opsRes, opsDeepCopy, opsBehaviour.OpsHandler)
 	}
@@ -129,6 +170,9 @@ func (opsMgr *OpsManager) Do(reqCtx intctrlutil.RequestCtx, cli client.Client, o
 		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeNeedWaiting) {
 			return intctrlutil.ResultToP(intctrlutil.Reconciled())
 		}
+		if backoff := opsBehaviour.RetryPolicy.backoff(); backoff > 0 {
+			return intctrlutil.ResultToP(intctrlutil.RequeueAfter(backoff, reqCtx.Log, err.Error()))
+		}
 		return nil, err
 	}
 	return nil, nil
@@ -156,18 +200,41 @@ func (opsMgr *OpsManager) Reconcile(reqCtx intctrlutil.RequestCtx, cli client.Cl
 			return requeueAfter, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, err.Error())
 		}
 	}
+	if isOpsRequestTimedOut(opsRequest, opsBehaviour) && opsRequest.Status.Phase != appsv1alpha1.OpsCancellingPhase {
+		err = fmt.Errorf("OpsRequest: %s timed out after %d seconds", opsRequest.Name, opsBehaviour.TimeoutSeconds)
+		if terminateErr := opsMgr.terminateOps(reqCtx, cli, opsRes, opsBehaviour); terminateErr != nil {
+			return 0, terminateErr
+		}
+		return 0, opsMgr.handleOpsFailed(reqCtx, cli, opsRes, opsBehaviour,
+			appsv1alpha1.NewCancelFailedCondition(opsRequest, err), appsv1alpha1.NewFailedCondition(opsRequest, err))
+	}
 	if opsRequestPhase, requeueAfter, err = opsBehaviour.OpsHandler.ReconcileAction(reqCtx, cli, opsRes); err != nil &&
 		!isOpsRequestFailedPhase(opsRequestPhase) {
 		// if the opsRequest phase is not failed, skipped
+		if backoff := opsBehaviour.RetryPolicy.backoff(); backoff > 0 {
+			reqCtx.Log.Info(fmt.Sprintf("reconcile OpsRequest failed, retry after %s: %s", backoff, err.Error()))
+			return backoff, nil
+		}
 		return requeueAfter, err
 	}
+	if syncErr := syncOpsProgressSummary(reqCtx, cli, opsRequest); syncErr != nil {
+		return requeueAfter, syncErr
+	}
 	switch opsRequestPhase {
 	case appsv1alpha1.OpsSucceedPhase:
 		return 0, opsMgr.handleOpsCompleted(reqCtx, cli, opsRes, opsRequestPhase,
 			appsv1alpha1.NewCancelSucceedCondition(opsRequest.Name), appsv1alpha1.NewSucceedCondition(opsRequest))
-	case appsv1alpha1.OpsFailedPhase:
+	case appsv1alpha1.OpsSucceedWithWarningsPhase:
 		return 0, opsMgr.handleOpsCompleted(reqCtx, cli, opsRes, opsRequestPhase,
-			appsv1alpha1.NewCancelFailedCondition(opsRequest, err), appsv1alpha1.NewFailedCondition(opsRequest, err))
+			appsv1alpha1.NewCancelSucceedCondition(opsRequest.Name), appsv1alpha1.NewSucceedWithWarningsCondition(opsRequest))
+	case appsv1alpha1.OpsFailedPhase:
+		failedCondition := appsv1alpha1.NewFailedCondition(opsRequest, err)
+		if opsRequest.Status.Phase == appsv1alpha1.OpsRunningPhase && canRetryOpsRequest(opsRequest) {
+			// re-run the failed steps according to spec.retryPolicy
+			return retryFailedOpsRequest(reqCtx, cli, opsRes, failedCondition)
+		}
+		return 0, opsMgr.handleOpsFailed(reqCtx, cli, opsRes, opsBehaviour,
+			appsv1alpha1.NewCancelFailedCondition(opsRequest, err), failedCondition)
 	default:
 		return requeueAfter, nil
 	}
@@ -177,27 +244,130 @@ func (opsMgr *OpsManager) handleOpsCompleted(reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
 	opsRes *OpsResource,
 	opsRequestPhase appsv1alpha1.OpsPhase,
-	cancelledCondition,
-	completedCondition *metav1.Condition) error {
+	cancelledCondition *metav1.Condition,
+	completedConditions ...*metav1.Condition) error {
 	if err := updateHAConfigIfNecessary(reqCtx, cli, opsRes.OpsRequest, "true"); err != nil {
 		return err
 	}
 	if opsRes.OpsRequest.Status.Phase == appsv1alpha1.OpsCancellingPhase {
 		return PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsCancelledPhase, cancelledCondition)
 	}
-	return PatchOpsStatus(reqCtx.Ctx, cli, opsRes, opsRequestPhase, completedCondition)
+	return PatchOpsStatus(reqCtx.Ctx, cli, opsRes, opsRequestPhase, completedConditions...)
+}
+
+// terminateOps terminates the in-flight tasks of the timed out OpsRequest, and records them in the status.
+func (opsMgr *OpsManager) terminateOps(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	opsBehaviour OpsBehaviour) error {
+	if opsBehaviour.TerminateFunc == nil {
+		return nil
+	}
+	opsDeepCopy := opsRes.OpsRequest.DeepCopy()
+	if err := opsBehaviour.TerminateFunc(reqCtx, cli, opsRes); err != nil {
+		return err
+	}
+	return PatchOpsRequestStatus(reqCtx.Ctx, cli, opsDeepCopy, opsRes.OpsRequest)
+}
+
+// handleOpsFailed marks the OpsRequest as failed, and rolls back the changes made to the Cluster
+// if spec.rollbackOnFailure is set and the operation supports it.
+func (opsMgr *OpsManager) handleOpsFailed(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	opsBehaviour OpsBehaviour,
+	cancelledCondition,
+	failedCondition *metav1.Condition) error {
+	opsRequest := opsRes.OpsRequest
+	if !opsRequest.Spec.RollbackOnFailure || opsBehaviour.RollbackFunc == nil ||
+		opsRequest.Status.Phase == appsv1alpha1.OpsCancellingPhase {
+		return opsMgr.handleOpsCompleted(reqCtx, cli, opsRes, appsv1alpha1.OpsFailedPhase, cancelledCondition, failedCondition)
+	}
+	// the rollback is retried in the next reconciliation if it fails, as the OpsRequest is still running.
+	if err := opsBehaviour.RollbackFunc(reqCtx, cli, opsRes); err != nil {
+		return err
+	}
+	rolledBackCondition := appsv1alpha1.NewRolledBackCondition(opsRequest)
+	opsRes.Recorder.Event(opsRequest, corev1.EventTypeWarning, appsv1alpha1.ReasonRolledBackOnFailure, rolledBackCondition.Message)
+	return opsMgr.handleOpsCompleted(reqCtx, cli, opsRes, appsv1alpha1.OpsFailedPhase, cancelledCondition, failedCondition, rolledBackCondition)
+}
+
+// waitForMaintenanceWindow holds the OpsRequest in the Scheduled phase until its maintenance window opens.
+// Once the window is open, the OpsRequest is released to the Pending phase and no longer bound to the window.
+func waitForMaintenanceWindow(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*ctrl.Result, error) {
+	opsRequest := opsRes.OpsRequest
+	if !opsRequest.WaitsForMaintenanceWindow() {
+		return nil, nil
+	}
+	now := time.Now()
+	start, end, err := opsRequest.Spec.Schedule.Window(now)
+	if err != nil {
+		return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, fmt.Sprintf("invalid spec.schedule: %v", err))
+	}
+	if !now.Before(end) {
+		return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes,
+			fmt.Sprintf("the maintenance window has ended at %s before the OpsRequest started", end.Format(time.RFC3339)))
+	}
+	if !now.Before(start) {
+		return &ctrl.Result{}, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsPendingPhase,
+			appsv1alpha1.NewMaintenanceWindowOpenedCondition(opsRequest, end))
+	}
+	condition := appsv1alpha1.NewWaitForMaintenanceWindowCondition(opsRequest, start, end)
+	lastCondition := meta.FindStatusCondition(opsRequest.Status.Conditions, condition.Type)
+	if opsRequest.Status.Phase != appsv1alpha1.OpsScheduledPhase || lastCondition == nil || lastCondition.Message != condition.Message {
+		if err = PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsScheduledPhase, condition); err != nil {
+			return nil, err
+		}
+	}
+	return intctrlutil.ResultToP(intctrlutil.RequeueAfter(start.Sub(now), reqCtx.Log, condition.Message))
+}
+
+// deferByFreezeWindow defers the OpsRequest without force until the freeze window of the cluster ends.
+func deferByFreezeWindow(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*ctrl.Result, error) {
+	if opsRes.Cluster == nil || opsRes.OpsRequest.Spec.Force {
+		return nil, nil
+	}
+	now := time.Now()
+	window, end := opsRes.Cluster.Spec.GetActiveFreezeWindow(now)
+	if window == nil {
+		return nil, nil
+	}
+	condition := appsv1alpha1.NewClusterFrozenCondition(opsRes.OpsRequest, window.Name, end)
+	lastCondition := meta.FindStatusCondition(opsRes.OpsRequest.Status.Conditions, condition.Type)
+	if lastCondition == nil || lastCondition.Reason != condition.Reason || lastCondition.Message != condition.Message {
+		if err := PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsPendingPhase, condition); err != nil {
+			return nil, err
+		}
+	}
+	return intctrlutil.ResultToP(intctrlutil.RequeueAfter(end.Sub(now), reqCtx.Log, condition.Message))
+}
+
+// deferByMaintenanceWindow defers the OpsRequest without force until the next maintenance window of the cluster opens.
+// The OpsRequest with its own schedule has waited for its maintenance window, which takes precedence.
+func deferByMaintenanceWindow(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*ctrl.Result, error) {
+	if opsRes.Cluster == nil || opsRes.OpsRequest.Spec.Force || opsRes.OpsRequest.Spec.Schedule != nil {
+		return nil, nil
+	}
+	now := time.Now()
+	start, end, found := opsRes.Cluster.Spec.GetMaintenanceWindow(now)
+	if !found || !now.Before(start) {
+		return nil, nil
+	}
+	condition := appsv1alpha1.NewOutOfMaintenanceWindowCondition(opsRes.OpsRequest, start, end)
+	lastCondition := meta.FindStatusCondition(opsRes.OpsRequest.Status.Conditions, condition.Type)
+	if lastCondition == nil || lastCondition.Reason != condition.Reason || lastCondition.Message != condition.Message {
+		if err := PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsPendingPhase, condition); err != nil {
+			return nil, err
+		}
+	}
+	return intctrlutil.ResultToP(intctrlutil.RequeueAfter(start.Sub(now), reqCtx.Log, condition.Message))
 }
 
 // validateDependOnOps validates if the dependent ops have been successful
 func (opsMgr *OpsManager) validateDependOnSuccessfulOps(reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
 	opsRes *OpsResource) (bool, error) {
-	dependentOpsStr := opsRes.OpsRequest.Annotations[constant.OpsDependentOnSuccessfulOpsAnnoKey]
-	if dependentOpsStr == "" {
-		return true, nil
-	}
-	opsNames := strings.Split(dependentOpsStr, ",")
-	for _, opsName := range opsNames {
+	for _, opsName := range opsRes.OpsRequest.GetDependentOps() {
 		ops := &appsv1alpha1.OpsRequest{}
 		if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: opsName, Namespace: opsRes.OpsRequest.Namespace}, ops); err != nil {
 			if apierrors.IsNotFound(err) {
@@ -222,9 +392,14 @@ func (opsMgr *OpsManager) validateDependOnSuccessfulOps(reqCtx intctrlutil.Reque
 			}
 		}
 		if slices.Contains([]appsv1alpha1.OpsPhase{appsv1alpha1.OpsFailedPhase, appsv1alpha1.OpsCancelledPhase, appsv1alpha1.OpsAbortedPhase}, ops.Status.Phase) {
+			// the OpsRequest fails with the dependencies declared in spec.dependsOn,
+			// and is cancelled with the ones declared in the annotation.
+			if slices.Contains(opsRes.OpsRequest.Spec.DependsOn, opsName) {
+				return false, intctrlutil.NewFatalError(fmt.Sprintf(`the OpsRequest "%s" it depends on is %s`, opsName, ops.Status.Phase))
+			}
 			return false, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsCancelledPhase)
 		}
-		if ops.Status.Phase != appsv1alpha1.OpsSucceedPhase {
+		if ops.Status.Phase != appsv1alpha1.OpsSucceedPhase && ops.Status.Phase != appsv1alpha1.OpsSucceedWithWarningsPhase {
 			return false, nil
 		}
 	}
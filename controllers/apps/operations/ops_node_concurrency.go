@@ -0,0 +1,321 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	// nodeConcurrencyRequeueAfter is the interval to recheck the OpsRequest limited by the node concurrency policy.
+	nodeConcurrencyRequeueAfter = 10 * time.Second
+	// nodeReservationTTL is how long an admitted OpsRequest is counted by its in-memory reservation,
+	// after which the annotation of the OpsRequest is expected to be observed in the cache.
+	nodeReservationTTL = time.Minute
+)
+
+// OpsNodeConcurrencyPolicy limits the disruptive OpsRequests running simultaneously on the same node or node pool
+// across the Clusters, to avoid correlated disruptions on the dense nodes.
+// The policy is loaded from the manager config with the key "OPS_NODE_CONCURRENCY" in json format, for example:
+//
+//	{"maxPerNode": 1, "nodePoolLabel": "node.kubernetes.io/pool", "maxPerNodePool": 3}
+type OpsNodeConcurrencyPolicy struct {
+	// OpsTypes specifies the types of the OpsRequests limited by the policy.
+	// Defaults to the OpsRequests whose impact is "BriefInterruptions" or "Downtime".
+	OpsTypes []appsv1alpha1.OpsType `json:"opsTypes,omitempty"`
+
+	// MaxPerNode is the maximum number of the OpsRequests disrupting the instances on a node simultaneously.
+	// 0 means no limit.
+	MaxPerNode int32 `json:"maxPerNode,omitempty"`
+
+	// NodePoolLabel is the label of the nodes whose value identifies the node pool.
+	NodePoolLabel string `json:"nodePoolLabel,omitempty"`
+
+	// MaxPerNodePool is the maximum number of the OpsRequests disrupting the instances in a node pool simultaneously.
+	// 0 means no limit.
+	MaxPerNodePool int32 `json:"maxPerNodePool,omitempty"`
+}
+
+// disruptedNodes records the nodes and node pools disrupted by an OpsRequest.
+type disruptedNodes struct {
+	Nodes     []string `json:"nodes,omitempty"`
+	NodePools []string `json:"nodePools,omitempty"`
+}
+
+type nodeReservation struct {
+	disruptedNodes
+	reservedAt time.Time
+}
+
+// nodeConcurrencyScheduler admits the disruptive OpsRequests according to the OpsNodeConcurrencyPolicy.
+type nodeConcurrencyScheduler struct {
+	policy       OpsNodeConcurrencyPolicy
+	mu           sync.Mutex
+	reservations map[types.NamespacedName]nodeReservation
+}
+
+// LoadNodeConcurrencyPolicy parses the node concurrency policy, the disruptive OpsRequests are not limited if it's empty.
+func (opsMgr *OpsManager) LoadNodeConcurrencyPolicy(config string) error {
+	opsMgr.nodeScheduler = nil
+	if config == "" {
+		return nil
+	}
+	policy := OpsNodeConcurrencyPolicy{}
+	if err := json.Unmarshal([]byte(config), &policy); err != nil {
+		return fmt.Errorf("failed to parse the ops node concurrency policy: %s", err.Error())
+	}
+	if policy.MaxPerNode < 0 || policy.MaxPerNodePool < 0 {
+		return fmt.Errorf("the limits of the ops node concurrency policy must not be negative")
+	}
+	if policy.MaxPerNodePool > 0 && policy.NodePoolLabel == "" {
+		return fmt.Errorf("nodePoolLabel is required to limit the OpsRequests per node pool")
+	}
+	opsMgr.nodeScheduler = &nodeConcurrencyScheduler{
+		policy:       policy,
+		reservations: map[types.NamespacedName]nodeReservation{},
+	}
+	return nil
+}
+
+// waitForNodeConcurrency holds the disruptive OpsRequest in the Pending phase while the nodes or node pools
+// hosting the instances it disrupts have reached the limits of the node concurrency policy.
+func (opsMgr *OpsManager) waitForNodeConcurrency(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*ctrl.Result, error) {
+	scheduler := opsMgr.nodeScheduler
+	opsRequest := opsRes.OpsRequest
+	if scheduler == nil || opsRes.Cluster == nil || opsRequest.Spec.Force || !scheduler.limits(opsRequest) {
+		return nil, nil
+	}
+	disrupted, err := scheduler.disruptedNodes(reqCtx.Ctx, cli, opsRequest, opsRes.Cluster)
+	if err != nil {
+		return nil, err
+	}
+	message, err := scheduler.admit(reqCtx.Ctx, cli, opsRequest, disrupted)
+	if err != nil {
+		return nil, err
+	}
+	if message != "" {
+		condition := appsv1alpha1.NewNodeConcurrencyLimitedCondition(opsRequest, message)
+		lastCondition := meta.FindStatusCondition(opsRequest.Status.Conditions, condition.Type)
+		if lastCondition == nil || lastCondition.Reason != condition.Reason || lastCondition.Message != condition.Message {
+			if err = PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsPendingPhase, condition); err != nil {
+				return nil, err
+			}
+		}
+		return intctrlutil.ResultToP(intctrlutil.RequeueAfter(nodeConcurrencyRequeueAfter, reqCtx.Log, message))
+	}
+	// record the disrupted nodes, which are counted by the OpsRequests admitted later
+	value, _ := json.Marshal(disrupted)
+	patch := client.MergeFrom(opsRequest.DeepCopy())
+	if opsRequest.Annotations == nil {
+		opsRequest.Annotations = map[string]string{}
+	}
+	opsRequest.Annotations[constant.OpsDisruptedNodesAnnotationKey] = string(value)
+	if err = cli.Patch(reqCtx.Ctx, opsRequest, patch); err != nil {
+		scheduler.release(client.ObjectKeyFromObject(opsRequest))
+		return nil, err
+	}
+	return nil, nil
+}
+
+// limits checks if the OpsRequest is limited by the policy.
+func (s *nodeConcurrencyScheduler) limits(opsRequest *appsv1alpha1.OpsRequest) bool {
+	if len(s.policy.OpsTypes) > 0 {
+		return slices.Contains(s.policy.OpsTypes, opsRequest.Spec.Type)
+	}
+	return opsRequest.Status.Impact == appsv1alpha1.OpsImpactBriefInterruptions || opsRequest.Status.Impact == appsv1alpha1.OpsImpactDowntime
+}
+
+// admit checks the limits against the running OpsRequests and reserves the nodes for the OpsRequest if they are not reached,
+// otherwise it returns the message why the OpsRequest is limited.
+func (s *nodeConcurrencyScheduler) admit(ctx context.Context, cli client.Reader, opsRequest *appsv1alpha1.OpsRequest, disrupted disruptedNodes) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	running, err := s.runningOps(ctx, cli)
+	if err != nil {
+		return "", err
+	}
+	key := client.ObjectKeyFromObject(opsRequest)
+	delete(running, key)
+	nodeCount := map[string]int32{}
+	poolCount := map[string]int32{}
+	for _, v := range running {
+		for _, node := range v.Nodes {
+			nodeCount[node]++
+		}
+		for _, pool := range v.NodePools {
+			poolCount[pool]++
+		}
+	}
+	if s.policy.MaxPerNode > 0 {
+		for _, node := range disrupted.Nodes {
+			if nodeCount[node] >= s.policy.MaxPerNode {
+				return fmt.Sprintf(`%d disruptive OpsRequests are running on node "%s", which reaches the limit %d`,
+					nodeCount[node], node, s.policy.MaxPerNode), nil
+			}
+		}
+	}
+	if s.policy.MaxPerNodePool > 0 {
+		for _, pool := range disrupted.NodePools {
+			if poolCount[pool] >= s.policy.MaxPerNodePool {
+				return fmt.Sprintf(`%d disruptive OpsRequests are running in node pool "%s", which reaches the limit %d`,
+					poolCount[pool], pool, s.policy.MaxPerNodePool), nil
+			}
+		}
+	}
+	s.reservations[key] = nodeReservation{disruptedNodes: disrupted, reservedAt: time.Now()}
+	return "", nil
+}
+
+func (s *nodeConcurrencyScheduler) release(key types.NamespacedName) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.reservations, key)
+}
+
+// runningOps returns the nodes disrupted by the running OpsRequests, including the ones reserved recently
+// whose annotations may not be observed in the cache yet.
+func (s *nodeConcurrencyScheduler) runningOps(ctx context.Context, cli client.Reader) (map[types.NamespacedName]disruptedNodes, error) {
+	opsList := &appsv1alpha1.OpsRequestList{}
+	if err := cli.List(ctx, opsList); err != nil {
+		return nil, err
+	}
+	running := map[types.NamespacedName]disruptedNodes{}
+	for i := range opsList.Items {
+		ops := &opsList.Items[i]
+		key := client.ObjectKeyFromObject(ops)
+		if !slices.Contains([]appsv1alpha1.OpsPhase{appsv1alpha1.OpsCreatingPhase, appsv1alpha1.OpsRunningPhase,
+			appsv1alpha1.OpsCancellingPhase}, ops.Status.Phase) {
+			// the completed OpsRequest releases its reservation
+			if ops.IsComplete() {
+				delete(s.reservations, key)
+			}
+			continue
+		}
+		value, ok := ops.Annotations[constant.OpsDisruptedNodesAnnotationKey]
+		if !ok {
+			continue
+		}
+		disrupted := disruptedNodes{}
+		if err := json.Unmarshal([]byte(value), &disrupted); err == nil {
+			running[key] = disrupted
+			delete(s.reservations, key)
+		}
+	}
+	for key, reservation := range s.reservations {
+		if time.Since(reservation.reservedAt) > nodeReservationTTL {
+			delete(s.reservations, key)
+			continue
+		}
+		running[key] = reservation.disruptedNodes
+	}
+	return running, nil
+}
+
+// disruptedNodes returns the nodes and node pools hosting the instances disrupted by the OpsRequest.
+func (s *nodeConcurrencyScheduler) disruptedNodes(ctx context.Context, cli client.Reader,
+	opsRequest *appsv1alpha1.OpsRequest, cluster *appsv1alpha1.Cluster) (disruptedNodes, error) {
+	pods := &corev1.PodList{}
+	if err := cli.List(ctx, pods, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels(constant.GetClusterWellKnownLabels(cluster.Name))); err != nil {
+		return disruptedNodes{}, err
+	}
+	nodes := sets.New[string]()
+	for i := range pods.Items {
+		if pods.Items[i].Spec.NodeName != "" && isPodDisruptedByOps(opsRequest, &pods.Items[i]) {
+			nodes.Insert(pods.Items[i].Spec.NodeName)
+		}
+	}
+	disrupted := disruptedNodes{Nodes: sets.List(nodes)}
+	if s.policy.NodePoolLabel == "" {
+		return disrupted, nil
+	}
+	pools := sets.New[string]()
+	for _, nodeName := range disrupted.Nodes {
+		node := &corev1.Node{}
+		if err := cli.Get(ctx, types.NamespacedName{Name: nodeName}, node); err != nil {
+			return disruptedNodes{}, client.IgnoreNotFound(err)
+		}
+		if pool := node.Labels[s.policy.NodePoolLabel]; pool != "" {
+			pools.Insert(pool)
+		}
+	}
+	disrupted.NodePools = sets.List(pools)
+	return disrupted, nil
+}
+
+// isPodDisruptedByOps checks if the pod is disrupted by the OpsRequest.
+// For a switchover, only the primary and the candidate instances are disrupted.
+func isPodDisruptedByOps(opsRequest *appsv1alpha1.OpsRequest, pod *corev1.Pod) bool {
+	compName := pod.Labels[constant.KBAppComponentLabelKey]
+	shardingName := pod.Labels[constant.KBAppShardingNameLabelKey]
+	matches := func(componentName string) bool {
+		return componentName == compName || (shardingName != "" && componentName == shardingName)
+	}
+	matchesAny := func(compOpsList []appsv1alpha1.ComponentOps) bool {
+		return slices.ContainsFunc(compOpsList, func(compOps appsv1alpha1.ComponentOps) bool {
+			return matches(compOps.ComponentName)
+		})
+	}
+	spec := opsRequest.Spec
+	switch spec.Type {
+	case appsv1alpha1.SwitchoverType:
+		return slices.ContainsFunc(spec.SwitchoverList, func(switchover appsv1alpha1.Switchover) bool {
+			return matches(switchover.ComponentName) && (pod.Name == switchover.InstanceName ||
+				pod.Labels[constant.AccessModeLabelKey] == string(appsv1alpha1.ReadWrite))
+		})
+	case appsv1alpha1.RestartType:
+		return matchesAny(spec.RestartList)
+	case appsv1alpha1.StopType:
+		return len(spec.StopList) == 0 || matchesAny(spec.StopList)
+	case appsv1alpha1.VerticalScalingType:
+		return slices.ContainsFunc(spec.VerticalScalingList, func(v appsv1alpha1.VerticalScaling) bool {
+			return matches(v.ComponentName)
+		})
+	case appsv1alpha1.RebuildInstanceType:
+		return slices.ContainsFunc(spec.RebuildFrom, func(v appsv1alpha1.RebuildInstance) bool {
+			return matches(v.ComponentName)
+		})
+	case appsv1alpha1.RotateTLSType:
+		return matchesAny(spec.RotateTLSList)
+	case appsv1alpha1.MigrateNodePoolType:
+		return spec.MigrateNodePool != nil && matches(spec.MigrateNodePool.ComponentName)
+	case appsv1alpha1.MigrateInstanceType:
+		return spec.MigrateInstance != nil && pod.Name == spec.MigrateInstance.InstanceName
+	default:
+		// all the instances of the cluster are counted if the disrupted ones can't be told
+		return true
+	}
+}
@@ -21,7 +21,7 @@ package operations
 
 import (
 	"fmt"
-	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -39,6 +39,7 @@ import (
 	intctrlcomp "github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/quorum"
 )
 
 // getProgressObjectKey gets progress object key from the client.Object.
@@ -198,6 +199,9 @@ func handleComponentStatusProgress(
 		completedCount = handleCancelProgressForPodsRollingUpdate(opsRes, pods, pgRes, compStatus, minReadySeconds)
 	} else {
 		completedCount = handleProgressForPodsRollingUpdate(opsRes, pods, pgRes, compStatus, minReadySeconds, podApplyOps)
+		if err = continueOnTolerableFailure(reqCtx, cli, opsRes, pgRes.fullComponentName, pods, compStatus); err != nil {
+			return expectReplicas, completedCount, err
+		}
 	}
 	if opsRes.OpsRequest.Status.Phase == appsv1alpha1.OpsCancellingPhase {
 		// only rollback the actual re-created pod during cancelling.
@@ -234,6 +238,82 @@ func handleProgressForPodsRollingUpdate(
 	return completedCount
 }
 
+// continueOnTolerableFailure deletes a pending pod to continue the rolling update on the remaining pods
+// if the failed pods are tolerated by the failure policy of the OpsRequest,
+// because the workload will not update the next pod until the failed one is available.
+// The leader is left to the workload, and no pod is deleted if it would break the quorum of the component.
+func continueOnTolerableFailure(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	compName string,
+	pods []*corev1.Pod,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus) error {
+	failurePolicy := opsRes.OpsRequest.Spec.FailurePolicy
+	if failurePolicy == "" || failurePolicy == appsv1alpha1.OpsFailurePolicyFail {
+		return nil
+	}
+	var (
+		failedCount int
+		pendingPods []*corev1.Pod
+	)
+	for _, pod := range pods {
+		objectKey := getProgressObjectKey(constant.PodKind, pod.Name)
+		progressDetail := findStatusProgressDetail(compStatus.ProgressDetails, objectKey)
+		if progressDetail == nil {
+			continue
+		}
+		switch progressDetail.Status {
+		case appsv1alpha1.ProcessingProgressStatus:
+			// wait for the processing pod.
+			return nil
+		case appsv1alpha1.FailedProgressStatus:
+			failedCount += 1
+		case appsv1alpha1.PendingProgressStatus:
+			pendingPods = append(pendingPods, pod)
+		}
+	}
+	if failedCount == 0 || len(pendingPods) == 0 || !failurePolicy.IsFailureTolerated(failedCount, len(pods)) {
+		return nil
+	}
+	its := &workloads.InstanceSet{}
+	itsKey := client.ObjectKey{Namespace: opsRes.Cluster.Namespace, Name: constant.GenerateWorkloadNamePattern(opsRes.Cluster.Name, compName)}
+	if err := cli.Get(reqCtx.Ctx, itsKey, its); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	// the quorum is counted on all the pods of the component, which may be more than the pods operated.
+	ownedPods, err := intctrlcomp.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, opsRes.Cluster.Name, compName)
+	if err != nil {
+		return err
+	}
+	members := make([]corev1.Pod, len(ownedPods))
+	for i := range ownedPods {
+		members[i] = *ownedPods[i]
+	}
+	pendingPod := selectPodToContinue(its, members, pendingPods)
+	if pendingPod == nil {
+		return nil
+	}
+	reqCtx.Log.Info(fmt.Sprintf("continue the operation on the pod %s as the failed pods are tolerated", pendingPod.Name))
+	return intctrlutil.BackgroundDeleteObject(cli, reqCtx.Ctx, pendingPod)
+}
+
+// selectPodToContinue selects the pending pod to be deleted to continue the rolling update. The leader is never
+// selected as it is updated last by the workload, and the pod is skipped if deleting it would drop the available
+// voting members below the quorum, e.g. the pods of a 3-replica consensus component with one failed pod.
+func selectPodToContinue(its *workloads.InstanceSet, members []corev1.Pod, pendingPods []*corev1.Pod) *corev1.Pod {
+	quorumMembers, consensus := quorum.ForInstanceSet(its, members)
+	for _, pod := range pendingPods {
+		if isLeaderPod(its, pod) {
+			continue
+		}
+		if consensus && quorum.IsAvailableVoter(its, pod) && quorumMembers.CheckDisruption(1) != nil {
+			continue
+		}
+		return pod
+	}
+	return nil
+}
+
 // handleCancelProgressForPodsRollingUpdate handles the cancel progress of pods during rolling update.
 func handleCancelProgressForPodsRollingUpdate(
 	opsRes *OpsResource,
@@ -524,8 +604,62 @@ func syncProgressToOpsRequest(
 	completedCount, expectCount int) error {
 	// sync progress
 	opsRes.OpsRequest.Status.Progress = fmt.Sprintf("%d/%d", completedCount, expectCount)
-	if !reflect.DeepEqual(opsRes.OpsRequest.Status, oldOpsRequest.Status) {
-		return cli.Status().Patch(reqCtx.Ctx, opsRes.OpsRequest, client.MergeFrom(oldOpsRequest))
+	return PatchOpsRequestStatus(reqCtx.Ctx, cli, oldOpsRequest, opsRes.OpsRequest)
+}
+
+// syncOpsProgressSummary summarizes the progress reported by the ops handler, i.e. the progress details of the components
+// and status.progress, into the progress of the components and the overall progress percentage.
+func syncOpsProgressSummary(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *appsv1alpha1.OpsRequest) error {
+	oldOpsRequest := opsRequest.DeepCopy()
+	summarizeOpsProgress(opsRequest)
+	return PatchOpsRequestStatus(reqCtx.Ctx, cli, oldOpsRequest, opsRequest)
+}
+
+// summarizeOpsProgress summarizes the progress details of each component into its progress, start time and end time,
+// and computes the overall progress percentage from status.progress.
+func summarizeOpsProgress(opsRequest *appsv1alpha1.OpsRequest) {
+	for name, compStatus := range opsRequest.Status.Components {
+		summarizeComponentProgress(&compStatus)
+		opsRequest.Status.Components[name] = compStatus
+	}
+	completed, expected, ok := strings.Cut(opsRequest.Status.Progress, "/")
+	if !ok {
+		return
+	}
+	completedCount, err1 := strconv.Atoi(completed)
+	expectCount, err2 := strconv.Atoi(expected)
+	if err1 != nil || err2 != nil || expectCount <= 0 {
+		return
+	}
+	opsRequest.Status.ProgressPercentage = int32(min(completedCount, expectCount) * 100 / expectCount)
+}
+
+func summarizeComponentProgress(compStatus *appsv1alpha1.OpsRequestComponentStatus) {
+	if len(compStatus.ProgressDetails) == 0 {
+		return
+	}
+	var (
+		completedCount     int
+		startTime, endTime metav1.Time
+	)
+	for _, detail := range compStatus.ProgressDetails {
+		if !detail.StartTime.IsZero() && (startTime.IsZero() || detail.StartTime.Before(&startTime)) {
+			startTime = detail.StartTime
+		}
+		if isCompletedProgressStatus(detail.Status) {
+			completedCount++
+			if endTime.Before(&detail.EndTime) {
+				endTime = detail.EndTime
+			}
+		}
+	}
+	compStatus.Progress = fmt.Sprintf("%d/%d", completedCount, len(compStatus.ProgressDetails))
+	if compStatus.StartTime.IsZero() {
+		compStatus.StartTime = startTime
+	}
+	if completedCount == len(compStatus.ProgressDetails) {
+		compStatus.EndTime = endTime
+	} else {
+		compStatus.EndTime = metav1.Time{}
 	}
-	return nil
 }
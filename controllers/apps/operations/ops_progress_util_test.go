@@ -25,6 +25,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -193,6 +194,50 @@ var _ = Describe("Ops ProgressDetails", func() {
 			Expect(opsRes.OpsRequest.Status.Progress).Should(Equal("1/1"))
 		})
 	})
+
+	Context("Test Ops continuing on the tolerable failures", func() {
+		It("keeps the leader and the quorum of a 3-replica consensus component with one failed pod", func() {
+			By("init operations resources with a failed follower")
+			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+			opsRes, _, _ := initOperationsResources(clusterDefinitionName, clusterVersionName, clusterName)
+			its := testapps.MockInstanceSetComponent(&testCtx, clusterName, consensusComp)
+			podList := testapps.MockInstanceSetPods(&testCtx, its, opsRes.Cluster, consensusComp)
+			Expect(podList).Should(HaveLen(3))
+			testk8s.MockPodIsFailed(ctx, testCtx, podList[1])
+
+			opsRes.OpsRequest = createRestartOpsObj(clusterName, "restart-"+randomStr)
+			opsRes.OpsRequest.Spec.FailurePolicy = appsv1alpha1.OpsFailurePolicyIgnore
+			progressStatus := map[*corev1.Pod]appsv1alpha1.ProgressStatus{
+				podList[0]: appsv1alpha1.PendingProgressStatus,
+				podList[1]: appsv1alpha1.FailedProgressStatus,
+				podList[2]: appsv1alpha1.PendingProgressStatus,
+			}
+			compStatus := &appsv1alpha1.OpsRequestComponentStatus{}
+			for _, pod := range podList {
+				compStatus.ProgressDetails = append(compStatus.ProgressDetails, appsv1alpha1.ProgressStatusDetail{
+					ObjectKey: getProgressObjectKey(constant.PodKind, pod.Name),
+					Status:    progressStatus[pod],
+				})
+			}
+			podExists := func(pod *corev1.Pod, expectExisted bool) func(g Gomega) {
+				return testapps.CheckObjExists(&testCtx, client.ObjectKeyFromObject(pod), &corev1.Pod{}, expectExisted)
+			}
+
+			By("expect no pod is deleted, as deleting the ready follower drops the voting members below the quorum")
+			Expect(continueOnTolerableFailure(reqCtx, k8sClient, opsRes, consensusComp, podList, compStatus)).Should(Succeed())
+			for _, pod := range podList {
+				Consistently(podExists(pod, true)).Should(Succeed())
+			}
+
+			By("expect the follower rather than the leader is deleted once the failed pod is available again")
+			Expect(testapps.ChangeObjStatus(&testCtx, podList[1], func() {
+				testk8s.MockPodAvailable(podList[1], metav1.Now())
+			})).Should(Succeed())
+			Expect(continueOnTolerableFailure(reqCtx, k8sClient, opsRes, consensusComp, podList, compStatus)).Should(Succeed())
+			Eventually(podExists(podList[2], false)).Should(Succeed())
+			Consistently(podExists(podList[0], true)).Should(Succeed())
+		})
+	})
 })
 
 func getProgressDetailStatus(opsRes *OpsResource, componentName string, pod *corev1.Pod) appsv1alpha1.ProgressStatus {
@@ -0,0 +1,79 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// canRetryOpsRequest checks if the failed OpsRequest has retries left in its spec.retryPolicy.
+func canRetryOpsRequest(opsRequest *appsv1alpha1.OpsRequest) bool {
+	policy := opsRequest.Spec.RetryPolicy
+	return policy != nil && opsRequest.Status.RetryCount < policy.MaxRetries
+}
+
+// isRetryingOpsRequest checks if the OpsRequest is going to re-run its action after a failure.
+func isRetryingOpsRequest(opsRequest *appsv1alpha1.OpsRequest) bool {
+	return opsRequest.Status.Phase == appsv1alpha1.OpsCreatingPhase && opsRequest.Status.RetryCount > 0
+}
+
+// retryBackoffRemaining returns the remaining duration to wait before the retrying OpsRequest re-runs its action.
+func retryBackoffRemaining(opsRequest *appsv1alpha1.OpsRequest) time.Duration {
+	if !isRetryingOpsRequest(opsRequest) || opsRequest.Spec.RetryPolicy == nil {
+		return 0
+	}
+	backoff := time.Duration(opsRequest.Spec.RetryPolicy.BackoffSeconds) * time.Second
+	if remaining := time.Until(opsRequest.Status.LastFailureTimestamp.Add(backoff)); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// retryFailedOpsRequest records the failure and moves the OpsRequest back to the Creating phase,
+// so that the action is re-run after the backoff. The failed progress details are dropped
+// to track the instances again.
+func retryFailedOpsRequest(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	failedCondition *metav1.Condition) (time.Duration, error) {
+	opsRequest := opsRes.OpsRequest
+	opsDeepCopy := opsRequest.DeepCopy()
+	opsRequest.Status.RetryCount++
+	opsRequest.Status.LastFailureReason = failedCondition.Message
+	opsRequest.Status.LastFailureTimestamp = metav1.Now()
+	for name, compStatus := range opsRequest.Status.Components {
+		compStatus.ProgressDetails = slices.DeleteFunc(compStatus.ProgressDetails, func(detail appsv1alpha1.ProgressStatusDetail) bool {
+			return detail.Status == appsv1alpha1.FailedProgressStatus
+		})
+		opsRequest.Status.Components[name] = compStatus
+	}
+	if err := PatchOpsStatusWithOpsDeepCopy(reqCtx.Ctx, cli, opsRes, opsDeepCopy, appsv1alpha1.OpsCreatingPhase,
+		appsv1alpha1.NewRetryingCondition(opsRequest, failedCondition)); err != nil {
+		return 0, err
+	}
+	return retryBackoffRemaining(opsRequest), nil
+}
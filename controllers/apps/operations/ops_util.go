@@ -27,16 +27,22 @@ import (
 	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	opsutil "github.com/apecloud/kubeblocks/controllers/apps/operations/util"
 	"github.com/apecloud/kubeblocks/pkg/configuration/core"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
+// OpsRequestControllerName is the name of the OpsRequest controller in the metrics of the status writes.
+const OpsRequestControllerName = "opsrequest"
+
 var _ error = &WaitForClusterPhaseErr{}
 
 type WaitForClusterPhaseErr struct {
@@ -75,7 +81,6 @@ func PatchOpsStatusWithOpsDeepCopy(ctx context.Context,
 	condition ...*metav1.Condition) error {
 
 	opsRequest := opsRes.OpsRequest
-	patch := client.MergeFrom(opsRequestDeepCopy)
 	for _, v := range condition {
 		if v == nil {
 			continue
@@ -83,12 +88,19 @@ func PatchOpsStatusWithOpsDeepCopy(ctx context.Context,
 		opsRequest.SetStatusCondition(*v)
 		// emit an event
 		eventType := corev1.EventTypeNormal
-		if phase == appsv1alpha1.OpsFailedPhase {
+		if phase == appsv1alpha1.OpsFailedPhase || phase == appsv1alpha1.OpsSucceedWithWarningsPhase {
 			eventType = corev1.EventTypeWarning
 		}
 		opsRes.Recorder.Event(opsRequest, eventType, v.Reason, v.Message)
 	}
 	opsRequest.Status.Phase = phase
+	if phase != appsv1alpha1.OpsPendingPhase {
+		// the OpsRequest has left the queue of the cluster.
+		opsRequest.Status.QueuePosition = 0
+	}
+	if phase == appsv1alpha1.OpsSucceedPhase || phase == appsv1alpha1.OpsSucceedWithWarningsPhase {
+		opsRequest.Status.ProgressPercentage = 100
+	}
 	if opsRequest.IsComplete(phase) {
 		opsRequest.Status.CompletionTimestamp = metav1.Time{Time: time.Now()}
 		// when OpsRequest is completed, remove it from annotation
@@ -99,7 +111,13 @@ func PatchOpsStatusWithOpsDeepCopy(ctx context.Context,
 	if phase == appsv1alpha1.OpsCreatingPhase && opsRequest.Status.StartTimestamp.IsZero() {
 		opsRequest.Status.StartTimestamp = metav1.Time{Time: time.Now()}
 	}
-	return cli.Status().Patch(ctx, opsRequest, patch)
+	return PatchOpsRequestStatus(ctx, cli, opsRequestDeepCopy, opsRequest)
+}
+
+// PatchOpsRequestStatus patches the changes of OpsRequest.status against the original OpsRequest,
+// the write is skipped if the status is not changed.
+func PatchOpsRequestStatus(ctx context.Context, cli client.Client, orig, opsRequest *appsv1alpha1.OpsRequest) error {
+	return model.PatchStatus(ctx, cli, OpsRequestControllerName, orig, opsRequest)
 }
 
 // PatchOpsStatus patches OpsRequest.status
@@ -118,6 +136,18 @@ func PatchClusterNotFound(ctx context.Context, cli client.Client, opsRes *OpsRes
 	return PatchOpsStatus(ctx, cli, opsRes, appsv1alpha1.OpsFailedPhase, condition)
 }
 
+// PatchClusterTerminating patches ClusterTerminating condition to the OpsRequest.status.conditions.
+// The OpsRequest which has not been validated yet fails, and the queued one is cancelled.
+func PatchClusterTerminating(ctx context.Context, cli client.Client, opsRes *OpsResource) error {
+	if opsRes.OpsRequest.Status.Phase == "" {
+		message := fmt.Sprintf("spec.clusterRef %s is being deleted", opsRes.OpsRequest.Spec.GetClusterName())
+		condition := appsv1alpha1.NewValidateFailedCondition(appsv1alpha1.ReasonClusterTerminating, message)
+		return PatchOpsStatus(ctx, cli, opsRes, appsv1alpha1.OpsFailedPhase, condition)
+	}
+	condition := appsv1alpha1.NewClusterTerminatingCondition(opsRes.OpsRequest)
+	return PatchOpsStatus(ctx, cli, opsRes, appsv1alpha1.OpsCancelledPhase, condition)
+}
+
 // PatchOpsHandlerNotSupported patches OpsNotSupported condition to the OpsRequest.status.conditions.
 func PatchOpsHandlerNotSupported(ctx context.Context, cli client.Client, opsRes *OpsResource) error {
 	message := fmt.Sprintf("spec.type %s is not supported by operator", opsRes.OpsRequest.Spec.Type)
@@ -205,7 +235,7 @@ func validateOpsWaitingPhase(cluster *appsv1alpha1.Cluster, ops *appsv1alpha1.Op
 	// or opsRequest status.phase is not Pending,
 	// or opsRequest will create cluster,
 	// we don't validate the cluster phase.
-	if len(opsBehaviour.FromClusterPhases) == 0 || ops.Status.Phase != appsv1alpha1.OpsPendingPhase || opsBehaviour.IsClusterCreation {
+	if len(opsBehaviour.FromClusterPhases) == 0 || ops.Status.Phase != appsv1alpha1.OpsPendingPhase || opsBehaviour.CreatesCluster(ops) {
 		return nil
 	}
 	if slices.Contains(opsBehaviour.FromClusterPhases, cluster.Status.Phase) {
@@ -213,7 +243,12 @@ func validateOpsWaitingPhase(cluster *appsv1alpha1.Cluster, ops *appsv1alpha1.Op
 	}
 	// check if entry-condition is met
 	// if the cluster is not in the expected phase, we should wait for it for up to TTLSecondsBeforeAbort seconds.
-	if ops.Spec.PreConditionDeadlineSeconds == nil || (time.Now().After(ops.GetCreationTimestamp().Add(time.Duration(*ops.Spec.PreConditionDeadlineSeconds) * time.Second))) {
+	// the deadline of a scheduled OpsRequest is counted from the time its maintenance window opened.
+	waitFrom := ops.GetCreationTimestamp().Time
+	if condition := meta.FindStatusCondition(ops.Status.Conditions, appsv1alpha1.ConditionTypeScheduled); condition != nil && condition.Status == metav1.ConditionTrue {
+		waitFrom = condition.LastTransitionTime.Time
+	}
+	if ops.Spec.PreConditionDeadlineSeconds == nil || (time.Now().After(waitFrom.Add(time.Duration(*ops.Spec.PreConditionDeadlineSeconds) * time.Second))) {
 		return nil
 	}
 
@@ -256,8 +291,8 @@ func abortEarlierOpsRequestWithSameKind(reqCtx intctrlutil.RequestCtx,
 			}
 			return err
 		}
-		if slices.Contains([]appsv1alpha1.OpsPhase{appsv1alpha1.OpsSucceedPhase, appsv1alpha1.OpsFailedPhase,
-			appsv1alpha1.OpsCancelledPhase}, earlierOps.Status.Phase) {
+		if slices.Contains([]appsv1alpha1.OpsPhase{appsv1alpha1.OpsSucceedPhase, appsv1alpha1.OpsSucceedWithWarningsPhase,
+			appsv1alpha1.OpsFailedPhase, appsv1alpha1.OpsCancelledPhase}, earlierOps.Status.Phase) {
 			continue
 		}
 		needAborted, err := matchAbortCondition(earlierOps)
@@ -266,12 +301,12 @@ func abortEarlierOpsRequestWithSameKind(reqCtx intctrlutil.RequestCtx,
 		}
 		if needAborted {
 			// abort the opsRequest that matches the abort condition.
-			patch := client.MergeFrom(earlierOps.DeepCopy())
+			earlierOpsDeepCopy := earlierOps.DeepCopy()
 			earlierOps.Status.Phase = appsv1alpha1.OpsAbortedPhase
 			abortedCondition := appsv1alpha1.NewAbortedCondition(earlierOps)
 			earlierOps.SetStatusCondition(abortedCondition)
 			earlierOps.Status.CompletionTimestamp = metav1.Time{Time: time.Now()}
-			if err = cli.Status().Patch(reqCtx.Ctx, earlierOps, patch); err != nil {
+			if err = PatchOpsRequestStatus(reqCtx.Ctx, cli, earlierOpsDeepCopy, earlierOps); err != nil {
 				return err
 			}
 			opsRes.Recorder.Event(earlierOps, corev1.EventTypeNormal, abortedCondition.Type, abortedCondition.Message)
@@ -314,3 +349,37 @@ func getComponentSpecOrShardingTemplate(cluster *appsv1alpha1.Cluster, component
 	}
 	return nil
 }
+
+// saveComponentSnapshots records the replicas, resources and versions of all the components prior to the operation
+// into OpsRequest.status.lastConfiguration, the configurations saved by the OpsHandler are kept as they are.
+func saveComponentSnapshots(opsRes *OpsResource) {
+	if opsRes.Cluster == nil {
+		return
+	}
+	lastConfiguration := &opsRes.OpsRequest.Status.LastConfiguration
+	if lastConfiguration.Components == nil {
+		lastConfiguration.Components = map[string]appsv1alpha1.LastComponentConfiguration{}
+	}
+	saveSnapshot := func(compSpec appsv1alpha1.ClusterComponentSpec, componentName string) {
+		lastCompConfiguration := lastConfiguration.Components[componentName]
+		if lastCompConfiguration.Replicas == nil {
+			lastCompConfiguration.Replicas = pointer.Int32(compSpec.Replicas)
+		}
+		if len(lastCompConfiguration.Limits) == 0 && len(lastCompConfiguration.Requests) == 0 {
+			lastCompConfiguration.ResourceRequirements = compSpec.Resources
+		}
+		if lastCompConfiguration.ComponentDefinitionName == "" {
+			lastCompConfiguration.ComponentDefinitionName = compSpec.ComponentDef
+		}
+		if lastCompConfiguration.ServiceVersion == "" {
+			lastCompConfiguration.ServiceVersion = compSpec.ServiceVersion
+		}
+		lastConfiguration.Components[componentName] = lastCompConfiguration
+	}
+	for _, v := range opsRes.Cluster.Spec.ComponentSpecs {
+		saveSnapshot(v, v.Name)
+	}
+	for _, v := range opsRes.Cluster.Spec.ShardingSpecs {
+		saveSnapshot(v.Template, v.Name)
+	}
+}
@@ -20,12 +20,16 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"fmt"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -138,6 +142,143 @@ var _ = Describe("OpsUtil functions", func() {
 			Expect(opsPhase).Should(Equal(appsv1alpha1.OpsFailedPhase))
 		})
 
+		It("Test opsRequest retry policy", func() {
+			By("init operations resources ")
+			opsRes, _, _ := initOperationsResources(clusterDefinitionName, clusterVersionName, clusterName)
+			ops := testapps.NewOpsRequestObj("restart-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.RestartType)
+			ops.Spec.RestartList = []appsv1alpha1.ComponentOps{{ComponentName: consensusComp}}
+			ops.Spec.RetryPolicy = &appsv1alpha1.OpsRequestRetryPolicy{MaxRetries: 1, BackoffSeconds: 30}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsRunningPhase
+			opsRes.OpsRequest.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{
+				consensusComp: {
+					ProgressDetails: []appsv1alpha1.ProgressStatusDetail{
+						{ObjectKey: "pod-0", Status: appsv1alpha1.SucceedProgressStatus},
+						{ObjectKey: "pod-1", Status: appsv1alpha1.FailedProgressStatus},
+					},
+				},
+			}
+			Expect(canRetryOpsRequest(opsRes.OpsRequest)).Should(BeTrue())
+
+			By("expect the failed OpsRequest is moved back to Creating and waits for the backoff")
+			reqCtx := intctrlutil.RequestCtx{Ctx: ctx}
+			failedCondition := appsv1alpha1.NewFailedCondition(opsRes.OpsRequest, fmt.Errorf("back-off pulling image"))
+			backoff, err := retryFailedOpsRequest(reqCtx, k8sClient, opsRes, failedCondition)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(backoff).Should(BeNumerically(">", 25*time.Second))
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+				g.Expect(ops.Status.Phase).Should(Equal(appsv1alpha1.OpsCreatingPhase))
+				g.Expect(ops.Status.RetryCount).Should(BeEquivalentTo(1))
+				g.Expect(ops.Status.LastFailureReason).Should(Equal("back-off pulling image"))
+				g.Expect(ops.Status.Components[consensusComp].ProgressDetails).Should(HaveLen(1))
+				g.Expect(meta.IsStatusConditionTrue(ops.Status.Conditions, appsv1alpha1.ConditionTypeRetrying)).Should(BeTrue())
+			})).Should(Succeed())
+			Expect(isRetryingOpsRequest(opsRes.OpsRequest)).Should(BeTrue())
+
+			By("expect the retries are exhausted")
+			Expect(canRetryOpsRequest(opsRes.OpsRequest)).Should(BeFalse())
+		})
+
+		It("Test opsRequest node concurrency", func() {
+			By("init operations resources ")
+			initOperationsResources(clusterDefinitionName, clusterVersionName, clusterName)
+			createRestartOps := func(name string) *appsv1alpha1.OpsRequest {
+				ops := testapps.NewOpsRequestObj(name, testCtx.DefaultNamespace, clusterName, appsv1alpha1.RestartType)
+				ops.Spec.RestartList = []appsv1alpha1.ComponentOps{{ComponentName: consensusComp}}
+				return testapps.CreateOpsRequest(ctx, testCtx, ops)
+			}
+			scheduler := &nodeConcurrencyScheduler{
+				policy:       OpsNodeConcurrencyPolicy{MaxPerNode: 1, NodePoolLabel: "pool", MaxPerNodePool: 2},
+				reservations: map[types.NamespacedName]nodeReservation{},
+			}
+			ops1 := createRestartOps("restart-ops-1-" + randomStr)
+			ops2 := createRestartOps("restart-ops-2-" + randomStr)
+			ops3 := createRestartOps("restart-ops-3-" + randomStr)
+
+			By("expect the first OpsRequest is admitted")
+			message, err := scheduler.admit(ctx, k8sClient, ops1, disruptedNodes{Nodes: []string{"node-1"}, NodePools: []string{"pool-1"}})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(message).Should(BeEmpty())
+
+			By("expect the OpsRequest disrupting the same node is limited")
+			message, err = scheduler.admit(ctx, k8sClient, ops2, disruptedNodes{Nodes: []string{"node-1"}, NodePools: []string{"pool-1"}})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(message).Should(ContainSubstring(`node "node-1"`))
+
+			By("expect the OpsRequest disrupting another node in the same node pool is admitted")
+			message, err = scheduler.admit(ctx, k8sClient, ops2, disruptedNodes{Nodes: []string{"node-2"}, NodePools: []string{"pool-1"}})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(message).Should(BeEmpty())
+
+			By("expect the OpsRequest is limited when the node pool reaches the limit")
+			message, err = scheduler.admit(ctx, k8sClient, ops3, disruptedNodes{Nodes: []string{"node-3"}, NodePools: []string{"pool-1"}})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(message).Should(ContainSubstring(`node pool "pool-1"`))
+
+			By("expect the OpsRequest is admitted after the others succeed")
+			for _, ops := range []*appsv1alpha1.OpsRequest{ops1, ops2} {
+				Expect(testapps.ChangeObjStatus(&testCtx, ops, func() {
+					ops.Status.Phase = appsv1alpha1.OpsSucceedPhase
+				})).Should(Succeed())
+			}
+			Eventually(func(g Gomega) {
+				message, err = scheduler.admit(ctx, k8sClient, ops3, disruptedNodes{Nodes: []string{"node-3"}, NodePools: []string{"pool-1"}})
+				g.Expect(err).ShouldNot(HaveOccurred())
+				g.Expect(message).Should(BeEmpty())
+			}).Should(Succeed())
+
+			By("expect only the primary and the candidate are disrupted by a switchover")
+			switchover := testapps.NewOpsRequestObj("switchover-ops-"+randomStr, testCtx.DefaultNamespace, clusterName, appsv1alpha1.SwitchoverType)
+			switchover.Spec.SwitchoverList = []appsv1alpha1.Switchover{{ComponentOps: appsv1alpha1.ComponentOps{ComponentName: consensusComp}, InstanceName: "pod-1"}}
+			newPod := func(name string, accessMode appsv1alpha1.AccessMode) *corev1.Pod {
+				return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{
+					constant.KBAppComponentLabelKey: consensusComp,
+					constant.AccessModeLabelKey:     string(accessMode),
+				}}}
+			}
+			Expect(isPodDisruptedByOps(switchover, newPod("pod-0", appsv1alpha1.ReadWrite))).Should(BeTrue())
+			Expect(isPodDisruptedByOps(switchover, newPod("pod-1", appsv1alpha1.Readonly))).Should(BeTrue())
+			Expect(isPodDisruptedByOps(switchover, newPod("pod-2", appsv1alpha1.Readonly))).Should(BeFalse())
+		})
+
+		It("Test opsRequest progress summary", func() {
+			startTime := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+			endTime := metav1.NewTime(startTime.Add(30 * time.Second))
+			ops := testapps.NewOpsRequestObj("restart-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.RestartType)
+			ops.Status.Progress = "1/3"
+			ops.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{
+				consensusComp: {ProgressDetails: []appsv1alpha1.ProgressStatusDetail{
+					{ObjectKey: "Pod/pod-0", Status: appsv1alpha1.SucceedProgressStatus, StartTime: startTime, EndTime: endTime},
+					{ObjectKey: "Pod/pod-1", Status: appsv1alpha1.ProcessingProgressStatus, StartTime: endTime},
+					{ObjectKey: "Pod/pod-2", Status: appsv1alpha1.PendingProgressStatus},
+				}},
+			}
+
+			By("expect the progress of the component and the overall percentage")
+			summarizeOpsProgress(ops)
+			compStatus := ops.Status.Components[consensusComp]
+			Expect(compStatus.Progress).Should(Equal("1/3"))
+			Expect(compStatus.StartTime).Should(Equal(startTime))
+			Expect(compStatus.EndTime.IsZero()).Should(BeTrue())
+			Expect(ops.Status.ProgressPercentage).Should(BeEquivalentTo(33))
+
+			By("expect the end time to be set after all the instances completed")
+			lastEndTime := metav1.NewTime(endTime.Add(time.Minute))
+			compStatus.ProgressDetails[1].Status = appsv1alpha1.SucceedProgressStatus
+			compStatus.ProgressDetails[1].EndTime = lastEndTime
+			compStatus.ProgressDetails[2].Status = appsv1alpha1.FailedProgressStatus
+			compStatus.ProgressDetails[2].EndTime = endTime
+			ops.Status.Components[consensusComp] = compStatus
+			ops.Status.Progress = "3/3"
+			summarizeOpsProgress(ops)
+			compStatus = ops.Status.Components[consensusComp]
+			Expect(compStatus.Progress).Should(Equal("3/3"))
+			Expect(compStatus.EndTime).Should(Equal(lastEndTime))
+			Expect(ops.Status.ProgressPercentage).Should(BeEquivalentTo(100))
+		})
+
 		It("Test opsRequest with disable ha", func() {
 			By("init operations resources ")
 			opsRes, _, _ := initOperationsResources(clusterDefinitionName, clusterVersionName, clusterName)
@@ -245,6 +386,73 @@ var _ = Describe("OpsUtil functions", func() {
 			})
 		})
 
+		It("Test opsRequest priority queue", func() {
+			By("init operations resources ")
+			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+			opsRes, _, _ := initOperationsResources(clusterDefinitionName, clusterVersionName, clusterName)
+
+			runHscaleOps := func(priority int32, preemptionPolicy appsv1alpha1.OpsPreemptionPolicy) *appsv1alpha1.OpsRequest {
+				ops := testapps.NewOpsRequestObj("horizontal-scaling-ops-"+testCtx.GetRandomStr(), testCtx.DefaultNamespace,
+					clusterName, appsv1alpha1.HorizontalScalingType)
+				ops.Spec.Priority = priority
+				ops.Spec.PreemptionPolicy = preemptionPolicy
+				ops.Spec.HorizontalScalingList = []appsv1alpha1.HorizontalScaling{
+					{
+						ComponentOps: appsv1alpha1.ComponentOps{ComponentName: consensusComp},
+						Replicas:     pointer.Int32(1),
+					},
+				}
+				ops = testapps.CreateOpsRequest(ctx, testCtx, ops)
+				ops.Status.Phase = appsv1alpha1.OpsPendingPhase
+				opsRes.OpsRequest = ops
+				_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+				Expect(err).ShouldNot(HaveOccurred())
+				return ops
+			}
+
+			By("run the first h-scale ops, expect phase to Creating")
+			ops1 := runHscaleOps(0, "")
+			Expect(ops1.Status.Phase).Should(Equal(appsv1alpha1.OpsCreatingPhase))
+			Expect(ops1.Status.QueuePosition).Should(BeEquivalentTo(0))
+
+			By("queue a low-priority ops and a high-priority ops, expect the high-priority ops to jump ahead")
+			ops2 := runHscaleOps(0, appsv1alpha1.PreemptLowerPriorityPolicy)
+			Expect(ops2.Status.Phase).Should(Equal(appsv1alpha1.OpsPendingPhase))
+			Expect(ops2.Status.QueuePosition).Should(BeEquivalentTo(1))
+			ops3 := runHscaleOps(10, appsv1alpha1.PreemptLowerPriorityPolicy)
+			Expect(ops3.Status.Phase).Should(Equal(appsv1alpha1.OpsPendingPhase))
+			Expect(ops3.Status.QueuePosition).Should(BeEquivalentTo(1))
+
+			By("queue a high-priority ops with Never preemption policy, expect it to be queued at the tail")
+			ops4 := runHscaleOps(20, appsv1alpha1.PreemptNeverPolicy)
+			Expect(ops4.Status.QueuePosition).Should(BeEquivalentTo(3))
+
+			cluster := &appsv1alpha1.Cluster{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(opsRes.Cluster), cluster)).Should(Succeed())
+			opsSlice, _ := opsutil.GetOpsRequestSliceFromCluster(cluster)
+			Expect(opsSlice).Should(HaveLen(4))
+			Expect(opsSlice[0].Name).Should(Equal(ops1.Name))
+			Expect(opsSlice[1].Name).Should(Equal(ops3.Name))
+			Expect(opsSlice[2].Name).Should(Equal(ops2.Name))
+			Expect(opsSlice[3].Name).Should(Equal(ops4.Name))
+
+			By("expect the low-priority ops to stay in the queue when the running ops is completed")
+			opsSlice = slices.Delete(opsSlice, 0, 1)
+			Expect(opsutil.UpdateClusterOpsAnnotations(ctx, k8sClient, opsRes.Cluster, opsSlice)).Should(Succeed())
+			opsRes.OpsRequest = ops2
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(ops2.Status.Phase).Should(Equal(appsv1alpha1.OpsPendingPhase))
+			Expect(ops2.Status.QueuePosition).Should(BeEquivalentTo(2))
+
+			By("expect the high-priority ops to start")
+			opsRes.OpsRequest = ops3
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(ops3.Status.Phase).Should(Equal(appsv1alpha1.OpsCreatingPhase))
+			Expect(ops3.Status.QueuePosition).Should(BeEquivalentTo(0))
+		})
+
 		It("Test opsRequest dependency", func() {
 			By("init operations resources ")
 			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
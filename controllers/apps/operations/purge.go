@@ -0,0 +1,179 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlcomp "github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+)
+
+type purgeOpsHandler struct{}
+
+// purgeRequeueInterval is the interval to retry purging the instances which are not ready,
+// as no events are triggered when the instances are purged.
+const purgeRequeueInterval = 10 * time.Second
+
+var _ OpsHandler = purgeOpsHandler{}
+
+func init() {
+	purgeBehaviour := OpsBehaviour{
+		FromClusterPhases: appsv1alpha1.GetClusterUpRunningPhases(),
+		QueueByCluster:    true,
+		OpsHandler:        purgeOpsHandler{},
+	}
+
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(appsv1alpha1.PurgeType, purgeBehaviour)
+}
+
+// ActionStartedCondition the started condition when handle the purge request.
+func (p purgeOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return appsv1alpha1.NewPurgeCondition(opsRes.OpsRequest), nil
+}
+
+// Action checks whether the ComponentDefinitions of the components provide the purge action,
+// the instances are purged one by one in ReconcileAction.
+func (p purgeOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	for _, purge := range opsRes.OpsRequest.Spec.PurgeList {
+		compSpec := opsRes.Cluster.Spec.GetComponentByName(purge.ComponentName)
+		if compSpec == nil {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`component "%s" not found`, purge.ComponentName))
+		}
+		if compSpec.ComponentDef == "" {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`the purge action is not defined for the component "%s"`, purge.ComponentName))
+		}
+		compDef := &appsv1alpha1.ComponentDefinition{}
+		if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: compSpec.ComponentDef}, compDef); err != nil {
+			return err
+		}
+		if compDef.Spec.LifecycleActions == nil || compDef.Spec.LifecycleActions.Purge == nil {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`the purge action is not defined for the component "%s"`, purge.ComponentName))
+		}
+	}
+	return nil
+}
+
+// ReconcileAction will be performed when action is done and loops till OpsRequest.status.phase is Succeed/Failed.
+// the Reconcile function for purge opsRequest.
+func (p purgeOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.PurgeList)
+	purgePending := false
+	handlePurgeProgress := func(reqCtx intctrlutil.RequestCtx,
+		cli client.Client,
+		opsRes *OpsResource,
+		pgRes *progressResource,
+		compStatus *appsv1alpha1.OpsRequestComponentStatus) (int32, int32, error) {
+		// the component phase is not changed when purging the instances online.
+		pgRes.noWaitComponentCompleted = true
+		purge := pgRes.compOps.(appsv1alpha1.Purge)
+		pods, err := intctrlcomp.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, opsRes.Cluster.Name, pgRes.fullComponentName)
+		if err != nil {
+			return 0, 0, err
+		}
+		var (
+			expectCount    int32
+			completedCount int32
+		)
+		for _, pod := range pods {
+			if len(purge.Instances) > 0 && !slices.Contains(purge.Instances, pod.Name) {
+				continue
+			}
+			expectCount += 1
+			if p.purgePod(reqCtx, opsRes, pgRes, compStatus, purge, pod) {
+				completedCount += 1
+			} else {
+				purgePending = true
+			}
+		}
+		return expectCount, completedCount, nil
+	}
+	phase, requeueAfter, err := compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes, "purge", handlePurgeProgress)
+	if err == nil && phase == appsv1alpha1.OpsRunningPhase && requeueAfter == 0 && purgePending {
+		requeueAfter = purgeRequeueInterval
+	}
+	return phase, requeueAfter, err
+}
+
+// SaveLastConfiguration this operation only purges the logs and temporary files of the instances, no changes for Cluster.spec.
+// empty implementation here.
+func (p purgeOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
+// purgePod invokes the purge action in the instance and records the reclaimed space, it returns true if the instance
+// is purged or failed to purge. The instance which is not ready will be retried in the next reconciliation.
+func (p purgeOpsHandler) purgePod(reqCtx intctrlutil.RequestCtx,
+	opsRes *OpsResource,
+	pgRes *progressResource,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus,
+	purge appsv1alpha1.Purge,
+	pod *corev1.Pod) bool {
+	objectKey := getProgressObjectKey(constant.PodKind, pod.Name)
+	progressDetail := findStatusProgressDetail(compStatus.ProgressDetails, objectKey)
+	if progressDetail != nil && isCompletedProgressStatus(progressDetail.Status) {
+		return true
+	}
+	compName := pgRes.compOps.GetComponentName()
+	newProgressDetail := appsv1alpha1.ProgressStatusDetail{ObjectKey: objectKey}
+	if !intctrlutil.PodIsReady(pod) {
+		newProgressDetail.Status = appsv1alpha1.PendingProgressStatus
+		newProgressDetail.Message = fmt.Sprintf("Waiting for the instance to be ready to %s: %s in Component: %s", pgRes.opsMessageKey, objectKey, compName)
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, newProgressDetail)
+		return false
+	}
+	lorryCli, err := lorry.NewClient(*pod)
+	if err != nil || intctrlutil.IsNil(lorryCli) {
+		reqCtx.Log.Info("failed to get the lorry client of the pod", "pod", pod.Name, "error", err)
+		return false
+	}
+	var retainBytes *int64
+	if purge.RetainBytes != nil {
+		bytes := purge.RetainBytes.Value()
+		retainBytes = &bytes
+	}
+	reclaimedBytes, err := lorryCli.Purge(reqCtx.Ctx, purge.RetainHours, retainBytes)
+	if err != nil {
+		newProgressDetail.Status = appsv1alpha1.FailedProgressStatus
+		newProgressDetail.Message = getProgressFailedMessage(pgRes.opsMessageKey, objectKey, compName, err.Error())
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, newProgressDetail)
+		return true
+	}
+	reclaimedSpace := resource.NewQuantity(reclaimedBytes, resource.BinarySI)
+	newProgressDetail.Status = appsv1alpha1.SucceedProgressStatus
+	newProgressDetail.Message = fmt.Sprintf("%s, reclaimed space: %s", getProgressSucceedMessage(pgRes.opsMessageKey, objectKey, compName), reclaimedSpace.String())
+	setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, newProgressDetail)
+	if compStatus.ReclaimedSpace == nil {
+		compStatus.ReclaimedSpace = resource.NewQuantity(0, resource.BinarySI)
+	}
+	compStatus.ReclaimedSpace.Add(*reclaimedSpace)
+	return true
+}
@@ -0,0 +1,99 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("Purge OpsRequest", func() {
+	It("purges the instances by the purge action", func() {
+		var (
+			reqCtx      = intctrlutil.RequestCtx{Ctx: testCtx.Ctx, Log: logf.FromContext(testCtx.Ctx)}
+			retainHours = int32(24)
+			retainBytes = resource.MustParse("1Gi")
+			purge       = appsv1alpha1.Purge{
+				ComponentOps: appsv1alpha1.ComponentOps{ComponentName: consensusComp},
+				RetainHours:  &retainHours,
+				RetainBytes:  &retainBytes,
+			}
+			opsRes = &OpsResource{
+				OpsRequest: testapps.NewOpsRequestObj("purge-ops", testCtx.DefaultNamespace, "test", appsv1alpha1.PurgeType),
+				Recorder:   k8sManager.GetEventRecorderFor("opsrequest-controller"),
+			}
+			pgRes      = &progressResource{opsMessageKey: "purge", compOps: purge}
+			compStatus = &appsv1alpha1.OpsRequestComponentStatus{}
+			handler    = purgeOpsHandler{}
+			recorder   = mockLorryClient()
+			newPod     = func(name string, ready bool) *corev1.Pod {
+				pod := testapps.NewPodFactory(testCtx.DefaultNamespace, name).GetObject()
+				if ready {
+					pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+				}
+				return pod
+			}
+		)
+
+		By("expect the instance which is not ready is retried later")
+		Expect(handler.purgePod(reqCtx, opsRes, pgRes, compStatus, purge, newPod("test-mysql-2", false))).Should(BeFalse())
+
+		By("expect the failed instance is completed")
+		recorder.Purge(gomock.Any(), &retainHours, gomock.Any()).Return(int64(0), errors.New("purge failed")).Times(1)
+		Expect(handler.purgePod(reqCtx, opsRes, pgRes, compStatus, purge, newPod("test-mysql-0", true))).Should(BeTrue())
+
+		By("expect the instance is purged with the retained bytes")
+		recorder.Purge(gomock.Any(), &retainHours, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ *int32, retainBytes *int64) (int64, error) {
+				Expect(retainBytes).ShouldNot(BeNil())
+				Expect(*retainBytes).Should(BeEquivalentTo(1 << 30))
+				return 2 << 20, nil
+			}).Times(1)
+		Expect(handler.purgePod(reqCtx, opsRes, pgRes, compStatus, purge, newPod("test-mysql-1", true))).Should(BeTrue())
+
+		By("expect the completed instance is not purged again")
+		Expect(handler.purgePod(reqCtx, opsRes, pgRes, compStatus, purge, newPod("test-mysql-1", true))).Should(BeTrue())
+
+		expectedStatus := map[string]appsv1alpha1.ProgressStatus{
+			"test-mysql-0": appsv1alpha1.FailedProgressStatus,
+			"test-mysql-1": appsv1alpha1.SucceedProgressStatus,
+			"test-mysql-2": appsv1alpha1.PendingProgressStatus,
+		}
+		for podName, status := range expectedStatus {
+			progressDetail := findStatusProgressDetail(compStatus.ProgressDetails, getProgressObjectKey(constant.PodKind, podName))
+			Expect(progressDetail).ShouldNot(BeNil())
+			Expect(progressDetail.Status).Should(Equal(status))
+		}
+		Expect(compStatus.ReclaimedSpace).ShouldNot(BeNil())
+		Expect(compStatus.ReclaimedSpace.String()).Should(Equal("2Mi"))
+	})
+})
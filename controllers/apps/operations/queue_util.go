@@ -66,7 +66,7 @@ func DequeueOpsRequestInClusterAnnotation(ctx context.Context, cli client.Client
 				}
 				return err
 			}
-			patch := client.MergeFrom(ops.DeepCopy())
+			opsDeepCopy := ops.DeepCopy()
 			ops.Status.Phase = appsv1alpha1.OpsCancelledPhase
 			ops.Status.CompletionTimestamp = metav1.Time{Time: time.Now()}
 			ops.SetStatusCondition(metav1.Condition{
@@ -75,7 +75,7 @@ func DequeueOpsRequestInClusterAnnotation(ctx context.Context, cli client.Client
 				Status:  metav1.ConditionTrue,
 				Message: fmt.Sprintf(`Cancelled by controller due to the failure of previous OpsRequest "%s"`, opsRes.OpsRequest.Name),
 			})
-			if err = cli.Status().Patch(ctx, ops, patch); err != nil && apierrors.IsNotFound(err) {
+			if err = PatchOpsRequestStatus(ctx, cli, opsDeepCopy, ops); err != nil && apierrors.IsNotFound(err) {
 				return err
 			}
 		}
@@ -120,17 +120,19 @@ func enqueueOpsRequestToClusterAnnotation(ctx context.Context, cli client.Client
 			Name:        opsRes.OpsRequest.Name,
 			Type:        opsRes.OpsRequest.Spec.Type,
 			QueueBySelf: opsBehaviour.QueueBySelf,
+			Priority:    opsRes.OpsRequest.Spec.Priority,
 			// check if the opsRequest should be in the queue.
 			InQueue: existOtherRunningOps(opsRequestSlice, opsRes.OpsRequest.Spec.Type, opsBehaviour) && !opsRes.OpsRequest.Force(),
 		}
-		opsRequestSlice = append(opsRequestSlice, opsRecorder)
+		opsRequestSlice = insertOpsRecorder(opsRequestSlice, opsRecorder, opsRes.OpsRequest.Spec.PreemptionPolicy)
 	default:
 		if !opsRecorder.InQueue {
 			// the opsRequest is already running.
 			return &opsRecorder, nil
 		}
-		if !opsRes.OpsRequest.Spec.Force && existOtherRunningOps(opsRequestSlice, opsRecorder.Type, opsBehaviour) {
-			// if exists other running opsRequest, return.
+		if !opsRes.OpsRequest.Spec.Force &&
+			(existOtherRunningOps(opsRequestSlice, opsRecorder.Type, opsBehaviour) || getQueuePosition(opsRequestSlice, index, opsBehaviour) > 1) {
+			// if exists other running opsRequest or the opsRequests queued ahead, return.
 			return &opsRecorder, nil
 		}
 		// mark to handle the next opsRequest
@@ -139,13 +141,68 @@ func enqueueOpsRequestToClusterAnnotation(ctx context.Context, cli client.Client
 	return &opsRecorder, opsutil.UpdateClusterOpsAnnotations(ctx, cli, opsRes.Cluster, opsRequestSlice)
 }
 
-// existOtherRunningOps checks if exists other running opsRequest.
-func existOtherRunningOps(opsRecorderSlice []appsv1alpha1.OpsRecorder, opsType appsv1alpha1.OpsType, opsBehaviour OpsBehaviour) bool {
+// patchOpsQueuePosition patches the position of the queued OpsRequest in the queue of the Cluster to status.queuePosition.
+func patchOpsQueuePosition(ctx context.Context, cli client.Client, opsRes *OpsResource, opsBehaviour OpsBehaviour) error {
+	opsRequestSlice, err := opsutil.GetOpsRequestSliceFromCluster(opsRes.Cluster)
+	if err != nil {
+		return err
+	}
+	index, _ := GetOpsRecorderFromSlice(opsRequestSlice, opsRes.OpsRequest.Name)
+	if index == -1 {
+		return nil
+	}
+	position := getQueuePosition(opsRequestSlice, index, opsBehaviour)
+	if opsRes.OpsRequest.Status.QueuePosition == position {
+		return nil
+	}
+	opsDeepCopy := opsRes.OpsRequest.DeepCopy()
+	opsRes.OpsRequest.Status.QueuePosition = position
+	return PatchOpsRequestStatus(ctx, cli, opsDeepCopy, opsRes.OpsRequest)
+}
+
+// insertOpsRecorder inserts the opsRecorder into the queue. A queued opsRecorder is placed ahead of
+// the queued opsRecorders with lower priorities unless the preemption policy is Never.
+func insertOpsRecorder(opsRecorderSlice []appsv1alpha1.OpsRecorder,
+	opsRecorder appsv1alpha1.OpsRecorder,
+	preemptionPolicy appsv1alpha1.OpsPreemptionPolicy) []appsv1alpha1.OpsRecorder {
+	if !opsRecorder.InQueue || preemptionPolicy == appsv1alpha1.PreemptNeverPolicy {
+		return append(opsRecorderSlice, opsRecorder)
+	}
 	for i := range opsRecorderSlice {
-		if opsBehaviour.QueueByCluster && opsRecorderSlice[i].QueueBySelf {
+		if opsRecorderSlice[i].InQueue && opsRecorderSlice[i].Priority < opsRecorder.Priority {
+			return slices.Insert(opsRecorderSlice, i, opsRecorder)
+		}
+	}
+	return append(opsRecorderSlice, opsRecorder)
+}
+
+// getQueuePosition returns the position of the queued opsRecorder at the index within its queue scope, starting from 1.
+func getQueuePosition(opsRecorderSlice []appsv1alpha1.OpsRecorder, index int, opsBehaviour OpsBehaviour) int32 {
+	position := int32(1)
+	for i := 0; i < index; i++ {
+		if !opsRecorderSlice[i].InQueue || !inSameQueueScope(opsRecorderSlice[i], opsRecorderSlice[index].Type, opsBehaviour) {
 			continue
 		}
-		if opsBehaviour.QueueBySelf && opsRecorderSlice[i].Type != opsType {
+		position++
+	}
+	return position
+}
+
+// inSameQueueScope checks if the opsRecorder is in the same queue scope as the opsRequest of the opsType.
+func inSameQueueScope(opsRecorder appsv1alpha1.OpsRecorder, opsType appsv1alpha1.OpsType, opsBehaviour OpsBehaviour) bool {
+	if opsBehaviour.QueueByCluster && opsRecorder.QueueBySelf {
+		return false
+	}
+	if opsBehaviour.QueueBySelf && opsRecorder.Type != opsType {
+		return false
+	}
+	return true
+}
+
+// existOtherRunningOps checks if exists other running opsRequest.
+func existOtherRunningOps(opsRecorderSlice []appsv1alpha1.OpsRecorder, opsType appsv1alpha1.OpsType, opsBehaviour OpsBehaviour) bool {
+	for i := range opsRecorderSlice {
+		if !inSameQueueScope(opsRecorderSlice[i], opsType, opsBehaviour) {
 			continue
 		}
 		if !opsRecorderSlice[i].InQueue {
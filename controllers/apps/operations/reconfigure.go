@@ -24,11 +24,11 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/conditions"
 	"github.com/apecloud/kubeblocks/pkg/configuration/core"
 	configctrl "github.com/apecloud/kubeblocks/pkg/controller/configuration"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
@@ -267,7 +267,7 @@ func (r *reconfigureAction) doReconfiguring(params reconfigureParams) error {
 		return err
 	}
 	condition := constructReconfiguringConditions(result, params.resource, opsPipeline.configSpec)
-	meta.SetStatusCondition(&params.configurationStatus.Conditions, *condition)
+	conditions.Set(&params.configurationStatus.Conditions, *condition)
 	return nil
 }
 
@@ -292,7 +292,7 @@ func syncStatus(reconfiguringStatus *appsv1alpha1.ReconfiguringStatus,
 	phase appsv1alpha1.ConfigurationPhase) error {
 	err := updateReconfigureStatusByCM(reconfiguringStatus, status.Name,
 		handleReconfigureStatusProgress(status.ReconcileDetail, &opsRes.OpsRequest.Status, phase))
-	meta.SetStatusCondition(&reconfiguringStatus.Conditions, *appsv1alpha1.NewReconfigureRunningCondition(
+	conditions.Set(&reconfiguringStatus.Conditions, *appsv1alpha1.NewReconfigureRunningCondition(
 		opsRes.OpsRequest, string(phase), status.Name))
 	return err
 }
@@ -154,6 +154,10 @@ func (p *pipeline) doMergeImpl(parameters appsv1alpha1.ConfigurationItem) error
 			if key.FileContent != "" {
 				return cfgcore.MakeError("not allowed to update file content: %s", key.Key)
 			}
+			if hasSecretParameters(key.Parameters) && !configSpec.AllowSecretParameters {
+				p.isFailed = true
+				return cfgcore.MakeError("not allowed to reference secrets by parameters, the config template[%s] does not allow secret parameters", configSpec.Name)
+			}
 			updateParameters(item, key.Key, key.Parameters, paramFilter)
 			p.updatedParameters = append(p.updatedParameters, cfgcore.ParamPairs{
 				Key:           key.Key,
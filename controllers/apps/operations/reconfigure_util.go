@@ -31,6 +31,7 @@ import (
 	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
 	"github.com/apecloud/kubeblocks/pkg/configuration/core"
 	"github.com/apecloud/kubeblocks/pkg/configuration/validate"
+	configctrl "github.com/apecloud/kubeblocks/pkg/controller/configuration"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
@@ -103,15 +104,28 @@ func fetchOriginalValue(keyFile, data string, params map[string]interface{}, for
 func fromKeyValuePair(parameters []appsv1alpha1.ParameterPair) map[string]interface{} {
 	m := make(map[string]interface{}, len(parameters))
 	for _, param := range parameters {
-		if param.Value != nil {
+		switch {
+		case param.ValueFrom != nil:
+			// never expose the values of the secret-backed parameters
+			m[param.Key] = configctrl.SecretParameterMask
+		case param.Value != nil:
 			m[param.Key] = *param.Value
-		} else {
+		default:
 			m[param.Key] = nil
 		}
 	}
 	return m
 }
 
+func hasSecretParameters(parameters []appsv1alpha1.ParameterPair) bool {
+	for _, param := range parameters {
+		if param.ValueFrom != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func withFailed(failed bool) func(result *reconfiguringResult) {
 	return func(result *reconfiguringResult) {
 		result.failed = failed
@@ -215,15 +229,22 @@ func updateFileContent(item *appsv1alpha1.ConfigurationItemDetail, key string, c
 		return
 	}
 	item.ConfigFileParams[key] = appsv1alpha1.ConfigParams{
-		Parameters: params.Parameters,
-		Content:    &content,
+		Parameters:     params.Parameters,
+		ParametersFrom: params.ParametersFrom,
+		Content:        &content,
 	}
 }
 
 func updateParameters(item *appsv1alpha1.ConfigurationItemDetail, key string, parameters []appsv1alpha1.ParameterPair, filter validate.ValidatorOptions) {
 	updatedParams := make(map[string]*string, len(parameters))
+	updatedParamsFrom := make(map[string]appsv1alpha1.ParameterValueSource)
 	for _, parameter := range parameters {
-		if filter(parameter.Key) {
+		if !filter(parameter.Key) {
+			continue
+		}
+		if parameter.ValueFrom != nil {
+			updatedParamsFrom[parameter.Key] = *parameter.ValueFrom
+		} else {
 			updatedParams[parameter.Key] = parameter.Value
 		}
 	}
@@ -231,15 +252,49 @@ func updateParameters(item *appsv1alpha1.ConfigurationItemDetail, key string, pa
 	params, ok := item.ConfigFileParams[key]
 	if !ok {
 		item.ConfigFileParams[key] = appsv1alpha1.ConfigParams{
-			Parameters: updatedParams,
+			Parameters:     updatedParams,
+			ParametersFrom: mergeParametersFrom(nil, updatedParamsFrom, updatedParams),
 		}
 		return
 	}
 
 	item.ConfigFileParams[key] = appsv1alpha1.ConfigParams{
-		Content:    params.Content,
-		Parameters: mergeMaps(params.Parameters, updatedParams),
+		Content:        params.Content,
+		Parameters:     mergeMaps(removeKeys(params.Parameters, updatedParamsFrom), updatedParams),
+		ParametersFrom: mergeParametersFrom(params.ParametersFrom, updatedParamsFrom, updatedParams),
+	}
+}
+
+// mergeParametersFrom merges the updated secret-backed parameters, and drops the ones updated with plain values.
+func mergeParametersFrom(m1 map[string]appsv1alpha1.ParameterValueSource,
+	m2 map[string]appsv1alpha1.ParameterValueSource,
+	updatedParams map[string]*string) map[string]appsv1alpha1.ParameterValueSource {
+	merged := make(map[string]appsv1alpha1.ParameterValueSource)
+	for key, value := range m1 {
+		if _, ok := updatedParams[key]; !ok {
+			merged[key] = value
+		}
+	}
+	for key, value := range m2 {
+		merged[key] = value
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	return merged
+}
+
+func removeKeys[T any](m map[string]*string, keys map[string]T) map[string]*string {
+	if len(keys) == 0 {
+		return m
+	}
+	r := make(map[string]*string, len(m))
+	for key, value := range m {
+		if _, ok := keys[key]; !ok {
+			r[key] = value
+		}
 	}
+	return r
 }
 
 func mergeMaps(m1 map[string]*string, m2 map[string]*string) map[string]*string {
@@ -20,9 +20,14 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	configctrl "github.com/apecloud/kubeblocks/pkg/controller/configuration"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
@@ -49,3 +54,44 @@ func testUpdateConfigConfigmapResource(
 		Sync().
 		Complete()
 }
+
+func TestUpdateSecretParameters(t *testing.T) {
+	valueFrom := &appsv1alpha1.ParameterValueSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "mysql-secret"},
+			Key:                  "password",
+		},
+	}
+	item := &appsv1alpha1.ConfigurationItemDetail{
+		ConfigFileParams: map[string]appsv1alpha1.ConfigParams{
+			"my.cnf": {Parameters: map[string]*string{"max_connections": pointer.String("1000"), "password": pointer.String("plain")}},
+		},
+	}
+	filter := func(string) bool { return true }
+
+	parameters := []appsv1alpha1.ParameterPair{{Key: "password", ValueFrom: valueFrom}}
+	updateParameters(item, "my.cnf", parameters, filter)
+	params := item.ConfigFileParams["my.cnf"]
+	if _, ok := params.Parameters["password"]; ok {
+		t.Errorf("expect the plain value of the secret parameter is removed: %v", params.Parameters)
+	}
+	if *params.Parameters["max_connections"] != "1000" || params.ParametersFrom["password"].SecretKeyRef.Name != "mysql-secret" {
+		t.Errorf("unexpected parameters: %+v", params)
+	}
+	if updated := fromKeyValuePair(parameters); updated["password"] != configctrl.SecretParameterMask {
+		t.Errorf("expect the value of the secret parameter is masked: %v", updated)
+	}
+
+	// the content of the file is updated with the references kept
+	updateFileContent(item, "my.cnf", "[mysqld]")
+	if len(item.ConfigFileParams["my.cnf"].ParametersFrom) != 1 {
+		t.Errorf("expect the secret parameters are kept: %+v", item.ConfigFileParams["my.cnf"])
+	}
+
+	// the secret parameter is updated with a plain value again
+	updateParameters(item, "my.cnf", []appsv1alpha1.ParameterPair{{Key: "password", Value: pointer.String("plain")}}, filter)
+	params = item.ConfigFileParams["my.cnf"]
+	if params.ParametersFrom != nil || *params.Parameters["password"] != "plain" {
+		t.Errorf("unexpected parameters: %+v", params)
+	}
+}
@@ -32,6 +32,7 @@ import (
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlcomp "github.com/apecloud/kubeblocks/pkg/controller/component"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
@@ -42,12 +43,14 @@ type restartOpsHandler struct {
 var _ OpsHandler = restartOpsHandler{}
 
 func init() {
+	restartHandler := restartOpsHandler{}
 	restartBehaviour := OpsBehaviour{
 		// if cluster is Abnormal or Failed, new opsRequest may repair it.
 		FromClusterPhases: appsv1alpha1.GetClusterUpRunningPhases(),
 		ToClusterPhase:    appsv1alpha1.UpdatingClusterPhase,
 		QueueByCluster:    true,
-		OpsHandler:        restartOpsHandler{},
+		OpsHandler:        restartHandler,
+		TerminateFunc:     restartHandler.Terminate,
 	}
 
 	opsMgr := GetOpsManager()
@@ -72,6 +75,10 @@ func (r restartOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clie
 		return err
 	}
 	r.compOpsHelper = newComponentOpsHelper(opsRes.OpsRequest.Spec.RestartList)
+	// pause the rolling update before restarting, if the proxies of the cluster can drain the connections to the instances.
+	if err := pauseForDraining(reqCtx, cli, opsRes, r.restartComponentNames(opsRes)); err != nil {
+		return err
+	}
 	componentKindList := []client.ObjectList{
 		&appv1.StatefulSetList{},
 		&workloads.InstanceSetList{},
@@ -88,15 +95,26 @@ func (r restartOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clie
 // the Reconcile function for restart opsRequest.
 func (r restartOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
 	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.RestartList)
+	drainPending := false
 	handleRestartProgress := func(reqCtx intctrlutil.RequestCtx,
 		cli client.Client,
 		opsRes *OpsResource,
 		pgRes *progressResource,
 		compStatus *appsv1alpha1.OpsRequestComponentStatus) (expectProgressCount int32, completedCount int32, err error) {
+		if !pgRes.isShardingComponent {
+			rolled, err := rollWithDraining(reqCtx, cli, opsRes, pgRes.fullComponentName, func(its *workloads.InstanceSet) bool {
+				return r.isTemplateRestarted(opsRes, &its.Spec.Template)
+			})
+			if err != nil {
+				return 0, 0, err
+			}
+			drainPending = drainPending || !rolled
+		}
 		return handleComponentStatusProgress(reqCtx, cli, opsRes, pgRes, compStatus, r.podApplyCompOps)
 	}
-	return compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes,
+	phase, requeueAfter, err := compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes,
 		"restart", handleRestartProgress)
+	return handleDrainingResult(reqCtx, cli, opsRes, r.restartComponentNames(opsRes), drainPending, phase, requeueAfter, err)
 }
 
 // SaveLastConfiguration this operation only restart the pods of the component, no changes for Cluster.spec.
@@ -105,6 +123,11 @@ func (r restartOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx,
 	return nil
 }
 
+// Terminate releases the InstanceSets paused for draining when the opsRequest times out.
+func (r restartOpsHandler) Terminate(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return terminateDraining(reqCtx, cli, opsRes, r.restartComponentNames(opsRes))
+}
+
 func (r restartOpsHandler) podApplyCompOps(
 	pod *corev1.Pod,
 	compOps ComponentOpsInteface,
@@ -113,6 +136,20 @@ func (r restartOpsHandler) podApplyCompOps(
 	return !pod.CreationTimestamp.Before(&opsStartTime)
 }
 
+func (r restartOpsHandler) restartComponentNames(opsRes *OpsResource) []string {
+	compNames := make([]string, 0, len(opsRes.OpsRequest.Spec.RestartList))
+	for _, restart := range opsRes.OpsRequest.Spec.RestartList {
+		compNames = append(compNames, restart.ComponentName)
+	}
+	return compNames
+}
+
+// isTemplateRestarted checks whether the restart annotation of the OpsRequest has been applied to the pod template.
+func (r restartOpsHandler) isTemplateRestarted(opsRes *OpsResource, podTemplate *corev1.PodTemplateSpec) bool {
+	restartTimestamp, _ := time.Parse(time.RFC3339, podTemplate.Annotations[constant.RestartAnnotationKey])
+	return !opsRes.OpsRequest.Status.StartTimestamp.After(restartTimestamp)
+}
+
 // restartStatefulSet restarts statefulSet workload
 func (r restartOpsHandler) restartComponent(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource, objList client.ObjectList) error {
 	if err := cli.List(reqCtx.Ctx, objList,
@@ -130,6 +167,10 @@ func (r restartOpsHandler) restartComponent(reqCtx intctrlutil.RequestCtx, cli c
 		if r.isRestarted(opsRes, object, template) {
 			continue
 		}
+		// the changes of the scheduling policy pending a restart are applied along with the restart.
+		if err := intctrlcomp.ApplyPendingSchedulingPolicy(object, template); err != nil {
+			return err
+		}
 		if err := cli.Update(reqCtx.Ctx, object); err != nil {
 			return err
 		}
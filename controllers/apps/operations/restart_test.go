@@ -20,12 +20,16 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/generics"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
@@ -55,6 +59,7 @@ var _ = Describe("Restart OpsRequest", func() {
 		ml := client.HasLabels{testCtx.TestObjLabelKey}
 		// namespaced
 		testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.InstanceSetSignature, true, inNS, ml)
 	}
 
 	BeforeEach(cleanEnv)
@@ -91,6 +96,41 @@ var _ = Describe("Restart OpsRequest", func() {
 			Expect(err == nil).Should(BeTrue())
 		})
 
+		It("releases the InstanceSet paused for draining when the OpsRequest times out", func() {
+			By("mock the InstanceSet is paused for draining by a running restart opsRequest")
+			opsRes.OpsRequest = createRestartOpsObj(clusterName, "restart-ops-"+randomStr)
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsRunningPhase
+			opsRes.OpsRequest.Status.StartTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+			its := testapps.MockInstanceSetComponent(&testCtx, clusterName, consensusComp)
+			Expect(testapps.GetAndChangeObj(&testCtx, client.ObjectKeyFromObject(its), func(its *workloads.InstanceSet) {
+				its.Spec.Paused = true
+				its.Annotations = map[string]string{
+					drainingOpsAnnotationKey:      opsRes.OpsRequest.Name,
+					drainingInstanceAnnotationKey: its.Name + "-0",
+				}
+			})()).Should(Succeed())
+
+			By("mock the restart OpsRequest times out")
+			opsMgr := GetOpsManager()
+			origin := opsMgr.OpsMap[appsv1alpha1.RestartType]
+			behaviour := origin
+			behaviour.TimeoutSeconds = 60
+			opsMgr.OpsMap[appsv1alpha1.RestartType] = behaviour
+			defer func() {
+				opsMgr.OpsMap[appsv1alpha1.RestartType] = origin
+			}()
+			_, err := opsMgr.Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			By("expect the OpsRequest is failed and the InstanceSet is released")
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(appsv1alpha1.OpsFailedPhase))
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(its), func(g Gomega, its *workloads.InstanceSet) {
+				g.Expect(its.Spec.Paused).Should(BeFalse())
+				g.Expect(its.Annotations).ShouldNot(HaveKey(drainingOpsAnnotationKey))
+				g.Expect(its.Annotations).ShouldNot(HaveKey(drainingInstanceAnnotationKey))
+			})).Should(Succeed())
+		})
+
 		It("expect failed when cluster is stopped", func() {
 			By("mock cluster is stopped")
 			Expect(testapps.ChangeObjStatus(&testCtx, cluster, func() {
@@ -45,7 +45,8 @@ var _ OpsHandler = RestoreOpsHandler{}
 
 func init() {
 	// register restore operation, it will create a new cluster
-	// so set IsClusterCreationEnabled to true
+	// so set IsClusterCreationEnabled to true,
+	// except that the restore with component mappings restores the data into the existing cluster.
 	restoreBehaviour := OpsBehaviour{
 		OpsHandler:        RestoreOpsHandler{},
 		IsClusterCreation: true,
@@ -66,6 +67,9 @@ func (r RestoreOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clie
 	var err error
 
 	opsRequest := opsRes.OpsRequest
+	if opsRequest.Spec.IsInPlaceRestore() {
+		return r.stopTargetComponents(reqCtx, cli, opsRes)
+	}
 
 	// restore the cluster from the backup
 	if cluster, err = r.restoreClusterFromBackup(reqCtx, cli, opsRequest); err != nil {
@@ -103,6 +107,9 @@ func (r RestoreOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clie
 // If the cluster is not running, it will update the OpsRequest status to Running.
 func (r RestoreOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
 	opsRequest := opsRes.OpsRequest
+	if opsRequest.Spec.IsInPlaceRestore() {
+		return r.reconcileInPlaceRestore(reqCtx, cli, opsRes)
+	}
 	clusterDef := opsRequest.Spec.GetClusterName()
 
 	// get cluster
@@ -128,35 +135,48 @@ func (r RestoreOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli cl
 
 // SaveLastConfiguration saves last configuration to the OpsRequest.status.lastConfiguration
 func (r RestoreOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsResource *OpsResource) error {
+	if opsResource.OpsRequest.Spec.IsInPlaceRestore() {
+		r.saveLastConfigurationForInPlaceRestore(opsResource)
+	}
 	return nil
 }
 
-func (r RestoreOpsHandler) restoreClusterFromBackup(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *appsv1alpha1.OpsRequest) (*appsv1alpha1.Cluster, error) {
-	backupName := opsRequest.Spec.GetRestore().BackupName
+// getRestoreBackup gets the backup to restore from, and returns the validated restore time for the continuous backup.
+func (r RestoreOpsHandler) getRestoreBackup(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *appsv1alpha1.OpsRequest) (*dpv1alpha1.Backup, string, error) {
+	restoreSpec := opsRequest.Spec.GetRestore()
 
 	// check if the backup exists
 	backup := &dpv1alpha1.Backup{}
 	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{
-		Name:      backupName,
+		Name:      restoreSpec.BackupName,
 		Namespace: opsRequest.Namespace,
 	}, backup); err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	// check if the backup is completed
 	backupType := backup.Labels[dptypes.BackupTypeLabelKey]
 	if backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted && backupType != string(dpv1alpha1.BackupTypeContinuous) {
-		return nil, intctrlutil.NewFatalError(fmt.Sprintf("backup %s status is %s, only completed backup can be used to restore", backupName, backup.Status.Phase))
+		return nil, "", intctrlutil.NewFatalError(fmt.Sprintf("backup %s status is %s, only completed backup can be used to restore", backup.Name, backup.Status.Phase))
 	}
 
 	// format and validate the restore time
 	if backupType == string(dpv1alpha1.BackupTypeContinuous) {
-		restoreTimeStr, err := restore.FormatRestoreTimeAndValidate(opsRequest.Spec.GetRestore().RestorePointInTime, backup)
+		restoreTimeStr, err := restore.FormatRestoreTimeAndValidate(restoreSpec.RestorePointInTime, backup)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
-		opsRequest.Spec.GetRestore().RestorePointInTime = restoreTimeStr
+		return backup, restoreTimeStr, nil
+	}
+	return backup, restoreSpec.RestorePointInTime, nil
+}
+
+func (r RestoreOpsHandler) restoreClusterFromBackup(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRequest *appsv1alpha1.OpsRequest) (*appsv1alpha1.Cluster, error) {
+	backup, restoreTime, err := r.getRestoreBackup(reqCtx, cli, opsRequest)
+	if err != nil {
+		return nil, err
 	}
+	opsRequest.Spec.GetRestore().RestorePointInTime = restoreTime
 	// get the cluster object from backup
 	clusterObj, err := r.getClusterObjFromBackup(backup, opsRequest)
 	if err != nil {
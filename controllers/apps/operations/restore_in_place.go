@@ -0,0 +1,421 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/scheduling"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+)
+
+// the stages of restoring a component in place, recorded in the reason of the component status.
+const (
+	inPlaceRestoreStoppingReason  = "StoppingComponent"
+	inPlaceRestoreRestoringReason = "RestoringData"
+	inPlaceRestoreStartingReason  = "StartingComponent"
+	inPlaceRestorePostReadyReason = "PostReadyRestoring"
+)
+
+// inPlaceRestoreHelper holds the objects to restore a target component in place.
+type inPlaceRestoreHelper struct {
+	backup      *dpv1alpha1.Backup
+	actionSet   *dpv1alpha1.ActionSet
+	restoreTime string
+	mapping     appsv1alpha1.RestoreComponentMapping
+	compSpec    *appsv1alpha1.ClusterComponentSpec
+	// the replicas of the component before it is stopped.
+	replicas int32
+}
+
+// saveLastConfigurationForInPlaceRestore records the replicas of the target components,
+// which are restored to start the components after the data is restored.
+func (r RestoreOpsHandler) saveLastConfigurationForInPlaceRestore(opsRes *OpsResource) {
+	lastConfiguration := &opsRes.OpsRequest.Status.LastConfiguration
+	lastConfiguration.Components = map[string]appsv1alpha1.LastComponentConfiguration{}
+	for _, v := range opsRes.OpsRequest.Spec.GetRestore().ComponentMappings {
+		compSpec := opsRes.Cluster.Spec.GetComponentByName(v.TargetComponentName)
+		if compSpec == nil {
+			continue
+		}
+		lastConfiguration.Components[v.TargetComponentName] = appsv1alpha1.LastComponentConfiguration{
+			Replicas: pointer.Int32(compSpec.Replicas),
+		}
+	}
+}
+
+// getInPlaceRestoreResources gets the backup and its actionSet, and checks if they can be restored in place.
+func (r RestoreOpsHandler) getInPlaceRestoreResources(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRequest *appsv1alpha1.OpsRequest) (*dpv1alpha1.Backup, *dpv1alpha1.ActionSet, string, error) {
+	backup, restoreTime, err := r.getRestoreBackup(reqCtx, cli, opsRequest)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if err = r.validateBackupForInPlaceRestore(backup, opsRequest.Spec.GetRestore().ComponentMappings); err != nil {
+		return nil, nil, "", err
+	}
+	actionSet, err := dputils.GetActionSetByName(reqCtx, cli, backup.Status.BackupMethod.ActionSetName)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if !actionSet.HasPrepareDataStage() {
+		return nil, nil, "", intctrlutil.NewFatalError(fmt.Sprintf(`the actionSet of backup "%s" has no prepareData stage, which is required to restore in place`, backup.Name))
+	}
+	return backup, actionSet, restoreTime, nil
+}
+
+// validateBackupForInPlaceRestore checks if the backup is taken by a backup tool and contains the source components.
+func (r RestoreOpsHandler) validateBackupForInPlaceRestore(backup *dpv1alpha1.Backup, mappings []appsv1alpha1.RestoreComponentMapping) error {
+	backupMethod := backup.Status.BackupMethod
+	if backupMethod == nil || backupMethod.TargetVolumes == nil {
+		return intctrlutil.NewFatalError(fmt.Sprintf(`backup "%s" has no target volumes to restore`, backup.Name))
+	}
+	// the data restored from the volume snapshots can only be provisioned to new volumes.
+	if boolptr.IsSetToTrue(backupMethod.SnapshotVolumes) {
+		return intctrlutil.NewFatalError(fmt.Sprintf(`backup "%s" is taken by volume snapshots, which is not supported to restore in place`, backup.Name))
+	}
+	for _, v := range mappings {
+		found := true
+		if len(backup.Status.Targets) > 0 {
+			found = dputils.GetBackupStatusTarget(backup, v.SourceComponentName) != nil
+		} else if compName := backup.Labels[constant.KBAppComponentLabelKey]; compName != "" {
+			found = compName == v.SourceComponentName
+		}
+		if !found {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`component "%s" not found in backup "%s"`, v.SourceComponentName, backup.Name))
+		}
+	}
+	return nil
+}
+
+// stopTargetComponents stops the target components to stop the writes before restoring the data.
+func (r RestoreOpsHandler) stopTargetComponents(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	if _, _, _, err := r.getInPlaceRestoreResources(reqCtx, cli, opsRes.OpsRequest); err != nil {
+		return err
+	}
+	for _, v := range opsRes.OpsRequest.Spec.GetRestore().ComponentMappings {
+		compSpec := opsRes.Cluster.Spec.GetComponentByName(v.TargetComponentName)
+		if compSpec == nil {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`component "%s" not found in cluster "%s"`, v.TargetComponentName, opsRes.Cluster.Name))
+		}
+		compSpec.Replicas = 0
+	}
+	return cli.Update(reqCtx.Ctx, opsRes.Cluster)
+}
+
+// reconcileInPlaceRestore restores the data of the target components and starts them again,
+// the progress of each component is recorded in the OpsRequest.status.components.
+func (r RestoreOpsHandler) reconcileInPlaceRestore(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	var (
+		opsRequest      = opsRes.OpsRequest
+		oldOpsRequest   = opsRequest.DeepCopy()
+		opsRequestPhase = opsRequest.Status.Phase
+		mappings        = opsRequest.Spec.GetRestore().ComponentMappings
+		completedCount  int
+		failedCount     int
+	)
+	backup, actionSet, restoreTime, err := r.getInPlaceRestoreResources(reqCtx, cli, opsRequest)
+	if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+		return appsv1alpha1.OpsFailedPhase, 0, err
+	} else if err != nil {
+		return opsRequestPhase, 0, err
+	}
+	if opsRequest.Status.Components == nil {
+		opsRequest.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{}
+	}
+	for _, mapping := range mappings {
+		compName := mapping.TargetComponentName
+		compStatus := opsRequest.Status.Components[compName]
+		if slices.Contains([]appsv1alpha1.ClusterComponentPhase{appsv1alpha1.RunningClusterCompPhase,
+			appsv1alpha1.FailedClusterCompPhase}, compStatus.Phase) {
+			completedCount += 1
+			if compStatus.Phase == appsv1alpha1.FailedClusterCompPhase {
+				failedCount += 1
+			}
+			continue
+		}
+		helper := &inPlaceRestoreHelper{
+			backup:      backup,
+			actionSet:   actionSet,
+			restoreTime: restoreTime,
+			mapping:     mapping,
+			compSpec:    opsRes.Cluster.Spec.GetComponentByName(compName),
+		}
+		var completed bool
+		if lastCompConfiguration, ok := opsRequest.Status.LastConfiguration.Components[compName]; !ok ||
+			lastCompConfiguration.Replicas == nil || helper.compSpec == nil {
+			err = intctrlutil.NewFatalError(fmt.Sprintf(`component "%s" not found in cluster "%s"`, compName, opsRes.Cluster.Name))
+		} else {
+			helper.replicas = *lastCompConfiguration.Replicas
+			completed, err = r.restoreComponent(reqCtx, cli, opsRes, helper, &compStatus)
+		}
+		switch {
+		case intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal):
+			// the component is left stopped, as its data may be restored partially.
+			compStatus.Phase = appsv1alpha1.FailedClusterCompPhase
+			compStatus.Message = err.Error()
+			completedCount += 1
+			failedCount += 1
+		case err != nil:
+			return opsRequestPhase, 0, err
+		case completed:
+			compStatus.Phase = appsv1alpha1.RunningClusterCompPhase
+			compStatus.Message = fmt.Sprintf(`Restore component "%s" from component "%s" of backup "%s" successfully`,
+				compName, mapping.SourceComponentName, backup.Name)
+			completedCount += 1
+		}
+		opsRequest.Status.Components[compName] = compStatus
+	}
+	if err = syncProgressToOpsRequest(reqCtx, cli, opsRes, oldOpsRequest, completedCount, len(mappings)); err != nil {
+		return opsRequestPhase, 0, err
+	}
+	if completedCount != len(mappings) {
+		return opsRequestPhase, 0, nil
+	}
+	if failedCount == 0 {
+		return appsv1alpha1.OpsSucceedPhase, 0, nil
+	}
+	return appsv1alpha1.OpsFailedPhase, 0, fmt.Errorf("failed to restore %d of %d components", failedCount, len(mappings))
+}
+
+// restoreComponent restores a target component in stages: waits for the component to be stopped, restores the data
+// into its volumes, starts it and then does the postReady restore if required.
+func (r RestoreOpsHandler) restoreComponent(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	helper *inPlaceRestoreHelper,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus) (bool, error) {
+	var (
+		cluster  = opsRes.Cluster
+		compName = helper.mapping.TargetComponentName
+	)
+	if compStatus.Reason == "" || compStatus.Reason == inPlaceRestoreStoppingReason {
+		compStatus.Phase = appsv1alpha1.StoppingClusterCompPhase
+		compStatus.Reason = inPlaceRestoreStoppingReason
+		compStatus.Message = "Waiting for the pods to be terminated"
+		podList := &corev1.PodList{}
+		if err := cli.List(reqCtx.Ctx, podList, client.InNamespace(cluster.Namespace),
+			client.MatchingLabels(constant.GetComponentWellKnownLabels(cluster.Name, compName))); err != nil {
+			return false, err
+		}
+		if len(podList.Items) > 0 {
+			return false, nil
+		}
+		compStatus.Phase = appsv1alpha1.UpdatingClusterCompPhase
+		compStatus.Reason = inPlaceRestoreRestoringReason
+	}
+	if compStatus.Reason == inPlaceRestoreRestoringReason {
+		completed, err := r.waitRestoreCompleted(reqCtx, cli, opsRes, helper, compStatus, dpv1alpha1.PrepareData)
+		if err != nil || !completed {
+			return false, err
+		}
+		// start the component with the replicas before it is stopped.
+		if helper.compSpec.Replicas != helper.replicas {
+			helper.compSpec.Replicas = helper.replicas
+			if err = cli.Update(reqCtx.Ctx, cluster); err != nil {
+				return false, err
+			}
+		}
+		compStatus.Reason = inPlaceRestoreStartingReason
+		compStatus.Message = "Waiting for the component to be running"
+		return false, nil
+	}
+	if compStatus.Reason == inPlaceRestoreStartingReason {
+		if cluster.Status.ObservedGeneration != cluster.Generation ||
+			cluster.Status.Components[compName].Phase != appsv1alpha1.RunningClusterCompPhase {
+			return false, nil
+		}
+		if !helper.actionSet.HasPostReadyStage() {
+			return true, nil
+		}
+		compStatus.Reason = inPlaceRestorePostReadyReason
+	}
+	return r.waitRestoreCompleted(reqCtx, cli, opsRes, helper, compStatus, dpv1alpha1.PostReady)
+}
+
+// waitRestoreCompleted creates the Restore of the stage if not exists, and checks if it is completed.
+func (r RestoreOpsHandler) waitRestoreCompleted(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	helper *inPlaceRestoreHelper,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus,
+	stage dpv1alpha1.RestoreStage) (bool, error) {
+	restoreName := fmt.Sprintf("%s-%s-%s", opsRes.OpsRequest.Name, helper.mapping.TargetComponentName, strings.ToLower(string(stage)))
+	compStatus.Message = fmt.Sprintf(`Waiting for %s Restore "%s" to be completed`, stage, restoreName)
+	restore := &dpv1alpha1.Restore{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: restoreName, Namespace: opsRes.OpsRequest.Namespace}, restore); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		if stage == dpv1alpha1.PostReady {
+			restore = r.buildPostReadyRestore(opsRes, helper, restoreName)
+		} else if restore, err = r.buildPrepareDataRestore(opsRes, helper, restoreName); err != nil {
+			return false, err
+		}
+		_ = intctrlutil.SetControllerReference(opsRes.OpsRequest, restore)
+		return false, client.IgnoreAlreadyExists(cli.Create(reqCtx.Ctx, restore))
+	}
+	switch restore.Status.Phase {
+	case dpv1alpha1.RestorePhaseFailed:
+		return false, intctrlutil.NewFatalError(fmt.Sprintf(`restore component "%s" failed, due to the %s Restore "%s" is Failed`,
+			helper.mapping.TargetComponentName, stage, restoreName))
+	case dpv1alpha1.RestorePhaseCompleted:
+		return true, nil
+	}
+	return false, nil
+}
+
+func (r RestoreOpsHandler) buildRestoreMetaObject(opsRes *OpsResource, compName, restoreName string) metav1.ObjectMeta {
+	labels := constant.GetComponentWellKnownLabels(opsRes.Cluster.Name, compName)
+	labels[constant.OpsRequestNameLabelKey] = opsRes.OpsRequest.Name
+	labels[constant.OpsRequestNamespaceLabelKey] = opsRes.OpsRequest.Namespace
+	return metav1.ObjectMeta{
+		Name:      restoreName,
+		Namespace: opsRes.OpsRequest.Namespace,
+		Labels:    labels,
+	}
+}
+
+// buildRestoreBackupRef builds the backup reference of the Restore, the source component is the source target of a multi-target backup.
+func (r RestoreOpsHandler) buildRestoreBackupRef(helper *inPlaceRestoreHelper) (dpv1alpha1.BackupRef, *dpv1alpha1.RequiredPolicyForAllPodSelection) {
+	backupRef := dpv1alpha1.BackupRef{
+		Name:      helper.backup.Name,
+		Namespace: helper.backup.Namespace,
+	}
+	if len(helper.backup.Status.Targets) > 0 {
+		backupRef.SourceTargetName = helper.mapping.SourceComponentName
+	}
+	var requiredPolicy *dpv1alpha1.RequiredPolicyForAllPodSelection
+	sourceTarget := dputils.GetBackupStatusTarget(helper.backup, backupRef.SourceTargetName)
+	if sourceTarget != nil && sourceTarget.PodSelector.Strategy == dpv1alpha1.PodSelectionStrategyAll {
+		requiredPolicy = &dpv1alpha1.RequiredPolicyForAllPodSelection{
+			DataRestorePolicy: dpv1alpha1.OneToOneRestorePolicy,
+		}
+	}
+	return backupRef, requiredPolicy
+}
+
+// buildPrepareDataRestore builds the Restore to restore the data into the existing volumes of the component.
+func (r RestoreOpsHandler) buildPrepareDataRestore(opsRes *OpsResource, helper *inPlaceRestoreHelper, restoreName string) (*dpv1alpha1.Restore, error) {
+	var (
+		cluster       = opsRes.Cluster
+		compName      = helper.mapping.TargetComponentName
+		targetVolumes = helper.backup.Status.BackupMethod.TargetVolumes
+		templates     []dpv1alpha1.RestoreVolumeClaim
+	)
+	for i := range helper.compSpec.VolumeClaimTemplates {
+		vct := &helper.compSpec.VolumeClaimTemplates[i]
+		sourceVolume := helper.mapping.GetSourceVolumeName(vct.Name)
+		if !dputils.ExistTargetVolume(targetVolumes, sourceVolume) {
+			continue
+		}
+		// the pvcs of the component already exist, the data is restored into them directly.
+		templates = append(templates, dpv1alpha1.RestoreVolumeClaim{
+			ObjectMeta:      metav1.ObjectMeta{Name: fmt.Sprintf("%s-%s-%s", vct.Name, cluster.Name, compName)},
+			VolumeClaimSpec: vct.Spec.ToV1PersistentVolumeClaimSpec(),
+			VolumeConfig: dpv1alpha1.VolumeConfig{
+				VolumeSource: sourceVolume,
+			},
+		})
+	}
+	if len(templates) == 0 {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf(`none of the volumes of component "%s" is found in backup "%s"`, compName, helper.backup.Name))
+	}
+	schedulingPolicy, err := scheduling.BuildSchedulingPolicy(cluster, helper.compSpec)
+	if err != nil {
+		return nil, err
+	}
+	backupRef, requiredPolicy := r.buildRestoreBackupRef(helper)
+	volumeRestorePolicy := dpv1alpha1.VolumeClaimRestorePolicy(opsRes.OpsRequest.Spec.GetRestore().VolumeRestorePolicy)
+	if volumeRestorePolicy == "" {
+		volumeRestorePolicy = dpv1alpha1.VolumeClaimRestorePolicyParallel
+	}
+	return &dpv1alpha1.Restore{
+		ObjectMeta: r.buildRestoreMetaObject(opsRes, compName, restoreName),
+		Spec: dpv1alpha1.RestoreSpec{
+			Backup:      backupRef,
+			RestoreTime: helper.restoreTime,
+			PrepareDataConfig: &dpv1alpha1.PrepareDataConfig{
+				RequiredPolicyForAllPodSelection: requiredPolicy,
+				SchedulingSpec: dpv1alpha1.SchedulingSpec{
+					Affinity:                  schedulingPolicy.Affinity,
+					Tolerations:               schedulingPolicy.Tolerations,
+					TopologySpreadConstraints: schedulingPolicy.TopologySpreadConstraints,
+				},
+				VolumeClaimRestorePolicy: volumeRestorePolicy,
+				RestoreVolumeClaimsTemplate: &dpv1alpha1.RestoreVolumeClaimsTemplate{
+					Replicas:  helper.replicas,
+					Templates: templates,
+				},
+			},
+		},
+	}, nil
+}
+
+// buildPostReadyRestore builds the Restore to do the postReady actions on the running component.
+func (r RestoreOpsHandler) buildPostReadyRestore(opsRes *OpsResource, helper *inPlaceRestoreHelper, restoreName string) *dpv1alpha1.Restore {
+	var (
+		compName     = helper.mapping.TargetComponentName
+		podSelector  = metav1.LabelSelector{MatchLabels: constant.GetComponentWellKnownLabels(opsRes.Cluster.Name, compName)}
+		backupMethod = helper.backup.Status.BackupMethod
+	)
+	backupRef, requiredPolicy := r.buildRestoreBackupRef(helper)
+	restore := &dpv1alpha1.Restore{
+		ObjectMeta: r.buildRestoreMetaObject(opsRes, compName, restoreName),
+		Spec: dpv1alpha1.RestoreSpec{
+			Backup:      backupRef,
+			RestoreTime: helper.restoreTime,
+			ReadyConfig: &dpv1alpha1.ReadyConfig{
+				ExecAction: &dpv1alpha1.ExecAction{
+					Target: dpv1alpha1.ExecActionTarget{PodSelector: podSelector},
+				},
+				JobAction: &dpv1alpha1.JobAction{
+					RequiredPolicyForAllPodSelection: requiredPolicy,
+					Target: dpv1alpha1.JobActionTarget{
+						PodSelector: dpv1alpha1.PodSelector{
+							LabelSelector: &podSelector,
+							Strategy:      dpv1alpha1.PodSelectionStrategyAny,
+						},
+						VolumeMounts: backupMethod.TargetVolumes.VolumeMounts,
+					},
+				},
+			},
+		},
+	}
+	if requiredPolicy != nil {
+		restore.Spec.ReadyConfig.JobAction.Target.PodSelector.Strategy = dpv1alpha1.PodSelectionStrategyAll
+	}
+	return restore
+}
@@ -0,0 +1,135 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+func TestValidateBackupForInPlaceRestore(t *testing.T) {
+	snapshotVolumes := true
+	backup := &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "test-backup",
+			Labels: map[string]string{constant.KBAppComponentLabelKey: "mysql"},
+		},
+		Status: dpv1alpha1.BackupStatus{
+			BackupMethod: &dpv1alpha1.BackupMethod{
+				TargetVolumes: &dpv1alpha1.TargetVolumeInfo{Volumes: []string{"data"}},
+			},
+		},
+	}
+	mappings := []appsv1alpha1.RestoreComponentMapping{{SourceComponentName: "mysql", TargetComponentName: "mysql-new"}}
+	handler := RestoreOpsHandler{}
+	if err := handler.validateBackupForInPlaceRestore(backup, mappings); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	mappings[0].SourceComponentName = "proxy"
+	if err := handler.validateBackupForInPlaceRestore(backup, mappings); !intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+		t.Errorf("expect the source component is not found, but got: %v", err)
+	}
+
+	// the source component is the target name of a multi-target backup
+	backup.Status.Targets = []dpv1alpha1.BackupStatusTarget{{BackupTarget: dpv1alpha1.BackupTarget{Name: "proxy"}}}
+	if err := handler.validateBackupForInPlaceRestore(backup, mappings); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	backup.Status.BackupMethod.SnapshotVolumes = &snapshotVolumes
+	if err := handler.validateBackupForInPlaceRestore(backup, mappings); !intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+		t.Errorf("expect the volume snapshot backup is rejected, but got: %v", err)
+	}
+}
+
+func TestBuildPrepareDataRestoreForInPlaceRestore(t *testing.T) {
+	compSpec := &appsv1alpha1.ClusterComponentSpec{
+		Name:     "mysql",
+		Replicas: 0,
+		VolumeClaimTemplates: []appsv1alpha1.ClusterComponentVolumeClaimTemplate{
+			{Name: "data"},
+			{Name: "log"},
+			{Name: "tmp"},
+		},
+	}
+	opsRes := &OpsResource{
+		Cluster: &appsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"},
+			Spec:       appsv1alpha1.ClusterSpec{ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{*compSpec}},
+		},
+		OpsRequest: &appsv1alpha1.OpsRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-ops", Namespace: "default"},
+			Spec: appsv1alpha1.OpsRequestSpec{
+				Type: appsv1alpha1.RestoreType,
+				SpecificOpsRequest: appsv1alpha1.SpecificOpsRequest{
+					Restore: &appsv1alpha1.Restore{BackupName: "test-backup"},
+				},
+			},
+		},
+	}
+	helper := &inPlaceRestoreHelper{
+		backup: &dpv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-backup", Namespace: "default"},
+			Status: dpv1alpha1.BackupStatus{
+				BackupMethod: &dpv1alpha1.BackupMethod{
+					TargetVolumes: &dpv1alpha1.TargetVolumeInfo{Volumes: []string{"data", "binlog"}},
+				},
+			},
+		},
+		mapping: appsv1alpha1.RestoreComponentMapping{
+			SourceComponentName: "mysql",
+			TargetComponentName: "mysql",
+			VolumeMappings:      []appsv1alpha1.RestoreVolumeMapping{{SourceVolumeName: "binlog", TargetVolumeName: "log"}},
+		},
+		compSpec: compSpec,
+		replicas: 3,
+	}
+	restore, err := RestoreOpsHandler{}.buildPrepareDataRestore(opsRes, helper, "test-ops-mysql-preparedata")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	claimsTemplate := restore.Spec.PrepareDataConfig.RestoreVolumeClaimsTemplate
+	if claimsTemplate.Replicas != 3 || len(claimsTemplate.Templates) != 2 {
+		t.Fatalf("unexpected claims template: %+v", claimsTemplate)
+	}
+	// the volumes not found in the backup are skipped
+	expected := map[string]string{"data-test-cluster-mysql": "data", "log-test-cluster-mysql": "binlog"}
+	for _, claim := range claimsTemplate.Templates {
+		if expected[claim.Name] != claim.VolumeSource {
+			t.Errorf("unexpected volume source %s of claim %s", claim.VolumeSource, claim.Name)
+		}
+	}
+	if restore.Labels[constant.OpsRequestNameLabelKey] != "test-ops" {
+		t.Errorf("unexpected labels: %v", restore.Labels)
+	}
+
+	helper.mapping.VolumeMappings = nil
+	helper.backup.Status.BackupMethod.TargetVolumes.Volumes = []string{"binlog"}
+	if _, err = (RestoreOpsHandler{}).buildPrepareDataRestore(opsRes, helper, "test-ops-mysql-preparedata"); !intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+		t.Errorf("expect no volume is found in the backup, but got: %v", err)
+	}
+}
@@ -0,0 +1,308 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	intctrlcomp "github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+)
+
+type rotateCredentialsOpsHandler struct{}
+
+const (
+	// rotateCredentialsRequeueInterval is the interval to retry rotating the passwords when no instance is ready,
+	// as no events are triggered when the passwords are changed.
+	rotateCredentialsRequeueInterval = 10 * time.Second
+
+	// accountProgressKind is the kind of the progress details of the rotated accounts.
+	accountProgressKind = "Account"
+)
+
+var _ OpsHandler = rotateCredentialsOpsHandler{}
+
+func init() {
+	rotateCredentialsBehaviour := OpsBehaviour{
+		FromClusterPhases: appsv1alpha1.GetClusterUpRunningPhases(),
+		QueueByCluster:    true,
+		OpsHandler:        rotateCredentialsOpsHandler{},
+	}
+
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(appsv1alpha1.RotateCredentialsType, rotateCredentialsBehaviour)
+}
+
+// ActionStartedCondition the started condition when handle the rotate credentials request.
+func (r rotateCredentialsOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return appsv1alpha1.NewRotateCredentialsCondition(opsRes.OpsRequest), nil
+}
+
+// Action generates the new passwords of the accounts and saves them into a Secret owned by the OpsRequest,
+// the passwords are applied to the engine and the Secrets of the accounts one by one in ReconcileAction.
+func (r rotateCredentialsOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	opsRequest := opsRes.OpsRequest
+	secretKey := client.ObjectKey{Namespace: opsRequest.Namespace, Name: getRotateCredentialsSecretName(opsRequest)}
+	if err := cli.Get(reqCtx.Ctx, secretKey, &corev1.Secret{}); err == nil || !apierrors.IsNotFound(err) {
+		// the passwords have been generated
+		return err
+	}
+
+	secretBuilder := builder.NewSecretBuilder(secretKey.Namespace, secretKey.Name).
+		AddLabels(constant.AppInstanceLabelKey, opsRes.Cluster.Name).
+		AddLabels(constant.OpsRequestNameLabelKey, opsRequest.Name)
+	for _, rotate := range opsRequest.Spec.RotateCredentialsList {
+		compSpec := opsRes.Cluster.Spec.GetComponentByName(rotate.ComponentName)
+		if compSpec == nil {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`component "%s" not found`, rotate.ComponentName))
+		}
+		if compSpec.ComponentDef == "" {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`the accountRotation action is not defined for the component "%s"`, rotate.ComponentName))
+		}
+		compDef := &appsv1alpha1.ComponentDefinition{}
+		if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: compSpec.ComponentDef}, compDef); err != nil {
+			return err
+		}
+		if compDef.Spec.LifecycleActions == nil || compDef.Spec.LifecycleActions.AccountRotation == nil {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`the accountRotation action is not defined for the component "%s"`, rotate.ComponentName))
+		}
+		for _, accountName := range rotate.Accounts {
+			account := getRotatedAccount(compDef, compSpec, accountName)
+			if account == nil {
+				return intctrlutil.NewFatalError(fmt.Sprintf(`system account "%s" not found in the component "%s"`, accountName, rotate.ComponentName))
+			}
+			secretBuilder.PutData(getRotatedPasswordKey(rotate.ComponentName, accountName), intctrlcomp.GenerateAccountPassword(*account))
+		}
+	}
+	secret := secretBuilder.GetObject()
+	if err := intctrlutil.SetControllerReference(opsRequest, secret); err != nil {
+		return err
+	}
+	return cli.Create(reqCtx.Ctx, secret)
+}
+
+// ReconcileAction will be performed when action is done and loops till OpsRequest.status.phase is Succeed/Failed.
+// the Reconcile function for rotate credentials opsRequest.
+func (r rotateCredentialsOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	secret := &corev1.Secret{}
+	secretKey := client.ObjectKey{Namespace: opsRes.OpsRequest.Namespace, Name: getRotateCredentialsSecretName(opsRes.OpsRequest)}
+	if err := cli.Get(reqCtx.Ctx, secretKey, secret); err != nil {
+		return "", 0, err
+	}
+	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.RotateCredentialsList)
+	rotatePending := false
+	handleRotateProgress := func(reqCtx intctrlutil.RequestCtx,
+		cli client.Client,
+		opsRes *OpsResource,
+		pgRes *progressResource,
+		compStatus *appsv1alpha1.OpsRequestComponentStatus) (int32, int32, error) {
+		// the component phase is not changed when rotating the passwords online.
+		pgRes.noWaitComponentCompleted = true
+		rotate := pgRes.compOps.(appsv1alpha1.RotateCredentials)
+		pods, err := intctrlcomp.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, opsRes.Cluster.Name, pgRes.fullComponentName)
+		if err != nil {
+			return 0, 0, err
+		}
+		pod := selectWritablePod(pgRes.componentDef, pods)
+		var completedCount int32
+		for _, accountName := range rotate.Accounts {
+			password := secret.Data[getRotatedPasswordKey(rotate.ComponentName, accountName)]
+			completed, err := r.rotateAccount(reqCtx, cli, opsRes, pgRes, compStatus, pod, accountName, password)
+			if err != nil {
+				return 0, 0, err
+			}
+			if completed {
+				completedCount += 1
+			} else {
+				rotatePending = true
+			}
+		}
+		return int32(len(rotate.Accounts)), completedCount, nil
+	}
+	phase, requeueAfter, err := compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes, "rotate the password", handleRotateProgress)
+	if err == nil && phase == appsv1alpha1.OpsRunningPhase && requeueAfter == 0 && rotatePending {
+		requeueAfter = rotateCredentialsRequeueInterval
+	}
+	return phase, requeueAfter, err
+}
+
+// SaveLastConfiguration this operation only changes the passwords of the accounts, no changes for Cluster.spec.
+// empty implementation here.
+func (r rotateCredentialsOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
+// rotateAccount changes the password of the account by the accountRotation action, and then saves it into the Secret
+// of the account. It returns true if the password is rotated or failed to rotate, the account is retried in the next
+// reconciliation if no instance is ready.
+func (r rotateCredentialsOpsHandler) rotateAccount(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	pgRes *progressResource,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus,
+	pod *corev1.Pod,
+	accountName string,
+	password []byte) (bool, error) {
+	objectKey := getProgressObjectKey(accountProgressKind, accountName)
+	progressDetail := findStatusProgressDetail(compStatus.ProgressDetails, objectKey)
+	if progressDetail != nil && isCompletedProgressStatus(progressDetail.Status) {
+		return true, nil
+	}
+	compName := pgRes.compOps.GetComponentName()
+	newProgressDetail := appsv1alpha1.ProgressStatusDetail{ObjectKey: objectKey}
+	if len(password) == 0 {
+		newProgressDetail.Status = appsv1alpha1.FailedProgressStatus
+		newProgressDetail.Message = getProgressFailedMessage(pgRes.opsMessageKey, objectKey, compName, "the new password is not generated")
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, newProgressDetail)
+		return true, nil
+	}
+	// the password is applied to the engine and saved into the Secret of the account, but the progress is not updated.
+	rotated, err := r.isAccountSecretUpdated(reqCtx, cli, opsRes.Cluster, compName, accountName, password)
+	if err != nil {
+		return false, err
+	}
+	if !rotated {
+		if pod == nil {
+			newProgressDetail.Status = appsv1alpha1.PendingProgressStatus
+			newProgressDetail.Message = fmt.Sprintf("Waiting for an instance to be ready to %s: %s in Component: %s", pgRes.opsMessageKey, objectKey, compName)
+			setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, newProgressDetail)
+			return false, nil
+		}
+		lorryCli, err := lorry.NewClient(*pod)
+		if err != nil || intctrlutil.IsNil(lorryCli) {
+			reqCtx.Log.Info("failed to get the lorry client of the pod", "pod", pod.Name, "error", err)
+			return false, nil
+		}
+		// the secret of the account is left unchanged if the password is not changed at the engine level.
+		if err = lorryCli.RotateCredential(reqCtx.Ctx, accountName, string(password)); err != nil {
+			newProgressDetail.Status = appsv1alpha1.FailedProgressStatus
+			newProgressDetail.Message = getProgressFailedMessage(pgRes.opsMessageKey, objectKey, compName, err.Error())
+			setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, newProgressDetail)
+			return true, nil
+		}
+		if err = r.updateAccountSecret(reqCtx, cli, opsRes.Cluster, compName, accountName, password); err != nil {
+			return false, err
+		}
+	}
+	newProgressDetail.Status = appsv1alpha1.SucceedProgressStatus
+	newProgressDetail.Message = getProgressSucceedMessage(pgRes.opsMessageKey, objectKey, compName)
+	setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, newProgressDetail)
+	return true, nil
+}
+
+// isAccountSecretUpdated checks whether the Secret of the account has been updated with the new password.
+func (r rotateCredentialsOpsHandler) isAccountSecretUpdated(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	cluster *appsv1alpha1.Cluster, compName, accountName string, password []byte) (bool, error) {
+	secret := &corev1.Secret{}
+	secretKey := client.ObjectKey{Namespace: cluster.Namespace, Name: constant.GenerateAccountSecretName(cluster.Name, compName, accountName)}
+	if err := cli.Get(reqCtx.Ctx, secretKey, secret); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return bytes.Equal(secret.Data[constant.AccountPasswdForSecret], password), nil
+}
+
+// updateAccountSecret saves the new password into the Secret of the account.
+// The Secret is immutable, so it is deleted and created again with the new password.
+func (r rotateCredentialsOpsHandler) updateAccountSecret(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	cluster *appsv1alpha1.Cluster, compName, accountName string, password []byte) error {
+	comp := &appsv1alpha1.Component{}
+	compKey := client.ObjectKey{Namespace: cluster.Namespace, Name: constant.GenerateClusterComponentName(cluster.Name, compName)}
+	if err := cli.Get(reqCtx.Ctx, compKey, comp); err != nil {
+		return err
+	}
+	secret := builder.NewSecretBuilder(cluster.Namespace, constant.GenerateAccountSecretName(cluster.Name, compName, accountName)).
+		AddLabelsInMap(constant.GetComponentWellKnownLabels(cluster.Name, compName)).
+		AddLabels(constant.ClusterAccountLabelKey, accountName).
+		PutData(constant.AccountNameForSecret, []byte(accountName)).
+		PutData(constant.AccountPasswdForSecret, password).
+		SetImmutable(true).
+		GetObject()
+	if err := intctrlutil.SetControllerReference(comp, secret); err != nil {
+		return err
+	}
+	if err := cli.Delete(reqCtx.Ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	// the Secret may be created again by the component controller with a random password in the meantime,
+	// it is replaced in the next reconciliation in that case.
+	return cli.Create(reqCtx.Ctx, secret)
+}
+
+// getRotatedAccount gets the system account defined in the ComponentDefinition, with the password generation policy
+// overridden by the Component. The seed of the policy is dropped to generate a new password.
+func getRotatedAccount(compDef *appsv1alpha1.ComponentDefinition,
+	compSpec *appsv1alpha1.ClusterComponentSpec, accountName string) *appsv1alpha1.SystemAccount {
+	for i := range compDef.Spec.SystemAccounts {
+		if compDef.Spec.SystemAccounts[i].Name != accountName {
+			continue
+		}
+		account := compDef.Spec.SystemAccounts[i].DeepCopy()
+		for _, compAccount := range compSpec.SystemAccounts {
+			if compAccount.Name == accountName && compAccount.PasswordConfig != nil {
+				account.PasswordGenerationPolicy = *compAccount.PasswordConfig
+			}
+		}
+		account.PasswordGenerationPolicy.Seed = ""
+		return account
+	}
+	return nil
+}
+
+// selectWritablePod selects a ready instance to invoke the actions that change or inspect the data, such as accountRotation.
+// The instance with a writable role is required if the ComponentDefinition defines one, as the changes are replicated from it
+// in most engines.
+func selectWritablePod(compDef *appsv1alpha1.ComponentDefinition, pods []*corev1.Pod) *corev1.Pod {
+	writableRoles := map[string]bool{}
+	if compDef != nil {
+		for _, role := range compDef.Spec.Roles {
+			if role.Writable {
+				writableRoles[role.Name] = true
+			}
+		}
+	}
+	for _, pod := range pods {
+		if !intctrlutil.PodIsReady(pod) {
+			continue
+		}
+		if len(writableRoles) == 0 || writableRoles[pod.Labels[constant.RoleLabelKey]] {
+			return pod
+		}
+	}
+	return nil
+}
+
+func getRotateCredentialsSecretName(opsRequest *appsv1alpha1.OpsRequest) string {
+	return fmt.Sprintf("%s-credentials", opsRequest.Name)
+}
+
+func getRotatedPasswordKey(compName, accountName string) string {
+	return fmt.Sprintf("%s.%s", compName, accountName)
+}
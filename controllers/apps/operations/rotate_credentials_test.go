@@ -0,0 +1,88 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("RotateCredentials OpsRequest", func() {
+	var compDefName = "compdef-for-rotate-credentials-" + testCtx.GetRandomStr()
+
+	It("gets the account to rotate with the password config of the component", func() {
+		compDef := testapps.NewComponentDefinitionFactory(compDefName).GetObject()
+		compDef.Spec.SystemAccounts = []appsv1alpha1.SystemAccount{
+			{
+				Name:                     "root",
+				PasswordGenerationPolicy: appsv1alpha1.PasswordConfig{Length: 16, Seed: "seed"},
+			},
+		}
+		compSpec := &appsv1alpha1.ClusterComponentSpec{
+			SystemAccounts: []appsv1alpha1.ComponentSystemAccount{
+				{Name: "root", PasswordConfig: &appsv1alpha1.PasswordConfig{Length: 32, Seed: "seed"}},
+			},
+		}
+		Expect(getRotatedAccount(compDef, compSpec, "admin")).Should(BeNil())
+
+		account := getRotatedAccount(compDef, compSpec, "root")
+		Expect(account).ShouldNot(BeNil())
+		Expect(account.PasswordGenerationPolicy.Length).Should(BeEquivalentTo(32))
+		Expect(account.PasswordGenerationPolicy.Seed).Should(BeEmpty())
+
+		By("expect the ComponentDefinition is not changed")
+		Expect(compDef.Spec.SystemAccounts[0].PasswordGenerationPolicy.Seed).Should(Equal("seed"))
+	})
+
+	It("selects the ready writable instance to rotate the password", func() {
+		newPod := func(name, role string, ready bool) *corev1.Pod {
+			pod := testapps.NewPodFactory(testCtx.DefaultNamespace, name).AddRoleLabel(role).GetObject()
+			if ready {
+				pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+			}
+			return pod
+		}
+		pods := []*corev1.Pod{
+			newPod("test-mysql-0", "secondary", true),
+			newPod("test-mysql-1", "primary", false),
+			newPod("test-mysql-2", "primary", true),
+		}
+
+		By("expect any ready instance is selected if no role is defined")
+		compDef := testapps.NewComponentDefinitionFactory(compDefName).GetObject()
+		pod := selectWritablePod(compDef, pods)
+		Expect(pod).ShouldNot(BeNil())
+		Expect(pod.Name).Should(Equal("test-mysql-0"))
+
+		By("expect the ready writable instance is selected")
+		compDef.Spec.Roles = []appsv1alpha1.ReplicaRole{
+			{Name: "primary", Serviceable: true, Writable: true},
+			{Name: "secondary", Serviceable: true},
+		}
+		pod = selectWritablePod(compDef, pods)
+		Expect(pod).ShouldNot(BeNil())
+		Expect(pod.Name).Should(Equal("test-mysql-2"))
+		Expect(selectWritablePod(compDef, pods[:2])).Should(BeNil())
+	})
+})
@@ -0,0 +1,288 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/plan"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+)
+
+type rotateTLSOpsHandler struct{}
+
+// reloadTLSRequeueInterval is the interval to retry reloading the certificates,
+// as the renewed certificates are propagated to the instances by kubelet periodically.
+const reloadTLSRequeueInterval = 10 * time.Second
+
+var _ OpsHandler = rotateTLSOpsHandler{}
+
+func init() {
+	rotateTLSBehaviour := OpsBehaviour{
+		FromClusterPhases: appsv1alpha1.GetClusterUpRunningPhases(),
+		ToClusterPhase:    appsv1alpha1.UpdatingClusterPhase,
+		QueueByCluster:    true,
+		OpsHandler:        rotateTLSOpsHandler{},
+	}
+
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(appsv1alpha1.RotateTLSType, rotateTLSBehaviour)
+}
+
+// ActionStartedCondition the started condition when handle the rotateTLS request.
+func (r rotateTLSOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return appsv1alpha1.NewRotateTLSCondition(opsRes.OpsRequest), nil
+}
+
+// Action renews the TLS certificates of the components, and restarts the components
+// whose ComponentDefinition does not provide the reloadTLS action.
+// For a sharding, the certificates of all its shards are renewed.
+func (r rotateTLSOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	if opsRes.OpsRequest.Status.StartTimestamp.IsZero() {
+		return fmt.Errorf("status.startTimestamp can not be null")
+	}
+	for _, compOps := range opsRes.OpsRequest.Spec.RotateTLSList {
+		compSpec := getComponentSpecOrShardingTemplate(opsRes.Cluster, compOps.ComponentName)
+		if compSpec == nil || !compSpec.TLS {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`TLS is not enabled for the component "%s"`, compOps.ComponentName))
+		}
+		compDef, err := r.getComponentDefinition(reqCtx, cli, compSpec)
+		if err != nil {
+			return err
+		}
+		compNames, err := getFullComponentNames(reqCtx, cli, opsRes, compOps.ComponentName)
+		if err != nil {
+			return err
+		}
+		reloadable := compDef != nil && compDef.Spec.LifecycleActions != nil && compDef.Spec.LifecycleActions.ReloadTLS != nil
+		for _, compName := range compNames {
+			if err = r.renewTLSCertificate(reqCtx, cli, opsRes, compSpec, compName); err != nil {
+				return err
+			}
+			if reloadable {
+				continue
+			}
+			if err = r.restartComponent(reqCtx, cli, opsRes, compName); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getFullComponentNames returns the names of the shards if the component is a sharding,
+// otherwise the name of the component itself.
+func getFullComponentNames(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	compName string) ([]string, error) {
+	if opsRes.Cluster.Spec.GetShardingByName(compName) == nil {
+		return []string{compName}, nil
+	}
+	shardingComps, err := intctrlutil.ListShardingComponents(reqCtx.Ctx, cli, opsRes.Cluster, compName)
+	if err != nil {
+		return nil, err
+	}
+	compNames := make([]string, 0, len(shardingComps))
+	for _, comp := range shardingComps {
+		compNames = append(compNames, comp.Labels[constant.KBAppComponentLabelKey])
+	}
+	return compNames, nil
+}
+
+// ReconcileAction will be performed when action is done and loops till OpsRequest.status.phase is Succeed/Failed.
+// the Reconcile function for rotateTLS opsRequest.
+func (r rotateTLSOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.RotateTLSList)
+	pending := false
+	handleRotateTLSProgress := func(reqCtx intctrlutil.RequestCtx,
+		cli client.Client,
+		opsRes *OpsResource,
+		pgRes *progressResource,
+		compStatus *appsv1alpha1.OpsRequestComponentStatus) (expectProgressCount int32, completedCount int32, err error) {
+		certHash, err := r.getCertificateHash(reqCtx, cli, opsRes, pgRes.clusterComponent, pgRes.fullComponentName)
+		if err != nil {
+			return 0, 0, err
+		}
+		// the instances are completed only after the renewed certificate is confirmed to be mounted in them.
+		verifier := newMountPropagationVerifier(filepath.Join(constant.MountPath, constant.CertName), certHash)
+		defer verifier.report(opsRes, compStatus)
+		compDef := pgRes.componentDef
+		if compDef == nil || compDef.Spec.LifecycleActions == nil || compDef.Spec.LifecycleActions.ReloadTLS == nil {
+			podRestarted := func(pod *corev1.Pod, compOps ComponentOpsInteface, opsStartTime metav1.Time, insTemplateName string) bool {
+				return r.podRestarted(pod, compOps, opsStartTime, insTemplateName) && verifier.verify(reqCtx, pod, compStatus)
+			}
+			expectProgressCount, completedCount, err = handleComponentStatusProgress(reqCtx, cli, opsRes, pgRes, compStatus, podRestarted)
+			pending = pending || len(verifier.stragglers) > 0
+			return expectProgressCount, completedCount, err
+		}
+		// the component phase is not changed when reloading the certificates online.
+		pgRes.noWaitComponentCompleted = true
+		podReloaded := func(pod *corev1.Pod, compOps ComponentOpsInteface, opsStartTime metav1.Time, insTemplateName string) bool {
+			return verifier.verify(reqCtx, pod, compStatus) && r.reloadPodTLS(reqCtx, pod, compStatus, certHash)
+		}
+		expectProgressCount, completedCount, err = handleComponentStatusProgress(reqCtx, cli, opsRes, pgRes, compStatus, podReloaded)
+		pending = pending || completedCount < expectProgressCount
+		return expectProgressCount, completedCount, err
+	}
+	phase, requeueAfter, err := compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes, "rotate TLS", handleRotateTLSProgress)
+	if err == nil && phase == appsv1alpha1.OpsRunningPhase && requeueAfter == 0 && pending {
+		// no events are triggered when the certificates are propagated or reloaded, retry the pending instances periodically.
+		requeueAfter = reloadTLSRequeueInterval
+	}
+	return phase, requeueAfter, err
+}
+
+// SaveLastConfiguration this operation only renews the certificates of the component, no changes for Cluster.spec.
+// empty implementation here.
+func (r rotateTLSOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
+// renewTLSCertificate renews the certificates issued by KubeBlocks, the certificates provided by the user
+// are expected to be renewed in the referenced secret before the OpsRequest is created.
+func (r rotateTLSOpsHandler) renewTLSCertificate(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	compSpec *appsv1alpha1.ClusterComponentSpec,
+	compName string) error {
+	if compSpec.Issuer != nil && compSpec.Issuer.Name == appsv1alpha1.IssuerUserProvided {
+		return nil
+	}
+	secret := &corev1.Secret{}
+	secretKey := client.ObjectKey{Namespace: opsRes.Cluster.Namespace, Name: plan.GenerateTLSSecretName(opsRes.Cluster.Name, compName)}
+	if err := cli.Get(reqCtx.Ctx, secretKey, secret); err != nil {
+		return err
+	}
+	if secret.Annotations[constant.TLSRotatedByOpsAnnotationKey] == opsRes.OpsRequest.Name {
+		// the certificates have been renewed by this OpsRequest.
+		return nil
+	}
+	renewedSecret, err := plan.ComposeTLSSecret(secret.Namespace, opsRes.Cluster.Name, compName)
+	if err != nil {
+		return err
+	}
+	patch := client.MergeFrom(secret.DeepCopy())
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[constant.TLSRotatedByOpsAnnotationKey] = opsRes.OpsRequest.Name
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	for k, v := range renewedSecret.StringData {
+		secret.Data[k] = []byte(v)
+	}
+	secret.StringData = nil
+	return cli.Patch(reqCtx.Ctx, secret, patch)
+}
+
+func (r rotateTLSOpsHandler) getComponentDefinition(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	compSpec *appsv1alpha1.ClusterComponentSpec) (*appsv1alpha1.ComponentDefinition, error) {
+	if compSpec.ComponentDef == "" {
+		return nil, nil
+	}
+	compDef := &appsv1alpha1.ComponentDefinition{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: compSpec.ComponentDef}, compDef); err != nil {
+		return nil, err
+	}
+	return compDef, nil
+}
+
+// restartComponent restarts the instances of the component to load the renewed certificates.
+func (r rotateTLSOpsHandler) restartComponent(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource, compName string) error {
+	its := &workloads.InstanceSet{}
+	itsKey := client.ObjectKey{Namespace: opsRes.Cluster.Namespace, Name: constant.GenerateWorkloadNamePattern(opsRes.Cluster.Name, compName)}
+	if err := cli.Get(reqCtx.Ctx, itsKey, its); err != nil {
+		return err
+	}
+	startTimestamp := opsRes.OpsRequest.Status.StartTimestamp
+	restartTimestamp := its.Spec.Template.Annotations[constant.RestartAnnotationKey]
+	if res, _ := time.Parse(time.RFC3339, restartTimestamp); !startTimestamp.After(res) {
+		return nil
+	}
+	if its.Spec.Template.Annotations == nil {
+		its.Spec.Template.Annotations = map[string]string{}
+	}
+	its.Spec.Template.Annotations[constant.RestartAnnotationKey] = startTimestamp.Format(time.RFC3339)
+	return cli.Update(reqCtx.Ctx, its)
+}
+
+func (r rotateTLSOpsHandler) podRestarted(pod *corev1.Pod,
+	compOps ComponentOpsInteface,
+	opsStartTime metav1.Time,
+	insTemplateName string) bool {
+	return !pod.CreationTimestamp.Before(&opsStartTime)
+}
+
+// getCertificateHash returns the hash of the certificate used by the component,
+// it is used to check whether the renewed certificate has been propagated to the instances.
+func (r rotateTLSOpsHandler) getCertificateHash(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	compSpec *appsv1alpha1.ClusterComponentSpec,
+	compName string) (string, error) {
+	secretName, certKey := plan.GetTLSCertSecretKey(opsRes.Cluster.Name, compName, compSpec.Issuer)
+	secret := &corev1.Secret{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Namespace: opsRes.Cluster.Namespace, Name: secretName}, secret); err != nil {
+		return "", err
+	}
+	return getTLSCertificateHash(plan.GetTLSSecretValue(secret, certKey)), nil
+}
+
+// reloadPodTLS reloads the renewed certificates into the instance, the failed instances will be retried in the next reconciliation.
+func (r rotateTLSOpsHandler) reloadPodTLS(reqCtx intctrlutil.RequestCtx,
+	pod *corev1.Pod,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus,
+	certHash string) bool {
+	objectKey := getProgressObjectKey(constant.PodKind, pod.Name)
+	progressDetail := findStatusProgressDetail(compStatus.ProgressDetails, objectKey)
+	if progressDetail != nil && progressDetail.Status == appsv1alpha1.SucceedProgressStatus {
+		return true
+	}
+	lorryCli, err := lorry.NewClient(*pod)
+	if err != nil || intctrlutil.IsNil(lorryCli) {
+		reqCtx.Log.Info("failed to get the lorry client of the pod", "pod", pod.Name, "error", err)
+		return false
+	}
+	if err = lorryCli.ReloadTLS(reqCtx.Ctx, certHash); err != nil {
+		reqCtx.Log.Info("failed to reload the TLS certificates", "pod", pod.Name, "error", err.Error())
+		return false
+	}
+	return true
+}
+
+func getTLSCertificateHash(cert []byte) string {
+	hash := sha256.Sum256(cert)
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,114 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("RotateTLS OpsRequest", func() {
+	var (
+		randomStr   = testCtx.GetRandomStr()
+		clusterName = "cluster-for-ops-" + randomStr
+		compDefName = "compdef-for-rotate-tls-" + randomStr
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		// delete cluster(and all dependent sub-resources), clusterversion and clusterdef
+		testapps.ClearClusterResources(&testCtx)
+
+		// delete rest resources
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		// namespaced
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ComponentSignature, true, inNS, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(cleanEnv)
+
+	It("gets the full names of the components and the shards", func() {
+		cluster := testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, "", "").
+			AddComponentV2(statelessComp, compDefName).
+			AddShardingSpecV2("shard", compDefName).SetShards(2).
+			GetObject()
+		for _, shardName := range []string{"shard-abc", "shard-def"} {
+			testapps.NewComponentFactory(testCtx.DefaultNamespace, constant.GenerateClusterComponentName(clusterName, shardName), compDefName).
+				AddLabels(constant.AppInstanceLabelKey, clusterName,
+					constant.KBAppShardingNameLabelKey, "shard",
+					constant.KBAppComponentLabelKey, shardName).
+				Create(&testCtx)
+		}
+		reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx, Log: logf.FromContext(testCtx.Ctx)}
+		opsRes := &OpsResource{Cluster: cluster}
+
+		compNames, err := getFullComponentNames(reqCtx, k8sClient, opsRes, statelessComp)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(compNames).Should(Equal([]string{statelessComp}))
+
+		compNames, err = getFullComponentNames(reqCtx, k8sClient, opsRes, "shard")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(compNames).Should(ConsistOf("shard-abc", "shard-def"))
+	})
+
+	It("reloads the certificates of the instance by the reloadTLS action", func() {
+		var (
+			reqCtx     = intctrlutil.RequestCtx{Ctx: testCtx.Ctx, Log: logf.FromContext(testCtx.Ctx)}
+			pod        = testapps.NewPodFactory(testCtx.DefaultNamespace, "test-mysql-0").GetObject()
+			compStatus = &appsv1alpha1.OpsRequestComponentStatus{}
+			certHash   = getTLSCertificateHash([]byte("cert"))
+			handler    = rotateTLSOpsHandler{}
+			recorder   = mockLorryClient()
+		)
+
+		By("expect the instance is retried if the renewed certificate has not been propagated to it yet")
+		recorder.ReloadTLS(gomock.Any(), certHash).Return(errors.New("not propagated")).Times(1)
+		Expect(handler.reloadPodTLS(reqCtx, pod, compStatus, certHash)).Should(BeFalse())
+
+		recorder.ReloadTLS(gomock.Any(), certHash).Return(nil).Times(1)
+		Expect(handler.reloadPodTLS(reqCtx, pod, compStatus, certHash)).Should(BeTrue())
+
+		By("expect the succeed instance is not reloaded again")
+		compStatus.ProgressDetails = []appsv1alpha1.ProgressStatusDetail{{
+			ObjectKey: getProgressObjectKey(constant.PodKind, pod.Name),
+			Status:    appsv1alpha1.SucceedProgressStatus,
+		}}
+		Expect(handler.reloadPodTLS(reqCtx, pod, compStatus, certHash)).Should(BeTrue())
+	})
+})
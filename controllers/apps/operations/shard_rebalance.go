@@ -0,0 +1,179 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	intctrlcomp "github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+)
+
+type shardRebalanceOpsHandler struct{}
+
+const (
+	// shardRebalanceRequeueInterval is the interval to retry rebalancing the shards which have no ready instance,
+	// as no events are triggered when the data is moved.
+	shardRebalanceRequeueInterval = 10 * time.Second
+
+	// shardProgressKind is the kind of the progress details of the rebalanced shards.
+	shardProgressKind = "Shard"
+)
+
+var _ OpsHandler = shardRebalanceOpsHandler{}
+
+func init() {
+	shardRebalanceBehaviour := OpsBehaviour{
+		FromClusterPhases: appsv1alpha1.GetClusterUpRunningPhases(),
+		QueueByCluster:    true,
+		OpsHandler:        shardRebalanceOpsHandler{},
+	}
+
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(appsv1alpha1.ShardRebalanceType, shardRebalanceBehaviour)
+}
+
+// ActionStartedCondition the started condition when handle the shard rebalance request.
+func (s shardRebalanceOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return appsv1alpha1.NewShardRebalanceCondition(opsRes.OpsRequest), nil
+}
+
+// Action checks whether the ComponentDefinitions of the shardings provide the shardRebalance action,
+// the shards are rebalanced one by one in ReconcileAction.
+func (s shardRebalanceOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	for _, compOps := range opsRes.OpsRequest.Spec.ShardRebalanceList {
+		shardingSpec := opsRes.Cluster.Spec.GetShardingByName(compOps.ComponentName)
+		if shardingSpec == nil {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`sharding "%s" not found`, compOps.ComponentName))
+		}
+		if shardingSpec.Template.ComponentDef == "" {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`the shardRebalance action is not defined for the sharding "%s"`, compOps.ComponentName))
+		}
+		compDef := &appsv1alpha1.ComponentDefinition{}
+		if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: shardingSpec.Template.ComponentDef}, compDef); err != nil {
+			return err
+		}
+		if compDef.Spec.LifecycleActions == nil || compDef.Spec.LifecycleActions.ShardRebalance == nil {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`the shardRebalance action is not defined for the sharding "%s"`, compOps.ComponentName))
+		}
+	}
+	return nil
+}
+
+// ReconcileAction will be performed when action is done and loops till OpsRequest.status.phase is Succeed/Failed.
+// the Reconcile function for shard rebalance opsRequest.
+func (s shardRebalanceOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.ShardRebalanceList)
+	rebalancePending := false
+	shardsOfSharding := map[string][]string{}
+	handleRebalanceProgress := func(reqCtx intctrlutil.RequestCtx,
+		cli client.Client,
+		opsRes *OpsResource,
+		pgRes *progressResource,
+		compStatus *appsv1alpha1.OpsRequestComponentStatus) (int32, int32, error) {
+		// the component phase is not changed when rebalancing the shards online.
+		pgRes.noWaitComponentCompleted = true
+		shardingName := pgRes.compOps.GetComponentName()
+		shards, ok := shardsOfSharding[shardingName]
+		if !ok {
+			var err error
+			if shards, err = getFullComponentNames(reqCtx, cli, opsRes, shardingName); err != nil {
+				return 0, 0, err
+			}
+			slices.Sort(shards)
+			shardsOfSharding[shardingName] = shards
+		}
+		pods, err := intctrlcomp.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, opsRes.Cluster.Name, pgRes.fullComponentName)
+		if err != nil {
+			return 0, 0, err
+		}
+		pod := selectWritablePod(pgRes.componentDef, pods)
+		if !s.rebalanceShard(reqCtx, opsRes, pgRes, compStatus, pod, shards) {
+			rebalancePending = true
+			return 1, 0, nil
+		}
+		return 1, 1, nil
+	}
+	phase, requeueAfter, err := compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes, "rebalance", handleRebalanceProgress)
+	if err == nil && phase == appsv1alpha1.OpsRunningPhase && requeueAfter == 0 && rebalancePending {
+		requeueAfter = shardRebalanceRequeueInterval
+	}
+	return phase, requeueAfter, err
+}
+
+// SaveLastConfiguration this operation only moves the data between the shards, no changes for Cluster.spec.
+// empty implementation here.
+func (s shardRebalanceOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
+// rebalanceShard invokes the shardRebalance action in the instance of the shard and records the moved data, it returns
+// true if the shard is rebalanced or failed to rebalance. The shard will be retried in the next reconciliation
+// if no instance is ready.
+func (s shardRebalanceOpsHandler) rebalanceShard(reqCtx intctrlutil.RequestCtx,
+	opsRes *OpsResource,
+	pgRes *progressResource,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus,
+	pod *corev1.Pod,
+	shards []string) bool {
+	objectKey := getProgressObjectKey(shardProgressKind, pgRes.fullComponentName)
+	progressDetail := findStatusProgressDetail(compStatus.ProgressDetails, objectKey)
+	if progressDetail != nil && isCompletedProgressStatus(progressDetail.Status) {
+		return true
+	}
+	compName := pgRes.compOps.GetComponentName()
+	newProgressDetail := appsv1alpha1.ProgressStatusDetail{ObjectKey: objectKey}
+	if pod == nil {
+		newProgressDetail.Status = appsv1alpha1.PendingProgressStatus
+		newProgressDetail.Message = fmt.Sprintf("Waiting for an instance to be ready to %s: %s in Component: %s", pgRes.opsMessageKey, objectKey, compName)
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, newProgressDetail)
+		return false
+	}
+	lorryCli, err := lorry.NewClient(*pod)
+	if err != nil || intctrlutil.IsNil(lorryCli) {
+		reqCtx.Log.Info("failed to get the lorry client of the pod", "pod", pod.Name, "error", err)
+		return false
+	}
+	movedBytes, err := lorryCli.ShardRebalance(reqCtx.Ctx, pgRes.fullComponentName, shards)
+	if err != nil {
+		newProgressDetail.Status = appsv1alpha1.FailedProgressStatus
+		newProgressDetail.Message = getProgressFailedMessage(pgRes.opsMessageKey, objectKey, compName, err.Error())
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, newProgressDetail)
+		return true
+	}
+	movedData := resource.NewQuantity(movedBytes, resource.BinarySI)
+	newProgressDetail.Status = appsv1alpha1.SucceedProgressStatus
+	newProgressDetail.Message = fmt.Sprintf("%s, moved data: %s", getProgressSucceedMessage(pgRes.opsMessageKey, objectKey, compName), movedData.String())
+	setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, newProgressDetail)
+	if compStatus.MovedData == nil {
+		compStatus.MovedData = resource.NewQuantity(0, resource.BinarySI)
+	}
+	compStatus.MovedData.Add(*movedData)
+	return true
+}
@@ -0,0 +1,80 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("ShardRebalance OpsRequest", func() {
+	It("rebalances the shards by the shardRebalance action", func() {
+		var (
+			reqCtx = intctrlutil.RequestCtx{Ctx: testCtx.Ctx, Log: logf.FromContext(testCtx.Ctx)}
+			opsRes = &OpsResource{
+				OpsRequest: testapps.NewOpsRequestObj("shard-rebalance-ops", testCtx.DefaultNamespace, "test", appsv1alpha1.ShardRebalanceType),
+				Recorder:   k8sManager.GetEventRecorderFor("opsrequest-controller"),
+			}
+			shards     = []string{"shard-a", "shard-b", "shard-c"}
+			compOps    = appsv1alpha1.ComponentOps{ComponentName: "shard"}
+			compStatus = &appsv1alpha1.OpsRequestComponentStatus{}
+			handler    = shardRebalanceOpsHandler{}
+			pgRes      = func(shardName string) *progressResource {
+				return &progressResource{opsMessageKey: "rebalance", compOps: compOps, fullComponentName: shardName, isShardingComponent: true}
+			}
+			pod      = testapps.NewPodFactory(testCtx.DefaultNamespace, "test-shard-a-0").GetObject()
+			recorder = mockLorryClient()
+		)
+
+		By("expect the shard without ready instances is retried later")
+		Expect(handler.rebalanceShard(reqCtx, opsRes, pgRes("shard-c"), compStatus, nil, shards)).Should(BeFalse())
+
+		recorder.ShardRebalance(gomock.Any(), "shard-a", shards).Return(int64(2<<20), nil).Times(1)
+		Expect(handler.rebalanceShard(reqCtx, opsRes, pgRes("shard-a"), compStatus, pod, shards)).Should(BeTrue())
+
+		By("expect the completed shard is not rebalanced again")
+		Expect(handler.rebalanceShard(reqCtx, opsRes, pgRes("shard-a"), compStatus, pod, shards)).Should(BeTrue())
+
+		By("expect the failed shard is completed")
+		recorder.ShardRebalance(gomock.Any(), "shard-b", shards).Return(int64(0), errors.New("slots migrating")).Times(1)
+		Expect(handler.rebalanceShard(reqCtx, opsRes, pgRes("shard-b"), compStatus, pod, shards)).Should(BeTrue())
+
+		expectedStatus := map[string]appsv1alpha1.ProgressStatus{
+			"shard-a": appsv1alpha1.SucceedProgressStatus,
+			"shard-b": appsv1alpha1.FailedProgressStatus,
+			"shard-c": appsv1alpha1.PendingProgressStatus,
+		}
+		for shardName, status := range expectedStatus {
+			progressDetail := findStatusProgressDetail(compStatus.ProgressDetails, getProgressObjectKey(shardProgressKind, shardName))
+			Expect(progressDetail).ShouldNot(BeNil())
+			Expect(progressDetail.Status).Should(Equal(status))
+		}
+		Expect(compStatus.MovedData).ShouldNot(BeNil())
+		Expect(compStatus.MovedData.String()).Should(Equal("2Mi"))
+	})
+})
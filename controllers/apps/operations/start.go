@@ -24,6 +24,7 @@ import (
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -38,9 +39,11 @@ var _ OpsHandler = StartOpsHandler{}
 
 func init() {
 	stopBehaviour := OpsBehaviour{
-		FromClusterPhases: []appsv1alpha1.ClusterPhase{appsv1alpha1.StoppedClusterPhase},
+		// the components stopped by a component-scoped Stop OpsRequest can be started in a running cluster.
+		FromClusterPhases: append([]appsv1alpha1.ClusterPhase{appsv1alpha1.StoppedClusterPhase}, appsv1alpha1.GetClusterUpRunningPhases()...),
 		ToClusterPhase:    appsv1alpha1.UpdatingClusterPhase,
 		QueueByCluster:    true,
+		ForceDisabled:     true,
 		OpsHandler:        StartOpsHandler{},
 	}
 
@@ -60,9 +63,20 @@ func (start StartOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Cl
 	if err != nil {
 		return intctrlutil.NewFatalError(err.Error())
 	}
+	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.StartList)
 	applyReplicas := func(compSpec *appsv1alpha1.ClusterComponentSpec, componentName string) {
+		if !compOpsHelper.isTargetComponent(componentName) {
+			return
+		}
 		componentKey := getComponentKeyForStartSnapshot(componentName, "")
 		replicasOfSnapshot := componentReplicasMap[componentKey]
+		// remove the started component from the snapshot
+		defer func() {
+			delete(componentReplicasMap, componentKey)
+			for i := range compSpec.Instances {
+				delete(componentReplicasMap, getComponentKeyForStartSnapshot(componentName, compSpec.Instances[i].Name))
+			}
+		}()
 		if replicasOfSnapshot == 0 {
 			return
 		}
@@ -70,12 +84,11 @@ func (start StartOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Cl
 		if compSpec.Replicas == 0 {
 			compSpec.Replicas = replicasOfSnapshot
 			for i := range compSpec.Instances {
-				componentKey = getComponentKeyForStartSnapshot(componentName, compSpec.Instances[i].Name)
-				replicasOfSnapshot = componentReplicasMap[componentKey]
-				if replicasOfSnapshot == 0 {
+				instanceReplicas := componentReplicasMap[getComponentKeyForStartSnapshot(componentName, compSpec.Instances[i].Name)]
+				if instanceReplicas == 0 {
 					continue
 				}
-				compSpec.Instances[i].Replicas = &replicasOfSnapshot
+				compSpec.Instances[i].Replicas = pointer.Int32(instanceReplicas)
 			}
 		}
 	}
@@ -87,8 +100,16 @@ func (start StartOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Cl
 		shardingSpec := &cluster.Spec.ShardingSpecs[i]
 		applyReplicas(&shardingSpec.Template, shardingSpec.Name)
 	}
-	// delete the replicas snapshot of components from the cluster.
-	delete(cluster.Annotations, constant.SnapShotForStartAnnotationKey)
+	// delete the replicas snapshot of components from the cluster once all of them are started.
+	if len(componentReplicasMap) == 0 {
+		delete(cluster.Annotations, constant.SnapShotForStartAnnotationKey)
+	} else {
+		componentReplicasSnapshot, err := json.Marshal(componentReplicasMap)
+		if err != nil {
+			return err
+		}
+		cluster.Annotations[constant.SnapShotForStartAnnotationKey] = string(componentReplicasSnapshot)
+	}
 	return cli.Update(reqCtx.Ctx, cluster)
 }
 
@@ -104,7 +125,7 @@ func (start StartOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli
 			pgRes.clusterComponent.OfflineInstances, opsRes.Cluster.Name, pgRes.fullComponentName)
 		return handleComponentProgressForScalingReplicas(reqCtx, cli, opsRes, pgRes, compStatus)
 	}
-	compOpsHelper := newComponentOpsHelper([]appsv1alpha1.ComponentOps{})
+	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.StartList)
 	return compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes, "start", handleComponentProgress)
 }
 
@@ -117,7 +138,7 @@ func (start StartOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx
 	if err = start.setOpsAnnotation(reqCtx, cli, opsRes, componentReplicasMap); err != nil {
 		return err
 	}
-	saveLastConfigurationForStopAndStart(opsRes)
+	saveLastConfigurationForStopAndStart(opsRes, opsRes.OpsRequest.Spec.StartList)
 	return nil
 }
 
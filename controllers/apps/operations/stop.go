@@ -58,17 +58,16 @@ func (stop StopOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx,
 
 // Action modifies Cluster.spec.components[*].replicas from the opsRequest
 func (stop StopOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
-	var (
-		componentReplicasMap = map[string]int32{}
-		cluster              = opsRes.Cluster
-	)
+	cluster := opsRes.Cluster
 	// if the cluster is already stopping or stopped, return
 	if slices.Contains([]appsv1alpha1.ClusterPhase{appsv1alpha1.StoppedClusterPhase,
 		appsv1alpha1.StoppingClusterPhase}, opsRes.Cluster.Status.Phase) {
 		return nil
 	}
-	if _, ok := cluster.Annotations[constant.SnapShotForStartAnnotationKey]; ok {
-		return fmt.Errorf("wait for the cluster to start before continuing to stop the cluster")
+	// the replicas of the components stopped before are kept in the snapshot.
+	componentReplicasMap, err := getComponentReplicasSnapshot(cluster.Annotations)
+	if err != nil {
+		return intctrlutil.NewFatalError(err.Error())
 	}
 	// abort earlier running vertical scaling opsRequest.
 	if err := abortEarlierOpsRequestWithSameKind(reqCtx, cli, opsRes, []appsv1alpha1.OpsType{appsv1alpha1.HorizontalScalingType,
@@ -78,8 +77,13 @@ func (stop StopOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clie
 		}); err != nil {
 		return err
 	}
+	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.StopList)
 	setReplicas := func(compSpec *appsv1alpha1.ClusterComponentSpec, componentName string) {
 		compKey := getComponentKeyForStartSnapshot(componentName, "")
+		if _, ok := componentReplicasMap[compKey]; ok && compSpec.Replicas == 0 {
+			// the component is already stopped
+			return
+		}
 		componentReplicasMap[compKey] = compSpec.Replicas
 		expectReplicas := int32(0)
 		compSpec.Replicas = expectReplicas
@@ -91,10 +95,14 @@ func (stop StopOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clie
 	}
 	for i := range cluster.Spec.ComponentSpecs {
 		compSpec := &cluster.Spec.ComponentSpecs[i]
-		setReplicas(compSpec, compSpec.Name)
+		if compOpsHelper.isTargetComponent(compSpec.Name) {
+			setReplicas(compSpec, compSpec.Name)
+		}
 	}
 	for i, v := range cluster.Spec.ShardingSpecs {
-		setReplicas(&cluster.Spec.ShardingSpecs[i].Template, v.Name)
+		if compOpsHelper.isTargetComponent(v.Name) {
+			setReplicas(&cluster.Spec.ShardingSpecs[i].Template, v.Name)
+		}
 	}
 	componentReplicasSnapshot, err := json.Marshal(componentReplicasMap)
 	if err != nil {
@@ -125,13 +133,13 @@ func (stop StopOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli cl
 		}
 		return expectProgressCount, completedCount, nil
 	}
-	compOpsHelper := newComponentOpsHelper([]appsv1alpha1.ComponentOps{})
+	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.StopList)
 	return compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes, "stop", handleComponentProgress)
 }
 
 // SaveLastConfiguration records last configuration to the OpsRequest.status.lastConfiguration
 func (stop StopOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
-	saveLastConfigurationForStopAndStart(opsRes)
+	saveLastConfigurationForStopAndStart(opsRes, opsRes.OpsRequest.Spec.StopList)
 	return nil
 }
 
@@ -142,7 +150,8 @@ func getComponentKeyForStartSnapshot(compName, templateName string) string {
 	return compName
 }
 
-func saveLastConfigurationForStopAndStart(opsRes *OpsResource) {
+func saveLastConfigurationForStopAndStart(opsRes *OpsResource, compOpsList []appsv1alpha1.ComponentOps) {
+	compOpsHelper := newComponentOpsHelper(compOpsList)
 	getLastComponentConfiguration := func(compSpec appsv1alpha1.ClusterComponentSpec) appsv1alpha1.LastComponentConfiguration {
 		var instances []appsv1alpha1.InstanceTemplate
 		for _, v := range compSpec.Instances {
@@ -159,9 +168,13 @@ func saveLastConfigurationForStopAndStart(opsRes *OpsResource) {
 	lastConfiguration := &opsRes.OpsRequest.Status.LastConfiguration
 	lastConfiguration.Components = map[string]appsv1alpha1.LastComponentConfiguration{}
 	for _, v := range opsRes.Cluster.Spec.ComponentSpecs {
-		lastConfiguration.Components[v.Name] = getLastComponentConfiguration(v)
+		if compOpsHelper.isTargetComponent(v.Name) {
+			lastConfiguration.Components[v.Name] = getLastComponentConfiguration(v)
+		}
 	}
 	for _, v := range opsRes.Cluster.Spec.ShardingSpecs {
-		lastConfiguration.Components[v.Name] = getLastComponentConfiguration(v.Template)
+		if compOpsHelper.isTargetComponent(v.Name) {
+			lastConfiguration.Components[v.Name] = getLastComponentConfiguration(v.Template)
+		}
 	}
 }
@@ -28,6 +28,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/golang/mock/gomock"
 	"go.uber.org/zap/zapcore"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -47,6 +48,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlcomp "github.com/apecloud/kubeblocks/pkg/controller/component"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
 	"github.com/apecloud/kubeblocks/pkg/testutil"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
@@ -199,3 +201,54 @@ func mockComponentIsOperating(cluster *appsv1alpha1.Cluster, expectPhase appsv1a
 		}
 	})).Should(Succeed())
 }
+
+// createCompDefWithLifecycleActions creates a ComponentDefinition which provides the lifecycle actions.
+func createCompDefWithLifecycleActions(compDefName string, actions ...string) *appsv1alpha1.ComponentDefinition {
+	factory := testapps.NewComponentDefinitionFactory(compDefName).SetDefaultSpec()
+	// the default lifecycle actions are shared by the factories, copy them before setting the actions.
+	factory.Get().Spec.LifecycleActions = factory.Get().Spec.LifecycleActions.DeepCopy()
+	for _, action := range actions {
+		factory.SetLifecycleAction(action, &appsv1alpha1.LifecycleActionHandler{})
+	}
+	return factory.Create(&testCtx).GetObject()
+}
+
+// initOpsResourceWithCluster mocks the cluster and the components are Running, and returns the OpsResource of the cluster.
+func initOpsResourceWithCluster(cluster *appsv1alpha1.Cluster, compNames ...string) *OpsResource {
+	Expect(testapps.ChangeObjStatus(&testCtx, cluster, func() {
+		cluster.Status.Phase = appsv1alpha1.RunningClusterPhase
+		cluster.Status.Components = map[string]appsv1alpha1.ClusterComponentStatus{}
+		for _, compName := range compNames {
+			cluster.Status.Components[compName] = appsv1alpha1.ClusterComponentStatus{
+				Phase: appsv1alpha1.RunningClusterCompPhase,
+			}
+		}
+	})).Should(Succeed())
+	return &OpsResource{
+		Cluster:  cluster,
+		Recorder: k8sManager.GetEventRecorderFor("opsrequest-controller"),
+	}
+}
+
+// mockLorryClient mocks the lorry client of the instances till the end of the spec.
+func mockLorryClient() *lorry.MockClientMockRecorder {
+	mockLorryCli := lorry.NewMockClient(gomock.NewController(GinkgoT()))
+	lorry.SetMockClient(mockLorryCli, nil)
+	DeferCleanup(lorry.UnsetMockClient)
+	return mockLorryCli.EXPECT()
+}
+
+// createOpsAndRunAction creates the OpsRequest and runs its action by the OpsManager as the OpsRequest controller does.
+// The action is not run if the OpsRequest fails the validation.
+func createOpsAndRunAction(reqCtx intctrlutil.RequestCtx, opsRes *OpsResource, ops *appsv1alpha1.OpsRequest) {
+	opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+	opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
+	_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+	Expect(err).ShouldNot(HaveOccurred())
+	if opsRes.OpsRequest.Status.Phase == appsv1alpha1.OpsFailedPhase {
+		return
+	}
+	Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(ops))).Should(Equal(appsv1alpha1.OpsCreatingPhase))
+	_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+	Expect(err).ShouldNot(HaveOccurred())
+}
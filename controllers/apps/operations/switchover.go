@@ -22,16 +22,15 @@ package operations
 import (
 	"encoding/json"
 	"fmt"
-	"reflect"
 	"time"
 
 	"github.com/pkg/errors"
-	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/conditions"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/job"
@@ -123,10 +122,9 @@ func (r switchoverOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCt
 // doSwitchoverComponents creates the switchover job for each component.
 func doSwitchoverComponents(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource, switchoverList []appsv1alpha1.Switchover) error {
 	var (
-		opsRequest          = opsRes.OpsRequest
-		oldOpsRequestStatus = opsRequest.Status.DeepCopy()
+		opsRequest  = opsRes.OpsRequest
+		opsDeepCopy = opsRequest.DeepCopy()
 	)
-	patch := client.MergeFrom(opsRequest.DeepCopy())
 	if opsRequest.Status.Components == nil {
 		opsRequest.Status.Components = make(map[string]appsv1alpha1.OpsRequestComponentStatus)
 	}
@@ -154,14 +152,16 @@ func doSwitchoverComponents(reqCtx intctrlutil.RequestCtx, cli client.Client, op
 				ProgressDetails: []appsv1alpha1.ProgressStatusDetail{},
 			}
 		}
+		// the switchover job is created once the candidate has caught up, see checkCandidateReplicationLag
+		if switchover.MaxLagSeconds != nil {
+			continue
+		}
 		if err := createSwitchoverJob(reqCtx, cli, opsRes.Cluster, synthesizedComp, &switchover); err != nil {
 			return err
 		}
 	}
-	if !reflect.DeepEqual(*oldOpsRequestStatus, opsRequest.Status) {
-		if err := cli.Status().Patch(reqCtx.Ctx, opsRequest, patch); err != nil {
-			return err
-		}
+	if err := PatchOpsRequestStatus(reqCtx.Ctx, cli, opsDeepCopy, opsRequest); err != nil {
+		return err
 	}
 	return nil
 }
@@ -173,18 +173,17 @@ func doSwitchoverComponents(reqCtx intctrlutil.RequestCtx, cli client.Client, op
 // - error: any error that occurred during the handling
 func handleSwitchoverProgress(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (int32, int32, int32, error) {
 	var (
-		expectCount         = int32(len(opsRes.OpsRequest.Spec.SwitchoverList))
-		failedCount         int32
-		completedCount      int32
-		opsRequest          = opsRes.OpsRequest
-		oldOpsRequestStatus = opsRequest.Status.DeepCopy()
-		consistency         bool
-		err                 error
+		expectCount    = int32(len(opsRes.OpsRequest.Spec.SwitchoverList))
+		failedCount    int32
+		completedCount int32
+		opsRequest     = opsRes.OpsRequest
+		opsDeepCopy    = opsRequest.DeepCopy()
+		consistency    bool
+		err            error
 	)
-	patch := client.MergeFrom(opsRequest.DeepCopy())
 	succeedJobs := make([]string, 0, len(opsRes.OpsRequest.Spec.SwitchoverList))
 	for _, switchover := range opsRequest.Spec.SwitchoverList {
-		switchoverCondition := meta.FindStatusCondition(opsRes.OpsRequest.Status.Conditions, appsv1alpha1.ConditionTypeSwitchover)
+		switchoverCondition := conditions.Get(opsRes.OpsRequest.Status.Conditions, appsv1alpha1.ConditionTypeSwitchover)
 		if switchoverCondition == nil {
 			err = errors.New("switchover condition is nil")
 			break
@@ -196,26 +195,57 @@ func handleSwitchoverProgress(reqCtx intctrlutil.RequestCtx, cli client.Client,
 			completedCount += 1
 			continue
 		}
-		// check the current component switchoverJob whether succeed
-		jobName := genSwitchoverJobName(opsRes.Cluster.Name, switchover.ComponentName, switchoverCondition.ObservedGeneration)
+		// hold the promotion until the candidate has caught up
+		if switchover.MaxLagSeconds != nil {
+			lagStatus, lagErr := checkCandidateReplicationLag(reqCtx, cli, opsRes, &switchover)
+			if lagErr != nil {
+				err = lagErr
+				continue
+			}
+			if lagStatus == appsv1alpha1.FailedProgressStatus {
+				completedCount += 1
+				failedCount += 1
+				continue
+			}
+			if lagStatus != appsv1alpha1.SucceedProgressStatus {
+				continue
+			}
+		}
+		// check the switchoverJob of the current attempt whether succeed, the candidate may have been re-selected by the retries
+		attempt := currentSwitchoverAttempt(opsRequest, &switchover,
+			genSwitchoverJobName(opsRes.Cluster.Name, switchover.ComponentName, switchoverCondition.ObservedGeneration))
+		jobName := attempt.JobName
+		switchover.InstanceName = attempt.InstanceName
 		checkJobProcessDetail := appsv1alpha1.ProgressStatusDetail{
 			ObjectKey: getProgressObjectKey(KBSwitchoverCheckJobKey, jobName),
 			Status:    appsv1alpha1.ProcessingProgressStatus,
 		}
 		if err = job.CheckJobSucceed(reqCtx.Ctx, cli, opsRes.Cluster, jobName); err != nil {
+			checkJobProcessDetail.Message = fmt.Sprintf("switchover job %s is not succeed", jobName)
 			if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
 				// means this job is failed
-				completedCount += 1
-				failedCount += 1
 				checkJobProcessDetail.Status = appsv1alpha1.FailedProgressStatus
-				err = nil
+				completeSwitchoverAttempt(opsRequest, switchover.ComponentName, appsv1alpha1.FailedProgressStatus, err.Error())
+				retryAttempt, retryErr := retrySwitchover(reqCtx, cli, opsRes, &switchover)
+				switch {
+				case retryErr != nil:
+					err = retryErr
+				case retryAttempt != nil:
+					err = nil
+					checkJobProcessDetail.Message = fmt.Sprintf("switchover job %s is failed, retry the switchover with the candidate %s by the job %s",
+						jobName, retryAttempt.InstanceName, retryAttempt.JobName)
+				default:
+					err = nil
+					completedCount += 1
+					failedCount += 1
+				}
 			}
-			checkJobProcessDetail.Message = fmt.Sprintf("switchover job %s is not succeed", jobName)
 			setComponentSwitchoverProgressDetails(reqCtx.Recorder, opsRequest, appsv1alpha1.UpdatingClusterCompPhase, checkJobProcessDetail, switchover.ComponentName)
 			continue
 		} else {
 			checkJobProcessDetail.Message = fmt.Sprintf("switchover job %s is succeed", jobName)
 			checkJobProcessDetail.Status = appsv1alpha1.SucceedProgressStatus
+			completeSwitchoverAttempt(opsRequest, switchover.ComponentName, appsv1alpha1.SucceedProgressStatus, checkJobProcessDetail.Message)
 			setComponentSwitchoverProgressDetails(reqCtx.Recorder, opsRequest, appsv1alpha1.UpdatingClusterCompPhase, checkJobProcessDetail, switchover.ComponentName)
 		}
 
@@ -263,10 +293,8 @@ func handleSwitchoverProgress(reqCtx intctrlutil.RequestCtx, cli client.Client,
 
 	opsRequest.Status.Progress = fmt.Sprintf("%d/%d", completedCount, expectCount)
 	// patch OpsRequest.status.components
-	if !reflect.DeepEqual(*oldOpsRequestStatus, opsRequest.Status) {
-		if err := cli.Status().Patch(reqCtx.Ctx, opsRequest, patch); err != nil {
-			return expectCount, 0, 0, err
-		}
+	if err := PatchOpsRequestStatus(reqCtx.Ctx, cli, opsDeepCopy, opsRequest); err != nil {
+		return expectCount, 0, 0, err
 	}
 
 	if err != nil {
@@ -294,8 +322,10 @@ func setComponentSwitchoverProgressDetails(recorder record.EventRecorder,
 	componentProcessDetails := opsRequest.Status.Components[componentName].ProgressDetails
 	setComponentStatusProgressDetail(recorder, opsRequest, &componentProcessDetails, processDetail)
 	opsRequest.Status.Components[componentName] = appsv1alpha1.OpsRequestComponentStatus{
-		Phase:           phase,
-		ProgressDetails: componentProcessDetails,
+		Phase:                 phase,
+		ProgressDetails:       componentProcessDetails,
+		ReplicationLagSeconds: opsRequest.Status.Components[componentName].ReplicationLagSeconds,
+		SwitchoverAttempts:    opsRequest.Status.Components[componentName].SwitchoverAttempts,
 	}
 }
 
@@ -23,13 +23,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -38,6 +41,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
 	"github.com/apecloud/kubeblocks/pkg/controller/job"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
 )
 
 // switchover constants
@@ -54,6 +58,9 @@ const (
 	KBSwitchoverCheckJobKey       = "CheckJob"
 	KBSwitchoverCheckRoleLabelKey = "CheckRoleLabel"
 
+	KBSwitchoverCheckReplicationLagKey = "CheckReplicationLag"
+	defaultSwitchoverLagWaitSeconds    = 300
+
 	KBSwitchoverCandidateName = "KB_SWITCHOVER_CANDIDATE_NAME"
 	KBSwitchoverCandidateFqdn = "KB_SWITCHOVER_CANDIDATE_FQDN"
 
@@ -114,6 +121,15 @@ func createSwitchoverJob(reqCtx intctrlutil.RequestCtx,
 	if err != nil {
 		return err
 	}
+	return ensureSwitchoverJob(reqCtx, cli, cluster, synthesizedComp, switchoverJob)
+}
+
+// ensureSwitchoverJob creates the switchover job if it does not exist, the switchover jobs of the previous generations or attempts are deleted.
+func ensureSwitchoverJob(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	cluster *appsv1alpha1.Cluster,
+	synthesizedComp *component.SynthesizedComponent,
+	switchoverJob *batchv1.Job) error {
 	// check the current generation switchoverJob whether exist
 	key := types.NamespacedName{Namespace: cluster.Namespace, Name: switchoverJob.Name}
 	exists, _ := intctrlutil.CheckResourceExists(reqCtx.Ctx, cli, key, &batchv1.Job{})
@@ -140,6 +156,91 @@ func createSwitchoverJob(reqCtx intctrlutil.RequestCtx,
 	return nil
 }
 
+// checkCandidateReplicationLag measures the replication lag of the candidate instance by the replicationLag action,
+// and creates the switchover job once the lag is within switchover.maxLagSeconds.
+// It returns the status of the check, which fails if the candidate has not caught up within switchover.lagWaitSeconds.
+func checkCandidateReplicationLag(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	switchover *appsv1alpha1.Switchover) (appsv1alpha1.ProgressStatus, error) {
+	opsRequest := opsRes.OpsRequest
+	progressDetail := appsv1alpha1.ProgressStatusDetail{
+		ObjectKey: getProgressObjectKey(KBSwitchoverCheckReplicationLagKey, switchover.InstanceName),
+		Status:    appsv1alpha1.PendingProgressStatus,
+	}
+	detail := findStatusProgressDetail(opsRequest.Status.Components[switchover.ComponentName].ProgressDetails, progressDetail.ObjectKey)
+	if detail != nil && isCompletedProgressStatus(detail.Status) {
+		return detail.Status, nil
+	}
+
+	compSpec := opsRes.Cluster.Spec.GetComponentByName(switchover.ComponentName)
+	synthesizedComp, err := buildSynthesizedComp(reqCtx, cli, opsRes, compSpec)
+	if err != nil {
+		return "", err
+	}
+	leader, err := getServiceableNWritablePod(reqCtx.Ctx, cli, *synthesizedComp)
+	if err != nil {
+		return "", err
+	}
+	if leader == nil {
+		return "", errors.New("serviceable and writable pod not found")
+	}
+	candidate := &corev1.Pod{}
+	if err = cli.Get(reqCtx.Ctx, types.NamespacedName{Namespace: opsRes.Cluster.Namespace, Name: switchover.InstanceName}, candidate); err != nil {
+		return "", err
+	}
+	lagSeconds, err := measureReplicationLag(reqCtx.Ctx, synthesizedComp, candidate, leader)
+	if err != nil {
+		return "", err
+	}
+	compStatus := opsRequest.Status.Components[switchover.ComponentName]
+	compStatus.ReplicationLagSeconds = &lagSeconds
+	opsRequest.Status.Components[switchover.ComponentName] = compStatus
+
+	progressDetail.Status, progressDetail.Message = evaluateReplicationLag(switchover, lagSeconds, opsRequest.Status.StartTimestamp)
+	if progressDetail.Status == appsv1alpha1.SucceedProgressStatus {
+		if err = createSwitchoverJob(reqCtx, cli, opsRes.Cluster, synthesizedComp, switchover); err != nil {
+			return "", err
+		}
+	}
+	setComponentSwitchoverProgressDetails(reqCtx.Recorder, opsRequest, appsv1alpha1.UpdatingClusterCompPhase, progressDetail, switchover.ComponentName)
+	return progressDetail.Status, nil
+}
+
+// measureReplicationLag measures the replication lag in seconds of the candidate instance against the leader by the replicationLag action.
+func measureReplicationLag(ctx context.Context, synthesizedComp *component.SynthesizedComponent, candidate, leader *corev1.Pod) (int64, error) {
+	lorryCli, err := lorry.NewClient(*candidate)
+	if err != nil {
+		return 0, err
+	}
+	if intctrlutil.IsNil(lorryCli) {
+		return 0, fmt.Errorf(`failed to get the lorry client of the pod "%s"`, candidate.Name)
+	}
+	svcName := strings.Join([]string{synthesizedComp.ClusterName, synthesizedComp.Name, "headless"}, "-")
+	return lorryCli.ReplicationLag(ctx, leader.Name, fmt.Sprintf("%s.%s", leader.Name, svcName))
+}
+
+// evaluateReplicationLag evaluates the replication lag of the candidate instance against switchover.maxLagSeconds,
+// the candidate is pending until it catches up or switchover.lagWaitSeconds has elapsed since the OpsRequest started.
+func evaluateReplicationLag(switchover *appsv1alpha1.Switchover, lagSeconds int64, startTime metav1.Time) (appsv1alpha1.ProgressStatus, string) {
+	maxLagSeconds := int64(*switchover.MaxLagSeconds)
+	lagWaitSeconds := int32(defaultSwitchoverLagWaitSeconds)
+	if switchover.LagWaitSeconds != nil {
+		lagWaitSeconds = *switchover.LagWaitSeconds
+	}
+	switch {
+	case lagSeconds <= maxLagSeconds:
+		return appsv1alpha1.SucceedProgressStatus, fmt.Sprintf("the replication lag %ds of the candidate %s is within the maximum %ds",
+			lagSeconds, switchover.InstanceName, maxLagSeconds)
+	case time.Since(startTime.Time) > time.Duration(lagWaitSeconds)*time.Second:
+		return appsv1alpha1.FailedProgressStatus, fmt.Sprintf("the candidate %s has not caught up in %ds, the replication lag %ds exceeds the maximum %ds",
+			switchover.InstanceName, lagWaitSeconds, lagSeconds, maxLagSeconds)
+	default:
+		return appsv1alpha1.PendingProgressStatus, fmt.Sprintf("waiting for the candidate %s to catch up, the replication lag exceeds the maximum %ds",
+			switchover.InstanceName, maxLagSeconds)
+	}
+}
+
 // checkPodRoleLabelConsistency checks whether the pod role label is consistent with the specified role label after switchover.
 func checkPodRoleLabelConsistency(ctx context.Context,
 	cli client.Client,
@@ -161,17 +262,18 @@ func checkPodRoleLabelConsistency(ctx context.Context,
 		return false, err
 	}
 
+	// the candidate may have been re-selected by the retries, so the switchover.InstanceName is checked rather than the one in the message.
 	for _, switchoverMessage := range switchoverMessageMap {
 		if switchoverMessage.ComponentName != synthesizedComp.Name {
 			continue
 		}
-		switch switchoverMessage.Switchover.InstanceName {
+		switch switchover.InstanceName {
 		case KBSwitchoverCandidateInstanceForAnyPod:
 			if pod.Name != switchoverMessage.OldPrimary {
 				return true, nil
 			}
 		default:
-			if pod.Name == switchoverMessage.Switchover.InstanceName {
+			if pod.Name == switchover.InstanceName {
 				return true, nil
 			}
 		}
@@ -179,6 +281,164 @@ func checkPodRoleLabelConsistency(ctx context.Context,
 	return false, nil
 }
 
+// currentSwitchoverAttempt returns the current attempt of the switchover, the first attempt is recorded if there is none.
+func currentSwitchoverAttempt(opsRequest *appsv1alpha1.OpsRequest, switchover *appsv1alpha1.Switchover, jobName string) appsv1alpha1.SwitchoverAttempt {
+	attempts := opsRequest.Status.Components[switchover.ComponentName].SwitchoverAttempts
+	if len(attempts) == 0 {
+		recordSwitchoverAttempt(opsRequest, switchover.ComponentName, switchover.InstanceName, jobName)
+		attempts = opsRequest.Status.Components[switchover.ComponentName].SwitchoverAttempts
+	}
+	return attempts[len(attempts)-1]
+}
+
+// recordSwitchoverAttempt records a new attempt of the switchover in the status of the component.
+func recordSwitchoverAttempt(opsRequest *appsv1alpha1.OpsRequest, componentName, instanceName, jobName string) {
+	compStatus := opsRequest.Status.Components[componentName]
+	compStatus.SwitchoverAttempts = append(compStatus.SwitchoverAttempts, appsv1alpha1.SwitchoverAttempt{
+		InstanceName: instanceName,
+		JobName:      jobName,
+		Status:       appsv1alpha1.ProcessingProgressStatus,
+		StartTime:    metav1.Now(),
+	})
+	opsRequest.Status.Components[componentName] = compStatus
+}
+
+// completeSwitchoverAttempt sets the result of the current attempt of the switchover.
+func completeSwitchoverAttempt(opsRequest *appsv1alpha1.OpsRequest, componentName string, status appsv1alpha1.ProgressStatus, message string) {
+	compStatus := opsRequest.Status.Components[componentName]
+	if len(compStatus.SwitchoverAttempts) == 0 {
+		return
+	}
+	attempt := &compStatus.SwitchoverAttempts[len(compStatus.SwitchoverAttempts)-1]
+	if isCompletedProgressStatus(attempt.Status) {
+		return
+	}
+	attempt.Status = status
+	attempt.Message = message
+	attempt.EndTime = metav1.Now()
+	opsRequest.Status.Components[componentName] = compStatus
+}
+
+// retrySwitchover retries the failed switchover with a re-selected candidate instance until switchover.maxRetries is exhausted.
+// It returns the new attempt, or nil if the switchover is not retried.
+func retrySwitchover(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	switchover *appsv1alpha1.Switchover) (*appsv1alpha1.SwitchoverAttempt, error) {
+	attempts := opsRes.OpsRequest.Status.Components[switchover.ComponentName].SwitchoverAttempts
+	retries := int32(len(attempts) - 1)
+	if len(attempts) == 0 || switchover.MaxRetries == nil || retries >= *switchover.MaxRetries {
+		return nil, nil
+	}
+	compSpec := opsRes.Cluster.Spec.GetComponentByName(switchover.ComponentName)
+	synthesizedComp, err := buildSynthesizedComp(reqCtx, cli, opsRes, compSpec)
+	if err != nil {
+		return nil, err
+	}
+	candidate, err := selectSwitchoverCandidate(reqCtx, cli, synthesizedComp, switchover, attempts)
+	if err != nil {
+		return nil, err
+	}
+	if candidate == "" {
+		reqCtx.Log.Info("no candidate is available to retry the switchover", "component", switchover.ComponentName)
+		return nil, nil
+	}
+
+	retry := *switchover
+	retry.InstanceName = candidate
+	switchoverJob, err := renderSwitchoverCmdJob(reqCtx.Ctx, cli, opsRes.Cluster, synthesizedComp, &retry)
+	if err != nil {
+		return nil, err
+	}
+	jobName := genSwitchoverRetryJobName(attempts[0].JobName, retries+1)
+	switchoverJob.Name = jobName
+	switchoverJob.Spec.Template.Name = jobName
+	if err = ensureSwitchoverJob(reqCtx, cli, opsRes.Cluster, synthesizedComp, switchoverJob); err != nil {
+		return nil, err
+	}
+	recordSwitchoverAttempt(opsRes.OpsRequest, switchover.ComponentName, candidate, jobName)
+	attempts = opsRes.OpsRequest.Status.Components[switchover.ComponentName].SwitchoverAttempts
+	return &attempts[len(attempts)-1], nil
+}
+
+// selectSwitchoverCandidate re-selects the candidate instance for retrying the switchover, the candidates of the failed attempts are excluded.
+// The candidate with the least replication lag within switchover.maxLagSeconds is selected if it is specified.
+// It returns "*" if no specific instance is designated by the switchover, or empty if there is no candidate available.
+func selectSwitchoverCandidate(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	synthesizedComp *component.SynthesizedComponent,
+	switchover *appsv1alpha1.Switchover,
+	attempts []appsv1alpha1.SwitchoverAttempt) (string, error) {
+	leader, err := getServiceableNWritablePod(reqCtx.Ctx, cli, *synthesizedComp)
+	if err != nil {
+		return "", err
+	}
+	if leader == nil {
+		return "", errors.New("serviceable and writable pod not found")
+	}
+	pods, err := component.ListOwnedPods(reqCtx.Ctx, cli, synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name)
+	if err != nil {
+		return "", err
+	}
+	failed := sets.New[string]()
+	for _, attempt := range attempts {
+		failed.Insert(attempt.InstanceName)
+	}
+	candidates := filterSwitchoverCandidates(synthesizedComp.Roles, pods, leader.Name, failed)
+	switch {
+	case len(candidates) == 0:
+		return "", nil
+	case switchover.InstanceName == KBSwitchoverCandidateInstanceForAnyPod:
+		return KBSwitchoverCandidateInstanceForAnyPod, nil
+	case switchover.MaxLagSeconds == nil:
+		return candidates[0].Name, nil
+	}
+	var (
+		selected string
+		minLag   int64
+	)
+	for _, candidate := range candidates {
+		lagSeconds, err := measureReplicationLag(reqCtx.Ctx, synthesizedComp, candidate, leader)
+		if err != nil {
+			reqCtx.Log.Info("failed to measure the replication lag of the candidate", "pod", candidate.Name, "error", err.Error())
+			continue
+		}
+		if lagSeconds <= int64(*switchover.MaxLagSeconds) && (selected == "" || lagSeconds < minLag) {
+			selected, minLag = candidate.Name, lagSeconds
+		}
+	}
+	return selected, nil
+}
+
+// filterSwitchoverCandidates returns the ready pods that can be promoted to the primary or leader, sorted by name.
+// The pods holding a votable role can be promoted, or the ones holding a serviceable role if no role is votable,
+// and the leader and the excluded pods are filtered out.
+func filterSwitchoverCandidates(roles []appsv1alpha1.ReplicaRole, pods []*corev1.Pod, leaderName string, excluded sets.Set[string]) []*corev1.Pod {
+	votable := false
+	for _, role := range roles {
+		votable = votable || role.Votable
+	}
+	promotable := sets.New[string]()
+	for _, role := range roles {
+		if role.Writable || (votable && !role.Votable) || (!votable && !role.Serviceable) {
+			continue
+		}
+		promotable.Insert(role.Name)
+	}
+	var candidates []*corev1.Pod
+	for _, pod := range pods {
+		if pod.Name == leaderName || excluded.Has(pod.Name) || !intctrlutil.PodIsReady(pod) ||
+			!promotable.Has(pod.Labels[constant.RoleLabelKey]) {
+			continue
+		}
+		candidates = append(candidates, pod)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Name < candidates[j].Name
+	})
+	return candidates
+}
+
 // renderSwitchoverCmdJob renders and creates the switchover command jobs.
 func renderSwitchoverCmdJob(ctx context.Context,
 	cli client.Client,
@@ -311,6 +571,11 @@ func genSwitchoverJobName(clusterName, componentName string, generation int64) s
 	return fmt.Sprintf("%s-%s-%s-%d", KBSwitchoverJobNamePrefix, clusterName, componentName, generation)
 }
 
+// genSwitchoverRetryJobName generates the name of the switchover job that retries the switchover job for the given times.
+func genSwitchoverRetryJobName(jobName string, retries int32) string {
+	return fmt.Sprintf("%s-retry-%d", jobName, retries)
+}
+
 // getSwitchoverCmdJobLabel gets the labels for job that execute the switchover commands.
 func getSwitchoverCmdJobLabel(clusterName, componentName string) map[string]string {
 	return map[string]string{
@@ -21,11 +21,16 @@ package operations
 
 import (
 	"fmt"
+	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -248,3 +253,89 @@ var _ = Describe("Switchover Util", func() {
 		})
 	})
 })
+
+func TestEvaluateReplicationLag(t *testing.T) {
+	switchover := &appsv1alpha1.Switchover{
+		ComponentOps:   appsv1alpha1.ComponentOps{ComponentName: "mysql"},
+		InstanceName:   "test-mysql-1",
+		MaxLagSeconds:  pointer.Int32(5),
+		LagWaitSeconds: pointer.Int32(60),
+	}
+	cases := []struct {
+		name       string
+		lagSeconds int64
+		startTime  metav1.Time
+		expected   appsv1alpha1.ProgressStatus
+	}{
+		{"caught up", 5, metav1.Now(), appsv1alpha1.SucceedProgressStatus},
+		{"catching up", 6, metav1.Now(), appsv1alpha1.PendingProgressStatus},
+		{"not caught up in time", 6, metav1.NewTime(time.Now().Add(-time.Minute * 2)), appsv1alpha1.FailedProgressStatus},
+		{"caught up after waiting", 0, metav1.NewTime(time.Now().Add(-time.Minute * 2)), appsv1alpha1.SucceedProgressStatus},
+	}
+	for _, c := range cases {
+		if status, msg := evaluateReplicationLag(switchover, c.lagSeconds, c.startTime); status != c.expected {
+			t.Errorf("%s: expect the status %s, but got %s: %s", c.name, c.expected, status, msg)
+		}
+	}
+
+	// the candidate is waited for 300 seconds by default
+	switchover.LagWaitSeconds = nil
+	if status, _ := evaluateReplicationLag(switchover, 6, metav1.NewTime(time.Now().Add(-time.Minute*2))); status != appsv1alpha1.PendingProgressStatus {
+		t.Errorf("expect the candidate is still waited for, but got %s", status)
+	}
+}
+
+func TestFilterSwitchoverCandidates(t *testing.T) {
+	newPod := func(name, role string, ready bool) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{constant.RoleLabelKey: role},
+			},
+		}
+		if ready {
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		}
+		return pod
+	}
+	names := func(pods []*corev1.Pod) []string {
+		var result []string
+		for _, pod := range pods {
+			result = append(result, pod.Name)
+		}
+		return result
+	}
+	pods := []*corev1.Pod{
+		newPod("test-mysql-3", "follower", true),
+		newPod("test-mysql-0", "leader", true),
+		newPod("test-mysql-1", "follower", true),
+		newPod("test-mysql-2", "follower", false),
+		newPod("test-mysql-4", "learner", true),
+	}
+	roles := []appsv1alpha1.ReplicaRole{
+		{Name: "leader", Serviceable: true, Writable: true, Votable: true},
+		{Name: "follower", Serviceable: true, Votable: true},
+		{Name: "learner", Serviceable: true},
+	}
+	cases := []struct {
+		name     string
+		roles    []appsv1alpha1.ReplicaRole
+		excluded []string
+		expected []string
+	}{
+		{"votable roles", roles, nil, []string{"test-mysql-1", "test-mysql-3"}},
+		{"exclude the failed candidates", roles, []string{"test-mysql-1"}, []string{"test-mysql-3"}},
+		{"no candidate", roles, []string{"test-mysql-1", "test-mysql-3"}, nil},
+		{"serviceable roles", []appsv1alpha1.ReplicaRole{
+			{Name: "leader", Serviceable: true, Writable: true},
+			{Name: "follower", Serviceable: true},
+			{Name: "learner"},
+		}, nil, []string{"test-mysql-1", "test-mysql-3"}},
+	}
+	for _, c := range cases {
+		candidates := names(filterSwitchoverCandidates(c.roles, pods, "test-mysql-0", sets.New(c.excluded...)))
+		if fmt.Sprint(candidates) != fmt.Sprint(c.expected) {
+			t.Errorf("%s: expect the candidates %v, but got %v", c.name, c.expected, candidates)
+		}
+	}
+}
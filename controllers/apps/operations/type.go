@@ -59,6 +59,14 @@ type OpsBehaviour struct {
 	// only update the opsRequest object, then opsRequest controller will update uniformly.
 	CancelFunc func(reqCtx intctrlutil.RequestCtx, cli client.Client, opsResource *OpsResource) error
 
+	// RollbackFunc this function restores the Cluster from OpsRequest.status.lastConfiguration when the opsRequest
+	// with spec.rollbackOnFailure fails, and does not patch/update the opsRequest by client-go in here.
+	RollbackFunc func(reqCtx intctrlutil.RequestCtx, cli client.Client, opsResource *OpsResource) error
+
+	// TerminateFunc this function terminates the in-flight tasks of the opsRequest when it times out,
+	// and does not patch/update the opsRequest by client-go in here.
+	TerminateFunc func(reqCtx intctrlutil.RequestCtx, cli client.Client, opsResource *OpsResource) error
+
 	// IsClusterCreation indicates whether the opsRequest will create a new cluster.
 	IsClusterCreation bool
 
@@ -68,9 +76,24 @@ type OpsBehaviour struct {
 	// QueueWithSelf indicates that the operation is queued for execution within opsType scope.
 	QueueBySelf bool
 
+	// ForceDisabled indicates that the operation does not support force execution.
+	ForceDisabled bool
+
+	// TimeoutSeconds indicates the maximum duration of the operation, 0 means no timeout.
+	TimeoutSeconds int32
+
+	// RetryPolicy defines how the operation is requeued, nil means using the default policy.
+	RetryPolicy *OpsRetryPolicy
+
 	OpsHandler OpsHandler
 }
 
+// CreatesCluster checks if the opsRequest will create a new cluster,
+// a Restore OpsRequest with component mappings restores the data into the existing cluster.
+func (b OpsBehaviour) CreatesCluster(opsRequest *appsv1alpha1.OpsRequest) bool {
+	return b.IsClusterCreation && !opsRequest.Spec.IsInPlaceRestore()
+}
+
 type reconfigureParams struct {
 	resource *OpsResource
 	reqCtx   intctrlutil.RequestCtx
@@ -93,6 +116,9 @@ type OpsResource struct {
 
 type OpsManager struct {
 	OpsMap map[appsv1alpha1.OpsType]OpsBehaviour
+
+	// nodeScheduler limits the disruptive OpsRequests per node and node pool, nil means no limits.
+	nodeScheduler *nodeConcurrencyScheduler
 }
 
 type progressResource struct {
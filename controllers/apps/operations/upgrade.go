@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
@@ -38,12 +39,15 @@ type upgradeOpsHandler struct{}
 var _ OpsHandler = upgradeOpsHandler{}
 
 func init() {
+	upgradeHandler := upgradeOpsHandler{}
 	upgradeBehaviour := OpsBehaviour{
 		// if cluster is Abnormal or Failed, new opsRequest may can repair it.
 		FromClusterPhases: appsv1alpha1.GetClusterUpRunningPhases(),
 		ToClusterPhase:    appsv1alpha1.UpdatingClusterPhase,
 		QueueByCluster:    true,
-		OpsHandler:        upgradeOpsHandler{},
+		OpsHandler:        upgradeHandler,
+		RollbackFunc:      upgradeHandler.Rollback,
+		TerminateFunc:     upgradeHandler.Terminate,
 	}
 
 	opsMgr := GetOpsManager()
@@ -63,6 +67,10 @@ func (u upgradeOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clie
 		// TODO: remove this deprecated API after v0.9
 		opsRes.Cluster.Spec.ClusterVersionRef = *opsRes.OpsRequest.Spec.Upgrade.ClusterVersionRef
 	} else {
+		// the upgrade is aborted before any instance is touched if the pre-checks of the major-version upgrades fail.
+		if err := u.preCheckMajorVersionUpgrades(reqCtx, cli, opsRes); err != nil {
+			return err
+		}
 		compOpsHelper = newComponentOpsHelper(upgradeSpec.Components)
 		if err := compOpsHelper.updateClusterComponentsAndShardings(opsRes.Cluster, func(compSpec *appsv1alpha1.ClusterComponentSpec, obj ComponentOpsInteface) error {
 			upgradeComp := obj.(appsv1alpha1.UpgradeComponent)
@@ -93,6 +101,12 @@ func (u upgradeOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clie
 		}); err != nil {
 		return err
 	}
+	if !u.existClusterVersion(opsRes.OpsRequest) {
+		// pause the rolling update before upgrading, if the proxies of the cluster can drain the connections to the instances.
+		if err := pauseForDraining(reqCtx, cli, opsRes, u.upgradeComponentNames(opsRes)); err != nil {
+			return err
+		}
+	}
 	return cli.Update(reqCtx.Ctx, opsRes.Cluster)
 }
 
@@ -158,14 +172,28 @@ func (u upgradeOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli cl
 		}
 		return u.podImageApplied(pod, compDef.Spec.Runtime.Containers)
 	}
+	drainPending := false
 	handleUpgradeProgress := func(reqCtx intctrlutil.RequestCtx,
 		cli client.Client,
 		opsRes *OpsResource,
 		pgRes *progressResource,
 		compStatus *appsv1alpha1.OpsRequestComponentStatus) (expectProgressCount int32, completedCount int32, err error) {
+		if compDef, ok := componentDefMap[pgRes.compOps.GetComponentName()]; ok && !pgRes.isShardingComponent {
+			rolled, err := rollWithDraining(reqCtx, cli, opsRes, pgRes.fullComponentName, func(its *workloads.InstanceSet) bool {
+				return u.podImageApplied(&corev1.Pod{Spec: its.Spec.Template.Spec}, compDef.Spec.Runtime.Containers)
+			})
+			if err != nil {
+				return 0, 0, err
+			}
+			drainPending = drainPending || !rolled
+		}
 		return handleComponentStatusProgress(reqCtx, cli, opsRes, pgRes, compStatus, podApplyCompOps)
 	}
-	return compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes, "upgrade", handleUpgradeProgress)
+	phase, requeueAfter, err := compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes, "upgrade", handleUpgradeProgress)
+	if u.existClusterVersion(opsRes.OpsRequest) {
+		return phase, requeueAfter, err
+	}
+	return handleDrainingResult(reqCtx, cli, opsRes, u.upgradeComponentNames(opsRes), drainPending, phase, requeueAfter, err)
 }
 
 // SaveLastConfiguration records last configuration to the OpsRequest.status.lastConfiguration
@@ -181,6 +209,28 @@ func (u upgradeOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx,
 	return nil
 }
 
+// Rollback restores the ComponentDefinition and the service version of the upgraded components,
+// and the instances are rolled back to the previous images by the rolling update.
+func (u upgradeOpsHandler) Rollback(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	if u.existClusterVersion(opsRes.OpsRequest) {
+		// TODO: remove this deprecated API after v0.9
+		opsRes.Cluster.Spec.ClusterVersionRef = opsRes.OpsRequest.Status.LastConfiguration.ClusterVersionRef
+		return cli.Update(reqCtx.Ctx, opsRes.Cluster)
+	}
+	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.Upgrade.Components)
+	return compOpsHelper.cancelComponentOps(reqCtx.Ctx, cli, opsRes, func(lastConfig *appsv1alpha1.LastComponentConfiguration, comp *appsv1alpha1.ClusterComponentSpec) {
+		if lastConfig.ComponentDefinitionName != "" {
+			comp.ComponentDef = lastConfig.ComponentDefinitionName
+		}
+		comp.ServiceVersion = lastConfig.ServiceVersion
+	})
+}
+
+// Terminate releases the InstanceSets paused for draining when the opsRequest times out.
+func (u upgradeOpsHandler) Terminate(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return terminateDraining(reqCtx, cli, opsRes, u.upgradeComponentNames(opsRes))
+}
+
 // getClusterComponentVersionMap gets the components of ClusterVersion and converts the component list to map.
 func (u upgradeOpsHandler) getClusterComponentVersionMap(ctx context.Context,
 	cli client.Client, clusterVersionName string) (map[string]appsv1alpha1.ClusterComponentVersion, error) {
@@ -250,6 +300,14 @@ func (u upgradeOpsHandler) podImageApplied(pod *corev1.Pod, expectContainers []c
 	return true
 }
 
+func (u upgradeOpsHandler) upgradeComponentNames(opsRes *OpsResource) []string {
+	compNames := make([]string, 0, len(opsRes.OpsRequest.Spec.Upgrade.Components))
+	for _, upgradeComp := range opsRes.OpsRequest.Spec.Upgrade.Components {
+		compNames = append(compNames, upgradeComp.ComponentName)
+	}
+	return compNames
+}
+
 func (u upgradeOpsHandler) existClusterVersion(ops *appsv1alpha1.OpsRequest) bool {
 	return ops.Spec.Upgrade.ClusterVersionRef != nil && *ops.Spec.Upgrade.ClusterVersionRef != ""
 }
@@ -0,0 +1,177 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	intctrlcomp "github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+)
+
+const (
+	reasonUpgradePreCheckPassed = "UpgradePreCheckPassed"
+	reasonUpgradePreCheckFailed = "UpgradePreCheckFailed"
+)
+
+// preCheckMajorVersionUpgrades runs the pre-checks declared by the upgrade paths of the major-version upgrades
+// before any instance is touched, and records the results in OpsRequest.status.preCheckResults.
+// The checks that have passed are not performed again when the action is retried.
+func (u upgradeOpsHandler) preCheckMajorVersionUpgrades(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	opsDeepCopy := opsRes.OpsRequest.DeepCopy()
+	failed := false
+	for _, upgradeComp := range opsRes.OpsRequest.Spec.Upgrade.Components {
+		compSpec := getComponentSpecOrShardingTemplate(opsRes.Cluster, upgradeComp.ComponentName)
+		if compSpec == nil || upgradeComp.ServiceVersion == nil ||
+			!appsv1alpha1.IsMajorVersionUpgrade(compSpec.ServiceVersion, *upgradeComp.ServiceVersion) {
+			continue
+		}
+		fromVersion, toVersion := compSpec.ServiceVersion, *upgradeComp.ServiceVersion
+		compDefName := compSpec.ComponentDef
+		if u.needUpdateCompDef(upgradeComp, opsRes.Cluster) {
+			compDefName = *upgradeComp.ComponentDefinitionName
+		}
+		checks, err := getUpgradePreChecks(reqCtx, cli, compDefName, fromVersion, toVersion)
+		if err != nil {
+			return err
+		}
+		if len(checks) == 0 {
+			continue
+		}
+		// the checks are performed by the running instances, with the action defined in the current ComponentDefinition.
+		compDef, err := intctrlcomp.GetCompDefByName(reqCtx.Ctx, cli, compSpec.ComponentDef)
+		if err != nil {
+			return err
+		}
+		if compDef.Spec.LifecycleActions == nil || compDef.Spec.LifecycleActions.UpgradePreCheck == nil {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`the upgradePreCheck action is not defined for the component "%s"`, upgradeComp.ComponentName))
+		}
+		compNames, err := getFullComponentNames(reqCtx, cli, opsRes, upgradeComp.ComponentName)
+		if err != nil {
+			return err
+		}
+		for _, compName := range compNames {
+			pods, err := intctrlcomp.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, opsRes.Cluster.Name, compName)
+			if err != nil {
+				return err
+			}
+			pod := selectWritablePod(compDef, pods)
+			if pod == nil {
+				return fmt.Errorf(`waiting for an instance of the component "%s" to be ready to run the upgrade pre-checks`, compName)
+			}
+			compFailed, err := runUpgradePreChecks(reqCtx, opsRes, pod, compName, checks, fromVersion, toVersion)
+			if err != nil {
+				return err
+			}
+			failed = failed || compFailed
+		}
+	}
+	// the status is not patched by the controller if the action fails, so the results are patched here.
+	if len(opsRes.OpsRequest.Status.PreCheckResults) != len(opsDeepCopy.Status.PreCheckResults) {
+		if err := PatchOpsRequestStatus(reqCtx.Ctx, cli, opsDeepCopy, opsRes.OpsRequest); err != nil {
+			return err
+		}
+	}
+	if failed {
+		return intctrlutil.NewFatalError("the upgrade pre-checks failed, see status.preCheckResults for details")
+	}
+	return nil
+}
+
+// getUpgradePreChecks gets the pre-checks declared by the upgrade paths matched in the ComponentVersions
+// of the ComponentDefinition.
+func getUpgradePreChecks(reqCtx intctrlutil.RequestCtx, cli client.Client, compDefName, fromVersion, toVersion string) ([]string, error) {
+	compVersionList := &appsv1alpha1.ComponentVersionList{}
+	if err := cli.List(reqCtx.Ctx, compVersionList, client.MatchingLabels{compDefName: compDefName}); err != nil {
+		return nil, err
+	}
+	var checks []string
+	for _, compVersion := range compVersionList.Items {
+		path, ok := compVersion.Spec.GetUpgradePath(fromVersion, toVersion)
+		if !ok {
+			return nil, intctrlutil.NewFatalError(fmt.Sprintf(`upgrading the service version from "%s" to "%s" is not allowed by ComponentVersion "%s"`,
+				fromVersion, toVersion, compVersion.Name))
+		}
+		if path == nil {
+			continue
+		}
+		for _, check := range path.PreChecks {
+			if !slices.Contains(checks, check) {
+				checks = append(checks, check)
+			}
+		}
+	}
+	return checks, nil
+}
+
+// runUpgradePreChecks performs the checks in the pod by the upgradePreCheck action, and returns true if any of them fails.
+func runUpgradePreChecks(reqCtx intctrlutil.RequestCtx,
+	opsRes *OpsResource,
+	pod *corev1.Pod,
+	compName string,
+	checks []string,
+	fromVersion, toVersion string) (bool, error) {
+	var lorryCli lorry.Client
+	failed := false
+	for _, check := range checks {
+		if result := getUpgradePreCheckResult(opsRes.OpsRequest, compName, check); result != nil {
+			failed = failed || !result.Pass
+			continue
+		}
+		if lorryCli == nil {
+			var err error
+			lorryCli, err = lorry.NewClient(*pod)
+			if err != nil {
+				return false, err
+			}
+			if intctrlutil.IsNil(lorryCli) {
+				return false, fmt.Errorf(`failed to get the lorry client of the pod "%s"`, pod.Name)
+			}
+		}
+		result := appsv1alpha1.UpgradePreCheckResult{ComponentName: compName, Name: check}
+		if err := lorryCli.UpgradePreCheck(reqCtx.Ctx, check, fromVersion, toVersion); err != nil {
+			result.Message = err.Error()
+			failed = true
+			opsRes.Recorder.Eventf(opsRes.OpsRequest, corev1.EventTypeWarning, reasonUpgradePreCheckFailed,
+				`the upgrade pre-check "%s" of the component "%s" failed: %s`, check, compName, err.Error())
+		} else {
+			result.Pass = true
+			opsRes.Recorder.Eventf(opsRes.OpsRequest, corev1.EventTypeNormal, reasonUpgradePreCheckPassed,
+				`the upgrade pre-check "%s" of the component "%s" passed`, check, compName)
+		}
+		opsRes.OpsRequest.Status.PreCheckResults = append(opsRes.OpsRequest.Status.PreCheckResults, result)
+	}
+	return failed, nil
+}
+
+func getUpgradePreCheckResult(opsRequest *appsv1alpha1.OpsRequest, compName, check string) *appsv1alpha1.UpgradePreCheckResult {
+	for i, result := range opsRequest.Status.PreCheckResults {
+		if result.ComponentName == compName && result.Name == check {
+			return &opsRequest.Status.PreCheckResults[i]
+		}
+	}
+	return nil
+}
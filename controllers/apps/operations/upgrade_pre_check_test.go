@@ -0,0 +1,73 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("Upgrade OpsRequest with the pre-checks", func() {
+	const (
+		fromVersion = "5.7.44"
+		toVersion   = "8.0.36"
+	)
+
+	It("runs the pre-checks in the instance and records the results", func() {
+		var (
+			reqCtx = intctrlutil.RequestCtx{Ctx: testCtx.Ctx, Log: logf.FromContext(testCtx.Ctx)}
+			opsRes = &OpsResource{
+				OpsRequest: testapps.NewOpsRequestObj("upgrade-ops", testCtx.DefaultNamespace, "test", appsv1alpha1.UpgradeType),
+				Recorder:   k8sManager.GetEventRecorderFor("opsrequest-controller"),
+			}
+			pod      = testapps.NewPodFactory(testCtx.DefaultNamespace, "test-mysql-0").GetObject()
+			checks   = []string{"diskSpace", "replicationHealth"}
+			recorder = mockLorryClient()
+		)
+		recorder.UpgradePreCheck(gomock.Any(), "diskSpace", fromVersion, toVersion).Return(nil).Times(1)
+		recorder.UpgradePreCheck(gomock.Any(), "replicationHealth", fromVersion, toVersion).
+			Return(errors.New("replication lag is too large")).Times(1)
+
+		failed, err := runUpgradePreChecks(reqCtx, opsRes, pod, consensusComp, checks, fromVersion, toVersion)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(failed).Should(BeTrue())
+		result := getUpgradePreCheckResult(opsRes.OpsRequest, consensusComp, "diskSpace")
+		Expect(result).ShouldNot(BeNil())
+		Expect(result.Pass).Should(BeTrue())
+		result = getUpgradePreCheckResult(opsRes.OpsRequest, consensusComp, "replicationHealth")
+		Expect(result).ShouldNot(BeNil())
+		Expect(result.Pass).Should(BeFalse())
+		Expect(result.Message).Should(Equal("replication lag is too large"))
+
+		By("expect the recorded checks are not performed again")
+		failed, err = runUpgradePreChecks(reqCtx, opsRes, pod, consensusComp, checks, fromVersion, toVersion)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(failed).Should(BeTrue())
+		Expect(opsRes.OpsRequest.Status.PreCheckResults).Should(HaveLen(2))
+	})
+})
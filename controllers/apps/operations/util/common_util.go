@@ -24,6 +24,8 @@ import (
 	"encoding/json"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -110,3 +112,29 @@ func GetOpsRequestFromBackup(backup *dpv1alpha1.Backup) *appsv1alpha1.OpsRecorde
 		Type: appsv1alpha1.OpsType(opsRequestType),
 	}
 }
+
+// GetClustersByStorageClass gets the clusters whose volumes are provisioned by the storage class.
+func GetClustersByStorageClass(ctx context.Context, cli client.Client, storageClassName string) ([]types.NamespacedName, error) {
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	// the PVCs are only read, skip copying them from the cache
+	if err := cli.List(ctx, pvcList, client.MatchingLabels{intctrlutil.AppManagedByLabelKey: intctrlutil.AppName}, client.UnsafeDisableDeepCopy); err != nil {
+		return nil, err
+	}
+	var (
+		clusters   []types.NamespacedName
+		clusterSet = map[types.NamespacedName]struct{}{}
+	)
+	for _, pvc := range pvcList.Items {
+		clusterName := pvc.Labels[intctrlutil.AppInstanceLabelKey]
+		if clusterName == "" || pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName != storageClassName {
+			continue
+		}
+		clusterKey := types.NamespacedName{Namespace: pvc.Namespace, Name: clusterName}
+		if _, ok := clusterSet[clusterKey]; ok {
+			continue
+		}
+		clusterSet[clusterKey] = struct{}{}
+		clusters = append(clusters, clusterKey)
+	}
+	return clusters, nil
+}
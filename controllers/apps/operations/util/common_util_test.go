@@ -20,9 +20,13 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package util
 
 import (
+	"fmt"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -46,6 +50,8 @@ var _ = Describe("OpsRequest Controller", func() {
 		Expect(err).NotTo(HaveOccurred())
 		err = k8sClient.DeleteAllOf(ctx, &appsv1alpha1.OpsRequest{}, client.InNamespace(testCtx.DefaultNamespace), client.HasLabels{testCtx.TestObjLabelKey})
 		Expect(err).NotTo(HaveOccurred())
+		err = k8sClient.DeleteAllOf(ctx, &corev1.PersistentVolumeClaim{}, client.InNamespace(testCtx.DefaultNamespace), client.HasLabels{testCtx.TestObjLabelKey})
+		Expect(err).NotTo(HaveOccurred())
 	}
 
 	BeforeEach(func() {
@@ -116,5 +122,26 @@ var _ = Describe("OpsRequest Controller", func() {
 			}
 			Expect(GetOpsRequestFromBackup(backup)).Should(BeNil())
 		})
+
+		It("Should Test GetClustersByStorageClass", func() {
+			By("mock the pvcs provisioned by different storage classes")
+			for i, scName := range []string{"expandable-sc", "expandable-sc", "standard-sc"} {
+				testapps.NewPersistentVolumeClaimFactory(testCtx.DefaultNamespace, fmt.Sprintf("data-%s-%d", clusterName, i),
+					clusterName, consensusCompName, "data").
+					SetStorage("1Gi").
+					SetStorageClass(scName).
+					Create(&testCtx)
+			}
+
+			By("expect the cluster is found only once by the storage class")
+			clusters, err := GetClustersByStorageClass(ctx, k8sClient, "expandable-sc")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusters).Should(Equal([]types.NamespacedName{{Namespace: testCtx.DefaultNamespace, Name: clusterName}}))
+
+			By("expect no cluster is found by the unused storage class")
+			clusters, err = GetClustersByStorageClass(ctx, k8sClient, "unused-sc")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusters).Should(BeEmpty())
+		})
 	})
 })
@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"fmt"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -45,6 +46,7 @@ func init() {
 		OpsHandler:        vsHandler,
 		QueueByCluster:    true,
 		CancelFunc:        vsHandler.Cancel,
+		RollbackFunc:      vsHandler.Cancel,
 	}
 
 	opsMgr := GetOpsManager()
@@ -74,7 +76,14 @@ func (vs verticalScalingHandler) Action(reqCtx intctrlutil.RequestCtx, cli clien
 		}
 		return nil
 	}
-	compOpsSet := newComponentOpsHelper(opsRes.OpsRequest.Spec.VerticalScalingList)
+	verticalScalingList, err := vs.resolveVerticalScalingList(reqCtx, cli, opsRes)
+	if err != nil {
+		return err
+	}
+	if err = vs.preCheckNodeCapacity(reqCtx, cli, opsRes, verticalScalingList); err != nil {
+		return err
+	}
+	compOpsSet := newComponentOpsHelper(verticalScalingList)
 	// abort earlier running vertical scaling opsRequest.
 	if err := abortEarlierOpsRequestWithSameKind(reqCtx, cli, opsRes, []appsv1alpha1.OpsType{appsv1alpha1.VerticalScalingType},
 		func(earlierOps *appsv1alpha1.OpsRequest) (bool, error) {
@@ -97,7 +106,11 @@ func (vs verticalScalingHandler) Action(reqCtx intctrlutil.RequestCtx, cli clien
 // ReconcileAction will be performed when action is done and loops till OpsRequest.status.phase is Succeed/Failed.
 // the Reconcile function for vertical scaling opsRequest.
 func (vs verticalScalingHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
-	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.VerticalScalingList)
+	verticalScalingList, err := vs.resolveVerticalScalingList(reqCtx, cli, opsRes)
+	if err != nil {
+		return opsRes.OpsRequest.Status.Phase, 0, err
+	}
+	compOpsHelper := newComponentOpsHelper(verticalScalingList)
 	handleComponentStatusProgressForVS := func(
 		reqCtx intctrlutil.RequestCtx,
 		cli client.Client,
@@ -142,6 +155,26 @@ func (vs verticalScalingHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx,
 	return compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes, "vertical scale", handleComponentStatusProgressForVS)
 }
 
+// resolveVerticalScalingList resolves the classes referenced by the verticalScaling list to the compute resources.
+func (vs verticalScalingHandler) resolveVerticalScalingList(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) ([]appsv1alpha1.VerticalScaling, error) {
+	verticalScalingList := make([]appsv1alpha1.VerticalScaling, 0, len(opsRes.OpsRequest.Spec.VerticalScalingList))
+	for _, v := range opsRes.OpsRequest.Spec.VerticalScalingList {
+		if v.ClassRef != nil {
+			compSpec := getComponentSpecOrShardingTemplate(opsRes.Cluster, v.ComponentName)
+			if compSpec == nil {
+				return nil, intctrlutil.NewFatalError(fmt.Sprintf(`component "%s" not found`, v.ComponentName))
+			}
+			class, err := appsv1alpha1.ResolveComponentClass(reqCtx.Ctx, cli, *v.ClassRef, compSpec.ComponentDef)
+			if err != nil {
+				return nil, err
+			}
+			v.ResourceRequirements = class.ToResourceRequirements()
+		}
+		verticalScalingList = append(verticalScalingList, v)
+	}
+	return verticalScalingList, nil
+}
+
 func (vs verticalScalingHandler) verticalScalingComp(verticalScaling appsv1alpha1.VerticalScaling) bool {
 	return len(verticalScaling.Requests) != 0 || len(verticalScaling.Limits) != 0
 }
@@ -185,7 +218,11 @@ func (vs verticalScalingHandler) podApplyCompOps(
 
 // SaveLastConfiguration records last configuration to the OpsRequest.status.lastConfiguration
 func (vs verticalScalingHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
-	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.VerticalScalingList)
+	verticalScalingList, err := vs.resolveVerticalScalingList(reqCtx, cli, opsRes)
+	if err != nil {
+		return err
+	}
+	compOpsHelper := newComponentOpsHelper(verticalScalingList)
 	compOpsHelper.saveLastConfigurations(opsRes, func(compSpec appsv1alpha1.ClusterComponentSpec, comOps ComponentOpsInteface) appsv1alpha1.LastComponentConfiguration {
 		verticalScaling := comOps.(appsv1alpha1.VerticalScaling)
 		var instanceTemplates []appsv1alpha1.InstanceTemplate
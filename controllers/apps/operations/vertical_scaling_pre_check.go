@@ -0,0 +1,144 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+	resourcehelper "k8s.io/kubectl/pkg/util/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlcomp "github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
+	"github.com/apecloud/kubeblocks/pkg/controller/scheduling"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// preCheckNodeCapacity checks whether each instance to be scaled can fit into at least one of the schedulable nodes
+// with the new resources before the cluster is updated, rather than leaving the instances unschedulable halfway
+// through the rolling update.
+//
+// The check respects the node selectors, the required node affinity and the tolerations of the instances, and is
+// optimistic like the capacity pre-check of provisioning: the resources requested by the other pods are not taken
+// into account, so a failure means the instance can never be scheduled with the new resources.
+func (vs verticalScalingHandler) preCheckNodeCapacity(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource,
+	verticalScalingList []appsv1alpha1.VerticalScaling) error {
+	if !viper.GetBool(constant.CfgKeyOpsCapacityPreCheck) {
+		return nil
+	}
+	nodes := &corev1.NodeList{}
+	if err := cli.List(reqCtx.Ctx, nodes); err != nil {
+		if apierrors.IsForbidden(err) {
+			reqCtx.Log.Info("skip the node capacity pre-check since the nodes are not accessible")
+			return nil
+		}
+		return err
+	}
+	for _, verticalScaling := range verticalScalingList {
+		compSpec := getComponentSpecOrShardingTemplate(opsRes.Cluster, verticalScaling.ComponentName)
+		if compSpec == nil {
+			continue
+		}
+		compNames, err := getFullComponentNames(reqCtx, cli, opsRes, verticalScaling.ComponentName)
+		if err != nil {
+			return err
+		}
+		for _, compName := range compNames {
+			pods, err := intctrlcomp.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, opsRes.Cluster.Name, compName)
+			if err != nil {
+				return err
+			}
+			templateNames := getPodTemplateNames(opsRes.Cluster.Name, compName, compSpec)
+			for _, pod := range pods {
+				resources, ok := vs.desiredPodResources(verticalScaling, compSpec, templateNames[pod.Name])
+				if !ok {
+					continue
+				}
+				if requests, fit := podFitsAnyNode(pod, resources, nodes.Items); !fit {
+					return intctrlutil.NewFatalError(fmt.Sprintf(`the instance "%s" of the component "%s" requesting %s can not fit into any of the %d nodes, `+
+						`check the allocatable resources, the taints and the labels of the nodes`,
+						pod.Name, compName, scheduling.FormatResourceList(requests), len(nodes.Items)))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// desiredPodResources returns the resources of the instance after the vertical scaling,
+// and false if the instance is not scaled.
+func (vs verticalScalingHandler) desiredPodResources(verticalScaling appsv1alpha1.VerticalScaling,
+	compSpec *appsv1alpha1.ClusterComponentSpec, templateName string) (corev1.ResourceRequirements, bool) {
+	if templateName != "" {
+		for _, ins := range verticalScaling.Instances {
+			if ins.Name == templateName {
+				return ins.ResourceRequirements, true
+			}
+		}
+		for _, template := range compSpec.Instances {
+			// the instances of the templates having their own resources are not scaled by the component-level resources.
+			if template.Name == templateName && template.Resources != nil {
+				return corev1.ResourceRequirements{}, false
+			}
+		}
+	}
+	if !vs.verticalScalingComp(verticalScaling) {
+		return corev1.ResourceRequirements{}, false
+	}
+	return verticalScaling.ResourceRequirements, true
+}
+
+// getPodTemplateNames returns the names of the instance templates keyed by the names of the pods generated from them.
+func getPodTemplateNames(clusterName, compName string, compSpec *appsv1alpha1.ClusterComponentSpec) map[string]string {
+	templateNames := map[string]string{}
+	workloadName := constant.GenerateWorkloadNamePattern(clusterName, compName)
+	for _, template := range compSpec.Instances {
+		for _, podName := range instanceset.GenerateInstanceNamesFromTemplate(workloadName, template.Name,
+			template.GetReplicas(), compSpec.OfflineInstances) {
+			templateNames[podName] = template.Name
+		}
+	}
+	return templateNames
+}
+
+// podFitsAnyNode checks whether the pod with the new resources of the main container can fit into
+// any of the eligible nodes, it returns the requests of the pod as well.
+func podFitsAnyNode(pod *corev1.Pod, resources corev1.ResourceRequirements, nodes []corev1.Node) (corev1.ResourceList, bool) {
+	pod = pod.DeepCopy()
+	if len(pod.Spec.Containers) > 0 {
+		pod.Spec.Containers[0].Resources = resources
+	}
+	requests, _ := resourcehelper.PodRequestsAndLimits(pod)
+	requests[corev1.ResourcePods] = *resource.NewQuantity(1, resource.DecimalSI)
+	affinity := nodeaffinity.GetRequiredNodeAffinity(pod)
+	for i := range nodes {
+		if scheduling.IsNodeEligible(&nodes[i], pod, affinity) && scheduling.PodsFitOnNode(requests, nodes[i].Status.Allocatable) > 0 {
+			return requests, true
+		}
+	}
+	return requests, false
+}
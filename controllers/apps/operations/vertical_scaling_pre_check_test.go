@@ -0,0 +1,126 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+func TestDesiredPodResources(t *testing.T) {
+	resources := func(cpu string) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}}
+	}
+	templateResources := resources("2")
+	compSpec := &appsv1alpha1.ClusterComponentSpec{
+		Name:     "mysql",
+		Replicas: 3,
+		Instances: []appsv1alpha1.InstanceTemplate{
+			{Name: "large", Replicas: pointer.Int32(1), Resources: &templateResources},
+			{Name: "plain", Replicas: pointer.Int32(1)},
+		},
+	}
+	vs := verticalScalingHandler{}
+	verticalScaling := appsv1alpha1.VerticalScaling{
+		ComponentOps:         appsv1alpha1.ComponentOps{ComponentName: "mysql"},
+		ResourceRequirements: resources("4"),
+	}
+	if r, ok := vs.desiredPodResources(verticalScaling, compSpec, ""); !ok || !r.Requests.Cpu().Equal(resource.MustParse("4")) {
+		t.Errorf("expect the component-level resources, but got: %v, %v", r, ok)
+	}
+	if r, ok := vs.desiredPodResources(verticalScaling, compSpec, "plain"); !ok || !r.Requests.Cpu().Equal(resource.MustParse("4")) {
+		t.Errorf("expect the component-level resources, but got: %v, %v", r, ok)
+	}
+	if _, ok := vs.desiredPodResources(verticalScaling, compSpec, "large"); ok {
+		t.Error("expect the instances of the template having its own resources are not scaled")
+	}
+
+	verticalScaling.ResourceRequirements = corev1.ResourceRequirements{}
+	verticalScaling.Instances = []appsv1alpha1.InstanceResourceTemplate{{Name: "large", ResourceRequirements: resources("8")}}
+	if r, ok := vs.desiredPodResources(verticalScaling, compSpec, "large"); !ok || !r.Requests.Cpu().Equal(resource.MustParse("8")) {
+		t.Errorf("expect the resources of the instance template, but got: %v, %v", r, ok)
+	}
+	if _, ok := vs.desiredPodResources(verticalScaling, compSpec, ""); ok {
+		t.Error("expect the instances are not scaled without the component-level resources")
+	}
+
+	templateNames := getPodTemplateNames("mycluster", "mysql", compSpec)
+	if len(templateNames) != 2 || templateNames["mycluster-mysql-large-0"] != "large" || templateNames["mycluster-mysql-plain-0"] != "plain" {
+		t.Errorf("unexpected template names: %v", templateNames)
+	}
+}
+
+func TestPodFitsAnyNode(t *testing.T) {
+	buildNode := func(name, cpu string, labels map[string]string, taints ...corev1.Taint) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Spec:       corev1.NodeSpec{Taints: taints},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:  resource.MustParse(cpu),
+					corev1.ResourcePods: resource.MustParse("110"),
+				},
+			},
+		}
+	}
+	resources := func(cpu string) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}}
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "mycluster-mysql-0"},
+		Spec: corev1.PodSpec{
+			NodeSelector: map[string]string{"pool": "db"},
+			Containers: []corev1.Container{
+				{Name: "mysql", Resources: resources("1")},
+				{Name: "exporter", Resources: resources("500m")},
+			},
+		},
+	}
+	nodes := []corev1.Node{
+		buildNode("large", "16", map[string]string{"pool": "app"}),
+		buildNode("tainted", "16", map[string]string{"pool": "db"}, corev1.Taint{Key: "dedicated", Effect: corev1.TaintEffectNoSchedule}),
+		buildNode("small", "4", map[string]string{"pool": "db"}),
+	}
+
+	requests, fit := podFitsAnyNode(pod, resources("3"), nodes)
+	if !fit {
+		t.Error("expect the pod fits into the small node")
+	}
+	if !requests.Cpu().Equal(resource.MustParse("3500m")) {
+		t.Errorf("expect the requests of all the containers, but got: %v", requests.Cpu())
+	}
+	if _, fit = podFitsAnyNode(pod, resources("4"), nodes); fit {
+		t.Error("expect the pod can not fit into any node")
+	}
+	if !pod.Spec.Containers[0].Resources.Requests.Cpu().Equal(resource.MustParse("1")) {
+		t.Error("expect the pod is not modified")
+	}
+
+	pod.Spec.Tolerations = []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}}
+	if _, fit = podFitsAnyNode(pod, resources("4"), nodes); !fit {
+		t.Error("expect the pod fits into the tainted node with the toleration")
+	}
+}
@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -116,6 +117,48 @@ var _ = Describe("VerticalScaling OpsRequest", func() {
 			testVerticalScaling(verticalScaling)
 		})
 
+		It("vertical scaling in the dry-run mode", func() {
+			By("init operations resources")
+			opsRes, _, _ := initOperationsResources(clusterDefinitionName, clusterVersionName, clusterName)
+			originalResources := opsRes.Cluster.Spec.GetComponentByName(consensusComp).Resources
+
+			By("create VerticalScaling ops in the dry-run mode")
+			ops := testapps.NewOpsRequestObj("vertical-scaling-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.VerticalScalingType)
+			ops.Spec.DryRun = true
+			ops.Spec.VerticalScalingList = []appsv1alpha1.VerticalScaling{
+				{
+					ComponentOps: appsv1alpha1.ComponentOps{ComponentName: consensusComp},
+					ResourceRequirements: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("400m"),
+						},
+					},
+				},
+			}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
+
+			By("expect the changes are rendered and the OpsRequest succeeds")
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(ops), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+				g.Expect(ops.Status.Phase).Should(Equal(appsv1alpha1.OpsSucceedPhase))
+				g.Expect(ops.Status.DryRunResult).ShouldNot(BeNil())
+				g.Expect(ops.Status.DryRunResult.ObjectChanges).Should(HaveLen(1))
+				change := ops.Status.DryRunResult.ObjectChanges[0]
+				g.Expect(change.Kind).Should(Equal(appsv1alpha1.ClusterKind))
+				g.Expect(change.Name).Should(Equal(clusterName))
+				g.Expect(change.Operation).Should(Equal(appsv1alpha1.DryRunUpdateOperation))
+				g.Expect(change.Diff).Should(ContainSubstring(`"400m"`))
+			})).Should(Succeed())
+
+			By("expect the cluster is not mutated")
+			Consistently(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.Cluster), func(g Gomega, cluster *appsv1alpha1.Cluster) {
+				g.Expect(cluster.Spec.GetComponentByName(consensusComp).Resources).Should(Equal(originalResources))
+			})).Should(Succeed())
+		})
+
 		It("cancel vertical scaling opsRequest", func() {
 			By("init operations resources with CLusterDefinition/ClusterVersion/Hybrid components Cluster/consensus Pods")
 			reqCtx := intctrlutil.RequestCtx{Ctx: ctx}
@@ -188,6 +231,57 @@ var _ = Describe("VerticalScaling OpsRequest", func() {
 			Expect(progressDetail.Message).Should(ContainSubstring("with rollback"))
 		})
 
+		It("rollback vertical scaling opsRequest on failure", func() {
+			By("init operations resources ")
+			opsRes, _, _ := initOperationsResources(clusterDefinitionName, clusterVersionName, clusterName)
+			lastResources := opsRes.Cluster.Spec.GetComponentByName(consensusComp).Resources
+
+			By("create VerticalScaling ops with rollbackOnFailure")
+			ops := testapps.NewOpsRequestObj("vertical-scaling-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.VerticalScalingType)
+			ops.Spec.RollbackOnFailure = true
+			ops.Spec.VerticalScalingList = []appsv1alpha1.VerticalScaling{
+				{
+					ComponentOps: appsv1alpha1.ComponentOps{ComponentName: consensusComp},
+					ResourceRequirements: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("400m"),
+							corev1.ResourceMemory: resource.MustParse("300Mi"),
+						},
+					},
+				},
+			}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
+
+			By("expect the snapshots of all the components are recorded")
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			lastComponents := opsRes.OpsRequest.Status.LastConfiguration.Components
+			Expect(lastComponents).Should(HaveLen(3))
+			Expect(*lastComponents[statelessComp].Replicas).Should(Equal(opsRes.Cluster.Spec.GetComponentByName(statelessComp).Replicas))
+
+			By("scale the resources of the component")
+			vsHandler := verticalScalingHandler{}
+			Expect(vsHandler.Action(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.Cluster), func(g Gomega, cluster *appsv1alpha1.Cluster) {
+				g.Expect(cluster.Spec.GetComponentByName(consensusComp).Resources).Should(Equal(ops.Spec.VerticalScalingList[0].ResourceRequirements))
+			})).Should(Succeed())
+
+			By("expect the resources are rolled back when the opsRequest fails")
+			opsBehaviour := GetOpsManager().OpsMap[appsv1alpha1.VerticalScalingType]
+			failedCondition := appsv1alpha1.NewFailedCondition(opsRes.OpsRequest, nil)
+			Expect(GetOpsManager().handleOpsFailed(reqCtx, k8sClient, opsRes, opsBehaviour,
+				appsv1alpha1.NewCancelFailedCondition(opsRes.OpsRequest, nil), failedCondition)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.Cluster), func(g Gomega, cluster *appsv1alpha1.Cluster) {
+				g.Expect(cluster.Spec.GetComponentByName(consensusComp).Resources).Should(Equal(lastResources))
+			})).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest), func(g Gomega, opsRequest *appsv1alpha1.OpsRequest) {
+				g.Expect(opsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsFailedPhase))
+				g.Expect(meta.IsStatusConditionTrue(opsRequest.Status.Conditions, appsv1alpha1.ConditionTypeRolledBack)).Should(BeTrue())
+			})).Should(Succeed())
+		})
+
 		It("force run vertical scaling opsRequests", func() {
 			By("create the first vertical scaling")
 			verticalScaling1 := []appsv1alpha1.VerticalScaling{
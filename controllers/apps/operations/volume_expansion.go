@@ -21,7 +21,6 @@ package operations
 
 import (
 	"fmt"
-	"reflect"
 	"regexp"
 	"strconv"
 	"time"
@@ -114,7 +113,7 @@ func (ve volumeExpansionOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCt
 		requeueAfter           time.Duration
 		err                    error
 		opsRequestPhase        = appsv1alpha1.OpsRunningPhase
-		oldOpsRequestStatus    = opsRequest.Status.DeepCopy()
+		opsDeepCopy            = opsRequest.DeepCopy()
 		expectProgressCount    int
 		succeedProgressCount   int
 		completedProgressCount int
@@ -126,7 +125,6 @@ func (ve volumeExpansionOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCt
 		}
 		return replicaCount
 	}
-	patch := client.MergeFrom(opsRequest.DeepCopy())
 	if opsRequest.Status.Components == nil {
 		ve.initComponentStatus(opsRequest)
 	}
@@ -203,10 +201,8 @@ func (ve volumeExpansionOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCt
 	}
 	opsRequest.Status.Progress = fmt.Sprintf("%d/%d", completedProgressCount, expectProgressCount)
 	// patch OpsRequest.status.components
-	if !reflect.DeepEqual(*oldOpsRequestStatus, opsRequest.Status) {
-		if err = cli.Status().Patch(reqCtx.Ctx, opsRequest, patch); err != nil {
-			return opsRequestPhase, requeueAfter, err
-		}
+	if err = PatchOpsRequestStatus(reqCtx.Ctx, cli, opsDeepCopy, opsRequest); err != nil {
+		return opsRequestPhase, requeueAfter, err
 	}
 
 	// check all PVCs of volumeClaimTemplate are successful
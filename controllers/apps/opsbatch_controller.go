@@ -0,0 +1,321 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// OpsBatchReconciler reconciles an OpsBatch object
+type OpsBatchReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsbatches,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsbatches/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsbatches/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.14.4/pkg/reconcile
+func (r *OpsBatchReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("opsBatch", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	opsBatch := &appsv1alpha1.OpsBatch{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, opsBatch); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	// the OpsRequests created are not owned by the OpsBatch, deleting the OpsBatch does not affect them.
+	if !opsBatch.DeletionTimestamp.IsZero() || opsBatch.Status.IsTerminalPhase() {
+		return intctrlutil.Reconciled()
+	}
+
+	patch := client.MergeFrom(opsBatch.DeepCopy())
+	requeueAfter, err := r.reconcileOpsBatch(reqCtx, opsBatch)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if err = r.Client.Status().Patch(reqCtx.Ctx, opsBatch, patch); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if requeueAfter > 0 {
+		return intctrlutil.RequeueAfter(requeueAfter, reqCtx.Log, "waiting for the soak time of the wave")
+	}
+	return intctrlutil.Reconciled()
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OpsBatchReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
+		For(&appsv1alpha1.OpsBatch{}).
+		Watches(&appsv1alpha1.OpsRequest{}, handler.EnqueueRequestsFromMapFunc(r.parseOpsBatch)).
+		Complete(r)
+}
+
+func (r *OpsBatchReconciler) parseOpsBatch(_ context.Context, object client.Object) []reconcile.Request {
+	opsBatchName := object.GetLabels()[constant.OpsBatchNameLabelKey]
+	if opsBatchName == "" {
+		return []reconcile.Request{}
+	}
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Namespace: object.GetNamespace(),
+				Name:      opsBatchName,
+			},
+		},
+	}
+}
+
+// reconcileOpsBatch rolls the OpsRequests out wave by wave, it returns the time to wait before starting the next wave.
+func (r *OpsBatchReconciler) reconcileOpsBatch(reqCtx intctrlutil.RequestCtx, opsBatch *appsv1alpha1.OpsBatch) (time.Duration, error) {
+	if len(opsBatch.Status.Clusters) == 0 {
+		if err := r.startOpsBatch(reqCtx, opsBatch); err != nil {
+			return 0, err
+		}
+		if opsBatch.Status.IsTerminalPhase() {
+			return 0, nil
+		}
+	}
+
+	if err := r.syncOpsRequestPhases(reqCtx, opsBatch); err != nil {
+		return 0, err
+	}
+	finished, failed := countFinishedOpsRequests(opsBatch.Status.Clusters)
+	opsBatch.Status.Progress = fmt.Sprintf("%d/%d", finished, len(opsBatch.Status.Clusters))
+	if exceedsFailurePercentage(finished, failed, opsBatch.Spec.WaveStrategy.MaxFailurePercentage) {
+		r.completeOpsBatch(opsBatch, appsv1alpha1.OpsBatchAbortedPhase,
+			fmt.Sprintf("%d of %d finished OpsRequests failed, exceeding the max failure percentage %d%%",
+				failed, finished, opsBatch.Spec.WaveStrategy.MaxFailurePercentage))
+		return 0, nil
+	}
+
+	status := &opsBatch.Status
+	if isWaveFinished(status.Clusters, status.CurrentWave) {
+		status.LastWaveCompletionTimestamp = metav1.Now()
+		if status.CurrentWave+1 >= status.TotalWaves {
+			if failed > 0 {
+				r.completeOpsBatch(opsBatch, appsv1alpha1.OpsBatchFailedPhase,
+					fmt.Sprintf("%d of %d OpsRequests failed", failed, finished))
+			} else {
+				r.completeOpsBatch(opsBatch, appsv1alpha1.OpsBatchSucceedPhase, "")
+			}
+			return 0, nil
+		}
+		status.CurrentWave++
+	}
+
+	if isWaveStarted(status.Clusters, status.CurrentWave) {
+		return 0, nil
+	}
+	if status.CurrentWave > 0 {
+		soakTime := time.Duration(opsBatch.Spec.WaveStrategy.SoakSeconds) * time.Second
+		if remaining := time.Until(status.LastWaveCompletionTimestamp.Add(soakTime)); remaining > 0 {
+			return remaining, nil
+		}
+	}
+	return 0, r.startWave(reqCtx, opsBatch)
+}
+
+// startOpsBatch selects the Clusters and splits them into waves.
+func (r *OpsBatchReconciler) startOpsBatch(reqCtx intctrlutil.RequestCtx, opsBatch *appsv1alpha1.OpsBatch) error {
+	opsBatch.Status.StartTimestamp = metav1.Now()
+	selector, err := metav1.LabelSelectorAsSelector(&opsBatch.Spec.ClusterSelector)
+	if err != nil {
+		r.completeOpsBatch(opsBatch, appsv1alpha1.OpsBatchAbortedPhase, fmt.Sprintf("invalid cluster selector: %s", err.Error()))
+		return nil
+	}
+	var clusterNames []string
+	if err = intctrlutil.ForEachObject(reqCtx.Ctx, r.Client, &appsv1alpha1.ClusterList{}, func(obj client.Object) error {
+		clusterNames = append(clusterNames, obj.GetName())
+		return nil
+	}, client.InNamespace(opsBatch.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return err
+	}
+	if len(clusterNames) == 0 {
+		r.completeOpsBatch(opsBatch, appsv1alpha1.OpsBatchAbortedPhase, "no Cluster is selected")
+		return nil
+	}
+
+	opsBatch.Status.Clusters, opsBatch.Status.TotalWaves = splitClustersIntoWaves(clusterNames,
+		opsBatch.Spec.WaveStrategy.GetWaveSize())
+	opsBatch.Status.CurrentWave = 0
+	opsBatch.Status.Phase = appsv1alpha1.OpsBatchRunningPhase
+	r.Recorder.Eventf(opsBatch, corev1.EventTypeNormal, "OpsBatchStarted",
+		"%d Clusters are selected and split into %d waves", len(clusterNames), opsBatch.Status.TotalWaves)
+	return nil
+}
+
+// startWave creates the OpsRequests for the Clusters of the current wave.
+func (r *OpsBatchReconciler) startWave(reqCtx intctrlutil.RequestCtx, opsBatch *appsv1alpha1.OpsBatch) error {
+	for i, clusterStatus := range opsBatch.Status.Clusters {
+		if clusterStatus.Wave != opsBatch.Status.CurrentWave || clusterStatus.OpsRequestName != "" {
+			continue
+		}
+		opsRequest := buildOpsRequestForCluster(opsBatch, clusterStatus.ClusterName, i)
+		if err := r.Client.Create(reqCtx.Ctx, opsRequest); err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		opsBatch.Status.Clusters[i].OpsRequestName = opsRequest.Name
+		opsBatch.Status.Clusters[i].Phase = appsv1alpha1.OpsPendingPhase
+	}
+	r.Recorder.Eventf(opsBatch, corev1.EventTypeNormal, "WaveStarted", "wave %d of %d is started",
+		opsBatch.Status.CurrentWave+1, opsBatch.Status.TotalWaves)
+	return nil
+}
+
+// syncOpsRequestPhases refreshes the phases of the OpsRequests which are not finished.
+func (r *OpsBatchReconciler) syncOpsRequestPhases(reqCtx intctrlutil.RequestCtx, opsBatch *appsv1alpha1.OpsBatch) error {
+	for i, clusterStatus := range opsBatch.Status.Clusters {
+		if clusterStatus.OpsRequestName == "" || isOpsRequestFinished(clusterStatus.Phase) {
+			continue
+		}
+		opsRequest := &appsv1alpha1.OpsRequest{}
+		err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Namespace: opsBatch.Namespace, Name: clusterStatus.OpsRequestName}, opsRequest)
+		switch {
+		case apierrors.IsNotFound(err):
+			// the OpsRequest is deleted before it finishes, treat it as failed.
+			opsBatch.Status.Clusters[i].Phase = appsv1alpha1.OpsFailedPhase
+		case err != nil:
+			return err
+		case opsRequest.Status.Phase != "":
+			opsBatch.Status.Clusters[i].Phase = opsRequest.Status.Phase
+		}
+	}
+	return nil
+}
+
+func (r *OpsBatchReconciler) completeOpsBatch(opsBatch *appsv1alpha1.OpsBatch, phase appsv1alpha1.OpsBatchPhase, message string) {
+	opsBatch.Status.Phase = phase
+	opsBatch.Status.Message = message
+	opsBatch.Status.CompletionTimestamp = metav1.Now()
+	eventType := corev1.EventTypeNormal
+	if phase != appsv1alpha1.OpsBatchSucceedPhase {
+		eventType = corev1.EventTypeWarning
+	}
+	r.Recorder.Eventf(opsBatch, eventType, "OpsBatch"+string(phase), "OpsBatch is %s. %s", phase, message)
+}
+
+// splitClustersIntoWaves sorts the Clusters by name and assigns them to waves of the specified size.
+func splitClustersIntoWaves(clusterNames []string, waveSize int32) ([]appsv1alpha1.OpsBatchClusterStatus, int32) {
+	sort.Strings(clusterNames)
+	clusters := make([]appsv1alpha1.OpsBatchClusterStatus, 0, len(clusterNames))
+	for i, name := range clusterNames {
+		clusters = append(clusters, appsv1alpha1.OpsBatchClusterStatus{
+			ClusterName: name,
+			Wave:        int32(i) / waveSize,
+		})
+	}
+	totalWaves := (int32(len(clusterNames)) + waveSize - 1) / waveSize
+	return clusters, totalWaves
+}
+
+func buildOpsRequestForCluster(opsBatch *appsv1alpha1.OpsBatch, clusterName string, index int) *appsv1alpha1.OpsRequest {
+	opsRequest := &appsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			// use a deterministic name to avoid creating the OpsRequest repeatedly.
+			Name:      fmt.Sprintf("%s-%d", opsBatch.Name, index),
+			Namespace: opsBatch.Namespace,
+			Labels:    map[string]string{constant.OpsBatchNameLabelKey: opsBatch.Name},
+		},
+		Spec: *opsBatch.Spec.OpsTemplate.DeepCopy(),
+	}
+	opsRequest.Spec.ClusterName = clusterName
+	return opsRequest
+}
+
+func isOpsRequestFinished(phase appsv1alpha1.OpsPhase) bool {
+	return isOpsRequestSucceed(phase) || isOpsRequestFailed(phase)
+}
+
+func isOpsRequestSucceed(phase appsv1alpha1.OpsPhase) bool {
+	return phase == appsv1alpha1.OpsSucceedPhase || phase == appsv1alpha1.OpsSucceedWithWarningsPhase
+}
+
+func isOpsRequestFailed(phase appsv1alpha1.OpsPhase) bool {
+	switch phase {
+	case appsv1alpha1.OpsFailedPhase, appsv1alpha1.OpsCancelledPhase, appsv1alpha1.OpsAbortedPhase:
+		return true
+	}
+	return false
+}
+
+func countFinishedOpsRequests(clusters []appsv1alpha1.OpsBatchClusterStatus) (int, int) {
+	var finished, failed int
+	for _, clusterStatus := range clusters {
+		if isOpsRequestFinished(clusterStatus.Phase) {
+			finished++
+		}
+		if isOpsRequestFailed(clusterStatus.Phase) {
+			failed++
+		}
+	}
+	return finished, failed
+}
+
+func exceedsFailurePercentage(finished, failed int, maxFailurePercentage int32) bool {
+	return failed > 0 && failed*100 > int(maxFailurePercentage)*finished
+}
+
+func isWaveStarted(clusters []appsv1alpha1.OpsBatchClusterStatus, wave int32) bool {
+	for _, clusterStatus := range clusters {
+		if clusterStatus.Wave == wave && clusterStatus.OpsRequestName == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func isWaveFinished(clusters []appsv1alpha1.OpsBatchClusterStatus, wave int32) bool {
+	for _, clusterStatus := range clusters {
+		if clusterStatus.Wave == wave && !isOpsRequestFinished(clusterStatus.Phase) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestSplitClustersIntoWaves(t *testing.T) {
+	clusters, totalWaves := splitClustersIntoWaves([]string{"mysql-c", "mysql-a", "mysql-e", "mysql-b", "mysql-d"}, 2)
+	assert.Equal(t, int32(3), totalWaves)
+	assert.Equal(t, []appsv1alpha1.OpsBatchClusterStatus{
+		{ClusterName: "mysql-a", Wave: 0},
+		{ClusterName: "mysql-b", Wave: 0},
+		{ClusterName: "mysql-c", Wave: 1},
+		{ClusterName: "mysql-d", Wave: 1},
+		{ClusterName: "mysql-e", Wave: 2},
+	}, clusters)
+
+	_, totalWaves = splitClustersIntoWaves([]string{"mysql-a", "mysql-b"}, 2)
+	assert.Equal(t, int32(1), totalWaves)
+}
+
+func TestOpsBatchWaves(t *testing.T) {
+	clusters := []appsv1alpha1.OpsBatchClusterStatus{
+		{ClusterName: "mysql-a", Wave: 0, OpsRequestName: "batch-0", Phase: appsv1alpha1.OpsSucceedPhase},
+		{ClusterName: "mysql-b", Wave: 0, OpsRequestName: "batch-1", Phase: appsv1alpha1.OpsRunningPhase},
+		{ClusterName: "mysql-c", Wave: 1},
+	}
+	assert.True(t, isWaveStarted(clusters, 0))
+	assert.False(t, isWaveFinished(clusters, 0))
+	assert.False(t, isWaveStarted(clusters, 1))
+
+	clusters[1].Phase = appsv1alpha1.OpsFailedPhase
+	assert.True(t, isWaveFinished(clusters, 0))
+	finished, failed := countFinishedOpsRequests(clusters)
+	assert.Equal(t, 2, finished)
+	assert.Equal(t, 1, failed)
+	assert.True(t, exceedsFailurePercentage(finished, failed, 0))
+	assert.True(t, exceedsFailurePercentage(finished, failed, 49))
+	assert.False(t, exceedsFailurePercentage(finished, failed, 50))
+	assert.False(t, exceedsFailurePercentage(finished, 0, 0))
+}
+
+func TestBuildOpsRequestForCluster(t *testing.T) {
+	opsBatch := &appsv1alpha1.OpsBatch{
+		ObjectMeta: metav1.ObjectMeta{Name: "batch-restart", Namespace: "default"},
+		Spec: appsv1alpha1.OpsBatchSpec{
+			OpsTemplate: appsv1alpha1.OpsRequestSpec{
+				Type: appsv1alpha1.RestartType,
+				SpecificOpsRequest: appsv1alpha1.SpecificOpsRequest{
+					RestartList: []appsv1alpha1.ComponentOps{{ComponentName: "mysql"}},
+				},
+			},
+		},
+	}
+	opsRequest := buildOpsRequestForCluster(opsBatch, "mysql-a", 3)
+	assert.Equal(t, "batch-restart-3", opsRequest.Name)
+	assert.Equal(t, "default", opsRequest.Namespace)
+	assert.Equal(t, "batch-restart", opsRequest.Labels[constant.OpsBatchNameLabelKey])
+	assert.Equal(t, "mysql-a", opsRequest.Spec.ClusterName)
+	assert.Equal(t, appsv1alpha1.RestartType, opsRequest.Spec.Type)
+	// the template is not changed
+	assert.Empty(t, opsBatch.Spec.OpsTemplate.ClusterName)
+}
@@ -22,13 +22,14 @@ package apps
 import (
 	"context"
 	"math"
-	"reflect"
+	"sort"
 	"strings"
 	"time"
 
 	"golang.org/x/exp/slices"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -62,6 +63,12 @@ type OpsRequestReconciler struct {
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequests,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequests/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequests/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=pods/ephemeralcontainers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=pods/eviction,verbs=create
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=core,resources=resourcequotas,verbs=get;list;watch
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=csistoragecapacities,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -87,6 +94,12 @@ func (r *OpsRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *OpsRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := operations.GetOpsManager().LoadBehaviourConfigs(viper.GetString(constant.CfgKeyOpsBehaviours)); err != nil {
+		return err
+	}
+	if err := operations.GetOpsManager().LoadNodeConcurrencyPolicy(viper.GetString(constant.CfgKeyOpsNodeConcurrency)); err != nil {
+		return err
+	}
 	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
 		For(&appsv1alpha1.OpsRequest{}).
 		WithOptions(controller.Options{
@@ -95,8 +108,9 @@ func (r *OpsRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&appsv1alpha1.Cluster{}, handler.EnqueueRequestsFromMapFunc(r.parseRunningOpsRequests)).
 		Watches(&workloadsv1alpha1.InstanceSet{}, handler.EnqueueRequestsFromMapFunc(r.parseRunningOpsRequestsForInstanceSet)).
 		Watches(&dpv1alpha1.Backup{}, handler.EnqueueRequestsFromMapFunc(r.parseBackupOpsRequest)).
-		Watches(&corev1.PersistentVolumeClaim{}, handler.EnqueueRequestsFromMapFunc(r.parseVolumeExpansionOpsRequest)).
+		Watches(&corev1.PersistentVolumeClaim{}, handler.EnqueueRequestsFromMapFunc(r.parsePVC)).
 		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.parsePod)).
+		Watches(&storagev1.StorageClass{}, handler.EnqueueRequestsFromMapFunc(r.parseStorageClass)).
 		Owns(&batchv1.Job{}).
 		Owns(&dpv1alpha1.Restore{}).
 		Complete(r)
@@ -128,6 +142,9 @@ func (r *OpsRequestReconciler) handleDeletion(reqCtx intctrlutil.RequestCtx, ops
 		if err := r.deleteCreatedPodsInKBNamespace(reqCtx, opsRes.OpsRequest); err != nil {
 			return nil, err
 		}
+		if err := operations.ReleaseDrainingInstanceSets(reqCtx, r.Client, opsRes.OpsRequest); err != nil {
+			return nil, err
+		}
 		return nil, operations.DequeueOpsRequestInClusterAnnotation(reqCtx.Ctx, r.Client, opsRes)
 	})
 }
@@ -139,7 +156,7 @@ func (r *OpsRequestReconciler) fetchCluster(reqCtx intctrlutil.RequestCtx, opsRe
 	if !ok || opsBehaviour.OpsHandler == nil {
 		return nil, operations.PatchOpsHandlerNotSupported(reqCtx.Ctx, r.Client, opsRes)
 	}
-	if opsBehaviour.IsClusterCreation {
+	if opsBehaviour.CreatesCluster(opsRes.OpsRequest) {
 		// check if the cluster already exists
 		cluster.Name = opsRes.OpsRequest.Spec.GetClusterName()
 		cluster.Namespace = opsRes.OpsRequest.GetNamespace()
@@ -157,6 +174,16 @@ func (r *OpsRequestReconciler) fetchCluster(reqCtx intctrlutil.RequestCtx, opsRe
 	}
 	// set cluster variable
 	opsRes.Cluster = cluster
+	if !cluster.DeletionTimestamp.IsZero() {
+		switch opsRes.OpsRequest.Status.Phase {
+		case "", appsv1alpha1.OpsPendingPhase, appsv1alpha1.OpsScheduledPhase:
+			// the OpsRequest which has not started will never run, fail or cancel it instead of waiting forever.
+			if err := operations.PatchClusterTerminating(reqCtx.Ctx, r.Client, opsRes); err != nil {
+				return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
+			}
+			return intctrlutil.ResultToP(intctrlutil.Reconciled())
+		}
+	}
 	return nil, nil
 }
 
@@ -164,23 +191,25 @@ func (r *OpsRequestReconciler) fetchCluster(reqCtx intctrlutil.RequestCtx, opsRe
 func (r *OpsRequestReconciler) handleOpsRequestByPhase(reqCtx intctrlutil.RequestCtx, opsRes *operations.OpsResource) (*ctrl.Result, error) {
 	switch opsRes.OpsRequest.Status.Phase {
 	case "":
-		// update status.phase to pending
-		if err := operations.PatchOpsStatus(reqCtx.Ctx, r.Client, opsRes, appsv1alpha1.OpsPendingPhase,
+		// classify the impact of the OpsRequest before it is executed, and update status.phase to pending
+		opsDeepCopy := opsRes.OpsRequest.DeepCopy()
+		opsRes.OpsRequest.Status.Impact, opsRes.OpsRequest.Status.ImpactMessage = opsRes.OpsRequest.ClassifyImpact(reqCtx.Ctx, r.Client, opsRes.Cluster)
+		if err := operations.PatchOpsStatusWithOpsDeepCopy(reqCtx.Ctx, r.Client, opsRes, opsDeepCopy, appsv1alpha1.OpsPendingPhase,
 			appsv1alpha1.NewWaitForProcessingCondition(opsRes.OpsRequest)); err != nil {
 			return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
 		}
 		return intctrlutil.ResultToP(intctrlutil.Reconciled())
-	case appsv1alpha1.OpsPendingPhase, appsv1alpha1.OpsCreatingPhase:
+	case appsv1alpha1.OpsPendingPhase, appsv1alpha1.OpsScheduledPhase, appsv1alpha1.OpsCreatingPhase:
 		return r.doOpsRequestAction(reqCtx, opsRes)
 	case appsv1alpha1.OpsRunningPhase, appsv1alpha1.OpsCancellingPhase:
 		return r.reconcileStatusDuringRunningOrCanceling(reqCtx, opsRes)
-	case appsv1alpha1.OpsSucceedPhase:
+	case appsv1alpha1.OpsSucceedPhase, appsv1alpha1.OpsSucceedWithWarningsPhase:
 		return r.handleSucceedOpsRequest(reqCtx, opsRes.OpsRequest)
 	default:
 		if err := r.annotateRelatedOps(reqCtx, opsRes.OpsRequest); err != nil {
 			return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
 		}
-		return intctrlutil.ResultToP(intctrlutil.Reconciled())
+		return r.deleteExpiredOpsRequest(reqCtx, opsRes.OpsRequest)
 	}
 }
 
@@ -193,7 +222,7 @@ func (r *OpsRequestReconciler) handleCancelSignal(reqCtx intctrlutil.RequestCtx,
 	if opsRequest.IsComplete() || opsRequest.Status.Phase == appsv1alpha1.OpsCancellingPhase {
 		return nil, nil
 	}
-	if opsRequest.Status.Phase == appsv1alpha1.OpsPendingPhase {
+	if opsRequest.Status.Phase == appsv1alpha1.OpsPendingPhase || opsRequest.Status.Phase == appsv1alpha1.OpsScheduledPhase {
 		return &ctrl.Result{}, operations.PatchOpsStatus(reqCtx.Ctx, r.Client, opsRes, appsv1alpha1.OpsCancelledPhase)
 	}
 	opsBehaviour := operations.GetOpsManager().OpsMap[opsRequest.Spec.Type]
@@ -227,20 +256,77 @@ func (r *OpsRequestReconciler) handleSucceedOpsRequest(reqCtx intctrlutil.Reques
 	if err := r.deleteExternalJobs(reqCtx.Ctx, opsRequest); err != nil {
 		return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
 	}
-	if opsRequest.Status.CompletionTimestamp.IsZero() || opsRequest.Spec.TTLSecondsAfterSucceed == 0 {
+	return r.deleteExpiredOpsRequest(reqCtx, opsRequest)
+}
+
+// deleteExpiredOpsRequest deletes the completed opsRequest when its ttl expires.
+// the succeeded opsRequest is deleted after spec.ttlSecondsAfterSucceed seconds if it is set,
+// otherwise the completed opsRequest is deleted after spec.ttlSecondsAfterFinished seconds
+// unless it is one of the most recent completed opsRequests of the cluster.
+func (r *OpsRequestReconciler) deleteExpiredOpsRequest(reqCtx intctrlutil.RequestCtx, opsRequest *appsv1alpha1.OpsRequest) (*ctrl.Result, error) {
+	ttlSeconds := opsRequest.Spec.TTLSecondsAfterFinished
+	keepHistory := true
+	if opsRequest.Spec.TTLSecondsAfterSucceed != 0 && slices.Contains([]appsv1alpha1.OpsPhase{appsv1alpha1.OpsSucceedPhase,
+		appsv1alpha1.OpsSucceedWithWarningsPhase}, opsRequest.Status.Phase) {
+		ttlSeconds = opsRequest.Spec.TTLSecondsAfterSucceed
+		keepHistory = false
+	}
+	if opsRequest.Status.CompletionTimestamp.IsZero() || ttlSeconds == 0 {
 		return intctrlutil.ResultToP(intctrlutil.Reconciled())
 	}
-	deadline := opsRequest.Status.CompletionTimestamp.Add(time.Duration(opsRequest.Spec.TTLSecondsAfterSucceed) * time.Second)
+	deadline := opsRequest.Status.CompletionTimestamp.Add(time.Duration(ttlSeconds) * time.Second)
 	if time.Now().Before(deadline) {
 		return intctrlutil.ResultToP(intctrlutil.RequeueAfter(time.Until(deadline), reqCtx.Log, ""))
 	}
-	// the opsRequest will be deleted after spec.ttlSecondsAfterSucceed seconds when status.phase is Succeed
-	if err := r.Client.Delete(reqCtx.Ctx, opsRequest); err != nil {
+	if keepHistory {
+		retained, err := r.isRetainedOpsHistory(reqCtx, opsRequest)
+		if err != nil {
+			return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
+		}
+		if retained {
+			// check it again later, the newer opsRequests may complete and push it out of the history.
+			return intctrlutil.ResultToP(intctrlutil.RequeueAfter(opsHistoryRecheckInterval, reqCtx.Log, ""))
+		}
+	}
+	if err := r.Client.Delete(reqCtx.Ctx, opsRequest); err != nil && !apierrors.IsNotFound(err) {
 		return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
 	}
 	return intctrlutil.ResultToP(intctrlutil.Reconciled())
 }
 
+// isRetainedOpsHistory checks if the opsRequest is one of the most recent completed opsRequests of the cluster,
+// which are retained regardless of spec.ttlSecondsAfterFinished.
+func (r *OpsRequestReconciler) isRetainedOpsHistory(reqCtx intctrlutil.RequestCtx, opsRequest *appsv1alpha1.OpsRequest) (bool, error) {
+	historyLimit := viper.GetInt(constant.CfgKeyOpsHistoryLimit)
+	if historyLimit <= 0 {
+		return false, nil
+	}
+	opsList := &appsv1alpha1.OpsRequestList{}
+	if err := r.Client.List(reqCtx.Ctx, opsList, client.InNamespace(opsRequest.Namespace),
+		client.MatchingLabels{constant.AppInstanceLabelKey: opsRequest.Spec.GetClusterName()}); err != nil {
+		return false, err
+	}
+	completedOps := make([]appsv1alpha1.OpsRequest, 0, len(opsList.Items))
+	for _, ops := range opsList.Items {
+		if ops.IsComplete() && !ops.Status.CompletionTimestamp.IsZero() && ops.DeletionTimestamp.IsZero() {
+			completedOps = append(completedOps, ops)
+		}
+	}
+	sort.Slice(completedOps, func(i, j int) bool {
+		ti, tj := completedOps[i].Status.CompletionTimestamp, completedOps[j].Status.CompletionTimestamp
+		if !ti.Equal(&tj) {
+			return tj.Before(&ti)
+		}
+		return completedOps[i].Name < completedOps[j].Name
+	})
+	for i := 0; i < len(completedOps) && i < historyLimit; i++ {
+		if completedOps[i].Name == opsRequest.Name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // reconcileStatusDuringRunningOrCanceling reconciles the status of OpsRequest when it is running or canceling.
 func (r *OpsRequestReconciler) reconcileStatusDuringRunningOrCanceling(reqCtx intctrlutil.RequestCtx, opsRes *operations.OpsResource) (*ctrl.Result, error) {
 	opsRequest := opsRes.OpsRequest
@@ -263,7 +349,7 @@ func (r *OpsRequestReconciler) addClusterLabelAndSetOwnerReference(reqCtx intctr
 	// so don't add label and set owner reference in here
 	// it should be done in this opsRequest action
 	opsBehaviour := operations.GetOpsManager().OpsMap[opsRes.OpsRequest.Spec.Type]
-	if opsBehaviour.IsClusterCreation {
+	if opsBehaviour.CreatesCluster(opsRes.OpsRequest) {
 		return nil, nil
 	}
 
@@ -289,6 +375,10 @@ func (r *OpsRequestReconciler) addClusterLabelAndSetOwnerReference(reqCtx intctr
 	if opsRequest.Spec.ClusterName == "" {
 		opsRequest.Spec.ClusterName = opsRequest.Spec.ClusterRef
 	}
+	// keep the clusterRef in sync, so that both spec.clusterName and spec.clusterRef can be used as field selectors.
+	if opsRequest.Spec.ClusterRef == "" {
+		opsRequest.Spec.ClusterRef = opsRequest.Spec.ClusterName
+	}
 	if err := r.Client.Patch(reqCtx.Ctx, opsRequest, patch); err != nil {
 		return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
 	}
@@ -305,10 +395,8 @@ func (r *OpsRequestReconciler) doOpsRequestAction(reqCtx intctrlutil.RequestCtx,
 		if !apierrors.IsConflict(err) {
 			r.Recorder.Eventf(opsRequest, corev1.EventTypeWarning, reasonOpsDoActionFailed, "Failed to process the operation of OpsRequest: %s", err.Error())
 		}
-		if !reflect.DeepEqual(opsRequest.Status, opsDeepCopy.Status) {
-			if patchErr := r.Client.Status().Patch(reqCtx.Ctx, opsRequest, client.MergeFrom(opsDeepCopy)); patchErr != nil {
-				return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
-			}
+		if patchErr := operations.PatchOpsRequestStatus(reqCtx.Ctx, r.Client, opsDeepCopy, opsRequest); patchErr != nil {
+			return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
 		}
 		return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
 	}
@@ -318,7 +406,7 @@ func (r *OpsRequestReconciler) doOpsRequestAction(reqCtx intctrlutil.RequestCtx,
 	}
 	opsRequest.Status.Phase = appsv1alpha1.OpsRunningPhase
 	opsRequest.Status.ClusterGeneration = opsRes.Cluster.Generation
-	if err = r.Client.Status().Patch(reqCtx.Ctx, opsRequest, client.MergeFrom(opsDeepCopy)); err != nil {
+	if err = operations.PatchOpsRequestStatus(reqCtx.Ctx, r.Client, opsDeepCopy, opsRequest); err != nil {
 		return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
 	}
 	return intctrlutil.ResultToP(intctrlutil.Reconciled())
@@ -326,21 +414,20 @@ func (r *OpsRequestReconciler) doOpsRequestAction(reqCtx intctrlutil.RequestCtx,
 
 // handleOpsReqDeletedDuringRunning handles the cluster annotation if the OpsRequest is deleted during running.
 func (r *OpsRequestReconciler) handleOpsReqDeletedDuringRunning(reqCtx intctrlutil.RequestCtx) error {
-	clusterList := &appsv1alpha1.ClusterList{}
-	if err := r.Client.List(reqCtx.Ctx, clusterList, client.InNamespace(reqCtx.Req.Namespace)); err != nil {
-		return err
-	}
-	for _, cluster := range clusterList.Items {
-		opsRequestSlice, _ := opsutil.GetOpsRequestSliceFromCluster(&cluster)
+	return intctrlutil.ForEachObject(reqCtx.Ctx, r.Client, &appsv1alpha1.ClusterList{}, func(obj client.Object) error {
+		cluster := obj.(*appsv1alpha1.Cluster)
+		opsRequestSlice, _ := opsutil.GetOpsRequestSliceFromCluster(cluster)
 		index, _ := operations.GetOpsRecorderFromSlice(opsRequestSlice, reqCtx.Req.Name)
 		if index == -1 {
-			continue
+			return nil
 		}
 		// if the OpsRequest is abnormal, we should clear the OpsRequest annotation in referencing cluster.
 		opsRequestSlice = slices.Delete(opsRequestSlice, index, index+1)
-		return opsutil.UpdateClusterOpsAnnotations(reqCtx.Ctx, r.Client, &cluster, opsRequestSlice)
-	}
-	return nil
+		if err := opsutil.UpdateClusterOpsAnnotations(reqCtx.Ctx, r.Client, cluster, opsRequestSlice); err != nil {
+			return err
+		}
+		return intctrlutil.ErrStopListing
+	}, client.InNamespace(reqCtx.Req.Namespace))
 }
 
 func (r *OpsRequestReconciler) getRunningOpsRequestsFromCluster(cluster *appsv1alpha1.Cluster) []reconcile.Request {
@@ -348,43 +435,19 @@ func (r *OpsRequestReconciler) getRunningOpsRequestsFromCluster(cluster *appsv1a
 		opsRequestSlice []appsv1alpha1.OpsRecorder
 		err             error
 		requests        []reconcile.Request
-		clusterType     = "cluster"
-		typeSet         = map[string]appsv1alpha1.OpsRecorder{}
 	)
 	if opsRequestSlice, err = opsutil.GetOpsRequestSliceFromCluster(cluster); err != nil {
 		return nil
 	}
+	// append the running opsRequests and the queued opsRequests, the first queued opsRequest of each queue scope
+	// will be started once no other opsRequests are running, and the others will refresh their queue positions.
 	for i := range opsRequestSlice {
-		ops := opsRequestSlice[i]
-		if !ops.InQueue {
-			// append running opsRequest
-			requests = append(requests, reconcile.Request{
-				NamespacedName: types.NamespacedName{
-					Namespace: cluster.Namespace,
-					Name:      ops.Name,
-				},
-			})
-		}
-		opsType := string(ops.Type)
-		if !opsRequestSlice[i].QueueBySelf {
-			// If opsRequest is not the type-scope queue, unified as "cluster" scope.
-			opsType = clusterType
-		}
-		if _, ok := typeSet[opsType]; !ok {
-			typeSet[opsType] = ops
-		}
-	}
-
-	for _, v := range typeSet {
-		if v.InQueue {
-			// append the first opsRequest which is in the queue.
-			requests = append(requests, reconcile.Request{
-				NamespacedName: types.NamespacedName{
-					Namespace: cluster.Namespace,
-					Name:      v.Name,
-				},
-			})
-		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: cluster.Namespace,
+				Name:      opsRequestSlice[i].Name,
+			},
+		})
 	}
 	return requests
 }
@@ -394,20 +457,26 @@ func (r *OpsRequestReconciler) parseRunningOpsRequests(ctx context.Context, obje
 	return r.getRunningOpsRequestsFromCluster(cluster)
 }
 
-func (r *OpsRequestReconciler) parseRunningOpsRequestsForInstanceSet(ctx context.Context, object client.Object) []reconcile.Request {
-	its := object.(*workloadsv1alpha1.InstanceSet)
-	clusterName := its.Labels[constant.AppInstanceLabelKey]
-	if clusterName == "" {
+// getRunningOpsRequestsByClusterKey gets the running OpsRequests of the cluster by its key.
+func (r *OpsRequestReconciler) getRunningOpsRequestsByClusterKey(ctx context.Context, clusterKey types.NamespacedName) []reconcile.Request {
+	if clusterKey.Name == "" {
 		return nil
 	}
 	cluster := &appsv1alpha1.Cluster{}
-	if err := r.Client.Get(ctx, client.ObjectKey{Name: clusterName, Namespace: its.Namespace}, cluster); err != nil {
+	if err := r.Client.Get(ctx, clusterKey, cluster); err != nil {
 		return nil
 	}
 	return r.getRunningOpsRequestsFromCluster(cluster)
 }
 
-func (r *OpsRequestReconciler) parseVolumeExpansionOpsRequest(ctx context.Context, object client.Object) []reconcile.Request {
+func (r *OpsRequestReconciler) parseRunningOpsRequestsForInstanceSet(ctx context.Context, object client.Object) []reconcile.Request {
+	its := object.(*workloadsv1alpha1.InstanceSet)
+	return r.getRunningOpsRequestsByClusterKey(ctx, types.NamespacedName{Namespace: its.Namespace, Name: its.Labels[constant.AppInstanceLabelKey]})
+}
+
+// parsePVC triggers the running OpsRequests of the cluster that the PVC belongs to,
+// so that the resizing and the binding of PVCs are handled without polling.
+func (r *OpsRequestReconciler) parsePVC(ctx context.Context, object client.Object) []reconcile.Request {
 	pvc := object.(*corev1.PersistentVolumeClaim)
 	if pvc.Labels[constant.AppManagedByLabelKey] != constant.AppName {
 		return nil
@@ -416,19 +485,37 @@ func (r *OpsRequestReconciler) parseVolumeExpansionOpsRequest(ctx context.Contex
 	if clusterName == "" {
 		return nil
 	}
+	requests := r.getRunningOpsRequestsByClusterKey(ctx, types.NamespacedName{Namespace: pvc.Namespace, Name: clusterName})
+	// the VolumeExpansion OpsRequests may not be recorded in the cluster when they are forced to run.
 	opsRequestList, err := appsv1alpha1.GetRunningOpsByOpsType(ctx, r.Client,
-		pvc.Labels[constant.AppInstanceLabelKey], pvc.Namespace, string(appsv1alpha1.VolumeExpansionType))
+		clusterName, pvc.Namespace, string(appsv1alpha1.VolumeExpansionType))
 	if err != nil {
-		return nil
+		return requests
 	}
-	var requests []reconcile.Request
 	for _, v := range opsRequestList {
-		requests = append(requests, reconcile.Request{
+		request := reconcile.Request{
 			NamespacedName: types.NamespacedName{
 				Namespace: v.Namespace,
 				Name:      v.Name,
 			},
-		})
+		}
+		if !slices.Contains(requests, request) {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+// parseStorageClass triggers the running OpsRequests of the clusters whose volumes are provisioned by the StorageClass,
+// such as the VolumeExpansion waiting for the StorageClass to allow volume expansion.
+func (r *OpsRequestReconciler) parseStorageClass(ctx context.Context, object client.Object) []reconcile.Request {
+	clusterKeys, err := opsutil.GetClustersByStorageClass(ctx, r.Client, object.GetName())
+	if err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, clusterKey := range clusterKeys {
+		requests = append(requests, r.getRunningOpsRequestsByClusterKey(ctx, clusterKey)...)
 	}
 	return requests
 }
@@ -478,6 +565,11 @@ func (r *OpsRequestReconciler) parsePod(ctx context.Context, object client.Objec
 			},
 		})
 	}
+	// trigger the running OpsRequests of the cluster when the state of its pods changes, such as the readiness.
+	if pod.Labels[constant.AppManagedByLabelKey] == constant.AppName {
+		requests = append(requests, r.getRunningOpsRequestsByClusterKey(ctx,
+			types.NamespacedName{Namespace: pod.Namespace, Name: pod.Labels[constant.AppInstanceLabelKey]})...)
+	}
 	return requests
 }
 
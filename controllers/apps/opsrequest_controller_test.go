@@ -49,6 +49,7 @@ import (
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
 	testdp "github.com/apecloud/kubeblocks/pkg/testutil/dataprotection"
 	testk8s "github.com/apecloud/kubeblocks/pkg/testutil/k8s"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 var _ = Describe("OpsRequest Controller", func() {
@@ -812,6 +813,51 @@ var _ = Describe("OpsRequest Controller", func() {
 			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(ops3))).Should(Equal(appsv1alpha1.OpsSucceedPhase))
 		})
 
+		It("test opsRequest ttlSecondsAfterFinished", func() {
+			By("retain one completed opsRequest per cluster")
+			viper.Set(constant.CfgKeyOpsHistoryLimit, 1)
+			defer viper.Set(constant.CfgKeyOpsHistoryLimit, 3)
+
+			By("create cluster and mock it to running")
+			replicas := int32(3)
+			createMysqlCluster(replicas)
+			mockCompRunning(replicas, false)
+
+			createTTLRestartOps := func(index int, force bool) *appsv1alpha1.OpsRequest {
+				ops := testapps.NewOpsRequestObj(fmt.Sprintf("ttl-restart-ops-%d", index), testCtx.DefaultNamespace,
+					clusterObj.Name, appsv1alpha1.RestartType)
+				ops.Spec.RestartList = []appsv1alpha1.ComponentOps{{ComponentName: mysqlCompName}}
+				ops.Spec.TTLSecondsAfterFinished = 1
+				ops.Spec.Force = force
+				return testapps.CreateOpsRequest(ctx, testCtx, ops)
+			}
+
+			By("create the first restart ops and abort it by the second one with force flag")
+			time.Sleep(time.Second)
+			ops1 := createTTLRestartOps(1, false)
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(ops1))).Should(Equal(appsv1alpha1.OpsRunningPhase))
+			ops2 := createTTLRestartOps(2, true)
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(ops2))).Should(Equal(appsv1alpha1.OpsRunningPhase))
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(ops1))).Should(Equal(appsv1alpha1.OpsAbortedPhase))
+
+			By("expect the aborted ops to be retained as the most recent completed one")
+			Consistently(testapps.CheckObjExists(&testCtx, client.ObjectKeyFromObject(ops1), &appsv1alpha1.OpsRequest{}, true)).Should(Succeed())
+
+			By("mock component to running and expect ops2 phase to Succeed")
+			mockCompRunning(replicas, true)
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(ops2))).Should(Equal(appsv1alpha1.OpsSucceedPhase))
+
+			By("notice opsrequest controller to recheck the aborted ops and expect it to be deleted")
+			Expect(testapps.ChangeObj(&testCtx, ops1, func(lopsReq *appsv1alpha1.OpsRequest) {
+				if lopsReq.Annotations == nil {
+					lopsReq.Annotations = map[string]string{}
+				}
+				lopsReq.Annotations[constant.ReconcileAnnotationKey] = time.Now().Format(time.RFC3339Nano)
+			})).ShouldNot(HaveOccurred())
+			Eventually(testapps.CheckObjExists(&testCtx, client.ObjectKeyFromObject(ops1), &appsv1alpha1.OpsRequest{}, false)).Should(Succeed())
+			Consistently(testapps.CheckObjExists(&testCtx, client.ObjectKeyFromObject(ops2), &appsv1alpha1.OpsRequest{}, true)).Should(Succeed())
+		})
+
 		It("test opsRequest queue for QueueBySelf", func() {
 			By("create cluster and mock it to running")
 			replicas := int32(3)
@@ -350,6 +350,7 @@ func (r *clusterBackupPolicyTransformer) syncBackupPolicy(comp componentItem, ba
 	}
 	mergeMap(backupPolicy.Annotations, r.buildAnnotations())
 	mergeMap(backupPolicy.Labels, r.buildLabels(comp, nil))
+	r.syncVolumeSnapshotClass(backupPolicy, comp)
 
 	// update backup repo of the backup policy.
 	if r.Cluster.Spec.Backup != nil && r.Cluster.Spec.Backup.RepoName != "" {
@@ -405,10 +406,22 @@ func (r *clusterBackupPolicyTransformer) buildBackupPolicy(comp componentItem, b
 	bpSpec.BackoffLimit = r.backupPolicy.BackoffLimit
 	backupPolicy.Spec = bpSpec
 	r.setDefaultEncryptionConfig(backupPolicy)
+	r.syncVolumeSnapshotClass(backupPolicy, comp)
 	r.syncBackupPolicyTargetSpec(backupPolicy, comp)
 	return backupPolicy
 }
 
+// syncVolumeSnapshotClass annotates the backup policy with the preferred VolumeSnapshotClass of the component,
+// which is used to create the volume snapshots of the backups.
+func (r *clusterBackupPolicyTransformer) syncVolumeSnapshotClass(backupPolicy *dpv1alpha1.BackupPolicy, comp componentItem) {
+	policy := comp.compSpec.VolumeSnapshotPolicy
+	if policy == nil || len(policy.VolumeSnapshotClassName) == 0 {
+		delete(backupPolicy.Annotations, dptypes.VolumeSnapshotClassAnnotationKey)
+		return
+	}
+	backupPolicy.Annotations[dptypes.VolumeSnapshotClassAnnotationKey] = policy.VolumeSnapshotClassName
+}
+
 // syncBackupMethods syncs the backupMethod of tpl to backupPolicy.
 func (r *clusterBackupPolicyTransformer) syncBackupMethods(backupPolicy *dpv1alpha1.BackupPolicy, comp componentItem) {
 	var backupMethods []dpv1alpha1.BackupMethod
@@ -212,6 +212,7 @@ func copyAndMergeComponent(oldCompObj, newCompObj *appsv1alpha1.Component) *apps
 	compObjCopy.Spec.Resources = compProto.Spec.Resources
 	compObjCopy.Spec.VolumeClaimTemplates = compProto.Spec.VolumeClaimTemplates
 	compObjCopy.Spec.Volumes = compProto.Spec.Volumes
+	compObjCopy.Spec.SharedVolumes = compProto.Spec.SharedVolumes
 	compObjCopy.Spec.Services = compProto.Spec.Services
 	compObjCopy.Spec.Replicas = compProto.Spec.Replicas
 	compObjCopy.Spec.Configs = compProto.Spec.Configs
@@ -225,6 +226,16 @@ func copyAndMergeComponent(oldCompObj, newCompObj *appsv1alpha1.Component) *apps
 	compObjCopy.Spec.OfflineInstances = compProto.Spec.OfflineInstances
 	compObjCopy.Spec.RuntimeClassName = compProto.Spec.RuntimeClassName
 	compObjCopy.Spec.DisableExporter = compProto.Spec.DisableExporter
+	compObjCopy.Spec.ReadWriteSplitServices = compProto.Spec.ReadWriteSplitServices
+	compObjCopy.Spec.CanaryService = compProto.Spec.CanaryService
+	compObjCopy.Spec.VolumeSnapshotPolicy = compProto.Spec.VolumeSnapshotPolicy
+	compObjCopy.Spec.DNSPolicy = compProto.Spec.DNSPolicy
+	compObjCopy.Spec.DNSConfig = compProto.Spec.DNSConfig
+	compObjCopy.Spec.PriorityClassName = compProto.Spec.PriorityClassName
+	compObjCopy.Spec.DataExport = compProto.Spec.DataExport
+	compObjCopy.Spec.InitContainers = compProto.Spec.InitContainers
+	compObjCopy.Spec.InitScripts = compProto.Spec.InitScripts
+	compObjCopy.Spec.PinImageDigests = compProto.Spec.PinImageDigests
 
 	if reflect.DeepEqual(oldCompObj.Annotations, compObjCopy.Annotations) &&
 		reflect.DeepEqual(oldCompObj.Labels, compObjCopy.Labels) &&
@@ -311,7 +322,7 @@ func newOrderedHandler(compSpecs map[string]*appsv1alpha1.ClusterComponentSpec,
 			},
 			compPhasePrecondition: compPhasePrecondition{
 				orders:         orders,
-				expectedPhases: []appsv1alpha1.ClusterComponentPhase{appsv1alpha1.RunningClusterCompPhase},
+				expectedPhases: []appsv1alpha1.ClusterComponentPhase{appsv1alpha1.RunningClusterCompPhase, appsv1alpha1.CompletedClusterCompPhase},
 			},
 			createCompHandler: createCompHandler{
 				compSpecs:   compSpecs,
@@ -336,7 +347,7 @@ func newOrderedHandler(compSpecs map[string]*appsv1alpha1.ClusterComponentSpec,
 			},
 			compPhasePrecondition: compPhasePrecondition{
 				orders:         orders,
-				expectedPhases: []appsv1alpha1.ClusterComponentPhase{appsv1alpha1.RunningClusterCompPhase},
+				expectedPhases: []appsv1alpha1.ClusterComponentPhase{appsv1alpha1.RunningClusterCompPhase, appsv1alpha1.CompletedClusterCompPhase},
 			},
 			updateCompHandler: updateCompHandler{
 				compSpecs:   compSpecs,
@@ -29,9 +29,11 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/plan"
 )
 
 // clusterComponentStatusTransformer transforms all cluster components' status.
@@ -70,7 +72,14 @@ func (t *clusterComponentStatusTransformer) reconcileComponentsStatus(transCtx *
 			}
 			return err
 		}
-		cluster.Status.Components[compSpec.Name] = t.buildClusterCompStatus(transCtx, comp, compSpec.Name)
+		status := t.buildClusterCompStatus(transCtx, comp, compSpec.Name)
+		if err := t.updateTLSCertificateStatus(transCtx, compSpec, &status); err != nil {
+			return err
+		}
+		if err := t.updateMembersStatus(transCtx, compKey, &status); err != nil {
+			return err
+		}
+		cluster.Status.Components[compSpec.Name] = status
 	}
 	return nil
 }
@@ -111,6 +120,7 @@ func (t *clusterComponentStatusTransformer) updateClusterComponentStatus(comp *a
 			}
 		}
 	}
+	status.RestoreProgress = comp.Status.RestoreProgress
 	// if ready flag not changed, don't update the ready time
 	ready := t.isClusterComponentPodsReady(comp.Status.Phase)
 	if status.PodsReady == nil || *status.PodsReady != ready {
@@ -122,9 +132,54 @@ func (t *clusterComponentStatusTransformer) updateClusterComponentStatus(comp *a
 	}
 }
 
+// updateTLSCertificateStatus records the validity period of the TLS certificate used by the component,
+// so that the certificate can be rotated before it expires.
+func (t *clusterComponentStatusTransformer) updateTLSCertificateStatus(transCtx *clusterTransformContext,
+	compSpec *appsv1alpha1.ClusterComponentSpec, status *appsv1alpha1.ClusterComponentStatus) error {
+	if !compSpec.TLS {
+		status.TLSCertificate = nil
+		return nil
+	}
+	cluster := transCtx.Cluster
+	secretName, certKey := plan.GetTLSCertSecretKey(cluster.Name, compSpec.Name, compSpec.Issuer)
+	secret := &corev1.Secret{}
+	if err := transCtx.Client.Get(transCtx.Context, types.NamespacedName{Namespace: cluster.Namespace, Name: secretName}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	cert, err := plan.ParseTLSCertificate(plan.GetTLSSecretValue(secret, certKey))
+	if err != nil {
+		// the certificate provided by the user may be malformed, it should not block the reconciliation.
+		transCtx.GetLogger().Info(fmt.Sprintf("failed to parse the TLS certificate of component %s: %s", compSpec.Name, err.Error()))
+		return nil
+	}
+	status.TLSCertificate = &appsv1alpha1.TLSCertificateStatus{
+		NotBefore: &metav1.Time{Time: cert.NotBefore},
+		NotAfter:  &metav1.Time{Time: cert.NotAfter},
+	}
+	return nil
+}
+
+// updateMembersStatus records the roles of the component members, which are taken from the underlying InstanceSet.
+func (t *clusterComponentStatusTransformer) updateMembersStatus(transCtx *clusterTransformContext,
+	itsKey types.NamespacedName, status *appsv1alpha1.ClusterComponentStatus) error {
+	its := &workloads.InstanceSet{}
+	if err := transCtx.Client.Get(transCtx.Context, itsKey, its); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	status.MembersStatus = its.Status.MembersStatus
+	return nil
+}
+
 func (t *clusterComponentStatusTransformer) isClusterComponentPodsReady(phase appsv1alpha1.ClusterComponentPhase) bool {
 	podsReadyPhases := []appsv1alpha1.ClusterComponentPhase{
 		appsv1alpha1.RunningClusterCompPhase,
+		appsv1alpha1.CompletedClusterCompPhase,
 		appsv1alpha1.StoppingClusterCompPhase,
 		appsv1alpha1.StoppedClusterCompPhase,
 	}
@@ -116,8 +116,21 @@ func (t *clusterDeletionTransformer) Transform(ctx graph.TransformContext, dag *
 	}
 	delObjs = append(delObjs, toDeleteObjs(nonNamespacedObjs)...)
 
+	// track the release of the external resources of the deleted services
+	var services []*corev1.Service
+	for _, o := range delObjs {
+		if svc, ok := o.(*corev1.Service); ok {
+			services = append(services, svc)
+		}
+	}
+	releaseExternalResources(transCtx, dag, services)
+
 	delKindMap := map[string]sets.Empty{}
 	for _, o := range delObjs {
+		if svc, ok := o.(*corev1.Service); ok && isReleasingExternalResources(svc) {
+			delKindMap[o.GetObjectKind().GroupVersionKind().Kind] = sets.Empty{}
+			continue
+		}
 		// skip the objects owned by the component and InstanceSet controller
 		if shouldSkipObjOwnedByComp(o, *cluster) || isOwnedByInstanceSet(o) {
 			continue
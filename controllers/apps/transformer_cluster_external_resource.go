@@ -0,0 +1,174 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/conditions"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	// externalDNSHostnameAnnotationKey is the annotation to publish the DNS records of the service by external-dns.
+	externalDNSHostnameAnnotationKey = "external-dns.alpha.kubernetes.io/hostname"
+
+	// externalResourceCleanupTimeout is the time to wait for the external resources of the deleted services to be released,
+	// the resources are reported as leaked after that.
+	externalResourceCleanupTimeout = 10 * time.Minute
+)
+
+// clusterExternalResourceTransformer tracks the release of the external resources, e.g. cloud load balancers and DNS records,
+// of the cluster services removed by disabling expose, and reports the resources that are not released in time as leaked.
+type clusterExternalResourceTransformer struct{}
+
+var _ graph.Transformer = &clusterExternalResourceTransformer{}
+
+func (t *clusterExternalResourceTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*clusterTransformContext)
+	// the services of the deleting cluster are tracked in the cluster deletion transformer.
+	if model.IsObjectDeleting(transCtx.OrigCluster) {
+		return nil
+	}
+
+	cluster := transCtx.Cluster
+	svcList := &corev1.ServiceList{}
+	labels := client.MatchingLabels(constant.GetClusterWellKnownLabels(cluster.Name))
+	if err := transCtx.Client.List(transCtx.Context, svcList, labels, client.InNamespace(cluster.Namespace)); err != nil {
+		return err
+	}
+	var services []*corev1.Service
+	for i := range svcList.Items {
+		if model.IsOwnerOf(cluster, &svcList.Items[i]) {
+			services = append(services, &svcList.Items[i])
+		}
+	}
+
+	if requeueAfter := releaseExternalResources(transCtx, dag, services); requeueAfter > 0 {
+		// the error is returned in the last step of the DAG
+		return intctrlutil.NewDelayedRequeueError(requeueAfter, "wait for the external resources of services to be released")
+	}
+	return nil
+}
+
+// hasExternalResources checks whether the service provisions resources outside the k8s cluster,
+// i.e. the load balancer of the cloud provider or the DNS records published by external-dns.
+func hasExternalResources(svc *corev1.Service) bool {
+	if svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		return true
+	}
+	_, ok := svc.Annotations[externalDNSHostnameAnnotationKey]
+	return ok
+}
+
+// isReleasingExternalResources checks whether the service is deleted and waiting for its external resources to be released.
+func isReleasingExternalResources(svc *corev1.Service) bool {
+	return svc.DeletionTimestamp != nil && controllerutil.ContainsFinalizer(svc, constant.ExternalResourceFinalizerName)
+}
+
+// checkExternalResources returns the deleted services whose external resources are released, the services whose
+// external resources are leaked, and the time to check the services being released again.
+//
+// The external resources are released once all the finalizers of other controllers, e.g. the load balancer cleanup
+// finalizer of the cloud provider, are removed from the service.
+func checkExternalResources(services []*corev1.Service, now time.Time) ([]*corev1.Service, []string, time.Duration) {
+	var (
+		released     []*corev1.Service
+		leaked       []string
+		requeueAfter time.Duration
+	)
+	for _, svc := range services {
+		if !isReleasingExternalResources(svc) {
+			continue
+		}
+		var pending []string
+		for _, finalizer := range svc.Finalizers {
+			if finalizer != constant.ExternalResourceFinalizerName && finalizer != constant.DBClusterFinalizerName {
+				pending = append(pending, finalizer)
+			}
+		}
+		if len(pending) == 0 {
+			released = append(released, svc)
+			continue
+		}
+		elapsed := now.Sub(svc.DeletionTimestamp.Time)
+		if elapsed >= externalResourceCleanupTimeout {
+			leaked = append(leaked, fmt.Sprintf("%s(%s)", svc.Name, strings.Join(pending, ",")))
+			continue
+		}
+		if wait := externalResourceCleanupTimeout - elapsed; requeueAfter == 0 || wait < requeueAfter {
+			requeueAfter = wait
+		}
+	}
+	sort.Strings(leaked)
+	return released, leaked, requeueAfter
+}
+
+// releaseExternalResources removes the external resource finalizer from the deleted services whose external resources
+// are released, and publishes the leaked ones as the ExternalResourcesLeaked condition. It returns the time to check
+// the services being released again.
+func releaseExternalResources(transCtx *clusterTransformContext, dag *graph.DAG, services []*corev1.Service) time.Duration {
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	cluster := transCtx.Cluster
+
+	released, leaked, requeueAfter := checkExternalResources(services, time.Now())
+	for _, svc := range released {
+		svcCopy := svc.DeepCopy()
+		controllerutil.RemoveFinalizer(svcCopy, constant.ExternalResourceFinalizerName)
+		graphCli.Patch(dag, svc, svcCopy, inDataContext4G())
+	}
+
+	if len(leaked) == 0 {
+		if conditions.Remove(&cluster.Status.Conditions, appsv1alpha1.ConditionTypeExternalResourcesLeaked) {
+			transCtx.EventRecorder.Event(cluster, corev1.EventTypeNormal, ReasonExternalResourcesReleased,
+				"the leaked external resources of services are released")
+		}
+		return requeueAfter
+	}
+	message := fmt.Sprintf("the external resources of services are not released in %s, waiting for finalizers: %s",
+		externalResourceCleanupTimeout, strings.Join(leaked, ", "))
+	if conditions.Set(&cluster.Status.Conditions, newExternalResourcesLeakedCondition(cluster.Generation, message)) {
+		transCtx.EventRecorder.Event(cluster, corev1.EventTypeWarning, ReasonExternalResourcesLeaked, message)
+	}
+	return requeueAfter
+}
+
+// newExternalResourcesLeakedCondition creates the condition which reports the leaked external resources.
+func newExternalResourcesLeakedCondition(clusterGeneration int64, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:               appsv1alpha1.ConditionTypeExternalResourcesLeaked,
+		ObservedGeneration: clusterGeneration,
+		Status:             metav1.ConditionTrue,
+		Message:            message,
+		Reason:             ReasonExternalResourcesLeaked,
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestHasExternalResources(t *testing.T) {
+	svc := &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP}}
+	assert.False(t, hasExternalResources(svc))
+
+	svc.Annotations = map[string]string{externalDNSHostnameAnnotationKey: "mysql.example.com"}
+	assert.True(t, hasExternalResources(svc))
+
+	svc = &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer}}
+	assert.True(t, hasExternalResources(svc))
+}
+
+func TestCheckExternalResources(t *testing.T) {
+	now := time.Now()
+	newService := func(name string, deletedAt time.Duration, finalizers ...string) *corev1.Service {
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       name,
+				Finalizers: finalizers,
+			},
+		}
+		if deletedAt > 0 {
+			deletionTimestamp := metav1.NewTime(now.Add(-deletedAt))
+			svc.DeletionTimestamp = &deletionTimestamp
+		}
+		return svc
+	}
+	const lbFinalizer = "service.kubernetes.io/load-balancer-cleanup"
+	services := []*corev1.Service{
+		// not deleted
+		newService("svc-running", 0, constant.ExternalResourceFinalizerName, lbFinalizer),
+		// not tracked
+		newService("svc-untracked", time.Hour, lbFinalizer),
+		// released
+		newService("svc-released", time.Minute, constant.ExternalResourceFinalizerName),
+		// releasing
+		newService("svc-releasing", 4*time.Minute, constant.ExternalResourceFinalizerName, lbFinalizer),
+		newService("svc-releasing-2", 8*time.Minute, constant.ExternalResourceFinalizerName, lbFinalizer),
+		// leaked
+		newService("svc-leaked", time.Hour, constant.ExternalResourceFinalizerName, lbFinalizer),
+	}
+
+	released, leaked, requeueAfter := checkExternalResources(services, now)
+	assert.Len(t, released, 1)
+	assert.Equal(t, "svc-released", released[0].Name)
+	assert.Equal(t, []string{"svc-leaked(" + lbFinalizer + ")"}, leaked)
+	assert.Equal(t, 2*time.Minute, requeueAfter)
+}
@@ -24,11 +24,11 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/conditions"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 )
@@ -63,16 +63,8 @@ func (t *clusterHaltRecoveryTransformer) Transform(ctx graph.TransformContext, d
 	}
 
 	emitError := func(newCondition metav1.Condition) error {
-		if newCondition.LastTransitionTime.IsZero() {
-			newCondition.LastTransitionTime = metav1.Now()
-		}
 		newCondition.Status = metav1.ConditionFalse
-		oldCondition := meta.FindStatusCondition(cluster.Status.Conditions, newCondition.Type)
-		if oldCondition == nil {
-			cluster.Status.Conditions = append(cluster.Status.Conditions, newCondition)
-		} else {
-			*oldCondition = newCondition
-		}
+		conditions.Set(&cluster.Status.Conditions, newCondition)
 		transCtx.EventRecorder.Event(transCtx.Cluster, corev1.EventTypeWarning, newCondition.Reason, newCondition.Message)
 		return graph.ErrPrematureStop
 	}
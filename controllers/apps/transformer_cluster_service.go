@@ -29,6 +29,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/common"
@@ -102,6 +103,10 @@ func (t *clusterServiceTransformer) Transform(ctx graph.TransformContext, dag *g
 	}
 
 	for svc := range services {
+		// the deleted services are waiting for the external resources to be released
+		if isReleasingExternalResources(services[svc]) {
+			continue
+		}
 		graphCli.Delete(dag, services[svc])
 	}
 
@@ -206,7 +211,12 @@ func (t *clusterServiceTransformer) buildService(transCtx *clusterTransformConte
 		builder.AddSelector(constant.RoleLabelKey, genSvc.RoleSelector)
 	}
 
-	return builder.GetObject(), nil
+	service := builder.GetObject()
+	if hasExternalResources(service) {
+		// track the cleanup of the external resources, it's released after the service is deleted
+		controllerutil.AddFinalizer(service, constant.ExternalResourceFinalizerName)
+	}
+	return service, nil
 }
 
 func (t *clusterServiceTransformer) genMultiServiceIfNeed(transCtx *clusterTransformContext,
@@ -318,6 +328,9 @@ func createOrUpdateService(ctx graph.TransformContext, dag *graph.DAG, graphCli
 
 	objCopy := obj.DeepCopy()
 	objCopy.Spec = service.Spec
+	if controllerutil.ContainsFinalizer(service, constant.ExternalResourceFinalizerName) {
+		controllerutil.AddFinalizer(objCopy, constant.ExternalResourceFinalizerName)
+	}
 
 	resolveServiceDefaultFields(&obj.Spec, &objCopy.Spec)
 
@@ -0,0 +1,146 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/conditions"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	// endpointProbeInterval is the interval to check the unready external endpoints of the cluster services again.
+	endpointProbeInterval = 30 * time.Second
+	// endpointProbingCheckInterval is the interval to check the result of the in-flight probes.
+	endpointProbingCheckInterval = 5 * time.Second
+)
+
+// clusterServiceEndpointTransformer publishes the health of the external endpoints of the LoadBalancer services
+// of the cluster as the EndpointsReady condition. The endpoints are probed in background, and the cluster is
+// requeued only while some endpoints are unready.
+type clusterServiceEndpointTransformer struct {
+	prober *intctrlutil.AsyncEndpointProber
+}
+
+var _ graph.Transformer = &clusterServiceEndpointTransformer{}
+
+func (t *clusterServiceEndpointTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*clusterTransformContext)
+	if model.IsObjectDeleting(transCtx.OrigCluster) || !viper.GetBool(constant.CfgKeyEndpointProbe) {
+		return nil
+	}
+
+	cluster := transCtx.Cluster
+	svcList := &corev1.ServiceList{}
+	labels := client.MatchingLabels(constant.GetClusterWellKnownLabels(cluster.Name))
+	if err := transCtx.Client.List(transCtx.Context, svcList, labels, client.InNamespace(cluster.Namespace)); err != nil {
+		return err
+	}
+
+	var (
+		probed   bool
+		probing  []string
+		pending  []string
+		failures []string
+	)
+	for i := range svcList.Items {
+		svc := &svcList.Items[i]
+		if svc.Spec.Type != corev1.ServiceTypeLoadBalancer || !model.IsOwnerOf(cluster, svc) {
+			continue
+		}
+		probed = true
+		result := t.getProber().Get(svc)
+		switch {
+		case result == nil:
+			probing = append(probing, svc.Name)
+			continue
+		case !result.Provisioned:
+			pending = append(pending, svc.Name)
+			continue
+		}
+		for _, failure := range result.Failures {
+			failures = append(failures, fmt.Sprintf("%s(%s)", svc.Name, failure))
+		}
+	}
+
+	if !probed {
+		conditions.Remove(&cluster.Status.Conditions, appsv1alpha1.ConditionTypeEndpointsReady)
+		return nil
+	}
+	condition := newEndpointsReadyCondition(cluster.Generation, probing, pending, failures)
+	conditions.Set(&cluster.Status.Conditions, condition)
+	if condition.Status == metav1.ConditionTrue {
+		return nil
+	}
+	// check the endpoints again until they are ready, the error is returned in the last step of the DAG
+	if len(probing) > 0 {
+		return intctrlutil.NewDelayedRequeueError(endpointProbingCheckInterval, "wait for the probes of the external endpoints")
+	}
+	return intctrlutil.NewDelayedRequeueError(endpointProbeInterval, "wait for the external endpoints to be ready")
+}
+
+func (t *clusterServiceEndpointTransformer) getProber() *intctrlutil.AsyncEndpointProber {
+	if t.prober == nil {
+		return intctrlutil.DefaultAsyncEndpointProber
+	}
+	return t.prober
+}
+
+// newEndpointsReadyCondition creates the condition which reports the health of the external endpoints.
+func newEndpointsReadyCondition(clusterGeneration int64, probing, pending, failures []string) metav1.Condition {
+	if len(probing) == 0 && len(pending) == 0 && len(failures) == 0 {
+		return metav1.Condition{
+			Type:               appsv1alpha1.ConditionTypeEndpointsReady,
+			ObservedGeneration: clusterGeneration,
+			Status:             metav1.ConditionTrue,
+			Message:            "all external endpoints of the LoadBalancer services are reachable",
+			Reason:             ReasonEndpointsReachable,
+		}
+	}
+	var messages []string
+	if len(probing) > 0 {
+		messages = append(messages, fmt.Sprintf("endpoints are being probed for services: %v", probing))
+	}
+	if len(pending) > 0 {
+		messages = append(messages, fmt.Sprintf("load balancers are not provisioned for services: %v", pending))
+	}
+	if len(failures) > 0 {
+		messages = append(messages, fmt.Sprintf("endpoints are unreachable: %s", strings.Join(failures, "; ")))
+	}
+	return metav1.Condition{
+		Type:               appsv1alpha1.ConditionTypeEndpointsReady,
+		ObservedGeneration: clusterGeneration,
+		Status:             metav1.ConditionFalse,
+		Message:            strings.Join(messages, ", "),
+		Reason:             ReasonEndpointsUnreachable,
+	}
+}
@@ -0,0 +1,204 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+func TestNewEndpointsReadyCondition(t *testing.T) {
+	condition := newEndpointsReadyCondition(2, nil, nil, nil)
+	assert.Equal(t, appsv1alpha1.ConditionTypeEndpointsReady, condition.Type)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, int64(2), condition.ObservedGeneration)
+
+	condition = newEndpointsReadyCondition(2, nil, []string{"test-mysql-vpc"}, []string{"test-mysql-internet(10.0.0.1:3306: i/o timeout)"})
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonEndpointsUnreachable, condition.Reason)
+	assert.Equal(t, "load balancers are not provisioned for services: [test-mysql-vpc], "+
+		"endpoints are unreachable: test-mysql-internet(10.0.0.1:3306: i/o timeout)", condition.Message)
+
+	condition = newEndpointsReadyCondition(2, []string{"test-mysql-internet"}, nil, nil)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "endpoints are being probed for services: [test-mysql-internet]", condition.Message)
+}
+
+type fakeEndpointProber struct {
+	sync.Mutex
+	blocked     chan struct{}
+	unreachable map[string]bool
+}
+
+func (p *fakeEndpointProber) Probe(_ context.Context, host string, port corev1.ServicePort) error {
+	if p.blocked != nil {
+		<-p.blocked
+	}
+	p.Lock()
+	defer p.Unlock()
+	if p.unreachable[net.JoinHostPort(host, strconv.Itoa(int(port.Port)))] {
+		return context.DeadlineExceeded
+	}
+	return nil
+}
+
+var _ = Describe("clusterServiceEndpointTransformer", func() {
+	var (
+		reader      *mockReader
+		transCtx    *clusterTransformContext
+		prober      *fakeEndpointProber
+		transformer *clusterServiceEndpointTransformer
+		cluster     *appsv1alpha1.Cluster
+	)
+
+	newLoadBalancerService := func(name, host string) *corev1.Service {
+		svc := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testCtx.DefaultNamespace,
+				Name:      name,
+				Labels:    constant.GetClusterWellKnownLabels(cluster.Name),
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: appsv1alpha1.APIVersion,
+					Kind:       appsv1alpha1.ClusterKind,
+					Name:       cluster.Name,
+					UID:        cluster.UID,
+				}},
+			},
+			Spec: corev1.ServiceSpec{
+				Type:  corev1.ServiceTypeLoadBalancer,
+				Ports: []corev1.ServicePort{{Port: 3306}},
+			},
+		}
+		if host != "" {
+			svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{Hostname: host}}
+		}
+		return svc
+	}
+
+	transform := func() error {
+		dag := graph.NewDAG()
+		graphCli := transCtx.Client.(model.GraphClient)
+		graphCli.Root(dag, transCtx.OrigCluster, transCtx.Cluster, model.ActionStatusPtr())
+		return transformer.Transform(transCtx, dag)
+	}
+
+	endpointsReadyCondition := func() *metav1.Condition {
+		return meta.FindStatusCondition(transCtx.Cluster.Status.Conditions, appsv1alpha1.ConditionTypeEndpointsReady)
+	}
+
+	BeforeEach(func() {
+		viper.Set(constant.CfgKeyEndpointProbe, true)
+
+		cluster = testapps.NewClusterFactory(testCtx.DefaultNamespace, "test-cluster", "", "").GetObject()
+		cluster.UID = types.UID("test-cluster-uid")
+		prober = &fakeEndpointProber{}
+		// refresh the probe result on every reconciliation
+		transformer = &clusterServiceEndpointTransformer{
+			prober: intctrlutil.NewAsyncEndpointProber(prober, 0),
+		}
+		reader = &mockReader{}
+		transCtx = &clusterTransformContext{
+			Context:       testCtx.Ctx,
+			Client:        model.NewGraphClient(reader),
+			EventRecorder: clusterRecorder,
+			Logger:        logger,
+			Cluster:       cluster.DeepCopy(),
+			OrigCluster:   cluster,
+		}
+	})
+
+	AfterEach(func() {
+		viper.Set(constant.CfgKeyEndpointProbe, false)
+	})
+
+	It("does nothing if the endpoint probe is disabled", func() {
+		viper.Set(constant.CfgKeyEndpointProbe, false)
+		reader.objs = []client.Object{newLoadBalancerService("test-cluster-internet", "lb.example.com")}
+		Expect(transform()).Should(Succeed())
+		Expect(endpointsReadyCondition()).Should(BeNil())
+	})
+
+	It("removes the condition if there are no LoadBalancer services", func() {
+		transCtx.Cluster.Status.Conditions = []metav1.Condition{
+			newEndpointsReadyCondition(cluster.Generation, nil, []string{"test-cluster-internet"}, nil),
+		}
+		Expect(transform()).Should(Succeed())
+		Expect(endpointsReadyCondition()).Should(BeNil())
+	})
+
+	It("requeues until the endpoints are ready", func() {
+		prober.blocked = make(chan struct{})
+		prober.unreachable = map[string]bool{"lb.example.com:3306": true}
+		reader.objs = []client.Object{newLoadBalancerService("test-cluster-internet", "lb.example.com")}
+
+		By("waiting for the in-flight probe without blocking the reconciliation")
+		err := transform()
+		Expect(intctrlutil.IsDelayedRequeueError(err)).Should(BeTrue())
+		Expect(err.(intctrlutil.RequeueError).RequeueAfter()).Should(Equal(endpointProbingCheckInterval))
+		Expect(endpointsReadyCondition().Status).Should(Equal(metav1.ConditionFalse))
+		close(prober.blocked)
+
+		By("requeuing with the probe interval while the endpoint is unreachable")
+		Eventually(func(g Gomega) {
+			err := transform()
+			g.Expect(intctrlutil.IsDelayedRequeueError(err)).Should(BeTrue())
+			g.Expect(err.(intctrlutil.RequeueError).RequeueAfter()).Should(Equal(endpointProbeInterval))
+			g.Expect(endpointsReadyCondition().Message).Should(ContainSubstring("endpoints are unreachable"))
+		}).Should(Succeed())
+
+		By("not requeuing once the endpoint is reachable")
+		prober.Lock()
+		prober.unreachable = nil
+		prober.Unlock()
+		Eventually(func(g Gomega) {
+			g.Expect(transform()).Should(Succeed())
+			g.Expect(endpointsReadyCondition().Status).Should(Equal(metav1.ConditionTrue))
+		}).Should(Succeed())
+	})
+
+	It("requeues if the load balancer is not provisioned", func() {
+		reader.objs = []client.Object{newLoadBalancerService("test-cluster-internet", "")}
+		err := transform()
+		Expect(intctrlutil.IsDelayedRequeueError(err)).Should(BeTrue())
+		Expect(err.(intctrlutil.RequeueError).RequeueAfter()).Should(Equal(endpointProbeInterval))
+		Expect(endpointsReadyCondition().Message).Should(ContainSubstring("load balancers are not provisioned"))
+	})
+})
@@ -22,9 +22,13 @@ package apps
 import (
 	"fmt"
 
-	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/conditions"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 )
@@ -81,74 +85,54 @@ func (t *clusterStatusTransformer) markClusterDagStatusAction(graphCli model.Gra
 }
 
 func (t *clusterStatusTransformer) reconcileClusterPhase(cluster *appsv1alpha1.Cluster) {
-	var (
-		isAllComponentCreating       = true
-		isAllComponentRunning        = true
-		isAllComponentWorking        = true
-		hasComponentStopping         = false
-		isAllComponentStopped        = true
-		isAllComponentFailed         = true
-		hasComponentAbnormalOrFailed = false
-	)
-	isPhaseIn := func(phase appsv1alpha1.ClusterComponentPhase, phases ...appsv1alpha1.ClusterComponentPhase) bool {
-		for _, p := range phases {
-			if p == phase {
-				return true
-			}
-		}
-		return false
+	phase, ok := computeClusterPhase(cluster.Status.Components)
+	if !ok {
+		return
 	}
-	for _, status := range cluster.Status.Components {
-		phase := status.Phase
-		if !isPhaseIn(phase, appsv1alpha1.CreatingClusterCompPhase) {
-			isAllComponentCreating = false
-		}
-		if !isPhaseIn(phase, appsv1alpha1.RunningClusterCompPhase) {
-			isAllComponentRunning = false
-		}
-		if !isPhaseIn(phase, appsv1alpha1.CreatingClusterCompPhase,
-			appsv1alpha1.RunningClusterCompPhase,
-			appsv1alpha1.UpdatingClusterCompPhase) {
-			isAllComponentWorking = false
-		}
-		if isPhaseIn(phase, appsv1alpha1.StoppingClusterCompPhase) {
-			hasComponentStopping = true
-		}
-		if !isPhaseIn(phase, appsv1alpha1.StoppedClusterCompPhase) {
-			isAllComponentStopped = false
-		}
-		if !isPhaseIn(phase, appsv1alpha1.FailedClusterCompPhase) {
-			isAllComponentFailed = false
-		}
-		if isPhaseIn(phase, appsv1alpha1.AbnormalClusterCompPhase, appsv1alpha1.FailedClusterCompPhase) {
-			hasComponentAbnormalOrFailed = true
-		}
-	}
-
-	switch {
-	case isAllComponentRunning:
+	switch phase {
+	case appsv1alpha1.RunningClusterPhase:
 		if cluster.Status.Phase != appsv1alpha1.RunningClusterPhase {
 			t.syncClusterPhaseToRunning(cluster)
 		}
-	case isAllComponentCreating:
-		cluster.Status.Phase = appsv1alpha1.CreatingClusterPhase
-	case isAllComponentWorking:
-		cluster.Status.Phase = appsv1alpha1.UpdatingClusterPhase
-	case isAllComponentStopped:
+	case appsv1alpha1.StoppedClusterPhase:
 		if cluster.Status.Phase != appsv1alpha1.StoppedClusterPhase {
 			t.syncClusterPhaseToStopped(cluster)
 		}
-	case hasComponentStopping:
-		cluster.Status.Phase = appsv1alpha1.StoppingClusterPhase
-	case isAllComponentFailed:
-		cluster.Status.Phase = appsv1alpha1.FailedClusterPhase
-	case hasComponentAbnormalOrFailed:
-		cluster.Status.Phase = appsv1alpha1.AbnormalClusterPhase
 	default:
-		// nothing
+		cluster.Status.Phase = phase
 	}
 }
 
+// computeClusterPhase computes the cluster phase from the phases of the components,
+// it returns false if the phase of the cluster should be kept.
+func computeClusterPhase(compStatuses map[string]appsv1alpha1.ClusterComponentStatus) (appsv1alpha1.ClusterPhase, bool) {
+	phases := make([]appsv1alpha1.ClusterComponentPhase, 0, len(compStatuses))
+	for _, status := range compStatuses {
+		phases = append(phases, status.Phase)
+	}
+	return conditions.ComputePhase(
+		// the completed run-to-completion components are treated as running for the cluster
+		conditions.Rule(appsv1alpha1.RunningClusterPhase, conditions.AllIn(phases,
+			appsv1alpha1.RunningClusterCompPhase, appsv1alpha1.CompletedClusterCompPhase)),
+		conditions.Rule(appsv1alpha1.CreatingClusterPhase, conditions.AllIn(phases,
+			appsv1alpha1.CreatingClusterCompPhase)),
+		conditions.Rule(appsv1alpha1.StoppedClusterPhase, conditions.AllIn(phases,
+			appsv1alpha1.StoppedClusterCompPhase)),
+		// the components stopped by a component-scoped Stop OpsRequest don't affect the phase of the cluster
+		conditions.Rule(appsv1alpha1.RunningClusterPhase, conditions.AllIn(phases,
+			appsv1alpha1.RunningClusterCompPhase, appsv1alpha1.CompletedClusterCompPhase, appsv1alpha1.StoppedClusterCompPhase)),
+		conditions.Rule(appsv1alpha1.UpdatingClusterPhase, conditions.AllIn(phases,
+			appsv1alpha1.CreatingClusterCompPhase, appsv1alpha1.RunningClusterCompPhase,
+			appsv1alpha1.CompletedClusterCompPhase, appsv1alpha1.UpdatingClusterCompPhase, appsv1alpha1.StoppedClusterCompPhase)),
+		conditions.Rule(appsv1alpha1.StoppingClusterPhase, conditions.AnyIn(phases,
+			appsv1alpha1.StoppingClusterCompPhase)),
+		conditions.Rule(appsv1alpha1.FailedClusterPhase, conditions.AllIn(phases,
+			appsv1alpha1.FailedClusterCompPhase)),
+		conditions.Rule(appsv1alpha1.AbnormalClusterPhase, conditions.AnyIn(phases,
+			appsv1alpha1.AbnormalClusterCompPhase, appsv1alpha1.FailedClusterCompPhase)),
+	)
+}
+
 // reconcileClusterStatus reconciles phase and conditions of the Cluster.status.
 func (t *clusterStatusTransformer) reconcileClusterStatus(transCtx *clusterTransformContext, cluster *appsv1alpha1.Cluster) error {
 	if len(cluster.Status.Components) == 0 {
@@ -172,11 +156,55 @@ func (t *clusterStatusTransformer) reconcileClusterStatus(transCtx *clusterTrans
 	// sync the cluster phase.
 	t.reconcileClusterPhase(cluster)
 
+	// sync the primary instance and the last backup time of the cluster.
+	cluster.Status.PrimaryInstance = getPrimaryInstance(transCtx.ComponentSpecs, cluster.Status.Components)
+	if err := t.syncLastBackupTime(transCtx, cluster); err != nil {
+		return err
+	}
+
 	// removes the component of status.components which is created by simplified API.
 	t.removeInnerCompStatus(transCtx, cluster)
 	return nil
 }
 
+// getPrimaryInstance returns the leader pod of the first component that has one.
+func getPrimaryInstance(compSpecs []*appsv1alpha1.ClusterComponentSpec,
+	compStatuses map[string]appsv1alpha1.ClusterComponentStatus) string {
+	for _, compSpec := range compSpecs {
+		for _, member := range compStatuses[compSpec.Name].MembersStatus {
+			if member.ReplicaRole != nil && member.ReplicaRole.IsLeader {
+				return member.PodName
+			}
+		}
+	}
+	return ""
+}
+
+// syncLastBackupTime records the completion time of the latest completed backup of the cluster.
+func (t *clusterStatusTransformer) syncLastBackupTime(transCtx *clusterTransformContext, cluster *appsv1alpha1.Cluster) error {
+	backupList := &dpv1alpha1.BackupList{}
+	if err := transCtx.Client.List(transCtx.Context, backupList, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{constant.AppInstanceLabelKey: cluster.Name}); err != nil {
+		return err
+	}
+	cluster.Status.LastBackupTime = getLastBackupTime(backupList.Items)
+	return nil
+}
+
+func getLastBackupTime(backups []dpv1alpha1.Backup) *metav1.Time {
+	var lastBackupTime *metav1.Time
+	for _, backup := range backups {
+		completionTime := backup.Status.CompletionTimestamp
+		if backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted || completionTime == nil {
+			continue
+		}
+		if lastBackupTime == nil || lastBackupTime.Before(completionTime) {
+			lastBackupTime = completionTime
+		}
+	}
+	return lastBackupTime
+}
+
 // removeInvalidCompStatus removes the invalid component of status.components which is deleted from spec.components.
 func (t *clusterStatusTransformer) removeInvalidCompStatus(transCtx *clusterTransformContext, cluster *appsv1alpha1.Cluster) {
 	// removes deleted components and keeps created components by simplified API
@@ -229,20 +257,20 @@ func (t *clusterStatusTransformer) syncReadyConditionForCluster(cluster *appsv1a
 	if len(t.replicasNotReadyCompNames) == 0 {
 		// if all replicas of cluster are ready, set ReasonAllReplicasReady to status.conditions
 		readyCondition := newAllReplicasPodsReadyConditions()
-		meta.SetStatusCondition(&cluster.Status.Conditions, readyCondition)
+		conditions.Set(&cluster.Status.Conditions, readyCondition)
 	} else {
-		meta.SetStatusCondition(&cluster.Status.Conditions, newReplicasNotReadyCondition(t.replicasNotReadyCompNames))
+		conditions.Set(&cluster.Status.Conditions, newReplicasNotReadyCondition(t.replicasNotReadyCompNames))
 	}
 
 	if len(t.notReadyCompNames) > 0 {
-		meta.SetStatusCondition(&cluster.Status.Conditions, newComponentsNotReadyCondition(t.notReadyCompNames))
+		conditions.Set(&cluster.Status.Conditions, newComponentsNotReadyCondition(t.notReadyCompNames))
 	}
 }
 
 // syncClusterPhaseToRunning syncs the cluster phase to Running.
 func (t *clusterStatusTransformer) syncClusterPhaseToRunning(cluster *appsv1alpha1.Cluster) {
 	cluster.Status.Phase = appsv1alpha1.RunningClusterPhase
-	meta.SetStatusCondition(&cluster.Status.Conditions, newClusterReadyCondition(cluster.Name))
+	conditions.Set(&cluster.Status.Conditions, newClusterReadyCondition(cluster.Name))
 }
 
 // syncClusterPhaseToStopped syncs the cluster phase to Stopped.
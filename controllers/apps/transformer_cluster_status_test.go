@@ -0,0 +1,76 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+)
+
+func TestGetPrimaryInstance(t *testing.T) {
+	compSpecs := []*appsv1alpha1.ClusterComponentSpec{{Name: "proxy"}, {Name: "mysql"}}
+	compStatuses := map[string]appsv1alpha1.ClusterComponentStatus{
+		"proxy": {MembersStatus: []workloads.MemberStatus{{PodName: "test-proxy-0"}}},
+		"mysql": {MembersStatus: []workloads.MemberStatus{
+			{PodName: "test-mysql-1", ReplicaRole: &workloads.ReplicaRole{Name: "follower"}},
+			{PodName: "test-mysql-0", ReplicaRole: &workloads.ReplicaRole{Name: "leader", IsLeader: true}},
+		}},
+	}
+	assert.Equal(t, "test-mysql-0", getPrimaryInstance(compSpecs, compStatuses))
+
+	delete(compStatuses, "mysql")
+	assert.Equal(t, "", getPrimaryInstance(compSpecs, compStatuses))
+}
+
+func TestGetLastBackupTime(t *testing.T) {
+	now := metav1.Now()
+	earlier := metav1.NewTime(now.Add(-time.Hour))
+	later := metav1.NewTime(now.Add(time.Hour))
+	backups := []dpv1alpha1.Backup{
+		{Status: dpv1alpha1.BackupStatus{Phase: dpv1alpha1.BackupPhaseCompleted, CompletionTimestamp: &earlier}},
+		{Status: dpv1alpha1.BackupStatus{Phase: dpv1alpha1.BackupPhaseCompleted, CompletionTimestamp: &now}},
+		{Status: dpv1alpha1.BackupStatus{Phase: dpv1alpha1.BackupPhaseFailed, CompletionTimestamp: &later}},
+		{Status: dpv1alpha1.BackupStatus{Phase: dpv1alpha1.BackupPhaseRunning}},
+	}
+	assert.Equal(t, &now, getLastBackupTime(backups))
+	assert.Nil(t, getLastBackupTime(nil))
+}
+
+func TestComputeClusterPhaseWithStoppedComponents(t *testing.T) {
+	compute := func(phases ...appsv1alpha1.ClusterComponentPhase) appsv1alpha1.ClusterPhase {
+		compStatuses := map[string]appsv1alpha1.ClusterComponentStatus{}
+		for i, phase := range phases {
+			compStatuses[string(rune('a'+i))] = appsv1alpha1.ClusterComponentStatus{Phase: phase}
+		}
+		phase, _ := computeClusterPhase(compStatuses)
+		return phase
+	}
+	assert.Equal(t, appsv1alpha1.StoppedClusterPhase, compute(appsv1alpha1.StoppedClusterCompPhase, appsv1alpha1.StoppedClusterCompPhase))
+	assert.Equal(t, appsv1alpha1.RunningClusterPhase, compute(appsv1alpha1.RunningClusterCompPhase, appsv1alpha1.StoppedClusterCompPhase))
+	assert.Equal(t, appsv1alpha1.UpdatingClusterPhase, compute(appsv1alpha1.UpdatingClusterCompPhase, appsv1alpha1.StoppedClusterCompPhase))
+	assert.Equal(t, appsv1alpha1.StoppingClusterPhase, compute(appsv1alpha1.StoppingClusterCompPhase, appsv1alpha1.RunningClusterCompPhase))
+}
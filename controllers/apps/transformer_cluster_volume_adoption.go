@@ -0,0 +1,160 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/conditions"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// clusterVolumeAdoptionTransformer adopts the PVCs which are expanded out-of-band, i.e. by editing the PVCs directly.
+//
+// If all the PVCs of a volume claim template have been expanded beyond the storage of the template, the storage of
+// the template is raised to the smallest of them, so that the controller won't try to shrink the PVCs and the later VolumeExpansion
+// OpsRequests are validated against the actual size. If the PVCs are expanded to different sizes, the VolumesInSync
+// condition is set with the VolumeExpansion OpsRequest to reconcile them.
+type clusterVolumeAdoptionTransformer struct{}
+
+var _ graph.Transformer = &clusterVolumeAdoptionTransformer{}
+
+func (t *clusterVolumeAdoptionTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*clusterTransformContext)
+	if model.IsObjectDeleting(transCtx.OrigCluster) || !viper.GetBool(constant.CfgKeyVolumeAdoption) {
+		return nil
+	}
+
+	cluster := transCtx.Cluster
+	var diverged []string
+	adopt := func(compSpec *appsv1alpha1.ClusterComponentSpec, compName string, labels client.MatchingLabels) error {
+		pvcList := &corev1.PersistentVolumeClaimList{}
+		if err := transCtx.Client.List(transCtx.Context, pvcList, labels, client.InNamespace(cluster.Namespace), inDataContext4C()); err != nil {
+			return err
+		}
+		for i, vct := range compSpec.VolumeClaimTemplates {
+			if isVolumeClaimTemplateOverridden(compSpec, vct.Name) {
+				continue
+			}
+			smallest, largest := expandedStorageRange(pvcList.Items, vct.Name)
+			if smallest == nil {
+				continue
+			}
+			storage := compSpec.VolumeClaimTemplates[i].Spec.Resources.Requests[corev1.ResourceStorage]
+			if smallest.Cmp(storage) > 0 {
+				if compSpec.VolumeClaimTemplates[i].Spec.Resources.Requests == nil {
+					compSpec.VolumeClaimTemplates[i].Spec.Resources.Requests = corev1.ResourceList{}
+				}
+				compSpec.VolumeClaimTemplates[i].Spec.Resources.Requests[corev1.ResourceStorage] = smallest.DeepCopy()
+				transCtx.EventRecorder.Eventf(cluster, corev1.EventTypeNormal, ReasonVolumesAdopted,
+					"the storage of volumeClaimTemplate %s in component %s is changed from %s to %s, which is expanded out-of-band",
+					vct.Name, compName, storage.String(), smallest.String())
+				storage = *smallest
+			}
+			if largest.Cmp(storage) > 0 {
+				diverged = append(diverged, fmt.Sprintf("%s/%s(%s < %s)", compName, vct.Name, storage.String(), largest.String()))
+			}
+		}
+		return nil
+	}
+	for i := range cluster.Spec.ComponentSpecs {
+		compSpec := &cluster.Spec.ComponentSpecs[i]
+		if err := adopt(compSpec, compSpec.Name, constant.GetComponentWellKnownLabels(cluster.Name, compSpec.Name)); err != nil {
+			return err
+		}
+	}
+	for i := range cluster.Spec.ShardingSpecs {
+		sharding := &cluster.Spec.ShardingSpecs[i]
+		labels := client.MatchingLabels{
+			constant.AppInstanceLabelKey:       cluster.Name,
+			constant.KBAppShardingNameLabelKey: sharding.Name,
+		}
+		if err := adopt(&sharding.Template, sharding.Name, labels); err != nil {
+			return err
+		}
+	}
+
+	if len(diverged) == 0 {
+		conditions.Remove(&cluster.Status.Conditions, appsv1alpha1.ConditionTypeVolumesInSync)
+		return nil
+	}
+	sort.Strings(diverged)
+	conditions.Set(&cluster.Status.Conditions, newVolumesDivergedCondition(cluster, diverged))
+	return nil
+}
+
+// isVolumeClaimTemplateOverridden checks whether the volume claim template is overridden by an instance template,
+// the PVCs of such a template have different sizes by design.
+func isVolumeClaimTemplateOverridden(compSpec *appsv1alpha1.ClusterComponentSpec, vctName string) bool {
+	for _, ins := range compSpec.Instances {
+		for _, vct := range ins.VolumeClaimTemplates {
+			if vct.Name == vctName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// expandedStorageRange returns the smallest and largest storage of the PVCs of the volume claim template.
+// It returns nil if there is no PVC or any of them is not bound or being resized, which is checked again later.
+func expandedStorageRange(pvcs []corev1.PersistentVolumeClaim, vctName string) (*resource.Quantity, *resource.Quantity) {
+	var smallest, largest *resource.Quantity
+	for i := range pvcs {
+		if pvcs[i].Labels[constant.VolumeClaimTemplateNameLabelKey] != vctName {
+			continue
+		}
+		capacity, ok := pvcs[i].Status.Capacity[corev1.ResourceStorage]
+		if !ok || capacity.Cmp(pvcs[i].Spec.Resources.Requests[corev1.ResourceStorage]) < 0 {
+			return nil, nil
+		}
+		if smallest == nil || capacity.Cmp(*smallest) < 0 {
+			smallest = &capacity
+		}
+		if largest == nil || capacity.Cmp(*largest) > 0 {
+			largest = &capacity
+		}
+	}
+	return smallest, largest
+}
+
+// newVolumesDivergedCondition creates the condition which reports the PVCs expanded out-of-band to different sizes.
+func newVolumesDivergedCondition(cluster *appsv1alpha1.Cluster, diverged []string) metav1.Condition {
+	return metav1.Condition{
+		Type:               appsv1alpha1.ConditionTypeVolumesInSync,
+		ObservedGeneration: cluster.Generation,
+		Status:             metav1.ConditionFalse,
+		Message: fmt.Sprintf("the PVCs are expanded out-of-band to different sizes: %s, "+
+			"create a VolumeExpansion OpsRequest with the largest size to reconcile them, e.g. kbcli cluster volume-expand %s -n %s",
+			strings.Join(diverged, ", "), cluster.Name, cluster.Namespace),
+		Reason: ReasonVolumesExpandedOutOfBand,
+	}
+}
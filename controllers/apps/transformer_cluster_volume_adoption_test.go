@@ -0,0 +1,71 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestExpandedStorageRange(t *testing.T) {
+	newPVC := func(vctName, request, capacity string) corev1.PersistentVolumeClaim {
+		pvc := corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{constant.VolumeClaimTemplateNameLabelKey: vctName},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(request)},
+				},
+			},
+		}
+		if capacity != "" {
+			pvc.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(capacity)}
+		}
+		return pvc
+	}
+
+	pvcs := []corev1.PersistentVolumeClaim{
+		newPVC("data", "30Gi", "30Gi"),
+		newPVC("data", "20Gi", "20Gi"),
+		newPVC("log", "5Gi", "5Gi"),
+	}
+	smallest, largest := expandedStorageRange(pvcs, "data")
+	assert.Equal(t, "20Gi", smallest.String())
+	assert.Equal(t, "30Gi", largest.String())
+
+	smallest, _ = expandedStorageRange(pvcs, "not-exist")
+	assert.Nil(t, smallest)
+
+	// the PVC is being resized
+	pvcs = append(pvcs, newPVC("data", "40Gi", "30Gi"))
+	smallest, _ = expandedStorageRange(pvcs, "data")
+	assert.Nil(t, smallest)
+
+	// the PVC is not bound
+	smallest, _ = expandedStorageRange([]corev1.PersistentVolumeClaim{newPVC("data", "20Gi", "")}, "data")
+	assert.Nil(t, smallest)
+}
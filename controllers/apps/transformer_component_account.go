@@ -21,7 +21,6 @@ package apps
 
 import (
 	"fmt"
-	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -133,15 +132,7 @@ func (t *componentAccountTransformer) buildPassword(ctx *componentTransformConte
 }
 
 func (t *componentAccountTransformer) generatePassword(account appsv1alpha1.SystemAccount) []byte {
-	config := account.PasswordGenerationPolicy
-	passwd, _ := common.GeneratePassword((int)(config.Length), (int)(config.NumDigits), (int)(config.NumSymbols), false, config.Seed)
-	switch config.LetterCase {
-	case appsv1alpha1.UpperCases:
-		passwd = strings.ToUpper(passwd)
-	case appsv1alpha1.LowerCases:
-		passwd = strings.ToLower(passwd)
-	}
-	return []byte(passwd)
+	return component.GenerateAccountPassword(account)
 }
 
 func (t *componentAccountTransformer) buildAccountSecretWithPassword(synthesizeComp *component.SynthesizedComponent,
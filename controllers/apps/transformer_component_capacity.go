@@ -0,0 +1,188 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+	resourcehelper "k8s.io/kubectl/pkg/util/resource"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/conditions"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	"github.com/apecloud/kubeblocks/pkg/controller/scheduling"
+)
+
+// componentCapacityAdvisoryTransformer checks whether the nodes of the K8s cluster can possibly hold the pods
+// of the component before the underlying workload is created.
+//
+// It is advisory only: the result is reported as the InsufficientCapacity condition and a warning event, and the
+// workload is created anyway, since the nodes may be provisioned on demand by the cluster autoscaler for the pending pods.
+type componentCapacityAdvisoryTransformer struct{}
+
+var _ graph.Transformer = &componentCapacityAdvisoryTransformer{}
+
+func (t *componentCapacityAdvisoryTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	comp := transCtx.Component
+	if model.IsObjectDeleting(transCtx.ComponentOrig) || !viper.GetBool(constant.CfgKeyCapacityPreCheck) {
+		return nil
+	}
+
+	// the check only makes sense before provisioning, the running workload is handled by the scheduler.
+	exist, err := t.workloadExists(transCtx)
+	if err != nil {
+		return err
+	}
+	if exist {
+		if comp.Status.Phase == appsv1alpha1.RunningClusterCompPhase {
+			conditions.Remove(&comp.Status.Conditions, appsv1alpha1.ConditionTypeInsufficientCapacity)
+		}
+		return nil
+	}
+
+	synthesizeComp := transCtx.SynthesizeComponent
+	if synthesizeComp.PodSpec == nil || synthesizeComp.Replicas == 0 {
+		conditions.Remove(&comp.Status.Conditions, appsv1alpha1.ConditionTypeInsufficientCapacity)
+		return nil
+	}
+
+	nodes := &corev1.NodeList{}
+	if err = transCtx.Client.List(transCtx.Context, nodes); err != nil {
+		if apierrors.IsForbidden(err) {
+			transCtx.Logger.Info("skip capacity pre-check since the nodes are not accessible")
+			return nil
+		}
+		return err
+	}
+
+	shortfall := checkComponentCapacity(synthesizeComp, nodes.Items)
+	if shortfall == nil {
+		conditions.Remove(&comp.Status.Conditions, appsv1alpha1.ConditionTypeInsufficientCapacity)
+		return nil
+	}
+
+	message := shortfall.String()
+	conditions.Set(&comp.Status.Conditions, metav1.Condition{
+		Type:               appsv1alpha1.ConditionTypeInsufficientCapacity,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: comp.Generation,
+		Reason:             ReasonInsufficientCapacity,
+		Message:            message,
+	})
+	transCtx.EventRecorder.Event(comp, corev1.EventTypeWarning, ReasonInsufficientCapacity, message)
+	return nil
+}
+
+func (t *componentCapacityAdvisoryTransformer) workloadExists(transCtx *componentTransformContext) (bool, error) {
+	if transCtx.SynthesizeComponent.WorkloadType == appsv1alpha1.JobWorkloadType {
+		job, err := (&componentWorkloadTransformer{}).runningTaskJobObject(transCtx, transCtx.SynthesizeComponent)
+		if err != nil {
+			return false, err
+		}
+		return job != nil, nil
+	}
+	its, err := (&componentWorkloadTransformer{}).runningInstanceSetObject(transCtx, transCtx.SynthesizeComponent)
+	if err != nil {
+		return false, err
+	}
+	return its != nil, nil
+}
+
+// capacityShortfall describes why the pods of a component can not fit into the nodes.
+type capacityShortfall struct {
+	replicas         int32
+	schedulable      int32
+	eligibleNodes    int
+	podRequests      corev1.ResourceList
+	resourcesMissing corev1.ResourceList
+}
+
+func (s *capacityShortfall) String() string {
+	msg := fmt.Sprintf("insufficient capacity: %d of %d replicas requesting %s can be placed on %d eligible nodes",
+		s.schedulable, s.replicas, scheduling.FormatResourceList(s.podRequests), s.eligibleNodes)
+	if len(s.resourcesMissing) > 0 {
+		msg += fmt.Sprintf(", shortfall: %s", scheduling.FormatResourceList(s.resourcesMissing))
+	}
+	return msg
+}
+
+// checkComponentCapacity calculates how many replicas of the component can be placed on the nodes according to
+// the node allocatable, it returns nil if all the replicas can possibly fit.
+//
+// The calculation is optimistic, the resources requested by the pods already running on the nodes are not taken
+// into account, so a positive result means the component can never be scheduled as it is.
+func checkComponentCapacity(synthesizeComp *component.SynthesizedComponent, nodes []corev1.Node) *capacityShortfall {
+	pod := &corev1.Pod{Spec: *synthesizeComp.PodSpec}
+	requests, _ := resourcehelper.PodRequestsAndLimits(pod)
+	requests[corev1.ResourcePods] = *resource.NewQuantity(1, resource.DecimalSI)
+
+	var (
+		schedulable   int32
+		eligibleNodes int
+		allocatable   = corev1.ResourceList{}
+		affinity      = nodeaffinity.GetRequiredNodeAffinity(pod)
+	)
+	for i := range nodes {
+		node := &nodes[i]
+		if !scheduling.IsNodeEligible(node, pod, affinity) {
+			continue
+		}
+		eligibleNodes++
+		schedulable += scheduling.PodsFitOnNode(requests, node.Status.Allocatable)
+		for name, quantity := range node.Status.Allocatable {
+			total := allocatable[name]
+			total.Add(quantity)
+			allocatable[name] = total
+		}
+		if schedulable >= synthesizeComp.Replicas {
+			return nil
+		}
+	}
+
+	missing := corev1.ResourceList{}
+	for name, quantity := range requests {
+		if name == corev1.ResourcePods {
+			continue
+		}
+		total := quantity.DeepCopy()
+		total.Mul(int64(synthesizeComp.Replicas))
+		total.Sub(allocatable[name])
+		if total.Sign() > 0 {
+			missing[name] = total
+		}
+	}
+	return &capacityShortfall{
+		replicas:         synthesizeComp.Replicas,
+		schedulable:      schedulable,
+		eligibleNodes:    eligibleNodes,
+		podRequests:      requests,
+		resourcesMissing: missing,
+	}
+}
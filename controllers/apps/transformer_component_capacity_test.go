@@ -0,0 +1,102 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+)
+
+var _ = Describe("component capacity advisory transformer", func() {
+	var synthesizeComp *component.SynthesizedComponent
+
+	newNode := func(cpu, memory string, taints ...corev1.Taint) corev1.Node {
+		return corev1.Node{
+			Spec: corev1.NodeSpec{Taints: taints},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse(memory),
+					corev1.ResourcePods:   resource.MustParse("110"),
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		synthesizeComp = &component.SynthesizedComponent{
+			Replicas: 3,
+			PodSpec: &corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name: "mysql",
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("2"),
+								corev1.ResourceMemory: resource.MustParse("4Gi"),
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	Context("checkComponentCapacity", func() {
+		It("reports no shortfall if all the replicas fit", func() {
+			By("two pods fit on the first node, one on the second")
+			nodes := []corev1.Node{newNode("4", "8Gi"), newNode("2", "16Gi")}
+			Expect(checkComponentCapacity(synthesizeComp, nodes)).Should(BeNil())
+		})
+
+		It("excludes the nodes not tolerated", func() {
+			taint := corev1.Taint{Key: "dedicated", Value: "infra", Effect: corev1.TaintEffectNoSchedule}
+			nodes := []corev1.Node{newNode("4", "8Gi"), newNode("2", "16Gi", taint)}
+
+			By("the tainted node is not eligible")
+			shortfall := checkComponentCapacity(synthesizeComp, nodes)
+			Expect(shortfall).ShouldNot(BeNil())
+			Expect(shortfall.schedulable).Should(Equal(int32(2)))
+			Expect(shortfall.eligibleNodes).Should(Equal(1))
+			cpu := shortfall.resourcesMissing[corev1.ResourceCPU]
+			Expect(cpu.String()).Should(Equal("2"))
+			memory := shortfall.resourcesMissing[corev1.ResourceMemory]
+			Expect(memory.String()).Should(Equal("4Gi"))
+
+			By("the tainted node is eligible once the taint is tolerated")
+			synthesizeComp.PodSpec.Tolerations = []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}}
+			Expect(checkComponentCapacity(synthesizeComp, nodes)).Should(BeNil())
+		})
+
+		It("reports the shortfall if no node is large enough even though the total allocatable is sufficient", func() {
+			nodes := []corev1.Node{newNode("1", "8Gi"), newNode("1", "8Gi"), newNode("1", "8Gi"), newNode("4", "2Gi")}
+			shortfall := checkComponentCapacity(synthesizeComp, nodes)
+			Expect(shortfall).ShouldNot(BeNil())
+			Expect(shortfall.schedulable).Should(Equal(int32(0)))
+			Expect(shortfall.resourcesMissing).Should(BeEmpty())
+			Expect(shortfall.String()).Should(ContainSubstring("0 of 3 replicas requesting cpu=2,memory=4Gi can be placed on 4 eligible nodes"))
+		})
+	})
+})
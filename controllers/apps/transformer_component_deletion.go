@@ -27,6 +27,7 @@ import (
 	"github.com/pkg/errors"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -72,7 +73,26 @@ func (t *componentDeletionTransformer) Transform(ctx graph.TransformContext, dag
 		return newRequeueError(time.Second*1, "updating component status to deleting")
 	}
 
-	// step2: do the pre-terminate action if needed
+	compShortName, err := component.ShortName(cluster.Name, comp.Name)
+	if err != nil {
+		return err
+	}
+	compScaleIn, ok := comp.Annotations[constant.ComponentScaleInAnnotationKey]
+	isScaleIn := ok && compScaleIn == trueVal
+
+	// step2: export the data of the component removed from the cluster before it's deleted
+	if isScaleIn {
+		exported, err := exportCompData(transCtx, graphCli, dag, cluster, comp, compShortName)
+		if err != nil {
+			transCtx.EventRecorder.Event(comp, corev1.EventTypeWarning, "DataExportFailed", err.Error())
+			return newRequeueError(requeueDuration, err.Error())
+		}
+		if !exported {
+			return newRequeueError(requeueDuration, "waiting for the data export of the component")
+		}
+	}
+
+	// step3: do the pre-terminate action if needed
 	if err := component.ReconcileCompPreTerminate(reqCtx, transCtx.Client, graphCli, cluster, comp, dag); err != nil {
 		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeExpectedInProcess) {
 			// waiting for the preTerminate action to be done, and watch the action finish event to trigger the next reconcile
@@ -84,15 +104,9 @@ func (t *componentDeletionTransformer) Transform(ctx graph.TransformContext, dag
 		return err
 	}
 
-	// step3: delete the sub-resources
-	compShortName, err := component.ShortName(cluster.Name, comp.Name)
-	if err != nil {
-		return err
-	}
+	// step4: delete the sub-resources
 	ml := constant.GetComponentWellKnownLabels(cluster.Name, compShortName)
-
-	compScaleIn, ok := comp.Annotations[constant.ComponentScaleInAnnotationKey]
-	if ok && compScaleIn == trueVal {
+	if isScaleIn {
 		return t.handleCompDeleteWhenScaleIn(transCtx, graphCli, dag, comp, ml)
 	}
 	return t.handleCompDeleteWhenClusterDelete(transCtx, graphCli, dag, cluster, comp, ml)
@@ -178,6 +192,7 @@ func compOwnedKinds() []client.ObjectList {
 		&workloads.InstanceSetList{},
 		&policyv1.PodDisruptionBudgetList{},
 		&corev1.ServiceList{},
+		&networkingv1.NetworkPolicyList{},
 		&corev1.ServiceAccountList{},
 		&rbacv1.RoleBindingList{},
 		&batchv1.JobList{},
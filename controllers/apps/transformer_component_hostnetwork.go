@@ -105,7 +105,10 @@ func allocateHostPortsWithFunc(pm *intctrlutil.PortManager, synthesizedComp *com
 
 func updateObjectsWithAllocatedPorts(synthesizedComp *component.SynthesizedComponent, ports map[string]map[string]int32) error {
 	synthesizedComp.PodSpec.HostNetwork = true
-	synthesizedComp.PodSpec.DNSPolicy = corev1.DNSClusterFirstWithHostNet
+	// respect the DNS policy specified for the component explicitly
+	if synthesizedComp.DNSPolicy == nil {
+		synthesizedComp.PodSpec.DNSPolicy = corev1.DNSClusterFirstWithHostNet
+	}
 
 	for i, c := range synthesizedComp.PodSpec.Containers {
 		containerPorts, ok := ports[c.Name]
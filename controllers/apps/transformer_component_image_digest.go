@@ -0,0 +1,151 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/conditions"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+// componentImageDigestTransformer pins the images of the component to the digests reported by the pods
+// running them, and reports the pods whose images drift from the pinned digests.
+type componentImageDigestTransformer struct{}
+
+var _ graph.Transformer = &componentImageDigestTransformer{}
+
+func (t *componentImageDigestTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	comp := transCtx.Component
+	if model.IsObjectDeleting(transCtx.ComponentOrig) {
+		return nil
+	}
+
+	if comp.Spec.PinImageDigests == nil || !*comp.Spec.PinImageDigests {
+		comp.Status.ImageDigests = nil
+		conditions.Remove(&comp.Status.Conditions, appsv1alpha1.ConditionTypeImageDigestDrifted)
+		return nil
+	}
+
+	synthesizeComp := transCtx.SynthesizeComponent
+	if synthesizeComp.PodSpec == nil {
+		return nil
+	}
+	pods, err := component.ListOwnedPods(transCtx.Context, transCtx.Client, synthesizeComp.Namespace,
+		synthesizeComp.ClusterName, synthesizeComp.Name)
+	if err != nil {
+		return err
+	}
+
+	digests, drifts := pinImageDigests(comp.Status.ImageDigests, synthesizeComp.PodSpec, pods)
+	comp.Status.ImageDigests = digests
+	if len(drifts) == 0 {
+		if conditions.Remove(&comp.Status.Conditions, appsv1alpha1.ConditionTypeImageDigestDrifted) {
+			transCtx.EventRecorder.Event(comp, corev1.EventTypeNormal, ReasonImageDigestDriftResolved,
+				"the pods run the images of the pinned digests")
+		}
+		return nil
+	}
+
+	message := fmt.Sprintf("the images of the pods drift from the pinned digests: %s", strings.Join(drifts, "; "))
+	if conditions.Set(&comp.Status.Conditions, metav1.Condition{
+		Type:               appsv1alpha1.ConditionTypeImageDigestDrifted,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: comp.Generation,
+		Reason:             ReasonImageDigestDrifted,
+		Message:            message,
+	}) {
+		transCtx.EventRecorder.Event(comp, corev1.EventTypeWarning, ReasonImageDigestDrifted, message)
+	}
+	return nil
+}
+
+// pinImageDigests returns the digests pinned for the images of the pod spec, and the drifts of the pods from them.
+//
+// The digest of an image not pinned yet, i.e. the component is just provisioned or the image is updated, is resolved
+// from the first pod running it. The pinned digests of the images no longer used are dropped.
+func pinImageDigests(pinned map[string]string, podSpec *corev1.PodSpec, pods []*corev1.Pod) (map[string]string, []string) {
+	digests := map[string]string{}
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for _, c := range containers {
+			digests[c.Image] = pinned[c.Image]
+		}
+	}
+
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+	var drifts []string
+	for _, pod := range pods {
+		images := map[string]string{}
+		for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+			for _, c := range containers {
+				images[c.Name] = c.Image
+			}
+		}
+		for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+			for _, status := range statuses {
+				image := images[status.Name]
+				digest := getImageDigest(status.ImageID)
+				if _, ok := digests[image]; !ok || digest == "" {
+					continue
+				}
+				switch digests[image] {
+				case "":
+					digests[image] = digest
+				case digest:
+				default:
+					drifts = append(drifts, fmt.Sprintf("%s/%s runs %s@%s instead of %s",
+						pod.Name, status.Name, image, digest, digests[image]))
+				}
+			}
+		}
+	}
+
+	for image, digest := range digests {
+		if digest == "" {
+			delete(digests, image)
+		}
+	}
+	if len(digests) == 0 {
+		return nil, drifts
+	}
+	return digests, drifts
+}
+
+// getImageDigest returns the repo digest in the image ID reported by the container status,
+// e.g. "docker.io/apecloud/mysql@sha256:1a2b...". The image IDs without a repo digest are ignored,
+// since the IDs of the same image may vary across the container runtimes.
+func getImageDigest(imageID string) string {
+	i := strings.LastIndex(imageID, "@")
+	if i < 0 {
+		return ""
+	}
+	return imageID[i+1:]
+}
@@ -0,0 +1,75 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPinImageDigests(t *testing.T) {
+	const (
+		image    = "apecloud/mysql:8.0.33"
+		digest   = "sha256:1a2b"
+		drifted  = "sha256:3c4d"
+		repoName = "docker.io/apecloud/mysql"
+	)
+	buildPod := func(name, imageID string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "mysql", Image: image}},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "mysql", ImageID: imageID}},
+			},
+		}
+	}
+	podSpec := &corev1.PodSpec{
+		Containers: []corev1.Container{{Name: "mysql", Image: image}},
+	}
+
+	// the digest is resolved from the first pod, the image IDs without a repo digest are ignored
+	pods := []*corev1.Pod{buildPod("mysql-1", "sha256:ffff"), buildPod("mysql-0", repoName+"@"+digest)}
+	digests, drifts := pinImageDigests(nil, podSpec, pods)
+	assert.Equal(t, map[string]string{image: digest}, digests)
+	assert.Empty(t, drifts)
+
+	// the pod running another digest drifts
+	pods = append(pods, buildPod("mysql-2", repoName+"@"+drifted))
+	digests, drifts = pinImageDigests(digests, podSpec, pods)
+	assert.Equal(t, map[string]string{image: digest}, digests)
+	assert.Len(t, drifts, 1)
+	assert.Contains(t, drifts[0], "mysql-2/mysql")
+
+	// the pinned digest prevails over the pods
+	digests, drifts = pinImageDigests(map[string]string{image: drifted}, podSpec, pods[:2])
+	assert.Equal(t, map[string]string{image: drifted}, digests)
+	assert.Len(t, drifts, 1)
+
+	// the digests of the images no longer used are dropped
+	podSpec.Containers[0].Image = "apecloud/mysql:8.0.34"
+	digests, drifts = pinImageDigests(map[string]string{image: digest}, podSpec, nil)
+	assert.Nil(t, digests)
+	assert.Empty(t, drifts)
+}
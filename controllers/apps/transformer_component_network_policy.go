@@ -0,0 +1,229 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// componentNetworkPolicyTransformer handles the network policy of the component.
+type componentNetworkPolicyTransformer struct{}
+
+var _ graph.Transformer = &componentNetworkPolicyTransformer{}
+
+func (t *componentNetworkPolicyTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	if model.IsObjectDeleting(transCtx.ComponentOrig) {
+		return nil
+	}
+	if common.IsCompactMode(transCtx.ComponentOrig.Annotations) {
+		transCtx.V(1).Info("Component is in compact mode, no need to create network policy", "component", client.ObjectKeyFromObject(transCtx.ComponentOrig))
+		return nil
+	}
+
+	synthesizeComp := transCtx.SynthesizeComponent
+	key := types.NamespacedName{
+		Namespace: synthesizeComp.Namespace,
+		Name:      constant.GenerateClusterComponentName(synthesizeComp.ClusterName, synthesizeComp.Name),
+	}
+	runningPolicy := &networkingv1.NetworkPolicy{}
+	if err := transCtx.Client.Get(transCtx.Context, key, runningPolicy, inDataContext4C()); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		runningPolicy = nil
+	}
+	// don't touch the network policy not owned by the component
+	if runningPolicy != nil && !model.IsOwnerOf(transCtx.ComponentOrig, runningPolicy) {
+		transCtx.V(1).Info("NetworkPolicy is not owned by the component, skip it", "networkPolicy", key)
+		return nil
+	}
+
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	if !isNetworkPolicyEnabled(transCtx.Cluster) {
+		if runningPolicy != nil {
+			graphCli.Delete(dag, runningPolicy, inDataContext4G())
+		}
+		return nil
+	}
+
+	policy := buildComponentNetworkPolicy(transCtx.Cluster, transCtx.CompDef, synthesizeComp, key.Name)
+	if runningPolicy == nil {
+		graphCli.Create(dag, policy, inDataContext4G())
+		return nil
+	}
+	policyCopy := runningPolicy.DeepCopy()
+	policyCopy.Spec = policy.Spec
+	if !reflect.DeepEqual(runningPolicy, policyCopy) {
+		graphCli.Update(dag, runningPolicy, policyCopy, inDataContext4G())
+	}
+	return nil
+}
+
+func isNetworkPolicyEnabled(cluster *appsv1alpha1.Cluster) bool {
+	return cluster != nil && cluster.Spec.Network != nil && cluster.Spec.Network.NetworkPolicyEnabled
+}
+
+// buildComponentNetworkPolicy builds the network policy which only allows the ingress traffic from the pods of the cluster,
+// the KubeBlocks namespace, the allowed client namespaces, and the exposed service and exporter ports.
+func buildComponentNetworkPolicy(cluster *appsv1alpha1.Cluster, compDef *appsv1alpha1.ComponentDefinition,
+	synthesizeComp *component.SynthesizedComponent, name string) *networkingv1.NetworkPolicy {
+	rules := []networkingv1.NetworkPolicyIngressRule{
+		{
+			From: []networkingv1.NetworkPolicyPeer{{
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{constant.AppInstanceLabelKey: cluster.Name},
+				},
+			}},
+		},
+	}
+	if namespace := viper.GetString(constant.CfgKeyCtrlrMgrNS); namespace != "" {
+		rules = append(rules, networkingv1.NetworkPolicyIngressRule{
+			From: []networkingv1.NetworkPolicyPeer{{NamespaceSelector: buildNamespaceSelector(namespace)}},
+		})
+	}
+
+	servicePorts, externalPorts := buildServicePolicyPorts(cluster, synthesizeComp)
+	if namespaces := cluster.Spec.Network.AllowedNamespaces; len(namespaces) > 0 {
+		// all ports are allowed if the component has no service
+		rules = append(rules, networkingv1.NetworkPolicyIngressRule{
+			From:  []networkingv1.NetworkPolicyPeer{{NamespaceSelector: buildNamespaceSelector(namespaces...)}},
+			Ports: servicePorts,
+		})
+	}
+	if len(externalPorts) > 0 {
+		rules = append(rules, networkingv1.NetworkPolicyIngressRule{Ports: externalPorts})
+	}
+	if exporterPort := buildExporterPolicyPort(compDef, synthesizeComp); exporterPort != nil {
+		rules = append(rules, networkingv1.NetworkPolicyIngressRule{Ports: []networkingv1.NetworkPolicyPort{*exporterPort}})
+	}
+
+	labels := constant.GetComponentWellKnownLabels(synthesizeComp.ClusterName, synthesizeComp.Name)
+	return builder.NewNetworkPolicyBuilder(synthesizeComp.Namespace, name).
+		AddLabelsInMap(labels).
+		SetPodSelector(metav1.LabelSelector{MatchLabels: labels}).
+		AddPolicyTypes(networkingv1.PolicyTypeIngress).
+		AddIngressRules(rules...).
+		GetObject()
+}
+
+func buildNamespaceSelector(namespaces ...string) *metav1.LabelSelector {
+	return &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      corev1.LabelMetadataName,
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   namespaces,
+		}},
+	}
+}
+
+// buildServicePolicyPorts returns the target ports of all the services of the component,
+// and the ones of the services exposed outside the k8s cluster.
+func buildServicePolicyPorts(cluster *appsv1alpha1.Cluster,
+	synthesizeComp *component.SynthesizedComponent) ([]networkingv1.NetworkPolicyPort, []networkingv1.NetworkPolicyPort) {
+	services := make([]appsv1alpha1.Service, 0)
+	for _, svc := range synthesizeComp.ComponentServices {
+		services = append(services, svc.Service)
+	}
+	for _, svc := range cluster.Spec.Services {
+		if svc.ComponentSelector == synthesizeComp.Name {
+			services = append(services, svc.Service)
+		}
+	}
+
+	var (
+		servicePorts, externalPorts       []networkingv1.NetworkPolicyPort
+		servicePortKeys, externalPortKeys = sets.New[string](), sets.New[string]()
+	)
+	for _, svc := range services {
+		external := svc.Spec.Type == corev1.ServiceTypeNodePort || svc.Spec.Type == corev1.ServiceTypeLoadBalancer
+		for _, port := range svc.Spec.Ports {
+			policyPort := buildServicePolicyPort(port)
+			key := fmt.Sprintf("%s/%s", *policyPort.Protocol, policyPort.Port.String())
+			if !servicePortKeys.Has(key) {
+				servicePortKeys.Insert(key)
+				servicePorts = append(servicePorts, policyPort)
+			}
+			if external && !externalPortKeys.Has(key) {
+				externalPortKeys.Insert(key)
+				externalPorts = append(externalPorts, policyPort)
+			}
+		}
+	}
+	return servicePorts, externalPorts
+}
+
+func buildServicePolicyPort(port corev1.ServicePort) networkingv1.NetworkPolicyPort {
+	protocol := port.Protocol
+	if protocol == "" {
+		protocol = corev1.ProtocolTCP
+	}
+	// the network policy takes effect on the ports of the pods
+	targetPort := port.TargetPort
+	if targetPort.Type == intstr.Int && targetPort.IntVal == 0 {
+		targetPort = intstr.FromInt32(port.Port)
+	}
+	return networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &targetPort}
+}
+
+func buildExporterPolicyPort(compDef *appsv1alpha1.ComponentDefinition,
+	synthesizeComp *component.SynthesizedComponent) *networkingv1.NetworkPolicyPort {
+	if compDef == nil || synthesizeComp.DisableExporter == nil || *synthesizeComp.DisableExporter {
+		return nil
+	}
+	exporter := component.GetExporter(compDef.Spec)
+	if exporter == nil {
+		return nil
+	}
+	var container *corev1.Container
+	if synthesizeComp.PodSpec != nil {
+		for i := range synthesizeComp.PodSpec.Containers {
+			if synthesizeComp.PodSpec.Containers[i].Name == exporter.ContainerName {
+				container = &synthesizeComp.PodSpec.Containers[i]
+			}
+		}
+	}
+	port := common.FromContainerPort(*exporter, container)
+	if port == "" {
+		return nil
+	}
+	protocol := corev1.ProtocolTCP
+	targetPort := intstr.Parse(port)
+	return &networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &targetPort}
+}
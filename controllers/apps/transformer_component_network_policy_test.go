@@ -0,0 +1,116 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+func TestBuildComponentNetworkPolicy(t *testing.T) {
+	viper.Set(constant.CfgKeyCtrlrMgrNS, "kb-system")
+	defer viper.Set(constant.CfgKeyCtrlrMgrNS, "")
+
+	cluster := &appsv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-cluster"},
+		Spec: appsv1alpha1.ClusterSpec{
+			Network: &appsv1alpha1.ClusterNetwork{
+				NetworkPolicyEnabled: true,
+				AllowedNamespaces:    []string{"app"},
+			},
+			Services: []appsv1alpha1.ClusterService{
+				{
+					Service: appsv1alpha1.Service{
+						Name: "external",
+						Spec: corev1.ServiceSpec{
+							Type:  corev1.ServiceTypeLoadBalancer,
+							Ports: []corev1.ServicePort{{Name: "mysql", Port: 13306, TargetPort: intstr.FromString("mysql")}},
+						},
+					},
+					ComponentSelector: "mysql",
+				},
+			},
+		},
+	}
+	synthesizeComp := &component.SynthesizedComponent{
+		Namespace:   "default",
+		ClusterName: "test-cluster",
+		Name:        "mysql",
+		ComponentServices: []appsv1alpha1.ComponentService{
+			{
+				Service: appsv1alpha1.Service{
+					Name: "default",
+					Spec: corev1.ServiceSpec{
+						Ports: []corev1.ServicePort{{Name: "mysql", Port: 3306}, {Name: "paxos", Port: 13306, TargetPort: intstr.FromString("mysql")}},
+					},
+				},
+			},
+		},
+	}
+
+	policy := buildComponentNetworkPolicy(cluster, nil, synthesizeComp, "test-cluster-mysql")
+	assert.Equal(t, "test-cluster-mysql", policy.Name)
+	assert.Equal(t, constant.GetComponentWellKnownLabels("test-cluster", "mysql"), policy.Spec.PodSelector.MatchLabels)
+	assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, policy.Spec.PolicyTypes)
+	// intra-cluster, operator, allowed namespaces and the load balancer service
+	assert.Len(t, policy.Spec.Ingress, 4)
+	assert.Equal(t, "test-cluster", policy.Spec.Ingress[0].From[0].PodSelector.MatchLabels[constant.AppInstanceLabelKey])
+	assert.Equal(t, []string{"kb-system"}, policy.Spec.Ingress[1].From[0].NamespaceSelector.MatchExpressions[0].Values)
+	assert.Equal(t, []string{"app"}, policy.Spec.Ingress[2].From[0].NamespaceSelector.MatchExpressions[0].Values)
+	// the ports are deduplicated by the target ports
+	assert.Len(t, policy.Spec.Ingress[2].Ports, 2)
+	assert.Equal(t, intstr.FromInt32(3306), *policy.Spec.Ingress[2].Ports[0].Port)
+	assert.Equal(t, intstr.FromString("mysql"), *policy.Spec.Ingress[2].Ports[1].Port)
+	assert.Empty(t, policy.Spec.Ingress[3].From)
+	assert.Len(t, policy.Spec.Ingress[3].Ports, 1)
+
+	// all ports are allowed for the allowed namespaces if the component has no service
+	cluster.Spec.Services = nil
+	synthesizeComp.ComponentServices = nil
+	policy = buildComponentNetworkPolicy(cluster, nil, synthesizeComp, "test-cluster-mysql")
+	assert.Len(t, policy.Spec.Ingress, 3)
+	assert.Empty(t, policy.Spec.Ingress[2].Ports)
+}
+
+func TestBuildExporterPolicyPort(t *testing.T) {
+	disableExporter := false
+	compDef := &appsv1alpha1.ComponentDefinition{
+		Spec: appsv1alpha1.ComponentDefinitionSpec{
+			Exporter: &appsv1alpha1.Exporter{ContainerName: "exporter", ScrapePort: "http-metrics"},
+		},
+	}
+	synthesizeComp := &component.SynthesizedComponent{DisableExporter: &disableExporter}
+	port := buildExporterPolicyPort(compDef, synthesizeComp)
+	assert.NotNil(t, port)
+	assert.Equal(t, intstr.FromString("http-metrics"), *port.Port)
+
+	disableExporter = true
+	assert.Nil(t, buildExporterPolicyPort(compDef, synthesizeComp))
+}
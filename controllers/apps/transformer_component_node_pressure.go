@@ -0,0 +1,260 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/kubectl/pkg/util/podutils"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/conditions"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// componentNodePressureTransformer reports the nodes hosting the pods of the component which are under pressure
+// or not ready, and optionally switches the leader over to a healthy node before its node degrades further.
+type componentNodePressureTransformer struct{}
+
+var _ graph.Transformer = &componentNodePressureTransformer{}
+
+func (t *componentNodePressureTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	comp := transCtx.Component
+	if model.IsObjectDeleting(transCtx.ComponentOrig) || !viper.GetBool(constant.CfgKeyNodePressureDetection) {
+		return nil
+	}
+
+	synthesizeComp := transCtx.SynthesizeComponent
+	pods, err := component.ListOwnedPods(transCtx.Context, transCtx.Client, synthesizeComp.Namespace,
+		synthesizeComp.ClusterName, synthesizeComp.Name)
+	if err != nil {
+		return err
+	}
+	pressures, err := t.getNodePressures(transCtx, pods)
+	if err != nil {
+		if apierrors.IsForbidden(err) {
+			transCtx.Logger.Info("skip node pressure detection since the nodes are not accessible")
+			return nil
+		}
+		return err
+	}
+
+	if len(pressures) == 0 {
+		if conditions.Remove(&comp.Status.Conditions, appsv1alpha1.ConditionTypeNodePressure) {
+			transCtx.EventRecorder.Event(comp, corev1.EventTypeNormal, ReasonNodePressureResolved,
+				"the nodes hosting the pods are healthy")
+		}
+		return nil
+	}
+
+	message := formatNodePressures(pressures)
+	if conditions.Set(&comp.Status.Conditions, metav1.Condition{
+		Type:               appsv1alpha1.ConditionTypeNodePressure,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: comp.Generation,
+		Reason:             ReasonNodePressure,
+		Message:            message,
+	}) {
+		transCtx.EventRecorder.Event(comp, corev1.EventTypeWarning, ReasonNodePressure, message)
+	}
+
+	if viper.GetBool(constant.CfgKeyNodePressureSwitchover) {
+		t.switchoverLeader(transCtx, dag, pods, pressures)
+	}
+	return nil
+}
+
+// getNodePressures returns the pressures of the nodes hosting the pods, keyed by the node name.
+func (t *componentNodePressureTransformer) getNodePressures(transCtx *componentTransformContext,
+	pods []*corev1.Pod) (map[string]*nodePressure, error) {
+	pressures := map[string]*nodePressure{}
+	checked := map[string]bool{}
+	for _, pod := range pods {
+		nodeName := pod.Spec.NodeName
+		if nodeName == "" {
+			continue
+		}
+		if !checked[nodeName] {
+			checked[nodeName] = true
+			node := &corev1.Node{}
+			if err := transCtx.Client.Get(transCtx.Context, types.NamespacedName{Name: nodeName}, node); err != nil {
+				// the pods on a deleted node will be rescheduled, nothing to report.
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			if pressure := getNodePressure(node); pressure != nil {
+				pressures[nodeName] = pressure
+			}
+		}
+		if pressure, ok := pressures[nodeName]; ok {
+			pressure.pods = append(pressure.pods, pod.Name)
+		}
+	}
+	return pressures, nil
+}
+
+// switchoverLeader creates a Switchover OpsRequest to move the leader away from the node under pressure,
+// if there is a ready candidate on a healthy node and the component supports switchover.
+func (t *componentNodePressureTransformer) switchoverLeader(transCtx *componentTransformContext, dag *graph.DAG,
+	pods []*corev1.Pod, pressures map[string]*nodePressure) {
+	synthesizeComp := transCtx.SynthesizeComponent
+	actions := synthesizeComp.LifecycleActions
+	if actions == nil || actions.Switchover == nil {
+		return
+	}
+	leaderPod, candidatePod := selectNodePressureSwitchover(synthesizeComp.Roles, pods, pressures)
+	if leaderPod == nil || candidatePod == nil {
+		return
+	}
+	instanceName := candidatePod.Name
+	if actions.Switchover.WithCandidate == nil {
+		if actions.Switchover.WithoutCandidate == nil {
+			return
+		}
+		instanceName = appsv1alpha1.KBSwitchoverCandidateInstanceForAnyPod
+	}
+
+	pressure := pressures[leaderPod.Spec.NodeName]
+	opsRequest := &appsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			// the name is deterministic for the pressure of the node, to avoid switching over repeatedly.
+			Name:      fmt.Sprintf("%s-switchover-%d", leaderPod.Name, pressure.since.Unix()),
+			Namespace: synthesizeComp.Namespace,
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey:    synthesizeComp.ClusterName,
+				constant.KBAppComponentLabelKey: synthesizeComp.Name,
+			},
+		},
+		Spec: appsv1alpha1.OpsRequestSpec{
+			ClusterName: synthesizeComp.ClusterName,
+			Type:        appsv1alpha1.SwitchoverType,
+			SwitchoverList: []appsv1alpha1.Switchover{{
+				ComponentOps: appsv1alpha1.ComponentOps{ComponentName: synthesizeComp.Name},
+				InstanceName: instanceName,
+			}},
+		},
+	}
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	graphCli.Create(dag, opsRequest)
+	transCtx.EventRecorder.Eventf(transCtx.Component, corev1.EventTypeWarning, ReasonNodePressureSwitchover,
+		"the leader %s is on the node %s under pressure, switch it over by the OpsRequest %s",
+		leaderPod.Name, leaderPod.Spec.NodeName, opsRequest.Name)
+}
+
+// nodePressure describes why a node hosting the pods of a component is degrading.
+type nodePressure struct {
+	node       string
+	conditions []string
+	// since is the earliest transition time of the conditions.
+	since time.Time
+	pods  []string
+}
+
+// getNodePressure returns the pressure of the node, or nil if the node is ready and not under
+// disk or memory pressure.
+func getNodePressure(node *corev1.Node) *nodePressure {
+	pressure := &nodePressure{node: node.Name}
+	add := func(condition corev1.NodeCondition, name string) {
+		pressure.conditions = append(pressure.conditions, name)
+		if pressure.since.IsZero() || condition.LastTransitionTime.Time.Before(pressure.since) {
+			pressure.since = condition.LastTransitionTime.Time
+		}
+	}
+	for _, condition := range node.Status.Conditions {
+		switch condition.Type {
+		case corev1.NodeReady:
+			if condition.Status != corev1.ConditionTrue {
+				add(condition, "NotReady")
+			}
+		case corev1.NodeDiskPressure, corev1.NodeMemoryPressure:
+			if condition.Status == corev1.ConditionTrue {
+				add(condition, string(condition.Type))
+			}
+		}
+	}
+	if len(pressure.conditions) == 0 {
+		return nil
+	}
+	return pressure
+}
+
+func formatNodePressures(pressures map[string]*nodePressure) string {
+	nodes := make([]string, 0, len(pressures))
+	for node := range pressures {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	msgs := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		pressure := pressures[node]
+		sort.Strings(pressure.pods)
+		msgs = append(msgs, fmt.Sprintf("node %s is %s, hosting pods: %s",
+			node, strings.Join(pressure.conditions, ", "), strings.Join(pressure.pods, ", ")))
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// selectNodePressureSwitchover returns the leader pod on a node under pressure, and a ready candidate
+// on a healthy node to take over the leadership, the candidates with a votable role are preferred.
+func selectNodePressureSwitchover(roles []appsv1alpha1.ReplicaRole, pods []*corev1.Pod,
+	pressures map[string]*nodePressure) (*corev1.Pod, *corev1.Pod) {
+	leaderRoles := map[string]bool{}
+	votableRoles := map[string]bool{}
+	for _, role := range roles {
+		if role.Serviceable && role.Writable {
+			leaderRoles[role.Name] = true
+		}
+		if role.Votable {
+			votableRoles[role.Name] = true
+		}
+	}
+	var leaderPod, candidatePod *corev1.Pod
+	for _, pod := range pods {
+		role := pod.Labels[constant.RoleLabelKey]
+		_, underPressure := pressures[pod.Spec.NodeName]
+		if leaderRoles[role] {
+			if underPressure {
+				leaderPod = pod
+			}
+			continue
+		}
+		if role == "" || underPressure || !podutils.IsPodReady(pod) {
+			continue
+		}
+		if candidatePod == nil || (votableRoles[role] && !votableRoles[candidatePod.Labels[constant.RoleLabelKey]]) {
+			candidatePod = pod
+		}
+	}
+	return leaderPod, candidatePod
+}
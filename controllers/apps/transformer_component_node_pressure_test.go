@@ -0,0 +1,98 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestGetNodePressure(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				{Type: corev1.NodeDiskPressure, Status: corev1.ConditionFalse},
+				{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse},
+			},
+		},
+	}
+	assert.Nil(t, getNodePressure(node))
+
+	node.Status.Conditions[1] = corev1.NodeCondition{Type: corev1.NodeDiskPressure, Status: corev1.ConditionTrue,
+		LastTransitionTime: metav1.NewTime(now)}
+	node.Status.Conditions[0] = corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionUnknown,
+		LastTransitionTime: metav1.NewTime(now.Add(-time.Minute))}
+	pressure := getNodePressure(node)
+	assert.NotNil(t, pressure)
+	assert.Equal(t, []string{"NotReady", "DiskPressure"}, pressure.conditions)
+	assert.Equal(t, now.Add(-time.Minute), pressure.since)
+
+	pressure.pods = []string{"pod-1", "pod-0"}
+	assert.Equal(t, "node node-1 is NotReady, DiskPressure, hosting pods: pod-0, pod-1",
+		formatNodePressures(map[string]*nodePressure{"node-1": pressure}))
+}
+
+func TestSelectNodePressureSwitchover(t *testing.T) {
+	roles := []appsv1alpha1.ReplicaRole{
+		{Name: "leader", Serviceable: true, Writable: true, Votable: true},
+		{Name: "follower", Serviceable: true, Votable: true},
+		{Name: "learner", Serviceable: true},
+	}
+	newPod := func(name, role, node string, ready bool) *corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{constant.RoleLabelKey: role}},
+			Spec:       corev1.PodSpec{NodeName: node},
+			Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}},
+		}
+	}
+	pods := []*corev1.Pod{
+		newPod("pod-0", "leader", "node-0", true),
+		newPod("pod-1", "learner", "node-1", true),
+		newPod("pod-2", "follower", "node-2", true),
+		newPod("pod-3", "follower", "node-3", false),
+	}
+
+	// the leader is on a healthy node
+	leader, _ := selectNodePressureSwitchover(roles, pods, map[string]*nodePressure{"node-1": {}})
+	assert.Nil(t, leader)
+
+	// the votable candidate is preferred
+	leader, candidate := selectNodePressureSwitchover(roles, pods, map[string]*nodePressure{"node-0": {}})
+	assert.Equal(t, "pod-0", leader.Name)
+	assert.Equal(t, "pod-2", candidate.Name)
+
+	// no ready candidate on a healthy node
+	_, candidate = selectNodePressureSwitchover(roles, pods, map[string]*nodePressure{"node-0": {}, "node-1": {}, "node-2": {}})
+	assert.Nil(t, candidate)
+}
@@ -23,6 +23,8 @@ import (
 	"fmt"
 	"time"
 
+	"golang.org/x/exp/slices"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -96,6 +98,9 @@ func (t *componentRBACTransformer) Transform(ctx graph.TransformContext, dag *gr
 		// serviceAccount must be created before workload
 		graphCli.DependOn(dag, its, serviceAccount)
 	}
+	for _, job := range graphCli.FindAll(dag, &batchv1.Job{}) {
+		graphCli.DependOn(dag, job, serviceAccount)
+	}
 
 	return nil
 }
@@ -132,6 +137,10 @@ func isVolumeProtectionEnabled(compDef *appsv1alpha1.ComponentDefinition) bool {
 	return false
 }
 
+func isNodeCordonFencingEnabled(compDef *appsv1alpha1.ComponentDefinition) bool {
+	return compDef.Spec.Fencing != nil && slices.Contains(compDef.Spec.Fencing.Strategies, appsv1alpha1.NodeCordonFencingStrategy)
+}
+
 func isServiceAccountExist(transCtx *componentTransformContext, serviceAccountName string) bool {
 	synthesizedComp := transCtx.SynthesizeComponent
 	namespaceName := types.NamespacedName{
@@ -260,6 +269,8 @@ func buildServiceAccount(transCtx *componentTransformContext) (*corev1.ServiceAc
 
 	serviceAccountName := comp.Spec.ServiceAccountName
 	volumeProtectionEnable := isVolumeProtectionEnabled(compDef)
+	// both volume protection and node cordon fencing require reading the nodes.
+	clusterRoleRequired := volumeProtectionEnable || isNodeCordonFencingEnabled(compDef)
 	dataProtectionEnable := isDataProtectionEnabled(backupPolicyTPL, cluster, comp)
 	if serviceAccountName == "" {
 		// If probe, volume protection, and data protection are disabled at the same tme, then do not create a service account.
@@ -271,15 +282,15 @@ func buildServiceAccount(transCtx *componentTransformContext) (*corev1.ServiceAc
 	}
 
 	if isRoleBindingExist(transCtx, serviceAccountName) && isServiceAccountExist(transCtx, serviceAccountName) {
-		// Volume protection requires the clusterRoleBinding permission, if volume protection is not enabled or the corresponding clusterRoleBinding already exists, then skip.
-		if !volumeProtectionEnable || isClusterRoleBindingExist(transCtx, serviceAccountName) {
+		// Volume protection and node cordon fencing require the clusterRoleBinding permission, if neither is enabled or the corresponding clusterRoleBinding already exists, then skip.
+		if !clusterRoleRequired || isClusterRoleBindingExist(transCtx, serviceAccountName) {
 			return nil, false, nil
 		}
 	}
 
 	buildSa := factory.BuildServiceAccount(cluster, serviceAccountName)
-	// if volume protection is enabled, the service account needs to be bound to the clusterRoleBinding.
-	return buildSa, volumeProtectionEnable, nil
+	// if volume protection or node cordon fencing is enabled, the service account needs to be bound to the clusterRoleBinding.
+	return buildSa, clusterRoleRequired, nil
 }
 
 func buildRoleBinding(cluster *appsv1alpha1.Cluster, serviceAccountName string) *rbacv1.RoleBinding {
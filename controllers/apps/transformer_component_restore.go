@@ -20,17 +20,27 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package apps
 
 import (
+	"fmt"
 	"reflect"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	"github.com/apecloud/kubeblocks/pkg/controller/plan"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	// componentRestoreProgress the event reason indicates the progress of restoring the data of the component.
+	componentRestoreProgress = "ComponentRestoreProgress"
 )
 
 type componentRestoreTransformer struct {
@@ -43,6 +53,7 @@ func (t *componentRestoreTransformer) Transform(ctx graph.TransformContext, dag
 	transCtx, _ := ctx.(*componentTransformContext)
 	synthesizedComp := transCtx.SynthesizeComponent
 	if synthesizedComp.Annotations[constant.RestoreFromBackupAnnotationKey] == "" {
+		transCtx.Component.Status.RestoreProgress = nil
 		return nil
 	}
 	reqCtx := intctrlutil.RequestCtx{
@@ -67,7 +78,11 @@ func (t *componentRestoreTransformer) Transform(ctx graph.TransformContext, dag
 		return err
 	}
 	needDoPostProvision, _ := component.NeedDoPostProvision(transCtx.Context, transCtx.Client, actionCtx)
-	if err := restoreMGR.DoRestore(synthesizedComp, transCtx.Component, needDoPostProvision); err != nil {
+	err = restoreMGR.DoRestore(synthesizedComp, transCtx.Component, needDoPostProvision)
+	if syncErr := t.syncRestoreProgress(transCtx, restoreMGR); syncErr != nil {
+		return syncErr
+	}
+	if err != nil {
 		return commitError(err)
 	}
 
@@ -77,3 +92,32 @@ func (t *componentRestoreTransformer) Transform(ctx graph.TransformContext, dag
 	}
 	return nil
 }
+
+// syncRestoreProgress records the progress of restoring the data of the component in its status,
+// and reports it by an event every time it advances by the configured step in percent.
+func (t *componentRestoreTransformer) syncRestoreProgress(transCtx *componentTransformContext, restoreMGR *plan.RestoreManager) error {
+	restore := &dpv1alpha1.Restore{}
+	restoreMeta := restoreMGR.GetRestoreObjectMeta(transCtx.SynthesizeComponent, dpv1alpha1.PrepareData)
+	if err := t.Client.Get(transCtx.Context, types.NamespacedName{Namespace: restoreMeta.Namespace, Name: restoreMeta.Name}, restore); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	progress := restore.Status.DataProgress
+	if progress == nil {
+		return nil
+	}
+	lastPercentage := transCtx.Component.Status.RestoreProgress.Percentage()
+	transCtx.Component.Status.RestoreProgress = progress.DeepCopy()
+
+	step := viper.GetInt(constant.CfgKeyRestoreProgressStep)
+	percentage := progress.Percentage()
+	if step <= 0 || percentage < 0 || percentage/step <= lastPercentage/step {
+		return nil
+	}
+	msg := fmt.Sprintf("restored %d%% of the data of component %s, %d/%d bytes at %d bytes/s",
+		percentage, transCtx.SynthesizeComponent.Name, progress.BytesRestored, progress.TotalBytes, progress.BytesPerSecond)
+	if progress.EstimatedCompletionTime != nil {
+		msg += fmt.Sprintf(", estimated to complete at %s", progress.EstimatedCompletionTime.Format(time.RFC3339))
+	}
+	transCtx.EventRecorder.Event(transCtx.Cluster, corev1.EventTypeNormal, componentRestoreProgress, msg)
+	return nil
+}
@@ -28,6 +28,8 @@ import (
 
 	"golang.org/x/exp/maps"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -48,6 +50,10 @@ var (
 
 	multiClusterServicePlacementInMirror = "mirror"
 	multiClusterServicePlacementInUnique = "unique"
+
+	readWriteServiceName = "rw"
+	readOnlyServiceName  = "ro"
+	canaryServiceName    = "canary"
 )
 
 // componentServiceTransformer handles component services.
@@ -89,6 +95,28 @@ func (t *componentServiceTransformer) Transform(ctx graph.TransformContext, dag
 		}
 	}
 
+	services, err := t.buildReadWriteSplitServices(transCtx.Component, synthesizeComp)
+	if err != nil {
+		return err
+	}
+	for _, svc := range services {
+		if err = createOrUpdateService(ctx, dag, graphCli, svc, transCtx.ComponentOrig); err != nil {
+			return err
+		}
+		delete(runningServices, svc.Name)
+	}
+
+	canaryService, err := t.buildCanaryService(transCtx, synthesizeComp)
+	if err != nil {
+		return err
+	}
+	if canaryService != nil {
+		if err = createOrUpdateService(ctx, dag, graphCli, canaryService, transCtx.ComponentOrig); err != nil {
+			return err
+		}
+		delete(runningServices, canaryService.Name)
+	}
+
 	for svc := range runningServices {
 		graphCli.Delete(dag, runningServices[svc], inDataContext4G())
 	}
@@ -211,6 +239,135 @@ func (t *componentServiceTransformer) buildService(comp *appsv1alpha1.Component,
 	return builder.GetObject(), nil
 }
 
+// buildReadWriteSplitServices builds the read-write and read-only services which select the pods by the access mode label,
+// the label is updated along with the role label once the role of a pod changes.
+func (t *componentServiceTransformer) buildReadWriteSplitServices(comp *appsv1alpha1.Component,
+	synthesizeComp *component.SynthesizedComponent) ([]*corev1.Service, error) {
+	if synthesizeComp.ReadWriteSplitServices == nil || !*synthesizeComp.ReadWriteSplitServices {
+		return nil, nil
+	}
+	var writable, readonly bool
+	for _, role := range synthesizeComp.Roles {
+		switch {
+		case role.Writable:
+			writable = true
+		case role.Serviceable:
+			readonly = true
+		}
+	}
+	if !writable {
+		return nil, fmt.Errorf("no writable role is defined for the read-write service, component: %s", synthesizeComp.Name)
+	}
+
+	userDefined := make(map[string]bool)
+	for _, service := range synthesizeComp.ComponentServices {
+		userDefined[service.ServiceName] = true
+	}
+	services := make([]*corev1.Service, 0)
+	build := func(serviceName string, accessMode workloads.AccessMode) error {
+		if userDefined[serviceName] {
+			return fmt.Errorf("the service name %s is reserved for the read-write split services, component: %s", serviceName, synthesizeComp.Name)
+		}
+		svcName := constant.GenerateComponentServiceName(synthesizeComp.ClusterName, synthesizeComp.Name, serviceName)
+		svc := builder.NewServiceBuilder(synthesizeComp.Namespace, svcName).
+			AddLabelsInMap(constant.GetComponentWellKnownLabels(synthesizeComp.ClusterName, synthesizeComp.Name)).
+			AddLabels(constant.AccessModeLabelKey, string(accessMode)).
+			AddSelectorsInMap(t.builtinSelector(comp)).
+			AddSelector(constant.AccessModeLabelKey, string(accessMode)).
+			AddPorts(t.containerServicePorts(synthesizeComp)...).
+			GetObject()
+		services = append(services, svc)
+		return nil
+	}
+	if err := build(readWriteServiceName, workloads.ReadWriteMode); err != nil {
+		return nil, err
+	}
+	if readonly {
+		if err := build(readOnlyServiceName, workloads.ReadonlyMode); err != nil {
+			return nil, err
+		}
+	}
+	return services, nil
+}
+
+// buildCanaryService builds the canary service which selects only the updated pods while the workload is being updated,
+// the updated pods are labeled by the InstanceSet controller.
+func (t *componentServiceTransformer) buildCanaryService(transCtx *componentTransformContext,
+	synthesizeComp *component.SynthesizedComponent) (*corev1.Service, error) {
+	if synthesizeComp.CanaryService == nil || !*synthesizeComp.CanaryService {
+		return nil, nil
+	}
+	for _, service := range synthesizeComp.ComponentServices {
+		if service.ServiceName == canaryServiceName {
+			return nil, fmt.Errorf("the service name %s is reserved for the canary service, component: %s", canaryServiceName, synthesizeComp.Name)
+		}
+	}
+
+	its := &workloads.InstanceSet{}
+	itsKey := types.NamespacedName{
+		Namespace: synthesizeComp.Namespace,
+		Name:      constant.GenerateWorkloadNamePattern(synthesizeComp.ClusterName, synthesizeComp.Name),
+	}
+	if err := transCtx.Client.Get(transCtx.Context, itsKey, its); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	if updating, err := isInstanceSetUpdating(its); err != nil || !updating {
+		return nil, err
+	}
+
+	svcName := constant.GenerateComponentServiceName(synthesizeComp.ClusterName, synthesizeComp.Name, canaryServiceName)
+	return builder.NewServiceBuilder(synthesizeComp.Namespace, svcName).
+		AddLabelsInMap(constant.GetComponentWellKnownLabels(synthesizeComp.ClusterName, synthesizeComp.Name)).
+		AddSelectorsInMap(t.builtinSelector(transCtx.Component)).
+		AddSelector(constant.UpdatedLabelKey, "true").
+		AddPorts(t.containerServicePorts(synthesizeComp)...).
+		GetObject(), nil
+}
+
+// isInstanceSetUpdating tells whether the InstanceSet is rolling out a new revision,
+// i.e. there are pods whose current revision differs from the update revision.
+func isInstanceSetUpdating(its *workloads.InstanceSet) (bool, error) {
+	currentRevisions, err := instanceset.GetRevisions(its.Status.CurrentRevisions)
+	if err != nil {
+		return false, err
+	}
+	updateRevisions, err := instanceset.GetRevisions(its.Status.UpdateRevisions)
+	if err != nil {
+		return false, err
+	}
+	for name, revision := range currentRevisions {
+		if updateRevision, ok := updateRevisions[name]; ok && updateRevision != revision {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (t *componentServiceTransformer) containerServicePorts(synthesizeComp *component.SynthesizedComponent) []corev1.ServicePort {
+	ports := make([]corev1.ServicePort, 0)
+	if synthesizeComp.PodSpec == nil {
+		return ports
+	}
+	for _, container := range synthesizeComp.PodSpec.Containers {
+		for _, port := range container.Ports {
+			servicePort := corev1.ServicePort{
+				Protocol: port.Protocol,
+				Port:     port.ContainerPort,
+			}
+			switch {
+			case len(port.Name) > 0:
+				servicePort.Name = port.Name
+				servicePort.TargetPort = intstr.FromString(port.Name)
+			default:
+				servicePort.Name = fmt.Sprintf("%s-%d", strings.ToLower(string(port.Protocol)), port.ContainerPort)
+				servicePort.TargetPort = intstr.FromInt(int(port.ContainerPort))
+			}
+			ports = append(ports, servicePort)
+		}
+	}
+	return ports
+}
+
 func (t *componentServiceTransformer) builtinSelector(comp *appsv1alpha1.Component) map[string]string {
 	selectors := map[string]string{
 		constant.AppManagedByLabelKey:   "",
@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
@@ -235,4 +236,125 @@ var _ = Describe(" component service transformer test", func() {
 			Expect(graphCli.IsAction(dag, svc, model.ActionCreatePtr())).Should(BeTrue())
 		})
 	})
+
+	Context("read-write split services", func() {
+		BeforeEach(func() {
+			transCtx.SynthesizeComponent.ReadWriteSplitServices = truep()
+			transCtx.SynthesizeComponent.PodSpec = &corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:  "mysql",
+					Ports: []corev1.ContainerPort{{Name: "mysql", ContainerPort: 3306, Protocol: corev1.ProtocolTCP}},
+				}},
+			}
+		})
+
+		It("provision", func() {
+			transCtx.SynthesizeComponent.Roles = []appsv1alpha1.ReplicaRole{
+				{Name: "leader", Serviceable: true, Writable: true},
+				{Name: "follower", Serviceable: true},
+				{Name: "learner"},
+			}
+			transformer := &componentServiceTransformer{}
+			err := transformer.Transform(transCtx, dag)
+			Expect(err).Should(BeNil())
+
+			graphCli := transCtx.Client.(model.GraphClient)
+			objs := graphCli.FindAll(dag, &corev1.Service{})
+			Expect(len(objs)).Should(Equal(3))
+			services := map[string]*corev1.Service{}
+			for _, obj := range objs {
+				services[obj.GetName()] = obj.(*corev1.Service)
+			}
+			for name, accessMode := range map[string]string{"rw": "ReadWrite", "ro": "Readonly"} {
+				svc := services[constant.GenerateComponentServiceName(clusterName, compName, name)]
+				Expect(svc).ShouldNot(BeNil())
+				Expect(svc.Spec.Selector).Should(HaveKeyWithValue(constant.AccessModeLabelKey, accessMode))
+				Expect(svc.Spec.Selector).Should(HaveKeyWithValue(constant.KBAppComponentLabelKey, compName))
+				Expect(svc.Labels).Should(HaveKeyWithValue(constant.AccessModeLabelKey, accessMode))
+				Expect(svc.Spec.Ports).Should(HaveLen(1))
+				Expect(svc.Spec.Ports[0].Port).Should(Equal(int32(3306)))
+			}
+		})
+
+		It("no read-only role", func() {
+			transCtx.SynthesizeComponent.Roles = []appsv1alpha1.ReplicaRole{
+				{Name: "primary", Serviceable: true, Writable: true},
+				{Name: "secondary"},
+			}
+			transformer := &componentServiceTransformer{}
+			err := transformer.Transform(transCtx, dag)
+			Expect(err).Should(BeNil())
+
+			graphCli := transCtx.Client.(model.GraphClient)
+			objs := graphCli.FindAll(dag, &corev1.Service{})
+			Expect(len(objs)).Should(Equal(2))
+			for _, obj := range objs {
+				Expect(obj.GetName()).ShouldNot(Equal(constant.GenerateComponentServiceName(clusterName, compName, "ro")))
+			}
+		})
+
+		It("no writable role", func() {
+			transformer := &componentServiceTransformer{}
+			err := transformer.Transform(transCtx, dag)
+			Expect(err).ShouldNot(BeNil())
+		})
+	})
+
+	Context("canary service", func() {
+		canaryServiceName := constant.GenerateComponentServiceName(clusterName, compName, "canary")
+
+		mockITS := func(currentRevision, updateRevision string) {
+			reader.objs = append(reader.objs, &workloads.InstanceSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testCtx.DefaultNamespace,
+					Name:      constant.GenerateWorkloadNamePattern(clusterName, compName),
+				},
+				Status: workloads.InstanceSetStatus{
+					CurrentRevisions: map[string]string{"pod-0": currentRevision, "pod-1": currentRevision},
+					UpdateRevisions:  map[string]string{"pod-0": updateRevision, "pod-1": updateRevision},
+				},
+			})
+		}
+
+		findCanaryService := func() *corev1.Service {
+			graphCli := transCtx.Client.(model.GraphClient)
+			for _, obj := range graphCli.FindAll(dag, &corev1.Service{}) {
+				if obj.GetName() == canaryServiceName {
+					return obj.(*corev1.Service)
+				}
+			}
+			return nil
+		}
+
+		BeforeEach(func() {
+			transCtx.SynthesizeComponent.CanaryService = truep()
+		})
+
+		It("provision during updating", func() {
+			mockITS("old-revision", "new-revision")
+			transformer := &componentServiceTransformer{}
+			err := transformer.Transform(transCtx, dag)
+			Expect(err).Should(BeNil())
+
+			svc := findCanaryService()
+			Expect(svc).ShouldNot(BeNil())
+			Expect(svc.Spec.Selector).Should(HaveKeyWithValue(constant.UpdatedLabelKey, "true"))
+			Expect(svc.Spec.Selector).Should(HaveKeyWithValue(constant.KBAppComponentLabelKey, compName))
+		})
+
+		It("not provision after updated", func() {
+			mockITS("new-revision", "new-revision")
+			transformer := &componentServiceTransformer{}
+			err := transformer.Transform(transCtx, dag)
+			Expect(err).Should(BeNil())
+			Expect(findCanaryService()).Should(BeNil())
+		})
+
+		It("not provision if the workload not exist", func() {
+			transformer := &componentServiceTransformer{}
+			err := transformer.Transform(transCtx, dag)
+			Expect(err).Should(BeNil())
+			Expect(findCanaryService()).Should(BeNil())
+		})
+	})
 })
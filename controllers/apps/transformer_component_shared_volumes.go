@@ -0,0 +1,70 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/controller/factory"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+// componentSharedVolumesTransformer handles the PVCs shared by all the instances of the component.
+// The shared PVCs are not managed by the workload, so they are kept when the component is scaled in or out,
+// and removed together with the other PVCs of the component according to the termination policy.
+type componentSharedVolumesTransformer struct{}
+
+var _ graph.Transformer = &componentSharedVolumesTransformer{}
+
+func (t *componentSharedVolumesTransformer) Transform(ctx graph.TransformContext, dag *graph.DAG) error {
+	transCtx, _ := ctx.(*componentTransformContext)
+	if model.IsObjectDeleting(transCtx.ComponentOrig) {
+		return nil
+	}
+
+	synthesizeComp := transCtx.SynthesizeComponent
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	for _, sharedVolume := range synthesizeComp.SharedVolumes {
+		pvc := factory.BuildSharedVolumeClaim(synthesizeComp, sharedVolume)
+		runningPVC := &corev1.PersistentVolumeClaim{}
+		if err := transCtx.Client.Get(transCtx.Context, client.ObjectKeyFromObject(pvc), runningPVC, inDataContext4C()); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			graphCli.Create(dag, pvc, inDataContext4G())
+			continue
+		}
+		// the shared volume can only be expanded, the other fields of the PVC spec are immutable.
+		storage := pvc.Spec.Resources.Requests.Storage()
+		if storage.IsZero() || storage.Cmp(*runningPVC.Spec.Resources.Requests.Storage()) <= 0 {
+			continue
+		}
+		pvcCopy := runningPVC.DeepCopy()
+		if pvcCopy.Spec.Resources.Requests == nil {
+			pvcCopy.Spec.Resources.Requests = corev1.ResourceList{}
+		}
+		pvcCopy.Spec.Resources.Requests[corev1.ResourceStorage] = *storage
+		graphCli.Update(dag, runningPVC, pvcCopy, inDataContext4G())
+	}
+	return nil
+}
@@ -24,14 +24,16 @@ import (
 	"strconv"
 	"time"
 
+	"golang.org/x/exp/slices"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/conditions"
 	cfgcore "github.com/apecloud/kubeblocks/pkg/configuration/core"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
@@ -90,6 +92,7 @@ func (t *componentStatusTransformer) Transform(ctx graph.TransformContext, dag *
 	synthesizeComp := transCtx.SynthesizeComponent
 	runningITS, _ := transCtx.RunningWorkload.(*workloads.InstanceSet)
 	protoITS, _ := transCtx.ProtoWorkload.(*workloads.InstanceSet)
+	runningJob, _ := transCtx.RunningWorkload.(*batchv1.Job)
 	switch {
 	case model.IsObjectUpdating(transCtx.ComponentOrig):
 		transCtx.Logger.Info(fmt.Sprintf("update component status after applying resources, generation: %d", comp.Generation))
@@ -97,7 +100,9 @@ func (t *componentStatusTransformer) Transform(ctx graph.TransformContext, dag *
 	case model.IsObjectStatusUpdating(transCtx.ComponentOrig):
 		// reconcile the component status and sync the component status to cluster status
 		csh := newComponentStatusHandler(reqCtx, t.Client, cluster, comp, synthesizeComp, runningITS, protoITS, dag)
-		if err := csh.reconcileComponentStatus(); err != nil {
+		if runningJob != nil {
+			csh.reconcileTaskComponentStatus(runningJob)
+		} else if err := csh.reconcileComponentStatus(); err != nil {
 			return err
 		}
 		comp = csh.comp
@@ -177,32 +182,56 @@ func (r *componentStatusHandler) reconcileComponentStatus() error {
 		fmt.Sprintf("component status conditions, isInstanceSetRunning: %v, isAllConfigSynced: %v, hasRunningVolumeExpansion: %v, hasFailure: %v,  isInCreatingPhase: %v, isComponentAvailable: %v",
 			isITSUpdatedNRunning, isAllConfigSynced, hasRunningVolumeExpansion, hasFailure, isInCreatingPhase, isComponentAvailable))
 
-	r.podsReady = false
-	switch {
-	case isDeleting:
-		r.setComponentStatusPhase(appsv1alpha1.DeletingClusterCompPhase, nil, "component is Deleting")
-	case isZeroReplica && hasComponentPod:
-		r.setComponentStatusPhase(appsv1alpha1.StoppingClusterCompPhase, nil, "component is Stopping")
-		r.podsReady = true
-	case isZeroReplica:
-		r.setComponentStatusPhase(appsv1alpha1.StoppedClusterCompPhase, nil, "component is Stopped")
-		r.podsReady = true
-	case isITSUpdatedNRunning && isAllConfigSynced && !hasRunningVolumeExpansion:
-		r.setComponentStatusPhase(appsv1alpha1.RunningClusterCompPhase, nil, "component is Running")
-		r.podsReady = true
-	case !hasFailure && isInCreatingPhase:
-		r.setComponentStatusPhase(appsv1alpha1.CreatingClusterCompPhase, nil, "component is Creating")
-	case !hasFailure:
-		r.setComponentStatusPhase(appsv1alpha1.UpdatingClusterCompPhase, nil, "component is Updating")
-	case !isComponentAvailable:
-		r.setComponentStatusPhase(appsv1alpha1.FailedClusterCompPhase, messages, "component is Failed")
-	default:
-		r.setComponentStatusPhase(appsv1alpha1.AbnormalClusterCompPhase, nil, "component is Abnormal")
+	phase, _ := conditions.ComputePhase(
+		conditions.Rule(appsv1alpha1.DeletingClusterCompPhase, isDeleting),
+		conditions.Rule(appsv1alpha1.StoppingClusterCompPhase, isZeroReplica && hasComponentPod),
+		conditions.Rule(appsv1alpha1.StoppedClusterCompPhase, isZeroReplica),
+		conditions.Rule(appsv1alpha1.RunningClusterCompPhase, isITSUpdatedNRunning && isAllConfigSynced && !hasRunningVolumeExpansion),
+		conditions.Rule(appsv1alpha1.CreatingClusterCompPhase, !hasFailure && isInCreatingPhase),
+		conditions.Rule(appsv1alpha1.UpdatingClusterCompPhase, !hasFailure),
+		conditions.Rule(appsv1alpha1.FailedClusterCompPhase, !isComponentAvailable),
+		conditions.Rule(appsv1alpha1.AbnormalClusterCompPhase, true),
+	)
+	r.podsReady = slices.Contains([]appsv1alpha1.ClusterComponentPhase{appsv1alpha1.StoppingClusterCompPhase,
+		appsv1alpha1.StoppedClusterCompPhase, appsv1alpha1.RunningClusterCompPhase}, phase)
+	if phase != appsv1alpha1.FailedClusterCompPhase {
+		messages = nil
 	}
+	r.setComponentStatusPhase(phase, messages, fmt.Sprintf("component is %s", phase))
 
 	return nil
 }
 
+// reconcileTaskComponentStatus reconciles the status of the run-to-completion component from its underlying Job.
+func (r *componentStatusHandler) reconcileTaskComponentStatus(job *batchv1.Job) {
+	isJobConditionTrue := func(conditionType batchv1.JobConditionType) (bool, string) {
+		for _, cond := range job.Status.Conditions {
+			if cond.Type == conditionType && cond.Status == corev1.ConditionTrue {
+				return true, cond.Message
+			}
+		}
+		return false, ""
+	}
+	isComplete, _ := isJobConditionTrue(batchv1.JobComplete)
+	isFailed, failedMessage := isJobConditionTrue(batchv1.JobFailed)
+
+	phase, _ := conditions.ComputePhase(
+		conditions.Rule(appsv1alpha1.DeletingClusterCompPhase, !job.DeletionTimestamp.IsZero()),
+		conditions.Rule(appsv1alpha1.CompletedClusterCompPhase, isComplete),
+		conditions.Rule(appsv1alpha1.FailedClusterCompPhase, isFailed),
+		conditions.Rule(appsv1alpha1.CreatingClusterCompPhase,
+			r.comp.Status.Phase == "" || r.comp.Status.Phase == appsv1alpha1.CreatingClusterCompPhase),
+		conditions.Rule(appsv1alpha1.UpdatingClusterCompPhase, true),
+	)
+	var messages appsv1alpha1.ComponentMessageMap
+	if phase == appsv1alpha1.FailedClusterCompPhase {
+		messages = appsv1alpha1.ComponentMessageMap{}
+		messages.SetObjectMessage(constant.JobKind, job.Name, failedMessage)
+	}
+	r.podsReady = phase == appsv1alpha1.CompletedClusterCompPhase
+	r.setComponentStatusPhase(phase, messages, fmt.Sprintf("component is %s", phase))
+}
+
 func (r *componentStatusHandler) isWorkloadUpdated() bool {
 	if r.cluster == nil || r.runningITS == nil {
 		return false
@@ -349,15 +378,15 @@ func (r *componentStatusHandler) hasVolumeExpansionRunning() (bool, bool, error)
 func (r *componentStatusHandler) hasFailedPod() (bool, appsv1alpha1.ComponentMessageMap) {
 	messages := appsv1alpha1.ComponentMessageMap{}
 	// check InstanceFailure condition
-	hasFailedPod := meta.IsStatusConditionTrue(r.runningITS.Status.Conditions, string(workloads.InstanceFailure))
+	hasFailedPod := conditions.IsTrue(r.runningITS.Status.Conditions, string(workloads.InstanceFailure))
 	if hasFailedPod {
-		failureCondition := meta.FindStatusCondition(r.runningITS.Status.Conditions, string(workloads.InstanceFailure))
+		failureCondition := conditions.Get(r.runningITS.Status.Conditions, string(workloads.InstanceFailure))
 		messages.SetObjectMessage(workloads.Kind, r.runningITS.Name, failureCondition.Message)
 		return true, messages
 	}
 
 	// check InstanceReady condition
-	if !meta.IsStatusConditionTrue(r.runningITS.Status.Conditions, string(workloads.InstanceReady)) {
+	if !conditions.IsTrue(r.runningITS.Status.Conditions, string(workloads.InstanceReady)) {
 		return false, nil
 	}
 
@@ -369,7 +398,7 @@ func (r *componentStatusHandler) hasFailedPod() (bool, appsv1alpha1.ComponentMes
 		return false, nil
 	}
 	probeTimeoutDuration := time.Duration(appsv1alpha1.DefaultRoleProbeTimeoutAfterPodsReady) * time.Second
-	condition := meta.FindStatusCondition(r.runningITS.Status.Conditions, string(workloads.InstanceReady))
+	condition := conditions.Get(r.runningITS.Status.Conditions, string(workloads.InstanceReady))
 	if time.Now().After(condition.LastTransitionTime.Add(probeTimeoutDuration)) {
 		messages.SetObjectMessage(workloads.Kind, r.runningITS.Name, "Role probe timeout, check whether the application is available")
 		return true, messages
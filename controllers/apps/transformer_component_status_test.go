@@ -0,0 +1,81 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+func TestReconcileTaskComponentStatus(t *testing.T) {
+	newJob := func(conditions ...batchv1.JobCondition) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-task"},
+			Status:     batchv1.JobStatus{Conditions: conditions},
+		}
+	}
+	reconcile := func(phase appsv1alpha1.ClusterComponentPhase, job *batchv1.Job) *componentStatusHandler {
+		comp := &appsv1alpha1.Component{Status: appsv1alpha1.ComponentStatus{Phase: phase}}
+		csh := newComponentStatusHandler(intctrlutil.RequestCtx{}, nil, nil, comp, nil, nil, nil, nil)
+		csh.reconcileTaskComponentStatus(job)
+		return csh
+	}
+
+	// the job is just created
+	csh := reconcile("", newJob())
+	assert.Equal(t, appsv1alpha1.CreatingClusterCompPhase, csh.comp.Status.Phase)
+	assert.False(t, csh.podsReady)
+
+	// the job is recreated for the changed spec
+	csh = reconcile(appsv1alpha1.CompletedClusterCompPhase, newJob())
+	assert.Equal(t, appsv1alpha1.UpdatingClusterCompPhase, csh.comp.Status.Phase)
+
+	// all pods have terminated successfully
+	csh = reconcile(appsv1alpha1.CreatingClusterCompPhase, newJob(batchv1.JobCondition{
+		Type:   batchv1.JobComplete,
+		Status: corev1.ConditionTrue,
+	}))
+	assert.Equal(t, appsv1alpha1.CompletedClusterCompPhase, csh.comp.Status.Phase)
+	assert.True(t, csh.podsReady)
+
+	// the job has failed
+	csh = reconcile(appsv1alpha1.CreatingClusterCompPhase, newJob(batchv1.JobCondition{
+		Type:    batchv1.JobFailed,
+		Status:  corev1.ConditionTrue,
+		Message: "Job has reached the specified backoff limit",
+	}))
+	assert.Equal(t, appsv1alpha1.FailedClusterCompPhase, csh.comp.Status.Phase)
+	assert.False(t, csh.podsReady)
+	assert.Equal(t, "Job has reached the specified backoff limit", csh.comp.Status.Message[constant.JobKind+"/test-cluster-task"])
+
+	// the job is deleting
+	job := newJob()
+	job.DeletionTimestamp = &metav1.Time{}
+	csh = reconcile(appsv1alpha1.CompletedClusterCompPhase, job)
+	assert.Equal(t, appsv1alpha1.DeletingClusterCompPhase, csh.comp.Status.Phase)
+}
@@ -21,11 +21,34 @@ package apps
 
 import (
 	"fmt"
+	"strings"
+
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/kubectl/pkg/util/storage"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/graph"
 )
 
+// rwxProvisioners lists the well-known provisioners of the file storages which support the ReadWriteMany access mode,
+// the other StorageClasses declare the supported access modes by annotation.
+var rwxProvisioners = []string{
+	"nfs.csi.k8s.io",
+	"efs.csi.aws.com",
+	"file.csi.azure.com",
+	"kubernetes.io/azure-file",
+	"filestore.csi.storage.gke.io",
+	"cephfs.csi.ceph.com",
+	"nasplugin.csi.alibabacloud.com",
+}
+
 // componentValidationTransformer validates the consistency between spec & definition.
 type componentValidationTransformer struct{}
 
@@ -46,6 +69,22 @@ func (t *componentValidationTransformer) Transform(ctx graph.TransformContext, d
 	if err = validateCompReplicas(comp, transCtx.CompDef); err != nil {
 		return newRequeueError(requeueDuration, err.Error())
 	}
+	if err = component.ValidateInitContainers(comp, transCtx.CompDef); err != nil {
+		return newRequeueError(requeueDuration, err.Error())
+	}
+	if err = validatePriorityClass(transCtx, comp); err != nil {
+		return newRequeueError(requeueDuration, err.Error())
+	}
+	// the pinned nodes are validated only when the spec is changed, to keep reconciling the instances on the other nodes
+	// if a pinned node is lost afterwards.
+	if comp.Generation != comp.Status.ObservedGeneration {
+		if err = validateInstanceNodes(transCtx, comp); err != nil {
+			return newRequeueError(requeueDuration, err.Error())
+		}
+		if err = validateSharedVolumes(transCtx, comp); err != nil {
+			return newRequeueError(requeueDuration, err.Error())
+		}
+	}
 	// if err = validateSidecarContainers(comp, transCtx.CompDef); err != nil {
 	// 	return newRequeueError(requeueDuration, err.Error())
 	// }
@@ -86,7 +125,8 @@ func validateCompReplicas(comp *appsv1alpha1.Component, compDef *appsv1alpha1.Co
 	}
 	replicas := comp.Spec.Replicas
 	replicasLimit := compDef.Spec.ReplicasLimit
-	if replicas >= replicasLimit.MinReplicas && replicas <= replicasLimit.MaxReplicas {
+	// zero replicas means the component is stopped, e.g. by a Stop OpsRequest or the cluster is paused
+	if replicas == 0 || (replicas >= replicasLimit.MinReplicas && replicas <= replicasLimit.MaxReplicas) {
 		return nil
 	}
 	return replicasOutOfLimitError(replicas, *replicasLimit)
@@ -95,3 +135,111 @@ func validateCompReplicas(comp *appsv1alpha1.Component, compDef *appsv1alpha1.Co
 func replicasOutOfLimitError(replicas int32, replicasLimit appsv1alpha1.ReplicasLimit) error {
 	return fmt.Errorf("replicas %d out-of-limit [%d, %d]", replicas, replicasLimit.MinReplicas, replicasLimit.MaxReplicas)
 }
+
+// validatePriorityClass checks that the PriorityClass for the Pods of the Component exists.
+func validatePriorityClass(transCtx *componentTransformContext, comp *appsv1alpha1.Component) error {
+	priorityClassName := component.GetPriorityClassName(comp, transCtx.CompDef)
+	if priorityClassName == "" {
+		return nil
+	}
+	priorityClass := &schedulingv1.PriorityClass{}
+	if err := transCtx.Client.Get(transCtx.Context, client.ObjectKey{Name: priorityClassName}, priorityClass); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("the PriorityClass %s does not exist", priorityClassName)
+		}
+		return err
+	}
+	return nil
+}
+
+// validateInstanceNodes checks that the nodes which the instances are pinned to exist.
+func validateInstanceNodes(transCtx *componentTransformContext, comp *appsv1alpha1.Component) error {
+	for _, instance := range comp.Spec.Instances {
+		if instance.GetReplicas() == 0 {
+			continue
+		}
+		if instance.NodeName != "" {
+			node := &corev1.Node{}
+			if err := transCtx.Client.Get(transCtx.Context, client.ObjectKey{Name: instance.NodeName}, node); err != nil {
+				if apierrors.IsNotFound(err) {
+					return fmt.Errorf("the node %s pinned by instance template %s does not exist", instance.NodeName, instance.Name)
+				}
+				return ignoreNodesForbidden(transCtx, err)
+			}
+		}
+		if len(instance.NodeSelector) > 0 {
+			nodes := &corev1.NodeList{}
+			if err := transCtx.Client.List(transCtx.Context, nodes, client.MatchingLabels(instance.NodeSelector)); err != nil {
+				return ignoreNodesForbidden(transCtx, err)
+			}
+			if len(nodes.Items) == 0 {
+				return fmt.Errorf("no node matches the node selector %v of instance template %s", instance.NodeSelector, instance.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func ignoreNodesForbidden(transCtx *componentTransformContext, err error) error {
+	if apierrors.IsForbidden(err) {
+		transCtx.Logger.Info("skip validating the pinned nodes since the nodes are not accessible")
+		return nil
+	}
+	return err
+}
+
+// validateSharedVolumes checks that the shared volumes can be provisioned with the ReadWriteMany access mode.
+func validateSharedVolumes(transCtx *componentTransformContext, comp *appsv1alpha1.Component) error {
+	for _, sv := range comp.Spec.SharedVolumes {
+		if len(sv.Spec.AccessModes) > 0 && !slices.Contains(sv.Spec.AccessModes, corev1.ReadWriteMany) {
+			return fmt.Errorf("the access modes of shared volume %s must contain %s", sv.Name, corev1.ReadWriteMany)
+		}
+		storageClass, err := getSharedVolumeStorageClass(transCtx, sv)
+		if err != nil {
+			return err
+		}
+		if !isStorageClassSupportRWX(storageClass) {
+			return fmt.Errorf("the StorageClass %s of shared volume %s does not support the %s access mode",
+				storageClass.Name, sv.Name, corev1.ReadWriteMany)
+		}
+	}
+	return nil
+}
+
+// getSharedVolumeStorageClass returns the StorageClass of the shared volume, or the default one if not specified.
+func getSharedVolumeStorageClass(transCtx *componentTransformContext, sv appsv1alpha1.SharedVolume) (*storagev1.StorageClass, error) {
+	pvcSpec := sv.Spec.ToV1PersistentVolumeClaimSpec()
+	if pvcSpec.StorageClassName != nil {
+		storageClass := &storagev1.StorageClass{}
+		if err := transCtx.Client.Get(transCtx.Context, client.ObjectKey{Name: *pvcSpec.StorageClassName}, storageClass); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("the StorageClass %s of shared volume %s does not exist", *pvcSpec.StorageClassName, sv.Name)
+			}
+			return nil, err
+		}
+		return storageClass, nil
+	}
+	storageClasses := &storagev1.StorageClassList{}
+	if err := transCtx.Client.List(transCtx.Context, storageClasses); err != nil {
+		return nil, err
+	}
+	for i, storageClass := range storageClasses.Items {
+		if storageClass.Annotations[storage.IsDefaultStorageClassAnnotation] == "true" {
+			return &storageClasses.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no StorageClass is specified for shared volume %s and there is no default StorageClass", sv.Name)
+}
+
+// isStorageClassSupportRWX checks whether the volumes provisioned by the StorageClass support the ReadWriteMany access mode.
+func isStorageClassSupportRWX(storageClass *storagev1.StorageClass) bool {
+	if accessModes, ok := storageClass.Annotations[constant.StorageClassAccessModesAnnotationKey]; ok {
+		for _, accessMode := range strings.Split(accessModes, ",") {
+			if corev1.PersistentVolumeAccessMode(strings.TrimSpace(accessMode)) == corev1.ReadWriteMany {
+				return true
+			}
+		}
+		return false
+	}
+	return slices.Contains(rwxProvisioners, storageClass.Provisioner)
+}
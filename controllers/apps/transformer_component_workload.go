@@ -28,8 +28,10 @@ import (
 	"github.com/spf13/viper"
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -37,6 +39,7 @@ import (
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/conditions"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/configuration"
@@ -83,6 +86,10 @@ func (t *componentWorkloadTransformer) Transform(ctx graph.TransformContext, dag
 		Recorder: transCtx.EventRecorder,
 	}
 
+	if synthesizeComp.WorkloadType == appsv1alpha1.JobWorkloadType {
+		return t.reconcileTaskJob(transCtx, dag)
+	}
+
 	runningITS, err := t.runningInstanceSetObject(ctx, synthesizeComp)
 	if err != nil {
 		return err
@@ -119,11 +126,38 @@ func (t *componentWorkloadTransformer) Transform(ctx graph.TransformContext, dag
 			graphCli.Delete(dag, runningITS)
 		} else {
 			err = t.handleUpdate(reqCtx, graphCli, dag, cluster, synthesizeComp, runningITS, protoITS)
+			if err == nil {
+				t.checkPendingRestart(transCtx, runningITS, protoITS)
+			}
 		}
 	}
 	return err
 }
 
+// checkPendingRestart reports the changes of the scheduling policy which are pending a restart to take effect.
+func (t *componentWorkloadTransformer) checkPendingRestart(transCtx *componentTransformContext, runningITS, protoITS *workloads.InstanceSet) {
+	comp := transCtx.Component
+	fields := component.GetPendingSchedulingFields(&runningITS.Spec.Template.Spec, &protoITS.Spec.Template.Spec)
+	if len(fields) == 0 {
+		if conditions.Remove(&comp.Status.Conditions, appsv1alpha1.ConditionTypeRestartPending) {
+			transCtx.EventRecorder.Event(comp, corev1.EventTypeNormal, ReasonSchedulingPolicyApplied,
+				"the pending changes of the scheduling policy are applied")
+		}
+		return
+	}
+	message := fmt.Sprintf("the changes of %s are pending a restart to take effect, restart the component by a Restart OpsRequest to apply them",
+		strings.Join(fields, ", "))
+	if conditions.Set(&comp.Status.Conditions, metav1.Condition{
+		Type:               appsv1alpha1.ConditionTypeRestartPending,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: comp.Generation,
+		Reason:             ReasonSchedulingPolicyPending,
+		Message:            message,
+	}) {
+		transCtx.EventRecorder.Event(comp, corev1.EventTypeNormal, ReasonSchedulingPolicyPending, message)
+	}
+}
+
 func (t *componentWorkloadTransformer) runningInstanceSetObject(ctx graph.TransformContext,
 	synthesizeComp *component.SynthesizedComponent) (*workloads.InstanceSet, error) {
 	itsKey := types.NamespacedName{
@@ -140,6 +174,54 @@ func (t *componentWorkloadTransformer) runningInstanceSetObject(ctx graph.Transf
 	return its, nil
 }
 
+func (t *componentWorkloadTransformer) runningTaskJobObject(ctx graph.TransformContext,
+	synthesizeComp *component.SynthesizedComponent) (*batchv1.Job, error) {
+	jobKey := types.NamespacedName{
+		Namespace: synthesizeComp.Namespace,
+		Name:      constant.GenerateWorkloadNamePattern(synthesizeComp.ClusterName, synthesizeComp.Name),
+	}
+	job := &batchv1.Job{}
+	if err := ctx.GetClient().Get(ctx.GetContext(), jobKey, job); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return job, nil
+}
+
+// reconcileTaskJob handles the Job of the run-to-completion component. The pod template of a Job is immutable,
+// so the Job is recreated to run again once the spec of it is changed by the component spec.
+func (t *componentWorkloadTransformer) reconcileTaskJob(transCtx *componentTransformContext, dag *graph.DAG) error {
+	synthesizeComp := transCtx.SynthesizeComponent
+	runningJob, err := t.runningTaskJobObject(transCtx, synthesizeComp)
+	if err != nil {
+		return err
+	}
+
+	buildPodSpecVolumeMounts(synthesizeComp)
+	protoJob := factory.BuildTaskJob(synthesizeComp)
+	configuration.BuildConfigTemplateAnnotations(protoJob, synthesizeComp)
+	transCtx.ProtoWorkload = protoJob
+
+	graphCli, _ := transCtx.Client.(model.GraphClient)
+	if runningJob == nil {
+		graphCli.Create(dag, protoJob)
+		return nil
+	}
+	transCtx.RunningWorkload = runningJob
+	if runningJob.DeletionTimestamp.IsZero() && isTaskJobSpecChanged(runningJob, protoJob) {
+		// the pods of Jobs are orphaned by default, delete them together, the new Job is created in the next round.
+		graphCli.Delete(dag, runningJob, model.WithPropagationPolicy(client.PropagationPolicy(metav1.DeletePropagationBackground)))
+	}
+	return nil
+}
+
+func isTaskJobSpecChanged(runningJob, protoJob *batchv1.Job) bool {
+	hash, ok := runningJob.Annotations[constant.TaskJobSpecHashAnnotationKey]
+	return ok && hash != protoJob.Annotations[constant.TaskJobSpecHashAnnotationKey]
+}
+
 func (t *componentWorkloadTransformer) handleUpdate(reqCtx intctrlutil.RequestCtx, cli model.GraphClient, dag *graph.DAG,
 	cluster *appsv1alpha1.Cluster, synthesizeComp *component.SynthesizedComponent, runningITS, protoITS *workloads.InstanceSet) error {
 	// TODO(xingran): Some workload operations should be moved down to Lorry implementation. Subsequent operations such as horizontal scaling will be removed from the component controller
@@ -290,6 +372,8 @@ func copyAndMergeITS(oldITS, newITS *workloads.InstanceSet, synthesizeComp *comp
 	// if annotations exist and are replaced, the its will be updated.
 	mergeMetadataMap(itsObjCopy.Spec.Template.Annotations, &itsProto.Spec.Template.Annotations)
 	itsObjCopy.Spec.Template = *itsProto.Spec.Template.DeepCopy()
+	// the changes of the scheduling policy which can't be applied in-place are delayed to the next restart.
+	component.DelaySchedulingPolicyUpdate(&oldITS.Spec.Template.Spec, itsObjCopy)
 	itsObjCopy.Spec.Replicas = itsProto.Spec.Replicas
 	itsObjCopy.Spec.Service = updateService(itsObjCopy, itsProto)
 	itsObjCopy.Spec.Roles = itsProto.Spec.Roles
@@ -300,6 +384,7 @@ func copyAndMergeITS(oldITS, newITS *workloads.InstanceSet, synthesizeComp *comp
 	itsObjCopy.Spec.Instances = itsProto.Spec.Instances
 	itsObjCopy.Spec.OfflineInstances = itsProto.Spec.OfflineInstances
 	itsObjCopy.Spec.MinReadySeconds = itsProto.Spec.MinReadySeconds
+	itsObjCopy.Spec.WarmUpCheck = itsProto.Spec.WarmUpCheck
 
 	if itsProto.Spec.UpdateStrategy.Type != "" || itsProto.Spec.UpdateStrategy.RollingUpdate != nil {
 		updateUpdateStrategy(itsObjCopy, itsProto)
@@ -659,6 +744,12 @@ func (r *componentWorkloadOps) expandVolumes(vctName string, proto *corev1.Persi
 			quantity := pvc.Spec.Resources.Requests.Storage()
 			newQuantity := proto.Spec.Resources.Requests.Storage()
 			if quantity.Cmp(*pvc.Status.Capacity.Storage()) == 0 && newQuantity.Cmp(*quantity) < 0 {
+				if viper.GetBool(constant.CfgKeyVolumeAdoption) {
+					// the volume is expanded out-of-band, its size will be adopted by the cluster controller.
+					r.reqCtx.Log.Info("skip the volume expanded out-of-band", "volume", pvc.GetName(),
+						"quantity", quantity.String(), "new quantity", newQuantity.String())
+					continue
+				}
 				errMsg := fmt.Sprintf("shrinking the volume is not supported, volume: %s, quantity: %s, new quantity: %s",
 					pvc.GetName(), quantity.String(), newQuantity.String())
 				r.reqCtx.Event(r.cluster, corev1.EventTypeWarning, "VolumeExpansionFailed", errMsg)
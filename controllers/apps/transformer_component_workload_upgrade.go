@@ -21,18 +21,23 @@ package apps
 
 import (
 	"context"
+	"fmt"
 	"reflect"
+	"strings"
 
+	"github.com/spf13/viper"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/apis/workloads/legacy"
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/conditions"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	"github.com/apecloud/kubeblocks/pkg/controller/factory"
@@ -41,7 +46,12 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 )
 
-// componentWorkloadUpgradeTransformer upgrades the underlying workload from the legacy RSM API to the InstanceSet API.
+// componentWorkloadUpgradeTransformer migrates the underlying workload from the legacy StatefulSet and RSM API to
+// the InstanceSet API.
+//
+// The pods, PVCs and the headless service are adopted in place and the legacy workload is deleted with the orphan
+// propagation policy, so neither the pods are restarted nor the data is moved. The migration is gated by the
+// CfgKeyWorkloadMigration feature gate, and a preflight check holds it until the StatefulSet is in a steady state.
 type componentWorkloadUpgradeTransformer struct{}
 
 var _ graph.Transformer = &componentWorkloadUpgradeTransformer{}
@@ -57,6 +67,16 @@ func (t *componentWorkloadUpgradeTransformer) Transform(ctx graph.TransformConte
 		return nil
 	}
 
+	sts, rsm, err := t.legacyWorkloads(transCtx, graphCli)
+	if err != nil {
+		return err
+	}
+	if sts != nil || rsm != nil {
+		if err = t.preflight(transCtx, graphCli, sts); err != nil {
+			return err
+		}
+	}
+
 	var parent *model.ObjectVertex
 	legacyFound := false
 
@@ -89,6 +109,7 @@ func (t *componentWorkloadUpgradeTransformer) Transform(ctx graph.TransformConte
 							return err
 						}
 					}
+					preserveLegacyRevision(object)
 					object.GetLabels()[appsv1.ControllerRevisionHashLabelKey] = revision
 				}
 				parent = graphCli.Do(dag, nil, object, model.ActionUpdatePtr(), parent)
@@ -97,30 +118,27 @@ func (t *componentWorkloadUpgradeTransformer) Transform(ctx graph.TransformConte
 	}
 
 	// remove the StatefulSet object if found
-	sts := &appsv1.StatefulSet{}
-	if err := graphCli.Get(transCtx.Context, client.ObjectKeyFromObject(comp), sts); err == nil {
+	if sts != nil {
 		legacyFound = true
 		parent = graphCli.Do(dag, nil, sts, model.ActionDeletePtr(), parent, model.WithPropagationPolicy(client.PropagationPolicy(metav1.DeletePropagationOrphan)))
-	} else if !apierrors.IsNotFound(err) {
-		return err
 	}
 
 	// remove the RSM object if found
-	exists, err := legacyCRDExists(transCtx.Context, graphCli)
-	if err != nil {
-		return err
-	}
-	if exists {
-		rsm := &legacy.ReplicatedStateMachine{}
-		if err := graphCli.Get(transCtx.Context, client.ObjectKeyFromObject(comp), rsm); err == nil {
-			legacyFound = true
-			graphCli.Do(dag, nil, rsm, model.ActionDeletePtr(), parent, model.WithPropagationPolicy(client.PropagationPolicy(metav1.DeletePropagationOrphan)))
-		} else if !apierrors.IsNotFound(err) {
-			return err
-		}
+	if rsm != nil {
+		legacyFound = true
+		graphCli.Do(dag, nil, rsm, model.ActionDeletePtr(), parent, model.WithPropagationPolicy(client.PropagationPolicy(metav1.DeletePropagationOrphan)))
 	}
 
 	if legacyFound {
+		message := "the legacy workload is migrated to InstanceSet"
+		conditions.Set(&comp.Status.Conditions, metav1.Condition{
+			Type:               appsv1alpha1.ConditionTypeWorkloadMigrated,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: comp.Generation,
+			Reason:             ReasonWorkloadMigrated,
+			Message:            message,
+		})
+		transCtx.EventRecorder.Event(comp, corev1.EventTypeNormal, ReasonWorkloadMigrated, message)
 		// set status.observedGeneration to zero to trigger a creation reconciliation loop of the component controller.
 		comp.Status.ObservedGeneration = 0
 		return graph.ErrPrematureStop
@@ -128,6 +146,122 @@ func (t *componentWorkloadUpgradeTransformer) Transform(ctx graph.TransformConte
 	return nil
 }
 
+func (t *componentWorkloadUpgradeTransformer) legacyWorkloads(transCtx *componentTransformContext,
+	cli model.GraphClient) (*appsv1.StatefulSet, *legacy.ReplicatedStateMachine, error) {
+	key := client.ObjectKeyFromObject(transCtx.Component)
+	var sts *appsv1.StatefulSet
+	obj := &appsv1.StatefulSet{}
+	if err := cli.Get(transCtx.Context, key, obj); err == nil {
+		sts = obj
+	} else if !apierrors.IsNotFound(err) {
+		return nil, nil, err
+	}
+
+	exists, err := legacyCRDExists(transCtx.Context, cli)
+	if err != nil || !exists {
+		return sts, nil, err
+	}
+	rsm := &legacy.ReplicatedStateMachine{}
+	if err = cli.Get(transCtx.Context, key, rsm); err == nil {
+		return sts, rsm, nil
+	} else if !apierrors.IsNotFound(err) {
+		return nil, nil, err
+	}
+	return sts, nil, nil
+}
+
+// preflight checks whether the legacy workload can be migrated, the blockers found are reported by the
+// WorkloadMigrated condition and the reconciliation is requeued until they are resolved.
+func (t *componentWorkloadUpgradeTransformer) preflight(transCtx *componentTransformContext,
+	cli model.GraphClient, sts *appsv1.StatefulSet) error {
+	comp := transCtx.Component
+	reason, message := "", ""
+	if !viper.GetBool(constant.CfgKeyWorkloadMigration) {
+		reason = ReasonWorkloadMigrationDisabled
+		message = "the migration of the legacy workload to InstanceSet is disabled"
+	} else if sts != nil {
+		pods := &corev1.PodList{}
+		ml := constant.GetComponentWellKnownLabels(transCtx.SynthesizeComponent.ClusterName, transCtx.SynthesizeComponent.Name)
+		if err := cli.List(transCtx.Context, pods, client.MatchingLabels(ml), client.InNamespace(comp.Namespace)); err != nil {
+			return err
+		}
+		if blockers := checkStatefulSetMigrationBlockers(sts, pods.Items, transCtx.SynthesizeComponent); len(blockers) > 0 {
+			reason = ReasonWorkloadMigrationBlocked
+			message = fmt.Sprintf("the migration of the legacy StatefulSet is blocked: %s", strings.Join(blockers, "; "))
+		}
+	}
+	if len(reason) == 0 {
+		return nil
+	}
+
+	conditions.Set(&comp.Status.Conditions, metav1.Condition{
+		Type:               appsv1alpha1.ConditionTypeWorkloadMigrated,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: comp.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+	transCtx.EventRecorder.Event(comp, corev1.EventTypeWarning, reason, message)
+	return newRequeueError(requeueDuration, message)
+}
+
+// checkStatefulSetMigrationBlockers returns the reasons why the StatefulSet can not be migrated in place, the pods
+// must all be in the latest revision of the StatefulSet and the volumes must match the component to be adopted.
+func checkStatefulSetMigrationBlockers(sts *appsv1.StatefulSet, pods []corev1.Pod,
+	synthesizeComp *component.SynthesizedComponent) []string {
+	var blockers []string
+	if sts.Status.ObservedGeneration < sts.Generation || sts.Status.CurrentRevision != sts.Status.UpdateRevision {
+		blockers = append(blockers, "the rolling update of the StatefulSet is in progress")
+	}
+	if sts.Spec.Replicas != nil && *sts.Spec.Replicas != synthesizeComp.Replicas {
+		blockers = append(blockers, fmt.Sprintf("the replicas of the StatefulSet %d mismatch the component %d",
+			*sts.Spec.Replicas, synthesizeComp.Replicas))
+	}
+
+	stsVolumes, compVolumes := sets.New[string](), sets.New[string]()
+	for _, vct := range sts.Spec.VolumeClaimTemplates {
+		stsVolumes.Insert(vct.Name)
+	}
+	for _, vct := range synthesizeComp.VolumeClaimTemplates {
+		compVolumes.Insert(vct.Name)
+	}
+	if !stsVolumes.Equal(compVolumes) {
+		blockers = append(blockers, fmt.Sprintf("the volumeClaimTemplates of the StatefulSet %v mismatch the component %v",
+			sets.List(stsVolumes), sets.List(compVolumes)))
+	}
+
+	var deleting, outdated []string
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			deleting = append(deleting, pod.Name)
+		} else if pod.Labels[appsv1.ControllerRevisionHashLabelKey] != sts.Status.UpdateRevision {
+			outdated = append(outdated, pod.Name)
+		}
+	}
+	if len(deleting) > 0 {
+		blockers = append(blockers, fmt.Sprintf("the pods %v are being deleted", deleting))
+	}
+	if len(outdated) > 0 {
+		blockers = append(blockers, fmt.Sprintf("the pods %v are not in the revision %s", outdated, sts.Status.UpdateRevision))
+	}
+	return blockers
+}
+
+// preserveLegacyRevision records the revision of the pod under the legacy workload before it is replaced by the
+// revision of the InstanceSet.
+func preserveLegacyRevision(pod client.Object) {
+	legacyRevision, ok := pod.GetLabels()[appsv1.ControllerRevisionHashLabelKey]
+	if !ok {
+		return
+	}
+	annotations := pod.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[constant.LegacyRevisionAnnotationKey] = legacyRevision
+	pod.SetAnnotations(annotations)
+}
+
 func legacyCRDExists(ctx context.Context, cli model.GraphClient) (bool, error) {
 	crdName := "replicatedstatemachines.workloads.kubeblocks.io"
 	crd := &apiextv1.CustomResourceDefinition{}
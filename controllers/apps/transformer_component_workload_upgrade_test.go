@@ -0,0 +1,113 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+)
+
+func TestCheckStatefulSetMigrationBlockers(t *testing.T) {
+	buildSts := func(replicas int32, currentRevision, updateRevision string, volumes ...string) *appsv1.StatefulSet {
+		sts := &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Spec:       appsv1.StatefulSetSpec{Replicas: pointer.Int32(replicas)},
+			Status: appsv1.StatefulSetStatus{
+				ObservedGeneration: 2,
+				CurrentRevision:    currentRevision,
+				UpdateRevision:     updateRevision,
+			},
+		}
+		for _, name := range volumes {
+			sts.Spec.VolumeClaimTemplates = append(sts.Spec.VolumeClaimTemplates, corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+			})
+		}
+		return sts
+	}
+	buildPod := func(name, revision string, deleting bool) corev1.Pod {
+		pod := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{appsv1.ControllerRevisionHashLabelKey: revision},
+			},
+		}
+		if deleting {
+			pod.DeletionTimestamp = &metav1.Time{}
+		}
+		return pod
+	}
+	synthesizeComp := &component.SynthesizedComponent{
+		Replicas: 2,
+		VolumeClaimTemplates: []corev1.PersistentVolumeClaimTemplate{
+			{ObjectMeta: metav1.ObjectMeta{Name: "data"}},
+		},
+	}
+
+	// steady StatefulSet
+	sts := buildSts(2, "rev-1", "rev-1", "data")
+	pods := []corev1.Pod{buildPod("mysql-0", "rev-1", false), buildPod("mysql-1", "rev-1", false)}
+	assert.Empty(t, checkStatefulSetMigrationBlockers(sts, pods, synthesizeComp))
+
+	// rolling update in progress
+	sts = buildSts(2, "rev-1", "rev-2", "data")
+	pods = []corev1.Pod{buildPod("mysql-0", "rev-1", false), buildPod("mysql-1", "rev-2", false)}
+	blockers := checkStatefulSetMigrationBlockers(sts, pods, synthesizeComp)
+	assert.Len(t, blockers, 2)
+	assert.Contains(t, blockers[0], "rolling update")
+	assert.Contains(t, blockers[1], "mysql-0")
+
+	// the StatefulSet spec is not observed yet
+	sts = buildSts(2, "rev-1", "rev-1", "data")
+	sts.Generation = 3
+	pods = []corev1.Pod{buildPod("mysql-0", "rev-1", false), buildPod("mysql-1", "rev-1", false)}
+	assert.Len(t, checkStatefulSetMigrationBlockers(sts, pods, synthesizeComp), 1)
+
+	// replicas, volumes mismatched and pods being deleted
+	sts = buildSts(3, "rev-1", "rev-1", "data", "log")
+	pods = []corev1.Pod{buildPod("mysql-0", "rev-1", true), buildPod("mysql-1", "rev-1", false)}
+	blockers = checkStatefulSetMigrationBlockers(sts, pods, synthesizeComp)
+	assert.Len(t, blockers, 3)
+	assert.Contains(t, blockers[0], "replicas")
+	assert.Contains(t, blockers[1], "volumeClaimTemplates")
+	assert.Contains(t, blockers[2], "mysql-0")
+}
+
+func TestPreserveLegacyRevision(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{appsv1.ControllerRevisionHashLabelKey: "mysql-6f8b9c7d5"},
+		},
+	}
+	preserveLegacyRevision(pod)
+	assert.Equal(t, "mysql-6f8b9c7d5", pod.Annotations[constant.LegacyRevisionAnnotationKey])
+
+	pod = &corev1.Pod{}
+	preserveLegacyRevision(pod)
+	assert.Empty(t, pod.Annotations)
+}
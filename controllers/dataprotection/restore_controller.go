@@ -156,6 +156,12 @@ func CheckBackupRepoForRestore(reqCtx intctrlutil.RequestCtx, cli client.Client,
 		}
 		return repoName, err
 	}
+	if err := checkBackupRepoAccess(reqCtx.Ctx, cli, repo, restoreNamespace); err != nil {
+		if intctrlutil.IsTargetError(err, dperrors.ErrorTypeBackupRepoAccessDenied) {
+			return repoName, intctrlutil.NewFatalError(err.Error())
+		}
+		return repoName, err
+	}
 	if repo.Status.Phase != dpv1alpha1.BackupRepoReady {
 		return repoName, dperrors.NewBackupRepoIsNotReady(repo.Name)
 	}
@@ -371,6 +377,11 @@ func (r *RestoreReconciler) prepareData(reqCtx intctrlutil.RequestCtx, restoreMg
 			return false, nil
 		}
 	}
+	// the data is restored completely even if the last progress is not reported.
+	if progress := restoreMgr.Restore.Status.DataProgress; progress != nil && progress.TotalBytes > 0 {
+		progress.BytesRestored = progress.TotalBytes
+		progress.EstimatedCompletionTime = nil
+	}
 	// set prepare data successfully condition
 	dprestore.SetRestoreStageCondition(restoreMgr.Restore, dpv1alpha1.PrepareData, dprestore.ReasonSucceed, "prepare data successfully")
 	return true, nil
@@ -396,6 +407,10 @@ func (r *RestoreReconciler) postReady(reqCtx intctrlutil.RequestCtx, restoreMgr
 		// TODO: check readiness probe, use a job and kubectl exec?
 		_ = klog.TODO()
 	}
+	var completedActions, totalActions int
+	for _, v := range restoreMgr.PostReadyBackupSets {
+		totalActions += len(v.ActionSet.Spec.Restore.PostReady)
+	}
 	for _, v := range restoreMgr.PostReadyBackupSets {
 		// handle postReady actions
 		for i := range v.ActionSet.Spec.Restore.PostReady {
@@ -405,10 +420,13 @@ func (r *RestoreReconciler) postReady(reqCtx intctrlutil.RequestCtx, restoreMgr
 			}
 			// waiting for restore jobs finished.
 			if !isCompleted {
+				restoreMgr.Restore.Status.Progress = fmt.Sprintf("%d/%d", completedActions, totalActions)
 				return false, nil
 			}
+			completedActions++
 		}
 	}
+	restoreMgr.Restore.Status.Progress = fmt.Sprintf("%d/%d", completedActions, totalActions)
 	dprestore.SetRestoreStageCondition(restoreMgr.Restore, dpv1alpha1.PostReady, dprestore.ReasonSucceed, "processing postReady stage successfully")
 	return true, nil
 }
@@ -474,6 +492,7 @@ func (r *RestoreReconciler) handleBackupActionSet(reqCtx intctrlutil.RequestCtx,
 	// 4. check if jobs are finished.
 	allActionsFinished, existFailedAction = restoreMgr.CheckJobsDone(stage, actionName, backupSet, jobs)
 	if stage == dpv1alpha1.PrepareData {
+		restoreMgr.SyncDataProgress(jobs)
 		// recalculation whether all actions have been completed.
 		restoreMgr.Recalculation(backupSet.Backup.Name, actionName, &allActionsFinished, &existFailedAction)
 	}
@@ -0,0 +1,525 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dataprotection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/restore"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+)
+
+// RestoreDrillReconciler periodically restores the latest backup of a BackupPolicy into a temporary
+// cluster, verifies it and records the measurements into the BackupPolicy status.
+type RestoreDrillReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backuppolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backuppolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clusters,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+
+// Reconcile starts a restore drill when it's due, and drives the running drill to completion.
+func (r *RestoreDrillReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("backupPolicy", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	backupPolicy := &dpv1alpha1.BackupPolicy{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, backupPolicy); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	// the temporary cluster is garbage collected by the owner reference.
+	if !backupPolicy.DeletionTimestamp.IsZero() {
+		return intctrlutil.Reconciled()
+	}
+
+	drillStatus := backupPolicy.Status.RestoreDrill
+	if drillStatus != nil && drillStatus.Phase == dpv1alpha1.RestoreDrillPhaseRunning {
+		return r.reconcileRunningDrill(reqCtx, backupPolicy)
+	}
+
+	drill := backupPolicy.Spec.RestoreDrill
+	if !isRestoreDrillEnabled(drill) {
+		return intctrlutil.Reconciled()
+	}
+	interval, err := drill.Interval.ToDuration()
+	if err != nil || interval <= 0 {
+		r.Recorder.Eventf(backupPolicy, corev1.EventTypeWarning, ReasonRestoreDrillFailed,
+			"invalid interval %s of the restore drill", drill.Interval)
+		return intctrlutil.Reconciled()
+	}
+	if next := nextRestoreDrillTime(drillStatus, interval); time.Now().Before(next) {
+		return intctrlutil.RequeueAfter(time.Until(next), reqCtx.Log, "wait for the next restore drill")
+	}
+
+	backup, err := r.getLatestCompletedBackup(reqCtx, backupPolicy, drill.BackupMethod)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if backup == nil {
+		// the backups are watched, the drill starts once a backup is completed.
+		reqCtx.Log.V(1).Info("no completed backup found for the restore drill")
+		return intctrlutil.Reconciled()
+	}
+	return r.startDrill(reqCtx, backupPolicy, backup)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RestoreDrillReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
+		Named("restoredrill").
+		For(&dpv1alpha1.BackupPolicy{}).
+		Owns(&appsv1alpha1.Cluster{}).
+		Owns(&batchv1.Job{}).
+		Watches(&dpv1alpha1.Backup{}, handler.EnqueueRequestsFromMapFunc(r.parseBackup)).
+		Complete(r)
+}
+
+func (r *RestoreDrillReconciler) parseBackup(ctx context.Context, object client.Object) []reconcile.Request {
+	backup := object.(*dpv1alpha1.Backup)
+	if backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted || backup.Spec.BackupPolicyName == "" {
+		return nil
+	}
+	return []reconcile.Request{{
+		NamespacedName: client.ObjectKey{Namespace: backup.Namespace, Name: backup.Spec.BackupPolicyName},
+	}}
+}
+
+func (r *RestoreDrillReconciler) startDrill(reqCtx intctrlutil.RequestCtx,
+	backupPolicy *dpv1alpha1.BackupPolicy,
+	backup *dpv1alpha1.Backup) (ctrl.Result, error) {
+	now := metav1.Now()
+	drillStatus := &dpv1alpha1.RestoreDrillStatus{
+		Phase:          dpv1alpha1.RestoreDrillPhaseRunning,
+		BackupName:     backup.Name,
+		ClusterName:    buildRestoreDrillClusterName(backup),
+		StartTimestamp: &now,
+		RPOSeconds:     getRestoreDrillRPO(backup, now.Time),
+	}
+	if backupPolicy.Status.RestoreDrill != nil {
+		drillStatus.LastSucceededTimestamp = backupPolicy.Status.RestoreDrill.LastSucceededTimestamp
+	}
+	if err := r.patchDrillStatus(reqCtx, backupPolicy, drillStatus); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	r.Recorder.Eventf(backupPolicy, corev1.EventTypeNormal, ReasonRestoreDrillStarted,
+		"start to restore backup %s into the temporary cluster %s", backup.Name, drillStatus.ClusterName)
+	return r.reconcileRunningDrill(reqCtx, backupPolicy)
+}
+
+func (r *RestoreDrillReconciler) reconcileRunningDrill(reqCtx intctrlutil.RequestCtx,
+	backupPolicy *dpv1alpha1.BackupPolicy) (ctrl.Result, error) {
+	drill := backupPolicy.Spec.RestoreDrill
+	drillStatus := backupPolicy.Status.RestoreDrill
+	if !isRestoreDrillEnabled(drill) {
+		return r.finishDrill(reqCtx, backupPolicy, dpv1alpha1.RestoreDrillPhaseFailed, "the restore drill is disabled")
+	}
+
+	timeoutSeconds := drill.TimeoutSeconds
+	if timeoutSeconds <= 0 {
+		timeoutSeconds = defaultRestoreDrillTimeoutSeconds
+	}
+	deadline := drillStatus.StartTimestamp.Add(time.Duration(timeoutSeconds) * time.Second)
+	if time.Now().After(deadline) {
+		return r.finishDrill(reqCtx, backupPolicy, dpv1alpha1.RestoreDrillPhaseFailed,
+			fmt.Sprintf("the restore drill does not complete in %d seconds", timeoutSeconds))
+	}
+
+	cluster := &appsv1alpha1.Cluster{}
+	err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Namespace: backupPolicy.Namespace, Name: drillStatus.ClusterName}, cluster)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if err != nil {
+		backup := &dpv1alpha1.Backup{}
+		if err = r.Client.Get(reqCtx.Ctx, client.ObjectKey{Namespace: backupPolicy.Namespace, Name: drillStatus.BackupName}, backup); err != nil {
+			if apierrors.IsNotFound(err) {
+				return r.finishDrill(reqCtx, backupPolicy, dpv1alpha1.RestoreDrillPhaseFailed,
+					fmt.Sprintf("backup %s is not found", drillStatus.BackupName))
+			}
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		if cluster, err = buildRestoreDrillCluster(backupPolicy, backup, drillStatus.ClusterName); err != nil {
+			return r.finishDrill(reqCtx, backupPolicy, dpv1alpha1.RestoreDrillPhaseFailed, err.Error())
+		}
+		if err = controllerutil.SetControllerReference(backupPolicy, cluster, r.Scheme); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		if err = r.Client.Create(reqCtx.Ctx, cluster); err != nil {
+			return intctrlutil.CheckedRequeueWithError(intctrlutil.IgnoreIsAlreadyExists(err), reqCtx.Log, "")
+		}
+		return intctrlutil.RequeueAfter(time.Until(deadline), reqCtx.Log, "wait for the temporary cluster to be running")
+	}
+
+	switch cluster.Status.Phase {
+	case appsv1alpha1.FailedClusterPhase:
+		return r.finishDrill(reqCtx, backupPolicy, dpv1alpha1.RestoreDrillPhaseFailed,
+			fmt.Sprintf("the temporary cluster %s is failed", cluster.Name))
+	case appsv1alpha1.RunningClusterPhase:
+	default:
+		return intctrlutil.RequeueAfter(time.Until(deadline), reqCtx.Log, "wait for the temporary cluster to be running")
+	}
+
+	if drillStatus.RTOSeconds == nil {
+		newStatus := drillStatus.DeepCopy()
+		rto := int64(time.Since(drillStatus.StartTimestamp.Time).Seconds())
+		newStatus.RTOSeconds = &rto
+		if err = r.patchDrillStatus(reqCtx, backupPolicy, newStatus); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+	}
+	if drill.VerifyAction == nil {
+		return r.finishDrill(reqCtx, backupPolicy, dpv1alpha1.RestoreDrillPhaseSucceeded, "")
+	}
+	return r.reconcileVerifyJob(reqCtx, backupPolicy, cluster, deadline)
+}
+
+func (r *RestoreDrillReconciler) reconcileVerifyJob(reqCtx intctrlutil.RequestCtx,
+	backupPolicy *dpv1alpha1.BackupPolicy,
+	cluster *appsv1alpha1.Cluster,
+	deadline time.Time) (ctrl.Result, error) {
+	job := &batchv1.Job{}
+	err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: buildRestoreDrillVerifyJobName(cluster.Name)}, job)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if err != nil {
+		if job, err = r.buildVerifyJob(reqCtx, backupPolicy, cluster); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		if err = r.Client.Create(reqCtx.Ctx, job); err != nil {
+			return intctrlutil.CheckedRequeueWithError(intctrlutil.IgnoreIsAlreadyExists(err), reqCtx.Log, "")
+		}
+		return intctrlutil.RequeueAfter(time.Until(deadline), reqCtx.Log, "wait for the verify job to complete")
+	}
+
+	finished, _, errMsg := dputils.IsJobFinished(job)
+	if !finished {
+		return intctrlutil.RequeueAfter(time.Until(deadline), reqCtx.Log, "wait for the verify job to complete")
+	}
+	if errMsg != "" {
+		return r.finishDrill(reqCtx, backupPolicy, dpv1alpha1.RestoreDrillPhaseFailed,
+			fmt.Sprintf("the verify job %s failed: %s", job.Name, errMsg))
+	}
+	return r.finishDrill(reqCtx, backupPolicy, dpv1alpha1.RestoreDrillPhaseSucceeded, "")
+}
+
+func (r *RestoreDrillReconciler) buildVerifyJob(reqCtx intctrlutil.RequestCtx,
+	backupPolicy *dpv1alpha1.BackupPolicy,
+	cluster *appsv1alpha1.Cluster) (*batchv1.Job, error) {
+	drillStatus := backupPolicy.Status.RestoreDrill
+	env := []corev1.EnvVar{
+		{Name: dptypes.DPBackupName, Value: drillStatus.BackupName},
+		{Name: dptypes.DPRestoreDrillClusterName, Value: cluster.Name},
+	}
+	credentialEnv, err := r.buildVerifyCredentialEnv(reqCtx, backupPolicy, cluster)
+	if err != nil {
+		return nil, err
+	}
+	env = append(env, credentialEnv...)
+
+	action := backupPolicy.Spec.RestoreDrill.VerifyAction
+	podSpec := corev1.PodSpec{
+		RestartPolicy: corev1.RestartPolicyNever,
+		Containers: []corev1.Container{
+			{
+				Name:            "verify",
+				Image:           action.Image,
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Command:         action.Command,
+				Env:             env,
+			},
+		},
+	}
+	if err = dputils.AddTolerations(&podSpec); err != nil {
+		return nil, err
+	}
+
+	labels := map[string]string{
+		constant.AppManagedByLabelKey:      dptypes.AppName,
+		dataProtectionRestoreDrillLabelKey: backupPolicy.Name,
+	}
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      buildRestoreDrillVerifyJobName(cluster.Name),
+			Namespace: cluster.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: backupPolicy.Spec.BackoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       podSpec,
+			},
+		},
+	}
+	if err = controllerutil.SetControllerReference(backupPolicy, job, r.Scheme); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// buildVerifyCredentialEnv builds the connection envs of the temporary cluster from the backup target,
+// the pod selector and the credential secret of the source cluster are redirected to the temporary cluster.
+func (r *RestoreDrillReconciler) buildVerifyCredentialEnv(reqCtx intctrlutil.RequestCtx,
+	backupPolicy *dpv1alpha1.BackupPolicy,
+	cluster *appsv1alpha1.Cluster) ([]corev1.EnvVar, error) {
+	target := backupPolicy.Spec.Target
+	if target == nil && len(backupPolicy.Spec.Targets) > 0 {
+		target = &backupPolicy.Spec.Targets[0]
+	}
+	if target == nil || target.PodSelector == nil || target.PodSelector.LabelSelector == nil {
+		return nil, nil
+	}
+	sourceClusterName := backupPolicy.Labels[constant.AppInstanceLabelKey]
+	drillTarget := target.DeepCopy()
+	if drillTarget.PodSelector.MatchLabels == nil {
+		drillTarget.PodSelector.MatchLabels = map[string]string{}
+	}
+	drillTarget.PodSelector.MatchLabels[constant.AppInstanceLabelKey] = cluster.Name
+	if credential := drillTarget.ConnectionCredential; credential != nil {
+		if sourceClusterName == "" || !strings.HasPrefix(credential.SecretName, sourceClusterName) {
+			// the credential secret does not belong to the source cluster, it can not be redirected.
+			drillTarget.ConnectionCredential = nil
+		} else {
+			credential.SecretName = cluster.Name + strings.TrimPrefix(credential.SecretName, sourceClusterName)
+		}
+	}
+	pods, err := GetTargetPods(reqCtx, r.Client, nil, backupPolicy, drillTarget, dpv1alpha1.BackupTypeFull)
+	if err != nil || len(pods) == 0 {
+		return nil, err
+	}
+	return dputils.BuildEnvByCredential(pods[0], drillTarget.ConnectionCredential), nil
+}
+
+// finishDrill cleans up the temporary resources and records the result of the drill.
+func (r *RestoreDrillReconciler) finishDrill(reqCtx intctrlutil.RequestCtx,
+	backupPolicy *dpv1alpha1.BackupPolicy,
+	phase dpv1alpha1.RestoreDrillPhase,
+	message string) (ctrl.Result, error) {
+	drillStatus := backupPolicy.Status.RestoreDrill
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Namespace: backupPolicy.Namespace, Name: buildRestoreDrillVerifyJobName(drillStatus.ClusterName)},
+	}
+	if err := intctrlutil.BackgroundDeleteObject(r.Client, reqCtx.Ctx, job); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	cluster := &appsv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: backupPolicy.Namespace, Name: drillStatus.ClusterName},
+	}
+	if err := r.Client.Delete(reqCtx.Ctx, cluster); client.IgnoreNotFound(err) != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	now := metav1.Now()
+	newStatus := drillStatus.DeepCopy()
+	newStatus.Phase = phase
+	newStatus.Message = message
+	newStatus.CompletionTimestamp = &now
+	if phase == dpv1alpha1.RestoreDrillPhaseSucceeded {
+		newStatus.LastSucceededTimestamp = &now
+	}
+	if err := r.patchDrillStatus(reqCtx, backupPolicy, newStatus); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if phase == dpv1alpha1.RestoreDrillPhaseSucceeded {
+		r.Recorder.Eventf(backupPolicy, corev1.EventTypeNormal, ReasonRestoreDrillSucceeded,
+			"backup %s is restored and verified successfully", newStatus.BackupName)
+	} else {
+		r.Recorder.Eventf(backupPolicy, corev1.EventTypeWarning, ReasonRestoreDrillFailed,
+			"failed to restore backup %s: %s", newStatus.BackupName, message)
+	}
+
+	drill := backupPolicy.Spec.RestoreDrill
+	if !isRestoreDrillEnabled(drill) {
+		return intctrlutil.Reconciled()
+	}
+	interval, err := drill.Interval.ToDuration()
+	if err != nil || interval <= 0 {
+		return intctrlutil.Reconciled()
+	}
+	return intctrlutil.RequeueAfter(time.Until(nextRestoreDrillTime(newStatus, interval)), reqCtx.Log, "wait for the next restore drill")
+}
+
+func (r *RestoreDrillReconciler) patchDrillStatus(reqCtx intctrlutil.RequestCtx,
+	backupPolicy *dpv1alpha1.BackupPolicy,
+	drillStatus *dpv1alpha1.RestoreDrillStatus) error {
+	patch := client.MergeFrom(backupPolicy.DeepCopy())
+	backupPolicy.Status.RestoreDrill = drillStatus
+	return r.Client.Status().Patch(reqCtx.Ctx, backupPolicy, patch)
+}
+
+// getLatestCompletedBackup gets the latest completed backup of the backup policy, the continuous
+// backups are ignored since they are never completed.
+func (r *RestoreDrillReconciler) getLatestCompletedBackup(reqCtx intctrlutil.RequestCtx,
+	backupPolicy *dpv1alpha1.BackupPolicy,
+	backupMethod string) (*dpv1alpha1.Backup, error) {
+	backupList := &dpv1alpha1.BackupList{}
+	if err := r.Client.List(reqCtx.Ctx, backupList, client.InNamespace(backupPolicy.Namespace),
+		client.MatchingLabels{dptypes.BackupPolicyLabelKey: backupPolicy.Name}); err != nil {
+		return nil, err
+	}
+	var latest *dpv1alpha1.Backup
+	for i := range backupList.Items {
+		backup := &backupList.Items[i]
+		if backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted || !backup.DeletionTimestamp.IsZero() ||
+			backup.Status.CompletionTimestamp == nil {
+			continue
+		}
+		if backupMethod != "" && backup.Spec.BackupMethod != backupMethod {
+			continue
+		}
+		if backup.Labels[dptypes.BackupTypeLabelKey] == string(dpv1alpha1.BackupTypeContinuous) {
+			continue
+		}
+		if latest == nil || latest.Status.CompletionTimestamp.Before(backup.Status.CompletionTimestamp) {
+			latest = backup
+		}
+	}
+	return latest, nil
+}
+
+// buildRestoreDrillCluster builds the temporary cluster from the cluster snapshot of the backup.
+func buildRestoreDrillCluster(backupPolicy *dpv1alpha1.BackupPolicy,
+	backup *dpv1alpha1.Backup,
+	clusterName string) (*appsv1alpha1.Cluster, error) {
+	clusterString, ok := backup.Annotations[constant.ClusterSnapshotAnnotationKey]
+	if !ok {
+		return nil, fmt.Errorf("missing snapshot annotation in backup %s, %s is empty in Annotations",
+			backup.Name, constant.ClusterSnapshotAnnotationKey)
+	}
+	snapshot := &appsv1alpha1.Cluster{}
+	if err := json.Unmarshal([]byte(clusterString), snapshot); err != nil {
+		return nil, err
+	}
+	restoreAnnotation, err := restore.GetRestoreFromBackupAnnotation(backup,
+		string(dpv1alpha1.VolumeClaimRestorePolicyParallel), "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := &appsv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterName,
+			Namespace: backupPolicy.Namespace,
+			Labels: map[string]string{
+				dataProtectionRestoreDrillLabelKey: backupPolicy.Name,
+			},
+			Annotations: map[string]string{
+				constant.RestoreFromBackupAnnotationKey: restoreAnnotation,
+			},
+		},
+		Spec: snapshot.Spec,
+	}
+	// the temporary cluster is thrown away after the drill, and should not be backed up.
+	cluster.Spec.TerminationPolicy = appsv1alpha1.WipeOut
+	cluster.Spec.Backup = nil
+	var services []appsv1alpha1.ClusterService
+	for i := range cluster.Spec.Services {
+		svc := cluster.Spec.Services[i]
+		if svc.Service.Spec.Type == corev1.ServiceTypeLoadBalancer {
+			continue
+		}
+		if svc.Service.Spec.Type == corev1.ServiceTypeNodePort {
+			for j := range svc.Spec.Ports {
+				svc.Spec.Ports[j].NodePort = 0
+			}
+		}
+		if svc.Service.Spec.Selector != nil {
+			delete(svc.Service.Spec.Selector, constant.AppInstanceLabelKey)
+		}
+		services = append(services, svc)
+	}
+	cluster.Spec.Services = services
+	return cluster, nil
+}
+
+func isRestoreDrillEnabled(drill *dpv1alpha1.RestoreDrill) bool {
+	return drill != nil && (drill.Enabled == nil || *drill.Enabled)
+}
+
+// nextRestoreDrillTime returns the time when the next drill should start, a zero time
+// is returned if no drill has been started.
+func nextRestoreDrillTime(drillStatus *dpv1alpha1.RestoreDrillStatus, interval time.Duration) time.Time {
+	if drillStatus == nil || drillStatus.StartTimestamp == nil {
+		return time.Time{}
+	}
+	return drillStatus.StartTimestamp.Add(interval)
+}
+
+// getRestoreDrillRPO returns the duration in seconds between the time of the backup data and the start of the drill.
+func getRestoreDrillRPO(backup *dpv1alpha1.Backup, start time.Time) *int64 {
+	var dataTime *metav1.Time
+	if backup.Status.TimeRange != nil && backup.Status.TimeRange.End != nil {
+		dataTime = backup.Status.TimeRange.End
+	} else {
+		dataTime = backup.Status.CompletionTimestamp
+	}
+	if dataTime == nil {
+		return nil
+	}
+	rpo := int64(start.Sub(dataTime.Time).Seconds())
+	if rpo < 0 {
+		rpo = 0
+	}
+	return &rpo
+}
+
+func buildRestoreDrillClusterName(backup *dpv1alpha1.Backup) string {
+	clusterName := backup.Labels[constant.AppInstanceLabelKey]
+	if clusterName == "" {
+		clusterName = backup.Spec.BackupPolicyName
+	}
+	return fmt.Sprintf("%s-drill-%s", clusterName, rand.String(4))
+}
+
+func buildRestoreDrillVerifyJobName(clusterName string) string {
+	return fmt.Sprintf("%s-verify", clusterName)
+}
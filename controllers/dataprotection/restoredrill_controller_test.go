@@ -0,0 +1,253 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dataprotection
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	testdp "github.com/apecloud/kubeblocks/pkg/testutil/dataprotection"
+)
+
+var _ = Describe("RestoreDrill Controller", func() {
+	const (
+		clusterName = "mysql"
+		policyName  = "mysql-backup-policy"
+	)
+
+	buildBackup := func() *dpv1alpha1.Backup {
+		snapshot := &appsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: testCtx.DefaultNamespace},
+			Spec: appsv1alpha1.ClusterSpec{
+				TerminationPolicy: appsv1alpha1.Delete,
+				Backup:            &appsv1alpha1.ClusterBackup{},
+				Services: []appsv1alpha1.ClusterService{
+					{Service: appsv1alpha1.Service{Name: "lb", Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer}}},
+					{Service: appsv1alpha1.Service{Name: "np", Spec: corev1.ServiceSpec{
+						Type:  corev1.ServiceTypeNodePort,
+						Ports: []corev1.ServicePort{{Name: "mysql", Port: 3306, NodePort: 30306}},
+					}}},
+				},
+			},
+		}
+		snapshotBytes, err := json.Marshal(snapshot)
+		Expect(err).ShouldNot(HaveOccurred())
+		return &dpv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "backup-1",
+				Namespace: testCtx.DefaultNamespace,
+				Labels: map[string]string{
+					constant.AppInstanceLabelKey:    clusterName,
+					constant.KBAppComponentLabelKey: clusterName,
+				},
+				Annotations: map[string]string{
+					constant.ClusterSnapshotAnnotationKey: string(snapshotBytes),
+				},
+			},
+			Spec: dpv1alpha1.BackupSpec{BackupPolicyName: policyName},
+		}
+	}
+
+	It("builds the temporary cluster from the backup", func() {
+		backupPolicy := &dpv1alpha1.BackupPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: policyName, Namespace: testCtx.DefaultNamespace},
+		}
+		backup := buildBackup()
+		drillClusterName := buildRestoreDrillClusterName(backup)
+		Expect(drillClusterName).Should(HavePrefix(clusterName + "-drill-"))
+
+		cluster, err := buildRestoreDrillCluster(backupPolicy, backup, drillClusterName)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cluster.Name).Should(Equal(drillClusterName))
+		Expect(cluster.Labels[dataProtectionRestoreDrillLabelKey]).Should(Equal(policyName))
+		Expect(cluster.Annotations[constant.RestoreFromBackupAnnotationKey]).ShouldNot(BeEmpty())
+		Expect(cluster.Spec.TerminationPolicy).Should(Equal(appsv1alpha1.WipeOut))
+		Expect(cluster.Spec.Backup).Should(BeNil())
+		Expect(cluster.Spec.Services).Should(HaveLen(1))
+		Expect(cluster.Spec.Services[0].Spec.Ports[0].NodePort).Should(BeZero())
+
+		By("the backup without cluster snapshot can not be restored")
+		delete(backup.Annotations, constant.ClusterSnapshotAnnotationKey)
+		_, err = buildRestoreDrillCluster(backupPolicy, backup, drillClusterName)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("calculates the schedule and the measurements", func() {
+		Expect(nextRestoreDrillTime(nil, time.Hour).IsZero()).Should(BeTrue())
+		start := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+		next := nextRestoreDrillTime(&dpv1alpha1.RestoreDrillStatus{StartTimestamp: &start}, time.Hour)
+		Expect(next).Should(Equal(start.Add(time.Hour)))
+
+		backup := buildBackup()
+		Expect(getRestoreDrillRPO(backup, time.Now())).Should(BeNil())
+		completion := metav1.NewTime(time.Now().Add(-time.Hour))
+		backup.Status.CompletionTimestamp = &completion
+		end := metav1.NewTime(completion.Add(-time.Hour))
+		backup.Status.TimeRange = &dpv1alpha1.BackupTimeRange{End: &end}
+		rpo := getRestoreDrillRPO(backup, completion.Add(time.Hour))
+		Expect(rpo).ShouldNot(BeNil())
+		Expect(*rpo).Should(Equal(int64(2 * time.Hour / time.Second)))
+
+		enabled := false
+		Expect(isRestoreDrillEnabled(nil)).Should(BeFalse())
+		Expect(isRestoreDrillEnabled(&dpv1alpha1.RestoreDrill{})).Should(BeTrue())
+		Expect(isRestoreDrillEnabled(&dpv1alpha1.RestoreDrill{Enabled: &enabled})).Should(BeFalse())
+	})
+
+	Context("run the restore drill", func() {
+		cleanEnv := func() {
+			// must wait till resources deleted and no longer existed before the testcases start,
+			// otherwise if later it needs to create some new resource objects with the same name,
+			// in race conditions, it will find the existence of old objects, resulting failure to
+			// create the new objects.
+			By("clean resources")
+			inNS := client.InNamespace(testCtx.DefaultNamespace)
+			ml := client.HasLabels{testCtx.TestObjLabelKey}
+
+			// non-namespaced
+			testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ActionSetSignature, true, ml)
+
+			// namespaced
+			testapps.ClearResources(&testCtx, generics.SecretSignature, inNS, ml)
+			testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ClusterSignature, true, inNS)
+			testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupSignature, true, inNS)
+			testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupPolicySignature, true, inNS)
+		}
+
+		var backupPolicy *dpv1alpha1.BackupPolicy
+
+		BeforeEach(func() {
+			cleanEnv()
+
+			By("creating an actionSet")
+			testdp.NewFakeActionSet(&testCtx)
+
+			By("creating a backupPolicy with the restore drill")
+			backupPolicy = testdp.NewFakeBackupPolicy(&testCtx, func(backupPolicy *dpv1alpha1.BackupPolicy) {
+				backupPolicy.Spec.RestoreDrill = &dpv1alpha1.RestoreDrill{
+					Interval:       "1h",
+					TimeoutSeconds: 600,
+				}
+			})
+		})
+
+		AfterEach(func() {
+			cleanEnv()
+		})
+
+		createCompletedBackup := func(withSnapshot bool) *dpv1alpha1.Backup {
+			source := buildBackup()
+			if !withSnapshot {
+				delete(source.Annotations, constant.ClusterSnapshotAnnotationKey)
+			}
+			backup := testdp.NewBackupFactory(testCtx.DefaultNamespace, source.Name).
+				WithRandomName().
+				SetBackupPolicyName(testdp.BackupPolicyName).
+				// the backup is failed by the backup controller for the missing method, and mocked to completed later.
+				SetBackupMethod("not-exist").
+				AddLabelsInMap(source.Labels).
+				AddLabels(dptypes.BackupPolicyLabelKey, testdp.BackupPolicyName).
+				AddAnnotationsInMap(source.Annotations).
+				Create(&testCtx).GetObject()
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(backup), func(g Gomega, fetched *dpv1alpha1.Backup) {
+				g.Expect(fetched.Status.Phase).Should(Equal(dpv1alpha1.BackupPhaseFailed))
+			})).Should(Succeed())
+			Expect(testapps.GetAndChangeObjStatus(&testCtx, client.ObjectKeyFromObject(backup), func(fetched *dpv1alpha1.Backup) {
+				now := metav1.Now()
+				fetched.Status.Phase = dpv1alpha1.BackupPhaseCompleted
+				fetched.Status.StartTimestamp = &now
+				fetched.Status.CompletionTimestamp = &now
+			})()).Should(Succeed())
+			return backup
+		}
+
+		checkDrillStatus := func(check func(g Gomega, drillStatus *dpv1alpha1.RestoreDrillStatus)) {
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(backupPolicy),
+				func(g Gomega, fetched *dpv1alpha1.BackupPolicy) {
+					g.Expect(fetched.Status.RestoreDrill).ShouldNot(BeNil())
+					check(g, fetched.Status.RestoreDrill)
+				})).Should(Succeed())
+		}
+
+		It("restores the backup into a temporary cluster and cleans it up", func() {
+			backup := createCompletedBackup(true)
+
+			By("the drill starts with the completed backup")
+			var drillClusterName string
+			checkDrillStatus(func(g Gomega, drillStatus *dpv1alpha1.RestoreDrillStatus) {
+				g.Expect(drillStatus.Phase).Should(Equal(dpv1alpha1.RestoreDrillPhaseRunning))
+				g.Expect(drillStatus.BackupName).Should(Equal(backup.Name))
+				g.Expect(drillStatus.StartTimestamp).ShouldNot(BeNil())
+				g.Expect(drillStatus.RPOSeconds).ShouldNot(BeNil())
+				drillClusterName = drillStatus.ClusterName
+			})
+
+			By("the temporary cluster is created from the backup")
+			clusterKey := client.ObjectKey{Namespace: testCtx.DefaultNamespace, Name: drillClusterName}
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1alpha1.Cluster) {
+				g.Expect(cluster.Labels).Should(HaveKeyWithValue(dataProtectionRestoreDrillLabelKey, backupPolicy.Name))
+				g.Expect(cluster.Annotations).Should(HaveKey(constant.RestoreFromBackupAnnotationKey))
+				g.Expect(cluster.Spec.TerminationPolicy).Should(Equal(appsv1alpha1.WipeOut))
+			})).Should(Succeed())
+
+			By("mock the temporary cluster running")
+			Expect(testapps.GetAndChangeObjStatus(&testCtx, clusterKey, func(cluster *appsv1alpha1.Cluster) {
+				cluster.Status.Phase = appsv1alpha1.RunningClusterPhase
+			})()).Should(Succeed())
+
+			By("the drill succeeds and the temporary cluster is deleted")
+			checkDrillStatus(func(g Gomega, drillStatus *dpv1alpha1.RestoreDrillStatus) {
+				g.Expect(drillStatus.Phase).Should(Equal(dpv1alpha1.RestoreDrillPhaseSucceeded))
+				g.Expect(drillStatus.RTOSeconds).ShouldNot(BeNil())
+				g.Expect(drillStatus.CompletionTimestamp).ShouldNot(BeNil())
+				g.Expect(drillStatus.LastSucceededTimestamp).ShouldNot(BeNil())
+			})
+			Eventually(testapps.CheckObjExists(&testCtx, clusterKey, &appsv1alpha1.Cluster{}, false)).Should(Succeed())
+		})
+
+		It("fails the drill if the backup can not be restored", func() {
+			backup := createCompletedBackup(false)
+
+			var drillClusterName string
+			checkDrillStatus(func(g Gomega, drillStatus *dpv1alpha1.RestoreDrillStatus) {
+				g.Expect(drillStatus.Phase).Should(Equal(dpv1alpha1.RestoreDrillPhaseFailed))
+				g.Expect(drillStatus.BackupName).Should(Equal(backup.Name))
+				g.Expect(drillStatus.Message).Should(ContainSubstring("missing snapshot annotation"))
+				g.Expect(drillStatus.LastSucceededTimestamp).Should(BeNil())
+				drillClusterName = drillStatus.ClusterName
+			})
+			clusterKey := client.ObjectKey{Namespace: testCtx.DefaultNamespace, Name: drillClusterName}
+			Eventually(testapps.CheckObjExists(&testCtx, clusterKey, &appsv1alpha1.Cluster{}, false)).Should(Succeed())
+		})
+	})
+})
@@ -23,6 +23,8 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 )
 
 const (
@@ -32,7 +34,7 @@ const (
 const (
 
 	// label keys
-	dataProtectionBackupRepoKey          = "dataprotection.kubeblocks.io/backup-repo-name"
+	dataProtectionBackupRepoKey          = dptypes.BackupRepoNameLabelKey
 	dataProtectionWaitRepoPreparationKey = "dataprotection.kubeblocks.io/wait-repo-preparation"
 	dataProtectionIsToolConfigKey        = "dataprotection.kubeblocks.io/is-tool-config"
 
@@ -94,4 +96,17 @@ const (
 	PersistentVolumeClaimPopulating corev1.PersistentVolumeClaimConditionType = "Populating"
 )
 
+// constant for restore drill
+const (
+	// label keys
+	dataProtectionRestoreDrillLabelKey = "dataprotection.kubeblocks.io/restore-drill"
+
+	defaultRestoreDrillTimeoutSeconds = 3600
+
+	// event reasons
+	ReasonRestoreDrillStarted   = "RestoreDrillStarted"
+	ReasonRestoreDrillSucceeded = "RestoreDrillSucceeded"
+	ReasonRestoreDrillFailed    = "RestoreDrillFailed"
+)
+
 var reconcileInterval = time.Second
@@ -82,11 +82,33 @@ func getBackupRepo(ctx context.Context,
 	return getDefaultBackupRepo(ctx, cli)
 }
 
+// checkBackupRepoAccess checks whether the backup repo is allowed to be accessed from the namespace.
+func checkBackupRepoAccess(ctx context.Context, cli client.Client, repo *dpv1alpha1.BackupRepo, namespace string) error {
+	if !repo.IsNamespaceRestricted() {
+		return nil
+	}
+	ns := &corev1.Namespace{}
+	if err := cli.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		return err
+	}
+	allowed, err := repo.IsNamespaceAllowed(ns.Name, ns.Labels)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return dperrors.NewBackupRepoAccessDenied(repo.Name, namespace)
+	}
+	return nil
+}
+
 func HandleBackupRepo(request *dpbackup.Request) error {
 	repo, err := getBackupRepo(request.Ctx, request.Client, request.Backup, request.BackupPolicy)
 	if err != nil {
 		return err
 	}
+	if err = checkBackupRepoAccess(request.Ctx, request.Client, repo, request.Backup.Namespace); err != nil {
+		return err
+	}
 	request.BackupRepo = repo
 
 	if repo.Status.Phase != dpv1alpha1.BackupRepoReady {
@@ -0,0 +1,257 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dataprotection
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"time"
+
+	vsv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+)
+
+// VolumeSnapshotGCReconciler periodically lists the volume snapshots of the components, records the live ones
+// in the component status, and deletes the automatically created ones which exceed the retention period of the component.
+type VolumeSnapshotGCReconciler struct {
+	client.Client
+	Recorder  record.EventRecorder
+	clock     clock.WithTickerAndDelayedExecution
+	frequency time.Duration
+}
+
+func NewVolumeSnapshotGCReconciler(mgr ctrl.Manager) *VolumeSnapshotGCReconciler {
+	return &VolumeSnapshotGCReconciler{
+		Client:    dputils.NewCompatClient(mgr.GetClient()),
+		Recorder:  mgr.GetEventRecorderFor("volume-snapshot-gc-controller"),
+		clock:     clock.RealClock{},
+		frequency: getGCFrequency(),
+	}
+}
+
+// SetupWithManager sets up the VolumeSnapshotGCReconciler using the supplied manager.
+// The components are enqueued periodically and whenever the backups of them are changed,
+// other events of the components are filtered to decrease the load on the controller.
+func (r *VolumeSnapshotGCReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	s := dputils.NewPeriodicalEnqueueSource(mgr.GetClient(), &appsv1alpha1.ComponentList{}, r.frequency, dputils.PeriodicalEnqueueSourceOption{})
+	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
+		For(&appsv1alpha1.Component{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(client.Object) bool { return false }))).
+		WatchesRawSource(s, nil).
+		Watches(&dpv1alpha1.Backup{}, handler.EnqueueRequestsFromMapFunc(r.filterComponentBackups)).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=components,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=components/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// sync the volume snapshots of the component and delete the expired ones.
+func (r *VolumeSnapshotGCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("gc volume snapshot", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	comp := &appsv1alpha1.Component{}
+	if err := r.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, comp); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	// component is being deleted, the backups and volume snapshots are handled by the cluster deletion.
+	if !comp.DeletionTimestamp.IsZero() {
+		return intctrlutil.Reconciled()
+	}
+
+	clusterName := comp.Labels[constant.AppInstanceLabelKey]
+	compName := comp.Labels[constant.KBAppComponentLabelKey]
+	if clusterName == "" || compName == "" {
+		return intctrlutil.Reconciled()
+	}
+
+	backupList := &dpv1alpha1.BackupList{}
+	if err := r.List(reqCtx.Ctx, backupList, client.InNamespace(comp.Namespace),
+		client.MatchingLabels{constant.AppInstanceLabelKey: clusterName, constant.KBAppComponentLabelKey: compName}); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if len(backupList.Items) == 0 && len(comp.Status.VolumeSnapshots) == 0 {
+		return intctrlutil.Reconciled()
+	}
+
+	// the component label is not propagated to the volume snapshots, match them by the backups.
+	snapList := &vsv1.VolumeSnapshotList{}
+	if len(backupList.Items) > 0 {
+		if err := r.List(reqCtx.Ctx, snapList, client.InNamespace(comp.Namespace),
+			client.MatchingLabels{constant.AppInstanceLabelKey: clusterName}); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+	}
+
+	retention := r.getRetentionPeriod(comp)
+	now := r.clock.Now()
+	backups := map[string]*dpv1alpha1.Backup{}
+	for i := range backupList.Items {
+		backups[backupList.Items[i].Name] = &backupList.Items[i]
+	}
+
+	var (
+		snapshots      []appsv1alpha1.ComponentVolumeSnapshot
+		nextExpiration *time.Time
+	)
+	for i := range snapList.Items {
+		snap := &snapList.Items[i]
+		backup, ok := backups[snap.Labels[dptypes.BackupNameLabelKey]]
+		if !ok || !snap.DeletionTimestamp.IsZero() {
+			continue
+		}
+		snapshot := buildComponentVolumeSnapshot(snap, backup, retention)
+		if snapshot.ExpirationTimestamp == nil {
+			snapshots = append(snapshots, snapshot)
+			continue
+		}
+		if expiration := snapshot.ExpirationTimestamp.Time; expiration.After(now) {
+			if nextExpiration == nil || expiration.Before(*nextExpiration) {
+				nextExpiration = &expiration
+			}
+			snapshots = append(snapshots, snapshot)
+			continue
+		}
+		if !backup.DeletionTimestamp.IsZero() {
+			continue
+		}
+		// the volume snapshot is owned by the backup, delete the backup to release both of them.
+		reqCtx.Log.Info("volume snapshot has expired, delete the backup", "volumeSnapshot", snap.Name, "backup", backup.Name)
+		if err := intctrlutil.BackgroundDeleteObject(r.Client, reqCtx.Ctx, backup); err != nil {
+			r.Recorder.Event(comp, corev1.EventTypeWarning, "RemoveExpiredVolumeSnapshotsFailed", err.Error())
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		backup.DeletionTimestamp = &metav1.Time{Time: now}
+	}
+	sortComponentVolumeSnapshots(snapshots)
+
+	if !reflect.DeepEqual(comp.Status.VolumeSnapshots, snapshots) {
+		patch := client.MergeFrom(comp.DeepCopy())
+		comp.Status.VolumeSnapshots = snapshots
+		if err := r.Status().Patch(reqCtx.Ctx, comp, patch); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+	}
+
+	if nextExpiration != nil && nextExpiration.Sub(now) < r.frequency {
+		return intctrlutil.RequeueAfter(nextExpiration.Sub(now), reqCtx.Log, "wait for the volume snapshot to expire")
+	}
+	return intctrlutil.Reconciled()
+}
+
+// getRetentionPeriod returns the retention period of the automatically created volume snapshots of the component,
+// zero means the volume snapshots are retained until the backups are deleted.
+func (r *VolumeSnapshotGCReconciler) getRetentionPeriod(comp *appsv1alpha1.Component) time.Duration {
+	if comp.Spec.VolumeSnapshotPolicy == nil {
+		return 0
+	}
+	retention, err := comp.Spec.VolumeSnapshotPolicy.RetentionPeriod.ToDuration()
+	if err != nil {
+		r.Recorder.Eventf(comp, corev1.EventTypeWarning, "InvalidRetentionPeriod",
+			"invalid retention period of volume snapshots: %s", err.Error())
+		return 0
+	}
+	return retention
+}
+
+func (r *VolumeSnapshotGCReconciler) filterComponentBackups(_ context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	clusterName, compName := labels[constant.AppInstanceLabelKey], labels[constant.KBAppComponentLabelKey]
+	if clusterName == "" || compName == "" {
+		return []reconcile.Request{}
+	}
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Namespace: obj.GetNamespace(),
+				Name:      constant.GenerateClusterComponentName(clusterName, compName),
+			},
+		},
+	}
+}
+
+// isAutoCreatedBackup checks if the backup is created automatically, by the backup schedule or
+// the horizontal scaling of the cluster.
+func isAutoCreatedBackup(backup *dpv1alpha1.Backup) bool {
+	return backup.Labels[dptypes.AutoBackupLabelKey] == "true" ||
+		backup.Labels[constant.KBManagedByKey] == "cluster"
+}
+
+func buildComponentVolumeSnapshot(snap *vsv1.VolumeSnapshot,
+	backup *dpv1alpha1.Backup,
+	retention time.Duration) appsv1alpha1.ComponentVolumeSnapshot {
+	snapshot := appsv1alpha1.ComponentVolumeSnapshot{
+		Name:              snap.Name,
+		BackupName:        backup.Name,
+		CreationTimestamp: snap.CreationTimestamp,
+	}
+	if snap.Spec.Source.PersistentVolumeClaimName != nil {
+		snapshot.PersistentVolumeClaimName = *snap.Spec.Source.PersistentVolumeClaimName
+	}
+	if snap.Spec.VolumeSnapshotClassName != nil {
+		snapshot.VolumeSnapshotClassName = *snap.Spec.VolumeSnapshotClassName
+	}
+	if snap.Status != nil {
+		snapshot.ReadyToUse = boolptr.IsSetToTrue(snap.Status.ReadyToUse)
+		if snap.Status.CreationTime != nil {
+			snapshot.CreationTimestamp = *snap.Status.CreationTime
+		}
+	}
+	if retention > 0 && isAutoCreatedBackup(backup) {
+		snapshot.ExpirationTimestamp = &metav1.Time{Time: snapshot.CreationTimestamp.Add(retention)}
+	}
+	return snapshot
+}
+
+func sortComponentVolumeSnapshots(snapshots []appsv1alpha1.ComponentVolumeSnapshot) {
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		if snapshots[i].CreationTimestamp.Equal(&snapshots[j].CreationTimestamp) {
+			return snapshots[i].Name < snapshots[j].Name
+		}
+		return snapshots[i].CreationTimestamp.Before(&snapshots[j].CreationTimestamp)
+	})
+}
@@ -0,0 +1,103 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dataprotection
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	vsv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+var _ = Describe("Volume Snapshot Garbage Collection Controller", func() {
+	var (
+		creationTime = metav1.NewTime(time.Now().Add(-time.Hour))
+		snap         *vsv1.VolumeSnapshot
+	)
+
+	BeforeEach(func() {
+		snap = &vsv1.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-backup-0"},
+			Spec: vsv1.VolumeSnapshotSpec{
+				Source:                  vsv1.VolumeSnapshotSource{PersistentVolumeClaimName: pointer.String("data-test-mysql-0")},
+				VolumeSnapshotClassName: pointer.String("csi-hostpath-snapclass"),
+			},
+			Status: &vsv1.VolumeSnapshotStatus{
+				CreationTime: &creationTime,
+				ReadyToUse:   pointer.Bool(true),
+			},
+		}
+	})
+
+	It("builds the volume snapshot of the component", func() {
+		backup := &dpv1alpha1.Backup{ObjectMeta: metav1.ObjectMeta{Name: "test-backup"}}
+		snapshot := buildComponentVolumeSnapshot(snap, backup, time.Hour*24)
+		Expect(snapshot.Name).Should(Equal(snap.Name))
+		Expect(snapshot.BackupName).Should(Equal(backup.Name))
+		Expect(snapshot.PersistentVolumeClaimName).Should(Equal("data-test-mysql-0"))
+		Expect(snapshot.VolumeSnapshotClassName).Should(Equal("csi-hostpath-snapclass"))
+		Expect(snapshot.ReadyToUse).Should(BeTrue())
+		Expect(snapshot.CreationTimestamp).Should(Equal(creationTime))
+		By("the snapshot of a backup created manually never expires")
+		Expect(snapshot.ExpirationTimestamp).Should(BeNil())
+	})
+
+	It("expires the volume snapshots of the backups created automatically", func() {
+		scheduledBackup := &dpv1alpha1.Backup{ObjectMeta: metav1.ObjectMeta{
+			Name:   "test-backup",
+			Labels: map[string]string{dptypes.AutoBackupLabelKey: "true"},
+		}}
+		snapshot := buildComponentVolumeSnapshot(snap, scheduledBackup, time.Hour*24)
+		Expect(snapshot.ExpirationTimestamp).ShouldNot(BeNil())
+		Expect(snapshot.ExpirationTimestamp.Time).Should(Equal(creationTime.Add(time.Hour * 24)))
+
+		hscaleBackup := &dpv1alpha1.Backup{ObjectMeta: metav1.ObjectMeta{
+			Name:   "test-backup",
+			Labels: map[string]string{constant.KBManagedByKey: "cluster"},
+		}}
+		Expect(buildComponentVolumeSnapshot(snap, hscaleBackup, time.Hour).ExpirationTimestamp).ShouldNot(BeNil())
+
+		By("no retention period")
+		Expect(buildComponentVolumeSnapshot(snap, hscaleBackup, 0).ExpirationTimestamp).Should(BeNil())
+	})
+
+	It("sorts the volume snapshots by the creation time", func() {
+		now := metav1.Now()
+		earlier := metav1.NewTime(now.Add(-time.Minute))
+		snapshots := []appsv1alpha1.ComponentVolumeSnapshot{
+			{Name: "snap-c", CreationTimestamp: now},
+			{Name: "snap-b", CreationTimestamp: now},
+			{Name: "snap-a", CreationTimestamp: earlier},
+		}
+		sortComponentVolumeSnapshots(snapshots)
+		Expect(snapshots[0].Name).Should(Equal("snap-a"))
+		Expect(snapshots[1].Name).Should(Equal("snap-b"))
+		Expect(snapshots[2].Name).Should(Equal("snap-c"))
+	})
+})
@@ -32,13 +32,17 @@ type AppsV1alpha1Interface interface {
 	ClustersGetter
 	ClusterDefinitionsGetter
 	ClusterVersionsGetter
+	CompliancePoliciesGetter
 	ComponentsGetter
+	ComponentClassDefinitionsGetter
 	ComponentDefinitionsGetter
 	ComponentVersionsGetter
 	ConfigConstraintsGetter
+	OpsBatchesGetter
 	OpsDefinitionsGetter
 	OpsRequestsGetter
 	ServiceDescriptorsGetter
+	ValidationPoliciesGetter
 }
 
 // AppsV1alpha1Client is used to interact with features provided by the apps.kubeblocks.io group.
@@ -62,10 +66,18 @@ func (c *AppsV1alpha1Client) ClusterVersions() ClusterVersionInterface {
 	return newClusterVersions(c)
 }
 
+func (c *AppsV1alpha1Client) CompliancePolicies() CompliancePolicyInterface {
+	return newCompliancePolicies(c)
+}
+
 func (c *AppsV1alpha1Client) Components(namespace string) ComponentInterface {
 	return newComponents(c, namespace)
 }
 
+func (c *AppsV1alpha1Client) ComponentClassDefinitions() ComponentClassDefinitionInterface {
+	return newComponentClassDefinitions(c)
+}
+
 func (c *AppsV1alpha1Client) ComponentDefinitions() ComponentDefinitionInterface {
 	return newComponentDefinitions(c)
 }
@@ -78,6 +90,10 @@ func (c *AppsV1alpha1Client) ConfigConstraints() ConfigConstraintInterface {
 	return newConfigConstraints(c)
 }
 
+func (c *AppsV1alpha1Client) OpsBatches(namespace string) OpsBatchInterface {
+	return newOpsBatches(c, namespace)
+}
+
 func (c *AppsV1alpha1Client) OpsDefinitions() OpsDefinitionInterface {
 	return newOpsDefinitions(c)
 }
@@ -90,6 +106,10 @@ func (c *AppsV1alpha1Client) ServiceDescriptors(namespace string) ServiceDescrip
 	return newServiceDescriptors(c, namespace)
 }
 
+func (c *AppsV1alpha1Client) ValidationPolicies() ValidationPolicyInterface {
+	return newValidationPolicies(c)
+}
+
 // NewForConfig creates a new AppsV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -44,10 +44,18 @@ func (c *FakeAppsV1alpha1) ClusterVersions() v1alpha1.ClusterVersionInterface {
 	return &FakeClusterVersions{c}
 }
 
+func (c *FakeAppsV1alpha1) CompliancePolicies() v1alpha1.CompliancePolicyInterface {
+	return &FakeCompliancePolicies{c}
+}
+
 func (c *FakeAppsV1alpha1) Components(namespace string) v1alpha1.ComponentInterface {
 	return &FakeComponents{c, namespace}
 }
 
+func (c *FakeAppsV1alpha1) ComponentClassDefinitions() v1alpha1.ComponentClassDefinitionInterface {
+	return &FakeComponentClassDefinitions{c}
+}
+
 func (c *FakeAppsV1alpha1) ComponentDefinitions() v1alpha1.ComponentDefinitionInterface {
 	return &FakeComponentDefinitions{c}
 }
@@ -60,6 +68,10 @@ func (c *FakeAppsV1alpha1) ConfigConstraints() v1alpha1.ConfigConstraintInterfac
 	return &FakeConfigConstraints{c}
 }
 
+func (c *FakeAppsV1alpha1) OpsBatches(namespace string) v1alpha1.OpsBatchInterface {
+	return &FakeOpsBatches{c, namespace}
+}
+
 func (c *FakeAppsV1alpha1) OpsDefinitions() v1alpha1.OpsDefinitionInterface {
 	return &FakeOpsDefinitions{c}
 }
@@ -72,6 +84,10 @@ func (c *FakeAppsV1alpha1) ServiceDescriptors(namespace string) v1alpha1.Service
 	return &FakeServiceDescriptors{c, namespace}
 }
 
+func (c *FakeAppsV1alpha1) ValidationPolicies() v1alpha1.ValidationPolicyInterface {
+	return &FakeValidationPolicies{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeAppsV1alpha1) RESTClient() rest.Interface {
@@ -26,16 +26,24 @@ type ClusterDefinitionExpansion interface{}
 
 type ClusterVersionExpansion interface{}
 
+type CompliancePolicyExpansion interface{}
+
 type ComponentExpansion interface{}
 
+type ComponentClassDefinitionExpansion interface{}
+
 type ComponentDefinitionExpansion interface{}
 
 type ComponentVersionExpansion interface{}
 
 type ConfigConstraintExpansion interface{}
 
+type OpsBatchExpansion interface{}
+
 type OpsDefinitionExpansion interface{}
 
 type OpsRequestExpansion interface{}
 
 type ServiceDescriptorExpansion interface{}
+
+type ValidationPolicyExpansion interface{}
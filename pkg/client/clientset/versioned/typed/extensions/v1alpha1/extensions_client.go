@@ -29,6 +29,7 @@ import (
 type ExtensionsV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	AddonsGetter
+	CRDMigrationsGetter
 }
 
 // ExtensionsV1alpha1Client is used to interact with features provided by the extensions.kubeblocks.io group.
@@ -40,6 +41,10 @@ func (c *ExtensionsV1alpha1Client) Addons() AddonInterface {
 	return newAddons(c)
 }
 
+func (c *ExtensionsV1alpha1Client) CRDMigrations() CRDMigrationInterface {
+	return newCRDMigrations(c)
+}
+
 // NewForConfig creates a new ExtensionsV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
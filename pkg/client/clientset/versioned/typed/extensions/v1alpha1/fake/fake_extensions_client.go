@@ -32,6 +32,10 @@ func (c *FakeExtensionsV1alpha1) Addons() v1alpha1.AddonInterface {
 	return &FakeAddons{c}
 }
 
+func (c *FakeExtensionsV1alpha1) CRDMigrations() v1alpha1.CRDMigrationInterface {
+	return &FakeCRDMigrations{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeExtensionsV1alpha1) RESTClient() rest.Interface {
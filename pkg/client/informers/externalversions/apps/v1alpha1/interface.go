@@ -32,20 +32,28 @@ type Interface interface {
 	ClusterDefinitions() ClusterDefinitionInformer
 	// ClusterVersions returns a ClusterVersionInformer.
 	ClusterVersions() ClusterVersionInformer
+	// CompliancePolicies returns a CompliancePolicyInformer.
+	CompliancePolicies() CompliancePolicyInformer
 	// Components returns a ComponentInformer.
 	Components() ComponentInformer
+	// ComponentClassDefinitions returns a ComponentClassDefinitionInformer.
+	ComponentClassDefinitions() ComponentClassDefinitionInformer
 	// ComponentDefinitions returns a ComponentDefinitionInformer.
 	ComponentDefinitions() ComponentDefinitionInformer
 	// ComponentVersions returns a ComponentVersionInformer.
 	ComponentVersions() ComponentVersionInformer
 	// ConfigConstraints returns a ConfigConstraintInformer.
 	ConfigConstraints() ConfigConstraintInformer
+	// OpsBatches returns a OpsBatchInformer.
+	OpsBatches() OpsBatchInformer
 	// OpsDefinitions returns a OpsDefinitionInformer.
 	OpsDefinitions() OpsDefinitionInformer
 	// OpsRequests returns a OpsRequestInformer.
 	OpsRequests() OpsRequestInformer
 	// ServiceDescriptors returns a ServiceDescriptorInformer.
 	ServiceDescriptors() ServiceDescriptorInformer
+	// ValidationPolicies returns a ValidationPolicyInformer.
+	ValidationPolicies() ValidationPolicyInformer
 }
 
 type version struct {
@@ -79,11 +87,21 @@ func (v *version) ClusterVersions() ClusterVersionInformer {
 	return &clusterVersionInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// CompliancePolicies returns a CompliancePolicyInformer.
+func (v *version) CompliancePolicies() CompliancePolicyInformer {
+	return &compliancePolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // Components returns a ComponentInformer.
 func (v *version) Components() ComponentInformer {
 	return &componentInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ComponentClassDefinitions returns a ComponentClassDefinitionInformer.
+func (v *version) ComponentClassDefinitions() ComponentClassDefinitionInformer {
+	return &componentClassDefinitionInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ComponentDefinitions returns a ComponentDefinitionInformer.
 func (v *version) ComponentDefinitions() ComponentDefinitionInformer {
 	return &componentDefinitionInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -99,6 +117,11 @@ func (v *version) ConfigConstraints() ConfigConstraintInformer {
 	return &configConstraintInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// OpsBatches returns a OpsBatchInformer.
+func (v *version) OpsBatches() OpsBatchInformer {
+	return &opsBatchInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // OpsDefinitions returns a OpsDefinitionInformer.
 func (v *version) OpsDefinitions() OpsDefinitionInformer {
 	return &opsDefinitionInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -113,3 +136,8 @@ func (v *version) OpsRequests() OpsRequestInformer {
 func (v *version) ServiceDescriptors() ServiceDescriptorInformer {
 	return &serviceDescriptorInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// ValidationPolicies returns a ValidationPolicyInformer.
+func (v *version) ValidationPolicies() ValidationPolicyInformer {
+	return &validationPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
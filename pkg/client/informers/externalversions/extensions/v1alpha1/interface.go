@@ -26,6 +26,8 @@ import (
 type Interface interface {
 	// Addons returns a AddonInformer.
 	Addons() AddonInformer
+	// CRDMigrations returns a CRDMigrationInformer.
+	CRDMigrations() CRDMigrationInformer
 }
 
 type version struct {
@@ -43,3 +45,8 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 func (v *version) Addons() AddonInformer {
 	return &addonInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// CRDMigrations returns a CRDMigrationInformer.
+func (v *version) CRDMigrations() CRDMigrationInformer {
+	return &cRDMigrationInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
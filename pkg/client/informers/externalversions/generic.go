@@ -66,20 +66,28 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().ClusterDefinitions().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("clusterversions"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().ClusterVersions().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("compliancepolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().CompliancePolicies().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("components"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().Components().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("componentclassdefinitions"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().ComponentClassDefinitions().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("componentdefinitions"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().ComponentDefinitions().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("componentversions"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().ComponentVersions().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("configconstraints"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().ConfigConstraints().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("opsbatches"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().OpsBatches().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("opsdefinitions"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().OpsDefinitions().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("opsrequests"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().OpsRequests().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("servicedescriptors"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().ServiceDescriptors().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("validationpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().ValidationPolicies().Informer()}, nil
 
 		// Group=apps.kubeblocks.io, Version=v1beta1
 	case v1beta1.SchemeGroupVersion.WithResource("configconstraints"):
@@ -104,6 +112,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		// Group=extensions.kubeblocks.io, Version=v1alpha1
 	case extensionsv1alpha1.SchemeGroupVersion.WithResource("addons"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Extensions().V1alpha1().Addons().Informer()}, nil
+	case extensionsv1alpha1.SchemeGroupVersion.WithResource("crdmigrations"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Extensions().V1alpha1().CRDMigrations().Informer()}, nil
 
 		// Group=storage.kubeblocks.io, Version=v1alpha1
 	case storagev1alpha1.SchemeGroupVersion.WithResource("storageproviders"):
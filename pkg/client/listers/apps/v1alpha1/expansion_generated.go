@@ -38,6 +38,10 @@ type ClusterDefinitionListerExpansion interface{}
 // ClusterVersionLister.
 type ClusterVersionListerExpansion interface{}
 
+// CompliancePolicyListerExpansion allows custom methods to be added to
+// CompliancePolicyLister.
+type CompliancePolicyListerExpansion interface{}
+
 // ComponentListerExpansion allows custom methods to be added to
 // ComponentLister.
 type ComponentListerExpansion interface{}
@@ -46,6 +50,10 @@ type ComponentListerExpansion interface{}
 // ComponentNamespaceLister.
 type ComponentNamespaceListerExpansion interface{}
 
+// ComponentClassDefinitionListerExpansion allows custom methods to be added to
+// ComponentClassDefinitionLister.
+type ComponentClassDefinitionListerExpansion interface{}
+
 // ComponentDefinitionListerExpansion allows custom methods to be added to
 // ComponentDefinitionLister.
 type ComponentDefinitionListerExpansion interface{}
@@ -58,6 +66,14 @@ type ComponentVersionListerExpansion interface{}
 // ConfigConstraintLister.
 type ConfigConstraintListerExpansion interface{}
 
+// OpsBatchListerExpansion allows custom methods to be added to
+// OpsBatchLister.
+type OpsBatchListerExpansion interface{}
+
+// OpsBatchNamespaceListerExpansion allows custom methods to be added to
+// OpsBatchNamespaceLister.
+type OpsBatchNamespaceListerExpansion interface{}
+
 // OpsDefinitionListerExpansion allows custom methods to be added to
 // OpsDefinitionLister.
 type OpsDefinitionListerExpansion interface{}
@@ -77,3 +93,7 @@ type ServiceDescriptorListerExpansion interface{}
 // ServiceDescriptorNamespaceListerExpansion allows custom methods to be added to
 // ServiceDescriptorNamespaceLister.
 type ServiceDescriptorNamespaceListerExpansion interface{}
+
+// ValidationPolicyListerExpansion allows custom methods to be added to
+// ValidationPolicyLister.
+type ValidationPolicyListerExpansion interface{}
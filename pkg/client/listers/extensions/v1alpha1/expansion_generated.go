@@ -21,3 +21,7 @@ package v1alpha1
 // AddonListerExpansion allows custom methods to be added to
 // AddonLister.
 type AddonListerExpansion interface{}
+
+// CRDMigrationListerExpansion allows custom methods to be added to
+// CRDMigrationLister.
+type CRDMigrationListerExpansion interface{}
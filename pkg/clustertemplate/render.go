@@ -0,0 +1,154 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clustertemplate
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Render renders the objects of the template with the values of the parameters, the parameters without values
+// take their default values, the values of the required parameters must be given.
+//
+// A field referencing a single parameter takes the typed value of the parameter, i.e. an integer parameter is
+// rendered as a number, and the parameters referenced in part of a string are substituted in the string.
+func (t *Template) Render(values map[string]string) ([]*unstructured.Unstructured, error) {
+	resolved, err := t.resolveValues(values)
+	if err != nil {
+		return nil, err
+	}
+	objs := make([]*unstructured.Unstructured, 0, len(t.Objects))
+	for i, obj := range t.Objects {
+		rendered, err := renderValue(obj, resolved)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render object %d: %w", i, err)
+		}
+		objs = append(objs, &unstructured.Unstructured{Object: rendered.(map[string]any)})
+	}
+	return objs, nil
+}
+
+// resolveValues resolves the values of the parameters into their typed values.
+func (t *Template) resolveValues(values map[string]string) (map[string]any, error) {
+	params := map[string]Parameter{}
+	for _, param := range t.Parameters {
+		params[param.Name] = param
+	}
+	var unknown []string
+	for name := range values {
+		if _, ok := params[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown parameters: %s", strings.Join(unknown, ","))
+	}
+	resolved := map[string]any{}
+	for _, param := range t.Parameters {
+		value, ok := values[param.Name]
+		if !ok {
+			value = param.Value
+		}
+		if value == "" {
+			if param.Required {
+				return nil, fmt.Errorf("the value of the parameter %s is required", param.Name)
+			}
+			resolved[param.Name] = value
+			continue
+		}
+		switch param.Type {
+		case ParameterTypeInteger:
+			i, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("the value of the parameter %s is not an integer: %s", param.Name, value)
+			}
+			resolved[param.Name] = i
+		case ParameterTypeQuantity:
+			if _, err := resource.ParseQuantity(value); err != nil {
+				return nil, fmt.Errorf("the value of the parameter %s is not a quantity: %s", param.Name, value)
+			}
+			resolved[param.Name] = value
+		default:
+			resolved[param.Name] = value
+		}
+	}
+	return resolved, nil
+}
+
+func renderValue(value any, values map[string]any) (any, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			rendered, err := renderValue(item, values)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = rendered
+		}
+		return out, nil
+	case []any:
+		out := make([]any, 0, len(v))
+		for _, item := range v {
+			rendered, err := renderValue(item, values)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, rendered)
+		}
+		return out, nil
+	case string:
+		return renderString(v, values)
+	default:
+		return v, nil
+	}
+}
+
+func renderString(s string, values map[string]any) (any, error) {
+	matches := placeholderRegex.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s, nil
+	}
+	if len(matches) == 1 && matches[0][0] == 0 && matches[0][1] == len(s) {
+		name := s[matches[0][2]:matches[0][3]]
+		value, ok := values[name]
+		if !ok {
+			return nil, fmt.Errorf("undefined parameter %s", name)
+		}
+		return value, nil
+	}
+	var err error
+	rendered := placeholderRegex.ReplaceAllStringFunc(s, func(m string) string {
+		name := placeholderRegex.FindStringSubmatch(m)[1]
+		value, ok := values[name]
+		if !ok {
+			err = fmt.Errorf("undefined parameter %s", name)
+			return m
+		}
+		return fmt.Sprint(value)
+	})
+	return rendered, err
+}
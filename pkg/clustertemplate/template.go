@@ -0,0 +1,372 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package clustertemplate exports a running Cluster into a reusable template, in which the sizes, storage classes,
+// configuration parameters and credentials are parameters, and renders the template into manifests.
+package clustertemplate
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	cfgcore "github.com/apecloud/kubeblocks/pkg/configuration/core"
+)
+
+// ParameterType is the type of the value of a Parameter.
+type ParameterType string
+
+const (
+	ParameterTypeString   ParameterType = "string"
+	ParameterTypeInteger  ParameterType = "integer"
+	ParameterTypeQuantity ParameterType = "quantity"
+)
+
+// ParamClusterName is the parameter of the name of the Cluster rendered from the template.
+const ParamClusterName = "CLUSTER_NAME"
+
+// reconfigureDeadlineSeconds is the pre-condition deadline of the exported Reconfigure OpsRequests,
+// which are applied together with the Cluster and wait for the Cluster to be created.
+const reconfigureDeadlineSeconds = 3600
+
+var (
+	placeholderRegex = regexp.MustCompile(`\$\{([A-Z0-9_]+)\}`)
+	credentialRegex  = regexp.MustCompile(`(?i)(password|passwd|secret|token|credential|private_?key|access_?key)`)
+)
+
+// Parameter is a parameter of the Template, it is referenced as ${NAME} in the objects.
+type Parameter struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description,omitempty"`
+	Type        ParameterType `json:"type"`
+	// Value is the default value of the parameter, which is taken from the source Cluster.
+	Value string `json:"value,omitempty"`
+	// Required indicates the value must be given when the template is rendered.
+	Required bool `json:"required,omitempty"`
+}
+
+// Template is a parameterized Cluster, with the Reconfigure OpsRequests to apply its configuration parameters.
+type Template struct {
+	Parameters []Parameter      `json:"parameters"`
+	Objects    []map[string]any `json:"objects"`
+}
+
+// Export exports the Cluster into a Template, the configurations are the Configurations of its Components,
+// from which the updated parameters are exported.
+//
+// The status, the generated metadata and the labels and annotations managed by KubeBlocks and Kubernetes are stripped,
+// the name of the Cluster, the replicas, resources, storage sizes and storage classes of the Components and
+// the updated configuration parameters are exported as parameters defaulting to the values of the source Cluster.
+// The credentials, i.e. the secrets referenced by the system accounts and the env values which look like credentials,
+// are never exported, the env values are exported as required parameters instead.
+func Export(cluster *appsv1alpha1.Cluster, configurations []appsv1alpha1.Configuration) (*Template, error) {
+	e := &exporter{}
+	obj, err := e.exportCluster(cluster)
+	if err != nil {
+		return nil, err
+	}
+	tpl := &Template{Objects: []map[string]any{obj}}
+	for i := range configurations {
+		config := &configurations[i]
+		if config.Spec.ClusterRef != cluster.Name {
+			continue
+		}
+		items := cfgcore.ExportConfigParameters(config)
+		if len(items) == 0 {
+			continue
+		}
+		ops, err := e.exportReconfigure(config, items)
+		if err != nil {
+			return nil, err
+		}
+		tpl.Objects = append(tpl.Objects, ops)
+	}
+	tpl.Parameters = e.params
+	return tpl, nil
+}
+
+type exporter struct {
+	params []Parameter
+	paths  []parameterPath
+}
+
+// parameterPath is the path of a field in the object, which is replaced with a parameter.
+type parameterPath struct {
+	path  []any
+	param string
+}
+
+func (e *exporter) addParam(param Parameter, path ...any) {
+	e.params = append(e.params, param)
+	e.paths = append(e.paths, parameterPath{path: path, param: param.Name})
+}
+
+// applyParams replaces the fields of the object with the placeholders of the parameters added since the index.
+func (e *exporter) applyParams(obj map[string]any, since int) error {
+	for _, p := range e.paths[since:] {
+		if err := setField(obj, placeholder(p.param), p.path...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *exporter) exportCluster(source *appsv1alpha1.Cluster) (map[string]any, error) {
+	cluster := &appsv1alpha1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1alpha1.GroupVersion.String(),
+			Kind:       appsv1alpha1.ClusterKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        placeholder(ParamClusterName),
+			Labels:      sanitizeMetadata(source.Labels),
+			Annotations: sanitizeMetadata(source.Annotations),
+		},
+		Spec: *source.Spec.DeepCopy(),
+	}
+	since := len(e.paths)
+	e.params = append(e.params, Parameter{
+		Name:        ParamClusterName,
+		Description: "The name of the Cluster",
+		Type:        ParameterTypeString,
+		Required:    true,
+	})
+	e.exportClusterSizes(cluster)
+	for i := range cluster.Spec.ComponentSpecs {
+		e.exportComponent(&cluster.Spec.ComponentSpecs[i], cluster.Spec.ComponentSpecs[i].Name, "spec", "componentSpecs", i)
+	}
+	for i := range cluster.Spec.ShardingSpecs {
+		sharding := &cluster.Spec.ShardingSpecs[i]
+		e.addParam(Parameter{
+			Name:        paramName(sharding.Name, "SHARDS"),
+			Description: fmt.Sprintf("The number of the shards of the sharding %s", sharding.Name),
+			Type:        ParameterTypeInteger,
+			Value:       strconv.Itoa(int(sharding.Shards)),
+		}, "spec", "shardingSpecs", i, "shards")
+		e.exportComponent(&sharding.Template, sharding.Name, "spec", "shardingSpecs", i, "template")
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cluster)
+	if err != nil {
+		return nil, err
+	}
+	delete(obj, "status")
+	delete(obj["metadata"].(map[string]any), "creationTimestamp")
+	if err = e.applyParams(obj, since); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// exportClusterSizes exports the sizes of the Cluster specified by the simplified API.
+func (e *exporter) exportClusterSizes(cluster *appsv1alpha1.Cluster) {
+	if cluster.Spec.Replicas != nil {
+		e.addParam(Parameter{
+			Name:        "REPLICAS",
+			Description: "The replicas of the Cluster",
+			Type:        ParameterTypeInteger,
+			Value:       strconv.Itoa(int(*cluster.Spec.Replicas)),
+		}, "spec", "replicas")
+	}
+	e.exportQuantity(cluster.Spec.Resources.CPU, "CPU", "The CPU of the Cluster", "spec", "resources", "cpu")
+	e.exportQuantity(cluster.Spec.Resources.Memory, "MEMORY", "The memory of the Cluster", "spec", "resources", "memory")
+	e.exportQuantity(cluster.Spec.Storage.Size, "STORAGE", "The storage size of the Cluster", "spec", "storage", "size")
+}
+
+// exportComponent exports the parameters of the Component and strips its credentials, prefix is the path of the Component spec.
+func (e *exporter) exportComponent(comp *appsv1alpha1.ClusterComponentSpec, name string, prefix ...any) {
+	at := func(path ...any) []any {
+		return append(append([]any{}, prefix...), path...)
+	}
+	e.addParam(Parameter{
+		Name:        paramName(name, "REPLICAS"),
+		Description: fmt.Sprintf("The replicas of the component %s", name),
+		Type:        ParameterTypeInteger,
+		Value:       strconv.Itoa(int(comp.Replicas)),
+	}, at("replicas")...)
+	for _, kind := range []string{"requests", "limits"} {
+		resources := comp.Resources.Requests
+		if kind == "limits" {
+			resources = comp.Resources.Limits
+		}
+		for _, res := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			if quantity, ok := resources[res]; ok {
+				e.exportQuantity(quantity, paramName(name, string(res), strings.TrimSuffix(kind, "s")),
+					fmt.Sprintf("The %s %s of the component %s", res, strings.TrimSuffix(kind, "s"), name),
+					at("resources", kind, string(res))...)
+			}
+		}
+	}
+	for j, vct := range comp.VolumeClaimTemplates {
+		if quantity, ok := vct.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+			e.exportQuantity(quantity, paramName(name, vct.Name, "STORAGE"),
+				fmt.Sprintf("The storage size of the volume %s of the component %s", vct.Name, name),
+				at("volumeClaimTemplates", j, "spec", "resources", "requests", "storage")...)
+		}
+		if vct.Spec.StorageClassName != nil && *vct.Spec.StorageClassName != "" {
+			e.addParam(Parameter{
+				Name:        paramName(name, vct.Name, "STORAGE_CLASS"),
+				Description: fmt.Sprintf("The storage class of the volume %s of the component %s", vct.Name, name),
+				Type:        ParameterTypeString,
+				Value:       *vct.Spec.StorageClassName,
+			}, at("volumeClaimTemplates", j, "spec", "storageClassName")...)
+		}
+	}
+	for j, env := range comp.Env {
+		if env.Value == "" || !credentialRegex.MatchString(env.Name) {
+			continue
+		}
+		e.addParam(Parameter{
+			Name:        paramName(name, env.Name),
+			Description: fmt.Sprintf("The env %s of the component %s", env.Name, name),
+			Type:        ParameterTypeString,
+			Required:    true,
+		}, at("env", j, "value")...)
+	}
+	// the secrets referenced by the system accounts are specific to the source Cluster, and the passwords are
+	// generated for the new Cluster by the password config instead.
+	for j := range comp.SystemAccounts {
+		comp.SystemAccounts[j].SecretRef = nil
+	}
+}
+
+func (e *exporter) exportQuantity(quantity resource.Quantity, name, description string, path ...any) {
+	if quantity.IsZero() {
+		return
+	}
+	e.addParam(Parameter{
+		Name:        name,
+		Description: description,
+		Type:        ParameterTypeQuantity,
+		Value:       quantity.String(),
+	}, path...)
+}
+
+// exportReconfigure exports the updated configuration parameters of a Component as a Reconfigure OpsRequest
+// of the rendered Cluster, in which the parameter values are parameters.
+func (e *exporter) exportReconfigure(config *appsv1alpha1.Configuration, items []appsv1alpha1.ConfigurationItem) (map[string]any, error) {
+	since := len(e.paths)
+	ops, err := cfgcore.BuildReconfigureOpsRequest(config, items, "", placeholder(ParamClusterName), "")
+	if err != nil {
+		return nil, err
+	}
+	ops.Namespace = ""
+	ops.Annotations = sanitizeMetadata(ops.Annotations)
+	ops.Spec.PreConditionDeadlineSeconds = pointer.Int32(reconfigureDeadlineSeconds)
+	for i, item := range items {
+		for j, key := range item.Keys {
+			for k, param := range key.Parameters {
+				if param.Value == nil {
+					continue
+				}
+				e.addParam(Parameter{
+					Name: paramName(config.Spec.ComponentName, item.Name, param.Key),
+					Description: fmt.Sprintf("The parameter %s of the config %s of the component %s",
+						param.Key, key.Key, config.Spec.ComponentName),
+					Type:  ParameterTypeString,
+					Value: *param.Value,
+				}, "spec", "reconfigures", 0, "configurations", i, "keys", j, "parameters", k, "value")
+			}
+		}
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(ops)
+	if err != nil {
+		return nil, err
+	}
+	delete(obj, "status")
+	delete(obj["metadata"].(map[string]any), "creationTimestamp")
+	if err = e.applyParams(obj, since); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// sanitizeMetadata strips the labels or annotations managed by KubeBlocks and Kubernetes.
+func sanitizeMetadata(m map[string]string) map[string]string {
+	var out map[string]string
+	for key, value := range m {
+		if i := strings.Index(key, "/"); i >= 0 {
+			domain := key[:i]
+			if strings.HasSuffix(domain, "kubeblocks.io") || strings.HasSuffix(domain, "kubernetes.io") || strings.HasSuffix(domain, "k8s.io") {
+				continue
+			}
+		}
+		if out == nil {
+			out = map[string]string{}
+		}
+		out[key] = value
+	}
+	return out
+}
+
+func placeholder(name string) string {
+	return "${" + name + "}"
+}
+
+// paramName builds the name of a parameter from the parts, in upper case with the non-alphanumeric characters replaced with '_'.
+func paramName(parts ...string) string {
+	name := strings.ToUpper(strings.Join(parts, "_"))
+	return strings.Map(func(r rune) rune {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// setField sets the field of the unstructured object, the path consists of map keys and slice indexes.
+func setField(obj map[string]any, value any, path ...any) error {
+	var cur any = obj
+	for i, p := range path {
+		last := i == len(path)-1
+		switch key := p.(type) {
+		case string:
+			m, ok := cur.(map[string]any)
+			if !ok {
+				return fmt.Errorf("field %v is not an object", path[:i])
+			}
+			if last {
+				m[key] = value
+				return nil
+			}
+			if _, ok = m[key]; !ok {
+				m[key] = map[string]any{}
+			}
+			cur = m[key]
+		case int:
+			s, ok := cur.([]any)
+			if !ok || key >= len(s) {
+				return fmt.Errorf("field %v has no item %d", path[:i], key)
+			}
+			if last {
+				s[key] = value
+				return nil
+			}
+			cur = s[key]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package clustertemplate
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/yaml"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func newTestCluster() *appsv1alpha1.Cluster {
+	return &appsv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "prod",
+			Name:            "orders",
+			UID:             "8f3c0d9e",
+			ResourceVersion: "1024",
+			Finalizers:      []string{constant.DBClusterFinalizerName},
+			Labels: map[string]string{
+				constant.AppInstanceLabelKey: "orders",
+				"team":                       "payments",
+			},
+			Annotations: map[string]string{
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+				"apps.kubeblocks.io/ops-request":                   "[]",
+			},
+		},
+		Spec: appsv1alpha1.ClusterSpec{
+			ClusterDefRef:     "apecloud-mysql",
+			TerminationPolicy: appsv1alpha1.WipeOut,
+			ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{{
+				Name:            "mysql",
+				ComponentDefRef: "mysql",
+				Replicas:        3,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+					Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("4Gi")},
+				},
+				VolumeClaimTemplates: []appsv1alpha1.ClusterComponentVolumeClaimTemplate{{
+					Name: "data",
+					Spec: appsv1alpha1.PersistentVolumeClaimSpec{
+						StorageClassName: pointer.String("ssd"),
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("20Gi")},
+						},
+					},
+				}},
+				Env: []corev1.EnvVar{
+					{Name: "TZ", Value: "UTC"},
+					{Name: "ADMIN_PASSWORD", Value: "s3cr3t"},
+				},
+				SystemAccounts: []appsv1alpha1.ComponentSystemAccount{{
+					Name:      "root",
+					SecretRef: &appsv1alpha1.ProvisionSecretRef{Name: "orders-root", Namespace: "prod"},
+				}},
+			}},
+		},
+		Status: appsv1alpha1.ClusterStatus{Phase: appsv1alpha1.RunningClusterPhase},
+	}
+}
+
+func newTestConfiguration() appsv1alpha1.Configuration {
+	return appsv1alpha1.Configuration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "orders-mysql"},
+		Spec: appsv1alpha1.ConfigurationSpec{
+			ClusterRef:    "orders",
+			ComponentName: "mysql",
+			ConfigItemDetails: []appsv1alpha1.ConfigurationItemDetail{{
+				Name: "mysql-config",
+				ConfigFileParams: map[string]appsv1alpha1.ConfigParams{
+					"my.cnf": {Parameters: map[string]*string{"max_connections": pointer.String("1000")}},
+				},
+			}},
+		},
+	}
+}
+
+func TestExport(t *testing.T) {
+	tpl, err := Export(newTestCluster(), []appsv1alpha1.Configuration{newTestConfiguration()})
+	if err != nil {
+		t.Fatalf("failed to export: %v", err)
+	}
+	expected := map[string]string{
+		ParamClusterName:                     "",
+		"MYSQL_REPLICAS":                     "3",
+		"MYSQL_CPU_REQUEST":                  "500m",
+		"MYSQL_CPU_LIMIT":                    "2",
+		"MYSQL_MEMORY_LIMIT":                 "4Gi",
+		"MYSQL_DATA_STORAGE":                 "20Gi",
+		"MYSQL_DATA_STORAGE_CLASS":           "ssd",
+		"MYSQL_ADMIN_PASSWORD":               "",
+		"MYSQL_MYSQL_CONFIG_MAX_CONNECTIONS": "1000",
+	}
+	if len(tpl.Parameters) != len(expected) {
+		t.Fatalf("expected %d parameters, got %v", len(expected), tpl.Parameters)
+	}
+	for _, param := range tpl.Parameters {
+		value, ok := expected[param.Name]
+		if !ok || value != param.Value {
+			t.Errorf("unexpected parameter %s=%q", param.Name, param.Value)
+		}
+	}
+	if len(tpl.Objects) != 2 {
+		t.Fatalf("expected the cluster and a reconfigure ops, got %d objects", len(tpl.Objects))
+	}
+
+	data, err := yaml.Marshal(tpl)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	for _, leaked := range []string{"s3cr3t", "orders-root", "8f3c0d9e", "1024", "prod", "last-applied", "ops-request"} {
+		if strings.Contains(string(data), leaked) {
+			t.Errorf("the template should not contain %q:\n%s", leaked, data)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	tpl, err := Export(newTestCluster(), []appsv1alpha1.Configuration{newTestConfiguration()})
+	if err != nil {
+		t.Fatalf("failed to export: %v", err)
+	}
+	// round trip the template as it is stored in git
+	data, err := yaml.Marshal(tpl)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	tpl = &Template{}
+	if err = yaml.Unmarshal(data, tpl); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if _, err = tpl.Render(map[string]string{ParamClusterName: "orders-staging"}); err == nil {
+		t.Error("expected error for the missing credential")
+	}
+	if _, err = tpl.Render(map[string]string{ParamClusterName: "orders-staging", "MYSQL_ADMIN_PASSWORD": "p", "UNKNOWN": "1"}); err == nil {
+		t.Error("expected error for the unknown parameter")
+	}
+	if _, err = tpl.Render(map[string]string{ParamClusterName: "orders-staging", "MYSQL_ADMIN_PASSWORD": "p", "MYSQL_REPLICAS": "three"}); err == nil {
+		t.Error("expected error for the invalid integer")
+	}
+
+	objs, err := tpl.Render(map[string]string{
+		ParamClusterName:       "orders-staging",
+		"MYSQL_ADMIN_PASSWORD": "p",
+		"MYSQL_REPLICAS":       "1",
+		"MYSQL_DATA_STORAGE":   "5Gi",
+	})
+	if err != nil {
+		t.Fatalf("failed to render: %v", err)
+	}
+	cluster := &appsv1alpha1.Cluster{}
+	if err = fromUnstructured(objs[0], cluster); err != nil {
+		t.Fatalf("failed to convert the cluster: %v", err)
+	}
+	comp := cluster.Spec.ComponentSpecs[0]
+	switch {
+	case cluster.Name != "orders-staging":
+		t.Errorf("unexpected cluster name %s", cluster.Name)
+	case cluster.Labels["team"] != "payments" || len(cluster.Labels) != 1:
+		t.Errorf("unexpected labels %v", cluster.Labels)
+	case comp.Replicas != 1:
+		t.Errorf("unexpected replicas %d", comp.Replicas)
+	case !comp.VolumeClaimTemplates[0].Spec.Resources.Requests.Storage().Equal(resource.MustParse("5Gi")):
+		t.Errorf("unexpected storage %v", comp.VolumeClaimTemplates[0].Spec.Resources.Requests)
+	case *comp.VolumeClaimTemplates[0].Spec.StorageClassName != "ssd":
+		t.Errorf("unexpected storage class %s", *comp.VolumeClaimTemplates[0].Spec.StorageClassName)
+	case comp.Env[1].Value != "p":
+		t.Errorf("unexpected env %v", comp.Env)
+	case comp.SystemAccounts[0].SecretRef != nil:
+		t.Errorf("unexpected secret ref %v", comp.SystemAccounts[0].SecretRef)
+	}
+
+	ops := &appsv1alpha1.OpsRequest{}
+	if err = fromUnstructured(objs[1], ops); err != nil {
+		t.Fatalf("failed to convert the ops: %v", err)
+	}
+	if ops.Spec.ClusterName != "orders-staging" || ops.GenerateName != "orders-staging-reconfigure-" {
+		t.Errorf("unexpected ops %s for cluster %s", ops.GenerateName, ops.Spec.ClusterName)
+	}
+	if value := ops.Spec.Reconfigures[0].Configurations[0].Keys[0].Parameters[0].Value; value == nil || *value != "1000" {
+		t.Errorf("unexpected parameter value %v", value)
+	}
+}
+
+func fromUnstructured(obj *unstructured.Unstructured, into any) error {
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, into)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+Package conditions provides the helpers to maintain the status conditions and to compute the phases,
+which are shared by the controllers of KubeBlocks to keep the status transitions consistent.
+*/
+package conditions
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Set sets the condition to the conditions and returns whether the conditions are changed.
+// The LastTransitionTime is kept if the status of the condition is not changed,
+// so that repeated reconciliations will not refresh it.
+func Set(conditions *[]metav1.Condition, condition metav1.Condition) bool {
+	if conditions == nil {
+		return false
+	}
+	var oldCondition *metav1.Condition
+	if c := meta.FindStatusCondition(*conditions, condition.Type); c != nil {
+		oldCondition = c.DeepCopy()
+	}
+	meta.SetStatusCondition(conditions, condition)
+	return oldCondition == nil || !reflect.DeepEqual(*oldCondition, *meta.FindStatusCondition(*conditions, condition.Type))
+}
+
+// Merge sets all the conditions to the dst conditions and returns whether the dst conditions are changed.
+func Merge(dst *[]metav1.Condition, conditions ...metav1.Condition) bool {
+	changed := false
+	for _, condition := range conditions {
+		if Set(dst, condition) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// Remove removes the condition with the conditionType and returns whether the conditions are changed.
+func Remove(conditions *[]metav1.Condition, conditionType string) bool {
+	if conditions == nil || meta.FindStatusCondition(*conditions, conditionType) == nil {
+		return false
+	}
+	meta.RemoveStatusCondition(conditions, conditionType)
+	return true
+}
+
+// Get returns the condition with the conditionType, or nil if not found.
+func Get(conditions []metav1.Condition, conditionType string) *metav1.Condition {
+	return meta.FindStatusCondition(conditions, conditionType)
+}
+
+// IsTrue checks if the status of the condition with the conditionType is True.
+func IsTrue(conditions []metav1.Condition, conditionType string) bool {
+	return meta.IsStatusConditionTrue(conditions, conditionType)
+}
+
+// IsFalse checks if the status of the condition with the conditionType is False.
+func IsFalse(conditions []metav1.Condition, conditionType string) bool {
+	return meta.IsStatusConditionFalse(conditions, conditionType)
+}
@@ -0,0 +1,70 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package conditions
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSet(t *testing.T) {
+	var conditions []metav1.Condition
+	lastTransitionTime := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	if !Set(&conditions, metav1.Condition{Type: "Ready", Status: metav1.ConditionFalse, Reason: "NotReady", LastTransitionTime: lastTransitionTime}) {
+		t.Error("expect the conditions are changed when adding a condition")
+	}
+	if Set(&conditions, metav1.Condition{Type: "Ready", Status: metav1.ConditionFalse, Reason: "NotReady", LastTransitionTime: metav1.Now()}) {
+		t.Error("expect the conditions are not changed when setting the same condition")
+	}
+	if !Get(conditions, "Ready").LastTransitionTime.Equal(&lastTransitionTime) {
+		t.Error("expect the last transition time is kept when the status is not changed")
+	}
+	if !Set(&conditions, metav1.Condition{Type: "Ready", Status: metav1.ConditionFalse, Reason: "PodsNotReady"}) {
+		t.Error("expect the conditions are changed when the reason is changed")
+	}
+	if !Set(&conditions, metav1.Condition{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Ready"}) {
+		t.Error("expect the conditions are changed when the status is changed")
+	}
+	if !IsTrue(conditions, "Ready") || IsFalse(conditions, "Ready") || Get(conditions, "Ready").LastTransitionTime.Equal(&lastTransitionTime) {
+		t.Errorf("unexpected conditions: %v", conditions)
+	}
+}
+
+func TestMergeAndRemove(t *testing.T) {
+	var conditions []metav1.Condition
+	if !Merge(&conditions, metav1.Condition{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Ready"},
+		metav1.Condition{Type: "Available", Status: metav1.ConditionFalse, Reason: "NotAvailable"}) {
+		t.Error("expect the conditions are changed")
+	}
+	if len(conditions) != 2 || !IsFalse(conditions, "Available") {
+		t.Errorf("unexpected conditions: %v", conditions)
+	}
+	if Merge(&conditions, metav1.Condition{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Ready"}) {
+		t.Error("expect the conditions are not changed")
+	}
+	if !Remove(&conditions, "Available") || Remove(&conditions, "Available") {
+		t.Error("unexpected result of removing the condition")
+	}
+	if len(conditions) != 1 || Get(conditions, "Available") != nil {
+		t.Errorf("unexpected conditions: %v", conditions)
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package conditions
+
+import (
+	"golang.org/x/exp/slices"
+)
+
+// PhaseRule transits the status to the Phase when the rule is matched.
+type PhaseRule[P ~string] struct {
+	Phase P
+	Match bool
+}
+
+// Rule creates a PhaseRule.
+func Rule[P ~string](phase P, match bool) PhaseRule[P] {
+	return PhaseRule[P]{Phase: phase, Match: match}
+}
+
+// ComputePhase returns the phase of the first matched rule, and false if no rule is matched.
+// The rules are evaluated in order, so the higher priority rules should be placed ahead.
+func ComputePhase[P ~string](rules ...PhaseRule[P]) (P, bool) {
+	for _, rule := range rules {
+		if rule.Match {
+			return rule.Phase, true
+		}
+	}
+	return "", false
+}
+
+// AllIn checks if all the phases are one of the expected phases, it returns true if phases is empty.
+func AllIn[P ~string](phases []P, expected ...P) bool {
+	for _, phase := range phases {
+		if !slices.Contains(expected, phase) {
+			return false
+		}
+	}
+	return true
+}
+
+// AnyIn checks if any of the phases is one of the expected phases.
+func AnyIn[P ~string](phases []P, expected ...P) bool {
+	for _, phase := range phases {
+		if slices.Contains(expected, phase) {
+			return true
+		}
+	}
+	return false
+}
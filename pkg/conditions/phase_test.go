@@ -0,0 +1,59 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package conditions
+
+import (
+	"testing"
+)
+
+type testPhase string
+
+func TestComputePhase(t *testing.T) {
+	phases := []testPhase{"Running", "Failed"}
+	rules := func(phases []testPhase) []PhaseRule[testPhase] {
+		return []PhaseRule[testPhase]{
+			Rule[testPhase]("Running", AllIn(phases, "Running")),
+			Rule[testPhase]("Failed", AllIn(phases, "Failed")),
+			Rule[testPhase]("Abnormal", AnyIn(phases, "Abnormal", "Failed")),
+		}
+	}
+	if phase, ok := ComputePhase(rules(phases)...); !ok || phase != "Abnormal" {
+		t.Errorf("expect phase Abnormal, but got: %s", phase)
+	}
+	if phase, ok := ComputePhase(rules([]testPhase{"Failed", "Failed"})...); !ok || phase != "Failed" {
+		t.Errorf("expect phase Failed, but got: %s", phase)
+	}
+	if phase, ok := ComputePhase(rules([]testPhase{"Creating"})...); ok {
+		t.Errorf("expect no phase is matched, but got: %s", phase)
+	}
+}
+
+func TestAllInAndAnyIn(t *testing.T) {
+	if !AllIn([]testPhase{}, "Running") || AnyIn([]testPhase{}, "Running") {
+		t.Error("unexpected result for empty phases")
+	}
+	phases := []testPhase{"Running", "Updating"}
+	if AllIn(phases, "Running") || !AllIn(phases, "Running", "Updating") {
+		t.Error("unexpected result of AllIn")
+	}
+	if !AnyIn(phases, "Updating", "Failed") || AnyIn(phases, "Failed") {
+		t.Error("unexpected result of AnyIn")
+	}
+}
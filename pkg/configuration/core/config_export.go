@@ -0,0 +1,133 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package core
+
+import (
+	"slices"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+// ExportConfigParameters exports the effective configuration of a Component, which is the difference from
+// the defaults of its configuration templates, as the ConfigurationItems of a Reconfigure operation.
+//
+// The difference is taken from the parameters and file contents recorded in the Configuration by the
+// previous Reconfigure operations. Parameters referencing Secrets are exported as references, the values are
+// never read. If configSpecs is not empty, only the configuration templates with these names are exported.
+func ExportConfigParameters(config *appsv1alpha1.Configuration, configSpecs ...string) []appsv1alpha1.ConfigurationItem {
+	var items []appsv1alpha1.ConfigurationItem
+	for _, detail := range config.Spec.ConfigItemDetails {
+		if len(configSpecs) > 0 && !slices.Contains(configSpecs, detail.Name) {
+			continue
+		}
+		keys := make([]appsv1alpha1.ParameterConfig, 0, len(detail.ConfigFileParams))
+		for _, key := range sortedKeys(detail.ConfigFileParams) {
+			if paramConfig := exportParameterConfig(key, detail.ConfigFileParams[key]); paramConfig != nil {
+				keys = append(keys, *paramConfig)
+			}
+		}
+		if len(keys) == 0 {
+			continue
+		}
+		items = append(items, appsv1alpha1.ConfigurationItem{
+			Name: detail.Name,
+			Keys: keys,
+		})
+	}
+	return items
+}
+
+// exportParameterConfig exports the updates of a configuration file, the parameters take precedence over the file content,
+// as a configuration file is updated by either of them, depending on whether it is constrained by a ConfigConstraint.
+func exportParameterConfig(key string, params appsv1alpha1.ConfigParams) *appsv1alpha1.ParameterConfig {
+	paramConfig := &appsv1alpha1.ParameterConfig{Key: key}
+	for _, name := range sortedKeys(params.Parameters) {
+		paramConfig.Parameters = append(paramConfig.Parameters, appsv1alpha1.ParameterPair{
+			Key:   name,
+			Value: params.Parameters[name],
+		})
+	}
+	for _, name := range sortedKeys(params.ParametersFrom) {
+		valueFrom := params.ParametersFrom[name]
+		paramConfig.Parameters = append(paramConfig.Parameters, appsv1alpha1.ParameterPair{
+			Key:       name,
+			ValueFrom: valueFrom.DeepCopy(),
+		})
+	}
+	switch {
+	case len(paramConfig.Parameters) > 0:
+		return paramConfig
+	case params.Content != nil:
+		paramConfig.FileContent = *params.Content
+		return paramConfig
+	default:
+		return nil
+	}
+}
+
+// BuildReconfigureOpsRequest builds a Reconfigure OpsRequest that applies the exported configuration items
+// to the Component of the target Cluster, the source of the configuration is recorded in the annotations for review.
+func BuildReconfigureOpsRequest(source *appsv1alpha1.Configuration,
+	items []appsv1alpha1.ConfigurationItem,
+	namespace, clusterName, componentName string) (*appsv1alpha1.OpsRequest, error) {
+	if len(items) == 0 {
+		return nil, MakeError("there is no updated parameter in the configuration of component[%s]", source.Spec.ComponentName)
+	}
+	if clusterName == "" {
+		return nil, MakeError("the name of the target cluster is required")
+	}
+	if componentName == "" {
+		componentName = source.Spec.ComponentName
+	}
+	return &appsv1alpha1.OpsRequest{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1alpha1.GroupVersion.String(),
+			Kind:       "OpsRequest",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: clusterName + "-reconfigure-",
+			Namespace:    namespace,
+			Annotations: map[string]string{
+				constant.ConfigExportedFromAnnotationKey: source.Namespace + "/" + source.Spec.ClusterRef + "/" + source.Spec.ComponentName,
+			},
+		},
+		Spec: appsv1alpha1.OpsRequestSpec{
+			ClusterName: clusterName,
+			Type:        appsv1alpha1.ReconfiguringType,
+			Reconfigures: []appsv1alpha1.Reconfigure{{
+				ComponentOps:   appsv1alpha1.ComponentOps{ComponentName: componentName},
+				Configurations: items,
+			}},
+		},
+	}, nil
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,94 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package core
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestExportConfigParameters(t *testing.T) {
+	content := "[mysqld]"
+	config := &appsv1alpha1.Configuration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "staging", Name: "mycluster-mysql"},
+		Spec: appsv1alpha1.ConfigurationSpec{
+			ClusterRef:    "mycluster",
+			ComponentName: "mysql",
+			ConfigItemDetails: []appsv1alpha1.ConfigurationItemDetail{{
+				Name: "mysql-config",
+				ConfigFileParams: map[string]appsv1alpha1.ConfigParams{
+					"my.cnf": {
+						Parameters: map[string]*string{"max_connections": pointer.String("1000"), "innodb_buffer_pool_size": nil},
+						ParametersFrom: map[string]appsv1alpha1.ParameterValueSource{
+							"admin_password": {SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "mysql-secret"},
+								Key:                  "password",
+							}},
+						},
+					},
+					"extra.cnf": {Content: &content},
+				},
+			}, {
+				Name: "agent-config",
+			}},
+		},
+	}
+
+	items := ExportConfigParameters(config)
+	if len(items) != 1 || items[0].Name != "mysql-config" || len(items[0].Keys) != 2 {
+		t.Fatalf("unexpected exported items: %+v", items)
+	}
+	if key := items[0].Keys[0]; key.Key != "extra.cnf" || key.FileContent != content || len(key.Parameters) != 0 {
+		t.Errorf("unexpected exported file content: %+v", key)
+	}
+	params := items[0].Keys[1].Parameters
+	if len(params) != 3 ||
+		params[0].Key != "innodb_buffer_pool_size" || params[0].Value != nil ||
+		params[1].Key != "max_connections" || *params[1].Value != "1000" ||
+		params[2].Key != "admin_password" || params[2].ValueFrom.SecretKeyRef.Name != "mysql-secret" {
+		t.Errorf("unexpected exported parameters: %+v", params)
+	}
+	if items = ExportConfigParameters(config, "agent-config"); len(items) != 0 {
+		t.Errorf("expect no item exported, got: %+v", items)
+	}
+
+	ops, err := BuildReconfigureOpsRequest(config, ExportConfigParameters(config), "production", "prod-cluster", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ops.Namespace != "production" || ops.Spec.ClusterName != "prod-cluster" || ops.Spec.Type != appsv1alpha1.ReconfiguringType {
+		t.Errorf("unexpected ops request: %+v", ops)
+	}
+	if len(ops.Spec.Reconfigures) != 1 || ops.Spec.Reconfigures[0].ComponentName != "mysql" {
+		t.Errorf("unexpected reconfigures: %+v", ops.Spec.Reconfigures)
+	}
+	if ops.Annotations[constant.ConfigExportedFromAnnotationKey] != "staging/mycluster/mysql" {
+		t.Errorf("unexpected annotations: %v", ops.Annotations)
+	}
+	if _, err = BuildReconfigureOpsRequest(config, nil, "production", "prod-cluster", ""); err == nil {
+		t.Error("expect error for no updated parameter")
+	}
+}
@@ -42,10 +42,23 @@ const (
 	KubeBlocksGenerationKey                  = "kubeblocks.io/generation"
 	ExtraEnvAnnotationKey                    = "kubeblocks.io/extra-env"
 	LastRoleSnapshotVersionAnnotationKey     = "apps.kubeblocks.io/last-role-snapshot-version"
-	ComponentScaleInAnnotationKey            = "apps.kubeblocks.io/component-scale-in" // ComponentScaleInAnnotationKey specifies whether the component is scaled in
+	LastRoleUpdateTimestampAnnotationKey     = "apps.kubeblocks.io/last-role-update-timestamp" // LastRoleUpdateTimestampAnnotationKey records when the role label of the pod is updated
+	ComponentScaleInAnnotationKey            = "apps.kubeblocks.io/component-scale-in"         // ComponentScaleInAnnotationKey specifies whether the component is scaled in
 	DisableHAAnnotationKey                   = "kubeblocks.io/disable-ha"
 	OpsDependentOnSuccessfulOpsAnnoKey       = "ops.kubeblocks.io/dependent-on-successful-ops" // OpsDependentOnSuccessfulOpsAnnoKey wait for the dependent ops to succeed before executing the current ops. If it fails, this ops will also fail.
 	RelatedOpsAnnotationKey                  = "ops.kubeblocks.io/related-ops"
+	OpsDefaultedFieldsAnnotationKey          = "ops.kubeblocks.io/defaulted-fields"           // OpsDefaultedFieldsAnnotationKey records the spec fields of the OpsRequest filled by the defaults of the Cluster
+	OpsDisruptedNodesAnnotationKey           = "ops.kubeblocks.io/disrupted-nodes"            // OpsDisruptedNodesAnnotationKey records the nodes and node pools disrupted by the running OpsRequest
+	TLSRotatedByOpsAnnotationKey             = "ops.kubeblocks.io/tls-rotated-by"             // TLSRotatedByOpsAnnotationKey records the RotateTLS OpsRequest which renewed the certificates in the secret
+	PendingSchedulingPolicyAnnotationKey     = "apps.kubeblocks.io/pending-scheduling-policy" // PendingSchedulingPolicyAnnotationKey saves the scheduling policy of the workload which is pending a restart
+	LegacyRevisionAnnotationKey              = "apps.kubeblocks.io/legacy-revision"           // LegacyRevisionAnnotationKey records the revision of the pod under the legacy workload it is migrated from
+	NodeSelectorOnceAnnotationKey            = "workloads.kubeblocks.io/node-selector-once"   // NodeSelectorOnceAnnotationKey specifies the node selectors of the InstanceSet pods to be recreated, keyed by the pod names
+	StorageClassAccessModesAnnotationKey     = "apps.kubeblocks.io/access-modes"              // StorageClassAccessModesAnnotationKey declares the access modes supported by the StorageClass, separated by commas
+	ForceRetryAnnotationKey                  = "apps.kubeblocks.io/force-retry"               // ForceRetryAnnotationKey changing its value, e.g. to the current time, resets the reconciliation backoff of the cluster and retries immediately
+	OpsTriggerAnnotationKeyPrefix            = "ops.kubeblocks.io/trigger-"                   // OpsTriggerAnnotationKeyPrefix the annotations of the Cluster with the prefix, e.g. "ops.kubeblocks.io/trigger-restart", are converted into OpsRequests once per value
+	TriggeredOpsAnnotationKey                = "ops.kubeblocks.io/triggered-ops"              // TriggeredOpsAnnotationKey records the hashes of the trigger annotations of the Cluster which have been converted into OpsRequests
+	OpsTriggeredByAnnotationKey              = "ops.kubeblocks.io/triggered-by"               // OpsTriggeredByAnnotationKey records the trigger annotation of the Cluster which the OpsRequest is created by
+	TaskJobSpecHashAnnotationKey             = "apps.kubeblocks.io/task-spec-hash"            // TaskJobSpecHashAnnotationKey records the hash of the spec of the Job of a run-to-completion component
 )
 
 // annotations for multi-cluster
@@ -57,6 +57,7 @@ const (
 	KBParameterUpdateSourceAnnotationKey        = "config.kubeblocks.io/reconfigure-source"
 	UpgradeRestartAnnotationKey                 = "config.kubeblocks.io/restart"
 	ConfigAppliedVersionAnnotationKey           = "config.kubeblocks.io/config-applied-version"
+	ConfigExportedFromAnnotationKey             = "config.kubeblocks.io/exported-from"
 )
 
 const (
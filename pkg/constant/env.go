@@ -104,6 +104,9 @@ const (
 	// KBEnvTTL controls the lease expiration time in DCS. If the leader fails to renew its lease within the TTL duration, it will lose the leader role, allowing other replicas to take over.
 	KBEnvTTL = "KB_TTL"
 
+	// KBEnvFencingStrategies defines the comma-separated strategies to verify that the former leader is fenced before promoting a new leader.
+	KBEnvFencingStrategies = "KB_FENCING_STRATEGIES"
+
 	// KBEnvMaxLag defines maximum replication lag permitted when performing a switchover.
 	KBEnvMaxLag = "KB_MAX_LAG"
 
@@ -26,4 +26,8 @@ const (
 	ConfigFinalizerName            = "config.kubeblocks.io/finalizer"
 	ServiceDescriptorFinalizerName = "servicedescriptor.kubeblocks.io/finalizer"
 	OpsRequestFinalizerName        = "opsrequest.kubeblocks.io/finalizer"
+
+	// ExternalResourceFinalizerName is added to the services provisioning external resources, e.g. cloud load balancers
+	// and DNS records, and is removed only after the external resources are released.
+	ExternalResourceFinalizerName = "cluster.kubeblocks.io/external-resource"
 )
@@ -39,6 +39,7 @@ const (
 	BackupProtectionLabelKey               = "kubeblocks.io/backup-protection" // BackupProtectionLabelKey Backup delete protection policy label
 	RoleLabelKey                           = "kubeblocks.io/role"              // RoleLabelKey consensusSet and replicationSet role label key
 	AccessModeLabelKey                     = "workloads.kubeblocks.io/access-mode"
+	UpdatedLabelKey                        = "workloads.kubeblocks.io/updated" // UpdatedLabelKey indicates whether the pod is updated to the latest revision
 	ReadyWithoutPrimaryKey                 = "kubeblocks.io/ready-without-primary"
 	VolumeTypeLabelKey                     = "kubeblocks.io/volume-type"
 	ClusterAccountLabelKey                 = "account.kubeblocks.io/name"
@@ -50,10 +51,12 @@ const (
 	KBManagedByKey                         = "apps.kubeblocks.io/managed-by"        // KBManagedByKey marks resources that auto created
 	PVCNameLabelKey                        = "apps.kubeblocks.io/pvc-name"
 	VolumeClaimTemplateNameLabelKey        = "apps.kubeblocks.io/vct-name"
+	SharedVolumeNameLabelKey               = "apps.kubeblocks.io/shared-volume-name"
 	KBAppComponentInstanceTemplateLabelKey = "apps.kubeblocks.io/instance-template"
 	KBAppServiceVersionKey                 = "apps.kubeblocks.io/service-version"
 	WorkloadTypeLabelKey                   = "apps.kubeblocks.io/workload-type"
 	KBAppPodNameLabelKey                   = "apps.kubeblocks.io/pod-name"
+	KBAppExportedComponentLabelKey         = "apps.kubeblocks.io/exported-component" // KBAppExportedComponentLabelKey marks the backups exporting the data of removed components
 	ClusterDefLabelKey                     = "clusterdefinition.kubeblocks.io/name"
 	ClusterVerLabelKey                     = "clusterversion.kubeblocks.io/name"
 	ComponentDefinitionLabelKey            = "componentdefinition.kubeblocks.io/name"
@@ -63,6 +66,7 @@ const (
 	OpsRequestTypeLabelKey                 = "ops.kubeblocks.io/ops-type"
 	OpsRequestNameLabelKey                 = "ops.kubeblocks.io/ops-name"
 	OpsRequestNamespaceLabelKey            = "ops.kubeblocks.io/ops-namespace"
+	OpsBatchNameLabelKey                   = "ops.kubeblocks.io/ops-batch-name"
 	ServiceDescriptorNameLabelKey          = "servicedescriptor.kubeblocks.io/name"
 )
 
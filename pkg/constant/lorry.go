@@ -43,17 +43,32 @@ const (
 
 // action keys
 const (
-	RoleProbeAction     = "roleProbe"
-	RebuildAction       = "rebuild"
-	HealthyCheckAction  = "healthyCheck"
-	MemberJoinAction    = "memberJoin"
-	MemberLeaveAction   = "memberLeave"
-	ReadonlyAction      = "readonly"
-	ReadWriteAction     = "readwrite"
-	PostProvisionAction = "postProvision"
-	PreTerminateAction  = "preTerminate"
-	DataDumpAction      = "dataDump"
-	DataLoadAction      = "dataLoad"
+	RoleProbeAction       = "roleProbe"
+	RebuildAction         = "rebuild"
+	HealthyCheckAction    = "healthyCheck"
+	MemberJoinAction      = "memberJoin"
+	MemberLeaveAction     = "memberLeave"
+	ReadonlyAction        = "readonly"
+	ReadWriteAction       = "readwrite"
+	PostProvisionAction   = "postProvision"
+	PreTerminateAction    = "preTerminate"
+	DataDumpAction        = "dataDump"
+	DataLoadAction        = "dataLoad"
+	ReloadTLSAction       = "reloadTLS"
+	FenceAction           = "fence"
+	PurgeAction           = "purge"
+	AccountRotationAction = "accountRotation"
+	DrainBackendAction    = "drainBackend"
+	ResumeBackendAction   = "resumeBackend"
+	UpgradePreCheckAction = "upgradePreCheck"
+	ShardRebalanceAction  = "shardRebalance"
+	WarmUpCheckAction     = "warmUpCheck"
+	ReplicationLagAction  = "replicationLag"
+
+	ReconfigureAction                = "reconfigure"
+	AccountProvisionAction           = "accountProvision"
+	SwitchoverWithCandidateAction    = "switchoverWithCandidate"
+	SwitchoverWithoutCandidateAction = "switchoverWithoutCandidate"
 )
 
 // action envs
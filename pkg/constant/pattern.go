@@ -29,6 +29,11 @@ func GenerateClusterComponentName(clusterName, compName string) string {
 	return fmt.Sprintf("%s-%s", clusterName, compName)
 }
 
+// GenerateSharedVolumeClaimName generates the name of the PVC shared by all the instances of the component.
+func GenerateSharedVolumeClaimName(clusterName, compName, volumeName string) string {
+	return fmt.Sprintf("%s-%s-%s", clusterName, compName, volumeName)
+}
+
 // GenerateAccountSecretName generates the secret name of system accounts.
 func GenerateAccountSecretName(clusterName, compName, name string) string {
 	replacedName := strings.ReplaceAll(name, "_", "-")
@@ -32,14 +32,30 @@ const (
 	CfgHostPortConfigMapName            = "HOST_PORT_CM_NAME"
 	CfgHostPortIncludeRanges            = "HOST_PORT_INCLUDE_RANGES"
 	CfgHostPortExcludeRanges            = "HOST_PORT_EXCLUDE_RANGES"
+	CfgKeyCapacityPreCheck              = "CAPACITY_PRE_CHECK"       // report the components not fitting into the node allocatable before provisioning, advisory only
+	CfgKeyEndpointProbe                 = "ENDPOINT_PROBE"           // probe the external endpoints of the LoadBalancer services
+	CfgKeyVolumeAdoption                = "VOLUME_ADOPTION"          // adopt the storage of the PVCs expanded out-of-band into the cluster spec
+	CfgKeyNodePressureDetection         = "NODE_PRESSURE_DETECTION"  // report the pressure of the nodes hosting the pods of components
+	CfgKeyNodePressureSwitchover        = "NODE_PRESSURE_SWITCHOVER" // switch the leader over to a healthy node when its node is under pressure
+	CfgKeyWorkloadMigration             = "WORKLOAD_MIGRATION"       // migrate the legacy StatefulSet and RSM workloads of components to InstanceSet
+	CfgKeyRestoreProgressStep           = "RESTORE_PROGRESS_STEP"    // the step in percent to report the restore progress of components by events
+
+	// metering config keys
+	CfgKeyMeteringSinks          = "METERING_SINKS"            // the comma-separated sinks of the usage records of clusters: prometheus, kafka and file, metering is disabled if empty
+	CfgKeyMeteringInterval       = "METERING_INTERVAL"         // the window of the usage records, e.g. "1h"
+	CfgKeyMeteringTenantLabels   = "METERING_TENANT_LABELS"    // the comma-separated keys of the cluster labels copied to the usage records for the tenant attribution
+	CfgKeyMeteringFilePath       = "METERING_FILE_PATH"        // the path of the file which the usage records are appended to
+	CfgKeyMeteringKafkaRESTProxy = "METERING_KAFKA_REST_PROXY" // the URL of the Kafka REST proxy which the usage records are produced through
+	CfgKeyMeteringKafkaTopic     = "METERING_KAFKA_TOPIC"      // the Kafka topic of the usage records
 
 	// addon config keys
 	CfgKeyAddonJobTTL        = "ADDON_JOB_TTL"
 	CfgAddonJobImgPullPolicy = "ADDON_JOB_IMAGE_PULL_POLICY"
 
 	// data plane config key
-	CfgKeyDataPlaneTolerations = "DATA_PLANE_TOLERATIONS"
-	CfgKeyDataPlaneAffinity    = "DATA_PLANE_AFFINITY"
+	CfgKeyDataPlaneTolerations       = "DATA_PLANE_TOLERATIONS"
+	CfgKeyDataPlaneAffinity          = "DATA_PLANE_AFFINITY"
+	CfgKeyDataPlanePriorityClassName = "DATA_PLANE_PRIORITY_CLASS_NAME" // the default PriorityClass of the Pods of the Components
 
 	// storage config keys
 	CfgKeyDefaultStorageClass = "DEFAULT_STORAGE_CLASS"
@@ -49,6 +65,14 @@ const (
 	CfgKeyDPBackupEncryptionSecretKeyRef = "DP_BACKUP_ENCRYPTION_SECRET_KEY_REF"
 	CfgKeyDPBackupEncryptionAlgorithm    = "DP_BACKUP_ENCRYPTION_ALGORITHM"
 
+	// ops config keys
+	CfgKeyOpsBehaviours       = "OPS_BEHAVIOURS"         // overrides the built-in behaviours of the OpsTypes, in json format
+	CfgKeyOpsNodeConcurrency  = "OPS_NODE_CONCURRENCY"   // limits the disruptive OpsRequests running simultaneously per node and node pool, in json format
+	CfgKeyOpsHistoryLimit     = "OPS_HISTORY_LIMIT"      // the number of the most recent completed OpsRequests retained per cluster regardless of ttlSecondsAfterFinished
+	CfgKeyOpsCapacityPreCheck = "OPS_CAPACITY_PRE_CHECK" // check whether the nodes can hold the instances with the new resources before applying the VerticalScaling OpsRequests
+
+	CfgKeyServiceNodePortRange = "SERVICE_NODE_PORT_RANGE" // the range of the NodePorts allowed by the API server, it should be consistent with the --service-node-port-range flag of it
+
 	CfgKBReconcileWorkers = "KUBEBLOCKS_RECONCILE_WORKERS"
 	CfgClientQPS          = "CLIENT_QPS"
 	CfgClientBurst        = "CLIENT_BURST"
@@ -54,11 +54,17 @@ func HasSimplifiedClusterAPI(cluster *appsv1alpha1.Cluster) bool {
 		!cluster.Spec.Resources.Memory.IsZero() ||
 		!cluster.Spec.Storage.Size.IsZero() ||
 		// cluster.Spec.Monitor.MonitoringInterval != nil ||
-		cluster.Spec.Network != nil ||
+		hasSimplifiedNetworkAPI(cluster) ||
 		len(cluster.Spec.Tenancy) > 0 ||
 		len(cluster.Spec.AvailabilityPolicy) > 0
 }
 
+// hasSimplifiedNetworkAPI checks the deprecated network fields only, the network policies are not part of the simplified API.
+func hasSimplifiedNetworkAPI(cluster *appsv1alpha1.Cluster) bool {
+	return cluster.Spec.Network != nil &&
+		(cluster.Spec.Network.HostNetworkAccessible || cluster.Spec.Network.PubliclyAccessible)
+}
+
 func fillSimplifiedClusterAPI(cluster *appsv1alpha1.Cluster, clusterCompDef *appsv1alpha1.ClusterComponentDefinition) *appsv1alpha1.ClusterComponentSpec {
 	clusterCompSpec := &appsv1alpha1.ClusterComponentSpec{
 		Name:            clusterCompDef.Name,
@@ -107,7 +113,7 @@ func fillSimplifiedClusterAPI(cluster *appsv1alpha1.Cluster, clusterCompDef *app
 	// 		// TODO: should also set interval
 	// 	}
 	// }
-	if cluster.Spec.Network != nil {
+	if hasSimplifiedNetworkAPI(cluster) {
 		clusterCompSpec.Services = []appsv1alpha1.ClusterComponentService{}
 		if cluster.Spec.Network.HostNetworkAccessible {
 			svc := appsv1alpha1.ClusterComponentService{
@@ -162,7 +168,7 @@ func fillSimplifiedClusterAPI(cluster *appsv1alpha1.Cluster, clusterCompDef *app
 	if len(cluster.Spec.Tenancy) > 0 || len(cluster.Spec.AvailabilityPolicy) > 0 {
 		clusterCompSpec.Affinity = &appsv1alpha1.Affinity{
 			PodAntiAffinity: appsv1alpha1.Preferred,
-			TopologyKeys:    []string{affinityTopologyKey(cluster.Spec.AvailabilityPolicy)},
+			TopologyKeys:    []string{cluster.Spec.AvailabilityPolicy.TopologyKey()},
 			Tenancy:         cluster.Spec.Tenancy,
 		}
 	}
@@ -186,13 +192,3 @@ func getCloudProvider() cloudProvider {
 	}
 	return cloudProviderUnknown
 }
-
-func affinityTopologyKey(policyType appsv1alpha1.AvailabilityPolicyType) string {
-	switch policyType {
-	case appsv1alpha1.AvailabilityPolicyZone:
-		return "topology.kubernetes.io/zone"
-	case appsv1alpha1.AvailabilityPolicyNode:
-		return "kubernetes.io/hostname"
-	}
-	return ""
-}
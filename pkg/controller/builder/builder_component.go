@@ -90,6 +90,56 @@ func (builder *ComponentBuilder) SetDisableExporter(disableExporter *bool) *Comp
 	return builder
 }
 
+func (builder *ComponentBuilder) SetReadWriteSplitServices(readWriteSplitServices *bool) *ComponentBuilder {
+	builder.get().Spec.ReadWriteSplitServices = readWriteSplitServices
+	return builder
+}
+
+func (builder *ComponentBuilder) SetCanaryService(canaryService *bool) *ComponentBuilder {
+	builder.get().Spec.CanaryService = canaryService
+	return builder
+}
+
+func (builder *ComponentBuilder) SetVolumeSnapshotPolicy(policy *appsv1alpha1.VolumeSnapshotPolicy) *ComponentBuilder {
+	builder.get().Spec.VolumeSnapshotPolicy = policy
+	return builder
+}
+
+func (builder *ComponentBuilder) SetDNSPolicy(dnsPolicy *corev1.DNSPolicy) *ComponentBuilder {
+	builder.get().Spec.DNSPolicy = dnsPolicy
+	return builder
+}
+
+func (builder *ComponentBuilder) SetDNSConfig(dnsConfig *corev1.PodDNSConfig) *ComponentBuilder {
+	builder.get().Spec.DNSConfig = dnsConfig
+	return builder
+}
+
+func (builder *ComponentBuilder) SetPriorityClassName(priorityClassName string) *ComponentBuilder {
+	builder.get().Spec.PriorityClassName = priorityClassName
+	return builder
+}
+
+func (builder *ComponentBuilder) SetDataExport(dataExport *appsv1alpha1.ComponentDataExport) *ComponentBuilder {
+	builder.get().Spec.DataExport = dataExport
+	return builder
+}
+
+func (builder *ComponentBuilder) SetInitContainers(containers []corev1.Container) *ComponentBuilder {
+	builder.get().Spec.InitContainers = containers
+	return builder
+}
+
+func (builder *ComponentBuilder) SetInitScripts(scripts *appsv1alpha1.InitScripts) *ComponentBuilder {
+	builder.get().Spec.InitScripts = scripts
+	return builder
+}
+
+func (builder *ComponentBuilder) SetPinImageDigests(pin *bool) *ComponentBuilder {
+	builder.get().Spec.PinImageDigests = pin
+	return builder
+}
+
 func (builder *ComponentBuilder) SetEnabledLogs(logNames []string) *ComponentBuilder {
 	builder.get().Spec.EnabledLogs = logNames
 	return builder
@@ -124,6 +174,11 @@ func (builder *ComponentBuilder) SetVolumes(volumes []corev1.Volume) *ComponentB
 	return builder
 }
 
+func (builder *ComponentBuilder) SetSharedVolumes(sharedVolumes []appsv1alpha1.SharedVolume) *ComponentBuilder {
+	builder.get().Spec.SharedVolumes = sharedVolumes
+	return builder
+}
+
 func (builder *ComponentBuilder) SetServices(services []appsv1alpha1.ClusterComponentService) *ComponentBuilder {
 	toCompService := func(svc appsv1alpha1.ClusterComponentService) appsv1alpha1.ComponentService {
 		return appsv1alpha1.ComponentService{
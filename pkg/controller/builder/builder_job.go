@@ -62,6 +62,16 @@ func (builder *JobBuilder) SetBackoffLimit(limit int32) *JobBuilder {
 	return builder
 }
 
+func (builder *JobBuilder) SetCompletions(completions int32) *JobBuilder {
+	builder.get().Spec.Completions = &completions
+	return builder
+}
+
+func (builder *JobBuilder) SetParallelism(parallelism int32) *JobBuilder {
+	builder.get().Spec.Parallelism = &parallelism
+	return builder
+}
+
 func (builder *JobBuilder) SetTTLSecondsAfterFinished(ttl int32) *JobBuilder {
 	builder.get().Spec.TTLSecondsAfterFinished = &ttl
 	return builder
@@ -53,11 +53,15 @@ var _ = Describe("job builder", func() {
 		suspend := true
 		limit := int32(5)
 		ttl := int32(12)
+		completions := int32(3)
+		parallelism := int32(2)
 		job := NewJobBuilder(ns, name).
 			SetPodTemplateSpec(template).
 			AddSelector(selectorKey, selectorValue).
 			SetSuspend(suspend).
 			SetBackoffLimit(limit).
+			SetCompletions(completions).
+			SetParallelism(parallelism).
 			SetTTLSecondsAfterFinished(ttl).
 			GetObject()
 
@@ -71,6 +75,10 @@ var _ = Describe("job builder", func() {
 		Expect(*job.Spec.Suspend).Should(Equal(suspend))
 		Expect(job.Spec.BackoffLimit).ShouldNot(BeNil())
 		Expect(*job.Spec.BackoffLimit).Should(Equal(limit))
+		Expect(job.Spec.Completions).ShouldNot(BeNil())
+		Expect(*job.Spec.Completions).Should(Equal(completions))
+		Expect(job.Spec.Parallelism).ShouldNot(BeNil())
+		Expect(*job.Spec.Parallelism).Should(Equal(parallelism))
 		Expect(job.Spec.TTLSecondsAfterFinished).ShouldNot(BeNil())
 		Expect(*job.Spec.TTLSecondsAfterFinished).Should(Equal(ttl))
 	})
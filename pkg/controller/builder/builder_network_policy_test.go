@@ -0,0 +1,57 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package builder
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+var _ = Describe("network policy builder", func() {
+	It("should work well", func() {
+		const (
+			name = "foo"
+			ns   = "default"
+		)
+		selector := metav1.LabelSelector{
+			MatchLabels: map[string]string{constant.AppInstanceLabelKey: name},
+		}
+		rule := networkingv1.NetworkPolicyIngressRule{
+			From: []networkingv1.NetworkPolicyPeer{{PodSelector: &selector}},
+		}
+		policy := NewNetworkPolicyBuilder(ns, name).
+			SetPodSelector(selector).
+			AddPolicyTypes(networkingv1.PolicyTypeIngress).
+			AddIngressRules(rule).
+			GetObject()
+
+		Expect(policy.Name).Should(Equal(name))
+		Expect(policy.Namespace).Should(Equal(ns))
+		Expect(policy.Spec.PodSelector).Should(Equal(selector))
+		Expect(policy.Spec.PolicyTypes).Should(Equal([]networkingv1.PolicyType{networkingv1.PolicyTypeIngress}))
+		Expect(policy.Spec.Ingress).Should(HaveLen(1))
+		Expect(policy.Spec.Ingress[0]).Should(Equal(rule))
+	})
+})
@@ -0,0 +1,88 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"fmt"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+// CustomLifecycleActions returns the custom handlers of the lifecycle actions, keyed by the names of the actions.
+func CustomLifecycleActions(lifecycleActions *appsv1alpha1.ComponentLifecycleActions) map[string]*appsv1alpha1.Action {
+	if lifecycleActions == nil {
+		return nil
+	}
+	handlers := map[string]*appsv1alpha1.LifecycleActionHandler{
+		constant.PostProvisionAction:    lifecycleActions.PostProvision,
+		constant.PreTerminateAction:     lifecycleActions.PreTerminate,
+		constant.MemberJoinAction:       lifecycleActions.MemberJoin,
+		constant.MemberLeaveAction:      lifecycleActions.MemberLeave,
+		constant.ReadonlyAction:         lifecycleActions.Readonly,
+		constant.ReadWriteAction:        lifecycleActions.Readwrite,
+		constant.DataDumpAction:         lifecycleActions.DataDump,
+		constant.DataLoadAction:         lifecycleActions.DataLoad,
+		constant.ReconfigureAction:      lifecycleActions.Reconfigure,
+		constant.AccountProvisionAction: lifecycleActions.AccountProvision,
+		constant.ReloadTLSAction:        lifecycleActions.ReloadTLS,
+		constant.FenceAction:            lifecycleActions.Fence,
+		constant.PurgeAction:            lifecycleActions.Purge,
+		constant.DrainBackendAction:     lifecycleActions.DrainBackend,
+		constant.ResumeBackendAction:    lifecycleActions.ResumeBackend,
+		constant.UpgradePreCheckAction:  lifecycleActions.UpgradePreCheck,
+		constant.ShardRebalanceAction:   lifecycleActions.ShardRebalance,
+		constant.ReplicationLagAction:   lifecycleActions.ReplicationLag,
+	}
+	if lifecycleActions.RoleProbe != nil {
+		handlers[constant.RoleProbeAction] = &lifecycleActions.RoleProbe.LifecycleActionHandler
+	}
+	if lifecycleActions.WarmUpCheck != nil {
+		handlers[constant.WarmUpCheckAction] = &lifecycleActions.WarmUpCheck.LifecycleActionHandler
+	}
+
+	actions := make(map[string]*appsv1alpha1.Action)
+	for name, handler := range handlers {
+		if handler != nil && handler.CustomHandler != nil {
+			actions[name] = handler.CustomHandler
+		}
+	}
+	if switchover := lifecycleActions.Switchover; switchover != nil {
+		if switchover.WithCandidate != nil {
+			actions[constant.SwitchoverWithCandidateAction] = switchover.WithCandidate
+		}
+		if switchover.WithoutCandidate != nil {
+			actions[constant.SwitchoverWithoutCandidateAction] = switchover.WithoutCandidate
+		}
+	}
+	return actions
+}
+
+// ValidateCustomAction checks that the action is executed by exec, either in the Pod or as a Job,
+// which is the only executor supported by now.
+func ValidateCustomAction(action *appsv1alpha1.Action) error {
+	switch {
+	case action.HTTP != nil:
+		return fmt.Errorf("the http executor is not supported, please use exec instead")
+	case action.Exec == nil:
+		return fmt.Errorf("the exec is required")
+	}
+	return nil
+}
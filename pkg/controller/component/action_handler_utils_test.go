@@ -0,0 +1,91 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestCustomLifecycleActions(t *testing.T) {
+	postProvision := &appsv1alpha1.Action{Exec: &appsv1alpha1.ExecAction{Command: []string{"init.sh"}}}
+	withCandidate := &appsv1alpha1.Action{Exec: &appsv1alpha1.ExecAction{Command: []string{"switchover.sh"}}}
+	actions := CustomLifecycleActions(&appsv1alpha1.ComponentLifecycleActions{
+		PostProvision: &appsv1alpha1.LifecycleActionHandler{CustomHandler: postProvision},
+		PreTerminate:  &appsv1alpha1.LifecycleActionHandler{},
+		Switchover:    &appsv1alpha1.ComponentSwitchover{WithCandidate: withCandidate},
+	})
+	if len(actions) != 2 {
+		t.Fatalf("unexpected custom actions: %v", actions)
+	}
+	if actions[constant.PostProvisionAction] != postProvision || actions[constant.SwitchoverWithCandidateAction] != withCandidate {
+		t.Errorf("unexpected custom actions: %v", actions)
+	}
+	if actions = CustomLifecycleActions(nil); len(actions) != 0 {
+		t.Errorf("expect no custom actions, but got: %v", actions)
+	}
+}
+
+func TestValidateCustomAction(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  *appsv1alpha1.Action
+		wantErr bool
+	}{{
+		name:    "no executor",
+		action:  &appsv1alpha1.Action{},
+		wantErr: true,
+	}, {
+		name: "exec with http",
+		action: &appsv1alpha1.Action{
+			Exec: &appsv1alpha1.ExecAction{Command: []string{"echo"}},
+			HTTP: &appsv1alpha1.HTTPAction{Port: intstr.FromInt(8080)},
+		},
+		wantErr: true,
+	}, {
+		name: "http executor",
+		action: &appsv1alpha1.Action{
+			HTTP: &appsv1alpha1.HTTPAction{Port: intstr.FromInt(8080)},
+		},
+		wantErr: true,
+	}, {
+		name: "exec in the pod",
+		action: &appsv1alpha1.Action{
+			Exec: &appsv1alpha1.ExecAction{Command: []string{"echo"}},
+		},
+	}, {
+		name: "exec in a job",
+		action: &appsv1alpha1.Action{
+			Image: "busybox",
+			Exec:  &appsv1alpha1.ExecAction{Command: []string{"echo"}},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateCustomAction(tt.action); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCustomAction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
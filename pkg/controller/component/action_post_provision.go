@@ -85,6 +85,11 @@ func ReconcileCompPostProvision(ctx context.Context,
 	return nil
 }
 
+// isComponentReady checks whether the component is running, or has completed if it's a run-to-completion component.
+func isComponentReady(comp *appsv1alpha1.Component) bool {
+	return comp.Status.Phase == appsv1alpha1.RunningClusterCompPhase || comp.Status.Phase == appsv1alpha1.CompletedClusterCompPhase
+}
+
 func NeedDoPostProvision(ctx context.Context, cli client.Reader, actionCtx *ActionContext) (bool, error) {
 	// if the component does not have a custom postProvision, skip it
 	actionExist, _ := checkLifeCycleAction(actionCtx)
@@ -106,7 +111,7 @@ func NeedDoPostProvision(ctx context.Context, cli client.Reader, actionCtx *Acti
 				return false, intctrlutil.NewErrorf(intctrlutil.ErrorTypeExpectedInProcess, "runtime is not ready when checking RuntimeReady preCondition in postProvision action")
 			}
 		case appsv1alpha1.ComponentReadyPreConditionType:
-			if actionCtx.component.Status.Phase != appsv1alpha1.RunningClusterCompPhase {
+			if !isComponentReady(actionCtx.component) {
 				return false, nil
 			}
 		case appsv1alpha1.ClusterReadyPreConditionType:
@@ -116,7 +121,7 @@ func NeedDoPostProvision(ctx context.Context, cli client.Reader, actionCtx *Acti
 		default:
 			return false, errors.New("unsupported postProvision preCondition type")
 		}
-	} else if actionCtx.component.Status.Phase != appsv1alpha1.RunningClusterCompPhase {
+	} else if !isComponentReady(actionCtx.component) {
 		// if the PreCondition is not set, the default preCondition is ComponentReady
 		return false, nil
 	}
@@ -128,7 +128,7 @@ func renderActionCmdJob(ctx context.Context, cli client.Reader, actionCtx *Actio
 		return nil, fmt.Errorf("lifecycle action %s custom handler not found", actionCtx.actionType)
 	}
 	if action.Exec == nil {
-		return nil, fmt.Errorf("lifecycle action %s custom handler only support exec command by now, please check your customHandler spec", actionCtx.actionType)
+		return nil, fmt.Errorf("lifecycle action %s runs as a job, only the exec custom handler is supported, please check your customHandler spec", actionCtx.actionType)
 	}
 	pods, err := ListOwnedPods(ctx, cli, actionCtx.cluster.Namespace, actionCtx.cluster.Name, actionCtx.compShortName)
 	if err != nil {
@@ -224,6 +224,10 @@ func renderActionCmdJob(ctx context.Context, cli client.Reader, actionCtx *Actio
 		if customAction.RetryPolicy != nil && customAction.RetryPolicy.MaxRetries > 0 {
 			jobObj.Spec.BackoffLimit = pointer.Int32(int32(customAction.RetryPolicy.MaxRetries))
 		}
+		// each retry runs in a new pod, so the timeout applies to each attempt as the other executors do
+		if customAction.TimeoutSeconds > 0 {
+			jobObj.Spec.Template.Spec.ActiveDeadlineSeconds = pointer.Int64(int64(customAction.TimeoutSeconds))
+		}
 		return jobObj, nil
 	}
 
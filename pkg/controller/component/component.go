@@ -27,6 +27,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
@@ -77,6 +78,11 @@ func BuildComponent(cluster *appsv1alpha1.Cluster, compSpec *appsv1alpha1.Cluste
 	if err != nil {
 		return nil, err
 	}
+	replicas, instances := compSpec.Replicas, compSpec.Instances
+	if cluster.Spec.Paused {
+		// the replicas specified are restored once the cluster is resumed
+		replicas, instances = 0, pausedInstances(compSpec.Instances)
+	}
 	compBuilder := builder.NewComponentBuilder(cluster.Namespace, compName, compDefName).
 		AddAnnotations(constant.KubeBlocksGenerationKey, strconv.FormatInt(cluster.Generation, 10)).
 		AddAnnotations(constant.KBAppMultiClusterPlacementKey, cluster.Annotations[constant.KBAppMultiClusterPlacementKey]).
@@ -88,16 +94,27 @@ func BuildComponent(cluster *appsv1alpha1.Cluster, compSpec *appsv1alpha1.Cluste
 		SetEnv(compSpec.Env).
 		SetSchedulingPolicy(schedulingPolicy).
 		SetDisableExporter(compSpec.GetDisableExporter()).
-		SetReplicas(compSpec.Replicas).
+		SetReadWriteSplitServices(compSpec.ReadWriteSplitServices).
+		SetCanaryService(compSpec.CanaryService).
+		SetVolumeSnapshotPolicy(compSpec.VolumeSnapshotPolicy).
+		SetDNSPolicy(compSpec.DNSPolicy).
+		SetDNSConfig(compSpec.DNSConfig).
+		SetPriorityClassName(compSpec.PriorityClassName).
+		SetDataExport(compSpec.DataExport).
+		SetInitContainers(compSpec.InitContainers).
+		SetInitScripts(compSpec.InitScripts).
+		SetPinImageDigests(compSpec.PinImageDigests).
+		SetReplicas(replicas).
 		SetResources(compSpec.Resources).
 		SetServiceAccountName(compSpec.ServiceAccountName).
 		SetVolumeClaimTemplates(compSpec.VolumeClaimTemplates).
 		SetVolumes(compSpec.Volumes).
+		SetSharedVolumes(compSpec.SharedVolumes).
 		SetConfigs(compSpec.Configs).
 		SetEnabledLogs(compSpec.EnabledLogs).
 		SetServiceRefs(compSpec.ServiceRefs).
 		SetTLSConfig(compSpec.TLS, compSpec.Issuer).
-		SetInstances(compSpec.Instances).
+		SetInstances(instances).
 		SetOfflineInstances(compSpec.OfflineInstances).
 		SetRuntimeClassName(cluster.Spec.RuntimeClassName).
 		SetSystemAccounts(compSpec.SystemAccounts)
@@ -113,6 +130,19 @@ func BuildComponent(cluster *appsv1alpha1.Cluster, compSpec *appsv1alpha1.Cluste
 	return compBuilder.GetObject(), nil
 }
 
+// pausedInstances returns the instance templates scaled to zero replicas for the paused cluster.
+func pausedInstances(instances []appsv1alpha1.InstanceTemplate) []appsv1alpha1.InstanceTemplate {
+	if len(instances) == 0 {
+		return instances
+	}
+	paused := make([]appsv1alpha1.InstanceTemplate, len(instances))
+	for i := range instances {
+		instances[i].DeepCopyInto(&paused[i])
+		paused[i].Replicas = pointer.Int32(0)
+	}
+	return paused
+}
+
 func BuildComponentDefinition(clusterDef *appsv1alpha1.ClusterDefinition,
 	clusterVer *appsv1alpha1.ClusterVersion,
 	clusterCompSpec *appsv1alpha1.ClusterComponentSpec) (*appsv1alpha1.ComponentDefinition, error) {
@@ -353,6 +353,33 @@ var _ = Describe("Component", func() {
 				}
 			}
 		})
+
+		It("scale the component to zero when the cluster is paused", func() {
+			compSpec := &cluster.Spec.ComponentSpecs[0]
+			compSpec.Replicas = 3
+			compSpec.Instances = []appsv1alpha1.InstanceTemplate{
+				{
+					Name:     "foo",
+					Replicas: func() *int32 { r := int32(1); return &r }(),
+				},
+			}
+
+			By("build the component of the running cluster")
+			comp, err := BuildComponent(cluster, compSpec, nil, nil)
+			Expect(err).Should(Succeed())
+			Expect(comp.Spec.Replicas).Should(BeEquivalentTo(3))
+			Expect(*comp.Spec.Instances[0].Replicas).Should(BeEquivalentTo(1))
+
+			By("build the component of the paused cluster")
+			cluster.Spec.Paused = true
+			comp, err = BuildComponent(cluster, compSpec, nil, nil)
+			Expect(err).Should(Succeed())
+			Expect(comp.Spec.Replicas).Should(BeEquivalentTo(0))
+			Expect(comp.Spec.Instances).Should(HaveLen(1))
+			Expect(*comp.Spec.Instances[0].Replicas).Should(BeEquivalentTo(0))
+			Expect(compSpec.Replicas).Should(BeEquivalentTo(3))
+			Expect(*compSpec.Instances[0].Replicas).Should(BeEquivalentTo(1))
+		})
 	})
 })
 
@@ -0,0 +1,177 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/graph"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+)
+
+// init-scripts constants
+const (
+	// InitScriptsContainerName is the name of the init container executing the init scripts of the component.
+	InitScriptsContainerName = "kb-init-scripts"
+	// InitScriptsVolumeName is the name of the volume of the ConfigMap holding the init scripts.
+	InitScriptsVolumeName = "kb-init-scripts"
+
+	initScriptsMountPath = "/kubeblocks/init-scripts"
+
+	// kbCompInitScriptsDoneKey is used to mark the init scripts of the component are done
+	kbCompInitScriptsDoneKey = "kubeblocks.io/init-scripts-done"
+)
+
+// buildInitContainers appends the user-supplied init containers, and the init container executing the init scripts
+// on the first provision, after the init containers defined in the definition.
+func buildInitContainers(synthesizeComp *SynthesizedComponent, comp *appsv1alpha1.Component) {
+	for _, c := range comp.Spec.InitContainers {
+		synthesizeComp.PodSpec.InitContainers = append(synthesizeComp.PodSpec.InitContainers, *c.DeepCopy())
+	}
+	if !needInitScripts(comp) {
+		return
+	}
+	container := getInitScriptsRuntimeContainer(synthesizeComp.PodSpec, comp.Spec.InitScripts)
+	if container == nil {
+		return
+	}
+	synthesizeComp.PodSpec.InitContainers = append(synthesizeComp.PodSpec.InitContainers,
+		buildInitScriptsContainer(container))
+	synthesizeComp.PodSpec.Volumes = append(synthesizeComp.PodSpec.Volumes, corev1.Volume{
+		Name: InitScriptsVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: comp.Spec.InitScripts.ConfigMapName},
+			},
+		},
+	})
+}
+
+// needInitScripts checks whether the init scripts should be executed, they are only executed on the first provision,
+// neither after they are done nor if they are specified for a provisioned component.
+func needInitScripts(comp *appsv1alpha1.Component) bool {
+	if comp.Spec.InitScripts == nil || isInitScriptsDone(comp) {
+		return false
+	}
+	return comp.Status.Phase == "" || comp.Status.Phase == appsv1alpha1.CreatingClusterCompPhase
+}
+
+func isInitScriptsDone(comp *appsv1alpha1.Component) bool {
+	_, ok := comp.Annotations[kbCompInitScriptsDoneKey]
+	return ok
+}
+
+// getInitScriptsRuntimeContainer returns the container whose runtime is used to execute the init scripts.
+func getInitScriptsRuntimeContainer(podSpec *corev1.PodSpec, scripts *appsv1alpha1.InitScripts) *corev1.Container {
+	if len(podSpec.Containers) == 0 {
+		return nil
+	}
+	if scripts.Container == "" {
+		return &podSpec.Containers[0]
+	}
+	for i, c := range podSpec.Containers {
+		if c.Name == scripts.Container {
+			return &podSpec.Containers[i]
+		}
+	}
+	return nil
+}
+
+func buildInitScriptsContainer(runtime *corev1.Container) corev1.Container {
+	c := runtime.DeepCopy()
+	command := fmt.Sprintf(`for script in %s/*; do
+  [ -f "${script}" ] || continue
+  echo "executing the init script ${script}"
+  sh "${script}" || exit 1
+done`, initScriptsMountPath)
+	return corev1.Container{
+		Name:            InitScriptsContainerName,
+		Image:           c.Image,
+		ImagePullPolicy: c.ImagePullPolicy,
+		Command:         []string{"sh", "-c", command},
+		Env:             c.Env,
+		EnvFrom:         c.EnvFrom,
+		Resources:       c.Resources,
+		VolumeMounts: append(c.VolumeMounts, corev1.VolumeMount{
+			Name:      InitScriptsVolumeName,
+			MountPath: initScriptsMountPath,
+			ReadOnly:  true,
+		}),
+		SecurityContext: c.SecurityContext,
+	}
+}
+
+// ValidateInitContainers validates the user-supplied init containers and the init scripts of the component
+// against the definition, the names of the init containers must not collide with the containers of the definition.
+func ValidateInitContainers(comp *appsv1alpha1.Component, compDef *appsv1alpha1.ComponentDefinition) error {
+	defined := sets.New[string](InitScriptsContainerName)
+	for _, cc := range [][]corev1.Container{compDef.Spec.Runtime.InitContainers, compDef.Spec.Runtime.Containers} {
+		for _, c := range cc {
+			defined.Insert(c.Name)
+		}
+	}
+	names := sets.New[string]()
+	for _, c := range comp.Spec.InitContainers {
+		if names.Has(c.Name) {
+			return fmt.Errorf("the init container %s is duplicated", c.Name)
+		}
+		if defined.Has(c.Name) {
+			return fmt.Errorf("the name of the init container %s collides with a container defined by the definition %s", c.Name, compDef.Name)
+		}
+		names.Insert(c.Name)
+	}
+
+	scripts := comp.Spec.InitScripts
+	if scripts != nil && scripts.Container != "" {
+		if !slices.ContainsFunc(compDef.Spec.Runtime.Containers, func(c corev1.Container) bool {
+			return c.Name == scripts.Container
+		}) {
+			return fmt.Errorf("the container %s to execute the init scripts is not defined by the definition %s", scripts.Container, compDef.Name)
+		}
+	}
+	return nil
+}
+
+// ReconcileCompInitScripts marks the init scripts of the component as done once the component is ready on the first
+// provision, the init container executing them is removed from the pod template afterward.
+func ReconcileCompInitScripts(graphCli model.GraphClient, comp *appsv1alpha1.Component, workload client.Object, dag *graph.DAG) {
+	if comp.Spec.InitScripts == nil || isInitScriptsDone(comp) || !isComponentReady(comp) {
+		return
+	}
+	its, ok := workload.(*workloads.InstanceSet)
+	if !ok || its == nil || !instanceset.IsInstancesReady(its) {
+		return
+	}
+	compObj := comp.DeepCopy()
+	if comp.Annotations == nil {
+		comp.Annotations = make(map[string]string)
+	}
+	comp.Annotations[kbCompInitScriptsDoneKey] = time.Now().Format(time.RFC3339Nano)
+	graphCli.Update(dag, compObj, comp, &model.ReplaceIfExistingOption{})
+}
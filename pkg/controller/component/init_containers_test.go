@@ -0,0 +1,126 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+func TestBuildInitContainers(t *testing.T) {
+	newComp := func(phase appsv1alpha1.ClusterComponentPhase, annotations map[string]string) *appsv1alpha1.Component {
+		return &appsv1alpha1.Component{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Annotations: annotations},
+			Spec: appsv1alpha1.ComponentSpec{
+				InitContainers: []corev1.Container{{Name: "seed", Image: "busybox"}},
+				InitScripts:    &appsv1alpha1.InitScripts{ConfigMapName: "scripts", Container: "engine"},
+			},
+			Status: appsv1alpha1.ComponentStatus{Phase: phase},
+		}
+	}
+	newSynthesizedComp := func() *SynthesizedComponent {
+		return &SynthesizedComponent{
+			PodSpec: &corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "init"}},
+				Containers: []corev1.Container{
+					{Name: "sidecar", Image: "sidecar"},
+					{Name: "engine", Image: "engine", VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/data"}}},
+				},
+			},
+		}
+	}
+	names := func(containers []corev1.Container) []string {
+		var names []string
+		for _, c := range containers {
+			names = append(names, c.Name)
+		}
+		return names
+	}
+
+	// the init scripts are executed on the first provision.
+	synthesizedComp := newSynthesizedComp()
+	buildInitContainers(synthesizedComp, newComp(appsv1alpha1.CreatingClusterCompPhase, nil))
+	if got := names(synthesizedComp.PodSpec.InitContainers); len(got) != 3 || got[0] != "init" || got[1] != "seed" || got[2] != InitScriptsContainerName {
+		t.Fatalf("unexpected init containers: %v", got)
+	}
+	scriptsContainer := synthesizedComp.PodSpec.InitContainers[2]
+	if scriptsContainer.Image != "engine" || len(scriptsContainer.VolumeMounts) != 2 || scriptsContainer.VolumeMounts[1].Name != InitScriptsVolumeName {
+		t.Errorf("expect the init scripts executed by the runtime of the engine, but got %v", scriptsContainer)
+	}
+	if len(synthesizedComp.PodSpec.Volumes) != 1 || synthesizedComp.PodSpec.Volumes[0].ConfigMap.Name != "scripts" {
+		t.Errorf("expect the volume of the init scripts, but got %v", synthesizedComp.PodSpec.Volumes)
+	}
+	if len(synthesizedComp.PodSpec.Containers[1].VolumeMounts) != 1 {
+		t.Error("expect the engine container unchanged")
+	}
+
+	// the init scripts are not executed once they are done, or for a provisioned component.
+	for _, comp := range []*appsv1alpha1.Component{
+		newComp(appsv1alpha1.CreatingClusterCompPhase, map[string]string{kbCompInitScriptsDoneKey: "done"}),
+		newComp(appsv1alpha1.RunningClusterCompPhase, nil),
+	} {
+		synthesizedComp = newSynthesizedComp()
+		buildInitContainers(synthesizedComp, comp)
+		if got := names(synthesizedComp.PodSpec.InitContainers); len(got) != 2 || got[1] != "seed" {
+			t.Errorf("unexpected init containers: %v", got)
+		}
+		if len(synthesizedComp.PodSpec.Volumes) != 0 {
+			t.Errorf("expect no volume of the init scripts, but got %v", synthesizedComp.PodSpec.Volumes)
+		}
+	}
+}
+
+func TestValidateInitContainers(t *testing.T) {
+	compDef := &appsv1alpha1.ComponentDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: appsv1alpha1.ComponentDefinitionSpec{
+			Runtime: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "init"}},
+				Containers:     []corev1.Container{{Name: "engine"}},
+			},
+		},
+	}
+	cases := []struct {
+		name       string
+		containers []string
+		scripts    *appsv1alpha1.InitScripts
+		valid      bool
+	}{
+		{"valid", []string{"seed", "prepare"}, &appsv1alpha1.InitScripts{ConfigMapName: "scripts", Container: "engine"}, true},
+		{"duplicated", []string{"seed", "seed"}, nil, false},
+		{"collides with init container", []string{"init"}, nil, false},
+		{"collides with container", []string{"engine"}, nil, false},
+		{"collides with init scripts", []string{InitScriptsContainerName}, nil, false},
+		{"undefined scripts container", nil, &appsv1alpha1.InitScripts{ConfigMapName: "scripts", Container: "init"}, false},
+	}
+	for _, c := range cases {
+		comp := &appsv1alpha1.Component{Spec: appsv1alpha1.ComponentSpec{InitScripts: c.scripts}}
+		for _, name := range c.containers {
+			comp.Spec.InitContainers = append(comp.Spec.InitContainers, corev1.Container{Name: name})
+		}
+		if err := ValidateInitContainers(comp, compDef); (err == nil) != c.valid {
+			t.Errorf("%s: unexpected result %v", c.name, err)
+		}
+	}
+}
@@ -22,6 +22,7 @@ package component
 import (
 	"errors"
 
+	"golang.org/x/exp/maps"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -51,6 +52,7 @@ func BuildWorkloadFrom(synthesizeComp *SynthesizedComponent, protoITS *workloads
 		"updatestrategy":            &itsUpdateStrategyConvertor{},
 		"instances":                 &itsInstancesConvertor{},
 		"offlineinstances":          &itsOfflineInstancesConvertor{},
+		"warmupcheck":               &itsWarmUpCheckConvertor{},
 	}
 	if err := covertObject(convertors, &protoITS.Spec, synthesizeComp); err != nil {
 		return nil, err
@@ -73,6 +75,9 @@ type itsRoleProbeConvertor struct{}
 // itsCredentialConvertor is an implementation of the convertor interface, used to convert the given object into InstanceSet.Spec.Credential.
 type itsCredentialConvertor struct{}
 
+// itsWarmUpCheckConvertor is an implementation of the convertor interface, used to convert the given object into InstanceSet.Spec.WarmUpCheck.
+type itsWarmUpCheckConvertor struct{}
+
 // itsMembershipReconfigurationConvertor is an implementation of the convertor interface, used to convert the given object into InstanceSet.Spec.MembershipReconfiguration.
 type itsMembershipReconfigurationConvertor struct{}
 
@@ -165,6 +170,21 @@ func AppsInstanceToWorkloadInstance(instance *appsv1alpha1.InstanceTemplate) *wo
 			TopologySpreadConstraints: instance.SchedulingPolicy.TopologySpreadConstraints,
 		}
 	}
+	// the node pinning of the instance template takes precedence over its scheduling policy.
+	if instance.NodeName != "" || len(instance.NodeSelector) > 0 {
+		if schedulingPolicy == nil {
+			schedulingPolicy = &workloads.SchedulingPolicy{}
+		}
+		if instance.NodeName != "" {
+			schedulingPolicy.NodeName = instance.NodeName
+		}
+		if len(instance.NodeSelector) > 0 {
+			nodeSelector := make(map[string]string, len(schedulingPolicy.NodeSelector)+len(instance.NodeSelector))
+			maps.Copy(nodeSelector, schedulingPolicy.NodeSelector)
+			maps.Copy(nodeSelector, instance.NodeSelector)
+			schedulingPolicy.NodeSelector = nodeSelector
+		}
+	}
 
 	return &workloads.InstanceTemplate{
 		Name:                 instance.Name,
@@ -283,6 +303,35 @@ func (c *itsRoleProbeConvertor) convert(args ...any) (any, error) {
 	return itsRoleProbe, nil
 }
 
+// itsWarmUpCheckConvertor converts the ComponentDefinition.Spec.LifecycleActions.WarmUpCheck into InstanceSet.Spec.WarmUpCheck.
+func (c *itsWarmUpCheckConvertor) convert(args ...any) (any, error) {
+	synthesizeComp, err := parseITSConvertorArgs(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if synthesizeComp.LifecycleActions == nil || synthesizeComp.LifecycleActions.WarmUpCheck == nil {
+		return nil, nil
+	}
+	warmUpCheck := synthesizeComp.LifecycleActions.WarmUpCheck
+	if warmUpCheck.Metric == nil && warmUpCheck.BuiltinHandler == nil && warmUpCheck.CustomHandler == nil {
+		return nil, nil
+	}
+
+	itsWarmUpCheck := &workloads.WarmUpCheck{
+		Metric:         warmUpCheck.Metric.DeepCopy(),
+		PeriodSeconds:  warmUpCheck.PeriodSeconds,
+		TimeoutSeconds: warmUpCheck.TimeoutSeconds,
+	}
+	if itsWarmUpCheck.PeriodSeconds == 0 {
+		itsWarmUpCheck.PeriodSeconds = 10
+	}
+	if itsWarmUpCheck.TimeoutSeconds == 0 {
+		itsWarmUpCheck.TimeoutSeconds = 600
+	}
+	return itsWarmUpCheck, nil
+}
+
 func (c *itsCredentialConvertor) convert(args ...any) (any, error) {
 	synthesizeComp, err := parseITSConvertorArgs(args...)
 	if err != nil {
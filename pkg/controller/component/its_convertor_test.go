@@ -59,5 +59,27 @@ var _ = Describe("Test InstanceSet Convertor", func() {
 			Expect(probe.CustomHandler[0].Command).Should(BeEquivalentTo(command))
 			Expect(probe.CustomHandler[0].Args).Should(BeEquivalentTo(args))
 		})
+
+		It("pins the instances to the node", func() {
+			instance := &appsv1alpha1.InstanceTemplate{
+				Name: "pinned",
+				SchedulingPolicy: &appsv1alpha1.SchedulingPolicy{
+					NodeName:     "node-0",
+					NodeSelector: map[string]string{"zone": "a", "disk": "hdd"},
+				},
+				NodeName:     "node-1",
+				NodeSelector: map[string]string{"disk": "nvme"},
+			}
+			itsInstance := AppsInstanceToWorkloadInstance(instance)
+			Expect(itsInstance.SchedulingPolicy.NodeName).Should(Equal("node-1"))
+			Expect(itsInstance.SchedulingPolicy.NodeSelector).Should(Equal(map[string]string{"zone": "a", "disk": "nvme"}))
+			// the scheduling policy of the instance template is not changed
+			Expect(instance.SchedulingPolicy.NodeSelector["disk"]).Should(Equal("hdd"))
+
+			instance.SchedulingPolicy = nil
+			itsInstance = AppsInstanceToWorkloadInstance(instance)
+			Expect(itsInstance.SchedulingPolicy.NodeName).Should(Equal("node-1"))
+			Expect(itsInstance.SchedulingPolicy.NodeSelector).Should(Equal(map[string]string{"disk": "nvme"}))
+		})
 	})
 })
@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -275,6 +276,9 @@ func buildLorryEnvs(container *corev1.Container, synthesizeComp *SynthesizedComp
 		envs = append(envs, buildEnv4VolumeProtection(synthesizeComp))
 	}
 	envs = append(envs, buildEnv4CronJobs(synthesizeComp)...)
+	if env := buildEnv4Fencing(synthesizeComp); env != nil {
+		envs = append(envs, *env)
+	}
 
 	container.Env = append(container.Env, envs...)
 }
@@ -392,6 +396,20 @@ func buildEnv4VolumeProtection(synthesizedComp *SynthesizedComponent) corev1.Env
 	}
 }
 
+func buildEnv4Fencing(synthesizedComp *SynthesizedComponent) *corev1.EnvVar {
+	if synthesizedComp.Fencing == nil || len(synthesizedComp.Fencing.Strategies) == 0 {
+		return nil
+	}
+	strategies := make([]string, 0, len(synthesizedComp.Fencing.Strategies))
+	for _, strategy := range synthesizedComp.Fencing.Strategies {
+		strategies = append(strategies, string(strategy))
+	}
+	return &corev1.EnvVar{
+		Name:  constant.KBEnvFencingStrategies,
+		Value: strings.Join(strategies, ","),
+	}
+}
+
 func buildEnv4CronJobs(_ *SynthesizedComponent) []corev1.EnvVar {
 	return nil
 	// if synthesizeComp.LifecycleActions == nil || synthesizeComp.LifecycleActions.HealthyCheck == nil {
@@ -441,6 +459,12 @@ func getBuiltinActionHandler(synthesizeComp *SynthesizedComponent) appsv1alpha1.
 		synthesizeComp.LifecycleActions.DataDump,
 		synthesizeComp.LifecycleActions.DataLoad,
 		synthesizeComp.LifecycleActions.Reconfigure,
+		synthesizeComp.LifecycleActions.ReloadTLS,
+		synthesizeComp.LifecycleActions.Fence,
+		synthesizeComp.LifecycleActions.Purge,
+		synthesizeComp.LifecycleActions.AccountRotation,
+		synthesizeComp.LifecycleActions.DrainBackend,
+		synthesizeComp.LifecycleActions.ResumeBackend,
 		// synthesizeComp.LifecycleActions.AccountProvision,
 	}
 
@@ -465,14 +489,23 @@ func getActionCommandsWithExecImageOrContainerName(synthesizeComp *SynthesizedCo
 	}
 
 	actions := map[string]*appsv1alpha1.LifecycleActionHandler{
-		constant.PostProvisionAction: synthesizeComp.LifecycleActions.PostProvision,
-		constant.PreTerminateAction:  synthesizeComp.LifecycleActions.PreTerminate,
-		constant.MemberJoinAction:    synthesizeComp.LifecycleActions.MemberJoin,
-		constant.MemberLeaveAction:   synthesizeComp.LifecycleActions.MemberLeave,
-		constant.ReadonlyAction:      synthesizeComp.LifecycleActions.Readonly,
-		constant.ReadWriteAction:     synthesizeComp.LifecycleActions.Readwrite,
-		constant.DataDumpAction:      synthesizeComp.LifecycleActions.DataDump,
-		constant.DataLoadAction:      synthesizeComp.LifecycleActions.DataLoad,
+		constant.PostProvisionAction:   synthesizeComp.LifecycleActions.PostProvision,
+		constant.PreTerminateAction:    synthesizeComp.LifecycleActions.PreTerminate,
+		constant.MemberJoinAction:      synthesizeComp.LifecycleActions.MemberJoin,
+		constant.MemberLeaveAction:     synthesizeComp.LifecycleActions.MemberLeave,
+		constant.ReadonlyAction:        synthesizeComp.LifecycleActions.Readonly,
+		constant.ReadWriteAction:       synthesizeComp.LifecycleActions.Readwrite,
+		constant.DataDumpAction:        synthesizeComp.LifecycleActions.DataDump,
+		constant.DataLoadAction:        synthesizeComp.LifecycleActions.DataLoad,
+		constant.ReloadTLSAction:       synthesizeComp.LifecycleActions.ReloadTLS,
+		constant.FenceAction:           synthesizeComp.LifecycleActions.Fence,
+		constant.PurgeAction:           synthesizeComp.LifecycleActions.Purge,
+		constant.AccountRotationAction: synthesizeComp.LifecycleActions.AccountRotation,
+		constant.DrainBackendAction:    synthesizeComp.LifecycleActions.DrainBackend,
+		constant.ResumeBackendAction:   synthesizeComp.LifecycleActions.ResumeBackend,
+		constant.UpgradePreCheckAction: synthesizeComp.LifecycleActions.UpgradePreCheck,
+		constant.ShardRebalanceAction:  synthesizeComp.LifecycleActions.ShardRebalance,
+		constant.ReplicationLagAction:  synthesizeComp.LifecycleActions.ReplicationLag,
 		// "reconfigure":                synthesizeComp.LifecycleActions.Reconfigure,
 		// "accountProvision": synthesizeComp.LifecycleActions.AccountProvision,
 	}
@@ -480,6 +513,9 @@ func getActionCommandsWithExecImageOrContainerName(synthesizeComp *SynthesizedCo
 	if synthesizeComp.LifecycleActions.RoleProbe != nil {
 		actions[constant.RoleProbeAction] = &synthesizeComp.LifecycleActions.RoleProbe.LifecycleActionHandler
 	}
+	if synthesizeComp.LifecycleActions.WarmUpCheck != nil {
+		actions[constant.WarmUpCheckAction] = &synthesizeComp.LifecycleActions.WarmUpCheck.LifecycleActionHandler
+	}
 
 	var toolImage string
 	var containerName string
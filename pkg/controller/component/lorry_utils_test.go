@@ -221,6 +221,29 @@ var _ = Describe("Lorry Utils", func() {
 			Expect(spec.Volumes).Should(HaveLen(1))
 			Expect(*spec.Volumes[0].HighWatermark).Should(Equal(90))
 		})
+
+		It("build lorry container with fencing strategies", func() {
+			component.Fencing = &appsv1alpha1.FencingPolicy{
+				Strategies: []appsv1alpha1.FencingStrategy{
+					appsv1alpha1.PodDeletionFencingStrategy,
+					appsv1alpha1.ActionFencingStrategy,
+				},
+			}
+			component.LifecycleActions.Fence = &appsv1alpha1.LifecycleActionHandler{
+				CustomHandler: &appsv1alpha1.Action{
+					Exec: &appsv1alpha1.ExecAction{
+						Command: []string{"fence"},
+					},
+				},
+			}
+			buildLorryServiceContainer(component, container, lorryHTTPPort, lorryGRPCPort, nil)
+			Expect(container.Env).Should(ContainElement(corev1.EnvVar{
+				Name:  constant.KBEnvFencingStrategies,
+				Value: "PodDeletion,Action",
+			}))
+			actionCommands, _, _ := getActionCommandsWithExecImageOrContainerName(component)
+			Expect(actionCommands).Should(HaveKeyWithValue(constant.FenceAction, []string{"fence"}))
+		})
 	})
 })
 
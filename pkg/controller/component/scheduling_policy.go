@@ -0,0 +1,128 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// podSchedulingPolicy holds the scheduling fields of a pod template.
+// Except appending tolerations, the changes of them can't be applied to the running pods in-place.
+type podSchedulingPolicy struct {
+	NodeSelector              map[string]string                 `json:"nodeSelector,omitempty"`
+	Affinity                  *corev1.Affinity                  `json:"affinity,omitempty"`
+	Tolerations               []corev1.Toleration               `json:"tolerations,omitempty"`
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+}
+
+func getPodSchedulingPolicy(podSpec *corev1.PodSpec) podSchedulingPolicy {
+	return podSchedulingPolicy{
+		NodeSelector:              podSpec.NodeSelector,
+		Affinity:                  podSpec.Affinity,
+		Tolerations:               podSpec.Tolerations,
+		TopologySpreadConstraints: podSpec.TopologySpreadConstraints,
+	}
+}
+
+func (p podSchedulingPolicy) applyTo(podSpec *corev1.PodSpec) {
+	podSpec.NodeSelector = p.NodeSelector
+	podSpec.Affinity = p.Affinity
+	podSpec.Tolerations = p.Tolerations
+	podSpec.TopologySpreadConstraints = p.TopologySpreadConstraints
+}
+
+// GetPendingSchedulingFields returns the scheduling fields of the desired pod spec which differ from the running one
+// and can't be applied to the running pods in-place, they take effect only after the pods are recreated.
+func GetPendingSchedulingFields(running, desired *corev1.PodSpec) []string {
+	var fields []string
+	if !equality.Semantic.DeepEqual(running.NodeSelector, desired.NodeSelector) {
+		fields = append(fields, "nodeSelector")
+	}
+	if !equality.Semantic.DeepEqual(running.Affinity, desired.Affinity) {
+		fields = append(fields, "affinity")
+	}
+	// the tolerations can only be appended to the running pods.
+	for _, toleration := range running.Tolerations {
+		if !slices.ContainsFunc(desired.Tolerations, func(t corev1.Toleration) bool {
+			return reflect.DeepEqual(t, toleration)
+		}) {
+			fields = append(fields, "tolerations")
+			break
+		}
+	}
+	if !equality.Semantic.DeepEqual(running.TopologySpreadConstraints, desired.TopologySpreadConstraints) {
+		fields = append(fields, "topologySpreadConstraints")
+	}
+	return fields
+}
+
+// DelaySchedulingPolicyUpdate keeps the scheduling policy of the running pod template in the InstanceSet to avoid
+// recreating the pods, except appending the new tolerations which are applied to the pods in-place.
+// The desired scheduling policy is saved in the annotation of the InstanceSet and applied by the next restart.
+func DelaySchedulingPolicyUpdate(running *corev1.PodSpec, its *workloads.InstanceSet) {
+	desired := &its.Spec.Template.Spec
+	if len(GetPendingSchedulingFields(running, desired)) == 0 {
+		delete(its.Annotations, constant.PendingSchedulingPolicyAnnotationKey)
+		return
+	}
+
+	data, _ := json.Marshal(getPodSchedulingPolicy(desired))
+	if its.Annotations == nil {
+		its.Annotations = map[string]string{}
+	}
+	its.Annotations[constant.PendingSchedulingPolicyAnnotationKey] = string(data)
+
+	policy := getPodSchedulingPolicy(running)
+	policy.Tolerations = slices.Clone(running.Tolerations)
+	intctrlutil.MergeList(&desired.Tolerations, &policy.Tolerations, func(item corev1.Toleration) func(corev1.Toleration) bool {
+		return func(t corev1.Toleration) bool {
+			return reflect.DeepEqual(item, t)
+		}
+	})
+	policy.applyTo(desired)
+}
+
+// ApplyPendingSchedulingPolicy applies the scheduling policy saved by DelaySchedulingPolicyUpdate to the pod template
+// of the workload, it should be called only if the pods are about to be recreated.
+func ApplyPendingSchedulingPolicy(obj client.Object, template *corev1.PodTemplateSpec) error {
+	annotations := obj.GetAnnotations()
+	data, ok := annotations[constant.PendingSchedulingPolicyAnnotationKey]
+	if !ok {
+		return nil
+	}
+	policy := podSchedulingPolicy{}
+	if err := json.Unmarshal([]byte(data), &policy); err != nil {
+		return err
+	}
+	policy.applyTo(&template.Spec)
+	delete(annotations, constant.PendingSchedulingPolicyAnnotationKey)
+	obj.SetAnnotations(annotations)
+	return nil
+}
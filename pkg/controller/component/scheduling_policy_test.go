@@ -0,0 +1,89 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestDelaySchedulingPolicyUpdate(t *testing.T) {
+	oldToleration := corev1.Toleration{Key: "old", Operator: corev1.TolerationOpExists}
+	newToleration := corev1.Toleration{Key: "new", Operator: corev1.TolerationOpExists}
+	running := corev1.PodSpec{
+		NodeSelector: map[string]string{"zone": "a"},
+		Tolerations:  []corev1.Toleration{oldToleration},
+	}
+	newITS := func(spec corev1.PodSpec) *workloads.InstanceSet {
+		return &workloads.InstanceSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec:       workloads.InstanceSetSpec{Template: corev1.PodTemplateSpec{Spec: spec}},
+		}
+	}
+
+	// the appended tolerations are applied in-place.
+	its := newITS(corev1.PodSpec{
+		NodeSelector: map[string]string{"zone": "a"},
+		Tolerations:  []corev1.Toleration{oldToleration, newToleration},
+	})
+	if fields := GetPendingSchedulingFields(&running, &its.Spec.Template.Spec); len(fields) != 0 {
+		t.Errorf("expect no pending fields, but got %v", fields)
+	}
+	DelaySchedulingPolicyUpdate(&running, its)
+	if _, ok := its.Annotations[constant.PendingSchedulingPolicyAnnotationKey]; ok {
+		t.Error("expect no pending scheduling policy")
+	}
+
+	// the changes of the node selector and the removed tolerations are delayed.
+	desired := corev1.PodSpec{
+		NodeSelector: map[string]string{"zone": "b"},
+		Tolerations:  []corev1.Toleration{newToleration},
+	}
+	its = newITS(*desired.DeepCopy())
+	fields := GetPendingSchedulingFields(&running, &its.Spec.Template.Spec)
+	if !reflect.DeepEqual(fields, []string{"nodeSelector", "tolerations"}) {
+		t.Errorf("unexpected pending fields: %v", fields)
+	}
+	DelaySchedulingPolicyUpdate(&running, its)
+	if its.Spec.Template.Spec.NodeSelector["zone"] != "a" {
+		t.Errorf("expect the node selector is kept, but got %v", its.Spec.Template.Spec.NodeSelector)
+	}
+	if !reflect.DeepEqual(its.Spec.Template.Spec.Tolerations, []corev1.Toleration{oldToleration, newToleration}) {
+		t.Errorf("expect the new tolerations are appended, but got %v", its.Spec.Template.Spec.Tolerations)
+	}
+
+	// the pending scheduling policy is applied by the restart.
+	template := its.Spec.Template.DeepCopy()
+	if err := ApplyPendingSchedulingPolicy(its, template); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(template.Spec, desired) {
+		t.Errorf("expect the desired scheduling policy is applied, but got %+v", template.Spec)
+	}
+	if _, ok := its.Annotations[constant.PendingSchedulingPolicyAnnotationKey]; ok {
+		t.Error("expect the pending scheduling policy is removed")
+	}
+}
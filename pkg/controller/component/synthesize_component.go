@@ -25,6 +25,7 @@ import (
 	"strconv"
 	"strings"
 
+	"golang.org/x/exp/slices"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -37,6 +38,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/apiconversion"
 	"github.com/apecloud/kubeblocks/pkg/controller/scheduling"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 var (
@@ -168,8 +170,10 @@ func buildSynthesizedComponent(reqCtx intctrlutil.RequestCtx,
 		Roles:                  compDefObj.Spec.Roles,
 		UpdateStrategy:         compDefObj.Spec.UpdateStrategy,
 		MinReadySeconds:        compDefObj.Spec.MinReadySeconds,
+		WorkloadType:           compDefObj.Spec.WorkloadType,
 		PolicyRules:            compDefObj.Spec.PolicyRules,
 		LifecycleActions:       compDefObj.Spec.LifecycleActions,
+		Fencing:                compDefObj.Spec.Fencing,
 		SystemAccounts:         mergeSystemAccounts(compDefObj.Spec.SystemAccounts, comp.Spec.SystemAccounts),
 		Replicas:               comp.Spec.Replicas,
 		Resources:              comp.Spec.Resources,
@@ -178,6 +182,11 @@ func buildSynthesizedComponent(reqCtx intctrlutil.RequestCtx,
 		Instances:              comp.Spec.Instances,
 		OfflineInstances:       comp.Spec.OfflineInstances,
 		DisableExporter:        comp.Spec.DisableExporter,
+		ReadWriteSplitServices: comp.Spec.ReadWriteSplitServices,
+		CanaryService:          comp.Spec.CanaryService,
+		VolumeSnapshotPolicy:   comp.Spec.VolumeSnapshotPolicy,
+		SharedVolumes:          comp.Spec.SharedVolumes,
+		DNSPolicy:              comp.Spec.DNSPolicy,
 		PodManagementPolicy:    compDef.Spec.PodManagementPolicy,
 	}
 
@@ -214,9 +223,15 @@ func buildSynthesizedComponent(reqCtx intctrlutil.RequestCtx,
 	if err = mergeUserDefinedVolumes(synthesizeComp, comp); err != nil {
 		return nil, err
 	}
+	if err = buildSharedVolumes(synthesizeComp); err != nil {
+		return nil, err
+	}
 
 	limitSharedMemoryVolumeSize(synthesizeComp, comp)
 
+	// build the user-supplied init containers and init scripts
+	buildInitContainers(synthesizeComp, comp)
+
 	// build componentService
 	buildComponentServices(synthesizeComp, comp)
 
@@ -230,6 +245,12 @@ func buildSynthesizedComponent(reqCtx intctrlutil.RequestCtx,
 	// build runtimeClassName
 	buildRuntimeClassName(synthesizeComp, comp)
 
+	// build dnsPolicy and dnsConfig
+	buildDNSConfig(synthesizeComp, comp)
+
+	// build priorityClassName
+	synthesizeComp.PodSpec.PriorityClassName = GetPriorityClassName(comp, compDef)
+
 	// build lorryContainer
 	// TODO(xingran): buildLorryContainers relies on synthesizeComp.CharacterType and synthesizeComp.WorkloadType, which will be deprecated in the future.
 	if err := buildLorryContainers(reqCtx, synthesizeComp, clusterCompSpec); err != nil {
@@ -468,6 +489,48 @@ func mergeUserDefinedVolumes(synthesizedComp *SynthesizedComponent, comp *appsv1
 	return nil
 }
 
+// buildSharedVolumes adds the volumes of the shared PersistentVolumeClaims to the pod spec and mounts them in the containers.
+func buildSharedVolumes(synthesizeComp *SynthesizedComponent) error {
+	if len(synthesizeComp.SharedVolumes) == 0 {
+		return nil
+	}
+	volumes := map[string]bool{}
+	for _, vol := range synthesizeComp.PodSpec.Volumes {
+		volumes[vol.Name] = true
+	}
+	for _, vct := range synthesizeComp.VolumeClaimTemplates {
+		volumes[vct.Name] = true
+	}
+	for _, sv := range synthesizeComp.SharedVolumes {
+		if volumes[sv.Name] {
+			return fmt.Errorf("duplicated volume %s", sv.Name)
+		}
+		volumes[sv.Name] = true
+		synthesizeComp.PodSpec.Volumes = append(synthesizeComp.PodSpec.Volumes, corev1.Volume{
+			Name: sv.Name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: constant.GenerateSharedVolumeClaimName(synthesizeComp.ClusterName, synthesizeComp.Name, sv.Name),
+				},
+			},
+		})
+		if len(sv.MountPath) == 0 {
+			continue
+		}
+		for i := range synthesizeComp.PodSpec.Containers {
+			container := &synthesizeComp.PodSpec.Containers[i]
+			if len(sv.Containers) > 0 && !slices.Contains(sv.Containers, container.Name) {
+				continue
+			}
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      sv.Name,
+				MountPath: sv.MountPath,
+			})
+		}
+	}
+	return nil
+}
+
 // limitSharedMemoryVolumeSize limits the shared memory volume size to memory requests/limits.
 func limitSharedMemoryVolumeSize(synthesizeComp *SynthesizedComponent, comp *appsv1alpha1.Component) {
 	shm := defaultShmQuantity
@@ -600,6 +663,27 @@ func buildRuntimeClassName(synthesizeComp *SynthesizedComponent, comp *appsv1alp
 	synthesizeComp.PodSpec.RuntimeClassName = comp.Spec.RuntimeClassName
 }
 
+func buildDNSConfig(synthesizeComp *SynthesizedComponent, comp *appsv1alpha1.Component) {
+	if comp.Spec.DNSPolicy != nil {
+		synthesizeComp.PodSpec.DNSPolicy = *comp.Spec.DNSPolicy
+	}
+	if comp.Spec.DNSConfig != nil {
+		synthesizeComp.PodSpec.DNSConfig = comp.Spec.DNSConfig.DeepCopy()
+	}
+}
+
+// GetPriorityClassName returns the name of the PriorityClass for the Pods of the Component, which is specified by the Component,
+// the ComponentDefinition, or the data plane config of KubeBlocks in order.
+func GetPriorityClassName(comp *appsv1alpha1.Component, compDef *appsv1alpha1.ComponentDefinition) string {
+	if comp.Spec.PriorityClassName != "" {
+		return comp.Spec.PriorityClassName
+	}
+	if compDef.Spec.Runtime.PriorityClassName != "" {
+		return compDef.Spec.Runtime.PriorityClassName
+	}
+	return viper.GetString(constant.CfgKeyDataPlanePriorityClassName)
+}
+
 // buildBackwardCompatibleFields builds backward compatible fields for component which referenced a clusterComponentDefinition and clusterComponentVersion
 // TODO(xingran): it will be removed in the future
 func buildBackwardCompatibleFields(reqCtx intctrlutil.RequestCtx,
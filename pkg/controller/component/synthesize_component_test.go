@@ -30,6 +30,7 @@ import (
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 var _ = Describe("synthesized component", func() {
@@ -342,4 +343,197 @@ var _ = Describe("synthesized component", func() {
 			Expect(synthesizedComp.PodSpec.Volumes[3].Name).Should(Equal("not-defined"))
 		})
 	})
+
+	Context("shared volumes", func() {
+		BeforeEach(func() {
+			compDef = &appsv1alpha1.ComponentDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-compdef",
+				},
+				Spec: appsv1alpha1.ComponentDefinitionSpec{
+					Runtime: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: "app",
+								VolumeMounts: []corev1.VolumeMount{
+									{
+										Name:      "archive",
+										MountPath: "/archive",
+									},
+								},
+							},
+							{
+								Name: "sidecar",
+							},
+						},
+					},
+				},
+			}
+			comp = &appsv1alpha1.Component{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-cluster-comp",
+					Labels: map[string]string{
+						constant.AppInstanceLabelKey:     "test-cluster",
+						constant.KBAppClusterUIDLabelKey: "uuid",
+					},
+					Annotations: map[string]string{
+						constant.KubeBlocksGenerationKey: "1",
+					},
+				},
+				Spec: appsv1alpha1.ComponentSpec{
+					SharedVolumes: []appsv1alpha1.SharedVolume{
+						{
+							Name: "archive",
+						},
+						{
+							Name:       "plugins",
+							MountPath:  "/plugins",
+							Containers: []string{"app"},
+						},
+					},
+				},
+			}
+		})
+
+		It("duplicated", func() {
+			comp.Spec.Volumes = []corev1.Volume{{Name: "archive"}}
+
+			_, err := buildSynthesizedComponent(reqCtx, cli, compDef, comp, nil, nil, nil)
+			Expect(err).ShouldNot(BeNil())
+			Expect(err.Error()).Should(ContainSubstring("duplicated volume"))
+		})
+
+		It("ok", func() {
+			synthesizedComp, err := buildSynthesizedComponent(reqCtx, cli, compDef, comp, nil, nil, nil)
+			Expect(err).Should(BeNil())
+			Expect(synthesizedComp).ShouldNot(BeNil())
+			Expect(synthesizedComp.PodSpec.Volumes).Should(HaveLen(2))
+			Expect(synthesizedComp.PodSpec.Volumes[0].Name).Should(Equal("archive"))
+			Expect(synthesizedComp.PodSpec.Volumes[0].PersistentVolumeClaim.ClaimName).Should(Equal("test-cluster-comp-archive"))
+			Expect(synthesizedComp.PodSpec.Volumes[1].Name).Should(Equal("plugins"))
+			Expect(synthesizedComp.PodSpec.Volumes[1].PersistentVolumeClaim.ClaimName).Should(Equal("test-cluster-comp-plugins"))
+
+			By("the volume is mounted at the mount path in the specified containers only")
+			Expect(synthesizedComp.PodSpec.Containers[0].VolumeMounts).Should(HaveLen(2))
+			Expect(synthesizedComp.PodSpec.Containers[0].VolumeMounts[1].Name).Should(Equal("plugins"))
+			Expect(synthesizedComp.PodSpec.Containers[0].VolumeMounts[1].MountPath).Should(Equal("/plugins"))
+			Expect(synthesizedComp.PodSpec.Containers[1].VolumeMounts).Should(BeEmpty())
+		})
+	})
+
+	Context("dns config", func() {
+		BeforeEach(func() {
+			compDef = &appsv1alpha1.ComponentDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-compdef",
+				},
+				Spec: appsv1alpha1.ComponentDefinitionSpec{
+					Runtime: corev1.PodSpec{
+						DNSPolicy: corev1.DNSClusterFirst,
+						Containers: []corev1.Container{
+							{
+								Name: "app",
+							},
+						},
+					},
+				},
+			}
+			comp = &appsv1alpha1.Component{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-cluster-comp",
+					Labels: map[string]string{
+						constant.AppInstanceLabelKey:     "test-cluster",
+						constant.KBAppClusterUIDLabelKey: "uuid",
+					},
+					Annotations: map[string]string{
+						constant.KubeBlocksGenerationKey: "1",
+					},
+				},
+				Spec: appsv1alpha1.ComponentSpec{},
+			}
+		})
+
+		It("comp def", func() {
+			synthesizedComp, err := buildSynthesizedComponent(reqCtx, cli, compDef, comp, nil, nil, nil)
+			Expect(err).Should(BeNil())
+			Expect(synthesizedComp).ShouldNot(BeNil())
+			Expect(synthesizedComp.PodSpec.DNSPolicy).Should(Equal(corev1.DNSClusterFirst))
+			Expect(synthesizedComp.PodSpec.DNSConfig).Should(BeNil())
+		})
+
+		It("w/ comp override", func() {
+			dnsPolicy := corev1.DNSNone
+			ndots := "2"
+			comp.Spec.DNSPolicy = &dnsPolicy
+			comp.Spec.DNSConfig = &corev1.PodDNSConfig{
+				Nameservers: []string{"10.96.0.10"},
+				Searches:    []string{"default.svc.cluster.local", "svc.cluster.local"},
+				Options:     []corev1.PodDNSConfigOption{{Name: "ndots", Value: &ndots}},
+			}
+			synthesizedComp, err := buildSynthesizedComponent(reqCtx, cli, compDef, comp, nil, nil, nil)
+			Expect(err).Should(BeNil())
+			Expect(synthesizedComp).ShouldNot(BeNil())
+			Expect(synthesizedComp.PodSpec.DNSPolicy).Should(Equal(corev1.DNSNone))
+			Expect(synthesizedComp.PodSpec.DNSConfig).Should(BeEquivalentTo(comp.Spec.DNSConfig))
+		})
+	})
+
+	Context("priority class", func() {
+		BeforeEach(func() {
+			compDef = &appsv1alpha1.ComponentDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-compdef",
+				},
+				Spec: appsv1alpha1.ComponentDefinitionSpec{
+					Runtime: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name: "app",
+							},
+						},
+					},
+				},
+			}
+			comp = &appsv1alpha1.Component{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-cluster-comp",
+					Labels: map[string]string{
+						constant.AppInstanceLabelKey:     "test-cluster",
+						constant.KBAppClusterUIDLabelKey: "uuid",
+					},
+					Annotations: map[string]string{
+						constant.KubeBlocksGenerationKey: "1",
+					},
+				},
+				Spec: appsv1alpha1.ComponentSpec{},
+			}
+		})
+
+		AfterEach(func() {
+			viper.Set(constant.CfgKeyDataPlanePriorityClassName, "")
+		})
+
+		It("data plane default", func() {
+			viper.Set(constant.CfgKeyDataPlanePriorityClassName, "kb-data-plane")
+			synthesizedComp, err := buildSynthesizedComponent(reqCtx, cli, compDef, comp, nil, nil, nil)
+			Expect(err).Should(BeNil())
+			Expect(synthesizedComp.PodSpec.PriorityClassName).Should(Equal("kb-data-plane"))
+		})
+
+		It("comp def", func() {
+			viper.Set(constant.CfgKeyDataPlanePriorityClassName, "kb-data-plane")
+			compDef.Spec.Runtime.PriorityClassName = "compdef-priority"
+			synthesizedComp, err := buildSynthesizedComponent(reqCtx, cli, compDef, comp, nil, nil, nil)
+			Expect(err).Should(BeNil())
+			Expect(synthesizedComp.PodSpec.PriorityClassName).Should(Equal("compdef-priority"))
+		})
+
+		It("w/ comp override", func() {
+			compDef.Spec.Runtime.PriorityClassName = "compdef-priority"
+			comp.Spec.PriorityClassName = "database-critical"
+			synthesizedComp, err := buildSynthesizedComponent(reqCtx, cli, compDef, comp, nil, nil, nil)
+			Expect(err).Should(BeNil())
+			Expect(synthesizedComp.PodSpec.PriorityClassName).Should(Equal("database-critical"))
+		})
+	})
 })
@@ -0,0 +1,40 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"strings"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+)
+
+// GenerateAccountPassword generates a password for the system account with its password generation policy.
+func GenerateAccountPassword(account appsv1alpha1.SystemAccount) []byte {
+	config := account.PasswordGenerationPolicy
+	passwd, _ := common.GeneratePassword((int)(config.Length), (int)(config.NumDigits), (int)(config.NumSymbols), false, config.Seed)
+	switch config.LetterCase {
+	case appsv1alpha1.UpperCases:
+		passwd = strings.ToUpper(passwd)
+	case appsv1alpha1.LowerCases:
+		passwd = strings.ToLower(passwd)
+	}
+	return []byte(passwd)
+}
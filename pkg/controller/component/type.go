@@ -63,13 +63,20 @@ type SynthesizedComponent struct {
 	PodManagementPolicy    *appsv1.PodManagementPolicyType     `json:"podManagementPolicy,omitempty"`
 	PolicyRules            []rbacv1.PolicyRule                 `json:"policyRules,omitempty"`
 	LifecycleActions       *v1alpha1.ComponentLifecycleActions `json:"lifecycleActions,omitempty"`
+	Fencing                *v1alpha1.FencingPolicy             `json:"fencing,omitempty"`
 	SystemAccounts         []v1alpha1.SystemAccount            `json:"systemAccounts,omitempty"`
 	Volumes                []v1alpha1.ComponentVolume          `json:"volumes,omitempty"`
 	HostNetwork            *v1alpha1.HostNetwork               `json:"hostNetwork,omitempty"`
 	ComponentServices      []v1alpha1.ComponentService         `json:"componentServices,omitempty"`
 	MinReadySeconds        int32                               `json:"minReadySeconds,omitempty"`
+	WorkloadType           v1alpha1.ComponentWorkloadType      `json:"workloadType,omitempty"`
 	Sidecars               []string                            `json:"sidecars,omitempty"`
 	DisableExporter        *bool                               `json:"disableExporter,omitempty"`
+	ReadWriteSplitServices *bool                               `json:"readWriteSplitServices,omitempty"`
+	CanaryService          *bool                               `json:"canaryService,omitempty"`
+	VolumeSnapshotPolicy   *v1alpha1.VolumeSnapshotPolicy      `json:"volumeSnapshotPolicy,omitempty"`
+	SharedVolumes          []v1alpha1.SharedVolume             `json:"sharedVolumes,omitempty"`
+	DNSPolicy              *corev1.DNSPolicy                   `json:"dnsPolicy,omitempty"`
 
 	// TODO(xingran): The following fields will be deprecated after KubeBlocks version 0.8.0
 	ClusterDefName        string                          `json:"clusterDefName,omitempty"`     // the name of the clusterDefinition
@@ -309,3 +309,13 @@ func configSetFromComponent(templates []appsv1alpha1.ComponentConfigSpec) []stri
 	}
 	return configSet
 }
+
+func secretParamsSetFromComponent(templates []appsv1alpha1.ComponentConfigSpec) []string {
+	secretParamsSet := make([]string, 0)
+	for _, template := range templates {
+		if template.AllowSecretParameters {
+			secretParamsSet = append(secretParamsSet, template.Name)
+		}
+	}
+	return secretParamsSet
+}
@@ -36,10 +36,11 @@ func DoMerge(baseData map[string]string, patch map[string]appsv1alpha1.ConfigPar
 		if params.Content != nil {
 			updatedFiles[key] = *params.Content
 		}
-		if len(params.Parameters) > 0 {
+		// the secret-backed parameters are masked, and rendered into the Secret of the configuration
+		if len(params.Parameters) > 0 || len(params.ParametersFrom) > 0 {
 			updatedParams = append(updatedParams, core.ParamPairs{
 				Key:           key,
-				UpdatedParams: core.FromStringMap(params.Parameters),
+				UpdatedParams: core.FromStringMap(maskSecretParameters(params)),
 			})
 		}
 	}
@@ -181,7 +181,8 @@ func (p *pipeline) UpdatePodVolumes() *pipeline {
 	return p.Wrap(func() error {
 		return intctrlutil.CreateOrUpdatePodVolumes(p.ctx.PodSpec,
 			p.renderWrapper.volumes,
-			configSetFromComponent(p.ctx.SynthesizedComponent.ConfigTemplates))
+			configSetFromComponent(p.ctx.SynthesizedComponent.ConfigTemplates),
+			secretParamsSetFromComponent(p.ctx.SynthesizedComponent.ConfigTemplates))
 	})
 }
 
@@ -370,6 +371,11 @@ func (p *updatePipeline) Sync() *updatePipeline {
 				return err
 			}
 		}
+		if p.configSpec.AllowSecretParameters && p.newCM != nil && !p.isDone() {
+			if err := syncSecretParameters(p.Context, p.Client, p.newCM, &p.item, p.ConfigConstraintObj, *p.configSpec); err != nil {
+				return err
+			}
+		}
 		switch {
 		case p.isDone():
 			return nil
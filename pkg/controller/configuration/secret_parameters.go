@@ -0,0 +1,179 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package configuration
+
+import (
+	"context"
+
+	"golang.org/x/exp/maps"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	appsv1beta1 "github.com/apecloud/kubeblocks/apis/apps/v1beta1"
+	"github.com/apecloud/kubeblocks/pkg/configuration/core"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+)
+
+// SecretParameterMask is the value of the secret-backed parameters in the ConfigMap,
+// the actual values are only rendered into the Secret with the same name as the ConfigMap.
+const SecretParameterMask = "******"
+
+// HasSecretParameters checks whether the configuration item has parameters referencing Secrets.
+func HasSecretParameters(item appsv1alpha1.ConfigurationItemDetail) bool {
+	for _, params := range item.ConfigFileParams {
+		if len(params.ParametersFrom) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// maskSecretParameters returns the updated parameters of a configuration file,
+// with the values of the secret-backed parameters masked.
+func maskSecretParameters(params appsv1alpha1.ConfigParams) map[string]*string {
+	if len(params.ParametersFrom) == 0 {
+		return params.Parameters
+	}
+	masked := make(map[string]*string, len(params.Parameters)+len(params.ParametersFrom))
+	maps.Copy(masked, params.Parameters)
+	for key := range params.ParametersFrom {
+		masked[key] = pointer.String(SecretParameterMask)
+	}
+	return masked
+}
+
+func resolveSecretParameters(ctx context.Context, cli client.Client, namespace string, parametersFrom map[string]appsv1alpha1.ParameterValueSource) (map[string]*string, error) {
+	values := make(map[string]*string, len(parametersFrom))
+	for key, source := range parametersFrom {
+		ref := source.SecretKeyRef
+		if ref == nil {
+			continue
+		}
+		optional := ref.Optional != nil && *ref.Optional
+		secret := &corev1.Secret{}
+		if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+			if apierrors.IsNotFound(err) && optional {
+				// the parameter is removed from the configuration file if the optional secret is absent
+				values[key] = nil
+				continue
+			}
+			return nil, core.WrapError(err, "failed to get the secret[%s] referenced by parameter[%s]", ref.Name, key)
+		}
+		value, ok := secret.Data[ref.Key]
+		switch {
+		case ok:
+			values[key] = pointer.String(string(value))
+		case optional:
+			values[key] = nil
+		default:
+			return nil, core.MakeError("the key[%s] of secret[%s] referenced by parameter[%s] is not found", ref.Key, ref.Name, key)
+		}
+	}
+	return values, nil
+}
+
+// buildSecretParameters renders the configuration files with the actual values of the secret-backed parameters,
+// and returns the Secret that holds them, or nil if the item has no secret-backed parameter.
+func buildSecretParameters(ctx context.Context, cli client.Client,
+	cm *corev1.ConfigMap,
+	item *appsv1alpha1.ConfigurationItemDetail,
+	cc *appsv1beta1.ConfigConstraint,
+	configSpec appsv1alpha1.ComponentConfigSpec) (*corev1.Secret, error) {
+	// the parameters are only merged into the configuration files with the ConfigConstraint
+	if item == nil || cc == nil || !configSpec.AllowSecretParameters {
+		return nil, nil
+	}
+
+	updatedParams := make([]core.ParamPairs, 0)
+	for key, params := range item.ConfigFileParams {
+		if len(params.ParametersFrom) == 0 {
+			continue
+		}
+		values, err := resolveSecretParameters(ctx, cli, cm.Namespace, params.ParametersFrom)
+		if err != nil {
+			return nil, err
+		}
+		updatedParams = append(updatedParams, core.ParamPairs{
+			Key:           key,
+			UpdatedParams: core.FromStringMap(values),
+		})
+	}
+	if len(updatedParams) == 0 {
+		return nil, nil
+	}
+
+	renderedData, err := mergeUpdatedParams(cm.Data, nil, updatedParams, cc, configSpec)
+	if err != nil {
+		return nil, err
+	}
+	data := make(map[string]string, len(updatedParams))
+	for _, params := range updatedParams {
+		data[params.Key] = renderedData[params.Key]
+	}
+	secret := builder.NewSecretBuilder(cm.Namespace, cm.Name).
+		AddLabelsInMap(cm.Labels).
+		SetStringData(data).
+		GetObject()
+	secret.OwnerReferences = cm.OwnerReferences
+	return secret, nil
+}
+
+// syncSecretParameters creates or updates the Secret of the secret-backed parameters for the ConfigMap,
+// and deletes it once there is no secret-backed parameter.
+func syncSecretParameters(ctx context.Context, cli client.Client,
+	cm *corev1.ConfigMap,
+	item *appsv1alpha1.ConfigurationItemDetail,
+	cc *appsv1beta1.ConfigConstraint,
+	configSpec appsv1alpha1.ComponentConfigSpec) error {
+	expected, err := buildSecretParameters(ctx, cli, cm, item, cc, configSpec)
+	if err != nil {
+		return err
+	}
+
+	existing := &corev1.Secret{}
+	err = cli.Get(ctx, client.ObjectKeyFromObject(cm), existing, inDataContext())
+	switch {
+	case apierrors.IsNotFound(err):
+		if expected == nil {
+			return nil
+		}
+		return cli.Create(ctx, expected, inDataContext())
+	case err != nil:
+		return err
+	case expected == nil:
+		if !isControlledBySameOwner(existing, cm) {
+			return nil
+		}
+		return cli.Delete(ctx, existing, inDataContext())
+	default:
+		existing.Data = nil
+		existing.StringData = expected.StringData
+		return cli.Update(ctx, existing, inDataContext())
+	}
+}
+
+func isControlledBySameOwner(obj, cm client.Object) bool {
+	owner := metav1.GetControllerOf(cm)
+	return owner != nil && metav1.IsControlledBy(obj, &metav1.ObjectMeta{UID: owner.UID})
+}
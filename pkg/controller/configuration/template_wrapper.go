@@ -129,7 +129,28 @@ func (wrapper *renderWrapper) renderConfigTemplate(cluster *appsv1alpha1.Cluster
 		if err := updateConfigMetaForCM(newCMObj, item, revision); err != nil {
 			return err
 		}
+		if err := wrapper.addSecretParameters(configSpec, newCMObj, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addSecretParameters adds the Secret holding the files rendered with the secret-backed parameters
+// into the rendered objects, which is created along with the ConfigMap.
+func (wrapper *renderWrapper) addSecretParameters(configSpec appsv1alpha1.ComponentConfigSpec, cm *corev1.ConfigMap, item *appsv1alpha1.ConfigurationItemDetail) error {
+	if !configSpec.AllowSecretParameters || item == nil || !HasSecretParameters(*item) || configSpec.ConfigConstraintRef == "" {
+		return nil
+	}
+	configConstraint, err := fetchConfigConstraint(configSpec.ConfigConstraintRef, wrapper.ctx, wrapper.cli)
+	if err != nil {
+		return err
+	}
+	secret, err := buildSecretParameters(wrapper.ctx, wrapper.cli, cm, item, configConstraint, configSpec)
+	if err != nil || secret == nil {
+		return err
 	}
+	wrapper.renderedObjs = append(wrapper.renderedObjs, secret)
 	return nil
 }
 
@@ -24,12 +24,14 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"path/filepath"
 	"strconv"
 	"strings"
 
 	"github.com/google/uuid"
 	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -134,6 +136,59 @@ func BuildInstanceSet(synthesizedComp *component.SynthesizedComponent, component
 	return itsObj, nil
 }
 
+// BuildTaskJob builds a Job object from SynthesizedComponent for the run-to-completion component,
+// each replica of the component corresponds to one completion of the Job.
+func BuildTaskJob(synthesizedComp *component.SynthesizedComponent) *batchv1.Job {
+	var (
+		compDefName = synthesizedComp.CompDefName
+		clusterName = synthesizedComp.ClusterName
+		compName    = synthesizedComp.Name
+	)
+	labels := constant.GetKBWellKnownLabelsWithCompDef(compDefName, clusterName, compName)
+	compDefLabel := constant.GetComponentDefLabel(compDefName)
+	mergeLabels := intctrlutil.MergeMetadataMaps(labels, compDefLabel, synthesizedComp.Labels)
+	mergeAnnotations := intctrlutil.MergeMetadataMaps(
+		constant.GetKBGenerationAnnotation(synthesizedComp.ClusterGeneration),
+		compDefLabel,
+		constant.GetServiceVersionAnnotation(synthesizedComp.ServiceVersion),
+		synthesizedComp.Annotations,
+	)
+
+	podBuilder := builder.NewPodBuilder("", "").
+		AddLabelsInMap(synthesizedComp.Labels).
+		AddLabelsInMap(labels).
+		AddLabelsInMap(compDefLabel).
+		AddLabelsInMap(constant.GetAppVersionLabel(compDefName)).
+		AddLabelsInMap(synthesizedComp.UserDefinedLabels).
+		AddAnnotationsInMap(synthesizedComp.UserDefinedAnnotations)
+	template := corev1.PodTemplateSpec{
+		ObjectMeta: podBuilder.GetObject().ObjectMeta,
+		Spec:       *synthesizedComp.PodSpec.DeepCopy(),
+	}
+	if template.Spec.RestartPolicy != corev1.RestartPolicyNever {
+		template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
+	}
+	for _, cc := range []*[]corev1.Container{&template.Spec.Containers, &template.Spec.InitContainers} {
+		for i := range *cc {
+			intctrlutil.InjectZeroResourcesLimitsIfEmpty(&(*cc)[i])
+		}
+	}
+
+	jobName := constant.GenerateWorkloadNamePattern(clusterName, compName)
+	job := builder.NewJobBuilder(synthesizedComp.Namespace, jobName).
+		AddLabelsInMap(mergeLabels).
+		AddAnnotationsInMap(mergeAnnotations).
+		SetPodTemplateSpec(template).
+		SetCompletions(synthesizedComp.Replicas).
+		SetParallelism(synthesizedComp.Replicas).
+		GetObject()
+	// the spec of a Job is immutable, the hash tells whether the Job needs to be recreated.
+	hf := fnv.New32()
+	instanceset.DeepHashObject(hf, job.Spec)
+	job.Annotations[constant.TaskJobSpecHashAnnotationKey] = rand.SafeEncodeString(fmt.Sprint(hf.Sum32()))
+	return job
+}
+
 func vctToPVC(vct corev1.PersistentVolumeClaimTemplate) corev1.PersistentVolumeClaim {
 	return corev1.PersistentVolumeClaim{
 		ObjectMeta: vct.ObjectMeta,
@@ -351,12 +406,30 @@ func BuildPVC(cluster *appsv1alpha1.Cluster,
 	return pvc
 }
 
+// BuildSharedVolumeClaim builds the PVC shared by all the instances of the component.
+func BuildSharedVolumeClaim(synthesizedComp *component.SynthesizedComponent, sharedVolume appsv1alpha1.SharedVolume) *corev1.PersistentVolumeClaim {
+	pvcSpec := sharedVolume.Spec.ToV1PersistentVolumeClaimSpec()
+	if len(pvcSpec.AccessModes) == 0 {
+		pvcSpec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}
+	}
+	pvcName := constant.GenerateSharedVolumeClaimName(synthesizedComp.ClusterName, synthesizedComp.Name, sharedVolume.Name)
+	return builder.NewPVCBuilder(synthesizedComp.Namespace, pvcName).
+		AddLabelsInMap(constant.GetComponentWellKnownLabels(synthesizedComp.ClusterName, synthesizedComp.Name)).
+		AddLabels(constant.SharedVolumeNameLabelKey, sharedVolume.Name).
+		SetSpec(pvcSpec).
+		GetObject()
+}
+
 func BuildBackup(cluster *appsv1alpha1.Cluster,
 	component *component.SynthesizedComponent,
 	backupPolicyName string,
 	backupKey types.NamespacedName,
 	backupMethod string) *dpv1alpha1.Backup {
-	return builder.NewBackupBuilder(backupKey.Namespace, backupKey.Name).
+	backupBuilder := builder.NewBackupBuilder(backupKey.Namespace, backupKey.Name)
+	if component.VolumeSnapshotPolicy != nil && len(component.VolumeSnapshotPolicy.VolumeSnapshotClassName) > 0 {
+		backupBuilder.AddAnnotations(dptypes.VolumeSnapshotClassAnnotationKey, component.VolumeSnapshotPolicy.VolumeSnapshotClassName)
+	}
+	return backupBuilder.
 		AddLabels(dptypes.BackupMethodLabelKey, backupMethod).
 		AddLabels(dptypes.BackupPolicyLabelKey, backupPolicyName).
 		AddLabels(constant.KBManagedByKey, "cluster").
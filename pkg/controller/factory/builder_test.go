@@ -335,6 +335,33 @@ var _ = Describe("builder", func() {
 			Expect(*its.Spec.MemberUpdateStrategy).Should(BeEquivalentTo(workloads.BestEffortParallelUpdateStrategy))
 		})
 
+		It("builds task Job correctly", func() {
+			_, _, synthesizedComponent := newClusterObjs(nil)
+			synthesizedComponent.Replicas = 2
+
+			job := BuildTaskJob(synthesizedComponent)
+			Expect(job).ShouldNot(BeNil())
+			Expect(job.Name).Should(Equal(constant.GenerateWorkloadNamePattern(clusterName, mysqlCompName)))
+			Expect(*job.Spec.Completions).Should(BeEquivalentTo(2))
+			Expect(*job.Spec.Parallelism).Should(BeEquivalentTo(2))
+			Expect(job.Spec.Template.Spec.RestartPolicy).Should(Equal(corev1.RestartPolicyOnFailure))
+			Expect(job.Spec.Template.Labels).Should(HaveKeyWithValue(constant.KBAppComponentLabelKey, mysqlCompName))
+			hash := job.Annotations[constant.TaskJobSpecHashAnnotationKey]
+			Expect(hash).ShouldNot(BeEmpty())
+
+			By("the spec hash is stable")
+			Expect(BuildTaskJob(synthesizedComponent).Annotations[constant.TaskJobSpecHashAnnotationKey]).Should(Equal(hash))
+
+			By("the spec hash changes with the replicas")
+			synthesizedComponent.Replicas = 3
+			Expect(BuildTaskJob(synthesizedComponent).Annotations[constant.TaskJobSpecHashAnnotationKey]).ShouldNot(Equal(hash))
+
+			By("the restart policy Never is kept")
+			synthesizedComponent.PodSpec.RestartPolicy = corev1.RestartPolicyNever
+			job = BuildTaskJob(synthesizedComponent)
+			Expect(job.Spec.Template.Spec.RestartPolicy).Should(Equal(corev1.RestartPolicyNever))
+		})
+
 		It("builds BackupJob correctly", func() {
 			_, cluster, synthesizedComponent := newClusterObjs(nil)
 			backupJobKey := types.NamespacedName{
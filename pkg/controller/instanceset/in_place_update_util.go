@@ -217,6 +217,16 @@ func equalField(old, new any) bool {
 		}
 		return true
 	case []corev1.Toleration:
+		// the tolerations can only be appended in-place, the removed ones are kept in the pod until it is recreated.
+		ots := o
+		nts, _ := new.([]corev1.Toleration)
+		for _, nt := range nts {
+			if !slices.ContainsFunc(ots, func(ot corev1.Toleration) bool {
+				return reflect.DeepEqual(ot, nt)
+			}) {
+				return false
+			}
+		}
 		return true
 	case corev1.ResourceList:
 		or := o
@@ -91,6 +91,18 @@ var _ = Describe("instance util test", func() {
 			newPod.Spec.Tolerations = append(newPod.Spec.Tolerations, oldPod.Spec.Tolerations...)
 			mergeInPlaceFields(newPod, oldPod)
 			Expect(equalBasicInPlaceFields(oldPod, newPod)).Should(BeTrue())
+
+			By("build new pod with tolerations appended")
+			newPod = oldPod.DeepCopy()
+			newPod.Spec.Tolerations = append(newPod.Spec.Tolerations, buildRandomPod().Spec.Tolerations...)
+			Expect(equalBasicInPlaceFields(oldPod, newPod)).Should(BeFalse())
+			mergeInPlaceFields(newPod, oldPod)
+			Expect(equalBasicInPlaceFields(oldPod, newPod)).Should(BeTrue())
+
+			By("build new pod with tolerations removed")
+			newPod = oldPod.DeepCopy()
+			newPod.Spec.Tolerations = nil
+			Expect(equalBasicInPlaceFields(oldPod, newPod)).Should(BeTrue())
 		})
 	})
 
@@ -455,6 +455,12 @@ func validateSpec(its *workloads.InstanceSet, tree *kubebuilderx.ObjectTree) err
 		}
 		return err
 	}
+	// the revision to roll back to should exist
+	if its.Spec.RollbackTo != nil && tree != nil && findTemplateRevision(tree, its.Spec.RollbackTo.Revision) == nil {
+		err = fmt.Errorf("the revision %d to roll back to is not found", its.Spec.RollbackTo.Revision)
+		tree.EventRecorder.Event(its, corev1.EventTypeWarning, EventReasonInvalidSpec, err.Error())
+		return err
+	}
 
 	return nil
 }
@@ -536,6 +542,39 @@ func getInstanceTemplateMap(annotations map[string]string) (map[string]string, e
 	return templateMap, nil
 }
 
+// GetNodeSelectorOnce parses the node selectors of the pods specified by the NodeSelectorOnceAnnotationKey annotation.
+func GetNodeSelectorOnce(annotations map[string]string) (map[string]map[string]string, error) {
+	value, ok := annotations[constant.NodeSelectorOnceAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	nodeSelectors := make(map[string]map[string]string)
+	if err := json.Unmarshal([]byte(value), &nodeSelectors); err != nil {
+		return nil, err
+	}
+	return nodeSelectors, nil
+}
+
+// mergeNodeSelectorOnce merges the node selector specified for the pod into its spec,
+// it only takes effect when the pod is created and never triggers an update of the existing pod.
+func mergeNodeSelectorOnce(its *workloads.InstanceSet, pod *corev1.Pod) error {
+	nodeSelectors, err := GetNodeSelectorOnce(its.Annotations)
+	if err != nil {
+		return err
+	}
+	nodeSelector, ok := nodeSelectors[pod.Name]
+	if !ok {
+		return nil
+	}
+	if pod.Spec.NodeSelector == nil {
+		pod.Spec.NodeSelector = map[string]string{}
+	}
+	for k, v := range nodeSelector {
+		pod.Spec.NodeSelector[k] = v
+	}
+	return nil
+}
+
 func getInstanceTemplates(instances []workloads.InstanceTemplate, template *corev1.ConfigMap) []workloads.InstanceTemplate {
 	if template == nil {
 		return instances
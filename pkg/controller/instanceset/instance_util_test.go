@@ -211,6 +211,27 @@ var _ = Describe("instance util test", func() {
 		})
 	})
 
+	Context("mergeNodeSelectorOnce", func() {
+		It("should work well", func() {
+			pod := builder.NewPodBuilder(namespace, name+"-0").GetObject()
+			Expect(mergeNodeSelectorOnce(its, pod)).Should(Succeed())
+			Expect(pod.Spec.NodeSelector).Should(BeEmpty())
+
+			its.Annotations = map[string]string{
+				constant.NodeSelectorOnceAnnotationKey: fmt.Sprintf(`{"%s-0":{"kubernetes.io/hostname":"node-1"}}`, name),
+			}
+			Expect(mergeNodeSelectorOnce(its, pod)).Should(Succeed())
+			Expect(pod.Spec.NodeSelector).Should(HaveKeyWithValue(corev1.LabelHostname, "node-1"))
+
+			pod = builder.NewPodBuilder(namespace, name+"-1").GetObject()
+			Expect(mergeNodeSelectorOnce(its, pod)).Should(Succeed())
+			Expect(pod.Spec.NodeSelector).Should(BeEmpty())
+
+			its.Annotations[constant.NodeSelectorOnceAnnotationKey] = "invalid"
+			Expect(mergeNodeSelectorOnce(its, pod)).ShouldNot(Succeed())
+		})
+	})
+
 	Context("buildInstancePVCByTemplate", func() {
 		It("should work well", func() {
 			itsExt, err := buildInstanceSetExt(its, nil)
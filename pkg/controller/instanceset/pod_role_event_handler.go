@@ -26,6 +26,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -211,6 +212,7 @@ func updatePodRoleLabel(cli client.Client, reqCtx intctrlutil.RequestCtx,
 
 	// update pod role label
 	patch := client.MergeFrom(pod.DeepCopy())
+	lastRoleName := pod.Labels[RoleLabelKey]
 	role, ok := roleMap[roleName]
 	switch ok {
 	case true:
@@ -225,6 +227,9 @@ func updatePodRoleLabel(cli client.Client, reqCtx intctrlutil.RequestCtx,
 		pod.Annotations = map[string]string{}
 	}
 	pod.Annotations[constant.LastRoleSnapshotVersionAnnotationKey] = version
+	if lastRoleName != pod.Labels[RoleLabelKey] {
+		pod.Annotations[constant.LastRoleUpdateTimestampAnnotationKey] = time.Now().Format(time.RFC3339Nano)
+	}
 	return cli.Patch(ctx, pod, patch, inDataContext())
 }
 
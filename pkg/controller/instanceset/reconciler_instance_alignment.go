@@ -122,6 +122,9 @@ func (r *instanceAlignmentReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (
 		if err != nil {
 			return nil, err
 		}
+		if err := mergeNodeSelectorOnce(its, inst.pod); err != nil {
+			return nil, err
+		}
 		if err := tree.Add(inst.pod); err != nil {
 			return nil, err
 		}
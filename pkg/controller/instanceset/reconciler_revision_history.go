@@ -0,0 +1,152 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package instanceset
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// revisionHistoryReconciler records the pod templates as ControllerRevisions, which can be rolled back to by spec.rollbackTo,
+// and cleans up the old ones beyond spec.revisionHistoryLimit.
+type revisionHistoryReconciler struct{}
+
+func NewRevisionHistoryReconciler() kubebuilderx.Reconciler {
+	return &revisionHistoryReconciler{}
+}
+
+func (r *revisionHistoryReconciler) PreCondition(tree *kubebuilderx.ObjectTree) *kubebuilderx.CheckResult {
+	if tree.GetRoot() == nil || model.IsObjectDeleting(tree.GetRoot()) {
+		return kubebuilderx.ResultUnsatisfied
+	}
+	if model.IsReconciliationPaused(tree.GetRoot()) {
+		return kubebuilderx.ResultUnsatisfied
+	}
+	return kubebuilderx.ResultSatisfied
+}
+
+func (r *revisionHistoryReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (*kubebuilderx.ObjectTree, error) {
+	its, _ := tree.GetRoot().(*workloads.InstanceSet)
+	revisions := listTemplateRevisions(tree)
+
+	// the pod template has been replaced by the one to roll back to when loading the tree,
+	// so the current revision is always the one of the pod template which the instances are updated to.
+	current, err := buildTemplateRevision(its)
+	if err != nil {
+		return nil, err
+	}
+	index := -1
+	for i, revision := range revisions {
+		if revision.Labels[ControllerRevisionHashLabel] == current.Labels[ControllerRevisionHashLabel] {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		current.Revision = 1
+		if len(revisions) > 0 {
+			current.Revision = revisions[len(revisions)-1].Revision + 1
+		}
+		if err = tree.Add(current); err != nil {
+			return nil, err
+		}
+	} else {
+		current = revisions[index]
+		revisions = append(revisions[:index], revisions[index+1:]...)
+	}
+	its.Status.TemplateRevision = current.Revision
+
+	// clean up the oldest revisions
+	limit := defaultRevisionHistoryLimit
+	if its.Spec.RevisionHistoryLimit != nil {
+		limit = int(*its.Spec.RevisionHistoryLimit)
+	}
+	for i := 0; i < len(revisions)-limit; i++ {
+		if err = tree.Delete(revisions[i]); err != nil {
+			return nil, err
+		}
+	}
+	return tree, nil
+}
+
+// listTemplateRevisions lists the ControllerRevisions of the pod templates in the ascending order of the revision number.
+func listTemplateRevisions(tree *kubebuilderx.ObjectTree) []*appsv1.ControllerRevision {
+	var revisions []*appsv1.ControllerRevision
+	for _, object := range tree.List(&appsv1.ControllerRevision{}) {
+		revision, _ := object.(*appsv1.ControllerRevision)
+		revisions = append(revisions, revision)
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision < revisions[j].Revision
+	})
+	return revisions
+}
+
+// findTemplateRevision finds the ControllerRevision of the pod template with the revision number.
+func findTemplateRevision(tree *kubebuilderx.ObjectTree, revisionNumber int64) *appsv1.ControllerRevision {
+	for _, revision := range listTemplateRevisions(tree) {
+		if revision.Revision == revisionNumber {
+			return revision
+		}
+	}
+	return nil
+}
+
+// buildTemplateRevision builds the ControllerRevision which records the pod template of the InstanceSet.
+func buildTemplateRevision(its *workloads.InstanceSet) (*appsv1.ControllerRevision, error) {
+	revision, err := NewRevision(its)
+	if err != nil {
+		return nil, err
+	}
+	revision.Namespace = its.Namespace
+	// the owner is set to the InstanceSet below
+	revision.OwnerReferences = nil
+	for k, v := range getMatchLabels(its.Name) {
+		revision.Labels[k] = v
+	}
+	if err = intctrlutil.SetOwnership(its, revision, model.GetScheme(), finalizer); err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// getRevisionTemplate gets the pod template recorded in the ControllerRevision.
+func getRevisionTemplate(revision *appsv1.ControllerRevision) (*corev1.PodTemplateSpec, error) {
+	data := struct {
+		Spec struct {
+			Template corev1.PodTemplateSpec `json:"template"`
+		} `json:"spec"`
+	}{}
+	if err := json.Unmarshal(revision.Data.Raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode the pod template of revision %d: %w", revision.Revision, err)
+	}
+	return &data.Spec.Template, nil
+}
+
+var _ kubebuilderx.Reconciler = &revisionHistoryReconciler{}
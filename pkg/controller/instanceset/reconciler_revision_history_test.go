@@ -0,0 +1,91 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package instanceset
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+)
+
+var _ = Describe("revision history reconciler test", func() {
+	BeforeEach(func() {
+		its = builder.NewInstanceSetBuilder(namespace, name).
+			SetUID(uid).
+			SetService(&corev1.Service{}).
+			SetReplicas(3).
+			SetTemplate(*template.DeepCopy()).
+			SetVolumeClaimTemplates(volumeClaimTemplates...).
+			SetRoles(roles).
+			GetObject()
+	})
+
+	Context("PreCondition & Reconcile", func() {
+		It("should record the pod templates and roll back", func() {
+			tree := kubebuilderx.NewObjectTree()
+			tree.SetRoot(its)
+			tree.EventRecorder = record.NewFakeRecorder(10)
+			reconciler := NewRevisionHistoryReconciler()
+			Expect(reconciler.PreCondition(tree)).Should(Equal(kubebuilderx.ResultSatisfied))
+
+			By("record the first revision")
+			_, err := reconciler.Reconcile(tree)
+			Expect(err).Should(BeNil())
+			Expect(tree.List(&appsv1.ControllerRevision{})).Should(HaveLen(1))
+			Expect(its.Status.TemplateRevision).Should(Equal(int64(1)))
+
+			By("record a new revision after the pod template updated")
+			its.Spec.Template.Spec.Containers[0].Image = "bar:v2"
+			_, err = reconciler.Reconcile(tree)
+			Expect(err).Should(BeNil())
+			Expect(tree.List(&appsv1.ControllerRevision{})).Should(HaveLen(2))
+			Expect(its.Status.TemplateRevision).Should(Equal(int64(2)))
+
+			By("roll back to the first revision")
+			its.Spec.RollbackTo = &workloads.RollbackConfig{Revision: 1}
+			Expect(loadRollbackTemplate(tree)).Should(Succeed())
+			Expect(its.Spec.Template.Spec.Containers[0].Image).Should(Equal(template.Spec.Containers[0].Image))
+			_, err = reconciler.Reconcile(tree)
+			Expect(err).Should(BeNil())
+			Expect(tree.List(&appsv1.ControllerRevision{})).Should(HaveLen(2))
+			Expect(its.Status.TemplateRevision).Should(Equal(int64(1)))
+
+			By("clean up the revisions beyond the limit")
+			its.Spec.RevisionHistoryLimit = pointer.Int32(0)
+			_, err = reconciler.Reconcile(tree)
+			Expect(err).Should(BeNil())
+			revisions := tree.List(&appsv1.ControllerRevision{})
+			Expect(revisions).Should(HaveLen(1))
+			Expect(revisions[0].(*appsv1.ControllerRevision).Revision).Should(Equal(int64(1)))
+
+			By("validate the revision to roll back to")
+			its.Spec.RollbackTo.Revision = 2
+			Expect(validateSpec(its, tree)).ShouldNot(Succeed())
+		})
+	})
+})
@@ -21,6 +21,8 @@ package instanceset
 
 import (
 	"encoding/json"
+	"fmt"
+	"strconv"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -33,6 +35,7 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
 	"github.com/apecloud/kubeblocks/pkg/controller/model"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 // statusReconciler computes the current status
@@ -80,7 +83,7 @@ func (r *statusReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (*kubebuilde
 		if isRunningAndReady(pod) && !isTerminating(pod) {
 			readyReplicas++
 			notReadyNames.Delete(pod.Name)
-			if isRunningAndAvailable(pod, its.Spec.MinReadySeconds) {
+			if isRunningAndAvailable(pod, its.Spec.MinReadySeconds) && isWarmedUp(its, pod) {
 				availableReplicas++
 			} else {
 				notAvailableNames.Insert(pod.Name)
@@ -91,12 +94,14 @@ func (r *statusReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (*kubebuilde
 			if err != nil {
 				return nil, err
 			}
-			switch _, ok := updateRevisions[pod.Name]; {
-			case !ok, !isPodUpdated:
-				currentReplicas++
-			default:
+			_, ok := updateRevisions[pod.Name]
+			updated := ok && isPodUpdated
+			if updated {
 				updatedReplicas++
+			} else {
+				currentReplicas++
 			}
+			markPodUpdated(pod, updated)
 		}
 	}
 	its.Status.Replicas = replicas
@@ -140,13 +145,19 @@ func (r *statusReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (*kubebuilde
 	// 4. set members status
 	setMembersStatus(its, podList)
 
-	// 5. set readyWithoutPrimary
+	// 5. set instance status
+	setInstanceStatus(its, podList)
+
+	// 6. set readyWithoutPrimary
 	// TODO(free6om): should put this field to the spec
 	setReadyWithPrimary(its, podList)
 
 	if its.Spec.MinReadySeconds > 0 && availableReplicas != readyReplicas {
 		return tree, intctrlutil.NewDelayedRequeueError(time.Second, "requeue for right status update")
 	}
+	if its.Spec.WarmUpCheck != nil && availableReplicas != readyReplicas {
+		return tree, intctrlutil.NewDelayedRequeueError(getWarmUpCheckPeriod(its), "requeue for the warm-up of instances")
+	}
 	return tree, nil
 }
 
@@ -269,6 +280,39 @@ func setMembersStatus(its *workloads.InstanceSet, pods []*corev1.Pod) {
 	its.Status.MembersStatus = newMembersStatus
 }
 
+// setInstanceStatus publishes the FQDN and IP of each instance, the FQDN is resolved by the headless Service,
+// as the pod sets its hostname and subdomain on creation.
+func setInstanceStatus(its *workloads.InstanceSet, pods []*corev1.Pod) {
+	instanceStatus := make([]workloads.InstanceStatus, 0, len(pods))
+	for _, pod := range pods {
+		if isTerminating(pod) {
+			continue
+		}
+		instanceStatus = append(instanceStatus, workloads.InstanceStatus{
+			PodName: pod.Name,
+			FQDN:    getPodFQDN(its, pod),
+			PodIP:   pod.Status.PodIP,
+		})
+	}
+	getNameNOrdinalFunc := func(i int) (string, int) {
+		return ParseParentNameAndOrdinal(instanceStatus[i].PodName)
+	}
+	baseSort(instanceStatus, getNameNOrdinalFunc, nil, true)
+	its.Status.InstanceStatus = instanceStatus
+}
+
+// getPodFQDN returns the FQDN of the pod, which is stable across the re-creations of the pod.
+func getPodFQDN(its *workloads.InstanceSet, pod *corev1.Pod) string {
+	hostname, subdomain := pod.Spec.Hostname, pod.Spec.Subdomain
+	if hostname == "" {
+		hostname = pod.Name
+	}
+	if subdomain == "" {
+		subdomain = getHeadlessSvcName(its.Name)
+	}
+	return fmt.Sprintf("%s.%s.%s.svc.%s", hostname, subdomain, pod.Namespace, viper.GetString(constant.KubernetesClusterDomainEnv))
+}
+
 func sortMembersStatus(membersStatus []workloads.MemberStatus, rolePriorityMap map[string]int) {
 	getRolePriorityFunc := func(i int) int {
 		role := membersStatus[i].ReplicaRole.Name
@@ -279,3 +323,12 @@ func sortMembersStatus(membersStatus []workloads.MemberStatus, rolePriorityMap m
 	}
 	baseSort(membersStatus, getNameNOrdinalFunc, getRolePriorityFunc, true)
 }
+
+// markPodUpdated labels whether the pod is updated to the latest revision,
+// so that the updated pods can be selected by a Service, e.g. the canary Service of a Component.
+func markPodUpdated(pod *corev1.Pod, updated bool) {
+	if pod.Labels == nil {
+		pod.Labels = map[string]string{}
+	}
+	pod.Labels[constant.UpdatedLabelKey] = strconv.FormatBool(updated)
+}
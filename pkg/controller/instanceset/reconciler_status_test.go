@@ -21,6 +21,7 @@ package instanceset
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -31,9 +32,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/builder"
 	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 var _ = Describe("status reconciler test", func() {
@@ -140,6 +143,9 @@ var _ = Describe("status reconciler test", func() {
 			Expect(its.Status.CurrentRevisions).Should(Equal(currentRevisions))
 			Expect(its.Status.Conditions[1].Type).Should(BeEquivalentTo(workloads.InstanceAvailable))
 			Expect(its.Status.Conditions[1].Status).Should(BeEquivalentTo(corev1.ConditionFalse))
+			for _, object := range pods {
+				Expect(object.GetLabels()[constant.UpdatedLabelKey]).Should(Equal("false"))
+			}
 
 			By("make all pods available with latest revision")
 			updateRevisions, err := GetRevisions(its.Status.UpdateRevisions)
@@ -160,6 +166,9 @@ var _ = Describe("status reconciler test", func() {
 			Expect(its.Status.Conditions).Should(HaveLen(2))
 			Expect(its.Status.Conditions[1].Type).Should(BeEquivalentTo(workloads.InstanceAvailable))
 			Expect(its.Status.Conditions[1].Status).Should(BeEquivalentTo(corev1.ConditionTrue))
+			for _, object := range pods {
+				Expect(object.GetLabels()[constant.UpdatedLabelKey]).Should(Equal("true"))
+			}
 
 			By("make all pods failed")
 			for _, object := range pods {
@@ -229,6 +238,34 @@ var _ = Describe("status reconciler test", func() {
 		})
 	})
 
+	Context("setInstanceStatus function", func() {
+		It("should work well", func() {
+			clusterDomain := viper.GetString(constant.KubernetesClusterDomainEnv)
+			defer viper.Set(constant.KubernetesClusterDomainEnv, clusterDomain)
+			viper.Set(constant.KubernetesClusterDomainEnv, "cluster.local")
+
+			pods := []*corev1.Pod{
+				builder.NewPodBuilder(namespace, name+"-foo-0").GetObject(),
+				builder.NewPodBuilder(namespace, name+"-1").GetObject(),
+				builder.NewPodBuilder(namespace, name+"-0").GetObject(),
+			}
+			pods[0].Spec.Hostname = pods[0].Name
+			pods[0].Spec.Subdomain = getHeadlessSvcName(its.Name)
+			pods[2].Status.PodIP = "10.0.0.1"
+			setInstanceStatus(its, pods)
+
+			Expect(its.Status.InstanceStatus).Should(HaveLen(3))
+			Expect(its.Status.InstanceStatus[0].PodName).Should(Equal(name + "-0"))
+			Expect(its.Status.InstanceStatus[0].PodIP).Should(Equal("10.0.0.1"))
+			Expect(its.Status.InstanceStatus[1].PodName).Should(Equal(name + "-1"))
+			Expect(its.Status.InstanceStatus[1].PodIP).Should(BeEmpty())
+			Expect(its.Status.InstanceStatus[2].PodName).Should(Equal(name + "-foo-0"))
+			for _, status := range its.Status.InstanceStatus {
+				Expect(status.FQDN).Should(Equal(fmt.Sprintf("%s.%s-headless.%s.svc.cluster.local", status.PodName, its.Name, namespace)))
+			}
+		})
+	})
+
 	Context("sortMembersStatus function", func() {
 		It("should work well", func() {
 			// 2(learner)->1(learner)->4(logger)->0(follower)->3(leader)
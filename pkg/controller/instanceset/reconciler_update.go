@@ -94,6 +94,10 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (*kubebuilde
 	if its.Spec.UpdateStrategy.Type == apps.OnDeleteStatefulSetStrategyType {
 		return tree, nil
 	}
+	// do nothing if the InstanceSet is paused, the instances are updated by others, e.g. the node pool migration.
+	if its.Spec.Paused {
+		return tree, nil
+	}
 
 	// handle 'RollingUpdate'
 	partition, maxUnavailable, err := parsePartitionNMaxUnavailable(its.Spec.UpdateStrategy.RollingUpdate, len(oldPodList))
@@ -102,7 +106,7 @@ func (r *updateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (*kubebuilde
 	}
 	currentUnavailable := 0
 	for _, pod := range oldPodList {
-		if !isHealthy(pod) {
+		if !isHealthy(pod) || !isWarmedUp(its, pod) {
 			currentUnavailable++
 		}
 	}
@@ -196,6 +196,16 @@ var _ = Describe("update reconciler test", func() {
 			_, err = reconciler.Reconcile(onDeleteTree)
 			Expect(err).Should(BeNil())
 			expectUpdatedPods(onDeleteTree, []string{})
+
+			By("reconcile with paused InstanceSet")
+			pausedTree, err := newTree.DeepCopy()
+			Expect(err).Should(BeNil())
+			root, ok = pausedTree.GetRoot().(*workloads.InstanceSet)
+			Expect(ok).Should(BeTrue())
+			root.Spec.Paused = true
+			_, err = reconciler.Reconcile(pausedTree)
+			Expect(err).Should(BeNil())
+			expectUpdatedPods(pausedTree, []string{})
 		})
 	})
 })
@@ -0,0 +1,216 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package instanceset
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+	"github.com/apecloud/kubeblocks/pkg/controller/model"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	lorry "github.com/apecloud/kubeblocks/pkg/lorry/client"
+)
+
+// warmUpReconciler checks whether the ready instances have been warmed up, by the engine metric or the lorry action
+// specified in spec.warmUpCheck, and marks the warmed up ones, which are considered available from then on.
+type warmUpReconciler struct{}
+
+var _ kubebuilderx.Reconciler = &warmUpReconciler{}
+
+func NewWarmUpReconciler() kubebuilderx.Reconciler {
+	return &warmUpReconciler{}
+}
+
+func (r *warmUpReconciler) PreCondition(tree *kubebuilderx.ObjectTree) *kubebuilderx.CheckResult {
+	if tree.GetRoot() == nil || model.IsObjectDeleting(tree.GetRoot()) {
+		return kubebuilderx.ResultUnsatisfied
+	}
+	if model.IsReconciliationPaused(tree.GetRoot()) {
+		return kubebuilderx.ResultUnsatisfied
+	}
+	its, _ := tree.GetRoot().(*workloads.InstanceSet)
+	if its.Spec.WarmUpCheck == nil {
+		return kubebuilderx.ResultUnsatisfied
+	}
+	return kubebuilderx.ResultSatisfied
+}
+
+func (r *warmUpReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (*kubebuilderx.ObjectTree, error) {
+	its, _ := tree.GetRoot().(*workloads.InstanceSet)
+	for _, object := range tree.List(&corev1.Pod{}) {
+		pod, _ := object.(*corev1.Pod)
+		if !isHealthy(pod) || isWarmedUp(its, pod) {
+			continue
+		}
+		readyTime := getPodReadyTime(pod)
+		timeout := time.Duration(its.Spec.WarmUpCheck.TimeoutSeconds) * time.Second
+		if timeout > 0 && time.Since(readyTime) >= timeout {
+			tree.Logger.Info(fmt.Sprintf("InstanceSet %s/%s gives up the warm-up check of pod %s as it times out", its.Namespace, its.Name, pod.Name))
+		} else if err := checkWarmUp(its.Spec.WarmUpCheck, pod); err != nil {
+			tree.Logger.Info(fmt.Sprintf("InstanceSet %s/%s waits for pod %s to be warmed up: %s", its.Namespace, its.Name, pod.Name, err.Error()))
+			continue
+		}
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[warmedUpAnnotationKey] = readyTime.Format(time.RFC3339)
+		if err := tree.Update(pod); err != nil {
+			return nil, err
+		}
+	}
+	return tree, nil
+}
+
+// isWarmedUp returns true if the pod has been warmed up since it became ready last time,
+// or no warm-up check is required by the InstanceSet.
+func isWarmedUp(its *workloads.InstanceSet, pod *corev1.Pod) bool {
+	if its.Spec.WarmUpCheck == nil {
+		return true
+	}
+	warmedUp, ok := pod.Annotations[warmedUpAnnotationKey]
+	return ok && warmedUp == getPodReadyTime(pod).Format(time.RFC3339)
+}
+
+func getWarmUpCheckPeriod(its *workloads.InstanceSet) time.Duration {
+	if its.Spec.WarmUpCheck == nil || its.Spec.WarmUpCheck.PeriodSeconds <= 0 {
+		return defaultWarmUpCheckPeriod
+	}
+	return time.Duration(its.Spec.WarmUpCheck.PeriodSeconds) * time.Second
+}
+
+func getPodReadyTime(pod *corev1.Pod) time.Time {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.LastTransitionTime.Time
+		}
+	}
+	return time.Time{}
+}
+
+func checkWarmUp(warmUpCheck *workloads.WarmUpCheck, pod *corev1.Pod) error {
+	period := time.Duration(warmUpCheck.PeriodSeconds) * time.Second
+	if period <= 0 {
+		period = defaultWarmUpCheckPeriod
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), period)
+	defer cancel()
+
+	if warmUpCheck.Metric != nil {
+		return checkWarmUpMetric(ctx, warmUpCheck.Metric, pod)
+	}
+	lorryCli, err := lorry.NewClient(*pod)
+	if err != nil {
+		return err
+	}
+	if intctrlutil.IsNil(lorryCli) {
+		return fmt.Errorf("failed to get the lorry client of the pod")
+	}
+	return lorryCli.WarmUpCheck(ctx)
+}
+
+func checkWarmUpMetric(ctx context.Context, metric *workloads.WarmUpMetric, pod *corev1.Pod) error {
+	path := metric.Path
+	if path == "" {
+		path = defaultWarmUpMetricPath
+	}
+	url := fmt.Sprintf("http://%s%s", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(metric.Port))), path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get the metrics from %s, status code: %d", url, resp.StatusCode)
+	}
+	value, found, err := parseMetricValue(resp.Body, metric.Name)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("the metric %s is not found", metric.Name)
+	}
+	if !isWarmUpThresholdReached(metric, value) {
+		return fmt.Errorf("the metric %s is %v, which has not reached the threshold %s", metric.Name, value, metric.Threshold.String())
+	}
+	return nil
+}
+
+// parseMetricValue sums the values of all the series of the metric name in the Prometheus text format.
+func parseMetricValue(r io.Reader, name string) (float64, bool, error) {
+	var sum float64
+	found := false
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || !strings.HasPrefix(line, name) {
+			continue
+		}
+		rest := line[len(name):]
+		switch {
+		case strings.HasPrefix(rest, "{"):
+			end := strings.LastIndex(rest, "}")
+			if end < 0 {
+				return 0, false, fmt.Errorf("invalid metric line: %s", line)
+			}
+			rest = rest[end+1:]
+		case strings.HasPrefix(rest, " ") || strings.HasPrefix(rest, "\t"):
+		default:
+			// another metric with the name as prefix
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			return 0, false, fmt.Errorf("invalid metric line: %s", line)
+		}
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid metric line: %s", line)
+		}
+		sum += value
+		found = true
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, false, err
+	}
+	return sum, found, nil
+}
+
+func isWarmUpThresholdReached(metric *workloads.WarmUpMetric, value float64) bool {
+	threshold := metric.Threshold.AsApproximateFloat64()
+	if metric.Operator == workloads.WarmUpMetricLessThanOrEqual {
+		return value <= threshold
+	}
+	return value >= threshold
+}
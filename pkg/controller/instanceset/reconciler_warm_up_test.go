@@ -0,0 +1,137 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package instanceset
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/kubebuilderx"
+)
+
+var _ = Describe("warm-up reconciler test", func() {
+	BeforeEach(func() {
+		its = builder.NewInstanceSetBuilder(namespace, name).
+			SetUID(uid).
+			SetReplicas(3).
+			AddMatchLabelsInMap(selectors).
+			SetTemplate(template).
+			SetRoles(roles).
+			GetObject()
+	})
+
+	Context("parseMetricValue function", func() {
+		It("should work well", func() {
+			metrics := `# HELP cache_hit_ratio The cache hit ratio.
+# TYPE cache_hit_ratio gauge
+cache_hit_ratio{db="a"} 0.5
+cache_hit_ratio{db="b",name="x y"} 0.25 1700000000000
+cache_hit_ratio_total 100
+buffer_pool_pages 2048
+`
+			value, found, err := parseMetricValue(strings.NewReader(metrics), "cache_hit_ratio")
+			Expect(err).Should(BeNil())
+			Expect(found).Should(BeTrue())
+			Expect(value).Should(BeEquivalentTo(0.75))
+
+			value, found, err = parseMetricValue(strings.NewReader(metrics), "buffer_pool_pages")
+			Expect(err).Should(BeNil())
+			Expect(found).Should(BeTrue())
+			Expect(value).Should(BeEquivalentTo(2048))
+
+			_, found, err = parseMetricValue(strings.NewReader(metrics), "cache_hit")
+			Expect(err).Should(BeNil())
+			Expect(found).Should(BeFalse())
+
+			_, _, err = parseMetricValue(strings.NewReader("cache_hit_ratio{db=\"a\" 0.5\n"), "cache_hit_ratio")
+			Expect(err).ShouldNot(BeNil())
+		})
+	})
+
+	Context("isWarmUpThresholdReached function", func() {
+		It("should work well", func() {
+			metric := &workloads.WarmUpMetric{
+				Name:      "cache_hit_ratio",
+				Threshold: resource.MustParse("900m"),
+			}
+			Expect(isWarmUpThresholdReached(metric, 0.95)).Should(BeTrue())
+			Expect(isWarmUpThresholdReached(metric, 0.5)).Should(BeFalse())
+
+			metric.Operator = workloads.WarmUpMetricLessThanOrEqual
+			Expect(isWarmUpThresholdReached(metric, 0.5)).Should(BeTrue())
+			Expect(isWarmUpThresholdReached(metric, 0.95)).Should(BeFalse())
+		})
+	})
+
+	Context("PreCondition & Reconcile", func() {
+		It("should work well", func() {
+			By("PreCondition")
+			tree := kubebuilderx.NewObjectTree()
+			tree.SetRoot(its)
+			reconciler = NewWarmUpReconciler()
+			Expect(reconciler.PreCondition(tree)).Should(Equal(kubebuilderx.ResultUnsatisfied))
+
+			its.Spec.WarmUpCheck = &workloads.WarmUpCheck{
+				Metric: &workloads.WarmUpMetric{
+					Port:      9104,
+					Name:      "cache_hit_ratio",
+					Threshold: resource.MustParse("900m"),
+				},
+				PeriodSeconds:  1,
+				TimeoutSeconds: 60,
+			}
+			Expect(reconciler.PreCondition(tree)).Should(Equal(kubebuilderx.ResultSatisfied))
+
+			By("prepare a pod which has been ready longer than the timeout")
+			readyTime := metav1.NewTime(time.Now().Add(-2 * time.Minute).Truncate(time.Second))
+			pod := builder.NewPodBuilder(namespace, "pod-0").GetObject()
+			pod.Status.Phase = corev1.PodRunning
+			pod.Status.Conditions = []corev1.PodCondition{{
+				Type:               corev1.PodReady,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: readyTime,
+			}}
+			Expect(tree.Add(pod)).Should(Succeed())
+			Expect(isWarmedUp(its, pod)).Should(BeFalse())
+
+			By("the pod is considered warmed up as the check times out")
+			newTree, err := reconciler.Reconcile(tree)
+			Expect(err).Should(BeNil())
+			object, err := newTree.Get(pod)
+			Expect(err).Should(BeNil())
+			newPod, _ := object.(*corev1.Pod)
+			Expect(newPod.Annotations).Should(HaveKeyWithValue(warmedUpAnnotationKey, readyTime.Format(time.RFC3339)))
+			Expect(isWarmedUp(its, newPod)).Should(BeTrue())
+
+			By("the pod needs to be warmed up again after it is restarted")
+			newPod.Status.Conditions[0].LastTransitionTime = metav1.NewTime(readyTime.Add(time.Minute))
+			Expect(isWarmedUp(its, newPod)).Should(BeFalse())
+		})
+	})
+})
@@ -23,6 +23,7 @@ import (
 	"context"
 
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -50,6 +51,11 @@ func (r *treeLoader) Load(ctx context.Context, reader client.Reader, req ctrl.Re
 		return nil, err
 	}
 
+	// load the pod template to roll back to if present
+	if err = loadRollbackTemplate(tree); err != nil {
+		return nil, err
+	}
+
 	tree.EventRecorder = recorder
 	tree.Logger = logger
 	tree.SetFinalizer(finalizer)
@@ -78,6 +84,29 @@ func loadCompressedInstanceTemplates(ctx context.Context, reader client.Reader,
 	return nil
 }
 
+// loadRollbackTemplate replaces the pod template with the one recorded in the ControllerRevision referred by spec.rollbackTo.
+// The replacement is never persisted, as the controller only updates the status and metadata of the InstanceSet.
+// If the revision is not found, it is reported by the spec validation.
+func loadRollbackTemplate(tree *kubebuilderx.ObjectTree) error {
+	if tree.GetRoot() == nil || model.IsObjectDeleting(tree.GetRoot()) {
+		return nil
+	}
+	its, _ := tree.GetRoot().(*workloads.InstanceSet)
+	if its.Spec.RollbackTo == nil {
+		return nil
+	}
+	revision := findTemplateRevision(tree, its.Spec.RollbackTo.Revision)
+	if revision == nil {
+		return nil
+	}
+	template, err := getRevisionTemplate(revision)
+	if err != nil {
+		return err
+	}
+	its.Spec.Template = *template
+	return nil
+}
+
 func ownedKinds() []client.ObjectList {
 	return []client.ObjectList{
 		&corev1.ServiceList{},
@@ -85,6 +114,7 @@ func ownedKinds() []client.ObjectList {
 		&corev1.PodList{},
 		&corev1.PersistentVolumeClaimList{},
 		&batchv1.JobList{},
+		&appsv1.ControllerRevisionList{},
 	}
 }
 
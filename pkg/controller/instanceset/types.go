@@ -19,6 +19,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 
 package instanceset
 
+import "time"
+
 const (
 	WorkloadsManagedByLabelKey = "workloads.kubeblocks.io/managed-by"
 	WorkloadsInstanceLabelKey  = "workloads.kubeblocks.io/instance"
@@ -77,6 +79,16 @@ const (
 	FeatureGateIgnorePodVerticalScaling = "IGNORE_POD_VERTICAL_SCALING"
 
 	finalizer = "instanceset.workloads.kubeblocks.io/finalizer"
+
+	// defaultRevisionHistoryLimit is the number of old ControllerRevisions retained if spec.revisionHistoryLimit is not set.
+	defaultRevisionHistoryLimit = 10
+
+	// warmedUpAnnotationKey records the time the pod became ready when it has been warmed up,
+	// the pod needs to be warmed up again once it becomes ready at another time, e.g. restarted.
+	warmedUpAnnotationKey = "workloads.kubeblocks.io/warmed-up"
+
+	defaultWarmUpCheckPeriod = 10 * time.Second
+	defaultWarmUpMetricPath  = "/metrics"
 )
 
 // AnnotationScope defines scope that annotations belong to.
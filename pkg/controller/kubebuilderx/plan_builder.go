@@ -292,10 +292,9 @@ func (b *PlanBuilder) deleteObject(ctx context.Context, vertex *model.ObjectVert
 }
 
 func (b *PlanBuilder) statusObject(ctx context.Context, vertex *model.ObjectVertex) error {
-	if err := b.cli.Status().Update(ctx, vertex.Obj, clientOption(vertex)); err != nil {
-		return err
-	}
-	return nil
+	// the controller is named after the kind of the root object, e.g. "instanceset".
+	controller := strings.ToLower(getTypeName(vertex.Obj))
+	return model.PatchStatus(ctx, b.cli, controller, vertex.OriObj, vertex.Obj, clientOption(vertex))
 }
 
 func (b *PlanBuilder) emitEvent(obj client.Object, reason string, action model.Action) {
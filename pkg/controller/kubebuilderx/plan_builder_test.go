@@ -189,18 +189,34 @@ var _ = Describe("plan builder test", func() {
 			gomock.InOrder(
 				k8sMock.EXPECT().Status().Return(statusWriter),
 				statusWriter.EXPECT().
-					Update(gomock.Any(), gomock.Any(), gomock.Any()).
-					DoAndReturn(func(_ context.Context, obj *workloads.InstanceSet, _ ...client.UpdateOption) error {
+					Patch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, obj *workloads.InstanceSet, patch client.Patch, _ ...client.SubResourcePatchOption) error {
 						Expect(obj).ShouldNot(BeNil())
 						Expect(obj.Namespace).Should(Equal(its.Namespace))
 						Expect(obj.Name).Should(Equal(its.Name))
-						Expect(obj.Status.ObservedGeneration).Should(Equal(its.Status.ObservedGeneration))
+						data, err := patch.Data(obj)
+						Expect(err).Should(Succeed())
+						Expect(string(data)).Should(Equal(`{"status":{"observedGeneration":2}}`))
 						return nil
 					}).Times(1),
 			)
 			Expect(planBuilder.defaultWalkFunc(v)).Should(Succeed())
 		})
 
+		It("should skip the status write if the status is not changed", func() {
+			its.Generation = 2
+			itsOrig := its.DeepCopy()
+			its.Labels = map[string]string{"foo": "bar"}
+
+			v := &model.ObjectVertex{
+				Obj:    its,
+				OriObj: itsOrig,
+				Action: model.ActionStatusPtr(),
+			}
+			k8sMock.EXPECT().Status().Times(0)
+			Expect(planBuilder.defaultWalkFunc(v)).Should(Succeed())
+		})
+
 		It("should return error if no action set", func() {
 			v := &model.ObjectVertex{}
 			err := planBuilder.defaultWalkFunc(v)
@@ -0,0 +1,67 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	"context"
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apecloud/kubeblocks/pkg/metrics"
+)
+
+// PatchStatus writes the status of obj by a merge patch which only contains the changes of the status against
+// the original object orig, and skips the write if the status is not changed, rather than writing the full status
+// on each resync. The writes are counted per controller by the metric kubeblocks_status_writes_total.
+func PatchStatus(ctx context.Context, cli client.Client, controller string, orig, obj client.Object, opts ...client.SubResourcePatchOption) error {
+	patch, err := StatusPatch(orig, obj)
+	if err != nil {
+		return err
+	}
+	if patch == nil {
+		metrics.StatusWritesTotal.WithLabelValues(controller, metrics.StatusWriteResultSkipped).Inc()
+		return nil
+	}
+	if err = cli.Status().Patch(ctx, obj, client.RawPatch(types.MergePatchType, patch), opts...); err != nil {
+		metrics.StatusWritesTotal.WithLabelValues(controller, metrics.StatusWriteResultFailed).Inc()
+		return err
+	}
+	metrics.StatusWritesTotal.WithLabelValues(controller, metrics.StatusWriteResultPatched).Inc()
+	return nil
+}
+
+// StatusPatch computes the minimal merge patch of the status from orig to obj, it returns nil if the status is not changed.
+func StatusPatch(orig, obj client.Object) ([]byte, error) {
+	data, err := client.MergeFrom(orig).Data(obj)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]json.RawMessage{}
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	status, ok := fields["status"]
+	if !ok {
+		return nil, nil
+	}
+	return json.Marshal(map[string]json.RawMessage{"status": status})
+}
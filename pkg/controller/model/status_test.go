@@ -0,0 +1,61 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+)
+
+var _ = Describe("status patch test", func() {
+	const (
+		namespace = "foo"
+		name      = "bar"
+	)
+
+	Context("StatusPatch function", func() {
+		It("should only contain the changes of the status", func() {
+			orig := builder.NewPodBuilder(namespace, name).GetObject()
+			orig.Status.Phase = corev1.PodPending
+			obj := orig.DeepCopy()
+			obj.Labels = map[string]string{"foo": "bar"}
+			obj.Status.Phase = corev1.PodRunning
+
+			patch, err := StatusPatch(orig, obj)
+			Expect(err).Should(BeNil())
+			Expect(string(patch)).Should(Equal(`{"status":{"phase":"Running"}}`))
+		})
+
+		It("should return nil if the status is not changed", func() {
+			orig := builder.NewPodBuilder(namespace, name).GetObject()
+			orig.Status.Phase = corev1.PodRunning
+			obj := orig.DeepCopy()
+			obj.Labels = map[string]string{"foo": "bar"}
+
+			patch, err := StatusPatch(orig, obj)
+			Expect(err).Should(BeNil())
+			Expect(patch).Should(BeNil())
+		})
+	})
+})
@@ -22,6 +22,8 @@ package plan
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"strings"
 	"text/template"
 
@@ -74,6 +76,40 @@ func GenerateTLSSecretName(clusterName, componentName string) string {
 	return clusterName + "-" + componentName + "-tls-certs"
 }
 
+// GetTLSCertSecretKey returns the name of the secret and the key of the certificate in it used by the component.
+func GetTLSCertSecretKey(clusterName, componentName string, issuer *dbaasv1alpha1.Issuer) (string, string) {
+	if issuer != nil && issuer.Name == dbaasv1alpha1.IssuerUserProvided && issuer.SecretRef != nil {
+		return issuer.SecretRef.Name, issuer.SecretRef.Cert
+	}
+	return GenerateTLSSecretName(clusterName, componentName), constant.CertName
+}
+
+// GetTLSSecretValue returns the value of the key in the TLS secret, the string data is taken into account
+// for the secrets which are composed but not persisted yet.
+func GetTLSSecretValue(secret *v1.Secret, key string) []byte {
+	if value, ok := secret.Data[key]; ok {
+		return value
+	}
+	if value, ok := secret.StringData[key]; ok {
+		return []byte(value)
+	}
+	return nil
+}
+
+// ParseTLSCertificate parses the first certificate of the PEM encoded data.
+func ParseTLSCertificate(data []byte) (*x509.Certificate, error) {
+	for {
+		block, rest := pem.Decode(data)
+		if block == nil {
+			return nil, errors.New("no certificate found in the PEM data")
+		}
+		if block.Type == "CERTIFICATE" {
+			return x509.ParseCertificate(block.Bytes)
+		}
+		data = rest
+	}
+}
+
 func buildFromTemplate(tpl string, vars interface{}) (string, error) {
 	fmap := sprig.TxtFuncMap()
 	t := template.Must(template.New("tls").Funcs(fmap).Parse(tpl))
@@ -59,6 +59,32 @@ var _ = Describe("TLSUtilsTest", func() {
 		})
 	})
 
+	Context("ParseTLSCertificate function", func() {
+		It("should work well", func() {
+			secret, err := ComposeTLSSecret(namespace, "bar", "test")
+			Expect(err).Should(BeNil())
+			secretName, certKey := GetTLSCertSecretKey("bar", "test", &appsv1alpha1.Issuer{Name: appsv1alpha1.IssuerKubeBlocks})
+			Expect(secretName).Should(Equal(secret.Name))
+
+			By("parse the certificate composed by KubeBlocks")
+			cert, err := ParseTLSCertificate(GetTLSSecretValue(secret, certKey))
+			Expect(err).Should(BeNil())
+			Expect(cert.NotAfter.After(cert.NotBefore)).Should(BeTrue())
+
+			By("the private key is not a certificate")
+			_, err = ParseTLSCertificate(GetTLSSecretValue(secret, constant.KeyName))
+			Expect(err).ShouldNot(BeNil())
+
+			By("get the certificate key of the user provided secret")
+			secretName, certKey = GetTLSCertSecretKey("bar", "test", &appsv1alpha1.Issuer{
+				Name:      appsv1alpha1.IssuerUserProvided,
+				SecretRef: &appsv1alpha1.TLSSecretRef{Name: "user-secret", Cert: "cert"},
+			})
+			Expect(secretName).Should(Equal("user-secret"))
+			Expect(certKey).Should(Equal("cert"))
+		})
+	})
+
 	Context("CheckTLSSecretRef function", func() {
 		It("should work well", func() {
 			ctx := context.Background()
@@ -0,0 +1,81 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package scheduling
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+)
+
+// IsNodeEligible checks whether the pod can be scheduled to the node according to the required node affinity
+// and the taints of the node, the resources are not taken into account.
+func IsNodeEligible(node *corev1.Node, pod *corev1.Pod, affinity nodeaffinity.RequiredNodeAffinity) bool {
+	if node.Spec.Unschedulable || !node.DeletionTimestamp.IsZero() {
+		return false
+	}
+	if match, _ := affinity.Match(node); !match {
+		return false
+	}
+	_, untolerated := corev1helpers.FindMatchingUntoleratedTaint(node.Spec.Taints, pod.Spec.Tolerations, func(t *corev1.Taint) bool {
+		return t.Effect == corev1.TaintEffectNoSchedule || t.Effect == corev1.TaintEffectNoExecute
+	})
+	return !untolerated
+}
+
+// PodsFitOnNode returns the number of pods with @requests can fit into the @allocatable,
+// the @requests always contains the pods resource.
+func PodsFitOnNode(requests, allocatable corev1.ResourceList) int32 {
+	fits := int64(-1)
+	for name, request := range requests {
+		if request.IsZero() {
+			continue
+		}
+		available, ok := allocatable[name]
+		if !ok {
+			return 0
+		}
+		n := available.MilliValue() / request.MilliValue()
+		if fits < 0 || n < fits {
+			fits = n
+		}
+	}
+	if fits < 0 {
+		return 0
+	}
+	return int32(fits)
+}
+
+// FormatResourceList formats the resources except the pods as "name=quantity" pairs sorted by the names.
+func FormatResourceList(resources corev1.ResourceList) string {
+	items := make([]string, 0, len(resources))
+	for name, quantity := range resources {
+		if name == corev1.ResourcePods || quantity.IsZero() {
+			continue
+		}
+		items = append(items, fmt.Sprintf("%s=%s", name, quantity.String()))
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
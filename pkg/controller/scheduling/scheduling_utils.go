@@ -34,10 +34,45 @@ import (
 )
 
 func BuildSchedulingPolicy(cluster *appsv1alpha1.Cluster, compSpec *appsv1alpha1.ClusterComponentSpec) (*appsv1alpha1.SchedulingPolicy, error) {
+	var (
+		schedulingPolicy *appsv1alpha1.SchedulingPolicy
+		err              error
+	)
 	if cluster.Spec.SchedulingPolicy != nil || (compSpec != nil && compSpec.SchedulingPolicy != nil) {
-		return buildSchedulingPolicy(cluster, compSpec)
+		schedulingPolicy, err = buildSchedulingPolicy(cluster, compSpec)
+	} else {
+		schedulingPolicy, err = buildSchedulingPolicy4Legacy(cluster, compSpec)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return withDefaultTopologySpreadConstraints(cluster, compSpec, schedulingPolicy), nil
+}
+
+// withDefaultTopologySpreadConstraints spreads the Pods of the component across the topology domains
+// by cluster.spec.topologySpreadPolicy, if the scheduling policy specifies no topology spread constraints.
+func withDefaultTopologySpreadConstraints(cluster *appsv1alpha1.Cluster, compSpec *appsv1alpha1.ClusterComponentSpec,
+	schedulingPolicy *appsv1alpha1.SchedulingPolicy) *appsv1alpha1.SchedulingPolicy {
+	topologyKey := cluster.Spec.TopologySpreadPolicy.TopologyKey()
+	if compSpec == nil || len(topologyKey) == 0 || len(schedulingPolicy.TopologySpreadConstraints) > 0 {
+		return schedulingPolicy
+	}
+	// the scheduling policy may be shared with the cluster spec
+	schedulingPolicy = schedulingPolicy.DeepCopy()
+	schedulingPolicy.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       topologyKey,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					constant.AppInstanceLabelKey:    cluster.Name,
+					constant.KBAppComponentLabelKey: compSpec.Name,
+				},
+			},
+		},
 	}
-	return buildSchedulingPolicy4Legacy(cluster, compSpec)
+	return schedulingPolicy
 }
 
 func BuildSchedulingPolicy4Component(clusterName, compName string, affinity *appsv1alpha1.Affinity,
@@ -167,4 +167,43 @@ var _ = Describe("affinity utils", func() {
 			Expect(tolerations[1].Key).Should(Equal(dpTolerationKey))
 		})
 	})
+
+	Context("with topology spread policy", func() {
+		BeforeEach(func() {
+			clusterObj = testapps.NewClusterFactory("default", clusterName, "", "").
+				AddComponent(compName, "").
+				GetObject()
+			clusterObj.Spec.TopologySpreadPolicy = appsv1alpha1.AvailabilityPolicyZone
+			clusterObj.Spec.SchedulingPolicy = &appsv1alpha1.SchedulingPolicy{}
+			compSpec = &clusterObj.Spec.ComponentSpecs[0]
+		})
+
+		It("should spread the pods across the zones by default", func() {
+			schedulingPolicy, err := BuildSchedulingPolicy(clusterObj, compSpec)
+			Expect(err).Should(Succeed())
+
+			topologySpreadConstraints := schedulingPolicy.TopologySpreadConstraints
+			Expect(topologySpreadConstraints).Should(HaveLen(1))
+			Expect(topologySpreadConstraints[0].TopologyKey).Should(Equal(corev1.LabelTopologyZone))
+			Expect(topologySpreadConstraints[0].WhenUnsatisfiable).Should(Equal(corev1.ScheduleAnyway))
+			Expect(topologySpreadConstraints[0].LabelSelector.MatchLabels).Should(HaveKeyWithValue(constant.KBAppComponentLabelKey, compName))
+			Expect(clusterObj.Spec.SchedulingPolicy.TopologySpreadConstraints).Should(BeEmpty())
+		})
+
+		It("should not override the topology spread constraints of the component", func() {
+			compSpec.SchedulingPolicy = &appsv1alpha1.SchedulingPolicy{
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+					MaxSkew:           2,
+					TopologyKey:       topologyKey,
+					WhenUnsatisfiable: corev1.DoNotSchedule,
+				}},
+			}
+			schedulingPolicy, err := BuildSchedulingPolicy(clusterObj, compSpec)
+			Expect(err).Should(Succeed())
+
+			topologySpreadConstraints := schedulingPolicy.TopologySpreadConstraints
+			Expect(topologySpreadConstraints).Should(HaveLen(1))
+			Expect(topologySpreadConstraints[0].TopologyKey).Should(Equal(topologyKey))
+		})
+	})
 })
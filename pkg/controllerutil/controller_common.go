@@ -43,6 +43,7 @@ import (
 
 	"github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/kberrors"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
@@ -66,10 +67,15 @@ func CheckedRequeueWithError(err error, logger logr.Logger, msg string, keysAndV
 	return RequeueWithError(err, logger, msg, keysAndValues...)
 }
 
-// RequeueWithErrorAndRecordEvent requeues when an error occurs. if it is an unknown error, triggers an event
+// RequeueWithErrorAndRecordEvent requeues when an error occurs. if it is a not found error or a typed error
+// with a reason code, triggers an event
 func RequeueWithErrorAndRecordEvent(obj client.Object, recorder record.EventRecorder, err error, logger logr.Logger) (reconcile.Result, error) {
-	if apierrors.IsNotFound(err) && recorder != nil {
-		recorder.Eventf(obj, corev1.EventTypeWarning, constant.ReasonNotFoundCR, err.Error())
+	if recorder != nil {
+		if apierrors.IsNotFound(err) {
+			recorder.Eventf(obj, corev1.EventTypeWarning, constant.ReasonNotFoundCR, err.Error())
+		} else if reason := kberrors.ReasonOf(err); len(reason) > 0 {
+			recorder.Event(obj, corev1.EventTypeWarning, string(reason), err.Error())
+		}
 	}
 	return RequeueWithError(err, logger, "")
 }
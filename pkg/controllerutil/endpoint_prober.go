@@ -0,0 +1,248 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// defaultEndpointProbeTimeout is the timeout of probing a single endpoint.
+	defaultEndpointProbeTimeout = 3 * time.Second
+	// defaultEndpointProbeTTL is the period in which a probe result of a service is considered to be fresh.
+	defaultEndpointProbeTTL = 30 * time.Second
+)
+
+// EndpointProber checks whether an external endpoint of a service is reachable.
+type EndpointProber interface {
+	Probe(ctx context.Context, host string, port corev1.ServicePort) error
+}
+
+// DefaultEndpointProber probes the endpoint by TCP, and by HTTP if the app protocol of the port is http or https.
+var DefaultEndpointProber EndpointProber = &endpointProber{timeout: defaultEndpointProbeTimeout}
+
+type endpointProber struct {
+	timeout time.Duration
+}
+
+func (p *endpointProber) Probe(ctx context.Context, host string, port corev1.ServicePort) error {
+	if port.Protocol != "" && port.Protocol != corev1.ProtocolTCP {
+		// UDP and SCTP endpoints can not be probed reliably
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(host, strconv.Itoa(int(port.Port)))
+	if scheme := endpointHTTPScheme(port); scheme != "" {
+		return p.probeHTTP(ctx, scheme, addr)
+	}
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func (p *endpointProber) probeHTTP(ctx context.Context, scheme, addr string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s://%s/", scheme, addr), nil)
+	if err != nil {
+		return err
+	}
+	cli := &http.Client{
+		Transport: &http.Transport{
+			// the certificates of the exposed endpoints are usually not signed for the ingress address
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+		// the redirection is treated as a response of the endpoint
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := cli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func endpointHTTPScheme(port corev1.ServicePort) string {
+	if port.AppProtocol == nil {
+		return ""
+	}
+	switch strings.ToLower(*port.AppProtocol) {
+	case "http":
+		return "http"
+	case "https":
+		return "https"
+	}
+	return ""
+}
+
+// ProbeLoadBalancerService probes all ports on the ingress addresses of a LoadBalancer service.
+// It returns false if the load balancer has not been provisioned, and the failed endpoints otherwise.
+func ProbeLoadBalancerService(ctx context.Context, prober EndpointProber, svc *corev1.Service) (bool, []string) {
+	var (
+		provisioned bool
+		failures    []string
+	)
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		host := ingress.IP
+		if host == "" {
+			host = ingress.Hostname
+		}
+		if host == "" {
+			continue
+		}
+		provisioned = true
+		for _, port := range svc.Spec.Ports {
+			if err := prober.Probe(ctx, host, port); err != nil {
+				failures = append(failures, fmt.Sprintf("%s:%d: %s", host, port.Port, err.Error()))
+			}
+		}
+	}
+	return provisioned, failures
+}
+
+// EndpointProbeResult is the result of probing the endpoints of a LoadBalancer service.
+type EndpointProbeResult struct {
+	// Provisioned tells whether the load balancer has been provisioned.
+	Provisioned bool
+	// Failures are the unreachable endpoints.
+	Failures []string
+	// ProbeTime is the time when the endpoints were probed.
+	ProbeTime time.Time
+}
+
+// DefaultAsyncEndpointProber is the shared AsyncEndpointProber of the controllers.
+var DefaultAsyncEndpointProber = NewAsyncEndpointProber(DefaultEndpointProber, defaultEndpointProbeTTL)
+
+// AsyncEndpointProber probes the LoadBalancer services in background and caches the results,
+// so that the reconciliation is never blocked by the network round trips.
+type AsyncEndpointProber struct {
+	prober EndpointProber
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*endpointProbeEntry
+}
+
+type endpointProbeEntry struct {
+	// specKey identifies the ingress addresses and ports the result was probed with
+	specKey    string
+	result     *EndpointProbeResult
+	probing    bool
+	lastAccess time.Time
+}
+
+// NewAsyncEndpointProber creates an AsyncEndpointProber which refreshes a result once it is older than the ttl.
+func NewAsyncEndpointProber(prober EndpointProber, ttl time.Duration) *AsyncEndpointProber {
+	return &AsyncEndpointProber{
+		prober:  prober,
+		ttl:     ttl,
+		entries: map[string]*endpointProbeEntry{},
+	}
+}
+
+// Get returns the last probe result of the service, and starts a background probe if the result is missing or stale.
+// It returns nil if the service has never been probed, the caller should check it again later.
+func (p *AsyncEndpointProber) Get(svc *corev1.Service) *EndpointProbeResult {
+	now := time.Now()
+	specKey := endpointProbeSpecKey(svc)
+	if specKey == "" {
+		// the load balancer is not provisioned, nothing to probe
+		return &EndpointProbeResult{ProbeTime: now}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pruneLocked(now)
+
+	key := svc.Namespace + "/" + svc.Name
+	entry, ok := p.entries[key]
+	if !ok || entry.specKey != specKey {
+		entry = &endpointProbeEntry{specKey: specKey}
+		p.entries[key] = entry
+	}
+	entry.lastAccess = now
+	if !entry.probing && (entry.result == nil || now.Sub(entry.result.ProbeTime) >= p.ttl) {
+		entry.probing = true
+		go p.probe(entry, svc.DeepCopy())
+	}
+	return entry.result
+}
+
+func (p *AsyncEndpointProber) probe(entry *endpointProbeEntry, svc *corev1.Service) {
+	provisioned, failures := ProbeLoadBalancerService(context.Background(), p.prober, svc)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	entry.probing = false
+	entry.result = &EndpointProbeResult{
+		Provisioned: provisioned,
+		Failures:    failures,
+		ProbeTime:   time.Now(),
+	}
+}
+
+// pruneLocked removes the results of the services which are not queried anymore, e.g. the deleted ones.
+func (p *AsyncEndpointProber) pruneLocked(now time.Time) {
+	for key, entry := range p.entries {
+		if !entry.probing && now.Sub(entry.lastAccess) > 2*p.ttl {
+			delete(p.entries, key)
+		}
+	}
+}
+
+func endpointProbeSpecKey(svc *corev1.Service) string {
+	var hosts []string
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			hosts = append(hosts, ingress.IP)
+		} else if ingress.Hostname != "" {
+			hosts = append(hosts, ingress.Hostname)
+		}
+	}
+	if len(hosts) == 0 {
+		return ""
+	}
+	var ports []string
+	for _, port := range svc.Spec.Ports {
+		appProtocol := ""
+		if port.AppProtocol != nil {
+			appProtocol = *port.AppProtocol
+		}
+		ports = append(ports, fmt.Sprintf("%s/%d/%s", port.Protocol, port.Port, appProtocol))
+	}
+	return strings.Join(hosts, ",") + "|" + strings.Join(ports, ",")
+}
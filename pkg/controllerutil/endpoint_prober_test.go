@@ -0,0 +1,130 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestEndpointProber(t *testing.T) {
+	prober := &endpointProber{timeout: time.Second}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	if err = prober.Probe(context.Background(), "127.0.0.1", corev1.ServicePort{Port: int32(port)}); err != nil {
+		t.Errorf("expect the tcp endpoint is reachable, but got: %v", err)
+	}
+	_ = listener.Close()
+	if err = prober.Probe(context.Background(), "127.0.0.1", corev1.ServicePort{Port: int32(port)}); err == nil {
+		t.Error("expect the closed tcp endpoint is unreachable")
+	}
+
+	statusCode := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statusCode)
+	}))
+	defer server.Close()
+	host, portStr, _ := net.SplitHostPort(server.Listener.Addr().String())
+	httpPort, _ := strconv.Atoi(portStr)
+	appProtocol := "http"
+	svcPort := corev1.ServicePort{Port: int32(httpPort), AppProtocol: &appProtocol}
+	if err = prober.Probe(context.Background(), host, svcPort); err != nil {
+		t.Errorf("expect the http endpoint is healthy, but got: %v", err)
+	}
+	statusCode = http.StatusServiceUnavailable
+	if err = prober.Probe(context.Background(), host, svcPort); err == nil {
+		t.Error("expect the http endpoint is unhealthy")
+	}
+}
+
+type fakeEndpointProber struct {
+	unreachable map[string]bool
+}
+
+func (p *fakeEndpointProber) Probe(_ context.Context, host string, port corev1.ServicePort) error {
+	if p.unreachable[net.JoinHostPort(host, strconv.Itoa(int(port.Port)))] {
+		return context.DeadlineExceeded
+	}
+	return nil
+}
+
+func TestProbeLoadBalancerService(t *testing.T) {
+	svc := &corev1.Service{
+		Spec: corev1.ServiceSpec{
+			Type:  corev1.ServiceTypeLoadBalancer,
+			Ports: []corev1.ServicePort{{Port: 3306}, {Port: 9104}},
+		},
+	}
+	prober := &fakeEndpointProber{unreachable: map[string]bool{"lb.example.com:9104": true}}
+	if provisioned, _ := ProbeLoadBalancerService(context.Background(), prober, svc); provisioned {
+		t.Error("expect the load balancer is not provisioned")
+	}
+
+	svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{Hostname: "lb.example.com"}}
+	provisioned, failures := ProbeLoadBalancerService(context.Background(), prober, svc)
+	if !provisioned {
+		t.Error("expect the load balancer is provisioned")
+	}
+	if len(failures) != 1 || failures[0] != "lb.example.com:9104: context deadline exceeded" {
+		t.Errorf("unexpected failures: %v", failures)
+	}
+}
+
+func TestAsyncEndpointProber(t *testing.T) {
+	svc := &corev1.Service{
+		Spec: corev1.ServiceSpec{
+			Type:  corev1.ServiceTypeLoadBalancer,
+			Ports: []corev1.ServicePort{{Port: 3306}},
+		},
+	}
+	prober := NewAsyncEndpointProber(&fakeEndpointProber{unreachable: map[string]bool{"lb.example.com:3306": true}}, time.Hour)
+	if result := prober.Get(svc); result == nil || result.Provisioned {
+		t.Errorf("expect the load balancer is not provisioned, but got: %v", result)
+	}
+
+	svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{Hostname: "lb.example.com"}}
+	if result := prober.Get(svc); result != nil {
+		t.Errorf("expect the endpoints are being probed, but got: %v", result)
+	}
+	var result *EndpointProbeResult
+	for i := 0; i < 100 && result == nil; i++ {
+		time.Sleep(10 * time.Millisecond)
+		result = prober.Get(svc)
+	}
+	if result == nil || !result.Provisioned || len(result.Failures) != 1 {
+		t.Fatalf("unexpected probe result: %v", result)
+	}
+
+	// the cached result is dropped once the ingress address is changed
+	svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "10.0.0.1"}}
+	if result = prober.Get(svc); result != nil {
+		t.Errorf("expect the new endpoints are being probed, but got: %v", result)
+	}
+}
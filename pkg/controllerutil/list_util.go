@@ -0,0 +1,90 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"context"
+	"errors"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultListChunkSize is the number of objects fetched by each request when listing in chunks.
+const DefaultListChunkSize int64 = 500
+
+// ErrStopListing stops ListInChunks and ForEachObject without returning an error.
+var ErrStopListing = errors.New("stop listing")
+
+// ListInChunks lists the objects page by page and calls fn after each page is fetched into the list,
+// so that a large number of objects are not fetched from the API server in one response.
+//
+// The pagination only takes effect for the uncached objects returned by GetUncachedObjects,
+// which are read from the API server directly, since the cached client does not support continuing a list,
+// the cached objects are listed at once.
+// The list is reused for the pages, fn should not keep references to its items.
+func ListInChunks(ctx context.Context, cli client.Client, list client.ObjectList, fn func() error, opts ...client.ListOption) error {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	if !isUncachedList(cli, list) {
+		listOpts.Limit = 0
+	} else if listOpts.Limit == 0 {
+		listOpts.Limit = DefaultListChunkSize
+	}
+	for {
+		if err := cli.List(ctx, list, listOpts); err != nil {
+			return err
+		}
+		if err := fn(); err != nil {
+			if errors.Is(err, ErrStopListing) {
+				return nil
+			}
+			return err
+		}
+		listOpts.Continue = list.GetContinue()
+		if listOpts.Limit == 0 || listOpts.Continue == "" {
+			return nil
+		}
+	}
+}
+
+// ForEachObject lists the objects in chunks by ListInChunks, and calls fn with each object.
+func ForEachObject(ctx context.Context, cli client.Client, list client.ObjectList, fn func(obj client.Object) error, opts ...client.ListOption) error {
+	return ListInChunks(ctx, cli, list, func() error {
+		return meta.EachListItem(list, func(obj runtime.Object) error {
+			return fn(obj.(client.Object))
+		})
+	}, opts...)
+}
+
+func isUncachedList(cli client.Client, list client.ObjectList) bool {
+	gvk, err := cli.GroupVersionKindFor(list)
+	if err != nil {
+		return false
+	}
+	for _, obj := range GetUncachedObjects() {
+		objGVK, err := cli.GroupVersionKindFor(obj)
+		if err == nil && objGVK.GroupVersion() == gvk.GroupVersion() && objGVK.Kind+"List" == gvk.Kind {
+			return true
+		}
+	}
+	return false
+}
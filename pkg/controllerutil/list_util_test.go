@@ -0,0 +1,105 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package controllerutil
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestListInChunks(t *testing.T) {
+	var objs []client.Object
+	for i := 0; i < 5; i++ {
+		objs = append(objs, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: fmt.Sprintf("cm-%d", i)}})
+		objs = append(objs, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: fmt.Sprintf("pod-%d", i)}})
+	}
+	requests := 0
+	cli := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objs...).
+		WithInterceptorFuncs(interceptor.Funcs{
+			// the fake client does not support pagination, emulate it by the offset in the continue token
+			List: func(ctx context.Context, cli client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				requests++
+				listOpts := &client.ListOptions{}
+				listOpts.ApplyOptions(opts)
+				if err := cli.List(ctx, list, &client.ListOptions{Namespace: listOpts.Namespace}); err != nil {
+					return err
+				}
+				cmList, ok := list.(*corev1.ConfigMapList)
+				if !ok || listOpts.Limit == 0 {
+					return nil
+				}
+				offset, _ := strconv.Atoi(listOpts.Continue)
+				end := min(offset+int(listOpts.Limit), len(cmList.Items))
+				cmList.Items = cmList.Items[offset:end]
+				cmList.Continue = ""
+				if end < len(objs)/2 {
+					cmList.Continue = strconv.Itoa(end)
+				}
+				return nil
+			},
+		}).Build()
+
+	var names []string
+	if err := ForEachObject(context.Background(), cli, &corev1.ConfigMapList{}, func(obj client.Object) error {
+		names = append(names, obj.GetName())
+		return nil
+	}, client.InNamespace("default"), client.Limit(2)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(names) != 5 || requests != 3 {
+		t.Errorf("expect 5 ConfigMaps listed by 3 requests, but got %v by %d requests", names, requests)
+	}
+
+	// the cached objects are listed at once
+	requests = 0
+	count := 0
+	if err := ForEachObject(context.Background(), cli, &corev1.PodList{}, func(obj client.Object) error {
+		count++
+		return nil
+	}, client.InNamespace("default"), client.Limit(2)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 5 || requests != 1 {
+		t.Errorf("expect 5 Pods listed by 1 request, but got %d by %d requests", count, requests)
+	}
+
+	// stop listing once the object is found
+	requests = 0
+	if err := ForEachObject(context.Background(), cli, &corev1.ConfigMapList{}, func(obj client.Object) error {
+		if obj.GetName() == "cm-1" {
+			return ErrStopListing
+		}
+		return nil
+	}, client.InNamespace("default"), client.Limit(2)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expect the listing is stopped after the first page, but got %d requests", requests)
+	}
+}
@@ -26,6 +26,7 @@ import (
 
 	"golang.org/x/exp/maps"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
 
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
@@ -65,7 +66,7 @@ func CreateOrUpdateVolume(volumes []corev1.Volume, volumeName string, createFn c
 	return append(volumes, createFn(volumeName)), nil
 }
 
-func CreateOrUpdatePodVolumes(podSpec *corev1.PodSpec, volumes map[string]appsv1alpha1.ComponentTemplateSpec, configSet []string) error {
+func CreateOrUpdatePodVolumes(podSpec *corev1.PodSpec, volumes map[string]appsv1alpha1.ComponentTemplateSpec, configSet []string, secretParamsSet []string) error {
 	var (
 		err        error
 		podVolumes = podSpec.Volumes
@@ -82,21 +83,19 @@ func CreateOrUpdatePodVolumes(podSpec *corev1.PodSpec, volumes map[string]appsv1
 		}
 		if podVolumes, err = CreateOrUpdateVolume(podVolumes, templateSpec.VolumeName, func(volumeName string) corev1.Volume {
 			return corev1.Volume{
-				Name: volumeName,
-				VolumeSource: corev1.VolumeSource{
-					ConfigMap: &corev1.ConfigMapVolumeSource{
-						LocalObjectReference: corev1.LocalObjectReference{Name: cmName},
-						// TODO: remove ComponentTemplateSpec.DefaultMode
-						DefaultMode: buildVolumeMode(configSet, templateSpec),
-					},
-				},
+				Name:         volumeName,
+				VolumeSource: buildConfigVolumeSource(cmName, templateSpec, configSet, secretParamsSet),
 			}
 		}, func(volume *corev1.Volume) error {
-			configMap := volume.ConfigMap
-			if configMap == nil {
+			if volume.ConfigMap == nil && volume.Projected == nil {
 				return fmt.Errorf("mount volume[%s] requires a ConfigMap: [%+v]", volume.Name, volume)
 			}
-			configMap.Name = cmName
+			if volume.Projected == nil && !slices.Contains(secretParamsSet, templateSpec.Name) {
+				volume.ConfigMap.Name = cmName
+				return nil
+			}
+			// switch the volume between the ConfigMap and the projected one with the secret parameters
+			volume.VolumeSource = buildConfigVolumeSource(cmName, templateSpec, configSet, secretParamsSet)
 			return nil
 		}); err != nil {
 			return err
@@ -106,6 +105,40 @@ func CreateOrUpdatePodVolumes(podSpec *corev1.PodSpec, volumes map[string]appsv1
 	return nil
 }
 
+// buildConfigVolumeSource builds the volume source of the rendered template.
+// For the configuration with secret parameters, the ConfigMap and the Secret with the same name are projected
+// into the volume, and the files rendered with the actual values in the Secret take precedence.
+func buildConfigVolumeSource(cmName string, templateSpec appsv1alpha1.ComponentTemplateSpec, configSet []string, secretParamsSet []string) corev1.VolumeSource {
+	// TODO: remove ComponentTemplateSpec.DefaultMode
+	defaultMode := buildVolumeMode(configSet, templateSpec)
+	if !slices.Contains(secretParamsSet, templateSpec.Name) {
+		return corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: cmName},
+				DefaultMode:          defaultMode,
+			},
+		}
+	}
+	return corev1.VolumeSource{
+		Projected: &corev1.ProjectedVolumeSource{
+			Sources: []corev1.VolumeProjection{
+				{
+					ConfigMap: &corev1.ConfigMapProjection{
+						LocalObjectReference: corev1.LocalObjectReference{Name: cmName},
+					},
+				},
+				{
+					Secret: &corev1.SecretProjection{
+						LocalObjectReference: corev1.LocalObjectReference{Name: cmName},
+						Optional:             pointer.Bool(true),
+					},
+				},
+			},
+			DefaultMode: defaultMode,
+		},
+	}
+}
+
 func buildVolumeMode(configs []string, configSpec appsv1alpha1.ComponentTemplateSpec) *int32 {
 	// If the defaultMode is not set, permissions are automatically set based on the template type.
 	if !viper.GetBool(constant.FeatureGateIgnoreConfigTemplateDefaultMode) && configSpec.DefaultMode != nil {
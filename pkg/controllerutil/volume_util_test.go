@@ -76,7 +76,7 @@ var _ = Describe("lifecycle_utils", func() {
 
 		It("should succeed in corner case where input volumes is nil, which means no volume is added", func() {
 			ps := &sts.Spec.Template.Spec
-			err := CreateOrUpdatePodVolumes(ps, volumes, nil)
+			err := CreateOrUpdatePodVolumes(ps, volumes, nil, nil)
 			Expect(err).Should(BeNil())
 			Expect(len(ps.Volumes)).To(Equal(1))
 		})
@@ -88,7 +88,7 @@ var _ = Describe("lifecycle_utils", func() {
 				VolumeName:  "myConfigVolume",
 			}
 			ps := &sts.Spec.Template.Spec
-			err := CreateOrUpdatePodVolumes(ps, volumes, nil)
+			err := CreateOrUpdatePodVolumes(ps, volumes, nil, nil)
 			Expect(err).Should(BeNil())
 			Expect(len(ps.Volumes)).To(Equal(2))
 		})
@@ -105,7 +105,7 @@ var _ = Describe("lifecycle_utils", func() {
 				VolumeName:  "myConfigVolume2",
 			}
 			ps := &sts.Spec.Template.Spec
-			err := CreateOrUpdatePodVolumes(ps, volumes, nil)
+			err := CreateOrUpdatePodVolumes(ps, volumes, nil, nil)
 			Expect(err).Should(BeNil())
 			Expect(len(ps.Volumes)).To(Equal(3))
 		})
@@ -128,7 +128,7 @@ var _ = Describe("lifecycle_utils", func() {
 				VolumeName:  replicaVolumeName,
 			}
 			ps := &sts.Spec.Template.Spec
-			Expect(CreateOrUpdatePodVolumes(ps, volumes, nil)).ShouldNot(Succeed())
+			Expect(CreateOrUpdatePodVolumes(ps, volumes, nil, nil)).ShouldNot(Succeed())
 		})
 
 		It("should succeed if updated volume contains ConfigMap", func() {
@@ -154,7 +154,7 @@ var _ = Describe("lifecycle_utils", func() {
 				VolumeName:  replicaVolumeName,
 			}
 			ps := &sts.Spec.Template.Spec
-			err := CreateOrUpdatePodVolumes(ps, volumes, nil)
+			err := CreateOrUpdatePodVolumes(ps, volumes, nil, nil)
 			Expect(err).Should(BeNil())
 			Expect(len(sts.Spec.Template.Spec.Volumes)).To(Equal(2))
 			volume := GetVolumeMountName(sts.Spec.Template.Spec.Volumes, cmName)
@@ -164,6 +164,36 @@ var _ = Describe("lifecycle_utils", func() {
 			Expect(volume.Name).Should(BeEquivalentTo(replicaVolumeName))
 		})
 
+		It("should mount the ConfigMap and the Secret by a projected volume for the secret parameters", func() {
+			const (
+				cmName            = "my_config_for_secret"
+				replicaVolumeName = "mytest-cm-volume_for_secret"
+			)
+			volumes[cmName] = appsv1alpha1.ComponentTemplateSpec{
+				Name:        "configTplName",
+				TemplateRef: "configTplName",
+				VolumeName:  replicaVolumeName,
+			}
+			ps := &sts.Spec.Template.Spec
+			Expect(CreateOrUpdatePodVolumes(ps, volumes, nil, nil)).Should(Succeed())
+			Expect(ps.Volumes[1].ConfigMap).ShouldNot(BeNil())
+
+			Expect(CreateOrUpdatePodVolumes(ps, volumes, nil, []string{"configTplName"})).Should(Succeed())
+			Expect(len(ps.Volumes)).To(Equal(2))
+			volume := ps.Volumes[1]
+			Expect(volume.ConfigMap).Should(BeNil())
+			Expect(volume.Projected).ShouldNot(BeNil())
+			Expect(volume.Projected.Sources).Should(HaveLen(2))
+			Expect(volume.Projected.Sources[0].ConfigMap.Name).Should(BeEquivalentTo(cmName))
+			Expect(volume.Projected.Sources[1].Secret.Name).Should(BeEquivalentTo(cmName))
+			Expect(GetVolumeMountName(ps.Volumes, cmName)).ShouldNot(BeNil())
+
+			// switch back to the ConfigMap volume
+			Expect(CreateOrUpdatePodVolumes(ps, volumes, nil, nil)).Should(Succeed())
+			Expect(ps.Volumes[1].ConfigMap).ShouldNot(BeNil())
+			Expect(ps.Volumes[1].Projected).Should(BeNil())
+		})
+
 	})
 })
 
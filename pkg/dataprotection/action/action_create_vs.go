@@ -58,6 +58,10 @@ type CreateVolumeSnapshotAction struct {
 	// ObjectMeta is the metadata of the volume snapshot.
 	ObjectMeta metav1.ObjectMeta
 
+	// VolumeSnapshotClassName is the preferred volume snapshot class. If it is empty,
+	// the volume snapshot class whose driver matches the CSI driver of the volume is used.
+	VolumeSnapshotClassName string
+
 	// PersistentVolumeClaimWrappers is the list of persistent volume claims wrapper to snapshot.
 	PersistentVolumeClaimWrappers []PersistentVolumeClaimWrapper
 }
@@ -222,6 +226,9 @@ func (c *CreateVolumeSnapshotAction) getVolumeSnapshotClassName(
 	ctx context.Context,
 	cli client.Client,
 	pvName string) (string, error) {
+	if len(c.VolumeSnapshotClassName) > 0 {
+		return c.VolumeSnapshotClassName, nil
+	}
 	pv := &corev1.PersistentVolume{}
 	if err := cli.Get(ctx, types.NamespacedName{Name: pvName}, pv); err != nil {
 		return "", err
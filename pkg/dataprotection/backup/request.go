@@ -43,7 +43,9 @@ const (
 	BackupDataJobNamePrefix = "dp-backup"
 	prebackupJobNamePrefix  = "dp-prebackup"
 	postbackupJobNamePrefix = "dp-postbackup"
+	introspectJobNamePrefix = "dp-introspect"
 	BackupDataContainerName = "backupdata"
+	introspectContainerName = "introspect"
 	managerContainerName    = "manager"
 	managerSharedVolumeName = "manager-shared-volume"
 	managerSharedMountPath  = "/dp-manager"
@@ -117,6 +119,15 @@ func (r *Request) BuildActions() (map[string][]action.Action, error) {
 		if err = r.buildPostBackupActions(&podActions, r.TargetPods[i], i); err != nil {
 			return nil, err
 		}
+
+		// 5. build introspect action, which only runs on the first target pod
+		if i == 0 {
+			introspectAction, err := r.buildIntrospectAction(r.TargetPods[i], fmt.Sprintf("%s-%s%d", introspectJobNamePrefix, r.getActionTargetPrefix(), i))
+			if err != nil {
+				return nil, err
+			}
+			podActions = appendIgnoreNil(podActions, introspectAction)
+		}
 		actions[r.TargetPods[i].Name] = podActions
 	}
 
@@ -205,6 +216,34 @@ func (r *Request) buildBackupDataAction(targetPod *corev1.Pod, name string) (act
 	return nil, fmt.Errorf("unsupported backup type %s", r.ActionSet.Spec.BackupType)
 }
 
+// buildIntrospectAction builds the action to introspect the backed up data, the catalog
+// reported by it is synchronized to the backup status by the manager container.
+func (r *Request) buildIntrospectAction(targetPod *corev1.Pod, name string) (action.Action, error) {
+	if !r.backupActionSetExists() ||
+		r.ActionSet.Spec.Backup.Introspect == nil ||
+		r.ActionSet.Spec.BackupType != dpv1alpha1.BackupTypeFull {
+		return nil, nil
+	}
+	// only introspect the first backup target
+	targets := utils.GetBackupTargets(r.BackupPolicy, r.BackupMethod)
+	if len(targets) > 0 && r.Target != nil && targets[0].Name != r.Target.Name {
+		return nil, nil
+	}
+
+	podSpec, err := r.BuildJobActionPodSpec(targetPod, introspectContainerName, r.ActionSet.Spec.Backup.Introspect)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build job action pod spec: %w", err)
+	}
+	r.InjectManagerContainer(podSpec, nil, r.buildSyncProgressCommand())
+	return &action.JobAction{
+		Name:         name,
+		ObjectMeta:   *buildBackupJobObjMeta(r.Backup, name),
+		Owner:        r.Backup,
+		PodSpec:      podSpec,
+		BackOffLimit: r.BackupPolicy.Spec.BackoffLimit,
+	}, nil
+}
+
 func (r *Request) buildCreateVolumeSnapshotAction(targetPod *corev1.Pod, name string, index int) (action.Action, error) {
 	if r.BackupMethod == nil ||
 		!boolptr.IsSetToTrue(r.BackupMethod.SnapshotVolumes) {
@@ -242,9 +281,22 @@ func (r *Request) buildCreateVolumeSnapshotAction(targetPod *corev1.Pod, name st
 		},
 		Owner:                         r.Backup,
 		PersistentVolumeClaimWrappers: pvcs,
+		VolumeSnapshotClassName:       r.getVolumeSnapshotClassName(),
 	}, nil
 }
 
+// getVolumeSnapshotClassName returns the preferred volume snapshot class, which is specified
+// by the annotation of the backup, or else by the annotation of the backup policy.
+func (r *Request) getVolumeSnapshotClassName() string {
+	if vscName := r.Backup.Annotations[dptypes.VolumeSnapshotClassAnnotationKey]; vscName != "" {
+		return vscName
+	}
+	if r.BackupPolicy != nil {
+		return r.BackupPolicy.Annotations[dptypes.VolumeSnapshotClassAnnotationKey]
+	}
+	return ""
+}
+
 func (r *Request) buildAction(targetPod *corev1.Pod,
 	name string,
 	act *dpv1alpha1.ActionSpec) (action.Action, error) {
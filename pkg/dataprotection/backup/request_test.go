@@ -144,6 +144,27 @@ var _ = Describe("Request Test", func() {
 				Expect(err).Should(HaveOccurred())
 			})
 
+			It("should build introspect action", func() {
+				request.Backup = backup
+				request.ActionSet = actionSet.DeepCopy()
+				request.ActionSet.Spec.Backup.Introspect = &dpv1alpha1.JobActionSpec{
+					BaseJobActionSpec: dpv1alpha1.BaseJobActionSpec{
+						Image:   "introspect-image",
+						Command: []string{"sh", "-c", "echo introspect"},
+					},
+				}
+				request.TargetPods = []*corev1.Pod{targetPod}
+				request.BackupPolicy = backupPolicy
+				request.BackupMethod = &backupPolicy.Spec.BackupMethods[0]
+				request.BackupRepo = backupRepo
+				request.Target = backupPolicy.Spec.Target
+				actions, err := request.BuildActions()
+				Expect(err).NotTo(HaveOccurred())
+				podActions := actions[targetPod.Name]
+				Expect(podActions).ShouldNot(BeEmpty())
+				Expect(podActions[len(podActions)-1].GetName()).Should(HavePrefix(introspectJobNamePrefix))
+			})
+
 		})
 	})
 })
@@ -35,6 +35,8 @@ const (
 	ErrorTypeBackupPVCNameIsEmpty intctrlutil.ErrorType = "BackupPVCNameIsEmpty"
 	// ErrorTypeBackupRepoIsNotReady the backup repository is not ready
 	ErrorTypeBackupRepoIsNotReady intctrlutil.ErrorType = "BackupRepoIsNotReady"
+	// ErrorTypeBackupRepoAccessDenied the backup repository is not allowed to be accessed from the namespace
+	ErrorTypeBackupRepoAccessDenied intctrlutil.ErrorType = "BackupRepoAccessDenied"
 	// ErrorTypeToolConfigSecretNameIsEmpty the name of  repository is not ready
 	ErrorTypeToolConfigSecretNameIsEmpty intctrlutil.ErrorType = "ToolConfigSecretNameIsEmpty"
 	// ErrorTypeBackupJobFailed backup job failed
@@ -71,6 +73,11 @@ func NewBackupRepoIsNotReady(backupRepo string) *intctrlutil.Error {
 	return intctrlutil.NewErrorf(ErrorTypeBackupRepoIsNotReady, `the backup repository %s is not ready`, backupRepo)
 }
 
+// NewBackupRepoAccessDenied returns a new Error with ErrorTypeBackupRepoAccessDenied.
+func NewBackupRepoAccessDenied(backupRepo, namespace string) *intctrlutil.Error {
+	return intctrlutil.NewErrorf(ErrorTypeBackupRepoAccessDenied, `the backup repository %s is not allowed to be accessed from the namespace %s`, backupRepo, namespace)
+}
+
 // NewToolConfigSecretNameIsEmpty returns a new Error with ErrorTypeToolConfigSecretNameIsEmpty.
 func NewToolConfigSecretNameIsEmpty(backupRepo string) *intctrlutil.Error {
 	return intctrlutil.NewErrorf(ErrorTypeToolConfigSecretNameIsEmpty, `the secret name of tool config from %s is empty`, backupRepo)
@@ -69,6 +69,10 @@ func TestNewErrors(t *testing.T) {
 	if !intctrlutil.IsTargetError(repoIsNotReady, ErrorTypeBackupRepoIsNotReady) {
 		t.Error("should be error of BackupRepoIsNotReady")
 	}
+	repoAccessDenied := NewBackupRepoAccessDenied("repo", "default")
+	if !intctrlutil.IsTargetError(repoAccessDenied, ErrorTypeBackupRepoAccessDenied) {
+		t.Error("should be error of BackupRepoAccessDenied")
+	}
 	toolConfigSecretNameIsEmpty := NewToolConfigSecretNameIsEmpty("repo")
 	if !intctrlutil.IsTargetError(toolConfigSecretNameIsEmpty, ErrorTypeToolConfigSecretNameIsEmpty) {
 		t.Error("should be error of ToolConfigSecretNameIsEmpty")
@@ -34,9 +34,12 @@ import (
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
 )
 
 type restoreJobBuilder struct {
@@ -61,6 +64,7 @@ type restoreJobBuilder struct {
 	jobName              string
 	labels               map[string]string
 	serviceAccount       string
+	syncProgress         *dpv1alpha1.SyncProgress
 }
 
 func newRestoreJobBuilder(restore *dpv1alpha1.Restore, backupSet BackupActionSet, backupRepo *dpv1alpha1.BackupRepo, stage dpv1alpha1.RestoreStage) *restoreJobBuilder {
@@ -178,6 +182,12 @@ func (r *restoreJobBuilder) setServiceAccount(serviceAccount string) *restoreJob
 	return r
 }
 
+// setSyncProgress sets whether to synchronize the progress reported by the restore container.
+func (r *restoreJobBuilder) setSyncProgress(syncProgress *dpv1alpha1.SyncProgress) *restoreJobBuilder {
+	r.syncProgress = syncProgress
+	return r
+}
+
 func (r *restoreJobBuilder) attachBackupRepo() *restoreJobBuilder {
 	r.buildWithRepo = true
 	return r
@@ -226,6 +236,10 @@ func (r *restoreJobBuilder) addCommonEnv(sourceTargetPodName string) *restoreJob
 		restoreTime, _ := time.Parse(time.RFC3339, r.restore.Spec.RestoreTime)
 		appendTimeEnv(DPRestoreTime, DPRestoreTimestamp, backup.GetTimeZone(), &metav1.Time{Time: restoreTime})
 	}
+	// add partial restore env
+	if partialRestore := r.restore.Spec.PartialRestore; partialRestore != nil {
+		r.env = append(r.env, buildPartialRestoreEnv(partialRestore)...)
+	}
 	// append actionSet env
 	r.env = append(r.env, actionSetEnv...)
 	backupMethod := r.backupSet.Backup.Status.BackupMethod
@@ -378,8 +392,23 @@ func (r *restoreJobBuilder) build() *batchv1.Job {
 	// downward backup.status.extras to volumes
 	buildBackupExtrasDownward()
 
+	syncProgress := r.syncProgress != nil && boolptr.IsSetToTrue(r.syncProgress.Enabled)
+	if syncProgress {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  DPRestoreProgressFile,
+			Value: filepath.Join(restoreProgressMountPath, restoreProgressFileName),
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      restoreProgressVolumeName,
+			MountPath: restoreProgressMountPath,
+		})
+	}
+
 	intctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
 	job.Spec.Template.Spec.Containers = []corev1.Container{container}
+	if syncProgress {
+		r.injectSyncProgressContainer(&job.Spec.Template.Spec)
+	}
 	controllerutil.AddFinalizer(job, dptypes.DataProtectionFinalizerName)
 
 	// 3. inject datasafed if needed
@@ -398,3 +427,75 @@ func (r *restoreJobBuilder) build() *batchv1.Job {
 	}
 	return job
 }
+
+// injectSyncProgressContainer injects a sidecar that synchronizes the progress written by the restore container
+// to the annotations of the job, and exits once the restore container terminates.
+func (r *restoreJobBuilder) injectSyncProgressContainer(podSpec *corev1.PodSpec) {
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name:         restoreProgressVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	})
+	checkIntervalSeconds := int32(5)
+	if r.syncProgress.IntervalSeconds != nil && *r.syncProgress.IntervalSeconds > 0 {
+		checkIntervalSeconds = *r.syncProgress.IntervalSeconds
+	}
+	container := corev1.Container{
+		Name:            syncProgressContainerName,
+		Image:           viper.GetString(constant.KBToolsImage),
+		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
+		Command:         []string{"sh", "-c"},
+		Args:            []string{r.buildSyncProgressCommand()},
+		Env: []corev1.EnvVar{
+			{Name: DPRestoreProgressFile, Value: filepath.Join(restoreProgressMountPath, restoreProgressFileName)},
+			{Name: dptypes.DPCheckInterval, Value: strconv.Itoa(int(checkIntervalSeconds))},
+			{Name: constant.KBEnvPodName, ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+			}},
+		},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      restoreProgressVolumeName,
+			MountPath: restoreProgressMountPath,
+		}},
+	}
+	intctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
+	podSpec.Containers = append(podSpec.Containers, container)
+}
+
+func (r *restoreJobBuilder) buildSyncProgressCommand() string {
+	// sync progress script will annotate the job with the progress file once it changes,
+	// and exit after the last sync once the restore container terminates.
+	return fmt.Sprintf(`
+set -o nounset
+
+progress_file="${%s}"
+sleep_seconds="${%s}"
+pod_name="${%s}"
+namespace="%s"
+job_name="%s"
+
+if [ "$sleep_seconds" -le 0 ]; then
+  sleep_seconds=5
+fi
+
+last_progress=
+trap "echo 'Terminating...' && exit" TERM
+while true; do
+  terminated=$(kubectl -n "$namespace" get pod "$pod_name" -o jsonpath='{.status.containerStatuses[?(@.name=="%s")].state.terminated.reason}')
+  if [ -f "$progress_file" ]; then
+    progress=$(cat "$progress_file")
+    if [ "$progress" != "$last_progress" ]; then
+      echo "start to patch restore progress: ${progress}"
+      if kubectl -n "$namespace" annotate jobs.batch "$job_name" --overwrite "%s=${progress}"; then
+        last_progress="$progress"
+      fi
+    fi
+  fi
+  if [ -n "$terminated" ]; then
+    echo "restore container terminated with ${terminated}, exit"
+    exit 0
+  fi
+  sleep "$sleep_seconds"
+done
+`, DPRestoreProgressFile, dptypes.DPCheckInterval, constant.KBEnvPodName, r.restore.Namespace, r.jobName,
+		Restore, DataProtectionRestoreProgressAnnotationKey)
+}
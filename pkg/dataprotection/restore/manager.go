@@ -401,6 +401,7 @@ func (r *RestoreManager) BuildPrepareDataJobs(reqCtx intctrlutil.RequestCtx, cli
 		setImage(backupSet.ActionSet.Spec.Restore.PrepareData.Image).
 		setCommand(backupSet.ActionSet.Spec.Restore.PrepareData.Command).
 		setServiceAccount(r.WorkerServiceAccount).
+		setSyncProgress(backupSet.ActionSet.Spec.Restore.SyncProgress).
 		attachBackupRepo()
 
 	createPVCIfNotExistsAndBuildVolume := func(claim dpv1alpha1.RestoreVolumeClaim, identifier string) (*corev1.Volume, *corev1.VolumeMount, error) {
@@ -749,6 +750,13 @@ func (r *RestoreManager) CheckJobsDone(
 	return allJobFinished, existFailedJob
 }
 
+// SyncDataProgress records the progress reported by the prepareData jobs in the status of the restore.
+func (r *RestoreManager) SyncDataProgress(jobs []*batchv1.Job) {
+	if progress := BuildRestoreDataProgress(jobs, time.Now()); progress != nil {
+		r.Restore.Status.DataProgress = progress
+	}
+}
+
 // Recalculation whether all actions have been completed.
 func (r *RestoreManager) Recalculation(backupName, actionName string, allActionsFinished, existFailedAction *bool) {
 	prepareDataConfig := r.Restore.Spec.PrepareDataConfig
@@ -358,6 +358,36 @@ var _ = Describe("Backup Deleter Test", func() {
 			})).Should(Succeed())
 			testPostReady(false)
 		})
+
+		It("test with partial restore", func() {
+			restore := &dpv1alpha1.Restore{
+				Spec: dpv1alpha1.RestoreSpec{
+					PartialRestore: &dpv1alpha1.PartialRestore{
+						Objects:        []dpv1alpha1.PartialRestoreObject{{Name: "t1", Parent: "db1"}},
+						ConflictPolicy: dpv1alpha1.PartialRestoreConflictPolicyRename,
+						RenameSuffix:   "_bak",
+					},
+				},
+			}
+			backupSet := &BackupActionSet{Backup: &dpv1alpha1.Backup{}, ActionSet: actionSet}
+
+			By("the objects are not checked if the backup has no catalog")
+			Expect(validatePartialRestore(restore, backupSet)).Should(Succeed())
+
+			By("expect for failure if the objects are not found in the catalog")
+			backupSet.Backup.Status.Catalog = []dpv1alpha1.BackupCatalogEntry{{Name: "db1", Type: "database"}}
+			Expect(validatePartialRestore(restore, backupSet)).Should(HaveOccurred())
+			backupSet.Backup.Status.Catalog = append(backupSet.Backup.Status.Catalog,
+				dpv1alpha1.BackupCatalogEntry{Name: "t1", Parent: "db1", Type: "table"})
+			Expect(validatePartialRestore(restore, backupSet)).Should(Succeed())
+
+			By("test the env of partial restore")
+			Expect(buildPartialRestoreEnv(restore.Spec.PartialRestore)).Should(ConsistOf(
+				corev1.EnvVar{Name: DPRestoreObjects, Value: "db1.t1"},
+				corev1.EnvVar{Name: DPRestoreConflictPolicy, Value: string(dpv1alpha1.PartialRestoreConflictPolicyRename)},
+				corev1.EnvVar{Name: DPRestoreRenameSuffix, Value: "_bak"},
+			))
+		})
 	})
 
 })
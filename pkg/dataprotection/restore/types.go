@@ -55,6 +55,8 @@ const (
 // Annotations key
 const (
 	DataProtectionBackupExtrasLabelKey = "dataprotection.kubeblocks.io/backup-extras"
+	// DataProtectionRestoreProgressAnnotationKey records the progress reported by the restore job.
+	DataProtectionRestoreProgressAnnotationKey = "dataprotection.kubeblocks.io/restore-progress"
 )
 
 // env name for restore
@@ -66,9 +68,24 @@ const (
 	DPBaseBackupStartTimestamp = "DP_BASE_BACKUP_START_TIMESTAMP"
 	DPBaseBackupStopTime       = "DP_BASE_BACKUP_STOP_TIME"
 	DPBaseBackupStopTimestamp  = "DP_BASE_BACKUP_STOP_TIMESTAMP"
+	// DPRestoreObjects the objects to be restored by the partial restore, separated by commas,
+	// each of them is in the format of "parent.name", or "name" if it has no parent.
+	DPRestoreObjects        = "DP_RESTORE_OBJECTS"
+	DPRestoreConflictPolicy = "DP_RESTORE_CONFLICT_POLICY"
+	DPRestoreRenameSuffix   = "DP_RESTORE_RENAME_SUFFIX"
+	// DPRestoreProgressFile the file which the restore job writes its progress to, in the format of
+	// `{"bytesRestored": 1048576, "totalBytes": 4194304}`.
+	DPRestoreProgressFile = "DP_RESTORE_PROGRESS_FILE"
 )
 
 // Restore constant
 const Restore = "restore"
 
+const (
+	syncProgressContainerName = "sync-progress"
+	restoreProgressVolumeName = "dp-restore-progress"
+	restoreProgressMountPath  = "/dp-restore-progress"
+	restoreProgressFileName   = "progress.json"
+)
+
 var defaultBackoffLimit int32 = 2
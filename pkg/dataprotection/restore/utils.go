@@ -265,6 +265,11 @@ func ValidateAndInitRestoreMGR(reqCtx intctrlutil.RequestCtx,
 		return err
 	}
 
+	// check if the partial restore is supported by the backup.
+	if err = validatePartialRestore(restoreMgr.Restore, backupSet); err != nil {
+		return err
+	}
+
 	// build backupActionSets of prepareData and postReady stage based on the specified backup's type.
 	switch backupType {
 	case dpv1alpha1.BackupTypeFull:
@@ -281,6 +286,103 @@ func ValidateAndInitRestoreMGR(reqCtx intctrlutil.RequestCtx,
 	return err
 }
 
+// validatePartialRestore checks if the actionSet of the backup supports restoring data into an existing cluster,
+// and the objects to be restored exist in the catalog of the backup.
+func validatePartialRestore(restore *dpv1alpha1.Restore, backupSet *BackupActionSet) error {
+	partialRestore := restore.Spec.PartialRestore
+	if partialRestore == nil {
+		return nil
+	}
+	if restore.Spec.PrepareDataConfig != nil {
+		return intctrlutil.NewFatalError("spec.prepareDataConfig is not supported by the partial restore")
+	}
+	if !backupSet.ActionSet.HasPostReadyStage() {
+		return intctrlutil.NewFatalError(fmt.Sprintf(`the actionSet of backup "%s" does not support the partial restore`, backupSet.Backup.Name))
+	}
+	catalog := backupSet.Backup.Status.Catalog
+	if len(catalog) == 0 {
+		// the backup is not introspected, leave the check to the restore actions.
+		return nil
+	}
+	for _, obj := range partialRestore.Objects {
+		if !slices.ContainsFunc(catalog, func(entry dpv1alpha1.BackupCatalogEntry) bool {
+			return entry.Name == obj.Name && entry.Parent == obj.Parent
+		}) {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`object "%s" is not found in the catalog of backup "%s"`,
+				formatPartialRestoreObject(obj), backupSet.Backup.Name))
+		}
+	}
+	return nil
+}
+
+func formatPartialRestoreObject(obj dpv1alpha1.PartialRestoreObject) string {
+	if obj.Parent == "" {
+		return obj.Name
+	}
+	return obj.Parent + "." + obj.Name
+}
+
+// buildPartialRestoreEnv builds the env of the partial restore for the restore actions.
+func buildPartialRestoreEnv(partialRestore *dpv1alpha1.PartialRestore) []corev1.EnvVar {
+	objects := make([]string, 0, len(partialRestore.Objects))
+	for _, obj := range partialRestore.Objects {
+		objects = append(objects, formatPartialRestoreObject(obj))
+	}
+	conflictPolicy := partialRestore.ConflictPolicy
+	if conflictPolicy == "" {
+		conflictPolicy = dpv1alpha1.PartialRestoreConflictPolicyFail
+	}
+	env := []corev1.EnvVar{
+		{Name: DPRestoreObjects, Value: strings.Join(objects, ",")},
+		{Name: DPRestoreConflictPolicy, Value: string(conflictPolicy)},
+	}
+	if conflictPolicy == dpv1alpha1.PartialRestoreConflictPolicyRename {
+		env = append(env, corev1.EnvVar{Name: DPRestoreRenameSuffix, Value: partialRestore.RenameSuffix})
+	}
+	return env
+}
+
+// BuildRestoreDataProgress aggregates the progress reported by the restore jobs, the rate is averaged since
+// the earliest job started. It returns nil if none of the jobs reports the progress.
+func BuildRestoreDataProgress(jobs []*batchv1.Job, now time.Time) *dpv1alpha1.RestoreDataProgress {
+	var (
+		progress  *dpv1alpha1.RestoreDataProgress
+		startTime *metav1.Time
+	)
+	for _, job := range jobs {
+		value := job.Annotations[DataProtectionRestoreProgressAnnotationKey]
+		if value == "" {
+			continue
+		}
+		jobProgress := dpv1alpha1.RestoreDataProgress{}
+		if err := json.Unmarshal([]byte(value), &jobProgress); err != nil {
+			// ignore the malformed progress reported by the job.
+			continue
+		}
+		if progress == nil {
+			progress = &dpv1alpha1.RestoreDataProgress{}
+		}
+		progress.BytesRestored += jobProgress.BytesRestored
+		progress.TotalBytes += jobProgress.TotalBytes
+		if job.Status.StartTime != nil && (startTime == nil || job.Status.StartTime.Before(startTime)) {
+			startTime = job.Status.StartTime
+		}
+	}
+	if progress == nil || startTime == nil {
+		return progress
+	}
+	elapsedSeconds := int64(now.Sub(startTime.Time).Seconds())
+	if elapsedSeconds <= 0 {
+		return progress
+	}
+	progress.BytesPerSecond = progress.BytesRestored / elapsedSeconds
+	if progress.BytesPerSecond > 0 && progress.TotalBytes > progress.BytesRestored {
+		remainingSeconds := (progress.TotalBytes - progress.BytesRestored) / progress.BytesPerSecond
+		progress.EstimatedCompletionTime = &metav1.Time{Time: now.Add(time.Duration(remainingSeconds) * time.Second).Truncate(time.Second)}
+	}
+	return progress
+}
+
 func cutJobName(jobName string) string {
 	l := len(jobName)
 	if l > 63 {
@@ -0,0 +1,68 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package restore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildRestoreDataProgress(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 1, 40, 0, time.UTC)
+	newJob := func(progress string, startTime time.Time) *batchv1.Job {
+		job := &batchv1.Job{}
+		if progress != "" {
+			job.Annotations = map[string]string{DataProtectionRestoreProgressAnnotationKey: progress}
+		}
+		job.Status.StartTime = &metav1.Time{Time: startTime}
+		return job
+	}
+
+	// no progress reported
+	assert.Nil(t, BuildRestoreDataProgress([]*batchv1.Job{newJob("", now)}, now))
+
+	// malformed progress is ignored
+	assert.Nil(t, BuildRestoreDataProgress([]*batchv1.Job{newJob("{", now)}, now))
+
+	// the progress of jobs is summed up and the rate is averaged since the earliest job started
+	progress := BuildRestoreDataProgress([]*batchv1.Job{
+		newJob(`{"bytesRestored": 600, "totalBytes": 1000}`, now.Add(-100*time.Second)),
+		newJob(`{"bytesRestored": 400, "totalBytes": 1000}`, now.Add(-50*time.Second)),
+		newJob("", now),
+	}, now)
+	assert.NotNil(t, progress)
+	assert.Equal(t, int64(1000), progress.BytesRestored)
+	assert.Equal(t, int64(2000), progress.TotalBytes)
+	assert.Equal(t, int64(10), progress.BytesPerSecond)
+	assert.Equal(t, now.Add(100*time.Second), progress.EstimatedCompletionTime.Time)
+	assert.Equal(t, 50, progress.Percentage())
+
+	// no estimated completion time if the total size is unknown
+	progress = BuildRestoreDataProgress([]*batchv1.Job{
+		newJob(`{"bytesRestored": 1000}`, now.Add(-100*time.Second)),
+	}, now)
+	assert.Equal(t, int64(10), progress.BytesPerSecond)
+	assert.Nil(t, progress.EstimatedCompletionTime)
+	assert.Equal(t, -1, progress.Percentage())
+}
@@ -62,6 +62,8 @@ const (
 	ConnectionPasswordAnnotationKey = "dataprotection.kubeblocks.io/connection-password"
 	// GeminiAcknowledgedAnnotationKey indicates whether Gemini has acknowledged the backup.
 	GeminiAcknowledgedAnnotationKey = "dataprotection.kubeblocks.io/gemini-acknowledged"
+	// VolumeSnapshotClassAnnotationKey specifies the VolumeSnapshotClass used to create the volume snapshots of the backup.
+	VolumeSnapshotClassAnnotationKey = "dataprotection.kubeblocks.io/volume-snapshot-class"
 )
 
 // label keys
@@ -84,6 +86,8 @@ const (
 	AutoBackupLabelKey = "dataprotection.kubeblocks.io/autobackup"
 	// BackupTargetPodLabelKey specifies the backup target pod label key.
 	BackupTargetPodLabelKey = "dataprotection.kubeblocks.io/target-pod-name"
+	// BackupRepoNameLabelKey specifies the backup repo label key, which overrides the backup repo of the backup policy.
+	BackupRepoNameLabelKey = "dataprotection.kubeblocks.io/backup-repo-name"
 )
 
 // env names
@@ -122,6 +126,8 @@ const (
 	DPBackupStopTime = "DP_BACKUP_STOP_TIME" // backup stop time
 	// DPDatasafedBinPath the path containing the datasafed binary
 	DPDatasafedBinPath = "DP_DATASAFED_BIN_PATH"
+	// DPRestoreDrillClusterName the name of the temporary cluster restored by the restore drill
+	DPRestoreDrillClusterName = "DP_RESTORE_DRILL_CLUSTER_NAME"
 
 	// NOTE: do not add 'DP_' prefix to the value of the following constants, they are the datasafed built-in environment.
 
@@ -66,6 +66,7 @@ var PersistentVolumeClaimSignature = func(_ corev1.PersistentVolumeClaim, _ *cor
 var PersistentVolumeSignature = func(_ corev1.PersistentVolume, _ *corev1.PersistentVolume, _ corev1.PersistentVolumeList, _ *corev1.PersistentVolumeList) {
 }
 var PodSignature = func(_ corev1.Pod, _ *corev1.Pod, _ corev1.PodList, _ *corev1.PodList) {}
+var NodeSignature = func(_ corev1.Node, _ *corev1.Node, _ corev1.NodeList, _ *corev1.NodeList) {}
 var EventSignature = func(_ corev1.Event, _ *corev1.Event, _ corev1.EventList, _ *corev1.EventList) {}
 var ConfigMapSignature = func(_ corev1.ConfigMap, _ *corev1.ConfigMap, _ corev1.ConfigMapList, _ *corev1.ConfigMapList) {}
 var EndpointsSignature = func(_ corev1.Endpoints, _ *corev1.Endpoints, _ corev1.EndpointsList, _ *corev1.EndpointsList) {}
@@ -102,6 +103,10 @@ var OpsDefinitionSignature = func(_ appsv1alpha1.OpsDefinition, _ *appsv1alpha1.
 }
 var OpsRequestSignature = func(_ appsv1alpha1.OpsRequest, _ *appsv1alpha1.OpsRequest, _ appsv1alpha1.OpsRequestList, _ *appsv1alpha1.OpsRequestList) {
 }
+var OpsBatchSignature = func(_ appsv1alpha1.OpsBatch, _ *appsv1alpha1.OpsBatch, _ appsv1alpha1.OpsBatchList, _ *appsv1alpha1.OpsBatchList) {
+}
+var CompliancePolicySignature = func(_ appsv1alpha1.CompliancePolicy, _ *appsv1alpha1.CompliancePolicy, _ appsv1alpha1.CompliancePolicyList, _ *appsv1alpha1.CompliancePolicyList) {
+}
 var ConfigConstraintSignature = func(_ appsv1beta1.ConfigConstraint, _ *appsv1beta1.ConfigConstraint, _ appsv1beta1.ConfigConstraintList, _ *appsv1beta1.ConfigConstraintList) {
 }
 
@@ -132,6 +137,12 @@ var ConfigurationSignature = func(_ appsv1alpha1.Configuration, _ *appsv1alpha1.
 var ServiceDescriptorSignature = func(_ appsv1alpha1.ServiceDescriptor, _ *appsv1alpha1.ServiceDescriptor, _ appsv1alpha1.ServiceDescriptorList, _ *appsv1alpha1.ServiceDescriptorList) {
 }
 
+var ComponentClassDefinitionSignature = func(_ appsv1alpha1.ComponentClassDefinition, _ *appsv1alpha1.ComponentClassDefinition, _ appsv1alpha1.ComponentClassDefinitionList, _ *appsv1alpha1.ComponentClassDefinitionList) {
+}
+
+var ValidationPolicySignature = func(_ appsv1alpha1.ValidationPolicy, _ *appsv1alpha1.ValidationPolicy, _ appsv1alpha1.ValidationPolicyList, _ *appsv1alpha1.ValidationPolicyList) {
+}
+
 func ToGVK(object client.Object) schema.GroupVersionKind {
 	t := reflect.TypeOf(object)
 	if t.Kind() != reflect.Pointer {
@@ -23,6 +23,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -83,7 +84,7 @@ func Do(ctx context.Context, action string, args map[string]any) (*Response, err
 		return nil, errors.New("no handler found")
 	}
 
-	resp, err := handler.Do(ctx, handlerSpec, args)
+	resp, err := doWithRetry(ctx, handler, handlerSpec, args)
 	if err != nil {
 		logger.Info("action exec failed", "action", action, "handler spec", handlerSpec, "error", err.Error())
 		return nil, err
@@ -92,6 +93,40 @@ func Do(ctx context.Context, action string, args map[string]any) (*Response, err
 	return resp, nil
 }
 
+// doWithRetry executes the action with the timeout applied to each attempt,
+// and retries the failed attempts according to the retry policy, no matter which handler executes it.
+func doWithRetry(ctx context.Context, handler Handler, handlerSpec util.HandlerSpec, args map[string]any) (*Response, error) {
+	var (
+		maxRetries    int
+		retryInterval time.Duration
+	)
+	if handlerSpec.RetryPolicy != nil {
+		maxRetries = handlerSpec.RetryPolicy.MaxRetries
+		retryInterval = handlerSpec.RetryPolicy.RetryInterval
+	}
+	for attempt := 0; ; attempt++ {
+		resp, err := doWithTimeout(ctx, handler, handlerSpec, args)
+		if err == nil || errors.Is(err, ErrNotImplemented) || attempt >= maxRetries {
+			return resp, err
+		}
+		logger.Info("action exec failed, retry it", "attempt", attempt+1, "error", err.Error())
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+func doWithTimeout(ctx context.Context, handler Handler, handlerSpec util.HandlerSpec, args map[string]any) (*Response, error) {
+	if handlerSpec.TimeoutSeconds > 0 {
+		timeoutCtx, cancel := context.WithTimeout(ctx, time.Duration(handlerSpec.TimeoutSeconds)*time.Second)
+		defer cancel()
+		ctx = timeoutCtx
+	}
+	return handler.Do(ctx, handlerSpec, args)
+}
+
 func GetHandler(handlerSpec util.HandlerSpec) Handler {
 	if len(handlerSpec.Command) != 0 {
 		return execHandler
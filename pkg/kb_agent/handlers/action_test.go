@@ -109,6 +109,43 @@ func TestDo(t *testing.T) {
 		assert.NotNil(t, resp)
 		assert.Equal(t, "success", resp.Message)
 	})
+
+	t.Run("action retried", func(t *testing.T) {
+		actionHandlerSpecs["action2"] = util.HandlerSpec{
+			TimeoutSeconds: 1,
+			RetryPolicy:    &util.RetryPolicy{MaxRetries: 2},
+		}
+		defer delete(actionHandlerSpecs, "action2")
+
+		attempts := 0
+		handler := &MockHandler{}
+		handler.DoFunc = func(ctx context.Context, handlerSpec util.HandlerSpec, args map[string]interface{}) (*Response, error) {
+			attempts++
+			if _, ok := ctx.Deadline(); !ok {
+				return nil, errors.New("timeout not set")
+			}
+			if attempts < 3 {
+				return nil, errors.New("execution failed")
+			}
+			return &Response{Message: "success"}, nil
+		}
+		SetDefaultHandler(handler)
+
+		resp, err := Do(ctx, "action2", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "success", resp.Message)
+		assert.Equal(t, 3, attempts)
+
+		attempts = 0
+		handler.DoFunc = func(ctx context.Context, handlerSpec util.HandlerSpec, args map[string]interface{}) (*Response, error) {
+			attempts++
+			return nil, errors.New("execution failed")
+		}
+		_, err = Do(ctx, "action2", nil)
+		assert.Error(t, err)
+		assert.Equal(t, 3, attempts)
+	})
 }
 
 type MockHandler struct {
@@ -21,7 +21,6 @@ package handlers
 
 import (
 	"context"
-	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -56,12 +55,6 @@ func (h *ExecHandler) Do(ctx context.Context, setting util.HandlerSpec, args map
 	}
 	envs := util.GetAllEnvs(args)
 	h.Logger.Info("execute action", "commands", setting.Command, "envs", envs)
-	if setting.TimeoutSeconds > 0 {
-		timeoutCtx, cancel := context.WithTimeout(ctx, time.Duration(setting.TimeoutSeconds)*time.Second)
-		defer cancel()
-		ctx = timeoutCtx
-	}
-
 	output, err := h.Executor.ExecCommand(ctx, setting.Command, envs)
 	if err != nil {
 		return nil, errors.Wrap(err, "ExecHandler executes action failed")
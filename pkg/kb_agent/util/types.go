@@ -19,6 +19,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 
 package util
 
+import "time"
+
 const (
 	OperationSuccess      = "Success"
 	OperationFailed       = "Failed"
@@ -39,13 +41,21 @@ type CronJob struct {
 	ReportFrequency  int `json:"reportFrequency,omitempty"`
 }
 
+// HandlerSpec specifies how an action is executed.
+// The timeout applies to each attempt, and the failed attempts are retried according to the retry policy.
 type HandlerSpec struct {
 	TimeoutSeconds int               `json:"timeoutSeconds,omitempty"`
+	RetryPolicy    *RetryPolicy      `json:"retryPolicy,omitempty"`
 	Command        []string          `json:"command,omitempty"`
 	GPRC           map[string]string `json:"grpc,omitempty"`
 	CronJob        *CronJob          `json:"cronJob,omitempty"`
 }
 
+type RetryPolicy struct {
+	MaxRetries    int           `json:"maxRetries,omitempty"`
+	RetryInterval time.Duration `json:"retryInterval,omitempty"`
+}
+
 type ActionMessage interface {
 	GetAction() string
 }
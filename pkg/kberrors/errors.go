@@ -0,0 +1,147 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+Package kberrors provides the typed errors shared by the webhooks and controllers.
+
+An Error carries a machine-readable reason code and an optional remediation hint for the users,
+the reason can be used as the reason of a condition or an event directly, and the message is rendered
+uniformly as "<message>: <cause>. Hint: <hint>" in the conditions, events and the output of the webhooks.
+*/
+package kberrors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Reason is the machine-readable code of an Error, it's in CamelCase.
+type Reason string
+
+const (
+	ReasonInvalid      Reason = "Invalid"
+	ReasonNotFound     Reason = "NotFound"
+	ReasonNotSupported Reason = "NotSupported"
+	ReasonForbidden    Reason = "Forbidden"
+	ReasonUnavailable  Reason = "Unavailable"
+
+	ReasonVolumeExpansionNotSupported Reason = "VolumeExpansionNotSupported"
+	ReasonInstanceNotFound            Reason = "InstanceNotFound"
+	ReasonBackupMethodNotFound        Reason = "BackupMethodNotFound"
+	ReasonJobFailed                   Reason = "JobFailed"
+	ReasonQuorumLoss                  Reason = "QuorumLoss"
+	ReasonClusterTerminating          Reason = "ClusterTerminating"
+	ReasonClusterPaused               Reason = "ClusterPaused"
+	ReasonDataExportFailed            Reason = "DataExportFailed"
+	ReasonQuotaExceeded               Reason = "QuotaExceeded"
+	ReasonStorageCapacityExceeded     Reason = "StorageCapacityExceeded"
+	ReasonPreconditionMismatch        Reason = "PreconditionMismatch"
+)
+
+// Error is an error with a reason code and a remediation hint.
+type Error struct {
+	Reason  Reason
+	Message string
+	// Hint tells the users how to diagnose or fix the error, e.g. the command to view the related resources.
+	Hint  string
+	cause error
+}
+
+var _ error = &Error{}
+
+// New returns an Error with the reason and the formatted message.
+func New(reason Reason, format string, a ...any) *Error {
+	return &Error{
+		Reason:  reason,
+		Message: fmt.Sprintf(format, a...),
+	}
+}
+
+// Wrap returns an Error which wraps the cause with the reason and the formatted message.
+func Wrap(cause error, reason Reason, format string, a ...any) *Error {
+	return &Error{
+		Reason:  reason,
+		Message: fmt.Sprintf(format, a...),
+		cause:   cause,
+	}
+}
+
+// WithHint sets the remediation hint of the Error.
+func (e *Error) WithHint(format string, a ...any) *Error {
+	e.Hint = fmt.Sprintf(format, a...)
+	return e
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	msg := e.Message
+	if e.cause != nil {
+		msg = fmt.Sprintf("%s: %s", msg, e.cause.Error())
+	}
+	if len(e.Hint) > 0 {
+		msg = fmt.Sprintf("%s. Hint: %s", strings.TrimRight(msg, ". "), e.Hint)
+	}
+	return msg
+}
+
+// Unwrap returns the cause of the Error.
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// ReasonOf returns the reason of the first Error in the chain of err, or an empty reason if there is none.
+func ReasonOf(err error) Reason {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Reason
+	}
+	return ""
+}
+
+// ReasonOrDefault returns the reason of err as a string, or the default reason if err is not an Error,
+// it's used to fill the reason of the conditions and events.
+func ReasonOrDefault(err error, defaultReason string) string {
+	if reason := ReasonOf(err); len(reason) > 0 {
+		return string(reason)
+	}
+	return defaultReason
+}
+
+// HintOf returns the hint of the first Error in the chain of err which has a hint.
+func HintOf(err error) string {
+	for err != nil {
+		if e, ok := err.(*Error); ok && len(e.Hint) > 0 {
+			return e.Hint
+		}
+		err = errors.Unwrap(err)
+	}
+	return ""
+}
+
+// IsReason checks whether an Error with the reason is in the chain of err.
+func IsReason(err error, reason Reason) bool {
+	for err != nil {
+		if e, ok := err.(*Error); ok && e.Reason == reason {
+			return true
+		}
+		err = errors.Unwrap(err)
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package kberrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestError(t *testing.T) {
+	err := New(ReasonVolumeExpansionNotSupported, "volumeClaimTemplate: %v not support volume expansion", []string{"data"}).
+		WithHint(`view the StorageClasses by "kubectl get sc"`)
+	expected := `volumeClaimTemplate: [data] not support volume expansion. Hint: view the StorageClasses by "kubectl get sc"`
+	if err.Error() != expected {
+		t.Errorf("unexpected message: %s", err.Error())
+	}
+
+	wrapped := fmt.Errorf("failed to validate: %w", err)
+	if ReasonOf(wrapped) != ReasonVolumeExpansionNotSupported || !IsReason(wrapped, ReasonVolumeExpansionNotSupported) {
+		t.Errorf("unexpected reason: %s", ReasonOf(wrapped))
+	}
+	if HintOf(wrapped) != err.Hint {
+		t.Errorf("unexpected hint: %s", HintOf(wrapped))
+	}
+	if ReasonOrDefault(errors.New("unknown"), "Failed") != "Failed" || ReasonOrDefault(wrapped, "Failed") != string(ReasonVolumeExpansionNotSupported) {
+		t.Error("unexpected reason with default")
+	}
+}
+
+func TestWrap(t *testing.T) {
+	cause := New(ReasonNotFound, "pod not found").WithHint("kubectl get pod")
+	err := Wrap(cause, ReasonInstanceNotFound, "failed to rebuild the instance")
+	if err.Error() != "failed to rebuild the instance: pod not found. Hint: kubectl get pod" {
+		t.Errorf("unexpected message: %s", err.Error())
+	}
+	if !errors.Is(err, cause) || !IsReason(err, ReasonNotFound) || ReasonOf(err) != ReasonInstanceNotFound {
+		t.Error("unexpected error chain")
+	}
+	// the hint of the cause is used if the error has no hint
+	if HintOf(err) != "kubectl get pod" {
+		t.Errorf("unexpected hint: %s", HintOf(err))
+	}
+}
@@ -0,0 +1,294 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package loadgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+// Run creates the synthetic Clusters, and the OpsRequests of each Cluster once it has been reconciled,
+// waits for all of them to be reconciled, and reports the latencies and the metrics of the manager.
+//
+// The objects are polled by the PollInterval, which is the resolution of the latencies measured.
+func Run(ctx context.Context, cli client.Client, opts Options) (*Report, error) {
+	if err := opts.complete(); err != nil {
+		return nil, err
+	}
+	g := &generator{
+		cli:             cli,
+		opts:            opts,
+		clustersCreated: map[string]time.Time{},
+		opsCreated:      map[string]time.Time{},
+		reconciled:      map[string]bool{},
+		report:          &Report{RunID: opts.RunID},
+	}
+	return g.run(ctx)
+}
+
+// Cleanup deletes the objects created by the run.
+func Cleanup(ctx context.Context, cli client.Client, namespace, runID string) error {
+	opts := []client.DeleteAllOfOption{client.InNamespace(namespace), client.MatchingLabels{RunIDLabelKey: runID}}
+	if err := cli.DeleteAllOf(ctx, &appsv1alpha1.OpsRequest{}, opts...); err != nil {
+		return err
+	}
+	return cli.DeleteAllOf(ctx, &appsv1alpha1.Cluster{}, opts...)
+}
+
+func (o *Options) complete() error {
+	if o.RunID == "" {
+		return errors.New("the run ID is required")
+	}
+	if o.ClusterTemplate == nil {
+		return errors.New("the cluster template is required")
+	}
+	if o.Clusters <= 0 {
+		return errors.New("the number of the clusters should be greater than 0")
+	}
+	if o.OpsPerCluster < 0 {
+		return errors.New("the number of the OpsRequests per cluster should not be less than 0")
+	}
+	if o.Namespace == "" {
+		o.Namespace = metav1.NamespaceDefault
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = DefaultConcurrency
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultTimeout
+	}
+	if o.PollInterval <= 0 {
+		o.PollInterval = DefaultPollInterval
+	}
+	return nil
+}
+
+type generator struct {
+	cli  client.Client
+	opts Options
+
+	// the local time when the objects are created, by the name
+	clustersCreated map[string]time.Time
+	opsCreated      map[string]time.Time
+	// the objects reconciled, by the kind and name
+	reconciled map[string]bool
+
+	clusterLatencies []time.Duration
+	opsLatencies     []time.Duration
+	report           *Report
+}
+
+func (g *generator) run(ctx context.Context) (*Report, error) {
+	var before *metricsSnapshot
+	if g.opts.MetricsURL != "" {
+		var err error
+		if before, err = scrapeMetrics(ctx, g.opts.MetricsURL); err != nil {
+			return nil, err
+		}
+		g.report.Memory = &MemoryStats{PeakHeapInuseBytes: before.heapInuse}
+	}
+
+	start := time.Now()
+	var clusters []client.Object
+	for i := 0; i < g.opts.Clusters; i++ {
+		clusters = append(clusters, g.buildCluster(i))
+	}
+	if err := g.createAll(ctx, clusters, g.clustersCreated); err != nil {
+		return nil, err
+	}
+	if err := g.wait(ctx); err != nil {
+		return nil, err
+	}
+	g.report.DurationSeconds = time.Since(start).Seconds()
+	g.report.ClusterLatency = latencyStats(g.clusterLatencies)
+	g.report.OpsLatency = latencyStats(g.opsLatencies)
+
+	if before != nil {
+		after, err := scrapeMetrics(ctx, g.opts.MetricsURL)
+		if err != nil {
+			return nil, err
+		}
+		g.observeMemory(after)
+		g.report.ReconcileTime = reconcileTimeStats(before, after)
+		g.report.Memory.HeapInuseBytes = after.heapInuse
+		g.report.Memory.ResidentBytes = after.resident
+	}
+	return g.report, nil
+}
+
+// wait polls the objects until all of them are reconciled or the timeout, the OpsRequests of a Cluster are created
+// once the Cluster is reconciled.
+func (g *generator) wait(ctx context.Context) error {
+	ticker := time.NewTicker(g.opts.PollInterval)
+	defer ticker.Stop()
+	timeout := time.After(g.opts.Timeout)
+	total := g.opts.Clusters * (1 + g.opts.OpsPerCluster)
+	for len(g.reconciled) < total {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+			g.report.TimedOut = total - len(g.reconciled)
+			return nil
+		case <-ticker.C:
+		}
+		if err := g.poll(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *generator) poll(ctx context.Context) error {
+	now := time.Now()
+	opts := []client.ListOption{client.InNamespace(g.opts.Namespace), client.MatchingLabels{RunIDLabelKey: g.opts.RunID}}
+
+	clusterList := &appsv1alpha1.ClusterList{}
+	if err := g.cli.List(ctx, clusterList, opts...); err != nil {
+		return err
+	}
+	var opsList []client.Object
+	for i := range clusterList.Items {
+		cluster := &clusterList.Items[i]
+		key := "Cluster/" + cluster.Name
+		if g.reconciled[key] || cluster.Status.ObservedGeneration < cluster.Generation {
+			continue
+		}
+		g.reconciled[key] = true
+		g.clusterLatencies = append(g.clusterLatencies, now.Sub(g.clustersCreated[cluster.Name]))
+		for j := 0; j < g.opts.OpsPerCluster; j++ {
+			opsList = append(opsList, g.buildOpsRequest(cluster, j))
+		}
+	}
+
+	opsRequestList := &appsv1alpha1.OpsRequestList{}
+	if err := g.cli.List(ctx, opsRequestList, opts...); err != nil {
+		return err
+	}
+	for _, ops := range opsRequestList.Items {
+		key := "OpsRequest/" + ops.Name
+		if g.reconciled[key] || ops.Status.Phase == "" || ops.Status.Phase == appsv1alpha1.OpsPendingPhase {
+			continue
+		}
+		g.reconciled[key] = true
+		g.opsLatencies = append(g.opsLatencies, now.Sub(g.opsCreated[ops.Name]))
+	}
+
+	if g.opts.MetricsURL != "" {
+		snapshot, err := scrapeMetrics(ctx, g.opts.MetricsURL)
+		if err != nil {
+			return err
+		}
+		g.observeMemory(snapshot)
+	}
+	return g.createAll(ctx, opsList, g.opsCreated)
+}
+
+func (g *generator) observeMemory(snapshot *metricsSnapshot) {
+	if snapshot.heapInuse > g.report.Memory.PeakHeapInuseBytes {
+		g.report.Memory.PeakHeapInuseBytes = snapshot.heapInuse
+	}
+}
+
+// createAll creates the objects concurrently, and records the local time when they are created.
+func (g *generator) createAll(ctx context.Context, objs []client.Object, created map[string]time.Time) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+		sem  = make(chan struct{}, g.opts.Concurrency)
+	)
+	for _, obj := range objs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(obj client.Object) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			createTime := time.Now()
+			err := g.cli.Create(ctx, obj)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("create %s failed: %w", obj.GetName(), err))
+				return
+			}
+			created[obj.GetName()] = createTime
+		}(obj)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (g *generator) buildCluster(i int) *appsv1alpha1.Cluster {
+	cluster := g.opts.ClusterTemplate.DeepCopy()
+	cluster.ObjectMeta = metav1.ObjectMeta{
+		Name:        fmt.Sprintf("%s-%d", g.opts.RunID, i),
+		Namespace:   g.opts.Namespace,
+		Labels:      g.runLabels(cluster.Labels),
+		Annotations: cluster.Annotations,
+	}
+	cluster.Status = appsv1alpha1.ClusterStatus{}
+	return cluster
+}
+
+// buildOpsRequest builds the OpsRequest of the Cluster from the template, or restarting all the components if
+// there is no template.
+func (g *generator) buildOpsRequest(cluster *appsv1alpha1.Cluster, i int) *appsv1alpha1.OpsRequest {
+	ops := &appsv1alpha1.OpsRequest{
+		Spec: appsv1alpha1.OpsRequestSpec{Type: appsv1alpha1.RestartType},
+	}
+	if g.opts.OpsTemplate != nil {
+		ops = g.opts.OpsTemplate.DeepCopy()
+	} else {
+		for _, compSpec := range cluster.Spec.ComponentSpecs {
+			ops.Spec.RestartList = append(ops.Spec.RestartList, appsv1alpha1.ComponentOps{ComponentName: compSpec.Name})
+		}
+	}
+	ops.ObjectMeta = metav1.ObjectMeta{
+		Name:        fmt.Sprintf("%s-ops-%d", cluster.Name, i),
+		Namespace:   cluster.Namespace,
+		Labels:      g.runLabels(ops.Labels),
+		Annotations: ops.Annotations,
+	}
+	ops.Spec.ClusterName = cluster.Name
+	ops.Spec.ClusterRef = ""
+	ops.Status = appsv1alpha1.OpsRequestStatus{}
+	return ops
+}
+
+func (g *generator) runLabels(labels map[string]string) map[string]string {
+	result := map[string]string{RunIDLabelKey: g.opts.RunID}
+	for k, v := range labels {
+		if k != RunIDLabelKey {
+			result[k] = v
+		}
+	}
+	return result
+}
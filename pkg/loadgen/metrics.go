@@ -0,0 +1,174 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package loadgen
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+const (
+	reconcileTimeMetric = "controller_runtime_reconcile_time_seconds"
+	heapInuseMetric     = "go_memstats_heap_inuse_bytes"
+	residentMetric      = "process_resident_memory_bytes"
+	controllerLabel     = "controller"
+)
+
+// metricsSnapshot is the metrics of the manager scraped at a time.
+type metricsSnapshot struct {
+	// reconcileTime is the histogram of the reconcile time, by the controller.
+	reconcileTime map[string]*dto.Histogram
+	heapInuse     float64
+	resident      float64
+}
+
+func scrapeMetrics(ctx context.Context, url string) (*metricsSnapshot, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("scrape metrics from %s failed: %s", url, resp.Status)
+	}
+	return parseMetrics(resp.Body)
+}
+
+func parseMetrics(in io.Reader) (*metricsSnapshot, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(in)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &metricsSnapshot{reconcileTime: map[string]*dto.Histogram{}}
+	if family, ok := families[reconcileTimeMetric]; ok {
+		for _, m := range family.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == controllerLabel {
+					snapshot.reconcileTime[label.GetValue()] = m.GetHistogram()
+				}
+			}
+		}
+	}
+	gauge := func(name string) float64 {
+		if family, ok := families[name]; ok && len(family.GetMetric()) > 0 {
+			return family.GetMetric()[0].GetGauge().GetValue()
+		}
+		return 0
+	}
+	snapshot.heapInuse = gauge(heapInuseMetric)
+	snapshot.resident = gauge(residentMetric)
+	return snapshot, nil
+}
+
+// reconcileTimeStats returns the distributions of the reconcile time observed between the two snapshots.
+func reconcileTimeStats(before, after *metricsSnapshot) map[string]LatencyStats {
+	stats := map[string]LatencyStats{}
+	for controller, histogram := range after.reconcileTime {
+		count, buckets := histogram.GetSampleCount(), histogram.GetBucket()
+		if prev, ok := before.reconcileTime[controller]; ok {
+			count -= prev.GetSampleCount()
+			buckets = subtractBuckets(buckets, prev.GetBucket())
+		}
+		if count == 0 {
+			continue
+		}
+		stats[controller] = LatencyStats{
+			Count: int(count),
+			P50:   histogramQuantile(0.5, count, buckets),
+			P90:   histogramQuantile(0.9, count, buckets),
+			P99:   histogramQuantile(0.99, count, buckets),
+		}
+	}
+	return stats
+}
+
+func subtractBuckets(buckets, prev []*dto.Bucket) []*dto.Bucket {
+	prevCounts := map[float64]uint64{}
+	for _, b := range prev {
+		prevCounts[b.GetUpperBound()] = b.GetCumulativeCount()
+	}
+	var result []*dto.Bucket
+	for _, b := range buckets {
+		count := b.GetCumulativeCount() - prevCounts[b.GetUpperBound()]
+		upperBound := b.GetUpperBound()
+		result = append(result, &dto.Bucket{CumulativeCount: &count, UpperBound: &upperBound})
+	}
+	return result
+}
+
+// histogramQuantile estimates the quantile from the cumulative buckets of a histogram by the linear interpolation,
+// in the same way as the histogram_quantile function of Prometheus.
+func histogramQuantile(q float64, count uint64, buckets []*dto.Bucket) float64 {
+	buckets = append([]*dto.Bucket{}, buckets...)
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].GetUpperBound() < buckets[j].GetUpperBound()
+	})
+	rank := q * float64(count)
+	lowerBound, lowerCount := 0.0, 0.0
+	for _, b := range buckets {
+		if math.IsInf(b.GetUpperBound(), 1) {
+			break
+		}
+		if c := float64(b.GetCumulativeCount()); c >= rank {
+			return lowerBound + (b.GetUpperBound()-lowerBound)*(rank-lowerCount)/(c-lowerCount)
+		}
+		lowerBound, lowerCount = b.GetUpperBound(), float64(b.GetCumulativeCount())
+	}
+	// the quantile falls into the +Inf bucket, return the upper bound of the highest finite bucket
+	return lowerBound
+}
+
+// latencyStats returns the distribution of the latencies measured.
+func latencyStats(latencies []time.Duration) LatencyStats {
+	if len(latencies) == 0 {
+		return LatencyStats{}
+	}
+	sorted := append([]time.Duration{}, latencies...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	percentile := func(p float64) float64 {
+		i := int(math.Ceil(p*float64(len(sorted)))) - 1
+		if i < 0 {
+			i = 0
+		}
+		return sorted[i].Seconds()
+	}
+	return LatencyStats{
+		Count: len(sorted),
+		P50:   percentile(0.5),
+		P90:   percentile(0.9),
+		P99:   percentile(0.99),
+		Max:   sorted[len(sorted)-1].Seconds(),
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package loadgen
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testMetricsBefore = `# HELP controller_runtime_reconcile_time_seconds Length of time per reconciliation per controller
+# TYPE controller_runtime_reconcile_time_seconds histogram
+controller_runtime_reconcile_time_seconds_bucket{controller="cluster",le="0.1"} 10
+controller_runtime_reconcile_time_seconds_bucket{controller="cluster",le="0.5"} 10
+controller_runtime_reconcile_time_seconds_bucket{controller="cluster",le="1"} 10
+controller_runtime_reconcile_time_seconds_bucket{controller="cluster",le="+Inf"} 10
+controller_runtime_reconcile_time_seconds_sum{controller="cluster"} 0.5
+controller_runtime_reconcile_time_seconds_count{controller="cluster"} 10
+# HELP go_memstats_heap_inuse_bytes Number of heap bytes that are in use.
+# TYPE go_memstats_heap_inuse_bytes gauge
+go_memstats_heap_inuse_bytes 1e+07
+`
+
+const testMetricsAfter = `# HELP controller_runtime_reconcile_time_seconds Length of time per reconciliation per controller
+# TYPE controller_runtime_reconcile_time_seconds histogram
+controller_runtime_reconcile_time_seconds_bucket{controller="cluster",le="0.1"} 60
+controller_runtime_reconcile_time_seconds_bucket{controller="cluster",le="0.5"} 100
+controller_runtime_reconcile_time_seconds_bucket{controller="cluster",le="1"} 110
+controller_runtime_reconcile_time_seconds_bucket{controller="cluster",le="+Inf"} 110
+controller_runtime_reconcile_time_seconds_sum{controller="cluster"} 20
+controller_runtime_reconcile_time_seconds_count{controller="cluster"} 110
+controller_runtime_reconcile_time_seconds_bucket{controller="opsrequest",le="0.1"} 0
+controller_runtime_reconcile_time_seconds_bucket{controller="opsrequest",le="0.5"} 0
+controller_runtime_reconcile_time_seconds_bucket{controller="opsrequest",le="1"} 0
+controller_runtime_reconcile_time_seconds_bucket{controller="opsrequest",le="+Inf"} 0
+controller_runtime_reconcile_time_seconds_sum{controller="opsrequest"} 0
+controller_runtime_reconcile_time_seconds_count{controller="opsrequest"} 0
+# HELP go_memstats_heap_inuse_bytes Number of heap bytes that are in use.
+# TYPE go_memstats_heap_inuse_bytes gauge
+go_memstats_heap_inuse_bytes 3e+07
+# HELP process_resident_memory_bytes Resident memory size in bytes.
+# TYPE process_resident_memory_bytes gauge
+process_resident_memory_bytes 5e+07
+`
+
+func TestReconcileTimeStats(t *testing.T) {
+	before, err := parseMetrics(strings.NewReader(testMetricsBefore))
+	if err != nil {
+		t.Fatal(err)
+	}
+	after, err := parseMetrics(strings.NewReader(testMetricsAfter))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.heapInuse != 3e7 || after.resident != 5e7 {
+		t.Errorf("unexpected memory, heap in use %v, resident %v", after.heapInuse, after.resident)
+	}
+
+	stats := reconcileTimeStats(before, after)
+	if _, ok := stats["opsrequest"]; ok {
+		t.Errorf("expected no stats of the controller without reconciliations")
+	}
+	// 100 reconciliations during the run: 50 within 0.1s, 40 within 0.5s and 10 within 1s
+	cluster := stats["cluster"]
+	expected := LatencyStats{Count: 100, P50: 0.1, P90: 0.5, P99: 0.95}
+	if cluster.Count != expected.Count || !approxEqual(cluster.P50, expected.P50) ||
+		!approxEqual(cluster.P90, expected.P90) || !approxEqual(cluster.P99, expected.P99) {
+		t.Errorf("expected reconcile time %+v, got %+v", expected, cluster)
+	}
+}
+
+func TestLatencyStats(t *testing.T) {
+	if stats := latencyStats(nil); stats != (LatencyStats{}) {
+		t.Errorf("expected empty stats, got %+v", stats)
+	}
+	var latencies []time.Duration
+	for i := 100; i > 0; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	stats := latencyStats(latencies)
+	expected := LatencyStats{Count: 100, P50: 0.05, P90: 0.09, P99: 0.099, Max: 0.1}
+	if stats.Count != expected.Count || !approxEqual(stats.P50, expected.P50) || !approxEqual(stats.P90, expected.P90) ||
+		!approxEqual(stats.P99, expected.P99) || !approxEqual(stats.Max, expected.Max) {
+		t.Errorf("expected latencies %+v, got %+v", expected, stats)
+	}
+}
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
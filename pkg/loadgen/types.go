@@ -0,0 +1,103 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+Package loadgen generates the load of synthetic Clusters and OpsRequests against a KubeBlocks manager, which runs
+with an API server of envtest or kwok, and measures the latencies of the reconciliations and the memory of the manager,
+to make the performance regressions across the releases measurable.
+*/
+package loadgen
+
+import (
+	"time"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+const (
+	// RunIDLabelKey labels the objects created by a run of the load generation, which are cleaned up together.
+	RunIDLabelKey = "loadgen.kubeblocks.io/run-id"
+
+	DefaultConcurrency  = 10
+	DefaultTimeout      = 10 * time.Minute
+	DefaultPollInterval = 500 * time.Millisecond
+)
+
+// Options defines the options of the load generation.
+type Options struct {
+	// RunID identifies the run, the names of the objects created are prefixed with it.
+	RunID string
+	// Namespace is the namespace to create the objects in.
+	Namespace string
+	// Clusters is the number of the synthetic Clusters to create.
+	Clusters int
+	// OpsPerCluster is the number of the OpsRequests to create for each Cluster, once it has been reconciled.
+	OpsPerCluster int
+	// ClusterTemplate is the template of the synthetic Clusters, the name and namespace are overridden.
+	ClusterTemplate *appsv1alpha1.Cluster
+	// OpsTemplate is the template of the OpsRequests, the name, namespace and cluster are overridden.
+	// The OpsRequests restart all the components of the Cluster if not specified.
+	OpsTemplate *appsv1alpha1.OpsRequest
+	// Concurrency is the number of the objects created concurrently.
+	Concurrency int
+	// MetricsURL is the URL of the metrics endpoint of the manager, e.g. "http://localhost:8080/metrics".
+	// The reconcile time and the memory of the manager are not measured if not specified.
+	MetricsURL string
+	// Timeout is the duration to wait for all the objects to be reconciled.
+	Timeout time.Duration
+	// PollInterval is the interval to poll the objects and scrape the metrics.
+	PollInterval time.Duration
+}
+
+// LatencyStats is the distribution of the latencies, in seconds.
+type LatencyStats struct {
+	Count int     `json:"count"`
+	P50   float64 `json:"p50"`
+	P90   float64 `json:"p90"`
+	P99   float64 `json:"p99"`
+	Max   float64 `json:"max,omitempty"`
+}
+
+// MemoryStats is the memory of the manager, in bytes.
+type MemoryStats struct {
+	// HeapInuseBytes is the heap in use at the end of the run.
+	HeapInuseBytes float64 `json:"heapInuseBytes"`
+	// PeakHeapInuseBytes is the peak of the heap in use sampled during the run.
+	PeakHeapInuseBytes float64 `json:"peakHeapInuseBytes"`
+	// ResidentBytes is the resident memory of the process at the end of the run.
+	ResidentBytes float64 `json:"residentBytes"`
+}
+
+// Report is the result of a run of the load generation.
+type Report struct {
+	RunID string `json:"runID"`
+	// DurationSeconds is the duration of the run, excluding the cleanup.
+	DurationSeconds float64 `json:"durationSeconds"`
+	// ClusterLatency is the distribution of the latencies from creating a Cluster to its first reconciliation,
+	// i.e. the observed generation of the status catches up with the generation.
+	ClusterLatency LatencyStats `json:"clusterLatency"`
+	// OpsLatency is the distribution of the latencies from creating an OpsRequest to it leaving the Pending phase.
+	OpsLatency LatencyStats `json:"opsLatency"`
+	// TimedOut is the number of the objects not reconciled within the timeout.
+	TimedOut int `json:"timedOut"`
+	// ReconcileTime is the distribution of the reconcile time of the controllers during the run, by the controller.
+	ReconcileTime map[string]LatencyStats `json:"reconcileTime,omitempty"`
+	// Memory is the memory of the manager.
+	Memory *MemoryStats `json:"memory,omitempty"`
+}
@@ -23,6 +23,8 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/rest"
@@ -243,6 +245,145 @@ func (cli *lorryClient) PreTerminate(ctx context.Context) error {
 	return err
 }
 
+// ReloadTLS sends a reload TLS request to Lorry.
+func (cli *lorryClient) ReloadTLS(ctx context.Context, certHash string) error {
+	parameters := map[string]any{
+		"certHash": certHash,
+	}
+	req := map[string]any{"parameters": parameters}
+	_, err := cli.Request(ctx, string(ReloadTLSOperation), http.MethodPost, req)
+	return err
+}
+
+// Purge sends a purge request to Lorry, and returns the space in bytes reclaimed by the purge action.
+func (cli *lorryClient) Purge(ctx context.Context, retainHours *int32, retainBytes *int64) (int64, error) {
+	parameters := map[string]any{}
+	if retainHours != nil {
+		parameters["retainHours"] = strconv.FormatInt(int64(*retainHours), 10)
+	}
+	if retainBytes != nil {
+		parameters["retainBytes"] = strconv.FormatInt(*retainBytes, 10)
+	}
+	req := map[string]any{"parameters": parameters}
+	resp, err := cli.Request(ctx, string(PurgeOperation), http.MethodPost, req)
+	if err != nil {
+		return 0, err
+	}
+	reclaimedBytes, ok := resp["reclaimedBytes"].(float64)
+	if !ok {
+		return 0, nil
+	}
+	return int64(reclaimedBytes), nil
+}
+
+// RotateCredential sends a rotate credential request to Lorry.
+func (cli *lorryClient) RotateCredential(ctx context.Context, accountName, password string) error {
+	parameters := map[string]any{
+		"accountName": accountName,
+		"password":    password,
+	}
+	req := map[string]any{"parameters": parameters}
+	_, err := cli.Request(ctx, string(RotateCredentialOperation), http.MethodPost, req)
+	return err
+}
+
+// DrainBackend sends a drain backend request to Lorry.
+func (cli *lorryClient) DrainBackend(ctx context.Context, componentName, podName, podFQDN string) error {
+	req := map[string]any{"parameters": buildBackendParameters(componentName, podName, podFQDN)}
+	_, err := cli.Request(ctx, string(DrainBackendOperation), http.MethodPost, req)
+	return err
+}
+
+// ResumeBackend sends a resume backend request to Lorry.
+func (cli *lorryClient) ResumeBackend(ctx context.Context, componentName, podName, podFQDN string) error {
+	req := map[string]any{"parameters": buildBackendParameters(componentName, podName, podFQDN)}
+	_, err := cli.Request(ctx, string(ResumeBackendOperation), http.MethodPost, req)
+	return err
+}
+
+// UpgradePreCheck sends an upgrade pre-check request to Lorry.
+func (cli *lorryClient) UpgradePreCheck(ctx context.Context, check, fromVersion, toVersion string) error {
+	parameters := map[string]any{
+		"check":       check,
+		"fromVersion": fromVersion,
+		"toVersion":   toVersion,
+	}
+	req := map[string]any{"parameters": parameters}
+	_, err := cli.Request(ctx, string(UpgradePreCheckOperation), http.MethodPost, req)
+	return err
+}
+
+// ShardRebalance sends a shard rebalance request to Lorry.
+func (cli *lorryClient) ShardRebalance(ctx context.Context, shardName string, shards []string) (int64, error) {
+	parameters := map[string]any{
+		"shardName": shardName,
+		"shards":    strings.Join(shards, ","),
+	}
+	req := map[string]any{"parameters": parameters}
+	resp, err := cli.Request(ctx, string(ShardRebalanceOperation), http.MethodPost, req)
+	if err != nil {
+		return 0, err
+	}
+	movedBytes, ok := resp["movedBytes"].(float64)
+	if !ok {
+		return 0, nil
+	}
+	return int64(movedBytes), nil
+}
+
+// WarmUpCheck sends a warm-up check request to Lorry.
+func (cli *lorryClient) WarmUpCheck(ctx context.Context) error {
+	_, err := cli.Request(ctx, string(WarmUpCheckOperation), http.MethodPost, nil)
+	return err
+}
+
+// Checksum sends a checksum request to Lorry, and returns the checksums keyed by the files.
+func (cli *lorryClient) Checksum(ctx context.Context, files []string) (map[string]string, error) {
+	parameters := map[string]any{
+		"files": strings.Join(files, ","),
+	}
+	req := map[string]any{"parameters": parameters}
+	resp, err := cli.Request(ctx, string(ChecksumOperation), http.MethodPost, req)
+	if err != nil {
+		return nil, err
+	}
+	checksums := map[string]string{}
+	values, _ := resp["checksums"].(map[string]any)
+	for file, value := range values {
+		if checksum, ok := value.(string); ok {
+			checksums[file] = checksum
+		}
+	}
+	return checksums, nil
+}
+
+// ReplicationLag sends a replication lag request to Lorry, and returns the replication lag in seconds of the replica.
+func (cli *lorryClient) ReplicationLag(ctx context.Context, leaderPodName, leaderPodFQDN string) (int64, error) {
+	parameters := map[string]any{
+		"leaderPodName": leaderPodName,
+		"leaderPodFQDN": leaderPodFQDN,
+	}
+	req := map[string]any{"parameters": parameters}
+	resp, err := cli.Request(ctx, string(ReplicationLagOperation), http.MethodPost, req)
+	if err != nil {
+		return 0, err
+	}
+	// the lag is never assumed to be 0, or a stale replica may be promoted
+	lagSeconds, ok := resp["lagSeconds"].(float64)
+	if !ok {
+		return 0, errors.New("the replication lag is not reported by lorry")
+	}
+	return int64(lagSeconds), nil
+}
+
+func buildBackendParameters(componentName, podName, podFQDN string) map[string]any {
+	return map[string]any{
+		"componentName": componentName,
+		"podName":       podName,
+		"podFQDN":       podFQDN,
+	}
+}
+
 // Rebuild sends a slave rebuild request to Lorry.
 func (cli *lorryClient) Rebuild(ctx context.Context) error {
 	_, err := cli.Request(ctx, "rebuild", http.MethodPost, nil)
@@ -55,6 +55,21 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// Checksum mocks base method.
+func (m *MockClient) Checksum(arg0 context.Context, arg1 []string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checksum", arg0, arg1)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checksum indicates an expected call of Checksum.
+func (mr *MockClientMockRecorder) Checksum(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checksum", reflect.TypeOf((*MockClient)(nil).Checksum), arg0, arg1)
+}
+
 // CreateUser mocks base method.
 func (m *MockClient) CreateUser(arg0 context.Context, arg1, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
@@ -126,6 +141,20 @@ func (mr *MockClientMockRecorder) DescribeUser(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeUser", reflect.TypeOf((*MockClient)(nil).DescribeUser), arg0, arg1)
 }
 
+// DrainBackend mocks base method.
+func (m *MockClient) DrainBackend(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DrainBackend", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DrainBackend indicates an expected call of DrainBackend.
+func (mr *MockClientMockRecorder) DrainBackend(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DrainBackend", reflect.TypeOf((*MockClient)(nil).DrainBackend), arg0, arg1, arg2, arg3)
+}
+
 // GetRole mocks base method.
 func (m *MockClient) GetRole(arg0 context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -255,6 +284,21 @@ func (mr *MockClientMockRecorder) PreTerminate(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreTerminate", reflect.TypeOf((*MockClient)(nil).PreTerminate), arg0)
 }
 
+// Purge mocks base method.
+func (m *MockClient) Purge(arg0 context.Context, arg1 *int32, arg2 *int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockClientMockRecorder) Purge(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockClient)(nil).Purge), arg0, arg1, arg2)
+}
+
 // Rebuild mocks base method.
 func (m *MockClient) Rebuild(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -269,6 +313,49 @@ func (mr *MockClientMockRecorder) Rebuild(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebuild", reflect.TypeOf((*MockClient)(nil).Rebuild), arg0)
 }
 
+// ReloadTLS mocks base method.
+func (m *MockClient) ReloadTLS(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReloadTLS", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReloadTLS indicates an expected call of ReloadTLS.
+func (mr *MockClientMockRecorder) ReloadTLS(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadTLS", reflect.TypeOf((*MockClient)(nil).ReloadTLS), arg0, arg1)
+}
+
+// ReplicationLag mocks base method.
+func (m *MockClient) ReplicationLag(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplicationLag", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplicationLag indicates an expected call of ReplicationLag.
+func (mr *MockClientMockRecorder) ReplicationLag(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplicationLag", reflect.TypeOf((*MockClient)(nil).ReplicationLag), arg0, arg1, arg2)
+}
+
+// ResumeBackend mocks base method.
+func (m *MockClient) ResumeBackend(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeBackend", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResumeBackend indicates an expected call of ResumeBackend.
+func (mr *MockClientMockRecorder) ResumeBackend(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeBackend", reflect.TypeOf((*MockClient)(nil).ResumeBackend), arg0, arg1, arg2, arg3)
+}
+
 // RevokeUserRole mocks base method.
 func (m *MockClient) RevokeUserRole(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -283,6 +370,35 @@ func (mr *MockClientMockRecorder) RevokeUserRole(arg0, arg1, arg2 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserRole", reflect.TypeOf((*MockClient)(nil).RevokeUserRole), arg0, arg1, arg2)
 }
 
+// RotateCredential mocks base method.
+func (m *MockClient) RotateCredential(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateCredential", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateCredential indicates an expected call of RotateCredential.
+func (mr *MockClientMockRecorder) RotateCredential(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateCredential", reflect.TypeOf((*MockClient)(nil).RotateCredential), arg0, arg1, arg2)
+}
+
+// ShardRebalance mocks base method.
+func (m *MockClient) ShardRebalance(arg0 context.Context, arg1 string, arg2 []string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShardRebalance", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShardRebalance indicates an expected call of ShardRebalance.
+func (mr *MockClientMockRecorder) ShardRebalance(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShardRebalance", reflect.TypeOf((*MockClient)(nil).ShardRebalance), arg0, arg1, arg2)
+}
+
 // Switchover mocks base method.
 func (m *MockClient) Switchover(arg0 context.Context, arg1, arg2 string, arg3 bool) error {
 	m.ctrl.T.Helper()
@@ -297,6 +413,20 @@ func (mr *MockClientMockRecorder) Switchover(arg0, arg1, arg2, arg3 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Switchover", reflect.TypeOf((*MockClient)(nil).Switchover), arg0, arg1, arg2, arg3)
 }
 
+// UpgradePreCheck mocks base method.
+func (m *MockClient) UpgradePreCheck(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpgradePreCheck", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpgradePreCheck indicates an expected call of UpgradePreCheck.
+func (mr *MockClientMockRecorder) UpgradePreCheck(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradePreCheck", reflect.TypeOf((*MockClient)(nil).UpgradePreCheck), arg0, arg1, arg2, arg3)
+}
+
 // Unlock mocks base method.
 func (m *MockClient) Unlock(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -310,3 +440,17 @@ func (mr *MockClientMockRecorder) Unlock(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockClient)(nil).Unlock), arg0)
 }
+
+// WarmUpCheck mocks base method.
+func (m *MockClient) WarmUpCheck(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WarmUpCheck", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WarmUpCheck indicates an expected call of WarmUpCheck.
+func (mr *MockClientMockRecorder) WarmUpCheck(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WarmUpCheck", reflect.TypeOf((*MockClient)(nil).WarmUpCheck), arg0)
+}
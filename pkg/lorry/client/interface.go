@@ -46,6 +46,46 @@ type Client interface {
 	PostProvision(ctx context.Context, componentNames, podNames, podIPs, podHostNames, podHostIPs string) error
 	PreTerminate(ctx context.Context) error
 
+	// ReloadTLS sends a reload TLS request to Lorry, the certHash is the sha256 of the renewed certificate,
+	// Lorry returns an error if the certificate mounted in the pod has not been refreshed to it yet.
+	ReloadTLS(ctx context.Context, certHash string) error
+
+	// Purge sends a purge request to Lorry to clean up the logs and temporary files of the DB service,
+	// the data of the last retainHours hours or retainBytes bytes is kept, and the reclaimed bytes are returned.
+	Purge(ctx context.Context, retainHours *int32, retainBytes *int64) (int64, error)
+
+	// RotateCredential sends a rotate credential request to Lorry to change the password of the system account
+	// at the engine level.
+	RotateCredential(ctx context.Context, accountName, password string) error
+
+	// DrainBackend sends a drain backend request to the Lorry of a proxy, to stop routing new connections to
+	// the backend instance and drain the existing ones before the instance is stopped.
+	DrainBackend(ctx context.Context, componentName, podName, podFQDN string) error
+
+	// ResumeBackend sends a resume backend request to the Lorry of a proxy, to route connections to
+	// the backend instance again after it is restarted.
+	ResumeBackend(ctx context.Context, componentName, podName, podFQDN string) error
+
+	// UpgradePreCheck sends an upgrade pre-check request to Lorry, to check whether the replica is ready to be
+	// upgraded from the service version fromVersion to toVersion, an error is returned if the check fails.
+	UpgradePreCheck(ctx context.Context, check, fromVersion, toVersion string) error
+
+	// ShardRebalance sends a shard rebalance request to Lorry, to move the data between the shard and the others,
+	// and the size in bytes of the data moved into the shard is returned.
+	ShardRebalance(ctx context.Context, shardName string, shards []string) (int64, error)
+
+	// WarmUpCheck sends a warm-up check request to Lorry, to check whether the replica has been warmed up
+	// after it is restarted, an error is returned if the replica is still warming up.
+	WarmUpCheck(ctx context.Context) error
+
+	// Checksum sends a checksum request to Lorry, to get the sha256 checksums of the files mounted in the pod,
+	// which are used to verify whether the renewed secrets or configs have been propagated to the pod.
+	Checksum(ctx context.Context, files []string) (map[string]string, error)
+
+	// ReplicationLag sends a replication lag request to Lorry, to measure the replication lag in seconds of
+	// the replica behind the leader, whose pod name and FQDN are given.
+	ReplicationLag(ctx context.Context, leaderPodName, leaderPodFQDN string) (int64, error)
+
 	// local rebuild slave
 	Rebuild(ctx context.Context) error
 	DataDump(ctx context.Context) error
@@ -325,6 +325,7 @@ func (store *KubernetesStore) CreateLease() error {
 				"acquire-time": nowStr,
 				"renew-time":   nowStr,
 				"ttl":          ttl,
+				"node":         viper.GetString(constant.KBEnvNodeName),
 				"extra":        "",
 			},
 		},
@@ -373,8 +374,11 @@ func (store *KubernetesStore) GetLeader() (*Leader, error) {
 		}
 	}
 
+	var formerLeader, formerNode string
 	if ttl > 0 && time.Now().Unix()-renewTime > int64(ttl) {
 		store.logger.Info(fmt.Sprintf("lock expired: %v, now: %d", annotations, time.Now().Unix()))
+		formerLeader = leader
+		formerNode = annotations["node"]
 		leader = ""
 	}
 
@@ -386,6 +390,8 @@ func (store *KubernetesStore) GetLeader() (*Leader, error) {
 		TTL:         ttl,
 		Resource:    configmap,
 		DBState:     dbState,
+		FormerName:  formerLeader,
+		FormerNode:  formerNode,
 	}, nil
 }
 
@@ -408,6 +414,7 @@ func (store *KubernetesStore) AttemptAcquireLease() error {
 		"ttl":          strconv.Itoa(ttl),
 		"renew-time":   now,
 		"acquire-time": now,
+		"node":         viper.GetString(constant.KBEnvNodeName),
 	}
 
 	configMap := store.cluster.Leader.Resource.(*corev1.ConfigMap)
@@ -442,6 +449,7 @@ func (store *KubernetesStore) UpdateLease() error {
 	ttl := store.cluster.HaConfig.ttl
 	annotations["ttl"] = strconv.Itoa(ttl)
 	annotations["renew-time"] = strconv.FormatInt(time.Now().Unix(), 10)
+	annotations["node"] = viper.GetString(constant.KBEnvNodeName)
 
 	if store.cluster.Leader.DBState != nil {
 		str, _ := json.Marshal(store.cluster.Leader.DBState)
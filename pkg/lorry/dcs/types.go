@@ -225,6 +225,11 @@ type Leader struct {
 	RenewTime   int64
 	TTL         int
 	Resource    any
+	// FormerName is the holder of the expired lease, which is not released gracefully.
+	// The former leader may be partitioned but still alive, it should be fenced before a new leader is promoted.
+	FormerName string
+	// FormerNode is the node of the former leader when it renewed the lease for the last time.
+	FormerNode string
 }
 
 type DBState struct {
@@ -25,6 +25,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -320,3 +321,271 @@ func (mgr *Manager) PreTerminate(ctx context.Context) error {
 	}
 	return err
 }
+
+// ReloadTLS reloads the renewed certificates mounted at /etc/pki/tls into the DB service,
+// it provides the same environment variables as PreTerminate for the action.
+func (mgr *Manager) ReloadTLS(ctx context.Context) error {
+	reloadTLSCmd, ok := mgr.actionCommands[constant.ReloadTLSAction]
+	if !ok || len(reloadTLSCmd) == 0 {
+		return errors.New("component reloadTLS command is empty")
+	}
+	envs, err := util.GetGlobalSharedEnvs()
+	if err != nil {
+		return err
+	}
+	output, err := util.ExecCommand(ctx, reloadTLSCmd, envs)
+
+	if output != "" {
+		mgr.Logger.Info("component reloadTLS", "output", output)
+	}
+	return err
+}
+
+// Fence provides the following dedicated environment variables for the action:
+//
+// - KB_SERVICE_PORT: The port on which the DB service listens.
+// - KB_SERVICE_USER: The username used to access the DB service with sufficient privileges.
+// - KB_SERVICE_PASSWORD: The password of the user used to access the DB service .
+// - KB_FENCED_MEMBER_POD_NAME: The name of the former leader's Pod.
+// - KB_FENCED_MEMBER_POD_IP: The IP of the former leader's Pod.
+func (mgr *Manager) Fence(ctx context.Context, cluster *dcs.Cluster, memberName string) error {
+	fenceCmd, ok := mgr.actionCommands[constant.FenceAction]
+	if !ok || len(fenceCmd) == 0 {
+		return errors.New("component fence command is empty")
+	}
+	envs, err := util.GetGlobalSharedEnvs()
+	if err != nil {
+		return err
+	}
+	envs = append(envs, "KB_FENCED_MEMBER_POD_NAME"+"="+memberName)
+	member := cluster.GetMemberWithName(memberName)
+	if member != nil {
+		envs = append(envs, "KB_FENCED_MEMBER_POD_IP"+"="+member.PodIP)
+	}
+	output, err := util.ExecCommand(ctx, fenceCmd, envs)
+
+	if output != "" {
+		mgr.Logger.Info("component fence", "output", output)
+	}
+	return err
+}
+
+// DrainBackend provides the following dedicated environment variables for the action:
+//
+// - KB_BACKEND_COMP_NAME: The name of the Component to which the backend instance belongs.
+// - KB_BACKEND_POD_NAME: The name of the backend instance's Pod.
+// - KB_BACKEND_POD_FQDN: The FQDN of the backend instance's Pod.
+func (mgr *Manager) DrainBackend(ctx context.Context, componentName, podName, podFQDN string) error {
+	return mgr.execBackendAction(ctx, constant.DrainBackendAction, componentName, podName, podFQDN)
+}
+
+// ResumeBackend provides the same dedicated environment variables as DrainBackend.
+func (mgr *Manager) ResumeBackend(ctx context.Context, componentName, podName, podFQDN string) error {
+	return mgr.execBackendAction(ctx, constant.ResumeBackendAction, componentName, podName, podFQDN)
+}
+
+func (mgr *Manager) execBackendAction(ctx context.Context, action, componentName, podName, podFQDN string) error {
+	cmd, ok := mgr.actionCommands[action]
+	if !ok || len(cmd) == 0 {
+		return errors.Errorf("component %s command is empty", action)
+	}
+	envs, err := util.GetGlobalSharedEnvs()
+	if err != nil {
+		return err
+	}
+	envs = append(envs, "KB_BACKEND_COMP_NAME"+"="+componentName)
+	envs = append(envs, "KB_BACKEND_POD_NAME"+"="+podName)
+	envs = append(envs, "KB_BACKEND_POD_FQDN"+"="+podFQDN)
+	output, err := util.ExecCommand(ctx, cmd, envs)
+
+	if output != "" {
+		mgr.Logger.Info("component "+action, "output", output)
+	}
+	return err
+}
+
+// Purge provides the following dedicated environment variables for the action:
+//
+// - KB_SERVICE_PORT: The port on which the DB service listens.
+// - KB_SERVICE_USER: The username used to access the DB service with sufficient privileges.
+// - KB_SERVICE_PASSWORD: The password of the user used to access the DB service .
+// - KB_PURGE_RETAIN_HOURS: The number of hours of the most recent data to retain, empty if not specified.
+// - KB_PURGE_RETAIN_BYTES: The size in bytes of the most recent data to retain, empty if not specified.
+//
+// The last line of the output is parsed as the reclaimed space in bytes, 0 is returned if it is not a number.
+func (mgr *Manager) Purge(ctx context.Context, retainHours, retainBytes string) (int64, error) {
+	purgeCmd, ok := mgr.actionCommands[constant.PurgeAction]
+	if !ok || len(purgeCmd) == 0 {
+		return 0, errors.New("component purge command is empty")
+	}
+	envs, err := util.GetGlobalSharedEnvs()
+	if err != nil {
+		return 0, err
+	}
+	envs = append(envs, "KB_PURGE_RETAIN_HOURS"+"="+retainHours)
+	envs = append(envs, "KB_PURGE_RETAIN_BYTES"+"="+retainBytes)
+	output, err := util.ExecCommand(ctx, purgeCmd, envs)
+
+	if output != "" {
+		mgr.Logger.Info("component purge", "output", output)
+	}
+	if err != nil {
+		return 0, err
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	reclaimedBytes, err := strconv.ParseInt(strings.TrimSpace(lines[len(lines)-1]), 10, 64)
+	if err != nil {
+		mgr.Logger.Info("the reclaimed space is not reported by the purge action", "error", err.Error())
+		return 0, nil
+	}
+	return reclaimedBytes, nil
+}
+
+// RotateCredential provides the following dedicated environment variables for the action:
+//
+// - KB_SERVICE_PORT: The port on which the DB service listens.
+// - KB_SERVICE_USER: The username used to access the DB service with sufficient privileges.
+// - KB_SERVICE_PASSWORD: The password of the user used to access the DB service .
+// - KB_ACCOUNT_NAME: The name of the system account.
+// - KB_ACCOUNT_PASSWORD: The new password of the system account.
+func (mgr *Manager) RotateCredential(ctx context.Context, accountName, password string) error {
+	rotateCmd, ok := mgr.actionCommands[constant.AccountRotationAction]
+	if !ok || len(rotateCmd) == 0 {
+		return errors.New("component account rotation command is empty")
+	}
+	envs, err := util.GetGlobalSharedEnvs()
+	if err != nil {
+		return err
+	}
+	envs = append(envs, "KB_ACCOUNT_NAME"+"="+accountName)
+	envs = append(envs, "KB_ACCOUNT_PASSWORD"+"="+password)
+	output, err := util.ExecCommand(ctx, rotateCmd, envs)
+
+	if output != "" {
+		mgr.Logger.Info("component account rotation", "account", accountName, "output", output)
+	}
+	return err
+}
+
+// UpgradePreCheck provides the following dedicated environment variables for the action:
+//
+// - KB_SERVICE_PORT: The port on which the DB service listens.
+// - KB_SERVICE_USER: The username used to access the DB service with sufficient privileges.
+// - KB_SERVICE_PASSWORD: The password of the user used to access the DB service .
+// - KB_UPGRADE_PRE_CHECK: The name of the pre-check to perform.
+// - KB_UPGRADE_FROM_VERSION: The service version currently running.
+// - KB_UPGRADE_TO_VERSION: The service version to upgrade to.
+func (mgr *Manager) UpgradePreCheck(ctx context.Context, check, fromVersion, toVersion string) error {
+	checkCmd, ok := mgr.actionCommands[constant.UpgradePreCheckAction]
+	if !ok || len(checkCmd) == 0 {
+		return errors.New("component upgrade pre-check command is empty")
+	}
+	envs, err := util.GetGlobalSharedEnvs()
+	if err != nil {
+		return err
+	}
+	envs = append(envs, "KB_UPGRADE_PRE_CHECK"+"="+check)
+	envs = append(envs, "KB_UPGRADE_FROM_VERSION"+"="+fromVersion)
+	envs = append(envs, "KB_UPGRADE_TO_VERSION"+"="+toVersion)
+	output, err := util.ExecCommand(ctx, checkCmd, envs)
+
+	if output != "" {
+		mgr.Logger.Info("component upgrade pre-check", "check", check, "output", output)
+	}
+	return err
+}
+
+// ShardRebalance provides the following dedicated environment variables for the action:
+//
+// - KB_SERVICE_PORT: The port on which the DB service listens.
+// - KB_SERVICE_USER: The username used to access the DB service with sufficient privileges.
+// - KB_SERVICE_PASSWORD: The password of the user used to access the DB service .
+// - KB_REBALANCE_SHARD_NAME: The name of the shard to rebalance.
+// - KB_REBALANCE_SHARDS: The names of all the shards of the sharding, separated by commas.
+//
+// The last line of the output is parsed as the moved data in bytes, 0 is returned if it is not a number.
+func (mgr *Manager) ShardRebalance(ctx context.Context, shardName, shards string) (int64, error) {
+	rebalanceCmd, ok := mgr.actionCommands[constant.ShardRebalanceAction]
+	if !ok || len(rebalanceCmd) == 0 {
+		return 0, errors.New("component shard rebalance command is empty")
+	}
+	envs, err := util.GetGlobalSharedEnvs()
+	if err != nil {
+		return 0, err
+	}
+	envs = append(envs, "KB_REBALANCE_SHARD_NAME"+"="+shardName)
+	envs = append(envs, "KB_REBALANCE_SHARDS"+"="+shards)
+	output, err := util.ExecCommand(ctx, rebalanceCmd, envs)
+
+	if output != "" {
+		mgr.Logger.Info("component shard rebalance", "shard", shardName, "output", output)
+	}
+	if err != nil {
+		return 0, err
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	movedBytes, err := strconv.ParseInt(strings.TrimSpace(lines[len(lines)-1]), 10, 64)
+	if err != nil {
+		mgr.Logger.Info("the moved data is not reported by the shard rebalance action", "error", err.Error())
+		return 0, nil
+	}
+	return movedBytes, nil
+}
+
+// WarmUpCheck provides the following dedicated environment variables for the action:
+//
+// - KB_SERVICE_PORT: The port on which the DB service listens.
+// - KB_SERVICE_USER: The username used to access the DB service with sufficient privileges.
+// - KB_SERVICE_PASSWORD: The password of the user used to access the DB service .
+func (mgr *Manager) WarmUpCheck(ctx context.Context) error {
+	checkCmd, ok := mgr.actionCommands[constant.WarmUpCheckAction]
+	if !ok || len(checkCmd) == 0 {
+		return errors.New("component warm-up check command is empty")
+	}
+	envs, err := util.GetGlobalSharedEnvs()
+	if err != nil {
+		return err
+	}
+	output, err := util.ExecCommand(ctx, checkCmd, envs)
+
+	if output != "" {
+		mgr.Logger.Info("component warm-up check", "output", output)
+	}
+	return err
+}
+
+// ReplicationLag provides the following dedicated environment variables for the action:
+//
+// - KB_SERVICE_PORT: The port on which the DB service listens.
+// - KB_SERVICE_USER: The username used to access the DB service with sufficient privileges.
+// - KB_SERVICE_PASSWORD: The password of the user used to access the DB service .
+// - KB_LEADER_POD_NAME: The name of the current leader's Pod.
+// - KB_LEADER_POD_FQDN: The FQDN of the current leader's Pod.
+//
+// The last line of the output is parsed as the replication lag in seconds, an error is returned if it is not a number.
+func (mgr *Manager) ReplicationLag(ctx context.Context, leaderPodName, leaderPodFQDN string) (int64, error) {
+	lagCmd, ok := mgr.actionCommands[constant.ReplicationLagAction]
+	if !ok || len(lagCmd) == 0 {
+		return 0, errors.New("component replication lag command is empty")
+	}
+	envs, err := util.GetGlobalSharedEnvs()
+	if err != nil {
+		return 0, err
+	}
+	envs = append(envs, "KB_LEADER_POD_NAME"+"="+leaderPodName)
+	envs = append(envs, "KB_LEADER_POD_FQDN"+"="+leaderPodFQDN)
+	output, err := util.ExecCommand(ctx, lagCmd, envs)
+
+	if output != "" {
+		mgr.Logger.Info("component replication lag", "output", output)
+	}
+	if err != nil {
+		return 0, err
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	lagSeconds, err := strconv.ParseInt(strings.TrimSpace(lines[len(lines)-1]), 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "the replication lag is not reported by the replication lag action")
+	}
+	return lagSeconds, nil
+}
@@ -0,0 +1,186 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package highavailability
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	dcs3 "github.com/apecloud/kubeblocks/pkg/lorry/dcs"
+	"github.com/apecloud/kubeblocks/pkg/lorry/engines/register"
+	k8s "github.com/apecloud/kubeblocks/pkg/lorry/util/kubernetes"
+)
+
+// the fencing strategies, which are consistent with the FencingStrategy of the ComponentDefinition.
+const (
+	PodDeletionFencing = "PodDeletion"
+	NodeCordonFencing  = "NodeCordon"
+	ActionFencing      = "Action"
+)
+
+type FenceManager interface {
+	Fence(ctx context.Context, cluster *dcs3.Cluster, memberName string) error
+}
+
+// Fencer verifies that the former leader whose lease has expired is fenced,
+// to prevent split-brain when the former leader is partitioned but still alive.
+type Fencer struct {
+	strategies []string
+	namespace  string
+	clientset  kubernetes.Interface
+	fenceCmd   []string
+}
+
+// NewFencer returns nil if no fencing strategy is specified for the component.
+func NewFencer() (*Fencer, error) {
+	strategiesStr := viper.GetString(constant.KBEnvFencingStrategies)
+	if strategiesStr == "" {
+		return nil, nil
+	}
+	clientset, err := k8s.GetClientSet()
+	if err != nil {
+		return nil, err
+	}
+	fencer := &Fencer{
+		strategies: strings.Split(strategiesStr, ","),
+		namespace:  viper.GetString(constant.KBEnvNamespace),
+		clientset:  clientset,
+	}
+	actionJSON := viper.GetString(constant.KBEnvActionCommands)
+	if actionJSON != "" {
+		actionCommands := map[string][]string{}
+		if err = json.Unmarshal([]byte(actionJSON), &actionCommands); err != nil {
+			return nil, err
+		}
+		fencer.fenceCmd = actionCommands[constant.FenceAction]
+	}
+	return fencer, nil
+}
+
+// IsFormerLeaderFenced checks whether the former leader is fenced by any of the strategies,
+// the new leader can't be promoted otherwise.
+func (f *Fencer) IsFormerLeaderFenced(ctx context.Context, cluster *dcs3.Cluster, currentMemberName string) (bool, error) {
+	if f == nil || cluster.Leader == nil || cluster.Leader.FormerName == "" || cluster.Leader.FormerName == currentMemberName {
+		return true, nil
+	}
+	var errs []string
+	for _, strategy := range f.strategies {
+		fenced, err := f.fence(ctx, strategy, cluster)
+		if err != nil {
+			errs = append(errs, strategy+": "+err.Error())
+			continue
+		}
+		if fenced {
+			return true, nil
+		}
+	}
+	if len(errs) > 0 {
+		return false, errors.New(strings.Join(errs, "; "))
+	}
+	return false, nil
+}
+
+func (f *Fencer) fence(ctx context.Context, strategy string, cluster *dcs3.Cluster) (bool, error) {
+	formerLeader := cluster.Leader.FormerName
+	switch strategy {
+	case PodDeletionFencing:
+		return f.isPodDeleted(ctx, formerLeader, time.Unix(cluster.Leader.RenewTime, 0))
+	case NodeCordonFencing:
+		return f.isNodeFenced(ctx, formerLeader, cluster.Leader.FormerNode)
+	case ActionFencing:
+		if len(f.fenceCmd) == 0 {
+			return false, errors.New("fence action is not defined")
+		}
+		manager, err := register.GetDBManager(f.fenceCmd)
+		if err != nil {
+			return false, err
+		}
+		fenceManager, ok := manager.(FenceManager)
+		if !ok {
+			return false, errors.New("fence action is not supported")
+		}
+		if err = fenceManager.Fence(ctx, cluster, formerLeader); err != nil {
+			return false, err
+		}
+		return true, nil
+	default:
+		return false, errors.Errorf("unknown fencing strategy %s", strategy)
+	}
+}
+
+// isPodDeleted checks whether the pod of the former leader has been deleted,
+// or recreated after the last heartbeat of the former leader.
+// A terminating pod is not fenced since the DB service may still be running during the grace period.
+func (f *Fencer) isPodDeleted(ctx context.Context, podName string, lastHeartbeat time.Time) (bool, error) {
+	pod, err := f.clientset.CoreV1().Pods(f.namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	return pod.DeletionTimestamp == nil && pod.CreationTimestamp.Time.After(lastHeartbeat), nil
+}
+
+// isNodeFenced checks whether the node of the former leader has been removed, or has been cordoned and tainted
+// with NoExecute and the pod of the former leader no longer runs on it.
+// Cordoning only stops new pods from being scheduled to the node, a partitioned former leader keeps running there.
+func (f *Fencer) isNodeFenced(ctx context.Context, podName, nodeName string) (bool, error) {
+	if nodeName == "" {
+		return false, errors.New("the node of the former leader is unknown")
+	}
+	node, err := f.clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	if !node.Spec.Unschedulable || !hasNoExecuteTaint(node) {
+		return false, nil
+	}
+	pod, err := f.clientset.CoreV1().Pods(f.namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	return pod.Spec.NodeName != nodeName, nil
+}
+
+func hasNoExecuteTaint(node *corev1.Node) bool {
+	for _, taint := range node.Spec.Taints {
+		if taint.Effect == corev1.TaintEffectNoExecute {
+			return true
+		}
+	}
+	return false
+}
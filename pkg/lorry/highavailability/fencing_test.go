@@ -0,0 +1,227 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package highavailability
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	dcs3 "github.com/apecloud/kubeblocks/pkg/lorry/dcs"
+	"github.com/apecloud/kubeblocks/pkg/lorry/engines"
+	"github.com/apecloud/kubeblocks/pkg/lorry/engines/register"
+)
+
+const (
+	fencingTestNamespace = "default"
+	formerLeaderName     = "mysql-0"
+)
+
+type fakeFenceManager struct {
+	engines.DBManager
+	err    error
+	fenced string
+}
+
+func (m *fakeFenceManager) Fence(_ context.Context, _ *dcs3.Cluster, memberName string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.fenced = memberName
+	return nil
+}
+
+func newFencingTestCluster(renewTime time.Time) *dcs3.Cluster {
+	return &dcs3.Cluster{
+		Leader: &dcs3.Leader{
+			RenewTime:  renewTime.Unix(),
+			FormerName: formerLeaderName,
+		},
+	}
+}
+
+func newFormerLeaderPod(creationTime time.Time) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         fencingTestNamespace,
+			Name:              formerLeaderName,
+			CreationTimestamp: metav1.NewTime(creationTime),
+		},
+	}
+}
+
+func TestIsFormerLeaderFencedWithoutFormerLeader(t *testing.T) {
+	ctx := context.Background()
+	var fencer *Fencer
+	fenced, err := fencer.IsFormerLeaderFenced(ctx, newFencingTestCluster(time.Now()), "mysql-1")
+	assert.Nil(t, err)
+	assert.True(t, fenced, "no fencing is required if the fencing is not enabled")
+
+	fencer = &Fencer{strategies: []string{PodDeletionFencing}, namespace: fencingTestNamespace, clientset: fake.NewSimpleClientset()}
+	fenced, err = fencer.IsFormerLeaderFenced(ctx, &dcs3.Cluster{Leader: &dcs3.Leader{}}, "mysql-1")
+	assert.Nil(t, err)
+	assert.True(t, fenced, "no fencing is required if the lease is released gracefully")
+
+	fenced, err = fencer.IsFormerLeaderFenced(ctx, newFencingTestCluster(time.Now()), formerLeaderName)
+	assert.Nil(t, err)
+	assert.True(t, fenced, "no fencing is required if the former leader acquires the lease again")
+}
+
+func TestPodDeletionFencing(t *testing.T) {
+	ctx := context.Background()
+	lastHeartbeat := time.Now().Add(-time.Minute)
+	cluster := newFencingTestCluster(lastHeartbeat)
+	newFencer := func(objs ...*corev1.Pod) *Fencer {
+		clientset := fake.NewSimpleClientset()
+		for _, obj := range objs {
+			_, _ = clientset.CoreV1().Pods(fencingTestNamespace).Create(ctx, obj, metav1.CreateOptions{})
+		}
+		return &Fencer{strategies: []string{PodDeletionFencing}, namespace: fencingTestNamespace, clientset: clientset}
+	}
+
+	fenced, err := newFencer().IsFormerLeaderFenced(ctx, cluster, "mysql-1")
+	assert.Nil(t, err)
+	assert.True(t, fenced, "the deleted pod is fenced")
+
+	fenced, err = newFencer(newFormerLeaderPod(lastHeartbeat.Add(-time.Hour))).IsFormerLeaderFenced(ctx, cluster, "mysql-1")
+	assert.Nil(t, err)
+	assert.False(t, fenced, "the pod created before the last heartbeat is not fenced")
+
+	fenced, err = newFencer(newFormerLeaderPod(lastHeartbeat.Add(time.Second))).IsFormerLeaderFenced(ctx, cluster, "mysql-1")
+	assert.Nil(t, err)
+	assert.True(t, fenced, "the pod recreated after the last heartbeat is fenced")
+
+	terminating := newFormerLeaderPod(lastHeartbeat.Add(time.Second))
+	terminating.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	fenced, err = newFencer(terminating).IsFormerLeaderFenced(ctx, cluster, "mysql-1")
+	assert.Nil(t, err)
+	assert.False(t, fenced, "the terminating pod is not fenced")
+}
+
+func TestNodeCordonFencing(t *testing.T) {
+	ctx := context.Background()
+	const nodeName = "node-1"
+	cluster := newFencingTestCluster(time.Now().Add(-time.Minute))
+	cluster.Leader.FormerNode = nodeName
+	newNode := func(unschedulable bool, taints ...corev1.Taint) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+			Spec:       corev1.NodeSpec{Unschedulable: unschedulable, Taints: taints},
+		}
+	}
+	onNode := func(node string) *corev1.Pod {
+		pod := newFormerLeaderPod(time.Now().Add(-time.Hour))
+		pod.Spec.NodeName = node
+		return pod
+	}
+	newFencer := func(objs ...runtime.Object) *Fencer {
+		return &Fencer{strategies: []string{NodeCordonFencing}, namespace: fencingTestNamespace, clientset: fake.NewSimpleClientset(objs...)}
+	}
+	noExecute := corev1.Taint{Key: "node.kubernetes.io/out-of-service", Effect: corev1.TaintEffectNoExecute}
+
+	fenced, err := newFencer(onNode(nodeName)).IsFormerLeaderFenced(ctx, cluster, "mysql-1")
+	assert.Nil(t, err)
+	assert.True(t, fenced, "the removed node is fenced")
+
+	fenced, err = newFencer(newNode(true), onNode(nodeName)).IsFormerLeaderFenced(ctx, cluster, "mysql-1")
+	assert.Nil(t, err)
+	assert.False(t, fenced, "the cordoned node is not fenced as the former leader may keep running on it")
+
+	fenced, err = newFencer(newNode(true, noExecute), onNode(nodeName)).IsFormerLeaderFenced(ctx, cluster, "mysql-1")
+	assert.Nil(t, err)
+	assert.False(t, fenced, "the node is not fenced while the former leader still runs on it")
+
+	fenced, err = newFencer(newNode(false, noExecute)).IsFormerLeaderFenced(ctx, cluster, "mysql-1")
+	assert.Nil(t, err)
+	assert.False(t, fenced, "the node tainted but not cordoned is not fenced")
+
+	fenced, err = newFencer(newNode(true, noExecute)).IsFormerLeaderFenced(ctx, cluster, "mysql-1")
+	assert.Nil(t, err)
+	assert.True(t, fenced, "the cordoned and tainted node is fenced once the pod is gone")
+
+	fenced, err = newFencer(newNode(true, noExecute), onNode("node-2")).IsFormerLeaderFenced(ctx, cluster, "mysql-1")
+	assert.Nil(t, err)
+	assert.True(t, fenced, "the cordoned and tainted node is fenced once the pod is rescheduled to another node")
+
+	cluster.Leader.FormerNode = ""
+	fenced, err = newFencer().IsFormerLeaderFenced(ctx, cluster, "mysql-1")
+	assert.ErrorContains(t, err, "the node of the former leader is unknown")
+	assert.False(t, fenced)
+}
+
+func TestActionFencing(t *testing.T) {
+	ctx := context.Background()
+	cluster := newFencingTestCluster(time.Now())
+	fencer := &Fencer{strategies: []string{ActionFencing}, namespace: fencingTestNamespace, clientset: fake.NewSimpleClientset()}
+
+	fenced, err := fencer.IsFormerLeaderFenced(ctx, cluster, "mysql-1")
+	assert.NotNil(t, err, "the fence action is not defined")
+	assert.False(t, fenced)
+
+	manager := &fakeFenceManager{}
+	register.SetCustomManager(manager)
+	defer register.SetCustomManager(nil)
+	fencer.fenceCmd = []string{"fence.sh"}
+	fenced, err = fencer.IsFormerLeaderFenced(ctx, cluster, "mysql-1")
+	assert.Nil(t, err)
+	assert.True(t, fenced)
+	assert.Equal(t, formerLeaderName, manager.fenced)
+
+	manager.err = errors.New("failed to fence")
+	fenced, err = fencer.IsFormerLeaderFenced(ctx, cluster, "mysql-1")
+	assert.ErrorContains(t, err, "failed to fence")
+	assert.False(t, fenced)
+}
+
+func TestFencingStrategiesInOrder(t *testing.T) {
+	ctx := context.Background()
+	lastHeartbeat := time.Now().Add(-time.Minute)
+	cluster := newFencingTestCluster(lastHeartbeat)
+	clientset := fake.NewSimpleClientset(newFormerLeaderPod(lastHeartbeat.Add(-time.Hour)))
+	manager := &fakeFenceManager{}
+	register.SetCustomManager(manager)
+	defer register.SetCustomManager(nil)
+
+	fencer := &Fencer{
+		strategies: []string{PodDeletionFencing, ActionFencing},
+		namespace:  fencingTestNamespace,
+		clientset:  clientset,
+		fenceCmd:   []string{"fence.sh"},
+	}
+	fenced, err := fencer.IsFormerLeaderFenced(ctx, cluster, "mysql-1")
+	assert.Nil(t, err)
+	assert.True(t, fenced, "the former leader is fenced by the action if the pod is still alive")
+
+	manager.err = errors.New("failed to fence")
+	fenced, err = fencer.IsFormerLeaderFenced(ctx, cluster, "mysql-1")
+	assert.ErrorContains(t, err, "Action: failed to fence")
+	assert.False(t, fenced)
+
+	fencer.strategies = []string{"Unknown"}
+	_, err = fencer.IsFormerLeaderFenced(ctx, cluster, "mysql-1")
+	assert.ErrorContains(t, err, "unknown fencing strategy")
+}
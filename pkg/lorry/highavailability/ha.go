@@ -39,6 +39,7 @@ type Ha struct {
 	dcs               dcs3.DCS
 	logger            logr.Logger
 	disableDNSChecker bool
+	fencer            *Fencer
 }
 
 var ha *Ha
@@ -53,12 +54,19 @@ func NewHa(disableDNSChecker bool) *Ha {
 		return nil
 	}
 
+	fencer, err := NewFencer()
+	if err != nil {
+		logger.Error(err, "Init fencer failed")
+		return nil
+	}
+
 	ha = &Ha{
 		ctx:               context.Background(),
 		dcs:               dcs,
 		logger:            logger,
 		dbManager:         manager,
 		disableDNSChecker: disableDNSChecker,
+		fencer:            fencer,
 	}
 	return ha
 }
@@ -126,6 +134,11 @@ func (ha *Ha) RunCycle() {
 		if !ha.IsHealthiestMember(ha.ctx, cluster) {
 			break
 		}
+		fenced, fenceErr := ha.fencer.IsFormerLeaderFenced(ha.ctx, cluster, ha.dbManager.GetCurrentMemberName())
+		if !fenced {
+			ha.logger.Info("The former leader is not fenced, wait...", "former leader", cluster.Leader.FormerName, "error", fenceErr)
+			break
+		}
 
 		cluster.Leader.DBState = DBState
 		if ha.dcs.AttemptAcquireLease() != nil {
@@ -0,0 +1,105 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/lorry/operations"
+	"github.com/apecloud/kubeblocks/pkg/lorry/util"
+)
+
+// checksumDirs are the directories of the files whose checksums can be queried, i.e. the mounted TLS certificates.
+var checksumDirs = []string{constant.MountPath}
+
+type Checksum struct {
+	operations.Base
+}
+
+var checksum operations.Operation = &Checksum{}
+
+func init() {
+	err := operations.Register(strings.ToLower(string(util.ChecksumOperation)), checksum)
+	if err != nil {
+		panic(err.Error())
+	}
+}
+
+func (s *Checksum) Init(_ context.Context) error {
+	return nil
+}
+
+func (s *Checksum) PreCheck(ctx context.Context, req *operations.OpsRequest) error {
+	files := getChecksumFiles(req)
+	if len(files) == 0 {
+		return errors.New("no file is specified")
+	}
+	for _, file := range files {
+		if !isChecksumAllowed(file) {
+			return errors.Errorf("the checksum of the file %s is not allowed to be queried", file)
+		}
+	}
+	return nil
+}
+
+func (s *Checksum) Do(ctx context.Context, req *operations.OpsRequest) (*operations.OpsResponse, error) {
+	resp := operations.NewOpsResponse(util.ChecksumOperation)
+	checksums := map[string]string{}
+	for _, file := range getChecksumFiles(req) {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "read file %s failed", file)
+		}
+		hash := sha256.Sum256(content)
+		checksums[file] = hex.EncodeToString(hash[:])
+	}
+	resp.Data["checksums"] = checksums
+	return resp.WithSuccess("")
+}
+
+func getChecksumFiles(req *operations.OpsRequest) []string {
+	var files []string
+	for _, file := range strings.Split(req.GetString("files"), ",") {
+		if file = strings.TrimSpace(file); file != "" {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+func isChecksumAllowed(file string) bool {
+	if !filepath.IsAbs(file) || filepath.Clean(file) != file {
+		return false
+	}
+	for _, dir := range checksumDirs {
+		if strings.HasPrefix(file, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/lorry/engines/models"
+	"github.com/apecloud/kubeblocks/pkg/lorry/engines/register"
+	"github.com/apecloud/kubeblocks/pkg/lorry/operations"
+	"github.com/apecloud/kubeblocks/pkg/lorry/util"
+)
+
+type Purge struct {
+	operations.Base
+	logger  logr.Logger
+	Timeout time.Duration
+	Command []string
+}
+
+type PurgeManager interface {
+	Purge(ctx context.Context, retainHours, retainBytes string) (int64, error)
+}
+
+var purge operations.Operation = &Purge{}
+
+func init() {
+	err := operations.Register(strings.ToLower(string(util.PurgeOperation)), purge)
+	if err != nil {
+		panic(err.Error())
+	}
+}
+
+func (s *Purge) Init(_ context.Context) error {
+	actionJSON := viper.GetString(constant.KBEnvActionCommands)
+	if actionJSON != "" {
+		actionCommands := map[string][]string{}
+		err := json.Unmarshal([]byte(actionJSON), &actionCommands)
+		if err != nil {
+			s.logger.Info("get action commands failed", "error", err.Error())
+			return err
+		}
+		purgeCmd, ok := actionCommands[constant.PurgeAction]
+		if ok && len(purgeCmd) > 0 {
+			s.Command = purgeCmd
+		}
+	}
+	return nil
+}
+
+// PreCheck checks the safety parameters again in case the request is not sent by the OpsRequest controller,
+// the data is never purged without a retention.
+func (s *Purge) PreCheck(ctx context.Context, req *operations.OpsRequest) error {
+	retainHours := req.GetString("retainHours")
+	retainBytes := req.GetString("retainBytes")
+	if retainHours == "" && retainBytes == "" {
+		return errors.New("retainHours or retainBytes must be specified")
+	}
+	for _, v := range []string{retainHours, retainBytes} {
+		if v == "" {
+			continue
+		}
+		if n, err := strconv.ParseInt(v, 10, 64); err != nil || n < 0 {
+			return errors.Errorf("invalid retention: %s", v)
+		}
+	}
+	return nil
+}
+
+func (s *Purge) Do(ctx context.Context, req *operations.OpsRequest) (*operations.OpsResponse, error) {
+	resp := operations.NewOpsResponse(util.PurgeOperation)
+	manager, err := register.GetDBManager(s.Command)
+	if err != nil {
+		return nil, errors.Wrap(err, "get manager failed")
+	}
+
+	purgeManager, ok := manager.(PurgeManager)
+	if !ok {
+		return nil, models.ErrNotImplemented
+	}
+	reclaimedBytes, err := purgeManager.Purge(ctx, req.GetString("retainHours"), req.GetString("retainBytes"))
+	if err != nil {
+		return resp, err
+	}
+	resp.Data["reclaimedBytes"] = reclaimedBytes
+	return resp.WithSuccess("")
+}
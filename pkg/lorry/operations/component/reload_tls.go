@@ -0,0 +1,112 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/lorry/engines/models"
+	"github.com/apecloud/kubeblocks/pkg/lorry/engines/register"
+	"github.com/apecloud/kubeblocks/pkg/lorry/operations"
+	"github.com/apecloud/kubeblocks/pkg/lorry/util"
+)
+
+type ReloadTLS struct {
+	operations.Base
+	logger   logr.Logger
+	Timeout  time.Duration
+	Command  []string
+	CertFile string
+}
+
+type ReloadTLSManager interface {
+	ReloadTLS(ctx context.Context) error
+}
+
+var reloadTLS operations.Operation = &ReloadTLS{}
+
+func init() {
+	err := operations.Register(strings.ToLower(string(util.ReloadTLSOperation)), reloadTLS)
+	if err != nil {
+		panic(err.Error())
+	}
+}
+
+func (s *ReloadTLS) Init(_ context.Context) error {
+	s.CertFile = filepath.Join(constant.MountPath, constant.CertName)
+	actionJSON := viper.GetString(constant.KBEnvActionCommands)
+	if actionJSON != "" {
+		actionCommands := map[string][]string{}
+		err := json.Unmarshal([]byte(actionJSON), &actionCommands)
+		if err != nil {
+			s.logger.Info("get action commands failed", "error", err.Error())
+			return err
+		}
+		reloadTLSCmd, ok := actionCommands[constant.ReloadTLSAction]
+		if ok && len(reloadTLSCmd) > 0 {
+			s.Command = reloadTLSCmd
+		}
+	}
+	return nil
+}
+
+// PreCheck makes sure the renewed certificate has been propagated to the pod before reloading it,
+// the secret volume is refreshed by kubelet periodically, so the caller is expected to retry later.
+func (s *ReloadTLS) PreCheck(ctx context.Context, req *operations.OpsRequest) error {
+	certHash := req.GetString("certHash")
+	if certHash == "" {
+		return nil
+	}
+	content, err := os.ReadFile(s.CertFile)
+	if err != nil {
+		return errors.Wrap(err, "read certificate failed")
+	}
+	hash := sha256.Sum256(content)
+	if hex.EncodeToString(hash[:]) != certHash {
+		return errors.New("the renewed certificate has not been propagated to the pod yet")
+	}
+	return nil
+}
+
+func (s *ReloadTLS) Do(ctx context.Context, req *operations.OpsRequest) (*operations.OpsResponse, error) {
+	manager, err := register.GetDBManager(s.Command)
+	if err != nil {
+		return nil, errors.Wrap(err, "get manager failed")
+	}
+
+	rtManager, ok := manager.(ReloadTLSManager)
+	if !ok {
+		return nil, models.ErrNotImplemented
+	}
+	err = rtManager.ReloadTLS(ctx)
+	return nil, err
+}
@@ -0,0 +1,101 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/lorry/engines/models"
+	"github.com/apecloud/kubeblocks/pkg/lorry/engines/register"
+	"github.com/apecloud/kubeblocks/pkg/lorry/operations"
+	"github.com/apecloud/kubeblocks/pkg/lorry/util"
+)
+
+type RotateCredential struct {
+	operations.Base
+	logger  logr.Logger
+	Timeout time.Duration
+	Command []string
+}
+
+type CredentialRotationManager interface {
+	RotateCredential(ctx context.Context, accountName, password string) error
+}
+
+var rotateCredential operations.Operation = &RotateCredential{}
+
+func init() {
+	err := operations.Register(strings.ToLower(string(util.RotateCredentialOperation)), rotateCredential)
+	if err != nil {
+		panic(err.Error())
+	}
+}
+
+func (s *RotateCredential) Init(_ context.Context) error {
+	actionJSON := viper.GetString(constant.KBEnvActionCommands)
+	if actionJSON != "" {
+		actionCommands := map[string][]string{}
+		err := json.Unmarshal([]byte(actionJSON), &actionCommands)
+		if err != nil {
+			s.logger.Info("get action commands failed", "error", err.Error())
+			return err
+		}
+		rotateCmd, ok := actionCommands[constant.AccountRotationAction]
+		if ok && len(rotateCmd) > 0 {
+			s.Command = rotateCmd
+		}
+	}
+	return nil
+}
+
+func (s *RotateCredential) PreCheck(ctx context.Context, req *operations.OpsRequest) error {
+	if req.GetString("accountName") == "" {
+		return errors.New("accountName must be specified")
+	}
+	if req.GetString("password") == "" {
+		return errors.New("password must be specified")
+	}
+	return nil
+}
+
+func (s *RotateCredential) Do(ctx context.Context, req *operations.OpsRequest) (*operations.OpsResponse, error) {
+	resp := operations.NewOpsResponse(util.RotateCredentialOperation)
+	manager, err := register.GetDBManager(s.Command)
+	if err != nil {
+		return nil, errors.Wrap(err, "get manager failed")
+	}
+
+	rotationManager, ok := manager.(CredentialRotationManager)
+	if !ok {
+		return nil, models.ErrNotImplemented
+	}
+	if err = rotationManager.RotateCredential(ctx, req.GetString("accountName"), req.GetString("password")); err != nil {
+		return resp, err
+	}
+	return resp.WithSuccess("")
+}
@@ -0,0 +1,101 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package component
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/lorry/engines/models"
+	"github.com/apecloud/kubeblocks/pkg/lorry/engines/register"
+	"github.com/apecloud/kubeblocks/pkg/lorry/operations"
+	"github.com/apecloud/kubeblocks/pkg/lorry/util"
+)
+
+type UpgradePreCheck struct {
+	operations.Base
+	logger  logr.Logger
+	Timeout time.Duration
+	Command []string
+}
+
+type UpgradePreCheckManager interface {
+	UpgradePreCheck(ctx context.Context, check, fromVersion, toVersion string) error
+}
+
+var upgradePreCheck operations.Operation = &UpgradePreCheck{}
+
+func init() {
+	err := operations.Register(strings.ToLower(string(util.UpgradePreCheckOperation)), upgradePreCheck)
+	if err != nil {
+		panic(err.Error())
+	}
+}
+
+func (s *UpgradePreCheck) Init(_ context.Context) error {
+	actionJSON := viper.GetString(constant.KBEnvActionCommands)
+	if actionJSON != "" {
+		actionCommands := map[string][]string{}
+		err := json.Unmarshal([]byte(actionJSON), &actionCommands)
+		if err != nil {
+			s.logger.Info("get action commands failed", "error", err.Error())
+			return err
+		}
+		checkCmd, ok := actionCommands[constant.UpgradePreCheckAction]
+		if ok && len(checkCmd) > 0 {
+			s.Command = checkCmd
+		}
+	}
+	return nil
+}
+
+func (s *UpgradePreCheck) PreCheck(ctx context.Context, req *operations.OpsRequest) error {
+	if req.GetString("check") == "" {
+		return errors.New("check must be specified")
+	}
+	if req.GetString("fromVersion") == "" || req.GetString("toVersion") == "" {
+		return errors.New("fromVersion and toVersion must be specified")
+	}
+	return nil
+}
+
+func (s *UpgradePreCheck) Do(ctx context.Context, req *operations.OpsRequest) (*operations.OpsResponse, error) {
+	resp := operations.NewOpsResponse(util.UpgradePreCheckOperation)
+	manager, err := register.GetDBManager(s.Command)
+	if err != nil {
+		return nil, errors.Wrap(err, "get manager failed")
+	}
+
+	checkManager, ok := manager.(UpgradePreCheckManager)
+	if !ok {
+		return nil, models.ErrNotImplemented
+	}
+	if err = checkManager.UpgradePreCheck(ctx, req.GetString("check"), req.GetString("fromVersion"), req.GetString("toVersion")); err != nil {
+		return resp, err
+	}
+	return resp.WithSuccess("")
+}
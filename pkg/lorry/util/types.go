@@ -53,8 +53,18 @@ const (
 	VolumeProtection OperationKind = "volumeProtection"
 
 	// for component
-	PostProvisionOperation OperationKind = "postProvision"
-	PreTerminateOperation  OperationKind = "preTerminate"
+	PostProvisionOperation    OperationKind = "postProvision"
+	PreTerminateOperation     OperationKind = "preTerminate"
+	ReloadTLSOperation        OperationKind = "reloadTLS"
+	PurgeOperation            OperationKind = "purge"
+	RotateCredentialOperation OperationKind = "rotateCredential"
+	DrainBackendOperation     OperationKind = "drainBackend"
+	ResumeBackendOperation    OperationKind = "resumeBackend"
+	UpgradePreCheckOperation  OperationKind = "upgradePreCheck"
+	ShardRebalanceOperation   OperationKind = "shardRebalance"
+	WarmUpCheckOperation      OperationKind = "warmUpCheck"
+	ChecksumOperation         OperationKind = "checksum"
+	ReplicationLagOperation   OperationKind = "replicationLag"
 
 	// actions for cluster accounts management
 	ListUsersOp          OperationKind = "listUsers"
@@ -0,0 +1,160 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metering
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// maxSampleInterval is the maximum interval between two samples of a Cluster.
+const maxSampleInterval = 5 * time.Minute
+
+// Sample is the resources of a Cluster at a moment.
+type Sample struct {
+	VCPUs     float64
+	StorageGB float64
+	BackupGB  float64
+}
+
+// Meter integrates the samples of the Clusters over time, and cuts the usage into records of the windows
+// aligned to the interval, e.g. the records of the hourly windows start at the top of the hours.
+// The usage is kept in memory, the usage between the last sample before the manager restarts
+// and the first sample after it is not metered.
+type Meter struct {
+	interval time.Duration
+	mu       sync.Mutex
+	usages   map[types.NamespacedName]*clusterUsage
+}
+
+type clusterUsage struct {
+	labels         map[string]string
+	windowStart    time.Time
+	sampledAt      time.Time
+	last           Sample
+	vcpuHours      float64
+	storageGBHours float64
+	// pending are the records of the ended windows which have not been emitted.
+	pending []UsageRecord
+}
+
+// NewMeter creates a Meter with the interval of the windows.
+func NewMeter(interval time.Duration) *Meter {
+	return &Meter{
+		interval: interval,
+		usages:   map[types.NamespacedName]*clusterUsage{},
+	}
+}
+
+// Observe integrates the last sample of the Cluster till now and takes the new sample,
+// it returns the records of the windows which have ended and not been emitted.
+func (m *Meter) Observe(key types.NamespacedName, labels map[string]string, sample Sample, now time.Time) []UsageRecord {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	u, ok := m.usages[key]
+	if !ok {
+		m.usages[key] = &clusterUsage{labels: labels, windowStart: now.Truncate(m.interval), sampledAt: now, last: sample}
+		return nil
+	}
+	for end := u.windowStart.Add(m.interval); !now.Before(end); end = u.windowStart.Add(m.interval) {
+		u.pending = append(u.pending, u.cut(key, end))
+	}
+	u.integrate(now)
+	u.labels = labels
+	u.last = sample
+	return append([]UsageRecord(nil), u.pending...)
+}
+
+// Emitted removes the first n pending records of the Cluster after they are emitted.
+func (m *Meter) Emitted(key types.NamespacedName, n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if u, ok := m.usages[key]; ok && n <= len(u.pending) {
+		u.pending = u.pending[n:]
+	}
+}
+
+// Flush cuts the usage of the deleted Cluster till now and stops metering it,
+// it returns the pending records and the record of the partial window.
+func (m *Meter) Flush(key types.NamespacedName, now time.Time) []UsageRecord {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	u, ok := m.usages[key]
+	if !ok {
+		return nil
+	}
+	delete(m.usages, key)
+	for end := u.windowStart.Add(m.interval); !now.Before(end); end = u.windowStart.Add(m.interval) {
+		u.pending = append(u.pending, u.cut(key, end))
+	}
+	if now.After(u.windowStart) {
+		u.pending = append(u.pending, u.cut(key, now))
+	}
+	return u.pending
+}
+
+// NextSample returns the duration until the Cluster should be sampled again,
+// which is no later than the end of the current window.
+func (m *Meter) NextSample(key types.NamespacedName, now time.Time) time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	next := maxSampleInterval
+	if u, ok := m.usages[key]; ok {
+		if untilEnd := u.windowStart.Add(m.interval).Sub(now); untilEnd < next {
+			next = untilEnd
+		}
+	}
+	if next <= 0 {
+		return time.Second
+	}
+	return next
+}
+
+// integrate accumulates the last sample from the time it was taken till the end.
+func (u *clusterUsage) integrate(end time.Time) {
+	if !end.After(u.sampledAt) {
+		return
+	}
+	hours := end.Sub(u.sampledAt).Hours()
+	u.vcpuHours += u.last.VCPUs * hours
+	u.storageGBHours += u.last.StorageGB * hours
+	u.sampledAt = end
+}
+
+// cut ends the current window at the end and starts a new one.
+func (u *clusterUsage) cut(key types.NamespacedName, end time.Time) UsageRecord {
+	u.integrate(end)
+	record := UsageRecord{
+		Namespace:      key.Namespace,
+		Cluster:        key.Name,
+		Labels:         u.labels,
+		StartTime:      u.windowStart,
+		EndTime:        end,
+		VCPUHours:      u.vcpuHours,
+		StorageGBHours: u.storageGBHours,
+		BackupGBStored: u.last.BackupGB,
+	}
+	u.windowStart = end
+	u.vcpuHours = 0
+	u.storageGBHours = 0
+	return record
+}
@@ -0,0 +1,141 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metering
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// GigaByte is the unit of the storage usage, the sizes of the volumes and backups are measured in GiB.
+const GigaByte = float64(1 << 30)
+
+// UsageRecord is the usage of a Cluster in a metering window.
+type UsageRecord struct {
+	Namespace string `json:"namespace"`
+	Cluster   string `json:"cluster"`
+	// Labels are the labels of the Cluster selected for the tenant attribution.
+	Labels    map[string]string `json:"labels,omitempty"`
+	StartTime time.Time         `json:"startTime"`
+	EndTime   time.Time         `json:"endTime"`
+	// VCPUHours is the vCPUs requested by the pods of the Cluster integrated over the window.
+	VCPUHours float64 `json:"vcpuHours"`
+	// StorageGBHours is the capacity of the volumes of the Cluster integrated over the window.
+	StorageGBHours float64 `json:"storageGBHours"`
+	// BackupGBStored is the total size of the completed backups of the Cluster at the end of the window.
+	BackupGBStored float64 `json:"backupGBStored"`
+}
+
+// Config is the config of the metering sinks.
+type Config struct {
+	// TenantLabels are the keys of the Cluster labels copied to the usage records for the tenant attribution.
+	TenantLabels []string
+	// FilePath is the path of the file which the "file" sink appends the usage records to.
+	FilePath string
+	// KafkaRESTProxy is the URL of the Kafka REST proxy which the "kafka" sink produces the usage records through.
+	KafkaRESTProxy string
+	// KafkaTopic is the topic of the usage records produced by the "kafka" sink.
+	KafkaTopic string
+}
+
+// Sink receives the usage records.
+type Sink interface {
+	Emit(ctx context.Context, records []UsageRecord) error
+}
+
+// SinkFactory creates a Sink by the config.
+type SinkFactory func(config Config) (Sink, error)
+
+var sinkFactories = map[string]SinkFactory{}
+
+// RegisterSink registers a SinkFactory with the name, which can be referred to by NewSink.
+func RegisterSink(name string, factory SinkFactory) {
+	sinkFactories[name] = factory
+}
+
+func init() {
+	RegisterSink("prometheus", newPrometheusSink)
+	RegisterSink("kafka", newKafkaSink)
+	RegisterSink("file", newFileSink)
+}
+
+// NewSink creates the sinks by the comma-separated names, e.g. "prometheus,file",
+// the usage records are emitted to all of them.
+func NewSink(names string, config Config) (Sink, error) {
+	var sinks multiSink
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		factory, ok := sinkFactories[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown metering sink: %s", name)
+		}
+		sink, err := factory(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the metering sink %s: %s", name, err.Error())
+		}
+		sinks = append(sinks, sink)
+	}
+	if len(sinks) == 0 {
+		return nil, fmt.Errorf("no metering sink is specified")
+	}
+	return sinks, nil
+}
+
+type multiSink []Sink
+
+func (s multiSink) Emit(ctx context.Context, records []UsageRecord) error {
+	var errs []error
+	for _, sink := range s {
+		if err := sink.Emit(ctx, records); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// TenantLabels selects the labels for the tenant attribution from the labels of a Cluster.
+func TenantLabels(labels map[string]string, keys []string) map[string]string {
+	selected := map[string]string{}
+	for _, key := range keys {
+		if value, ok := labels[key]; ok {
+			selected[key] = value
+		}
+	}
+	return selected
+}
+
+// ParseTenantLabels parses the comma-separated keys of the labels for the tenant attribution.
+func ParseTenantLabels(keys string) []string {
+	var result []string
+	for _, key := range strings.Split(keys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			result = append(result, key)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,170 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metering
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestMeterObserve(t *testing.T) {
+	key := types.NamespacedName{Namespace: "default", Name: "mycluster"}
+	labels := map[string]string{"tenant": "t1"}
+	meter := NewMeter(time.Hour)
+	base := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+
+	if records := meter.Observe(key, labels, Sample{VCPUs: 2, StorageGB: 10, BackupGB: 1}, base); len(records) != 0 {
+		t.Fatalf("expected no records for the first sample, got %d", len(records))
+	}
+	if next := meter.NextSample(key, base); next != maxSampleInterval {
+		t.Errorf("expected to sample again after %s, got %s", maxSampleInterval, next)
+	}
+	// scale out at 10:45
+	if records := meter.Observe(key, labels, Sample{VCPUs: 4, StorageGB: 20, BackupGB: 1}, base.Add(15*time.Minute)); len(records) != 0 {
+		t.Fatalf("expected no records in the window, got %d", len(records))
+	}
+	// the window [10:00, 11:00) ends
+	records := meter.Observe(key, labels, Sample{VCPUs: 4, StorageGB: 20, BackupGB: 3}, base.Add(45*time.Minute))
+	if len(records) != 1 {
+		t.Fatalf("expected one record, got %d", len(records))
+	}
+	record := records[0]
+	if !record.StartTime.Equal(base.Truncate(time.Hour)) || !record.EndTime.Equal(base.Truncate(time.Hour).Add(time.Hour)) {
+		t.Errorf("unexpected window: %s - %s", record.StartTime, record.EndTime)
+	}
+	if !almostEqual(record.VCPUHours, 2*0.25+4*0.25) || !almostEqual(record.StorageGBHours, 10*0.25+20*0.25) {
+		t.Errorf("unexpected usage: %f vCPU-hours, %f GB-hours", record.VCPUHours, record.StorageGBHours)
+	}
+	if !almostEqual(record.BackupGBStored, 1) || record.Labels["tenant"] != "t1" {
+		t.Errorf("unexpected record: %+v", record)
+	}
+
+	// the records are kept until they are emitted
+	if records = meter.Observe(key, labels, Sample{VCPUs: 4, StorageGB: 20, BackupGB: 3}, base.Add(50*time.Minute)); len(records) != 1 {
+		t.Fatalf("expected the pending record, got %d", len(records))
+	}
+	meter.Emitted(key, len(records))
+
+	// the cluster is deleted at 11:30
+	records = meter.Flush(key, base.Add(time.Hour))
+	if len(records) != 1 {
+		t.Fatalf("expected one record of the partial window, got %d", len(records))
+	}
+	if !almostEqual(records[0].VCPUHours, 4*0.5) || !records[0].EndTime.Equal(base.Add(time.Hour)) {
+		t.Errorf("unexpected record of the partial window: %+v", records[0])
+	}
+	if records = meter.Flush(key, base.Add(time.Hour)); len(records) != 0 {
+		t.Errorf("expected no records after the cluster is flushed, got %d", len(records))
+	}
+}
+
+func TestNewSink(t *testing.T) {
+	if _, err := NewSink("", Config{}); err == nil {
+		t.Errorf("expected error for no sinks")
+	}
+	if _, err := NewSink("unknown", Config{}); err == nil {
+		t.Errorf("expected error for the unknown sink")
+	}
+	if _, err := NewSink("kafka", Config{}); err == nil {
+		t.Errorf("expected error for the kafka sink without the REST proxy")
+	}
+	if _, err := NewSink("prometheus, file", Config{FilePath: filepath.Join(t.TempDir(), "metering.jsonl")}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "usage", "metering.jsonl")
+	sink, err := NewSink("file", Config{FilePath: path})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	records := []UsageRecord{{Namespace: "default", Cluster: "c1", VCPUHours: 1}, {Namespace: "default", Cluster: "c2", VCPUHours: 2}}
+	for i := 0; i < 2; i++ {
+		if err = sink.Emit(context.Background(), records); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer f.Close()
+	var lines int
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		record := UsageRecord{}
+		if err = json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("invalid record: %v", err)
+		}
+		lines++
+	}
+	if lines != 4 {
+		t.Errorf("expected 4 records appended, got %d", lines)
+	}
+}
+
+func TestKafkaSink(t *testing.T) {
+	var received struct {
+		Records []kafkaRecord `json:"records"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/topics/metering" || r.Header.Get("Content-Type") != "application/vnd.kafka.json.v2+json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sink, err := NewSink("kafka", Config{KafkaRESTProxy: server.URL, KafkaTopic: "metering"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = sink.Emit(context.Background(), []UsageRecord{{Namespace: "default", Cluster: "c1", VCPUHours: 1}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(received.Records) != 1 || received.Records[0].Key != "default/c1" || received.Records[0].Value.VCPUHours != 1 {
+		t.Errorf("unexpected records produced: %+v", received.Records)
+	}
+
+	sink, _ = NewSink("kafka", Config{KafkaRESTProxy: server.URL, KafkaTopic: "unknown"})
+	if err = sink.Emit(context.Background(), []UsageRecord{{Namespace: "default", Cluster: "c1"}}); err == nil {
+		t.Errorf("expected error for the unknown topic")
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metering
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// fileSink appends the usage records to a file in the JSON Lines format.
+type fileSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+func newFileSink(config Config) (Sink, error) {
+	if config.FilePath == "" {
+		return nil, fmt.Errorf("the file path is empty")
+	}
+	if err := os.MkdirAll(filepath.Dir(config.FilePath), 0755); err != nil {
+		return nil, err
+	}
+	return &fileSink{path: config.FilePath}, nil
+}
+
+func (s *fileSink) Emit(_ context.Context, records []UsageRecord) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(buf.Bytes()); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// kafkaSink produces the usage records to a Kafka topic through the Kafka REST proxy (API v2),
+// keyed by the namespace and name of the Cluster to keep the records of a Cluster in order.
+type kafkaSink struct {
+	endpoint string
+	client   *http.Client
+}
+
+type kafkaRecord struct {
+	Key   string      `json:"key"`
+	Value UsageRecord `json:"value"`
+}
+
+func newKafkaSink(config Config) (Sink, error) {
+	if config.KafkaRESTProxy == "" || config.KafkaTopic == "" {
+		return nil, fmt.Errorf("the Kafka REST proxy and topic are required")
+	}
+	endpoint, err := url.JoinPath(config.KafkaRESTProxy, "topics", config.KafkaTopic)
+	if err != nil {
+		return nil, err
+	}
+	return &kafkaSink{endpoint: endpoint, client: &http.Client{Timeout: 10 * time.Second}}, nil
+}
+
+func (s *kafkaSink) Emit(ctx context.Context, records []UsageRecord) error {
+	body := struct {
+		Records []kafkaRecord `json:"records"`
+	}{}
+	for _, record := range records {
+		body.Records = append(body.Records, kafkaRecord{Key: record.Namespace + "/" + record.Cluster, Value: record})
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to produce the usage records to Kafka, status: %s, message: %s", resp.Status, string(message))
+	}
+	return nil
+}
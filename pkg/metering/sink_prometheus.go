@@ -0,0 +1,102 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metering
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var invalidLabelNameChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// prometheusSink exposes the usage records as metrics by the metrics server of the manager,
+// the tenant labels are converted into the metric labels prefixed with "label_".
+type prometheusSink struct {
+	tenantLabels   []string
+	vcpuHours      *prometheus.CounterVec
+	storageGBHours *prometheus.CounterVec
+	backupGBStored *prometheus.GaugeVec
+}
+
+func newPrometheusSink(config Config) (Sink, error) {
+	labelNames := []string{"namespace", "cluster"}
+	for _, key := range config.TenantLabels {
+		labelNames = append(labelNames, "label_"+invalidLabelNameChars.ReplaceAllString(key, "_"))
+	}
+	var err error
+	s := &prometheusSink{tenantLabels: config.TenantLabels}
+	if s.vcpuHours, err = register(prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kubeblocks",
+		Subsystem: "metering",
+		Name:      "vcpu_hours_total",
+		Help:      "The vCPU-hours requested by the pods of the cluster.",
+	}, labelNames)); err != nil {
+		return nil, err
+	}
+	if s.storageGBHours, err = register(prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kubeblocks",
+		Subsystem: "metering",
+		Name:      "storage_gb_hours_total",
+		Help:      "The GB-hours of the volumes of the cluster.",
+	}, labelNames)); err != nil {
+		return nil, err
+	}
+	if s.backupGBStored, err = register(prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kubeblocks",
+		Subsystem: "metering",
+		Name:      "backup_gb_stored",
+		Help:      "The GB of the completed backups of the cluster.",
+	}, labelNames)); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// register registers the collector to the metrics registry of the manager,
+// the existing one is reused if it has been registered by a former sink.
+func register[T prometheus.Collector](collector T) (T, error) {
+	if err := ctrlmetrics.Registry.Register(collector); err != nil {
+		var registered prometheus.AlreadyRegisteredError
+		if errors.As(err, &registered) {
+			if existing, ok := registered.ExistingCollector.(T); ok {
+				return existing, nil
+			}
+		}
+		return collector, err
+	}
+	return collector, nil
+}
+
+func (s *prometheusSink) Emit(_ context.Context, records []UsageRecord) error {
+	for _, record := range records {
+		labelValues := []string{record.Namespace, record.Cluster}
+		for _, key := range s.tenantLabels {
+			labelValues = append(labelValues, record.Labels[key])
+		}
+		s.vcpuHours.WithLabelValues(labelValues...).Add(record.VCPUHours)
+		s.storageGBHours.WithLabelValues(labelValues...).Add(record.StorageGBHours)
+		s.backupGBStored.WithLabelValues(labelValues...).Set(record.BackupGBStored)
+	}
+	return nil
+}
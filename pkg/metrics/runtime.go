@@ -36,7 +36,12 @@ func RuntimeMetric() map[string]http.Handler {
 	if !controllerutil.EnabledRuntimeMetrics() {
 		return nil
 	}
+	return RuntimeMetricHandlers()
+}
 
+// RuntimeMetricHandlers returns the handlers serving the Go runtime metrics regardless of the feature gate,
+// e.g. in the profiling mode of the manager.
+func RuntimeMetricHandlers() map[string]http.Handler {
 	reg := prometheus.NewRegistry()
 	reg.MustRegister(collectors.NewBuildInfoCollector())
 	reg.MustRegister(collectors.NewGoCollector(
@@ -0,0 +1,41 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// RoleServicePropagationSeconds observes the latency from the role label of a Pod being updated
+// to the Pod being added to the endpoints of the read-write or read-only Service of the Component.
+var RoleServicePropagationSeconds = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: "kubeblocks",
+		Name:      "role_service_propagation_seconds",
+		Help:      "The latency from the role label of a pod being updated to the pod being added to the endpoints of the read-write or read-only service.",
+		Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	},
+	[]string{"access_mode"},
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(RoleServicePropagationSeconds)
+}
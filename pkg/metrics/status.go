@@ -0,0 +1,47 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// the results of the status writes
+const (
+	StatusWriteResultPatched = "patched"
+	StatusWriteResultSkipped = "skipped"
+	StatusWriteResultFailed  = "failed"
+)
+
+// StatusWritesTotal counts the writes of the status of the objects per controller, and the ones skipped since
+// the status is not changed, to make the regressions of the status write rate visible.
+var StatusWritesTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "kubeblocks",
+		Name:      "status_writes_total",
+		Help:      "The number of the status writes of the objects, by the controller and the result of the write.",
+	},
+	[]string{"controller", "result"},
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(StatusWritesTotal)
+}
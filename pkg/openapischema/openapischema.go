@@ -17,7 +17,11 @@ You should have received a copy of the GNU Affero General Public License
 along with this program.  If not, see <http://www.gnu.org/licenses/>.
 */
 
-package common
+/*
+Package openapischema validates the data with the OpenAPI v3 schema, which is shared by the webhooks
+and controllers, such as validating the parameters of a Custom OpsRequest against its OpsDefinition.
+*/
+package openapischema
 
 import (
 	"fmt"
@@ -54,6 +58,20 @@ func ValidateDataWithSchema(openAPIV3Schema *apiextensionsv1.JSONSchemaProps, da
 	return nil
 }
 
+// ValidateParameters converts the string parameters by the types declared in the schema, and validates them with the schema.
+func ValidateParameters(openAPIV3Schema *apiextensionsv1.JSONSchemaProps, parameters map[string]string) error {
+	if openAPIV3Schema == nil {
+		return nil
+	}
+	params, err := CoverStringToInterfaceBySchemaType(openAPIV3Schema, parameters)
+	if err != nil {
+		return err
+	}
+	return ValidateDataWithSchema(openAPIV3Schema, params)
+}
+
+// CoverStringToInterfaceBySchemaType converts the string values to the types declared in the schema properties,
+// the keys not declared are skipped.
 func CoverStringToInterfaceBySchemaType(openAPIV3Schema *apiextensionsv1.JSONSchemaProps, input map[string]string) (map[string]interface{}, error) {
 	out := map[string]interface{}{}
 	properties := openAPIV3Schema.Properties
@@ -0,0 +1,60 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package openapischema
+
+import (
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestValidateParameters(t *testing.T) {
+	minimum := float64(1)
+	schema := &apiextensionsv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"database": {Type: "string"},
+			"threads":  {Type: "integer", Minimum: &minimum},
+			"dryRun":   {Type: "boolean"},
+		},
+		Required: []string{"database"},
+	}
+	tests := []struct {
+		name      string
+		params    map[string]string
+		expectErr bool
+	}{
+		{name: "valid", params: map[string]string{"database": "test", "threads": "4", "dryRun": "true"}},
+		{name: "missing required", params: map[string]string{"threads": "4"}, expectErr: true},
+		{name: "invalid type", params: map[string]string{"database": "test", "threads": "four"}, expectErr: true},
+		{name: "out of range", params: map[string]string{"database": "test", "threads": "0"}, expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateParameters(schema, tt.params)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("expect error: %v, but got: %v", tt.expectErr, err)
+			}
+		})
+	}
+	if err := ValidateParameters(nil, map[string]string{"any": "value"}); err != nil {
+		t.Errorf("unexpected error without schema: %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+Package opsvalidation provides the static validation rules of the OpsRequests, which need no access to the API server.
+The OpsRequest webhook validates the OpsRequests with these rules, so that external tools, such as CI pipelines
+and UIs, are able to lint the OpsRequests offline with exactly the same rules as the operator.
+
+The rules are defined on the names and the K8s core types rather than the KubeBlocks API types, the Cluster that
+an OpsRequest is validated against is described by a Topology, and the components and instance templates operated
+by the OpsRequest are described by the Targets. Topology.ValidateTargets reports all the violations as field errors
+with their paths in the OpsRequest, which LintOpsRequestSpec of the apps API builds on. The resources of the
+components in the Cluster are validated by ValidateResourceRequirements as well, the same rule as the vertical scaling.
+*/
+package opsvalidation
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// NotEmptyError returns the error for the required field which is empty.
+func NotEmptyError(target string) error {
+	return fmt.Errorf(`"%s" can not be empty`, target)
+}
+
+// isScalableResource checks whether the resource is allowed to be scaled vertically.
+func isScalableResource(name corev1.ResourceName) bool {
+	return name == corev1.ResourceCPU || name == corev1.ResourceMemory || strings.HasPrefix(name.String(), corev1.ResourceHugePagesPrefix)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package opsvalidation
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateResources(t *testing.T) {
+	path := field.NewPath("spec", "components").Index(0).Child("resources")
+	cases := []struct {
+		name      string
+		resources corev1.ResourceRequirements
+		err       string
+	}{
+		{
+			name: "valid",
+			resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), "hugepages-2Mi": resource.MustParse("1Gi")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+		},
+		{
+			name: "invalid resource name",
+			resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+			err: `spec.components[0].resources.limits[storage]: Unsupported value: "storage"`,
+		},
+		{
+			name: "requests exceed limits",
+			resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+			err: `spec.components[0].resources.requests[memory]: Invalid value: "2Gi": must be less than or equal to memory limit`,
+		},
+	}
+	for _, c := range cases {
+		err := ValidateResourceRequirements(path, c.resources).ToAggregate()
+		switch {
+		case c.err == "" && err != nil:
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+		case c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)):
+			t.Errorf("%s: expected error containing %q, got %v", c.name, c.err, err)
+		}
+	}
+}
+
+func TestTopology(t *testing.T) {
+	topology := Topology{
+		ClusterName: "mycluster",
+		Namespace:   "default",
+		Components: map[string]Component{
+			"mysql": {
+				VolumeClaimTemplates: []string{"data", "log"},
+				Instances:            map[string][]string{"large": {"data"}},
+			},
+			"shard": {VolumeClaimTemplates: []string{"data"}},
+		},
+	}
+
+	if !topology.hasComponent("mysql") || !topology.hasComponent("shard") || topology.hasComponent("redis") {
+		t.Error("unexpected components of the topology")
+	}
+	if !topology.hasInstance("mysql", "large") || topology.hasInstance("shard", "large") {
+		t.Error("unexpected instance templates of the topology")
+	}
+	if vcts := topology.volumeClaimTemplates("mysql", "large"); len(vcts) != 1 || vcts[0] != "data" {
+		t.Errorf("unexpected volumeClaimTemplates of the instance template: %v", vcts)
+	}
+
+	path := field.NewPath("spec", "volumeExpansion")
+	errs := topology.ValidateTargets([]Target{
+		{Path: path.Index(0), ComponentName: "mysql", VolumeClaimTemplates: []string{"data", "log"}},
+		{Path: path.Index(0).Child("instances").Index(0), ComponentName: "mysql", InstanceName: "large", VolumeClaimTemplates: []string{"data", "log"}},
+	})
+	if len(errs) != 1 {
+		t.Fatalf("expected the volumeClaimTemplate log of the instance template not found, got %v", errs)
+	}
+	expected := `spec.volumeExpansion[0].instances[0].volumeClaimTemplates[1].name: Not found: "log": ` +
+		`the volumeClaimTemplates of component: mysql.large are [data]. ` +
+		`Hint: view the volumeClaimTemplates of the component by command: kubectl get cluster mycluster -n default -o yaml`
+	if errs[0].Error() != expected {
+		t.Errorf("expected error %q, got %q", expected, errs[0].Error())
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package opsvalidation
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/apecloud/kubeblocks/pkg/kberrors"
+)
+
+// Target is a component, or an instance template of it, operated by an OpsRequest.
+type Target struct {
+	// Path is the path of the operated item in the OpsRequest, e.g. "spec.volumeExpansion[0]"
+	// or "spec.volumeExpansion[0].instances[1]".
+	Path *field.Path
+	// ComponentName is the name of the component or the sharding.
+	ComponentName string
+	// InstanceName is the name of the instance template, it's empty if the target is the component.
+	InstanceName string
+	// VolumeClaimTemplates are the names of the volumeClaimTemplates to be operated.
+	VolumeClaimTemplates []string
+	// Resources are the resources to be scaled vertically.
+	Resources *corev1.ResourceRequirements
+}
+
+// ValidateTargets validates the targets of an OpsRequest against the Topology, and returns all the errors
+// found with the paths of the fields.
+func (t Topology) ValidateTargets(targets []Target) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, target := range targets {
+		allErrs = append(allErrs, t.validateTarget(target)...)
+	}
+	return allErrs
+}
+
+func (t Topology) validateTarget(target Target) field.ErrorList {
+	var allErrs field.ErrorList
+	if target.InstanceName == "" && !t.hasComponent(target.ComponentName) {
+		return append(allErrs, field.NotFound(target.Path.Child("componentName"), target.ComponentName))
+	}
+	if target.InstanceName != "" && !t.hasInstance(target.ComponentName, target.InstanceName) {
+		return append(allErrs, field.NotFound(target.Path.Child("name"), target.InstanceName))
+	}
+	existing := sets.New(t.volumeClaimTemplates(target.ComponentName, target.InstanceName)...)
+	for i, vctName := range target.VolumeClaimTemplates {
+		if !existing.Has(vctName) {
+			allErrs = append(allErrs, t.volumeClaimTemplateNotFound(target, i, vctName))
+		}
+	}
+	if target.Resources != nil {
+		allErrs = append(allErrs, ValidateResourceRequirements(target.Path, *target.Resources)...)
+	}
+	return allErrs
+}
+
+// volumeClaimTemplateNotFound returns the error of the volumeClaimTemplate not found in the component or
+// the instance template, with the hint to view the volumeClaimTemplates of the Cluster.
+func (t Topology) volumeClaimTemplateNotFound(target Target, index int, vctName string) *field.Error {
+	key := target.ComponentName
+	if target.InstanceName != "" {
+		key = fmt.Sprintf("%s.%s", target.ComponentName, target.InstanceName)
+	}
+	err := field.NotFound(target.Path.Child("volumeClaimTemplates").Index(index).Child("name"), vctName)
+	err.Detail = kberrors.New(kberrors.ReasonNotFound, "the volumeClaimTemplates of component: %s are %v",
+		key, t.volumeClaimTemplates(target.ComponentName, target.InstanceName)).
+		WithHint("view the volumeClaimTemplates of the component by command: kubectl get cluster %s -n %s -o yaml", t.ClusterName, t.Namespace).
+		Error()
+	return err
+}
+
+// ValidateResourceRequirements validates the resource requirements of the vertical scaling at the path,
+// and returns all the errors found with the paths of the fields.
+func ValidateResourceRequirements(path *field.Path, resources corev1.ResourceRequirements) field.ErrorList {
+	var allErrs field.ErrorList
+	supported := []string{corev1.ResourceCPU.String(), corev1.ResourceMemory.String(), corev1.ResourceHugePagesPrefix + "<size>"}
+	for _, name := range sortedResourceNames(resources.Requests) {
+		if !isScalableResource(name) {
+			allErrs = append(allErrs, field.NotSupported(path.Child("requests").Key(name.String()), name.String(), supported))
+		}
+	}
+	for _, name := range sortedResourceNames(resources.Limits) {
+		if !isScalableResource(name) {
+			allErrs = append(allErrs, field.NotSupported(path.Child("limits").Key(name.String()), name.String(), supported))
+		}
+	}
+	for _, name := range sortedResourceNames(resources.Requests) {
+		request := resources.Requests[name]
+		if limit, ok := resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("requests").Key(name.String()), request.String(),
+				fmt.Sprintf("must be less than or equal to %s limit", name)))
+		}
+	}
+	return allErrs
+}
+
+func sortedResourceNames(resourceList corev1.ResourceList) []corev1.ResourceName {
+	names := make([]corev1.ResourceName, 0, len(resourceList))
+	for name := range resourceList {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+	return names
+}
@@ -0,0 +1,75 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package opsvalidation
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateTargets(t *testing.T) {
+	topology := Topology{
+		Components: map[string]Component{
+			"mysql": {
+				VolumeClaimTemplates: []string{"data"},
+				Instances:            map[string][]string{"large": {"data"}},
+			},
+		},
+	}
+	path := field.NewPath("spec", "verticalScaling")
+	targets := []Target{
+		{
+			Path:          path.Index(0),
+			ComponentName: "mysql",
+			Resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceMemory:  resource.MustParse("2Gi"),
+					corev1.ResourceStorage: resource.MustParse("1Gi"),
+				},
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+		},
+		{Path: path.Index(0).Child("instances").Index(0), ComponentName: "mysql", InstanceName: "large", VolumeClaimTemplates: []string{"data", "log"}},
+		{Path: path.Index(0).Child("instances").Index(1), ComponentName: "mysql", InstanceName: "small"},
+		{Path: path.Index(1), ComponentName: "redis", VolumeClaimTemplates: []string{"data"}},
+	}
+	expected := []struct {
+		errType field.ErrorType
+		field   string
+	}{
+		{field.ErrorTypeNotSupported, "spec.verticalScaling[0].requests[storage]"},
+		{field.ErrorTypeInvalid, "spec.verticalScaling[0].requests[memory]"},
+		{field.ErrorTypeNotFound, "spec.verticalScaling[0].instances[0].volumeClaimTemplates[1].name"},
+		{field.ErrorTypeNotFound, "spec.verticalScaling[0].instances[1].name"},
+		{field.ErrorTypeNotFound, "spec.verticalScaling[1].componentName"},
+	}
+	errs := topology.ValidateTargets(targets)
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, got %v", len(expected), errs)
+	}
+	for i, err := range errs {
+		if err.Type != expected[i].errType || err.Field != expected[i].field {
+			t.Errorf("expected the error %s of %s, got %v", expected[i].errType, expected[i].field, err)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package opsvalidation
+
+// Topology describes the components and shardings of the Cluster that the OpsRequests are validated against.
+type Topology struct {
+	// ClusterName is the name of the Cluster.
+	ClusterName string
+	// Namespace is the namespace of the Cluster.
+	Namespace string
+	// Components are the components and shardings of the Cluster, keyed by their names.
+	Components map[string]Component
+}
+
+// Component describes a component or a sharding of the Cluster.
+type Component struct {
+	// VolumeClaimTemplates are the names of the volumeClaimTemplates of the component.
+	VolumeClaimTemplates []string
+	// Instances are the names of the volumeClaimTemplates of the instance templates, keyed by the template names.
+	Instances map[string][]string
+}
+
+func (t Topology) hasComponent(compName string) bool {
+	_, ok := t.Components[compName]
+	return ok
+}
+
+func (t Topology) hasInstance(compName, instanceName string) bool {
+	_, ok := t.Components[compName].Instances[instanceName]
+	return ok
+}
+
+// volumeClaimTemplates returns the names of the volumeClaimTemplates of the component,
+// or of the instance template of the component if instanceName is not empty.
+func (t Topology) volumeClaimTemplates(compName, instanceName string) []string {
+	if instanceName != "" {
+		return t.Components[compName].Instances[instanceName]
+	}
+	return t.Components[compName].VolumeClaimTemplates
+}
@@ -0,0 +1,153 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+Package quorum provides the quorum calculator of the consensus components, which is shared by the webhooks
+and controllers to reject the operations that would drop the available voting members below the quorum.
+
+A component is a consensus component if any of its roles has voting rights. The voting members are the
+instances whose roles have voting rights, the instances whose roles are not reported yet are counted as
+voting members but not available ones.
+*/
+package quorum
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/kberrors"
+)
+
+const overrideHint = `set both "spec.force" and "spec.acknowledgeQuorumLoss" of the OpsRequest to bypass the quorum-safety check`
+
+// Members describes the voting members of a consensus component.
+type Members struct {
+	// Voters is the number of the voting members.
+	Voters int32
+	// Available is the number of the voting members which are ready.
+	Available int32
+}
+
+// Size returns the number of the voting members required to form a quorum, which is the majority of the voters.
+func Size(voters int32) int32 {
+	if voters <= 0 {
+		return 0
+	}
+	return voters/2 + 1
+}
+
+// ForInstanceSet returns the voting members of the InstanceSet with its pods,
+// and false if the InstanceSet has no roles with voting rights.
+func ForInstanceSet(its *workloads.InstanceSet, pods []corev1.Pod) (Members, bool) {
+	if !hasVotingRole(its.Spec.Roles) {
+		return Members{}, false
+	}
+	members := Members{Voters: 1}
+	if its.Spec.Replicas != nil {
+		members.Voters = *its.Spec.Replicas
+	}
+	for i := range pods {
+		voting, known := isVotingRole(its.Spec.Roles, pods[i].Labels[constant.RoleLabelKey])
+		switch {
+		case known && !voting:
+			members.Voters--
+		case known && isPodReady(&pods[i]):
+			members.Available++
+		}
+	}
+	return members, true
+}
+
+// IsAvailableVoter checks whether the pod is an available voting member of the InstanceSet.
+func IsAvailableVoter(its *workloads.InstanceSet, pod *corev1.Pod) bool {
+	voting, known := isVotingRole(its.Spec.Roles, pod.Labels[constant.RoleLabelKey])
+	return known && voting && isPodReady(pod)
+}
+
+// CheckScaleIn checks whether the quorum is kept when the voting members are scaled in to the target,
+// the unavailable members are assumed to be kept in the worst case.
+// Stopping all the members at once is allowed, as no quorum is required while the component is stopped.
+func (m Members) CheckScaleIn(target int32) error {
+	if target <= 0 || target >= m.Voters {
+		return nil
+	}
+	if m.Available < Size(m.Voters) {
+		return kberrors.New(kberrors.ReasonQuorumLoss,
+			"only %d of %d voting members are available, the membership can not be changed without a quorum of %d",
+			m.Available, m.Voters, Size(m.Voters)).WithHint(overrideHint)
+	}
+	available := target - min(m.Voters-m.Available, target)
+	if available < Size(target) {
+		return kberrors.New(kberrors.ReasonQuorumLoss,
+			"scaling in to %d voting members leaves %d available, which is below the quorum of %d",
+			target, available, Size(target)).WithHint(overrideHint)
+	}
+	return nil
+}
+
+// CheckDisruption checks whether the quorum is kept when the given number of the available voting members
+// are disrupted, e.g. evicted from the nodes to be drained.
+func (m Members) CheckDisruption(disrupted int32) error {
+	if disrupted <= 0 {
+		return nil
+	}
+	if m.Available-disrupted < Size(m.Voters) {
+		return kberrors.New(kberrors.ReasonQuorumLoss,
+			"disrupting %d of %d available voting members drops below the quorum of %d",
+			disrupted, m.Available, Size(m.Voters)).WithHint(overrideHint)
+	}
+	return nil
+}
+
+func hasVotingRole(roles []workloads.ReplicaRole) bool {
+	for _, role := range roles {
+		if role.CanVote {
+			return true
+		}
+	}
+	return false
+}
+
+// isVotingRole returns whether the role has voting rights, and whether the role is known.
+func isVotingRole(roles []workloads.ReplicaRole, roleName string) (bool, bool) {
+	if roleName == "" {
+		return false, false
+	}
+	for _, role := range roles {
+		if strings.EqualFold(role.Name, roleName) {
+			return role.CanVote, true
+		}
+	}
+	return false, false
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package quorum
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/kberrors"
+)
+
+func newPod(name, role string, ready bool) corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{constant.RoleLabelKey: role}},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+}
+
+func TestSize(t *testing.T) {
+	for voters, expected := range map[int32]int32{0: 0, 1: 1, 2: 2, 3: 2, 4: 3, 5: 3} {
+		if size := Size(voters); size != expected {
+			t.Errorf("unexpected quorum size of %d voters: %d", voters, size)
+		}
+	}
+}
+
+func TestForInstanceSet(t *testing.T) {
+	replicas := int32(4)
+	its := &workloads.InstanceSet{
+		Spec: workloads.InstanceSetSpec{
+			Replicas: &replicas,
+			Roles: []workloads.ReplicaRole{
+				{Name: "leader", IsLeader: true, CanVote: true},
+				{Name: "follower", CanVote: true},
+				{Name: "learner", CanVote: false},
+			},
+		},
+	}
+	pods := []corev1.Pod{
+		newPod("pod-0", "leader", true),
+		newPod("pod-1", "follower", false),
+		newPod("pod-2", "learner", true),
+		newPod("pod-3", "", true),
+	}
+	members, ok := ForInstanceSet(its, pods)
+	if !ok {
+		t.Fatal("expect a consensus component")
+	}
+	// the learner is not a voter, and the pod without role is not available
+	if members.Voters != 3 || members.Available != 1 {
+		t.Errorf("unexpected members: %+v", members)
+	}
+	if !IsAvailableVoter(its, &pods[0]) || IsAvailableVoter(its, &pods[1]) || IsAvailableVoter(its, &pods[2]) {
+		t.Error("unexpected available voters")
+	}
+
+	its.Spec.Roles = []workloads.ReplicaRole{{Name: "primary"}, {Name: "secondary"}}
+	if _, ok = ForInstanceSet(its, pods); ok {
+		t.Error("expect not a consensus component")
+	}
+}
+
+func TestCheckScaleIn(t *testing.T) {
+	tests := []struct {
+		name    string
+		members Members
+		target  int32
+		lost    bool
+	}{
+		{name: "scale in healthy members", members: Members{Voters: 5, Available: 5}, target: 3},
+		{name: "scale in to a single member", members: Members{Voters: 3, Available: 3}, target: 1},
+		{name: "scale out", members: Members{Voters: 3, Available: 1}, target: 5},
+		{name: "stop", members: Members{Voters: 3, Available: 1}, target: 0},
+		{name: "unavailable members are kept", members: Members{Voters: 5, Available: 4}, target: 2, lost: true},
+		{name: "quorum has been lost", members: Members{Voters: 5, Available: 2}, target: 4, lost: true},
+	}
+	for _, tt := range tests {
+		err := tt.members.CheckScaleIn(tt.target)
+		if tt.lost != kberrors.IsReason(err, kberrors.ReasonQuorumLoss) {
+			t.Errorf("%s: unexpected result: %v", tt.name, err)
+		}
+	}
+}
+
+func TestCheckDisruption(t *testing.T) {
+	members := Members{Voters: 3, Available: 3}
+	if err := members.CheckDisruption(1); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := members.CheckDisruption(2); !kberrors.IsReason(err, kberrors.ReasonQuorumLoss) {
+		t.Errorf("expect a quorum loss error, but got: %v", err)
+	}
+	members.Available = 2
+	if err := members.CheckDisruption(1); !kberrors.IsReason(err, kberrors.ReasonQuorumLoss) {
+		t.Errorf("expect a quorum loss error, but got: %v", err)
+	}
+	if err := members.CheckDisruption(0); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
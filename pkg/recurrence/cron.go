@@ -0,0 +1,168 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package recurrence
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronField describes the range of a field of the cron expression.
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// maxCronSearchYears bounds the search of the next time, the expressions like "0 0 30 2 *" never match.
+const maxCronSearchYears = 5
+
+// Cron is a parsed cron expression in the standard 5-field format "minute hour day-of-month month day-of-week".
+//
+// Each field is "*", a value, a range "a-b", optionally followed by a step "/n", or a comma-separated list of them.
+// The day of week is 0 to 7, both 0 and 7 are Sunday. As in the standard cron, a time matches if either the day
+// of month or the day of week matches when both of them are restricted.
+type Cron struct {
+	minutes, hours, daysOfMonth, months, daysOfWeek uint64
+	// anyDayOfMonth and anyDayOfWeek are true if the field is "*".
+	anyDayOfMonth, anyDayOfWeek bool
+	loc                         *time.Location
+}
+
+// ParseCron parses the cron expression, the times are evaluated in the location loc.
+func ParseCron(expr string, loc *time.Location) (*Cron, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf(`invalid cron expression "%s", expect 5 fields but got %d`, expr, len(fields))
+	}
+	bits := make([]uint64, len(fields))
+	for i, field := range fields {
+		var err error
+		if bits[i], err = parseCronField(field, cronFields[i]); err != nil {
+			return nil, err
+		}
+	}
+	// Sunday is either 0 or 7
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+	return &Cron{
+		minutes:       bits[0],
+		hours:         bits[1],
+		daysOfMonth:   bits[2],
+		months:        bits[3],
+		daysOfWeek:    bits[4],
+		anyDayOfMonth: fields[2] == "*",
+		anyDayOfWeek:  fields[4] == "*",
+		loc:           loc,
+	}, nil
+}
+
+func parseCronField(field string, f cronField) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepStr); err != nil || step <= 0 {
+				return 0, fmt.Errorf(`invalid step "%s" of the %s`, stepStr, f.name)
+			}
+		}
+		low, high := f.min, f.max
+		if rng != "*" {
+			lowStr, highStr, isRange := strings.Cut(rng, "-")
+			var err error
+			if low, err = parseCronValue(lowStr, f); err != nil {
+				return 0, err
+			}
+			switch {
+			case isRange:
+				if high, err = parseCronValue(highStr, f); err != nil {
+					return 0, err
+				}
+				if high < low {
+					return 0, fmt.Errorf(`invalid range "%s" of the %s`, rng, f.name)
+				}
+			case !hasStep:
+				high = low
+			}
+		}
+		for i := low; i <= high; i += step {
+			bits |= 1 << i
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(value string, f cronField) (int, error) {
+	i, err := strconv.Atoi(value)
+	if err != nil || i < f.min || i > f.max {
+		return 0, fmt.Errorf(`invalid %s "%s", expect a value between %d and %d`, f.name, value, f.min, f.max)
+	}
+	return i, nil
+}
+
+// Next returns the first time matching the expression after t, it is zero if no time matches in the next years.
+func (c *Cron) Next(t time.Time) time.Time {
+	t = t.In(c.loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxCronSearchYears, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case c.months&(1<<int(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, c.loc)
+		case !c.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, c.loc)
+		case c.hours&(1<<t.Hour()) == 0:
+			next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, c.loc)
+			if !next.After(t) {
+				// the clock is set back, e.g. the end of the daylight saving time
+				next = t.Truncate(time.Hour).Add(time.Hour)
+			}
+			t = next
+		case c.minutes&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (c *Cron) matchDay(t time.Time) bool {
+	matchDayOfMonth := c.daysOfMonth&(1<<t.Day()) != 0
+	matchDayOfWeek := c.daysOfWeek&(1<<int(t.Weekday())) != 0
+	if c.anyDayOfMonth || c.anyDayOfWeek {
+		return matchDayOfMonth && matchDayOfWeek
+	}
+	return matchDayOfMonth || matchDayOfWeek
+}
@@ -0,0 +1,99 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package recurrence
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	for _, expr := range []string{
+		"* * * * *",
+		"*/15 0-6 1,15 * 1-5",
+		"30 2 * * 7",
+		"0 0/6 * 1-12/3 *",
+	} {
+		if _, err := ParseCron(expr, time.UTC); err != nil {
+			t.Errorf("unexpected error for %s: %v", expr, err)
+		}
+	}
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+	} {
+		if _, err := ParseCron(expr, time.UTC); err == nil {
+			t.Errorf("expect an error for %s", expr)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// 2024-01-06 is a Saturday
+	now := time.Date(2024, 1, 6, 22, 30, 15, 0, time.UTC)
+	tests := []struct {
+		expr string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2024, 1, 6, 22, 31, 0, 0, time.UTC)},
+		{"*/20 * * * *", time.Date(2024, 1, 6, 22, 40, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2024, 1, 7, 2, 0, 0, 0, time.UTC)},
+		{"0 2 * * 1", time.Date(2024, 1, 8, 2, 0, 0, 0, time.UTC)},
+		{"0 2 * * 7", time.Date(2024, 1, 7, 2, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// either the day of month or the day of week matches
+		{"0 0 15 * 1", time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		c, err := ParseCron(tt.expr, time.UTC)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if next := c.Next(now); !next.Equal(tt.next) {
+			t.Errorf("%s: expect next %v, but got %v", tt.expr, tt.next, next)
+		}
+	}
+}
+
+func TestCronNextInLocation(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Skipf("time zone data is not available: %v", err)
+	}
+	c, err := ParseCron("0 2 * * *", loc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	now := time.Date(2024, 1, 6, 17, 0, 0, 0, time.UTC)
+	if next := c.Next(now); !next.Equal(time.Date(2024, 1, 6, 18, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected next %v", next)
+	}
+}
@@ -0,0 +1,275 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+Package recurrence implements a subset of the RFC 5545 recurrence rule (RRULE) to describe the recurring time windows.
+
+The supported rule parts are FREQ (DAILY, WEEKLY or MONTHLY), INTERVAL, BYDAY (without the ordinal),
+BYMONTHDAY (1 to 31), COUNT and UNTIL, e.g. "FREQ=WEEKLY;BYDAY=SA,SU" or "FREQ=MONTHLY;BYMONTHDAY=1;COUNT=12".
+*/
+package recurrence
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the FREQ part of a rule.
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+var untilLayouts = []string{"20060102T150405Z", "20060102T150405", "20060102"}
+
+// Rule is a parsed recurrence rule.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay []int
+	Count      int
+	Until      *time.Time
+}
+
+// Parse parses the rule in the form of "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR", the "RRULE:" prefix is optional.
+// The UNTIL part without the "Z" suffix is parsed in the location loc.
+func Parse(rule string, loc *time.Location) (*Rule, error) {
+	r := &Rule{Interval: 1}
+	for _, part := range strings.Split(strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:"), ";") {
+		if len(part) == 0 {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || len(value) == 0 {
+			return nil, fmt.Errorf(`invalid rule part "%s"`, part)
+		}
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			r.Freq = Frequency(strings.ToUpper(value))
+			if r.Freq != Daily && r.Freq != Weekly && r.Freq != Monthly {
+				return nil, fmt.Errorf("unsupported FREQ %s, expect DAILY, WEEKLY or MONTHLY", value)
+			}
+		case "INTERVAL":
+			if r.Interval, err = parsePositive(key, value); err != nil {
+				return nil, err
+			}
+		case "COUNT":
+			if r.Count, err = parsePositive(key, value); err != nil {
+				return nil, err
+			}
+		case "BYDAY":
+			for _, day := range strings.Split(strings.ToUpper(value), ",") {
+				weekday, ok := weekdays[day]
+				if !ok {
+					return nil, fmt.Errorf("unsupported BYDAY %s, expect one of MO, TU, WE, TH, FR, SA and SU", day)
+				}
+				r.ByDay = append(r.ByDay, weekday)
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(value, ",") {
+				monthDay, err := strconv.Atoi(day)
+				if err != nil || monthDay < 1 || monthDay > 31 {
+					return nil, fmt.Errorf("unsupported BYMONTHDAY %s, expect a day from 1 to 31", day)
+				}
+				r.ByMonthDay = append(r.ByMonthDay, monthDay)
+			}
+		case "UNTIL":
+			if r.Until, err = parseUntil(value, loc); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unsupported rule part %s", key)
+		}
+	}
+	switch {
+	case len(r.Freq) == 0:
+		return nil, fmt.Errorf("FREQ is required in rule %s", rule)
+	case r.Count > 0 && r.Until != nil:
+		return nil, fmt.Errorf("COUNT and UNTIL can not be specified at the same time")
+	case len(r.ByDay) > 0 && r.Freq != Weekly:
+		return nil, fmt.Errorf("BYDAY is only supported by WEEKLY")
+	case len(r.ByMonthDay) > 0 && r.Freq != Monthly:
+		return nil, fmt.Errorf("BYMONTHDAY is only supported by MONTHLY")
+	}
+	return r, nil
+}
+
+func parsePositive(key, value string) (int, error) {
+	i, err := strconv.Atoi(value)
+	if err != nil || i <= 0 {
+		return 0, fmt.Errorf("%s must be a positive integer, but got %s", key, value)
+	}
+	return i, nil
+}
+
+func parseUntil(value string, loc *time.Location) (*time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	for _, layout := range untilLayouts {
+		if until, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return &until, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid UNTIL %s, expect the format of 20060102T150405Z", value)
+}
+
+// Window is a recurring time window, the occurrences start at the times generated by the rule
+// from the start, and each of them lasts for the duration.
+type Window struct {
+	// Start is the start of the first occurrence, the occurrences are generated in its location.
+	Start    time.Time
+	Duration time.Duration
+	// Rule is nil for a window which occurs only once.
+	Rule *Rule
+}
+
+// ActiveAt checks whether t is in an occurrence of the window, and returns the end of the occurrence.
+func (w Window) ActiveAt(t time.Time) (time.Time, bool) {
+	var (
+		end    time.Time
+		active bool
+	)
+	w.iterate(t, func(start time.Time) {
+		if !t.Before(start) && t.Before(start.Add(w.Duration)) && start.Add(w.Duration).After(end) {
+			end, active = start.Add(w.Duration), true
+		}
+	})
+	return end, active
+}
+
+// iterate calls fn with the starts of the occurrences in order, until the occurrence starts after t.
+func (w Window) iterate(t time.Time, fn func(start time.Time)) {
+	if w.Rule == nil {
+		if !w.Start.After(t) {
+			fn(w.Start)
+		}
+		return
+	}
+	count := 0
+	for period := w.firstPeriod(t); ; period++ {
+		base, starts := w.period(period)
+		if base.After(t) {
+			return
+		}
+		for _, start := range starts {
+			if start.Before(w.Start) {
+				continue
+			}
+			if start.After(t) || (w.Rule.Until != nil && start.After(*w.Rule.Until)) {
+				return
+			}
+			if w.Rule.Count > 0 {
+				if count == w.Rule.Count {
+					return
+				}
+				count++
+			}
+			fn(start)
+		}
+	}
+}
+
+// firstPeriod skips the periods which end before the occurrences containing t can start,
+// the periods are counted from the first one if the occurrences are limited by COUNT.
+func (w Window) firstPeriod(t time.Time) int {
+	if w.Rule.Count > 0 {
+		return 0
+	}
+	from := t.Add(-w.Duration)
+	if !from.After(w.Start) {
+		return 0
+	}
+	var periods int
+	switch w.Rule.Freq {
+	case Daily:
+		periods = int(from.Sub(w.Start).Hours()/24) / w.Rule.Interval
+	case Weekly:
+		periods = int(from.Sub(w.Start).Hours()/24/7) / w.Rule.Interval
+	case Monthly:
+		from = from.In(w.Start.Location())
+		periods = ((from.Year()-w.Start.Year())*12 + int(from.Month()-w.Start.Month())) / w.Rule.Interval
+	}
+	// leave a period for the occurrences across the boundary of the periods
+	if periods--; periods < 0 {
+		return 0
+	}
+	return periods
+}
+
+// period returns the beginning of the period and the starts of the occurrences in it.
+func (w Window) period(period int) (time.Time, []time.Time) {
+	var (
+		start  = w.Start
+		offset = period * w.Rule.Interval
+		at     = func(year int, month time.Month, day int) time.Time {
+			return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+		}
+		starts []time.Time
+	)
+	switch w.Rule.Freq {
+	case Weekly:
+		// weeks begin on Monday
+		monday := at(start.Year(), start.Month(), start.Day()-(int(start.Weekday())+6)%7+offset*7)
+		days := w.Rule.ByDay
+		if len(days) == 0 {
+			days = []time.Weekday{start.Weekday()}
+		}
+		for _, day := range days {
+			starts = append(starts, at(monday.Year(), monday.Month(), monday.Day()+(int(day)+6)%7))
+		}
+		sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+		return monday, starts
+	case Monthly:
+		first := at(start.Year(), start.Month()+time.Month(offset), 1)
+		days := w.Rule.ByMonthDay
+		if len(days) == 0 {
+			days = []int{start.Day()}
+		}
+		for _, day := range days {
+			// the days which do not exist in the month are skipped
+			if date := at(first.Year(), first.Month(), day); date.Month() == first.Month() {
+				starts = append(starts, date)
+			}
+		}
+		sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+		return first, starts
+	default:
+		day := at(start.Year(), start.Month(), start.Day()+offset)
+		return day, []time.Time{day}
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package recurrence
+
+import (
+	"testing"
+	"time"
+)
+
+func mustParse(t *testing.T, rule string) *Rule {
+	r, err := Parse(rule, time.UTC)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return r
+}
+
+func TestParse(t *testing.T) {
+	r := mustParse(t, "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;UNTIL=20250101T000000Z")
+	if r.Freq != Weekly || r.Interval != 2 || len(r.ByDay) != 2 || r.Until == nil {
+		t.Errorf("unexpected rule: %+v", r)
+	}
+	for _, rule := range []string{
+		"",
+		"INTERVAL=1",
+		"FREQ=YEARLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;BYDAY=MO",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=DAILY;COUNT=1;UNTIL=20250101",
+		"FREQ=DAILY;BYHOUR=1",
+	} {
+		if _, err := Parse(rule, time.UTC); err == nil {
+			t.Errorf("expect an error for rule %s", rule)
+		}
+	}
+}
+
+func TestWindowActiveAt(t *testing.T) {
+	// 2024-01-06 is a Saturday
+	start := time.Date(2024, 1, 6, 22, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		window Window
+		t      time.Time
+		active bool
+		end    time.Time
+	}{
+		{
+			name:   "once",
+			window: Window{Start: start, Duration: time.Hour},
+			t:      start.Add(30 * time.Minute),
+			active: true,
+			end:    start.Add(time.Hour),
+		},
+		{
+			name:   "before the first occurrence",
+			window: Window{Start: start, Duration: time.Hour, Rule: mustParse(t, "FREQ=DAILY")},
+			t:      start.Add(-time.Minute),
+		},
+		{
+			name:   "daily",
+			window: Window{Start: start, Duration: 2 * time.Hour, Rule: mustParse(t, "FREQ=DAILY")},
+			t:      time.Date(2024, 3, 10, 23, 30, 0, 0, time.UTC),
+			active: true,
+			end:    time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "daily out of the window",
+			window: Window{Start: start, Duration: 2 * time.Hour, Rule: mustParse(t, "FREQ=DAILY;INTERVAL=2")},
+			t:      time.Date(2024, 1, 7, 23, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "weekly across the weeks",
+			window: Window{Start: start, Duration: 30 * time.Hour, Rule: mustParse(t, "FREQ=WEEKLY;BYDAY=SA")},
+			t:      time.Date(2024, 5, 6, 1, 0, 0, 0, time.UTC),
+			active: true,
+			end:    time.Date(2024, 5, 6, 4, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "weekly by days",
+			window: Window{Start: start, Duration: time.Hour, Rule: mustParse(t, "FREQ=WEEKLY;BYDAY=WE,SA")},
+			t:      time.Date(2024, 1, 10, 22, 59, 0, 0, time.UTC),
+			active: true,
+			end:    time.Date(2024, 1, 10, 23, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "monthly skips the days not in the month",
+			window: Window{Start: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), Duration: 24 * time.Hour, Rule: mustParse(t, "FREQ=MONTHLY")},
+			t:      time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "monthly",
+			window: Window{Start: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), Duration: 24 * time.Hour, Rule: mustParse(t, "FREQ=MONTHLY")},
+			t:      time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC),
+			active: true,
+			end:    time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "count",
+			window: Window{Start: start, Duration: time.Hour, Rule: mustParse(t, "FREQ=DAILY;COUNT=3")},
+			t:      time.Date(2024, 1, 9, 22, 30, 0, 0, time.UTC),
+		},
+		{
+			name:   "until",
+			window: Window{Start: start, Duration: time.Hour, Rule: mustParse(t, "FREQ=DAILY;UNTIL=20240108T220000Z")},
+			t:      time.Date(2024, 1, 8, 22, 30, 0, 0, time.UTC),
+			active: true,
+			end:    time.Date(2024, 1, 8, 23, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			end, active := tt.window.ActiveAt(tt.t)
+			if active != tt.active || !end.Equal(tt.end) {
+				t.Errorf("expect active %t and end %s, but got %t and %s", tt.active, tt.end, active, end)
+			}
+		})
+	}
+}
+
+func TestWindowInLocation(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database is not available")
+	}
+	// the occurrences keep the wall clock across the daylight saving time
+	window := Window{Start: time.Date(2024, 3, 1, 2, 30, 0, 0, loc), Duration: time.Hour, Rule: mustParse(t, "FREQ=DAILY")}
+	if _, active := window.ActiveAt(time.Date(2024, 3, 20, 2, 45, 0, 0, loc)); !active {
+		t.Error("expect the window is active")
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package simulation
+
+import (
+	"time"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+// History records the average duration to roll out an instance of each OpsType.
+type History struct {
+	durations map[appsv1alpha1.OpsType]time.Duration
+}
+
+// NewHistory builds the History from the completed OpsRequests.
+// The duration of an OpsRequest is divided by the number of the instances it processed.
+func NewHistory(opsRequests []appsv1alpha1.OpsRequest) *History {
+	var (
+		totalDurations = map[appsv1alpha1.OpsType]time.Duration{}
+		totalInstances = map[appsv1alpha1.OpsType]int64{}
+	)
+	for _, ops := range opsRequests {
+		if ops.Status.Phase != appsv1alpha1.OpsSucceedPhase && ops.Status.Phase != appsv1alpha1.OpsSucceedWithWarningsPhase {
+			continue
+		}
+		if ops.Status.StartTimestamp.IsZero() || ops.Status.CompletionTimestamp.IsZero() {
+			continue
+		}
+		duration := ops.Status.CompletionTimestamp.Sub(ops.Status.StartTimestamp.Time)
+		if duration < 0 {
+			continue
+		}
+		var instances int64
+		for _, compStatus := range ops.Status.Components {
+			instances += int64(len(compStatus.ProgressDetails))
+		}
+		if instances == 0 {
+			instances = 1
+		}
+		totalDurations[ops.Spec.Type] += duration
+		totalInstances[ops.Spec.Type] += instances
+	}
+	history := &History{durations: map[appsv1alpha1.OpsType]time.Duration{}}
+	for opsType, duration := range totalDurations {
+		history.durations[opsType] = duration / time.Duration(totalInstances[opsType])
+	}
+	return history
+}
+
+// InstanceDuration returns the average duration to roll out an instance of the OpsType,
+// and false if there is no historical OpsRequest of the type.
+func (h *History) InstanceDuration(opsType appsv1alpha1.OpsType) (time.Duration, bool) {
+	if h == nil {
+		return 0, false
+	}
+	duration, ok := h.durations[opsType]
+	return duration, ok
+}
@@ -0,0 +1,337 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package simulation
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+// simulatedComponent is a component of the cluster, the spec of a sharding is the template of its shards.
+type simulatedComponent struct {
+	name   string
+	spec   *appsv1alpha1.ClusterComponentSpec
+	shards int32
+}
+
+// Simulate applies the proposed operations to a copy of the cluster one by one,
+// and computes the resulting resource totals, the disruption implications and the expected rollout duration.
+func Simulate(cluster *appsv1alpha1.Cluster, opsRequests []appsv1alpha1.OpsRequest, history *History, opts Options) (*Result, error) {
+	if opts.DefaultInstanceDuration <= 0 {
+		opts.DefaultInstanceDuration = DefaultInstanceDuration
+	}
+	result := &Result{Cluster: cluster.Name}
+	origin := cluster.DeepCopy()
+	target := cluster.DeepCopy()
+	for _, ops := range opsRequests {
+		if ops.Spec.GetClusterName() != "" && ops.Spec.GetClusterName() != cluster.Name {
+			return nil, fmt.Errorf(`OpsRequest "%s" is not for the cluster "%s"`, ops.Name, cluster.Name)
+		}
+		step, warnings, err := applyOps(target, &ops)
+		if err != nil {
+			return nil, err
+		}
+		result.Warnings = append(result.Warnings, warnings...)
+		if duration, ok := history.InstanceDuration(step.Type); ok {
+			step.InstanceDuration = duration
+			step.FromHistory = true
+		} else {
+			step.InstanceDuration = opts.DefaultInstanceDuration
+		}
+		step.Duration = step.InstanceDuration * time.Duration(step.Instances)
+		result.ExpectedDuration += step.Duration
+		result.Steps = append(result.Steps, *step)
+	}
+
+	result.Before = newResourceTotals()
+	result.After = newResourceTotals()
+	originComps := getSimulatedComponents(origin)
+	for _, comp := range getSimulatedComponents(target) {
+		compResult := ComponentResult{
+			Name:       comp.name,
+			Before:     newResourceTotals(),
+			After:      componentResourceTotals(comp),
+			Disruption: getDisruptionImplication(comp),
+		}
+		for _, originComp := range originComps {
+			if originComp.name == comp.name {
+				compResult.Before = componentResourceTotals(originComp)
+				break
+			}
+		}
+		if compResult.After.Replicas < compResult.Before.Replicas {
+			compResult.Disruption.Warnings = append(compResult.Disruption.Warnings,
+				fmt.Sprintf("the replicas are scaled in from %d to %d, which reduces the tolerated failures",
+					compResult.Before.Replicas, compResult.After.Replicas))
+		}
+		result.Before.add(compResult.Before)
+		result.After.add(compResult.After)
+		result.Components = append(result.Components, compResult)
+	}
+	return result, nil
+}
+
+// applyOps applies the OpsRequest to the cluster and returns the instances to roll out.
+func applyOps(cluster *appsv1alpha1.Cluster, ops *appsv1alpha1.OpsRequest) (*StepEstimate, []string, error) {
+	var (
+		step     = &StepEstimate{Type: ops.Spec.Type}
+		warnings []string
+	)
+	getComponent := func(compName string) (*simulatedComponent, error) {
+		for _, comp := range getSimulatedComponents(cluster) {
+			if comp.name == compName {
+				return &comp, nil
+			}
+		}
+		return nil, fmt.Errorf(`component "%s" of OpsRequest "%s" not found in cluster "%s"`, compName, ops.Name, cluster.Name)
+	}
+	rollout := func(compName string, instances int32) {
+		step.Components = append(step.Components, compName)
+		step.Instances += instances
+	}
+	switch ops.Spec.Type {
+	case appsv1alpha1.RestartType:
+		for _, compOps := range ops.Spec.RestartList {
+			comp, err := getComponent(compOps.ComponentName)
+			if err != nil {
+				return nil, nil, err
+			}
+			rollout(comp.name, comp.replicas())
+		}
+	case appsv1alpha1.HorizontalScalingType:
+		for _, hScale := range ops.Spec.HorizontalScalingList {
+			comp, err := getComponent(hScale.ComponentName)
+			if err != nil {
+				return nil, nil, err
+			}
+			before := comp.spec.Replicas
+			switch {
+			case hScale.Replicas != nil:
+				comp.spec.Replicas = *hScale.Replicas
+			default:
+				if hScale.ScaleOut != nil && hScale.ScaleOut.ReplicaChanges != nil {
+					comp.spec.Replicas += *hScale.ScaleOut.ReplicaChanges
+				}
+				if hScale.ScaleIn != nil && hScale.ScaleIn.ReplicaChanges != nil {
+					comp.spec.Replicas -= *hScale.ScaleIn.ReplicaChanges
+				}
+			}
+			if comp.spec.Replicas < 0 {
+				return nil, nil, fmt.Errorf(`the replicas of component "%s" can not be less than 0`, comp.name)
+			}
+			changes := comp.spec.Replicas - before
+			if changes < 0 {
+				changes = -changes
+			}
+			rollout(comp.name, changes*comp.shardsOrOne())
+		}
+	case appsv1alpha1.VerticalScalingType:
+		for _, vScale := range ops.Spec.VerticalScalingList {
+			comp, err := getComponent(vScale.ComponentName)
+			if err != nil {
+				return nil, nil, err
+			}
+			mergeResources(&comp.spec.Resources, vScale.ResourceRequirements)
+			for _, insResources := range vScale.Instances {
+				for i := range comp.spec.Instances {
+					if comp.spec.Instances[i].Name != insResources.Name {
+						continue
+					}
+					if comp.spec.Instances[i].Resources == nil {
+						comp.spec.Instances[i].Resources = comp.spec.Resources.DeepCopy()
+					}
+					mergeResources(comp.spec.Instances[i].Resources, insResources.ResourceRequirements)
+				}
+			}
+			rollout(comp.name, comp.replicas())
+		}
+	case appsv1alpha1.UpgradeType:
+		if ops.Spec.Upgrade == nil {
+			break
+		}
+		for _, upgrade := range ops.Spec.Upgrade.Components {
+			comp, err := getComponent(upgrade.ComponentName)
+			if err != nil {
+				return nil, nil, err
+			}
+			if upgrade.ServiceVersion != nil {
+				comp.spec.ServiceVersion = *upgrade.ServiceVersion
+			}
+			if upgrade.ComponentDefinitionName != nil {
+				comp.spec.ComponentDef = *upgrade.ComponentDefinitionName
+			}
+			rollout(comp.name, comp.replicas())
+		}
+	case appsv1alpha1.VolumeExpansionType:
+		for _, vExpansion := range ops.Spec.VolumeExpansionList {
+			comp, err := getComponent(vExpansion.ComponentName)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, opsVCT := range vExpansion.VolumeClaimTemplates {
+				for i := range comp.spec.VolumeClaimTemplates {
+					vct := &comp.spec.VolumeClaimTemplates[i]
+					if vct.Name != opsVCT.Name {
+						continue
+					}
+					if vct.Spec.Resources.Requests == nil {
+						vct.Spec.Resources.Requests = corev1.ResourceList{}
+					}
+					vct.Spec.Resources.Requests[corev1.ResourceStorage] = opsVCT.Storage
+				}
+			}
+			rollout(comp.name, comp.replicas())
+		}
+	default:
+		warnings = append(warnings, fmt.Sprintf(`OpsRequest "%s" of type %s is not simulated`, ops.Name, ops.Spec.Type))
+	}
+	return step, warnings, nil
+}
+
+func getSimulatedComponents(cluster *appsv1alpha1.Cluster) []simulatedComponent {
+	var comps []simulatedComponent
+	for i := range cluster.Spec.ComponentSpecs {
+		comps = append(comps, simulatedComponent{name: cluster.Spec.ComponentSpecs[i].Name, spec: &cluster.Spec.ComponentSpecs[i]})
+	}
+	for i := range cluster.Spec.ShardingSpecs {
+		sharding := &cluster.Spec.ShardingSpecs[i]
+		comps = append(comps, simulatedComponent{name: sharding.Name, spec: &sharding.Template, shards: sharding.Shards})
+	}
+	return comps
+}
+
+func (c simulatedComponent) shardsOrOne() int32 {
+	if c.shards > 0 {
+		return c.shards
+	}
+	return 1
+}
+
+// replicas returns the replicas of all the shards for a sharding.
+func (c simulatedComponent) replicas() int32 {
+	return c.spec.Replicas * c.shardsOrOne()
+}
+
+// mergeResources merges the requests and limits of the source resources to the target.
+func mergeResources(target *corev1.ResourceRequirements, source corev1.ResourceRequirements) {
+	merge := func(target *corev1.ResourceList, source corev1.ResourceList) {
+		if len(source) == 0 {
+			return
+		}
+		if *target == nil {
+			*target = corev1.ResourceList{}
+		}
+		for name, quantity := range source {
+			(*target)[name] = quantity
+		}
+	}
+	merge(&target.Requests, source.Requests)
+	merge(&target.Limits, source.Limits)
+}
+
+func newResourceTotals() ResourceTotals {
+	return ResourceTotals{Requests: corev1.ResourceList{}, Limits: corev1.ResourceList{}}
+}
+
+// add adds the totals to the receiver.
+func (t *ResourceTotals) add(totals ResourceTotals) {
+	t.Replicas += totals.Replicas
+	addResourceList(t.Requests, totals.Requests, 1)
+	addResourceList(t.Limits, totals.Limits, 1)
+	t.Storage.Add(totals.Storage)
+}
+
+// addInstances adds the resources of the instances to the totals.
+func (t *ResourceTotals) addInstances(resources corev1.ResourceRequirements, instances int32) {
+	addResourceList(t.Requests, resources.Requests, instances)
+	addResourceList(t.Limits, resources.Limits, instances)
+}
+
+func addResourceList(target, source corev1.ResourceList, times int32) {
+	for name, quantity := range source {
+		total := target[name]
+		for i := int32(0); i < times; i++ {
+			total.Add(quantity)
+		}
+		target[name] = total
+	}
+}
+
+// componentResourceTotals computes the resource totals of a component, the instance templates override the
+// resources of the component for their replicas.
+func componentResourceTotals(comp simulatedComponent) ResourceTotals {
+	totals := newResourceTotals()
+	var templateReplicas int32
+	for _, ins := range comp.spec.Instances {
+		replicas := int32(1)
+		if ins.Replicas != nil {
+			replicas = *ins.Replicas
+		}
+		templateReplicas += replicas
+		resources := comp.spec.Resources
+		if ins.Resources != nil {
+			resources = *ins.Resources
+		}
+		totals.addInstances(resources, replicas*comp.shardsOrOne())
+	}
+	if comp.spec.Replicas > templateReplicas {
+		totals.addInstances(comp.spec.Resources, (comp.spec.Replicas-templateReplicas)*comp.shardsOrOne())
+	}
+	totals.Replicas = comp.replicas()
+	for _, vct := range comp.spec.VolumeClaimTemplates {
+		storage := vct.Spec.Resources.Requests[corev1.ResourceStorage]
+		for i := int32(0); i < totals.Replicas; i++ {
+			totals.Storage.Add(storage)
+		}
+	}
+	return totals
+}
+
+// getDisruptionImplication computes the availability of a component during the rollout,
+// the instances are rolled out one by one by default.
+func getDisruptionImplication(comp simulatedComponent) DisruptionImplication {
+	var (
+		replicas    = comp.spec.Replicas
+		implication = DisruptionImplication{Quorum: replicas/2 + 1}
+	)
+	if replicas == 0 {
+		implication.Quorum = 0
+		return implication
+	}
+	implication.MaxUnavailable = 1
+	implication.MinAvailable = replicas - implication.MaxUnavailable
+	implication.QuorumKept = implication.MinAvailable >= implication.Quorum
+	switch {
+	case replicas == 1:
+		implication.Warnings = append(implication.Warnings,
+			"the component has no redundancy, any rollout or voluntary disruption causes downtime")
+	case !implication.QuorumKept:
+		implication.Warnings = append(implication.Warnings,
+			fmt.Sprintf("the majority of %d replicas is lost when an instance is unavailable during the rollout", replicas))
+	case replicas%2 == 0:
+		implication.Warnings = append(implication.Warnings,
+			fmt.Sprintf("%d replicas tolerate the same failures as %d replicas", replicas, replicas-1))
+	}
+	return implication
+}
@@ -0,0 +1,164 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+func newTestCluster() *appsv1alpha1.Cluster {
+	return &appsv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+		Spec: appsv1alpha1.ClusterSpec{
+			ComponentSpecs: []appsv1alpha1.ClusterComponentSpec{{
+				Name:     "mysql",
+				Replicas: 3,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+					Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				},
+				VolumeClaimTemplates: []appsv1alpha1.ClusterComponentVolumeClaimTemplate{{
+					Name: "data",
+					Spec: appsv1alpha1.PersistentVolumeClaimSpec{
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+						},
+					},
+				}},
+			}},
+		},
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	replicas := int32(5)
+	serviceVersion := "8.0.33"
+	opsRequests := []appsv1alpha1.OpsRequest{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "scale"},
+			Spec: appsv1alpha1.OpsRequestSpec{
+				Type: appsv1alpha1.HorizontalScalingType,
+				SpecificOpsRequest: appsv1alpha1.SpecificOpsRequest{
+					HorizontalScalingList: []appsv1alpha1.HorizontalScaling{{
+						ComponentOps: appsv1alpha1.ComponentOps{ComponentName: "mysql"},
+						Replicas:     &replicas,
+					}},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "upgrade"},
+			Spec: appsv1alpha1.OpsRequestSpec{
+				Type: appsv1alpha1.UpgradeType,
+				SpecificOpsRequest: appsv1alpha1.SpecificOpsRequest{
+					Upgrade: &appsv1alpha1.Upgrade{
+						Components: []appsv1alpha1.UpgradeComponent{{
+							ComponentOps:   appsv1alpha1.ComponentOps{ComponentName: "mysql"},
+							ServiceVersion: &serviceVersion,
+						}},
+					},
+				},
+			},
+		},
+	}
+	now := time.Now()
+	history := NewHistory([]appsv1alpha1.OpsRequest{{
+		Spec: appsv1alpha1.OpsRequestSpec{Type: appsv1alpha1.UpgradeType},
+		Status: appsv1alpha1.OpsRequestStatus{
+			Phase:               appsv1alpha1.OpsSucceedPhase,
+			StartTimestamp:      metav1.NewTime(now.Add(-6 * time.Minute)),
+			CompletionTimestamp: metav1.NewTime(now),
+			Components: map[string]appsv1alpha1.OpsRequestComponentStatus{
+				"mysql": {ProgressDetails: make([]appsv1alpha1.ProgressStatusDetail, 3)},
+			},
+		},
+	}})
+
+	result, err := Simulate(newTestCluster(), opsRequests, history, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Before.Replicas != 3 || result.After.Replicas != 5 {
+		t.Errorf("unexpected replicas: before %d, after %d", result.Before.Replicas, result.After.Replicas)
+	}
+	if cpu := result.After.Requests[corev1.ResourceCPU]; cpu.Cmp(resource.MustParse("5")) != 0 {
+		t.Errorf("unexpected cpu requests: %s", cpu.String())
+	}
+	if cpu := result.After.Limits[corev1.ResourceCPU]; cpu.Cmp(resource.MustParse("10")) != 0 {
+		t.Errorf("unexpected cpu limits: %s", cpu.String())
+	}
+	if result.After.Storage.Cmp(resource.MustParse("50Gi")) != 0 {
+		t.Errorf("unexpected storage: %s", result.After.Storage.String())
+	}
+	if len(result.Steps) != 2 {
+		t.Fatalf("unexpected steps: %+v", result.Steps)
+	}
+	// scaling out 2 instances with the default duration
+	if step := result.Steps[0]; step.Instances != 2 || step.FromHistory || step.Duration != 2*DefaultInstanceDuration {
+		t.Errorf("unexpected horizontal scaling step: %+v", step)
+	}
+	// upgrading 5 instances with 2 minutes per instance from the history
+	if step := result.Steps[1]; step.Instances != 5 || !step.FromHistory || step.Duration != 10*time.Minute {
+		t.Errorf("unexpected upgrade step: %+v", step)
+	}
+	if result.ExpectedDuration != 12*time.Minute {
+		t.Errorf("unexpected expected duration: %s", result.ExpectedDuration)
+	}
+	disruption := result.Components[0].Disruption
+	if disruption.MinAvailable != 4 || disruption.Quorum != 3 || !disruption.QuorumKept {
+		t.Errorf("unexpected disruption: %+v", disruption)
+	}
+}
+
+func TestSimulateScaleIn(t *testing.T) {
+	replicas := int32(1)
+	opsRequests := []appsv1alpha1.OpsRequest{{
+		ObjectMeta: metav1.ObjectMeta{Name: "scale-in"},
+		Spec: appsv1alpha1.OpsRequestSpec{
+			Type: appsv1alpha1.HorizontalScalingType,
+			SpecificOpsRequest: appsv1alpha1.SpecificOpsRequest{
+				HorizontalScalingList: []appsv1alpha1.HorizontalScaling{{
+					ComponentOps: appsv1alpha1.ComponentOps{ComponentName: "mysql"},
+					Replicas:     &replicas,
+				}},
+			},
+		},
+	}}
+	result, err := Simulate(newTestCluster(), opsRequests, nil, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	disruption := result.Components[0].Disruption
+	if disruption.MinAvailable != 0 || len(disruption.Warnings) != 2 {
+		t.Errorf("unexpected disruption: %+v", disruption)
+	}
+
+	opsRequests[0].Spec.HorizontalScalingList[0].ComponentName = "not-exist"
+	if _, err = Simulate(newTestCluster(), opsRequests, nil, Options{}); err == nil {
+		t.Error("expect an error for the component not found")
+	}
+}
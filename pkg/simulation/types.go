@@ -0,0 +1,100 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+/*
+Package simulation computes the what-if results of the proposed operations on a cluster for capacity planning,
+including the resulting resource totals, the disruption implications and the expected rollout duration
+estimated from the historical OpsRequests.
+*/
+package simulation
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+const (
+	// DefaultInstanceDuration is the duration to roll out an instance if there is no historical OpsRequest.
+	DefaultInstanceDuration = time.Minute
+)
+
+// Options defines the options of the simulation.
+type Options struct {
+	// DefaultInstanceDuration is the duration to roll out an instance if there is no historical OpsRequest of the type.
+	DefaultInstanceDuration time.Duration
+}
+
+// ResourceTotals is the sum of the resources of the instances.
+type ResourceTotals struct {
+	Replicas int32               `json:"replicas"`
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+	Limits   corev1.ResourceList `json:"limits,omitempty"`
+	Storage  resource.Quantity   `json:"storage"`
+}
+
+// DisruptionImplication describes the availability of a component during the rollout.
+type DisruptionImplication struct {
+	// MaxUnavailable is the number of instances which are unavailable at the same time during the rollout.
+	MaxUnavailable int32 `json:"maxUnavailable"`
+	// MinAvailable is the number of instances which are available during the rollout.
+	MinAvailable int32 `json:"minAvailable"`
+	// Quorum is the majority of the instances.
+	Quorum int32 `json:"quorum"`
+	// QuorumKept indicates whether the majority of the instances are available during the rollout.
+	QuorumKept bool `json:"quorumKept"`
+	// Warnings are the risks of the disruption.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ComponentResult is the what-if result of a component.
+type ComponentResult struct {
+	Name       string                `json:"name"`
+	Before     ResourceTotals        `json:"before"`
+	After      ResourceTotals        `json:"after"`
+	Disruption DisruptionImplication `json:"disruption"`
+}
+
+// StepEstimate is the estimated rollout of a proposed operation.
+type StepEstimate struct {
+	Type       appsv1alpha1.OpsType `json:"type"`
+	Components []string             `json:"components"`
+	// Instances is the number of instances to roll out.
+	Instances int32 `json:"instances"`
+	// InstanceDuration is the duration to roll out an instance.
+	InstanceDuration time.Duration `json:"instanceDuration"`
+	Duration         time.Duration `json:"duration"`
+	// FromHistory indicates whether the InstanceDuration is estimated from the historical OpsRequests.
+	FromHistory bool `json:"fromHistory"`
+}
+
+// Result is the what-if result of the proposed operations on a cluster.
+type Result struct {
+	Cluster    string            `json:"cluster"`
+	Components []ComponentResult `json:"components"`
+	Before     ResourceTotals    `json:"before"`
+	After      ResourceTotals    `json:"after"`
+	Steps      []StepEstimate    `json:"steps"`
+	// ExpectedDuration is the expected duration to roll out all the operations one by one.
+	ExpectedDuration time.Duration `json:"expectedDuration"`
+	Warnings         []string      `json:"warnings,omitempty"`
+}
@@ -268,6 +268,11 @@ func (f *MockComponentDefinitionFactory) SetUpdateStrategy(strategy *appsv1alpha
 	return f
 }
 
+func (f *MockComponentDefinitionFactory) SetWorkloadType(workloadType appsv1alpha1.ComponentWorkloadType) *MockComponentDefinitionFactory {
+	f.Get().Spec.WorkloadType = workloadType
+	return f
+}
+
 func (f *MockComponentDefinitionFactory) AddRole(name string, serviceable, writable bool) *MockComponentDefinitionFactory {
 	role := appsv1alpha1.ReplicaRole{
 		Name:        name,